@@ -0,0 +1,201 @@
+package adapters
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Pinger is implemented by anything whose connectivity can be probed.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// DatabaseMonitor periodically pings MongoDB in the background, records the
+// round-trip latency, and tracks whether the database should be considered
+// healthy. Readiness probes consult Healthy instead of discovering outages
+// only when a user request fails.
+//
+// The MongoDB driver reconnects on its own; the monitor only observes. It
+// flips to unhealthy after failureThreshold consecutive failed pings and back
+// to healthy on the first successful one.
+type DatabaseMonitor struct {
+	pinger           Pinger
+	interval         time.Duration
+	timeout          time.Duration
+	failureThreshold int
+
+	healthy  atomic.Bool
+	failures int // Only touched by the monitor goroutine.
+
+	latency metric.Float64Histogram
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewDatabaseMonitor creates a monitor that starts out healthy, since the
+// initial connection has already been verified at startup.
+func NewDatabaseMonitor(
+	pinger Pinger,
+	interval time.Duration,
+	timeout time.Duration,
+	failureThreshold int,
+) *DatabaseMonitor {
+	latency, err := otel.Meter("database-monitor").Float64Histogram(
+		"db.ping.duration_seconds",
+		metric.WithDescription("Round-trip latency of background MongoDB pings in seconds"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		slog.Error("Failed to create database ping latency histogram",
+			logattr.Error(err),
+		)
+	}
+
+	m := &DatabaseMonitor{
+		pinger:           pinger,
+		interval:         interval,
+		timeout:          timeout,
+		failureThreshold: max(failureThreshold, 1),
+		latency:          latency,
+	}
+	m.healthy.Store(true)
+	return m
+}
+
+// Start launches the background ping loop. It returns immediately.
+func (m *DatabaseMonitor) Start(ctx context.Context) {
+	ctx, m.cancel = context.WithCancel(ctx)
+
+	m.wg.Go(func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.check(ctx)
+			}
+		}
+	})
+
+	slog.Info("Database health monitor started",
+		logattr.Interval(m.interval),
+		logattr.PingTimeout(m.timeout),
+		logattr.FailureThreshold(m.failureThreshold),
+	)
+}
+
+// Healthy reports whether the most recent pings succeeded.
+func (m *DatabaseMonitor) Healthy() bool {
+	return m.healthy.Load()
+}
+
+// check performs a single ping and updates the health state.
+func (m *DatabaseMonitor) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := m.pinger.Ping(ctx)
+	elapsed := time.Since(start)
+
+	if m.latency != nil {
+		m.latency.Record(ctx, elapsed.Seconds(),
+			metric.WithAttributes(attribute.Bool("success", err == nil)),
+		)
+	}
+
+	if err != nil {
+		m.failures++
+		if m.failures >= m.failureThreshold && m.healthy.CompareAndSwap(true, false) {
+			slog.Error("Database marked unhealthy",
+				logattr.ConsecutiveFailures(m.failures),
+				logattr.Latency(elapsed),
+				logattr.Error(err),
+			)
+		} else {
+			slog.Warn("Database ping failed",
+				logattr.ConsecutiveFailures(m.failures),
+				logattr.Latency(elapsed),
+				logattr.Error(err),
+			)
+		}
+		return
+	}
+
+	if m.healthy.CompareAndSwap(false, true) {
+		slog.Info("Database recovered",
+			logattr.ConsecutiveFailures(m.failures),
+			logattr.Latency(elapsed),
+		)
+	}
+	m.failures = 0
+	slog.Debug("Database ping succeeded", logattr.Latency(elapsed))
+}
+
+// Shutdown stops the background ping loop, respecting the provided context.
+func (m *DatabaseMonitor) Shutdown(ctx context.Context) error {
+	if m.cancel == nil {
+		return nil
+	}
+	m.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		slog.Info("Database health monitor stopped")
+		return nil
+	case <-ctx.Done():
+		slog.Warn("Context expired while stopping database health monitor")
+		return ctx.Err()
+	}
+}
+
+// NewTopologyLogger returns a driver ServerMonitor that logs server and
+// topology state changes, e.g. a primary stepping down or a node becoming
+// unreachable.
+func NewTopologyLogger() *event.ServerMonitor {
+	return &event.ServerMonitor{
+		ServerDescriptionChanged: func(e *event.ServerDescriptionChangedEvent) {
+			prev, next := e.PreviousDescription.Kind, e.NewDescription.Kind
+			if prev == next {
+				return
+			}
+			level := slog.LevelInfo
+			if next == "Unknown" {
+				level = slog.LevelWarn
+			}
+			slog.Log(context.Background(), level, "MongoDB server state changed",
+				logattr.ServerAddress(e.Address.String()),
+				logattr.PreviousKind(prev),
+				logattr.Kind(next),
+			)
+		},
+		TopologyDescriptionChanged: func(e *event.TopologyDescriptionChangedEvent) {
+			prev, next := e.PreviousDescription.Kind, e.NewDescription.Kind
+			if prev == next {
+				return
+			}
+			slog.Info("MongoDB topology changed",
+				logattr.PreviousKind(prev),
+				logattr.Kind(next),
+			)
+		},
+	}
+}
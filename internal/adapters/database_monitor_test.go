@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubPinger struct {
+	err error
+}
+
+func (p *stubPinger) Ping(context.Context) error {
+	return p.err
+}
+
+func TestDatabaseMonitor_Check(t *testing.T) {
+	pinger := &stubPinger{}
+	m := NewDatabaseMonitor(pinger, time.Second, time.Second, 2)
+	ctx := context.Background()
+
+	assert.True(t, m.Healthy(), "monitor should start healthy")
+
+	pinger.err = errors.New("connection refused")
+	m.check(ctx)
+	assert.True(t, m.Healthy(), "a single failure below the threshold should not flip health")
+
+	m.check(ctx)
+	assert.False(t, m.Healthy(), "reaching the threshold should mark the database unhealthy")
+
+	pinger.err = nil
+	m.check(ctx)
+	assert.True(t, m.Healthy(), "a successful ping should restore health")
+	assert.Zero(t, m.failures)
+}
+
+func TestDatabaseMonitor_ShutdownWithoutStart(t *testing.T) {
+	m := NewDatabaseMonitor(&stubPinger{}, time.Second, time.Second, 1)
+	assert.NoError(t, m.Shutdown(context.Background()))
+}
@@ -0,0 +1,92 @@
+package adapters
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// NewPoolMetrics returns a driver PoolMonitor that records the connection
+// pool of every server: open and checked-out connections, how long
+// checkouts wait, and why they fail. A pool exhausted by slow queries shows
+// up as in-use connections at the maximum and growing checkout waits.
+func NewPoolMetrics() *event.PoolMonitor {
+	meter := otel.Meter("database-pool")
+
+	open, err := meter.Int64UpDownCounter(
+		"db.pool.connections.open",
+		metric.WithDescription("Connections currently open in the MongoDB pool"),
+	)
+	if err != nil {
+		slog.Error("Failed to create open connections counter", logattr.Error(err))
+	}
+	inUse, err := meter.Int64UpDownCounter(
+		"db.pool.connections.in_use",
+		metric.WithDescription("Connections currently checked out of the MongoDB pool"),
+	)
+	if err != nil {
+		slog.Error("Failed to create in-use connections counter", logattr.Error(err))
+	}
+	wait, err := meter.Float64Histogram(
+		"db.pool.checkout.duration_seconds",
+		metric.WithDescription("Time spent waiting to check a connection out of the MongoDB pool in seconds"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		slog.Error("Failed to create checkout duration histogram", logattr.Error(err))
+	}
+	failures, err := meter.Int64Counter(
+		"db.pool.checkout.failures",
+		metric.WithDescription("Connection checkouts from the MongoDB pool that failed, by reason"),
+	)
+	if err != nil {
+		slog.Error("Failed to create checkout failures counter", logattr.Error(err))
+	}
+
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			ctx := context.Background()
+			server := metric.WithAttributes(attribute.String("server.address", e.Address))
+
+			switch e.Type {
+			case event.ConnectionCreated:
+				if open != nil {
+					open.Add(ctx, 1, server)
+				}
+			case event.ConnectionClosed:
+				if open != nil {
+					open.Add(ctx, -1, server)
+				}
+			case event.ConnectionCheckedOut:
+				if inUse != nil {
+					inUse.Add(ctx, 1, server)
+				}
+				if wait != nil {
+					wait.Record(ctx, e.Duration.Seconds(),
+						metric.WithAttributes(attribute.String("server.address", e.Address), attribute.Bool("success", true)),
+					)
+				}
+			case event.ConnectionCheckedIn:
+				if inUse != nil {
+					inUse.Add(ctx, -1, server)
+				}
+			case event.ConnectionCheckOutFailed:
+				if wait != nil {
+					wait.Record(ctx, e.Duration.Seconds(),
+						metric.WithAttributes(attribute.String("server.address", e.Address), attribute.Bool("success", false)),
+					)
+				}
+				if failures != nil {
+					failures.Add(ctx, 1,
+						metric.WithAttributes(attribute.String("server.address", e.Address), attribute.String("reason", e.Reason)),
+					)
+				}
+			}
+		},
+	}
+}
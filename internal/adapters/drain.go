@@ -0,0 +1,54 @@
+package adapters
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// Drain marks the instance as draining: it keeps serving, but readiness
+// probes fail so load balancers stop sending new traffic before the HTTP
+// server shuts down.
+type Drain struct {
+	draining atomic.Bool
+	delay    time.Duration
+}
+
+// NewDrain creates a Drain whose Shutdown waits delay after marking the
+// instance as draining, giving load balancers time to notice.
+func NewDrain(delay time.Duration) *Drain {
+	return &Drain{delay: delay}
+}
+
+// Draining reports whether the instance is draining.
+func (d *Drain) Draining() bool {
+	return d.draining.Load()
+}
+
+// Set starts or stops draining.
+func (d *Drain) Set(draining bool) {
+	if d.draining.Swap(draining) == draining {
+		return
+	}
+	if draining {
+		slog.Warn("Draining: readiness probe now reports unavailable")
+	} else {
+		slog.Info("Drain cancelled: readiness probe restored")
+	}
+}
+
+// Shutdown starts draining and waits for the drain delay, respecting the
+// provided context. It belongs in the stage before the HTTP servers stop.
+func (d *Drain) Shutdown(ctx context.Context) error {
+	d.Set(true)
+
+	timer := time.NewTimer(d.delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,39 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrain_Shutdown(t *testing.T) {
+	d := NewDrain(20 * time.Millisecond)
+	assert.False(t, d.Draining())
+
+	start := time.Now()
+	require.NoError(t, d.Shutdown(t.Context()))
+	assert.True(t, d.Draining())
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestDrain_ShutdownRespectsContext(t *testing.T) {
+	d := NewDrain(time.Hour)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, d.Shutdown(ctx), context.DeadlineExceeded)
+	assert.True(t, d.Draining())
+}
+
+func TestDrain_Set(t *testing.T) {
+	d := NewDrain(0)
+
+	d.Set(true)
+	assert.True(t, d.Draining())
+	d.Set(false)
+	assert.False(t, d.Draining())
+}
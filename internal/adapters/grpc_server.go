@@ -0,0 +1,75 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"google.golang.org/grpc"
+)
+
+// GRPCServer runs the internal gRPC API. Like HTTPServer, it belongs in the
+// first shutdown stage.
+type GRPCServer struct {
+	server *grpc.Server
+	addr   string
+	listen ListenOptions
+	errCh  chan error
+}
+
+// NewGRPCServer creates a server for server on host and port; an empty host
+// listens on all interfaces. Call Start to begin serving.
+func NewGRPCServer(server *grpc.Server, host string, port int, listen ListenOptions) *GRPCServer {
+	return &GRPCServer{
+		server: server,
+		addr:   net.JoinHostPort(host, strconv.Itoa(port)),
+		listen: listen,
+		errCh:  make(chan error, 1),
+	}
+}
+
+// Start binds the port and serves in the background. Bind errors are
+// returned immediately; failures after that are reported on Err.
+func (s *GRPCServer) Start() error {
+	ln, err := listen(s.addr, s.listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+
+	go func() {
+		slog.Info("gRPC server listening", logattr.ListenAddr(s.addr))
+		if err := s.server.Serve(ln); err != nil {
+			s.errCh <- fmt.Errorf("gRPC server failed: %w", err)
+		}
+	}()
+	return nil
+}
+
+// Err reports a failure of the running server.
+func (s *GRPCServer) Err() <-chan error {
+	return s.errCh
+}
+
+// Shutdown stops accepting new connections and waits for in-flight calls to
+// finish. Calls still running when ctx is done are canceled.
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	slog.Info("Stopping gRPC server")
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		slog.Info("gRPC server stopped")
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		slog.Warn("gRPC server did not drain in time", logattr.Error(ctx.Err()))
+		return fmt.Errorf("failed to drain gRPC server: %w", ctx.Err())
+	}
+}
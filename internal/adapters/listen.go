@@ -0,0 +1,67 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+// listenFDStart is the first file descriptor passed by systemd socket
+// activation.
+const listenFDStart = 3
+
+// ListenOptions controls how an HTTP server obtains its socket. Both options
+// let a new process take over the port while the old one drains, so a
+// restart does not refuse connections.
+type ListenOptions struct {
+	// ReusePort sets SO_REUSEPORT so the new process can bind the port while
+	// the old one is still running. The kernel balances connections across
+	// both until the old one closes its listener.
+	ReusePort bool
+
+	// Inherit serves on a socket passed in by the supervisor (systemd socket
+	// activation, LISTEN_FDS) when one is present, so the socket outlives
+	// the process and queues connections across the restart.
+	Inherit bool
+}
+
+// listen returns the listener for addr according to opts.
+func listen(addr string, opts ListenOptions) (net.Listener, error) {
+	if opts.Inherit {
+		ln, err := inheritedListener()
+		if err != nil || ln != nil {
+			return ln, err
+		}
+	}
+
+	var lc net.ListenConfig
+	if opts.ReusePort {
+		lc.Control = reusePortControl
+	}
+	return lc.Listen(context.Background(), "tcp", addr)
+}
+
+// inheritedListener returns the first socket passed through LISTEN_FDS, or
+// nil when none was passed to this process. The variables are cleared so
+// child processes do not pick up the same socket.
+func inheritedListener() (net.Listener, error) {
+	if pid, _ := strconv.Atoi(os.Getenv("LISTEN_PID")); pid != os.Getpid() {
+		return nil, nil
+	}
+	if n, _ := strconv.Atoi(os.Getenv("LISTEN_FDS")); n < 1 {
+		return nil, nil
+	}
+	_ = os.Unsetenv("LISTEN_PID")
+	_ = os.Unsetenv("LISTEN_FDS")
+	_ = os.Unsetenv("LISTEN_FDNAMES")
+
+	f := os.NewFile(listenFDStart, "inherited-listener")
+	defer f.Close() // FileListener duplicates the descriptor.
+	ln, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use inherited socket: %w", err)
+	}
+	return ln, nil
+}
@@ -0,0 +1,37 @@
+//go:build unix
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListen_ReusePort(t *testing.T) {
+	first, err := listen("127.0.0.1:0", ListenOptions{ReusePort: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = first.Close() })
+	addr := first.Addr().String()
+
+	// A replacement process binds the same port while the first still listens.
+	second, err := listen(addr, ListenOptions{ReusePort: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = second.Close() })
+	assert.Equal(t, addr, second.Addr().String())
+
+	// Without the option the port stays exclusive.
+	_, err = listen(addr, ListenOptions{})
+	assert.Error(t, err)
+}
+
+func TestListen_InheritWithoutSocketFallsBack(t *testing.T) {
+	t.Setenv("LISTEN_PID", "1")
+	t.Setenv("LISTEN_FDS", "1")
+
+	// The socket was meant for another process, so a new one is bound.
+	ln, err := listen("127.0.0.1:0", ListenOptions{Inherit: true})
+	require.NoError(t, err)
+	_ = ln.Close()
+}
@@ -0,0 +1,41 @@
+package adapters
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/scheduler"
+)
+
+// OutboxRelay wraps the OutboxRelay to provide graceful shutdown capabilities.
+type OutboxRelay struct {
+	Relay *scheduler.OutboxRelay
+}
+
+// Shutdown gracefully shuts down the outbox relay, respecting the provided context.
+func (o *OutboxRelay) Shutdown(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	closeChan := make(chan error, 1)
+
+	go func() {
+		slog.Info("Stopping outbox relay")
+		closeChan <- o.Relay.Close()
+	}()
+
+	select {
+	case err := <-closeChan:
+		if err != nil {
+			slog.Error("Failed to stop outbox relay", logattr.Error(err))
+		} else {
+			slog.Info("Outbox relay stopped successfully")
+		}
+		return err
+	case <-ctx.Done():
+		slog.Warn("Context expired while stopping outbox relay")
+		return ctx.Err()
+	}
+}
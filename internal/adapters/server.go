@@ -2,21 +2,91 @@ package adapters
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
-	"time"
+	"strconv"
 
 	"github.com/AnuragThePathak/my-go-packages/srv"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
 )
 
-// Server provides an interface for starting and gracefully shutting down an HTTP server.
-type Server interface {
-	// StartWithGracefulShutdown starts the server and shuts it down gracefully on context cancellation.
-	StartWithGracefulShutdown(
-		parentCtx context.Context,
-		timeout time.Duration,
-		handlers ...srv.CleanupHandler,
-	)
-
-	// Start starts the server without graceful shutdown.
-	Start() (*http.Server, error)
+// HTTPServer runs the API. Its Shutdown stops accepting connections and waits
+// for in-flight requests, so it belongs in the first shutdown stage.
+type HTTPServer struct {
+	server *http.Server
+	config srv.ServerConfig
+	listen ListenOptions
+	errCh  chan error
+}
+
+// NewHTTPServer creates a server for handler on host and config.Port; an
+// empty host listens on all interfaces. Call Start to begin serving.
+func NewHTTPServer(handler http.Handler, host string, config srv.ServerConfig, listen ListenOptions) *HTTPServer {
+	return &HTTPServer{
+		server: &http.Server{
+			Addr:    net.JoinHostPort(host, strconv.Itoa(config.Port)),
+			Handler: handler,
+		},
+		config: config,
+		listen: listen,
+		errCh:  make(chan error, 1),
+	}
+}
+
+// WithTLSConfig serves TLS with tlsConfig instead of the certificate of the
+// server config, e.g. one from lib.LoadServerTLSConfig that requires client
+// certificates.
+func (s *HTTPServer) WithTLSConfig(tlsConfig *tls.Config) *HTTPServer {
+	s.server.TLSConfig = tlsConfig
+	return s
+}
+
+// Start binds the port and serves in the background. Bind and certificate
+// errors are returned immediately; failures after that are reported on Err.
+func (s *HTTPServer) Start() error {
+	ln, err := listen(s.server.Addr, s.listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.server.Addr, err)
+	}
+
+	if s.server.TLSConfig == nil && s.config.TLSEnabled {
+		cert, err := tls.LoadX509KeyPair(s.config.TLSCertPath, s.config.TLSKeyPath)
+		if err != nil {
+			_ = ln.Close()
+			return fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		s.server.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+	if s.server.TLSConfig != nil {
+		ln = tls.NewListener(ln, s.server.TLSConfig)
+	}
+
+	go func() {
+		slog.Info("HTTP server listening", logattr.ListenAddr(s.server.Addr))
+		if err := s.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.errCh <- fmt.Errorf("HTTP server failed: %w", err)
+		}
+	}()
+	return nil
+}
+
+// Err reports a failure of the running server.
+func (s *HTTPServer) Err() <-chan error {
+	return s.errCh
+}
+
+// Shutdown stops accepting new connections and waits for in-flight requests
+// to finish, respecting the provided context.
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	slog.Info("Stopping HTTP server")
+	if err := s.server.Shutdown(ctx); err != nil {
+		slog.Warn("HTTP server did not drain in time", logattr.Error(err))
+		return fmt.Errorf("failed to drain HTTP server: %w", err)
+	}
+	slog.Info("HTTP server stopped")
+	return nil
 }
@@ -0,0 +1,91 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/AnuragThePathak/my-go-packages/srv"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+)
+
+// ShutdownStage is one step of an ordered shutdown. Handlers within a stage
+// stop concurrently and share the stage's timeout.
+type ShutdownStage struct {
+	Name     string
+	Timeout  time.Duration
+	Handlers []srv.CleanupHandler
+}
+
+// Shutdown runs stages in order, each under its own timeout. A stage that
+// fails or times out is logged and the remaining stages still run, so
+// connections are closed even if a component hangs. Empty stages are skipped.
+func Shutdown(stages ...ShutdownStage) error {
+	var errs []error
+	for _, stage := range stages {
+		if len(stage.Handlers) == 0 {
+			continue
+		}
+		if err := runStage(stage); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown stage %s: %w", stage.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func runStage(stage ShutdownStage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), stage.Timeout)
+	defer cancel()
+
+	slog.Info("Shutdown stage started",
+		logattr.Stage(stage.Name),
+		logattr.Timeout(stage.Timeout),
+	)
+	start := time.Now()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, h := range stage.Handlers {
+		wg.Go(func() {
+			if err := h.Shutdown(ctx); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		})
+	}
+
+	// Handlers are expected to honour ctx; stop waiting for any that do not.
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+		err = errors.Join(errs...)
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s: %w", stage.Timeout, ctx.Err())
+	}
+	if err != nil {
+		slog.Error("Shutdown stage failed",
+			logattr.Stage(stage.Name),
+			logattr.Elapsed(time.Since(start)),
+			logattr.Error(err),
+		)
+		return err
+	}
+	slog.Info("Shutdown stage completed",
+		logattr.Stage(stage.Name),
+		logattr.Elapsed(time.Since(start)),
+	)
+	return nil
+}
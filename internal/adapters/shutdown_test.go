@@ -0,0 +1,63 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AnuragThePathak/my-go-packages/srv"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingHandler appends its name to a shared log when shut down. A
+// blocking handler then waits for its context before returning.
+type recordingHandler struct {
+	name     string
+	log      *[]string
+	mu       *sync.Mutex
+	err      error
+	blocking bool
+}
+
+func (h *recordingHandler) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	*h.log = append(*h.log, h.name)
+	h.mu.Unlock()
+	if h.blocking {
+		<-ctx.Done()
+	}
+	return h.err
+}
+
+func TestShutdown(t *testing.T) {
+	var (
+		log []string
+		mu  sync.Mutex
+	)
+	handler := func(name string) *recordingHandler {
+		return &recordingHandler{name: name, log: &log, mu: &mu}
+	}
+
+	hung := handler("worker")
+	hung.blocking = true
+	failing := handler("redis")
+	failing.err = errors.New("close failed")
+
+	err := Shutdown(
+		ShutdownStage{Name: "http", Timeout: time.Second, Handlers: []srv.CleanupHandler{handler("http")}},
+		ShutdownStage{Name: "empty", Timeout: time.Second},
+		ShutdownStage{Name: "worker", Timeout: 10 * time.Millisecond, Handlers: []srv.CleanupHandler{hung}},
+		ShutdownStage{Name: "connections", Timeout: time.Second, Handlers: []srv.CleanupHandler{failing}},
+		ShutdownStage{Name: "telemetry", Timeout: time.Second, Handlers: []srv.CleanupHandler{handler("otel")}},
+	)
+
+	mu.Lock()
+	assert.Equal(t, []string{"http", "worker", "redis", "otel"}, log, "stages should run in order")
+	mu.Unlock()
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "a timed-out stage should be reported")
+	assert.ErrorContains(t, err, "shutdown stage worker")
+	assert.ErrorContains(t, err, "shutdown stage connections: close failed")
+}
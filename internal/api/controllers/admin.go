@@ -0,0 +1,451 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/adapters"
+	"github.com/anuragthepathak/subscription-management/internal/api/middlewares"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/go-chi/chi/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// maxLogLevelDuration caps how long a temporary log level may last, so a
+// forgotten debug session cannot flood the logs indefinitely.
+const maxLogLevelDuration = 24 * time.Hour
+
+type logLevelRequest struct {
+	Level    string `json:"level" validate:"required,oneof=debug info warn error DEBUG INFO WARN ERROR"`
+	Duration string `json:"duration"` // Go duration, e.g. "15m"; empty keeps the level until reset.
+}
+
+// ConfigSource reports the effective configuration with secrets masked.
+type ConfigSource interface {
+	Sanitized() map[string]any
+}
+
+type adminController struct {
+	logLevel              *observability.LogLevel
+	drain                 *adapters.Drain
+	reminderDedupeService services.ReminderDedupeService
+	analyticsService      services.AnalyticsServiceExternal
+	subscriptionService   services.SubscriptionServiceExternal
+	auditService          services.AuditServiceExternal
+	deadTaskService       services.DeadTaskServiceExternal
+	reconciliationService services.ReconciliationServiceExternal
+	rateLimiters          []services.RateLimiterService
+	configSource          ConfigSource
+	requestHandler        *endpoint.RequestHandler
+}
+
+// NewAdminController exposes operational endpoints for administrators. The
+// caller is responsible for restricting access to admins.
+func NewAdminController(
+	logLevel *observability.LogLevel,
+	drain *adapters.Drain,
+	reminderDedupeService services.ReminderDedupeService,
+	analyticsService services.AnalyticsServiceExternal,
+	subscriptionService services.SubscriptionServiceExternal,
+	auditService services.AuditServiceExternal,
+	deadTaskService services.DeadTaskServiceExternal,
+	reconciliationService services.ReconciliationServiceExternal,
+	rateLimiters []services.RateLimiterService,
+	configSource ConfigSource,
+	requestHandler *endpoint.RequestHandler,
+) http.Handler {
+	c := &adminController{
+		logLevel,
+		drain,
+		reminderDedupeService,
+		analyticsService,
+		subscriptionService,
+		auditService,
+		deadTaskService,
+		reconciliationService,
+		rateLimiters,
+		configSource,
+		requestHandler,
+	}
+
+	r := chi.NewRouter()
+	r.Get("/log-level", c.getLogLevel)
+	r.Put("/log-level", c.setLogLevel)
+	r.Delete("/log-level", c.resetLogLevel)
+
+	r.Get("/drain", c.getDrain)
+	r.Put("/drain", c.startDrain)
+	r.Delete("/drain", c.stopDrain)
+
+	r.Route("/subscriptions/{subscriptionID}/reminder-markers", func(r chi.Router) {
+		r.Use(middlewares.WithSubscriptionID)
+		r.Get("/", c.getReminderMarkers)
+		r.Delete("/", c.clearReminderMarkers)
+	})
+	r.Get("/rate-limits/{key}", c.getRateLimits)
+	r.Get("/config", c.getConfig)
+	r.Get("/analytics", c.getAnalytics)
+	r.Put("/bills/{billID}/status", c.updateBillStatus)
+	r.Get("/audit-events", c.getAuditEvents)
+	r.Get("/dead-tasks", c.getDeadTasks)
+	r.Get("/dead-tasks/{deadTaskID}", c.getDeadTask)
+	r.Post("/dead-tasks/{deadTaskID}/requeue", c.requeueDeadTask)
+	r.Get("/reconciliation", c.getReconciliation)
+	return r
+}
+
+// AdminOperations documents the routes of NewAdminController.
+var AdminOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/log-level", Summary: "Get the log level", Response: observability.LogLevelState{}},
+	{Method: http.MethodPut, Path: "/log-level", Summary: "Change the log level, optionally for a while", Request: logLevelRequest{}, Response: observability.LogLevelState{}},
+	{Method: http.MethodDelete, Path: "/log-level", Summary: "Restore the configured log level", Response: observability.LogLevelState{}},
+	{Method: http.MethodGet, Path: "/drain", Summary: "Report whether this instance is draining", Response: drainResponse{}},
+	{Method: http.MethodPut, Path: "/drain", Summary: "Start draining this instance", Response: drainResponse{}},
+	{Method: http.MethodDelete, Path: "/drain", Summary: "Stop draining this instance", Response: drainResponse{}},
+	{Method: http.MethodGet, Path: "/subscriptions/{subscriptionID}/reminder-markers", Summary: "List reminder dedupe markers", Response: []models.ReminderDedupeEntry{}},
+	{Method: http.MethodDelete, Path: "/subscriptions/{subscriptionID}/reminder-markers", Summary: "Clear reminder dedupe markers", Query: []openapi.Param{
+		{Name: "daysBefore", Type: "integer", Description: "Clear only the marker of this reminder"},
+	}, Response: models.ClearReminderDedupeResponse{}},
+	{Method: http.MethodGet, Path: "/rate-limits/{key}", Summary: "Report rate-limit state for an IP or user ID", Response: []models.RateLimitState{}},
+	{Method: http.MethodGet, Path: "/config", Summary: "Get the effective configuration with secrets masked", Response: map[string]any{}},
+	{Method: http.MethodGet, Path: "/analytics", Summary: "Summarize the platform", Response: models.PlatformStatsResponse{}},
+	{Method: http.MethodPut, Path: "/bills/{billID}/status", Summary: "Mark a bill paid or failed", Request: models.BillStatusRequest{}, Response: models.BillResponse{}},
+	{Method: http.MethodGet, Path: "/audit-events", Summary: "Search the audit log, newest first", Query: []openapi.Param{
+		{Name: "action", Type: "string", Description: "Only events of this action, e.g. subscription.canceled"},
+		{Name: "actorId", Type: "string", Description: "Only changes made by this user"},
+		{Name: "resourceType", Type: "string", Description: "Only changes to this type of resource, e.g. subscription"},
+		{Name: "resourceId", Type: "string", Description: "Only changes to this resource"},
+		{Name: "from", Type: "string", Description: "Only events at or after this RFC 3339 time"},
+		{Name: "to", Type: "string", Description: "Only events before this RFC 3339 time"},
+		{Name: "limit", Type: "integer", Description: "Page size"},
+		{Name: "offset", Type: "integer", Description: "Events to skip"},
+	}, Response: []models.AuditEventResponse{}},
+	{Method: http.MethodGet, Path: "/dead-tasks", Summary: "List background tasks that failed permanently, most recent first", Query: []openapi.Param{
+		{Name: "type", Type: "string", Description: "Only tasks of this type, e.g. subscription:renewal"},
+		{Name: "queue", Type: "string", Description: "Only tasks from this queue"},
+		{Name: "requeued", Type: "boolean", Description: "Only tasks that were (true) or were not (false) requeued"},
+		{Name: "limit", Type: "integer", Description: "Page size"},
+		{Name: "offset", Type: "integer", Description: "Tasks to skip"},
+	}, Response: []models.DeadTaskResponse{}},
+	{Method: http.MethodGet, Path: "/dead-tasks/{deadTaskID}", Summary: "Get a dead task with its payload and last error", Response: models.DeadTaskResponse{}},
+	{Method: http.MethodPost, Path: "/dead-tasks/{deadTaskID}/requeue", Summary: "Put a dead task back on its queue", Response: models.DeadTaskResponse{}},
+	{Method: http.MethodGet, Path: "/reconciliation", Summary: "Get the report of the latest subscription reconciliation", Response: models.ReconciliationReport{}},
+}
+
+func (c *adminController) getLogLevel(w http.ResponseWriter, r *http.Request) {
+	endpoint.WriteAPIResponse(w, http.StatusOK, c.logLevel.State())
+}
+
+func (c *adminController) setLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req logLevelRequest
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &req,
+		EndpointLogic: func() (any, error) {
+			var level slog.Level
+			if err := level.UnmarshalText([]byte(req.Level)); err != nil {
+				return nil, apperror.NewValidationError("Invalid log level")
+			}
+
+			var duration time.Duration
+			if req.Duration != "" {
+				var err error
+				if duration, err = time.ParseDuration(req.Duration); err != nil || duration <= 0 {
+					return nil, apperror.NewValidationError("Duration must be a positive Go duration, e.g. 15m")
+				}
+				if duration > maxLogLevelDuration {
+					return nil, apperror.NewValidationError("Duration must not exceed 24h")
+				}
+			}
+			return c.logLevel.Set(level, duration), nil
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *adminController) resetLogLevel(w http.ResponseWriter, r *http.Request) {
+	endpoint.WriteAPIResponse(w, http.StatusOK, c.logLevel.Reset())
+}
+
+type drainResponse struct {
+	Draining bool `json:"draining"`
+}
+
+func (c *adminController) getDrain(w http.ResponseWriter, r *http.Request) {
+	endpoint.WriteAPIResponse(w, http.StatusOK, drainResponse{c.drain.Draining()})
+}
+
+// startDrain fails this instance's readiness probe so it can be taken out of
+// rotation by hand, e.g. before maintenance.
+func (c *adminController) startDrain(w http.ResponseWriter, r *http.Request) {
+	c.drain.Set(true)
+	endpoint.WriteAPIResponse(w, http.StatusOK, drainResponse{true})
+}
+
+func (c *adminController) stopDrain(w http.ResponseWriter, r *http.Request) {
+	c.drain.Set(false)
+	endpoint.WriteAPIResponse(w, http.StatusOK, drainResponse{false})
+}
+
+func (c *adminController) getReminderMarkers(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, _ := appctx.GetSubscriptionID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return c.reminderDedupeService.ListReminderMarkers(r.Context(), subscriptionID)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// clearReminderMarkers deletes the marker given by the daysBefore query
+// parameter, or all markers of the subscription without it.
+func (c *adminController) clearReminderMarkers(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, _ := appctx.GetSubscriptionID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			var daysBefore *int
+			if raw := r.URL.Query().Get("daysBefore"); raw != "" {
+				days, err := strconv.Atoi(raw)
+				if err != nil || days < 0 {
+					return nil, apperror.NewValidationError("daysBefore must be a non-negative integer")
+				}
+				daysBefore = &days
+			}
+
+			deleted, err := c.reminderDedupeService.ClearReminderMarkers(r.Context(), subscriptionID, daysBefore)
+			if err != nil {
+				return nil, err
+			}
+			return models.ClearReminderDedupeResponse{Deleted: deleted}, nil
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// getAnalytics summarizes the health of the platform.
+func (c *adminController) getAnalytics(w http.ResponseWriter, r *http.Request) {
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return c.analyticsService.GetPlatformStats(r.Context())
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// getReconciliation returns the subscriptions the latest reconciliation found
+// inconsistent with their bills.
+func (c *adminController) getReconciliation(w http.ResponseWriter, r *http.Request) {
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return c.reconciliationService.GetReconciliationReport(r.Context())
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// updateBillStatus marks a renewal bill failed, which starts dunning of its
+// subscription, or paid, which ends it.
+func (c *adminController) updateBillStatus(w http.ResponseWriter, r *http.Request) {
+	var req models.BillStatusRequest
+	billID := chi.URLParam(r, "billID")
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &req,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.subscriptionService.UpdateBillStatus(r.Context(), billID, req.Status))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// getAuditEvents returns a page of the audit log matching the query
+// parameters, with the number of matching events in meta.pagination.
+func (c *adminController) getAuditEvents(w http.ResponseWriter, r *http.Request) {
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			query, err := auditQuery(r)
+			if err != nil {
+				return nil, err
+			}
+			page, err := c.auditService.GetAuditEvents(r.Context(), query)
+			if err != nil {
+				return nil, err
+			}
+			return pageResponse(page.Items, page.Total, query.Limit, query.Offset)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// auditQuery reads the filters and page of an audit log search from the
+// query parameters. The service validates the action and page size.
+func auditQuery(r *http.Request) (models.AuditQuery, error) {
+	params := r.URL.Query()
+	query := models.AuditQuery{
+		Action:       models.AuditAction(params.Get("action")),
+		ResourceType: params.Get("resourceType"),
+	}
+
+	var err error
+	if query.ActorID, err = objectIDParam(params.Get("actorId"), "actorId"); err != nil {
+		return query, err
+	}
+	if query.ResourceID, err = objectIDParam(params.Get("resourceId"), "resourceId"); err != nil {
+		return query, err
+	}
+	if query.From, err = timeParam(params.Get("from"), "from"); err != nil {
+		return query, err
+	}
+	if query.To, err = timeParam(params.Get("to"), "to"); err != nil {
+		return query, err
+	}
+
+	query.Limit, query.Offset, err = pageParams(r)
+	return query, err
+}
+
+// objectIDParam parses an optional ID query parameter; an absent one is nil.
+func objectIDParam(raw, name string) (*bson.ObjectID, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	id, err := bson.ObjectIDFromHex(raw)
+	if err != nil {
+		return nil, apperror.NewValidationError("%s must be a valid ID", name)
+	}
+	return &id, nil
+}
+
+// timeParam parses an optional RFC 3339 query parameter; an absent one is
+// the zero time.
+func timeParam(raw, name string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, apperror.NewValidationError("%s must be an RFC 3339 time", name)
+	}
+	return t, nil
+}
+
+// getDeadTasks returns a page of the dead tasks matching the query parameters
+// with the number of matching tasks in meta.pagination.
+func (c *adminController) getDeadTasks(w http.ResponseWriter, r *http.Request) {
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			query, err := deadTaskQuery(r)
+			if err != nil {
+				return nil, err
+			}
+			page, err := c.deadTaskService.GetDeadTasks(r.Context(), query)
+			if err != nil {
+				return nil, err
+			}
+			return pageResponse(page.Items, page.Total, query.Limit, query.Offset)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// deadTaskQuery reads the filters and page of a dead task listing from the
+// query parameters.
+func deadTaskQuery(r *http.Request) (models.DeadTaskQuery, error) {
+	params := r.URL.Query()
+	query := models.DeadTaskQuery{
+		Type:  params.Get("type"),
+		Queue: params.Get("queue"),
+	}
+	if raw := params.Get("requeued"); raw != "" {
+		requeued, err := strconv.ParseBool(raw)
+		if err != nil {
+			return query, apperror.NewValidationError("requeued must be true or false")
+		}
+		query.Requeued = &requeued
+	}
+
+	var err error
+	query.Limit, query.Offset, err = pageParams(r)
+	return query, err
+}
+
+func (c *adminController) getDeadTask(w http.ResponseWriter, r *http.Request) {
+	deadTaskID := chi.URLParam(r, "deadTaskID")
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.deadTaskService.GetDeadTask(r.Context(), deadTaskID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// requeueDeadTask puts a dead task back on its queue with a fresh retry
+// budget, e.g. once the outage that killed it is over.
+func (c *adminController) requeueDeadTask(w http.ResponseWriter, r *http.Request) {
+	deadTaskID := chi.URLParam(r, "deadTaskID")
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.deadTaskService.RequeueDeadTask(r.Context(), deadTaskID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// getRateLimits reports the state of every rate limiter for an IP address or
+// user ID, without consuming a request.
+func (c *adminController) getRateLimits(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "key")
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			states := make([]*models.RateLimitState, 0, len(c.rateLimiters))
+			for _, limiter := range c.rateLimiters {
+				state, err := limiter.State(r.Context(), key)
+				if err != nil {
+					return nil, apperror.NewInternalError(err)
+				}
+				states = append(states, state)
+			}
+			return states, nil
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *adminController) getConfig(w http.ResponseWriter, r *http.Request) {
+	endpoint.WriteAPIResponse(w, http.StatusOK, c.configSource.Sanitized())
+}
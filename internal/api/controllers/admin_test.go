@@ -0,0 +1,585 @@
+package controllers_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/adapters"
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/config"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newTestAdminController(level *slog.LevelVar) http.Handler {
+	logLevel := observability.NewLogLevel(level, time.Now)
+	return controllers.NewAdminController(logLevel, nil, nil, nil, nil, nil, nil, nil, nil, nil, endpoint.NewRequestHandler(validator.New()))
+}
+
+// ---------------------------------------------------------------------------
+// PUT /log-level
+// ---------------------------------------------------------------------------
+
+func TestAdminController_SetLogLevel(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantLevel  slog.Level
+		wantRevert bool
+	}{
+		{
+			name:       "success - temporary debug",
+			body:       `{"level":"debug","duration":"15m"}`,
+			wantStatus: http.StatusOK,
+			wantLevel:  slog.LevelDebug,
+			wantRevert: true,
+		},
+		{
+			name:       "success - no duration keeps the level",
+			body:       `{"level":"warn"}`,
+			wantStatus: http.StatusOK,
+			wantLevel:  slog.LevelWarn,
+		},
+		{
+			name:       "failure - unknown level",
+			body:       `{"level":"verbose"}`,
+			wantStatus: http.StatusBadRequest,
+			wantLevel:  slog.LevelInfo,
+		},
+		{
+			name:       "failure - invalid duration",
+			body:       `{"level":"debug","duration":"soon"}`,
+			wantStatus: http.StatusBadRequest,
+			wantLevel:  slog.LevelInfo,
+		},
+		{
+			name:       "failure - duration above cap",
+			body:       `{"level":"debug","duration":"48h"}`,
+			wantStatus: http.StatusBadRequest,
+			wantLevel:  slog.LevelInfo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var level slog.LevelVar
+			handler := newTestAdminController(&level)
+
+			req := httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			assert.Equal(t, tt.wantLevel, level.Level())
+
+			if tt.wantStatus == http.StatusOK {
+				var state observability.LogLevelState
+				require.NoError(t, decodeData(rr.Body, &state))
+				assert.Equal(t, tt.wantLevel.String(), state.Level)
+				assert.Equal(t, tt.wantRevert, state.RevertAt != nil)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET and DELETE /log-level
+// ---------------------------------------------------------------------------
+
+func TestAdminController_GetAndResetLogLevel(t *testing.T) {
+	var level slog.LevelVar
+	handler := newTestAdminController(&level)
+	level.Set(slog.LevelDebug)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/log-level", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var state observability.LogLevelState
+	require.NoError(t, decodeData(rr.Body, &state))
+	assert.Equal(t, "DEBUG", state.Level)
+	assert.Equal(t, "INFO", state.Configured)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/log-level", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, slog.LevelInfo, level.Level())
+}
+
+// ---------------------------------------------------------------------------
+// PUT, GET, and DELETE /drain
+// ---------------------------------------------------------------------------
+
+func TestAdminController_Drain(t *testing.T) {
+	drain := adapters.NewDrain(0)
+	handler := controllers.NewAdminController(nil, drain, nil, nil, nil, nil, nil, nil, nil, nil, endpoint.NewRequestHandler(validator.New()))
+
+	for _, step := range []struct {
+		method string
+		want   bool
+	}{
+		{http.MethodGet, false},
+		{http.MethodPut, true},
+		{http.MethodGet, true},
+		{http.MethodDelete, false},
+	} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(step.method, "/drain", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var body struct {
+			Draining bool `json:"draining"`
+		}
+		require.NoError(t, decodeData(rr.Body, &body))
+		assert.Equal(t, step.want, body.Draining, step.method)
+		assert.Equal(t, step.want, drain.Draining(), step.method)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET and DELETE /subscriptions/{subscriptionID}/reminder-markers
+// ---------------------------------------------------------------------------
+
+func TestAdminController_GetReminderMarkers(t *testing.T) {
+	subID := "507f1f77bcf86cd799439011"
+	dedupe := mocks.NewMockReminderDedupeService(t)
+	dedupe.EXPECT().ListReminderMarkers(mock.Anything, subID).Return([]models.ReminderDedupeEntry{
+		{Key: "reminder_sent:" + subID + ":7", DaysBefore: 7, ExpiresIn: "23h0m0s"},
+	}, nil)
+
+	handler := controllers.NewAdminController(nil, nil, dedupe, nil, nil, nil, nil, nil, nil, nil, endpoint.NewRequestHandler(validator.New()))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/subscriptions/"+subID+"/reminder-markers", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var entries []models.ReminderDedupeEntry
+	require.NoError(t, decodeData(rr.Body, &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, 7, entries[0].DaysBefore)
+}
+
+func TestAdminController_ClearReminderMarkers(t *testing.T) {
+	subID := "507f1f77bcf86cd799439011"
+
+	tests := []struct {
+		name        string
+		query       string
+		setupMock   func(m *mocks.MockReminderDedupeService)
+		wantStatus  int
+		wantDeleted int
+	}{
+		{
+			name:  "success - clears a single marker",
+			query: "?daysBefore=3",
+			setupMock: func(m *mocks.MockReminderDedupeService) {
+				m.EXPECT().ClearReminderMarkers(mock.Anything, subID, mock.MatchedBy(func(d *int) bool {
+					return d != nil && *d == 3
+				})).Return(1, nil)
+			},
+			wantStatus:  http.StatusOK,
+			wantDeleted: 1,
+		},
+		{
+			name: "success - clears every marker",
+			setupMock: func(m *mocks.MockReminderDedupeService) {
+				m.EXPECT().ClearReminderMarkers(mock.Anything, subID, (*int)(nil)).Return(2, nil)
+			},
+			wantStatus:  http.StatusOK,
+			wantDeleted: 2,
+		},
+		{
+			name:       "failure - invalid daysBefore",
+			query:      "?daysBefore=soon",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dedupe := mocks.NewMockReminderDedupeService(t)
+			if tt.setupMock != nil {
+				tt.setupMock(dedupe)
+			}
+
+			handler := controllers.NewAdminController(nil, nil, dedupe, nil, nil, nil, nil, nil, nil, nil, endpoint.NewRequestHandler(validator.New()))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/subscriptions/"+subID+"/reminder-markers"+tt.query, nil))
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			if tt.wantStatus == http.StatusOK {
+				var body models.ClearReminderDedupeResponse
+				require.NoError(t, decodeData(rr.Body, &body))
+				assert.Equal(t, tt.wantDeleted, body.Deleted)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /rate-limits/{key}
+// ---------------------------------------------------------------------------
+
+func TestAdminController_GetRateLimits(t *testing.T) {
+	limiter := mocks.NewMockRateLimiterService(t)
+	limiter.EXPECT().State(mock.Anything, "10.0.0.1").Return(&models.RateLimitState{
+		Limiter:    "app",
+		Key:        "10.0.0.1",
+		Limited:    true,
+		RetryAfter: "30s",
+	}, nil)
+
+	handler := controllers.NewAdminController(nil, nil, nil, nil, nil, nil, nil, nil, []services.RateLimiterService{limiter}, nil, endpoint.NewRequestHandler(validator.New()))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/rate-limits/10.0.0.1", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var states []models.RateLimitState
+	require.NoError(t, decodeData(rr.Body, &states))
+	require.Len(t, states, 1)
+	assert.Equal(t, "app", states[0].Limiter)
+	assert.True(t, states[0].Limited)
+}
+
+// ---------------------------------------------------------------------------
+// GET /config
+// ---------------------------------------------------------------------------
+
+func TestAdminController_GetConfig(t *testing.T) {
+	var cf config.Config
+	cf.Server.Port = 8080
+	cf.JWT.AccessSecret = "access-secret"
+
+	handler := controllers.NewAdminController(nil, nil, nil, nil, nil, nil, nil, nil, nil, cf, endpoint.NewRequestHandler(validator.New()))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/config", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var body struct {
+		Server struct {
+			Port int `json:"port"`
+		} `json:"server"`
+		JWT struct {
+			AccessSecret string `json:"access_secret"`
+		} `json:"jwt"`
+	}
+	require.NoError(t, decodeData(rr.Body, &body))
+	assert.Equal(t, 8080, body.Server.Port)
+	assert.NotContains(t, rr.Body.String(), "access-secret")
+	assert.NotEmpty(t, body.JWT.AccessSecret)
+}
+
+// ---------------------------------------------------------------------------
+// GET /analytics
+// ---------------------------------------------------------------------------
+
+func TestAdminController_GetAnalytics(t *testing.T) {
+	analytics := mocks.NewMockAnalyticsServiceExternal(t)
+	analytics.EXPECT().GetPlatformStats(mock.Anything).Return(&models.PlatformStatsResponse{
+		TotalUsers:       12,
+		Subscriptions:    models.SubscriptionCounts{Active: 30, Canceled: 2},
+		RenewalsToday:    4,
+		EmailsToday:      models.EmailDeliveryStats{Sent: 9, Failed: 1},
+		Queue:            &models.QueueStats{Name: "default", Pending: 3},
+		MonthlyRecurring: []models.CurrencyAmount{models.NewCurrencyAmount(29970, models.USD)},
+	}, nil).Once()
+
+	handler := controllers.NewAdminController(nil, nil, nil, analytics, nil, nil, nil, nil, nil, nil, endpoint.NewRequestHandler(validator.New()))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/analytics", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var stats models.PlatformStatsResponse
+	require.NoError(t, decodeData(rr.Body, &stats))
+	assert.Equal(t, int64(12), stats.TotalUsers)
+	assert.Equal(t, int64(30), stats.Subscriptions.Active)
+	assert.Equal(t, models.EmailDeliveryStats{Sent: 9, Failed: 1}, stats.EmailsToday)
+	require.NotNil(t, stats.Queue)
+	assert.Equal(t, 3, stats.Queue.Pending)
+}
+
+// ---------------------------------------------------------------------------
+// PUT /bills/{billID}/status
+// ---------------------------------------------------------------------------
+
+func TestAdminController_UpdateBillStatus(t *testing.T) {
+	billID := "507f1f77bcf86cd799439022"
+
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(m *mocks.MockSubscriptionServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success - marks the bill failed",
+			body: `{"status":"failed"}`,
+			setupMock: func(m *mocks.MockSubscriptionServiceExternal) {
+				m.EXPECT().UpdateBillStatus(mock.Anything, billID, models.Failed).
+					Return(&models.Bill{Status: models.Failed, Currency: models.USD}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "failure - refunds are not set by hand",
+			body:       `{"status":"refunded"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscriptions := mocks.NewMockSubscriptionServiceExternal(t)
+			if tt.setupMock != nil {
+				tt.setupMock(subscriptions)
+			}
+
+			handler := controllers.NewAdminController(nil, nil, nil, nil, subscriptions, nil, nil, nil, nil, nil, endpoint.NewRequestHandler(validator.New()))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/bills/"+billID+"/status", strings.NewReader(tt.body)))
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			if tt.wantStatus == http.StatusOK {
+				var bill models.BillResponse
+				require.NoError(t, decodeData(rr.Body, &bill))
+				assert.Equal(t, models.Failed, bill.Status)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /audit-events
+// ---------------------------------------------------------------------------
+
+func TestAdminController_GetAuditEvents(t *testing.T) {
+	resourceID := bson.NewObjectID()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		url        string
+		setupMock  func(m *mocks.MockAuditServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success - passes the filters and page",
+			url:  "/audit-events?action=subscription.canceled&resourceType=subscription&resourceId=" + resourceID.Hex() + "&from=2025-01-01T00:00:00Z&limit=10&offset=20",
+			setupMock: func(m *mocks.MockAuditServiceExternal) {
+				event, err := models.NewAuditEvent(models.AuditSubscriptionCanceled, models.ActorAdmin, nil, resourceID, nil, nil, from)
+				require.NoError(t, err)
+				m.EXPECT().GetAuditEvents(mock.Anything, models.AuditQuery{
+					Action:       models.AuditSubscriptionCanceled,
+					ResourceType: "subscription",
+					ResourceID:   &resourceID,
+					From:         from,
+					PageQuery:    models.PageQuery{Limit: 10, Offset: 20},
+				}).Return(&models.AuditPage{Items: []*models.AuditEvent{event}, Total: 21}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "failure - malformed actor ID",
+			url:        "/audit-events?actorId=nope",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "failure - malformed time",
+			url:        "/audit-events?to=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := mocks.NewMockAuditServiceExternal(t)
+			if tt.setupMock != nil {
+				tt.setupMock(audit)
+			}
+
+			handler := controllers.NewAdminController(nil, nil, nil, nil, nil, audit, nil, nil, nil, nil, endpoint.NewRequestHandler(validator.New()))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "21", rr.Header().Get("X-Total-Count"))
+				var events []models.AuditEventResponse
+				require.NoError(t, decodeData(rr.Body, &events))
+				require.Len(t, events, 1)
+				assert.Equal(t, "admin", events[0].Actor)
+				assert.Equal(t, resourceID.Hex(), events[0].ResourceID)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Dead tasks
+// ---------------------------------------------------------------------------
+
+func TestAdminController_GetDeadTasks(t *testing.T) {
+	task := &models.DeadTask{
+		ID:       bson.NewObjectID(),
+		Type:     "subscription:renewal",
+		Queue:    "default",
+		Payload:  []byte(`{"subscription_id":"abc"}`),
+		Error:    "payment provider unavailable",
+		FailedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	requeued := false
+
+	tests := []struct {
+		name       string
+		url        string
+		setupMock  func(m *mocks.MockDeadTaskServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success - passes the filters and page",
+			url:  "/dead-tasks?type=subscription:renewal&queue=default&requeued=false&limit=10&offset=20",
+			setupMock: func(m *mocks.MockDeadTaskServiceExternal) {
+				m.EXPECT().GetDeadTasks(mock.Anything, models.DeadTaskQuery{
+					Type:      "subscription:renewal",
+					Queue:     "default",
+					Requeued:  &requeued,
+					PageQuery: models.PageQuery{Limit: 10, Offset: 20},
+				}).Return(&models.DeadTaskPage{Items: []*models.DeadTask{task}, Total: 21}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "failure - malformed requeued filter",
+			url:        "/dead-tasks?requeued=maybe",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deadTasks := mocks.NewMockDeadTaskServiceExternal(t)
+			if tt.setupMock != nil {
+				tt.setupMock(deadTasks)
+			}
+
+			handler := controllers.NewAdminController(nil, nil, nil, nil, nil, nil, deadTasks, nil, nil, nil, endpoint.NewRequestHandler(validator.New()))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "21", rr.Header().Get("X-Total-Count"))
+				var tasks []models.DeadTaskResponse
+				require.NoError(t, decodeData(rr.Body, &tasks))
+				require.Len(t, tasks, 1)
+				assert.Equal(t, task.ID.Hex(), tasks[0].ID)
+				assert.JSONEq(t, `{"subscription_id":"abc"}`, string(tasks[0].Payload))
+			}
+		})
+	}
+}
+
+func TestAdminController_RequeueDeadTask(t *testing.T) {
+	id := bson.NewObjectID()
+	requeuedAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "success - returns the requeued task",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "failure - already requeued",
+			err:        apperror.NewConflictError("Dead task was already requeued"),
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deadTasks := mocks.NewMockDeadTaskServiceExternal(t)
+			var task *models.DeadTask
+			if tt.err == nil {
+				task = &models.DeadTask{ID: id, RequeuedAt: &requeuedAt, RequeuedTaskID: "requeue:" + id.Hex()}
+			}
+			deadTasks.EXPECT().RequeueDeadTask(mock.Anything, id.Hex()).Return(task, tt.err).Once()
+
+			handler := controllers.NewAdminController(nil, nil, nil, nil, nil, nil, deadTasks, nil, nil, nil, endpoint.NewRequestHandler(validator.New()))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/dead-tasks/"+id.Hex()+"/requeue", nil))
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			if tt.wantStatus == http.StatusOK {
+				var got models.DeadTaskResponse
+				require.NoError(t, decodeData(rr.Body, &got))
+				assert.Equal(t, "requeue:"+id.Hex(), got.RequeuedTaskID)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /reconciliation
+// ---------------------------------------------------------------------------
+
+func TestAdminController_GetReconciliation(t *testing.T) {
+	t.Run("success - returns the latest report", func(t *testing.T) {
+		reconciliation := mocks.NewMockReconciliationServiceExternal(t)
+		reconciliation.EXPECT().GetReconciliationReport(mock.Anything).Return(&models.ReconciliationReport{
+			Checked:  40,
+			Counts:   map[models.ReconciliationIssue]int{models.ReconcileDunningSettled: 1},
+			Repaired: 1,
+			Findings: []models.ReconciliationFinding{{
+				SubscriptionID: "507f1f77bcf86cd799439011",
+				Status:         models.PastDue,
+				Issue:          models.ReconcileDunningSettled,
+				Repaired:       true,
+			}},
+		}, nil).Once()
+
+		handler := controllers.NewAdminController(nil, nil, nil, nil, nil, nil, nil, reconciliation, nil, nil, endpoint.NewRequestHandler(validator.New()))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/reconciliation", nil))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var report models.ReconciliationReport
+		require.NoError(t, decodeData(rr.Body, &report))
+		assert.Equal(t, 40, report.Checked)
+		assert.Equal(t, 1, report.Counts[models.ReconcileDunningSettled])
+		require.Len(t, report.Findings, 1)
+		assert.True(t, report.Findings[0].Repaired)
+	})
+
+	t.Run("error - no run yet", func(t *testing.T) {
+		reconciliation := mocks.NewMockReconciliationServiceExternal(t)
+		reconciliation.EXPECT().
+			GetReconciliationReport(mock.Anything).
+			Return(nil, apperror.NewNotFoundError("No reconciliation has run yet")).
+			Once()
+
+		handler := controllers.NewAdminController(nil, nil, nil, nil, nil, nil, nil, reconciliation, nil, nil, endpoint.NewRequestHandler(validator.New()))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/reconciliation", nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// attachmentField is the multipart form field holding an uploaded file.
+const attachmentField = "file"
+
+// multipartOverhead is the room allowed for part headers and boundaries on
+// top of the file itself.
+const multipartOverhead = 64 * 1024
+
+type attachmentController struct {
+	attachmentService services.AttachmentServiceExternal
+	maxUploadBytes    int64
+	requestHandler    *endpoint.RequestHandler
+}
+
+// NewAttachmentController serves the attachments of one subscription. It is
+// mounted under a route with a {subscriptionID} parameter. Uploads larger
+// than maxUploadBytes are cut off while reading.
+func NewAttachmentController(
+	attachmentService services.AttachmentServiceExternal,
+	maxUploadBytes int64,
+	requestHandler *endpoint.RequestHandler,
+) http.Handler {
+	c := &attachmentController{attachmentService, maxUploadBytes, requestHandler}
+
+	r := chi.NewRouter()
+	r.Post("/", c.uploadAttachment)
+	r.Get("/", c.getAttachments)
+	r.Get("/{attachmentID}", c.getAttachment)
+	r.Delete("/{attachmentID}", c.deleteAttachment)
+	return r
+}
+
+// AttachmentOperations documents the routes of NewAttachmentController.
+var AttachmentOperations = []openapi.Operation{
+	{Method: http.MethodPost, Path: "/", Summary: "Attach a receipt to a subscription", Upload: attachmentField, Response: models.AttachmentResponse{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/", Summary: "List the attachments of a subscription with signed download URLs", Response: []models.AttachmentResponse{}},
+	{Method: http.MethodGet, Path: "/{attachmentID}", Summary: "Get an attachment with a signed download URL", Response: models.AttachmentResponse{}},
+	{Method: http.MethodDelete, Path: "/{attachmentID}", Summary: "Delete an attachment", Status: http.StatusNoContent},
+}
+
+func (c *attachmentController) uploadAttachment(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	userID, _ := appctx.GetUserID(r.Context())
+	r.Body = http.MaxBytesReader(w, r.Body, c.maxUploadBytes+multipartOverhead)
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			upload, err := readUpload(r)
+			if err != nil {
+				return nil, err
+			}
+			return endpoint.ToResponse(c.attachmentService.UploadAttachment(r.Context(), subscriptionID, *upload, userID))
+		},
+		SuccessCode: http.StatusCreated,
+	})
+}
+
+func (c *attachmentController) getAttachments(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponseSlice(c.attachmentService.GetAttachments(r.Context(), subscriptionID, userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *attachmentController) getAttachment(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	attachmentID := chi.URLParam(r, "attachmentID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.attachmentService.GetAttachmentByID(r.Context(), subscriptionID, attachmentID, userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *attachmentController) deleteAttachment(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	attachmentID := chi.URLParam(r, "attachmentID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return nil, c.attachmentService.DeleteAttachment(r.Context(), subscriptionID, attachmentID, userID)
+		},
+		SuccessCode: http.StatusNoContent,
+	})
+}
+
+// readUpload finds the file field of a multipart request. The file is
+// streamed from the request body rather than buffered, so it must be read
+// before the response is written.
+func readUpload(r *http.Request) (*models.AttachmentUpload, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Expected a multipart/form-data request")
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, apperror.NewBadRequestError("Missing form field %s", attachmentField)
+		}
+		if _, ok := errors.AsType[*http.MaxBytesError](err); ok {
+			return nil, apperror.NewPayloadTooLargeError("Request body too large")
+		}
+		if err != nil {
+			return nil, apperror.NewBadRequestError("Invalid multipart/form-data request")
+		}
+		if part.FormName() == attachmentField {
+			return &models.AttachmentUpload{Filename: part.FileName(), Content: part}, nil
+		}
+	}
+}
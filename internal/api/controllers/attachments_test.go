@@ -0,0 +1,205 @@
+package controllers_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var attachmentSubHex = bson.NewObjectID().Hex()
+
+func setupAttachmentController(t *testing.T) (*mocks.MockAttachmentServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockAttachmentServiceExternal(t)
+	r := chi.NewRouter()
+	r.Mount("/subscriptions/{subscriptionID}/attachments", controllers.NewAttachmentController(
+		svc, 1024, endpoint.NewRequestHandler(validator.New()),
+	))
+	return svc, r
+}
+
+func validSignedAttachment() *models.SignedAttachment {
+	return &models.SignedAttachment{
+		Attachment: &models.Attachment{
+			ID:          bson.NewObjectID(),
+			Filename:    "receipt.pdf",
+			ContentType: "application/pdf",
+			Size:        5,
+			CreatedAt:   mockTime,
+		},
+		URL:       "/api/v1/files/abc/download?expires=1&signature=ff",
+		ExpiresAt: mockTime,
+	}
+}
+
+// multipartBody builds a form with one field; a non-empty filename makes it
+// a file field.
+func multipartBody(t *testing.T, field, filename, content string) (*bytes.Buffer, string) {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	var part io.Writer
+	var err error
+	if filename != "" {
+		part, err = form.CreateFormFile(field, filename)
+	} else {
+		part, err = form.CreateFormField(field)
+	}
+	require.NoError(t, err)
+	_, err = io.WriteString(part, content)
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+	return &body, form.FormDataContentType()
+}
+
+// ---------------------------------------------------------------------------
+// POST /
+// ---------------------------------------------------------------------------
+
+func TestAttachmentController_UploadAttachment(t *testing.T) {
+	svc, handler := setupAttachmentController(t)
+	signed := validSignedAttachment()
+	svc.EXPECT().
+		UploadAttachment(mock.Anything, attachmentSubHex, mock.MatchedBy(func(upload models.AttachmentUpload) bool {
+			content, _ := io.ReadAll(upload.Content)
+			return upload.Filename == "receipt.pdf" && string(content) == "%PDF-"
+		}), defaultUserHex).
+		Return(signed, nil).
+		Once()
+
+	body, contentType := multipartBody(t, "file", "receipt.pdf", "%PDF-")
+	req := httptest.NewRequest(http.MethodPost, "/subscriptions/"+attachmentSubHex+"/attachments", body)
+	req.Header.Set("Content-Type", contentType)
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var resp models.AttachmentResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	assert.Equal(t, signed.Attachment.ID.Hex(), resp.ID)
+	assert.Equal(t, signed.URL, resp.DownloadURL)
+}
+
+func TestAttachmentController_UploadAttachment_Rejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        func(t *testing.T) (io.Reader, string)
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name: "not multipart",
+			body: func(*testing.T) (io.Reader, string) {
+				return strings.NewReader(`{"file":"x"}`), "application/json"
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "Expected a multipart/form-data request",
+		},
+		{
+			name: "no file field",
+			body: func(t *testing.T) (io.Reader, string) {
+				return multipartBody(t, "note", "", "hello")
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "Missing form field file",
+		},
+		{
+			name: "body over the limit",
+			body: func(t *testing.T) (io.Reader, string) {
+				return multipartBody(t, "note", "", strings.Repeat("x", 128*1024))
+			},
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantMessage: "Request body too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, handler := setupAttachmentController(t)
+			body, contentType := tt.body(t)
+			req := httptest.NewRequest(http.MethodPost, "/subscriptions/"+attachmentSubHex+"/attachments", body)
+			req.Header.Set("Content-Type", contentType)
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET / and GET /{attachmentID}
+// ---------------------------------------------------------------------------
+
+func TestAttachmentController_GetAttachments(t *testing.T) {
+	svc, handler := setupAttachmentController(t)
+	signed := validSignedAttachment()
+	svc.EXPECT().GetAttachments(mock.Anything, attachmentSubHex, defaultUserHex).Return([]*models.SignedAttachment{signed}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/subscriptions/"+attachmentSubHex+"/attachments", nil)
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp []models.AttachmentResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	require.Len(t, resp, 1)
+	assert.Equal(t, "receipt.pdf", resp[0].Filename)
+}
+
+func TestAttachmentController_GetAttachment(t *testing.T) {
+	svc, handler := setupAttachmentController(t)
+	signed := validSignedAttachment()
+	id := signed.Attachment.ID.Hex()
+	svc.EXPECT().GetAttachmentByID(mock.Anything, attachmentSubHex, id, defaultUserHex).Return(signed, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/subscriptions/"+attachmentSubHex+"/attachments/"+id, nil)
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+// ---------------------------------------------------------------------------
+// DELETE /{attachmentID}
+// ---------------------------------------------------------------------------
+
+func TestAttachmentController_DeleteAttachment(t *testing.T) {
+	svc, handler := setupAttachmentController(t)
+	id := bson.NewObjectID().Hex()
+	svc.EXPECT().DeleteAttachment(mock.Anything, attachmentSubHex, id, defaultUserHex).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/subscriptions/"+attachmentSubHex+"/attachments/"+id, nil)
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNoContent, rr.Code)
+}
@@ -3,9 +3,13 @@ package controllers
 import (
 	"net/http"
 
+	"github.com/anuragthepathak/subscription-management/internal/api/middlewares"
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/i18n"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -16,7 +20,15 @@ type authController struct {
 }
 
 // NewAuthController initializes the authentication controller with routes.
-func NewAuthController(authService services.AuthService, userService services.UserServiceExternal, requestHandler *endpoint.RequestHandler) http.Handler {
+// credentialRateLimit throttles the routes that accept credentials, and
+// authenticate guards logout.
+func NewAuthController(
+	authService services.AuthService,
+	userService services.UserServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+	credentialRateLimit func(http.Handler) http.Handler,
+	authenticate func(http.Handler) http.Handler,
+) http.Handler {
 	c := &authController{
 		authService,
 		userService,
@@ -24,13 +36,22 @@ func NewAuthController(authService services.AuthService, userService services.Us
 	}
 
 	r := chi.NewRouter()
-	r.Post("/login", c.login)
+	r.With(credentialRateLimit).Post("/login", c.login)
 	r.Post("/refresh", c.refreshToken)
-	r.Post("/register", c.createUser)
+	r.With(authenticate).Post("/logout", c.logout)
+	r.With(credentialRateLimit).Post("/register", c.createUser)
 
 	return r
 }
 
+// AuthOperations documents the routes of NewAuthController.
+var AuthOperations = []openapi.Operation{
+	{Method: http.MethodPost, Path: "/login", Summary: "Log in with email and password", Public: true, Request: models.LoginRequest{}, Response: models.TokenResponse{}},
+	{Method: http.MethodPost, Path: "/refresh", Summary: "Exchange a refresh token for new tokens", Public: true, Request: models.RefreshRequest{}, Response: models.TokenResponse{}},
+	{Method: http.MethodPost, Path: "/logout", Summary: "Revoke the access and refresh tokens and end their session", Request: models.LogoutRequest{}, Status: http.StatusNoContent},
+	{Method: http.MethodPost, Path: "/register", Summary: "Register a user", Public: true, Request: models.UserRequest{}, Response: models.UserResponse{}, Status: http.StatusCreated},
+}
+
 // createUser handles user registration.
 func (c *authController) createUser(w http.ResponseWriter, r *http.Request) {
 	user := models.UserRequest{}
@@ -41,6 +62,9 @@ func (c *authController) createUser(w http.ResponseWriter, r *http.Request) {
 			R:          r,
 			ReqBodyObj: &user,
 			EndpointLogic: func() (any, error) {
+				if user.Locale == "" {
+					user.Locale = i18n.FromContext(r.Context()).Locale()
+				}
 				return endpoint.ToResponse(c.userService.CreateUser(r.Context(), user.ToModel()))
 			},
 			SuccessCode: http.StatusCreated,
@@ -58,7 +82,7 @@ func (c *authController) login(w http.ResponseWriter, r *http.Request) {
 			R:          r,
 			ReqBodyObj: &loginReq,
 			EndpointLogic: func() (any, error) {
-				return c.authService.Login(r.Context(), loginReq)
+				return c.authService.Login(r.Context(), loginReq, sessionClient(r))
 			},
 			SuccessCode: http.StatusOK,
 		},
@@ -75,9 +99,34 @@ func (c *authController) refreshToken(w http.ResponseWriter, r *http.Request) {
 			R:          r,
 			ReqBodyObj: &req,
 			EndpointLogic: func() (any, error) {
-				return c.authService.RefreshToken(r.Context(), req.RefreshToken)
+				return c.authService.RefreshToken(r.Context(), req.RefreshToken, sessionClient(r))
 			},
 			SuccessCode: http.StatusOK,
 		},
 	)
 }
+
+// sessionClient describes the client of r for its session. The device name
+// comes from the login request.
+func sessionClient(r *http.Request) models.SessionClient {
+	ip, _ := lib.ClientIP(r)
+	return models.SessionClient{IP: ip, UserAgent: r.UserAgent()}
+}
+
+// logout revokes the access token of the request and the given refresh token.
+func (c *authController) logout(w http.ResponseWriter, r *http.Request) {
+	req := models.LogoutRequest{}
+	accessToken, _ := middlewares.BearerToken(r)
+
+	c.requestHandler.ServeRequest(
+		endpoint.InternalRequest{
+			W:          w,
+			R:          r,
+			ReqBodyObj: &req,
+			EndpointLogic: func() (any, error) {
+				return nil, c.authService.Logout(r.Context(), accessToken, req.RefreshToken)
+			},
+			SuccessCode: http.StatusNoContent,
+		},
+	)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
 	"github.com/go-playground/validator/v10"
@@ -40,7 +41,8 @@ func setupAuthController(t *testing.T) (*mocks.MockAuthService, *mocks.MockUserS
 	v := validator.New()
 	reqHandler := endpoint.NewRequestHandler(v)
 
-	router := controllers.NewAuthController(authSvc, userSvc, reqHandler)
+	passThrough := func(next http.Handler) http.Handler { return next }
+	router := controllers.NewAuthController(authSvc, userSvc, reqHandler, passThrough, passThrough)
 	return authSvc, userSvc, router
 }
 
@@ -56,22 +58,25 @@ func TestAuthController_CreateUser(t *testing.T) {
 			Password: "securepassword123",
 		}
 	}
-	validModelFromInput := func() *models.User {
-		return validInput().ToModel()
+	validModelFromInput := func(locale string) *models.User {
+		user := validInput().ToModel()
+		user.Locale = locale
+		return user
 	}
 
 	tests := []struct {
-		name       string
-		setupMocks func(authSvc *mocks.MockAuthService, userSvc *mocks.MockUserServiceExternal)
-		wantStatus int
-		wantUser   *models.UserResponse
+		name          string
+		requestLocale string
+		setupMocks    func(authSvc *mocks.MockAuthService, userSvc *mocks.MockUserServiceExternal)
+		wantStatus    int
+		wantUser      *models.UserResponse
 	}{
 		{
 			name: "success - parses body, calls user service, returns 201 Created",
 			setupMocks: func(authSvc *mocks.MockAuthService, userSvc *mocks.MockUserServiceExternal) {
 				// The Vault Lock: Proves UserRequest mapped to User correctly before hitting the service
 				matcher := mock.MatchedBy(func(u *models.User) bool {
-					return assert.ObjectsAreEqual(validModelFromInput(), u)
+					return assert.ObjectsAreEqual(validModelFromInput("en"), u)
 				})
 
 				// Note: Register doesn't use authSvc, only userSvc
@@ -83,6 +88,22 @@ func TestAuthController_CreateUser(t *testing.T) {
 			wantStatus: http.StatusCreated,
 			wantUser:   validUserResponse(),
 		},
+		{
+			name:          "success - locale defaults to that of the request",
+			requestLocale: "de-DE",
+			setupMocks: func(authSvc *mocks.MockAuthService, userSvc *mocks.MockUserServiceExternal) {
+				matcher := mock.MatchedBy(func(u *models.User) bool {
+					return assert.ObjectsAreEqual(validModelFromInput("de"), u)
+				})
+
+				userSvc.EXPECT().
+					CreateUser(mock.Anything, matcher).
+					Return(validUser(), nil).
+					Once()
+			},
+			wantStatus: http.StatusCreated,
+			wantUser:   validUserResponse(),
+		},
 		{
 			name: "error - propagates service error (e.g. email already exists)",
 			setupMocks: func(authSvc *mocks.MockAuthService, userSvc *mocks.MockUserServiceExternal) {
@@ -105,6 +126,9 @@ func TestAuthController_CreateUser(t *testing.T) {
 
 			req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(inputBytes))
 			req.Header.Set("Content-Type", "application/json")
+			if tt.requestLocale != "" {
+				req = req.WithContext(appctx.WithLocale(req.Context(), tt.requestLocale))
+			}
 			rr := httptest.NewRecorder()
 
 			handler.ServeHTTP(rr, req)
@@ -113,7 +137,7 @@ func TestAuthController_CreateUser(t *testing.T) {
 
 			if tt.wantUser != nil {
 				var resp *models.UserResponse
-				err := json.NewDecoder(rr.Body).Decode(&resp)
+				err := decodeData(rr.Body, &resp)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantUser, resp)
 			}
@@ -125,11 +149,16 @@ func TestAuthController_CreateUser(t *testing.T) {
 // POST /login
 // ---------------------------------------------------------------------------
 
+// testClient is the session client of the test requests; httptest sends
+// them from 192.0.2.1.
+var testClient = models.SessionClient{IP: "192.0.2.1", UserAgent: "test-agent"}
+
 func TestAuthController_Login(t *testing.T) {
 	validInput := func() models.LoginRequest {
 		return models.LoginRequest{
 			Email:    defaultUserEmail,
 			Password: "securepassword123",
+			Device:   "Work laptop",
 		}
 	}
 
@@ -146,7 +175,7 @@ func TestAuthController_Login(t *testing.T) {
 			setupMocks: func(authSvc *mocks.MockAuthService, userSvc *mocks.MockUserServiceExternal) {
 				// We pass the exact dereferenced struct to match the value sent from the controller
 				authSvc.EXPECT().
-					Login(mock.Anything, validInput()).
+					Login(mock.Anything, validInput(), testClient).
 					Return(validTokenResponse(), nil).
 					Once()
 			},
@@ -157,7 +186,7 @@ func TestAuthController_Login(t *testing.T) {
 			name: "error - propagates service error",
 			setupMocks: func(authSvc *mocks.MockAuthService, userSvc *mocks.MockUserServiceExternal) {
 				authSvc.EXPECT().
-					Login(mock.Anything, validInput()).
+					Login(mock.Anything, validInput(), testClient).
 					Return(nil, apperror.NewUnauthorizedError("unauthorized")).
 					Once()
 			},
@@ -175,6 +204,7 @@ func TestAuthController_Login(t *testing.T) {
 
 			req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(inputBytes))
 			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", testClient.UserAgent)
 			rr := httptest.NewRecorder()
 
 			handler.ServeHTTP(rr, req)
@@ -183,7 +213,7 @@ func TestAuthController_Login(t *testing.T) {
 
 			if tt.wantTokens != nil {
 				var resp *models.TokenResponse
-				err := json.NewDecoder(rr.Body).Decode(&resp)
+				err := decodeData(rr.Body, &resp)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantTokens, resp)
 			}
@@ -212,7 +242,7 @@ func TestAuthController_RefreshToken(t *testing.T) {
 			name: "success - parses body, calls auth service, returns 200 OK",
 			setupMocks: func(authSvc *mocks.MockAuthService, userSvc *mocks.MockUserServiceExternal) {
 				authSvc.EXPECT().
-					RefreshToken(mock.Anything, validInput().RefreshToken).
+					RefreshToken(mock.Anything, validInput().RefreshToken, testClient).
 					Return(validTokenResponse(), nil).
 					Once()
 			},
@@ -223,7 +253,7 @@ func TestAuthController_RefreshToken(t *testing.T) {
 			name: "error - propagates service error for expired refresh token",
 			setupMocks: func(authSvc *mocks.MockAuthService, userSvc *mocks.MockUserServiceExternal) {
 				authSvc.EXPECT().
-					RefreshToken(mock.Anything, validInput().RefreshToken).
+					RefreshToken(mock.Anything, validInput().RefreshToken, testClient).
 					Return(nil, apperror.NewUnauthorizedError("refresh token expired")).
 					Once()
 			},
@@ -241,6 +271,7 @@ func TestAuthController_RefreshToken(t *testing.T) {
 
 			req := httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewReader(inputBytes))
 			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", testClient.UserAgent)
 			rr := httptest.NewRecorder()
 
 			handler.ServeHTTP(rr, req)
@@ -249,10 +280,67 @@ func TestAuthController_RefreshToken(t *testing.T) {
 
 			if tt.wantTokens != nil {
 				var resp *models.TokenResponse
-				err := json.NewDecoder(rr.Body).Decode(&resp)
+				err := decodeData(rr.Body, &resp)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantTokens, resp)
 			}
 		})
 	}
 }
+
+// ---------------------------------------------------------------------------
+// POST /logout
+// ---------------------------------------------------------------------------
+
+func TestAuthController_Logout(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setupMocks func(authSvc *mocks.MockAuthService)
+		wantStatus int
+	}{
+		{
+			name: "success - revokes the bearer and refresh tokens",
+			body: `{"refreshToken":"refresh.token.string"}`,
+			setupMocks: func(authSvc *mocks.MockAuthService) {
+				authSvc.EXPECT().
+					Logout(mock.Anything, "access.token.string", "refresh.token.string").
+					Return(nil).
+					Once()
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "error - missing refresh token",
+			body:       `{}`,
+			setupMocks: func(authSvc *mocks.MockAuthService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - refresh token of another user",
+			body: `{"refreshToken":"refresh.token.string"}`,
+			setupMocks: func(authSvc *mocks.MockAuthService) {
+				authSvc.EXPECT().
+					Logout(mock.Anything, "access.token.string", "refresh.token.string").
+					Return(apperror.NewForbiddenError("Refresh token belongs to another user")).
+					Once()
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authSvc, _, handler := setupAuthController(t)
+			tt.setupMocks(authSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/logout", bytes.NewBufferString(tt.body))
+			req.Header.Set("Authorization", "Bearer access.token.string")
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type billController struct {
+	billService    services.BillServiceExternal
+	exportService  services.ExportServiceExternal
+	invoiceService services.InvoiceServiceExternal
+	requestHandler *endpoint.RequestHandler
+}
+
+// NewBillController serves single bills by ID, their PDF invoices, and
+// exports of the caller's bills, which run under exportTimeout.
+func NewBillController(
+	billService services.BillServiceExternal,
+	exportService services.ExportServiceExternal,
+	invoiceService services.InvoiceServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+	exportTimeout func(http.Handler) http.Handler,
+) http.Handler {
+	c := &billController{billService, exportService, invoiceService, requestHandler}
+
+	r := chi.NewRouter()
+	r.With(exportTimeout).Get("/export", c.exportBills)
+	r.Get("/{billID}", c.getBillByID)
+	r.Get("/{billID}/invoice.pdf", c.getInvoice)
+	return r
+}
+
+// BillOperations documents the routes of NewBillController.
+var BillOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/export", Summary: "Download bills as CSV or XLSX; large exports are emailed", Query: billExportParams, ContentType: "text/csv"},
+	{Method: http.MethodGet, Path: "/{billID}", Summary: "Get a bill", Response: models.BillResponse{}},
+	{Method: http.MethodGet, Path: "/{billID}/invoice.pdf", Summary: "Download the PDF invoice of a bill", ContentType: models.InvoiceContentType},
+}
+
+// NewSubscriptionBillController serves the bills of one subscription. It is
+// mounted under a route with a {subscriptionID} parameter.
+func NewSubscriptionBillController(billService services.BillServiceExternal, requestHandler *endpoint.RequestHandler) http.Handler {
+	c := &billController{billService, nil, nil, requestHandler}
+
+	r := chi.NewRouter()
+	r.Get("/", c.getBillsBySubscriptionID)
+	return r
+}
+
+// SubscriptionBillOperations documents the routes of
+// NewSubscriptionBillController.
+var SubscriptionBillOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/", Summary: "List the bills of a subscription, newest first", Query: []openapi.Param{limitParam, offsetParam}, Response: []models.BillResponse{}},
+}
+
+func (c *billController) getBillByID(w http.ResponseWriter, r *http.Request) {
+	billID := chi.URLParam(r, "billID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.billService.GetBillByID(r.Context(), billID, userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// getInvoice streams the PDF invoice of a bill of the caller's.
+func (c *billController) getInvoice(w http.ResponseWriter, r *http.Request) {
+	billID := chi.URLParam(r, "billID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			file, content, err := c.invoiceService.GetInvoice(r.Context(), billID, userID)
+			if err != nil {
+				return nil, err
+			}
+			return &endpoint.StreamResponse{
+				ContentType: file.Metadata.ContentType,
+				Filename:    file.Filename,
+				Size:        file.Size,
+				Body:        content,
+			}, nil
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// exportBills streams the caller's bills as a file, or queues the export
+// when it is too large to stream.
+func (c *billController) exportBills(w http.ResponseWriter, r *http.Request) {
+	serveExport(w, r, c.requestHandler, c.exportService, func() (models.ExportRequest, error) {
+		return billExportRequest(r)
+	})
+}
+
+// getBillsBySubscriptionID returns a page of bills, newest first, with the
+// total in meta.pagination.
+func (c *billController) getBillsBySubscriptionID(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			limit, offset, err := pageParams(r)
+			if err != nil {
+				return nil, err
+			}
+			page, err := c.billService.GetBillsBySubscriptionID(
+				r.Context(),
+				subscriptionID,
+				userID,
+				models.BillQuery{PageQuery: models.PageQuery{Limit: limit, Offset: offset}},
+			)
+			if err != nil {
+				return nil, err
+			}
+			return pageResponse(page.Items, page.Total, limit, offset)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
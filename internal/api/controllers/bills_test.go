@@ -0,0 +1,322 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// setupBillController mounts both bill routers the way the server does.
+func setupBillController(t *testing.T) (*mocks.MockBillServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockBillServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+
+	r := chi.NewRouter()
+	r.Mount("/subscriptions/{subscriptionID}/bills", controllers.NewSubscriptionBillController(svc, reqHandler))
+	r.Mount("/bills", controllers.NewBillController(
+		svc,
+		mocks.NewMockExportServiceExternal(t),
+		mocks.NewMockInvoiceServiceExternal(t),
+		reqHandler,
+		allowAll,
+	))
+	return svc, r
+}
+
+func setupBillExportController(t *testing.T) (*mocks.MockExportServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockExportServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	return svc, controllers.NewBillController(
+		mocks.NewMockBillServiceExternal(t),
+		svc,
+		mocks.NewMockInvoiceServiceExternal(t),
+		reqHandler,
+		allowAll,
+	)
+}
+
+func setupInvoiceController(t *testing.T) (*mocks.MockInvoiceServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockInvoiceServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	return svc, controllers.NewBillController(
+		mocks.NewMockBillServiceExternal(t),
+		mocks.NewMockExportServiceExternal(t),
+		svc,
+		reqHandler,
+		allowAll,
+	)
+}
+
+func validBill() *models.Bill {
+	return &models.Bill{
+		ID:             bson.NewObjectID(),
+		Amount:         999,
+		Currency:       models.USD,
+		SubscriptionID: defaultSubID,
+		StartDate:      mockTime,
+		EndDate:        mockTime.AddDate(0, 1, 0),
+		Status:         models.Paid,
+		CreatedAt:      mockTime,
+		UpdatedAt:      mockTime,
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /subscriptions/{subscriptionID}/bills
+// ---------------------------------------------------------------------------
+
+func TestBillController_GetBillsBySubscriptionID(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		setupMocks func(svc *mocks.MockBillServiceExternal)
+		wantStatus int
+		wantTotal  string
+	}{
+		{
+			name:  "success - passes the page and sets the total",
+			query: "?limit=10&offset=20",
+			setupMocks: func(svc *mocks.MockBillServiceExternal) {
+				svc.EXPECT().
+					GetBillsBySubscriptionID(mock.Anything, defaultSubHex, defaultUserHex, models.BillQuery{PageQuery: models.PageQuery{Limit: 10, Offset: 20}}).
+					Return(&models.BillPage{Items: []*models.Bill{validBill()}, Total: 21}, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+			wantTotal:  "21",
+		},
+		{
+			name:       "error - invalid limit",
+			query:      "?limit=zero",
+			setupMocks: func(svc *mocks.MockBillServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - subscription of another user",
+			setupMocks: func(svc *mocks.MockBillServiceExternal) {
+				svc.EXPECT().
+					GetBillsBySubscriptionID(mock.Anything, defaultSubHex, defaultUserHex, models.BillQuery{}).
+					Return(nil, apperror.NewForbiddenError("You are not allowed to view bills of this subscription")).
+					Once()
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupBillController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodGet, "/subscriptions/"+defaultSubHex+"/bills"+tt.query, nil)
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantTotal != "" {
+				assert.Equal(t, tt.wantTotal, rr.Header().Get("X-Total-Count"))
+				var resp struct {
+					Data []models.BillResponse `json:"data"`
+					Meta endpoint.Meta         `json:"meta"`
+				}
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+				assert.Len(t, resp.Data, 1)
+				assert.Equal(t, &endpoint.Pagination{Total: 21, Limit: 10, Offset: 20}, resp.Meta.Pagination)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /bills/{billID}
+// ---------------------------------------------------------------------------
+
+func TestBillController_GetBillByID(t *testing.T) {
+	svc, handler := setupBillController(t)
+	bill := validBill()
+	svc.EXPECT().GetBillByID(mock.Anything, bill.ID.Hex(), defaultUserHex).Return(bill, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/bills/"+bill.ID.Hex(), nil)
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp models.BillResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	assert.Equal(t, bill.ID.Hex(), resp.ID)
+	assert.Equal(t, defaultSubHex, resp.SubscriptionID)
+}
+
+// ---------------------------------------------------------------------------
+// GET /bills/{billID}/invoice.pdf
+// ---------------------------------------------------------------------------
+
+func TestBillController_GetInvoice(t *testing.T) {
+	billID := bson.NewObjectID().Hex()
+
+	tests := []struct {
+		name       string
+		setupMocks func(svc *mocks.MockInvoiceServiceExternal)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success - streams the PDF as an attachment",
+			setupMocks: func(svc *mocks.MockInvoiceServiceExternal) {
+				file := &models.StoredFile{
+					ID:       bson.NewObjectID(),
+					Filename: "invoice-" + billID + ".pdf",
+					Size:     5,
+					Metadata: models.FileMetadata{ContentType: models.InvoiceContentType},
+				}
+				svc.EXPECT().
+					GetInvoice(mock.Anything, billID, defaultUserHex).
+					Return(file, io.NopCloser(strings.NewReader("%PDF-")), nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "%PDF-",
+		},
+		{
+			name: "error - bill of another user",
+			setupMocks: func(svc *mocks.MockInvoiceServiceExternal) {
+				svc.EXPECT().
+					GetInvoice(mock.Anything, billID, defaultUserHex).
+					Return(nil, nil, apperror.NewForbiddenError("You are not allowed to view bills of this subscription")).
+					Once()
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupInvoiceController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodGet, "/"+billID+"/invoice.pdf", nil)
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+				assert.Equal(t, "attachment; filename=invoice-"+billID+".pdf", rr.Header().Get("Content-Disposition"))
+				assert.Equal(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /bills/export
+// ---------------------------------------------------------------------------
+
+func TestBillController_ExportBills(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMocks func(svc *mocks.MockExportServiceExternal)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "success - streams the file as an attachment",
+			query: "?format=csv&subscriptionId=" + defaultSubHex + "&status=paid&from=2026-01-01T00:00:00Z",
+			setupMocks: func(svc *mocks.MockExportServiceExternal) {
+				want := models.ExportRequest{
+					Kind:           models.BillExport,
+					Format:         models.CSVFormat,
+					SubscriptionID: defaultSubID,
+					BillStatus:     models.Paid,
+					From:           from,
+				}
+				svc.EXPECT().Export(mock.Anything, defaultUserHex, want).Return(&models.Export{
+					Request:     want,
+					Filename:    "bills-2026-03-14.csv",
+					ContentType: models.CSVFormat.ContentType(),
+					Rows:        1,
+					Body:        io.NopCloser(strings.NewReader("id\n")),
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "id\n",
+		},
+		{
+			name: "success - queues a large export",
+			setupMocks: func(svc *mocks.MockExportServiceExternal) {
+				want := models.ExportRequest{Kind: models.BillExport, Format: models.CSVFormat}
+				svc.EXPECT().Export(mock.Anything, defaultUserHex, want).
+					Return(&models.Export{Request: want, Rows: 20000}, nil).
+					Once()
+			},
+			wantStatus: http.StatusAccepted,
+			wantBody:   `{"data":{"kind":"bills","format":"csv","rows":20000}}`,
+		},
+		{
+			name:       "error - invalid from",
+			query:      "?from=2026-01-01",
+			setupMocks: func(svc *mocks.MockExportServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - invalid subscription ID",
+			query:      "?subscriptionId=nope",
+			setupMocks: func(svc *mocks.MockExportServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupBillExportController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodGet, "/export"+tt.query, nil)
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			switch tt.wantStatus {
+			case http.StatusOK:
+				assert.Equal(t, "attachment; filename=bills-2026-03-14.csv", rr.Header().Get("Content-Disposition"))
+				assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+				assert.Equal(t, tt.wantBody, rr.Body.String())
+			case http.StatusAccepted:
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
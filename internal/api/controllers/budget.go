@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type budgetController struct {
+	budgetService  services.BudgetServiceExternal
+	requestHandler *endpoint.RequestHandler
+}
+
+// NewBudgetController serves the budget of the authenticated user.
+func NewBudgetController(budgetService services.BudgetServiceExternal, requestHandler *endpoint.RequestHandler) http.Handler {
+	c := &budgetController{budgetService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Get("/", c.getBudget)
+	r.Put("/", c.setBudget)
+	r.Delete("/", c.deleteBudget)
+	r.Get("/stats", c.getBudgetStats)
+	return r
+}
+
+// BudgetOperations documents the routes of NewBudgetController.
+var BudgetOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/", Summary: "Get the budget", Response: models.BudgetResponse{}},
+	{Method: http.MethodPut, Path: "/", Summary: "Set the budget", Request: models.BudgetRequest{}, Response: models.BudgetResponse{}},
+	{Method: http.MethodDelete, Path: "/", Summary: "Remove the budget", Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/stats", Summary: "Compare spend against the budget", Response: models.BudgetStatsResponse{}},
+}
+
+func (c *budgetController) getBudget(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.budgetService.GetBudget(r.Context(), userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *budgetController) setBudget(w http.ResponseWriter, r *http.Request) {
+	budget := models.BudgetRequest{}
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &budget,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.budgetService.SetBudget(r.Context(), budget.ToModel(), userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *budgetController) deleteBudget(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return nil, c.budgetService.DeleteBudget(r.Context(), userID)
+		},
+		SuccessCode: http.StatusNoContent,
+	})
+}
+
+// getBudgetStats reports the caller's monthly spend per category against
+// their budget.
+func (c *budgetController) getBudgetStats(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return c.budgetService.GetBudgetStats(r.Context(), userID)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
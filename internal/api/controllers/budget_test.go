@@ -0,0 +1,153 @@
+package controllers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBudgetController(t *testing.T) (*mocks.MockBudgetServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockBudgetServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	return svc, controllers.NewBudgetController(svc, reqHandler)
+}
+
+// ---------------------------------------------------------------------------
+// PUT /
+// ---------------------------------------------------------------------------
+
+func TestBudgetController_SetBudget(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setupMocks func(svc *mocks.MockBudgetServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success - normalizes currency",
+			body: `{"currency":"usd","overall":5000,"categories":{"news":1000}}`,
+			setupMocks: func(svc *mocks.MockBudgetServiceExternal) {
+				want := &models.Budget{
+					Currency:   models.USD,
+					Overall:    5000,
+					Categories: map[models.Category]int64{models.News: 1000},
+				}
+				svc.EXPECT().
+					SetBudget(mock.Anything, want, defaultUserHex).
+					Return(want, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error - missing currency",
+			body:       `{"overall":5000}`,
+			setupMocks: func(svc *mocks.MockBudgetServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - propagates service error",
+			body: `{"currency":"USD"}`,
+			setupMocks: func(svc *mocks.MockBudgetServiceExternal) {
+				svc.EXPECT().
+					SetBudget(mock.Anything, mock.Anything, defaultUserHex).
+					Return(nil, apperror.NewValidationError("an overall or category budget is required")).
+					Once()
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupBudgetController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp models.BudgetResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				assert.Equal(t, "USD", resp.Currency)
+				assert.Equal(t, map[string]int64{"news": 1000}, resp.Categories)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /stats
+// ---------------------------------------------------------------------------
+
+func TestBudgetController_GetBudgetStats(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, handler := setupBudgetController(t)
+		stats := &models.BudgetStatsResponse{
+			Currency: "USD",
+			Overall:  models.BudgetLine{Actual: models.NewCurrencyAmount(2798, models.USD)},
+			Categories: []models.BudgetLine{{
+				Category: "entertainment",
+				Actual:   models.NewCurrencyAmount(1798, models.USD),
+				Exceeded: true,
+			}},
+		}
+		svc.EXPECT().GetBudgetStats(mock.Anything, defaultUserHex).Return(stats, nil).Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/stats", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp models.BudgetStatsResponse
+		require.NoError(t, decodeData(rr.Body, &resp))
+		assert.Equal(t, stats.Categories, resp.Categories)
+	})
+
+	t.Run("error - no budget", func(t *testing.T) {
+		svc, handler := setupBudgetController(t)
+		svc.EXPECT().
+			GetBudgetStats(mock.Anything, defaultUserHex).
+			Return(nil, apperror.NewNotFoundError("Document not found")).
+			Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/stats", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// DELETE /
+// ---------------------------------------------------------------------------
+
+func TestBudgetController_DeleteBudget(t *testing.T) {
+	svc, handler := setupBudgetController(t)
+	svc.EXPECT().DeleteBudget(mock.Anything, defaultUserHex).Return(nil).Once()
+
+	req := injectUserID(httptest.NewRequest(http.MethodDelete, "/", nil), defaultUserHex)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+}
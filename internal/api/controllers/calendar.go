@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type calendarController struct {
+	calendarFeedService services.CalendarFeedService
+	requestHandler      *endpoint.RequestHandler
+}
+
+// NewCalendarController serves private ICS feeds of renewals. The feed route
+// is authorized by the token in its URL so calendar apps can poll it, so only
+// the route issuing the URL sits behind the authentication middleware.
+func NewCalendarController(
+	calendarFeedService services.CalendarFeedService,
+	requestHandler *endpoint.RequestHandler,
+	authentication func(http.Handler) http.Handler,
+) http.Handler {
+	c := &calendarController{
+		calendarFeedService,
+		requestHandler,
+	}
+
+	r := chi.NewRouter()
+	r.Get("/{token}.ics", c.getFeed)
+
+	r.Group(func(r chi.Router) {
+		r.Use(authentication)
+		r.Get("/feed", c.getFeedURL)
+		r.Post("/feed/regenerate", c.regenerateFeedURL)
+	})
+
+	return r
+}
+
+// CalendarOperations documents the routes of NewCalendarController.
+var CalendarOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/{token}.ics", Summary: "Download the ICS feed of renewals", Public: true, ContentType: "text/calendar"},
+	{Method: http.MethodGet, Path: "/feed", Summary: "Get the private feed URL", Response: models.CalendarFeed{}},
+	{Method: http.MethodPost, Path: "/feed/regenerate", Summary: "Issue a new private feed URL, revoking the old one", Response: models.CalendarFeed{}},
+}
+
+// NewSubscriptionCalendarController serves the ICS feed at the URL issued by
+// GetFeedURL, taking the token from the query string. It is mounted outside
+// the authentication middleware, at a path the subscription routes leave
+// free.
+func NewSubscriptionCalendarController(
+	calendarFeedService services.CalendarFeedService,
+	requestHandler *endpoint.RequestHandler,
+) http.Handler {
+	c := &calendarController{
+		calendarFeedService,
+		requestHandler,
+	}
+
+	r := chi.NewRouter()
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		c.serveFeed(w, r, r.URL.Query().Get("token"))
+	})
+	return r
+}
+
+// SubscriptionCalendarOperations documents the routes of
+// NewSubscriptionCalendarController.
+var SubscriptionCalendarOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/", Summary: "Download the ICS feed of renewals", Public: true, Query: []openapi.Param{
+		{Name: "token", Description: "Feed token from the private feed URL", Required: true},
+	}, ContentType: "text/calendar"},
+}
+
+func (c *calendarController) getFeedURL(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return c.calendarFeedService.GetFeedURL(r.Context(), userID)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *calendarController) regenerateFeedURL(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return c.calendarFeedService.RegenerateFeedURL(r.Context(), userID)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *calendarController) getFeed(w http.ResponseWriter, r *http.Request) {
+	c.serveFeed(w, r, chi.URLParam(r, "token"))
+}
+
+// serveFeed renders the feed of the user the token was issued to.
+func (c *calendarController) serveFeed(w http.ResponseWriter, r *http.Request, token string) {
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			feed, err := c.calendarFeedService.RenderFeed(r.Context(), token)
+			if err != nil {
+				return nil, err
+			}
+			return &endpoint.StreamResponse{
+				ContentType: "text/calendar; charset=utf-8",
+				Size:        int64(len(feed)),
+				Body:        io.NopCloser(bytes.NewReader(feed)),
+			}, nil
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
@@ -0,0 +1,168 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCalendarController wires the controller with an authentication
+// middleware that rejects every request, so tests can tell which routes it
+// guards.
+func setupCalendarController(t *testing.T) (*mocks.MockCalendarFeedService, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockCalendarFeedService(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	denyAll := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+	}
+	return svc, controllers.NewCalendarController(svc, reqHandler, denyAll)
+}
+
+// ---------------------------------------------------------------------------
+// GET /{token}.ics
+// ---------------------------------------------------------------------------
+
+func TestCalendarController_GetFeed(t *testing.T) {
+	t.Run("success - serves the feed without a bearer token", func(t *testing.T) {
+		svc, handler := setupCalendarController(t)
+		feed := []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+		svc.EXPECT().RenderFeed(mock.Anything, defaultUserHex+"-abc123").Return(feed, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/"+defaultUserHex+"-abc123.ics", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, string(feed), rr.Body.String())
+	})
+
+	t.Run("error - invalid token", func(t *testing.T) {
+		svc, handler := setupCalendarController(t)
+		svc.EXPECT().
+			RenderFeed(mock.Anything, "bogus").
+			Return(nil, apperror.NewUnauthorizedError("Invalid calendar feed")).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/bogus.ics", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GET /subscriptions/calendar.ics
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionCalendarController_GetFeed(t *testing.T) {
+	t.Run("success - reads the token from the query", func(t *testing.T) {
+		svc := mocks.NewMockCalendarFeedService(t)
+		handler := controllers.NewSubscriptionCalendarController(svc, endpoint.NewRequestHandler(validator.New()))
+		feed := []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+		svc.EXPECT().RenderFeed(mock.Anything, defaultUserHex+"-abc123").Return(feed, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/?token="+defaultUserHex+"-abc123", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, string(feed), rr.Body.String())
+	})
+
+	t.Run("error - missing token", func(t *testing.T) {
+		svc := mocks.NewMockCalendarFeedService(t)
+		handler := controllers.NewSubscriptionCalendarController(svc, endpoint.NewRequestHandler(validator.New()))
+		svc.EXPECT().
+			RenderFeed(mock.Anything, "").
+			Return(nil, apperror.NewUnauthorizedError("Invalid calendar feed")).
+			Once()
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GET /feed
+// ---------------------------------------------------------------------------
+
+func TestCalendarController_GetFeedURL(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := mocks.NewMockCalendarFeedService(t)
+		passThrough := func(next http.Handler) http.Handler { return next }
+		handler := controllers.NewCalendarController(svc, endpoint.NewRequestHandler(validator.New()), passThrough)
+		want := &models.CalendarFeed{URL: "/api/v1/subscriptions/calendar.ics?token=" + defaultUserHex + "-abc123"}
+		svc.EXPECT().GetFeedURL(mock.Anything, defaultUserHex).Return(want, nil).Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/feed", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp models.CalendarFeed
+		require.NoError(t, decodeData(rr.Body, &resp))
+		assert.Equal(t, *want, resp)
+	})
+
+	t.Run("error - requires authentication", func(t *testing.T) {
+		_, handler := setupCalendarController(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/feed", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// POST /feed/regenerate
+// ---------------------------------------------------------------------------
+
+func TestCalendarController_RegenerateFeedURL(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := mocks.NewMockCalendarFeedService(t)
+		passThrough := func(next http.Handler) http.Handler { return next }
+		handler := controllers.NewCalendarController(svc, endpoint.NewRequestHandler(validator.New()), passThrough)
+		want := &models.CalendarFeed{URL: "/api/v1/subscriptions/calendar.ics?token=" + defaultUserHex + "-def456"}
+		svc.EXPECT().RegenerateFeedURL(mock.Anything, defaultUserHex).Return(want, nil).Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodPost, "/feed/regenerate", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp models.CalendarFeed
+		require.NoError(t, decodeData(rr.Body, &resp))
+		assert.Equal(t, *want, resp)
+	})
+
+	t.Run("error - requires authentication", func(t *testing.T) {
+		_, handler := setupCalendarController(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/feed/regenerate", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type cancelLinkController struct {
+	cancelLinkService services.CancelLinkServiceExternal
+	requestHandler    *endpoint.RequestHandler
+}
+
+// NewCancelLinkController serves the one-click cancel links sent with savings
+// suggestions. Its routes are authorized by the link signature rather than a
+// bearer token, so they must be mounted outside the authenticated group.
+func NewCancelLinkController(
+	cancelLinkService services.CancelLinkServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+) http.Handler {
+	c := &cancelLinkController{cancelLinkService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Get("/{subscriptionID}", c.cancelWithLink)
+	return r
+}
+
+// CancelLinkOperations documents the routes of NewCancelLinkController.
+var CancelLinkOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/{subscriptionID}", Summary: "Cancel a subscription with a signed link", Public: true, Query: signedURLParams, Response: models.SubscriptionResponse{}},
+}
+
+// signedURLParams are the query parameters of links authorized by their
+// signature.
+var signedURLParams = []openapi.Param{
+	{Name: "expires", Required: true, Description: "Unix time the link expires at"},
+	{Name: "signature", Required: true, Description: "HMAC signature of the link"},
+}
+
+func (c *cancelLinkController) cancelWithLink(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	query := r.URL.Query()
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.cancelLinkService.CancelWithLink(
+				r.Context(),
+				subscriptionID,
+				query.Get("expires"),
+				query.Get("signature"),
+			))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
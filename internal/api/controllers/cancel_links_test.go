@@ -0,0 +1,64 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCancelLinkController(t *testing.T) (*mocks.MockCancelLinkServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockCancelLinkServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	return svc, controllers.NewCancelLinkController(svc, reqHandler)
+}
+
+// ---------------------------------------------------------------------------
+// GET /{subscriptionID}
+// ---------------------------------------------------------------------------
+
+func TestCancelLinkController_CancelWithLink(t *testing.T) {
+	t.Run("success - passes query values, needs no token", func(t *testing.T) {
+		svc, handler := setupCancelLinkController(t)
+		canceled := validSub()
+		canceled.Status = models.Canceled
+		svc.EXPECT().
+			CancelWithLink(mock.Anything, defaultSubHex, "1739620800", "abc123").
+			Return(canceled, nil).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/"+defaultSubHex+"?expires=1739620800&signature=abc123", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp models.SubscriptionResponse
+		require.NoError(t, decodeData(rr.Body, &resp))
+		assert.Equal(t, string(models.Canceled), resp.Status)
+	})
+
+	t.Run("error - invalid signature", func(t *testing.T) {
+		svc, handler := setupCancelLinkController(t)
+		svc.EXPECT().
+			CancelWithLink(mock.Anything, defaultSubHex, "", "").
+			Return(nil, apperror.NewUnauthorizedError("Invalid cancel link")).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/"+defaultSubHex, nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
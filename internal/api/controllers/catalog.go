@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type catalogController struct {
+	catalogService services.CatalogServiceExternal
+	requestHandler *endpoint.RequestHandler
+}
+
+// NewCatalogController serves the catalog of well-known services.
+func NewCatalogController(
+	catalogService services.CatalogServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+) http.Handler {
+	c := &catalogController{catalogService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Get("/", c.searchCatalog)
+	r.Get("/{catalogID}", c.getCatalogEntry)
+	return r
+}
+
+// CatalogOperations documents the routes of NewCatalogController.
+var CatalogOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/", Summary: "Search the catalog of well-known services", Query: []openapi.Param{
+		{Name: "query", Description: "Name or the start of a name; empty lists the catalog"},
+		{Name: "limit", Type: "integer", Description: "Most entries to return, at most 50"},
+	}, Response: []models.CatalogEntryResponse{}},
+	{Method: http.MethodGet, Path: "/{catalogID}", Summary: "Get a catalog entry with its plans", Response: models.CatalogEntryResponse{}},
+}
+
+func (c *catalogController) searchCatalog(w http.ResponseWriter, r *http.Request) {
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			var limit int
+			if raw := r.URL.Query().Get("limit"); raw != "" {
+				var err error
+				if limit, err = strconv.Atoi(raw); err != nil || limit < 1 {
+					return nil, apperror.NewValidationError("limit must be a positive integer")
+				}
+			}
+			return endpoint.ToResponseSlice(c.catalogService.SearchCatalog(r.Context(), r.URL.Query().Get("query"), limit))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *catalogController) getCatalogEntry(w http.ResponseWriter, r *http.Request) {
+	catalogID := chi.URLParam(r, "catalogID")
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.catalogService.GetCatalogEntry(r.Context(), catalogID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
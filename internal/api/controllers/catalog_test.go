@@ -0,0 +1,114 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCatalogController(t *testing.T) (*mocks.MockCatalogServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockCatalogServiceExternal(t)
+	return svc, controllers.NewCatalogController(svc, endpoint.NewRequestHandler(validator.New()))
+}
+
+func validCatalogEntry() *models.CatalogEntry {
+	return &models.CatalogEntry{
+		ID:       "netflix",
+		Name:     "Netflix",
+		Category: models.Entertainment,
+		Website:  "https://www.netflix.com",
+		Plans: []models.CatalogPlan{
+			{Name: "Standard", Price: 1799, Currency: models.USD, Frequency: models.Monthly},
+		},
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /
+// ---------------------------------------------------------------------------
+
+func TestCatalogController_SearchCatalog(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		setupMocks func(svc *mocks.MockCatalogServiceExternal)
+		wantStatus int
+	}{
+		{
+			name:   "success - passes the query and limit",
+			target: "/?query=netf&limit=5",
+			setupMocks: func(svc *mocks.MockCatalogServiceExternal) {
+				svc.EXPECT().SearchCatalog(mock.Anything, "netf", 5).Return([]*models.CatalogEntry{validCatalogEntry()}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "success - no limit leaves it to the service",
+			target: "/",
+			setupMocks: func(svc *mocks.MockCatalogServiceExternal) {
+				svc.EXPECT().SearchCatalog(mock.Anything, "", 0).Return([]*models.CatalogEntry{validCatalogEntry()}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error - invalid limit",
+			target:     "/?limit=0",
+			setupMocks: func(*mocks.MockCatalogServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupCatalogController(t)
+			tt.setupMocks(svc)
+
+			req := injectUserID(httptest.NewRequest(http.MethodGet, tt.target, nil), defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp []models.CatalogEntryResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				require.Len(t, resp, 1)
+				assert.Equal(t, "netflix", resp[0].ID)
+				assert.Equal(t, "USD 17.99", resp[0].Plans[0].PriceFormatted)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /{catalogID}
+// ---------------------------------------------------------------------------
+
+func TestCatalogController_GetCatalogEntry(t *testing.T) {
+	svc, handler := setupCatalogController(t)
+	svc.EXPECT().GetCatalogEntry(mock.Anything, "netflix").Return(validCatalogEntry(), nil).Once()
+	svc.EXPECT().GetCatalogEntry(mock.Anything, "nope").Return(nil, apperror.NewNotFoundError("Catalog entry not found")).Once()
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, injectUserID(httptest.NewRequest(http.MethodGet, "/netflix", nil), defaultUserHex))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp models.CatalogEntryResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	assert.Equal(t, "Netflix", resp.Name)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, injectUserID(httptest.NewRequest(http.MethodGet, "/nope", nil), defaultUserHex))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
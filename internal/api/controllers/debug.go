@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/go-chi/chi/v5"
+)
+
+type runtimeStats struct {
+	GoVersion    string        `json:"goVersion"`
+	NumCPU       int           `json:"numCpu"`
+	GOMAXPROCS   int           `json:"gomaxprocs"`
+	Goroutines   int           `json:"goroutines"`
+	Uptime       string        `json:"uptime"`
+	HeapAlloc    uint64        `json:"heapAllocBytes"`
+	HeapInuse    uint64        `json:"heapInuseBytes"`
+	HeapObjects  uint64        `json:"heapObjects"`
+	Sys          uint64        `json:"sysBytes"`
+	NumGC        uint32        `json:"numGc"`
+	LastGCPause  time.Duration `json:"lastGcPauseNs"`
+	TotalGCPause time.Duration `json:"totalGcPauseNs"`
+}
+
+type debugController struct {
+	startedAt time.Time
+}
+
+// NewDebugController exposes pprof profiles, expvar variables, and a runtime
+// stats summary. It must only be served on an internal listener.
+func NewDebugController(startedAt time.Time) http.Handler {
+	c := &debugController{
+		startedAt: startedAt,
+	}
+
+	r := chi.NewRouter()
+	r.Get("/debug/runtime", c.runtimeStats)
+	r.Handle("/debug/vars", expvar.Handler())
+
+	r.HandleFunc("/debug/pprof/", pprof.Index)
+	r.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	r.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	r.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	r.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	// Named profiles (heap, goroutine, allocs, block, mutex, threadcreate).
+	r.HandleFunc("/debug/pprof/{profile}", pprof.Index)
+	return r
+}
+
+func (c *debugController) runtimeStats(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := runtimeStats{
+		GoVersion:    runtime.Version(),
+		NumCPU:       runtime.NumCPU(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		Goroutines:   runtime.NumGoroutine(),
+		Uptime:       time.Since(c.startedAt).Round(time.Second).String(),
+		HeapAlloc:    mem.HeapAlloc,
+		HeapInuse:    mem.HeapInuse,
+		HeapObjects:  mem.HeapObjects,
+		Sys:          mem.Sys,
+		NumGC:        mem.NumGC,
+		TotalGCPause: time.Duration(mem.PauseTotalNs),
+	}
+	if mem.NumGC > 0 {
+		stats.LastGCPause = time.Duration(mem.PauseNs[(mem.NumGC+255)%256])
+	}
+	endpoint.WriteAPIResponse(w, http.StatusOK, stats)
+}
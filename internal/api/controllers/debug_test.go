@@ -0,0 +1,46 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugController(t *testing.T) {
+	handler := controllers.NewDebugController(time.Now())
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "pprof index", path: "/debug/pprof/", wantStatus: http.StatusOK},
+		{name: "named profile", path: "/debug/pprof/goroutine?debug=1", wantStatus: http.StatusOK},
+		{name: "unknown profile", path: "/debug/pprof/nope", wantStatus: http.StatusNotFound},
+		{name: "expvar", path: "/debug/vars", wantStatus: http.StatusOK},
+		{name: "not mounted", path: "/api/v1/users", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+
+	t.Run("runtime stats", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/debug/runtime", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var body map[string]any
+		require.NoError(t, decodeData(rr.Body, &body))
+		assert.Greater(t, body["goroutines"], float64(0))
+		assert.Greater(t, body["heapAllocBytes"], float64(0))
+	})
+}
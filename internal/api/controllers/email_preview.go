@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type emailPreviewController struct {
+	emailPreviewService services.EmailPreviewService
+	requestHandler      *endpoint.RequestHandler
+}
+
+// NewEmailPreviewController serves previews of the emails sent to users. It
+// is meant for administrators. Test emails go to the calling admin, so
+// POST /test is served only when sendTests says callers are signed-in users.
+func NewEmailPreviewController(
+	emailPreviewService services.EmailPreviewService,
+	requestHandler *endpoint.RequestHandler,
+	sendTests bool,
+) http.Handler {
+	c := &emailPreviewController{emailPreviewService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Get("/templates", c.getTemplates)
+	r.Post("/preview", c.previewEmail)
+	if sendTests {
+		r.Post("/test", c.sendTestEmail)
+	}
+	return r
+}
+
+// EmailPreviewOperations documents the routes of NewEmailPreviewController.
+var EmailPreviewOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/templates", Summary: "List the email templates", Response: []models.EmailTemplate{}},
+	{Method: http.MethodPost, Path: "/preview", Summary: "Render an email with sample or supplied data", Request: models.EmailPreviewRequest{}, Response: models.EmailPreview{}},
+	{Method: http.MethodPost, Path: "/test", Summary: "Send a rendered email to the calling admin", Request: models.EmailPreviewRequest{}, Response: models.EmailPreview{}},
+}
+
+func (c *emailPreviewController) getTemplates(w http.ResponseWriter, r *http.Request) {
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return c.emailPreviewService.GetEmailTemplates(r.Context()), nil
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *emailPreviewController) previewEmail(w http.ResponseWriter, r *http.Request) {
+	request := models.EmailPreviewRequest{}
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &request,
+		EndpointLogic: func() (any, error) {
+			return c.emailPreviewService.PreviewEmail(r.Context(), &request)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *emailPreviewController) sendTestEmail(w http.ResponseWriter, r *http.Request) {
+	request := models.EmailPreviewRequest{}
+	userID, ok := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &request,
+		EndpointLogic: func() (any, error) {
+			if !ok || userID == "" {
+				return nil, apperror.NewUnauthorizedError("Test emails are sent to the signed-in admin")
+			}
+			return c.emailPreviewService.SendTestEmail(r.Context(), &request, userID)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
@@ -0,0 +1,156 @@
+package controllers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupEmailPreviewController(t *testing.T) (*mocks.MockEmailPreviewService, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockEmailPreviewService(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	return svc, controllers.NewEmailPreviewController(svc, reqHandler, true)
+}
+
+// ---------------------------------------------------------------------------
+// GET /templates
+// ---------------------------------------------------------------------------
+
+func TestEmailPreviewController_GetTemplates(t *testing.T) {
+	svc, handler := setupEmailPreviewController(t)
+	svc.EXPECT().
+		GetEmailTemplates(mock.Anything).
+		Return([]models.EmailTemplate{{Name: "reminder", Description: "Upcoming renewal"}}).
+		Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/templates", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp []models.EmailTemplate
+	require.NoError(t, decodeData(rr.Body, &resp))
+	require.Len(t, resp, 1)
+	assert.Equal(t, "reminder", resp[0].Name)
+}
+
+// ---------------------------------------------------------------------------
+// POST /preview
+// ---------------------------------------------------------------------------
+
+func TestEmailPreviewController_PreviewEmail(t *testing.T) {
+	daysBefore := 0
+	tests := []struct {
+		name       string
+		body       string
+		setupMocks func(svc *mocks.MockEmailPreviewService)
+		wantStatus int
+	}{
+		{
+			name: "success - passes the supplied data",
+			body: `{"template": "reminder", "locale": "de", "data": {"subscriptionName": "Disney+", "daysBefore": 0}}`,
+			setupMocks: func(svc *mocks.MockEmailPreviewService) {
+				svc.EXPECT().
+					PreviewEmail(mock.Anything, &models.EmailPreviewRequest{
+						Template: "reminder",
+						Locale:   "de",
+						Data:     models.EmailPreviewData{SubscriptionName: "Disney+", DaysBefore: &daysBefore},
+					}).
+					Return(&models.EmailPreview{Template: "reminder", Subject: "Disney+", HTML: "<html></html>"}, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error - missing template",
+			body:       `{"data": {}}`,
+			setupMocks: func(svc *mocks.MockEmailPreviewService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - unknown template",
+			body: `{"template": "welcome"}`,
+			setupMocks: func(svc *mocks.MockEmailPreviewService) {
+				svc.EXPECT().
+					PreviewEmail(mock.Anything, mock.Anything).
+					Return(nil, apperror.NewValidationError("unknown email template %s", "welcome")).
+					Once()
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupEmailPreviewController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodPost, "/preview", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// POST /test
+// ---------------------------------------------------------------------------
+
+func TestEmailPreviewController_SendTestEmail(t *testing.T) {
+	svc, handler := setupEmailPreviewController(t)
+	svc.EXPECT().
+		SendTestEmail(mock.Anything, &models.EmailPreviewRequest{Template: "dunning"}, defaultUserHex).
+		Return(&models.EmailPreview{Template: "dunning", Subject: "Payment failed"}, nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewBufferString(`{"template": "dunning"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp models.EmailPreview
+	require.NoError(t, decodeData(rr.Body, &resp))
+	assert.Equal(t, "Payment failed", resp.Subject)
+}
+
+func TestEmailPreviewController_SendTestEmail_WithoutUser(t *testing.T) {
+	_, handler := setupEmailPreviewController(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewBufferString(`{"template": "dunning"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestEmailPreviewController_SendTestEmail_NotServed(t *testing.T) {
+	svc := mocks.NewMockEmailPreviewService(t)
+	handler := controllers.NewEmailPreviewController(svc, endpoint.NewRequestHandler(validator.New()), false)
+
+	req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewBufferString(`{"template": "dunning"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// eventStreamRetry is how long clients wait before reconnecting to a closed
+// stream.
+const eventStreamRetry = 3 * time.Second
+
+type eventStreamController struct {
+	liveEventService services.LiveEventServiceExternal
+	config           services.LiveEventConfig
+	requestHandler   *endpoint.RequestHandler
+}
+
+// NewEventStreamController streams the live events of the authenticated user
+// as Server-Sent Events. Streams outlive the request timeout, so the router
+// must mount it outside the timeout middleware; config.MaxDuration bounds
+// them instead.
+func NewEventStreamController(
+	liveEventService services.LiveEventServiceExternal,
+	config services.LiveEventConfig,
+	requestHandler *endpoint.RequestHandler,
+) http.Handler {
+	c := &eventStreamController{
+		liveEventService,
+		config,
+		requestHandler,
+	}
+
+	r := chi.NewRouter()
+	r.Get("/stream", c.stream)
+
+	return r
+}
+
+// EventStreamOperations documents the routes of NewEventStreamController.
+var EventStreamOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/stream", Summary: "Stream renewals, reminders, and bill changes as Server-Sent Events", ContentType: "text/event-stream"},
+}
+
+func (c *eventStreamController) stream(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+	ctx, cancel := context.WithTimeout(r.Context(), c.config.MaxDuration)
+	defer cancel()
+
+	events, err := c.liveEventService.StreamLiveEvents(ctx, userID)
+	if err != nil {
+		c.requestHandler.ServeRequest(endpoint.InternalRequest{
+			W: w,
+			R: r,
+			EndpointLogic: func() (any, error) {
+				return nil, err
+			},
+			SuccessCode: http.StatusOK,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Keep nginx from buffering the stream.
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	write := func(format string, args ...any) bool {
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+	if !write("retry: %d\n\n", eventStreamRetry.Milliseconds()) {
+		return
+	}
+
+	heartbeat := time.NewTicker(c.config.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "Failed to encode live event",
+					logattr.EventID(event.ID),
+					logattr.Error(err),
+				)
+				continue
+			}
+			if !write("id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data) {
+				return
+			}
+		case <-heartbeat.C:
+			if !write(": keep-alive\n\n") {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupEventStreamController(t *testing.T) (*mocks.MockLiveEventServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockLiveEventServiceExternal(t)
+	config := services.LiveEventConfig{Heartbeat: time.Hour, MaxDuration: time.Hour}
+	return svc, controllers.NewEventStreamController(svc, config, endpoint.NewRequestHandler(validator.New()))
+}
+
+// ---------------------------------------------------------------------------
+// GET /stream
+// ---------------------------------------------------------------------------
+
+func TestEventStreamController_Stream(t *testing.T) {
+	svc, handler := setupEventStreamController(t)
+	events := make(chan *models.LiveEvent, 2)
+	events <- &models.LiveEvent{
+		ID:     "evt-1",
+		Type:   string(models.SubscriptionRenewedEvent),
+		UserID: defaultUserHex,
+		Data:   json.RawMessage(`{"name":"Netflix"}`),
+	}
+	events <- &models.LiveEvent{ID: "evt-2", Type: models.ReminderSentLiveEvent, UserID: defaultUserHex}
+	close(events)
+	svc.EXPECT().StreamLiveEvents(mock.Anything, defaultUserHex).Return(events, nil).Once()
+
+	req := injectUserID(httptest.NewRequest(http.MethodGet, "/stream", nil), defaultUserHex)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "retry: 3000\n\n"+
+		"id: evt-1\nevent: subscription.renewed\n"+
+		`data: {"id":"evt-1","type":"subscription.renewed","userId":"`+defaultUserHex+`","occurredAt":"0001-01-01T00:00:00Z","data":{"name":"Netflix"}}`+"\n\n"+
+		"id: evt-2\nevent: reminder.sent\n"+
+		`data: {"id":"evt-2","type":"reminder.sent","userId":"`+defaultUserHex+`","occurredAt":"0001-01-01T00:00:00Z","data":null}`+"\n\n",
+		rr.Body.String())
+	assert.True(t, rr.Flushed)
+}
+
+func TestEventStreamController_Stream_Error(t *testing.T) {
+	svc, handler := setupEventStreamController(t)
+	svc.EXPECT().StreamLiveEvents(mock.Anything, "").Return(nil, apperror.NewUnauthorizedError("Invalid user ID")).Once()
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+}
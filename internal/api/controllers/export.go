@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+)
+
+var (
+	exportFormatParam = openapi.Param{Name: "format", Description: "csv (default) or xlsx"}
+
+	subscriptionExportParams = []openapi.Param{
+		exportFormatParam,
+		{Name: "status", Description: "Filter by status"},
+		{Name: "category", Description: "Filter by category"},
+		{Name: "frequency", Description: "Filter by billing frequency"},
+		{Name: "tag", Description: "Filter by tag"},
+		{Name: "sort", Description: "Field to sort by"},
+		{Name: "order", Description: "asc (default) or desc"},
+	}
+	billExportParams = []openapi.Param{
+		exportFormatParam,
+		{Name: "subscriptionId", Description: "Only bills of this subscription"},
+		{Name: "status", Description: "Filter by payment status"},
+		{Name: "from", Description: "Only bills starting at or after this RFC 3339 time"},
+		{Name: "to", Description: "Only bills starting before this RFC 3339 time"},
+	}
+)
+
+// serveExport serves the export of the caller's records that request
+// selects. Exports within the configured size are streamed as an attachment;
+// larger ones are queued, answered with 202 Accepted, and emailed.
+func serveExport(
+	w http.ResponseWriter,
+	r *http.Request,
+	requestHandler *endpoint.RequestHandler,
+	exportService services.ExportServiceExternal,
+	request func() (models.ExportRequest, error),
+) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			exportRequest, err := request()
+			if err != nil {
+				return nil, err
+			}
+			exportRequest.Format = models.ExportFormat(r.URL.Query().Get("format"))
+			if exportRequest.Format == "" {
+				exportRequest.Format = models.CSVFormat
+			}
+
+			export, err := exportService.Export(r.Context(), userID, exportRequest)
+			if err != nil {
+				return nil, err
+			}
+			if export.Body == nil {
+				return &endpoint.StatusResponse{Status: http.StatusAccepted, Body: export.ToResponse()}, nil
+			}
+			return &endpoint.StreamResponse{
+				ContentType: export.ContentType,
+				Filename:    export.Filename,
+				Body:        export.Body,
+			}, nil
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// subscriptionExportRequest reads the filters and sort of a subscription
+// export from the same query parameters as a subscription listing.
+func subscriptionExportRequest(r *http.Request) (models.ExportRequest, error) {
+	query, err := subscriptionQuery(r)
+	if err != nil {
+		return models.ExportRequest{}, err
+	}
+	return models.ExportRequest{
+		Kind:       models.SubscriptionExport,
+		Status:     query.Status,
+		Category:   query.Category,
+		Frequency:  query.Frequency,
+		Tag:        query.Tag,
+		Sort:       query.Sort,
+		Descending: query.Descending,
+	}, nil
+}
+
+// billExportRequest reads the filters of a bill export from the
+// subscriptionId, status, from, and to query parameters.
+func billExportRequest(r *http.Request) (models.ExportRequest, error) {
+	params := r.URL.Query()
+	request := models.ExportRequest{
+		Kind:       models.BillExport,
+		BillStatus: models.PaymentStatus(params.Get("status")),
+	}
+
+	subscriptionID, err := objectIDParam(params.Get("subscriptionId"), "subscriptionId")
+	if err != nil {
+		return request, err
+	}
+	if subscriptionID != nil {
+		request.SubscriptionID = *subscriptionID
+	}
+	if request.From, err = timeParam(params.Get("from"), "from"); err != nil {
+		return request, err
+	}
+	if request.To, err = timeParam(params.Get("to"), "to"); err != nil {
+		return request, err
+	}
+	return request, nil
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type fileController struct {
+	fileService    services.FileServiceExternal
+	requestHandler *endpoint.RequestHandler
+}
+
+// NewFileController serves stored files. The download route is authorized by
+// its URL signature rather than a bearer token, so only the metadata routes
+// sit behind the authentication middleware.
+func NewFileController(
+	fileService services.FileServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+	authentication func(http.Handler) http.Handler,
+) http.Handler {
+	c := &fileController{
+		fileService,
+		requestHandler,
+	}
+
+	r := chi.NewRouter()
+	r.Get("/{fileID}/download", c.downloadFile)
+
+	r.Group(func(r chi.Router) {
+		r.Use(authentication)
+		r.Get("/{fileID}", c.getFileByID)
+		r.Delete("/{fileID}", c.deleteFile)
+	})
+
+	return r
+}
+
+// FileOperations documents the routes of NewFileController.
+var FileOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/{fileID}/download", Summary: "Download a file with a signed URL", Public: true, Query: signedURLParams, ContentType: "application/octet-stream"},
+	{Method: http.MethodGet, Path: "/{fileID}", Summary: "Get file metadata and a signed download URL", Response: models.FileResponse{}},
+	{Method: http.MethodDelete, Path: "/{fileID}", Summary: "Delete a file", Status: http.StatusNoContent},
+}
+
+func (c *fileController) getFileByID(w http.ResponseWriter, r *http.Request) {
+	fileID := chi.URLParam(r, "fileID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.fileService.GetFileByID(r.Context(), fileID, userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *fileController) deleteFile(w http.ResponseWriter, r *http.Request) {
+	fileID := chi.URLParam(r, "fileID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return nil, c.fileService.DeleteFile(r.Context(), fileID, userID)
+		},
+		SuccessCode: http.StatusNoContent,
+	})
+}
+
+func (c *fileController) downloadFile(w http.ResponseWriter, r *http.Request) {
+	fileID := chi.URLParam(r, "fileID")
+	query := r.URL.Query()
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			file, content, err := c.fileService.OpenSignedFile(r.Context(), fileID, query.Get("expires"), query.Get("signature"))
+			if err != nil {
+				return nil, err
+			}
+			return &endpoint.StreamResponse{
+				ContentType: file.Metadata.ContentType,
+				Filename:    file.Filename,
+				Size:        file.Size,
+				Body:        content,
+			}, nil
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
@@ -0,0 +1,149 @@
+package controllers_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ---------------------------------------------------------------------------
+// Setup Helpers
+// ---------------------------------------------------------------------------
+
+var defaultFileID = bson.NewObjectID()
+var defaultFileHex = defaultFileID.Hex()
+
+func validFile() *models.StoredFile {
+	return &models.StoredFile{
+		ID:         defaultFileID,
+		Filename:   "invoice.pdf",
+		Size:       5,
+		UploadedAt: mockTime,
+		Metadata: models.FileMetadata{
+			Key:         "invoice/123",
+			OwnerID:     defaultUserID,
+			ContentType: "application/pdf",
+		},
+	}
+}
+
+// setupFileController wires the controller with an authentication middleware
+// that rejects every request, so tests can tell which routes it guards.
+func setupFileController(t *testing.T) (*mocks.MockFileServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockFileServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	denyAll := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+	}
+	router := controllers.NewFileController(svc, reqHandler, denyAll)
+	return svc, router
+}
+
+// ---------------------------------------------------------------------------
+// GET /{fileID}/download
+// ---------------------------------------------------------------------------
+
+func TestFileController_DownloadFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func(svc *mocks.MockFileServiceExternal)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success - streams content without authentication",
+			setupMocks: func(svc *mocks.MockFileServiceExternal) {
+				svc.EXPECT().
+					OpenSignedFile(mock.Anything, defaultFileHex, "123", "abc").
+					Return(validFile(), io.NopCloser(strings.NewReader("%PDF-")), nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "%PDF-",
+		},
+		{
+			name: "error - propagates invalid signature",
+			setupMocks: func(svc *mocks.MockFileServiceExternal) {
+				svc.EXPECT().
+					OpenSignedFile(mock.Anything, defaultFileHex, "123", "abc").
+					Return(nil, nil, apperror.NewUnauthorizedError("Invalid download link")).
+					Once()
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupFileController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodGet, "/"+defaultFileHex+"/download?expires=123&signature=abc", nil)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+				assert.Equal(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /{fileID}
+// ---------------------------------------------------------------------------
+
+func TestFileController_GetFileByID(t *testing.T) {
+	t.Run("error - requires authentication", func(t *testing.T) {
+		_, handler := setupFileController(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/"+defaultFileHex, nil)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("success - returns metadata and signed URL", func(t *testing.T) {
+		svc := mocks.NewMockFileServiceExternal(t)
+		passThrough := func(next http.Handler) http.Handler { return next }
+		handler := controllers.NewFileController(svc, endpoint.NewRequestHandler(validator.New()), passThrough)
+
+		signed := &models.SignedFile{File: validFile(), URL: "/download", ExpiresAt: mockTime}
+		svc.EXPECT().
+			GetFileByID(mock.Anything, defaultFileHex, defaultUserHex).
+			Return(signed, nil).
+			Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/"+defaultFileHex, nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp *models.FileResponse
+		require.NoError(t, decodeData(rr.Body, &resp))
+		assert.Equal(t, signed.ToResponse(), resp)
+	})
+}
@@ -14,14 +14,19 @@ import (
 )
 
 type healthController struct {
-	db    *adapters.Database
-	redis *adapters.Redis
+	dbMonitor *adapters.DatabaseMonitor
+	redis     *adapters.Redis
+	drain     *adapters.Drain
 }
 
-func NewHealthController(db *adapters.Database, redis *adapters.Redis) http.Handler {
+// NewHealthController exposes liveness and readiness probes. Database
+// readiness is taken from the background monitor rather than pinged per probe.
+// Readiness also fails while the instance is draining.
+func NewHealthController(dbMonitor *adapters.DatabaseMonitor, redis *adapters.Redis, drain *adapters.Drain) http.Handler {
 	c := &healthController{
-		db:    db,
-		redis: redis,
+		dbMonitor: dbMonitor,
+		redis:     redis,
+		drain:     drain,
 	}
 
 	r := chi.NewRouter()
@@ -31,7 +36,7 @@ func NewHealthController(db *adapters.Database, redis *adapters.Redis) http.Hand
 }
 
 func (c *healthController) healthz(w http.ResponseWriter, r *http.Request) {
-	endpoint.WriteAPIResponse(w, http.StatusOK, map[string]string{"status": "ok"})
+	endpoint.WriteJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
 func (c *healthController) readyz(w http.ResponseWriter, r *http.Request) {
@@ -39,16 +44,23 @@ func (c *healthController) readyz(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	podName := lib.Hostname()
-	if err := c.db.Ping(ctx); err != nil {
+	if c.drain.Draining() {
+		endpoint.WriteJSON(
+			w,
+			http.StatusServiceUnavailable,
+			map[string]string{"status": "unavailable", "reason": "draining"},
+		)
+		return
+	}
+	if !c.dbMonitor.Healthy() {
 		slog.Error("Database readiness probe failed",
 			logattr.PodName(podName),
-			logattr.Error(err),
 		)
 
-		endpoint.WriteAPIResponse(
+		endpoint.WriteJSON(
 			w,
 			http.StatusServiceUnavailable,
-			map[string]string{"status": "unavailable", "reason": "db_ping_failed"},
+			map[string]string{"status": "unavailable", "reason": "db_unhealthy"},
 		)
 		return
 	}
@@ -58,7 +70,7 @@ func (c *healthController) readyz(w http.ResponseWriter, r *http.Request) {
 			logattr.Error(err),
 		)
 
-		endpoint.WriteAPIResponse(
+		endpoint.WriteJSON(
 			w,
 			http.StatusServiceUnavailable,
 			map[string]string{"status": "unavailable", "reason": "redis_ping_failed"},
@@ -66,5 +78,5 @@ func (c *healthController) readyz(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	endpoint.WriteAPIResponse(w, http.StatusOK, map[string]string{"status": "ready"})
+	endpoint.WriteJSON(w, http.StatusOK, map[string]string{"status": "ready"})
 }
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// jwksMaxAge lets validators cache the key set briefly; a key is published
+// well before it signs, so a cached set still finds it.
+const jwksMaxAge = "public, max-age=300"
+
+type jwksController struct {
+	jwtService services.JWTService
+}
+
+// NewJWKSController publishes the public keys tokens are signed with, so
+// other services can validate tokens without sharing a secret. It is
+// mounted at /.well-known.
+func NewJWKSController(jwtService services.JWTService) http.Handler {
+	c := &jwksController{
+		jwtService: jwtService,
+	}
+
+	r := chi.NewRouter()
+	r.Get("/jwks.json", c.getJWKS)
+	return r
+}
+
+// JWKSOperations documents the routes of NewJWKSController.
+var JWKSOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/jwks.json", Summary: "List the public keys tokens are signed with", Response: models.JWKS{}, Raw: true},
+}
+
+func (c *jwksController) getJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", jwksMaxAge)
+	endpoint.WriteJSON(w, http.StatusOK, c.jwtService.JWKS())
+}
@@ -0,0 +1,43 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// GET /jwks.json
+// ---------------------------------------------------------------------------
+
+func TestJWKSController_GetJWKS(t *testing.T) {
+	jwtService := mocks.NewMockJWTService(t)
+	jwtService.EXPECT().JWKS().Return(models.JWKS{Keys: []models.JWK{{
+		KeyType:   "OKP",
+		KeyID:     "2025-06",
+		Use:       "sig",
+		Algorithm: "EdDSA",
+		Curve:     "Ed25519",
+		X:         "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+	}}}).Once()
+
+	rr := httptest.NewRecorder()
+	controllers.NewJWKSController(jwtService).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/jwks.json", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "public, max-age=300", rr.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"keys":[{
+		"kty": "OKP",
+		"kid": "2025-06",
+		"use": "sig",
+		"alg": "EdDSA",
+		"crv": "Ed25519",
+		"x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"
+	}]}`, rr.Body.String())
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/buildinfo"
+	"github.com/go-chi/chi/v5"
+)
+
+// Meta describes the running instance. It is assembled once at startup.
+type Meta struct {
+	Build     buildinfo.Info  `json:"build"`
+	Features  map[string]bool `json:"features"`
+	Config    map[string]any  `json:"config"` // Effective configuration with secrets masked.
+	StartedAt time.Time       `json:"startedAt"`
+}
+
+type metaResponse struct {
+	Meta
+	Uptime string `json:"uptime"`
+}
+
+type metaController struct {
+	meta Meta
+}
+
+// NewMetaController exposes build information, enabled features, and the
+// sanitized configuration of this instance for debugging deployments.
+func NewMetaController(meta Meta) http.Handler {
+	c := &metaController{
+		meta: meta,
+	}
+
+	r := chi.NewRouter()
+	r.Get("/", c.getMeta)
+	return r
+}
+
+// MetaOperations documents the routes of NewMetaController.
+var MetaOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/", Summary: "Describe the running instance", Response: metaResponse{}},
+}
+
+func (c *metaController) getMeta(w http.ResponseWriter, r *http.Request) {
+	endpoint.WriteAPIResponse(w, http.StatusOK, metaResponse{
+		Meta:   c.meta,
+		Uptime: time.Since(c.meta.StartedAt).Round(time.Second).String(),
+	})
+}
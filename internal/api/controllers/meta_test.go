@@ -0,0 +1,46 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/core/buildinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// GET /
+// ---------------------------------------------------------------------------
+
+func TestMetaController_GetMeta(t *testing.T) {
+	meta := controllers.Meta{
+		Build:     buildinfo.Info{Version: "v1.2.0", Commit: "abc123"},
+		Features:  map[string]bool{"otel": true, "queue_worker": false},
+		Config:    map[string]any{"jwt": map[string]any{"access_secret": "********"}},
+		StartedAt: time.Now().Add(-time.Minute),
+	}
+	handler := controllers.NewMetaController(meta)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var body struct {
+		Build    buildinfo.Info  `json:"build"`
+		Features map[string]bool `json:"features"`
+		Config   map[string]any  `json:"config"`
+		Uptime   string          `json:"uptime"`
+	}
+	require.NoError(t, decodeData(rr.Body, &body))
+	assert.Equal(t, meta.Build, body.Build)
+	assert.Equal(t, meta.Features, body.Features)
+	assert.Equal(t, meta.Config, body.Config)
+	assert.Equal(t, "1m0s", body.Uptime)
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type notificationPreferenceController struct {
+	preferenceService services.NotificationPreferenceServiceExternal
+	requestHandler    *endpoint.RequestHandler
+}
+
+// NewNotificationPreferenceController serves the notification preferences of
+// one user. It is mounted under a route with a {userID} parameter.
+func NewNotificationPreferenceController(
+	preferenceService services.NotificationPreferenceServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+) http.Handler {
+	c := &notificationPreferenceController{preferenceService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Get("/", c.getPreferences)
+	r.Put("/", c.updatePreferences)
+	return r
+}
+
+// NotificationPreferenceOperations documents the routes of
+// NewNotificationPreferenceController.
+var NotificationPreferenceOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/", Summary: "Get notification preferences", Response: models.NotificationPreferencesResponse{}},
+	{Method: http.MethodPut, Path: "/", Summary: "Replace notification preferences", Request: models.NotificationPreferencesRequest{}, Response: models.NotificationPreferencesResponse{}},
+}
+
+func (c *notificationPreferenceController) getPreferences(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "userID")
+	claimedUserID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.preferenceService.GetPreferences(r.Context(), id, claimedUserID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *notificationPreferenceController) updatePreferences(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "userID")
+	prefs := models.NotificationPreferencesRequest{}
+	claimedUserID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &prefs,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.preferenceService.UpdatePreferences(r.Context(), id, prefs.ToModel(), claimedUserID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
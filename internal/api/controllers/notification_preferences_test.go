@@ -0,0 +1,145 @@
+package controllers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupNotificationPreferenceController mounts the preference router beside
+// the user router the way the server does.
+func setupNotificationPreferenceController(t *testing.T) (*mocks.MockNotificationPreferenceServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockNotificationPreferenceServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+
+	r := chi.NewRouter()
+	r.Mount("/users", controllers.NewUserController(mocks.NewMockUserServiceExternal(t), mocks.NewMockExportServiceExternal(t), reqHandler, allowAll))
+	r.Mount("/users/{userID}/preferences", controllers.NewNotificationPreferenceController(svc, reqHandler))
+	return svc, r
+}
+
+// ---------------------------------------------------------------------------
+// GET /users/{userID}/preferences
+// ---------------------------------------------------------------------------
+
+func TestNotificationPreferenceController_GetPreferences(t *testing.T) {
+	svc, handler := setupNotificationPreferenceController(t)
+	svc.EXPECT().
+		GetPreferences(mock.Anything, defaultUserHex, defaultUserHex).
+		Return(models.DefaultNotificationPreferences(defaultUserID), nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/users/"+defaultUserHex+"/preferences", nil)
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp models.NotificationPreferencesResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	assert.Equal(t, []models.NotificationChannel{models.ChannelEmail}, resp.Channels)
+	assert.Nil(t, resp.ReminderDays)
+	assert.Nil(t, resp.UpdatedAt)
+}
+
+// ---------------------------------------------------------------------------
+// PUT /users/{userID}/preferences
+// ---------------------------------------------------------------------------
+
+func TestNotificationPreferenceController_UpdatePreferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setupMocks func(svc *mocks.MockNotificationPreferenceServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: `{"channels":["email"],"reminderDays":[2,5],"quietHours":{"start":"22:00","end":"07:00","timezone":"Europe/Berlin"},"locale":"de-DE"}`,
+			setupMocks: func(svc *mocks.MockNotificationPreferenceServiceExternal) {
+				want := &models.NotificationPreferences{
+					Channels:     []models.NotificationChannel{models.ChannelEmail},
+					ReminderDays: []int{2, 5},
+					QuietHours:   &models.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Berlin"},
+					Locale:       "de-DE",
+				}
+				svc.EXPECT().
+					UpdatePreferences(mock.Anything, defaultUserHex, want, defaultUserHex).
+					Return(want, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "success - empty channels opt out",
+			body: `{"channels":[]}`,
+			setupMocks: func(svc *mocks.MockNotificationPreferenceServiceExternal) {
+				svc.EXPECT().
+					UpdatePreferences(mock.Anything, defaultUserHex, &models.NotificationPreferences{Channels: []models.NotificationChannel{}}, defaultUserHex).
+					Return(&models.NotificationPreferences{Channels: []models.NotificationChannel{}}, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error - missing channels",
+			body:       `{"locale":"en"}`,
+			setupMocks: func(svc *mocks.MockNotificationPreferenceServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - malformed quiet hours",
+			body:       `{"channels":["email"],"quietHours":{"start":"10pm","end":"07:00","timezone":"UTC"}}`,
+			setupMocks: func(svc *mocks.MockNotificationPreferenceServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - invalid locale",
+			body:       `{"channels":["email"],"locale":"not a locale"}`,
+			setupMocks: func(svc *mocks.MockNotificationPreferenceServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - propagates forbidden",
+			body: `{"channels":["email"]}`,
+			setupMocks: func(svc *mocks.MockNotificationPreferenceServiceExternal) {
+				svc.EXPECT().
+					UpdatePreferences(mock.Anything, defaultUserHex, mock.Anything, defaultUserHex).
+					Return(nil, apperror.NewForbiddenError("You can only update your own preferences")).
+					Once()
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupNotificationPreferenceController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodPut, "/users/"+defaultUserHex+"/preferences", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type notificationController struct {
+	notificationService services.NotificationServiceExternal
+	requestHandler      *endpoint.RequestHandler
+}
+
+// NewNotificationController serves the notification history of one user. It
+// is mounted under a route with a {userID} parameter.
+func NewNotificationController(
+	notificationService services.NotificationServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+) http.Handler {
+	c := &notificationController{notificationService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Get("/", c.getNotifications)
+	return r
+}
+
+// NotificationOperations documents the routes of NewNotificationController.
+var NotificationOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/", Summary: "List the notifications sent to a user, newest first", Query: []openapi.Param{limitParam, offsetParam}, Response: []models.NotificationResponse{}},
+}
+
+// getNotifications returns a page of notifications, newest first, with the
+// total in meta.pagination.
+func (c *notificationController) getNotifications(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "userID")
+	claimedUserID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			limit, offset, err := pageParams(r)
+			if err != nil {
+				return nil, err
+			}
+			page, err := c.notificationService.GetNotifications(
+				r.Context(),
+				id,
+				claimedUserID,
+				models.NotificationQuery{PageQuery: models.PageQuery{Limit: limit, Offset: offset}},
+			)
+			if err != nil {
+				return nil, err
+			}
+			return pageResponse(page.Items, page.Total, limit, offset)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
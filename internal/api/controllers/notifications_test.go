@@ -0,0 +1,112 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// setupNotificationController mounts the notification router beside the user
+// router the way the server does.
+func setupNotificationController(t *testing.T) (*mocks.MockNotificationServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockNotificationServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+
+	r := chi.NewRouter()
+	r.Mount("/users", controllers.NewUserController(mocks.NewMockUserServiceExternal(t), mocks.NewMockExportServiceExternal(t), reqHandler, allowAll))
+	r.Mount("/users/{userID}/notifications", controllers.NewNotificationController(svc, reqHandler))
+	return svc, r
+}
+
+// ---------------------------------------------------------------------------
+// GET /users/{userID}/notifications
+// ---------------------------------------------------------------------------
+
+func TestNotificationController_GetNotifications(t *testing.T) {
+	failed := &models.Notification{
+		ID:        bson.NewObjectID(),
+		UserID:    defaultUserID,
+		Channel:   models.ChannelEmail,
+		Recipient: "test@example.com",
+		Template:  "reminder",
+		TaskID:    "reminder:abc",
+		Status:    models.NotificationFailed,
+		Error:     "dial tcp: connection refused",
+		SentAt:    mockTime,
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMocks func(svc *mocks.MockNotificationServiceExternal)
+		wantStatus int
+		wantTotal  string
+	}{
+		{
+			name:  "success - passes the page and sets the total",
+			query: "?limit=10&offset=20",
+			setupMocks: func(svc *mocks.MockNotificationServiceExternal) {
+				svc.EXPECT().
+					GetNotifications(mock.Anything, defaultUserHex, defaultUserHex, models.NotificationQuery{PageQuery: models.PageQuery{Limit: 10, Offset: 20}}).
+					Return(&models.NotificationPage{Items: []*models.Notification{failed}, Total: 21}, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+			wantTotal:  "21",
+		},
+		{
+			name:       "error - invalid offset",
+			query:      "?offset=-1",
+			setupMocks: func(svc *mocks.MockNotificationServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - notifications of another user",
+			setupMocks: func(svc *mocks.MockNotificationServiceExternal) {
+				svc.EXPECT().
+					GetNotifications(mock.Anything, defaultUserHex, defaultUserHex, models.NotificationQuery{}).
+					Return(nil, apperror.NewForbiddenError("You can only view your own notifications")).
+					Once()
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupNotificationController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodGet, "/users/"+defaultUserHex+"/notifications"+tt.query, nil)
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantTotal != "" {
+				assert.Equal(t, tt.wantTotal, rr.Header().Get("X-Total-Count"))
+				var resp []models.NotificationResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				require.Len(t, resp, 1)
+				assert.Equal(t, "failed", resp[0].Status)
+				assert.Equal(t, "reminder:abc", resp[0].TaskID)
+				assert.Equal(t, "dial tcp: connection refused", resp[0].Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package controllers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOperations_MatchRoutes keeps the OpenAPI annotations of every
+// controller in step with the routes it registers.
+func TestOperations_MatchRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.Handler
+		ops     []openapi.Operation
+	}{
+		{"auth", controllers.NewAuthController(nil, nil, nil, allowAll, allowAll), controllers.AuthOperations},
+		{"users", controllers.NewUserController(nil, nil, nil, allowAll), controllers.UserOperations},
+		{"notification preferences", controllers.NewNotificationPreferenceController(nil, nil), controllers.NotificationPreferenceOperations},
+		{"notifications", controllers.NewNotificationController(nil, nil), controllers.NotificationOperations},
+		{"sessions", controllers.NewSessionController(nil, nil), controllers.SessionOperations},
+		{"subscriptions", controllers.NewSubscriptionController(nil, nil, nil, nil, nil, nil, allowAll, allowAll), controllers.SubscriptionOperations},
+		{"catalog", controllers.NewCatalogController(nil, nil), controllers.CatalogOperations},
+		{"subscription bills", controllers.NewSubscriptionBillController(nil, nil), controllers.SubscriptionBillOperations},
+		{"bills", controllers.NewBillController(nil, nil, nil, nil, allowAll), controllers.BillOperations},
+		{"budget", controllers.NewBudgetController(nil, nil), controllers.BudgetOperations},
+		{"webhooks", controllers.NewWebhookController(nil, nil), controllers.WebhookOperations},
+		{"payment methods", controllers.NewPaymentMethodController(nil, nil), controllers.PaymentMethodOperations},
+		{"subscription payment method", controllers.NewSubscriptionPaymentMethodController(nil, nil), controllers.SubscriptionPaymentMethodOperations},
+		{"attachments", controllers.NewAttachmentController(nil, 0, nil), controllers.AttachmentOperations},
+		{"files", controllers.NewFileController(nil, nil, allowAll), controllers.FileOperations},
+		{"calendar", controllers.NewCalendarController(nil, nil, allowAll), controllers.CalendarOperations},
+		{"subscription calendar", controllers.NewSubscriptionCalendarController(nil, nil), controllers.SubscriptionCalendarOperations},
+		{"cancel links", controllers.NewCancelLinkController(nil, nil), controllers.CancelLinkOperations},
+		{"unsubscribe", controllers.NewUnsubscribeController(nil, nil), controllers.UnsubscribeOperations},
+		{"meta", controllers.NewMetaController(controllers.Meta{}), controllers.MetaOperations},
+		{"jwks", controllers.NewJWKSController(nil), controllers.JWKSOperations},
+		{"events", controllers.NewEventStreamController(nil, services.LiveEventConfig{}, nil), controllers.EventStreamOperations},
+		{"admin", controllers.NewAdminController(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), controllers.AdminOperations},
+		{"suppressions", controllers.NewSuppressionController(nil, nil), controllers.SuppressionOperations},
+		{"email previews", controllers.NewEmailPreviewController(nil, nil, true), controllers.EmailPreviewOperations},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, ok := tt.handler.(chi.Routes)
+			require.True(t, ok, "controller should return a chi router")
+
+			var routes []string
+			err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+				routes = append(routes, method+" "+normalizeRoute(route))
+				return nil
+			})
+			require.NoError(t, err)
+
+			var documented []string
+			for _, op := range tt.ops {
+				documented = append(documented, op.Method+" "+normalizeRoute(op.Path))
+			}
+			assert.ElementsMatch(t, routes, documented)
+		})
+	}
+}
+
+func normalizeRoute(route string) string {
+	if route = strings.TrimSuffix(route, "/"); route == "" {
+		return "/"
+	}
+	return route
+}
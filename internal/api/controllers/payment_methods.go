@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type paymentMethodController struct {
+	paymentMethodService services.PaymentMethodServiceExternal
+	requestHandler       *endpoint.RequestHandler
+}
+
+// NewPaymentMethodController serves the payment methods of the authenticated
+// user.
+func NewPaymentMethodController(paymentMethodService services.PaymentMethodServiceExternal, requestHandler *endpoint.RequestHandler) http.Handler {
+	c := &paymentMethodController{paymentMethodService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Post("/", c.createPaymentMethod)
+	r.Get("/", c.getPaymentMethods)
+	r.Get("/{paymentMethodID}", c.getPaymentMethod)
+	r.Put("/{paymentMethodID}", c.updatePaymentMethod)
+	r.Delete("/{paymentMethodID}", c.deletePaymentMethod)
+	return r
+}
+
+// PaymentMethodOperations documents the routes of NewPaymentMethodController.
+var PaymentMethodOperations = []openapi.Operation{
+	{Method: http.MethodPost, Path: "/", Summary: "Add a payment method", Request: models.PaymentMethodRequest{}, Response: models.PaymentMethodResponse{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/", Summary: "List payment methods", Response: []models.PaymentMethodResponse{}},
+	{Method: http.MethodGet, Path: "/{paymentMethodID}", Summary: "Get a payment method", Response: models.PaymentMethodResponse{}},
+	{Method: http.MethodPut, Path: "/{paymentMethodID}", Summary: "Update a payment method", Request: models.PaymentMethodRequest{}, Response: models.PaymentMethodResponse{}},
+	{Method: http.MethodDelete, Path: "/{paymentMethodID}", Summary: "Delete a payment method and unlink its subscriptions", Status: http.StatusNoContent},
+}
+
+// NewSubscriptionPaymentMethodController links one subscription to a payment
+// method. It is mounted under a route with a {subscriptionID} parameter.
+func NewSubscriptionPaymentMethodController(paymentMethodService services.PaymentMethodServiceExternal, requestHandler *endpoint.RequestHandler) http.Handler {
+	c := &paymentMethodController{paymentMethodService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Put("/", c.linkSubscription)
+	r.Delete("/", c.unlinkSubscription)
+	return r
+}
+
+// SubscriptionPaymentMethodOperations documents the routes of
+// NewSubscriptionPaymentMethodController.
+var SubscriptionPaymentMethodOperations = []openapi.Operation{
+	{Method: http.MethodPut, Path: "/", Summary: "Pay a subscription with a payment method", Request: models.PaymentMethodLinkRequest{}, Response: models.SubscriptionResponse{}},
+	{Method: http.MethodDelete, Path: "/", Summary: "Unlink the payment method of a subscription", Response: models.SubscriptionResponse{}},
+}
+
+func (c *paymentMethodController) createPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	method := models.PaymentMethodRequest{}
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &method,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.paymentMethodService.CreatePaymentMethod(r.Context(), method.ToModel(), userID))
+		},
+		SuccessCode: http.StatusCreated,
+	})
+}
+
+func (c *paymentMethodController) getPaymentMethods(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponseSlice(c.paymentMethodService.GetPaymentMethods(r.Context(), userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *paymentMethodController) getPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	paymentMethodID := chi.URLParam(r, "paymentMethodID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.paymentMethodService.GetPaymentMethod(r.Context(), paymentMethodID, userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *paymentMethodController) updatePaymentMethod(w http.ResponseWriter, r *http.Request) {
+	paymentMethodID := chi.URLParam(r, "paymentMethodID")
+	method := models.PaymentMethodRequest{}
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &method,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.paymentMethodService.UpdatePaymentMethod(r.Context(), paymentMethodID, method.ToModel(), userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *paymentMethodController) deletePaymentMethod(w http.ResponseWriter, r *http.Request) {
+	paymentMethodID := chi.URLParam(r, "paymentMethodID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return nil, c.paymentMethodService.DeletePaymentMethod(r.Context(), paymentMethodID, userID)
+		},
+		SuccessCode: http.StatusNoContent,
+	})
+}
+
+func (c *paymentMethodController) linkSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	link := models.PaymentMethodLinkRequest{}
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &link,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.paymentMethodService.LinkSubscription(r.Context(), subscriptionID, link.PaymentMethodID, userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *paymentMethodController) unlinkSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.paymentMethodService.LinkSubscription(r.Context(), subscriptionID, "", userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
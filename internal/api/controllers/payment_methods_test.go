@@ -0,0 +1,153 @@
+package controllers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func setupPaymentMethodController(t *testing.T) (*mocks.MockPaymentMethodServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockPaymentMethodServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	return svc, controllers.NewPaymentMethodController(svc, reqHandler)
+}
+
+// ---------------------------------------------------------------------------
+// POST /
+// ---------------------------------------------------------------------------
+
+func TestPaymentMethodController_CreatePaymentMethod(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setupMocks func(svc *mocks.MockPaymentMethodServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: `{"type":"card","brand":"Visa","last4":"4242","expMonth":8,"expYear":2030}`,
+			setupMocks: func(svc *mocks.MockPaymentMethodServiceExternal) {
+				want := &models.PaymentMethod{Type: models.Card, Brand: "Visa", Last4: "4242", ExpMonth: 8, ExpYear: 2030}
+				created := *want
+				created.ID = bson.NewObjectID()
+				svc.EXPECT().
+					CreatePaymentMethod(mock.Anything, want, defaultUserHex).
+					Return(&created, nil).
+					Once()
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "error - missing type",
+			body:       `{"brand":"Visa"}`,
+			setupMocks: func(svc *mocks.MockPaymentMethodServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - last4 too long",
+			body:       `{"type":"card","last4":"42424"}`,
+			setupMocks: func(svc *mocks.MockPaymentMethodServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupPaymentMethodController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /
+// ---------------------------------------------------------------------------
+
+func TestPaymentMethodController_GetPaymentMethods(t *testing.T) {
+	svc, handler := setupPaymentMethodController(t)
+	method := &models.PaymentMethod{ID: bson.NewObjectID(), Type: models.Card, Label: "Work card", Brand: "Visa", Last4: "4242"}
+	svc.EXPECT().GetPaymentMethods(mock.Anything, defaultUserHex).Return([]*models.PaymentMethod{method}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp []models.PaymentMethodResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	require.Len(t, resp, 1)
+	assert.Equal(t, "Work card (Visa ending in 4242)", resp[0].Description)
+}
+
+// ---------------------------------------------------------------------------
+// PUT / and DELETE / under /subscriptions/{subscriptionID}/payment-method
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionPaymentMethodController(t *testing.T) {
+	methodID := bson.NewObjectID()
+	subID := bson.NewObjectID().Hex()
+
+	tests := []struct {
+		name          string
+		method        string
+		body          string
+		wantMethodHex string
+	}{
+		{"link", http.MethodPut, `{"paymentMethodId":"` + methodID.Hex() + `"}`, methodID.Hex()},
+		{"unlink", http.MethodDelete, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := mocks.NewMockPaymentMethodServiceExternal(t)
+			r := chi.NewRouter()
+			r.Mount("/subscriptions/{subscriptionID}/payment-method", controllers.NewSubscriptionPaymentMethodController(
+				svc, endpoint.NewRequestHandler(validator.New()),
+			))
+
+			linked := &models.Subscription{ID: bson.NewObjectID()}
+			if tt.wantMethodHex != "" {
+				linked.PaymentMethodID = &methodID
+			}
+			svc.EXPECT().
+				LinkSubscription(mock.Anything, subID, tt.wantMethodHex, defaultUserHex).
+				Return(linked, nil).
+				Once()
+
+			req := httptest.NewRequest(tt.method, "/subscriptions/"+subID+"/payment-method", bytes.NewBufferString(tt.body))
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			var resp models.SubscriptionResponse
+			require.NoError(t, decodeData(rr.Body, &resp))
+			assert.Equal(t, tt.wantMethodHex, resp.PaymentMethodID)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type sessionController struct {
+	sessionService services.SessionServiceExternal
+	requestHandler *endpoint.RequestHandler
+}
+
+// NewSessionController serves the signed-in devices of one user. It is
+// mounted under a route with a {userID} parameter.
+func NewSessionController(
+	sessionService services.SessionServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+) http.Handler {
+	c := &sessionController{sessionService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Get("/", c.getSessions)
+	r.Delete("/{sessionID}", c.revokeSession)
+	return r
+}
+
+// SessionOperations documents the routes of NewSessionController.
+var SessionOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/", Summary: "List the signed-in devices of a user, most recently used first", Response: []models.SessionResponse{}},
+	{Method: http.MethodDelete, Path: "/{sessionID}", Summary: "Sign a device out by revoking its session", Status: http.StatusNoContent},
+}
+
+func (c *sessionController) getSessions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "userID")
+	claimedUserID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponseSlice(c.sessionService.GetSessions(r.Context(), id, claimedUserID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *sessionController) revokeSession(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "userID")
+	sessionID := chi.URLParam(r, "sessionID")
+	claimedUserID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return nil, c.sessionService.RevokeSession(r.Context(), id, claimedUserID, sessionID)
+		},
+		SuccessCode: http.StatusNoContent,
+	})
+}
@@ -0,0 +1,144 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// setupSessionController mounts the session router under a {userID} route
+// the way the server does.
+func setupSessionController(t *testing.T) (*mocks.MockSessionServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockSessionServiceExternal(t)
+	r := chi.NewRouter()
+	r.Mount("/users/{userID}/sessions", controllers.NewSessionController(svc, endpoint.NewRequestHandler(validator.New())))
+	return svc, r
+}
+
+// ---------------------------------------------------------------------------
+// GET /users/{userID}/sessions
+// ---------------------------------------------------------------------------
+
+func TestSessionController_GetSessions(t *testing.T) {
+	session := &models.Session{
+		ID:         bson.NewObjectID(),
+		UserID:     defaultUserID,
+		Device:     "Work laptop",
+		IP:         "203.0.113.7",
+		UserAgent:  "Mozilla/5.0",
+		CreatedAt:  mockTime,
+		LastUsedAt: mockTime,
+		ExpiresAt:  mockTime.AddDate(0, 0, 7),
+		Current:    true,
+	}
+
+	tests := []struct {
+		name       string
+		setupMocks func(svc *mocks.MockSessionServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success - lists the sessions",
+			setupMocks: func(svc *mocks.MockSessionServiceExternal) {
+				svc.EXPECT().
+					GetSessions(mock.Anything, defaultUserHex, defaultUserHex).
+					Return([]*models.Session{session}, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "error - sessions of another user",
+			setupMocks: func(svc *mocks.MockSessionServiceExternal) {
+				svc.EXPECT().
+					GetSessions(mock.Anything, defaultUserHex, defaultUserHex).
+					Return(nil, apperror.NewForbiddenError("You can only view your own sessions")).
+					Once()
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupSessionController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodGet, "/users/"+defaultUserHex+"/sessions", nil)
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp []models.SessionResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				require.Len(t, resp, 1)
+				assert.Equal(t, session.ID.Hex(), resp[0].ID)
+				assert.Equal(t, "Work laptop", resp[0].Device)
+				assert.Equal(t, "203.0.113.7", resp[0].IP)
+				assert.True(t, resp[0].Current)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// DELETE /users/{userID}/sessions/{sessionID}
+// ---------------------------------------------------------------------------
+
+func TestSessionController_RevokeSession(t *testing.T) {
+	sessionHex := bson.NewObjectID().Hex()
+
+	tests := []struct {
+		name       string
+		setupMocks func(svc *mocks.MockSessionServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success - revokes the session",
+			setupMocks: func(svc *mocks.MockSessionServiceExternal) {
+				svc.EXPECT().RevokeSession(mock.Anything, defaultUserHex, defaultUserHex, sessionHex).Return(nil).Once()
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "error - session not found",
+			setupMocks: func(svc *mocks.MockSessionServiceExternal) {
+				svc.EXPECT().RevokeSession(mock.Anything, defaultUserHex, defaultUserHex, sessionHex).
+					Return(apperror.NewNotFoundError("Session not found")).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupSessionController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodDelete, "/users/"+defaultUserHex+"/sessions/"+sessionHex, nil)
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package controllers_test
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 	"time"
 
@@ -24,4 +26,15 @@ var defaultUserEmail = "alice@example.com"
 // Authentication middleware does for protected routes.
 func injectUserID(req *http.Request, userID string) *http.Request {
 	return req.WithContext(appctx.WithUserID(req.Context(), userID))
-}
\ No newline at end of file
+}
+
+// decodeData decodes the data of a response envelope into v.
+func decodeData(body io.Reader, v any) error {
+	var resp struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return err
+	}
+	return json.Unmarshal(resp.Data, v)
+}
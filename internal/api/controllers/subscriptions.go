@@ -1,42 +1,138 @@
 package controllers
 
 import (
+	"context"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/middlewares"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
 	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/i18n"
 	"github.com/go-chi/chi/v5"
 )
 
 type subscriptionController struct {
-	subscriptionService services.SubscriptionServiceExternal
-	requestHandler      *endpoint.RequestHandler
+	subscriptionService   services.SubscriptionServiceExternal
+	forecastService       services.ForecastService
+	reminderSnoozeService services.ReminderSnoozeService
+	exportService         services.ExportServiceExternal
+	catalogService        services.CatalogServiceExternal
+	requestHandler        *endpoint.RequestHandler
 }
 
-func NewSubscriptionController(subscriptionService services.SubscriptionServiceExternal, requestHandler *endpoint.RequestHandler) http.Handler {
+func NewSubscriptionController(
+	subscriptionService services.SubscriptionServiceExternal,
+	forecastService services.ForecastService,
+	reminderSnoozeService services.ReminderSnoozeService,
+	exportService services.ExportServiceExternal,
+	catalogService services.CatalogServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+	requireAdmin func(http.Handler) http.Handler,
+	exportTimeout func(http.Handler) http.Handler,
+) http.Handler {
 	c := &subscriptionController{
 		subscriptionService,
+		forecastService,
+		reminderSnoozeService,
+		exportService,
+		catalogService,
 		requestHandler,
 	}
 
 	r := chi.NewRouter()
 	r.Post("/", c.createSubscription)
-	r.Get("/", c.getAllSubscriptions)
+	r.With(requireAdmin).Get("/", c.getAllSubscriptions)
 	r.Get("/user/{id}", c.getSubscriptionsByUserID)
+	r.Get("/forecast", c.getForecast)
+	r.Get("/calendar", c.getCalendar)
+	r.Get("/price-changes", c.getPriceChangeSummary)
+	r.Get("/summary", c.getSummary)
+	r.Get("/duplicates", c.getDuplicates)
+	r.Get("/search", c.searchSubscriptions)
+	r.With(requireAdmin).Get("/search/all", c.searchAllSubscriptions)
+	r.With(exportTimeout).Get("/export", c.exportSubscriptions)
 
 	r.Route("/{subscriptionID}", func(r chi.Router) {
 		r.Use(middlewares.WithSubscriptionID)
 		r.Get("/", c.getSubscriptionByID)
+		r.Patch("/", c.updateSubscription)
+		r.Get("/events", c.getLifecycleEvents)
 		r.Put("/cancel", c.cancelSubscription)
+		r.Put("/pause", c.pauseSubscription)
+		r.Put("/resume", c.resumeSubscription)
+		r.Post("/usage", c.recordUsage)
+		r.Put("/price", c.updatePrice)
+		r.Get("/price-history", c.getPriceHistory)
+		r.Post("/reminders/snooze", c.snoozeReminders)
+		r.Delete("/reminders/snooze", c.unsnoozeReminders)
 		r.Delete("/", c.deleteSubscription)
 	})
 
 	return r
 }
 
+// SubscriptionOperations documents the routes of NewSubscriptionController.
+var SubscriptionOperations = []openapi.Operation{
+	{Method: http.MethodPost, Path: "/", Summary: "Create a subscription", Request: models.SubscriptionRequest{}, Response: models.SubscriptionResponse{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/", Summary: "List every user's subscriptions (admin role)", Query: subscriptionListParams, Response: []models.SubscriptionResponse{}},
+	{Method: http.MethodGet, Path: "/user/{id}", Summary: "List a user's subscriptions", Query: append(subscriptionListParams, includeArchivedParam), Response: []models.SubscriptionResponse{}},
+	{Method: http.MethodGet, Path: "/forecast", Summary: "Project spend per month and currency", Query: []openapi.Param{
+		{Name: "months", Type: "integer", Description: "Horizon of 1 to 36 months, 12 by default"},
+		{Name: "currency", Description: "Currency to convert the totals into"},
+	}, Response: models.ForecastResponse{}},
+	{Method: http.MethodGet, Path: "/calendar", Summary: "List renewals in a month grouped by day", Query: []openapi.Param{monthParam}, Response: models.RenewalCalendarResponse{}},
+	{Method: http.MethodGet, Path: "/price-changes", Summary: "List price increases in a month", Query: []openapi.Param{monthParam}, Response: models.PriceChangeSummaryResponse{}},
+	{Method: http.MethodGet, Path: "/summary", Summary: "Summarize spend, counts, and upcoming renewals", Query: []openapi.Param{
+		{Name: "currency", Description: "Currency to convert the spend into"},
+	}, Response: models.SubscriptionSummaryResponse{}},
+	{Method: http.MethodGet, Path: "/duplicates", Summary: "Group running subscriptions that are likely duplicates", Response: []models.DuplicateGroupResponse{}},
+	{Method: http.MethodGet, Path: "/search", Summary: "Search the name, tags, and notes of subscriptions, best match first", Query: subscriptionSearchParams, Response: []models.SubscriptionMatchResponse{}},
+	{Method: http.MethodGet, Path: "/search/all", Summary: "Search every user's subscriptions (admin role)", Query: subscriptionSearchParams, Response: []models.SubscriptionMatchResponse{}},
+	{Method: http.MethodGet, Path: "/export", Summary: "Download subscriptions as CSV or XLSX; large exports are emailed", Query: subscriptionExportParams, ContentType: "text/csv"},
+	{Method: http.MethodGet, Path: "/{subscriptionID}", Summary: "Get a subscription", Query: []openapi.Param{includeArchivedParam}, Response: models.SubscriptionResponse{}},
+	{Method: http.MethodPatch, Path: "/{subscriptionID}", Summary: "Change the name, category, tags, notes, or reminder days of a subscription", Request: models.SubscriptionUpdateRequest{}, Response: models.SubscriptionResponse{}},
+	{Method: http.MethodGet, Path: "/{subscriptionID}/events", Summary: "List the lifecycle events of a subscription", Response: []models.LifecycleEventResponse{}},
+	{Method: http.MethodPut, Path: "/{subscriptionID}/cancel", Summary: "Cancel a subscription", Response: models.SubscriptionResponse{}},
+	{Method: http.MethodPut, Path: "/{subscriptionID}/pause", Summary: "Pause reminders and renewals of a subscription", Response: models.SubscriptionResponse{}},
+	{Method: http.MethodPut, Path: "/{subscriptionID}/resume", Summary: "Resume a paused subscription", Response: models.SubscriptionResponse{}},
+	{Method: http.MethodPost, Path: "/{subscriptionID}/usage", Summary: "Record use of a subscription", Request: models.UsageRequest{}, Response: models.SubscriptionResponse{}},
+	{Method: http.MethodPut, Path: "/{subscriptionID}/price", Summary: "Change the price of a subscription, now or from a future renewal", Request: models.PriceUpdateRequest{}, Response: models.SubscriptionResponse{}},
+	{Method: http.MethodGet, Path: "/{subscriptionID}/price-history", Summary: "List the price changes of a subscription", Response: []models.PriceChangeResponse{}},
+	{Method: http.MethodPost, Path: "/{subscriptionID}/reminders/snooze", Summary: "Hold back reminders", Query: []openapi.Param{
+		{Name: "days", Type: "integer", Description: "Days to snooze for; until the renewal when omitted"},
+	}, Response: models.ReminderSnoozeResponse{}},
+	{Method: http.MethodDelete, Path: "/{subscriptionID}/reminders/snooze", Summary: "Resume reminders", Status: http.StatusNoContent},
+	{Method: http.MethodDelete, Path: "/{subscriptionID}", Summary: "Delete an expired subscription", Status: http.StatusNoContent},
+}
+
+var (
+	subscriptionListParams = []openapi.Param{
+		{Name: "status", Description: "Filter by status"},
+		{Name: "category", Description: "Filter by category"},
+		{Name: "frequency", Description: "Filter by billing frequency"},
+		{Name: "tag", Description: "Filter by tag"},
+		{Name: "sort", Description: "Field to sort by"},
+		{Name: "order", Description: "asc (default) or desc"},
+		limitParam,
+		offsetParam,
+	}
+	subscriptionSearchParams = []openapi.Param{
+		{Name: "q", Description: "Words to search for; \"quoted phrases\" must appear and -words must not", Required: true},
+		limitParam,
+		offsetParam,
+	}
+	includeArchivedParam = openapi.Param{Name: "include_archived", Type: "boolean", Description: "Include archived subscriptions"}
+	monthParam           = openapi.Param{Name: "month", Description: "Month as YYYY-MM, the current one by default"}
+	limitParam           = openapi.Param{Name: "limit", Type: "integer", Description: "Page size"}
+	offsetParam          = openapi.Param{Name: "offset", Type: "integer", Description: "Number of items to skip"}
+)
+
 func (c *subscriptionController) createSubscription(w http.ResponseWriter, r *http.Request) {
 	subscription := models.SubscriptionRequest{}
 	userID, _ := appctx.GetUserID(r.Context())
@@ -46,7 +142,26 @@ func (c *subscriptionController) createSubscription(w http.ResponseWriter, r *ht
 		R:          r,
 		ReqBodyObj: &subscription,
 		EndpointLogic: func() (any, error) {
-			return endpoint.ToResponse(c.subscriptionService.CreateSubscription(r.Context(), subscription.ToModel(), userID))
+			model := subscription.ToModel()
+			if subscription.CatalogID != "" {
+				err := c.catalogService.PrefillSubscription(r.Context(), model, subscription.CatalogID, subscription.CatalogPlan)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if !subscription.AllowDuplicate {
+				existing, err := c.subscriptionService.FindDuplicateSubscription(r.Context(), model, userID)
+				if err != nil {
+					return nil, err
+				}
+				if existing != nil {
+					return &endpoint.StatusResponse{Status: http.StatusConflict, Body: &models.DuplicateSubscriptionResponse{
+						Error:    i18n.T(r.Context(), "You already have a subscription named %s; set allowDuplicate to add another", existing.Name),
+						Existing: existing.ToResponse(),
+					}}, nil
+				}
+			}
+			return endpoint.ToResponse(c.subscriptionService.CreateSubscription(r.Context(), model, userID))
 		},
 		SuccessCode: http.StatusCreated,
 	})
@@ -57,7 +172,15 @@ func (c *subscriptionController) getAllSubscriptions(w http.ResponseWriter, r *h
 		W: w,
 		R: r,
 		EndpointLogic: func() (any, error) {
-			return endpoint.ToResponseSlice(c.subscriptionService.GetAllSubscriptions(r.Context()))
+			query, err := subscriptionQuery(r)
+			if err != nil {
+				return nil, err
+			}
+			page, err := c.subscriptionService.GetAllSubscriptions(r.Context(), query)
+			if err != nil {
+				return nil, err
+			}
+			return pageResponse(page.Items, page.Total, query.Limit, query.Offset)
 		},
 		SuccessCode: http.StatusOK,
 	})
@@ -71,7 +194,22 @@ func (c *subscriptionController) getSubscriptionByID(w http.ResponseWriter, r *h
 		W: w,
 		R: r,
 		EndpointLogic: func() (any, error) {
-			return endpoint.ToResponse(c.subscriptionService.GetSubscriptionByID(r.Context(), subscriptionID, userID))
+			return endpoint.ToResponse(c.subscriptionService.GetSubscriptionByID(r.Context(), subscriptionID, userID, includeArchived(r)))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// getLifecycleEvents returns the timeline of the subscription.
+func (c *subscriptionController) getLifecycleEvents(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, _ := appctx.GetSubscriptionID(r.Context())
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponseSlice(c.subscriptionService.GetLifecycleEvents(r.Context(), subscriptionID, userID))
 		},
 		SuccessCode: http.StatusOK,
 	})
@@ -99,7 +237,15 @@ func (c *subscriptionController) getSubscriptionsByUserID(w http.ResponseWriter,
 		W: w,
 		R: r,
 		EndpointLogic: func() (any, error) {
-			return endpoint.ToResponseSlice(c.subscriptionService.GetSubscriptionsByUserID(r.Context(), id, userID))
+			query, err := subscriptionQuery(r)
+			if err != nil {
+				return nil, err
+			}
+			page, err := c.subscriptionService.GetSubscriptionsByUserID(r.Context(), id, userID, includeArchived(r), query)
+			if err != nil {
+				return nil, err
+			}
+			return pageResponse(page.Items, page.Total, query.Limit, query.Offset)
 		},
 		SuccessCode: http.StatusOK,
 	})
@@ -118,3 +264,383 @@ func (c *subscriptionController) cancelSubscription(w http.ResponseWriter, r *ht
 		SuccessCode: http.StatusOK,
 	})
 }
+
+func (c *subscriptionController) pauseSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, _ := appctx.GetSubscriptionID(r.Context())
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.subscriptionService.PauseSubscription(r.Context(), subscriptionID, userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *subscriptionController) resumeSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, _ := appctx.GetSubscriptionID(r.Context())
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.subscriptionService.ResumeSubscription(r.Context(), subscriptionID, userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// recordUsage reports that the subscription was used, at the time given in
+// the body or now. Integrations can call it as a webhook with the owner's
+// token.
+func (c *subscriptionController) recordUsage(w http.ResponseWriter, r *http.Request) {
+	usage := models.UsageRequest{}
+	subscriptionID, _ := appctx.GetSubscriptionID(r.Context())
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &usage,
+		EndpointLogic: func() (any, error) {
+			var usedAt time.Time
+			if usage.UsedAt != nil {
+				usedAt = *usage.UsedAt
+			}
+			return endpoint.ToResponse(c.subscriptionService.RecordUsage(r.Context(), subscriptionID, userID, usedAt))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// updateSubscription changes the details of the subscription given in the
+// body, leaving the others as they are.
+func (c *subscriptionController) updateSubscription(w http.ResponseWriter, r *http.Request) {
+	update := models.SubscriptionUpdateRequest{}
+	subscriptionID, _ := appctx.GetSubscriptionID(r.Context())
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &update,
+		EndpointLogic: func() (any, error) {
+			ctx, err := c.checkIfMatch(r, subscriptionID, userID)
+			if err != nil {
+				return nil, err
+			}
+			return endpoint.ToResponse(c.subscriptionService.UpdateSubscription(ctx, subscriptionID, userID, &update))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// updatePrice changes the price of the subscription.
+func (c *subscriptionController) updatePrice(w http.ResponseWriter, r *http.Request) {
+	update := models.PriceUpdateRequest{}
+	subscriptionID, _ := appctx.GetSubscriptionID(r.Context())
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &update,
+		EndpointLogic: func() (any, error) {
+			ctx, err := c.checkIfMatch(r, subscriptionID, userID)
+			if err != nil {
+				return nil, err
+			}
+			var effectiveAt time.Time
+			if update.EffectiveAt != nil {
+				effectiveAt = *update.EffectiveAt
+			}
+			return endpoint.ToResponse(c.subscriptionService.UpdatePrice(ctx, subscriptionID, userID, update.Price, effectiveAt))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// getPriceHistory lists the price changes of the subscription, oldest first.
+func (c *subscriptionController) getPriceHistory(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, _ := appctx.GetSubscriptionID(r.Context())
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponseSlice(c.subscriptionService.GetPriceHistory(r.Context(), subscriptionID, userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// getPriceChangeSummary lists the caller's price increases in the month given
+// by the month query parameter.
+func (c *subscriptionController) getPriceChangeSummary(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return c.subscriptionService.GetPriceChangeSummary(r.Context(), userID, r.URL.Query().Get("month"))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// getSummary returns the spend, status counts, and upcoming renewals of the
+// authenticated user's subscriptions.
+func (c *subscriptionController) getSummary(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			target := models.ParseCurrency(r.URL.Query().Get("currency"))
+			return c.subscriptionService.GetSummary(r.Context(), userID, target)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// getDuplicates reports the caller's running subscriptions that share a name.
+func (c *subscriptionController) getDuplicates(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponseSlice(c.subscriptionService.GetDuplicateSubscriptions(r.Context(), userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *subscriptionController) searchSubscriptions(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			search, err := subscriptionSearch(r)
+			if err != nil {
+				return nil, err
+			}
+			page, err := c.subscriptionService.SearchSubscriptions(r.Context(), userID, search)
+			if err != nil {
+				return nil, err
+			}
+			return pageResponse(page.Items, page.Total, search.Limit, search.Offset)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *subscriptionController) searchAllSubscriptions(w http.ResponseWriter, r *http.Request) {
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			search, err := subscriptionSearch(r)
+			if err != nil {
+				return nil, err
+			}
+			page, err := c.subscriptionService.SearchAllSubscriptions(r.Context(), search)
+			if err != nil {
+				return nil, err
+			}
+			return pageResponse(page.Items, page.Total, search.Limit, search.Offset)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// snoozeReminders holds back the reminders of the current renewal cycle for
+// the number of days given by the days query parameter, or until the renewal
+// when it is omitted.
+func (c *subscriptionController) snoozeReminders(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, _ := appctx.GetSubscriptionID(r.Context())
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			days := 0
+			if raw := r.URL.Query().Get("days"); raw != "" {
+				var err error
+				if days, err = strconv.Atoi(raw); err != nil || days < 1 {
+					return nil, apperror.NewValidationError("days must be a positive integer")
+				}
+			}
+			return c.reminderSnoozeService.SnoozeReminders(r.Context(), subscriptionID, userID, days)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// unsnoozeReminders lifts a reminder snooze.
+func (c *subscriptionController) unsnoozeReminders(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, _ := appctx.GetSubscriptionID(r.Context())
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return nil, c.reminderSnoozeService.UnsnoozeReminders(r.Context(), subscriptionID, userID)
+		},
+		SuccessCode: http.StatusNoContent,
+	})
+}
+
+// defaultForecastMonths is the forecast horizon used when the months query
+// parameter is omitted.
+const defaultForecastMonths = 12
+
+// getForecast projects the caller's spend over the number of months given by
+// the months query parameter, converted into the optional currency parameter.
+func (c *subscriptionController) getForecast(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			months := defaultForecastMonths
+			if raw := r.URL.Query().Get("months"); raw != "" {
+				var err error
+				if months, err = strconv.Atoi(raw); err != nil {
+					return nil, apperror.NewValidationError("months must be an integer")
+				}
+			}
+			target := models.ParseCurrency(r.URL.Query().Get("currency"))
+			return c.forecastService.GetForecast(r.Context(), userID, months, target)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// getCalendar lists the caller's renewals in the month given by the month
+// query parameter, grouped by day.
+func (c *subscriptionController) getCalendar(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return c.forecastService.GetRenewalCalendar(r.Context(), userID, r.URL.Query().Get("month"))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+// exportSubscriptions streams the caller's subscriptions as a file, or
+// queues the export when it is too large to stream.
+func (c *subscriptionController) exportSubscriptions(w http.ResponseWriter, r *http.Request) {
+	serveExport(w, r, c.requestHandler, c.exportService, func() (models.ExportRequest, error) {
+		return subscriptionExportRequest(r)
+	})
+}
+
+// checkIfMatch fails unless the request has no If-Match header or it lists
+// the ETag a GET of the subscription returns, so an update based on a stale
+// read is rejected instead of overwriting a newer change. The returned context
+// carries the version behind the matched ETag, which the service's versioned
+// write requires, so a change landing between this check and the write is a
+// conflict rather than lost.
+//
+// Cancel, pause, and resume do not take If-Match: each is a status transition
+// that matches on the current status and writes no field the client sent, so
+// a stale read cannot make it overwrite a newer change.
+func (c *subscriptionController) checkIfMatch(r *http.Request, subscriptionID, userID string) (context.Context, error) {
+	ctx := r.Context()
+	if r.Header.Get("If-Match") == "" {
+		return ctx, nil
+	}
+	current, err := c.subscriptionService.GetSubscriptionByID(ctx, subscriptionID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if err = endpoint.CheckIfMatch(r, current.ToResponse()); err != nil {
+		return nil, err
+	}
+	return appctx.WithExpectedVersion(ctx, current.Version), nil
+}
+
+// includeArchived reports whether the caller asked for archived subscriptions
+// via the include_archived query parameter.
+func includeArchived(r *http.Request) bool {
+	include, _ := strconv.ParseBool(r.URL.Query().Get("include_archived"))
+	return include
+}
+
+// subscriptionQuery reads the filters, sort, and page of a subscription
+// listing from the status, category, frequency, tag, sort, order, limit, and
+// offset query parameters. The service validates their values.
+func subscriptionQuery(r *http.Request) (models.SubscriptionQuery, error) {
+	params := r.URL.Query()
+	query := models.SubscriptionQuery{
+		Status:    models.Status(params.Get("status")),
+		Category:  models.Category(params.Get("category")),
+		Frequency: models.Frequency(params.Get("frequency")),
+		Tag:       params.Get("tag"),
+		Sort:      models.SubscriptionSort(params.Get("sort")),
+	}
+
+	switch params.Get("order") {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return query, apperror.NewValidationError("order must be either asc or desc")
+	}
+
+	var err error
+	query.Limit, query.Offset, err = pageParams(r)
+	return query, err
+}
+
+// subscriptionSearch parses the q, limit, and offset query parameters of a
+// search.
+func subscriptionSearch(r *http.Request) (models.SubscriptionSearch, error) {
+	search := models.SubscriptionSearch{Text: r.URL.Query().Get("q")}
+	var err error
+	search.Limit, search.Offset, err = pageParams(r)
+	return search, err
+}
+
+// pageParams parses the limit and offset query parameters; absent ones are 0.
+func pageParams(r *http.Request) (limit, offset int, err error) {
+	params := r.URL.Query()
+	if raw := params.Get("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 {
+			return 0, 0, apperror.NewValidationError("limit must be a positive integer")
+		}
+	}
+	if raw := params.Get("offset"); raw != "" {
+		if offset, err = strconv.Atoi(raw); err != nil || offset < 0 {
+			return 0, 0, apperror.NewValidationError("offset must be a non-negative integer")
+		}
+	}
+	return limit, offset, nil
+}
+
+// pageResponse answers with the items of a page, out of total matching ones.
+// A limit of 0 is reported as the default page size the services apply.
+func pageResponse[T endpoint.InternalModel[R], R any](items []T, total int64, limit, offset int) (any, error) {
+	if limit == 0 {
+		limit = models.DefaultPageLimit
+	}
+	data, _ := endpoint.ToResponseSlice(items, nil)
+	return &endpoint.PageResponse{Items: data, Total: total, Limit: limit, Offset: offset}, nil
+}
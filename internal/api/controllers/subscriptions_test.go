@@ -2,15 +2,20 @@ package controllers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
 	"github.com/go-playground/validator/v10"
@@ -65,13 +70,69 @@ func validSubsResponse() []*models.SubscriptionResponse {
 	return res
 }
 
+// allowAll stands in for the admin guard in tests of the routes behind it.
+func allowAll(next http.Handler) http.Handler { return next }
+
+// forbidAll stands in for the admin guard when a test checks which routes it
+// covers.
+func forbidAll(http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+}
+
 func setupSubscriptionController(t *testing.T) (*mocks.MockSubscriptionServiceExternal, http.Handler) {
 	t.Helper()
 
 	svc := mocks.NewMockSubscriptionServiceExternal(t)
 	v := validator.New()
 	reqHandler := endpoint.NewRequestHandler(v)
-	router := controllers.NewSubscriptionController(svc, reqHandler)
+	router := controllers.NewSubscriptionController(svc, mocks.NewMockForecastService(t), mocks.NewMockReminderSnoozeService(t), mocks.NewMockExportServiceExternal(t), mocks.NewMockCatalogServiceExternal(t), reqHandler, allowAll, allowAll)
+	return svc, router
+}
+
+func setupForecastController(t *testing.T) (*mocks.MockForecastService, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockForecastService(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	router := controllers.NewSubscriptionController(mocks.NewMockSubscriptionServiceExternal(t), svc, mocks.NewMockReminderSnoozeService(t), mocks.NewMockExportServiceExternal(t), mocks.NewMockCatalogServiceExternal(t), reqHandler, allowAll, allowAll)
+	return svc, router
+}
+
+func setupReminderSnoozeController(t *testing.T) (*mocks.MockReminderSnoozeService, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockReminderSnoozeService(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	router := controllers.NewSubscriptionController(
+		mocks.NewMockSubscriptionServiceExternal(t),
+		mocks.NewMockForecastService(t),
+		svc,
+		mocks.NewMockExportServiceExternal(t),
+		mocks.NewMockCatalogServiceExternal(t),
+		reqHandler,
+		allowAll,
+		allowAll,
+	)
+	return svc, router
+}
+
+func setupExportController(t *testing.T) (*mocks.MockExportServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockExportServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	router := controllers.NewSubscriptionController(
+		mocks.NewMockSubscriptionServiceExternal(t),
+		mocks.NewMockForecastService(t),
+		mocks.NewMockReminderSnoozeService(t),
+		svc,
+		mocks.NewMockCatalogServiceExternal(t),
+		reqHandler,
+		allowAll,
+		allowAll,
+	)
 	return svc, router
 }
 
@@ -105,6 +166,7 @@ func TestSubscriptionController_CreateSubscription(t *testing.T) {
 					return assert.ObjectsAreEqual(validModelFromInput(), s)
 				})
 
+				svc.EXPECT().FindDuplicateSubscription(mock.Anything, matcher, defaultUserHex).Return(nil, nil).Once()
 				svc.EXPECT().
 					CreateSubscription(mock.Anything, matcher, defaultUserHex).
 					Return(validSub(), nil).
@@ -116,6 +178,7 @@ func TestSubscriptionController_CreateSubscription(t *testing.T) {
 		{
 			name: "error - propagates service error",
 			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().FindDuplicateSubscription(mock.Anything, mock.Anything, defaultUserHex).Return(nil, nil).Once()
 				svc.EXPECT().
 					CreateSubscription(mock.Anything, mock.Anything, defaultUserHex).
 					Return(nil, apperror.NewInternalError(errors.New("db down"))).
@@ -142,7 +205,7 @@ func TestSubscriptionController_CreateSubscription(t *testing.T) {
 			require.Equal(t, tt.wantStatus, rr.Code)
 			if tt.wantSub != nil {
 				var resp *models.SubscriptionResponse
-				err := json.NewDecoder(rr.Body).Decode(&resp)
+				err := decodeData(rr.Body, &resp)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantSub, resp)
 			}
@@ -150,6 +213,136 @@ func TestSubscriptionController_CreateSubscription(t *testing.T) {
 	}
 }
 
+func TestSubscriptionController_CreateSubscription_FromCatalog(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		prefillErr error
+		wantStatus int
+	}{
+		{
+			name:       "success - pre-fills from the catalog before creating",
+			body:       `{"catalogId":"netflix","catalogPlan":"Premium"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "error - unknown catalog entry",
+			body:       `{"catalogId":"nope"}`,
+			prefillErr: apperror.NewValidationError("unknown catalog entry %s", "nope"),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := mocks.NewMockSubscriptionServiceExternal(t)
+			catalog := mocks.NewMockCatalogServiceExternal(t)
+			handler := controllers.NewSubscriptionController(
+				svc,
+				mocks.NewMockForecastService(t),
+				mocks.NewMockReminderSnoozeService(t),
+				mocks.NewMockExportServiceExternal(t),
+				catalog,
+				endpoint.NewRequestHandler(validator.New()),
+				allowAll,
+				allowAll,
+			)
+
+			var request models.SubscriptionRequest
+			require.NoError(t, json.Unmarshal([]byte(tt.body), &request))
+			prefill := catalog.EXPECT().
+				PrefillSubscription(mock.Anything, mock.Anything, request.CatalogID, request.CatalogPlan)
+			if tt.prefillErr != nil {
+				prefill.Return(tt.prefillErr).Once()
+			} else {
+				prefill.RunAndReturn(func(_ context.Context, s *models.Subscription, _, _ string) error {
+					*s = *validSub()
+					return nil
+				}).Once()
+				svc.EXPECT().FindDuplicateSubscription(mock.Anything, mock.Anything, defaultUserHex).Return(nil, nil).Once()
+				svc.EXPECT().
+					CreateSubscription(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+						return s.Name == "Netflix"
+					}), defaultUserHex).
+					Return(validSub(), nil).
+					Once()
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestSubscriptionController_CreateSubscription_Duplicate(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setupMocks func(svc *mocks.MockSubscriptionServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "conflict - returns the existing subscription",
+			body: `{"name":"netflix","price":999,"frequency":"monthly","category":"entertainment"}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().FindDuplicateSubscription(mock.Anything, mock.Anything, defaultUserHex).Return(validSub(), nil).Once()
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "success - allowDuplicate skips the check",
+			body: `{"name":"netflix","price":999,"frequency":"monthly","category":"entertainment","allowDuplicate":true}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().CreateSubscription(mock.Anything, mock.Anything, defaultUserHex).Return(validSub(), nil).Once()
+			},
+			wantStatus: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupSubscriptionController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusConflict {
+				var resp models.DuplicateSubscriptionResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				assert.Contains(t, resp.Error, "Netflix")
+				assert.Equal(t, validSubResponse(), resp.Existing)
+			}
+		})
+	}
+}
+
+func TestSubscriptionController_CreateSubscription_PlanWithoutCatalog(t *testing.T) {
+	_, handler := setupSubscriptionController(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(
+		`{"name":"Netflix","price":999,"frequency":"monthly","category":"entertainment","catalogPlan":"Premium"}`,
+	))
+	req.Header.Set("Content-Type", "application/json")
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
 // ---------------------------------------------------------------------------
 // GET /
 // ---------------------------------------------------------------------------
@@ -165,8 +358,8 @@ func TestSubscriptionController_GetAllSubscriptions(t *testing.T) {
 			name: "success - calls service and returns 200 OK",
 			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
 				svc.EXPECT().
-					GetAllSubscriptions(mock.Anything).
-					Return(validSubs(), nil).
+					GetAllSubscriptions(mock.Anything, models.SubscriptionQuery{}).
+					Return(&models.SubscriptionPage{Items: validSubs(), Total: 2}, nil).
 					Once()
 			},
 			wantStatus: http.StatusOK,
@@ -176,8 +369,8 @@ func TestSubscriptionController_GetAllSubscriptions(t *testing.T) {
 			name: "Success - empty list and returns 200 OK",
 			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
 				svc.EXPECT().
-					GetAllSubscriptions(mock.Anything).
-					Return(nil, nil).
+					GetAllSubscriptions(mock.Anything, models.SubscriptionQuery{}).
+					Return(&models.SubscriptionPage{}, nil).
 					Once()
 			},
 			wantStatus: http.StatusOK,
@@ -186,7 +379,7 @@ func TestSubscriptionController_GetAllSubscriptions(t *testing.T) {
 		{
 			name: "error - propagates service error",
 			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
-				svc.EXPECT().GetAllSubscriptions(mock.Anything).Return(nil, errors.New("db error")).Once()
+				svc.EXPECT().GetAllSubscriptions(mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
 			},
 			wantStatus: http.StatusInternalServerError,
 		},
@@ -205,7 +398,7 @@ func TestSubscriptionController_GetAllSubscriptions(t *testing.T) {
 			require.Equal(t, tt.wantStatus, rr.Code)
 			if tt.wantSubs != nil {
 				var resp []*models.SubscriptionResponse
-				err := json.NewDecoder(rr.Body).Decode(&resp)
+				err := decodeData(rr.Body, &resp)
 				require.NoError(t, err)
 				assert.ElementsMatch(t, tt.wantSubs, resp)
 			}
@@ -213,6 +406,33 @@ func TestSubscriptionController_GetAllSubscriptions(t *testing.T) {
 	}
 }
 
+func TestSubscriptionController_GetAllSubscriptions_RequiresAdmin(t *testing.T) {
+	svc := mocks.NewMockSubscriptionServiceExternal(t)
+	handler := controllers.NewSubscriptionController(
+		svc,
+		mocks.NewMockForecastService(t),
+		mocks.NewMockReminderSnoozeService(t),
+		mocks.NewMockExportServiceExternal(t),
+		mocks.NewMockCatalogServiceExternal(t),
+		endpoint.NewRequestHandler(validator.New()),
+		forbidAll,
+		allowAll,
+	)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, injectUserID(httptest.NewRequest(http.MethodGet, "/", nil), defaultUserHex))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	// A user's own listing is not behind the guard.
+	svc.EXPECT().
+		GetSubscriptionsByUserID(mock.Anything, defaultUserHex, defaultUserHex, false, models.SubscriptionQuery{}).
+		Return(&models.SubscriptionPage{}, nil).
+		Once()
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, injectUserID(httptest.NewRequest(http.MethodGet, "/user/"+defaultUserHex, nil), defaultUserHex))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
 // ---------------------------------------------------------------------------
 // GET /user/{id}
 // ---------------------------------------------------------------------------
@@ -228,8 +448,8 @@ func TestSubscriptionController_GetSubscriptionsByUserID(t *testing.T) {
 			name: "success - parses URL param and context, calls service",
 			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
 				svc.EXPECT().
-					GetSubscriptionsByUserID(mock.Anything, defaultUserHex, defaultUserHex).
-					Return(validSubs(), nil).
+					GetSubscriptionsByUserID(mock.Anything, defaultUserHex, defaultUserHex, false, models.SubscriptionQuery{}).
+					Return(&models.SubscriptionPage{Items: validSubs(), Total: 2}, nil).
 					Once()
 			},
 			wantStatus: http.StatusOK,
@@ -239,8 +459,8 @@ func TestSubscriptionController_GetSubscriptionsByUserID(t *testing.T) {
 			name: "Success - empty list and returns 200 OK",
 			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
 				svc.EXPECT().
-					GetSubscriptionsByUserID(mock.Anything, defaultUserHex, defaultUserHex).
-					Return(nil, nil).
+					GetSubscriptionsByUserID(mock.Anything, defaultUserHex, defaultUserHex, false, models.SubscriptionQuery{}).
+					Return(&models.SubscriptionPage{}, nil).
 					Once()
 			},
 			wantStatus: http.StatusOK,
@@ -249,7 +469,7 @@ func TestSubscriptionController_GetSubscriptionsByUserID(t *testing.T) {
 		{
 			name: "error - propagates service error",
 			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
-				svc.EXPECT().GetSubscriptionsByUserID(mock.Anything, defaultUserHex, defaultUserHex).Return(nil, errors.New("db error")).Once()
+				svc.EXPECT().GetSubscriptionsByUserID(mock.Anything, defaultUserHex, defaultUserHex, false, mock.Anything).Return(nil, errors.New("db error")).Once()
 			},
 			wantStatus: http.StatusInternalServerError,
 		},
@@ -270,7 +490,7 @@ func TestSubscriptionController_GetSubscriptionsByUserID(t *testing.T) {
 			require.Equal(t, tt.wantStatus, rr.Code)
 			if tt.wantSubs != nil {
 				var resp []*models.SubscriptionResponse
-				err := json.NewDecoder(rr.Body).Decode(&resp)
+				err := decodeData(rr.Body, &resp)
 				require.NoError(t, err)
 				assert.ElementsMatch(t, tt.wantSubs, resp)
 			}
@@ -278,6 +498,63 @@ func TestSubscriptionController_GetSubscriptionsByUserID(t *testing.T) {
 	}
 }
 
+func TestSubscriptionController_GetSubscriptionsByUserID_IncludeArchived(t *testing.T) {
+	svc, handler := setupSubscriptionController(t)
+	svc.EXPECT().
+		GetSubscriptionsByUserID(mock.Anything, defaultUserHex, defaultUserHex, true, models.SubscriptionQuery{}).
+		Return(&models.SubscriptionPage{Items: validSubs(), Total: 2}, nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/user/"+defaultUserHex+"?include_archived=true", nil)
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestSubscriptionController_ListQuery(t *testing.T) {
+	t.Run("success - passes filters, sort, and page to the service", func(t *testing.T) {
+		svc, handler := setupSubscriptionController(t)
+		svc.EXPECT().
+			GetSubscriptionsByUserID(mock.Anything, defaultUserHex, defaultUserHex, false, models.SubscriptionQuery{
+				Status:     models.Active,
+				Category:   models.Entertainment,
+				Frequency:  models.Monthly,
+				Tag:        "work",
+				Sort:       models.SortByPrice,
+				Descending: true,
+				PageQuery:  models.PageQuery{Limit: 10, Offset: 20},
+			}).
+			Return(&models.SubscriptionPage{Items: validSubs(), Total: 42}, nil).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/user/"+defaultUserHex+
+			"?status=active&category=entertainment&frequency=monthly&tag=work&sort=price&order=desc&limit=10&offset=20", nil)
+		req = injectUserID(req, defaultUserHex)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "42", rr.Header().Get("X-Total-Count"))
+	})
+
+	for _, raw := range []string{"limit=ten", "limit=0", "offset=-1", "order=up"} {
+		t.Run("error - rejects "+raw, func(t *testing.T) {
+			_, handler := setupSubscriptionController(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/?"+raw, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // GET /{subscriptionID}
 // ---------------------------------------------------------------------------
@@ -293,7 +570,7 @@ func TestSubscriptionController_GetSubscriptionByID(t *testing.T) {
 			name: "success - extracts ID via middleware, context via auth, calls service",
 			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
 				svc.EXPECT().
-					GetSubscriptionByID(mock.Anything, defaultSubHex, defaultUserHex).
+					GetSubscriptionByID(mock.Anything, defaultSubHex, defaultUserHex, false).
 					Return(validSub(), nil).
 					Once()
 			},
@@ -304,7 +581,7 @@ func TestSubscriptionController_GetSubscriptionByID(t *testing.T) {
 			name: "error - propagates service error",
 			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
 				svc.EXPECT().
-					GetSubscriptionByID(mock.Anything, defaultSubHex, defaultUserHex).
+					GetSubscriptionByID(mock.Anything, defaultSubHex, defaultUserHex, false).
 					Return(nil, apperror.NewNotFoundError("not found")).
 					Once()
 			},
@@ -328,12 +605,76 @@ func TestSubscriptionController_GetSubscriptionByID(t *testing.T) {
 			require.Equal(t, tt.wantStatus, rr.Code)
 			if tt.wantSub != nil {
 				var resp *models.SubscriptionResponse
-				err := json.NewDecoder(rr.Body).Decode(&resp)
+				err := decodeData(rr.Body, &resp)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantSub, resp)
 			}
 		})
 	}
+
+	t.Run("conditional - If-None-Match with the ETag returns 304", func(t *testing.T) {
+		svc, handler := setupSubscriptionController(t)
+		svc.EXPECT().
+			GetSubscriptionByID(mock.Anything, defaultSubHex, defaultUserHex, false).
+			Return(validSub(), nil).
+			Twice()
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, injectUserID(httptest.NewRequest(http.MethodGet, "/"+defaultSubHex, nil), defaultUserHex))
+		require.Equal(t, http.StatusOK, rr.Code)
+		etag := rr.Header().Get("ETag")
+		wantETag, err := endpoint.ETag(validSubResponse())
+		require.NoError(t, err)
+		assert.Equal(t, wantETag, etag)
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/"+defaultSubHex, nil), defaultUserHex)
+		req.Header.Set("If-None-Match", etag)
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotModified, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GET /{subscriptionID}/events
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_GetLifecycleEvents(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, handler := setupSubscriptionController(t)
+		events := []*models.LifecycleEvent{
+			models.NewLifecycleEvent(validSub(), models.LifecycleCreated, models.ActorUser, "Subscription created", mockTime),
+			models.NewLifecycleEvent(validSub(), models.LifecycleReminderSent, models.ActorSystem, "Renewal reminder sent 3 days before renewal", mockTime),
+		}
+		svc.EXPECT().GetLifecycleEvents(mock.Anything, defaultSubHex, defaultUserHex).Return(events, nil).Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/"+defaultSubHex+"/events", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp []models.LifecycleEventResponse
+		require.NoError(t, decodeData(rr.Body, &resp))
+		require.Len(t, resp, 2)
+		assert.Equal(t, "created", resp[0].Type)
+		assert.Equal(t, "user", resp[0].Actor)
+		assert.Equal(t, "system", resp[1].Actor)
+	})
+
+	t.Run("error - not the owner", func(t *testing.T) {
+		svc, handler := setupSubscriptionController(t)
+		svc.EXPECT().
+			GetLifecycleEvents(mock.Anything, defaultSubHex, defaultUserHex).
+			Return(nil, apperror.NewForbiddenError("You are not allowed to view this subscription")).
+			Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/"+defaultSubHex+"/events", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
 }
 
 // ---------------------------------------------------------------------------
@@ -386,7 +727,7 @@ func TestSubscriptionController_CancelSubscription(t *testing.T) {
 			require.Equal(t, tt.wantStatus, rr.Code)
 			if tt.wantSub != nil {
 				var resp *models.SubscriptionResponse
-				err := json.NewDecoder(rr.Body).Decode(&resp)
+				err := decodeData(rr.Body, &resp)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantSub, resp)
 			}
@@ -395,31 +736,46 @@ func TestSubscriptionController_CancelSubscription(t *testing.T) {
 }
 
 // ---------------------------------------------------------------------------
-// DELETE /{subscriptionID}
+// PUT /{subscriptionID}/pause and /resume
 // ---------------------------------------------------------------------------
 
-func TestSubscriptionController_DeleteSubscription(t *testing.T) {
+func TestSubscriptionController_PauseResume(t *testing.T) {
 	tests := []struct {
 		name       string
+		path       string
 		setupMocks func(svc *mocks.MockSubscriptionServiceExternal)
 		wantStatus int
 	}{
 		{
-			name: "success - calls service and returns 204 No Content",
+			name: "success - pause",
+			path: "/pause",
 			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
 				svc.EXPECT().
-					DeleteSubscription(mock.Anything, defaultSubHex, defaultUserHex).
-					Return(nil).
+					PauseSubscription(mock.Anything, defaultSubHex, defaultUserHex).
+					Return(validSub(), nil).
 					Once()
 			},
-			wantStatus: http.StatusNoContent,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "success - resume",
+			path: "/resume",
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().
+					ResumeSubscription(mock.Anything, defaultSubHex, defaultUserHex).
+					Return(validSub(), nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
 		},
 		{
 			name: "error - propagates service error",
+			path: "/resume",
 			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
 				svc.EXPECT().
-					DeleteSubscription(mock.Anything, defaultSubHex, defaultUserHex).
-					Return(apperror.NewConflictError("not expired")).Once()
+					ResumeSubscription(mock.Anything, defaultSubHex, defaultUserHex).
+					Return(nil, apperror.NewConflictError("Only paused subscriptions can be resumed")).
+					Once()
 			},
 			wantStatus: http.StatusConflict,
 		},
@@ -430,16 +786,812 @@ func TestSubscriptionController_DeleteSubscription(t *testing.T) {
 			svc, handler := setupSubscriptionController(t)
 			tt.setupMocks(svc)
 
-			req := httptest.NewRequest(http.MethodDelete, "/"+defaultSubHex, nil)
+			req := httptest.NewRequest(http.MethodPut, "/"+defaultSubHex+tt.path, nil)
 			req = injectUserID(req, defaultUserHex)
 			rr := httptest.NewRecorder()
 
 			handler.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.wantStatus, rr.Code)
-			if tt.wantStatus == http.StatusNoContent {
-				assert.Empty(t, rr.Body)
+			if tt.wantStatus == http.StatusOK {
+				var resp *models.SubscriptionResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				assert.Equal(t, validSubResponse(), resp)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// POST /{subscriptionID}/usage
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_RecordUsage(t *testing.T) {
+	usedAt := mockTime.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		body       string
+		setupMocks func(svc *mocks.MockSubscriptionServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success - empty object means now",
+			body: `{}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().
+					RecordUsage(mock.Anything, defaultSubHex, defaultUserHex, time.Time{}).
+					Return(validSub(), nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "success - explicit usage time",
+			body: `{"usedAt":"` + usedAt.Format(time.RFC3339) + `"}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().
+					RecordUsage(mock.Anything, defaultSubHex, defaultUserHex, mock.MatchedBy(usedAt.Equal)).
+					Return(validSub(), nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "error - propagates service error",
+			body: `{}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().
+					RecordUsage(mock.Anything, defaultSubHex, defaultUserHex, time.Time{}).
+					Return(nil, apperror.NewForbiddenError("not yours")).
+					Once()
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupSubscriptionController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodPost, "/"+defaultSubHex+"/usage", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// PATCH /{subscriptionID}
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_UpdateSubscription(t *testing.T) {
+	currentETag, err := endpoint.ETag(validSub().ToResponse())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		body       string
+		ifMatch    string
+		setupMocks func(svc *mocks.MockSubscriptionServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success - passes only the given fields",
+			body: `{"notes":"","reminderDays":[14,2]}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				updated := validSub()
+				updated.ReminderDays = []int{14, 2}
+				svc.EXPECT().
+					UpdateSubscription(mock.Anything, defaultSubHex, defaultUserHex, mock.MatchedBy(func(u *models.SubscriptionUpdateRequest) bool {
+						return u.Name == nil && u.Tags == nil && u.Notes != nil && *u.Notes == "" &&
+							u.ReminderDays != nil && len(*u.ReminderDays) == 2
+					})).
+					Return(updated, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "success - If-Match lists the current ETag",
+			body:    `{"reminderDays":[14,2]}`,
+			ifMatch: currentETag,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				updated := validSub()
+				updated.ReminderDays = []int{14, 2}
+				current := validSub()
+				current.Version = 3
+				svc.EXPECT().GetSubscriptionByID(mock.Anything, defaultSubHex, defaultUserHex, false).Return(current, nil).Once()
+				svc.EXPECT().
+					UpdateSubscription(mock.MatchedBy(func(ctx context.Context) bool {
+						version, ok := appctx.GetExpectedVersion(ctx)
+						return ok && version == 3
+					}), defaultSubHex, defaultUserHex, mock.Anything).
+					Return(updated, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "error - stale If-Match",
+			body:    `{"reminderDays":[14,2]}`,
+			ifMatch: `"stale"`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().GetSubscriptionByID(mock.Anything, defaultSubHex, defaultUserHex, false).Return(validSub(), nil).Once()
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "error - name too short",
+			body:       `{"name":"N"}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - propagates service error",
+			body: `{"tags":["work"]}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().
+					UpdateSubscription(mock.Anything, defaultSubHex, defaultUserHex, mock.Anything).
+					Return(nil, apperror.NewForbiddenError("You are not allowed to update this subscription")).
+					Once()
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupSubscriptionController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodPatch, "/"+defaultSubHex, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp models.SubscriptionResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				assert.Equal(t, []int{14, 2}, resp.ReminderDays)
 			}
 		})
 	}
 }
+
+// ---------------------------------------------------------------------------
+// PUT /{subscriptionID}/price
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_UpdatePrice(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setupMocks func(svc *mocks.MockSubscriptionServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: `{"price":1199}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				updated := validSub()
+				updated.Price = 1199
+				svc.EXPECT().
+					UpdatePrice(mock.Anything, defaultSubHex, defaultUserHex, int64(1199), time.Time{}).
+					Return(updated, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "success - scheduled from an effective date",
+			body: `{"price":1199,"effectiveAt":"2025-07-01T00:00:00Z"}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				updated := validSub()
+				updated.Price = 1199
+				svc.EXPECT().
+					UpdatePrice(mock.Anything, defaultSubHex, defaultUserHex, int64(1199), time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)).
+					Return(updated, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error - missing price",
+			body:       `{}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - propagates service error",
+			body: `{"price":1199}`,
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().
+					UpdatePrice(mock.Anything, defaultSubHex, defaultUserHex, int64(1199), time.Time{}).
+					Return(nil, apperror.NewConflictError("Only active subscriptions can be repriced")).
+					Once()
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupSubscriptionController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodPut, "/"+defaultSubHex+"/price", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp models.SubscriptionResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				assert.Equal(t, int64(1199), resp.Price)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /{id}/price-history
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_GetPriceHistory(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, handler := setupSubscriptionController(t)
+		effective := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+		history := []*models.PriceChange{{
+			ID:          bson.NewObjectID(),
+			Name:        "Netflix",
+			OldPrice:    999,
+			NewPrice:    1199,
+			Currency:    models.USD,
+			Frequency:   models.Monthly,
+			EffectiveAt: effective,
+		}}
+		svc.EXPECT().GetPriceHistory(mock.Anything, defaultSubHex, defaultUserHex).Return(history, nil).Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/"+defaultSubHex+"/price-history", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp []models.PriceChangeResponse
+		require.NoError(t, decodeData(rr.Body, &resp))
+		require.Len(t, resp, 1)
+		assert.Equal(t, int64(1199), resp[0].NewPrice.Amount)
+		assert.True(t, effective.Equal(resp[0].EffectiveAt))
+	})
+
+	t.Run("error - propagates service error", func(t *testing.T) {
+		svc, handler := setupSubscriptionController(t)
+		svc.EXPECT().
+			GetPriceHistory(mock.Anything, defaultSubHex, defaultUserHex).
+			Return(nil, apperror.NewNotFoundError("Subscription not found")).
+			Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/"+defaultSubHex+"/price-history", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GET /price-changes
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_GetPriceChangeSummary(t *testing.T) {
+	svc, handler := setupSubscriptionController(t)
+	summary := &models.PriceChangeSummaryResponse{
+		Month:        "2025-07",
+		Increases:    []*models.PriceChangeResponse{{SubscriptionID: defaultSubHex, Name: "Netflix", PercentChange: 12}},
+		AnnualImpact: []models.CurrencyAmount{models.NewCurrencyAmount(2400, models.USD)},
+	}
+	svc.EXPECT().GetPriceChangeSummary(mock.Anything, defaultUserHex, "2025-07").Return(summary, nil).Once()
+
+	req := injectUserID(httptest.NewRequest(http.MethodGet, "/price-changes?month=2025-07", nil), defaultUserHex)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp models.PriceChangeSummaryResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	assert.Equal(t, summary.AnnualImpact, resp.AnnualImpact)
+	require.Len(t, resp.Increases, 1)
+	assert.Equal(t, "Netflix", resp.Increases[0].Name)
+}
+
+// ---------------------------------------------------------------------------
+// GET /summary
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_GetSummary(t *testing.T) {
+	svc, handler := setupSubscriptionController(t)
+	summary := &models.SubscriptionSummaryResponse{
+		MonthlySpend: []models.CurrencyAmount{models.NewCurrencyAmount(2998, models.USD)},
+		Counts:       models.SubscriptionCounts{Active: 2, Canceled: 1},
+	}
+	svc.EXPECT().GetSummary(mock.Anything, defaultUserHex, models.EUR).Return(summary, nil).Once()
+
+	req := injectUserID(httptest.NewRequest(http.MethodGet, "/summary?currency=eur", nil), defaultUserHex)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp models.SubscriptionSummaryResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	assert.Equal(t, summary.MonthlySpend, resp.MonthlySpend)
+	assert.Equal(t, summary.Counts, resp.Counts)
+}
+
+// ---------------------------------------------------------------------------
+// GET /duplicates
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_GetDuplicates(t *testing.T) {
+	svc, handler := setupSubscriptionController(t)
+	svc.EXPECT().GetDuplicateSubscriptions(mock.Anything, defaultUserHex).
+		Return([]*models.DuplicateGroup{{Name: "Netflix", Subscriptions: validSubs()}}, nil).
+		Once()
+
+	req := injectUserID(httptest.NewRequest(http.MethodGet, "/duplicates", nil), defaultUserHex)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp []models.DuplicateGroupResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	require.Len(t, resp, 1)
+	assert.Equal(t, "Netflix", resp[0].Name)
+	assert.Equal(t, validSubsResponse(), resp[0].Subscriptions)
+}
+
+// ---------------------------------------------------------------------------
+// GET /search
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_SearchSubscriptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		setupMocks func(svc *mocks.MockSubscriptionServiceExternal)
+		wantStatus int
+	}{
+		{
+			name:   "success - passes the text and page",
+			target: "/search?q=stream+music&limit=10&offset=20",
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().
+					SearchSubscriptions(mock.Anything, defaultUserHex, models.SubscriptionSearch{Text: "stream music", PageQuery: models.PageQuery{Limit: 10, Offset: 20}}).
+					Return(&models.SubscriptionSearchPage{
+						Items: []*models.SubscriptionMatch{{
+							Subscription: *validSub(),
+							Score:        10.5,
+							Highlights: []models.Highlight{{Field: "name", Fragments: []models.HighlightFragment{
+								{Text: "Netflix", Match: true},
+							}}},
+						}},
+						Total: 21,
+					}, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error - invalid limit",
+			target:     "/search?q=netflix&limit=0",
+			setupMocks: func(*mocks.MockSubscriptionServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "error - propagates service error",
+			target: "/search",
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().
+					SearchSubscriptions(mock.Anything, defaultUserHex, models.SubscriptionSearch{}).
+					Return(nil, apperror.NewValidationError("search text is required")).
+					Once()
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupSubscriptionController(t)
+			tt.setupMocks(svc)
+
+			req := injectUserID(httptest.NewRequest(http.MethodGet, tt.target, nil), defaultUserHex)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "21", rr.Header().Get("X-Total-Count"))
+				var resp []models.SubscriptionMatchResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				require.Len(t, resp, 1)
+				assert.Equal(t, validSubResponse(), resp[0].SubscriptionResponse)
+				assert.Equal(t, 10.5, resp[0].Score)
+				assert.Equal(t, []models.HighlightResponse{{Field: "name", Fragments: []models.HighlightFragment{
+					{Text: "Netflix", Match: true},
+				}}}, resp[0].Highlights)
+			}
+		})
+	}
+}
+
+func TestSubscriptionController_SearchAllSubscriptions(t *testing.T) {
+	svc, handler := setupSubscriptionController(t)
+	svc.EXPECT().
+		SearchAllSubscriptions(mock.Anything, models.SubscriptionSearch{Text: "netflix"}).
+		Return(&models.SubscriptionSearchPage{Items: []*models.SubscriptionMatch{{Subscription: *validSub()}}, Total: 1}, nil).
+		Once()
+
+	req := injectUserID(httptest.NewRequest(http.MethodGet, "/search/all?q=netflix", nil), defaultUserHex)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("X-Total-Count"))
+	var resp []models.SubscriptionMatchResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	require.Len(t, resp, 1)
+	assert.Empty(t, resp[0].Highlights)
+}
+
+func TestSubscriptionController_SearchAllSubscriptions_RequiresAdmin(t *testing.T) {
+	handler := controllers.NewSubscriptionController(
+		mocks.NewMockSubscriptionServiceExternal(t),
+		mocks.NewMockForecastService(t),
+		mocks.NewMockReminderSnoozeService(t),
+		mocks.NewMockExportServiceExternal(t),
+		mocks.NewMockCatalogServiceExternal(t),
+		endpoint.NewRequestHandler(validator.New()),
+		forbidAll,
+		allowAll,
+	)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, injectUserID(httptest.NewRequest(http.MethodGet, "/search/all?q=netflix", nil), defaultUserHex))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+// ---------------------------------------------------------------------------
+// POST /{subscriptionID}/reminders/snooze
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_SnoozeReminders(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		setupMocks func(svc *mocks.MockReminderSnoozeService)
+		wantStatus int
+	}{
+		{
+			name:  "success - for some days",
+			query: "?days=3",
+			setupMocks: func(svc *mocks.MockReminderSnoozeService) {
+				svc.EXPECT().
+					SnoozeReminders(mock.Anything, defaultSubHex, defaultUserHex, 3).
+					Return(&models.ReminderSnoozeResponse{SubscriptionID: defaultSubHex}, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "success - until the renewal",
+			setupMocks: func(svc *mocks.MockReminderSnoozeService) {
+				svc.EXPECT().
+					SnoozeReminders(mock.Anything, defaultSubHex, defaultUserHex, 0).
+					Return(&models.ReminderSnoozeResponse{SubscriptionID: defaultSubHex}, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error - days not a positive integer",
+			query:      "?days=0",
+			setupMocks: func(svc *mocks.MockReminderSnoozeService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "error - propagates service error",
+			query: "?days=3",
+			setupMocks: func(svc *mocks.MockReminderSnoozeService) {
+				svc.EXPECT().
+					SnoozeReminders(mock.Anything, defaultSubHex, defaultUserHex, 3).
+					Return(nil, apperror.NewConflictError("Only active subscriptions send reminders")).
+					Once()
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupReminderSnoozeController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodPost, "/"+defaultSubHex+"/reminders/snooze"+tt.query, nil)
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp models.ReminderSnoozeResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				assert.Equal(t, defaultSubHex, resp.SubscriptionID)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// DELETE /{subscriptionID}/reminders/snooze
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_UnsnoozeReminders(t *testing.T) {
+	svc, handler := setupReminderSnoozeController(t)
+	svc.EXPECT().UnsnoozeReminders(mock.Anything, defaultSubHex, defaultUserHex).Return(nil).Once()
+
+	req := injectUserID(httptest.NewRequest(http.MethodDelete, "/"+defaultSubHex+"/reminders/snooze", nil), defaultUserHex)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+// ---------------------------------------------------------------------------
+// DELETE /{subscriptionID}
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_DeleteSubscription(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func(svc *mocks.MockSubscriptionServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success - calls service and returns 204 No Content",
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().
+					DeleteSubscription(mock.Anything, defaultSubHex, defaultUserHex).
+					Return(nil).
+					Once()
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "error - propagates service error",
+			setupMocks: func(svc *mocks.MockSubscriptionServiceExternal) {
+				svc.EXPECT().
+					DeleteSubscription(mock.Anything, defaultSubHex, defaultUserHex).
+					Return(apperror.NewConflictError("not expired")).Once()
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupSubscriptionController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodDelete, "/"+defaultSubHex, nil)
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusNoContent {
+				assert.Empty(t, rr.Body)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /forecast
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_GetForecast(t *testing.T) {
+	forecast := &models.ForecastResponse{
+		Months: 6,
+		Totals: []models.CurrencyAmount{models.NewCurrencyAmount(5994, models.USD)},
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMocks func(svc *mocks.MockForecastService)
+		wantStatus int
+	}{
+		{
+			name:  "success - defaults to 12 months",
+			query: "",
+			setupMocks: func(svc *mocks.MockForecastService) {
+				svc.EXPECT().
+					GetForecast(mock.Anything, defaultUserHex, 12, models.Currency("")).
+					Return(forecast, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "success - passes months and currency through",
+			query: "?months=6&currency=eur",
+			setupMocks: func(svc *mocks.MockForecastService) {
+				svc.EXPECT().
+					GetForecast(mock.Anything, defaultUserHex, 6, models.EUR).
+					Return(forecast, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error - months is not an integer",
+			query:      "?months=six",
+			setupMocks: func(svc *mocks.MockForecastService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "error - propagates service error",
+			query: "?months=100",
+			setupMocks: func(svc *mocks.MockForecastService) {
+				svc.EXPECT().
+					GetForecast(mock.Anything, defaultUserHex, 100, models.Currency("")).
+					Return(nil, apperror.NewValidationError("months must be between 1 and 36")).
+					Once()
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupForecastController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodGet, "/forecast"+tt.query, nil)
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp models.ForecastResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				assert.Equal(t, forecast.Totals, resp.Totals)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /calendar
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_GetCalendar(t *testing.T) {
+	t.Run("success - passes month through", func(t *testing.T) {
+		svc, handler := setupForecastController(t)
+		calendar := &models.RenewalCalendarResponse{
+			Month: "2025-07",
+			Days: []models.CalendarDay{{
+				Date:     "2025-07-15",
+				Renewals: []models.UpcomingRenewal{{SubscriptionID: defaultSubHex, Name: "Netflix", Amount: 999, Currency: "USD"}},
+				Totals:   []models.CurrencyAmount{models.NewCurrencyAmount(999, models.USD)},
+			}},
+			Totals: []models.CurrencyAmount{models.NewCurrencyAmount(999, models.USD)},
+		}
+		svc.EXPECT().GetRenewalCalendar(mock.Anything, defaultUserHex, "2025-07").Return(calendar, nil).Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/calendar?month=2025-07", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp models.RenewalCalendarResponse
+		require.NoError(t, decodeData(rr.Body, &resp))
+		assert.Equal(t, *calendar, resp)
+	})
+
+	t.Run("error - propagates service error", func(t *testing.T) {
+		svc, handler := setupForecastController(t)
+		svc.EXPECT().
+			GetRenewalCalendar(mock.Anything, defaultUserHex, "July").
+			Return(nil, apperror.NewValidationError("month must be formatted as YYYY-MM")).
+			Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/calendar?month=July", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GET /export
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionController_ExportSubscriptions(t *testing.T) {
+	t.Run("success - streams the filtered export as an attachment", func(t *testing.T) {
+		svc, handler := setupExportController(t)
+		want := models.ExportRequest{
+			Kind:       models.SubscriptionExport,
+			Format:     models.XLSXFormat,
+			Status:     models.Active,
+			Sort:       models.SortByPrice,
+			Descending: true,
+		}
+		svc.EXPECT().Export(mock.Anything, defaultUserHex, want).Return(&models.Export{
+			Request:     want,
+			Filename:    "subscriptions-2026-03-14.xlsx",
+			ContentType: models.XLSXFormat.ContentType(),
+			Rows:        2,
+			Body:        io.NopCloser(strings.NewReader("PK")),
+		}, nil).Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/export?format=xlsx&status=active&sort=price&order=desc", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "attachment; filename=subscriptions-2026-03-14.xlsx", rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, models.XLSXFormat.ContentType(), rr.Header().Get("Content-Type"))
+		assert.Equal(t, "PK", rr.Body.String())
+	})
+
+	t.Run("success - queues a large export", func(t *testing.T) {
+		svc, handler := setupExportController(t)
+		want := models.ExportRequest{Kind: models.SubscriptionExport, Format: models.CSVFormat}
+		svc.EXPECT().Export(mock.Anything, defaultUserHex, want).
+			Return(&models.Export{Request: want, Rows: 20000}, nil).
+			Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/export", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusAccepted, rr.Code)
+		assert.JSONEq(t, `{"data":{"kind":"subscriptions","format":"csv","rows":20000}}`, rr.Body.String())
+	})
+
+	t.Run("error - propagates service error", func(t *testing.T) {
+		svc, handler := setupExportController(t)
+		svc.EXPECT().
+			Export(mock.Anything, defaultUserHex, models.ExportRequest{Kind: models.SubscriptionExport, Format: "pdf"}).
+			Return(nil, apperror.NewValidationError("format must be one of csv, xlsx")).
+			Once()
+
+		req := injectUserID(httptest.NewRequest(http.MethodGet, "/export?format=pdf", nil), defaultUserHex)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
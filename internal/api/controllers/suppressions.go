@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type suppressionController struct {
+	suppressionService services.SuppressionServiceExternal
+	requestHandler     *endpoint.RequestHandler
+}
+
+// NewSuppressionController serves the list of email addresses no email is
+// sent to. It is meant for administrators.
+func NewSuppressionController(
+	suppressionService services.SuppressionServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+) http.Handler {
+	c := &suppressionController{suppressionService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Get("/", c.getSuppressions)
+	r.Post("/", c.addSuppression)
+	r.Delete("/{email}", c.deleteSuppression)
+	return r
+}
+
+// SuppressionOperations documents the routes of NewSuppressionController.
+var SuppressionOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/", Summary: "List suppressed email addresses, newest first", Query: []openapi.Param{limitParam, offsetParam}, Response: []models.SuppressionResponse{}},
+	{Method: http.MethodPost, Path: "/", Summary: "Suppress an email address", Request: models.SuppressionRequest{}, Response: models.SuppressionResponse{}, Status: http.StatusCreated},
+	{Method: http.MethodDelete, Path: "/{email}", Summary: "Lift the suppression of an email address", Status: http.StatusNoContent},
+}
+
+// getSuppressions returns a page of suppressions, newest first, with the
+// total in meta.pagination.
+func (c *suppressionController) getSuppressions(w http.ResponseWriter, r *http.Request) {
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			limit, offset, err := pageParams(r)
+			if err != nil {
+				return nil, err
+			}
+			page, err := c.suppressionService.GetSuppressions(
+				r.Context(),
+				models.SuppressionQuery{PageQuery: models.PageQuery{Limit: limit, Offset: offset}},
+			)
+			if err != nil {
+				return nil, err
+			}
+			return pageResponse(page.Items, page.Total, limit, offset)
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *suppressionController) addSuppression(w http.ResponseWriter, r *http.Request) {
+	suppression := models.SuppressionRequest{}
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &suppression,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.suppressionService.AddSuppression(r.Context(), suppression.ToModel()))
+		},
+		SuccessCode: http.StatusCreated,
+	})
+}
+
+func (c *suppressionController) deleteSuppression(w http.ResponseWriter, r *http.Request) {
+	email := chi.URLParam(r, "email")
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return nil, c.suppressionService.DeleteSuppression(r.Context(), email)
+		},
+		SuccessCode: http.StatusNoContent,
+	})
+}
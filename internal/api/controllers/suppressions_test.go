@@ -0,0 +1,173 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSuppressionController(t *testing.T) (*mocks.MockSuppressionServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockSuppressionServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	return svc, controllers.NewSuppressionController(svc, reqHandler)
+}
+
+func validSuppression() *models.Suppression {
+	return &models.Suppression{
+		Email:     "gone@example.com",
+		Reason:    models.SuppressionBounced,
+		Detail:    "550 No such user",
+		CreatedAt: mockTime,
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /
+// ---------------------------------------------------------------------------
+
+func TestSuppressionController_GetSuppressions(t *testing.T) {
+	t.Run("success - passes the page and sets the total", func(t *testing.T) {
+		svc, handler := setupSuppressionController(t)
+		svc.EXPECT().
+			GetSuppressions(mock.Anything, models.SuppressionQuery{PageQuery: models.PageQuery{Limit: 10, Offset: 20}}).
+			Return(&models.SuppressionPage{Items: []*models.Suppression{validSuppression()}, Total: 21}, nil).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/?limit=10&offset=20", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "21", rr.Header().Get("X-Total-Count"))
+		var resp []models.SuppressionResponse
+		require.NoError(t, decodeData(rr.Body, &resp))
+		require.Len(t, resp, 1)
+		assert.Equal(t, "gone@example.com", resp[0].Email)
+		assert.Equal(t, "bounced", resp[0].Reason)
+	})
+
+	t.Run("success - reports the default page size", func(t *testing.T) {
+		svc, handler := setupSuppressionController(t)
+		svc.EXPECT().
+			GetSuppressions(mock.Anything, models.SuppressionQuery{}).
+			Return(&models.SuppressionPage{}, nil).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"data":[],"meta":{"pagination":{"total":0,"limit":%d,"offset":0}}}`, models.DefaultPageLimit),
+			rr.Body.String())
+	})
+
+	t.Run("error - invalid limit", func(t *testing.T) {
+		_, handler := setupSuppressionController(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/?limit=abc", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// POST /
+// ---------------------------------------------------------------------------
+
+func TestSuppressionController_AddSuppression(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       any
+		setupMocks func(svc *mocks.MockSuppressionServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success - normalizes the address",
+			body: models.SuppressionRequest{Email: "Gone@Example.com", Reason: models.SuppressionComplained},
+			setupMocks: func(svc *mocks.MockSuppressionServiceExternal) {
+				svc.EXPECT().
+					AddSuppression(mock.Anything, &models.Suppression{Email: "gone@example.com", Reason: models.SuppressionComplained}).
+					Return(validSuppression(), nil).
+					Once()
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "error - unknown reason",
+			body:       models.SuppressionRequest{Email: "gone@example.com", Reason: "annoyed"},
+			setupMocks: func(svc *mocks.MockSuppressionServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - invalid email",
+			body:       models.SuppressionRequest{Email: "gone", Reason: models.SuppressionBounced},
+			setupMocks: func(svc *mocks.MockSuppressionServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupSuppressionController(t)
+			tt.setupMocks(svc)
+
+			body, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// DELETE /{email}
+// ---------------------------------------------------------------------------
+
+func TestSuppressionController_DeleteSuppression(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, handler := setupSuppressionController(t)
+		svc.EXPECT().DeleteSuppression(mock.Anything, "gone@example.com").Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/gone@example.com", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("error - not suppressed", func(t *testing.T) {
+		svc, handler := setupSuppressionController(t)
+		svc.EXPECT().
+			DeleteSuppression(mock.Anything, "here@example.com").
+			Return(apperror.NewNotFoundError("Suppression not found")).
+			Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/here@example.com", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
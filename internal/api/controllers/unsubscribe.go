@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type unsubscribeController struct {
+	unsubscribeService services.UnsubscribeServiceExternal
+	requestHandler     *endpoint.RequestHandler
+}
+
+// NewUnsubscribeController serves the unsubscribe links in the footer of
+// every email. Like the cancel links, its routes are authorized by the link
+// signature and must be mounted outside the authenticated group.
+func NewUnsubscribeController(
+	unsubscribeService services.UnsubscribeServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+) http.Handler {
+	c := &unsubscribeController{unsubscribeService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Get("/", c.unsubscribe)
+	// Mail clients unsubscribe with a POST to the link of the
+	// List-Unsubscribe header (RFC 8058).
+	r.Post("/", c.unsubscribe)
+	return r
+}
+
+// UnsubscribeOperations documents the routes of NewUnsubscribeController.
+var UnsubscribeOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/", Summary: "Unsubscribe from a notification channel with a signed link", Public: true, Query: unsubscribeParams, Response: models.NotificationPreferencesResponse{}},
+	{Method: http.MethodPost, Path: "/", Summary: "Unsubscribe from a notification channel in one click", Public: true, Query: unsubscribeParams, Response: models.NotificationPreferencesResponse{}},
+}
+
+var unsubscribeParams = []openapi.Param{
+	{Name: "email", Required: true, Description: "Email address the link was sent to"},
+	{Name: "channel", Required: true, Description: "Notification channel to turn off"},
+	{Name: "signature", Required: true, Description: "HMAC signature of the link"},
+}
+
+func (c *unsubscribeController) unsubscribe(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponse(c.unsubscribeService.Unsubscribe(
+				r.Context(),
+				query.Get("email"),
+				query.Get("channel"),
+				query.Get("signature"),
+			))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
@@ -0,0 +1,82 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupUnsubscribeController(t *testing.T) (*mocks.MockUnsubscribeServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockUnsubscribeServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	return svc, controllers.NewUnsubscribeController(svc, reqHandler)
+}
+
+// ---------------------------------------------------------------------------
+// GET, POST /
+// ---------------------------------------------------------------------------
+
+func TestUnsubscribeController_Unsubscribe(t *testing.T) {
+	const query = "?channel=email&email=test%40example.com&signature=abc123"
+
+	t.Run("success - passes query values, needs no token", func(t *testing.T) {
+		svc, handler := setupUnsubscribeController(t)
+		prefs := models.DefaultNotificationPreferences(defaultUserID)
+		prefs.Channels = []models.NotificationChannel{}
+		svc.EXPECT().
+			Unsubscribe(mock.Anything, "test@example.com", "email", "abc123").
+			Return(prefs, nil).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/"+query, nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp models.NotificationPreferencesResponse
+		require.NoError(t, decodeData(rr.Body, &resp))
+		assert.Empty(t, resp.Channels)
+	})
+
+	t.Run("success - one-click POST", func(t *testing.T) {
+		svc, handler := setupUnsubscribeController(t)
+		svc.EXPECT().
+			Unsubscribe(mock.Anything, "test@example.com", "email", "abc123").
+			Return(models.DefaultNotificationPreferences(defaultUserID), nil).
+			Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/"+query, strings.NewReader("List-Unsubscribe=One-Click"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("error - invalid signature", func(t *testing.T) {
+		svc, handler := setupUnsubscribeController(t)
+		svc.EXPECT().
+			Unsubscribe(mock.Anything, "", "", "").
+			Return(nil, apperror.NewUnauthorizedError("Invalid unsubscribe link")).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -4,26 +4,45 @@ import (
 	"net/http"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
 	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/anuragthepathak/subscription-management/internal/domain/services"
 	"github.com/go-chi/chi/v5"
 )
 
 type userController struct {
 	userService    services.UserServiceExternal
+	exportService  services.ExportServiceExternal
 	requestHandler *endpoint.RequestHandler
 }
 
-func NewUserController(userService services.UserServiceExternal, requestHandler *endpoint.RequestHandler) http.Handler {
-	c := &userController{userService, requestHandler}
+// NewUserController serves user profiles and exports of everything stored
+// about a user. Listing every user is guarded by requireAdmin.
+func NewUserController(
+	userService services.UserServiceExternal,
+	exportService services.ExportServiceExternal,
+	requestHandler *endpoint.RequestHandler,
+	requireAdmin func(http.Handler) http.Handler,
+) http.Handler {
+	c := &userController{userService, exportService, requestHandler}
 
 	r := chi.NewRouter()
-	r.Get("/", c.getAllUsers)
+	r.With(requireAdmin).Get("/", c.getAllUsers)
 	r.Get("/{id}", c.getUserByID)
 	r.Delete("/{id}", c.deleteUser)
+	r.Post("/{id}/export", c.exportUser)
 	return r
 }
 
+// UserOperations documents the routes of NewUserController.
+var UserOperations = []openapi.Operation{
+	{Method: http.MethodGet, Path: "/", Summary: "List every user (admin role)", Response: []models.UserResponse{}},
+	{Method: http.MethodGet, Path: "/{id}", Summary: "Get a user", Response: models.UserResponse{}},
+	{Method: http.MethodDelete, Path: "/{id}", Summary: "Close a user's account, deleting it after the grace period", Response: models.UserResponse{}, Status: http.StatusAccepted},
+	{Method: http.MethodPost, Path: "/{id}/export", Summary: "Email a ZIP archive of everything stored about a user", Response: models.QueuedExportResponse{}, Status: http.StatusAccepted},
+}
+
 func (c *userController) getAllUsers(w http.ResponseWriter, r *http.Request) {
 	c.requestHandler.ServeRequest(endpoint.InternalRequest{
 		W: w,
@@ -49,6 +68,8 @@ func (c *userController) getUserByID(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// deleteUser closes the account. It is deleted once the grace period in the
+// response's purgeAt is over, so the request is only accepted.
 func (c *userController) deleteUser(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	claimedUserID, _ := appctx.GetUserID(r.Context())
@@ -57,8 +78,28 @@ func (c *userController) deleteUser(w http.ResponseWriter, r *http.Request) {
 		W: w,
 		R: r,
 		EndpointLogic: func() (any, error) {
-			return nil, c.userService.DeleteUser(r.Context(), id, claimedUserID)
+			return endpoint.ToResponse(c.userService.DeleteUser(r.Context(), id, claimedUserID))
+		},
+		SuccessCode: http.StatusAccepted,
+	})
+}
+
+// exportUser queues the archive of the user's data. The download link is
+// emailed, so the response only acknowledges the request.
+func (c *userController) exportUser(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	claimedUserID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			export, err := c.exportService.ExportAccount(r.Context(), id, claimedUserID)
+			if err != nil {
+				return nil, err
+			}
+			return export.ToResponse(), nil
 		},
-		SuccessCode: http.StatusNoContent,
+		SuccessCode: http.StatusAccepted,
 	})
 }
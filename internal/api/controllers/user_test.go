@@ -1,11 +1,11 @@
 package controllers_test
 
 import (
-	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
@@ -35,6 +35,14 @@ func validUser() *models.User {
 	}
 }
 
+// closedUser returns validUser after closing their account.
+func closedUser() *models.User {
+	user := validUser()
+	closedAt, purgeAt := mockTime, mockTime.Add(30*24*time.Hour)
+	user.ClosedAt, user.PurgeAt = &closedAt, &purgeAt
+	return user
+}
+
 func validUserResponse() *models.UserResponse {
 	return validUser().ToResponse()
 }
@@ -45,7 +53,7 @@ func setupUserController(t *testing.T) (*mocks.MockUserServiceExternal, http.Han
 	svc := mocks.NewMockUserServiceExternal(t)
 	v := validator.New()
 	reqHandler := endpoint.NewRequestHandler(v)
-	router := controllers.NewUserController(svc, reqHandler)
+	router := controllers.NewUserController(svc, mocks.NewMockExportServiceExternal(t), reqHandler, allowAll)
 	return svc, router
 }
 
@@ -113,7 +121,7 @@ func TestUserController_GetAllUsers(t *testing.T) {
 			// Assert Payload Delivery
 			if tt.wantUsers != nil {
 				var resp []*models.UserResponse
-				err := json.NewDecoder(rr.Body).Decode(&resp)
+				err := decodeData(rr.Body, &resp)
 				require.NoError(t, err)
 				assert.ElementsMatch(t, tt.wantUsers, resp)
 			}
@@ -121,6 +129,16 @@ func TestUserController_GetAllUsers(t *testing.T) {
 	}
 }
 
+func TestUserController_GetAllUsers_RequiresAdmin(t *testing.T) {
+	svc := mocks.NewMockUserServiceExternal(t)
+	handler := controllers.NewUserController(svc, nil, endpoint.NewRequestHandler(validator.New()), forbidAll)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, injectUserID(httptest.NewRequest(http.MethodGet, "/", nil), defaultUserHex))
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
 // ---------------------------------------------------------------------------
 // GET /{id}
 // ---------------------------------------------------------------------------
@@ -171,7 +189,7 @@ func TestUserController_GetUserByID(t *testing.T) {
 			// Assert Payload Delivery
 			if tt.wantUser != nil {
 				var resp *models.UserResponse
-				err := json.NewDecoder(rr.Body).Decode(&resp)
+				err := decodeData(rr.Body, &resp)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantUser, resp)
 			}
@@ -190,20 +208,20 @@ func TestUserController_DeleteUser(t *testing.T) {
 		wantStatus int
 	}{
 		{
-			name: "success - calls service and returns 204 No Content",
+			name: "success - closes the account and returns 202 Accepted",
 			setupMocks: func(svc *mocks.MockUserServiceExternal) {
 				svc.EXPECT().
 					DeleteUser(mock.Anything, defaultUserHex, defaultUserHex).
-					Return(nil).Once()
+					Return(closedUser(), nil).Once()
 			},
-			wantStatus: http.StatusNoContent,
+			wantStatus: http.StatusAccepted,
 		},
 		{
 			name: "error - propagates service error",
 			setupMocks: func(svc *mocks.MockUserServiceExternal) {
 				svc.EXPECT().
 					DeleteUser(mock.Anything, defaultUserHex, defaultUserHex).
-					Return(errors.New("something went wrong")).
+					Return(nil, errors.New("something went wrong")).
 					Once()
 			},
 			wantStatus: http.StatusInternalServerError,
@@ -223,8 +241,70 @@ func TestUserController_DeleteUser(t *testing.T) {
 			handler.ServeHTTP(rr, req)
 			// Assert Wiring
 			require.Equal(t, tt.wantStatus, rr.Code)
-			if tt.wantStatus == http.StatusNoContent {
-				assert.Empty(t, rr.Body)
+			if tt.wantStatus == http.StatusAccepted {
+				var resp *models.UserResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				assert.Equal(t, closedUser().ToResponse(), resp)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// POST /{id}/export
+// ---------------------------------------------------------------------------
+
+func TestUserController_ExportUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func(svc *mocks.MockExportServiceExternal)
+		wantStatus int
+	}{
+		{
+			name: "success - queues the archive and returns 202 Accepted",
+			setupMocks: func(svc *mocks.MockExportServiceExternal) {
+				svc.EXPECT().
+					ExportAccount(mock.Anything, defaultUserHex, defaultUserHex).
+					Return(&models.Export{Request: models.ExportRequest{
+						UserID: defaultUserID,
+						Kind:   models.AccountExport,
+						Format: models.ZIPFormat,
+					}}, nil).
+					Once()
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name: "error - propagates service error",
+			setupMocks: func(svc *mocks.MockExportServiceExternal) {
+				svc.EXPECT().
+					ExportAccount(mock.Anything, defaultUserHex, defaultUserHex).
+					Return(nil, apperror.NewForbiddenError("You can only export your own account")).
+					Once()
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := mocks.NewMockExportServiceExternal(t)
+			tt.setupMocks(svc)
+			handler := controllers.NewUserController(
+				mocks.NewMockUserServiceExternal(t), svc, endpoint.NewRequestHandler(validator.New()), allowAll,
+			)
+
+			req := httptest.NewRequest(http.MethodPost, "/"+defaultUserHex+"/export", nil)
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusAccepted {
+				var resp models.QueuedExportResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				assert.Equal(t, models.AccountExport, resp.Kind)
+				assert.Equal(t, models.ZIPFormat, resp.Format)
 			}
 		})
 	}
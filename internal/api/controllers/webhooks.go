@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type webhookController struct {
+	webhookService services.WebhookServiceExternal
+	requestHandler *endpoint.RequestHandler
+}
+
+// NewWebhookController serves the webhooks of the authenticated user.
+func NewWebhookController(webhookService services.WebhookServiceExternal, requestHandler *endpoint.RequestHandler) http.Handler {
+	c := &webhookController{webhookService, requestHandler}
+
+	r := chi.NewRouter()
+	r.Post("/", c.createWebhook)
+	r.Get("/", c.getWebhooks)
+	r.Delete("/{webhookID}", c.deleteWebhook)
+	r.Get("/{webhookID}/deliveries", c.getWebhookDeliveries)
+	return r
+}
+
+// WebhookOperations documents the routes of NewWebhookController.
+var WebhookOperations = []openapi.Operation{
+	{Method: http.MethodPost, Path: "/", Summary: "Register a webhook", Request: models.WebhookRequest{}, Response: models.WebhookResponse{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/", Summary: "List webhooks", Response: []models.WebhookResponse{}},
+	{Method: http.MethodDelete, Path: "/{webhookID}", Summary: "Delete a webhook", Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/{webhookID}/deliveries", Summary: "List recent deliveries of a webhook", Query: []openapi.Param{
+		{Name: "event", Description: "Filter by event type"},
+	}, Response: []models.WebhookDeliveryResponse{}},
+}
+
+// createWebhook registers a webhook and returns it with its signing secret,
+// which is not shown again.
+func (c *webhookController) createWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook := models.WebhookRequest{}
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W:          w,
+		R:          r,
+		ReqBodyObj: &webhook,
+		EndpointLogic: func() (any, error) {
+			created, err := c.webhookService.CreateWebhook(r.Context(), webhook.ToModel(), userID)
+			if err != nil {
+				return nil, err
+			}
+			resp := created.ToResponse()
+			resp.Secret = created.Secret
+			return resp, nil
+		},
+		SuccessCode: http.StatusCreated,
+	})
+}
+
+func (c *webhookController) getWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponseSlice(c.webhookService.GetWebhooks(r.Context(), userID))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
+
+func (c *webhookController) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "webhookID")
+	userID, _ := appctx.GetUserID(r.Context())
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return nil, c.webhookService.DeleteWebhook(r.Context(), webhookID, userID)
+		},
+		SuccessCode: http.StatusNoContent,
+	})
+}
+
+// getWebhookDeliveries returns the latest delivery attempts of a webhook,
+// optionally only those of the event type given in the event query parameter.
+func (c *webhookController) getWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "webhookID")
+	userID, _ := appctx.GetUserID(r.Context())
+	eventType := models.EventType(r.URL.Query().Get("event"))
+
+	c.requestHandler.ServeRequest(endpoint.InternalRequest{
+		W: w,
+		R: r,
+		EndpointLogic: func() (any, error) {
+			return endpoint.ToResponseSlice(c.webhookService.GetWebhookDeliveries(r.Context(), webhookID, userID, eventType))
+		},
+		SuccessCode: http.StatusOK,
+	})
+}
@@ -0,0 +1,144 @@
+package controllers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func setupWebhookController(t *testing.T) (*mocks.MockWebhookServiceExternal, http.Handler) {
+	t.Helper()
+
+	svc := mocks.NewMockWebhookServiceExternal(t)
+	reqHandler := endpoint.NewRequestHandler(validator.New())
+	return svc, controllers.NewWebhookController(svc, reqHandler)
+}
+
+// ---------------------------------------------------------------------------
+// POST /
+// ---------------------------------------------------------------------------
+
+func TestWebhookController_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setupMocks func(svc *mocks.MockWebhookServiceExternal)
+		wantStatus int
+		wantSecret string
+	}{
+		{
+			name: "success - returns the secret once",
+			body: `{"url":"https://example.com/hooks","events":["subscription.renewed"]}`,
+			setupMocks: func(svc *mocks.MockWebhookServiceExternal) {
+				want := &models.Webhook{
+					URL:    "https://example.com/hooks",
+					Events: []models.EventType{models.SubscriptionRenewedEvent},
+				}
+				created := *want
+				created.ID = bson.NewObjectID()
+				created.Secret = "whsec_abc"
+				svc.EXPECT().
+					CreateWebhook(mock.Anything, want, defaultUserHex).
+					Return(&created, nil).
+					Once()
+			},
+			wantStatus: http.StatusCreated,
+			wantSecret: "whsec_abc",
+		},
+		{
+			name:       "error - url is not http",
+			body:       `{"url":"ftp://example.com","events":["subscription.renewed"]}`,
+			setupMocks: func(svc *mocks.MockWebhookServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - no events",
+			body:       `{"url":"https://example.com/hooks","events":[]}`,
+			setupMocks: func(svc *mocks.MockWebhookServiceExternal) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, handler := setupWebhookController(t)
+			tt.setupMocks(svc)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+			req = injectUserID(req, defaultUserHex)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantSecret != "" {
+				var resp models.WebhookResponse
+				require.NoError(t, decodeData(rr.Body, &resp))
+				assert.Equal(t, tt.wantSecret, resp.Secret)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GET /
+// ---------------------------------------------------------------------------
+
+func TestWebhookController_GetWebhooks(t *testing.T) {
+	svc, handler := setupWebhookController(t)
+	webhook := &models.Webhook{
+		ID:     bson.NewObjectID(),
+		URL:    "https://example.com/hooks",
+		Secret: "whsec_abc",
+		Events: []models.EventType{models.SubscriptionExpiredEvent},
+	}
+	svc.EXPECT().GetWebhooks(mock.Anything, defaultUserHex).Return([]*models.Webhook{webhook}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp []models.WebhookResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	require.Len(t, resp, 1)
+	assert.Empty(t, resp[0].Secret, "listed webhooks must not expose their secret")
+}
+
+// ---------------------------------------------------------------------------
+// GET /{webhookID}/deliveries
+// ---------------------------------------------------------------------------
+
+func TestWebhookController_GetWebhookDeliveries(t *testing.T) {
+	svc, handler := setupWebhookController(t)
+	webhookID := bson.NewObjectID().Hex()
+	svc.EXPECT().
+		GetWebhookDeliveries(mock.Anything, webhookID, defaultUserHex, models.BillFailedEvent).
+		Return([]*models.WebhookDelivery{{ID: bson.NewObjectID(), EventType: models.BillFailedEvent, Attempt: 2}}, nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/"+webhookID+"/deliveries?event=bill.failed", nil)
+	req = injectUserID(req, defaultUserHex)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp []models.WebhookDeliveryResponse
+	require.NoError(t, decodeData(rr.Body, &resp))
+	require.Len(t, resp, 1)
+	assert.Equal(t, 2, resp[0].Attempt)
+}
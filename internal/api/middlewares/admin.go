@@ -0,0 +1,79 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// RequireRole allows only users whose token carries one of roles. It must run
+// after Authentication. The role comes from the access token, so a role change
+// applies once the user's token is refreshed; RequireAdmin checks the user
+// record instead.
+func RequireRole(roles ...models.Role) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, ok := appctx.GetUserRole(r.Context())
+			if !ok {
+				endpoint.WriteError(w, r, apperror.NewUnauthorizedError("Authentication required"))
+				return
+			}
+			if !slices.Contains(roles, models.Role(role)) {
+				slog.WarnContext(r.Context(), "Role denied route",
+					logattr.Path(r.URL.Path),
+				)
+				endpoint.WriteError(w, r, apperror.NewForbiddenError("Insufficient role"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireAdmin allows only users with the admin role. It must run after
+// Authentication. The role is read from the user record on every request, so
+// a demotion takes effect immediately.
+func RequireAdmin(userService services.UserServiceInternal) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := appctx.GetUserID(r.Context())
+			if !ok {
+				endpoint.WriteError(w, r, apperror.NewUnauthorizedError("Authentication required"))
+				return
+			}
+			id, err := bson.ObjectIDFromHex(userID)
+			if err != nil {
+				endpoint.WriteError(w, r, apperror.NewUnauthorizedError("Invalid token"))
+				return
+			}
+
+			user, err := userService.FetchUserByIDInternal(r.Context(), id)
+			if err != nil {
+				slog.WarnContext(r.Context(), "Admin check failed",
+					logattr.Path(r.URL.Path),
+					logattr.Error(err),
+				)
+				endpoint.WriteError(w, r, apperror.NewForbiddenError("Admin access required"))
+				return
+			}
+			if user.Role != models.AdminRole {
+				slog.WarnContext(r.Context(), "Non-admin denied admin route",
+					logattr.Path(r.URL.Path),
+				)
+				endpoint.WriteError(w, r, apperror.NewForbiddenError("Admin access required"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package middlewares_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/middlewares"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ---------------------------------------------------------------------------
+// RequireRole middleware
+// ---------------------------------------------------------------------------
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           string
+		wantStatus     int
+		wantNextCalled bool
+	}{
+		{
+			name:           "success - allowed role passes through",
+			role:           string(models.AdminRole),
+			wantStatus:     http.StatusOK,
+			wantNextCalled: true,
+		},
+		{
+			name:       "failure - other role is forbidden",
+			role:       string(models.UserRole),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "failure - missing role",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var nextCalled bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.role != "" {
+				req = req.WithContext(appctx.WithUserRole(req.Context(), tt.role))
+			}
+			rr := httptest.NewRecorder()
+
+			middlewares.RequireRole(models.AdminRole)(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			assert.Equal(t, tt.wantNextCalled, nextCalled)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// RequireAdmin middleware
+// ---------------------------------------------------------------------------
+
+func TestRequireAdmin(t *testing.T) {
+	userID := bson.NewObjectID()
+
+	tests := []struct {
+		name           string
+		userID         string
+		setupMock      func(m *mocks.MockUserServiceInternal)
+		wantStatus     int
+		wantNextCalled bool
+	}{
+		{
+			name:   "success - admin passes through",
+			userID: userID.Hex(),
+			setupMock: func(m *mocks.MockUserServiceInternal) {
+				m.EXPECT().FetchUserByIDInternal(mock.Anything, userID).
+					Return(&models.User{ID: userID, Role: models.AdminRole}, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantNextCalled: true,
+		},
+		{
+			name:   "failure - regular user is forbidden",
+			userID: userID.Hex(),
+			setupMock: func(m *mocks.MockUserServiceInternal) {
+				m.EXPECT().FetchUserByIDInternal(mock.Anything, userID).
+					Return(&models.User{ID: userID, Role: models.UserRole}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "failure - lookup error is forbidden",
+			userID: userID.Hex(),
+			setupMock: func(m *mocks.MockUserServiceInternal) {
+				m.EXPECT().FetchUserByIDInternal(mock.Anything, userID).
+					Return(nil, errors.New("not found"))
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "failure - missing user ID",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "failure - malformed user ID",
+			userID:     "not-an-object-id",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userService := mocks.NewMockUserServiceInternal(t)
+			if tt.setupMock != nil {
+				tt.setupMock(userService)
+			}
+
+			var nextCalled bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.userID != "" {
+				req = req.WithContext(appctx.WithUserID(req.Context(), tt.userID))
+			}
+			rr := httptest.NewRecorder()
+
+			middlewares.RequireAdmin(userService)(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			assert.Equal(t, tt.wantNextCalled, nextCalled)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
 	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
 	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
@@ -21,23 +22,21 @@ import (
 func Authentication(jwtService services.JWTService) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				endpoint.WriteAPIResponse(w, http.StatusUnauthorized, map[string]string{"error": "Authorization header required"})
+			if r.Header.Get("Authorization") == "" {
+				endpoint.WriteError(w, r, apperror.NewUnauthorizedError("Authorization header required"))
 				return
 			}
 
-			parts := strings.SplitN(authHeader, " ", 2)
-			if len(parts) != 2 || parts[0] != "Bearer" {
-				endpoint.WriteAPIResponse(w, http.StatusUnauthorized, map[string]string{"error": "Invalid authorization format"})
+			tokenString, ok := BearerToken(r)
+			if !ok {
+				endpoint.WriteError(w, r, apperror.NewUnauthorizedError("Invalid authorization format"))
 				return
 			}
 
-			tokenString := parts[1]
-			claims, err := jwtService.ValidateToken(tokenString, models.AccessToken)
+			claims, err := jwtService.ValidateToken(r.Context(), tokenString, models.AccessToken)
 			if err != nil {
-				if errors.Is(err, jwt.ErrTokenExpired) {
-					slog.DebugContext(r.Context(), "Token expired",
+				if errors.Is(err, jwt.ErrTokenExpired) || errors.Is(err, services.ErrTokenRevoked) {
+					slog.DebugContext(r.Context(), "Token expired or revoked",
 						logattr.Error(err),
 					)
 				} else {
@@ -46,13 +45,17 @@ func Authentication(jwtService services.JWTService) func(next http.Handler) http
 						logattr.IP(ip),
 						logattr.Error(err))
 				}
-				endpoint.WriteAPIResponse(w, http.StatusUnauthorized, map[string]string{"error": "Invalid token"})
+				endpoint.WriteError(w, r, apperror.NewUnauthorizedError("Invalid token"))
 				return
 			}
 
 			// Add user claims to context.
 			ctx := appctx.WithUserID(r.Context(), claims.UserID)
 			ctx = appctx.WithUserEmail(ctx, claims.Email)
+			ctx = appctx.WithUserRole(ctx, string(claims.Role.OrDefault()))
+			if claims.SessionID != "" {
+				ctx = appctx.WithSessionID(ctx, claims.SessionID)
+			}
 
 			// Add user ID to the span if available
 			trace.SpanFromContext(ctx).SetAttributes(
@@ -63,3 +66,12 @@ func Authentication(jwtService services.JWTService) func(next http.Handler) http
 		})
 	}
 }
+
+// BearerToken returns the token of a "Bearer" Authorization header.
+func BearerToken(r *http.Request) (string, bool) {
+	parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", false
+	}
+	return parts[1], true
+}
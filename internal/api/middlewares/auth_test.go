@@ -12,6 +12,7 @@ import (
 	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
@@ -27,8 +28,9 @@ func TestAuthentication(t *testing.T) {
 	validEmail := "alice@example.com"
 	validClaims := func() *models.Claims {
 		return &models.Claims{
-			UserID: validUserID,
-			Email:  validEmail,
+			UserID:    validUserID,
+			Email:     validEmail,
+			SessionID: "session_123",
 		}
 	}
 
@@ -45,7 +47,7 @@ func TestAuthentication(t *testing.T) {
 			token: "valid.jwt.token",
 			setupMocks: func(jwtSvc *mocks.MockJWTService, token string) {
 				jwtSvc.EXPECT().
-					ValidateToken(token, models.AccessToken).
+					ValidateToken(mock.Anything, token, models.AccessToken).
 					Return(validClaims(), nil).
 					Once()
 			},
@@ -74,7 +76,7 @@ func TestAuthentication(t *testing.T) {
 			token: "expired.jwt.token",
 			setupMocks: func(jwtSvc *mocks.MockJWTService, token string) {
 				jwtSvc.EXPECT().
-					ValidateToken(token, models.AccessToken).
+					ValidateToken(mock.Anything, token, models.AccessToken).
 					Return(nil, jwt.ErrTokenExpired).
 					Once()
 			},
@@ -86,7 +88,7 @@ func TestAuthentication(t *testing.T) {
 			token: "invalid.jwt.token",
 			setupMocks: func(jwtSvc *mocks.MockJWTService, token string) {
 				jwtSvc.EXPECT().
-					ValidateToken(token, models.AccessToken).
+					ValidateToken(mock.Anything, token, models.AccessToken).
 					Return(nil, jwt.ErrSignatureInvalid).
 					Once()
 			},
@@ -157,6 +159,15 @@ func TestAuthentication(t *testing.T) {
 				require.True(t, ok)
 				assert.Equal(t, validEmail, extractedEmail)
 
+				// Tokens without a role claim are treated as regular users.
+				extractedRole, ok := appctx.GetUserRole(capturedCtx)
+				require.True(t, ok)
+				assert.Equal(t, string(models.UserRole), extractedRole)
+
+				extractedSessionID, ok := appctx.GetSessionID(capturedCtx)
+				require.True(t, ok)
+				assert.Equal(t, "session_123", extractedSessionID)
+
 				// Assert the Telemetry Lock
 				spans := exporter.GetSpans()
 				require.Len(t, spans, 1)
@@ -169,6 +180,8 @@ func TestAuthentication(t *testing.T) {
 					}
 				}
 				assert.True(t, hasEnduserAttr, "Expected http.route attribute to be explicitly set on the span")
+			} else {
+				assert.Contains(t, rr.Body.String(), `"code":"UNAUTHORIZED"`)
 			}
 		})
 	}
@@ -0,0 +1,62 @@
+package middlewares
+
+import (
+	"crypto/x509"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ClientCertificate authenticates callers of the internal listener by the
+// client certificate the TLS handshake verified, in place of
+// Authentication and RequireAdmin. With allowed set, the certificate's
+// common name or one of its DNS names must be listed. Callers act as
+// administrators without a user ID.
+func ClientCertificate(allowed []string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+				endpoint.WriteError(w, r, apperror.NewUnauthorizedError("Client certificate required"))
+				return
+			}
+
+			cert := r.TLS.VerifiedChains[0][0]
+			if !clientAllowed(cert, allowed) {
+				slog.WarnContext(r.Context(), "Client certificate denied internal route",
+					logattr.ClientCert(cert.Subject.CommonName),
+					logattr.Path(r.URL.Path),
+				)
+				endpoint.WriteError(w, r, apperror.NewForbiddenError("Client certificate not allowed"))
+				return
+			}
+
+			trace.SpanFromContext(r.Context()).SetAttributes(
+				semconv.TLSClientSubject(cert.Subject.String()),
+			)
+			ctx := appctx.WithUserRole(r.Context(), string(models.AdminRole))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// clientAllowed reports whether allowed is empty or lists the common name or
+// a DNS name of cert.
+func clientAllowed(cert *x509.Certificate, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, name := range append([]string{cert.Subject.CommonName}, cert.DNSNames...) {
+		if name != "" && slices.Contains(allowed, name) {
+			return true
+		}
+	}
+	return false
+}
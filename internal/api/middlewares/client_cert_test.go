@@ -0,0 +1,91 @@
+package middlewares_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/middlewares"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// ---------------------------------------------------------------------------
+// ClientCertificate middleware
+// ---------------------------------------------------------------------------
+
+func TestClientCertificate(t *testing.T) {
+	// The TLS handshake verified the chain; the middleware only reads it.
+	verified := func(cert *x509.Certificate) *tls.ConnectionState {
+		return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+	billing := &x509.Certificate{Subject: pkix.Name{CommonName: "billing-tool"}}
+	ops := &x509.Certificate{Subject: pkix.Name{CommonName: "ops"}, DNSNames: []string{"ops.internal"}}
+
+	tests := []struct {
+		name       string
+		allowed    []string
+		tls        *tls.ConnectionState
+		wantStatus int
+	}{
+		{
+			name:       "success - any verified certificate",
+			tls:        verified(billing),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "success - listed common name",
+			allowed:    []string{"billing-tool"},
+			tls:        verified(billing),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "success - listed DNS name",
+			allowed:    []string{"ops.internal"},
+			tls:        verified(ops),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "failure - certificate not listed",
+			allowed:    []string{"billing-tool"},
+			tls:        verified(ops),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "failure - no certificate",
+			tls:        &tls.ConnectionState{},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "failure - plain HTTP",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var role string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				role, _ = appctx.GetUserRole(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.TLS = tt.tls
+			rr := httptest.NewRecorder()
+
+			middlewares.ClientCertificate(tt.allowed)(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, string(models.AdminRole), role, "certificate callers act as administrators")
+				return
+			}
+			assert.Empty(t, role, "next handler must not run")
+		})
+	}
+}
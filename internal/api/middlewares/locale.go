@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/i18n"
+)
+
+// Locale picks the locale of user-facing messages from the Accept-Language
+// header, falling back to English, and adds it to the request context.
+func Locale() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := i18n.Match(r.Header.Get("Accept-Language")).Locale()
+
+			w.Header().Add("Vary", "Accept-Language")
+			w.Header().Set("Content-Language", locale)
+			next.ServeHTTP(w, r.WithContext(appctx.WithLocale(r.Context(), locale)))
+		})
+	}
+}
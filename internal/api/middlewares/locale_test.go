@@ -0,0 +1,45 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/middlewares"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocale_Middleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wantLocale     string
+	}{
+		{name: "success - picks the best supported language", acceptLanguage: "fr-CH, es;q=0.9, de;q=0.8", wantLocale: "es"},
+		{name: "success - matches a regional variant", acceptLanguage: "de-AT", wantLocale: "de"},
+		{name: "fallback - unsupported language", acceptLanguage: "ja", wantLocale: "en"},
+		{name: "fallback - no header", wantLocale: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLocale string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotLocale, _ = appctx.GetLocale(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rr := httptest.NewRecorder()
+
+			middlewares.Locale()(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantLocale, gotLocale)
+			assert.Equal(t, tt.wantLocale, rr.Header().Get("Content-Language"))
+			assert.Equal(t, "Accept-Language", rr.Header().Get("Vary"))
+		})
+	}
+}
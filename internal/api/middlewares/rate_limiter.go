@@ -1,13 +1,19 @@
 package middlewares
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
 	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
 	"github.com/anuragthepathak/subscription-management/internal/domain/services"
 	"github.com/anuragthepathak/subscription-management/internal/lib"
@@ -20,8 +26,73 @@ import (
 // while the middleware is failing open.
 const failOpenLogInterval = 60
 
+// maxEmailPeekBytes bounds how much of an auth request body is read to find
+// the email. Auth payloads are far smaller; anything beyond is left for the
+// handler, which applies its own size limit.
+const maxEmailPeekBytes = 16 * 1024
+
 // RateLimiter returns a middleware that limits requests by IP address.
 func RateLimiter(rateLimiterService services.RateLimiterService) func(http.Handler) http.Handler {
+	return rateLimit(rateLimiterService, func(_ *http.Request, ip string) string {
+		return ip
+	})
+}
+
+// UserRateLimiter returns a middleware that limits requests by the
+// authenticated user ID, so users behind a shared IP each get their own
+// budget. Requests without a user, such as login, are limited by IP address.
+func UserRateLimiter(rateLimiterService services.RateLimiterService) func(http.Handler) http.Handler {
+	return rateLimit(rateLimiterService, func(r *http.Request, ip string) string {
+		if userID, ok := appctx.GetUserID(r.Context()); ok && userID != "" {
+			return userID
+		}
+		return ip
+	})
+}
+
+// AuthRateLimiter returns a middleware for credential endpoints that limits
+// requests by IP address and the email in the JSON body, so repeated guesses
+// against one account are throttled without blocking a whole office behind
+// a shared IP. Requests without an email are limited by IP alone.
+func AuthRateLimiter(rateLimiterService services.RateLimiterService) func(http.Handler) http.Handler {
+	return rateLimit(rateLimiterService, func(r *http.Request, ip string) string {
+		if email := peekEmail(r); email != "" {
+			return ip + ":" + email
+		}
+		return ip
+	})
+}
+
+// peekEmail returns the normalized "email" field of a JSON body, leaving the
+// body intact for the next handler.
+func peekEmail(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+	head, err := io.ReadAll(io.LimitReader(r.Body, maxEmailPeekBytes))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), r.Body), r.Body}
+	if err != nil {
+		return ""
+	}
+
+	var body struct {
+		Email string `json:"email"`
+	}
+	if json.Unmarshal(head, &body) != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(body.Email))
+}
+
+// rateLimit limits requests by the key that keyFn derives from the request
+// and the client IP.
+func rateLimit(
+	rateLimiterService services.RateLimiterService,
+	keyFn func(r *http.Request, ip string) string,
+) func(http.Handler) http.Handler {
 	var lastErrLog atomic.Int64
 
 	return func(next http.Handler) http.Handler {
@@ -32,17 +103,13 @@ func RateLimiter(rateLimiterService services.RateLimiterService) func(http.Handl
 				slog.WarnContext(r.Context(), "Failed to get client IP",
 					logattr.Error(err),
 				)
-				endpoint.WriteAPIResponse(w, http.StatusBadRequest,
-					map[string]string{
-						"error": "Malformed request environment",
-					},
-				)
+				endpoint.WriteError(w, r, apperror.NewBadRequestError("Malformed request environment"))
 				return
 			}
 
 			// Check if the request is allowed.
 			isAllowed, remaining, retryAfter, err :=
-				rateLimiterService.Allowed(r.Context(), ip)
+				rateLimiterService.Allowed(r.Context(), keyFn(r, ip))
 			if err != nil {
 				span := trace.SpanFromContext(r.Context())
 				span.RecordError(err)
@@ -78,9 +145,7 @@ func RateLimiter(rateLimiterService services.RateLimiterService) func(http.Handl
 					logattr.Path(r.URL.Path),
 				)
 
-				endpoint.WriteAPIResponse(w, http.StatusTooManyRequests, map[string]string{
-					"error": "Rate limit exceeded. Please try again later.",
-				})
+				endpoint.WriteError(w, r, apperror.NewRateLimitError("Rate limit exceeded. Please try again later."))
 				return
 			}
 
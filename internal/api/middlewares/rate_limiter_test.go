@@ -2,6 +2,7 @@ package middlewares_test
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/middlewares"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
 	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -144,3 +146,107 @@ func TestRateLimiter(t *testing.T) {
 		})
 	}
 }
+
+// ---------------------------------------------------------------------------
+// AuthRateLimiter middleware
+// ---------------------------------------------------------------------------
+
+func TestAuthRateLimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantKey string
+	}{
+		{
+			name:    "keys by IP and normalized email",
+			body:    `{"email":" Alice@Example.com ","password":"secret"}`,
+			wantKey: "203.0.113.7:alice@example.com",
+		},
+		{
+			name:    "falls back to IP without an email",
+			body:    `{"refreshToken":"abc"}`,
+			wantKey: "203.0.113.7",
+		},
+		{
+			name:    "falls back to IP for a malformed body",
+			body:    `not json`,
+			wantKey: "203.0.113.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := mocks.NewMockRateLimiterService(t)
+			svc.EXPECT().
+				Allowed(mock.Anything, tt.wantKey).
+				Return(true, 4, time.Duration(0), nil).
+				Once()
+
+			// The handler must still see the full body.
+			var gotBody string
+			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				gotBody = string(b)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(tt.body))
+			req.RemoteAddr = "203.0.113.7:4321"
+			rr := httptest.NewRecorder()
+
+			middlewares.AuthRateLimiter(svc)(nextHandler).ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tt.body, gotBody)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// UserRateLimiter middleware
+// ---------------------------------------------------------------------------
+
+func TestUserRateLimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		wantKey string
+	}{
+		{
+			name:    "keys by the authenticated user",
+			userID:  "64b7f0f0f0f0f0f0f0f0f0f0",
+			wantKey: "64b7f0f0f0f0f0f0f0f0f0f0",
+		},
+		{
+			name:    "falls back to IP without a user",
+			wantKey: "203.0.113.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := mocks.NewMockRateLimiterService(t)
+			svc.EXPECT().
+				Allowed(mock.Anything, tt.wantKey).
+				Return(false, 0, 30*time.Second, nil).
+				Once()
+
+			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("next handler must not run once the limit is exceeded")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/subscriptions", nil)
+			req.RemoteAddr = "203.0.113.7:4321"
+			if tt.userID != "" {
+				req = req.WithContext(appctx.WithUserID(req.Context(), tt.userID))
+			}
+			rr := httptest.NewRecorder()
+
+			middlewares.UserRateLimiter(svc)(nextHandler).ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusTooManyRequests, rr.Code)
+			assert.Equal(t, "30", rr.Header().Get("Retry-After"))
+		})
+	}
+}
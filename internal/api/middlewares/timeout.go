@@ -2,19 +2,47 @@ package middlewares
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/go-chi/chi/v5/middleware"
 )
 
+// requestContextKey holds the request context from before the outermost
+// Timeout set its deadline.
+type requestContextKey struct{}
+
 // Timeout returns a middleware that sets a timeout for incoming requests.
+// A Timeout nested inside another replaces the outer deadline, so a route
+// group can have a longer timeout than the routes around it; the request
+// is still canceled when the client goes away. A handler that runs out of
+// time without writing a response gets a 504 TIMEOUT.
 func Timeout(timeout time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			ctx := r.Context()
+			var cancel context.CancelFunc
+			if parent, ok := ctx.Value(requestContextKey{}).(context.Context); ok {
+				// Drop the outer deadline but keep the values added since.
+				ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), timeout)
+				stop := context.AfterFunc(parent, cancel)
+				defer stop()
+			} else {
+				ctx = context.WithValue(ctx, requestContextKey{}, ctx)
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+			}
 			defer cancel()
 
 			// Replace the request with the new context
-			next.ServeHTTP(w, r.WithContext(ctx))
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			if ww.Status() == 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				endpoint.WriteError(w, r, apperror.NewTimeoutError(ctx.Err()))
+			}
 		})
 	}
 }
@@ -85,4 +85,83 @@ func TestTimeout_Middleware(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestTimeout_WritesGatewayTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+	}{
+		{
+			name: "handler gives up without a response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			},
+			wantStatus: http.StatusGatewayTimeout,
+		},
+		{
+			name: "handler response is kept",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := middlewares.Timeout(10 * time.Millisecond)(tt.handler)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusGatewayTimeout {
+				assert.Contains(t, rr.Body.String(), `"code":"TIMEOUT"`)
+			}
+		})
+	}
+}
+
+func TestTimeout_NestedReplacesOuterDeadline(t *testing.T) {
+	tests := []struct {
+		name  string
+		outer time.Duration
+		inner time.Duration
+	}{
+		{name: "longer inner timeout", outer: 10 * time.Millisecond, inner: time.Hour},
+		{name: "shorter inner timeout", outer: time.Hour, inner: 10 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deadline time.Time
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deadline, _ = r.Context().Deadline()
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := middlewares.Timeout(tt.outer)(middlewares.Timeout(tt.inner)(next))
+
+			start := time.Now()
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.WithinDuration(t, start.Add(tt.inner), deadline, time.Second)
+		})
+	}
+}
+
+func TestTimeout_NestedCanceledWithRequest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel() // The client goes away.
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+			t.Error("request context was not canceled with the client's")
+		}
+	})
+	handler := middlewares.Timeout(time.Hour)(middlewares.Timeout(time.Hour)(next))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+}
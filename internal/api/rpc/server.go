@@ -0,0 +1,133 @@
+// Package rpc serves the internal gRPC API defined in proto/subman/v1. It
+// exposes the internal service methods to other services of the platform,
+// which are trusted by their client certificate rather than a user token.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	submanv1 "github.com/anuragthepathak/subscription-management/proto/subman/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer returns a gRPC server with the internal API and the standard
+// health service registered. opts add transport credentials and stats
+// handlers.
+func NewServer(
+	subscriptionService services.SubscriptionServiceInternal,
+	userService services.UserServiceInternal,
+	opts ...grpc.ServerOption,
+) *grpc.Server {
+	opts = append(opts, grpc.ChainUnaryInterceptor(recoverPanics, mapErrors))
+	server := grpc.NewServer(opts...)
+
+	submanv1.RegisterSubscriptionServiceInternalServer(server, &subscriptionServer{subscriptionService: subscriptionService})
+	submanv1.RegisterUserServiceInternalServer(server, &userServer{userService: userService})
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	return server
+}
+
+// recoverPanics turns a panicking call into an Internal error, so one bad
+// request does not take down the process.
+func recoverPanics(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "gRPC call panicked",
+				logattr.RPCMethod(info.FullMethod),
+				logattr.Error(fmt.Errorf("%v", r)),
+				logattr.Stack(debug.Stack()),
+			)
+			err = status.Error(codes.Internal, "An unexpected internal error occurred.")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// mapErrors converts the AppErrors of the services into gRPC status errors
+// and logs failed calls the way the REST API logs failed requests.
+func mapErrors(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return nil, err
+	}
+
+	appErr, ok := errors.AsType[apperror.AppError](err)
+	if !ok {
+		code := status.FromContextError(err).Code()
+		if code == codes.Unknown {
+			code = codes.Internal
+		}
+		slog.ErrorContext(ctx, "Unhandled gRPC call error",
+			logattr.RPCMethod(info.FullMethod),
+			logattr.GRPCCode(code.String()),
+			logattr.Error(err),
+		)
+		return nil, status.Error(code, "An unexpected internal error occurred.")
+	}
+
+	code := grpcCode(appErr.Code())
+	logAttrs := []any{
+		logattr.RPCMethod(info.FullMethod),
+		logattr.GRPCCode(code.String()),
+		logattr.ErrorCode(string(appErr.Code())),
+		logattr.Message(appErr.Message()),
+		logattr.Error(err),
+	}
+	for _, attr := range appErr.LogAttributes() {
+		logAttrs = append(logAttrs, attr)
+	}
+	if code == codes.Internal || code == codes.Unavailable {
+		slog.ErrorContext(ctx, "gRPC call failed", logAttrs...)
+	} else {
+		slog.WarnContext(ctx, "gRPC call rejected", logAttrs...)
+	}
+	return nil, status.Error(code, appErr.Message())
+}
+
+// grpcCode returns the gRPC status code matching an application error code.
+func grpcCode(code apperror.ErrorCode) codes.Code {
+	switch code {
+	case apperror.ErrNotFound:
+		return codes.NotFound
+	case apperror.ErrBadRequest, apperror.ErrValidation, apperror.ErrTooLarge:
+		return codes.InvalidArgument
+	case apperror.ErrUnauthorized:
+		return codes.Unauthenticated
+	case apperror.ErrForbidden:
+		return codes.PermissionDenied
+	case apperror.ErrConflict:
+		return codes.FailedPrecondition
+	case apperror.ErrTimeout:
+		return codes.DeadlineExceeded
+	case apperror.ErrRateLimited:
+		return codes.ResourceExhausted
+	case apperror.ErrDB:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,329 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/rpc"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	submanv1 "github.com/anuragthepathak/subscription-management/proto/subman/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	mockTime  = time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	userID    = bson.NewObjectID()
+	subID     = bson.NewObjectID()
+	paymentID = bson.NewObjectID()
+)
+
+type testClients struct {
+	subscriptions submanv1.SubscriptionServiceInternalClient
+	users         submanv1.UserServiceInternalClient
+	health        healthpb.HealthClient
+}
+
+// setupServer serves the API on an in-memory listener and returns clients
+// connected to it.
+func setupServer(t *testing.T) (testClients, *mocks.MockSubscriptionServiceInternal, *mocks.MockUserServiceInternal) {
+	t.Helper()
+
+	subscriptionService := mocks.NewMockSubscriptionServiceInternal(t)
+	userService := mocks.NewMockUserServiceInternal(t)
+	server := rpc.NewServer(subscriptionService, userService)
+
+	ln := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ln.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return testClients{
+		subscriptions: submanv1.NewSubscriptionServiceInternalClient(conn),
+		users:         submanv1.NewUserServiceInternalClient(conn),
+		health:        healthpb.NewHealthClient(conn),
+	}, subscriptionService, userService
+}
+
+func validSub() *models.Subscription {
+	trialEndsAt := mockTime.AddDate(0, 0, 7)
+	return &models.Subscription{
+		ID:              subID,
+		UserID:          userID,
+		Name:            "Netflix",
+		Price:           999,
+		Currency:        "USD",
+		Frequency:       models.Monthly,
+		Category:        models.Entertainment,
+		Status:          models.Active,
+		ValidTill:       mockTime.AddDate(0, 1, 0),
+		TrialEndsAt:     &trialEndsAt,
+		Timezone:        "America/New_York",
+		PaymentMethodID: &paymentID,
+		CreatedAt:       mockTime,
+		UpdatedAt:       mockTime,
+	}
+}
+
+// assertCode checks that err is a gRPC status error with code.
+func assertCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok, "expected a status error, got %v", err)
+	assert.Equal(t, code, st.Code(), st.Message())
+}
+
+func TestSubscriptionServer_GetSubscription(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		clients, subscriptionService, _ := setupServer(t)
+		subscriptionService.EXPECT().FetchSubscriptionByIDInternal(mock.Anything, subID).Return(validSub(), nil).Once()
+
+		got, err := clients.subscriptions.GetSubscription(t.Context(), &submanv1.GetSubscriptionRequest{Id: subID.Hex()})
+		require.NoError(t, err)
+		assert.Equal(t, subID.Hex(), got.GetId())
+		assert.Equal(t, userID.Hex(), got.GetUserId())
+		assert.EqualValues(t, 999, got.GetPrice())
+		assert.Equal(t, "USD", got.GetCurrency())
+		assert.Equal(t, "active", got.GetStatus())
+		assert.True(t, got.GetValidTill().AsTime().Equal(mockTime.AddDate(0, 1, 0)))
+		assert.True(t, got.GetTrialEndsAt().AsTime().Equal(mockTime.AddDate(0, 0, 7)))
+		assert.Nil(t, got.GetLastUsedAt(), "unset times stay unset")
+		assert.Nil(t, got.GetPausedAt())
+		assert.Equal(t, "America/New_York", got.GetTimezone())
+		assert.Equal(t, paymentID.Hex(), got.GetPaymentMethodId())
+	})
+
+	tests := []struct {
+		name       string
+		id         string
+		setupMocks func(m *mocks.MockSubscriptionServiceInternal)
+		wantCode   codes.Code
+	}{
+		{
+			name:       "invalid ID",
+			id:         "not-an-id",
+			setupMocks: func(m *mocks.MockSubscriptionServiceInternal) {},
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name: "not found",
+			id:   subID.Hex(),
+			setupMocks: func(m *mocks.MockSubscriptionServiceInternal) {
+				m.EXPECT().FetchSubscriptionByIDInternal(mock.Anything, subID).
+					Return(nil, apperror.NewNotFoundError("Subscription not found")).Once()
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "database error",
+			id:   subID.Hex(),
+			setupMocks: func(m *mocks.MockSubscriptionServiceInternal) {
+				m.EXPECT().FetchSubscriptionByIDInternal(mock.Anything, subID).
+					Return(nil, apperror.NewDBError(errors.New("connection reset"))).Once()
+			},
+			wantCode: codes.Unavailable,
+		},
+		{
+			name: "unhandled error",
+			id:   subID.Hex(),
+			setupMocks: func(m *mocks.MockSubscriptionServiceInternal) {
+				m.EXPECT().FetchSubscriptionByIDInternal(mock.Anything, subID).
+					Return(nil, errors.New("boom")).Once()
+			},
+			wantCode: codes.Internal,
+		},
+		{
+			name: "panic",
+			id:   subID.Hex(),
+			setupMocks: func(m *mocks.MockSubscriptionServiceInternal) {
+				m.EXPECT().FetchSubscriptionByIDInternal(mock.Anything, subID).
+					RunAndReturn(func(context.Context, bson.ObjectID) (*models.Subscription, error) {
+						panic("boom")
+					}).Once()
+			},
+			wantCode: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients, subscriptionService, _ := setupServer(t)
+			tt.setupMocks(subscriptionService)
+
+			_, err := clients.subscriptions.GetSubscription(t.Context(), &submanv1.GetSubscriptionRequest{Id: tt.id})
+			assertCode(t, err, tt.wantCode)
+		})
+	}
+}
+
+func TestSubscriptionServer_ListUpcomingRenewals(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		clients, subscriptionService, _ := setupServer(t)
+		subscriptionService.EXPECT().FetchUpcomingRenewalsInternal(mock.Anything, []int{1, 7}).
+			Return([]*models.Subscription{validSub()}, nil).Once()
+
+		got, err := clients.subscriptions.ListUpcomingRenewals(t.Context(), &submanv1.ListUpcomingRenewalsRequest{Days: []int32{1, 7}})
+		require.NoError(t, err)
+		require.Len(t, got.GetSubscriptions(), 1)
+		assert.Equal(t, subID.Hex(), got.GetSubscriptions()[0].GetId())
+	})
+
+	t.Run("no days", func(t *testing.T) {
+		clients, _, _ := setupServer(t)
+
+		got, err := clients.subscriptions.ListUpcomingRenewals(t.Context(), &submanv1.ListUpcomingRenewalsRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, got.GetSubscriptions())
+	})
+
+	t.Run("negative day", func(t *testing.T) {
+		clients, _, _ := setupServer(t)
+
+		_, err := clients.subscriptions.ListUpcomingRenewals(t.Context(), &submanv1.ListUpcomingRenewalsRequest{Days: []int32{-1}})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+}
+
+func TestSubscriptionServer_ListDueForRenewal(t *testing.T) {
+	start, end := mockTime, mockTime.Add(time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		clients, subscriptionService, _ := setupServer(t)
+		subscriptionService.EXPECT().
+			FetchSubscriptionsDueForRenewalInternal(mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, gotStart, gotEnd time.Time) ([]*models.Subscription, error) {
+				assert.True(t, gotStart.Equal(start))
+				assert.True(t, gotEnd.Equal(end))
+				return []*models.Subscription{validSub()}, nil
+			}).
+			Once()
+
+		got, err := clients.subscriptions.ListDueForRenewal(t.Context(), &submanv1.ListDueForRenewalRequest{
+			Start: timestamppb.New(start),
+			End:   timestamppb.New(end),
+		})
+		require.NoError(t, err)
+		assert.Len(t, got.GetSubscriptions(), 1)
+	})
+
+	tests := []struct {
+		name string
+		req  *submanv1.ListDueForRenewalRequest
+	}{
+		{name: "missing end", req: &submanv1.ListDueForRenewalRequest{Start: timestamppb.New(start)}},
+		{name: "end before start", req: &submanv1.ListDueForRenewalRequest{Start: timestamppb.New(end), End: timestamppb.New(start)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients, _, _ := setupServer(t)
+
+			_, err := clients.subscriptions.ListDueForRenewal(t.Context(), tt.req)
+			assertCode(t, err, codes.InvalidArgument)
+		})
+	}
+}
+
+func TestSubscriptionServer_ListPastDue(t *testing.T) {
+	clients, subscriptionService, _ := setupServer(t)
+	pastDue := validSub()
+	pastDue.Status = models.PastDue
+	subscriptionService.EXPECT().FetchPastDueSubscriptionsInternal(mock.Anything).
+		Return([]*models.Subscription{pastDue}, nil).Once()
+
+	got, err := clients.subscriptions.ListPastDue(t.Context(), &submanv1.ListPastDueRequest{})
+	require.NoError(t, err)
+	require.Len(t, got.GetSubscriptions(), 1)
+	assert.Equal(t, string(models.PastDue), got.GetSubscriptions()[0].GetStatus())
+}
+
+func TestSubscriptionServer_HasSubscriptions(t *testing.T) {
+	clients, subscriptionService, _ := setupServer(t)
+	subscriptionService.EXPECT().HasActiveSubscriptionsInternal(mock.Anything, userID).Return(true, nil).Once()
+
+	got, err := clients.subscriptions.HasSubscriptions(t.Context(), &submanv1.HasSubscriptionsRequest{UserId: userID.Hex()})
+	require.NoError(t, err)
+	assert.True(t, got.GetHasSubscriptions())
+
+	_, err = clients.subscriptions.HasSubscriptions(t.Context(), &submanv1.HasSubscriptionsRequest{UserId: "nope"})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestUserServer_GetUser(t *testing.T) {
+	user := &models.User{
+		ID:        userID,
+		Name:      "Jane",
+		Email:     "jane@example.com",
+		Password:  "hash",
+		Role:      models.UserRole,
+		Locale:    "de",
+		CreatedAt: mockTime,
+		UpdatedAt: mockTime,
+	}
+
+	t.Run("by ID", func(t *testing.T) {
+		clients, _, userService := setupServer(t)
+		userService.EXPECT().FetchUserByIDInternal(mock.Anything, userID).Return(user, nil).Once()
+
+		got, err := clients.users.GetUser(t.Context(), &submanv1.GetUserRequest{Id: userID.Hex()})
+		require.NoError(t, err)
+		assert.Equal(t, userID.Hex(), got.GetId())
+		assert.Equal(t, "jane@example.com", got.GetEmail())
+		assert.Equal(t, string(models.UserRole), got.GetRole())
+		assert.Equal(t, "de", got.GetLocale())
+	})
+
+	t.Run("by email", func(t *testing.T) {
+		clients, _, userService := setupServer(t)
+		userService.EXPECT().FetchUserByEmailInternal(mock.Anything, "jane@example.com").Return(user, nil).Once()
+
+		got, err := clients.users.GetUserByEmail(t.Context(), &submanv1.GetUserByEmailRequest{Email: "jane@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, userID.Hex(), got.GetId())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		clients, _, userService := setupServer(t)
+		userService.EXPECT().FetchUserByIDInternal(mock.Anything, userID).
+			Return(nil, apperror.NewNotFoundError("User not found")).Once()
+
+		_, err := clients.users.GetUser(t.Context(), &submanv1.GetUserRequest{Id: userID.Hex()})
+		assertCode(t, err, codes.NotFound)
+	})
+
+	t.Run("empty email", func(t *testing.T) {
+		clients, _, _ := setupServer(t)
+
+		_, err := clients.users.GetUserByEmail(t.Context(), &submanv1.GetUserByEmailRequest{})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+}
+
+func TestServer_Health(t *testing.T) {
+	clients, _, _ := setupServer(t)
+
+	got, err := clients.health.Check(t.Context(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, got.GetStatus())
+}
@@ -0,0 +1,133 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	submanv1 "github.com/anuragthepathak/subscription-management/proto/subman/v1"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type subscriptionServer struct {
+	submanv1.UnimplementedSubscriptionServiceInternalServer
+	subscriptionService services.SubscriptionServiceInternal
+}
+
+func (s *subscriptionServer) GetSubscription(
+	ctx context.Context,
+	req *submanv1.GetSubscriptionRequest,
+) (*submanv1.Subscription, error) {
+	id, err := bson.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid subscription ID")
+	}
+	subscription, err := s.subscriptionService.FetchSubscriptionByIDInternal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toSubscription(subscription), nil
+}
+
+func (s *subscriptionServer) ListUpcomingRenewals(
+	ctx context.Context,
+	req *submanv1.ListUpcomingRenewalsRequest,
+) (*submanv1.ListSubscriptionsResponse, error) {
+	days := make([]int, 0, len(req.GetDays()))
+	for _, d := range req.GetDays() {
+		if d < 0 {
+			return nil, apperror.NewValidationError("days must not be negative")
+		}
+		days = append(days, int(d))
+	}
+	if len(days) == 0 {
+		return &submanv1.ListSubscriptionsResponse{}, nil
+	}
+	return toSubscriptionList(s.subscriptionService.FetchUpcomingRenewalsInternal(ctx, days))
+}
+
+func (s *subscriptionServer) ListDueForRenewal(
+	ctx context.Context,
+	req *submanv1.ListDueForRenewalRequest,
+) (*submanv1.ListSubscriptionsResponse, error) {
+	if req.GetStart() == nil || req.GetEnd() == nil {
+		return nil, apperror.NewValidationError("start and end are required")
+	}
+	start, end := req.GetStart().AsTime(), req.GetEnd().AsTime()
+	if !start.Before(end) {
+		return nil, apperror.NewValidationError("start must be before end")
+	}
+	return toSubscriptionList(s.subscriptionService.FetchSubscriptionsDueForRenewalInternal(ctx, start, end))
+}
+
+func (s *subscriptionServer) ListPastDue(
+	ctx context.Context,
+	_ *submanv1.ListPastDueRequest,
+) (*submanv1.ListSubscriptionsResponse, error) {
+	return toSubscriptionList(s.subscriptionService.FetchPastDueSubscriptionsInternal(ctx))
+}
+
+func (s *subscriptionServer) HasSubscriptions(
+	ctx context.Context,
+	req *submanv1.HasSubscriptionsRequest,
+) (*submanv1.HasSubscriptionsResponse, error) {
+	userID, err := bson.ObjectIDFromHex(req.GetUserId())
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid user ID")
+	}
+	has, err := s.subscriptionService.HasActiveSubscriptionsInternal(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &submanv1.HasSubscriptionsResponse{HasSubscriptions: has}, nil
+}
+
+// toSubscriptionList converts the result of a service call listing
+// subscriptions.
+func toSubscriptionList(subscriptions []*models.Subscription, err error) (*submanv1.ListSubscriptionsResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	resp := &submanv1.ListSubscriptionsResponse{
+		Subscriptions: make([]*submanv1.Subscription, 0, len(subscriptions)),
+	}
+	for _, subscription := range subscriptions {
+		resp.Subscriptions = append(resp.Subscriptions, toSubscription(subscription))
+	}
+	return resp, nil
+}
+
+func toSubscription(s *models.Subscription) *submanv1.Subscription {
+	pb := &submanv1.Subscription{
+		Id:          s.ID.Hex(),
+		UserId:      s.UserID.Hex(),
+		Name:        s.Name,
+		Price:       s.Price,
+		Currency:    string(s.Currency),
+		Frequency:   string(s.Frequency),
+		Category:    string(s.Category),
+		Status:      string(s.Status),
+		ValidTill:   timestamppb.New(s.ValidTill),
+		TrialEndsAt: optionalTimestamp(s.TrialEndsAt),
+		LastUsedAt:  optionalTimestamp(s.LastUsedAt),
+		PausedAt:    optionalTimestamp(s.PausedAt),
+		Timezone:    s.Timezone,
+		CreatedAt:   timestamppb.New(s.CreatedAt),
+		UpdatedAt:   timestamppb.New(s.UpdatedAt),
+	}
+	if s.PaymentMethodID != nil {
+		pb.PaymentMethodId = s.PaymentMethodID.Hex()
+	}
+	return pb
+}
+
+// optionalTimestamp leaves the field unset for a nil time.
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
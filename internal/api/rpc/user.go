@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	submanv1 "github.com/anuragthepathak/subscription-management/proto/subman/v1"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type userServer struct {
+	submanv1.UnimplementedUserServiceInternalServer
+	userService services.UserServiceInternal
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *submanv1.GetUserRequest) (*submanv1.User, error) {
+	id, err := bson.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid user ID")
+	}
+	user, err := s.userService.FetchUserByIDInternal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+func (s *userServer) GetUserByEmail(ctx context.Context, req *submanv1.GetUserByEmailRequest) (*submanv1.User, error) {
+	if req.GetEmail() == "" {
+		return nil, apperror.NewValidationError("email is required")
+	}
+	user, err := s.userService.FetchUserByEmailInternal(ctx, req.GetEmail())
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+// toUser converts a user, leaving out the password hash.
+func toUser(u *models.User) *submanv1.User {
+	return &submanv1.User{
+		Id:        u.ID.Hex(),
+		Name:      u.Name,
+		Email:     u.Email,
+		Role:      string(u.Role),
+		Locale:    u.Locale,
+		CreatedAt: timestamppb.New(u.CreatedAt),
+		UpdatedAt: timestamppb.New(u.UpdatedAt),
+	}
+}
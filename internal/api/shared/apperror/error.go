@@ -14,11 +14,13 @@ const (
 	ErrForbidden    ErrorCode = "FORBIDDEN"
 	ErrNotFound     ErrorCode = "NOT_FOUND"
 	ErrConflict     ErrorCode = "CONFLICT"
+	ErrPrecondition ErrorCode = "PRECONDITION_FAILED"
 	ErrBadRequest   ErrorCode = "BAD_REQUEST"
 	ErrValidation   ErrorCode = "VALIDATION"
 	ErrTimeout      ErrorCode = "TIMEOUT"
 	ErrDB           ErrorCode = "DB_ERROR"
 	ErrRateLimited  ErrorCode = "RATE_LIMITED"
+	ErrTooLarge     ErrorCode = "PAYLOAD_TOO_LARGE"
 )
 
 // AppError defines a structured application error.
@@ -27,24 +29,46 @@ type AppError interface {
 	Code() ErrorCode
 	Unwrap() error
 	Message() string
+	// Localize returns the message translated by t.
+	Localize(t Translator) string
 	Status() int
 	LogAttributes() []slog.Attr
 	WithLogAttributes(attrs ...slog.Attr) AppError
+	// Details lists the fields of the request that failed validation.
+	Details() []FieldError
+	WithDetails(details ...FieldError) AppError
+}
+
+// FieldError names a request field that broke a validation rule, e.g.
+// {Field: "email", Rule: "email"} or {Field: "device", Rule: "max", Param:
+// "100"}.
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
+}
+
+// Translator translates a message format and formats it with args.
+// *i18n.Localizer satisfies it.
+type Translator interface {
+	Sprintf(format string, args ...any) string
 }
 
 type appError struct {
 	code    ErrorCode
-	message string
+	message string // A format when args are set.
+	args    []any
 	status  int
 	err     error
 	attrs   []slog.Attr
+	details []FieldError
 }
 
 func (e *appError) Error() string {
 	if e.err != nil {
-		return fmt.Sprintf("%s: %s (%v)", e.code, e.message, e.err)
+		return fmt.Sprintf("%s: %s (%v)", e.code, e.Message(), e.err)
 	}
-	return fmt.Sprintf("%s: %s", e.code, e.message)
+	return fmt.Sprintf("%s: %s", e.code, e.Message())
 }
 
 // Unwrap returns the underlying wrapped error, allowing standard library
@@ -58,7 +82,14 @@ func (e *appError) Code() ErrorCode {
 }
 
 func (e *appError) Message() string {
-	return e.message
+	if len(e.args) == 0 {
+		return e.message
+	}
+	return fmt.Sprintf(e.message, e.args...)
+}
+
+func (e *appError) Localize(t Translator) string {
+	return t.Sprintf(e.message, e.args...)
 }
 
 func (e *appError) Status() int {
@@ -74,3 +105,13 @@ func (e *appError) WithLogAttributes(attrs ...slog.Attr) AppError {
 	e.attrs = append(e.attrs, attrs...)
 	return e
 }
+
+func (e *appError) Details() []FieldError {
+	return e.details
+}
+
+// WithDetails adds the fields that failed validation to the error.
+func (e *appError) WithDetails(details ...FieldError) AppError {
+	e.details = append(e.details, details...)
+	return e
+}
@@ -2,6 +2,7 @@ package apperror_test
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"testing"
@@ -75,6 +76,13 @@ func TestAppError_CodeAndStatus(t *testing.T) {
 			wantStatus: http.StatusConflict,
 			wantMsg:    msg,
 		},
+		{
+			name:       "NewPreconditionFailedError",
+			err:        apperror.NewPreconditionFailedError(msg),
+			wantCode:   apperror.ErrPrecondition,
+			wantStatus: http.StatusPreconditionFailed,
+			wantMsg:    msg,
+		},
 		{
 			name:       "NewBadRequestError",
 			err:        apperror.NewBadRequestError(msg),
@@ -96,6 +104,13 @@ func TestAppError_CodeAndStatus(t *testing.T) {
 			wantStatus: http.StatusTooManyRequests,
 			wantMsg:    msg,
 		},
+		{
+			name:       "NewPayloadTooLargeError",
+			err:        apperror.NewPayloadTooLargeError(msg),
+			wantCode:   apperror.ErrTooLarge,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantMsg:    msg,
+		},
 	}
 
 	for _, tt := range tests {
@@ -142,6 +157,40 @@ func TestAppError_ErrorString(t *testing.T) {
 	})
 }
 
+// ---------------------------------------------------------------------------
+// Message formatting & Localize
+// ---------------------------------------------------------------------------
+
+// catalog is a Translator looking formats up in a map.
+type catalog map[string]string
+
+func (c catalog) Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(c[format], args...)
+}
+
+func TestAppError_Message(t *testing.T) {
+	t.Run("formats args", func(t *testing.T) {
+		err := apperror.NewValidationError("channel %s is not available", "sms")
+
+		assert.Equal(t, "channel sms is not available", err.Message())
+		assert.Contains(t, err.Error(), "channel sms is not available")
+	})
+
+	t.Run("without args the message is not a format", func(t *testing.T) {
+		err := apperror.NewValidationError("100% invalid")
+
+		assert.Equal(t, "100% invalid", err.Message())
+	})
+
+	t.Run("localizes the format before formatting", func(t *testing.T) {
+		err := apperror.NewValidationError("channel %s is not available", "sms")
+
+		assert.Equal(t, "Kanal sms ist nicht verfügbar", err.Localize(catalog{
+			"channel %s is not available": "Kanal %s ist nicht verfügbar",
+		}))
+	})
+}
+
 // ---------------------------------------------------------------------------
 // WithLogAttributes
 // ---------------------------------------------------------------------------
@@ -158,3 +207,20 @@ func TestAppError_WithLogAttributes(t *testing.T) {
 	assert.Equal(t, attr1, attrs[0])
 	assert.Equal(t, attr2, attrs[1])
 }
+
+// ---------------------------------------------------------------------------
+// WithDetails
+// ---------------------------------------------------------------------------
+
+func TestAppError_WithDetails(t *testing.T) {
+	assert.Empty(t, apperror.NewValidationError("invalid request").Details())
+
+	err := apperror.NewValidationError("invalid request").
+		WithDetails(apperror.FieldError{Field: "email", Rule: "required"}).
+		WithDetails(apperror.FieldError{Field: "device", Rule: "max", Param: "100"})
+
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "email", Rule: "required"},
+		{Field: "device", Rule: "max", Param: "100"},
+	}, err.Details())
+}
@@ -2,6 +2,10 @@ package apperror
 
 import "net/http"
 
+// Factories taking a msg treat it as a format when args are given, e.g.
+// NewValidationError("invalid channel %s", channel). Keep the format
+// constant, so it can be looked up in the message catalogs.
+
 // Generic errors.
 func NewInternalError(err error) AppError {
 	return &appError{
@@ -22,52 +26,69 @@ func NewTimeoutError(err error) AppError {
 }
 
 // Authentication errors.
-func NewUnauthorizedError(msg string) AppError {
+func NewUnauthorizedError(msg string, args ...any) AppError {
 	return &appError{
 		code:    ErrUnauthorized,
 		message: msg,
+		args:    args,
 		status:  http.StatusUnauthorized,
 	}
 }
 
-func NewForbiddenError(msg string) AppError {
+func NewForbiddenError(msg string, args ...any) AppError {
 	return &appError{
 		code:    ErrForbidden,
 		message: msg,
+		args:    args,
 		status:  http.StatusForbidden,
 	}
 }
 
 // Validation errors.
-func NewValidationError(msg string) AppError {
+func NewValidationError(msg string, args ...any) AppError {
 	return &appError{
 		code:    ErrValidation,
 		message: msg,
+		args:    args,
 		status:  http.StatusBadRequest,
 	}
 }
 
 // Database and CRUD errors.
-func NewNotFoundError(msg string) AppError {
+func NewNotFoundError(msg string, args ...any) AppError {
 	return &appError{
 		code:    ErrNotFound,
 		message: msg,
+		args:    args,
 		status:  http.StatusNotFound,
 	}
 }
 
-func NewConflictError(msg string) AppError {
+func NewConflictError(msg string, args ...any) AppError {
 	return &appError{
 		code:    ErrConflict,
 		message: msg,
+		args:    args,
 		status:  http.StatusConflict,
 	}
 }
 
-func NewBadRequestError(msg string) AppError {
+// NewPreconditionFailedError reports that a conditional request, e.g. one
+// with If-Match, no longer matches the resource.
+func NewPreconditionFailedError(msg string, args ...any) AppError {
+	return &appError{
+		code:    ErrPrecondition,
+		message: msg,
+		args:    args,
+		status:  http.StatusPreconditionFailed,
+	}
+}
+
+func NewBadRequestError(msg string, args ...any) AppError {
 	return &appError{
 		code:    ErrBadRequest,
 		message: msg,
+		args:    args,
 		status:  http.StatusBadRequest,
 	}
 }
@@ -81,11 +102,22 @@ func NewDBError(err error) AppError {
 	}
 }
 
+// NewPayloadTooLargeError reports an upload over the size limit.
+func NewPayloadTooLargeError(msg string, args ...any) AppError {
+	return &appError{
+		code:    ErrTooLarge,
+		message: msg,
+		args:    args,
+		status:  http.StatusRequestEntityTooLarge,
+	}
+}
+
 // Rate limit errors.
-func NewRateLimitError(msg string) AppError {
+func NewRateLimitError(msg string, args ...any) AppError {
 	return &appError{
 		code:    ErrRateLimited,
 		message: msg,
+		args:    args,
 		status:  http.StatusTooManyRequests,
 	}
 }
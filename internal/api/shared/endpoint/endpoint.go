@@ -1,14 +1,21 @@
 package endpoint
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"log/slog"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/i18n"
 	"github.com/go-playground/validator/v10"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -41,9 +48,7 @@ func (h *RequestHandler) readRequestBody(w http.ResponseWriter, r *http.Request,
 				logattr.LimitBytes(maxBytesErr.Limit),
 			)
 
-			WriteAPIResponse(w, http.StatusRequestEntityTooLarge, map[string]string{
-				"error": "Request body too large",
-			})
+			WriteError(w, r, apperror.NewPayloadTooLargeError("Request body too large"))
 			return false
 		}
 
@@ -53,11 +58,7 @@ func (h *RequestHandler) readRequestBody(w http.ResponseWriter, r *http.Request,
 			logattr.Error(err),
 		)
 
-		WriteAPIResponse(
-			w,
-			http.StatusBadRequest,
-			map[string]string{"error": "Invalid JSON"},
-		)
+		WriteError(w, r, apperror.NewBadRequestError("Invalid JSON"))
 		return false
 	}
 
@@ -68,16 +69,57 @@ func (h *RequestHandler) readRequestBody(w http.ResponseWriter, r *http.Request,
 			logattr.Error(err),
 		)
 
-		WriteAPIResponse(
-			w,
-			http.StatusBadRequest,
-			map[string]string{"error": err.Error()},
-		)
+		WriteError(w, r, validationError(err))
 		return false
 	}
 	return true
 }
 
+// validationError lists the fields a validator error names, so clients can
+// point at each of them.
+func validationError(err error) apperror.AppError {
+	appErr := apperror.NewValidationError("Request validation failed")
+	if fieldErrs, ok := errors.AsType[validator.ValidationErrors](err); ok {
+		for _, fe := range fieldErrs {
+			appErr = appErr.WithDetails(apperror.FieldError{
+				Field: fe.Field(),
+				Rule:  fe.Tag(),
+				Param: fe.Param(),
+			})
+		}
+	}
+	return appErr
+}
+
+// timeoutError turns a failure caused by the request deadline into a 504
+// TIMEOUT, whether the error reports the deadline itself or is a server
+// error of a call it cut short. Client errors are kept.
+func timeoutError(ctx context.Context, err error) error {
+	appErr, isAppErr := errors.AsType[apperror.AppError](err)
+	if isAppErr && appErr.Code() == apperror.ErrTimeout {
+		return err
+	}
+	if errors.Is(err, context.DeadlineExceeded) ||
+		(errors.Is(ctx.Err(), context.DeadlineExceeded) && (!isAppErr || appErr.Status() >= 500)) {
+		return apperror.NewTimeoutError(err)
+	}
+	return err
+}
+
+// JSONFieldName names a struct field by its JSON key. Registered with
+// validator.RegisterTagNameFunc, it makes validation errors name the fields
+// clients send.
+func JSONFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
 // ServeRequest processes an HTTP request using the provided InternalRequest configuration.
 func (h *RequestHandler) ServeRequest(req InternalRequest) {
 	if !h.readRequestBody(req.W, req.R, req.ReqBodyObj) {
@@ -95,6 +137,7 @@ func (h *RequestHandler) ServeRequest(req InternalRequest) {
 	respBodyObj, err := req.EndpointLogic()
 	if err != nil {
 		span := trace.SpanFromContext(req.R.Context())
+		err = timeoutError(req.R.Context(), err)
 
 		if appErr, ok := errors.AsType[apperror.AppError](err); ok {
 			status := appErr.Status()
@@ -124,11 +167,7 @@ func (h *RequestHandler) ServeRequest(req InternalRequest) {
 				)
 			}
 
-			WriteAPIResponse(
-				req.W,
-				status,
-				map[string]string{"error": appErr.Message()},
-			)
+			WriteError(req.W, req.R, appErr)
 		} else {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, "Unhandled error")
@@ -139,25 +178,97 @@ func (h *RequestHandler) ServeRequest(req InternalRequest) {
 				logattr.Error(err),
 			)
 
-			WriteAPIResponse(
-				req.W,
-				http.StatusInternalServerError,
-				map[string]string{
-					"error": "An unexpected internal error occurred.",
-				},
-			)
+			writeErrorBody(req.W, http.StatusInternalServerError, ErrorBody{
+				Code:    apperror.ErrInternal,
+				Message: i18n.T(req.R.Context(), "An unexpected internal error occurred."),
+			})
+		}
+		return
+	}
+
+	if status, ok := respBodyObj.(*StatusResponse); ok {
+		req.SuccessCode, respBodyObj = status.Status, status.Body
+	}
+	if stream, ok := respBodyObj.(*StreamResponse); ok {
+		writeStreamResponse(req.W, req.R, req.SuccessCode, stream)
+		return
+	}
+	if respBodyObj == nil {
+		WriteJSON(req.W, req.SuccessCode, nil)
+		return
+	}
+
+	body := Response{Data: respBodyObj}
+	if page, ok := respBodyObj.(*PageResponse); ok {
+		req.W.Header().Set("X-Total-Count", strconv.FormatInt(page.Total, 10))
+		body = Response{
+			Data: page.Items,
+			Meta: &Meta{Pagination: &Pagination{Total: page.Total, Limit: page.Limit, Offset: page.Offset}},
 		}
+	}
+	if req.R.Method == http.MethodGet && req.SuccessCode == http.StatusOK {
+		writeTaggedResponse(req.W, req.R, req.SuccessCode, body)
 		return
 	}
+	WriteJSON(req.W, req.SuccessCode, body)
+}
+
+// writeStreamResponse copies a raw body to the client.
+func writeStreamResponse(w http.ResponseWriter, r *http.Request, statusCode int, stream *StreamResponse) {
+	defer stream.Body.Close()
+
+	w.Header().Set("Content-Type", stream.ContentType)
+	if stream.Filename != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+			"filename": stream.Filename,
+		}))
+	}
+	if stream.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(stream.Size, 10))
+	}
+	w.WriteHeader(statusCode)
 
-	WriteAPIResponse(req.W, req.SuccessCode, respBodyObj)
+	// Headers are already sent, so a failed copy can only be logged.
+	if _, err := io.Copy(w, stream.Body); err != nil {
+		slog.WarnContext(r.Context(), "Failed to write stream response",
+			logattr.Method(r.Method),
+			logattr.Path(r.URL.Path),
+			logattr.Error(err),
+		)
+	}
 }
 
-// WriteAPIResponse writes the response in JSON format.
+// WriteAPIResponse writes res as the data of a response envelope. A nil res
+// writes no body.
 func WriteAPIResponse(w http.ResponseWriter, statusCode int, res any) {
+	if res == nil {
+		WriteJSON(w, statusCode, nil)
+		return
+	}
+	WriteJSON(w, statusCode, Response{Data: res})
+}
+
+// WriteJSON writes v in JSON format as is, outside the response envelope,
+// for bodies whose format a standard fixes, such as a JWKS. A nil v writes no
+// body.
+func WriteJSON(w http.ResponseWriter, statusCode int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
-	if res != nil {
-		_ = json.NewEncoder(w).Encode(res)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
 	}
 }
+
+// WriteError writes err as the error of a response envelope, with its status
+// and code, and its message translated to the locale of r.
+func WriteError(w http.ResponseWriter, r *http.Request, err apperror.AppError) {
+	writeErrorBody(w, err.Status(), ErrorBody{
+		Code:    err.Code(),
+		Message: err.Localize(i18n.FromContext(r.Context())),
+		Details: err.Details(),
+	})
+}
+
+func writeErrorBody(w http.ResponseWriter, statusCode int, body ErrorBody) {
+	WriteJSON(w, statusCode, ErrorResponse{Error: body})
+}
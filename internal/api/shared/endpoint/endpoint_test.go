@@ -2,15 +2,20 @@ package endpoint_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -64,10 +69,12 @@ func TestRequestHandler_ServeRequest(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 
-		var resp dummyResponse
+		var resp struct {
+			Data dummyResponse `json:"data"`
+		}
 		err := json.NewDecoder(rr.Body).Decode(&resp)
 		require.NoError(t, err)
-		assert.Equal(t, "success", resp.Message)
+		assert.Equal(t, "success", resp.Data.Message)
 	})
 
 	t.Run("success - defaults to 200 OK if SuccessCode is 0", func(t *testing.T) {
@@ -86,6 +93,77 @@ func TestRequestHandler_ServeRequest(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 	})
 
+	t.Run("success - writes StreamResponse body raw with download headers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+
+		handler.ServeRequest(endpoint.InternalRequest{
+			W: rr,
+			R: req,
+			EndpointLogic: func() (any, error) {
+				return &endpoint.StreamResponse{
+					ContentType: "application/pdf",
+					Filename:    "invoice.pdf",
+					Size:        5,
+					Body:        io.NopCloser(strings.NewReader("%PDF-")),
+				}, nil
+			},
+			SuccessCode: http.StatusOK,
+		})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=invoice.pdf`, rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, "5", rr.Header().Get("Content-Length"))
+		assert.Equal(t, "%PDF-", rr.Body.String())
+	})
+
+	t.Run("success - StatusResponse overrides SuccessCode", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+
+		handler.ServeRequest(endpoint.InternalRequest{
+			W: rr,
+			R: req,
+			EndpointLogic: func() (any, error) {
+				return &endpoint.StatusResponse{
+					Status: http.StatusAccepted,
+					Body:   map[string]string{"status": "queued"},
+				}, nil
+			},
+			SuccessCode: http.StatusOK,
+		})
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		assert.JSONEq(t, `{"data":{"status":"queued"}}`, rr.Body.String())
+	})
+
+	t.Run("success - PageResponse reports the page in meta.pagination", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+
+		handler.ServeRequest(endpoint.InternalRequest{
+			W: rr,
+			R: req,
+			EndpointLogic: func() (any, error) {
+				return &endpoint.PageResponse{
+					Items:  []dummyResponse{{Message: "first"}},
+					Total:  21,
+					Limit:  1,
+					Offset: 20,
+				}, nil
+			},
+			SuccessCode: http.StatusOK,
+		})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "21", rr.Header().Get("X-Total-Count"))
+		assert.JSONEq(t, `{
+			"data": [{"message": "first"}],
+			"meta": {"pagination": {"total": 21, "limit": 1, "offset": 20}}
+		}`, rr.Body.String())
+	})
+
 	t.Run("error - translates AppError to correct HTTP status code", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		rr := httptest.NewRecorder()
@@ -101,7 +179,24 @@ func TestRequestHandler_ServeRequest(t *testing.T) {
 		})
 
 		assert.Equal(t, http.StatusNotFound, rr.Code)
-		assert.Contains(t, rr.Body.String(), expectedErr.Message())
+		assert.JSONEq(t, `{"error":{"code":"NOT_FOUND","message":"user not found"}}`, rr.Body.String())
+	})
+
+	t.Run("error - localizes AppError message into the request locale", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(appctx.WithLocale(req.Context(), "de"))
+		rr := httptest.NewRecorder()
+
+		handler.ServeRequest(endpoint.InternalRequest{
+			W: rr,
+			R: req,
+			EndpointLogic: func() (any, error) {
+				return nil, apperror.NewValidationError("channel %s is not available", "sms")
+			},
+		})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Kanal sms ist nicht verfügbar")
 	})
 
 	t.Run("error - translates unhandled error to 500 Internal Server Error safely", func(t *testing.T) {
@@ -119,7 +214,57 @@ func TestRequestHandler_ServeRequest(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rr.Code)
 		// Vault Lock: Prove we don't leak the raw error message to the client
 		assert.NotContains(t, rr.Body.String(), "database exploded entirely")
-		assert.Contains(t, rr.Body.String(), "An unexpected internal error occurred")
+		assert.JSONEq(t, `{"error":{"code":"INTERNAL","message":"An unexpected internal error occurred."}}`, rr.Body.String())
+	})
+
+	t.Run("error - deadline exceeded returns 504 Gateway Timeout", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+
+		handler.ServeRequest(endpoint.InternalRequest{
+			W: rr,
+			R: req,
+			EndpointLogic: func() (any, error) {
+				return nil, fmt.Errorf("failed to read cache: %w", context.DeadlineExceeded)
+			},
+		})
+
+		assert.Equal(t, http.StatusGatewayTimeout, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"TIMEOUT"`)
+	})
+
+	t.Run("error - server error after the request deadline returns 504 Gateway Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+		defer cancel()
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+		rr := httptest.NewRecorder()
+
+		handler.ServeRequest(endpoint.InternalRequest{
+			W: rr,
+			R: req,
+			EndpointLogic: func() (any, error) {
+				return nil, apperror.NewDBError(errors.New("connection reset"))
+			},
+		})
+
+		assert.Equal(t, http.StatusGatewayTimeout, rr.Code)
+	})
+
+	t.Run("error - client error after the request deadline is kept", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+		defer cancel()
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+		rr := httptest.NewRecorder()
+
+		handler.ServeRequest(endpoint.InternalRequest{
+			W: rr,
+			R: req,
+			EndpointLogic: func() (any, error) {
+				return nil, apperror.NewNotFoundError("subscription not found")
+			},
+		})
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
 
 	t.Run("error - invalid JSON returns 400 Bad Request", func(t *testing.T) {
@@ -139,7 +284,7 @@ func TestRequestHandler_ServeRequest(t *testing.T) {
 		})
 
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Invalid JSON")
+		assert.JSONEq(t, `{"error":{"code":"BAD_REQUEST","message":"Invalid JSON"}}`, rr.Body.String())
 	})
 
 	t.Run("error - struct validation failure returns 400 Bad Request", func(t *testing.T) {
@@ -160,7 +305,11 @@ func TestRequestHandler_ServeRequest(t *testing.T) {
 		})
 
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Error:Field validation for")
+		assert.JSONEq(t, `{"error":{
+			"code": "VALIDATION",
+			"message": "Request validation failed",
+			"details": [{"field": "Email", "rule": "required"}]
+		}}`, rr.Body.String())
 	})
 
 	t.Run("error - payload exceeding max bytes returns 413 Request Entity Too Large", func(t *testing.T) {
@@ -183,6 +332,146 @@ func TestRequestHandler_ServeRequest(t *testing.T) {
 		})
 
 		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"PAYLOAD_TOO_LARGE"`)
 		assert.Contains(t, rr.Body.String(), "Request body too large")
 	})
 }
+
+func TestRequestHandler_ServeRequest_ETag(t *testing.T) {
+	handler := setupHandler()
+	serve := func(method, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeRequest(endpoint.InternalRequest{
+			W: rr,
+			R: req,
+			EndpointLogic: func() (any, error) {
+				return &dummyResponse{Message: "success"}, nil
+			},
+			SuccessCode: http.StatusOK,
+		})
+		return rr
+	}
+
+	etag, err := endpoint.ETag(&dummyResponse{Message: "success"})
+	require.NoError(t, err)
+
+	t.Run("tags GET responses with the ETag of the body", func(t *testing.T) {
+		rr := serve(http.MethodGet, "")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, etag, rr.Header().Get("ETag"))
+		assert.JSONEq(t, `{"data":{"message":"success"}}`, rr.Body.String())
+	})
+
+	t.Run("answers 304 without a body when If-None-Match lists the ETag", func(t *testing.T) {
+		rr := serve(http.MethodGet, `"other", W/`+etag)
+
+		assert.Equal(t, http.StatusNotModified, rr.Code)
+		assert.Equal(t, etag, rr.Header().Get("ETag"))
+		assert.Empty(t, rr.Body.String())
+	})
+
+	t.Run("answers in full when the ETag changed", func(t *testing.T) {
+		rr := serve(http.MethodGet, `"stale"`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotEmpty(t, rr.Body.String())
+	})
+
+	t.Run("leaves other methods untagged", func(t *testing.T) {
+		rr := serve(http.MethodPost, etag)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("ETag"))
+	})
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	current := &dummyResponse{Message: "current"}
+	etag, err := endpoint.ETag(current)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		wantErr bool
+	}{
+		{name: "no header", ifMatch: ""},
+		{name: "matching ETag", ifMatch: etag},
+		{name: "listed among others", ifMatch: `"other", ` + etag},
+		{name: "any", ifMatch: "*"},
+		{name: "stale ETag", ifMatch: `"stale"`, wantErr: true},
+		{name: "weak ETag never matches", ifMatch: "W/" + etag, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			err := endpoint.CheckIfMatch(req, current)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			appErr, ok := errors.AsType[apperror.AppError](err)
+			require.True(t, ok)
+			assert.Equal(t, apperror.ErrPrecondition, appErr.Code())
+		})
+	}
+}
+
+func TestJSONFieldName(t *testing.T) {
+	v := validator.New()
+	v.RegisterTagNameFunc(endpoint.JSONFieldName)
+	handler := endpoint.NewRequestHandler(v)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "John Doe", "email": "john"}`))
+	rr := httptest.NewRecorder()
+	handler.ServeRequest(endpoint.InternalRequest{
+		W: rr,
+		R: req,
+		EndpointLogic: func() (any, error) {
+			t.Fatal("EndpointLogic should NEVER be called if validation fails")
+			return nil, nil
+		},
+		ReqBodyObj: &dummyRequest{},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"details":[{"field":"email","rule":"email"}]`)
+}
+
+func TestWriteError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(appctx.WithLocale(req.Context(), "de"))
+	rr := httptest.NewRecorder()
+
+	endpoint.WriteError(rr, req, apperror.NewValidationError("Invalid JSON").
+		WithDetails(apperror.FieldError{Field: "device", Rule: "max", Param: "100"}))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error":{
+		"code": "VALIDATION",
+		"message": "Ungültiges JSON",
+		"details": [{"field": "device", "rule": "max", "param": "100"}]
+	}}`, rr.Body.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	rr := httptest.NewRecorder()
+	endpoint.WriteJSON(rr, http.StatusOK, map[string]string{"status": "ok"})
+	assert.JSONEq(t, `{"status":"ok"}`, rr.Body.String(), "the body is not enveloped")
+
+	rr = httptest.NewRecorder()
+	endpoint.WriteAPIResponse(rr, http.StatusAccepted, nil)
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	assert.Empty(t, rr.Body.String())
+}
@@ -0,0 +1,85 @@
+package endpoint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+)
+
+// ETag returns the strong entity tag of the response whose data is v. Every
+// successful GET answered by ServeRequest carries the tag of its body, so a
+// client can send it back in If-None-Match, or in If-Match to update the
+// resource only if it is unchanged.
+func ETag(v any) (string, error) {
+	data, err := json.Marshal(Response{Data: v})
+	if err != nil {
+		return "", err
+	}
+	return etagOf(data), nil
+}
+
+func etagOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// CheckIfMatch fails with a precondition error unless r has no If-Match
+// header or it lists the ETag of current, the representation a GET of the
+// resource returns.
+func CheckIfMatch(r *http.Request, current any) error {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return nil
+	}
+	etag, err := ETag(current)
+	if err != nil {
+		return apperror.NewInternalError(err)
+	}
+	if !etagListed(header, etag, false) {
+		return apperror.NewPreconditionFailedError("The resource has changed since it was read")
+	}
+	return nil
+}
+
+// etagListed reports whether a comma-separated If-Match or If-None-Match
+// header lists etag or is "*". If-None-Match compares weakly, so a W/ prefix
+// still matches; If-Match compares strongly.
+func etagListed(header, etag string, weak bool) bool {
+	for candidate := range strings.SplitSeq(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeTaggedResponse writes res as JSON with its ETag, or only 304 Not
+// Modified when the request's If-None-Match lists the tag.
+func writeTaggedResponse(w http.ResponseWriter, r *http.Request, statusCode int, res Response) {
+	data, err := json.Marshal(res)
+	if err != nil {
+		WriteJSON(w, statusCode, res)
+		return
+	}
+	etag := etagOf(data)
+	w.Header().Set("ETag", etag)
+	if etagListed(r.Header.Get("If-None-Match"), etag, true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(append(data, '\n'))
+}
@@ -1,5 +1,11 @@
 package endpoint
 
+import (
+	"io"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+)
+
 // InternalModel defines a generic interface for models that support conversion to response types.
 type InternalModel[T any] interface {
 	ToResponse() *T
@@ -24,3 +30,61 @@ func ToResponseSlice[T InternalModel[R], R any](models []T, err error) ([]*R, er
 	}
 	return responses, nil
 }
+
+// StatusResponse is returned from EndpointLogic to answer with a status other
+// than the request's SuccessCode, for endpoints whose outcome decides it. Body
+// is written as any other response, and may itself be a StreamResponse.
+type StatusResponse struct {
+	Status int
+	Body   any
+}
+
+// StreamResponse is returned from EndpointLogic to send a raw body, such as a
+// stored file, instead of JSON. ServeRequest closes Body once it is written.
+type StreamResponse struct {
+	ContentType string
+	Filename    string // Sent as an attachment filename when set.
+	Size        int64  // Content-Length when greater than zero.
+	Body        io.ReadCloser
+}
+
+// PageResponse is returned from EndpointLogic to answer with one page of a
+// listing. Items become the data of the response and the rest its
+// meta.pagination; Total is also sent in the X-Total-Count header.
+type PageResponse struct {
+	Items  any
+	Total  int64 // Number of matching items across all pages.
+	Limit  int
+	Offset int
+}
+
+// Response is the envelope of every successful JSON response.
+type Response struct {
+	Data any   `json:"data"`
+	Meta *Meta `json:"meta,omitempty"`
+}
+
+// Meta describes the data of a response.
+type Meta struct {
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination locates a page within a listing.
+type Pagination struct {
+	Total  int64 `json:"total"`
+	Limit  int   `json:"limit"`
+	Offset int   `json:"offset"`
+}
+
+// ErrorResponse is the envelope of every error response.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes a failure. Code is machine-readable, for clients to
+// branch on; Message is translated for people.
+type ErrorBody struct {
+	Code    apperror.ErrorCode    `json:"code"`
+	Message string                `json:"message"`
+	Details []apperror.FieldError `json:"details,omitempty"`
+}
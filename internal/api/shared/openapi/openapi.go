@@ -0,0 +1,252 @@
+// Package openapi builds an OpenAPI 3 document from the operations that
+// controllers declare next to their routes.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Version is the OpenAPI version of the generated document.
+const Version = "3.0.3"
+
+// Operation documents one route of a controller.
+type Operation struct {
+	Method  string // HTTP method, e.g. http.MethodGet.
+	Path    string // chi pattern relative to the controller's mount point.
+	Summary string
+
+	// Public marks routes that need no bearer token, such as login or links
+	// authorized by a signature.
+	Public bool
+	Query  []Param
+
+	// Request and Response are zero values of the JSON body types; nil means
+	// no body.
+	Request  any
+	Response any
+
+	// Upload names the multipart/form-data field of a file upload. It
+	// replaces Request.
+	Upload string
+
+	// ContentType of the response when it is not JSON, e.g. "text/calendar".
+	ContentType string
+	Status      int // Success status; 0 means 200.
+
+	// Raw marks JSON responses written outside the response envelope, such
+	// as a JWKS, whose format a standard fixes.
+	Raw bool
+}
+
+// Param documents a query parameter.
+type Param struct {
+	Name        string
+	Description string
+	Type        string // JSON schema type; empty means "string".
+	Required    bool
+}
+
+// Spec collects the operations of every controller into one document.
+type Spec struct {
+	mu          sync.Mutex
+	doc         document
+	schemas     *schemaRegistry
+	errorSchema *schema
+	metaSchema  *schema // Set when success bodies are enveloped.
+	json        []byte
+}
+
+// NewSpec creates an empty document for the API.
+func NewSpec(title, version string) *Spec {
+	schemas := newSchemaRegistry()
+	return &Spec{
+		doc: document{
+			OpenAPI: Version,
+			Info:    info{Title: title, Version: version},
+			Paths:   make(map[string]map[string]*operation),
+			Components: components{
+				Schemas: schemas.schemas,
+				SecuritySchemes: map[string]securityScheme{
+					bearerScheme: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				},
+			},
+			Security: []map[string][]string{{bearerScheme: {}}},
+		},
+		schemas: schemas,
+	}
+}
+
+// ErrorSchema registers the body of error responses. Every operation
+// documents it as its default response.
+func (s *Spec) ErrorSchema(body any) *Spec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errorSchema = s.schemas.schemaOf(body)
+	return s
+}
+
+// Envelope documents the JSON body of every success response as the data
+// property of an envelope, next to an optional meta property of the type of
+// meta. Operations marked Raw are left as they are.
+func (s *Spec) Envelope(meta any) *Spec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.metaSchema = s.schemas.schemaOf(meta)
+	return s
+}
+
+// Mount adds the operations of a controller mounted at prefix, grouped under
+// tag.
+func (s *Spec) Mount(prefix, tag string, ops []Operation) *Spec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, op := range ops {
+		path := joinPath(prefix, op.Path)
+		if s.doc.Paths[path] == nil {
+			s.doc.Paths[path] = make(map[string]*operation)
+		}
+		s.doc.Paths[path][strings.ToLower(op.Method)] = s.operation(path, tag, op)
+	}
+	s.json = nil
+	return s
+}
+
+// JSON returns the document encoded as JSON.
+func (s *Spec) JSON() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.json == nil {
+		b, err := json.Marshal(s.doc)
+		if err != nil {
+			return nil, err
+		}
+		s.json = b
+	}
+	return s.json, nil
+}
+
+// ServeHTTP serves the document.
+func (s *Spec) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	b, err := s.JSON()
+	if err != nil {
+		http.Error(w, "Failed to encode API document", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+func (s *Spec) operation(path, tag string, op Operation) *operation {
+	out := &operation{
+		Summary:     op.Summary,
+		Tags:        []string{tag},
+		OperationID: operationID(op.Method, path),
+		Responses:   make(map[string]response),
+	}
+	if op.Public {
+		out.Security = &[]map[string][]string{}
+	}
+
+	for _, name := range pathParams(path) {
+		out.Parameters = append(out.Parameters, parameter{
+			Name: name, In: "path", Required: true, Schema: &schema{Type: "string"},
+		})
+	}
+	for _, p := range op.Query {
+		typ := p.Type
+		if typ == "" {
+			typ = "string"
+		}
+		out.Parameters = append(out.Parameters, parameter{
+			Name: p.Name, In: "query", Description: p.Description, Required: p.Required, Schema: &schema{Type: typ},
+		})
+	}
+
+	switch {
+	case op.Upload != "":
+		out.RequestBody = &requestBody{
+			Required: true,
+			Content: map[string]mediaType{"multipart/form-data": {Schema: &schema{
+				Type:       "object",
+				Properties: map[string]*schema{op.Upload: {Type: "string", Format: "binary"}},
+				Required:   []string{op.Upload},
+			}}},
+		}
+	case op.Request != nil:
+		out.RequestBody = &requestBody{
+			Required: true,
+			Content:  map[string]mediaType{"application/json": {Schema: s.schemas.schemaOf(op.Request)}},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := response{Description: http.StatusText(status)}
+	switch {
+	case op.ContentType != "":
+		success.Content = map[string]mediaType{op.ContentType: {Schema: &schema{Type: "string", Format: "binary"}}}
+	case op.Response != nil:
+		body := s.schemas.schemaOf(op.Response)
+		if s.metaSchema != nil && !op.Raw {
+			body = &schema{
+				Type:       "object",
+				Properties: map[string]*schema{"data": body, "meta": s.metaSchema},
+				Required:   []string{"data"},
+			}
+		}
+		success.Content = map[string]mediaType{"application/json": {Schema: body}}
+	}
+	out.Responses[strconv.Itoa(status)] = success
+
+	if s.errorSchema != nil {
+		out.Responses["default"] = response{
+			Description: "Error",
+			Content:     map[string]mediaType{"application/json": {Schema: s.errorSchema}},
+		}
+	}
+	return out
+}
+
+// chiParam matches a chi URL parameter, with an optional regexp after a colon.
+var chiParam = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// joinPath joins a mount prefix and a chi pattern into an OpenAPI path,
+// dropping trailing slashes and parameter regexps.
+func joinPath(prefix, pattern string) string {
+	path := strings.TrimRight(prefix, "/") + "/" + strings.Trim(pattern, "/")
+	if len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	return chiParam.ReplaceAllString(path, "{$1}")
+}
+
+func pathParams(path string) []string {
+	var names []string
+	for _, m := range chiParam.FindAllStringSubmatch(path, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// operationID derives a stable identifier, e.g. "get_api_v1_bills_billID".
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for part := range strings.SplitSeq(path, "/") {
+		part = strings.NewReplacer("{", "", "}", "", ".", "_", "-", "_").Replace(part)
+		if part != "" {
+			id += "_" + part
+		}
+	}
+	return id
+}
@@ -0,0 +1,161 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type itemRequest struct {
+	Name string `json:"name" validate:"required"`
+	Note string `json:"note,omitempty"`
+}
+
+type itemResponse struct {
+	ID        string            `json:"id"`
+	Tags      []string          `json:"tags"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Parent    *itemResponse     `json:"parent,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	internal  int
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func TestSpec(t *testing.T) {
+	spec := openapi.NewSpec("Items", "1.0.0").
+		ErrorSchema(errorBody{}).
+		Mount("/api/v1/items/", "Items", []openapi.Operation{
+			{Method: http.MethodPost, Path: "/", Request: itemRequest{}, Response: itemResponse{}, Status: http.StatusCreated},
+			{Method: http.MethodGet, Path: "/{itemID:[a-f0-9]+}", Public: true, Query: []openapi.Param{{Name: "expand", Type: "boolean"}}, Response: itemResponse{}},
+			{Method: http.MethodGet, Path: "/{itemID}/export", ContentType: "text/csv"},
+			{Method: http.MethodPost, Path: "/{itemID}/photo", Upload: "file", Response: itemResponse{}},
+		})
+
+	rr := httptest.NewRecorder()
+	spec.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationID string             `json:"operationId"`
+			Security    *[]json.RawMessage `json:"security"`
+			Parameters  []struct {
+				Name, In string
+			} `json:"parameters"`
+			RequestBody *struct {
+				Content map[string]struct {
+					Schema map[string]any `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+			Responses map[string]struct {
+				Content map[string]struct {
+					Schema map[string]any `json:"schema"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Required   []string                  `json:"required"`
+				Properties map[string]map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+
+	create := doc.Paths["/api/v1/items"]["post"]
+	assert.Equal(t, "post_api_v1_items", create.OperationID)
+	assert.Nil(t, create.Security, "protected routes inherit the global bearer requirement")
+	assert.Equal(t, "#/components/schemas/itemRequest", create.RequestBody.Content["application/json"].Schema["$ref"])
+	assert.Equal(t, "#/components/schemas/itemResponse", create.Responses["201"].Content["application/json"].Schema["$ref"])
+	assert.Equal(t, "#/components/schemas/errorBody", create.Responses["default"].Content["application/json"].Schema["$ref"])
+
+	get := doc.Paths["/api/v1/items/{itemID}"]["get"]
+	require.NotNil(t, get.Security)
+	assert.Empty(t, *get.Security, "public routes clear the bearer requirement")
+	require.Len(t, get.Parameters, 2)
+	assert.Equal(t, "itemID", get.Parameters[0].Name)
+	assert.Equal(t, "path", get.Parameters[0].In)
+	assert.Equal(t, "expand", get.Parameters[1].Name)
+	assert.Equal(t, "query", get.Parameters[1].In)
+
+	export := doc.Paths["/api/v1/items/{itemID}/export"]["get"]
+	assert.Contains(t, export.Responses["200"].Content, "text/csv")
+
+	upload := doc.Paths["/api/v1/items/{itemID}/photo"]["post"]
+	form := upload.RequestBody.Content["multipart/form-data"].Schema
+	assert.Equal(t, []any{"file"}, form["required"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "binary"}, form["properties"].(map[string]any)["file"])
+
+	request := doc.Components.Schemas["itemRequest"]
+	assert.Equal(t, []string{"name"}, request.Required)
+
+	item := doc.Components.Schemas["itemResponse"]
+	assert.Equal(t, []string{"id", "tags", "createdAt"}, item.Required)
+	assert.Equal(t, "date-time", item.Properties["createdAt"]["format"])
+	assert.Equal(t, "array", item.Properties["tags"]["type"])
+	assert.Equal(t, "#/components/schemas/itemResponse", item.Properties["parent"]["$ref"])
+	assert.NotContains(t, item.Properties, "internal")
+}
+
+type pageMeta struct {
+	Total int `json:"total"`
+}
+
+func TestSpec_Envelope(t *testing.T) {
+	spec := openapi.NewSpec("Items", "1.0.0").
+		Envelope(pageMeta{}).
+		Mount("/api/v1/items", "Items", []openapi.Operation{
+			{Method: http.MethodGet, Path: "/", Response: []itemResponse{}},
+			{Method: http.MethodGet, Path: "/keys.json", Response: itemResponse{}, Raw: true},
+			{Method: http.MethodDelete, Path: "/{itemID}", Status: http.StatusNoContent},
+		})
+
+	b, err := spec.JSON()
+	require.NoError(t, err)
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Content map[string]struct {
+					Schema map[string]any `json:"schema"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(b, &doc))
+
+	list := doc.Paths["/api/v1/items"]["get"].Responses["200"].Content["application/json"].Schema
+	assert.Equal(t, "object", list["type"])
+	assert.Equal(t, []any{"data"}, list["required"])
+	properties := list["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"type":  "array",
+		"items": map[string]any{"$ref": "#/components/schemas/itemResponse"},
+	}, properties["data"])
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/pageMeta"}, properties["meta"])
+
+	raw := doc.Paths["/api/v1/items/keys.json"]["get"].Responses["200"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/itemResponse", raw["$ref"], "raw responses are not enveloped")
+
+	assert.Empty(t, doc.Paths["/api/v1/items/{itemID}"]["delete"].Responses["204"].Content)
+}
+
+func TestSwaggerUI(t *testing.T) {
+	rr := httptest.NewRecorder()
+	openapi.SwaggerUI("Items", "/api/v1/openapi.json").ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rr.Body.String(), `"/api/v1/openapi.json"`)
+}
@@ -0,0 +1,982 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AnuragThePathak/my-go-packages/srv"
+	"github.com/anuragthepathak/subscription-management/internal/adapters"
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/rpc"
+	"github.com/anuragthepathak/subscription-management/internal/config"
+	"github.com/anuragthepathak/subscription-management/internal/core/buildinfo"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/currency"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/eventbus"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"github.com/anuragthepathak/subscription-management/internal/migrations"
+	"github.com/anuragthepathak/subscription-management/internal/notifications"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/anuragthepathak/subscription-management/internal/payments"
+	"github.com/anuragthepathak/subscription-management/internal/scheduler"
+	"github.com/anuragthepathak/subscription-management/internal/storage"
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// workerShutdownGrace is added to shutdown.worker for the worker stage, so
+// the queue worker has time to requeue tasks that did not finish.
+const workerShutdownGrace = 5 * time.Second
+
+// drainGrace is added to shutdown.drain for the drain stage, whose handler
+// waits the full drain delay.
+const drainGrace = time.Second
+
+// components groups the shutdown handlers of running components by the stage
+// they stop in.
+type components struct {
+	drain     []srv.CleanupHandler // Fail readiness so load balancers move traffic away.
+	servers   []srv.CleanupHandler // HTTP and gRPC servers; stop accepting requests and drain in-flight ones.
+	producers []srv.CleanupHandler // Scheduler, outbox relay, and monitors; stop creating work.
+	workers   []srv.CleanupHandler // Queue worker; finishes running tasks.
+}
+
+// app holds the dependencies shared by every command. Commands build only
+// the parts they need, in order: newApp, connectDatabase, connectRedis,
+// buildDomain.
+type app struct {
+	cf           *config.Config
+	startedAt    time.Time
+	otelProvider *observability.Provider
+	logLevel     *slog.LevelVar          // Shared by the logger handlers; adjustable at runtime.
+	logLevelCtl  *observability.LogLevel // Adjusts logLevel for admins and config reloads.
+	configWatch  *config.ConfigWatcher   // Reloads the config files; started by run.
+	database     *adapters.Database
+	redis        *adapters.Redis
+	queueRedis   asynq.RedisConnOpt // Task queue connection, set by connectRedis.
+
+	// Populated by buildDomain.
+	subscriptionRepository repositories.SubscriptionRepository
+	userRepository         repositories.UserRepository
+	billRepository         repositories.BillRepository
+	jwtService             services.JWTService
+	subscriptionService    services.SubscriptionService
+	userService            services.UserService
+	authService            services.AuthService
+	sessionService         services.SessionService
+	fileService            services.FileService
+	budgetService          services.BudgetService
+	billService            services.BillServiceExternal
+	invoiceService         services.InvoiceService
+	attachmentService      services.AttachmentService
+	catalogService         services.CatalogService
+	cancelLinkService      services.CancelLinkService
+	webhookService         services.WebhookService
+	paymentMethodService   services.PaymentMethodService
+	preferenceService      services.NotificationPreferenceService
+	notificationService    services.NotificationService
+	unsubscribeService     services.UnsubscribeService
+	suppressionService     services.SuppressionService
+	emailPreviewService    services.EmailPreviewService
+	exportService          services.ExportService
+	analyticsService       services.AnalyticsService // Nil without a Redis connection.
+	liveEventService       services.LiveEventService // Nil without a Redis connection.
+	outboxService          services.OutboxServiceInternal
+	auditService           services.AuditService
+	deadTaskService        services.DeadTaskService
+	reconciliationService  services.ReconciliationService // Nil without a Redis connection.
+	currencyService        currency.Service
+	emailSender            notifications.EmailSender
+	notifiers              []notifications.Notifier // Email and every configured channel.
+	alerter                notifications.Alerter
+	webhookSender          notifications.WebhookSender
+	calendarFeedService    services.CalendarFeedService
+
+	// Populated by startBackground and startAPI for config reloads; nil
+	// when the component does not run.
+	subscriptionScheduler *scheduler.SubscriptionScheduler
+	appRateLimiter        services.RateLimiterService
+	authRateLimiter       services.RateLimiterService
+	groupRateLimiters     map[string]services.RateLimiterService
+}
+
+// features reports which optional features this process runs with.
+func (a *app) features(roles processRoles) map[string]bool {
+	cf := a.cf
+	return map[string]bool{
+		"otel":               cf.OTel.Enabled,
+		"tls":                cf.Server.TLS.Enabled,
+		"auto_migrate":       cf.Database.AutoMigrate,
+		"debug_server":       cf.Debug.Enabled,
+		"grpc":               cf.GRPC.Enabled,
+		"grpc_mtls":          cf.GRPC.Enabled && cf.GRPC.TLS.Enabled && cf.GRPC.TLS.ClientCAPath != "",
+		"internal_server":    cf.InternalServer.Enabled,
+		"secrets_provider":   cf.Secrets.Provider != "",
+		"secret_refs":        len(cf.SecretRefs) > 0,
+		"secrets_refresh":    (cf.Secrets.Provider != "" || len(cf.SecretRefs) > 0) && cf.Secrets.RefreshInterval > 0,
+		"scheduler":          roles.scheduler && slices.Contains(cf.Scheduler.EnabledForEnv, cf.Env),
+		"scheduler_cron":     roles.scheduler && slices.Contains(cf.Scheduler.EnabledForEnv, cf.Env) && cf.Scheduler.Mode == config.SchedulerModeCron,
+		"queue_worker":       roles.worker && slices.Contains(cf.QueueWorker.EnabledForEnv, cf.Env),
+		"exchange_rates":     cf.Currency.Provider != "",
+		"unused_suggestions": cf.Scheduler.UnusedAfterDays > 0,
+		"sms":                cf.Notifications.SMS.Provider != "",
+		"web_push":           cf.Notifications.Push.VAPIDPrivateKey != "",
+		"slack":              cf.Notifications.Slack.Enabled,
+		"dead_task_alerts":   len(cf.Notifications.Alerts.Emails) > 0 || cf.Notifications.Alerts.SlackWebhookURL != "",
+	}
+}
+
+// newApp loads configuration and sets up logging and OpenTelemetry.
+func newApp(ctx context.Context, opts *rootOptions) (*app, error) {
+	a := &app{startedAt: time.Now()}
+
+	var err error
+	if a.cf, err = config.LoadConfig(ctx, opts.configFile); err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Configure the default slog logger.
+	if a.logLevel, err = config.SetupLogger(a.cf.Log, a.cf.Env, a.cf.OTel.Enabled); err != nil {
+		return nil, fmt.Errorf("failed to configure logger: %w", err)
+	}
+	a.logLevelCtl = observability.NewLogLevel(a.logLevel, time.Now)
+	a.configWatch = config.NewConfigWatcher(opts.configFile, *a.cf)
+
+	for _, w := range a.cf.Warnings() {
+		slog.Warn("Suspicious configuration value",
+			logattr.Key(w.Field),
+			logattr.Message(w.Message),
+		)
+	}
+
+	build := buildinfo.Get()
+	slog.Info("Build info",
+		logattr.BuildVersion(build.Version),
+		logattr.Commit(build.Commit),
+		logattr.BuildTime(build.BuildTime),
+		logattr.GoVersion(build.GoVersion),
+	)
+
+	// Initialize OpenTelemetry (must be after logger, before DB/Redis so future phases can trace them).
+	if a.cf.OTel.Enabled {
+		otelConfig := a.cf.OTel
+		otelConfig.Environment = a.cf.Env
+		if a.otelProvider, err = observability.InitOTel(ctx, otelConfig); err != nil {
+			slog.Error("Failed to initialize OpenTelemetry",
+				logattr.Jaeger(otelConfig.JaegerEndpoint),
+				logattr.Error(err),
+			)
+		}
+	} else {
+		slog.Warn("OpenTelemetry disabled",
+			logattr.Env(a.cf.Env),
+			logattr.OtelEnabled(a.cf.OTel.Enabled),
+		)
+	}
+	return a, nil
+}
+
+// connectDatabase creates the MongoDB client and verifies connectivity.
+func (a *app) connectDatabase(ctx context.Context) error {
+	dbConfig := a.cf.Database
+
+	var err error
+	if a.database, err = config.DatabaseConnection(dbConfig, a.cf.OTel.Enabled); err != nil {
+		slog.Error("Failed to initialize database client",
+			logattr.Host(dbConfig.Host),
+			logattr.Port(dbConfig.Port),
+			logattr.Database(dbConfig.Name),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to initialize database client: %w", err)
+	}
+	if err = a.waitFor(ctx, "mongodb", a.database.Ping); err != nil {
+		slog.Error("Failed to connect to database",
+			logattr.Host(dbConfig.Host),
+			logattr.Port(dbConfig.Port),
+			logattr.Database(dbConfig.Name),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return nil
+}
+
+// applyMigrations runs pending schema migrations and returns how many were
+// applied. It must run before repositories build their indexes.
+func (a *app) applyMigrations(ctx context.Context) (int, error) {
+	runner, err := migrations.NewRunner(a.database.DB, migrations.All(), time.Now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create migration runner: %w", err)
+	}
+	applied, err := runner.Up(ctx)
+	if err != nil {
+		return applied, fmt.Errorf("failed to apply database migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// connectRedis creates the Redis client and verifies connectivity.
+func (a *app) connectRedis(ctx context.Context) error {
+	redisConfig := a.cf.Redis
+
+	var err error
+	if a.redis, err = config.RedisConnection(redisConfig, a.cf.OTel.Enabled); err != nil {
+		slog.Error("Failed initialize Redis client",
+			logattr.RedisMode(redisConfig.Mode),
+			logattr.RedisDB(redisConfig.DB),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to initialize Redis client: %w", err)
+	}
+	if a.queueRedis, err = config.QueueRedisConfig(redisConfig); err != nil {
+		return fmt.Errorf("failed to build task queue Redis configuration: %w", err)
+	}
+	if err = a.waitFor(ctx, "redis", a.redis.Ping); err != nil {
+		slog.Error("Failed to connect to Redis",
+			logattr.RedisMode(redisConfig.Mode),
+			logattr.RedisDB(redisConfig.DB),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	return nil
+}
+
+// waitFor retries ping with backoff until the dependency responds or
+// startup.max_wait passes, so the process can start alongside its
+// dependencies instead of exiting while they boot.
+func (a *app) waitFor(ctx context.Context, dependency string, ping func(context.Context) error) error {
+	start := time.Now()
+	retried := false
+	err := lib.Retry(ctx, a.cf.Startup.Backoff(), ping, func(attempt int, wait time.Duration, err error) {
+		retried = true
+		slog.Warn("Dependency not ready, retrying",
+			logattr.Dependency(dependency),
+			logattr.Attempt(attempt),
+			logattr.RetryIn(wait),
+			logattr.Error(err),
+		)
+	})
+	if err == nil && retried {
+		slog.Info("Dependency ready",
+			logattr.Dependency(dependency),
+			logattr.Elapsed(time.Since(start)),
+		)
+	}
+	return err
+}
+
+// buildDomain creates the repositories and services. It requires a database
+// connection.
+func (a *app) buildDomain(ctx context.Context) error {
+	cf := a.cf
+	db := a.database.DB
+
+	userRepository, err := repositories.NewUserRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create user repository: %w", err)
+	}
+	if a.subscriptionRepository, err = repositories.NewSubscriptionRepository(ctx, db); err != nil {
+		return fmt.Errorf("failed to create subscription repository: %w", err)
+	}
+	billRepository, err := repositories.NewBillRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create bill repository: %w", err)
+	}
+	archiveRepository, err := repositories.NewArchiveRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create archive repository: %w", err)
+	}
+	fileStore, err := storage.New(ctx, cf.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to create file storage: %w", err)
+	}
+	var fileRepository repositories.FileRepository
+	if fileStore != nil {
+		fileRepository, err = repositories.NewBlobFileRepository(ctx, db, fileStore)
+	} else {
+		fileRepository, err = repositories.NewFileRepository(ctx, db)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create file repository: %w", err)
+	}
+	attachmentRepository, err := repositories.NewAttachmentRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment repository: %w", err)
+	}
+	catalogRepository, err := repositories.NewCatalogRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create catalog repository: %w", err)
+	}
+	outboxRepository, err := repositories.NewOutboxRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create outbox repository: %w", err)
+	}
+	priceHistoryRepository, err := repositories.NewPriceHistoryRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create price history repository: %w", err)
+	}
+	lifecycleRepository, err := repositories.NewLifecycleEventRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create lifecycle event repository: %w", err)
+	}
+	auditRepository, err := repositories.NewAuditEventRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create audit event repository: %w", err)
+	}
+	notificationRepository, err := repositories.NewNotificationRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create notification repository: %w", err)
+	}
+	sessionRepository, err := repositories.NewSessionRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create session repository: %w", err)
+	}
+	suppressionRepository, err := repositories.NewSuppressionRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create suppression repository: %w", err)
+	}
+
+	webhookRepository, err := repositories.NewWebhookRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook repository: %w", err)
+	}
+	webhookDeliveryRepository, err := repositories.NewWebhookDeliveryRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery repository: %w", err)
+	}
+
+	paymentMethodRepository, err := repositories.NewPaymentMethodRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create payment method repository: %w", err)
+	}
+
+	deadTaskRepository, err := repositories.NewDeadTaskRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to create dead task repository: %w", err)
+	}
+
+	ratesProvider, err := currency.NewProvider(cf.Currency)
+	if err != nil {
+		return fmt.Errorf("failed to create exchange rate provider: %w", err)
+	}
+
+	paymentProvider, err := payments.NewProvider(cf.Payments)
+	if err != nil {
+		return fmt.Errorf("failed to create payment provider: %w", err)
+	}
+
+	// Hot reads are served from Redis when the cache is enabled and this
+	// command connected to it.
+	if cf.Cache.Enabled && a.redis != nil {
+		userRepository = repositories.NewCachedUserRepository(userRepository, a.redis.Client, cf.Cache.TTL)
+		a.subscriptionRepository = repositories.NewCachedSubscriptionRepository(a.subscriptionRepository, a.redis.Client, cf.Cache.TTL)
+		slog.Info("Read-through cache enabled", logattr.TTL(cf.Cache.TTL))
+	}
+	a.userRepository = userRepository
+	a.billRepository = billRepository
+
+	// Transaction executor for running multiple operations in a single transaction
+	txnExecutor := repositories.NewTxnExecutor(a.database.Client)
+	if !cf.Database.Transactions {
+		txnExecutor = repositories.NewNonTxnExecutor()
+		slog.Warn("MongoDB transactions are disabled; multi-document writes are not atomic",
+			logattr.Database(cf.Database.Name),
+		)
+	}
+
+	// Initialize business metrics adapter
+	var metricsPort *observability.OTelMetricsAdapter
+	if cf.OTel.Enabled {
+		type appMetricsState struct {
+			repositories.SubscriptionRepository
+		}
+
+		metricsPort, err = observability.NewMetricsAdapter(cf.OTel,
+			appMetricsState{
+				a.subscriptionRepository,
+			})
+		if err != nil {
+			slog.Error("Failed to initialize business metrics adapter",
+				logattr.Env(cf.Env),
+				logattr.OtelEnabled(cf.OTel.Enabled),
+				logattr.Error(err))
+		}
+
+		if err := observability.InitQueueMetrics(
+			cf.OTel.ServiceName,
+			a.queueRedis,
+		); err != nil {
+			slog.Error("Failed to initialize queue metrics",
+				logattr.Env(cf.Env),
+				logattr.OtelEnabled(cf.OTel.Enabled),
+				logattr.Error(err))
+		}
+	} else {
+		// Noop instruments — domain layer calls are safe no-ops.
+		metricsPort = observability.NewNoOpMetricsAdapter()
+		slog.Info("Business metrics adapter creation skipped",
+			logattr.Env(cf.Env),
+			logattr.OtelEnabled(cf.OTel.Enabled),
+		)
+	}
+
+	jwtKeys, err := services.LoadJWTKeySet(cf.JWT)
+	if err != nil {
+		return fmt.Errorf("failed to load JWT keys: %w", err)
+	}
+	// Token revocation needs Redis; commands without it cannot revoke.
+	var revokedTokens redis.UniversalClient
+	if a.redis != nil {
+		revokedTokens = a.redis.Client
+	}
+	a.jwtService = services.NewJWTService(cf.JWT, jwtKeys, revokedTokens, time.Now)
+	// Exchange rates are cached in Redis when this command connected to it.
+	var ratesCache redis.UniversalClient
+	if a.redis != nil {
+		ratesCache = a.redis.Client
+	}
+	a.currencyService = currency.NewService(
+		ratesProvider,
+		repositories.NewExchangeRateRepository(db),
+		ratesCache,
+		time.Now,
+	)
+	a.auditService = services.NewAuditService(auditRepository, time.Now)
+	a.subscriptionService = services.NewSubscriptionService(
+		txnExecutor.WithTransaction,
+		a.subscriptionRepository,
+		billRepository,
+		archiveRepository,
+		outboxRepository,
+		priceHistoryRepository,
+		lifecycleRepository,
+		paymentMethodRepository,
+		a.currencyService,
+		paymentProvider,
+		a.auditService,
+		metricsPort,
+		time.Now,
+	)
+	a.notificationService = services.NewNotificationService(notificationRepository, time.Now)
+	a.sessionService = services.NewSessionService(sessionRepository, a.jwtService, cf.Sessions, time.Now)
+	a.fileService = services.NewFileService(fileRepository, cf.Files, time.Now)
+	a.outboxService = services.NewOutboxService(outboxRepository, time.Now)
+
+	a.billService = services.NewBillService(billRepository, a.subscriptionRepository)
+	a.invoiceService = services.NewInvoiceService(
+		a.billService,
+		billRepository,
+		a.subscriptionRepository,
+		userRepository,
+		a.fileService,
+		cf.Invoice,
+	)
+	a.attachmentService = services.NewAttachmentService(
+		attachmentRepository,
+		a.subscriptionRepository,
+		a.fileService,
+		cf.Attachments,
+		time.Now,
+	)
+	a.catalogService = services.NewCatalogService(catalogRepository, cf.Catalog, time.Now)
+	a.budgetService = services.NewBudgetService(
+		repositories.NewBudgetRepository(db),
+		a.subscriptionRepository,
+		a.currencyService,
+		time.Now,
+	)
+	a.cancelLinkService = services.NewCancelLinkService(a.subscriptionService, cf.CancelLinks, time.Now)
+	if a.redis != nil {
+		a.analyticsService = services.NewAnalyticsService(
+			userRepository,
+			a.subscriptionRepository,
+			lifecycleRepository,
+			a.redis.Client,
+			asynq.NewInspectorFromRedisClient(a.redis.Client),
+			cf.Asynq.QueueName,
+			time.Now,
+		)
+		a.liveEventService = services.NewLiveEventService(a.redis.Client, cf.LiveEvents)
+		a.reconciliationService = services.NewReconciliationService(
+			a.subscriptionRepository,
+			billRepository,
+			a.subscriptionService,
+			a.redis.Client,
+			time.Now,
+		)
+	}
+	var webhookHostCheck services.HostCheckFn
+	if !cf.Webhooks.AllowPrivateNetworks {
+		webhookHostCheck = lib.CheckPublicHost
+	}
+	a.webhookService = services.NewWebhookService(webhookRepository, webhookDeliveryRepository, webhookHostCheck, time.Now)
+	a.paymentMethodService = services.NewPaymentMethodService(
+		txnExecutor.WithTransaction,
+		paymentMethodRepository,
+		a.subscriptionRepository,
+		time.Now,
+	)
+	a.preferenceService = services.NewNotificationPreferenceService(
+		repositories.NewNotificationPreferenceRepository(db),
+		notifications.ConfiguredChannels(cf.Notifications),
+		time.Now,
+	)
+	// Closed accounts are confirmed and purged by the queue worker; without a
+	// queue connection accounts can't be closed.
+	var closureQueue services.AccountClosureQueue
+	if a.queueRedis != nil {
+		closureQueue = scheduler.NewAccountClosureQueue(asynq.NewClient(a.queueRedis), cf.Asynq.QueueName)
+	}
+	a.userService = services.NewUserService(
+		userRepository,
+		a.subscriptionService,
+		a.notificationService,
+		a.sessionService,
+		a.paymentMethodService,
+		a.webhookService,
+		a.preferenceService,
+		a.budgetService,
+		a.attachmentService,
+		a.fileService,
+		a.auditService,
+		closureQueue,
+		cf.Account,
+		time.Now,
+	)
+	a.authService = services.NewAuthService(a.userService, a.sessionService, a.jwtService, a.auditService)
+	a.calendarFeedService = services.NewCalendarFeedService(
+		userRepository,
+		a.subscriptionRepository,
+		a.preferenceService,
+		cf.Calendar,
+		cf.Scheduler.ReminderDays,
+		time.Now,
+	)
+	a.unsubscribeService = services.NewUnsubscribeService(a.userService, a.preferenceService, cf.Unsubscribe)
+	a.suppressionService = services.NewSuppressionService(suppressionRepository, time.Now)
+	if a.emailSender, err = notifications.NewEmailSender(
+		cf.Email,
+		a.analyticsService,
+		a.currencyService,
+		a.suppressionService,
+		a.unsubscribeService,
+	); err != nil {
+		return fmt.Errorf("failed to create email sender: %w", err)
+	}
+	if a.notifiers, err = notifications.NewNotifiers(cf.Notifications, a.emailSender); err != nil {
+		return fmt.Errorf("failed to create notifiers: %w", err)
+	}
+	a.emailPreviewService = services.NewEmailPreviewService(a.userService, a.emailSender)
+	// Large exports are generated by the queue worker; without a queue
+	// connection they are streamed whatever their size.
+	var exportQueue services.ExportQueue
+	if a.queueRedis != nil {
+		exportQueue = scheduler.NewExportQueue(asynq.NewClient(a.queueRedis), cf.Asynq.QueueName)
+	}
+	a.exportService = services.NewExportService(
+		userRepository,
+		a.subscriptionRepository,
+		billRepository,
+		archiveRepository,
+		lifecycleRepository,
+		a.fileService,
+		exportQueue,
+		cf.Export,
+		time.Now,
+	)
+	// Dead tasks are requeued from the admin API, so any process with a
+	// queue connection can put them back.
+	var deadTaskQueue services.DeadTaskQueue
+	if a.queueRedis != nil {
+		deadTaskQueue = scheduler.NewDeadTaskQueue(asynq.NewClient(a.queueRedis))
+	}
+	a.deadTaskService = services.NewDeadTaskService(deadTaskRepository, deadTaskQueue, time.Now)
+	a.alerter = notifications.NewAlerter(cf.Notifications, a.emailSender)
+	a.webhookSender = notifications.NewWebhookSender(cf.Webhooks)
+	return nil
+}
+
+// startBackground starts the scheduler and outbox relay, and the queue
+// worker, when roles select them and the environment is one they are enabled
+// in, and returns their shutdown handlers. It requires buildDomain and a
+// Redis connection.
+func (a *app) startBackground(ctx context.Context, roles processRoles) (components, error) {
+	cf := a.cf
+	var running components
+
+	if roles.scheduler && slices.Contains(cf.Scheduler.EnabledForEnv, cf.Env) {
+		var dunningRetryDays []int
+		if cf.Dunning.Enabled {
+			dunningRetryDays = cf.Dunning.RetryDays
+		}
+		var cronSchedule *scheduler.CronSchedule
+		if cf.Scheduler.Mode == config.SchedulerModeCron {
+			location, err := time.LoadLocation(cf.Scheduler.Cron.Timezone)
+			if err != nil {
+				return running, fmt.Errorf("failed to load scheduler time zone: %w", err)
+			}
+			cronSchedule = &scheduler.CronSchedule{
+				Specs:            cf.Scheduler.Cron.Schedules(),
+				Location:         location,
+				RenewalLookahead: cf.Scheduler.Cron.RenewalLookahead,
+			}
+		}
+		sch := scheduler.NewSubscriptionScheduler(
+			a.subscriptionService,
+			a.preferenceService,
+			a.redis.Client,
+			a.queueRedis,
+			cf.Scheduler.Interval,
+			cf.Scheduler.ReminderDays,
+			cf.Scheduler.SendWindow.Window(),
+			cf.Scheduler.StartupDelay,
+			cf.Scheduler.ArchiveAfterMonths,
+			cf.Scheduler.UnusedAfterDays,
+			cf.Scheduler.UnusedNoticeDays,
+			dunningRetryDays,
+			models.DunningFinalAction(cf.Dunning.FinalAction),
+			cf.Currency.Provider != "",
+			!cf.Database.Transactions,
+			cf.Payments.Provider != "",
+			cf.Scheduler.Reconcile,
+			cronSchedule,
+			cf.Asynq.QueueName,
+			cf.Scheduler.Name,
+			time.Now,
+		)
+		a.subscriptionScheduler = sch
+		go func() {
+			if startErr := sch.Start(ctx); startErr != nil && startErr != context.Canceled {
+				slog.Error("Scheduler failed",
+					logattr.SchedulerName(cf.Scheduler.Name),
+					logattr.Queue(cf.Asynq.QueueName),
+					logattr.Error(startErr),
+				)
+			}
+		}()
+		running.producers = append(running.producers, &adapters.Scheduler{
+			Scheduler: sch,
+		})
+
+		relay := scheduler.NewOutboxRelay(
+			a.outboxService,
+			a.queueRedis,
+			cf.Scheduler.OutboxRelay.Interval,
+			cf.Scheduler.OutboxRelay.BatchSize,
+			cf.Asynq.QueueName,
+			cf.Scheduler.Name,
+		)
+		go func() {
+			if startErr := relay.Start(ctx); startErr != nil && startErr != context.Canceled {
+				slog.Error("Outbox relay failed",
+					logattr.SchedulerName(cf.Scheduler.Name),
+					logattr.Queue(cf.Asynq.QueueName),
+					logattr.Error(startErr),
+				)
+			}
+		}()
+		running.producers = append(running.producers, &adapters.OutboxRelay{
+			Relay: relay,
+		})
+	} else if roles.scheduler {
+		slog.Info("Scheduler skipped",
+			logattr.Env(cf.Env),
+			logattr.SchedulerName(cf.Scheduler.Name),
+			logattr.EnabledForEnv(cf.Scheduler.EnabledForEnv),
+		)
+	}
+
+	if roles.worker && slices.Contains(cf.QueueWorker.EnabledForEnv, cf.Env) {
+		eventPublisher, err := eventbus.NewPublisher(cf.EventBus)
+		if err != nil {
+			return running, fmt.Errorf("failed to create event publisher: %w", err)
+		}
+		if cf.EventBus.Driver != "" {
+			slog.Info("Publishing domain events to the message bus",
+				logattr.EventBus(eventPublisher.Name()),
+			)
+		}
+		var sendLimiter services.RateLimiterService
+		if cf.QueueWorker.SendRate.Rate > 0 {
+			sendLimiter = services.NewRateLimiterService(
+				redis_rate.NewLimiter(a.redis.Client),
+				config.NewRateLimit(cf.QueueWorker.SendRate),
+				"send",
+			)
+		}
+		worker := scheduler.NewQueueWorker(
+			a.subscriptionService,
+			a.userService,
+			a.budgetService,
+			a.cancelLinkService,
+			a.webhookService,
+			a.paymentMethodService,
+			a.preferenceService,
+			a.notificationService,
+			a.exportService,
+			a.invoiceService,
+			a.deadTaskService,
+			a.reconciliationService,
+			a.analyticsService,
+			a.currencyService,
+			a.emailSender,
+			a.notifiers,
+			cf.Scheduler.SendWindow.Window(),
+			sendLimiter,
+			a.webhookSender,
+			cf.Webhooks,
+			a.alerter,
+			eventPublisher,
+			a.liveEventService,
+			a.redis.Client,
+			a.queueRedis,
+			cf.QueueWorker.Concurrency,
+			cf.Shutdown.WorkerDrain,
+			cf.Shutdown.Worker,
+			cf.Asynq.QueueName,
+			cf.QueueWorker.Name,
+			time.Now,
+		)
+		if startErr := worker.Start(); startErr != nil && startErr != context.Canceled {
+			slog.Error("Queue worker failed",
+				logattr.WorkerName(cf.QueueWorker.Name),
+				logattr.Queue(cf.Asynq.QueueName),
+				logattr.Concurrency(cf.QueueWorker.Concurrency),
+				logattr.Error(startErr))
+			return running, fmt.Errorf("failed to start queue worker: %w", startErr)
+		}
+		running.workers = append(running.workers, &adapters.QueueWorker{
+			Worker: worker,
+		})
+	} else if roles.worker {
+		slog.Info("Queue worker skipped",
+			logattr.Env(cf.Env),
+			logattr.WorkerName(cf.QueueWorker.Name),
+			logattr.EnabledForEnv(cf.QueueWorker.EnabledForEnv),
+		)
+	}
+	return running, nil
+}
+
+// startDebugServer serves pprof and runtime stats on the internal debug
+// listener when enabled, and returns its shutdown handler.
+func (a *app) startDebugServer() (srv.CleanupHandler, error) {
+	cf := a.cf
+	if !cf.Debug.Enabled {
+		return nil, nil
+	}
+
+	server := adapters.NewHTTPServer(
+		controllers.NewDebugController(a.startedAt),
+		cf.Debug.Host,
+		srv.ServerConfig{Port: cf.Debug.Port},
+		// Reuse the port like the API, so a replacement process can start
+		// its debug server while this one drains.
+		adapters.ListenOptions{ReusePort: cf.Server.ReusePort},
+	)
+	if err := server.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start debug server: %w", err)
+	}
+	go func() {
+		// A failed debug server must not take the service down.
+		if err := <-server.Err(); err != nil {
+			slog.Error("Debug server failed", logattr.Error(err))
+		}
+	}()
+
+	slog.Warn("Debug server enabled; keep it off public networks",
+		logattr.Host(cf.Debug.Host),
+		logattr.Port(cf.Debug.Port),
+	)
+	return server, nil
+}
+
+// startGRPCServer serves the internal gRPC API when enabled. It requires
+// buildDomain.
+func (a *app) startGRPCServer() (*adapters.GRPCServer, error) {
+	cf := a.cf
+	if !cf.GRPC.Enabled {
+		return nil, nil
+	}
+
+	var opts []grpc.ServerOption
+	if cf.GRPC.TLS.Enabled {
+		tlsConfig, err := lib.LoadServerTLSConfig(cf.GRPC.TLS.CertPath, cf.GRPC.TLS.KeyPath, cf.GRPC.TLS.ClientCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load gRPC TLS config: %w", err)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	if cf.OTel.Enabled {
+		opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	}
+
+	server := adapters.NewGRPCServer(
+		rpc.NewServer(a.subscriptionService, a.userService, opts...),
+		cf.GRPC.Host,
+		cf.GRPC.Port,
+		adapters.ListenOptions{ReusePort: cf.Server.ReusePort},
+	)
+	if err := server.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start gRPC server: %w", err)
+	}
+	slog.Info("gRPC API enabled",
+		logattr.Host(cf.GRPC.Host),
+		logattr.Port(cf.GRPC.Port),
+		logattr.TLSEnabled(cf.GRPC.TLS.Enabled),
+	)
+	return server, nil
+}
+
+// startSecretsWatcher refreshes secrets in the background so rotated
+// credentials take effect without a restart. It requires buildDomain.
+func (a *app) startSecretsWatcher(ctx context.Context) error {
+	cf := a.cf
+	if cf.Secrets.RefreshInterval <= 0 {
+		return nil
+	}
+
+	secretsProvider, err := config.NewConfigSecretsProvider(ctx, *cf)
+	if err != nil {
+		return fmt.Errorf("failed to create secrets provider: %w", err)
+	}
+	if secretsProvider == nil {
+		return nil
+	}
+
+	watcher := config.NewSecretsWatcher(secretsProvider, *cf)
+	watcher.OnChange(a.applyConfigChange)
+	go func() {
+		if startErr := watcher.Start(ctx); startErr != nil && startErr != context.Canceled {
+			slog.Error("Secrets watcher failed",
+				logattr.SecretsProvider(secretsProvider.Name()),
+				logattr.Error(startErr),
+			)
+		}
+	}()
+	return nil
+}
+
+// startConfigWatcher reloads the config files in the background so tunable
+// settings take effect without a restart. Start it after the components, so
+// reloads find them in place.
+func (a *app) startConfigWatcher(ctx context.Context) {
+	a.configWatch.OnChange(a.applyConfigChange)
+	go func() {
+		if startErr := a.configWatch.Start(ctx); startErr != nil && startErr != context.Canceled {
+			slog.Error("Config watcher failed", logattr.Error(startErr))
+		}
+	}()
+}
+
+// applyConfigChange applies the changed keys of updated to the running
+// components. Rotated secrets, JWT keys, the log level, rate limits, and
+// reminder days take effect immediately; other keys are reported as needing a restart.
+func (a *app) applyConfigChange(updated config.Config, changed []string) {
+	var restartRequired []string
+	for _, key := range changed {
+		switch {
+		case key == "jwt.access_secret" || key == "jwt.refresh_secret":
+			a.jwtService.RotateSecrets(updated.JWT.AccessSecret, updated.JWT.RefreshSecret)
+		case key == "jwt.algorithm" || key == "jwt.signing_key" || key == "jwt.keys":
+			keys, err := services.LoadJWTKeySet(updated.JWT)
+			if err != nil {
+				slog.Error("Failed to reload JWT keys", logattr.Error(err))
+				continue
+			}
+			a.jwtService.RotateKeys(keys)
+		case key == "email.smtp_username" || key == "email.smtp_password":
+			a.emailSender.UpdateCredentials(updated.Email.SMTPUsername, updated.Email.SMTPPassword)
+		case key == "log.level":
+			level, err := updated.Log.EffectiveLevel(updated.Env)
+			if err != nil {
+				restartRequired = append(restartRequired, key)
+				continue
+			}
+			a.logLevelCtl.SetConfigured(level)
+		case strings.HasPrefix(key, "rate_limiter.app."):
+			if a.appRateLimiter != nil {
+				a.appRateLimiter.SetLimit(config.NewRateLimit(updated.RateLimiter.App))
+			}
+		case strings.HasPrefix(key, "rate_limiter.auth."):
+			if a.authRateLimiter != nil {
+				a.authRateLimiter.SetLimit(config.NewRateLimit(updated.RateLimiter.Auth))
+			}
+		case strings.HasPrefix(key, "rate_limiter.groups."):
+			// Adding or removing a group changes the routes it applies to.
+			group, field, _ := strings.Cut(strings.TrimPrefix(key, "rate_limiter.groups."), ".")
+			limiter, running := a.groupRateLimiters[group]
+			limit, configured := updated.RateLimiter.Groups[group]
+			if field == "" || !running || !configured {
+				restartRequired = append(restartRequired, key)
+				continue
+			}
+			limiter.SetLimit(config.NewRateLimit(limit))
+		case key == "scheduler.reminder_days":
+			if a.subscriptionScheduler != nil {
+				a.subscriptionScheduler.SetReminderDays(updated.Scheduler.ReminderDays)
+			}
+			if a.calendarFeedService != nil {
+				a.calendarFeedService.SetReminderDays(updated.Scheduler.ReminderDays)
+			}
+		default:
+			restartRequired = append(restartRequired, key)
+		}
+	}
+	if len(restartRequired) > 0 {
+		slog.Warn("Changed settings take effect after a restart",
+			logattr.ConfigKeys(restartRequired),
+		)
+	}
+}
+
+// shutdown stops the running components in order: the HTTP server drains
+// in-flight requests, producers stop creating work, the queue worker finishes
+// running tasks, and then Redis and MongoDB are closed and telemetry is
+// flushed. Each stage has its own timeout from the shutdown config.
+func (a *app) shutdown(running components) {
+	cf := a.cf
+
+	var connections, telemetry []srv.CleanupHandler
+	if a.database != nil {
+		connections = append(connections, a.database)
+	}
+	if a.redis != nil {
+		connections = append(connections, a.redis)
+	}
+	if a.otelProvider != nil {
+		telemetry = append(telemetry, a.otelProvider)
+	}
+
+	start := time.Now()
+	err := adapters.Shutdown(
+		adapters.ShutdownStage{Name: "drain", Timeout: cf.Shutdown.Drain + drainGrace, Handlers: running.drain},
+		adapters.ShutdownStage{Name: "http", Timeout: cf.Shutdown.HTTP, Handlers: running.servers},
+		adapters.ShutdownStage{Name: "scheduler", Timeout: cf.Shutdown.Scheduler, Handlers: running.producers},
+		adapters.ShutdownStage{Name: "worker", Timeout: cf.Shutdown.Worker + workerShutdownGrace, Handlers: running.workers},
+		adapters.ShutdownStage{Name: "connections", Timeout: cf.Shutdown.Connections, Handlers: connections},
+		// Telemetry goes last so spans and metrics from earlier stages are exported.
+		adapters.ShutdownStage{Name: "telemetry", Timeout: cf.Shutdown.Connections, Handlers: telemetry},
+	)
+	if err != nil {
+		slog.Error("Shutdown completed with errors",
+			logattr.Elapsed(time.Since(start)),
+			logattr.Error(err),
+		)
+		return
+	}
+	slog.Info("Shutdown completed", logattr.Elapsed(time.Since(start)))
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/anuragthepathak/subscription-management/internal/config"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+func newConfigCommand(opts *rootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the effective configuration",
+	}
+	cmd.AddCommand(
+		newConfigValidateCommand(opts),
+		newConfigPrintCommand(opts),
+	)
+	return cmd
+}
+
+func newConfigValidateCommand(opts *rootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration and report invalid or suspicious values",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			out := cmd.OutOrStdout()
+
+			cf, err := config.LoadConfig(cmd.Context(), opts.configFile)
+			if verr := (*config.ValidationError)(nil); errors.As(err, &verr) {
+				for _, f := range verr.Fields {
+					fmt.Fprintf(out, "error    %s\n", f)
+				}
+				return fmt.Errorf("configuration is invalid: %d errors", len(verr.Fields))
+			} else if err != nil {
+				return err
+			}
+
+			warnings := cf.Warnings()
+			for _, f := range warnings {
+				fmt.Fprintf(out, "warning  %s\n", f)
+			}
+			fmt.Fprintf(out, "configuration is valid (%d warnings)\n", len(warnings))
+			return nil
+		},
+	}
+}
+
+func newConfigPrintCommand(opts *rootOptions) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "print",
+		Short: "Print the merged configuration with secrets masked",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cf, err := config.LoadConfig(cmd.Context(), opts.configFile)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			switch format {
+			case "yaml":
+				enc := yaml.NewEncoder(out)
+				enc.SetIndent(2)
+				if err := enc.Encode(cf.Sanitized()); err != nil {
+					return err
+				}
+				return enc.Close()
+			case "json":
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(cf.Sanitized())
+			default:
+				return fmt.Errorf("unknown format %q: must be yaml or json", format)
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&format, "output", "o", "yaml", "output format: yaml or json")
+	return cmd
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"log/slog"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/spf13/cobra"
+)
+
+func newMigrateCommand(opts *rootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply pending database migrations",
+		Long: "Apply pending database migrations and exit. Use this with " +
+			"database.auto_migrate disabled to migrate as a separate deploy step.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			a, err := newApp(ctx, opts)
+			if err != nil {
+				return err
+			}
+			defer a.shutdown(components{})
+
+			if err := a.connectDatabase(ctx); err != nil {
+				return err
+			}
+			applied, err := a.applyMigrations(ctx)
+			if err != nil {
+				return err
+			}
+
+			slog.Info("Migrations complete", logattr.Total(applied))
+			return nil
+		},
+	}
+}
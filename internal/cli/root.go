@@ -0,0 +1,41 @@
+// Package cli implements the subman command line. Every command shares the
+// configuration loading and dependency wiring in app.go and builds only the
+// parts it needs.
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// rootOptions holds the flags shared by every command.
+type rootOptions struct {
+	configFile string
+}
+
+// NewRootCommand builds the subman command tree.
+func NewRootCommand() *cobra.Command {
+	opts := &rootOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "subman",
+		Short: "Subscription management service",
+		// Errors are logged once by the caller; usage is only useful for
+		// flag mistakes, which cobra reports on its own.
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.PersistentFlags().StringVar(&opts.configFile, "config", "",
+		"path to the base config file (default: ./config.yaml)")
+
+	cmd.AddCommand(
+		newRunCommand(opts),
+		newServeCommand(opts),
+		newWorkerCommand(opts),
+		newMigrateCommand(opts),
+		newSeedCommand(opts),
+		newUserCommand(opts),
+		newConfigCommand(opts),
+		newVersionCommand(),
+	)
+	return cmd
+}
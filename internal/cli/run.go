@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/adapters"
+	"github.com/anuragthepathak/subscription-management/internal/config"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/spf13/cobra"
+)
+
+// processRoles selects the components a process runs, so each can be
+// deployed and scaled on its own.
+type processRoles struct {
+	api       bool
+	scheduler bool
+	worker    bool
+}
+
+// parseRoles reads role names as accepted in config.Config.Roles.
+func parseRoles(names []string) (processRoles, error) {
+	var roles processRoles
+	for _, name := range names {
+		switch name {
+		case config.RoleAPI:
+			roles.api = true
+		case config.RoleScheduler:
+			roles.scheduler = true
+		case config.RoleWorker:
+			roles.worker = true
+		case config.RoleAll:
+			roles = processRoles{api: true, scheduler: true, worker: true}
+		default:
+			return roles, fmt.Errorf("unknown role %q", name)
+		}
+	}
+	if roles == (processRoles{}) {
+		return roles, fmt.Errorf("no role selected")
+	}
+	return roles, nil
+}
+
+// names lists the selected roles for logging.
+func (r processRoles) names() []string {
+	var names []string
+	if r.api {
+		names = append(names, config.RoleAPI)
+	}
+	if r.scheduler {
+		names = append(names, config.RoleScheduler)
+	}
+	if r.worker {
+		names = append(names, config.RoleWorker)
+	}
+	return names
+}
+
+func newRunCommand(opts *rootOptions) *cobra.Command {
+	var roleNames []string
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run the roles selected in the configuration",
+		Long: "Run any combination of the api, scheduler, and worker roles, or all of " +
+			"them, as listed in roles or given with --role. The scheduler and worker " +
+			"still run only in the environments listed in their enabled_for_env.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			a, err := newApp(ctx, opts)
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("role") {
+				roleNames = a.cf.Roles
+			}
+			roles, err := parseRoles(roleNames)
+			if err != nil {
+				return err
+			}
+			return a.run(ctx, roles)
+		},
+	}
+	cmd.Flags().StringSliceVar(&roleNames, "role", nil,
+		"roles to run, replacing roles from the config: api, scheduler, worker, or all")
+	return cmd
+}
+
+// run starts the components of roles and blocks until the context is
+// canceled or a server fails, then shuts everything down.
+func (a *app) run(ctx context.Context, roles processRoles) error {
+	cf := a.cf
+
+	slog.Info("Starting Subscription Management Service",
+		logattr.Env(cf.Env),
+		logattr.Roles(roles.names()),
+	)
+	if err := a.connectDatabase(ctx); err != nil {
+		return err
+	}
+
+	var dbMonitor *adapters.DatabaseMonitor
+	if roles.api {
+		// Apply pending schema migrations before repositories build their indexes.
+		if cf.Database.AutoMigrate {
+			if _, err := a.applyMigrations(ctx); err != nil {
+				return err
+			}
+		}
+
+		// Monitor database health in the background so readiness reflects outages
+		// before user requests start failing.
+		dbMonitor = adapters.NewDatabaseMonitor(
+			a.database,
+			cf.Database.HealthCheck.Interval,
+			cf.Database.HealthCheck.Timeout,
+			cf.Database.HealthCheck.FailureThreshold,
+		)
+		dbMonitor.Start(ctx)
+	}
+
+	if err := a.connectRedis(ctx); err != nil {
+		return err
+	}
+	if err := a.buildDomain(ctx); err != nil {
+		return err
+	}
+	if roles.api {
+		if err := a.catalogService.SyncCatalogInternal(ctx); err != nil {
+			return fmt.Errorf("failed to sync the catalog: %w", err)
+		}
+	}
+
+	running, err := a.startBackground(ctx, roles)
+	if err != nil {
+		a.shutdown(running)
+		return err
+	}
+	if dbMonitor != nil {
+		running.producers = append(running.producers, dbMonitor)
+	}
+	if err := a.startSecretsWatcher(ctx); err != nil {
+		a.shutdown(running)
+		return err
+	}
+	debugServer, err := a.startDebugServer()
+	if err != nil {
+		a.shutdown(running)
+		return err
+	}
+	if debugServer != nil {
+		running.servers = append(running.servers, debugServer)
+	}
+
+	features := a.features(roles)
+	var apiErr, grpcErr, internalErr <-chan error // Nil, so never ready, without the servers.
+	if roles.api {
+		if apiErr, grpcErr, internalErr, err = a.startAPI(dbMonitor, features, &running); err != nil {
+			a.shutdown(running)
+			return err
+		}
+	}
+
+	a.startConfigWatcher(ctx)
+
+	slog.Info("Service ready",
+		logattr.StartupTime(time.Since(a.startedAt)),
+		logattr.Roles(roles.names()),
+		logattr.Features(features),
+	)
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+		slog.Info("Shutdown signal received")
+	case serveErr = <-apiErr:
+		slog.Error("HTTP server failed", logattr.Error(serveErr))
+	case serveErr = <-grpcErr:
+		slog.Error("gRPC server failed", logattr.Error(serveErr))
+	case serveErr = <-internalErr:
+		slog.Error("Internal server failed", logattr.Error(serveErr))
+	}
+	a.shutdown(running)
+
+	slog.Info("Service shutdown completed")
+	return serveErr
+}
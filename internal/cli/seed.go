@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/config"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/seed"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// demoSubscriptions are created for the seeded user.
+var demoSubscriptions = []models.SubscriptionRequest{
+	{Name: "Netflix", Price: 1549, Currency: models.USD, Frequency: models.Monthly, Category: models.Entertainment},
+	{Name: "Spotify", Price: 1099, Currency: models.USD, Frequency: models.Monthly, Category: models.Lifestyle},
+	{Name: "The Economist", Price: 18900, Currency: models.GBP, Frequency: models.Yearly, Category: models.News},
+}
+
+func newSeedCommand(opts *rootOptions) *cobra.Command {
+	var (
+		req      models.UserRequest
+		generate seed.Options
+	)
+
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Create a demo user with sample subscriptions",
+		Long: "Create a demo user with sample subscriptions for local development. " +
+			"Data is written through the services, so bills and domain events are " +
+			"created as they would be for API calls.\n\n" +
+			"With --users, also generate that many fake users with subscriptions " +
+			"across statuses and frequencies, started up to --months ago, and a " +
+			"bill for every period since. Generated data is written straight to " +
+			"the database, without domain events, and users share the demo " +
+			"user's password.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			a, err := newApp(ctx, opts)
+			if err != nil {
+				return err
+			}
+			defer a.shutdown(components{})
+
+			if a.cf.Env == config.EnvProduction {
+				return fmt.Errorf("refusing to seed a production environment")
+			}
+			if generate.Users < 0 || generate.SubscriptionsPerUser < 1 || generate.Months < 1 {
+				return fmt.Errorf("--users must be 0 or greater, --subscriptions and --months greater than 0")
+			}
+			if err := a.connectDatabase(ctx); err != nil {
+				return err
+			}
+			if err := a.buildDomain(ctx); err != nil {
+				return err
+			}
+
+			user, err := a.userService.CreateUser(ctx, req.ToModel())
+			if err != nil {
+				return fmt.Errorf("failed to create demo user: %w", err)
+			}
+			for _, sub := range demoSubscriptions {
+				if _, err := a.subscriptionService.CreateSubscription(ctx, sub.ToModel(), user.ID.Hex()); err != nil {
+					return fmt.Errorf("failed to create subscription %q: %w", sub.Name, err)
+				}
+			}
+
+			slog.Info("Seed data created",
+				logattr.UserID(user.ID.Hex()),
+				logattr.Total(len(demoSubscriptions)),
+			)
+
+			if generate.Users > 0 {
+				if err := a.seedGenerated(ctx, generate, req.Password); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&req.Name, "name", "Demo User", "demo user's display name")
+	cmd.Flags().StringVar(&req.Email, "email", "demo@example.com", "demo user's login email")
+	cmd.Flags().StringVar(&req.Password, "password", "password123", "demo user's login password")
+	cmd.Flags().IntVar(&generate.Users, "users", 0, "fake users to generate")
+	cmd.Flags().IntVar(&generate.SubscriptionsPerUser, "subscriptions", 8, "most subscriptions per generated user")
+	cmd.Flags().IntVar(&generate.Months, "months", 12, "how far back generated subscriptions start, in months")
+	cmd.Flags().Int64Var(&generate.Seed, "random-seed", 0, "seed of the generator, to repeat a run; 0 picks one")
+	return cmd
+}
+
+// seedGenerated generates fake data and inserts it through the repositories.
+func (a *app) seedGenerated(ctx context.Context, opts seed.Options, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	data := seed.Generate(opts, string(hash), time.Now())
+
+	for _, user := range data.Users {
+		if _, err := a.userRepository.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to create user %q: %w", user.Email, err)
+		}
+	}
+	for _, subscription := range data.Subscriptions {
+		if _, err := a.subscriptionRepository.Create(ctx, subscription); err != nil {
+			return fmt.Errorf("failed to create subscription %q: %w", subscription.Name, err)
+		}
+	}
+	for _, bill := range data.Bills {
+		if _, err := a.billRepository.Create(ctx, bill); err != nil {
+			return fmt.Errorf("failed to create bill: %w", err)
+		}
+	}
+
+	slog.Info("Generated seed data created",
+		logattr.Users(len(data.Users)),
+		logattr.Subscriptions(len(data.Subscriptions)),
+		logattr.Bills(len(data.Bills)),
+	)
+	return nil
+}
@@ -0,0 +1,404 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/AnuragThePathak/my-go-packages/srv"
+	"github.com/anuragthepathak/subscription-management/internal/adapters"
+	"github.com/anuragthepathak/subscription-management/internal/api/controllers"
+	"github.com/anuragthepathak/subscription-management/internal/api/middlewares"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/endpoint"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/openapi"
+	"github.com/anuragthepathak/subscription-management/internal/config"
+	"github.com/anuragthepathak/subscription-management/internal/core/buildinfo"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+)
+
+func newServeCommand(opts *rootOptions) *cobra.Command {
+	var withWorker bool
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the HTTP API",
+		Long: "Run the HTTP API, and the internal gRPC API when grpc.enabled is set. Pending migrations are applied first when " +
+			"database.auto_migrate is enabled. With --with-worker, the scheduler " +
+			"and queue worker also run in this process. Same as run --role api.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			a, err := newApp(ctx, opts)
+			if err != nil {
+				return err
+			}
+			return a.run(ctx, processRoles{api: true, scheduler: withWorker, worker: withWorker})
+		},
+	}
+	cmd.Flags().BoolVar(&withWorker, "with-worker", false,
+		"also run the scheduler and queue worker in this process")
+	return cmd
+}
+
+// startAPI serves the HTTP API, and the gRPC API when enabled, adding their
+// shutdown handlers to running. It returns the channels reporting server
+// failures; the gRPC and internal ones are nil when those servers are off.
+func (a *app) startAPI(
+	dbMonitor *adapters.DatabaseMonitor,
+	features map[string]bool,
+	running *components,
+) (<-chan error, <-chan error, <-chan error, error) {
+	cf := a.cf
+
+	a.appRateLimiter = services.NewRateLimiterService(
+		redis_rate.NewLimiter(a.redis.Client),
+		config.NewRateLimit(cf.RateLimiter.App),
+		"app",
+	)
+	a.authRateLimiter = services.NewRateLimiterService(
+		redis_rate.NewLimiter(a.redis.Client),
+		config.NewRateLimit(cf.RateLimiter.Auth),
+		"auth",
+	)
+	a.groupRateLimiters = services.NewRateLimiterGroups(
+		redis_rate.NewLimiter(a.redis.Client),
+		config.NewRateLimits(cf.RateLimiter.Groups),
+	)
+	// groupRateLimit limits a route group per user when it has its own
+	// entry in rate_limiter.groups.
+	groupRateLimit := func(group string) func(http.Handler) http.Handler {
+		if limiter, ok := a.groupRateLimiters[group]; ok {
+			return middlewares.UserRateLimiter(limiter)
+		}
+		return func(next http.Handler) http.Handler { return next }
+	}
+	// groupTimeout replaces server.request_timeout for a route group when
+	// it has its own entry in server.route_timeouts.
+	groupTimeout := func(group string) func(http.Handler) http.Handler {
+		if timeout, ok := cf.Server.RouteTimeouts[group]; ok {
+			return middlewares.Timeout(timeout)
+		}
+		return func(next http.Handler) http.Handler { return next }
+	}
+	// routeGroup applies the per-group rate limit and timeout.
+	routeGroup := func(group string) chi.Middlewares {
+		return chi.Middlewares{groupRateLimit(group), groupTimeout(group)}
+	}
+	rateLimiters := []services.RateLimiterService{a.appRateLimiter, a.authRateLimiter}
+	for _, group := range slices.Sorted(maps.Keys(a.groupRateLimiters)) {
+		rateLimiters = append(rateLimiters, a.groupRateLimiters[group])
+	}
+
+	meta := controllers.Meta{
+		Build:     buildinfo.Get(),
+		Features:  features,
+		Config:    cf.Sanitized(),
+		StartedAt: a.startedAt,
+	}
+
+	// Readiness fails while draining; shutdown drains first.
+	drain := adapters.NewDrain(cf.Shutdown.Drain)
+	running.drain = append(running.drain, drain)
+
+	var requestHandler *endpoint.RequestHandler
+	{
+		validate := validator.New(validator.WithRequiredStructEnabled())
+		validate.RegisterTagNameFunc(endpoint.JSONFieldName)
+		requestHandler = endpoint.NewRequestHandler(validate)
+	}
+
+	// mountAdmin mounts the admin routes, which both the API and the
+	// internal listener serve behind their own authentication. signedIn
+	// says whether callers are users, which sending test emails needs.
+	mountAdmin := func(r chi.Router, signedIn bool) {
+		r.Mount("/api/v1/admin", controllers.NewAdminController(
+			a.logLevelCtl,
+			drain,
+			services.NewReminderDedupeService(a.redis.Client),
+			a.analyticsService,
+			a.subscriptionService,
+			a.auditService,
+			a.deadTaskService,
+			a.reconciliationService,
+			rateLimiters,
+			a.configWatch,
+			requestHandler,
+		))
+		r.Mount("/api/v1/admin/suppressions", controllers.NewSuppressionController(a.suppressionService, requestHandler))
+		r.Mount("/api/v1/admin/emails", controllers.NewEmailPreviewController(a.emailPreviewService, requestHandler, signedIn))
+	}
+
+	var apiServer *adapters.HTTPServer
+	{
+		// Setup router
+		r := chi.NewRouter()
+
+		// Observability: Prometheus metrics endpoint — always exposed so
+		// infrastructure tooling (healthchecks, Prometheus) can scrape it
+		// regardless of whether OTel tracing is enabled.
+		r.Method(http.MethodGet, "/metrics", promhttp.Handler())
+
+		// Health Checks
+		r.Mount("/", controllers.NewHealthController(dbMonitor, a.redis, drain))
+
+		// Public keys for validating tokens in other services.
+		r.Mount("/.well-known", controllers.NewJWKSController(a.jwtService))
+
+		// Event streams stay open past the request timeout, so they get
+		// the API middlewares except Timeout.
+		r.Group(func(r chi.Router) {
+			if cf.OTel.Enabled {
+				r.Use(middlewares.OTel())
+			}
+			r.Use(middleware.Recoverer)
+			r.Use(middleware.Logger)
+			r.Use(middlewares.Locale())
+			r.Use(middlewares.RateLimiter(a.appRateLimiter))
+			r.Use(middlewares.Authentication(a.jwtService))
+			r.Mount("/api/v1/events", controllers.NewEventStreamController(a.liveEventService, cf.LiveEvents, requestHandler))
+		})
+
+		// Service Specific API Group
+		r.Group(func(r chi.Router) {
+			// Observability: OTel middleware first to capture the full request lifecycle.
+			// Ensures trace_id is injected into r.Context() for subsequent middlewares (like Logger).
+			if cf.OTel.Enabled {
+				r.Use(middlewares.OTel())
+			}
+			r.Use(middleware.Recoverer)
+			r.Use(middleware.Logger)
+			r.Use(middlewares.Locale())
+			r.Use(middlewares.Timeout(cf.Server.RequestTimeout))
+			r.Use(middlewares.RateLimiter(a.appRateLimiter))
+
+			// API documentation
+			r.Method(http.MethodGet, "/api/v1/openapi.json", apiSpec(meta.Build.Version))
+			r.Method(http.MethodGet, "/api/v1/docs", openapi.SwaggerUI(apiTitle, "/api/v1/openapi.json"))
+
+			// Setup routes
+			r.With(routeGroup("auth")...).Mount("/api/v1/auth", controllers.NewAuthController(
+				a.authService,
+				a.userService,
+				requestHandler,
+				middlewares.AuthRateLimiter(a.authRateLimiter),
+				middlewares.Authentication(a.jwtService),
+			))
+			// Downloads are authorized by signed URLs; the controller applies
+			// authentication to its remaining routes.
+			// Cancel links from savings suggestions are authorized by their signature.
+			r.Mount("/api/v1/cancel-links", controllers.NewCancelLinkController(a.cancelLinkService, requestHandler))
+			r.Mount("/api/v1/unsubscribe", controllers.NewUnsubscribeController(a.unsubscribeService, requestHandler))
+			r.With(routeGroup("files")...).Mount("/api/v1/files", controllers.NewFileController(a.fileService, requestHandler, middlewares.Authentication(a.jwtService)))
+			r.With(routeGroup("calendar")...).Mount("/api/v1/calendar", controllers.NewCalendarController(
+				a.calendarFeedService,
+				requestHandler,
+				middlewares.Authentication(a.jwtService),
+			))
+			// Authorized by the feed token; takes precedence over the
+			// authenticated subscription routes mounted below.
+			r.With(routeGroup("calendar")...).Mount("/api/v1/subscriptions/calendar.ics", controllers.NewSubscriptionCalendarController(
+				a.calendarFeedService,
+				requestHandler,
+			))
+
+			// Protected routes
+			r.Group(func(r chi.Router) {
+				// Apply authentication middleware
+				r.Use(middlewares.Authentication(a.jwtService))
+				// Guards the routes listing every user's data.
+				requireAdminRole := middlewares.RequireRole(models.AdminRole)
+
+				// User routes with authentication
+				r.With(routeGroup("users")...).Mount("/api/v1/users", controllers.NewUserController(a.userService, a.exportService, requestHandler, requireAdminRole))
+				r.With(routeGroup("users")...).Mount("/api/v1/users/{userID}/preferences", controllers.NewNotificationPreferenceController(a.preferenceService, requestHandler))
+				r.With(routeGroup("users")...).Mount("/api/v1/users/{userID}/notifications", controllers.NewNotificationController(a.notificationService, requestHandler))
+				r.With(routeGroup("users")...).Mount("/api/v1/users/{userID}/sessions", controllers.NewSessionController(a.sessionService, requestHandler))
+				r.With(routeGroup("subscriptions")...).Mount("/api/v1/subscriptions", controllers.NewSubscriptionController(
+					a.subscriptionService,
+					services.NewForecastService(
+						a.subscriptionRepository,
+						a.currencyService,
+						a.redis.Client,
+						cf.Forecast,
+						time.Now,
+					),
+					services.NewReminderSnoozeService(a.subscriptionService, a.redis.Client, time.Now),
+					a.exportService,
+					a.catalogService,
+					requestHandler,
+					requireAdminRole,
+					groupTimeout("exports"),
+				))
+				r.Mount("/api/v1/catalog", controllers.NewCatalogController(a.catalogService, requestHandler))
+				r.With(routeGroup("bills")...).Mount("/api/v1/subscriptions/{subscriptionID}/bills", controllers.NewSubscriptionBillController(a.billService, requestHandler))
+				r.With(routeGroup("bills")...).Mount("/api/v1/bills", controllers.NewBillController(
+					a.billService,
+					a.exportService,
+					a.invoiceService,
+					requestHandler,
+					groupTimeout("exports"),
+				))
+				r.With(routeGroup("budget")...).Mount("/api/v1/budget", controllers.NewBudgetController(a.budgetService, requestHandler))
+				r.With(routeGroup("webhooks")...).Mount("/api/v1/webhooks", controllers.NewWebhookController(a.webhookService, requestHandler))
+				r.With(routeGroup("payment_methods")...).Mount("/api/v1/payment-methods", controllers.NewPaymentMethodController(a.paymentMethodService, requestHandler))
+				r.With(routeGroup("payment_methods")...).Mount("/api/v1/subscriptions/{subscriptionID}/payment-method", controllers.NewSubscriptionPaymentMethodController(a.paymentMethodService, requestHandler))
+				r.With(routeGroup("files")...).Mount("/api/v1/subscriptions/{subscriptionID}/attachments", controllers.NewAttachmentController(a.attachmentService, cf.Attachments.MaxSize, requestHandler))
+				r.Mount("/api/v1/meta", controllers.NewMetaController(meta))
+
+				// Admin routes
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.RequireAdmin(a.userService))
+					mountAdmin(r.With(routeGroup("admin")...), true)
+				})
+			})
+		})
+
+		// Create a new server configuration
+		apiserverConfig := srv.ServerConfig{
+			Port:        cf.Server.Port,
+			TLSEnabled:  cf.Server.TLS.Enabled,
+			TLSCertPath: cf.Server.TLS.CertPath,
+			TLSKeyPath:  cf.Server.TLS.KeyPath,
+		}
+
+		apiServer = adapters.NewHTTPServer(r, "", apiserverConfig, adapters.ListenOptions{
+			ReusePort: cf.Server.ReusePort,
+			Inherit:   true,
+		})
+	}
+	if err := apiServer.Start(); err != nil {
+		return nil, nil, nil, err
+	}
+	// Open event streams would hold up the HTTP server; they close
+	// alongside it and clients reconnect elsewhere.
+	running.servers = append(running.servers, apiServer, a.liveEventService)
+
+	// Internal gRPC API for other services, on its own port.
+	grpcServer, err := a.startGRPCServer()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var grpcErr <-chan error // Nil, so never ready, when gRPC is off.
+	if grpcServer != nil {
+		running.servers = append(running.servers, grpcServer)
+		grpcErr = grpcServer.Err()
+	}
+
+	// Admin routes for internal tooling, authenticated by client
+	// certificates on their own port. Only the timeout of routeGroup
+	// applies: its rate limit is per user, and certificate callers have
+	// none, so every tool behind one address would share a budget sized
+	// for a single admin. The listener is meant for trusted networks.
+	internalServer, err := a.startInternalServer(func(r chi.Router) {
+		mountAdmin(r.With(groupTimeout("admin")), false)
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var internalErr <-chan error // Nil, so never ready, when disabled.
+	if internalServer != nil {
+		running.servers = append(running.servers, internalServer)
+		internalErr = internalServer.Err()
+	}
+
+	slog.Info("API server started",
+		logattr.Port(cf.Server.Port),
+		logattr.Timeout(cf.Server.RequestTimeout),
+		logattr.TLSEnabled(cf.Server.TLS.Enabled),
+	)
+	return apiServer.Err(), grpcErr, internalErr, nil
+}
+
+// startInternalServer serves the routes mount adds on the internal listener,
+// requiring a client certificate signed by its CA bundle. It returns nil when
+// the listener is disabled.
+func (a *app) startInternalServer(mount func(chi.Router)) (*adapters.HTTPServer, error) {
+	cf := a.cf
+	if !cf.InternalServer.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig, err := lib.LoadServerTLSConfig(
+		cf.InternalServer.TLS.CertPath,
+		cf.InternalServer.TLS.KeyPath,
+		cf.InternalServer.TLS.ClientCAPath,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load internal server TLS config: %w", err)
+	}
+
+	r := chi.NewRouter()
+	if cf.OTel.Enabled {
+		r.Use(middlewares.OTel())
+	}
+	r.Use(middleware.Recoverer)
+	r.Use(middleware.Logger)
+	r.Use(middlewares.Locale())
+	r.Use(middlewares.Timeout(cf.Server.RequestTimeout))
+	r.Use(middlewares.ClientCertificate(cf.InternalServer.AllowedClients))
+	mount(r)
+
+	server := adapters.NewHTTPServer(
+		r,
+		cf.InternalServer.Host,
+		srv.ServerConfig{Port: cf.InternalServer.Port},
+		adapters.ListenOptions{ReusePort: cf.Server.ReusePort},
+	).WithTLSConfig(tlsConfig)
+	if err := server.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start internal server: %w", err)
+	}
+	slog.Info("Internal server enabled",
+		logattr.Host(cf.InternalServer.Host),
+		logattr.Port(cf.InternalServer.Port),
+	)
+	return server, nil
+}
+
+// apiTitle names the API in its OpenAPI document.
+const apiTitle = "Subscription Management API"
+
+// apiSpec documents the routes mounted by serve; keep it in step with the
+// router.
+func apiSpec(version string) *openapi.Spec {
+	return openapi.NewSpec(apiTitle, version).
+		ErrorSchema(endpoint.ErrorResponse{}).
+		Envelope(endpoint.Meta{}).
+		Mount("/api/v1/auth", "Auth", controllers.AuthOperations).
+		Mount("/api/v1/cancel-links", "Subscriptions", controllers.CancelLinkOperations).
+		Mount("/api/v1/unsubscribe", "Users", controllers.UnsubscribeOperations).
+		Mount("/api/v1/files", "Files", controllers.FileOperations).
+		Mount("/api/v1/calendar", "Calendar", controllers.CalendarOperations).
+		Mount("/api/v1/subscriptions/calendar.ics", "Calendar", controllers.SubscriptionCalendarOperations).
+		Mount("/api/v1/users", "Users", controllers.UserOperations).
+		Mount("/api/v1/users/{userID}/preferences", "Users", controllers.NotificationPreferenceOperations).
+		Mount("/api/v1/users/{userID}/notifications", "Users", controllers.NotificationOperations).
+		Mount("/api/v1/users/{userID}/sessions", "Users", controllers.SessionOperations).
+		Mount("/api/v1/subscriptions", "Subscriptions", controllers.SubscriptionOperations).
+		Mount("/api/v1/catalog", "Subscriptions", controllers.CatalogOperations).
+		Mount("/api/v1/subscriptions/{subscriptionID}/bills", "Bills", controllers.SubscriptionBillOperations).
+		Mount("/api/v1/bills", "Bills", controllers.BillOperations).
+		Mount("/api/v1/budget", "Budget", controllers.BudgetOperations).
+		Mount("/api/v1/webhooks", "Webhooks", controllers.WebhookOperations).
+		Mount("/api/v1/payment-methods", "Payment methods", controllers.PaymentMethodOperations).
+		Mount("/api/v1/subscriptions/{subscriptionID}/payment-method", "Payment methods", controllers.SubscriptionPaymentMethodOperations).
+		Mount("/api/v1/subscriptions/{subscriptionID}/attachments", "Files", controllers.AttachmentOperations).
+		Mount("/.well-known", "Auth", controllers.JWKSOperations).
+		Mount("/api/v1/meta", "Meta", controllers.MetaOperations).
+		Mount("/api/v1/events", "Events", controllers.EventStreamOperations).
+		Mount("/api/v1/admin", "Admin", controllers.AdminOperations).
+		Mount("/api/v1/admin/suppressions", "Admin", controllers.SuppressionOperations).
+		Mount("/api/v1/admin/emails", "Admin", controllers.EmailPreviewOperations)
+}
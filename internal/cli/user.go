@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/cobra"
+)
+
+func newUserCommand(opts *rootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage user accounts",
+	}
+	cmd.AddCommand(newCreateAdminCommand(opts))
+	return cmd
+}
+
+func newCreateAdminCommand(opts *rootOptions) *cobra.Command {
+	var req models.UserRequest
+
+	cmd := &cobra.Command{
+		Use:   "create-admin",
+		Short: "Create an administrator account",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			// Apply the same rules as API registration.
+			validate := validator.New(validator.WithRequiredStructEnabled())
+			if err := validate.Struct(req); err != nil {
+				return fmt.Errorf("invalid admin account: %w", err)
+			}
+
+			a, err := newApp(ctx, opts)
+			if err != nil {
+				return err
+			}
+			defer a.shutdown(components{})
+
+			if err := a.connectDatabase(ctx); err != nil {
+				return err
+			}
+			if err := a.buildDomain(ctx); err != nil {
+				return err
+			}
+
+			user := req.ToModel()
+			user.Role = models.AdminRole
+			created, err := a.userService.CreateUser(ctx, user)
+			if err != nil {
+				return fmt.Errorf("failed to create admin: %w", err)
+			}
+
+			slog.Info("Admin created", logattr.UserID(created.ID.Hex()))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&req.Name, "name", "", "display name")
+	cmd.Flags().StringVar(&req.Email, "email", "", "login email")
+	cmd.Flags().StringVar(&req.Password, "password", "", "login password (at least 8 characters)")
+	for _, name := range []string{"name", "email", "password"} {
+		_ = cmd.MarkFlagRequired(name)
+	}
+	return cmd
+}
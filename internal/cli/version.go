@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/buildinfo"
+	"github.com/spf13/cobra"
+)
+
+func newVersionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print build information",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			info := buildinfo.Get()
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "version:    %s\n", info.Version)
+			fmt.Fprintf(out, "commit:     %s\n", info.Commit)
+			fmt.Fprintf(out, "build time: %s\n", info.BuildTime)
+			fmt.Fprintf(out, "go version: %s\n", info.GoVersion)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newWorkerCommand(opts *rootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "worker",
+		Short: "Run the scheduler and queue worker",
+		Long: "Run background processing: the scheduler and outbox relay, and the " +
+			"queue worker, each in the environments listed in its enabled_for_env. " +
+			"Same as run --role scheduler,worker.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			a, err := newApp(ctx, opts)
+			if err != nil {
+				return err
+			}
+			return a.run(ctx, processRoles{scheduler: true, worker: true})
+		},
+	}
+}
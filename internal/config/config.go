@@ -1,18 +1,34 @@
 package config
 
 import (
+	"slices"
 	"time"
 
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+
+	"github.com/anuragthepathak/subscription-management/internal/currency"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/eventbus"
 	"github.com/anuragthepathak/subscription-management/internal/notifications"
 	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/anuragthepathak/subscription-management/internal/payments"
+	"github.com/anuragthepathak/subscription-management/internal/storage"
 )
 
 // ServerConfig holds the server configuration, including TLS settings.
 type ServerConfig struct {
 	Port           int           `mapstructure:"port"`
 	RequestTimeout time.Duration `mapstructure:"request_timeout"`
-	TLS            struct {
+
+	// RouteTimeouts replaces RequestTimeout for the route groups listed in
+	// TimeoutGroups, e.g. a short one for auth and a longer one for exports.
+	RouteTimeouts map[string]time.Duration `mapstructure:"route_timeouts"`
+
+	// ReusePort sets SO_REUSEPORT on the listeners, so a new process can bind
+	// the same ports while the old one drains during a restart.
+	ReusePort bool `mapstructure:"reuse_port"`
+	TLS       struct {
 		Enabled  bool   `mapstructure:"enabled"`
 		CertPath string `mapstructure:"cert_path"`
 		KeyPath  string `mapstructure:"key_path"`
@@ -21,12 +37,66 @@ type ServerConfig struct {
 
 // DatabaseConfig holds the MongoDB connection details.
 type DatabaseConfig struct {
+	// URI is a full connection string (mongodb:// or mongodb+srv://). When
+	// set, it replaces Host, Port, and SRV; credentials below still apply.
+	URI        string `mapstructure:"uri"`
 	Host       string `mapstructure:"host"`
 	Port       int    `mapstructure:"port"`
+	SRV        bool   `mapstructure:"srv"` // Resolve Host as an SRV record; hosts ending in mongodb.net always do.
 	Username   string `mapstructure:"username"`
 	Password   string `mapstructure:"password"`
 	Name       string `mapstructure:"name"`
 	AuthSource string `mapstructure:"auth_source"`
+
+	// AuthMechanism selects the authentication mechanism (e.g. SCRAM-SHA-256,
+	// MONGODB-X509, MONGODB-AWS). Empty lets the driver negotiate SCRAM.
+	AuthMechanism string `mapstructure:"auth_mechanism"`
+
+	TLS ClientTLSConfig `mapstructure:"tls"`
+
+	// Client settings. They replace any given in URI; empty ones leave the
+	// URI's or the driver's default.
+	MaxPoolSize            uint64        `mapstructure:"max_pool_size"`            // Connections per server, in use or idle.
+	MinPoolSize            uint64        `mapstructure:"min_pool_size"`            // Connections per server kept open while idle.
+	ReadPreference         string        `mapstructure:"read_preference"`          // e.g. primary or secondaryPreferred.
+	WriteConcern           string        `mapstructure:"write_concern"`            // "majority" or the number of nodes that acknowledge a write.
+	ServerSelectionTimeout time.Duration `mapstructure:"server_selection_timeout"` // How long an operation waits for a suitable server.
+	Compressors            []string      `mapstructure:"compressors"`              // Wire compression in order of preference: zstd, snappy, zlib.
+
+	// AutoMigrate applies pending schema migrations on startup.
+	AutoMigrate bool `mapstructure:"auto_migrate"`
+
+	// Transactions runs multi-document writes in transactions, which need a
+	// replica set or sharded cluster. Without them each write commits on its
+	// own and the scheduler repairs bills left behind by a failed write.
+	Transactions bool `mapstructure:"transactions"`
+
+	HealthCheck DatabaseHealthCheckConfig `mapstructure:"health_check"`
+	Timeouts    DatabaseTimeoutsConfig    `mapstructure:"timeouts"`
+}
+
+// DatabaseTimeoutsConfig bounds single MongoDB operations by class. Zero
+// leaves a class bounded only by the request or task deadline.
+type DatabaseTimeoutsConfig struct {
+	Read  time.Duration `mapstructure:"read"`  // Finds and counts.
+	Write time.Duration `mapstructure:"write"` // Inserts, updates, and deletes of one document.
+	Bulk  time.Duration `mapstructure:"bulk"`  // Aggregations and multi-document updates and deletes.
+}
+
+// DatabaseHealthCheckConfig defines the background MongoDB health monitor settings.
+type DatabaseHealthCheckConfig struct {
+	Interval         time.Duration `mapstructure:"interval"`          // Time between background pings.
+	Timeout          time.Duration `mapstructure:"timeout"`           // Deadline for a single ping.
+	FailureThreshold int           `mapstructure:"failure_threshold"` // Consecutive failures before marking unhealthy.
+}
+
+// ClientTLSConfig holds TLS settings for outbound connections.
+type ClientTLSConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	CAFile             string `mapstructure:"ca_file"`              // CA bundle to trust instead of the system roots.
+	CertFile           string `mapstructure:"cert_file"`            // Client certificate, for mutual TLS.
+	KeyFile            string `mapstructure:"key_file"`             // Client certificate key.
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"` // Skip server verification; never use in production.
 }
 
 // RateLimiterConfig defines the rate limiting settings.
@@ -36,26 +106,168 @@ type RateLimiterConfig struct {
 	Period time.Duration `mapstructure:"period"` // Time period for rate limiting.
 }
 
+// RateLimitGroups lists the route groups that accept a per-user limit in
+// rate_limiter.groups.
+var RateLimitGroups = []string{
+	"auth", "users", "subscriptions", "bills", "budget", "webhooks", "payment_methods", "files", "calendar", "admin",
+}
+
+// TimeoutGroups lists the route groups that accept their own timeout in
+// server.route_timeouts: the rate-limited groups, and exports, which are
+// part of the subscriptions and bills groups.
+var TimeoutGroups = append(slices.Clone(RateLimitGroups), "exports")
+
 // RedisConfig holds the Redis connection details.
 type RedisConfig struct {
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
+	// Mode selects the topology: standalone (default), sentinel, or cluster.
+	Mode string `mapstructure:"mode"`
+
+	// URL is a redis:// or rediss:// connection string for standalone mode.
+	// When set, it replaces Host, Port, and DB; Username and Password fill in
+	// whatever the URL leaves out.
+	URL  string `mapstructure:"url"`
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+
+	// Addrs lists "host:port" seed nodes for cluster mode, or the sentinels
+	// for sentinel mode.
+	Addrs            []string `mapstructure:"addrs"`
+	MasterName       string   `mapstructure:"master_name"`       // Master monitored by the sentinels.
+	SentinelPassword string   `mapstructure:"sentinel_password"` // Password for the sentinels, if they require one.
+
+	Username string `mapstructure:"username"` // ACL user; empty uses the default user.
 	Password string `mapstructure:"password"`
-	DB       int    `mapstructure:"db"`
+	DB       int    `mapstructure:"db"` // Must be 0 in cluster mode.
+
+	TLS ClientTLSConfig `mapstructure:"tls"`
 }
 
+// CacheConfig defines the Redis read-through cache of subscriptions and users.
+type CacheConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	TTL     time.Duration `mapstructure:"ttl"` // How long a cached document may be served.
+}
+
+// Process roles accepted in Config.Roles.
+const (
+	RoleAPI       = "api"       // HTTP API, and the gRPC API when enabled.
+	RoleScheduler = "scheduler" // Scheduler and outbox relay.
+	RoleWorker    = "worker"    // Queue worker.
+	RoleAll       = "all"       // Every role above.
+)
+
 // AsynqConfig holds the configuration for the Asynq queue.
 type AsynqConfig struct {
 	QueueName string `mapstructure:"queue_name"`
 }
 
+// Scheduler modes accepted in SchedulerConfig.Mode.
+const (
+	SchedulerModePoll = "poll" // Run every scan on each interval.
+	SchedulerModeCron = "cron" // Run each scan on its own cron schedule.
+)
+
 // SchedulerConfig holds the configuration for the subscription scheduler.
 type SchedulerConfig struct {
 	Name          string        `mapstructure:"name"`
+	Mode          string        `mapstructure:"mode"`            // SchedulerModePoll or SchedulerModeCron.
 	Interval      time.Duration `mapstructure:"interval"`        // Polling interval for reminders.
 	ReminderDays  []int         `mapstructure:"reminder_days"`   // Days before renewal to send reminders.
 	StartupDelay  time.Duration `mapstructure:"startup_delay"`   // Delay before the first poll on startup.
 	EnabledForEnv []string      `mapstructure:"enabled_for_env"` // Environments where the scheduler is enabled.
+
+	// ArchiveAfterMonths moves subscriptions expired longer than this into
+	// the archive collection. Zero disables archival.
+	ArchiveAfterMonths int `mapstructure:"archive_after_months"`
+
+	// UnusedAfterDays flags active subscriptions not used for this many days
+	// and emails a savings suggestion UnusedNoticeDays before they renew.
+	// Zero disables suggestions.
+	UnusedAfterDays  int `mapstructure:"unused_after_days"`
+	UnusedNoticeDays int `mapstructure:"unused_notice_days"`
+
+	// Reconcile checks active and past-due subscriptions against their
+	// bills, reactivates those whose retried bill was already paid, and
+	// reports the rest to admins.
+	Reconcile bool `mapstructure:"reconcile"`
+
+	// SendWindow is the part of the day reminders are sent in, in each
+	// user's timezone. Reminders found outside it wait until it opens.
+	SendWindow SendWindowConfig `mapstructure:"send_window"`
+
+	OutboxRelay OutboxRelayConfig `mapstructure:"outbox_relay"`
+	Cron        CronConfig        `mapstructure:"cron"` // Used in cron mode only.
+}
+
+// SendWindowConfig holds the bounds of the reminder send window as "15:04"
+// wall clock times. Leaving both empty sends reminders at any time of day.
+type SendWindowConfig struct {
+	Start string `mapstructure:"start"`
+	End   string `mapstructure:"end"`
+}
+
+// Window returns the send window, or nil when it is disabled.
+func (c SendWindowConfig) Window() *models.SendWindow {
+	if c.Start == "" && c.End == "" {
+		return nil
+	}
+	return &models.SendWindow{Start: c.Start, End: c.End}
+}
+
+// CronConfig holds the schedules of the scheduler's scans in cron mode, as
+// five-field cron expressions or descriptors such as "@hourly". An empty
+// schedule disables its scan. Scans that are off for other reasons, e.g.
+// archival with archive_after_months 0, stay off.
+type CronConfig struct {
+	Timezone         string `mapstructure:"timezone"` // IANA zone the schedules are in.
+	Reminders        string `mapstructure:"reminders"`
+	Renewals         string `mapstructure:"renewals"`
+	Expirations      string `mapstructure:"expirations"`
+	Archive          string `mapstructure:"archive"`
+	Unused           string `mapstructure:"unused"`
+	Dunning          string `mapstructure:"dunning"`
+	ExchangeRates    string `mapstructure:"exchange_rates"`
+	BudgetAlerts     string `mapstructure:"budget_alerts"`
+	BillRepair       string `mapstructure:"bill_repair"`
+	ChargeSettlement string `mapstructure:"charge_settlement"`
+	Reconciliation   string `mapstructure:"reconciliation"`
+
+	// RenewalLookahead is how far ahead a renewal scan looks. Renewals found
+	// are enqueued to run RenewalHoursBeforeDay before they are due, so the
+	// scan can run far less often than the renewal window is wide.
+	RenewalLookahead time.Duration `mapstructure:"renewal_lookahead"`
+}
+
+// Schedules returns the schedule of each scan keyed by its config name.
+func (c CronConfig) Schedules() map[string]string {
+	return map[string]string{
+		"reminders":         c.Reminders,
+		"renewals":          c.Renewals,
+		"expirations":       c.Expirations,
+		"archive":           c.Archive,
+		"unused":            c.Unused,
+		"dunning":           c.Dunning,
+		"exchange_rates":    c.ExchangeRates,
+		"budget_alerts":     c.BudgetAlerts,
+		"bill_repair":       c.BillRepair,
+		"charge_settlement": c.ChargeSettlement,
+		"reconciliation":    c.Reconciliation,
+	}
+}
+
+// OutboxRelayConfig holds the configuration for the outbox relay, which runs
+// alongside the scheduler.
+type OutboxRelayConfig struct {
+	Interval  time.Duration `mapstructure:"interval"`   // Polling interval for pending events.
+	BatchSize int           `mapstructure:"batch_size"` // Maximum events relayed per poll.
+}
+
+// DunningConfig controls how failed renewal payments are retried. Each retry
+// emails the user; the last one expires or suspends the subscription.
+type DunningConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	RetryDays   []int  `mapstructure:"retry_days"`   // Days after the failure to retry, in increasing order.
+	FinalAction string `mapstructure:"final_action"` // expire or suspend, after the last retry fails.
 }
 
 // QueueWorkerConfig holds the configuration for the queue worker.
@@ -63,22 +275,160 @@ type QueueWorkerConfig struct {
 	Name          string   `mapstructure:"name"`
 	Concurrency   int      `mapstructure:"concurrency"`     // Number of concurrent workers.
 	EnabledForEnv []string `mapstructure:"enabled_for_env"` // Environments where the worker is enabled.
+
+	// SendRate caps how many reminders, digests, and trial-ending notices
+	// all workers together send per period; those over it wait for a later
+	// slot. A zero rate disables the cap.
+	SendRate RateLimiterConfig `mapstructure:"send_rate"`
+}
+
+// LogConfig controls where logs go and how they are formatted. Empty values
+// pick a default from the environment; see SetupLogger.
+type LogConfig struct {
+	Level    string            `mapstructure:"level"`  // debug, info, warn, or error.
+	Format   string            `mapstructure:"format"` // json or text.
+	Output   string            `mapstructure:"output"` // stderr, file, or both.
+	File     LogFileConfig     `mapstructure:"file"`
+	Sampling LogSamplingConfig `mapstructure:"sampling"`
+}
+
+// LogFileConfig holds the log file location and rotation policy.
+type LogFileConfig struct {
+	Path       string `mapstructure:"path"`
+	MaxSizeMB  int    `mapstructure:"max_size_mb"`  // Size at which the file is rotated.
+	MaxBackups int    `mapstructure:"max_backups"`  // Rotated files to keep; 0 keeps all.
+	MaxAgeDays int    `mapstructure:"max_age_days"` // Days to keep rotated files; 0 keeps them regardless of age.
+	Compress   bool   `mapstructure:"compress"`     // Gzip rotated files.
+}
+
+// LogSamplingConfig thins out debug logs on busy instances.
+type LogSamplingConfig struct {
+	DebugEvery int `mapstructure:"debug_every"` // Keep one in every N debug records; 0 or 1 keeps all.
+}
+
+// DebugConfig controls the internal server for pprof profiles and runtime
+// stats. It is separate from the API listener so it can stay unexposed.
+type DebugConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Host    string `mapstructure:"host"` // Interface to bind; defaults to loopback.
+	Port    int    `mapstructure:"port"`
+}
+
+// GRPCConfig controls the internal gRPC API other services query
+// subscriptions and users through. It has its own listener so it can stay on
+// a private network.
+type GRPCConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Host    string `mapstructure:"host"` // Interface to bind; empty listens on all.
+	Port    int    `mapstructure:"port"`
+	TLS     struct {
+		Enabled  bool   `mapstructure:"enabled"`
+		CertPath string `mapstructure:"cert_path"`
+		KeyPath  string `mapstructure:"key_path"`
+		// ClientCAPath requires clients to present a certificate signed by one
+		// of its CAs (mutual TLS). The API has no other authentication.
+		ClientCAPath string `mapstructure:"client_ca_path"`
+	} `mapstructure:"tls"`
+}
+
+// InternalServerConfig controls a second HTTP listener serving the admin
+// routes to internal tooling. Callers authenticate with a client certificate
+// signed by one of the CAs of TLS.ClientCAPath (mutual TLS) instead of a JWT,
+// so tooling needs no user account.
+type InternalServerConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Host    string `mapstructure:"host"` // Interface to bind; empty listens on all.
+	Port    int    `mapstructure:"port"`
+	TLS     struct {
+		CertPath     string `mapstructure:"cert_path"`
+		KeyPath      string `mapstructure:"key_path"`
+		ClientCAPath string `mapstructure:"client_ca_path"`
+	} `mapstructure:"tls"`
+	// AllowedClients limits callers to certificates with one of these common
+	// or DNS names; empty allows any certificate the CAs signed.
+	AllowedClients []string `mapstructure:"allowed_clients"`
+}
+
+// ShutdownConfig holds the timeout of each graceful shutdown stage. Stages
+// run in the order listed.
+type ShutdownConfig struct {
+	Drain     time.Duration `mapstructure:"drain"`     // Report not ready and keep serving, so load balancers move traffic away.
+	HTTP      time.Duration `mapstructure:"http"`      // Drain in-flight HTTP requests.
+	Scheduler time.Duration `mapstructure:"scheduler"` // Stop the scheduler, outbox relay, and health monitor.
+	Worker    time.Duration `mapstructure:"worker"`    // Let running tasks finish before they are requeued.
+	// Part of Worker spent draining: the worker stops fetching tasks and waits
+	// for running ones. Tasks still running get the rest of Worker to finish.
+	WorkerDrain time.Duration `mapstructure:"worker_drain"`
+	Connections time.Duration `mapstructure:"connections"` // Close Redis and MongoDB, then flush telemetry.
+}
+
+// StartupConfig controls how long startup waits for MongoDB and Redis to
+// become reachable, e.g. while docker-compose is still starting them.
+type StartupConfig struct {
+	MaxWait        time.Duration `mapstructure:"max_wait"`        // Total time to keep retrying; 0 fails on the first error.
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"` // Wait after the first failure; doubled after each further one.
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`     // Upper bound for a single wait.
+}
+
+// Backoff returns the retry schedule for startup dependency checks.
+func (s StartupConfig) Backoff() lib.Backoff {
+	return lib.Backoff{
+		Initial: s.InitialBackoff,
+		Max:     s.MaxBackoff,
+		MaxWait: s.MaxWait,
+	}
 }
 
 // Config holds the complete application configuration.
 type Config struct {
-	Server      ServerConfig              `mapstructure:"server"`
-	Database    DatabaseConfig            `mapstructure:"database"`
-	JWT         services.JWTConfig        `mapstructure:"jwt"`
-	Redis       RedisConfig               `mapstructure:"redis"`
-	Asynq       AsynqConfig               `mapstructure:"asynq"`
-	Env         string                    `mapstructure:"env"` // Current application environment (e.g., development, production).
-	Scheduler   SchedulerConfig           `mapstructure:"scheduler"`
-	QueueWorker QueueWorkerConfig         `mapstructure:"queue_worker"`
-	Email       notifications.EmailConfig `mapstructure:"email"`
-	OTel        observability.Config      `mapstructure:"otel"`
+	Server         ServerConfig                 `mapstructure:"server"`
+	Database       DatabaseConfig               `mapstructure:"database"`
+	JWT            services.JWTConfig           `mapstructure:"jwt"`
+	Redis          RedisConfig                  `mapstructure:"redis"`
+	Cache          CacheConfig                  `mapstructure:"cache"`
+	Asynq          AsynqConfig                  `mapstructure:"asynq"`
+	Env            string                       `mapstructure:"env"`   // Current application environment (e.g., development, production).
+	Roles          []string                     `mapstructure:"roles"` // Roles started by `subman run`; see RoleAPI.
+	Scheduler      SchedulerConfig              `mapstructure:"scheduler"`
+	QueueWorker    QueueWorkerConfig            `mapstructure:"queue_worker"`
+	Dunning        DunningConfig                `mapstructure:"dunning"`
+	Email          notifications.EmailConfig    `mapstructure:"email"`
+	Webhooks       notifications.WebhookConfig  `mapstructure:"webhooks"`
+	Notifications  notifications.ChannelsConfig `mapstructure:"notifications"`
+	OTel           observability.Config         `mapstructure:"otel"`
+	Files          services.FileConfig          `mapstructure:"files"`
+	Storage        storage.Config               `mapstructure:"storage"`
+	Attachments    services.AttachmentConfig    `mapstructure:"attachments"`
+	Catalog        services.CatalogConfig       `mapstructure:"catalog"`
+	LiveEvents     services.LiveEventConfig     `mapstructure:"live_events"`
+	Calendar       services.CalendarFeedConfig  `mapstructure:"calendar"`
+	Export         services.ExportConfig        `mapstructure:"export"`
+	Invoice        services.InvoiceConfig       `mapstructure:"invoice"`
+	Account        services.AccountConfig       `mapstructure:"account"`
+	Sessions       services.SessionConfig       `mapstructure:"sessions"`
+	Forecast       services.ForecastConfig      `mapstructure:"forecast"`
+	CancelLinks    services.CancelLinkConfig    `mapstructure:"cancel_links"`
+	Unsubscribe    services.UnsubscribeConfig   `mapstructure:"unsubscribe"`
+	Currency       currency.Config              `mapstructure:"currency"`
+	Payments       payments.Config              `mapstructure:"payments"`
+	EventBus       eventbus.Config              `mapstructure:"event_bus"`
+	Secrets        SecretsConfig                `mapstructure:"secrets"`
+	Startup        StartupConfig                `mapstructure:"startup"`
+	Shutdown       ShutdownConfig               `mapstructure:"shutdown"`
+	Debug          DebugConfig                  `mapstructure:"debug"`
+	GRPC           GRPCConfig                   `mapstructure:"grpc"`
+	InternalServer InternalServerConfig         `mapstructure:"internal_server"`
+	Log            LogConfig                    `mapstructure:"log"`
 
 	RateLimiter struct {
-		App RateLimiterConfig `mapstructure:"app"` // Application-level rate limiter settings.
+		App  RateLimiterConfig `mapstructure:"app"`  // Application-level rate limiter settings.
+		Auth RateLimiterConfig `mapstructure:"auth"` // Stricter limit for login and registration, per IP and email.
+		// Per-user limits for route groups, keyed by a name in RateLimitGroups.
+		// They apply on top of App; groups without an entry have no own limit.
+		Groups map[string]RateLimiterConfig `mapstructure:"groups"`
 	} `mapstructure:"rate_limiter"`
+
+	// SecretRefs keeps the secret references found in the loaded values,
+	// keyed by config key, so they can be resolved again on refresh.
+	SecretRefs map[string]string `mapstructure:"-"`
 }
@@ -1,195 +1,326 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/currency"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
 	"github.com/spf13/viper"
 )
 
-// LoadConfig loads the application configuration from a YAML file or environment variables.
-func LoadConfig() (*Config, error) {
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(".")
+// LoadConfig loads the application configuration. Sources are layered, each
+// overriding the ones before it:
+//
+//  1. Built-in defaults.
+//  2. The base file: configFile, or config.yaml in the working directory
+//     when configFile is empty.
+//  3. The environment overlay next to the base file, e.g. config.production.yaml
+//     for env "production". It is optional.
+//  4. Environment variables prefixed with APP_ (e.g. APP_JWT_ACCESS_SECRET).
+//  5. Secret references in the values above, such as vault:secret/jwt#access,
+//     and then the secrets provider, when configured.
+func LoadConfig(ctx context.Context, configFile string) (*Config, error) {
 
 	// Set default values for configuration.
+	viper.SetDefault("env", EnvDevelopment)
+	viper.SetDefault("roles", []string{RoleAll})
+
 	viper.SetDefault("server.port", 8080)
 	viper.SetDefault("server.request_timeout", "10s")
 	viper.SetDefault("server.tls.enabled", false)
+	viper.SetDefault("server.reuse_port", false)
 
 	viper.SetDefault("database.auth_source", "admin")
 	viper.SetDefault("database.port", 27017)
+	viper.SetDefault("database.auto_migrate", true)
+	viper.SetDefault("database.transactions", true)
+	viper.SetDefault("database.tls.enabled", false)
+	viper.SetDefault("database.max_pool_size", 100)
+	viper.SetDefault("database.min_pool_size", 0)
+	viper.SetDefault("database.read_preference", "primary")
+	viper.SetDefault("database.write_concern", "majority")
+	viper.SetDefault("database.server_selection_timeout", "30s")
+	viper.SetDefault("database.health_check.interval", "15s")
+	viper.SetDefault("database.health_check.timeout", "2s")
+	viper.SetDefault("database.health_check.failure_threshold", 3)
+	viper.SetDefault("database.timeouts.read", "5s")
+	viper.SetDefault("database.timeouts.write", "5s")
+	viper.SetDefault("database.timeouts.bulk", "1m")
 
+	viper.SetDefault("redis.mode", RedisModeStandalone)
 	viper.SetDefault("redis.port", 6379)
 	viper.SetDefault("redis.db", 0)
+	viper.SetDefault("redis.tls.enabled", false)
 
 	viper.SetDefault("asynq.queue_name", "subscription")
 
 	viper.SetDefault("rate_limiter.app.period", "1m")
+	viper.SetDefault("rate_limiter.auth.rate", 5)
+	viper.SetDefault("rate_limiter.auth.period", "1m")
 
+	viper.SetDefault("jwt.algorithm", services.JWTAlgorithmHS256)
 	viper.SetDefault("jwt.access_timeout", "1")
 	viper.SetDefault("jwt.refresh_timeout", "72")
 
 	// Scheduler configuration
+	viper.SetDefault("scheduler.mode", SchedulerModePoll)
 	viper.SetDefault("scheduler.interval", "12h")
 	viper.SetDefault("scheduler.reminder_days", [3]int{1, 3, 7})
 	viper.SetDefault("scheduler.startup_delay", "15m")
-	viper.SetDefault("scheduler.enabled_for_env", []string{"production", "staging"})
+	viper.SetDefault("scheduler.enabled_for_env", []string{EnvProduction, EnvStaging})
+	viper.SetDefault("scheduler.archive_after_months", 12)
+	viper.SetDefault("scheduler.unused_after_days", 0)
+	viper.SetDefault("scheduler.unused_notice_days", 7)
+	viper.SetDefault("scheduler.reconcile", true)
+	viper.SetDefault("scheduler.send_window.start", "09:00")
+	viper.SetDefault("scheduler.send_window.end", "18:00")
+	viper.SetDefault("scheduler.outbox_relay.interval", "5s")
+	viper.SetDefault("scheduler.outbox_relay.batch_size", 100)
+	viper.SetDefault("scheduler.cron.timezone", "UTC")
+	viper.SetDefault("scheduler.cron.reminders", "0 */6 * * *")
+	viper.SetDefault("scheduler.cron.renewals", "0 * * * *")
+	viper.SetDefault("scheduler.cron.expirations", "*/15 * * * *")
+	viper.SetDefault("scheduler.cron.archive", "0 3 * * *")
+	viper.SetDefault("scheduler.cron.unused", "0 10 * * *")
+	viper.SetDefault("scheduler.cron.dunning", "0 */6 * * *")
+	viper.SetDefault("scheduler.cron.exchange_rates", "0 17 * * *")
+	viper.SetDefault("scheduler.cron.budget_alerts", "0 * * * *")
+	viper.SetDefault("scheduler.cron.bill_repair", "0 * * * *")
+	viper.SetDefault("scheduler.cron.charge_settlement", "15 * * * *")
+	viper.SetDefault("scheduler.cron.reconciliation", "30 4 * * *")
+	viper.SetDefault("scheduler.cron.renewal_lookahead", "24h")
+
+	// Dunning configuration
+	viper.SetDefault("dunning.enabled", true)
+	viper.SetDefault("dunning.retry_days", [3]int{1, 3, 7})
+	viper.SetDefault("dunning.final_action", string(models.DunningExpire))
 
 	// Queue worker configuration
 	viper.SetDefault("queue_worker.concurrency", 2)
-	viper.SetDefault("queue_worker.enabled_for_env", []string{"production", "staging"})
+	viper.SetDefault("queue_worker.enabled_for_env", []string{EnvProduction, EnvStaging})
+	viper.SetDefault("queue_worker.send_rate.rate", 0)
+	viper.SetDefault("queue_worker.send_rate.period", "1m")
 
 	// OpenTelemetry configuration
 	viper.SetDefault("otel.enabled", false)
 	viper.SetDefault("otel.service_name", "subscription-management")
 	viper.SetDefault("otel.jaeger_endpoint", "localhost:4317")
+	viper.SetDefault("otel.sample_ratio", 1.0)
 	viper.SetDefault("email.smtp_port", 587)
 	viper.SetDefault("email.from_name", "Subscription Management")
+	viper.SetDefault("email.send_timeout", "30s")
+	viper.SetDefault("email.max_retries", 2)
+	viper.SetDefault("email.retry_base_delay", "1s")
+	viper.SetDefault("email.retry_max_delay", "10s")
+	viper.SetDefault("email.max_idle_conns", 2)
+	viper.SetDefault("email.idle_timeout", "30s")
+	viper.SetDefault("email.breaker_threshold", 5)
+	viper.SetDefault("email.breaker_cooldown", "1m")
 
-	// Read the YAML configuration file.
-	if err := viper.ReadInConfig(); err != nil &&
-		!errors.As(err, &viper.ConfigFileNotFoundError{}) {
-		return nil, fmt.Errorf("config file found but failed to parse: %w", err)
-	}
+	// Webhook delivery configuration
+	viper.SetDefault("webhooks.timeout", "10s")
+	viper.SetDefault("webhooks.max_retries", 8)
+	viper.SetDefault("webhooks.retry_base_delay", "30s")
+	viper.SetDefault("webhooks.retry_max_delay", "1h")
+	viper.SetDefault("webhooks.allow_private_networks", false)
 
-	// Enable environment variables to override config file settings.
-	viper.SetEnvPrefix("APP")
-	viper.AutomaticEnv()
+	// Notification channel configuration
+	viper.SetDefault("notifications.timeout", "10s")
+	viper.SetDefault("notifications.sms.base_url", "https://api.twilio.com")
+	viper.SetDefault("notifications.push.ttl", "24h")
 
-	var config Config
-	if err := viper.Unmarshal(&config); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal configuration: %w", err)
-	}
-	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	// File storage configuration
+	viper.SetDefault("files.url_expiry", "15m")
+	viper.SetDefault("storage.local.dir", "data/files")
+	viper.SetDefault("attachments.max_size", 10<<20)
+	viper.SetDefault("attachments.max_per_subscription", 20)
+	viper.SetDefault("attachments.allowed_types", []string{"application/pdf", "image/jpeg", "image/png", "image/webp", "image/gif"})
+	viper.SetDefault("catalog.logo_url", "https://icons.duckduckgo.com/ip3/%s.ico")
+
+	// Live event stream configuration
+	viper.SetDefault("live_events.channel", "live_events")
+	viper.SetDefault("live_events.buffer_size", 64)
+	viper.SetDefault("live_events.heartbeat", "30s")
+	viper.SetDefault("live_events.max_duration", "1h")
+
+	// Calendar feed configuration
+	viper.SetDefault("calendar.months", 12)
+
+	// Export configuration
+	viper.SetDefault("export.max_rows", 5000)
+	viper.SetDefault("export.link_expiry", "72h")
+	viper.SetDefault("account.closure_grace_period", "720h")
+	viper.SetDefault("sessions.max_concurrent", 10)
+
+	// Invoice configuration
+	viper.SetDefault("invoice.company_name", "Subscription Management")
+	viper.SetDefault("invoice.tax_name", "Tax")
+
+	// Forecast configuration
+	viper.SetDefault("forecast.cache_ttl", "10m")
+	viper.SetDefault("cache.enabled", false)
+	viper.SetDefault("cache.ttl", "1m")
+
+	// Exchange rate configuration
+	viper.SetDefault("currency.provider", currency.ProviderFrankfurter)
+	viper.SetDefault("currency.base_url", "https://api.frankfurter.app")
+	viper.SetDefault("currency.base", "EUR")
+	viper.SetDefault("currency.timeout", "10s")
+
+	// Payment provider configuration; charging is off until a provider is set.
+	viper.SetDefault("payments.base_url", "https://api.stripe.com")
+	viper.SetDefault("payments.timeout", "30s")
+
+	// Event bus configuration; publishing is off until a driver is set.
+	viper.SetDefault("event_bus.subject_prefix", "subman.events")
+	viper.SetDefault("event_bus.timeout", "10s")
+
+	// Logging configuration; level, format, and output default by environment.
+	viper.SetDefault("log.file.path", "logs/app.log")
+	viper.SetDefault("log.file.max_size_mb", 100)
+	viper.SetDefault("log.file.max_backups", 5)
+	viper.SetDefault("log.file.max_age_days", 28)
+	viper.SetDefault("log.file.compress", false)
+	viper.SetDefault("log.sampling.debug_every", 0)
+
+	// Debug server configuration
+	viper.SetDefault("debug.enabled", false)
+	viper.SetDefault("debug.host", "127.0.0.1")
+	viper.SetDefault("debug.port", 6060)
+
+	// Internal gRPC API configuration
+	viper.SetDefault("grpc.enabled", false)
+	viper.SetDefault("grpc.port", 9090)
+
+	// Internal admin listener configuration
+	viper.SetDefault("internal_server.enabled", false)
+	viper.SetDefault("internal_server.port", 8444)
+
+	// Startup dependency retries
+	viper.SetDefault("startup.max_wait", "1m")
+	viper.SetDefault("startup.initial_backoff", "1s")
+	viper.SetDefault("startup.max_backoff", "10s")
+
+	// Graceful shutdown stage timeouts
+	viper.SetDefault("shutdown.drain", "0s")
+	viper.SetDefault("shutdown.http", "15s")
+	viper.SetDefault("shutdown.scheduler", "5s")
+	viper.SetDefault("shutdown.worker", "30s")
+	viper.SetDefault("shutdown.worker_drain", "25s")
+	viper.SetDefault("shutdown.connections", "10s")
+
+	// Secrets provider configuration
+	viper.SetDefault("secrets.timeout", "10s")
+	viper.SetDefault("secrets.vault.mount", "secret")
+	viper.SetDefault("secrets.gcp.version", "latest")
+
+	bindEnv()
+
+	config, files, err := readConfig(ctx, configFile)
+	if err != nil {
+		return nil, err
 	}
 	slog.Info("Configuration loaded successfully",
 		logattr.Env(config.Env),
-		logattr.ConfigFile(viper.ConfigFileUsed()),
+		logattr.ConfigFiles(files),
 	)
-	return &config, nil
+	return config, nil
 }
 
-// Validate checks for missing or invalid configuration fields.
-func (c *Config) Validate() error {
-	var missing []string
-
-	if c.Server.TLS.Enabled {
-		if c.Server.TLS.CertPath == "" {
-			missing = append(missing, "server.tls.cert_path")
-		}
-		if c.Server.TLS.KeyPath == "" {
-			missing = append(missing, "server.tls.key_path")
-		}
-	}
+// readConfig reads the config files over the defaults, applies environment
+// variables and secrets, and validates the result. It can run again to
+// reload the files. It returns the files read, in order.
+func readConfig(ctx context.Context, configFile string) (*Config, []string, error) {
+	// Reading the overlay replaced the file name, so set it on every read.
+	useConfigFile(configFile)
 
-	// Database configuration validation
-	if c.Database.Host == "" {
-		missing = append(missing, "database.host")
-	}
-	if c.Database.Username == "" {
-		missing = append(missing, "database.username")
-	}
-	if c.Database.Password == "" {
-		missing = append(missing, "database.password")
-	}
-	if c.Database.Name == "" {
-		missing = append(missing, "database.name")
-	}
-	if c.Database.AuthSource == "" {
-		missing = append(missing, "database.auth_source")
-	}
-	if c.Database.Port <= 0 || c.Database.Port > 65535 {
-		missing = append(missing, "database.port (must be between 1 and 65535)")
+	files, err := readConfigFiles(configFile != "")
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Redis configuration validation
-	if c.Redis.Host == "" {
-		missing = append(missing, "redis.host")
+	var config Config
+	if err := viper.Unmarshal(&config); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal configuration: %w", err)
 	}
-	if c.Redis.Port <= 0 || c.Redis.Port > 65535 {
-		missing = append(missing, "redis.port (must be between 1 and 65535)")
+	if err := config.loadSecrets(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to load secrets: %w", err)
 	}
-	if c.Redis.DB < 0 {
-		missing = append(missing, "redis.db (must be 0 or greater)")
+	if err := config.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
+	return &config, files, nil
+}
 
-	// Asynq configuration validation
-	if c.Asynq.QueueName == "" {
-		missing = append(missing, "asynq.queue_name")
-	}
+// bindEnv lets environment variables override config file settings. Nested
+// keys map to underscores: jwt.access_secret -> APP_JWT_ACCESS_SECRET.
+func bindEnv() {
+	viper.SetEnvPrefix("APP")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+}
 
-	// Rate limiter configuration validation
-	if c.RateLimiter.App.Rate == 0 {
-		missing = append(missing, "rate_limiter.app.rate")
-	}
-	if c.RateLimiter.App.Period == 0 {
-		missing = append(missing, "rate_limiter.app.period")
+// useConfigFile points viper at configFile, or at config.yaml in the working
+// directory when configFile is empty.
+func useConfigFile(configFile string) {
+	if configFile != "" {
+		viper.SetConfigFile(configFile)
+	} else {
+		viper.SetConfigName("config")
+		viper.SetConfigType("yaml")
+		viper.AddConfigPath(".")
 	}
+}
 
-	// JWT configuration validation
-	if c.JWT.AccessSecret == "" {
-		missing = append(missing, "jwt.access_secret")
-	}
-	if c.JWT.RefreshSecret == "" {
-		missing = append(missing, "jwt.refresh_secret")
-	}
-	if c.JWT.Issuer == "" {
-		missing = append(missing, "jwt.issuer")
-	}
+// readConfigFiles reads the base config file and merges the overlay for the
+// current environment on top of it. A missing base file is only an error when
+// it was named explicitly. It returns the files read, in order.
+func readConfigFiles(explicit bool) ([]string, error) {
+	var files []string
 
-	// Scheduler configuration validation
-	if c.Scheduler.Interval <= 0 {
-		missing = append(missing, "scheduler.interval (must be greater than 0)")
-	}
-	if c.Scheduler.Name == "" {
-		missing = append(missing, "scheduler.name")
-	}
-	if c.Scheduler.ReminderDays == nil {
-		missing = append(missing, "scheduler.reminder_days")
-	}
-	if c.Scheduler.StartupDelay <= 0 {
-		missing = append(missing, "scheduler.startup_delay (must be greater than 0)")
+	err := viper.ReadInConfig()
+	switch {
+	case err == nil:
+		files = append(files, viper.ConfigFileUsed())
+	case !explicit && errors.As(err, &viper.ConfigFileNotFoundError{}):
+		// Run from defaults and environment variables alone.
+	default:
+		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Queue worker configuration validation
-	if c.QueueWorker.Concurrency == 0 {
-		missing = append(missing, "queue_worker.concurrency")
+	env := viper.GetString("env")
+	if env == "" {
+		return files, nil
 	}
 
-	// OpenTelemetry configuration validation
-	if c.OTel.ServiceName == "" {
-		missing = append(missing, "otel.service_name")
-	}
-	if c.OTel.JaegerEndpoint == "" {
-		missing = append(missing, "otel.jaeger_endpoint")
+	base := "config.yaml"
+	if len(files) > 0 {
+		base = files[0]
 	}
+	overlay := overlayFile(base, env)
 
-	// Email configuration validation
-	if c.Email.SMTPHost == "" {
-		missing = append(missing, "email.smtp_host")
-	}
-	if c.Email.FromEmail == "" {
-		missing = append(missing, "email.from_email")
-	}
-	if c.Email.SMTPUsername == "" {
-		missing = append(missing, "email.smtp_username")
+	if _, err := os.Stat(overlay); errors.Is(err, fs.ErrNotExist) {
+		return files, nil
 	}
-	if c.Email.SMTPPassword == "" {
-		missing = append(missing, "email.smtp_password")
-	}
-
-	if len(missing) > 0 {
-		return fmt.Errorf(
-			"%d missing required config fields: %v",
-			len(missing),
-			missing,
-		)
+	viper.SetConfigFile(overlay)
+	if err := viper.MergeInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read %s config overlay: %w", env, err)
 	}
+	return append(files, overlay), nil
+}
 
-	return nil
+// overlayFile returns the path of the overlay of base for env, e.g.
+// config.production.yaml next to config.yaml.
+func overlayFile(base, env string) string {
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "." + env + ext
 }
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfigFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string // Written to the working directory.
+		env        map[string]string
+		configFile string // Relative to the working directory.
+		wantFiles  []string
+		wantLevel  string
+		wantErr    bool
+	}{
+		{
+			name:      "base file only",
+			files:     map[string]string{"config.yaml": "log:\n  level: info\n"},
+			wantFiles: []string{"config.yaml"},
+			wantLevel: "info",
+		},
+		{
+			name: "overlay beats the base file",
+			files: map[string]string{
+				"config.yaml":         "env: staging\nlog:\n  level: info\n",
+				"config.staging.yaml": "log:\n  level: warn\n",
+			},
+			wantFiles: []string{"config.yaml", "config.staging.yaml"},
+			wantLevel: "warn",
+		},
+		{
+			name: "APP_ variable beats the base file and the overlay",
+			files: map[string]string{
+				"config.yaml":         "env: staging\nlog:\n  level: info\n",
+				"config.staging.yaml": "log:\n  level: warn\n",
+			},
+			env:       map[string]string{"APP_LOG_LEVEL": "error"},
+			wantFiles: []string{"config.yaml", "config.staging.yaml"},
+			wantLevel: "error",
+		},
+		{
+			name: "APP_ENV selects the overlay",
+			files: map[string]string{
+				"config.yaml":            "env: staging\nlog:\n  level: info\n",
+				"config.staging.yaml":    "log:\n  level: warn\n",
+				"config.production.yaml": "log:\n  level: error\n",
+			},
+			env:       map[string]string{"APP_ENV": "production"},
+			wantFiles: []string{"config.yaml", "config.production.yaml"},
+			wantLevel: "error",
+		},
+		{
+			name:      "missing overlay is ignored",
+			files:     map[string]string{"config.yaml": "env: staging\nlog:\n  level: info\n"},
+			wantFiles: []string{"config.yaml"},
+			wantLevel: "info",
+		},
+		{
+			name: "overlay sits next to an explicit config file",
+			files: map[string]string{
+				"base.yml":         "env: staging\nlog:\n  level: info\n",
+				"base.staging.yml": "log:\n  level: warn\n",
+				"config.yaml":      "log:\n  level: debug\n",
+			},
+			configFile: "base.yml",
+			wantFiles:  []string{"base.yml", "base.staging.yml"},
+			wantLevel:  "warn",
+		},
+		{
+			name: "missing default config file runs from defaults",
+		},
+		{
+			name:       "missing explicit config file is an error",
+			configFile: "missing.yaml",
+			wantErr:    true,
+		},
+		{
+			name:    "malformed overlay is an error",
+			files:   map[string]string{"config.yaml": "env: staging\n", "config.staging.yaml": "log: [\n"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Chdir(dir)
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			bindEnv()
+			useConfigFile(tt.configFile)
+
+			files, err := readConfigFiles(tt.configFile != "")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for i, file := range files {
+				files[i] = filepath.Base(file)
+			}
+			assert.Equal(t, tt.wantFiles, files)
+			assert.Equal(t, tt.wantLevel, viper.GetString("log.level"))
+		})
+	}
+}
+
+func TestOverlayFile(t *testing.T) {
+	assert.Equal(t, "config.production.yaml", overlayFile("config.yaml", "production"))
+	assert.Equal(t, "/etc/app/base.staging.yml", overlayFile("/etc/app/base.yml", "staging"))
+	assert.Equal(t, "settings.test", overlayFile("settings", "test"))
+}
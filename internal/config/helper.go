@@ -1,10 +1,14 @@
 package config
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"os"
+	"path/filepath"
+	"strconv"
 
 	"github.com/anuragthepathak/subscription-management/internal/adapters"
 	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
@@ -16,22 +20,64 @@ import (
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+	"go.mongodb.org/mongo-driver/v2/mongo/writeconcern"
 	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/v2/mongo/otelmongo"
 	"go.opentelemetry.io/otel"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// MongoDB authentication mechanisms accepted in database.auth_mechanism.
+const (
+	AuthMechanismSCRAMSHA1   = "SCRAM-SHA-1"
+	AuthMechanismSCRAMSHA256 = "SCRAM-SHA-256"
+	AuthMechanismPLAIN       = "PLAIN"
+	AuthMechanismX509        = "MONGODB-X509"
+	AuthMechanismAWS         = "MONGODB-AWS"
 )
 
 // DatabaseConnection establishes a connection to the MongoDB database.
 func DatabaseConnection(dbConfig DatabaseConfig, otelEnabled bool) (*adapters.Database, error) {
-	dbClientOpts := options.Client().ApplyURI(
-		lib.BuildMongoURI(
-			dbConfig.Host,
-			dbConfig.Port,
-			dbConfig.Username,
-			dbConfig.Password,
-			dbConfig.Name,
-			dbConfig.AuthSource,
-		),
-	)
+	uri := dbConfig.URI
+	if uri == "" {
+		uri = lib.BuildMongoURI(dbConfig.Host, dbConfig.Port, dbConfig.Name, dbConfig.SRV)
+	}
+	dbClientOpts := options.Client().
+		ApplyURI(uri).
+		SetServerMonitor(adapters.NewTopologyLogger())
+
+	// Credentials configured here take precedence over any in the URI.
+	if dbConfig.Username != "" || dbConfig.AuthMechanism != "" {
+		credential := options.Credential{
+			AuthMechanism: dbConfig.AuthMechanism,
+			AuthSource:    dbConfig.AuthSource,
+			Username:      dbConfig.Username,
+			Password:      dbConfig.Password,
+		}
+		// External mechanisms authenticate against the $external database,
+		// which the driver selects when no source is given.
+		if dbConfig.AuthMechanism == AuthMechanismX509 || dbConfig.AuthMechanism == AuthMechanismAWS {
+			credential.AuthSource = ""
+		}
+		dbClientOpts.SetAuth(credential)
+	}
+
+	if err := applyMongoClientSettings(dbClientOpts, dbConfig); err != nil {
+		return nil, err
+	}
+
+	if dbConfig.TLS.Enabled {
+		tlsConfig, err := lib.LoadClientTLSConfig(
+			dbConfig.TLS.CAFile,
+			dbConfig.TLS.CertFile,
+			dbConfig.TLS.KeyFile,
+			dbConfig.TLS.InsecureSkipVerify,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load MongoDB TLS configuration: %w", err)
+		}
+		dbClientOpts.SetTLSConfig(tlsConfig)
+	}
 
 	if otelEnabled {
 		dbClientOpts.SetMonitor(
@@ -39,6 +85,7 @@ func DatabaseConnection(dbConfig DatabaseConfig, otelEnabled bool) (*adapters.Da
 				otelmongo.WithMeterProvider(otel.GetMeterProvider()),
 			),
 		)
+		dbClientOpts.SetPoolMonitor(adapters.NewPoolMetrics())
 	}
 
 	db := adapters.Database{}
@@ -47,27 +94,192 @@ func DatabaseConnection(dbConfig DatabaseConfig, otelEnabled bool) (*adapters.Da
 		return nil, fmt.Errorf("failed to initialize MongoDB client: %w", err)
 	}
 	db.DB = db.Client.Database(dbConfig.Name)
+	lib.SetOperationTimeouts(lib.OperationTimeouts{
+		Read:  dbConfig.Timeouts.Read,
+		Write: dbConfig.Timeouts.Write,
+		Bulk:  dbConfig.Timeouts.Bulk,
+	})
 
 	slog.Info("Initialized MongoDB client",
 		logattr.Host(dbConfig.Host),
 		logattr.Port(dbConfig.Port),
 		logattr.Database(dbConfig.Name),
+		logattr.TLSEnabled(dbConfig.TLS.Enabled),
+		logattr.AuthMechanism(dbConfig.AuthMechanism),
+		logattr.MaxPoolSize(dbConfig.MaxPoolSize),
+		logattr.ReadPreference(dbConfig.ReadPreference),
+		logattr.WriteConcern(dbConfig.WriteConcern),
 	)
 	return &db, nil
 }
 
+// MongoDB wire compressors accepted in database.compressors.
+var mongoCompressors = []string{"zstd", "snappy", "zlib"}
+
+// applyMongoClientSettings sets the pool, read preference, write concern,
+// server selection, and compression settings that are configured.
+func applyMongoClientSettings(opts *options.ClientOptions, dbConfig DatabaseConfig) error {
+	if dbConfig.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(dbConfig.MaxPoolSize)
+	}
+	if dbConfig.MinPoolSize > 0 {
+		opts.SetMinPoolSize(dbConfig.MinPoolSize)
+	}
+	if dbConfig.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(dbConfig.ServerSelectionTimeout)
+	}
+	if len(dbConfig.Compressors) > 0 {
+		opts.SetCompressors(dbConfig.Compressors)
+	}
+	if dbConfig.ReadPreference != "" {
+		rp, err := mongoReadPreference(dbConfig.ReadPreference)
+		if err != nil {
+			return err
+		}
+		opts.SetReadPreference(rp)
+	}
+	if dbConfig.WriteConcern != "" {
+		wc, err := mongoWriteConcern(dbConfig.WriteConcern)
+		if err != nil {
+			return err
+		}
+		opts.SetWriteConcern(wc)
+	}
+	return nil
+}
+
+// mongoReadPreference parses a read preference mode, e.g. "secondaryPreferred".
+func mongoReadPreference(mode string) (*readpref.ReadPref, error) {
+	m, err := readpref.ModeFromString(mode)
+	if err != nil {
+		return nil, err
+	}
+	return readpref.New(m)
+}
+
+// mongoWriteConcern parses a write concern: "majority" or the number of nodes
+// that must acknowledge a write, where 0 does not wait for any.
+func mongoWriteConcern(w string) (*writeconcern.WriteConcern, error) {
+	if w == writeconcern.WCMajority {
+		return writeconcern.Majority(), nil
+	}
+	n, err := strconv.Atoi(w)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("unknown write concern %q", w)
+	}
+	return &writeconcern.WriteConcern{W: n}, nil
+}
+
+// Redis topologies accepted in redis.mode.
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+)
+
+// redisTarget is a RedisConfig resolved into the settings shared by the
+// Redis client and the task queue.
+type redisTarget struct {
+	addrs     []string // One address in standalone mode.
+	username  string
+	password  string
+	db        int
+	tlsConfig *tls.Config
+}
+
+// resolveRedis parses the URL, if any, and loads the TLS configuration.
+func resolveRedis(redisConfig RedisConfig) (redisTarget, error) {
+	target := redisTarget{
+		username: redisConfig.Username,
+		password: redisConfig.Password,
+		db:       redisConfig.DB,
+	}
+
+	switch {
+	case redisConfig.URL != "":
+		opts, err := redis.ParseURL(redisConfig.URL)
+		if err != nil {
+			return redisTarget{}, fmt.Errorf("failed to parse Redis URL: %w", err)
+		}
+		target.addrs = []string{opts.Addr}
+		target.db = opts.DB
+		target.tlsConfig = opts.TLSConfig // Set for rediss://.
+		if opts.Username != "" {
+			target.username = opts.Username
+		}
+		if opts.Password != "" {
+			target.password = opts.Password
+		}
+	case redisConfig.Mode == RedisModeStandalone:
+		target.addrs = []string{net.JoinHostPort(redisConfig.Host, strconv.Itoa(redisConfig.Port))}
+	default:
+		target.addrs = redisConfig.Addrs
+	}
+
+	if redisConfig.TLS.Enabled {
+		tlsConfig, err := lib.LoadClientTLSConfig(
+			redisConfig.TLS.CAFile,
+			redisConfig.TLS.CertFile,
+			redisConfig.TLS.KeyFile,
+			redisConfig.TLS.InsecureSkipVerify,
+		)
+		if err != nil {
+			return redisTarget{}, fmt.Errorf("failed to load Redis TLS configuration: %w", err)
+		}
+		if target.tlsConfig != nil {
+			tlsConfig.ServerName = target.tlsConfig.ServerName
+		}
+		target.tlsConfig = tlsConfig
+	}
+	return target, nil
+}
+
 // RedisConnection establishes a connection to the Redis database.
 func RedisConnection(
 	redisConfig RedisConfig,
 	otelEnabled bool,
 ) (*adapters.Redis, error) {
-	addr := fmt.Sprintf("%s:%d", redisConfig.Host, redisConfig.Port)
+	target, err := resolveRedis(redisConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// ContextTimeoutEnabled makes commands give up at the caller's deadline
+	// rather than the socket timeouts, so timed-out requests stop waiting.
 	rdb := adapters.Redis{}
-	rdb.Client = redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Password: redisConfig.Password,
-		DB:       redisConfig.DB,
-	})
+	switch redisConfig.Mode {
+	case RedisModeSentinel:
+		rdb.Client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       redisConfig.MasterName,
+			SentinelAddrs:    target.addrs,
+			SentinelPassword: redisConfig.SentinelPassword,
+			Username:         target.username,
+			Password:         target.password,
+			DB:               target.db,
+			TLSConfig:        target.tlsConfig,
+
+			ContextTimeoutEnabled: true,
+		})
+	case RedisModeCluster:
+		rdb.Client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     target.addrs,
+			Username:  target.username,
+			Password:  target.password,
+			TLSConfig: target.tlsConfig,
+
+			ContextTimeoutEnabled: true,
+		})
+	default:
+		rdb.Client = redis.NewClient(&redis.Options{
+			Addr:      target.addrs[0],
+			Username:  target.username,
+			Password:  target.password,
+			DB:        target.db,
+			TLSConfig: target.tlsConfig,
+
+			ContextTimeoutEnabled: true,
+		})
+	}
 
 	if otelEnabled {
 		if err := redisotel.InstrumentTracing(rdb.Client); err != nil {
@@ -79,68 +291,93 @@ func RedisConnection(
 	}
 
 	slog.Info("Initialized Redis client",
-		logattr.Host(redisConfig.Host),
-		logattr.Port(redisConfig.Port),
-		logattr.RedisDB(redisConfig.DB),
+		logattr.RedisMode(redisConfig.Mode),
+		logattr.Addrs(target.addrs),
+		logattr.RedisDB(target.db),
+		logattr.TLSEnabled(target.tlsConfig != nil),
 	)
 	return &rdb, nil
 }
 
-// SetupLogger configures the global logger based on the environment.
-// The handler is wrapped with trace correlation so that any log call
-// using slog.InfoContext (or similar) with a traced context automatically
-// includes trace_id and span_id fields.
+// Log formats and outputs accepted in the log config.
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+
+	LogOutputStderr = "stderr"
+	LogOutputFile   = "file"
+	LogOutputBoth   = "both"
+)
+
+// SetupLogger configures the global logger from logConfig. Settings left
+// empty default by environment:
 //
-// When OTel is enabled, logs are written as JSON to both stderr and
-// ./logs/app.log (for Promtail to tail and ship to Loki).
-func SetupLogger(env string, otelEnabled bool) error {
-	programLevel := new(slog.LevelVar)
-	if env == "production" {
-		programLevel.Set(slog.LevelInfo)
-	} else {
-		programLevel.Set(slog.LevelDebug)
+//   - level: info in production, debug elsewhere.
+//   - format: json in production or when OTel is enabled, text elsewhere.
+//   - output: both when OTel is enabled (Promtail tails the log file and
+//     ships it to Loki), stderr otherwise.
+//
+// The file output is rotated by size, with old files pruned by count and age.
+// The returned LevelVar is shared by every handler, so changing it adjusts
+// the level at runtime.
+// The handler is wrapped with trace correlation so that any log call using
+// slog.InfoContext (or similar) with a traced context automatically includes
+// trace_id and span_id fields.
+func SetupLogger(logConfig LogConfig, env string, otelEnabled bool) (*slog.LevelVar, error) {
+	level, err := logConfig.EffectiveLevel(env)
+	if err != nil {
+		return nil, err
 	}
+	programLevel := new(slog.LevelVar)
+	programLevel.Set(level)
 
-	var handler slog.Handler
-	if otelEnabled {
-		// When OTel is enabled, always use JSON for structured log ingestion.
-		// Promtail tails ./logs/app.log and requires JSON for trace_id extraction.
-		writers := []io.Writer{os.Stderr}
+	format := logConfig.Format
+	if format == "" {
+		format = LogFormatText
+		if otelEnabled || env == EnvProduction {
+			// Promtail requires JSON for trace_id extraction.
+			format = LogFormatJSON
+		}
+	}
 
-		if err := os.MkdirAll("logs", 0o755); err != nil {
-			return fmt.Errorf("failed to create logs directory: %w", err)
+	output := logConfig.Output
+	if output == "" {
+		output = LogOutputStderr
+		if otelEnabled {
+			output = LogOutputBoth
 		}
-		logFile, err := os.OpenFile("logs/app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
-		if err != nil {
-			return fmt.Errorf("failed to open log file named app.log: %w", err)
+	}
+
+	var writers []io.Writer
+	if output == LogOutputStderr || output == LogOutputBoth {
+		writers = append(writers, os.Stderr)
+	}
+	if output == LogOutputFile || output == LogOutputBoth {
+		fileConfig := logConfig.File
+		if err := os.MkdirAll(filepath.Dir(fileConfig.Path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create log directory: %w", err)
 		}
-		writers = append(writers, logFile)
-
-		handler = slog.NewJSONHandler(
-			io.MultiWriter(writers...),
-			&slog.HandlerOptions{
-				Level:     programLevel,
-				AddSource: true,
-			},
-		)
-	} else if env == "production" {
-		handler = slog.NewJSONHandler(
-			os.Stderr,
-			&slog.HandlerOptions{
-				Level:     programLevel,
-				AddSource: true,
-			},
-		)
+		writers = append(writers, &lumberjack.Logger{
+			Filename:   fileConfig.Path,
+			MaxSize:    fileConfig.MaxSizeMB,
+			MaxBackups: fileConfig.MaxBackups,
+			MaxAge:     fileConfig.MaxAgeDays,
+			Compress:   fileConfig.Compress,
+		})
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level:     programLevel,
+		AddSource: true,
+	}
+	var handler slog.Handler
+	if format == LogFormatJSON {
+		handler = slog.NewJSONHandler(io.MultiWriter(writers...), handlerOpts)
 	} else {
-		handler = slog.NewTextHandler(
-			os.Stderr,
-			&slog.HandlerOptions{
-				Level:     programLevel,
-				AddSource: true,
-			},
-		)
+		handler = slog.NewTextHandler(io.MultiWriter(writers...), handlerOpts)
 	}
 
+	handler = observability.NewSamplingHandler(handler, logConfig.Sampling.DebugEvery)
 	// Wrap with trace correlation — adds trace_id/span_id when an OTel span is active.
 	handler = observability.NewTraceHandler(handler)
 
@@ -148,8 +385,33 @@ func SetupLogger(env string, otelEnabled bool) error {
 	slog.Info("Logger initialized",
 		logattr.Env(env),
 		logattr.OtelEnabled(otelEnabled),
+		logattr.LogLevel(programLevel.Level().String()),
+		logattr.LogFormat(format),
+		logattr.LogOutput(output),
 	)
-	return nil
+	return programLevel, nil
+}
+
+// EffectiveLevel returns the configured level, or the default for env when
+// none is set: info in production, debug elsewhere.
+func (c LogConfig) EffectiveLevel(env string) (slog.Level, error) {
+	switch {
+	case c.Level != "":
+		return parseLogLevel(c.Level)
+	case env == EnvProduction:
+		return slog.LevelInfo, nil
+	default:
+		return slog.LevelDebug, nil
+	}
+}
+
+// parseLogLevel parses a level name such as "debug" or "WARN".
+func parseLogLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q: %w", name, err)
+	}
+	return level, nil
 }
 
 // NewRateLimit creates a rate limiter configuration.
@@ -165,11 +427,49 @@ func NewRateLimit(rateConfig RateLimiterConfig) redis_rate.Limit {
 	}
 }
 
-// QueueRedisConfig returns Redis configuration for the task queue.
-func QueueRedisConfig(redisConfig RedisConfig) asynq.RedisConnOpt {
-	return asynq.RedisClientOpt{
-		Addr:     fmt.Sprintf("%s:%d", redisConfig.Host, redisConfig.Port),
-		Password: redisConfig.Password,
-		DB:       redisConfig.DB,
+// NewRateLimits creates a rate limiter configuration for each route group.
+func NewRateLimits(groups map[string]RateLimiterConfig) map[string]redis_rate.Limit {
+	limits := make(map[string]redis_rate.Limit, len(groups))
+	for name, rateConfig := range groups {
+		limits[name] = NewRateLimit(rateConfig)
+	}
+	return limits
+}
+
+// QueueRedisConfig returns Redis configuration for the task queue. It
+// connects to the same topology, with the same credentials and TLS settings,
+// as RedisConnection.
+func QueueRedisConfig(redisConfig RedisConfig) (asynq.RedisConnOpt, error) {
+	target, err := resolveRedis(redisConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	switch redisConfig.Mode {
+	case RedisModeSentinel:
+		return asynq.RedisFailoverClientOpt{
+			MasterName:       redisConfig.MasterName,
+			SentinelAddrs:    target.addrs,
+			SentinelPassword: redisConfig.SentinelPassword,
+			Username:         target.username,
+			Password:         target.password,
+			DB:               target.db,
+			TLSConfig:        target.tlsConfig,
+		}, nil
+	case RedisModeCluster:
+		return asynq.RedisClusterClientOpt{
+			Addrs:     target.addrs,
+			Username:  target.username,
+			Password:  target.password,
+			TLSConfig: target.tlsConfig,
+		}, nil
+	default:
+		return asynq.RedisClientOpt{
+			Addr:      target.addrs[0],
+			Username:  target.username,
+			Password:  target.password,
+			DB:        target.db,
+			TLSConfig: target.tlsConfig,
+		}, nil
 	}
 }
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+)
+
+func TestQueueRedisConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RedisConfig
+		want    asynq.RedisConnOpt
+		wantTLS bool
+		wantErr bool
+	}{
+		{
+			name: "standalone from host and port",
+			cfg:  RedisConfig{Mode: RedisModeStandalone, Host: "localhost", Port: 6379, Username: "app", Password: "pw", DB: 2},
+			want: asynq.RedisClientOpt{Addr: "localhost:6379", Username: "app", Password: "pw", DB: 2},
+		},
+		{
+			name:    "rediss URL enables TLS and carries credentials",
+			cfg:     RedisConfig{Mode: RedisModeStandalone, URL: "rediss://app:pw@cache.example.com:6380/1"},
+			want:    asynq.RedisClientOpt{Addr: "cache.example.com:6380", Username: "app", Password: "pw", DB: 1},
+			wantTLS: true,
+		},
+		{
+			name: "URL without password falls back to the password field",
+			cfg:  RedisConfig{Mode: RedisModeStandalone, URL: "redis://cache:6379/0", Password: "from-secrets"},
+			want: asynq.RedisClientOpt{Addr: "cache:6379", Password: "from-secrets"},
+		},
+		{
+			name: "sentinel",
+			cfg: RedisConfig{
+				Mode:             RedisModeSentinel,
+				Addrs:            []string{"s1:26379", "s2:26379"},
+				MasterName:       "mymaster",
+				SentinelPassword: "spw",
+				Password:         "pw",
+			},
+			want: asynq.RedisFailoverClientOpt{
+				MasterName:       "mymaster",
+				SentinelAddrs:    []string{"s1:26379", "s2:26379"},
+				SentinelPassword: "spw",
+				Password:         "pw",
+			},
+		},
+		{
+			name: "cluster",
+			cfg:  RedisConfig{Mode: RedisModeCluster, Addrs: []string{"n1:6379", "n2:6379"}, Username: "app"},
+			want: asynq.RedisClusterClientOpt{Addrs: []string{"n1:6379", "n2:6379"}, Username: "app"},
+		},
+		{
+			name:    "error - invalid URL",
+			cfg:     RedisConfig{Mode: RedisModeStandalone, URL: "http://cache:6379"},
+			wantErr: true,
+		},
+		{
+			name:    "error - unreadable CA file",
+			cfg:     RedisConfig{Mode: RedisModeStandalone, Host: "cache", Port: 6379, TLS: ClientTLSConfig{Enabled: true, CAFile: "missing.pem"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QueueRedisConfig(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			// Compare everything except the TLS config, which is checked by presence.
+			switch opt := got.(type) {
+			case asynq.RedisClientOpt:
+				assert.Equal(t, tt.wantTLS, opt.TLSConfig != nil)
+				opt.TLSConfig = nil
+				got = opt
+			case asynq.RedisFailoverClientOpt:
+				assert.Equal(t, tt.wantTLS, opt.TLSConfig != nil)
+			case asynq.RedisClusterClientOpt:
+				assert.Equal(t, tt.wantTLS, opt.TLSConfig != nil)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyMongoClientSettings(t *testing.T) {
+	t.Run("applies configured settings", func(t *testing.T) {
+		opts := options.Client()
+		err := applyMongoClientSettings(opts, DatabaseConfig{
+			MaxPoolSize:            50,
+			MinPoolSize:            5,
+			ReadPreference:         "secondaryPreferred",
+			WriteConcern:           "2",
+			ServerSelectionTimeout: 5 * time.Second,
+			Compressors:            []string{"zstd", "snappy"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(50), *opts.MaxPoolSize)
+		assert.Equal(t, uint64(5), *opts.MinPoolSize)
+		assert.Equal(t, readpref.SecondaryPreferredMode, opts.ReadPreference.Mode())
+		assert.Equal(t, 2, opts.WriteConcern.W)
+		assert.Equal(t, 5*time.Second, *opts.ServerSelectionTimeout)
+		assert.Equal(t, []string{"zstd", "snappy"}, opts.Compressors)
+	})
+
+	t.Run("leaves unset settings to the URI", func(t *testing.T) {
+		opts := options.Client().ApplyURI("mongodb://localhost/?maxPoolSize=7&w=majority")
+
+		require.NoError(t, applyMongoClientSettings(opts, DatabaseConfig{}))
+		assert.Equal(t, uint64(7), *opts.MaxPoolSize)
+		assert.Equal(t, "majority", opts.WriteConcern.W)
+		assert.Nil(t, opts.ReadPreference)
+	})
+
+	t.Run("rejects an unknown write concern", func(t *testing.T) {
+		err := applyMongoClientSettings(options.Client(), DatabaseConfig{WriteConcern: "all"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSetupLogger_FileOutput(t *testing.T) {
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	path := filepath.Join(t.TempDir(), "nested", "app.log")
+	_, err := SetupLogger(LogConfig{
+		Level:  "warn",
+		Format: LogFormatJSON,
+		Output: LogOutputFile,
+		File:   LogFileConfig{Path: path, MaxSizeMB: 1},
+	}, "development", false)
+	require.NoError(t, err)
+
+	slog.Info("dropped below the configured level")
+	slog.Warn("kept")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 1, "only the warning should be written")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "kept", record["msg"])
+}
+
+func TestSetupLogger_InvalidLevel(t *testing.T) {
+	_, err := SetupLogger(LogConfig{Level: "verbose"}, "development", false)
+	assert.Error(t, err)
+}
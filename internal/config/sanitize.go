@@ -0,0 +1,71 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// maskedValue replaces secret values in the sanitized configuration.
+const maskedValue = "********"
+
+// Sanitized returns the effective configuration keyed like the config file,
+// with every secret masked. Empty secrets stay empty so a missing value is
+// still visible. Durations are rendered as strings (e.g. "15m0s").
+func (c Config) Sanitized() map[string]any {
+	masked := c
+	for _, field := range secretFields {
+		if target := field(&masked); *target != "" {
+			*target = maskedValue
+		}
+	}
+	if masked.Secrets.Vault.Token != "" {
+		masked.Secrets.Vault.Token = maskedValue
+	}
+
+	return structToMap(reflect.ValueOf(masked))
+}
+
+// structToMap converts a config struct into a map using its mapstructure
+// tags. Fields without a tag are not read from configuration and are skipped.
+func structToMap(v reflect.Value) map[string]any {
+	out := make(map[string]any, v.NumField())
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		out[name] = sanitizedValue(v.Field(i))
+	}
+	return out
+}
+
+func sanitizedValue(v reflect.Value) any {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return structToMap(v)
+	case reflect.Slice, reflect.Array:
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = sanitizedValue(v.Index(i))
+		}
+		return items
+	case reflect.Map:
+		items := make(map[string]any, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			items[iter.Key().String()] = sanitizedValue(iter.Value())
+		}
+		return items
+	default:
+		return v.Interface()
+	}
+}
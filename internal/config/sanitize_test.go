@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Sanitized(t *testing.T) {
+	var cf Config
+	cf.Server.Port = 8080
+	cf.Server.RequestTimeout = 10 * time.Second
+	cf.Database.Password = "db-pass"
+	cf.JWT.AccessSecret = "access"
+	cf.Secrets.Vault.Token = "vault-token"
+	cf.Scheduler.ReminderDays = []int{1, 3}
+	cf.RateLimiter.Groups = map[string]RateLimiterConfig{"bills": {Rate: 10, Period: time.Minute}}
+
+	got := cf.Sanitized()
+
+	server := got["server"].(map[string]any)
+	assert.Equal(t, 8080, server["port"])
+	assert.Equal(t, "10s", server["request_timeout"])
+
+	assert.Equal(t, maskedValue, got["database"].(map[string]any)["password"])
+	assert.Equal(t, maskedValue, got["jwt"].(map[string]any)["access_secret"])
+	assert.Equal(t, "", got["jwt"].(map[string]any)["refresh_secret"], "empty secrets stay visible")
+	vault := got["secrets"].(map[string]any)["vault"].(map[string]any)
+	assert.Equal(t, maskedValue, vault["token"])
+	assert.Equal(t, []any{1, 3}, got["scheduler"].(map[string]any)["reminder_days"])
+	groups := got["rate_limiter"].(map[string]any)["groups"].(map[string]any)
+	assert.Equal(t, map[string]any{"rate": 10, "burst": 0, "period": "1m0s"}, groups["bills"])
+
+	// The original configuration is left untouched.
+	assert.Equal(t, "db-pass", cf.Database.Password)
+
+	// Fields not read from configuration are omitted.
+	assert.NotContains(t, got["otel"], "Environment")
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	vault "github.com/hashicorp/vault/api"
+)
+
+// Schemes of secret references. A secret setting may hold a reference
+// instead of its value:
+//
+//	vault:<mount>/<path>#<key>  a key of a KV v2 secret, e.g. vault:secret/jwt#access
+//	aws:<secret id>[#<key>]     a Secrets Manager secret, or a key of its JSON object
+//	file:<path>                 the contents of a file, e.g. a Docker secret
+const (
+	secretRefVault = "vault"
+	secretRefAWS   = "aws"
+	secretRefFile  = "file"
+)
+
+// referenceProviderName identifies the references in logs.
+const referenceProviderName = "references"
+
+// secretRef is a parsed secret reference.
+type secretRef struct {
+	scheme   string
+	location string // Vault mount and path, AWS secret ID, or file path.
+	key      string // Key within the secret; empty for the whole value.
+}
+
+// parseSecretRef parses value as a secret reference. It reports false for
+// values that are not one, and an error for malformed references.
+func parseSecretRef(value string) (secretRef, bool, error) {
+	scheme, rest, found := strings.Cut(value, ":")
+	if !found {
+		return secretRef{}, false, nil
+	}
+	location, key, _ := strings.Cut(rest, "#")
+	ref := secretRef{scheme: scheme, location: location, key: key}
+
+	switch scheme {
+	case secretRefVault:
+		mount, path, _ := strings.Cut(location, "/")
+		if mount == "" || path == "" || key == "" {
+			return ref, true, errors.New("must look like vault:<mount>/<path>#<key>")
+		}
+	case secretRefAWS:
+		if location == "" {
+			return ref, true, errors.New("must look like aws:<secret id>#<key>")
+		}
+	case secretRefFile:
+		// Paths may contain #.
+		ref.location, ref.key = rest, ""
+		if rest == "" {
+			return ref, true, errors.New("must look like file:<path>")
+		}
+	default:
+		return secretRef{}, false, nil
+	}
+	return ref, true, nil
+}
+
+// collectSecretRefs records the secret references in the secret fields of
+// c in c.SecretRefs. Resolving them replaces the field values.
+func (c *Config) collectSecretRefs() error {
+	var f fieldChecker
+	refs := make(map[string]string)
+	for key, field := range secretFields {
+		value := *field(c)
+		_, ok, err := parseSecretRef(value)
+		if err != nil {
+			f.add(key, "secret reference "+err.Error())
+			continue
+		}
+		if ok {
+			refs[key] = value
+		}
+	}
+	if err := f.err(); err != nil {
+		return err
+	}
+	c.SecretRefs = nil
+	if len(refs) > 0 {
+		c.SecretRefs = refs
+	}
+	return nil
+}
+
+// referenceProvider resolves secret references, keyed by the config key
+// holding them. Each secret is read once per fetch, however many keys
+// reference it.
+type referenceProvider struct {
+	refs  map[string]secretRef
+	vault *vault.Client          // Nil without vault references.
+	aws   *secretsmanager.Client // Nil without aws references.
+}
+
+func newReferenceProvider(ctx context.Context, cfg SecretsConfig, refs map[string]string) (*referenceProvider, error) {
+	p := &referenceProvider{refs: make(map[string]secretRef, len(refs))}
+	for key, value := range refs {
+		ref, _, err := parseSecretRef(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: secret reference %w", key, err)
+		}
+		p.refs[key] = ref
+
+		if ref.scheme == secretRefVault && p.vault == nil {
+			if p.vault, err = newVaultClient(cfg); err != nil {
+				return nil, err
+			}
+		}
+		if ref.scheme == secretRefAWS && p.aws == nil {
+			if p.aws, err = newAWSClient(ctx, cfg); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p, nil
+}
+
+func (p *referenceProvider) Name() string { return referenceProviderName }
+
+func (p *referenceProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	vaultSecrets := make(map[string]map[string]any)
+	awsSecrets := make(map[string]string)
+
+	values := make(map[string]string, len(p.refs))
+	for key, ref := range p.refs {
+		var value string
+		var err error
+		switch ref.scheme {
+		case secretRefVault:
+			value, err = p.fetchVault(ctx, ref, vaultSecrets)
+		case secretRefAWS:
+			value, err = p.fetchAWS(ctx, ref, awsSecrets)
+		case secretRefFile:
+			value, err = readSecretFile(ref.location)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", key, err)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+func (p *referenceProvider) fetchVault(ctx context.Context, ref secretRef, cache map[string]map[string]any) (string, error) {
+	data, ok := cache[ref.location]
+	if !ok {
+		mount, path, _ := strings.Cut(ref.location, "/")
+		secret, err := p.vault.KVv2(mount).Get(ctx, path)
+		if err != nil {
+			return "", err
+		}
+		data = secret.Data
+		cache[ref.location] = data
+	}
+	value, ok := data[ref.key].(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no string key %q", ref.location, ref.key)
+	}
+	return value, nil
+}
+
+func (p *referenceProvider) fetchAWS(ctx context.Context, ref secretRef, cache map[string]string) (string, error) {
+	raw, ok := cache[ref.location]
+	if !ok {
+		out, err := p.aws.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: &ref.location,
+		})
+		if err != nil {
+			return "", err
+		}
+		if out.SecretString == nil {
+			return "", fmt.Errorf("secret %q has no string value", ref.location)
+		}
+		raw = *out.SecretString
+		cache[ref.location] = raw
+	}
+	if ref.key == "" {
+		return raw, nil
+	}
+	values, err := decodeSecretDocument([]byte(raw))
+	if err != nil {
+		return "", err
+	}
+	value, ok := values[ref.key]
+	if !ok {
+		return "", fmt.Errorf("secret %q has no key %q", ref.location, ref.key)
+	}
+	return value, nil
+}
+
+func (p *referenceProvider) Close() error { return nil }
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecretRef(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    secretRef
+		wantRef bool
+		wantErr bool
+	}{
+		{value: "plain-secret"},
+		{value: "mongodb://user:pass@db:27017"},
+		{value: "vault:secret/jwt#access", want: secretRef{"vault", "secret/jwt", "access"}, wantRef: true},
+		{value: "vault:kv/app/smtp#password", want: secretRef{"vault", "kv/app/smtp", "password"}, wantRef: true},
+		{value: "aws:prod/app#jwt_access", want: secretRef{"aws", "prod/app", "jwt_access"}, wantRef: true},
+		{value: "aws:arn:aws:secretsmanager:eu-west-1:123:secret:smtp", want: secretRef{"aws", "arn:aws:secretsmanager:eu-west-1:123:secret:smtp", ""}, wantRef: true},
+		{value: "file:/run/secrets/jwt#1", want: secretRef{"file", "/run/secrets/jwt#1", ""}, wantRef: true},
+		{value: "vault:secret/jwt", wantRef: true, wantErr: true},
+		{value: "vault:jwt#access", wantRef: true, wantErr: true},
+		{value: "aws:#key", wantRef: true, wantErr: true},
+		{value: "file:", wantRef: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			ref, ok, err := parseSecretRef(tt.value)
+
+			assert.Equal(t, tt.wantRef, ok)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if ok {
+				assert.Equal(t, tt.want, ref)
+			}
+		})
+	}
+}
+
+func TestCollectSecretRefs(t *testing.T) {
+	var cf Config
+	cf.JWT.AccessSecret = "vault:secret/jwt#access"
+	cf.JWT.RefreshSecret = "plain"
+	cf.Email.SMTPPassword = "file:/run/secrets/smtp"
+
+	require.NoError(t, cf.collectSecretRefs())
+	assert.Equal(t, map[string]string{
+		"jwt.access_secret":   "vault:secret/jwt#access",
+		"email.smtp_password": "file:/run/secrets/smtp",
+	}, cf.SecretRefs)
+
+	cf.Database.Password = "vault:secret"
+	err := cf.collectSecretRefs()
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "database.password", verr.Fields[0].Field)
+}
+
+func TestLoadSecrets_FileReferences(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jwt_access")
+	require.NoError(t, os.WriteFile(path, []byte("access-from-file\n"), 0o600))
+
+	var cf Config
+	cf.JWT.AccessSecret = "file:" + path
+	cf.JWT.RefreshSecret = "plain"
+	cf.Secrets.Timeout = time.Second
+
+	require.NoError(t, cf.loadSecrets(context.Background()))
+	assert.Equal(t, "access-from-file", cf.JWT.AccessSecret)
+	assert.Equal(t, "plain", cf.JWT.RefreshSecret)
+
+	// A refresh reads the rotated file.
+	require.NoError(t, os.WriteFile(path, []byte("rotated"), 0o600))
+	provider, err := NewConfigSecretsProvider(context.Background(), cf)
+	require.NoError(t, err)
+	watched := cf
+	changed := watched.applySecrets(mustFetch(t, provider))
+	assert.Equal(t, []string{"jwt.access_secret"}, changed)
+	assert.Equal(t, "rotated", watched.JWT.AccessSecret)
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "jwt.access_secret"), []byte("access\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("skipped"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..data"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..data", "email.smtp_password"), []byte("smtp"), 0o600))
+	// Kubernetes mounts each key as a link into ..data.
+	require.NoError(t, os.Symlink(filepath.Join("..data", "email.smtp_password"), filepath.Join(dir, "email.smtp_password")))
+
+	var cfg SecretsConfig
+	cfg.Provider = SecretsProviderFile
+	cfg.File.Dir = dir
+	provider, err := NewSecretsProvider(context.Background(), cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"jwt.access_secret":   "access",
+		"email.smtp_password": "smtp",
+	}, mustFetch(t, provider))
+}
+
+func TestNewConfigSecretsProvider_StoreWins(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "jwt.access_secret"), []byte("from-store"), 0o600))
+	ref := filepath.Join(t.TempDir(), "access")
+	require.NoError(t, os.WriteFile(ref, []byte("from-reference"), 0o600))
+
+	var cf Config
+	cf.Secrets.Provider = SecretsProviderFile
+	cf.Secrets.File.Dir = dir
+	cf.SecretRefs = map[string]string{"jwt.access_secret": "file:" + ref}
+
+	provider, err := NewConfigSecretsProvider(context.Background(), cf)
+	require.NoError(t, err)
+	defer provider.Close()
+
+	assert.Equal(t, "references+file", provider.Name())
+	assert.Equal(t, map[string]string{"jwt.access_secret": "from-store"}, mustFetch(t, provider))
+}
+
+func mustFetch(t *testing.T, provider SecretsProvider) map[string]string {
+	t.Helper()
+
+	values, err := provider.Fetch(context.Background())
+	require.NoError(t, err)
+	return values
+}
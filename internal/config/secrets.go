@@ -0,0 +1,527 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	vault "github.com/hashicorp/vault/api"
+)
+
+// Supported secrets providers.
+const (
+	SecretsProviderVault = "vault"
+	SecretsProviderAWS   = "aws"
+	SecretsProviderGCP   = "gcp"
+	SecretsProviderFile  = "file"
+)
+
+// SecretsConfig selects an external secrets store. The store holds a single
+// document mapping config keys (e.g. "jwt.access_secret") to their values.
+type SecretsConfig struct {
+	Provider        string        `mapstructure:"provider"`         // One of vault, aws, gcp, file; empty disables.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"` // How often to re-fetch secrets; 0 disables.
+	Timeout         time.Duration `mapstructure:"timeout"`          // Per-fetch timeout.
+
+	Vault struct {
+		Address string `mapstructure:"address"` // Defaults to VAULT_ADDR.
+		Token   string `mapstructure:"token"`   // Defaults to VAULT_TOKEN.
+		Mount   string `mapstructure:"mount"`   // KV v2 mount path.
+		Path    string `mapstructure:"path"`
+	} `mapstructure:"vault"`
+
+	AWS struct {
+		Region   string `mapstructure:"region"` // Defaults to the SDK's region chain.
+		SecretID string `mapstructure:"secret_id"`
+	} `mapstructure:"aws"`
+
+	GCP struct {
+		Project string `mapstructure:"project"`
+		Secret  string `mapstructure:"secret"`
+		Version string `mapstructure:"version"`
+	} `mapstructure:"gcp"`
+
+	File struct {
+		Dir string `mapstructure:"dir"` // Mounted secrets, one file per config key.
+	} `mapstructure:"file"`
+}
+
+// check reports missing or invalid secrets settings to f.
+func (s SecretsConfig) check(f *fieldChecker) {
+	switch s.Provider {
+	case "":
+	case SecretsProviderVault:
+		f.required("secrets.vault.path", s.Vault.Path)
+	case SecretsProviderAWS:
+		f.required("secrets.aws.secret_id", s.AWS.SecretID)
+	case SecretsProviderGCP:
+		f.required("secrets.gcp.project", s.GCP.Project)
+		f.required("secrets.gcp.secret", s.GCP.Secret)
+	case SecretsProviderFile:
+		f.required("secrets.file.dir", s.File.Dir)
+	default:
+		f.oneOf("secrets.provider", s.Provider, SecretsProviderVault, SecretsProviderAWS, SecretsProviderGCP, SecretsProviderFile)
+	}
+	if s.RefreshInterval < 0 {
+		f.add("secrets.refresh_interval", "must be 0 or greater")
+	}
+	f.positiveDuration("secrets.timeout", s.Timeout)
+}
+
+// secretFields lists the config keys that may be supplied by a secrets
+// provider and where each one lives in Config.
+var secretFields = map[string]func(*Config) *string{
+	"database.uri":                           func(c *Config) *string { return &c.Database.URI },
+	"database.username":                      func(c *Config) *string { return &c.Database.Username },
+	"database.password":                      func(c *Config) *string { return &c.Database.Password },
+	"redis.url":                              func(c *Config) *string { return &c.Redis.URL },
+	"redis.password":                         func(c *Config) *string { return &c.Redis.Password },
+	"redis.sentinel_password":                func(c *Config) *string { return &c.Redis.SentinelPassword },
+	"jwt.access_secret":                      func(c *Config) *string { return &c.JWT.AccessSecret },
+	"jwt.refresh_secret":                     func(c *Config) *string { return &c.JWT.RefreshSecret },
+	"email.smtp_username":                    func(c *Config) *string { return &c.Email.SMTPUsername },
+	"email.smtp_password":                    func(c *Config) *string { return &c.Email.SMTPPassword },
+	"files.signing_secret":                   func(c *Config) *string { return &c.Files.SigningSecret },
+	"calendar.signing_secret":                func(c *Config) *string { return &c.Calendar.SigningSecret },
+	"cancel_links.signing_secret":            func(c *Config) *string { return &c.CancelLinks.SigningSecret },
+	"unsubscribe.signing_secret":             func(c *Config) *string { return &c.Unsubscribe.SigningSecret },
+	"payments.api_key":                       func(c *Config) *string { return &c.Payments.APIKey },
+	"notifications.sms.auth_token":           func(c *Config) *string { return &c.Notifications.SMS.AuthToken },
+	"notifications.push.vapid_private_key":   func(c *Config) *string { return &c.Notifications.Push.VAPIDPrivateKey },
+	"notifications.alerts.slack_webhook_url": func(c *Config) *string { return &c.Notifications.Alerts.SlackWebhookURL },
+}
+
+// SecretsProvider fetches secret values from an external store.
+type SecretsProvider interface {
+	// Name identifies the provider in logs.
+	Name() string
+	// Fetch returns the current secret values keyed by config key.
+	Fetch(ctx context.Context) (map[string]string, error)
+	Close() error
+}
+
+// NewConfigSecretsProvider creates a provider for every secret of cfg: the
+// secret references in its values and the store selected in cfg.Secrets.
+// Values from the store take precedence. It returns nil when cfg has
+// neither.
+func NewConfigSecretsProvider(ctx context.Context, cfg Config) (SecretsProvider, error) {
+	var providers secretsProviders
+	if len(cfg.SecretRefs) > 0 {
+		refs, err := newReferenceProvider(ctx, cfg.Secrets, cfg.SecretRefs)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, refs)
+	}
+	store, err := NewSecretsProvider(ctx, cfg.Secrets)
+	if err != nil {
+		providers.Close()
+		return nil, err
+	}
+	if store != nil {
+		providers = append(providers, store)
+	}
+
+	switch len(providers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return providers[0], nil
+	default:
+		return providers, nil
+	}
+}
+
+// NewSecretsProvider creates the provider selected by cfg. It returns nil
+// when no provider is configured.
+func NewSecretsProvider(ctx context.Context, cfg SecretsConfig) (SecretsProvider, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case SecretsProviderVault:
+		return newVaultProvider(cfg)
+	case SecretsProviderAWS:
+		return newAWSProvider(ctx, cfg)
+	case SecretsProviderGCP:
+		return newGCPProvider(ctx, cfg)
+	case SecretsProviderFile:
+		return &fileProvider{dir: cfg.File.Dir}, nil
+	default:
+		return nil, fmt.Errorf("unknown secrets provider %q", cfg.Provider)
+	}
+}
+
+// applySecrets overwrites the secret fields of c with values. Unknown keys
+// are ignored. It returns the keys whose value changed, sorted.
+func (c *Config) applySecrets(values map[string]string) []string {
+	var changed []string
+	for key, value := range values {
+		field, ok := secretFields[key]
+		if !ok {
+			slog.Warn("Ignoring unsupported secret key", logattr.Key(key))
+			continue
+		}
+		if target := field(c); *target != value {
+			*target = value
+			changed = append(changed, key)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// loadSecrets fetches secrets once and applies them to c. The secrets
+// settings are checked first so a misconfigured provider is reported
+// clearly rather than as a fetch failure.
+func (c *Config) loadSecrets(ctx context.Context) error {
+	if err := c.collectSecretRefs(); err != nil {
+		return err
+	}
+	if c.Secrets.Provider == "" && len(c.SecretRefs) == 0 {
+		return nil
+	}
+	var f fieldChecker
+	c.Secrets.check(&f)
+	if err := f.err(); err != nil {
+		return err
+	}
+
+	provider, err := NewConfigSecretsProvider(ctx, *c)
+	if err != nil || provider == nil {
+		return err
+	}
+	defer provider.Close()
+
+	values, err := fetchSecrets(ctx, provider, c.Secrets.Timeout)
+	if err != nil {
+		return err
+	}
+	c.applySecrets(values)
+
+	slog.Info("Secrets loaded",
+		logattr.SecretsProvider(provider.Name()),
+		logattr.SecretKeys(slices.Sorted(maps.Keys(values))),
+	)
+	return nil
+}
+
+func fetchSecrets(ctx context.Context, provider SecretsProvider, timeout time.Duration) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	values, err := provider.Fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch secrets from %s: %w", provider.Name(), err)
+	}
+	return values, nil
+}
+
+// SecretsWatcher periodically re-fetches secrets and notifies subscribers
+// of changes, so rotated credentials take effect without a restart.
+type SecretsWatcher struct {
+	provider SecretsProvider
+	interval time.Duration
+	timeout  time.Duration
+	config   Config
+	handlers []func(cfg Config, changed []string)
+}
+
+// NewSecretsWatcher creates a watcher that starts from a copy of cfg.
+func NewSecretsWatcher(provider SecretsProvider, cfg Config) *SecretsWatcher {
+	return &SecretsWatcher{
+		provider: provider,
+		interval: cfg.Secrets.RefreshInterval,
+		timeout:  cfg.Secrets.Timeout,
+		config:   cfg,
+	}
+}
+
+// OnChange registers fn to be called with the updated config and the
+// changed keys whenever a refresh changes any secret. Register handlers
+// before calling Start.
+func (w *SecretsWatcher) OnChange(fn func(cfg Config, changed []string)) {
+	w.handlers = append(w.handlers, fn)
+}
+
+// Start refreshes secrets every interval until ctx is canceled, then closes
+// the provider.
+func (w *SecretsWatcher) Start(ctx context.Context) error {
+	defer w.provider.Close()
+
+	slog.InfoContext(ctx, "Secrets watcher started",
+		logattr.SecretsProvider(w.provider.Name()),
+		logattr.Interval(w.interval),
+	)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.refresh(ctx)
+		}
+	}
+}
+
+func (w *SecretsWatcher) refresh(ctx context.Context) {
+	values, err := fetchSecrets(ctx, w.provider, w.timeout)
+	if err != nil {
+		// Keep serving with the last known values.
+		slog.ErrorContext(ctx, "Failed to refresh secrets",
+			logattr.SecretsProvider(w.provider.Name()),
+			logattr.Error(err),
+		)
+		return
+	}
+
+	changed := w.config.applySecrets(values)
+	if len(changed) == 0 {
+		return
+	}
+	slog.InfoContext(ctx, "Secrets rotated",
+		logattr.SecretsProvider(w.provider.Name()),
+		logattr.SecretKeys(changed),
+	)
+	for _, handler := range w.handlers {
+		handler(w.config, changed)
+	}
+}
+
+// decodeSecretDocument parses a JSON object of config keys to string values.
+func decodeSecretDocument(raw []byte) (map[string]string, error) {
+	var values map[string]string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, fmt.Errorf("secret must be a JSON object of string values: %w", err)
+	}
+	return values, nil
+}
+
+// secretsProviders merges the values of several providers; later ones
+// take precedence.
+type secretsProviders []SecretsProvider
+
+func (p secretsProviders) Name() string {
+	names := make([]string, len(p))
+	for i, provider := range p {
+		names[i] = provider.Name()
+	}
+	return strings.Join(names, "+")
+}
+
+func (p secretsProviders) Fetch(ctx context.Context) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, provider := range p {
+		fetched, err := provider.Fetch(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", provider.Name(), err)
+		}
+		maps.Copy(values, fetched)
+	}
+	return values, nil
+}
+
+func (p secretsProviders) Close() error {
+	var errs []error
+	for _, provider := range p {
+		errs = append(errs, provider.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// fileProvider reads mounted secrets, such as a Kubernetes Secret volume,
+// from a directory holding one file per config key. The files are read
+// again on every fetch, so rotated mounts are picked up.
+type fileProvider struct {
+	dir string
+}
+
+func (p *fileProvider) Name() string { return SecretsProviderFile }
+
+func (p *fileProvider) Fetch(_ context.Context) (map[string]string, error) {
+	entries, err := os.ReadDir(p.dir)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		// Skips the ..data and timestamped directories of Kubernetes mounts.
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(p.dir, entry.Name())
+		// Follows symlinks, as mounted files are links into ..data.
+		if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		value, err := readSecretFile(path)
+		if err != nil {
+			return nil, err
+		}
+		values[entry.Name()] = value
+	}
+	return values, nil
+}
+
+func (p *fileProvider) Close() error { return nil }
+
+// readSecretFile reads a secret file without the trailing newline most
+// tools write.
+func readSecretFile(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(raw), "\r\n"), nil
+}
+
+// vaultProvider reads a KV v2 secret from HashiCorp Vault.
+type vaultProvider struct {
+	client *vault.Client
+	mount  string
+	path   string
+}
+
+func newVaultProvider(cfg SecretsConfig) (*vaultProvider, error) {
+	client, err := newVaultClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &vaultProvider{
+		client: client,
+		mount:  cfg.Vault.Mount,
+		path:   cfg.Vault.Path,
+	}, nil
+}
+
+// newVaultClient connects to the Vault of cfg, falling back to VAULT_ADDR
+// and VAULT_TOKEN.
+func newVaultClient(cfg SecretsConfig) (*vault.Client, error) {
+	vaultConfig := vault.DefaultConfig()
+	if cfg.Vault.Address != "" {
+		vaultConfig.Address = cfg.Vault.Address
+	}
+	client, err := vault.NewClient(vaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Vault client: %w", err)
+	}
+	if cfg.Vault.Token != "" {
+		client.SetToken(cfg.Vault.Token)
+	}
+	return client, nil
+}
+
+func (p *vaultProvider) Name() string { return SecretsProviderVault }
+
+func (p *vaultProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	secret, err := p.client.KVv2(p.mount).Get(ctx, p.path)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(secret.Data))
+	for key, value := range secret.Data {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("secret key %q is not a string", key)
+		}
+		values[key] = s
+	}
+	return values, nil
+}
+
+func (p *vaultProvider) Close() error { return nil }
+
+// awsProvider reads a JSON secret from AWS Secrets Manager.
+type awsProvider struct {
+	client   *secretsmanager.Client
+	secretID string
+}
+
+func newAWSProvider(ctx context.Context, cfg SecretsConfig) (*awsProvider, error) {
+	client, err := newAWSClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &awsProvider{
+		client:   client,
+		secretID: cfg.AWS.SecretID,
+	}, nil
+}
+
+// newAWSClient creates a Secrets Manager client in the region of cfg, or
+// the SDK's default region.
+func newAWSClient(ctx context.Context, cfg SecretsConfig) (*secretsmanager.Client, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if cfg.AWS.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.AWS.Region))
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	return secretsmanager.NewFromConfig(awsConfig), nil
+}
+
+func (p *awsProvider) Name() string { return SecretsProviderAWS }
+
+func (p *awsProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: &p.secretID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out.SecretString == nil {
+		return nil, fmt.Errorf("secret %q has no string value", p.secretID)
+	}
+	return decodeSecretDocument([]byte(*out.SecretString))
+}
+
+func (p *awsProvider) Close() error { return nil }
+
+// gcpProvider reads a JSON secret version from Google Secret Manager.
+type gcpProvider struct {
+	client *secretmanager.Client
+	name   string
+}
+
+func newGCPProvider(ctx context.Context, cfg SecretsConfig) (*gcpProvider, error) {
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Secret Manager client: %w", err)
+	}
+	return &gcpProvider{
+		client: client,
+		name: fmt.Sprintf("projects/%s/secrets/%s/versions/%s",
+			cfg.GCP.Project, cfg.GCP.Secret, cfg.GCP.Version),
+	}, nil
+}
+
+func (p *gcpProvider) Name() string { return SecretsProviderGCP }
+
+func (p *gcpProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	out, err := p.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: p.name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decodeSecretDocument(out.GetPayload().GetData())
+}
+
+func (p *gcpProvider) Close() error { return p.client.Close() }
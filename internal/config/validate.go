@@ -0,0 +1,658 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/currency"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/eventbus"
+	"github.com/anuragthepathak/subscription-management/internal/notifications"
+	"github.com/anuragthepathak/subscription-management/internal/payments"
+	"github.com/anuragthepathak/subscription-management/internal/storage"
+	"github.com/robfig/cron/v3"
+)
+
+// Application environments accepted in Config.Env.
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// minSecretLength is the shortest signing secret that does not trigger a
+// warning; HS256 keys should carry at least 256 bits.
+const minSecretLength = 32
+
+// FieldError describes a problem with one configuration field.
+type FieldError struct {
+	Field   string // Dotted config key, e.g. "database.port".
+	Message string // What is wrong, e.g. "must be between 1 and 65535".
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError lists every invalid field found by Validate.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return fmt.Sprintf("%d invalid config fields: %s", len(e.Fields), strings.Join(msgs, "; "))
+}
+
+// fieldChecker collects field errors so that every problem is reported at
+// once rather than one per run.
+type fieldChecker struct {
+	fields []FieldError
+}
+
+func (f *fieldChecker) add(field, message string) {
+	f.fields = append(f.fields, FieldError{field, message})
+}
+
+func (f *fieldChecker) required(field, value string) {
+	if value == "" {
+		f.add(field, "is required")
+	}
+}
+
+func (f *fieldChecker) port(field string, port int) {
+	if port <= 0 || port > 65535 {
+		f.add(field, "must be between 1 and 65535")
+	}
+}
+
+func (f *fieldChecker) positive(field string, n int) {
+	if n <= 0 {
+		f.add(field, "must be greater than 0")
+	}
+}
+
+func (f *fieldChecker) nonNegative(field string, n int) {
+	if n < 0 {
+		f.add(field, "must be 0 or greater")
+	}
+}
+
+func (f *fieldChecker) positiveDuration(field string, d time.Duration) {
+	if d <= 0 {
+		f.add(field, "must be a duration greater than 0")
+	}
+}
+
+func (f *fieldChecker) nonNegativeDuration(field string, d time.Duration) {
+	if d < 0 {
+		f.add(field, "must be a duration of 0 or greater")
+	}
+}
+
+// oneOf accepts value only if it is in allowed. List "" in allowed to make
+// the field optional.
+func (f *fieldChecker) oneOf(field, value string, allowed ...string) {
+	if slices.Contains(allowed, value) {
+		return
+	}
+	var names []string
+	for _, a := range allowed {
+		if a != "" {
+			names = append(names, a)
+		}
+	}
+	f.add(field, "must be one of "+strings.Join(names, ", "))
+}
+
+func (f *fieldChecker) err() error {
+	if len(f.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: f.fields}
+}
+
+// Validate checks every field and returns a *ValidationError listing the
+// invalid ones.
+func (c *Config) Validate() error {
+	var f fieldChecker
+
+	f.oneOf("env", c.Env, EnvDevelopment, EnvTest, EnvStaging, EnvProduction)
+	if len(c.Roles) == 0 {
+		f.add("roles", "is required")
+	}
+	for i, role := range c.Roles {
+		f.oneOf(fmt.Sprintf("roles[%d]", i), role, RoleAPI, RoleScheduler, RoleWorker, RoleAll)
+	}
+
+	// Server configuration validation
+	f.port("server.port", c.Server.Port)
+	f.positiveDuration("server.request_timeout", c.Server.RequestTimeout)
+	for _, group := range slices.Sorted(maps.Keys(c.Server.RouteTimeouts)) {
+		field := "server.route_timeouts." + group
+		f.oneOf(field, group, TimeoutGroups...)
+		f.positiveDuration(field, c.Server.RouteTimeouts[group])
+	}
+	if c.Server.TLS.Enabled {
+		f.required("server.tls.cert_path", c.Server.TLS.CertPath)
+		f.required("server.tls.key_path", c.Server.TLS.KeyPath)
+	}
+
+	// Database configuration validation
+	if c.Database.URI == "" {
+		f.required("database.host", c.Database.Host)
+		if !c.Database.SRV {
+			f.port("database.port", c.Database.Port)
+		}
+	} else if !strings.HasPrefix(c.Database.URI, "mongodb://") && !strings.HasPrefix(c.Database.URI, "mongodb+srv://") {
+		f.add("database.uri", "must start with mongodb:// or mongodb+srv://")
+	}
+	switch c.Database.AuthMechanism {
+	case "", AuthMechanismSCRAMSHA1, AuthMechanismSCRAMSHA256, AuthMechanismPLAIN:
+		f.required("database.username", c.Database.Username)
+		f.required("database.password", c.Database.Password)
+		f.required("database.auth_source", c.Database.AuthSource)
+	case AuthMechanismX509:
+		// The client certificate is the credential.
+		if !c.Database.TLS.Enabled || c.Database.TLS.CertFile == "" {
+			f.add("database.tls.cert_file", "is required: MONGODB-X509 needs TLS with a client certificate")
+		}
+		if c.Database.Password != "" {
+			f.add("database.password", "must be empty for MONGODB-X509")
+		}
+	case AuthMechanismAWS:
+		// Credentials may come from the AWS environment or instance role.
+	default:
+		f.oneOf("database.auth_mechanism", c.Database.AuthMechanism,
+			AuthMechanismSCRAMSHA1, AuthMechanismSCRAMSHA256, AuthMechanismPLAIN, AuthMechanismX509, AuthMechanismAWS)
+	}
+	f.required("database.name", c.Database.Name)
+	if (c.Database.TLS.CertFile == "") != (c.Database.TLS.KeyFile == "") {
+		f.add("database.tls.key_file", "must be set together with database.tls.cert_file")
+	}
+	f.positiveDuration("database.health_check.interval", c.Database.HealthCheck.Interval)
+	f.positiveDuration("database.health_check.timeout", c.Database.HealthCheck.Timeout)
+	f.positive("database.health_check.failure_threshold", c.Database.HealthCheck.FailureThreshold)
+	if c.Database.MinPoolSize > c.Database.MaxPoolSize && c.Database.MaxPoolSize > 0 {
+		f.add("database.min_pool_size", "must not exceed database.max_pool_size")
+	}
+	if c.Database.ReadPreference != "" {
+		if _, err := mongoReadPreference(c.Database.ReadPreference); err != nil {
+			f.add("database.read_preference", "must be one of primary, primaryPreferred, secondary, secondaryPreferred, nearest")
+		} else if c.Database.Transactions && !strings.EqualFold(c.Database.ReadPreference, "primary") {
+			f.add("database.read_preference", "must be primary while database.transactions is enabled")
+		}
+	}
+	if c.Database.WriteConcern != "" {
+		if _, err := mongoWriteConcern(c.Database.WriteConcern); err != nil {
+			f.add("database.write_concern", `must be "majority" or a number of nodes`)
+		} else if c.Database.Transactions && c.Database.WriteConcern == "0" {
+			f.add("database.write_concern", "must acknowledge writes while database.transactions is enabled")
+		}
+	}
+	f.nonNegativeDuration("database.server_selection_timeout", c.Database.ServerSelectionTimeout)
+	for _, compressor := range c.Database.Compressors {
+		if !slices.Contains(mongoCompressors, compressor) {
+			f.add("database.compressors", "must only list "+strings.Join(mongoCompressors, ", "))
+			break
+		}
+	}
+	f.nonNegativeDuration("database.timeouts.read", c.Database.Timeouts.Read)
+	f.nonNegativeDuration("database.timeouts.write", c.Database.Timeouts.Write)
+	f.nonNegativeDuration("database.timeouts.bulk", c.Database.Timeouts.Bulk)
+
+	// Redis configuration validation
+	switch c.Redis.Mode {
+	case RedisModeStandalone:
+		if c.Redis.URL == "" {
+			f.required("redis.host", c.Redis.Host)
+			f.port("redis.port", c.Redis.Port)
+		}
+	case RedisModeSentinel:
+		f.required("redis.master_name", c.Redis.MasterName)
+		if len(c.Redis.Addrs) == 0 {
+			f.add("redis.addrs", "must list the sentinel addresses")
+		}
+	case RedisModeCluster:
+		if len(c.Redis.Addrs) == 0 {
+			f.add("redis.addrs", "must list the cluster seed nodes")
+		}
+		if c.Redis.DB != 0 {
+			f.add("redis.db", "must be 0 in cluster mode")
+		}
+	default:
+		f.oneOf("redis.mode", c.Redis.Mode, RedisModeStandalone, RedisModeSentinel, RedisModeCluster)
+	}
+	for _, addr := range c.Redis.Addrs {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			f.add("redis.addrs", fmt.Sprintf("%q is not a host:port address", addr))
+		}
+	}
+	if c.Redis.URL != "" && c.Redis.Mode != RedisModeStandalone {
+		f.add("redis.url", "is only supported in standalone mode")
+	}
+	f.nonNegative("redis.db", c.Redis.DB)
+	if (c.Redis.TLS.CertFile == "") != (c.Redis.TLS.KeyFile == "") {
+		f.add("redis.tls.key_file", "must be set together with redis.tls.cert_file")
+	}
+	if c.Cache.Enabled {
+		f.positiveDuration("cache.ttl", c.Cache.TTL)
+	}
+
+	// Asynq configuration validation
+	f.required("asynq.queue_name", c.Asynq.QueueName)
+
+	// Rate limiter configuration validation
+	f.positive("rate_limiter.app.rate", c.RateLimiter.App.Rate)
+	f.nonNegative("rate_limiter.app.burst", c.RateLimiter.App.Burst)
+	f.positiveDuration("rate_limiter.app.period", c.RateLimiter.App.Period)
+	f.positive("rate_limiter.auth.rate", c.RateLimiter.Auth.Rate)
+	f.nonNegative("rate_limiter.auth.burst", c.RateLimiter.Auth.Burst)
+	f.positiveDuration("rate_limiter.auth.period", c.RateLimiter.Auth.Period)
+	for _, name := range slices.Sorted(maps.Keys(c.RateLimiter.Groups)) {
+		group := c.RateLimiter.Groups[name]
+		prefix := "rate_limiter.groups." + name
+		f.oneOf(prefix, name, RateLimitGroups...)
+		f.positive(prefix+".rate", group.Rate)
+		f.nonNegative(prefix+".burst", group.Burst)
+		f.positiveDuration(prefix+".period", group.Period)
+	}
+
+	// JWT configuration validation
+	switch c.JWT.Algorithm {
+	case "", services.JWTAlgorithmHS256:
+		f.required("jwt.access_secret", c.JWT.AccessSecret)
+		f.required("jwt.refresh_secret", c.JWT.RefreshSecret)
+	case services.JWTAlgorithmRS256, services.JWTAlgorithmEdDSA:
+		c.checkJWTKeys(&f)
+	default:
+		f.oneOf("jwt.algorithm", c.JWT.Algorithm, services.JWTAlgorithmHS256, services.JWTAlgorithmRS256, services.JWTAlgorithmEdDSA)
+	}
+	f.required("jwt.issuer", c.JWT.Issuer)
+	f.positive("jwt.access_timeout", c.JWT.AccessExpiryHours)
+	f.positive("jwt.refresh_timeout", c.JWT.RefreshExpiryHours)
+
+	// Scheduler configuration validation
+	f.required("scheduler.name", c.Scheduler.Name)
+	f.positiveDuration("scheduler.interval", c.Scheduler.Interval)
+	if len(c.Scheduler.ReminderDays) == 0 {
+		f.add("scheduler.reminder_days", "must list at least one day")
+	}
+	for _, days := range c.Scheduler.ReminderDays {
+		if days < 0 {
+			f.add("scheduler.reminder_days", fmt.Sprintf("%d is negative", days))
+		}
+	}
+	f.positiveDuration("scheduler.startup_delay", c.Scheduler.StartupDelay)
+	f.nonNegative("scheduler.archive_after_months", c.Scheduler.ArchiveAfterMonths)
+	f.nonNegative("scheduler.unused_after_days", c.Scheduler.UnusedAfterDays)
+	if c.Scheduler.UnusedAfterDays > 0 {
+		f.positive("scheduler.unused_notice_days", c.Scheduler.UnusedNoticeDays)
+		f.required("cancel_links.signing_secret", c.CancelLinks.SigningSecret)
+	}
+	if window := c.Scheduler.SendWindow.Window(); window != nil {
+		if err := window.Validate(); err != nil {
+			f.add("scheduler.send_window", `must have a start and end formatted as HH:MM, e.g. "09:00" and "18:00"`)
+		}
+	}
+	f.positiveDuration("scheduler.outbox_relay.interval", c.Scheduler.OutboxRelay.Interval)
+	f.positive("scheduler.outbox_relay.batch_size", c.Scheduler.OutboxRelay.BatchSize)
+	f.oneOf("scheduler.mode", c.Scheduler.Mode, SchedulerModePoll, SchedulerModeCron)
+	if c.Scheduler.Mode == SchedulerModeCron {
+		c.Scheduler.Cron.check(&f)
+	}
+
+	// Dunning configuration validation
+	if c.Dunning.Enabled {
+		if len(c.Dunning.RetryDays) == 0 {
+			f.add("dunning.retry_days", "must list at least one day")
+		}
+		for i, days := range c.Dunning.RetryDays {
+			if days <= 0 {
+				f.add("dunning.retry_days", fmt.Sprintf("%d is not positive", days))
+			} else if i > 0 && days <= c.Dunning.RetryDays[i-1] {
+				f.add("dunning.retry_days", "must be in increasing order")
+			}
+		}
+		f.oneOf("dunning.final_action", c.Dunning.FinalAction, string(models.DunningExpire), string(models.DunningSuspend))
+	}
+
+	// Queue worker configuration validation
+	f.positive("queue_worker.concurrency", c.QueueWorker.Concurrency)
+	if c.QueueWorker.SendRate.Rate != 0 {
+		f.positive("queue_worker.send_rate.rate", c.QueueWorker.SendRate.Rate)
+		f.nonNegative("queue_worker.send_rate.burst", c.QueueWorker.SendRate.Burst)
+		f.positiveDuration("queue_worker.send_rate.period", c.QueueWorker.SendRate.Period)
+	}
+
+	// OpenTelemetry configuration validation
+	f.required("otel.service_name", c.OTel.ServiceName)
+	f.required("otel.jaeger_endpoint", c.OTel.JaegerEndpoint)
+	if c.OTel.SampleRatio < 0 || c.OTel.SampleRatio > 1 {
+		f.add("otel.sample_ratio", "must be between 0 and 1")
+	}
+
+	// Email configuration validation
+	f.required("email.smtp_host", c.Email.SMTPHost)
+	f.port("email.smtp_port", c.Email.SMTPPort)
+	f.required("email.from_email", c.Email.FromEmail)
+	f.required("email.smtp_username", c.Email.SMTPUsername)
+	f.required("email.smtp_password", c.Email.SMTPPassword)
+	if c.Email.DisplayCurrency != "" && !models.ParseCurrency(c.Email.DisplayCurrency).Valid() {
+		f.add("email.display_currency", "must be an ISO 4217 currency code")
+	}
+	f.positiveDuration("email.send_timeout", c.Email.SendTimeout)
+	f.nonNegative("email.max_retries", c.Email.MaxRetries)
+	f.positiveDuration("email.retry_base_delay", c.Email.RetryBaseDelay)
+	if c.Email.RetryMaxDelay < c.Email.RetryBaseDelay {
+		f.add("email.retry_max_delay", "must not be less than email.retry_base_delay")
+	}
+	f.nonNegative("email.max_idle_conns", c.Email.MaxIdleConns)
+	if c.Email.MaxIdleConns > 0 {
+		f.positiveDuration("email.idle_timeout", c.Email.IdleTimeout)
+	}
+	f.nonNegative("email.breaker_threshold", c.Email.BreakerThreshold)
+	if c.Email.BreakerThreshold > 0 {
+		f.positiveDuration("email.breaker_cooldown", c.Email.BreakerCooldown)
+	}
+
+	// Webhook delivery configuration validation
+	f.positiveDuration("webhooks.timeout", c.Webhooks.Timeout)
+	f.nonNegative("webhooks.max_retries", c.Webhooks.MaxRetries)
+	f.positiveDuration("webhooks.retry_base_delay", c.Webhooks.RetryBaseDelay)
+	if c.Webhooks.RetryMaxDelay < c.Webhooks.RetryBaseDelay {
+		f.add("webhooks.retry_max_delay", "must not be less than webhooks.retry_base_delay")
+	}
+
+	// Notification channel configuration validation
+	f.positiveDuration("notifications.timeout", c.Notifications.Timeout)
+	f.oneOf("notifications.sms.provider", c.Notifications.SMS.Provider, "", notifications.SMSProviderTwilio)
+	if c.Notifications.SMS.Provider != "" {
+		f.required("notifications.sms.account_sid", c.Notifications.SMS.AccountSID)
+		f.required("notifications.sms.auth_token", c.Notifications.SMS.AuthToken)
+		f.required("notifications.sms.from_number", c.Notifications.SMS.FromNumber)
+		f.required("notifications.sms.base_url", c.Notifications.SMS.BaseURL)
+	}
+	if c.Notifications.Push.VAPIDPrivateKey != "" {
+		f.required("notifications.push.subject", c.Notifications.Push.Subject)
+		f.positiveDuration("notifications.push.ttl", c.Notifications.Push.TTL)
+		if _, err := notifications.ParseVAPIDKeys(
+			c.Notifications.Push.VAPIDPublicKey,
+			c.Notifications.Push.VAPIDPrivateKey,
+		); err != nil {
+			f.add("notifications.push.vapid_public_key", err.Error())
+		}
+	}
+
+	// File storage configuration validation
+	f.required("files.signing_secret", c.Files.SigningSecret)
+	f.positiveDuration("files.url_expiry", c.Files.URLExpiry)
+	f.oneOf("storage.driver", c.Storage.Driver, "", storage.DriverLocal, storage.DriverS3)
+	switch c.Storage.Driver {
+	case storage.DriverLocal:
+		f.required("storage.local.dir", c.Storage.Local.Dir)
+	case storage.DriverS3:
+		f.required("storage.s3.bucket", c.Storage.S3.Bucket)
+	}
+	if c.Attachments.MaxSize <= 0 {
+		f.add("attachments.max_size", "must be greater than 0")
+	}
+	f.positive("attachments.max_per_subscription", c.Attachments.MaxPerSubscription)
+	if len(c.Attachments.AllowedTypes) == 0 {
+		f.add("attachments.allowed_types", "must list at least one media type")
+	}
+	if c.Catalog.LogoURL != "" && strings.Count(c.Catalog.LogoURL, "%s") != 1 {
+		f.add("catalog.logo_url", "must contain %s exactly once, for the domain")
+	}
+	f.required("live_events.channel", c.LiveEvents.Channel)
+	f.positive("live_events.buffer_size", c.LiveEvents.BufferSize)
+	f.positiveDuration("live_events.heartbeat", c.LiveEvents.Heartbeat)
+	f.positiveDuration("live_events.max_duration", c.LiveEvents.MaxDuration)
+	f.required("calendar.signing_secret", c.Calendar.SigningSecret)
+	f.positive("calendar.months", c.Calendar.Months)
+	f.required("unsubscribe.signing_secret", c.Unsubscribe.SigningSecret)
+
+	// Export configuration validation
+	f.positive("export.max_rows", c.Export.MaxRows)
+	f.positiveDuration("export.link_expiry", c.Export.LinkExpiry)
+
+	// Invoice configuration validation
+	f.required("invoice.company_name", c.Invoice.CompanyName)
+	if c.Invoice.TaxRate < 0 || c.Invoice.TaxRate >= 100 {
+		f.add("invoice.tax_rate", "must be at least 0 and less than 100")
+	}
+	if c.Invoice.TaxRate > 0 {
+		f.required("invoice.tax_name", c.Invoice.TaxName)
+	}
+
+	// Account configuration validation
+	f.positiveDuration("account.closure_grace_period", c.Account.ClosureGracePeriod)
+	f.nonNegative("sessions.max_concurrent", c.Sessions.MaxConcurrent)
+
+	// Forecast configuration validation
+	if c.Forecast.CacheTTL < 0 {
+		f.add("forecast.cache_ttl", "must be 0 or greater")
+	}
+
+	// Exchange rate configuration validation
+	f.oneOf("currency.provider", c.Currency.Provider, "", currency.ProviderFrankfurter)
+	if c.Currency.Provider != "" {
+		f.required("currency.base_url", c.Currency.BaseURL)
+		if !models.Currency(c.Currency.Base).Valid() {
+			f.add("currency.base", "must be an ISO 4217 currency code")
+		}
+		f.positiveDuration("currency.timeout", c.Currency.Timeout)
+	}
+
+	// Payment provider configuration validation
+	f.oneOf("payments.provider", c.Payments.Provider, "", payments.ProviderStripe)
+	if c.Payments.Provider != "" {
+		f.required("payments.api_key", c.Payments.APIKey)
+		f.required("payments.base_url", c.Payments.BaseURL)
+		f.positiveDuration("payments.timeout", c.Payments.Timeout)
+	}
+
+	// Event bus configuration validation
+	f.oneOf("event_bus.driver", c.EventBus.Driver, "", eventbus.DriverNATS)
+	if c.EventBus.Driver != "" {
+		f.required("event_bus.url", c.EventBus.URL)
+		f.positiveDuration("event_bus.timeout", c.EventBus.Timeout)
+	}
+
+	// Logging configuration validation
+	if c.Log.Level != "" {
+		if _, err := parseLogLevel(c.Log.Level); err != nil {
+			f.add("log.level", "must be one of debug, info, warn, error")
+		}
+	}
+	f.oneOf("log.format", c.Log.Format, "", LogFormatJSON, LogFormatText)
+	f.oneOf("log.output", c.Log.Output, "", LogOutputStderr, LogOutputFile, LogOutputBoth)
+	if c.Log.Output == LogOutputFile || c.Log.Output == LogOutputBoth {
+		f.required("log.file.path", c.Log.File.Path)
+	}
+	f.positive("log.file.max_size_mb", c.Log.File.MaxSizeMB)
+	f.nonNegative("log.file.max_backups", c.Log.File.MaxBackups)
+	f.nonNegative("log.file.max_age_days", c.Log.File.MaxAgeDays)
+	f.nonNegative("log.sampling.debug_every", c.Log.Sampling.DebugEvery)
+
+	// Debug server configuration validation
+	if c.Debug.Enabled {
+		f.port("debug.port", c.Debug.Port)
+		if c.Debug.Port == c.Server.Port {
+			f.add("debug.port", "must differ from server.port")
+		}
+	}
+
+	// Internal gRPC API configuration validation
+	if c.GRPC.Enabled {
+		f.port("grpc.port", c.GRPC.Port)
+		if c.GRPC.Port == c.Server.Port || (c.Debug.Enabled && c.GRPC.Port == c.Debug.Port) {
+			f.add("grpc.port", "must differ from server.port and debug.port")
+		}
+		if c.GRPC.TLS.Enabled {
+			f.required("grpc.tls.cert_path", c.GRPC.TLS.CertPath)
+			f.required("grpc.tls.key_path", c.GRPC.TLS.KeyPath)
+		} else if c.GRPC.TLS.ClientCAPath != "" {
+			f.add("grpc.tls.client_ca_path", "requires grpc.tls.enabled")
+		}
+	}
+
+	// Internal admin listener configuration validation
+	if c.InternalServer.Enabled {
+		f.port("internal_server.port", c.InternalServer.Port)
+		if c.InternalServer.Port == c.Server.Port ||
+			(c.Debug.Enabled && c.InternalServer.Port == c.Debug.Port) ||
+			(c.GRPC.Enabled && c.InternalServer.Port == c.GRPC.Port) {
+			f.add("internal_server.port", "must differ from server.port, debug.port, and grpc.port")
+		}
+		f.required("internal_server.tls.cert_path", c.InternalServer.TLS.CertPath)
+		f.required("internal_server.tls.key_path", c.InternalServer.TLS.KeyPath)
+		f.required("internal_server.tls.client_ca_path", c.InternalServer.TLS.ClientCAPath)
+	}
+
+	// Startup configuration validation
+	if c.Startup.MaxWait < 0 {
+		f.add("startup.max_wait", "must be 0 or greater")
+	}
+	f.positiveDuration("startup.initial_backoff", c.Startup.InitialBackoff)
+	f.positiveDuration("startup.max_backoff", c.Startup.MaxBackoff)
+
+	// Shutdown configuration validation
+	if c.Shutdown.Drain < 0 {
+		f.add("shutdown.drain", "must be 0 or greater")
+	}
+	f.positiveDuration("shutdown.http", c.Shutdown.HTTP)
+	f.positiveDuration("shutdown.scheduler", c.Shutdown.Scheduler)
+	f.positiveDuration("shutdown.worker", c.Shutdown.Worker)
+	f.positiveDuration("shutdown.worker_drain", c.Shutdown.WorkerDrain)
+	if c.Shutdown.WorkerDrain >= c.Shutdown.Worker {
+		f.add("shutdown.worker_drain", "must be shorter than shutdown.worker")
+	}
+	f.positiveDuration("shutdown.connections", c.Shutdown.Connections)
+
+	// Secrets provider configuration validation
+	c.Secrets.check(&f)
+
+	return f.err()
+}
+
+// checkJWTKeys reports missing or conflicting key pairs of an asymmetric
+// JWT algorithm to f. The key files are read when the service starts.
+func (c Config) checkJWTKeys(f *fieldChecker) {
+	if len(c.JWT.Keys) == 0 {
+		f.add("jwt.keys", "is required for "+c.JWT.Algorithm)
+		return
+	}
+	signing := false
+	for i, key := range c.JWT.Keys {
+		prefix := fmt.Sprintf("jwt.keys[%d]", i)
+		f.required(prefix+".id", key.ID)
+		if key.PrivateKeyPath == "" && key.PublicKeyPath == "" {
+			f.add(prefix, "needs private_key_path or public_key_path")
+		}
+		for _, other := range c.JWT.Keys[:i] {
+			if key.ID != "" && other.ID == key.ID {
+				f.add(prefix+".id", fmt.Sprintf("%q is used by another key", key.ID))
+			}
+		}
+		if key.PrivateKeyPath != "" && (c.JWT.SigningKey == "" || c.JWT.SigningKey == key.ID) {
+			signing = true
+		}
+	}
+	if !signing {
+		if c.JWT.SigningKey != "" {
+			f.add("jwt.signing_key", "must name a key in jwt.keys with a private_key_path")
+		} else {
+			f.add("jwt.keys", "needs a key with a private_key_path to sign with")
+		}
+	}
+}
+
+// Warnings reports values that are valid but probably unintended. They do
+// not stop the application from starting.
+func (c *Config) Warnings() []FieldError {
+	var f fieldChecker
+
+	if slices.Contains(c.Scheduler.ReminderDays, 0) {
+		f.add("scheduler.reminder_days", "contains 0, so a reminder is sent on the renewal day itself")
+	}
+	for i, days := range c.Scheduler.ReminderDays {
+		if slices.Contains(c.Scheduler.ReminderDays[:i], days) {
+			f.add("scheduler.reminder_days", fmt.Sprintf("lists %d more than once", days))
+		}
+	}
+	if c.Scheduler.Interval > 24*time.Hour {
+		f.add("scheduler.interval", "is longer than 24h, so some reminder days may be skipped")
+	}
+
+	if len(c.JWT.AccessSecret) > 0 && len(c.JWT.AccessSecret) < minSecretLength {
+		f.add("jwt.access_secret", fmt.Sprintf("is shorter than %d bytes", minSecretLength))
+	}
+	if len(c.JWT.RefreshSecret) > 0 && len(c.JWT.RefreshSecret) < minSecretLength {
+		f.add("jwt.refresh_secret", fmt.Sprintf("is shorter than %d bytes", minSecretLength))
+	}
+	if c.JWT.AccessSecret != "" && c.JWT.AccessSecret == c.JWT.RefreshSecret {
+		f.add("jwt.refresh_secret", "is the same as jwt.access_secret")
+	}
+
+	if c.Server.RequestTimeout > c.Shutdown.HTTP {
+		f.add("shutdown.http", "is shorter than server.request_timeout, so slow requests may be cut off on shutdown")
+	}
+	for _, group := range slices.Sorted(maps.Keys(c.Server.RouteTimeouts)) {
+		if c.Server.RouteTimeouts[group] > c.Shutdown.HTTP {
+			f.add("shutdown.http", fmt.Sprintf("is shorter than server.route_timeouts.%s, so slow requests may be cut off on shutdown", group))
+		}
+	}
+
+	if c.Env == EnvProduction {
+		if c.Database.TLS.InsecureSkipVerify {
+			f.add("database.tls.insecure_skip_verify", "is enabled in production")
+		}
+		if c.Redis.TLS.InsecureSkipVerify {
+			f.add("redis.tls.insecure_skip_verify", "is enabled in production")
+		}
+		if strings.EqualFold(c.Log.Level, "debug") {
+			f.add("log.level", "is debug in production")
+		}
+	}
+	if c.Debug.Enabled {
+		if ip := net.ParseIP(c.Debug.Host); ip == nil || !ip.IsLoopback() {
+			if c.Debug.Host != "localhost" {
+				f.add("debug.host", "is not a loopback address, so profiles may be reachable from the network")
+			}
+		}
+	}
+
+	if c.GRPC.Enabled && c.GRPC.TLS.ClientCAPath == "" {
+		f.add("grpc.tls.client_ca_path", "is empty, so any client that reaches grpc.port can read every subscription")
+	}
+
+	return f.fields
+}
+
+// check reports an unknown time zone or a schedule that does not parse.
+func (c CronConfig) check(f *fieldChecker) {
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		f.add("scheduler.cron.timezone", "must be an IANA time zone, e.g. Europe/Berlin")
+	}
+	schedules := c.Schedules()
+	for _, name := range slices.Sorted(maps.Keys(schedules)) {
+		if spec := schedules[name]; spec != "" {
+			if _, err := cron.ParseStandard(spec); err != nil {
+				f.add("scheduler.cron."+name, `must be a cron expression, e.g. "0 * * * *", or a descriptor such as "@hourly"`)
+			}
+		}
+	}
+	if c.RenewalLookahead < 0 {
+		f.add("scheduler.cron.renewal_lookahead", "must not be negative")
+	}
+}
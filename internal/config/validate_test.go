@@ -0,0 +1,539 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/notifications"
+	"github.com/anuragthepathak/subscription-management/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validConfig returns a minimal Config that passes Validate without warnings.
+func validConfig() Config {
+	var c Config
+	c.Env = EnvDevelopment
+	c.Roles = []string{RoleAll}
+	c.Server.Port = 8080
+	c.Server.RequestTimeout = 10 * time.Second
+	c.Database = DatabaseConfig{
+		Host: "localhost", Port: 27017, Username: "app", Password: "pw", Name: "subs", AuthSource: "admin",
+		HealthCheck: DatabaseHealthCheckConfig{Interval: 15 * time.Second, Timeout: 2 * time.Second, FailureThreshold: 3},
+	}
+	c.Redis = RedisConfig{Mode: RedisModeStandalone, Host: "localhost", Port: 6379}
+	c.Asynq.QueueName = "subscription"
+	c.RateLimiter.App = RateLimiterConfig{Rate: 100, Period: time.Minute}
+	c.RateLimiter.Auth = RateLimiterConfig{Rate: 5, Period: time.Minute}
+	c.JWT.AccessSecret = "access-secret-that-is-at-least-32-bytes"
+	c.JWT.RefreshSecret = "refresh-secret-that-is-at-least-32-bytes"
+	c.JWT.Issuer = "subman"
+	c.JWT.AccessExpiryHours = 1
+	c.JWT.RefreshExpiryHours = 72
+	c.Scheduler = SchedulerConfig{
+		Name: "scheduler", Mode: SchedulerModePoll, Interval: 12 * time.Hour, ReminderDays: []int{1, 3, 7}, StartupDelay: time.Minute,
+		OutboxRelay: OutboxRelayConfig{Interval: 5 * time.Second, BatchSize: 100},
+	}
+	c.Dunning = DunningConfig{Enabled: true, RetryDays: []int{1, 3, 7}, FinalAction: string(models.DunningExpire)}
+	c.QueueWorker.Concurrency = 2
+	c.OTel.ServiceName = "subscription-management"
+	c.OTel.JaegerEndpoint = "localhost:4317"
+	c.Email.SMTPHost = "smtp.example.com"
+	c.Email.SMTPPort = 587
+	c.Email.FromEmail = "noreply@example.com"
+	c.Email.SMTPUsername = "smtp"
+	c.Email.SMTPPassword = "pw"
+	c.Email.SendTimeout = 30 * time.Second
+	c.Email.MaxRetries = 2
+	c.Email.RetryBaseDelay = time.Second
+	c.Email.RetryMaxDelay = 10 * time.Second
+	c.Email.MaxIdleConns = 2
+	c.Email.IdleTimeout = 30 * time.Second
+	c.Email.BreakerThreshold = 5
+	c.Email.BreakerCooldown = time.Minute
+	c.Webhooks = notifications.WebhookConfig{Timeout: 10 * time.Second, MaxRetries: 8, RetryBaseDelay: 30 * time.Second, RetryMaxDelay: time.Hour}
+	c.Notifications.Timeout = 10 * time.Second
+	c.Files.SigningSecret = "files-secret"
+	c.Files.URLExpiry = 15 * time.Minute
+	c.Attachments.MaxSize = 10 << 20
+	c.Attachments.MaxPerSubscription = 20
+	c.Attachments.AllowedTypes = []string{"application/pdf"}
+	c.LiveEvents = services.LiveEventConfig{Channel: "live_events", BufferSize: 64, Heartbeat: 30 * time.Second, MaxDuration: time.Hour}
+	c.Calendar.SigningSecret = "calendar-secret"
+	c.Calendar.Months = 12
+	c.Unsubscribe.SigningSecret = "unsubscribe-secret"
+	c.Export.MaxRows = 5000
+	c.Export.LinkExpiry = 72 * time.Hour
+	c.Invoice.CompanyName = "Example Inc."
+	c.Account.ClosureGracePeriod = 30 * 24 * time.Hour
+	c.Log.File.MaxSizeMB = 100
+	c.Startup = StartupConfig{MaxWait: time.Minute, InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	c.Shutdown = ShutdownConfig{HTTP: 15 * time.Second, Scheduler: 5 * time.Second, Worker: 30 * time.Second, WorkerDrain: 25 * time.Second, Connections: 10 * time.Second}
+	c.Secrets.Timeout = 10 * time.Second
+	return c
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		mutate     func(c *Config)
+		wantFields []string
+	}{
+		{
+			name:   "valid config",
+			mutate: func(c *Config) {},
+		},
+		{
+			name:       "unknown env",
+			mutate:     func(c *Config) { c.Env = "prod" },
+			wantFields: []string{"env"},
+		},
+		{
+			name:       "unknown role",
+			mutate:     func(c *Config) { c.Roles = []string{RoleAPI, "cron"} },
+			wantFields: []string{"roles[1]"},
+		},
+		{
+			name:       "no roles",
+			mutate:     func(c *Config) { c.Roles = nil },
+			wantFields: []string{"roles"},
+		},
+		{
+			name: "out-of-range ports",
+			mutate: func(c *Config) {
+				c.Server.Port = 0
+				c.Redis.Port = 70000
+			},
+			wantFields: []string{"server.port", "redis.port"},
+		},
+		{
+			name: "non-positive durations",
+			mutate: func(c *Config) {
+				c.Scheduler.Interval = 0
+				c.Shutdown.Worker = -time.Second
+			},
+			wantFields: []string{"scheduler.interval", "shutdown.worker", "shutdown.worker_drain"},
+		},
+		{
+			name:       "non-positive account closure grace period",
+			mutate:     func(c *Config) { c.Account.ClosureGracePeriod = 0 },
+			wantFields: []string{"account.closure_grace_period"},
+		},
+		{
+			name:       "negative max concurrent sessions",
+			mutate:     func(c *Config) { c.Sessions.MaxConcurrent = -1 },
+			wantFields: []string{"sessions.max_concurrent"},
+		},
+		{
+			name: "invalid mongo client settings",
+			mutate: func(c *Config) {
+				c.Database.MaxPoolSize = 10
+				c.Database.MinPoolSize = 20
+				c.Database.ReadPreference = "closest"
+				c.Database.WriteConcern = "all"
+				c.Database.Compressors = []string{"zstd", "gzip"}
+			},
+			wantFields: []string{"database.min_pool_size", "database.read_preference", "database.write_concern", "database.compressors"},
+		},
+		{
+			name: "transactions need primary reads and acknowledged writes",
+			mutate: func(c *Config) {
+				c.Database.Transactions = true
+				c.Database.ReadPreference = "secondaryPreferred"
+				c.Database.WriteConcern = "0"
+			},
+			wantFields: []string{"database.read_preference", "database.write_concern"},
+		},
+		{
+			name:       "cache without a ttl",
+			mutate:     func(c *Config) { c.Cache.Enabled = true },
+			wantFields: []string{"cache.ttl"},
+		},
+		{
+			name: "negative database timeouts",
+			mutate: func(c *Config) {
+				c.Database.Timeouts.Read = 0 // Disables the bound.
+				c.Database.Timeouts.Bulk = -time.Second
+			},
+			wantFields: []string{"database.timeouts.bulk"},
+		},
+		{
+			name:       "worker drain not shorter than worker shutdown",
+			mutate:     func(c *Config) { c.Shutdown.WorkerDrain = c.Shutdown.Worker },
+			wantFields: []string{"shutdown.worker_drain"},
+		},
+		{
+			name:       "unknown email display currency",
+			mutate:     func(c *Config) { c.Email.DisplayCurrency = "ABC" },
+			wantFields: []string{"email.display_currency"},
+		},
+		{
+			name: "payment provider without api key",
+			mutate: func(c *Config) {
+				c.Payments.Provider = "stripe"
+				c.Payments.BaseURL = "https://api.stripe.com"
+				c.Payments.Timeout = time.Second
+			},
+			wantFields: []string{"payments.api_key"},
+		},
+		{
+			name: "event bus without url",
+			mutate: func(c *Config) {
+				c.EventBus.Driver = "nats"
+				c.EventBus.Timeout = time.Second
+			},
+			wantFields: []string{"event_bus.url"},
+		},
+		{
+			name: "unknown event bus driver",
+			mutate: func(c *Config) {
+				c.EventBus.Driver = "kafka"
+				c.EventBus.URL = "kafka:9092"
+				c.EventBus.Timeout = time.Second
+			},
+			wantFields: []string{"event_bus.driver"},
+		},
+		{
+			name: "sms provider without credentials",
+			mutate: func(c *Config) {
+				c.Notifications.SMS.Provider = "twilio"
+				c.Notifications.SMS.BaseURL = "https://api.twilio.com"
+			},
+			wantFields: []string{
+				"notifications.sms.account_sid",
+				"notifications.sms.auth_token",
+				"notifications.sms.from_number",
+			},
+		},
+		{
+			name: "mismatched vapid keys",
+			mutate: func(c *Config) {
+				c.Notifications.Push = notifications.PushConfig{
+					VAPIDPublicKey:  "BAAA",
+					VAPIDPrivateKey: "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE",
+					Subject:         "mailto:ops@example.com",
+					TTL:             time.Hour,
+				}
+			},
+			wantFields: []string{"notifications.push.vapid_public_key"},
+		},
+		{
+			name:       "unknown scheduler mode",
+			mutate:     func(c *Config) { c.Scheduler.Mode = "ticker" },
+			wantFields: []string{"scheduler.mode"},
+		},
+		{
+			name: "cron mode with invalid schedules",
+			mutate: func(c *Config) {
+				c.Scheduler.Mode = SchedulerModeCron
+				c.Scheduler.Cron = CronConfig{
+					Timezone:         "Mars/Olympus",
+					Reminders:        "0 9 * * *",
+					Renewals:         "every hour",
+					Expirations:      "@every 15m",
+					RenewalLookahead: -time.Hour,
+				}
+			},
+			wantFields: []string{"scheduler.cron.timezone", "scheduler.cron.renewals", "scheduler.cron.renewal_lookahead"},
+		},
+		{
+			name: "invalid schedules ignored in poll mode",
+			mutate: func(c *Config) {
+				c.Scheduler.Cron = CronConfig{Timezone: "Mars/Olympus", Renewals: "every hour"}
+			},
+		},
+		{
+			name:       "send window with a malformed bound",
+			mutate:     func(c *Config) { c.Scheduler.SendWindow = SendWindowConfig{Start: "9am", End: "18:00"} },
+			wantFields: []string{"scheduler.send_window"},
+		},
+		{
+			name:       "send window that is empty",
+			mutate:     func(c *Config) { c.Scheduler.SendWindow = SendWindowConfig{Start: "09:00", End: "09:00"} },
+			wantFields: []string{"scheduler.send_window"},
+		},
+		{
+			name:       "send rate without a period",
+			mutate:     func(c *Config) { c.QueueWorker.SendRate = RateLimiterConfig{Rate: 100} },
+			wantFields: []string{"queue_worker.send_rate.period"},
+		},
+		{
+			name:       "s3 storage without a bucket",
+			mutate:     func(c *Config) { c.Storage.Driver = storage.DriverS3 },
+			wantFields: []string{"storage.s3.bucket"},
+		},
+		{
+			name:       "unknown storage driver",
+			mutate:     func(c *Config) { c.Storage.Driver = "ftp" },
+			wantFields: []string{"storage.driver"},
+		},
+		{
+			name: "attachments without limits or types",
+			mutate: func(c *Config) {
+				c.Attachments = services.AttachmentConfig{}
+			},
+			wantFields: []string{"attachments.max_size", "attachments.max_per_subscription", "attachments.allowed_types"},
+		},
+		{
+			name:       "catalog logo URL without the domain",
+			mutate:     func(c *Config) { c.Catalog.LogoURL = "https://logos.example.com/logo.png" },
+			wantFields: []string{"catalog.logo_url"},
+		},
+		{
+			name:       "live events without a channel or limits",
+			mutate:     func(c *Config) { c.LiveEvents = services.LiveEventConfig{} },
+			wantFields: []string{"live_events.channel", "live_events.buffer_size", "live_events.heartbeat", "live_events.max_duration"},
+		},
+		{
+			name:       "invoice tax rate of 100 percent",
+			mutate:     func(c *Config) { c.Invoice.TaxRate = 100 },
+			wantFields: []string{"invoice.tax_rate", "invoice.tax_name"},
+		},
+		{
+			name:       "negative reminder day",
+			mutate:     func(c *Config) { c.Scheduler.ReminderDays = []int{-1, 3} },
+			wantFields: []string{"scheduler.reminder_days"},
+		},
+		{
+			name:       "dunning retry days out of order",
+			mutate:     func(c *Config) { c.Dunning.RetryDays = []int{3, 1} },
+			wantFields: []string{"dunning.retry_days"},
+		},
+		{
+			name:       "unknown dunning final action",
+			mutate:     func(c *Config) { c.Dunning.FinalAction = "cancel" },
+			wantFields: []string{"dunning.final_action"},
+		},
+		{
+			name:   "dunning disabled without retry days",
+			mutate: func(c *Config) { c.Dunning = DunningConfig{} },
+		},
+		{
+			name:       "webhook retry cap below the base delay",
+			mutate:     func(c *Config) { c.Webhooks.RetryMaxDelay = time.Second },
+			wantFields: []string{"webhooks.retry_max_delay"},
+		},
+		{
+			name: "email delivery without timeouts",
+			mutate: func(c *Config) {
+				c.Email.SendTimeout = 0
+				c.Email.RetryMaxDelay = time.Millisecond
+				c.Email.IdleTimeout = 0
+				c.Email.BreakerCooldown = 0
+			},
+			wantFields: []string{"email.send_timeout", "email.retry_max_delay", "email.idle_timeout", "email.breaker_cooldown"},
+		},
+		{
+			name: "email reuse and circuit breaker disabled",
+			mutate: func(c *Config) {
+				c.Email.MaxIdleConns, c.Email.IdleTimeout = 0, 0
+				c.Email.BreakerThreshold, c.Email.BreakerCooldown = 0, 0
+			},
+		},
+		{
+			name: "rate limit groups with an unknown name and no rate",
+			mutate: func(c *Config) {
+				c.RateLimiter.Groups = map[string]RateLimiterConfig{
+					"subscriptions": {Period: time.Minute},
+					"subscription":  {Rate: 10, Period: time.Minute},
+				}
+			},
+			wantFields: []string{"rate_limiter.groups.subscription", "rate_limiter.groups.subscriptions.rate"},
+		},
+		{
+			name: "route timeouts with an unknown group and no duration",
+			mutate: func(c *Config) {
+				c.Server.RouteTimeouts = map[string]time.Duration{
+					"auth":   0,
+					"export": time.Minute,
+				}
+			},
+			wantFields: []string{"server.route_timeouts.auth", "server.route_timeouts.export"},
+		},
+		{
+			name:       "sample ratio above one",
+			mutate:     func(c *Config) { c.OTel.SampleRatio = 1.5 },
+			wantFields: []string{"otel.sample_ratio"},
+		},
+		{
+			name:       "unknown redis mode",
+			mutate:     func(c *Config) { c.Redis.Mode = "replica" },
+			wantFields: []string{"redis.mode"},
+		},
+		{
+			name:       "malformed cluster address",
+			mutate:     func(c *Config) { c.Redis = RedisConfig{Mode: RedisModeCluster, Addrs: []string{"node-1"}} },
+			wantFields: []string{"redis.addrs"},
+		},
+		{
+			name: "unused suggestions without a notice window or signing secret",
+			mutate: func(c *Config) {
+				c.Scheduler.UnusedAfterDays = 30
+				c.Scheduler.UnusedNoticeDays = 0
+			},
+			wantFields: []string{"scheduler.unused_notice_days", "cancel_links.signing_secret"},
+		},
+		{
+			name:       "unsubscribe links without a signing secret",
+			mutate:     func(c *Config) { c.Unsubscribe.SigningSecret = "" },
+			wantFields: []string{"unsubscribe.signing_secret"},
+		},
+		{
+			name: "asymmetric JWT algorithm without keys",
+			mutate: func(c *Config) {
+				c.JWT.Algorithm = services.JWTAlgorithmEdDSA
+				c.JWT.AccessSecret, c.JWT.RefreshSecret = "", ""
+			},
+			wantFields: []string{"jwt.keys"},
+		},
+		{
+			name: "JWT keys without IDs, files, or a signing key",
+			mutate: func(c *Config) {
+				c.JWT.Algorithm = services.JWTAlgorithmRS256
+				c.JWT.SigningKey = "missing"
+				c.JWT.Keys = []services.JWTKeyConfig{
+					{ID: "retired", PublicKeyPath: "/etc/jwt/retired.pub"},
+					{ID: "retired", PrivateKeyPath: "/etc/jwt/current.pem"},
+					{},
+				}
+			},
+			wantFields: []string{"jwt.keys[1].id", "jwt.keys[2].id", "jwt.keys[2]", "jwt.signing_key"},
+		},
+		{
+			name:       "unknown JWT algorithm",
+			mutate:     func(c *Config) { c.JWT.Algorithm = "HS512" },
+			wantFields: []string{"jwt.algorithm"},
+		},
+		{
+			name:       "secrets provider without its settings",
+			mutate:     func(c *Config) { c.Secrets.Provider = SecretsProviderGCP },
+			wantFields: []string{"secrets.gcp.project", "secrets.gcp.secret"},
+		},
+		{
+			name:       "file secrets provider without a directory",
+			mutate:     func(c *Config) { c.Secrets.Provider = SecretsProviderFile },
+			wantFields: []string{"secrets.file.dir"},
+		},
+		{
+			name: "gRPC API on the server port without its certificate",
+			mutate: func(c *Config) {
+				c.GRPC.Enabled = true
+				c.GRPC.Port = c.Server.Port
+				c.GRPC.TLS.Enabled = true
+				c.GRPC.TLS.ClientCAPath = "ca.pem"
+			},
+			wantFields: []string{"grpc.port", "grpc.tls.cert_path", "grpc.tls.key_path"},
+		},
+		{
+			name: "internal listener on the server port without its certificates",
+			mutate: func(c *Config) {
+				c.InternalServer.Enabled = true
+				c.InternalServer.Port = c.Server.Port
+			},
+			wantFields: []string{
+				"internal_server.port",
+				"internal_server.tls.cert_path",
+				"internal_server.tls.key_path",
+				"internal_server.tls.client_ca_path",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validConfig()
+			tt.mutate(&c)
+
+			err := c.Validate()
+			if tt.wantFields == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var verr *ValidationError
+			require.True(t, errors.As(err, &verr), "want *ValidationError, got %v", err)
+			var fields []string
+			for _, f := range verr.Fields {
+				fields = append(fields, f.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	tests := []struct {
+		name       string
+		mutate     func(c *Config)
+		wantFields []string
+	}{
+		{
+			name:   "no warnings",
+			mutate: func(c *Config) {},
+		},
+		{
+			name:       "reminder on the renewal day",
+			mutate:     func(c *Config) { c.Scheduler.ReminderDays = []int{0, 3} },
+			wantFields: []string{"scheduler.reminder_days"},
+		},
+		{
+			name:       "duplicate reminder day",
+			mutate:     func(c *Config) { c.Scheduler.ReminderDays = []int{3, 3} },
+			wantFields: []string{"scheduler.reminder_days"},
+		},
+		{
+			name: "short and shared JWT secrets",
+			mutate: func(c *Config) {
+				c.JWT.AccessSecret = "short"
+				c.JWT.RefreshSecret = "short"
+			},
+			wantFields: []string{"jwt.access_secret", "jwt.refresh_secret", "jwt.refresh_secret"},
+		},
+		{
+			name: "insecure TLS in production",
+			mutate: func(c *Config) {
+				c.Env = EnvProduction
+				c.Redis.TLS.InsecureSkipVerify = true
+			},
+			wantFields: []string{"redis.tls.insecure_skip_verify"},
+		},
+		{
+			name: "debug server on a public interface",
+			mutate: func(c *Config) {
+				c.Debug = DebugConfig{Enabled: true, Host: "0.0.0.0", Port: 6060}
+			},
+			wantFields: []string{"debug.host"},
+		},
+		{
+			name: "gRPC API without mutual TLS",
+			mutate: func(c *Config) {
+				c.GRPC = GRPCConfig{Enabled: true, Port: 9090}
+			},
+			wantFields: []string{"grpc.tls.client_ca_path"},
+		},
+		{
+			name: "route timeout longer than the HTTP shutdown",
+			mutate: func(c *Config) {
+				c.Server.RouteTimeouts = map[string]time.Duration{"exports": c.Shutdown.HTTP + time.Second}
+			},
+			wantFields: []string{"shutdown.http"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validConfig()
+			tt.mutate(&c)
+			require.NoError(t, c.Validate())
+
+			var fields []string
+			for _, f := range c.Warnings() {
+				fields = append(fields, f.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDelay collects the burst of events an editor or a ConfigMap update
+// produces into a single reload.
+const reloadDelay = 250 * time.Millisecond
+
+// ConfigWatcher reloads the configuration when its files change and notifies
+// subscribers of the changed keys, so tunable settings take effect without a
+// restart. A reload that fails to read or validate is logged and the last
+// good configuration is kept.
+type ConfigWatcher struct {
+	configFile string
+	files      []string
+	mu         sync.RWMutex
+	config     Config
+	handlers   []func(cfg Config, changed []string)
+}
+
+// NewConfigWatcher creates a watcher that starts from a copy of cfg, which
+// was loaded from configFile as given to LoadConfig.
+func NewConfigWatcher(configFile string, cfg Config) *ConfigWatcher {
+	base := configFile
+	if base == "" {
+		base = "config.yaml"
+	}
+	return &ConfigWatcher{
+		configFile: configFile,
+		files:      []string{base, overlayFile(base, cfg.Env)},
+		config:     cfg,
+	}
+}
+
+// OnChange registers fn to be called with the reloaded config and the
+// changed keys whenever a reload changes any setting. Register handlers
+// before calling Start.
+func (w *ConfigWatcher) OnChange(fn func(cfg Config, changed []string)) {
+	w.handlers = append(w.handlers, fn)
+}
+
+// Current returns the last successfully loaded configuration.
+func (w *ConfigWatcher) Current() Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.config
+}
+
+// Sanitized returns the current configuration with secrets masked.
+func (w *ConfigWatcher) Sanitized() map[string]any {
+	return w.Current().Sanitized()
+}
+
+// Start watches the config files until ctx is canceled. It watches their
+// directories rather than the files, so files created later and files
+// replaced by renaming, as Kubernetes does for mounted ConfigMaps, are
+// picked up.
+func (w *ConfigWatcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	paths := make(map[string]bool, len(w.files))
+	for _, file := range w.files {
+		path, err := filepath.Abs(file)
+		if err != nil {
+			return fmt.Errorf("failed to resolve config file %s: %w", file, err)
+		}
+		paths[path] = true
+		if err := watcher.Add(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("failed to watch config directory of %s: %w", file, err)
+		}
+	}
+
+	slog.InfoContext(ctx, "Config watcher started", logattr.ConfigFiles(w.files))
+
+	// The timer is armed by the first event of a burst.
+	timer := time.NewTimer(reloadDelay)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			// ConfigMap updates swap a ..data symlink instead of writing
+			// the files.
+			if paths[filepath.Clean(event.Name)] || filepath.Base(event.Name) == "..data" {
+				timer.Reset(reloadDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.ErrorContext(ctx, "Config file watcher failed", logattr.Error(err))
+		case <-timer.C:
+			w.reload(ctx)
+		}
+	}
+}
+
+func (w *ConfigWatcher) reload(ctx context.Context) {
+	updated, files, err := readConfig(ctx, w.configFile)
+	if err != nil {
+		// Keep serving with the last good configuration.
+		slog.ErrorContext(ctx, "Failed to reload configuration", logattr.Error(err))
+		return
+	}
+
+	w.mu.Lock()
+	changed := changedKeys(w.config, *updated)
+	w.config = *updated
+	w.mu.Unlock()
+	if len(changed) == 0 {
+		return
+	}
+	slog.InfoContext(ctx, "Configuration reloaded",
+		logattr.ConfigFiles(files),
+		logattr.ConfigKeys(changed),
+	)
+	for _, handler := range w.handlers {
+		handler(*updated, changed)
+	}
+}
+
+// changedKeys returns the dotted keys of the settings that differ between
+// old and updated, sorted. Lists and maps of values compare as a whole;
+// maps of sections, such as rate limiter groups, compare per entry.
+func changedKeys(old, updated Config) []string {
+	var changed []string
+	diffMaps("", structToMap(reflect.ValueOf(old)), structToMap(reflect.ValueOf(updated)), &changed)
+	slices.Sort(changed)
+	return changed
+}
+
+func diffMaps(prefix string, old, updated map[string]any, changed *[]string) {
+	for key, value := range old {
+		if _, ok := updated[key]; !ok {
+			*changed = append(*changed, prefix+key)
+			continue
+		}
+		diffValues(prefix+key, value, updated[key], changed)
+	}
+	for key := range updated {
+		if _, ok := old[key]; !ok {
+			*changed = append(*changed, prefix+key)
+		}
+	}
+}
+
+func diffValues(key string, old, updated any, changed *[]string) {
+	oldMap, oldOK := old.(map[string]any)
+	updatedMap, updatedOK := updated.(map[string]any)
+	if oldOK && updatedOK {
+		diffMaps(key+".", oldMap, updatedMap, changed)
+		return
+	}
+	if !reflect.DeepEqual(old, updated) {
+		*changed = append(*changed, key)
+	}
+}
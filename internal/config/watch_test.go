@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangedKeys(t *testing.T) {
+	var old Config
+	old.Log.Level = "info"
+	old.JWT.AccessSecret = "access"
+	old.Scheduler.ReminderDays = []int{1, 3}
+	old.RateLimiter.App = RateLimiterConfig{Rate: 100, Burst: 10, Period: time.Minute}
+	old.RateLimiter.Groups = map[string]RateLimiterConfig{
+		"bills": {Rate: 10, Period: time.Minute},
+		"files": {Rate: 5, Period: time.Minute},
+	}
+
+	updated := old
+	updated.Log.Level = "debug"
+	updated.JWT.AccessSecret = "rotated"
+	updated.Scheduler.ReminderDays = []int{1, 3, 7}
+	updated.RateLimiter.App.Burst = 20
+	updated.RateLimiter.Groups = map[string]RateLimiterConfig{
+		"bills":  {Rate: 20, Period: time.Minute},
+		"budget": {Rate: 5, Period: time.Minute},
+	}
+
+	assert.Equal(t, []string{
+		"jwt.access_secret",
+		"log.level",
+		"rate_limiter.app.burst",
+		"rate_limiter.groups.bills.rate",
+		"rate_limiter.groups.budget",
+		"rate_limiter.groups.files",
+		"scheduler.reminder_days",
+	}, changedKeys(old, updated))
+	assert.Empty(t, changedKeys(old, old))
+}
+
+func TestNewConfigWatcher_Files(t *testing.T) {
+	var cf Config
+	cf.Env = "production"
+
+	assert.Equal(t, []string{"config.yaml", "config.production.yaml"}, NewConfigWatcher("", cf).files)
+	assert.Equal(t, []string{"/etc/app/base.yml", "/etc/app/base.production.yml"}, NewConfigWatcher("/etc/app/base.yml", cf).files)
+}
@@ -2,6 +2,7 @@ package appctx
 
 import (
 	"context"
+	"time"
 )
 
 type contextKey string
@@ -9,8 +10,13 @@ type contextKey string
 const (
 	keyUserID         contextKey = "userID"         // Context key for authenticated user ID.
 	keyUserEmail      contextKey = "userEmail"      // Context key for authenticated user email.
+	keyUserRole       contextKey = "userRole"       // Context key for authenticated user role.
+	keySessionID      contextKey = "sessionID"      // Context key for the session of the authenticated token.
 	keySubscriptionID contextKey = "subscriptionID" // Context key for subscription ID.
 	keyTaskType       contextKey = "taskType"       // Context key for scheduler/worker task type.
+	keyLocale         contextKey = "locale"         // Context key for the locale of user-facing messages.
+	keyLocation       contextKey = "location"       // Context key for the timezone of user-facing dates.
+	keyVersion        contextKey = "version"        // Context key for the resource version a conditional write expects.
 )
 
 // WithUserID returns a new context with the given user ID.
@@ -35,6 +41,29 @@ func GetUserEmail(ctx context.Context) (string, bool) {
 	return email, ok
 }
 
+// WithUserRole returns a new context with the given user role.
+func WithUserRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, keyUserRole, role)
+}
+
+// GetUserRole retrieves the authenticated user role from the context.
+func GetUserRole(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(keyUserRole).(string)
+	return role, ok
+}
+
+// WithSessionID returns a new context with the given session ID.
+func WithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, keySessionID, sessionID)
+}
+
+// GetSessionID retrieves the session of the authenticated token from the
+// context. Tokens issued before sessions were tracked have none.
+func GetSessionID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(keySessionID).(string)
+	return id, ok
+}
+
 // WithSubscriptionID returns a new context with the given subscription ID.
 func WithSubscriptionID(ctx context.Context, subscriptionID string) context.Context {
 	return context.WithValue(ctx, keySubscriptionID, subscriptionID)
@@ -56,3 +85,38 @@ func GetTaskType(ctx context.Context) (string, bool) {
 	taskType, ok := ctx.Value(keyTaskType).(string)
 	return taskType, ok
 }
+
+// WithLocale returns a new context with the given locale.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, keyLocale, locale)
+}
+
+// GetLocale retrieves the locale of user-facing messages from the context.
+func GetLocale(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(keyLocale).(string)
+	return locale, ok
+}
+
+// WithLocation returns a new context with the given timezone.
+func WithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, keyLocation, loc)
+}
+
+// GetLocation retrieves the timezone of user-facing dates from the context.
+func GetLocation(ctx context.Context) (*time.Location, bool) {
+	loc, ok := ctx.Value(keyLocation).(*time.Location)
+	return loc, ok && loc != nil
+}
+
+// WithExpectedVersion returns a new context with the version a conditional
+// write expects its resource to still be at.
+func WithExpectedVersion(ctx context.Context, version int64) context.Context {
+	return context.WithValue(ctx, keyVersion, version)
+}
+
+// GetExpectedVersion retrieves the version a conditional write expects from
+// the context. Unconditional writes have none.
+func GetExpectedVersion(ctx context.Context) (int64, bool) {
+	version, ok := ctx.Value(keyVersion).(int64)
+	return version, ok
+}
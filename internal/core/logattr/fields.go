@@ -8,7 +8,9 @@ import (
 const (
 	keyUserID         = "user_id"
 	keyAttemptedID    = "attempted_id"
+	keySessionID      = "session_id"
 	keySubscriptionID = "subscription_id"
+	keyBillID         = "bill_id"
 	keyTaskID         = "task_id"
 	keyTaskType       = "task_type"
 	keyMethod         = "method"
@@ -22,20 +24,27 @@ const (
 	keyEnv            = "env"
 	keyPort           = "port"
 	keyInterval       = "interval"
+	keyTTL            = "ttl"
 	keyConcurrency    = "concurrency"
 	keyStartupTime    = "startup_time"
 	keySubscription   = "subscription"
 	keyTemplate       = "template"
 	keyKey            = "key"
+	keyKeys           = "keys"
 	keyRemaining      = "remaining"
 	keyValidTill      = "valid_till"
 	keyProcessAt      = "process_at"
 	keyService        = "service"
 	keyJaeger         = "jaeger"
+	keySampleRatio    = "sample_ratio"
 	keyIP             = "ip"
+	keyClientCert     = "client_cert"
 	keyMessage        = "message"
 	keyDaysBefore     = "days_before"
 	keyTotal          = "total"
+	keyUsers          = "users"
+	keySubscriptions  = "subscriptions"
+	keyBills          = "bills"
 	keySuccess        = "success"
 	keyFailed         = "failed"
 	keyHost           = "host"
@@ -44,6 +53,8 @@ const (
 	keyQueue          = "queue"
 	keyRenewalDate    = "renewal_date"
 	keyConfigFile     = "config_file"
+	keyConfigFiles    = "config_files"
+	keyConfigKeys     = "config_keys"
 	keyOtelEnabled    = "otel_enabled"
 
 	// Rate Limiter
@@ -74,6 +85,109 @@ const (
 	// Domain
 	keyUpdatedFields = "updated_fields"
 
+	// Database Monitor
+	keyPingTimeout         = "ping_timeout"
+	keyFailureThreshold    = "failure_threshold"
+	keyAuthMechanism       = "auth_mechanism"
+	keyMaxPoolSize         = "max_pool_size"
+	keyReadPreference      = "read_preference"
+	keyWriteConcern        = "write_concern"
+	keyRedisMode           = "redis_mode"
+	keyAddrs               = "addrs"
+	keyConsecutiveFailures = "consecutive_failures"
+	keyLatency             = "latency"
+	keyServerAddress       = "server_address"
+	keyPreviousKind        = "previous_kind"
+	keyKind                = "kind"
+
+	// Migrations
+	keyVersion = "version"
+
+	// Files
+	keyFileID        = "file_id"
+	keyStorageDriver = "storage_driver"
+	keyAttachmentID  = "attachment_id"
+
+	// Exports
+	keyExportKind   = "export_kind"
+	keyExportFormat = "export_format"
+
+	// Outbox
+	keyEventID   = "event_id"
+	keyEventType = "event_type"
+
+	// Webhooks
+	keyWebhookID = "webhook_id"
+
+	// Payment methods
+	keyPaymentMethodID = "payment_method_id"
+
+	// Secrets
+	keySecretsProvider = "secrets_provider"
+	keySecretKeys      = "secret_keys"
+
+	// Build
+	keyBuildVersion = "build_version"
+	keyCommit       = "commit"
+	keyBuildTime    = "build_time"
+	keyGoVersion    = "go_version"
+	keyFeatures     = "features"
+	keyRoles        = "roles"
+
+	// Servers
+	keyListenAddr = "listen_addr"
+
+	// Logging
+	keyLogLevel  = "log_level"
+	keyLogFormat = "log_format"
+	keyLogOutput = "log_output"
+
+	// Startup
+	keyDependency = "dependency"
+	keyAttempt    = "attempt"
+	keyRetryIn    = "retry_in"
+
+	// Shutdown
+	keyStage   = "stage"
+	keyElapsed = "elapsed"
+
+	// Exchange rates
+	keyRatesDate     = "rates_date"
+	keyRatesProvider = "rates_provider"
+	keyCurrency      = "currency"
+
+	// Budgets
+	keyCategory = "category"
+
+	// Price changes
+	keyOldPrice    = "old_price"
+	keyNewPrice    = "new_price"
+	keyEffectiveAt = "effective_at"
+
+	// Queue worker
+	keyRunningTasks = "running_tasks"
+
+	// Payments
+	keyPaymentProvider = "payment_provider"
+	keyChargeID        = "charge_id"
+	keyDeclineCode     = "decline_code"
+
+	// Event bus
+	keyEventBus = "event_bus"
+
+	// Notifications
+	keyChannel           = "channel"
+	keySuppressionReason = "suppression_reason"
+
+	// gRPC
+	keyRPCMethod = "rpc_method"
+	keyGRPCCode  = "grpc_code"
+	keyStack     = "stack"
+
+	// Dead tasks
+	keyDeadTaskID = "dead_task_id"
+	keyCronSpec   = "cron_spec"
+
 	// Miscellaneous
 	keyPodName = "pod_name"
 )
@@ -88,11 +202,21 @@ func AttemptedID(id string) slog.Attr {
 	return slog.String(keyAttemptedID, id)
 }
 
+// SessionID returns an slog.Attr for the session ID.
+func SessionID(id string) slog.Attr {
+	return slog.String(keySessionID, id)
+}
+
 // SubscriptionID returns an slog.Attr for the subscription ID.
 func SubscriptionID(id string) slog.Attr {
 	return slog.String(keySubscriptionID, id)
 }
 
+// BillID returns an slog.Attr for the bill ID.
+func BillID(id string) slog.Attr {
+	return slog.String(keyBillID, id)
+}
+
 // TaskID returns an slog.Attr for the task ID.
 func TaskID(id string) slog.Attr {
 	return slog.String(keyTaskID, id)
@@ -163,6 +287,11 @@ func Concurrency(c int) slog.Attr {
 	return slog.Int(keyConcurrency, c)
 }
 
+// TTL returns an slog.Attr for how long a cached value lives.
+func TTL(d time.Duration) slog.Attr {
+	return slog.Duration(keyTTL, d)
+}
+
 // StartupTime returns an slog.Attr for the service startup time.
 func StartupTime(d time.Duration) slog.Attr {
 	return slog.Duration(keyStartupTime, d)
@@ -183,6 +312,11 @@ func Key(k string) slog.Attr {
 	return slog.String(keyKey, k)
 }
 
+// Keys returns an slog.Attr for a list of generic keys.
+func Keys(k []string) slog.Attr {
+	return slog.Any(keyKeys, k)
+}
+
 // Remaining returns an slog.Attr for the remaining count.
 func Remaining(r int) slog.Attr {
 	return slog.Int(keyRemaining, r)
@@ -208,11 +342,21 @@ func Jaeger(j string) slog.Attr {
 	return slog.String(keyJaeger, j)
 }
 
+// SampleRatio returns an slog.Attr for the trace sampling ratio.
+func SampleRatio(r float64) slog.Attr {
+	return slog.Float64(keySampleRatio, r)
+}
+
 // IP returns an slog.Attr for the IP address.
 func IP(ip string) slog.Attr {
 	return slog.String(keyIP, ip)
 }
 
+// ClientCert returns an slog.Attr for the name of a client certificate.
+func ClientCert(name string) slog.Attr {
+	return slog.String(keyClientCert, name)
+}
+
 // Message returns an slog.Attr for the message text.
 func Message(m string) slog.Attr {
 	return slog.String(keyMessage, m)
@@ -228,6 +372,21 @@ func Total(c int) slog.Attr {
 	return slog.Int(keyTotal, c)
 }
 
+// Users returns an slog.Attr for a count of users.
+func Users(c int) slog.Attr {
+	return slog.Int(keyUsers, c)
+}
+
+// Subscriptions returns an slog.Attr for a count of subscriptions.
+func Subscriptions(c int) slog.Attr {
+	return slog.Int(keySubscriptions, c)
+}
+
+// Bills returns an slog.Attr for a count of bills.
+func Bills(c int) slog.Attr {
+	return slog.Int(keyBills, c)
+}
+
 // Success returns an slog.Attr for the count of items.
 func Success(c int) slog.Attr {
 	return slog.Int(keySuccess, c)
@@ -283,6 +442,16 @@ func ConfigFile(f string) slog.Attr {
 	return slog.String(keyConfigFile, f)
 }
 
+// ConfigFiles returns an slog.Attr for the config files read, in load order.
+func ConfigFiles(f []string) slog.Attr {
+	return slog.Any(keyConfigFiles, f)
+}
+
+// ConfigKeys returns an slog.Attr for the config keys changed by a reload.
+func ConfigKeys(keys []string) slog.Attr {
+	return slog.Any(keyConfigKeys, keys)
+}
+
 // OtelEnabled returns an slog.Attr for the OpenTelemetry enabled status.
 func OtelEnabled(b bool) slog.Attr {
 	return slog.Bool(keyOtelEnabled, b)
@@ -357,3 +526,302 @@ func TLSEnabled(b bool) slog.Attr {
 func UpdatedFields(fields []string) slog.Attr {
 	return slog.Any(keyUpdatedFields, fields)
 }
+
+// PingTimeout returns an slog.Attr for the health check ping timeout.
+func PingTimeout(d time.Duration) slog.Attr {
+	return slog.Duration(keyPingTimeout, d)
+}
+
+// FailureThreshold returns an slog.Attr for the failure threshold.
+func FailureThreshold(n int) slog.Attr {
+	return slog.Int(keyFailureThreshold, n)
+}
+
+// ConsecutiveFailures returns an slog.Attr for the consecutive failure count.
+func ConsecutiveFailures(n int) slog.Attr {
+	return slog.Int(keyConsecutiveFailures, n)
+}
+
+// Latency returns an slog.Attr for the measured latency.
+func Latency(d time.Duration) slog.Attr {
+	return slog.Duration(keyLatency, d)
+}
+
+// ServerAddress returns an slog.Attr for the database server address.
+func ServerAddress(a string) slog.Attr {
+	return slog.String(keyServerAddress, a)
+}
+
+// PreviousKind returns an slog.Attr for the previous server or topology kind.
+func PreviousKind(k string) slog.Attr {
+	return slog.String(keyPreviousKind, k)
+}
+
+// Kind returns an slog.Attr for the server or topology kind.
+func Kind(k string) slog.Attr {
+	return slog.String(keyKind, k)
+}
+
+// Version returns an slog.Attr for a migration version.
+func Version(v int) slog.Attr {
+	return slog.Int(keyVersion, v)
+}
+
+// FileID returns an slog.Attr for a stored file ID.
+func FileID(id string) slog.Attr {
+	return slog.String(keyFileID, id)
+}
+
+// AttachmentID returns an slog.Attr for a subscription attachment ID.
+func AttachmentID(id string) slog.Attr {
+	return slog.String(keyAttachmentID, id)
+}
+
+// StorageDriver returns an slog.Attr for the driver file content is stored with.
+func StorageDriver(d string) slog.Attr {
+	return slog.String(keyStorageDriver, d)
+}
+
+// ExportKind returns an slog.Attr for the records an export lists.
+func ExportKind(k string) slog.Attr {
+	return slog.String(keyExportKind, k)
+}
+
+// ExportFormat returns an slog.Attr for the file format of an export.
+func ExportFormat(f string) slog.Attr {
+	return slog.String(keyExportFormat, f)
+}
+
+// EventID returns an slog.Attr for a domain event ID.
+func EventID(id string) slog.Attr {
+	return slog.String(keyEventID, id)
+}
+
+// EventType returns an slog.Attr for a domain event type.
+func EventType(t string) slog.Attr {
+	return slog.String(keyEventType, t)
+}
+
+// WebhookID returns an slog.Attr for a webhook ID.
+func WebhookID(id string) slog.Attr {
+	return slog.String(keyWebhookID, id)
+}
+
+// PaymentMethodID returns an slog.Attr for a payment method ID.
+func PaymentMethodID(id string) slog.Attr {
+	return slog.String(keyPaymentMethodID, id)
+}
+
+// SecretsProvider returns an slog.Attr for the secrets provider name.
+func SecretsProvider(p string) slog.Attr {
+	return slog.String(keySecretsProvider, p)
+}
+
+// SecretKeys returns an slog.Attr for the config keys loaded from a secrets
+// provider. Only key names are logged, never values.
+func SecretKeys(keys []string) slog.Attr {
+	return slog.Any(keySecretKeys, keys)
+}
+
+// BuildVersion returns an slog.Attr for the version of the running binary.
+func BuildVersion(v string) slog.Attr {
+	return slog.String(keyBuildVersion, v)
+}
+
+// Commit returns an slog.Attr for the VCS commit the binary was built from.
+func Commit(c string) slog.Attr {
+	return slog.String(keyCommit, c)
+}
+
+// BuildTime returns an slog.Attr for the time the binary was built.
+func BuildTime(t string) slog.Attr {
+	return slog.String(keyBuildTime, t)
+}
+
+// GoVersion returns an slog.Attr for the Go toolchain version.
+func GoVersion(v string) slog.Attr {
+	return slog.String(keyGoVersion, v)
+}
+
+// Features returns an slog.Attr for the optional features and whether each
+// is enabled.
+func Features(f map[string]bool) slog.Attr {
+	return slog.Any(keyFeatures, f)
+}
+
+// Roles returns an slog.Attr for the roles a process runs.
+func Roles(r []string) slog.Attr {
+	return slog.Any(keyRoles, r)
+}
+
+// AuthMechanism returns an slog.Attr for a database authentication mechanism.
+func AuthMechanism(m string) slog.Attr {
+	return slog.String(keyAuthMechanism, m)
+}
+
+// MaxPoolSize returns an slog.Attr for the connections a pool may open per server.
+func MaxPoolSize(n uint64) slog.Attr {
+	return slog.Uint64(keyMaxPoolSize, n)
+}
+
+// ReadPreference returns an slog.Attr for a MongoDB read preference.
+func ReadPreference(mode string) slog.Attr {
+	return slog.String(keyReadPreference, mode)
+}
+
+// WriteConcern returns an slog.Attr for a MongoDB write concern.
+func WriteConcern(w string) slog.Attr {
+	return slog.String(keyWriteConcern, w)
+}
+
+// RedisMode returns an slog.Attr for the Redis topology.
+func RedisMode(m string) slog.Attr {
+	return slog.String(keyRedisMode, m)
+}
+
+// Addrs returns an slog.Attr for a list of "host:port" addresses.
+func Addrs(addrs []string) slog.Attr {
+	return slog.Any(keyAddrs, addrs)
+}
+
+// Stage returns an slog.Attr for a shutdown stage name.
+func Stage(name string) slog.Attr {
+	return slog.String(keyStage, name)
+}
+
+// Elapsed returns an slog.Attr for the time an operation took.
+func Elapsed(d time.Duration) slog.Attr {
+	return slog.Duration(keyElapsed, d)
+}
+
+// ListenAddr returns an slog.Attr for the address a server listens on.
+func ListenAddr(a string) slog.Attr {
+	return slog.String(keyListenAddr, a)
+}
+
+// LogLevel returns an slog.Attr for a log level name.
+func LogLevel(l string) slog.Attr {
+	return slog.String(keyLogLevel, l)
+}
+
+// LogFormat returns an slog.Attr for a log format.
+func LogFormat(f string) slog.Attr {
+	return slog.String(keyLogFormat, f)
+}
+
+// LogOutput returns an slog.Attr for a log output target.
+func LogOutput(o string) slog.Attr {
+	return slog.String(keyLogOutput, o)
+}
+
+// Dependency returns an slog.Attr for the name of an external dependency.
+func Dependency(name string) slog.Attr {
+	return slog.String(keyDependency, name)
+}
+
+// Attempt returns an slog.Attr for the attempt number.
+func Attempt(n int) slog.Attr {
+	return slog.Int(keyAttempt, n)
+}
+
+// RetryIn returns an slog.Attr for the wait before the next attempt.
+func RetryIn(d time.Duration) slog.Attr {
+	return slog.Duration(keyRetryIn, d)
+}
+
+// RatesDate returns an slog.Attr for the day exchange rates apply to.
+func RatesDate(d string) slog.Attr {
+	return slog.String(keyRatesDate, d)
+}
+
+// RatesProvider returns an slog.Attr for the exchange rate provider.
+func RatesProvider(p string) slog.Attr {
+	return slog.String(keyRatesProvider, p)
+}
+
+// Currency returns an slog.Attr for a currency code.
+func Currency(c string) slog.Attr {
+	return slog.String(keyCurrency, c)
+}
+
+// Category returns an slog.Attr for a subscription or budget category.
+func Category(c string) slog.Attr {
+	return slog.String(keyCategory, c)
+}
+
+// OldPrice returns an slog.Attr for a price before a change, in minor units.
+func OldPrice(p int64) slog.Attr {
+	return slog.Int64(keyOldPrice, p)
+}
+
+// NewPrice returns an slog.Attr for a price after a change, in minor units.
+func NewPrice(p int64) slog.Attr {
+	return slog.Int64(keyNewPrice, p)
+}
+
+// EffectiveAt returns an slog.Attr for when a price change takes effect.
+func EffectiveAt(t time.Time) slog.Attr {
+	return slog.Time(keyEffectiveAt, t)
+}
+
+// RunningTasks returns an slog.Attr for the number of tasks a worker is running.
+func RunningTasks(n int64) slog.Attr {
+	return slog.Int64(keyRunningTasks, n)
+}
+
+// PaymentProvider returns an slog.Attr for the payment provider.
+func PaymentProvider(p string) slog.Attr {
+	return slog.String(keyPaymentProvider, p)
+}
+
+// EventBus returns an slog.Attr for the message bus domain events are
+// published to.
+func EventBus(driver string) slog.Attr {
+	return slog.String(keyEventBus, driver)
+}
+
+// ChargeID returns an slog.Attr for a charge at the payment provider.
+func ChargeID(id string) slog.Attr {
+	return slog.String(keyChargeID, id)
+}
+
+// DeclineCode returns an slog.Attr for the reason a charge was declined.
+func DeclineCode(code string) slog.Attr {
+	return slog.String(keyDeclineCode, code)
+}
+
+// Channel returns an slog.Attr for a notification channel.
+func Channel(c string) slog.Attr {
+	return slog.String(keyChannel, c)
+}
+
+// SuppressionReason returns an slog.Attr for why an email address is
+// suppressed.
+func SuppressionReason(r string) slog.Attr {
+	return slog.String(keySuppressionReason, r)
+}
+
+// RPCMethod returns an slog.Attr for the full name of a gRPC method.
+func RPCMethod(m string) slog.Attr {
+	return slog.String(keyRPCMethod, m)
+}
+
+// GRPCCode returns an slog.Attr for a gRPC status code.
+func GRPCCode(c string) slog.Attr {
+	return slog.String(keyGRPCCode, c)
+}
+
+// Stack returns an slog.Attr for the stack trace of a recovered panic.
+func Stack(s []byte) slog.Attr {
+	return slog.String(keyStack, string(s))
+}
+
+// DeadTaskID returns an slog.Attr for the ID of a recorded dead task.
+func DeadTaskID(id string) slog.Attr {
+	return slog.String(keyDeadTaskID, id)
+}
+
+// CronSpec returns an slog.Attr for the cron expression of a periodic task.
+func CronSpec(spec string) slog.Attr {
+	return slog.String(keyCronSpec, spec)
+}
@@ -10,6 +10,7 @@ const (
 	// Business attributes
 	subscriptionIDKey = attribute.Key("subscription.id")
 	daysBeforeKey     = attribute.Key("subscription.days_before")
+	reminderCountKey  = attribute.Key("subscription.reminder_count")
 
 	// Queue related attributes
 	taskTypeKey  = attribute.Key("job.type")
@@ -29,6 +30,12 @@ func DaysBefore(days int) attribute.KeyValue {
 	return daysBeforeKey.Int(days)
 }
 
+// ReminderCount returns an attribute.KeyValue for the number of reminders in
+// a digest.
+func ReminderCount(n int) attribute.KeyValue {
+	return reminderCountKey.Int(n)
+}
+
 // TaskType returns an attribute.KeyValue for the task type.
 func TaskType(t string) attribute.KeyValue {
 	return taskTypeKey.String(t)
@@ -5,6 +5,9 @@ var (
 	StatusSuccess = statusKey.String("success")
 	// StatusError is the attribute value for a failed operation.
 	StatusError = statusKey.String("error")
+	// StatusDeferred is the attribute value for an operation postponed
+	// without failing.
+	StatusDeferred = statusKey.String("deferred")
 )
 
 var (
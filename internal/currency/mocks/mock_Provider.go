@@ -0,0 +1,144 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+
+	time "time"
+)
+
+// MockProvider is an autogenerated mock type for the Provider type
+type MockProvider struct {
+	mock.Mock
+}
+
+type MockProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProvider) EXPECT() *MockProvider_Expecter {
+	return &MockProvider_Expecter{mock: &_m.Mock}
+}
+
+// FetchRates provides a mock function with given fields: ctx, date
+func (_m *MockProvider) FetchRates(ctx context.Context, date time.Time) (*models.ExchangeRates, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRates")
+	}
+
+	var r0 *models.ExchangeRates
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*models.ExchangeRates, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *models.ExchangeRates); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExchangeRates)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProvider_FetchRates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchRates'
+type MockProvider_FetchRates_Call struct {
+	*mock.Call
+}
+
+// FetchRates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - date time.Time
+func (_e *MockProvider_Expecter) FetchRates(ctx interface{}, date interface{}) *MockProvider_FetchRates_Call {
+	return &MockProvider_FetchRates_Call{Call: _e.mock.On("FetchRates", ctx, date)}
+}
+
+func (_c *MockProvider_FetchRates_Call) Run(run func(ctx context.Context, date time.Time)) *MockProvider_FetchRates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockProvider_FetchRates_Call) Return(_a0 *models.ExchangeRates, _a1 error) *MockProvider_FetchRates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProvider_FetchRates_Call) RunAndReturn(run func(context.Context, time.Time) (*models.ExchangeRates, error)) *MockProvider_FetchRates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Name provides a mock function with no fields
+func (_m *MockProvider) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockProvider_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type MockProvider_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *MockProvider_Expecter) Name() *MockProvider_Name_Call {
+	return &MockProvider_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *MockProvider_Name_Call) Run(run func()) *MockProvider_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockProvider_Name_Call) Return(_a0 string) *MockProvider_Name_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProvider_Name_Call) RunAndReturn(run func() string) *MockProvider_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProvider creates a new instance of MockProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProvider {
+	mock := &MockProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,218 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+
+	time "time"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// Convert provides a mock function with given fields: ctx, amount, from, to, date
+func (_m *MockService) Convert(ctx context.Context, amount int64, from models.Currency, to models.Currency, date time.Time) (int64, error) {
+	ret := _m.Called(ctx, amount, from, to, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Convert")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.Currency, models.Currency, time.Time) (int64, error)); ok {
+		return rf(ctx, amount, from, to, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.Currency, models.Currency, time.Time) int64); ok {
+		r0 = rf(ctx, amount, from, to, date)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.Currency, models.Currency, time.Time) error); ok {
+		r1 = rf(ctx, amount, from, to, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Convert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Convert'
+type MockService_Convert_Call struct {
+	*mock.Call
+}
+
+// Convert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - amount int64
+//   - from models.Currency
+//   - to models.Currency
+//   - date time.Time
+func (_e *MockService_Expecter) Convert(ctx interface{}, amount interface{}, from interface{}, to interface{}, date interface{}) *MockService_Convert_Call {
+	return &MockService_Convert_Call{Call: _e.mock.On("Convert", ctx, amount, from, to, date)}
+}
+
+func (_c *MockService_Convert_Call) Run(run func(ctx context.Context, amount int64, from models.Currency, to models.Currency, date time.Time)) *MockService_Convert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.Currency), args[3].(models.Currency), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockService_Convert_Call) Return(_a0 int64, _a1 error) *MockService_Convert_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_Convert_Call) RunAndReturn(run func(context.Context, int64, models.Currency, models.Currency, time.Time) (int64, error)) *MockService_Convert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RatesOn provides a mock function with given fields: ctx, date
+func (_m *MockService) RatesOn(ctx context.Context, date time.Time) (*models.ExchangeRates, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RatesOn")
+	}
+
+	var r0 *models.ExchangeRates
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*models.ExchangeRates, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *models.ExchangeRates); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExchangeRates)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_RatesOn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RatesOn'
+type MockService_RatesOn_Call struct {
+	*mock.Call
+}
+
+// RatesOn is a helper method to define mock.On call
+//   - ctx context.Context
+//   - date time.Time
+func (_e *MockService_Expecter) RatesOn(ctx interface{}, date interface{}) *MockService_RatesOn_Call {
+	return &MockService_RatesOn_Call{Call: _e.mock.On("RatesOn", ctx, date)}
+}
+
+func (_c *MockService_RatesOn_Call) Run(run func(ctx context.Context, date time.Time)) *MockService_RatesOn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockService_RatesOn_Call) Return(_a0 *models.ExchangeRates, _a1 error) *MockService_RatesOn_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_RatesOn_Call) RunAndReturn(run func(context.Context, time.Time) (*models.ExchangeRates, error)) *MockService_RatesOn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshRates provides a mock function with given fields: ctx, date
+func (_m *MockService) RefreshRates(ctx context.Context, date time.Time) (*models.ExchangeRates, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshRates")
+	}
+
+	var r0 *models.ExchangeRates
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*models.ExchangeRates, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *models.ExchangeRates); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExchangeRates)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_RefreshRates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshRates'
+type MockService_RefreshRates_Call struct {
+	*mock.Call
+}
+
+// RefreshRates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - date time.Time
+func (_e *MockService_Expecter) RefreshRates(ctx interface{}, date interface{}) *MockService_RefreshRates_Call {
+	return &MockService_RefreshRates_Call{Call: _e.mock.On("RefreshRates", ctx, date)}
+}
+
+func (_c *MockService_RefreshRates_Call) Run(run func(ctx context.Context, date time.Time)) *MockService_RefreshRates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockService_RefreshRates_Call) Return(_a0 *models.ExchangeRates, _a1 error) *MockService_RefreshRates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_RefreshRates_Call) RunAndReturn(run func(context.Context, time.Time) (*models.ExchangeRates, error)) *MockService_RefreshRates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
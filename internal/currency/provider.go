@@ -0,0 +1,109 @@
+// Package currency keeps daily exchange rates and converts amounts between
+// currencies. Rates are fetched from a provider by a queue task, stored in
+// MongoDB as history, and cached in Redis for lookups.
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// ProviderFrankfurter fetches the European Central Bank reference rates from
+// the Frankfurter API, which needs no API key.
+const ProviderFrankfurter = "frankfurter"
+
+// Config holds the exchange rate settings.
+type Config struct {
+	Provider string        `mapstructure:"provider"` // Rate source; empty disables refreshes.
+	BaseURL  string        `mapstructure:"base_url"` // Provider API endpoint.
+	Base     string        `mapstructure:"base"`     // Currency rates are quoted against.
+	Timeout  time.Duration `mapstructure:"timeout"`  // Per-request timeout for the provider.
+}
+
+// Provider fetches the exchange rates published for a day.
+type Provider interface {
+	// Name identifies the provider in stored rates and logs.
+	Name() string
+	// FetchRates returns the latest rates published on or before date.
+	FetchRates(ctx context.Context, date time.Time) (*models.ExchangeRates, error)
+}
+
+// NewProvider creates the provider selected by config, or nil when none is
+// configured.
+func NewProvider(config Config) (Provider, error) {
+	client := &http.Client{Timeout: config.Timeout}
+	switch config.Provider {
+	case "":
+		return nil, nil
+	case ProviderFrankfurter:
+		return &frankfurterProvider{
+			baseURL: strings.TrimSuffix(config.BaseURL, "/"),
+			base:    models.Currency(config.Base),
+			client:  client,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown exchange rate provider %q", config.Provider)
+	}
+}
+
+type frankfurterProvider struct {
+	baseURL string
+	base    models.Currency
+	client  *http.Client
+}
+
+func (p *frankfurterProvider) Name() string {
+	return ProviderFrankfurter
+}
+
+// FetchRates requests GET {baseURL}/{date}?from={base}. For a day without
+// published rates the API answers with the previous publication.
+func (p *frankfurterProvider) FetchRates(ctx context.Context, date time.Time) (*models.ExchangeRates, error) {
+	endpoint := fmt.Sprintf("%s/%s?from=%s", p.baseURL, date.Format(time.DateOnly), url.QueryEscape(string(p.base)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build rates request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rates provider returned %s", resp.Status)
+	}
+
+	var body struct {
+		Base  string             `json:"base"`
+		Date  string             `json:"date"`
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode rates: %w", err)
+	}
+
+	published, err := time.Parse(time.DateOnly, body.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rates date %q: %w", body.Date, err)
+	}
+	rates := make(map[models.Currency]float64, len(body.Rates))
+	for code, rate := range body.Rates {
+		rates[models.Currency(code)] = rate
+	}
+
+	return &models.ExchangeRates{
+		Date:     published,
+		Base:     models.Currency(body.Base),
+		Rates:    rates,
+		Provider: ProviderFrankfurter,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package currency_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/currency"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider, err := currency.NewProvider(currency.Config{})
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	_, err = currency.NewProvider(currency.Config{Provider: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestFrankfurterProvider_FetchRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/2025-01-18", r.URL.Path)
+		assert.Equal(t, "EUR", r.URL.Query().Get("from"))
+		// Saturday: the API answers with Friday's rates.
+		_, _ = w.Write([]byte(`{"amount":1.0,"base":"EUR","date":"2025-01-17","rates":{"USD":1.03,"GBP":0.84}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := currency.NewProvider(currency.Config{
+		Provider: currency.ProviderFrankfurter,
+		BaseURL:  server.URL + "/",
+		Base:     "EUR",
+		Timeout:  time.Second,
+	})
+	require.NoError(t, err)
+
+	rates, err := provider.FetchRates(t.Context(), time.Date(2025, 1, 18, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC), rates.Date)
+	assert.Equal(t, models.EUR, rates.Base)
+	assert.Equal(t, map[models.Currency]float64{models.USD: 1.03, models.GBP: 0.84}, rates.Rates)
+	assert.Equal(t, currency.ProviderFrankfurter, rates.Provider)
+}
+
+func TestFrankfurterProvider_FetchRates_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "error status", status: http.StatusBadGateway, body: `{}`},
+		{name: "malformed body", status: http.StatusOK, body: `{`},
+		{name: "malformed date", status: http.StatusOK, body: `{"base":"EUR","date":"17/01/2025","rates":{}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(server.Close)
+
+			provider, err := currency.NewProvider(currency.Config{
+				Provider: currency.ProviderFrankfurter,
+				BaseURL:  server.URL,
+				Base:     "EUR",
+				Timeout:  time.Second,
+			})
+			require.NoError(t, err)
+
+			_, err = provider.FetchRates(t.Context(), time.Now())
+			assert.Error(t, err)
+		})
+	}
+}
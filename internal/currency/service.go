@@ -0,0 +1,167 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	cachePrefix = "exchange_rates:"
+	// cacheTTL bounds how long a day keeps resolving to older rates after
+	// newer ones are stored.
+	cacheTTL = time.Hour
+)
+
+// Service converts amounts between currencies using stored daily rates.
+type Service interface {
+	// Convert converts an amount in minor units of from into minor units of
+	// to, using the latest rates published on or before date.
+	Convert(ctx context.Context, amount int64, from, to models.Currency, date time.Time) (int64, error)
+	// RatesOn returns the latest rates published on or before date.
+	RatesOn(ctx context.Context, date time.Time) (*models.ExchangeRates, error)
+	// RefreshRates fetches the rates for date from the provider and stores
+	// them.
+	RefreshRates(ctx context.Context, date time.Time) (*models.ExchangeRates, error)
+}
+
+type service struct {
+	provider   Provider
+	repository repositories.ExchangeRateRepository
+	cache      redis.UniversalClient
+	getTime    clock.NowFn
+}
+
+// NewService creates a Service. provider may be nil when refreshes are
+// disabled, and cache may be nil for commands without a Redis connection.
+func NewService(
+	provider Provider,
+	repository repositories.ExchangeRateRepository,
+	cache redis.UniversalClient,
+	nowFn clock.NowFn,
+) Service {
+	return &service{
+		provider,
+		repository,
+		cache,
+		nowFn,
+	}
+}
+
+// CacheKey returns the Redis key caching the rates that apply on date.
+func CacheKey(date time.Time) string {
+	return cachePrefix + day(date).Format(time.DateOnly)
+}
+
+func (s *service) Convert(
+	ctx context.Context,
+	amount int64,
+	from, to models.Currency,
+	date time.Time,
+) (int64, error) {
+	if from == to {
+		return amount, nil
+	}
+	rates, err := s.RatesOn(ctx, date)
+	if err != nil {
+		return 0, err
+	}
+	return rates.Convert(amount, from, to)
+}
+
+func (s *service) RatesOn(ctx context.Context, date time.Time) (*models.ExchangeRates, error) {
+	date = day(date)
+	key := CacheKey(date)
+	if rates := s.cached(ctx, key); rates != nil {
+		return rates, nil
+	}
+
+	rates, err := s.repository.GetLatestOnOrBefore(ctx, date)
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			return nil, apperror.NewNotFoundError(
+				fmt.Sprintf("No exchange rates available for %s", date.Format(time.DateOnly)))
+		}
+		return nil, err
+	}
+
+	if s.cache != nil {
+		if data, err := json.Marshal(rates); err == nil {
+			if err = s.cache.Set(ctx, key, data, cacheTTL).Err(); err != nil {
+				slog.WarnContext(ctx, "Failed to cache exchange rates",
+					logattr.Key(key),
+					logattr.Error(err))
+			}
+		}
+	}
+	return rates, nil
+}
+
+func (s *service) RefreshRates(ctx context.Context, date time.Time) (*models.ExchangeRates, error) {
+	if s.provider == nil {
+		return nil, errors.New("no exchange rate provider configured")
+	}
+	date = day(date)
+
+	rates, err := s.provider.FetchRates(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	rates.Date = day(rates.Date)
+	rates.FetchedAt = s.getTime()
+	if err = s.repository.Upsert(ctx, rates); err != nil {
+		return nil, err
+	}
+
+	// Drop lookups that resolved to older rates before these were stored.
+	if s.cache != nil {
+		if err = s.cache.Del(ctx, CacheKey(date), CacheKey(rates.Date)).Err(); err != nil {
+			slog.WarnContext(ctx, "Failed to invalidate cached exchange rates",
+				logattr.Error(err))
+		}
+	}
+	return rates, nil
+}
+
+// cached returns the rates stored under key, or nil when there are none.
+// Cache failures are logged and treated as a miss.
+func (s *service) cached(ctx context.Context, key string) *models.ExchangeRates {
+	if s.cache == nil {
+		return nil
+	}
+
+	data, err := s.cache.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			slog.WarnContext(ctx, "Failed to read cached exchange rates",
+				logattr.Key(key),
+				logattr.Error(err))
+		}
+		return nil
+	}
+
+	var rates models.ExchangeRates
+	if err = json.Unmarshal(data, &rates); err != nil {
+		slog.WarnContext(ctx, "Discarding malformed cached exchange rates",
+			logattr.Key(key),
+			logattr.Error(err))
+		return nil
+	}
+	return &rates
+}
+
+// day truncates t to UTC midnight, the key rates are stored under.
+func day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
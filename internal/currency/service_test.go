@@ -0,0 +1,146 @@
+package currency_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/currency"
+	currencymocks "github.com/anuragthepathak/subscription-management/internal/currency/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	mockTime  = time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	mockToday = time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+)
+
+func mockRates() *models.ExchangeRates {
+	return &models.ExchangeRates{
+		Date:     mockToday,
+		Base:     models.EUR,
+		Rates:    map[models.Currency]float64{models.USD: 1.25, models.GBP: 0.8, "JPY": 160},
+		Provider: currency.ProviderFrankfurter,
+	}
+}
+
+func setupService(t *testing.T) (
+	currency.Service,
+	*currencymocks.MockProvider,
+	*repomocks.MockExchangeRateRepository,
+	*miniredis.Miniredis,
+) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	provider := currencymocks.NewMockProvider(t)
+	repo := repomocks.NewMockExchangeRateRepository(t)
+	svc := currency.NewService(provider, repo, rdb, func() time.Time { return mockTime })
+	return svc, provider, repo, mr
+}
+
+func TestService_Convert(t *testing.T) {
+	svc, _, repo, mr := setupService(t)
+	repo.EXPECT().
+		GetLatestOnOrBefore(mock.Anything, mockToday).
+		Return(mockRates(), nil).
+		Once()
+
+	// 1000 USD cents = 800 EUR cents = 640 GBP pence.
+	got, err := svc.Convert(t.Context(), 1000, models.USD, models.GBP, mockTime)
+	require.NoError(t, err)
+	assert.Equal(t, int64(640), got)
+	assert.True(t, mr.Exists(currency.CacheKey(mockTime)))
+
+	// Served from the cache: the repository expectation allows one call only.
+	got, err = svc.Convert(t.Context(), 1000, models.EUR, models.USD, mockTime)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1250), got)
+
+	// 1000 USD cents = 8 EUR = 1280 yen, which has no minor unit.
+	got, err = svc.Convert(t.Context(), 1000, models.USD, "JPY", mockTime)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1280), got)
+
+	// Same currency needs no rates.
+	got, err = svc.Convert(t.Context(), 999, models.GBP, models.GBP, mockTime.AddDate(-10, 0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(999), got)
+
+	_, err = svc.Convert(t.Context(), 1000, models.USD, "CHF", mockTime)
+	assertAppErr(t, err, apperror.ErrValidation)
+}
+
+func TestService_RatesOn_NotFound(t *testing.T) {
+	svc, _, repo, _ := setupService(t)
+	repo.EXPECT().
+		GetLatestOnOrBefore(mock.Anything, mockToday).
+		Return(nil, apperror.NewNotFoundError("Document not found")).
+		Once()
+
+	_, err := svc.RatesOn(t.Context(), mockTime)
+	assertAppErr(t, err, apperror.ErrNotFound)
+}
+
+func TestService_RefreshRates(t *testing.T) {
+	svc, provider, repo, mr := setupService(t)
+	published := mockRates()
+	published.Date = mockToday.AddDate(0, 0, -1).Add(time.Hour)
+
+	// Stale lookups for both days must be dropped.
+	require.NoError(t, mr.Set(currency.CacheKey(mockToday), "{}"))
+	require.NoError(t, mr.Set(currency.CacheKey(mockToday.AddDate(0, 0, -1)), "{}"))
+
+	provider.EXPECT().FetchRates(mock.Anything, mockToday).Return(published, nil).Once()
+	repo.EXPECT().
+		Upsert(mock.Anything, mock.MatchedBy(func(r *models.ExchangeRates) bool {
+			return r.Date.Equal(mockToday.AddDate(0, 0, -1)) && r.FetchedAt.Equal(mockTime)
+		})).
+		Return(nil).
+		Once()
+
+	rates, err := svc.RefreshRates(t.Context(), mockTime)
+	require.NoError(t, err)
+	assert.Equal(t, mockToday.AddDate(0, 0, -1), rates.Date)
+	assert.False(t, mr.Exists(currency.CacheKey(mockToday)))
+	assert.False(t, mr.Exists(currency.CacheKey(mockToday.AddDate(0, 0, -1))))
+}
+
+func TestService_RefreshRates_Errors(t *testing.T) {
+	t.Run("provider failure", func(t *testing.T) {
+		svc, provider, _, _ := setupService(t)
+		provider.EXPECT().FetchRates(mock.Anything, mockToday).Return(nil, errors.New("unavailable")).Once()
+
+		_, err := svc.RefreshRates(t.Context(), mockTime)
+		assert.Error(t, err)
+	})
+
+	t.Run("no provider", func(t *testing.T) {
+		repo := repomocks.NewMockExchangeRateRepository(t)
+		svc := currency.NewService(nil, repo, nil, time.Now)
+
+		_, err := svc.RefreshRates(t.Context(), mockTime)
+		assert.Error(t, err)
+	})
+}
+
+func assertAppErr(t *testing.T, err error, code apperror.ErrorCode) {
+	t.Helper()
+
+	appErr, ok := errors.AsType[apperror.AppError](err)
+	require.True(t, ok, "expected an AppError, got %v", err)
+	assert.Equal(t, code, appErr.Code())
+}
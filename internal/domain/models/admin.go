@@ -0,0 +1,28 @@
+package models
+
+// RateLimitState describes how much of a rate limit a client has used. It is
+// read without consuming a request.
+type RateLimitState struct {
+	Limiter    string `json:"limiter"` // Key prefix of the limiter, e.g. "app".
+	Key        string `json:"key"`     // IP address or user ID the limit applies to.
+	Rate       int    `json:"rate"`
+	Burst      int    `json:"burst"`
+	Period     string `json:"period"`
+	Remaining  int    `json:"remaining"`
+	Limited    bool   `json:"limited"`
+	RetryAfter string `json:"retryAfter,omitempty"` // Set while Limited.
+	ResetAfter string `json:"resetAfter"`           // Time until the full burst is available again.
+}
+
+// ReminderDedupeEntry is a marker recording that a renewal reminder was sent.
+// While it exists, the scheduler does not enqueue that reminder again.
+type ReminderDedupeEntry struct {
+	Key        string `json:"key"`
+	DaysBefore int    `json:"daysBefore"`
+	ExpiresIn  string `json:"expiresIn"`
+}
+
+// ClearReminderDedupeResponse reports how many markers were deleted.
+type ClearReminderDedupeResponse struct {
+	Deleted int `json:"deleted"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// PriceTotal is the combined price of active subscriptions sharing a currency
+// and billing frequency.
+type PriceTotal struct {
+	Currency  Currency  `bson:"currency"`
+	Frequency Frequency `bson:"frequency"`
+	Total     int64     `bson:"total"`
+}
+
+// SubscriptionCounts holds the number of subscriptions per status.
+type SubscriptionCounts struct {
+	Active    int64 `json:"active"`
+	Canceled  int64 `json:"canceled"`
+	Expired   int64 `json:"expired"`
+	PastDue   int64 `json:"pastDue"`
+	Paused    int64 `json:"paused"`
+	Suspended int64 `json:"suspended"`
+}
+
+// EmailDeliveryStats counts the emails handed to the SMTP server in a day.
+type EmailDeliveryStats struct {
+	Sent   int64 `json:"sent"`
+	Failed int64 `json:"failed"`
+}
+
+// DeliveryStats counts the reminders delivered through one channel in a day.
+type DeliveryStats struct {
+	Sent   int64 `json:"sent"`
+	Failed int64 `json:"failed"`
+}
+
+// QueueStats is a snapshot of the task queue by task state.
+type QueueStats struct {
+	Name      string `json:"name"`
+	Pending   int    `json:"pending"`
+	Active    int    `json:"active"`
+	Scheduled int    `json:"scheduled"`
+	Retry     int    `json:"retry"`
+	Archived  int    `json:"archived"` // Tasks that exhausted their retries.
+	Paused    bool   `json:"paused"`
+}
+
+// PlatformStatsResponse summarizes the health of the platform for admins.
+// Daily figures cover the current UTC day.
+type PlatformStatsResponse struct {
+	GeneratedAt   time.Time          `json:"generatedAt"`
+	TotalUsers    int64              `json:"totalUsers"`
+	Subscriptions SubscriptionCounts `json:"subscriptions"`
+	RenewalsToday int64              `json:"renewalsToday"`
+	EmailsToday   EmailDeliveryStats `json:"emailsToday"`
+	// RemindersToday counts renewal and trial reminders per channel.
+	RemindersToday map[NotificationChannel]DeliveryStats `json:"remindersToday"`
+	Queue          *QueueStats                           `json:"queue"` // Nil when the queue could not be inspected.
+	// MonthlyRecurring and AnnualRecurring are what active subscriptions
+	// bring in per month and per year, per currency.
+	MonthlyRecurring []CurrencyAmount `json:"monthlyRecurring"`
+	AnnualRecurring  []CurrencyAmount `json:"annualRecurring"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Attachment is a receipt or other document a user uploaded for one of their
+// subscriptions. The content is a stored file; the attachment records which
+// subscription it belongs to.
+type Attachment struct {
+	ID             bson.ObjectID `bson:"_id"`
+	SubscriptionID bson.ObjectID `bson:"subscription_id"`
+	UserID         bson.ObjectID `bson:"user_id"`
+	FileID         bson.ObjectID `bson:"file_id"`
+	Filename       string        `bson:"filename"`
+	ContentType    string        `bson:"content_type"` // Detected from the content, not taken from the client.
+	Size           int64         `bson:"size"`
+	CreatedAt      time.Time     `bson:"created_at"`
+}
+
+// AttachmentKey is the file storage key of the content of an attachment.
+func AttachmentKey(subscriptionID, attachmentID bson.ObjectID) string {
+	return "attachment/" + subscriptionID.Hex() + "/" + attachmentID.Hex()
+}
+
+// AttachmentUpload is an uploaded file as read from the request.
+type AttachmentUpload struct {
+	Filename string
+	Content  io.Reader
+}
+
+// SignedAttachment pairs an attachment with a time-limited download URL.
+type SignedAttachment struct {
+	Attachment *Attachment
+	URL        string
+	ExpiresAt  time.Time
+}
+
+// AttachmentResponse represents the response for an attachment.
+type AttachmentResponse struct {
+	ID             string    `json:"id"`
+	SubscriptionID string    `json:"subscriptionId"`
+	Filename       string    `json:"filename"`
+	ContentType    string    `json:"contentType"`
+	Size           int64     `json:"size"`
+	CreatedAt      time.Time `json:"createdAt"`
+	DownloadURL    string    `json:"downloadUrl"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+}
+
+func (a *SignedAttachment) ToResponse() *AttachmentResponse {
+	return &AttachmentResponse{
+		ID:             a.Attachment.ID.Hex(),
+		SubscriptionID: a.Attachment.SubscriptionID.Hex(),
+		Filename:       a.Attachment.Filename,
+		ContentType:    a.Attachment.ContentType,
+		Size:           a.Attachment.Size,
+		CreatedAt:      a.Attachment.CreatedAt,
+		DownloadURL:    a.URL,
+		ExpiresAt:      a.ExpiresAt,
+	}
+}
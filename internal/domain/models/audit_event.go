@@ -0,0 +1,180 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AuditAction names a state change recorded in the audit log. The part
+// before the dot is the type of the resource that changed.
+type AuditAction string
+
+const (
+	AuditSubscriptionCreated      AuditAction = "subscription.created"
+	AuditSubscriptionCanceled     AuditAction = "subscription.canceled"
+	AuditSubscriptionRenewed      AuditAction = "subscription.renewed"
+	AuditSubscriptionPaused       AuditAction = "subscription.paused"
+	AuditSubscriptionResumed      AuditAction = "subscription.resumed"
+	AuditSubscriptionPriceChanged AuditAction = "subscription.price_changed"
+	AuditSubscriptionUpdated      AuditAction = "subscription.updated"
+	AuditSubscriptionDeleted      AuditAction = "subscription.deleted"
+	AuditSubscriptionReconciled   AuditAction = "subscription.reconciled"
+	AuditBillStatusChanged        AuditAction = "bill.status_changed"
+	AuditUserCreated              AuditAction = "user.created"
+	AuditUserClosed               AuditAction = "user.closed"
+	AuditUserDeleted              AuditAction = "user.deleted"
+	AuditUserLoggedIn             AuditAction = "user.logged_in"
+)
+
+// AuditActions lists every audited action.
+var AuditActions = []AuditAction{
+	AuditSubscriptionCreated,
+	AuditSubscriptionCanceled,
+	AuditSubscriptionRenewed,
+	AuditSubscriptionPaused,
+	AuditSubscriptionResumed,
+	AuditSubscriptionPriceChanged,
+	AuditSubscriptionUpdated,
+	AuditSubscriptionDeleted,
+	AuditSubscriptionReconciled,
+	AuditBillStatusChanged,
+	AuditUserCreated,
+	AuditUserClosed,
+	AuditUserDeleted,
+	AuditUserLoggedIn,
+}
+
+// Valid reports whether a is a known audit action.
+func (a AuditAction) Valid() bool {
+	return slices.Contains(AuditActions, a)
+}
+
+// ResourceType returns the type of resource the action changes, e.g.
+// "subscription".
+func (a AuditAction) ResourceType() string {
+	resourceType, _, _ := strings.Cut(string(a), ".")
+	return resourceType
+}
+
+// AuditEvent records who changed what. Snapshots hold the API representation
+// of the resource, so secrets such as password hashes are never stored.
+type AuditEvent struct {
+	ID           bson.ObjectID  `bson:"_id"`
+	Action       AuditAction    `bson:"action"`
+	Actor        Actor          `bson:"actor"`
+	ActorID      *bson.ObjectID `bson:"actor_id,omitempty"` // Unset for the system.
+	ResourceType string         `bson:"resource_type"`
+	ResourceID   bson.ObjectID  `bson:"resource_id"`
+	Before       []byte         `bson:"before,omitempty"` // JSON snapshot before the change; unset for creations.
+	After        []byte         `bson:"after,omitempty"`  // JSON snapshot after the change; unset for deletions.
+	TraceID      string         `bson:"trace_id,omitempty"`
+	OccurredAt   time.Time      `bson:"occurred_at"`
+}
+
+// NewAuditEvent builds an event with before and after encoded as JSON
+// snapshots. A nil snapshot is left unset.
+func NewAuditEvent(
+	action AuditAction,
+	actor Actor,
+	actorID *bson.ObjectID,
+	resourceID bson.ObjectID,
+	before, after any,
+	now time.Time,
+) (*AuditEvent, error) {
+	event := &AuditEvent{
+		ID:           bson.NewObjectID(),
+		Action:       action,
+		Actor:        actor,
+		ActorID:      actorID,
+		ResourceType: action.ResourceType(),
+		ResourceID:   resourceID,
+		OccurredAt:   now,
+	}
+	var err error
+	if event.Before, err = snapshot(before); err != nil {
+		return nil, fmt.Errorf("failed to marshal %s snapshot: %w", action, err)
+	}
+	if event.After, err = snapshot(after); err != nil {
+		return nil, fmt.Errorf("failed to marshal %s snapshot: %w", action, err)
+	}
+	return event, nil
+}
+
+func snapshot(v any) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// AuditEventResponse represents the response for an audit event.
+type AuditEventResponse struct {
+	ID           string          `json:"id"`
+	Action       string          `json:"action"`
+	Actor        string          `json:"actor"`
+	ActorID      string          `json:"actorId,omitempty"`
+	ResourceType string          `json:"resourceType"`
+	ResourceID   string          `json:"resourceId"`
+	Before       json.RawMessage `json:"before,omitempty"`
+	After        json.RawMessage `json:"after,omitempty"`
+	TraceID      string          `json:"traceId,omitempty"`
+	OccurredAt   time.Time       `json:"occurredAt"`
+}
+
+// ToResponse converts an AuditEvent to an AuditEventResponse.
+func (e *AuditEvent) ToResponse() *AuditEventResponse {
+	resp := &AuditEventResponse{
+		ID:           e.ID.Hex(),
+		Action:       string(e.Action),
+		Actor:        string(e.Actor),
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID.Hex(),
+		Before:       e.Before,
+		After:        e.After,
+		TraceID:      e.TraceID,
+		OccurredAt:   e.OccurredAt,
+	}
+	if e.ActorID != nil {
+		resp.ActorID = e.ActorID.Hex()
+	}
+	return resp
+}
+
+// AuditQuery filters and pages the audit log, newest first. Zero fields do
+// not filter.
+type AuditQuery struct {
+	Action       AuditAction
+	ActorID      *bson.ObjectID
+	ResourceType string
+	ResourceID   *bson.ObjectID
+	From         time.Time // Inclusive.
+	To           time.Time // Exclusive.
+	PageQuery
+}
+
+// Normalize fills in the default page size and rejects unknown actions and
+// out-of-range values.
+func (q *AuditQuery) Normalize() error {
+	if err := q.PageQuery.Normalize(); err != nil {
+		return err
+	}
+	if q.Action != "" && !q.Action.Valid() {
+		return apperror.NewValidationError("unknown audit action %s", q.Action)
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return apperror.NewValidationError("from must be before to")
+	}
+	return nil
+}
+
+// AuditPage is one page of an audit log listing.
+type AuditPage struct {
+	Items []*AuditEvent
+	Total int64 // Matching events across all pages.
+}
@@ -0,0 +1,71 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestNewAuditEvent(t *testing.T) {
+	now := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	actorID := bson.NewObjectID()
+	resourceID := bson.NewObjectID()
+
+	event, err := models.NewAuditEvent(
+		models.AuditSubscriptionCanceled,
+		models.ActorUser,
+		&actorID,
+		resourceID,
+		map[string]string{"status": "active"},
+		map[string]string{"status": "canceled"},
+		now,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "subscription", event.ResourceType)
+	assert.JSONEq(t, `{"status":"active"}`, string(event.Before))
+	assert.JSONEq(t, `{"status":"canceled"}`, string(event.After))
+
+	resp := event.ToResponse()
+	assert.Equal(t, actorID.Hex(), resp.ActorID)
+	assert.Equal(t, resourceID.Hex(), resp.ResourceID)
+	assert.Equal(t, now, resp.OccurredAt)
+
+	created, err := models.NewAuditEvent(models.AuditUserCreated, models.ActorSystem, nil, resourceID, nil, map[string]string{}, now)
+	require.NoError(t, err)
+	assert.Nil(t, created.Before, "a nil snapshot should stay unset")
+	assert.Empty(t, created.ToResponse().ActorID)
+}
+
+func TestAuditQuery_Normalize(t *testing.T) {
+	now := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		query     models.AuditQuery
+		wantLimit int
+		wantErr   bool
+	}{
+		{name: "defaults the page size", query: models.AuditQuery{}, wantLimit: models.DefaultPageLimit},
+		{name: "keeps a valid action", query: models.AuditQuery{Action: models.AuditUserLoggedIn, PageQuery: models.PageQuery{Limit: 5}}, wantLimit: 5},
+		{name: "rejects an unknown action", query: models.AuditQuery{Action: "user.renamed"}, wantErr: true},
+		{name: "rejects an oversized page", query: models.AuditQuery{PageQuery: models.PageQuery{Limit: models.MaxPageLimit + 1}}, wantErr: true},
+		{name: "rejects a negative offset", query: models.AuditQuery{PageQuery: models.PageQuery{Offset: -1}}, wantErr: true},
+		{name: "rejects an empty time range", query: models.AuditQuery{From: now, To: now}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Normalize()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, tt.query.Limit)
+		})
+	}
+}
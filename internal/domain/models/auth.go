@@ -19,6 +19,10 @@ type Claims struct {
 	UserID string    `json:"userId"`
 	Email  string    `json:"email"`
 	Type   TokenType `json:"type"`
+	Role   Role      `json:"role,omitempty"` // Empty in tokens issued before roles were added; treated as UserRole.
+	// SessionID names the Session the token belongs to. Empty in tokens
+	// issued before sessions were tracked.
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -27,15 +31,43 @@ type TokenResponse struct {
 	AccessToken  string    `json:"accessToken"`
 	RefreshToken string    `json:"refreshToken"`
 	ExpiresAt    time.Time `json:"expiresAt"`
+	// RefreshExpiresAt is when the refresh token expires, and with it the
+	// session unless it is refreshed.
+	RefreshExpiresAt time.Time `json:"refreshExpiresAt"`
 }
 
 // LoginRequest represents user login credentials.
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
+	// Device names the device in the session list, e.g. "Work laptop".
+	Device string `json:"device,omitempty" validate:"max=100"`
 }
 
 // RefreshRequest represents user refresh token request.
 type RefreshRequest struct {
 	RefreshToken string `json:"refreshToken" validate:"required"`
 }
+
+// LogoutRequest carries the refresh token to revoke alongside the access token.
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+}
+
+// JWKS is a JSON Web Key Set (RFC 7517) of the public keys tokens are
+// signed with. Member names follow the RFC rather than the API's casing.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is a public key of a JWKS.
+type JWK struct {
+	KeyType   string `json:"kty"`           // RSA or OKP.
+	KeyID     string `json:"kid"`           // Matches the kid header of tokens.
+	Use       string `json:"use"`           // Always sig.
+	Algorithm string `json:"alg"`           // RS256 or EdDSA.
+	N         string `json:"n,omitempty"`   // RSA modulus, base64url.
+	E         string `json:"e,omitempty"`   // RSA exponent, base64url.
+	Curve     string `json:"crv,omitempty"` // Ed25519 for OKP keys.
+	X         string `json:"x,omitempty"`   // Ed25519 public key, base64url.
+}
@@ -11,17 +11,15 @@ import (
 type PaymentStatus string
 
 const (
-	Paid     PaymentStatus = "paid"
+	Paid PaymentStatus = "paid"
+	// Pending marks a bill whose charge the payment provider is still
+	// processing, e.g. a bank debit. It becomes paid or failed once the
+	// charge settles.
+	Pending  PaymentStatus = "pending"
 	Refunded PaymentStatus = "refunded"
-)
-
-// Currency represents valid currency types.
-type Currency string
-
-const (
-	USD Currency = "USD"
-	EUR Currency = "EUR"
-	GBP Currency = "GBP"
+	// Failed marks a renewal charge that did not go through; it puts the
+	// subscription into dunning.
+	Failed PaymentStatus = "failed"
 )
 
 type Bill struct {
@@ -32,6 +30,8 @@ type Bill struct {
 	StartDate      time.Time     `bson:"start_date"`
 	EndDate        time.Time     `bson:"end_date"`
 	Status         PaymentStatus `bson:"status"`
+	ChargeID       string        `bson:"charge_id,omitempty"`      // Charge at the payment provider.
+	FailureReason  string        `bson:"failure_reason,omitempty"` // Why the charge was declined.
 	CreatedAt      time.Time     `bson:"created_at"`
 	UpdatedAt      time.Time     `bson:"updated_at"`
 }
@@ -44,8 +44,8 @@ func (b *Bill) Validate() error {
 	if b.SubscriptionID.IsZero() {
 		return apperror.NewValidationError("subscription_id is required")
 	}
-	if b.Currency != USD && b.Currency != EUR && b.Currency != GBP {
-		return apperror.NewValidationError("currency must be one of USD, EUR, GBP")
+	if !b.Currency.Valid() {
+		return apperror.NewValidationError("currency must be an ISO 4217 currency code")
 	}
 	if b.StartDate.IsZero() {
 		return apperror.NewValidationError("start_date is required")
@@ -56,8 +56,8 @@ func (b *Bill) Validate() error {
 	if b.EndDate.Before(b.StartDate) {
 		return apperror.NewValidationError("end_date must be after start_date")
 	}
-	if b.Status != Paid && b.Status != Refunded {
-		return apperror.NewValidationError("status must be either paid or refunded")
+	if b.Status != Paid && b.Status != Pending && b.Status != Refunded && b.Status != Failed {
+		return apperror.NewValidationError("status must be one of paid, pending, refunded, or failed")
 	}
 	return nil
 }
@@ -71,8 +71,62 @@ type BillResponse struct {
 	EndDate        time.Time     `json:"endDate"`   // exclusive
 	Status         PaymentStatus `json:"status"`
 	SubscriptionID string        `json:"subscriptionId"`
+	ChargeID       string        `json:"chargeId,omitempty"`
+	FailureReason  string        `json:"failureReason,omitempty"`
 	CreatedAt      time.Time     `json:"createdAt"`
 	UpdatedAt      time.Time     `json:"updatedAt"`
+
+	AmountFormatted string `json:"amountFormatted"` // e.g. "USD 15.49".
+}
+
+// BillQuery pages the bills of a subscription, newest first.
+type BillQuery struct {
+	PageQuery
+}
+
+// BillFilter selects bills across subscriptions, e.g. for exports. Zero
+// values match any bill of the listed subscriptions.
+type BillFilter struct {
+	SubscriptionIDs []bson.ObjectID
+	Status          PaymentStatus
+	From            time.Time // Bills starting on or after.
+	To              time.Time // Bills starting before.
+}
+
+// Filter returns the Mongo filter matching the bill filter.
+func (f *BillFilter) Filter() bson.M {
+	// A nil slice would be encoded as null, which $in rejects.
+	ids := f.SubscriptionIDs
+	if ids == nil {
+		ids = []bson.ObjectID{}
+	}
+	filter := bson.M{"subscription_id": bson.M{"$in": ids}}
+	if f.Status != "" {
+		filter["status"] = f.Status
+	}
+	startDate := bson.M{}
+	if !f.From.IsZero() {
+		startDate["$gte"] = f.From
+	}
+	if !f.To.IsZero() {
+		startDate["$lt"] = f.To
+	}
+	if len(startDate) > 0 {
+		filter["start_date"] = startDate
+	}
+	return filter
+}
+
+// BillPage is one page of a bill listing.
+type BillPage struct {
+	Items []*Bill
+	Total int64 // Bills of the subscription across all pages.
+}
+
+// BillStatusRequest represents an administrative change of a bill's payment
+// status.
+type BillStatusRequest struct {
+	Status PaymentStatus `json:"status" validate:"required,oneof=paid failed"`
 }
 
 func (b *Bill) ToResponse() *BillResponse {
@@ -84,7 +138,11 @@ func (b *Bill) ToResponse() *BillResponse {
 		Currency:       b.Currency,
 		Status:         b.Status,
 		SubscriptionID: b.SubscriptionID.Hex(),
+		ChargeID:       b.ChargeID,
+		FailureReason:  b.FailureReason,
 		CreatedAt:      b.CreatedAt,
 		UpdatedAt:      b.UpdatedAt,
+
+		AmountFormatted: FormatMoney(b.Amount, b.Currency),
 	}
 }
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Budget caps the monthly subscription spend of a user, overall and per
+// category. A user has at most one budget, keyed by their ID.
+type Budget struct {
+	UserID     bson.ObjectID      `bson:"_id"`
+	Currency   Currency           `bson:"currency"`
+	Overall    int64              `bson:"overall"`    // In minor units of Currency; 0 means no overall cap.
+	Categories map[Category]int64 `bson:"categories"` // In minor units of Currency.
+	CreatedAt  time.Time          `bson:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at"`
+}
+
+// Validate validates the budget fields.
+func (b *Budget) Validate() error {
+	if !b.Currency.Valid() {
+		return apperror.NewValidationError("currency must be an ISO 4217 currency code")
+	}
+	if b.Overall < 0 {
+		return apperror.NewValidationError("overall budget must not be negative")
+	}
+	for category, amount := range b.Categories {
+		if !category.Valid() {
+			return apperror.NewValidationError("invalid category %s", category)
+		}
+		if amount <= 0 {
+			return apperror.NewValidationError("budget for %s must be greater than 0", category)
+		}
+	}
+	if b.Overall == 0 && len(b.Categories) == 0 {
+		return apperror.NewValidationError("an overall or category budget is required")
+	}
+	if b.UserID.IsZero() {
+		return apperror.NewValidationError("user ID is required")
+	}
+	return nil
+}
+
+// BudgetRequest represents the data structure for budget API requests.
+type BudgetRequest struct {
+	Currency   Currency           `json:"currency" validate:"required"`
+	Overall    int64              `json:"overall" validate:"gte=0"`
+	Categories map[Category]int64 `json:"categories"`
+}
+
+// ToModel converts a BudgetRequest to a Budget model.
+func (r *BudgetRequest) ToModel() *Budget {
+	return &Budget{
+		Currency:   ParseCurrency(string(r.Currency)),
+		Overall:    r.Overall,
+		Categories: r.Categories,
+	}
+}
+
+// BudgetResponse represents the data structure for budget API responses.
+type BudgetResponse struct {
+	Currency   string           `json:"currency"`
+	Overall    int64            `json:"overall"` // In minor units of Currency; 0 means no overall cap.
+	Categories map[string]int64 `json:"categories"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	UpdatedAt  time.Time        `json:"updatedAt"`
+}
+
+// ToResponse converts a Budget model to a BudgetResponse.
+func (b *Budget) ToResponse() *BudgetResponse {
+	categories := make(map[string]int64, len(b.Categories))
+	for category, amount := range b.Categories {
+		categories[string(category)] = amount
+	}
+	return &BudgetResponse{
+		Currency:   string(b.Currency),
+		Overall:    b.Overall,
+		Categories: categories,
+		CreatedAt:  b.CreatedAt,
+		UpdatedAt:  b.UpdatedAt,
+	}
+}
+
+// BudgetLine compares the monthly spend of one category, or of all
+// subscriptions, against its budget.
+type BudgetLine struct {
+	Category string          `json:"category,omitempty"` // Empty for the overall line.
+	Budget   *CurrencyAmount `json:"budget,omitempty"`   // Nil when no budget is set.
+	Actual   CurrencyAmount  `json:"actual"`
+	Exceeded bool            `json:"exceeded"`
+}
+
+// BudgetStatsResponse reports the monthly spend of a user's active
+// subscriptions against their budget, in the budget currency. Yearly
+// subscriptions count for a twelfth of their price.
+type BudgetStatsResponse struct {
+	Currency    string       `json:"currency"`
+	Overall     BudgetLine   `json:"overall"`
+	Categories  []BudgetLine `json:"categories"`
+	RatesDate   string       `json:"ratesDate,omitempty"` // Publication day of the rates used, as YYYY-MM-DD.
+	GeneratedAt time.Time    `json:"generatedAt"`
+}
+
+// BudgetAlert reports that a user's monthly spend exceeds a budget.
+type BudgetAlert struct {
+	UserID   bson.ObjectID
+	Category Category // Empty for the overall budget.
+	Budget   int64    // In minor units of Currency.
+	Actual   int64    // In minor units of Currency.
+	Currency Currency
+}
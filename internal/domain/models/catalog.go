@@ -0,0 +1,106 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// CatalogEntry is a well-known service users subscribe to, such as Netflix,
+// with its typical plans. Entries come from the curated list shipped with
+// the service.
+type CatalogEntry struct {
+	ID        string        `bson:"_id"` // Slug, e.g. "netflix".
+	Name      string        `bson:"name"`
+	Aliases   []string      `bson:"aliases,omitempty"` // Other names searches should find it by.
+	Category  Category      `bson:"category"`
+	Website   string        `bson:"website"`
+	LogoURL   string        `bson:"logo_url,omitempty"`
+	Plans     []CatalogPlan `bson:"plans"` // The first plan is the default.
+	UpdatedAt time.Time     `bson:"updated_at"`
+}
+
+// CatalogPlan is a typical plan of a catalog entry. Prices vary by country,
+// so they only pre-fill a subscription.
+type CatalogPlan struct {
+	Name      string    `bson:"name"`
+	Price     int64     `bson:"price"` // In minor units of Currency.
+	Currency  Currency  `bson:"currency"`
+	Frequency Frequency `bson:"frequency"`
+}
+
+// Plan returns the plan with the given name, ignoring case, or the default
+// plan for an empty name.
+func (e *CatalogEntry) Plan(name string) (*CatalogPlan, bool) {
+	if len(e.Plans) == 0 {
+		return nil, false
+	}
+	if name == "" {
+		return &e.Plans[0], true
+	}
+	for i := range e.Plans {
+		if strings.EqualFold(e.Plans[i].Name, strings.TrimSpace(name)) {
+			return &e.Plans[i], true
+		}
+	}
+	return nil, false
+}
+
+// Prefill sets the fields of subscription that were left empty from the
+// entry and plan, and links the subscription to the entry. A given price
+// keeps its own currency.
+func (e *CatalogEntry) Prefill(subscription *Subscription, plan *CatalogPlan) {
+	subscription.CatalogID = e.ID
+	if subscription.Name == "" {
+		subscription.Name = e.Name
+	}
+	if subscription.Category == "" {
+		subscription.Category = e.Category
+	}
+	if subscription.Price == 0 {
+		subscription.Price = plan.Price
+		subscription.Currency = plan.Currency
+	}
+	if subscription.Frequency == "" {
+		subscription.Frequency = plan.Frequency
+	}
+}
+
+// CatalogPlanResponse represents a plan in a catalog entry response.
+type CatalogPlanResponse struct {
+	Name           string `json:"name"`
+	Price          int64  `json:"price"` // In minor units of Currency.
+	Currency       string `json:"currency"`
+	Frequency      string `json:"frequency"`
+	PriceFormatted string `json:"priceFormatted"`
+}
+
+// CatalogEntryResponse represents the response for a catalog entry.
+type CatalogEntryResponse struct {
+	ID       string                `json:"id"`
+	Name     string                `json:"name"`
+	Category string                `json:"category"`
+	Website  string                `json:"website"`
+	LogoURL  string                `json:"logoUrl,omitempty"`
+	Plans    []CatalogPlanResponse `json:"plans"`
+}
+
+func (e *CatalogEntry) ToResponse() *CatalogEntryResponse {
+	plans := make([]CatalogPlanResponse, len(e.Plans))
+	for i, plan := range e.Plans {
+		plans[i] = CatalogPlanResponse{
+			Name:           plan.Name,
+			Price:          plan.Price,
+			Currency:       string(plan.Currency),
+			Frequency:      string(plan.Frequency),
+			PriceFormatted: FormatMoney(plan.Price, plan.Currency),
+		}
+	}
+	return &CatalogEntryResponse{
+		ID:       e.ID,
+		Name:     e.Name,
+		Category: string(e.Category),
+		Website:  e.Website,
+		LogoURL:  e.LogoURL,
+		Plans:    plans,
+	}
+}
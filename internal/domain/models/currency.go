@@ -0,0 +1,109 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Currency is an ISO 4217 alphabetic currency code. Amounts are stored as
+// int64 counts of the currency's minor unit, e.g. cents for USD.
+type Currency string
+
+const (
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+)
+
+// minorUnits maps every active ISO 4217 currency to the number of decimal
+// digits of its minor unit. Funds, precious metals, and withdrawn codes are
+// not accepted.
+var minorUnits = map[Currency]int{
+	// No minor unit.
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	// Cents and equivalents.
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "AOA": 2, "ARS": 2, "AUD": 2, "AWG": 2,
+	"AZN": 2, "BAM": 2, "BBD": 2, "BDT": 2, "BMD": 2, "BND": 2, "BOB": 2, "BRL": 2,
+	"BSD": 2, "BTN": 2, "BWP": 2, "BYN": 2, "BZD": 2, "CAD": 2, "CDF": 2, "CHF": 2,
+	"CNY": 2, "COP": 2, "CRC": 2, "CUP": 2, "CVE": 2, "CZK": 2, "DKK": 2, "DOP": 2,
+	"DZD": 2, "EGP": 2, "ERN": 2, "ETB": 2, "EUR": 2, "FJD": 2, "FKP": 2, "GBP": 2,
+	"GEL": 2, "GHS": 2, "GIP": 2, "GMD": 2, "GTQ": 2, "GYD": 2, "HKD": 2, "HNL": 2,
+	"HTG": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2, "IRR": 2, "JMD": 2, "KES": 2,
+	"KGS": 2, "KHR": 2, "KPW": 2, "KYD": 2, "KZT": 2, "LAK": 2, "LBP": 2, "LKR": 2,
+	"LRD": 2, "LSL": 2, "MAD": 2, "MDL": 2, "MGA": 2, "MKD": 2, "MMK": 2, "MNT": 2,
+	"MOP": 2, "MRU": 2, "MUR": 2, "MVR": 2, "MWK": 2, "MXN": 2, "MYR": 2, "MZN": 2,
+	"NAD": 2, "NGN": 2, "NIO": 2, "NOK": 2, "NPR": 2, "NZD": 2, "PAB": 2, "PEN": 2,
+	"PGK": 2, "PHP": 2, "PKR": 2, "PLN": 2, "QAR": 2, "RON": 2, "RSD": 2, "RUB": 2,
+	"SAR": 2, "SBD": 2, "SCR": 2, "SDG": 2, "SEK": 2, "SGD": 2, "SHP": 2, "SLE": 2,
+	"SOS": 2, "SRD": 2, "SSP": 2, "STN": 2, "SVC": 2, "SYP": 2, "SZL": 2, "THB": 2,
+	"TJS": 2, "TMT": 2, "TOP": 2, "TRY": 2, "TTD": 2, "TWD": 2, "TZS": 2, "UAH": 2,
+	"USD": 2, "UYU": 2, "UZS": 2, "VED": 2, "VES": 2, "WST": 2, "XCD": 2, "XCG": 2,
+	"YER": 2, "ZAR": 2, "ZMW": 2, "ZWG": 2,
+	// Thousandths.
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// Valid reports whether c is an active ISO 4217 currency code.
+func (c Currency) Valid() bool {
+	_, ok := minorUnits[c]
+	return ok
+}
+
+// CurrencyCodes returns every active ISO 4217 currency code, sorted.
+func CurrencyCodes() []Currency {
+	return slices.Sorted(maps.Keys(minorUnits))
+}
+
+// MinorUnits returns the number of decimal digits of the minor unit of c.
+// Unknown codes are treated as having two.
+func (c Currency) MinorUnits() int {
+	if digits, ok := minorUnits[c]; ok {
+		return digits
+	}
+	return 2
+}
+
+// ParseCurrency normalizes an ISO 4217 code, accepting any letter case.
+func ParseCurrency(code string) Currency {
+	return Currency(strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// FormatMoney renders an amount in minor units with its currency code and
+// thousands separators, e.g. "USD 1,234.56" or "JPY 1,500".
+func FormatMoney(amount int64, currency Currency) string {
+	sign := ""
+	// Negate in uint64 so the minimum int64 does not overflow.
+	abs := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		abs = -abs
+	}
+
+	digits := currency.MinorUnits()
+	scale := uint64(1)
+	for range digits {
+		scale *= 10
+	}
+
+	formatted := groupThousands(abs / scale)
+	if digits > 0 {
+		formatted += fmt.Sprintf(".%0*d", digits, abs%scale)
+	}
+	return fmt.Sprintf("%s %s%s", currency, sign, formatted)
+}
+
+// groupThousands formats n with a comma between every three digits.
+func groupThousands(n uint64) string {
+	s := fmt.Sprint(n)
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package models_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrency_Valid(t *testing.T) {
+	for _, code := range []models.Currency{models.USD, "JPY", "KWD", "INR", "XOF"} {
+		assert.True(t, code.Valid(), code)
+	}
+	for _, code := range []models.Currency{"", "usd", "XYZ", "XAU", "HRK"} {
+		assert.False(t, code.Valid(), code)
+	}
+}
+
+func TestCurrency_MinorUnits(t *testing.T) {
+	assert.Equal(t, 2, models.USD.MinorUnits())
+	assert.Equal(t, 0, models.Currency("JPY").MinorUnits())
+	assert.Equal(t, 3, models.Currency("BHD").MinorUnits())
+	assert.Equal(t, 2, models.Currency("XYZ").MinorUnits())
+}
+
+func TestParseCurrency(t *testing.T) {
+	assert.Equal(t, models.EUR, models.ParseCurrency(" eur "))
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		currency models.Currency
+		want     string
+	}{
+		{1549, models.USD, "USD 15.49"},
+		{5, models.EUR, "EUR 0.05"},
+		{123456789, models.GBP, "GBP 1,234,567.89"},
+		{1500, "JPY", "JPY 1,500"},
+		{1234567, "KWD", "KWD 1,234.567"},
+		{-1000, models.USD, "USD -10.00"},
+		{0, models.USD, "USD 0.00"},
+		{math.MinInt64, "JPY", "JPY -9,223,372,036,854,775,808"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, models.FormatMoney(tt.amount, tt.currency))
+		})
+	}
+}
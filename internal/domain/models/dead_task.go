@@ -0,0 +1,78 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// DeadTask is a background task that failed for the last time, kept so an
+// administrator can inspect it and put it back on the queue.
+type DeadTask struct {
+	ID       bson.ObjectID `bson:"_id"`
+	TaskID   string        `bson:"task_id"` // ID of the failed task in the queue.
+	Type     string        `bson:"type"`
+	Queue    string        `bson:"queue"`
+	Payload  []byte        `bson:"payload"`
+	Error    string        `bson:"error"` // Error of the last attempt.
+	Retried  int           `bson:"retried"`
+	MaxRetry int           `bson:"max_retry"`
+	FailedAt time.Time     `bson:"failed_at"`
+
+	// RequeuedAt is set once an administrator put the task back on the
+	// queue, as a new task with the ID RequeuedTaskID.
+	RequeuedAt     *time.Time `bson:"requeued_at,omitempty"`
+	RequeuedTaskID string     `bson:"requeued_task_id,omitempty"`
+}
+
+// DeadTaskResponse represents the response for a dead task.
+type DeadTaskResponse struct {
+	ID             string          `json:"id"`
+	TaskID         string          `json:"taskId"`
+	Type           string          `json:"type"`
+	Queue          string          `json:"queue"`
+	Payload        json.RawMessage `json:"payload"` // A JSON string when the payload is not JSON.
+	Error          string          `json:"error"`
+	Retried        int             `json:"retried"`
+	MaxRetry       int             `json:"maxRetry"`
+	FailedAt       time.Time       `json:"failedAt"`
+	RequeuedAt     *time.Time      `json:"requeuedAt,omitempty"`
+	RequeuedTaskID string          `json:"requeuedTaskId,omitempty"`
+}
+
+// ToResponse converts a DeadTask to a DeadTaskResponse.
+func (t *DeadTask) ToResponse() *DeadTaskResponse {
+	payload := json.RawMessage(t.Payload)
+	if !json.Valid(t.Payload) {
+		payload, _ = json.Marshal(string(t.Payload))
+	}
+	return &DeadTaskResponse{
+		ID:             t.ID.Hex(),
+		TaskID:         t.TaskID,
+		Type:           t.Type,
+		Queue:          t.Queue,
+		Payload:        payload,
+		Error:          t.Error,
+		Retried:        t.Retried,
+		MaxRetry:       t.MaxRetry,
+		FailedAt:       t.FailedAt,
+		RequeuedAt:     t.RequeuedAt,
+		RequeuedTaskID: t.RequeuedTaskID,
+	}
+}
+
+// DeadTaskQuery filters and pages the dead tasks, most recent failure
+// first. Zero fields do not filter.
+type DeadTaskQuery struct {
+	Type     string
+	Queue    string
+	Requeued *bool
+	PageQuery
+}
+
+// DeadTaskPage is one page of a dead task listing.
+type DeadTaskPage struct {
+	Items []*DeadTask
+	Total int64 // Matching tasks across all pages.
+}
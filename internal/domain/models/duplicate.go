@@ -0,0 +1,62 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// NameKey normalizes a subscription name for finding duplicates: case,
+// spaces, and punctuation are ignored, so "Disney+" and "disney" match.
+func NameKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// Covers reports whether the subscription runs at t: it renews, is paused or
+// retrying a payment, or was canceled but stays valid past t.
+func (s *Subscription) Covers(t time.Time) bool {
+	switch s.Status {
+	case Active, Paused, PastDue:
+		return true
+	case Canceled:
+		return s.ValidTill.After(t)
+	default:
+		return false
+	}
+}
+
+// DuplicateGroup is a set of a user's subscriptions that are likely
+// duplicates of each other, oldest first.
+type DuplicateGroup struct {
+	Name          string // Name of the oldest subscription.
+	Subscriptions []*Subscription
+}
+
+// DuplicateGroupResponse represents a DuplicateGroup in API responses.
+type DuplicateGroupResponse struct {
+	Name          string                  `json:"name"`
+	Subscriptions []*SubscriptionResponse `json:"subscriptions"`
+}
+
+func (g *DuplicateGroup) ToResponse() *DuplicateGroupResponse {
+	subscriptions := make([]*SubscriptionResponse, len(g.Subscriptions))
+	for i, s := range g.Subscriptions {
+		subscriptions[i] = s.ToResponse()
+	}
+	return &DuplicateGroupResponse{
+		Name:          g.Name,
+		Subscriptions: subscriptions,
+	}
+}
+
+// DuplicateSubscriptionResponse is the 409 body of a create request turned
+// down as a duplicate, naming the subscription it duplicates.
+type DuplicateSubscriptionResponse struct {
+	Error    string                `json:"error"`
+	Existing *SubscriptionResponse `json:"existing"`
+}
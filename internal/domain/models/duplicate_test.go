@@ -0,0 +1,46 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Netflix", "netflix"},
+		{"  NET flix ", "netflix"},
+		{"Disney+", "disney"},
+		{"Apple TV+ 4K", "appletv4k"},
+		{"Čeština Ünï", "češtinaünï"},
+		{"+++", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, models.NameKey(tt.name), tt.name)
+	}
+}
+
+func TestSubscription_Covers(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		status    models.Status
+		validTill time.Time
+		want      bool
+	}{
+		{models.Active, now.AddDate(0, 1, 0), true},
+		{models.Paused, now.AddDate(0, 1, 0), true},
+		{models.PastDue, now.AddDate(0, 0, -1), true},
+		{models.Canceled, now.AddDate(0, 0, 1), true},
+		{models.Canceled, now, false},
+		{models.Expired, now.AddDate(0, 1, 0), false},
+	}
+	for _, tt := range tests {
+		s := &models.Subscription{Status: tt.status, ValidTill: tt.validTill}
+		assert.Equal(t, tt.want, s.Covers(now), "%s until %s", tt.status, tt.validTill)
+	}
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+)
+
+// EmailTemplate describes one of the emails sent to users.
+type EmailTemplate struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// EmailPreviewRequest chooses an email template and the data to render it
+// with, so template changes can be reviewed without waiting for a real
+// reminder.
+type EmailPreviewRequest struct {
+	Template string           `json:"template" validate:"required"`
+	Locale   string           `json:"locale" validate:"max=35"` // E.g. "de"; empty follows Accept-Language.
+	Data     EmailPreviewData `json:"data"`
+}
+
+// EmailPreviewData overrides the sample values an email preview is rendered
+// with. Fields left empty keep their sample value.
+type EmailPreviewData struct {
+	UserName         string    `json:"userName" validate:"max=100"`
+	SubscriptionName string    `json:"subscriptionName" validate:"max=100"`
+	Price            int64     `json:"price" validate:"gte=0"` // In minor units of Currency.
+	Currency         Currency  `json:"currency"`
+	Frequency        Frequency `json:"frequency"`
+	// DaysBefore is how many days away the renewal or trial end is; 0
+	// previews the emails sent on the day.
+	DaysBefore *int `json:"daysBefore" validate:"omitempty,gte=0,lte=365"`
+	Attempt    int  `json:"attempt" validate:"gte=0,lte=10"` // Dunning emails; 0 is the declined renewal.
+	Final      bool `json:"final"`                           // Dunning emails: the subscription expired.
+	Suspend    bool `json:"suspend"`                         // Dunning emails: the final retry suspends instead.
+}
+
+// Validate checks the fields the validation tags can't.
+func (d *EmailPreviewData) Validate() error {
+	if d.Currency != "" && !d.Currency.Valid() {
+		return apperror.NewValidationError("invalid currency")
+	}
+	if d.Frequency != "" && d.Frequency != Monthly && d.Frequency != Yearly {
+		return apperror.NewValidationError("invalid frequency")
+	}
+	return nil
+}
+
+// EmailPreview is an email rendered without sending it.
+type EmailPreview struct {
+	Template string `json:"template"`
+	Subject  string `json:"subject"`
+	HTML     string `json:"html"`
+}
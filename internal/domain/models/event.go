@@ -0,0 +1,114 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// EventType names a domain event published through the outbox.
+type EventType string
+
+const (
+	SubscriptionCreatedEvent  EventType = "subscription.created"
+	SubscriptionRenewedEvent  EventType = "subscription.renewed"
+	SubscriptionCanceledEvent EventType = "subscription.canceled"
+	SubscriptionExpiredEvent  EventType = "subscription.expired"
+	SubscriptionPausedEvent   EventType = "subscription.paused"
+	SubscriptionResumedEvent  EventType = "subscription.resumed"
+	// SubscriptionSuspendedEvent is written when the final payment retry
+	// suspends a past-due subscription.
+	SubscriptionSuspendedEvent EventType = "subscription.suspended"
+	// SubscriptionPriceChangedEvent carries a PriceChangeResponse.
+	SubscriptionPriceChangedEvent EventType = "subscription.price_changed"
+	BillPaidEvent                 EventType = "bill.paid"
+	BillFailedEvent               EventType = "bill.failed"
+	// BillRefundedEvent is written when canceling a subscription refunds its
+	// prepaid upcoming bill.
+	BillRefundedEvent EventType = "bill.refunded"
+)
+
+// EventTypes lists every domain event, in the order they are documented.
+var EventTypes = []EventType{
+	SubscriptionCreatedEvent,
+	SubscriptionRenewedEvent,
+	SubscriptionCanceledEvent,
+	SubscriptionExpiredEvent,
+	SubscriptionPausedEvent,
+	SubscriptionResumedEvent,
+	SubscriptionSuspendedEvent,
+	SubscriptionPriceChangedEvent,
+	BillPaidEvent,
+	BillFailedEvent,
+	BillRefundedEvent,
+}
+
+// eventSchemaVersions is the version of the payload schema of each event type.
+// Bump an event's version whenever its payload changes incompatibly, e.g. a
+// field is renamed or removed; adding fields does not need a new version.
+var eventSchemaVersions = map[EventType]int{
+	SubscriptionCreatedEvent:      1,
+	SubscriptionRenewedEvent:      1,
+	SubscriptionCanceledEvent:     1,
+	SubscriptionExpiredEvent:      1,
+	SubscriptionPausedEvent:       1,
+	SubscriptionResumedEvent:      1,
+	SubscriptionSuspendedEvent:    1,
+	SubscriptionPriceChangedEvent: 1,
+	BillPaidEvent:                 1,
+	BillFailedEvent:               1,
+	BillRefundedEvent:             1,
+}
+
+// SchemaVersion returns the version of the payload schema of e, or 0 for an
+// unknown event.
+func (e EventType) SchemaVersion() int {
+	return eventSchemaVersions[e]
+}
+
+// Valid reports whether e is a known domain event.
+func (e EventType) Valid() bool {
+	return slices.Contains(EventTypes, e)
+}
+
+// OutboxEvent is a domain event written in the same transaction as the state
+// change it describes. A relay publishes it afterwards, so an event exists if
+// and only if the change was committed.
+type OutboxEvent struct {
+	ID          bson.ObjectID `bson:"_id"`
+	Type        EventType     `bson:"type"`
+	AggregateID bson.ObjectID `bson:"aggregate_id"` // ID of the subscription or bill the event is about.
+	UserID      bson.ObjectID `bson:"user_id"`
+	Payload     []byte        `bson:"payload"` // JSON body delivered to consumers.
+	CreatedAt   time.Time     `bson:"created_at"`
+	PublishedAt *time.Time    `bson:"published_at,omitempty"`
+	// W3C trace context of the operation that recorded the event, so that
+	// its consumers join the originating trace.
+	TraceContext map[string]string `bson:"trace_context,omitempty"`
+}
+
+// NewOutboxEvent builds an unpublished event with data encoded as its JSON
+// payload.
+func NewOutboxEvent(
+	eventType EventType,
+	aggregateID bson.ObjectID,
+	userID bson.ObjectID,
+	data any,
+	now time.Time,
+) (*OutboxEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s payload: %w", eventType, err)
+	}
+	return &OutboxEvent{
+		ID:          bson.NewObjectID(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		UserID:      userID,
+		Payload:     payload,
+		CreatedAt:   now,
+	}, nil
+}
@@ -0,0 +1,15 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventType_SchemaVersion(t *testing.T) {
+	for _, eventType := range models.EventTypes {
+		assert.Positive(t, eventType.SchemaVersion(), "%s has no schema version", eventType)
+	}
+	assert.Zero(t, models.EventType("subscription.unknown").SchemaVersion())
+}
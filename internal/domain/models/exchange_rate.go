@@ -0,0 +1,46 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+)
+
+// ExchangeRates are the rates published for one day. One unit of Base buys
+// Rates[c] units of currency c.
+type ExchangeRates struct {
+	Date      time.Time            `bson:"_id" json:"date"` // UTC midnight of the publication day.
+	Base      Currency             `bson:"base" json:"base"`
+	Rates     map[Currency]float64 `bson:"rates" json:"rates"`
+	Provider  string               `bson:"provider" json:"provider"`
+	FetchedAt time.Time            `bson:"fetched_at" json:"fetchedAt"`
+}
+
+// Rate returns how many units of c one unit of Base buys.
+func (r *ExchangeRates) Rate(c Currency) (float64, bool) {
+	if c == r.Base {
+		return 1, true
+	}
+	rate, ok := r.Rates[c]
+	return rate, ok && rate > 0
+}
+
+// Convert converts an amount in minor units of from into minor units of to,
+// rounding to the nearest unit. Amounts are rescaled when the currencies have
+// different minor units, e.g. USD cents to whole yen.
+func (r *ExchangeRates) Convert(amount int64, from, to Currency) (int64, error) {
+	if from == to {
+		return amount, nil
+	}
+	fromRate, ok := r.Rate(from)
+	if !ok {
+		return 0, apperror.NewValidationError("no exchange rate for %s", from)
+	}
+	toRate, ok := r.Rate(to)
+	if !ok {
+		return 0, apperror.NewValidationError("no exchange rate for %s", to)
+	}
+	scale := math.Pow10(to.MinorUnits() - from.MinorUnits())
+	return int64(math.Round(float64(amount) / fromRate * toRate * scale)), nil
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ExportFormat is the file format of an export.
+type ExportFormat string
+
+const (
+	CSVFormat  ExportFormat = "csv"
+	XLSXFormat ExportFormat = "xlsx"
+	// ZIPFormat is the format of account exports only: a ZIP archive of
+	// JSON files.
+	ZIPFormat ExportFormat = "zip"
+)
+
+// ContentType returns the media type of files in the format.
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case XLSXFormat:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case ZIPFormat:
+		return "application/zip"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ExportKind names the records an export lists.
+type ExportKind string
+
+const (
+	SubscriptionExport ExportKind = "subscriptions"
+	BillExport         ExportKind = "bills"
+	// AccountExport is everything stored about a user, the copy of their
+	// personal data they are entitled to. It is always generated in the
+	// background and takes no filters.
+	AccountExport ExportKind = "account"
+)
+
+// ExportRequest selects the records of an export and its file format. It is
+// also the payload of the task generating large exports in the background.
+type ExportRequest struct {
+	UserID bson.ObjectID `json:"userId"` // Set by the service.
+	Kind   ExportKind    `json:"kind"`
+	Format ExportFormat  `json:"format"`
+
+	// Subscription filters; zero values match any subscription.
+	Status     Status           `json:"status,omitempty"`
+	Category   Category         `json:"category,omitempty"`
+	Frequency  Frequency        `json:"frequency,omitempty"`
+	Tag        string           `json:"tag,omitempty"`
+	Sort       SubscriptionSort `json:"sort,omitempty"`
+	Descending bool             `json:"descending,omitempty"`
+
+	// Bill filters; zero values match any bill of the user.
+	SubscriptionID bson.ObjectID `json:"subscriptionId,omitzero"`
+	BillStatus     PaymentStatus `json:"billStatus,omitempty"`
+	From           time.Time     `json:"from,omitzero"` // Bills starting on or after.
+	To             time.Time     `json:"to,omitzero"`   // Bills starting before.
+}
+
+// Validate rejects unknown formats and filter values.
+func (r *ExportRequest) Validate() error {
+	if r.Kind == AccountExport {
+		if r.Format != ZIPFormat {
+			return apperror.NewValidationError("account exports must be zip")
+		}
+		return nil
+	}
+	if r.Format != CSVFormat && r.Format != XLSXFormat {
+		return apperror.NewValidationError("format must be one of csv, xlsx")
+	}
+
+	switch r.Kind {
+	case SubscriptionExport:
+		return r.SubscriptionQuery().Normalize()
+	case BillExport:
+		if r.BillStatus != "" && r.BillStatus != Paid && r.BillStatus != Pending && r.BillStatus != Refunded && r.BillStatus != Failed {
+			return apperror.NewValidationError("invalid status filter")
+		}
+		if !r.From.IsZero() && !r.To.IsZero() && !r.From.Before(r.To) {
+			return apperror.NewValidationError("from must be before to")
+		}
+		return nil
+	default:
+		return apperror.NewValidationError("unknown export kind %s", r.Kind)
+	}
+}
+
+// SubscriptionQuery returns the query selecting the subscriptions of a
+// subscription export. Its page fields are left unset, since exports are not
+// paged.
+func (r *ExportRequest) SubscriptionQuery() *SubscriptionQuery {
+	return &SubscriptionQuery{
+		UserID:     r.UserID,
+		Status:     r.Status,
+		Category:   r.Category,
+		Frequency:  r.Frequency,
+		Tag:        r.Tag,
+		Sort:       r.Sort,
+		Descending: r.Descending,
+	}
+}
+
+// Filename returns the name of the export file generated at now, e.g.
+// "subscriptions-2026-03-14.csv".
+func (r *ExportRequest) Filename(now time.Time) string {
+	return fmt.Sprintf("%s-%s.%s", r.Kind, now.UTC().Format(time.DateOnly), r.Format)
+}
+
+// Export is the outcome of an export request. Exports within the size limit
+// carry their content; larger ones are generated in the background and their
+// download link is emailed.
+type Export struct {
+	Request     ExportRequest
+	Filename    string
+	ContentType string
+	Rows        int64
+	Body        io.ReadCloser // Nil when the export was queued.
+}
+
+// QueuedExportResponse represents the response for an export generated in
+// the background.
+type QueuedExportResponse struct {
+	Kind   ExportKind   `json:"kind"`
+	Format ExportFormat `json:"format"`
+	Rows   int64        `json:"rows"`
+}
+
+func (e *Export) ToResponse() *QueuedExportResponse {
+	return &QueuedExportResponse{
+		Kind:   e.Request.Kind,
+		Format: e.Request.Format,
+		Rows:   e.Rows,
+	}
+}
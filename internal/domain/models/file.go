@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// StoredFile mirrors a GridFS files document. Generated artifacts such as
+// invoices and exports are stored once and served from here instead of being
+// regenerated on every request.
+type StoredFile struct {
+	ID         bson.ObjectID `bson:"_id"`
+	Filename   string        `bson:"filename"`
+	Size       int64         `bson:"length"`
+	UploadedAt time.Time     `bson:"uploadDate"`
+	Metadata   FileMetadata  `bson:"metadata"`
+}
+
+// FileMetadata is the application data attached to a stored file.
+type FileMetadata struct {
+	// Key identifies the logical artifact (e.g. "invoice/<billID>"). Storing
+	// a file under an existing key replaces the previous version.
+	Key         string            `bson:"key"`
+	OwnerID     bson.ObjectID     `bson:"owner_id"`
+	ContentType string            `bson:"content_type"`
+	Attributes  map[string]string `bson:"attributes,omitempty"`
+}
+
+// SignedFile pairs a stored file with a time-limited download URL.
+type SignedFile struct {
+	File      *StoredFile
+	URL       string
+	ExpiresAt time.Time
+}
+
+// FileResponse represents the response for a stored file.
+type FileResponse struct {
+	ID          string            `json:"id"`
+	Filename    string            `json:"filename"`
+	ContentType string            `json:"contentType"`
+	Size        int64             `json:"size"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	UploadedAt  time.Time         `json:"uploadedAt"`
+	DownloadURL string            `json:"downloadUrl"`
+	ExpiresAt   time.Time         `json:"expiresAt"`
+}
+
+func (f *SignedFile) ToResponse() *FileResponse {
+	return &FileResponse{
+		ID:          f.File.ID.Hex(),
+		Filename:    f.File.Filename,
+		ContentType: f.File.Metadata.ContentType,
+		Size:        f.File.Size,
+		Attributes:  f.File.Metadata.Attributes,
+		UploadedAt:  f.File.UploadedAt,
+		DownloadURL: f.URL,
+		ExpiresAt:   f.ExpiresAt,
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// CurrencyAmount is a sum of prices in a single currency, in minor units.
+type CurrencyAmount struct {
+	Currency  string `json:"currency"`
+	Amount    int64  `json:"amount"`
+	Formatted string `json:"formatted"` // e.g. "USD 15.49".
+}
+
+// NewCurrencyAmount creates a CurrencyAmount with its formatted form.
+func NewCurrencyAmount(amount int64, currency Currency) CurrencyAmount {
+	return CurrencyAmount{
+		Currency:  string(currency),
+		Amount:    amount,
+		Formatted: FormatMoney(amount, currency),
+	}
+}
+
+// ForecastMonth is the projected spend for one calendar month.
+type ForecastMonth struct {
+	Month     string           `json:"month"` // Formatted as YYYY-MM.
+	Totals    []CurrencyAmount `json:"totals"`
+	Converted *CurrencyAmount  `json:"converted,omitempty"` // Totals in the requested currency.
+}
+
+// UpcomingRenewal is the next charge of an active subscription.
+type UpcomingRenewal struct {
+	SubscriptionID string    `json:"subscriptionId"`
+	Name           string    `json:"name"`
+	Amount         int64     `json:"amount"`
+	Currency       string    `json:"currency"`
+	RenewsOn       time.Time `json:"renewsOn"`
+}
+
+// CalendarDay lists the renewals falling on one day.
+type CalendarDay struct {
+	Date     string            `json:"date"` // Formatted as YYYY-MM-DD.
+	Renewals []UpcomingRenewal `json:"renewals"`
+	Totals   []CurrencyAmount  `json:"totals"`
+}
+
+// RenewalCalendarResponse groups the renewals of a user's active subscriptions
+// in one calendar month by day. Days without renewals are omitted.
+type RenewalCalendarResponse struct {
+	Month  string           `json:"month"` // Formatted as YYYY-MM.
+	Days   []CalendarDay    `json:"days"`
+	Totals []CurrencyAmount `json:"totals"`
+}
+
+// CalendarFeed is the private URL of a user's renewal calendar, for
+// subscribing from a calendar app.
+type CalendarFeed struct {
+	URL string `json:"url"`
+}
+
+// ForecastResponse projects a user's spend over the coming months from their
+// active subscriptions. Amounts are kept per currency and, when a currency is
+// requested, also converted into it at the latest exchange rates.
+type ForecastResponse struct {
+	Months           int               `json:"months"`
+	Totals           []CurrencyAmount  `json:"totals"`
+	Converted        *CurrencyAmount   `json:"converted,omitempty"`
+	RatesDate        string            `json:"ratesDate,omitempty"` // Publication day of the rates used, as YYYY-MM-DD.
+	MonthlyBreakdown []ForecastMonth   `json:"monthlyBreakdown"`
+	UpcomingRenewals []UpcomingRenewal `json:"upcomingRenewals"`
+	GeneratedAt      time.Time         `json:"generatedAt"`
+}
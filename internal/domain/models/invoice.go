@@ -0,0 +1,38 @@
+package models
+
+import (
+	"math"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// InvoiceContentType is the content type of stored invoices.
+const InvoiceContentType = "application/pdf"
+
+// InvoiceKey is the file storage key of the PDF invoice of a bill.
+func InvoiceKey(billID bson.ObjectID) string {
+	return "invoice/" + billID.Hex()
+}
+
+// InvoiceNumber identifies the invoice of the bill. It is derived from the
+// bill ID, so a regenerated invoice keeps its number.
+func (b *Bill) InvoiceNumber() string {
+	return "INV-" + strings.ToUpper(b.ID.Hex())
+}
+
+// InvoiceFilename is the name the invoice of the bill is downloaded as.
+func (b *Bill) InvoiceFilename() string {
+	return "invoice-" + b.ID.Hex() + ".pdf"
+}
+
+// SplitTax splits amount, which includes tax at rate percent, into its net
+// and tax parts. The tax is rounded to the nearest minor unit and the net
+// amount takes the remainder, so the parts always add up to amount.
+func SplitTax(amount int64, rate float64) (net, tax int64) {
+	if rate <= 0 {
+		return amount, 0
+	}
+	tax = int64(math.Round(float64(amount) * rate / (100 + rate)))
+	return amount - tax, tax
+}
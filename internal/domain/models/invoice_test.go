@@ -0,0 +1,31 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTax(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		rate     float64
+		net, tax int64
+	}{
+		{"no tax", 1549, 0, 1549, 0},
+		{"rounds the tax", 1549, 19, 1302, 247},
+		{"fractional rate", 1000, 7.5, 930, 70},
+		{"zero amount", 0, 19, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			net, tax := models.SplitTax(tt.amount, tt.rate)
+
+			assert.Equal(t, tt.net, net)
+			assert.Equal(t, tt.tax, tax)
+			assert.Equal(t, tt.amount, net+tax)
+		})
+	}
+}
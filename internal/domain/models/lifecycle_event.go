@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// LifecycleEventType names a step in the timeline of a subscription.
+type LifecycleEventType string
+
+const (
+	LifecycleCreated      LifecycleEventType = "created"
+	LifecycleRenewed      LifecycleEventType = "renewed"
+	LifecycleReminderSent LifecycleEventType = "reminder_sent"
+	LifecyclePriceChanged LifecycleEventType = "price_changed"
+	LifecycleCanceled     LifecycleEventType = "canceled"
+	LifecyclePaused       LifecycleEventType = "paused"
+	LifecycleResumed      LifecycleEventType = "resumed"
+	LifecycleExpired      LifecycleEventType = "expired"
+	LifecycleArchived     LifecycleEventType = "archived"
+	LifecyclePastDue      LifecycleEventType = "past_due"
+	LifecycleRecovered    LifecycleEventType = "payment_recovered"
+	LifecycleSuspended    LifecycleEventType = "suspended"
+)
+
+// Actor names who caused a lifecycle or audit event.
+type Actor string
+
+const (
+	// ActorUser is the owner of the subscription, including actions taken
+	// through links sent to them.
+	ActorUser Actor = "user"
+	// ActorSystem is the scheduler and its workers.
+	ActorSystem Actor = "system"
+	// ActorAdmin is an administrator acting through the admin API.
+	ActorAdmin Actor = "admin"
+)
+
+// LifecycleEvent records a state transition of a subscription. Unlike outbox
+// events, lifecycle events are kept for as long as the subscription's data,
+// so users can review its complete timeline.
+type LifecycleEvent struct {
+	ID             bson.ObjectID      `bson:"_id"`
+	SubscriptionID bson.ObjectID      `bson:"subscription_id"`
+	UserID         bson.ObjectID      `bson:"user_id"`
+	Type           LifecycleEventType `bson:"type"`
+	Actor          Actor              `bson:"actor"`
+	Status         Status             `bson:"status"`     // Status after the event.
+	ValidTill      time.Time          `bson:"valid_till"` // Validity after the event.
+	Description    string             `bson:"description"`
+	OccurredAt     time.Time          `bson:"occurred_at"`
+}
+
+// NewLifecycleEvent builds an event describing the given subscription state.
+func NewLifecycleEvent(
+	subscription *Subscription,
+	eventType LifecycleEventType,
+	actor Actor,
+	description string,
+	now time.Time,
+) *LifecycleEvent {
+	return &LifecycleEvent{
+		ID:             bson.NewObjectID(),
+		SubscriptionID: subscription.ID,
+		UserID:         subscription.UserID,
+		Type:           eventType,
+		Actor:          actor,
+		Status:         subscription.Status,
+		ValidTill:      subscription.ValidTill,
+		Description:    description,
+		OccurredAt:     now,
+	}
+}
+
+// LifecycleEventResponse represents the response for a lifecycle event.
+type LifecycleEventResponse struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	Actor       string    `json:"actor"`
+	Status      string    `json:"status"`
+	ValidTill   time.Time `json:"validTill"`
+	Description string    `json:"description"`
+	OccurredAt  time.Time `json:"occurredAt"`
+}
+
+// ToResponse converts a LifecycleEvent to a LifecycleEventResponse.
+func (e *LifecycleEvent) ToResponse() *LifecycleEventResponse {
+	return &LifecycleEventResponse{
+		ID:          e.ID.Hex(),
+		Type:        string(e.Type),
+		Actor:       string(e.Actor),
+		Status:      string(e.Status),
+		ValidTill:   e.ValidTill,
+		Description: e.Description,
+		OccurredAt:  e.OccurredAt,
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ReminderSentLiveEvent is the live event of a reminder delivered to a user.
+// Unlike the EventTypes, it is only streamed: it goes through neither the
+// outbox, the message bus, nor webhooks.
+const ReminderSentLiveEvent = "reminder.sent"
+
+// LiveEvent is an update pushed to the open event streams of a user: one of
+// the EventTypes, or ReminderSentLiveEvent. Data is the payload of the
+// event, the same as webhooks receive for domain events.
+type LiveEvent struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	UserID     string          `json:"userId"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// ReminderSentData is the payload of a ReminderSentLiveEvent.
+type ReminderSentData struct {
+	SubscriptionID string    `json:"subscriptionId"`
+	Name           string    `json:"name"`
+	DaysBefore     int       `json:"daysBefore"`
+	ValidTill      time.Time `json:"validTill"`
+	TrialEnding    bool      `json:"trialEnding,omitempty"` // The reminder is about the end of a trial rather than a renewal.
+}
+
+// NewReminderSentEvent returns the live event of a reminder sent at t,
+// daysBefore the renewal or end of trial of a subscription.
+func NewReminderSentEvent(subscription *Subscription, daysBefore int, trialEnding bool, t time.Time) (*LiveEvent, error) {
+	data, err := json.Marshal(ReminderSentData{
+		SubscriptionID: subscription.ID.Hex(),
+		Name:           subscription.Name,
+		DaysBefore:     daysBefore,
+		ValidTill:      subscription.ValidTill,
+		TrialEnding:    trialEnding,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &LiveEvent{
+		ID:         bson.NewObjectID().Hex(),
+		Type:       ReminderSentLiveEvent,
+		UserID:     subscription.UserID.Hex(),
+		OccurredAt: t,
+		Data:       data,
+	}, nil
+}
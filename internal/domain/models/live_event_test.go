@@ -0,0 +1,36 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestNewReminderSentEvent(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	subscription := &models.Subscription{
+		ID:        bson.NewObjectID(),
+		UserID:    bson.NewObjectID(),
+		Name:      "Netflix",
+		ValidTill: now.AddDate(0, 0, 3),
+	}
+
+	event, err := models.NewReminderSentEvent(subscription, 3, true, now)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, event.ID)
+	assert.Equal(t, models.ReminderSentLiveEvent, event.Type)
+	assert.Equal(t, subscription.UserID.Hex(), event.UserID)
+	assert.Equal(t, now, event.OccurredAt)
+	assert.JSONEq(t, `{
+		"subscriptionId": "`+subscription.ID.Hex()+`",
+		"name": "Netflix",
+		"daysBefore": 3,
+		"validTill": "2025-06-04T09:00:00Z",
+		"trialEnding": true
+	}`, string(event.Data))
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// NotificationStatus is the outcome of delivering a notification.
+type NotificationStatus string
+
+const (
+	NotificationSent   NotificationStatus = "sent"
+	NotificationFailed NotificationStatus = "failed"
+	// NotificationSuppressed marks emails not sent because the address is
+	// on the suppression list.
+	NotificationSuppressed NotificationStatus = "suppressed"
+)
+
+// Notification records one delivery attempt of a message to a user, so users
+// and support can see what was sent and when. Failed attempts are recorded
+// too; a retried task adds a record per attempt.
+type Notification struct {
+	ID        bson.ObjectID       `bson:"_id"`
+	UserID    bson.ObjectID       `bson:"user_id"`
+	Channel   NotificationChannel `bson:"channel"`
+	Recipient string              `bson:"recipient,omitempty"` // Email address or phone number; unset for push and Slack.
+	Template  string              `bson:"template"`
+	TaskID    string              `bson:"task_id,omitempty"` // Queue task that sent it.
+	Status    NotificationStatus  `bson:"status"`
+	Error     string              `bson:"error,omitempty"` // Why a failed delivery failed.
+	SentAt    time.Time           `bson:"sent_at"`
+}
+
+// NotificationResponse represents the response for a notification.
+type NotificationResponse struct {
+	ID        string    `json:"id"`
+	Channel   string    `json:"channel"`
+	Recipient string    `json:"recipient,omitempty"`
+	Template  string    `json:"template"`
+	TaskID    string    `json:"taskId,omitempty"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	SentAt    time.Time `json:"sentAt"`
+}
+
+// ToResponse converts a Notification to a NotificationResponse.
+func (n *Notification) ToResponse() *NotificationResponse {
+	return &NotificationResponse{
+		ID:        n.ID.Hex(),
+		Channel:   string(n.Channel),
+		Recipient: n.Recipient,
+		Template:  n.Template,
+		TaskID:    n.TaskID,
+		Status:    string(n.Status),
+		Error:     n.Error,
+		SentAt:    n.SentAt,
+	}
+}
+
+// NotificationQuery pages the notifications of a user, newest first.
+type NotificationQuery struct {
+	PageQuery
+}
+
+// NotificationPage is one page of a notification listing.
+type NotificationPage struct {
+	Items []*Notification
+	Total int64 // Notifications of the user across all pages.
+}
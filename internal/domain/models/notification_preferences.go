@@ -0,0 +1,372 @@
+package models
+
+import (
+	"encoding/base64"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// NotificationChannel is a medium notifications are delivered through.
+type NotificationChannel string
+
+const (
+	ChannelEmail NotificationChannel = "email"
+	ChannelSMS   NotificationChannel = "sms"
+	ChannelPush  NotificationChannel = "push"  // Web push to a browser.
+	ChannelSlack NotificationChannel = "slack" // Slack incoming webhook.
+)
+
+// Valid reports whether c is one of the supported channels.
+func (c NotificationChannel) Valid() bool {
+	switch c {
+	case ChannelEmail, ChannelSMS, ChannelPush, ChannelSlack:
+		return true
+	}
+	return false
+}
+
+// phonePattern matches an E.164 phone number, e.g. "+14155550123".
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// slackWebhookPrefix is the start of every Slack incoming webhook URL.
+// Other URLs are refused so the server cannot be pointed at arbitrary hosts.
+const slackWebhookPrefix = "https://hooks.slack.com/"
+
+// PushSubscription is the Web Push subscription of a browser, in the shape
+// returned by PushSubscription.toJSON().
+type PushSubscription struct {
+	Endpoint string   `bson:"endpoint" json:"endpoint" validate:"required,url"`
+	Keys     PushKeys `bson:"keys" json:"keys"`
+}
+
+// PushKeys are the keys a push message is encrypted with, base64url
+// encoded.
+type PushKeys struct {
+	P256DH string `bson:"p256dh" json:"p256dh" validate:"required"` // Public key of the browser.
+	Auth   string `bson:"auth" json:"auth" validate:"required"`     // Authentication secret.
+}
+
+// Validate validates the endpoint and keys of the subscription.
+func (p *PushSubscription) Validate() error {
+	endpoint, err := url.Parse(p.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return apperror.NewValidationError("push subscription endpoint must be an https URL")
+	}
+	if key, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(p.Keys.P256DH, "=")); err != nil || len(key) != 65 {
+		return apperror.NewValidationError("push subscription p256dh must be a base64url P-256 public key")
+	}
+	if secret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(p.Keys.Auth, "=")); err != nil || len(secret) != 16 {
+		return apperror.NewValidationError("push subscription auth must be a base64url 16-byte secret")
+	}
+	return nil
+}
+
+// MaxReminderDays bounds how far before a renewal a user can ask to be
+// reminded.
+const MaxReminderDays = 90
+
+// quietHoursLayout is the wall clock format of the bounds of quiet hours.
+const quietHoursLayout = "15:04"
+
+// QuietHours is a daily window in which no notification is delivered. A
+// window whose end is before its start wraps past midnight.
+type QuietHours struct {
+	Start    string `bson:"start" json:"start" validate:"required,datetime=15:04"` // Wall clock, e.g. "22:00".
+	End      string `bson:"end" json:"end" validate:"required,datetime=15:04"`     // Wall clock, e.g. "07:00".
+	Timezone string `bson:"timezone" json:"timezone" validate:"required"`          // IANA zone the bounds are in.
+}
+
+// Validate validates the bounds and zone of the quiet hours.
+func (q *QuietHours) Validate() error {
+	start, err := time.Parse(quietHoursLayout, q.Start)
+	if err != nil {
+		return apperror.NewValidationError("quiet hours start must be formatted as HH:MM")
+	}
+	end, err := time.Parse(quietHoursLayout, q.End)
+	if err != nil {
+		return apperror.NewValidationError("quiet hours end must be formatted as HH:MM")
+	}
+	if start.Equal(end) {
+		return apperror.NewValidationError("quiet hours must not start and end at the same time")
+	}
+	if !validTimezone(q.Timezone) {
+		return apperror.NewValidationError("invalid quiet hours timezone")
+	}
+	return nil
+}
+
+// validTimezone reports whether name is an IANA zone. "Local" is refused as
+// it would depend on the server the notification is sent from.
+func validTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// Until reports whether t falls within the quiet hours and, if so, when they
+// end.
+func (q *QuietHours) Until(t time.Time) (time.Time, bool) {
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return windowUntil(t.In(loc), q.Start, q.End)
+}
+
+// SendWindow is the part of a day notifications are sent in, as wall clock
+// times of the recipient's timezone. A window whose end is before its start
+// wraps past midnight.
+type SendWindow struct {
+	Start string // Wall clock, e.g. "09:00".
+	End   string // Wall clock, e.g. "18:00".
+}
+
+// Validate validates the bounds of the send window.
+func (w *SendWindow) Validate() error {
+	start, err := time.Parse(quietHoursLayout, w.Start)
+	if err != nil {
+		return apperror.NewValidationError("send window start must be formatted as HH:MM")
+	}
+	end, err := time.Parse(quietHoursLayout, w.End)
+	if err != nil {
+		return apperror.NewValidationError("send window end must be formatted as HH:MM")
+	}
+	if start.Equal(end) {
+		return apperror.NewValidationError("send window must not start and end at the same time")
+	}
+	return nil
+}
+
+// Until reports whether t in loc falls outside the send window and, if so,
+// when it next opens.
+func (w *SendWindow) Until(t time.Time, loc *time.Location) (time.Time, bool) {
+	// Outside the window is a quiet period from its end to its start.
+	return windowUntil(t.In(loc), w.End, w.Start)
+}
+
+// windowUntil reports whether local falls within the daily period from from
+// to to, wall clock times in the location of local, and if so when the period
+// ends.
+func windowUntil(local time.Time, from, to string) (time.Time, bool) {
+	start, err := time.Parse(quietHoursLayout, from)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := time.Parse(quietHoursLayout, to)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	now := local.Hour()*60 + local.Minute()
+	startMin := start.Hour()*60 + start.Minute()
+	endMin := end.Hour()*60 + end.Minute()
+	endsToday := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, local.Location())
+
+	switch {
+	case startMin < endMin && now >= startMin && now < endMin:
+		return endsToday, true
+	case startMin > endMin && now < endMin:
+		return endsToday, true
+	case startMin > endMin && now >= startMin:
+		return endsToday.AddDate(0, 0, 1), true
+	}
+	return time.Time{}, false
+}
+
+// NotificationPreferences controls how and when a user is notified. A user
+// without stored preferences gets DefaultNotificationPreferences.
+type NotificationPreferences struct {
+	UserID   bson.ObjectID         `bson:"_id"`
+	Channels []NotificationChannel `bson:"channels"` // Empty opts out of every notification.
+	// ReminderDays overrides the days before a renewal that reminders are
+	// sent on. Nil keeps the server's scheduler.reminder_days.
+	ReminderDays []int       `bson:"reminder_days,omitempty"`
+	QuietHours   *QuietHours `bson:"quiet_hours,omitempty"`
+	Locale       string      `bson:"locale,omitempty"` // BCP 47 tag, e.g. "en-GB"; overrides that of the user.
+	// Timezone is the IANA zone the user lives in. Days before a renewal
+	// are counted in it, unless the subscription has a billing timezone,
+	// and reminders are sent within the send window of its local day.
+	// Empty keeps the server's zone.
+	Timezone string `bson:"timezone,omitempty"`
+	// Digest batches the renewal reminders due on a day into one
+	// notification per channel.
+	Digest bool `bson:"digest,omitempty"`
+
+	// Addresses of the channels other than email, which goes to the
+	// address of the account. Each is required while its channel is enabled.
+	Phone            string            `bson:"phone,omitempty"`             // E.164, for SMS.
+	PushSubscription *PushSubscription `bson:"push_subscription,omitempty"` // For web push.
+	SlackWebhookURL  string            `bson:"slack_webhook_url,omitempty"` // For Slack.
+
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// DefaultNotificationPreferences returns the preferences of a user who has
+// not set any: every reminder by email at the server's default days.
+func DefaultNotificationPreferences(userID bson.ObjectID) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:   userID,
+		Channels: []NotificationChannel{ChannelEmail},
+	}
+}
+
+// Validate validates the notification preference fields.
+func (p *NotificationPreferences) Validate() error {
+	for i, channel := range p.Channels {
+		if !channel.Valid() {
+			return apperror.NewValidationError("invalid channel %s", channel)
+		}
+		if slices.Contains(p.Channels[:i], channel) {
+			return apperror.NewValidationError("channel %s is listed more than once", channel)
+		}
+	}
+	if slices.Contains(p.Channels, ChannelSMS) && p.Phone == "" {
+		return apperror.NewValidationError("a phone number is required for SMS")
+	}
+	if slices.Contains(p.Channels, ChannelPush) && p.PushSubscription == nil {
+		return apperror.NewValidationError("a push subscription is required for push notifications")
+	}
+	if slices.Contains(p.Channels, ChannelSlack) && p.SlackWebhookURL == "" {
+		return apperror.NewValidationError("a Slack webhook URL is required for Slack")
+	}
+	if p.Phone != "" && !phonePattern.MatchString(p.Phone) {
+		return apperror.NewValidationError("phone must be an E.164 number, e.g. +14155550123")
+	}
+	if p.PushSubscription != nil {
+		if err := p.PushSubscription.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.SlackWebhookURL != "" && !strings.HasPrefix(p.SlackWebhookURL, slackWebhookPrefix) {
+		return apperror.NewValidationError("Slack webhook URL must start with %s", slackWebhookPrefix)
+	}
+	if p.ReminderDays != nil && len(p.ReminderDays) == 0 {
+		return apperror.NewValidationError("reminder days must list at least one day; omit them to use the defaults")
+	}
+	for i, days := range p.ReminderDays {
+		if days < 0 || days > MaxReminderDays {
+			return apperror.NewValidationError("reminder days must be between 0 and %d", MaxReminderDays)
+		}
+		if slices.Contains(p.ReminderDays[:i], days) {
+			return apperror.NewValidationError("reminder day %d is listed more than once", days)
+		}
+	}
+	if p.QuietHours != nil {
+		if err := p.QuietHours.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.Timezone != "" && !validTimezone(p.Timezone) {
+		return apperror.NewValidationError("invalid timezone")
+	}
+	if p.UserID.IsZero() {
+		return apperror.NewValidationError("user ID is required")
+	}
+	return nil
+}
+
+// ChannelEnabled reports whether the user receives notifications through c.
+func (p *NotificationPreferences) ChannelEnabled(c NotificationChannel) bool {
+	return slices.Contains(p.Channels, c)
+}
+
+// Location returns the zone the user lives in, or fallback when they have
+// not set one.
+func (p *NotificationPreferences) Location(fallback *time.Location) *time.Location {
+	if p.Timezone != "" {
+		if loc, err := time.LoadLocation(p.Timezone); err == nil {
+			return loc
+		}
+	}
+	return fallback
+}
+
+// ReminderDaysOr returns the days before a renewal the user is reminded on,
+// falling back to the server defaults when the user has not overridden them.
+func (p *NotificationPreferences) ReminderDaysOr(defaults []int) []int {
+	if p.ReminderDays != nil {
+		return p.ReminderDays
+	}
+	return defaults
+}
+
+// NotificationPreferencesRequest represents the data structure for
+// notification preference API requests. It replaces every preference.
+type NotificationPreferencesRequest struct {
+	Channels     []NotificationChannel `json:"channels" validate:"required"` // An empty list opts out of every notification.
+	ReminderDays []int                 `json:"reminderDays"`                 // Omit to use the server defaults.
+	QuietHours   *QuietHours           `json:"quietHours"`
+	Locale       string                `json:"locale" validate:"omitempty,bcp47_language_tag"`
+	Timezone     string                `json:"timezone"` // IANA zone, e.g. "Europe/Berlin"; omit for the server's.
+	Digest       bool                  `json:"digest"`   // One reminder per day listing every renewal.
+	// Addresses required by the channels other than email.
+	Phone            string            `json:"phone"`
+	PushSubscription *PushSubscription `json:"pushSubscription"`
+	SlackWebhookURL  string            `json:"slackWebhookUrl" validate:"omitempty,url"`
+}
+
+// ToModel converts a NotificationPreferencesRequest to a
+// NotificationPreferences model.
+func (r *NotificationPreferencesRequest) ToModel() *NotificationPreferences {
+	return &NotificationPreferences{
+		Channels:     r.Channels,
+		ReminderDays: r.ReminderDays,
+		QuietHours:   r.QuietHours,
+		Locale:       r.Locale,
+		Timezone:     r.Timezone,
+		Digest:       r.Digest,
+
+		Phone:            r.Phone,
+		PushSubscription: r.PushSubscription,
+		SlackWebhookURL:  r.SlackWebhookURL,
+	}
+}
+
+// NotificationPreferencesResponse represents the data structure for
+// notification preference API responses.
+type NotificationPreferencesResponse struct {
+	Channels     []NotificationChannel `json:"channels"`
+	ReminderDays []int                 `json:"reminderDays,omitempty"` // Omitted while the server defaults apply.
+	QuietHours   *QuietHours           `json:"quietHours,omitempty"`
+	Locale       string                `json:"locale,omitempty"`
+	Timezone     string                `json:"timezone,omitempty"`
+	Digest       bool                  `json:"digest"`
+
+	Phone            string            `json:"phone,omitempty"`
+	PushSubscription *PushSubscription `json:"pushSubscription,omitempty"`
+	SlackWebhookURL  string            `json:"slackWebhookUrl,omitempty"`
+
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"` // Omitted until the preferences are first saved.
+}
+
+// ToResponse converts a NotificationPreferences model to a
+// NotificationPreferencesResponse.
+func (p *NotificationPreferences) ToResponse() *NotificationPreferencesResponse {
+	resp := &NotificationPreferencesResponse{
+		Channels:     p.Channels,
+		ReminderDays: p.ReminderDays,
+		QuietHours:   p.QuietHours,
+		Locale:       p.Locale,
+		Timezone:     p.Timezone,
+		Digest:       p.Digest,
+
+		Phone:            p.Phone,
+		PushSubscription: p.PushSubscription,
+		SlackWebhookURL:  p.SlackWebhookURL,
+	}
+	if resp.Channels == nil {
+		resp.Channels = []NotificationChannel{}
+	}
+	if !p.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &p.UpdatedAt
+	}
+	return resp
+}
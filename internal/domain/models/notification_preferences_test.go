@@ -0,0 +1,252 @@
+package models_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestNotificationPreferences_Validate(t *testing.T) {
+	valid := func() *models.NotificationPreferences {
+		return &models.NotificationPreferences{
+			UserID:       defaultUserID,
+			Channels:     []models.NotificationChannel{models.ChannelEmail},
+			ReminderDays: []int{1, 14},
+			QuietHours:   &models.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Berlin"},
+			Locale:       "de-DE",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*models.NotificationPreferences)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(*models.NotificationPreferences) {}},
+		{name: "defaults", mutate: func(p *models.NotificationPreferences) {
+			*p = *models.DefaultNotificationPreferences(defaultUserID)
+		}},
+		{name: "opted out of every channel", mutate: func(p *models.NotificationPreferences) { p.Channels = nil }},
+		{name: "unknown channel", mutate: func(p *models.NotificationPreferences) {
+			p.Channels = []models.NotificationChannel{"pigeon"}
+		}, wantErr: true},
+		{name: "repeated channel", mutate: func(p *models.NotificationPreferences) {
+			p.Channels = []models.NotificationChannel{models.ChannelEmail, models.ChannelEmail}
+		}, wantErr: true},
+		{name: "empty reminder days", mutate: func(p *models.NotificationPreferences) { p.ReminderDays = []int{} }, wantErr: true},
+		{name: "negative reminder day", mutate: func(p *models.NotificationPreferences) { p.ReminderDays = []int{-1} }, wantErr: true},
+		{name: "reminder day too far out", mutate: func(p *models.NotificationPreferences) {
+			p.ReminderDays = []int{models.MaxReminderDays + 1}
+		}, wantErr: true},
+		{name: "repeated reminder day", mutate: func(p *models.NotificationPreferences) { p.ReminderDays = []int{3, 3} }, wantErr: true},
+		{name: "malformed quiet hours", mutate: func(p *models.NotificationPreferences) { p.QuietHours.Start = "25:00" }, wantErr: true},
+		{name: "empty quiet hours", mutate: func(p *models.NotificationPreferences) { p.QuietHours.End = "22:00" }, wantErr: true},
+		{name: "unknown quiet hours zone", mutate: func(p *models.NotificationPreferences) {
+			p.QuietHours.Timezone = "Mars/Olympus"
+		}, wantErr: true},
+		{name: "server local quiet hours zone", mutate: func(p *models.NotificationPreferences) {
+			p.QuietHours.Timezone = "Local"
+		}, wantErr: true},
+		{name: "timezone", mutate: func(p *models.NotificationPreferences) { p.Timezone = "Asia/Tokyo" }},
+		{name: "unknown timezone", mutate: func(p *models.NotificationPreferences) { p.Timezone = "Mars/Olympus" }, wantErr: true},
+		{name: "server local timezone", mutate: func(p *models.NotificationPreferences) { p.Timezone = "Local" }, wantErr: true},
+		{name: "missing user", mutate: func(p *models.NotificationPreferences) { p.UserID = bson.ObjectID{} }, wantErr: true},
+		{name: "sms with a phone number", mutate: func(p *models.NotificationPreferences) {
+			p.Channels = []models.NotificationChannel{models.ChannelSMS}
+			p.Phone = "+4915112345678"
+		}},
+		{name: "sms without a phone number", mutate: func(p *models.NotificationPreferences) {
+			p.Channels = []models.NotificationChannel{models.ChannelSMS}
+		}, wantErr: true},
+		{name: "phone number not in E.164", mutate: func(p *models.NotificationPreferences) { p.Phone = "0151 12345678" }, wantErr: true},
+		{name: "slack with a webhook", mutate: func(p *models.NotificationPreferences) {
+			p.Channels = []models.NotificationChannel{models.ChannelSlack}
+			p.SlackWebhookURL = "https://hooks.slack.com/services/T000/B000/XXXX"
+		}},
+		{name: "slack webhook on another host", mutate: func(p *models.NotificationPreferences) {
+			p.Channels = []models.NotificationChannel{models.ChannelSlack}
+			p.SlackWebhookURL = "https://example.com/services/T000/B000/XXXX"
+		}, wantErr: true},
+		{name: "push with a subscription", mutate: func(p *models.NotificationPreferences) {
+			p.Channels = []models.NotificationChannel{models.ChannelPush}
+			p.PushSubscription = validPushSubscription()
+		}},
+		{name: "push without a subscription", mutate: func(p *models.NotificationPreferences) {
+			p.Channels = []models.NotificationChannel{models.ChannelPush}
+		}, wantErr: true},
+		{name: "push subscription with a short key", mutate: func(p *models.NotificationPreferences) {
+			p.Channels = []models.NotificationChannel{models.ChannelPush}
+			p.PushSubscription = validPushSubscription()
+			p.PushSubscription.Keys.P256DH = "BAAA"
+		}, wantErr: true},
+		{name: "push endpoint over http", mutate: func(p *models.NotificationPreferences) {
+			p.Channels = []models.NotificationChannel{models.ChannelPush}
+			p.PushSubscription = validPushSubscription()
+			p.PushSubscription.Endpoint = "http://push.example.com/abc"
+		}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.mutate(p)
+			err := p.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationPreferences_ReminderDaysOr(t *testing.T) {
+	defaults := []int{1, 3, 7}
+
+	prefs := models.DefaultNotificationPreferences(defaultUserID)
+	assert.Equal(t, defaults, prefs.ReminderDaysOr(defaults))
+
+	prefs.ReminderDays = []int{14}
+	assert.Equal(t, []int{14}, prefs.ReminderDaysOr(defaults))
+}
+
+func TestNotificationPreferences_Location(t *testing.T) {
+	if _, err := time.LoadLocation("Asia/Tokyo"); err != nil {
+		t.Skip("tzdata unavailable")
+	}
+
+	prefs := models.DefaultNotificationPreferences(defaultUserID)
+	assert.Equal(t, time.UTC, prefs.Location(time.UTC))
+
+	prefs.Timezone = "Asia/Tokyo"
+	assert.Equal(t, "Asia/Tokyo", prefs.Location(time.UTC).String())
+
+	prefs.Timezone = "Mars/Olympus"
+	assert.Equal(t, time.UTC, prefs.Location(time.UTC))
+}
+
+func TestQuietHours_Until(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("tzdata unavailable")
+	}
+
+	tests := []struct {
+		name      string
+		hours     models.QuietHours
+		at        time.Time
+		wantUntil time.Time
+		wantQuiet bool
+	}{
+		{
+			name:      "before a daytime window",
+			hours:     models.QuietHours{Start: "12:00", End: "14:00", Timezone: "UTC"},
+			at:        time.Date(2025, 1, 15, 11, 59, 0, 0, time.UTC),
+			wantQuiet: false,
+		},
+		{
+			name:      "inside a daytime window",
+			hours:     models.QuietHours{Start: "12:00", End: "14:00", Timezone: "UTC"},
+			at:        time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+			wantUntil: time.Date(2025, 1, 15, 14, 0, 0, 0, time.UTC),
+			wantQuiet: true,
+		},
+		{
+			name:      "at the end of a window",
+			hours:     models.QuietHours{Start: "12:00", End: "14:00", Timezone: "UTC"},
+			at:        time.Date(2025, 1, 15, 14, 0, 0, 0, time.UTC),
+			wantQuiet: false,
+		},
+		{
+			name:      "before midnight in an overnight window",
+			hours:     models.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Berlin"},
+			at:        time.Date(2025, 1, 15, 22, 30, 0, 0, time.UTC), // 23:30 in Berlin.
+			wantUntil: time.Date(2025, 1, 16, 7, 0, 0, 0, berlin),
+			wantQuiet: true,
+		},
+		{
+			name:      "after midnight in an overnight window",
+			hours:     models.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Berlin"},
+			at:        time.Date(2025, 1, 15, 5, 0, 0, 0, time.UTC), // 06:00 in Berlin.
+			wantUntil: time.Date(2025, 1, 15, 7, 0, 0, 0, berlin),
+			wantQuiet: true,
+		},
+		{
+			name:      "outside an overnight window",
+			hours:     models.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Berlin"},
+			at:        mockTime, // 13:00 in Berlin.
+			wantQuiet: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, quiet := tt.hours.Until(tt.at)
+			assert.Equal(t, tt.wantQuiet, quiet)
+			if tt.wantQuiet {
+				assert.True(t, tt.wantUntil.Equal(until), "until = %s, want %s", until, tt.wantUntil)
+			}
+		})
+	}
+}
+
+func TestSendWindow_Until(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("tzdata unavailable")
+	}
+	window := models.SendWindow{Start: "09:00", End: "18:00"}
+
+	tests := []struct {
+		name      string
+		at        time.Time
+		wantUntil time.Time
+		wantWait  bool
+	}{
+		{
+			name:     "inside the window",
+			at:       time.Date(2025, 1, 15, 3, 0, 0, 0, time.UTC), // 12:00 in Tokyo.
+			wantWait: false,
+		},
+		{
+			name:      "early in the morning",
+			at:        time.Date(2025, 1, 14, 21, 0, 0, 0, time.UTC), // 06:00 in Tokyo.
+			wantUntil: time.Date(2025, 1, 15, 9, 0, 0, 0, tokyo),
+			wantWait:  true,
+		},
+		{
+			name:      "after the window closed",
+			at:        mockTime, // 21:00 in Tokyo.
+			wantUntil: time.Date(2025, 1, 16, 9, 0, 0, 0, tokyo),
+			wantWait:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, wait := window.Until(tt.at, tokyo)
+			assert.Equal(t, tt.wantWait, wait)
+			if tt.wantWait {
+				assert.True(t, tt.wantUntil.Equal(until), "until = %s, want %s", until, tt.wantUntil)
+			}
+		})
+	}
+
+	assert.Error(t, (&models.SendWindow{Start: "9am", End: "18:00"}).Validate())
+	assert.Error(t, (&models.SendWindow{Start: "09:00", End: "09:00"}).Validate())
+	assert.NoError(t, window.Validate())
+}
+
+func validPushSubscription() *models.PushSubscription {
+	return &models.PushSubscription{
+		Endpoint: "https://fcm.googleapis.com/fcm/send/abc",
+		Keys: models.PushKeys{
+			P256DH: base64.RawURLEncoding.EncodeToString(append([]byte{0x04}, make([]byte, 64)...)),
+			Auth:   base64.RawURLEncoding.EncodeToString(make([]byte, 16)),
+		},
+	}
+}
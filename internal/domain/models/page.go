@@ -0,0 +1,31 @@
+package models
+
+import "github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+
+const (
+	// DefaultPageLimit is the page size of listings that do not set one.
+	DefaultPageLimit = 50
+	// MaxPageLimit is the largest page a listing returns.
+	MaxPageLimit = 200
+)
+
+// PageQuery selects one page of a listing. Queries embed it to share its
+// validation.
+type PageQuery struct {
+	Limit  int
+	Offset int
+}
+
+// Normalize fills in the default page size and rejects out-of-range values.
+func (q *PageQuery) Normalize() error {
+	if q.Limit == 0 {
+		q.Limit = DefaultPageLimit
+	}
+	if q.Limit < 0 || q.Limit > MaxPageLimit {
+		return apperror.NewValidationError("limit must be between 1 and %d", MaxPageLimit)
+	}
+	if q.Offset < 0 {
+		return apperror.NewValidationError("offset must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageQuery_Normalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     models.PageQuery
+		wantLimit int
+		wantErr   bool
+	}{
+		{name: "defaults the page size", query: models.PageQuery{}, wantLimit: models.DefaultPageLimit},
+		{name: "keeps the largest page", query: models.PageQuery{Limit: models.MaxPageLimit, Offset: 10}, wantLimit: models.MaxPageLimit},
+		{name: "rejects a negative limit", query: models.PageQuery{Limit: -1}, wantErr: true},
+		{name: "rejects an oversized page", query: models.PageQuery{Limit: models.MaxPageLimit + 1}, wantErr: true},
+		{name: "rejects a negative offset", query: models.PageQuery{Offset: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Normalize()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, tt.query.Limit)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// PaymentMethodType is the kind of payment method a subscription is paid
+// with.
+type PaymentMethodType string
+
+const (
+	Card        PaymentMethodType = "card"
+	BankAccount PaymentMethodType = "bank_account"
+	PayPal      PaymentMethodType = "paypal"
+	OtherMethod PaymentMethodType = "other"
+)
+
+// Valid reports whether t is one of the supported payment method types.
+func (t PaymentMethodType) Valid() bool {
+	switch t {
+	case Card, BankAccount, PayPal, OtherMethod:
+		return true
+	}
+	return false
+}
+
+// paymentMethodTypeNames are shown when a payment method has no brand.
+var paymentMethodTypeNames = map[PaymentMethodType]string{
+	Card:        "Card",
+	BankAccount: "Bank account",
+	PayPal:      "PayPal",
+	OtherMethod: "Other",
+}
+
+// PaymentMethod describes how a user pays for subscriptions. Only what is
+// needed to recognize it is stored, never full card or account numbers.
+type PaymentMethod struct {
+	ID       bson.ObjectID     `bson:"_id"`
+	UserID   bson.ObjectID     `bson:"user_id"`
+	Type     PaymentMethodType `bson:"type"`
+	Label    string            `bson:"label,omitempty"`     // User-chosen name, e.g. "Work card".
+	Brand    string            `bson:"brand,omitempty"`     // Card network or bank, e.g. "Visa".
+	Last4    string            `bson:"last4,omitempty"`     // Last four digits of the card or account.
+	ExpMonth int               `bson:"exp_month,omitempty"` // Cards only, 1-12.
+	ExpYear  int               `bson:"exp_year,omitempty"`  // Cards only.
+	// ProviderCustomerID and ProviderMethodID identify the payment method at
+	// the payment provider. Renewals are only charged when both are set.
+	ProviderCustomerID string    `bson:"provider_customer_id,omitempty"`
+	ProviderMethodID   string    `bson:"provider_method_id,omitempty"`
+	CreatedAt          time.Time `bson:"created_at"`
+	UpdatedAt          time.Time `bson:"updated_at"`
+}
+
+// Validate validates the payment method fields.
+func (p *PaymentMethod) Validate() error {
+	if !p.Type.Valid() {
+		return apperror.NewValidationError("invalid payment method type %s", p.Type)
+	}
+	if len(p.Label) > 50 {
+		return apperror.NewValidationError("label must be at most 50 characters")
+	}
+	if len(p.Brand) > 30 {
+		return apperror.NewValidationError("brand must be at most 30 characters")
+	}
+	if p.Last4 != "" && !isDigits(p.Last4, 4) {
+		return apperror.NewValidationError("last4 must be 4 digits")
+	}
+	if (p.ExpMonth == 0) != (p.ExpYear == 0) {
+		return apperror.NewValidationError("expiry month and year must be set together")
+	}
+	if p.ExpMonth != 0 {
+		if p.Type != Card {
+			return apperror.NewValidationError("only cards have an expiry date")
+		}
+		if p.ExpMonth < 1 || p.ExpMonth > 12 {
+			return apperror.NewValidationError("expiry month must be between 1 and 12")
+		}
+		if p.ExpYear < 2000 || p.ExpYear > 9999 {
+			return apperror.NewValidationError("invalid expiry year")
+		}
+	}
+	if (p.ProviderCustomerID == "") != (p.ProviderMethodID == "") {
+		return apperror.NewValidationError("provider customer and method IDs must be set together")
+	}
+	if p.UserID.IsZero() {
+		return apperror.NewValidationError("user ID is required")
+	}
+	return nil
+}
+
+// Chargeable reports whether renewals can be charged to the payment method
+// through the payment provider.
+func (p *PaymentMethod) Chargeable() bool {
+	return p.ProviderCustomerID != "" && p.ProviderMethodID != ""
+}
+
+// Expired reports whether a card expired before now. Cards are valid through
+// the last day of their expiry month.
+func (p *PaymentMethod) Expired(now time.Time) bool {
+	if p.ExpYear == 0 {
+		return false
+	}
+	firstInvalid := time.Date(p.ExpYear, time.Month(p.ExpMonth)+1, 1, 0, 0, 0, 0, time.UTC)
+	return !now.Before(firstInvalid)
+}
+
+// Description names the payment method for people, e.g.
+// "Work card (Visa ending in 4242)".
+func (p *PaymentMethod) Description() string {
+	name := p.Brand
+	if name == "" {
+		name = paymentMethodTypeNames[p.Type]
+	}
+	if p.Last4 != "" {
+		name = fmt.Sprintf("%s ending in %s", name, p.Last4)
+	}
+	if p.Label != "" {
+		return fmt.Sprintf("%s (%s)", p.Label, name)
+	}
+	return name
+}
+
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// PaymentMethodRequest represents the data structure for payment method API
+// requests.
+type PaymentMethodRequest struct {
+	Type     PaymentMethodType `json:"type" validate:"required"`
+	Label    string            `json:"label" validate:"max=50"`
+	Brand    string            `json:"brand" validate:"max=30"`
+	Last4    string            `json:"last4" validate:"omitempty,len=4,numeric"`
+	ExpMonth int               `json:"expMonth" validate:"gte=0,lte=12"`
+	ExpYear  int               `json:"expYear" validate:"gte=0"`
+
+	ProviderCustomerID string `json:"providerCustomerId" validate:"max=255"`
+	ProviderMethodID   string `json:"providerMethodId" validate:"max=255"`
+}
+
+// ToModel converts a PaymentMethodRequest to a PaymentMethod model.
+func (r *PaymentMethodRequest) ToModel() *PaymentMethod {
+	return &PaymentMethod{
+		Type:     r.Type,
+		Label:    r.Label,
+		Brand:    r.Brand,
+		Last4:    r.Last4,
+		ExpMonth: r.ExpMonth,
+		ExpYear:  r.ExpYear,
+
+		ProviderCustomerID: r.ProviderCustomerID,
+		ProviderMethodID:   r.ProviderMethodID,
+	}
+}
+
+// PaymentMethodResponse represents the data structure for payment method API
+// responses.
+type PaymentMethodResponse struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	Label       string    `json:"label,omitempty"`
+	Brand       string    `json:"brand,omitempty"`
+	Last4       string    `json:"last4,omitempty"`
+	ExpMonth    int       `json:"expMonth,omitempty"`
+	ExpYear     int       `json:"expYear,omitempty"`
+	Description string    `json:"description"`
+	Chargeable  bool      `json:"chargeable"` // Renewals are charged through the payment provider.
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ToResponse converts a PaymentMethod model to a PaymentMethodResponse.
+func (p *PaymentMethod) ToResponse() *PaymentMethodResponse {
+	return &PaymentMethodResponse{
+		ID:          p.ID.Hex(),
+		Type:        string(p.Type),
+		Label:       p.Label,
+		Brand:       p.Brand,
+		Last4:       p.Last4,
+		ExpMonth:    p.ExpMonth,
+		ExpYear:     p.ExpYear,
+		Description: p.Description(),
+		Chargeable:  p.Chargeable(),
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+// PaymentMethodLinkRequest links a subscription to a payment method. An
+// empty ID unlinks it.
+type PaymentMethodLinkRequest struct {
+	PaymentMethodID string `json:"paymentMethodId"`
+}
@@ -0,0 +1,87 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestPaymentMethod_Validate(t *testing.T) {
+	valid := func() *models.PaymentMethod {
+		return &models.PaymentMethod{
+			UserID:   defaultUserID,
+			Type:     models.Card,
+			Brand:    "Visa",
+			Last4:    "4242",
+			ExpMonth: 8,
+			ExpYear:  2030,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*models.PaymentMethod)
+		wantErr bool
+	}{
+		{name: "valid card", mutate: func(*models.PaymentMethod) {}},
+		{name: "paypal without details", mutate: func(p *models.PaymentMethod) {
+			*p = models.PaymentMethod{UserID: defaultUserID, Type: models.PayPal}
+		}},
+		{name: "unknown type", mutate: func(p *models.PaymentMethod) { p.Type = "cash" }, wantErr: true},
+		{name: "last4 not digits", mutate: func(p *models.PaymentMethod) { p.Last4 = "42a2" }, wantErr: true},
+		{name: "expiry month without year", mutate: func(p *models.PaymentMethod) { p.ExpYear = 0 }, wantErr: true},
+		{name: "expiry month out of range", mutate: func(p *models.PaymentMethod) { p.ExpMonth = 13 }, wantErr: true},
+		{name: "expiry on a bank account", mutate: func(p *models.PaymentMethod) { p.Type = models.BankAccount }, wantErr: true},
+		{name: "missing user", mutate: func(p *models.PaymentMethod) { p.UserID = bson.ObjectID{} }, wantErr: true},
+		{name: "saved with the provider", mutate: func(p *models.PaymentMethod) {
+			p.ProviderCustomerID = "cus_123"
+			p.ProviderMethodID = "pm_123"
+		}},
+		{name: "provider method without customer", mutate: func(p *models.PaymentMethod) { p.ProviderMethodID = "pm_123" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.mutate(p)
+			err := p.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPaymentMethod_Description(t *testing.T) {
+	tests := []struct {
+		name   string
+		method models.PaymentMethod
+		want   string
+	}{
+		{"brand and digits", models.PaymentMethod{Type: models.Card, Brand: "Visa", Last4: "4242"}, "Visa ending in 4242"},
+		{"label", models.PaymentMethod{Type: models.Card, Label: "Work card", Brand: "Visa", Last4: "4242"}, "Work card (Visa ending in 4242)"},
+		{"type only", models.PaymentMethod{Type: models.BankAccount, Last4: "0001"}, "Bank account ending in 0001"},
+		{"paypal", models.PaymentMethod{Type: models.PayPal}, "PayPal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.method.Description())
+		})
+	}
+}
+
+func TestPaymentMethod_Expired(t *testing.T) {
+	card := models.PaymentMethod{Type: models.Card, ExpMonth: 1, ExpYear: 2025}
+
+	// Cards are valid through the end of their expiry month.
+	assert.False(t, card.Expired(mockTime))
+	assert.False(t, card.Expired(time.Date(2025, 1, 31, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, card.Expired(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, (&models.PaymentMethod{Type: models.PayPal}).Expired(mockTime))
+}
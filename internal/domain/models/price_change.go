@@ -0,0 +1,95 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// PriceChange records an edit of a subscription's price. Renewals starting at
+// or after EffectiveAt are billed at NewPrice; a change effective in the
+// future leaves the current price in place until then.
+type PriceChange struct {
+	ID             bson.ObjectID `bson:"_id"`
+	SubscriptionID bson.ObjectID `bson:"subscription_id"`
+	UserID         bson.ObjectID `bson:"user_id"`
+	Name           string        `bson:"name"` // Subscription name at the time of the change.
+	OldPrice       int64         `bson:"old_price"`
+	NewPrice       int64         `bson:"new_price"`
+	Currency       Currency      `bson:"currency"`
+	Frequency      Frequency     `bson:"frequency"`
+	ChangedAt      time.Time     `bson:"changed_at"`
+	EffectiveAt    time.Time     `bson:"effective_at"`
+}
+
+// Pending reports whether the change takes effect after now.
+func (c *PriceChange) Pending(now time.Time) bool {
+	return c.EffectiveAt.After(now)
+}
+
+// Delta returns the change of the price per billing period.
+func (c *PriceChange) Delta() int64 {
+	return c.NewPrice - c.OldPrice
+}
+
+// PercentChange returns the change relative to the old price, rounded to one
+// decimal place.
+func (c *PriceChange) PercentChange() float64 {
+	if c.OldPrice == 0 {
+		return 0
+	}
+	return math.Round(float64(c.Delta())*1000/float64(c.OldPrice)) / 10
+}
+
+// AnnualImpact returns what the change adds to, or saves from, a year of the
+// subscription.
+func (c *PriceChange) AnnualImpact() int64 {
+	return AnnualizedPrice(c.Delta(), c.Frequency)
+}
+
+// PriceUpdateRequest is the body for changing a subscription's price.
+type PriceUpdateRequest struct {
+	Price int64 `json:"price" validate:"required,gt=0"`
+	// EffectiveAt schedules the change for the first renewal at or after it;
+	// omit it to change the price now.
+	EffectiveAt *time.Time `json:"effectiveAt"`
+}
+
+// PriceChangeResponse represents the response for a price change.
+type PriceChangeResponse struct {
+	ID             string         `json:"id"`
+	SubscriptionID string         `json:"subscriptionId"`
+	Name           string         `json:"name"`
+	OldPrice       CurrencyAmount `json:"oldPrice"`
+	NewPrice       CurrencyAmount `json:"newPrice"`
+	Delta          CurrencyAmount `json:"delta"`
+	PercentChange  float64        `json:"percentChange"`
+	AnnualImpact   CurrencyAmount `json:"annualImpact"`
+	ChangedAt      time.Time      `json:"changedAt"`
+	EffectiveAt    time.Time      `json:"effectiveAt"`
+}
+
+// ToResponse converts a PriceChange to a PriceChangeResponse.
+func (c *PriceChange) ToResponse() *PriceChangeResponse {
+	return &PriceChangeResponse{
+		ID:             c.ID.Hex(),
+		SubscriptionID: c.SubscriptionID.Hex(),
+		Name:           c.Name,
+		OldPrice:       NewCurrencyAmount(c.OldPrice, c.Currency),
+		NewPrice:       NewCurrencyAmount(c.NewPrice, c.Currency),
+		Delta:          NewCurrencyAmount(c.Delta(), c.Currency),
+		PercentChange:  c.PercentChange(),
+		AnnualImpact:   NewCurrencyAmount(c.AnnualImpact(), c.Currency),
+		ChangedAt:      c.ChangedAt,
+		EffectiveAt:    c.EffectiveAt,
+	}
+}
+
+// PriceChangeSummaryResponse lists a user's price increases in one calendar
+// month with their combined yearly impact per currency.
+type PriceChangeSummaryResponse struct {
+	Month        string                 `json:"month"` // Formatted as YYYY-MM.
+	Increases    []*PriceChangeResponse `json:"increases"`
+	AnnualImpact []CurrencyAmount       `json:"annualImpact"`
+}
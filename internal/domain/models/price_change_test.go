@@ -0,0 +1,41 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceChange(t *testing.T) {
+	tests := []struct {
+		name        string
+		change      models.PriceChange
+		wantDelta   int64
+		wantPercent float64
+		wantAnnual  int64
+	}{
+		{
+			name:        "monthly increase",
+			change:      models.PriceChange{OldPrice: 1549, NewPrice: 1749, Frequency: models.Monthly},
+			wantDelta:   200,
+			wantPercent: 12.9,
+			wantAnnual:  2400,
+		},
+		{
+			name:        "yearly decrease",
+			change:      models.PriceChange{OldPrice: 13900, NewPrice: 11900, Frequency: models.Yearly},
+			wantDelta:   -2000,
+			wantPercent: -14.4,
+			wantAnnual:  -2000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantDelta, tt.change.Delta())
+			assert.InDelta(t, tt.wantPercent, tt.change.PercentChange(), 1e-9)
+			assert.Equal(t, tt.wantAnnual, tt.change.AnnualImpact())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package models
+
+import "time"
+
+// ReconciliationIssue names a way the status of a subscription disagrees
+// with its bills.
+type ReconciliationIssue string
+
+const (
+	// ReconcileMissingBill is an active or past-due subscription past its
+	// trial without any bill.
+	ReconcileMissingBill ReconciliationIssue = "missing_bill"
+	// ReconcileFailedBill is an active subscription whose latest bill
+	// failed without dunning starting.
+	ReconcileFailedBill ReconciliationIssue = "failed_bill"
+	// ReconcileUnappliedBill is a subscription whose latest bill ends after
+	// its validity, a renewal written without the subscription being
+	// extended.
+	ReconcileUnappliedBill ReconciliationIssue = "unapplied_bill"
+	// ReconcileRenewalMissed is an active subscription whose validity ended
+	// long enough ago that renewal should have extended or ended it.
+	ReconcileRenewalMissed ReconciliationIssue = "renewal_missed"
+	// ReconcileDunningSettled is a past-due subscription whose failed bill
+	// has since been paid. It is repaired by reactivating the subscription.
+	ReconcileDunningSettled ReconciliationIssue = "dunning_settled"
+	// ReconcileDunningBroken is a past-due subscription without a dunning
+	// record, or whose dunned bill is missing or no longer failed or paid.
+	ReconcileDunningBroken ReconciliationIssue = "dunning_broken"
+)
+
+// ReconciliationIssues lists every issue reconciliation detects.
+var ReconciliationIssues = []ReconciliationIssue{
+	ReconcileMissingBill,
+	ReconcileFailedBill,
+	ReconcileUnappliedBill,
+	ReconcileRenewalMissed,
+	ReconcileDunningSettled,
+	ReconcileDunningBroken,
+}
+
+// MaxReconciliationFindings caps the findings a report lists; the counts
+// still cover every one.
+const MaxReconciliationFindings = 1000
+
+// ReconciliationFinding is a subscription found inconsistent with its bills.
+type ReconciliationFinding struct {
+	SubscriptionID string              `json:"subscriptionId"`
+	UserID         string              `json:"userId"`
+	Status         Status              `json:"status"`
+	Issue          ReconciliationIssue `json:"issue"`
+	Detail         string              `json:"detail"`
+	Repaired       bool                `json:"repaired"`
+	RepairError    string              `json:"repairError,omitempty"` // Why an attempted repair failed.
+}
+
+// ReconciliationReport is the outcome of a reconciliation run.
+type ReconciliationReport struct {
+	StartedAt  time.Time                   `json:"startedAt"`
+	FinishedAt time.Time                   `json:"finishedAt"`
+	Checked    int                         `json:"checked"` // Subscriptions checked.
+	Counts     map[ReconciliationIssue]int `json:"counts"`  // Findings per issue, repaired ones included.
+	Repaired   int                         `json:"repaired"`
+	Findings   []ReconciliationFinding     `json:"findings"`
+	Truncated  bool                        `json:"truncated"` // More than MaxReconciliationFindings were found.
+}
+
+// Add records a finding, keeping the listed findings within
+// MaxReconciliationFindings.
+func (r *ReconciliationReport) Add(finding ReconciliationFinding) {
+	if r.Counts == nil {
+		r.Counts = make(map[ReconciliationIssue]int)
+	}
+	r.Counts[finding.Issue]++
+	if finding.Repaired {
+		r.Repaired++
+	}
+	if len(r.Findings) >= MaxReconciliationFindings {
+		r.Truncated = true
+		return
+	}
+	r.Findings = append(r.Findings, finding)
+}
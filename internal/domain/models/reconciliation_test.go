@@ -0,0 +1,27 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconciliationReport_Add(t *testing.T) {
+	var report models.ReconciliationReport
+	for i := range models.MaxReconciliationFindings + 2 {
+		report.Add(models.ReconciliationFinding{
+			Issue:    models.ReconcileDunningSettled,
+			Repaired: i%2 == 0,
+		})
+	}
+	report.Add(models.ReconciliationFinding{Issue: models.ReconcileMissingBill})
+
+	assert.Len(t, report.Findings, models.MaxReconciliationFindings)
+	assert.True(t, report.Truncated)
+	assert.Equal(t, map[models.ReconciliationIssue]int{
+		models.ReconcileDunningSettled: models.MaxReconciliationFindings + 2,
+		models.ReconcileMissingBill:    1,
+	}, report.Counts)
+	assert.Equal(t, models.MaxReconciliationFindings/2+1, report.Repaired)
+}
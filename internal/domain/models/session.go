@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Session is a signed-in device: the chain of refresh tokens started by one
+// login. Its ID is the sid claim of every token in the chain, so revoking
+// the session rejects all of them.
+type Session struct {
+	ID         bson.ObjectID `bson:"_id"`
+	UserID     bson.ObjectID `bson:"user_id"`
+	Device     string        `bson:"device,omitempty"` // Name the client gave at login.
+	IP         string        `bson:"ip,omitempty"`     // Of the latest login or refresh.
+	UserAgent  string        `bson:"user_agent,omitempty"`
+	CreatedAt  time.Time     `bson:"created_at"`
+	LastUsedAt time.Time     `bson:"last_used_at"`
+	ExpiresAt  time.Time     `bson:"expires_at"` // When the latest refresh token expires; MongoDB deletes the session then.
+
+	Current bool `bson:"-"` // Set when listing, for the session of the caller.
+}
+
+// SessionResponse represents the response for a session.
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device,omitempty"`
+	IP         string    `json:"ip,omitempty"`
+	UserAgent  string    `json:"userAgent,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Current    bool      `json:"current"`
+}
+
+// ToResponse converts a Session to a SessionResponse.
+func (s *Session) ToResponse() *SessionResponse {
+	return &SessionResponse{
+		ID:         s.ID.Hex(),
+		Device:     s.Device,
+		IP:         s.IP,
+		UserAgent:  s.UserAgent,
+		CreatedAt:  s.CreatedAt,
+		LastUsedAt: s.LastUsedAt,
+		ExpiresAt:  s.ExpiresAt,
+		Current:    s.Current,
+	}
+}
+
+// SessionClient describes the client of a login or refresh.
+type SessionClient struct {
+	Device    string
+	IP        string
+	UserAgent string
+}
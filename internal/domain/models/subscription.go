@@ -1,7 +1,10 @@
 package models
 
 import (
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -29,6 +32,15 @@ const (
 	Other         Category = "other"
 )
 
+// Valid reports whether c is one of the supported categories.
+func (c Category) Valid() bool {
+	switch c {
+	case Sports, News, Entertainment, Lifestyle, Technology, Finance, Politics, Other:
+		return true
+	}
+	return false
+}
+
 // Status represents subscription status.
 type Status string
 
@@ -36,8 +48,44 @@ const (
 	Active   Status = "active"
 	Canceled Status = "canceled"
 	Expired  Status = "expired"
+	// PastDue subscriptions have a failed renewal payment and are being
+	// retried; they expire or are suspended if the final retry fails too.
+	PastDue Status = "past_due"
+	// Paused subscriptions are neither reminded nor renewed; resuming extends
+	// their validity by the time they were paused.
+	Paused Status = "paused"
+	// Suspended subscriptions failed their final payment retry. They keep
+	// their dunning record and become active again once its bill is paid.
+	Suspended Status = "suspended"
 )
 
+// Valid reports whether s is one of the subscription statuses.
+func (s Status) Valid() bool {
+	switch s {
+	case Active, Canceled, Expired, PastDue, Paused, Suspended:
+		return true
+	}
+	return false
+}
+
+// DunningFinalAction is what the final failed payment retry does to a
+// past-due subscription.
+type DunningFinalAction string
+
+const (
+	// DunningExpire expires the subscription at the start of the failed bill.
+	DunningExpire DunningFinalAction = "expire"
+	// DunningSuspend suspends the subscription until the failed bill is paid.
+	DunningSuspend DunningFinalAction = "suspend"
+)
+
+// Dunning tracks the payment retries of a past-due subscription.
+type Dunning struct {
+	BillID    bson.ObjectID `bson:"bill_id"`    // The failed renewal bill.
+	StartedAt time.Time     `bson:"started_at"` // When the payment failed.
+	Attempts  int           `bson:"attempts"`   // Retries made so far.
+}
+
 // Subscription represents a subscription in the database.
 type Subscription struct {
 	ID        bson.ObjectID `bson:"_id,omitempty"`
@@ -51,28 +99,142 @@ type Subscription struct {
 	UserID    bson.ObjectID `bson:"user_id"`
 	CreatedAt time.Time     `bson:"created_at"`
 	UpdatedAt time.Time     `bson:"updated_at"`
+
+	// Version counts the writes to the subscription. Every write increments
+	// it, and Update only replaces the version it read, so a write based on a
+	// stale copy fails with a conflict instead of overwriting another.
+	Version int64 `bson:"version"`
+
+	// LastUsedAt is the latest usage reported for the subscription; nil if
+	// none has been reported.
+	LastUsedAt *time.Time `bson:"last_used_at,omitempty"`
+
+	// ArchivedAt is set only on subscriptions read from the archive.
+	ArchivedAt *time.Time `bson:"archived_at,omitempty"`
+
+	// TrialDays is the length of the free trial the subscription started
+	// with; TrialEndsAt is when it ends and the first charge is made. Both
+	// are unset for subscriptions without a trial.
+	TrialDays   int        `bson:"trial_days,omitempty"`
+	TrialEndsAt *time.Time `bson:"trial_ends_at,omitempty"`
+
+	// Timezone is the IANA name of the zone the vendor bills in, e.g.
+	// "America/New_York". Renewal dates fall on local midnight there.
+	Timezone string `bson:"timezone,omitempty"`
+
+	// Dunning is set while the subscription is past due.
+	Dunning *Dunning `bson:"dunning,omitempty"`
+
+	// PausedAt is when the subscription was paused; set only while paused.
+	PausedAt *time.Time `bson:"paused_at,omitempty"`
+
+	// PaymentMethodID is the payment method the subscription is paid with;
+	// nil if none is linked.
+	PaymentMethodID *bson.ObjectID `bson:"payment_method_id,omitempty"`
+
+	// Tags are the user's own labels, normalized by NormalizeTags; Notes is
+	// free text. Neither affects billing.
+	Tags  []string `bson:"tags,omitempty"`
+	Notes string   `bson:"notes,omitempty"`
+
+	// ReminderDays overrides the days before a renewal the owner is reminded
+	// on, for this subscription only; nil falls back to the owner's
+	// preferences and then the server defaults.
+	ReminderDays []int `bson:"reminder_days,omitempty"`
+
+	// CatalogID is the catalog entry the subscription was created from;
+	// empty if it was entered by hand.
+	CatalogID string `bson:"catalog_id,omitempty"`
+}
+
+// BillingLocation returns the zone renewal dates are computed in: the
+// subscription's own timezone, or fallback when it has none.
+func (s *Subscription) BillingLocation(fallback *time.Location) *time.Location {
+	if s.Timezone != "" {
+		if loc, err := time.LoadLocation(s.Timezone); err == nil {
+			return loc
+		}
+	}
+	if fallback == nil {
+		return time.Local
+	}
+	return fallback
+}
+
+// MaxTrialDays is the longest free trial a subscription can start with.
+const MaxTrialDays = 365
+
+const (
+	// MaxTags is the most tags a subscription can carry.
+	MaxTags = 20
+	// MaxTagLength is the longest tag, in characters.
+	MaxTagLength = 32
+	// MaxNotesLength is the longest note, in characters.
+	MaxNotesLength = 2000
+)
+
+// NormalizeTag trims and lowercases a tag, so tags differing only in case or
+// surrounding space are the same tag.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// NormalizeTags normalizes each tag and drops duplicates, keeping the first
+// occurrence. Empty tags are kept for Validate to reject.
+func NormalizeTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = NormalizeTag(tag); !slices.Contains(res, tag) {
+			res = append(res, tag)
+		}
+	}
+	return res
+}
+
+// ReminderDaysOr returns the days before a renewal the owner is reminded on,
+// falling back to defaults when the subscription has no days of its own.
+func (s *Subscription) ReminderDaysOr(defaults []int) []int {
+	if s.ReminderDays != nil {
+		return s.ReminderDays
+	}
+	return defaults
+}
+
+// InTrial reports whether the upcoming renewal ends the free trial, i.e. is
+// the first time the subscription is charged.
+func (s *Subscription) InTrial() bool {
+	return s.TrialEndsAt != nil && s.ValidTill.Equal(*s.TrialEndsAt)
+}
+
+// AnonymizedName replaces the name of the subscriptions of deleted accounts,
+// whose bills are kept.
+const AnonymizedName = "Deleted subscription"
+
+// ArchivedSubscription is a subscription moved to cold storage together with
+// its billing history.
+type ArchivedSubscription struct {
+	Subscription `bson:",inline"`
+	Bills        []*Bill `bson:"bills"`
 }
 
 // Validate validates the subscription fields.
 func (s *Subscription) Validate(now time.Time) error {
-	if s.Name == "" || len(s.Name) < 2 || len(s.Name) > 100 {
-		return apperror.NewValidationError("name must be between 2 and 100 characters")
+	if err := s.ValidateDetails(); err != nil {
+		return err
 	}
 	if s.Price <= 0 {
 		return apperror.NewValidationError("price must be greater than 0")
 	}
-	if s.Currency != USD && s.Currency != EUR && s.Currency != GBP {
+	if !s.Currency.Valid() {
 		return apperror.NewValidationError("invalid currency")
 	}
 	if s.Frequency != Monthly && s.Frequency != Yearly {
 		return apperror.NewValidationError("invalid frequency")
 	}
-	if s.Category != Sports && s.Category != News && s.Category != Entertainment &&
-		s.Category != Lifestyle && s.Category != Technology && s.Category != Finance &&
-		s.Category != Politics && s.Category != Other {
-		return apperror.NewValidationError("invalid category")
-	}
-	if s.Status != Active && s.Status != Canceled && s.Status != Expired {
+	if !s.Status.Valid() {
 		return apperror.NewValidationError("invalid status")
 	}
 	if s.ValidTill.IsZero() {
@@ -84,16 +246,134 @@ func (s *Subscription) Validate(now time.Time) error {
 	if s.UserID.IsZero() {
 		return apperror.NewValidationError("user ID is required")
 	}
+	if s.TrialDays < 0 || s.TrialDays > MaxTrialDays {
+		return apperror.NewValidationError("trial must be between 0 and 365 days")
+	}
+	if s.Timezone != "" {
+		// "Local" would depend on the server the request lands on.
+		if _, err := time.LoadLocation(s.Timezone); err != nil || s.Timezone == "Local" {
+			return apperror.NewValidationError("invalid timezone")
+		}
+	}
+	return nil
+}
+
+// ValidateDetails validates the fields the owner may change after the
+// subscription was created, as Validate does.
+func (s *Subscription) ValidateDetails() error {
+	if s.Name == "" || len(s.Name) < 2 || len(s.Name) > 100 {
+		return apperror.NewValidationError("name must be between 2 and 100 characters")
+	}
+	if !s.Category.Valid() {
+		return apperror.NewValidationError("invalid category")
+	}
+	if len(s.Tags) > MaxTags {
+		return apperror.NewValidationError("at most %d tags are allowed", MaxTags)
+	}
+	for _, tag := range s.Tags {
+		if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+			return apperror.NewValidationError("tags must be between 1 and %d characters", MaxTagLength)
+		}
+	}
+	if utf8.RuneCountInString(s.Notes) > MaxNotesLength {
+		return apperror.NewValidationError("notes must be at most %d characters", MaxNotesLength)
+	}
+	for i, days := range s.ReminderDays {
+		if days < 0 || days > MaxReminderDays {
+			return apperror.NewValidationError("reminder days must be between 0 and %d", MaxReminderDays)
+		}
+		if slices.Contains(s.ReminderDays[:i], days) {
+			return apperror.NewValidationError("reminder day %d is listed more than once", days)
+		}
+	}
 	return nil
 }
 
 // SubscriptionRequest represents the data structure for subscription API requests.
 type SubscriptionRequest struct {
-	Name      string    `json:"name" validate:"required,min=2,max=100"`
-	Price     int64     `json:"price" validate:"required,gt=0"`
+	// CatalogID pre-fills the name, category, price, currency, and
+	// frequency left out from a catalog entry's CatalogPlan, or its default
+	// plan.
+	CatalogID   string `json:"catalogId"`
+	CatalogPlan string `json:"catalogPlan" validate:"excluded_without=CatalogID"`
+
+	Name      string    `json:"name" validate:"required_without=CatalogID,omitempty,min=2,max=100"`
+	Price     int64     `json:"price" validate:"required_without=CatalogID,omitempty,gt=0"`
 	Currency  Currency  `json:"currency"`
-	Frequency Frequency `json:"frequency" validate:"required"`
-	Category  Category  `json:"category" validate:"required"`
+	Frequency Frequency `json:"frequency" validate:"required_without=CatalogID"`
+	Category  Category  `json:"category" validate:"required_without=CatalogID"`
+	TrialDays int       `json:"trialDays" validate:"gte=0,lte=365"` // Optional free trial.
+	Timezone  string    `json:"timezone"`                           // Optional IANA billing timezone.
+	Tags      []string  `json:"tags"`
+	Notes     string    `json:"notes"`
+
+	// ReminderDays overrides the days reminders are sent on for this
+	// subscription. Omit it, or send an empty list, to use the owner's days.
+	ReminderDays []int `json:"reminderDays"`
+
+	// AllowDuplicate creates the subscription even if the user has a running
+	// one with the same name.
+	AllowDuplicate bool `json:"allowDuplicate"`
+}
+
+// SubscriptionUpdateRequest represents the data structure for changing the
+// details of a subscription. Omitted fields are left unchanged.
+type SubscriptionUpdateRequest struct {
+	Name         *string   `json:"name" validate:"omitempty,min=2,max=100"`
+	Category     *Category `json:"category"`
+	Tags         *[]string `json:"tags"`         // An empty list removes every tag.
+	Notes        *string   `json:"notes"`        // An empty string removes the notes.
+	ReminderDays *[]int    `json:"reminderDays"` // An empty list restores the owner's days.
+}
+
+// Apply sets the fields given in the request on s and returns their bson
+// keys, the fields a partial update has to write.
+func (r *SubscriptionUpdateRequest) Apply(s *Subscription) []string {
+	var fields []string
+	if r.Name != nil {
+		s.Name = *r.Name
+		fields = append(fields, "name")
+	}
+	if r.Category != nil {
+		s.Category = *r.Category
+		fields = append(fields, "category")
+	}
+	if r.Tags != nil {
+		s.Tags = NormalizeTags(*r.Tags)
+		fields = append(fields, "tags")
+	}
+	if r.Notes != nil {
+		s.Notes = *r.Notes
+		fields = append(fields, "notes")
+	}
+	if r.ReminderDays != nil {
+		s.ReminderDays = normalizeReminderDays(*r.ReminderDays)
+		fields = append(fields, "reminder_days")
+	}
+	return fields
+}
+
+// normalizeReminderDays maps an empty list to nil, so the subscription falls
+// back to the owner's days.
+func normalizeReminderDays(days []int) []int {
+	if len(days) == 0 {
+		return nil
+	}
+	return days
+}
+
+// UsageRequest represents the data structure for reporting subscription
+// usage. UsedAt defaults to the time of the request.
+type UsageRequest struct {
+	UsedAt *time.Time `json:"usedAt"`
+}
+
+// ReminderSnoozeResponse reports until when renewal reminders of a
+// subscription are held back.
+type ReminderSnoozeResponse struct {
+	SubscriptionID string    `json:"subscriptionId"`
+	RenewsOn       time.Time `json:"renewsOn"`
+	SnoozedUntil   time.Time `json:"snoozedUntil"`
 }
 
 // ToSubscription converts a request to a Subscription model.
@@ -101,9 +381,15 @@ func (r *SubscriptionRequest) ToModel() *Subscription {
 	return &Subscription{
 		Name:      r.Name,
 		Price:     r.Price,
-		Currency:  r.Currency,
+		Currency:  ParseCurrency(string(r.Currency)),
 		Frequency: r.Frequency,
 		Category:  r.Category,
+		TrialDays: r.TrialDays,
+		Timezone:  r.Timezone,
+		Tags:      NormalizeTags(r.Tags),
+		Notes:     r.Notes,
+
+		ReminderDays: normalizeReminderDays(r.ReminderDays),
 	}
 }
 
@@ -111,7 +397,7 @@ func (r *SubscriptionRequest) ToModel() *Subscription {
 type SubscriptionResponse struct {
 	ID        string    `json:"id"`
 	Name      string    `json:"name"`
-	Price     int64     `json:"price"`
+	Price     int64     `json:"price"` // In minor units of Currency.
 	Currency  string    `json:"currency"`
 	Frequency string    `json:"frequency"`
 	Category  string    `json:"category"`
@@ -120,11 +406,28 @@ type SubscriptionResponse struct {
 	UserID    string    `json:"userId"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
+
+	PriceFormatted string     `json:"priceFormatted"` // e.g. "USD 15.49".
+	LastUsedAt     *time.Time `json:"lastUsedAt,omitempty"`
+	ArchivedAt     *time.Time `json:"archivedAt,omitempty"`
+	TrialDays      int        `json:"trialDays,omitempty"`
+	TrialEndsAt    *time.Time `json:"trialEndsAt,omitempty"`
+	Timezone       string     `json:"timezone,omitempty"`
+	PastDue        bool       `json:"pastDue"` // A renewal payment failed and is being retried.
+	PausedAt       *time.Time `json:"pausedAt,omitempty"`
+
+	PaymentMethodID string `json:"paymentMethodId,omitempty"`
+	CatalogID       string `json:"catalogId,omitempty"`
+
+	Tags  []string `json:"tags"`
+	Notes string   `json:"notes,omitempty"`
+
+	ReminderDays []int `json:"reminderDays,omitempty"` // Omitted while the owner's days apply.
 }
 
 // ToResponse converts a Subscription model to a SubscriptionResponse.
 func (s *Subscription) ToResponse() *SubscriptionResponse {
-	return &SubscriptionResponse{
+	resp := &SubscriptionResponse{
 		ID:        s.ID.Hex(),
 		Name:      s.Name,
 		Price:     s.Price,
@@ -136,5 +439,43 @@ func (s *Subscription) ToResponse() *SubscriptionResponse {
 		UserID:    s.UserID.Hex(),
 		CreatedAt: s.CreatedAt,
 		UpdatedAt: s.UpdatedAt,
+
+		PriceFormatted: FormatMoney(s.Price, s.Currency),
+		LastUsedAt:     s.LastUsedAt,
+		ArchivedAt:     s.ArchivedAt,
+		TrialDays:      s.TrialDays,
+		TrialEndsAt:    s.TrialEndsAt,
+		Timezone:       s.Timezone,
+		PastDue:        s.Status == PastDue,
+		PausedAt:       s.PausedAt,
+
+		Tags:  s.Tags,
+		Notes: s.Notes,
+
+		ReminderDays: s.ReminderDays,
+
+		CatalogID: s.CatalogID,
+	}
+	if resp.Tags == nil {
+		resp.Tags = []string{}
+	}
+	if s.PaymentMethodID != nil {
+		resp.PaymentMethodID = s.PaymentMethodID.Hex()
+	}
+	return resp
+}
+
+// YearlyCost returns what the subscription costs over a year, in minor units
+// of its currency.
+func (s *Subscription) YearlyCost() int64 {
+	return AnnualizedPrice(s.Price, s.Frequency)
+}
+
+// AnnualizedPrice returns what a price charged at the given frequency amounts
+// to over a year.
+func AnnualizedPrice(price int64, frequency Frequency) int64 {
+	if frequency == Monthly {
+		return price * 12
 	}
+	return price
 }
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"cmp"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// SubscriptionSort names the field subscription listings are ordered by.
+type SubscriptionSort string
+
+const (
+	SortByCreatedAt SubscriptionSort = "createdAt"
+	SortByPrice     SubscriptionSort = "price"
+	SortByValidTill SubscriptionSort = "validTill"
+)
+
+// Field returns the document field the sort key orders by.
+func (s SubscriptionSort) Field() string {
+	switch s {
+	case SortByPrice:
+		return "price"
+	case SortByValidTill:
+		return "valid_till"
+	default:
+		return "created_at"
+	}
+}
+
+// SubscriptionQuery filters, orders, and pages a subscription listing. Zero
+// filter values match any subscription.
+type SubscriptionQuery struct {
+	UserID    bson.ObjectID // Set by the service; zero lists every user.
+	Status    Status
+	Category  Category
+	Frequency Frequency
+	Tag       string // Matches subscriptions carrying the tag.
+
+	Sort       SubscriptionSort
+	Descending bool
+
+	PageQuery
+}
+
+// Normalize fills in the default sort and page size and rejects unknown
+// values.
+func (q *SubscriptionQuery) Normalize() error {
+	if q.Status != "" && !q.Status.Valid() {
+		return apperror.NewValidationError("invalid status filter")
+	}
+	if q.Category != "" && !q.Category.Valid() {
+		return apperror.NewValidationError("invalid category filter")
+	}
+	if q.Frequency != "" && q.Frequency != Monthly && q.Frequency != Yearly {
+		return apperror.NewValidationError("invalid frequency filter")
+	}
+	q.Tag = NormalizeTag(q.Tag)
+
+	switch q.Sort {
+	case "":
+		q.Sort = SortByCreatedAt
+	case SortByCreatedAt, SortByPrice, SortByValidTill:
+	default:
+		return apperror.NewValidationError("sort must be one of createdAt, price, validTill")
+	}
+
+	return q.PageQuery.Normalize()
+}
+
+// Filter returns the Mongo filter matching the query.
+func (q *SubscriptionQuery) Filter() bson.M {
+	filter := bson.M{}
+	if !q.UserID.IsZero() {
+		filter["user_id"] = q.UserID
+	}
+	if q.Status != "" {
+		filter["status"] = q.Status
+	}
+	if q.Category != "" {
+		filter["category"] = q.Category
+	}
+	if q.Frequency != "" {
+		filter["frequency"] = q.Frequency
+	}
+	if q.Tag != "" {
+		filter["tags"] = q.Tag
+	}
+	return filter
+}
+
+// SortSpec returns the Mongo sort of the query. The ID breaks ties, so pages
+// do not overlap.
+func (q *SubscriptionQuery) SortSpec() bson.D {
+	direction := 1
+	if q.Descending {
+		direction = -1
+	}
+	return bson.D{
+		{Key: q.Sort.Field(), Value: direction},
+		{Key: "_id", Value: direction},
+	}
+}
+
+// Compare orders two subscriptions the way SortSpec does.
+func (q *SubscriptionQuery) Compare(a, b *Subscription) int {
+	var c int
+	switch q.Sort {
+	case SortByPrice:
+		c = cmp.Compare(a.Price, b.Price)
+	case SortByValidTill:
+		c = a.ValidTill.Compare(b.ValidTill)
+	default:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if c == 0 {
+		c = cmp.Compare(a.ID.Hex(), b.ID.Hex())
+	}
+	if q.Descending {
+		return -c
+	}
+	return c
+}
+
+// SubscriptionPage is one page of a subscription listing.
+type SubscriptionPage struct {
+	Items []*Subscription
+	Total int64 // Matching subscriptions across all pages.
+}
@@ -0,0 +1,223 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// MaxSearchTextLength bounds the search text, so a query cannot make the
+// text index expand thousands of terms.
+const MaxSearchTextLength = 200
+
+// snippetRunes is how much of a long field a highlight keeps, and
+// snippetLead how much of it comes before the first match.
+const (
+	snippetRunes = 160
+	snippetLead  = 40
+)
+
+// SubscriptionSearch is a full-text search over the name, tags, and notes of
+// subscriptions. Text uses the Mongo $text syntax: words match any of them,
+// "quoted phrases" must all appear, and -words exclude.
+type SubscriptionSearch struct {
+	UserID bson.ObjectID // Set by the service; zero searches every user.
+	Text   string
+
+	PageQuery
+}
+
+// Normalize trims the text, fills in the default page size, and rejects an
+// empty or oversized search.
+func (q *SubscriptionSearch) Normalize() error {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Text == "" {
+		return apperror.NewValidationError("search text is required")
+	}
+	if len([]rune(q.Text)) > MaxSearchTextLength {
+		return apperror.NewValidationError("search text must be at most %d characters", MaxSearchTextLength)
+	}
+	return q.PageQuery.Normalize()
+}
+
+// Filter returns the Mongo filter matching the search.
+func (q *SubscriptionSearch) Filter() bson.M {
+	filter := bson.M{"$text": bson.M{"$search": q.Text}}
+	if !q.UserID.IsZero() {
+		filter["user_id"] = q.UserID
+	}
+	return filter
+}
+
+// Terms returns the lowercased words of the search that highlights mark,
+// leaving out excluded ones.
+func (q *SubscriptionSearch) Terms() []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for field := range strings.FieldsSeq(q.Text) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		for _, w := range words(field) {
+			term := strings.ToLower(w.text)
+			if !seen[term] {
+				seen[term] = true
+				terms = append(terms, term)
+			}
+		}
+	}
+	return terms
+}
+
+// SubscriptionMatch is a subscription found by a search, with its relevance
+// score and the matching parts of its fields.
+type SubscriptionMatch struct {
+	Subscription `bson:",inline"`
+	Score        float64     `bson:"score"`
+	Highlights   []Highlight `bson:"-"`
+}
+
+// Highlight marks the search terms in each field of the match that contains
+// any. Tags are highlighted one by one, long notes around the first match.
+func (m *SubscriptionMatch) Highlight(terms []string) {
+	m.Highlights = nil
+	m.addHighlight("name", m.Name, terms)
+	for _, tag := range m.Tags {
+		m.addHighlight("tags", tag, terms)
+	}
+	m.addHighlight("notes", m.Notes, terms)
+}
+
+func (m *SubscriptionMatch) addHighlight(field, value string, terms []string) {
+	if fragments := HighlightText(value, terms); fragments != nil {
+		m.Highlights = append(m.Highlights, Highlight{Field: field, Fragments: fragments})
+	}
+}
+
+// Highlight is the text of a field split into fragments that match the
+// search terms and the ones between them.
+type Highlight struct {
+	Field     string
+	Fragments []HighlightFragment
+}
+
+// HighlightFragment is a piece of highlighted text. Clients render the text
+// themselves, so field contents never need escaping.
+type HighlightFragment struct {
+	Text  string `json:"text"`
+	Match bool   `json:"match,omitempty"`
+}
+
+// HighlightText splits value into fragments, marking the words equal to a
+// term regardless of case. Text longer than a snippet is cut down to the part
+// around the first match. It returns nil when no word matches.
+func HighlightText(value string, terms []string) []HighlightFragment {
+	runes := []rune(value)
+	var matches []span
+	for _, w := range words(value) {
+		for _, term := range terms {
+			if strings.EqualFold(w.text, term) {
+				matches = append(matches, w.span)
+				break
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	start, end := 0, len(runes)
+	if end > snippetRunes {
+		start = max(0, matches[0].start-snippetLead)
+		end = min(len(runes), start+snippetRunes)
+		start = max(0, end-snippetRunes)
+	}
+
+	var fragments []HighlightFragment
+	add := func(text string, match bool) {
+		if text != "" {
+			fragments = append(fragments, HighlightFragment{Text: text, Match: match})
+		}
+	}
+	if start > 0 {
+		add("…", false)
+	}
+	pos := start
+	for _, m := range matches {
+		if m.start < pos || m.end > end {
+			continue
+		}
+		add(string(runes[pos:m.start]), false)
+		add(string(runes[m.start:m.end]), true)
+		pos = m.end
+	}
+	add(string(runes[pos:end]), false)
+	if end < len(runes) {
+		add("…", false)
+	}
+	return fragments
+}
+
+// span is a range of rune offsets.
+type span struct{ start, end int }
+
+type word struct {
+	text string
+	span
+}
+
+// words splits s into its runs of letters and digits, the way the text index
+// tokenizes it.
+func words(s string) []word {
+	var result []word
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			result = append(result, word{string(runes[start:i]), span{start, i}})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		result = append(result, word{string(runes[start:]), span{start, len(runes)}})
+	}
+	return result
+}
+
+// SubscriptionSearchPage is one page of search results, best match first.
+type SubscriptionSearchPage struct {
+	Items []*SubscriptionMatch
+	Total int64 // Matching subscriptions across all pages.
+}
+
+// HighlightResponse represents a Highlight in API responses.
+type HighlightResponse struct {
+	Field     string              `json:"field"`
+	Fragments []HighlightFragment `json:"fragments"`
+}
+
+// SubscriptionMatchResponse represents a SubscriptionMatch in API responses:
+// the subscription with its score and highlights.
+type SubscriptionMatchResponse struct {
+	*SubscriptionResponse
+	Score      float64             `json:"score"`
+	Highlights []HighlightResponse `json:"highlights"`
+}
+
+func (m *SubscriptionMatch) ToResponse() *SubscriptionMatchResponse {
+	highlights := make([]HighlightResponse, len(m.Highlights))
+	for i, h := range m.Highlights {
+		highlights[i] = HighlightResponse{Field: h.Field, Fragments: h.Fragments}
+	}
+	return &SubscriptionMatchResponse{
+		SubscriptionResponse: m.Subscription.ToResponse(),
+		Score:                m.Score,
+		Highlights:           highlights,
+	}
+}
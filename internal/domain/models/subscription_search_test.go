@@ -0,0 +1,119 @@
+package models_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestSubscriptionSearch_Normalize(t *testing.T) {
+	search := models.SubscriptionSearch{Text: "  netflix  "}
+	require.NoError(t, search.Normalize())
+	assert.Equal(t, models.SubscriptionSearch{Text: "netflix", PageQuery: models.PageQuery{Limit: models.DefaultPageLimit}}, search)
+
+	invalid := []models.SubscriptionSearch{
+		{Text: "  "},
+		{Text: strings.Repeat("a", models.MaxSearchTextLength+1)},
+		{Text: "netflix", PageQuery: models.PageQuery{Limit: models.MaxPageLimit + 1}},
+		{Text: "netflix", PageQuery: models.PageQuery{Offset: -1}},
+	}
+	for _, search := range invalid {
+		err := search.Normalize()
+		appErr, ok := errors.AsType[apperror.AppError](err)
+		require.True(t, ok, "%+v", search)
+		assert.Equal(t, apperror.ErrValidation, appErr.Code())
+	}
+}
+
+func TestSubscriptionSearch_Filter(t *testing.T) {
+	search := models.SubscriptionSearch{Text: "netflix"}
+	assert.Equal(t, bson.M{"$text": bson.M{"$search": "netflix"}}, search.Filter())
+
+	search.UserID = bson.NewObjectID()
+	assert.Equal(t, search.UserID, search.Filter()["user_id"])
+}
+
+func TestSubscriptionSearch_Terms(t *testing.T) {
+	search := models.SubscriptionSearch{Text: `Music "family plan" -video music, apple-tv`}
+	assert.Equal(t, []string{"music", "family", "plan", "apple", "tv"}, search.Terms())
+}
+
+func TestHighlightText(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		terms []string
+		want  []models.HighlightFragment
+	}{
+		{
+			name:  "no match",
+			value: "Netflix",
+			terms: []string{"hulu"},
+		},
+		{
+			name:  "whole words ignoring case",
+			value: "Apple Music, music and Musicals",
+			terms: []string{"music"},
+			want: []models.HighlightFragment{
+				{Text: "Apple "},
+				{Text: "Music", Match: true},
+				{Text: ", "},
+				{Text: "music", Match: true},
+				{Text: " and Musicals"},
+			},
+		},
+		{
+			name:  "several terms",
+			value: "Über family",
+			terms: []string{"über", "family"},
+			want: []models.HighlightFragment{
+				{Text: "Über", Match: true},
+				{Text: " "},
+				{Text: "family", Match: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, models.HighlightText(tt.value, tt.terms))
+		})
+	}
+}
+
+func TestHighlightText_Snippet(t *testing.T) {
+	value := strings.Repeat("lorem ", 50) + "renewal " + strings.Repeat("ipsum ", 50)
+
+	got := models.HighlightText(value, []string{"renewal"})
+
+	require.Len(t, got, 5)
+	assert.Equal(t, models.HighlightFragment{Text: "…"}, got[0])
+	assert.Equal(t, models.HighlightFragment{Text: "renewal", Match: true}, got[2])
+	assert.Equal(t, models.HighlightFragment{Text: "…"}, got[4])
+	var length int
+	for _, fragment := range got[1:4] {
+		length += len([]rune(fragment.Text))
+	}
+	assert.Equal(t, 160, length)
+	assert.Len(t, []rune(got[1].Text), 40, "the snippet starts a little before the match")
+}
+
+func TestSubscriptionMatch_Highlight(t *testing.T) {
+	match := &models.SubscriptionMatch{Subscription: models.Subscription{
+		Name:  "Spotify",
+		Tags:  []string{"music", "family"},
+		Notes: "Shared with the family",
+	}}
+
+	match.Highlight([]string{"family"})
+
+	assert.Equal(t, []models.Highlight{
+		{Field: "tags", Fragments: []models.HighlightFragment{{Text: "family", Match: true}}},
+		{Field: "notes", Fragments: []models.HighlightFragment{{Text: "Shared with the "}, {Text: "family", Match: true}}},
+	}, match.Highlights)
+}
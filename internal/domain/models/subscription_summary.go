@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// SummaryRenewalWindow is how far ahead a subscription summary lists
+// renewals.
+const SummaryRenewalWindow = 30 * 24 * time.Hour
+
+// CategorySpendTotal is the combined price of a user's active subscriptions
+// sharing a category, currency, and billing frequency.
+type CategorySpendTotal struct {
+	Category  Category  `bson:"category"`
+	Currency  Currency  `bson:"currency"`
+	Frequency Frequency `bson:"frequency"`
+	Total     int64     `bson:"total"`
+}
+
+// TagSpendTotal is the combined price and number of a user's active
+// subscriptions carrying a tag and sharing a currency and billing frequency.
+type TagSpendTotal struct {
+	Tag       string    `bson:"tag"`
+	Currency  Currency  `bson:"currency"`
+	Frequency Frequency `bson:"frequency"`
+	Total     int64     `bson:"total"`
+	Count     int64     `bson:"count"`
+}
+
+// StatusCount is the number of a user's subscriptions in one status.
+type StatusCount struct {
+	Status Status `bson:"_id"`
+	Count  int64  `bson:"count"`
+}
+
+// SummaryRenewal is an active subscription renewing within the summary
+// window.
+type SummaryRenewal struct {
+	ID        bson.ObjectID `bson:"_id"`
+	Name      string        `bson:"name"`
+	Price     int64         `bson:"price"`
+	Currency  Currency      `bson:"currency"`
+	ValidTill time.Time     `bson:"valid_till"`
+}
+
+// ToUpcomingRenewal converts a SummaryRenewal to an UpcomingRenewal.
+func (r *SummaryRenewal) ToUpcomingRenewal() UpcomingRenewal {
+	return UpcomingRenewal{
+		SubscriptionID: r.ID.Hex(),
+		Name:           r.Name,
+		Amount:         r.Price,
+		Currency:       string(r.Currency),
+		RenewsOn:       r.ValidTill,
+	}
+}
+
+// SubscriptionSummary holds the aggregates of one user's subscriptions, as
+// computed by the database.
+type SubscriptionSummary struct {
+	ByStatus []StatusCount        `bson:"by_status"`
+	Spend    []CategorySpendTotal `bson:"spend"`
+	TagSpend []TagSpendTotal      `bson:"tag_spend"`
+	Upcoming []SummaryRenewal     `bson:"upcoming"` // Soonest first.
+}
+
+// CategorySpend is the monthly spend of one category, per currency.
+type CategorySpend struct {
+	Category  Category         `json:"category"`
+	Monthly   []CurrencyAmount `json:"monthly"`
+	Converted *CurrencyAmount  `json:"converted,omitempty"` // Monthly in the requested currency.
+}
+
+// TagSpend is the monthly spend of the active subscriptions carrying a tag,
+// per currency. A subscription with several tags counts towards each.
+type TagSpend struct {
+	Tag       string           `json:"tag"`
+	Count     int64            `json:"count"` // Active subscriptions with the tag.
+	Monthly   []CurrencyAmount `json:"monthly"`
+	Converted *CurrencyAmount  `json:"converted,omitempty"` // Monthly in the requested currency.
+}
+
+// SubscriptionSummaryResponse summarizes the subscriptions of a user. Spend
+// covers active subscriptions, with yearly ones counted at a twelfth of their
+// price per month. Amounts are kept per currency and, when a currency is
+// requested, also converted into it at the latest exchange rates.
+type SubscriptionSummaryResponse struct {
+	GeneratedAt      time.Time          `json:"generatedAt"`
+	MonthlySpend     []CurrencyAmount   `json:"monthlySpend"`
+	ConvertedSpend   *CurrencyAmount    `json:"convertedSpend,omitempty"` // MonthlySpend in the requested currency.
+	RatesDate        string             `json:"ratesDate,omitempty"`      // Publication day of the rates used, as YYYY-MM-DD.
+	SpendByCategory  []CategorySpend    `json:"spendByCategory"`
+	SpendByTag       []TagSpend         `json:"spendByTag"`
+	Counts           SubscriptionCounts `json:"counts"`
+	UpcomingRenewals []UpcomingRenewal  `json:"upcomingRenewals"` // Next 30 days, soonest first.
+}
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// SuppressionReason is why an email address is suppressed.
+type SuppressionReason string
+
+const (
+	// SuppressionBounced marks addresses that do not accept mail, such as a
+	// mailbox that does not exist.
+	SuppressionBounced SuppressionReason = "bounced"
+	// SuppressionComplained marks addresses whose owner reported the emails
+	// as spam.
+	SuppressionComplained SuppressionReason = "complained"
+)
+
+// Suppression is an email address no email is sent to. Sending to addresses
+// that bounce or complain hurts the reputation of the sending domain.
+type Suppression struct {
+	Email     string            `bson:"_id"` // Normalized with NormalizeEmail.
+	Reason    SuppressionReason `bson:"reason"`
+	Detail    string            `bson:"detail,omitempty"` // E.g. the reply of the SMTP server.
+	CreatedAt time.Time         `bson:"created_at"`
+}
+
+// SuppressionRequest represents the data structure for adding a suppression
+// through the API, e.g. from the bounce report of an email provider.
+type SuppressionRequest struct {
+	Email  string            `json:"email" validate:"required,email"`
+	Reason SuppressionReason `json:"reason" validate:"required,oneof=bounced complained"`
+	Detail string            `json:"detail" validate:"max=1000"`
+}
+
+// ToModel converts a SuppressionRequest to a Suppression model.
+func (r *SuppressionRequest) ToModel() *Suppression {
+	return &Suppression{
+		Email:  NormalizeEmail(r.Email),
+		Reason: r.Reason,
+		Detail: r.Detail,
+	}
+}
+
+// SuppressionResponse represents the response for a suppression.
+type SuppressionResponse struct {
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ToResponse converts a Suppression to a SuppressionResponse.
+func (s *Suppression) ToResponse() *SuppressionResponse {
+	return &SuppressionResponse{
+		Email:     s.Email,
+		Reason:    string(s.Reason),
+		Detail:    s.Detail,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+// SuppressionQuery pages the suppression list, newest first.
+type SuppressionQuery struct {
+	PageQuery
+}
+
+// SuppressionPage is one page of the suppression list.
+type SuppressionPage struct {
+	Items []*Suppression
+	Total int64 // Suppressions across all pages.
+}
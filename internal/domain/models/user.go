@@ -1,19 +1,52 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+// Role determines what a user is allowed to do.
+type Role string
+
+const (
+	UserRole  Role = "user"
+	AdminRole Role = "admin"
+)
+
+// OrDefault returns r, or UserRole when r is empty.
+func (r Role) OrDefault() Role {
+	if r == "" {
+		return UserRole
+	}
+	return r
+}
+
 // User represents the database model for a user.
 type User struct {
 	ID        bson.ObjectID `bson:"_id,omitempty"`
 	Name      string        `bson:"name"`
 	Email     string        `bson:"email"`
 	Password  string        `bson:"password"`
+	Role      Role          `bson:"role"`             // Users created through the API are always UserRole.
+	Locale    string        `bson:"locale,omitempty"` // BCP 47 tag of the language of messages to the user.
 	CreatedAt time.Time     `bson:"created_at"`
 	UpdatedAt time.Time     `bson:"updated_at"`
+
+	// ClosedAt is when the user closed their account, and PurgeAt when it is
+	// deleted for good. Both are unset on open accounts.
+	ClosedAt *time.Time `bson:"closed_at,omitempty"`
+	PurgeAt  *time.Time `bson:"purge_at,omitempty"`
+
+	// CalendarFeedNonce is signed into the user's calendar feed token.
+	// Regenerating it revokes the feed URLs issued before.
+	CalendarFeedNonce string `bson:"calendar_feed_nonce,omitempty"`
+}
+
+// Closed reports whether the user closed their account.
+func (u *User) Closed() bool {
+	return u.ClosedAt != nil
 }
 
 // UserRequest represents the data structure for user registration API requests.
@@ -21,6 +54,7 @@ type UserRequest struct {
 	Name     string `json:"name" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
+	Locale   string `json:"locale" validate:"omitempty,bcp47_language_tag"` // Defaults to the Accept-Language header.
 }
 
 // ToModel converts a UserRequest to a User model.
@@ -29,6 +63,7 @@ func (r *UserRequest) ToModel() *User {
 		Name:     r.Name,
 		Email:    r.Email,
 		Password: r.Password, // Will be hashed before storing.
+		Locale:   r.Locale,
 	}
 }
 
@@ -37,7 +72,12 @@ type UserResponse struct {
 	ID        string    `json:"id"`
 	Name      string    `json:"name"`
 	Email     string    `json:"email"`
+	Role      Role      `json:"role"`
+	Locale    string    `json:"locale,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
+
+	ClosedAt *time.Time `json:"closedAt,omitempty"`
+	PurgeAt  *time.Time `json:"purgeAt,omitempty"`
 }
 
 // ToResponse converts a User model to a UserResponse.
@@ -46,7 +86,17 @@ func (u *User) ToResponse() *UserResponse {
 		ID:        u.ID.Hex(),
 		Name:      u.Name,
 		Email:     u.Email,
+		Role:      u.Role.OrDefault(),
+		Locale:    u.Locale,
 		CreatedAt: u.CreatedAt,
+		ClosedAt:  u.ClosedAt,
+		PurgeAt:   u.PurgeAt,
 	}
 }
 
+// NormalizeEmail returns the canonical form of an email address used for
+// storage and lookups, so addresses differing only in case or surrounding
+// whitespace are treated as the same account.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
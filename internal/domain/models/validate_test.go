@@ -112,6 +112,27 @@ func TestSubscription_Validate(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "success - past due status accepted",
+			mutate: func(s *models.Subscription) {
+				s.Status = models.PastDue
+			},
+			wantError: false,
+		},
+		{
+			name: "success - paused status accepted",
+			mutate: func(s *models.Subscription) {
+				s.Status = models.Paused
+			},
+			wantError: false,
+		},
+		{
+			name: "success - any ISO 4217 currency accepted",
+			mutate: func(s *models.Subscription) {
+				s.Currency = "JPY"
+			},
+			wantError: false,
+		},
 		{
 			name: "error - empty name",
 			mutate: func(s *models.Subscription) {
@@ -155,7 +176,7 @@ func TestSubscription_Validate(t *testing.T) {
 		{
 			name: "error - invalid currency",
 			mutate: func(s *models.Subscription) {
-				s.Currency = "INR"
+				s.Currency = "XYZ"
 			},
 			wantError:   true,
 			errContains: "invalid currency",
@@ -225,6 +246,100 @@ func TestSubscription_Validate(t *testing.T) {
 			wantError:   true,
 			errContains: "user ID is required",
 		},
+		{
+			name: "success - billing timezone accepted",
+			mutate: func(s *models.Subscription) {
+				s.Timezone = "America/New_York"
+			},
+		},
+		{
+			name: "error - unknown timezone",
+			mutate: func(s *models.Subscription) {
+				s.Timezone = "Mars/Olympus_Mons"
+			},
+			wantError:   true,
+			errContains: "invalid timezone",
+		},
+		{
+			name: "error - server-local timezone",
+			mutate: func(s *models.Subscription) {
+				s.Timezone = "Local"
+			},
+			wantError:   true,
+			errContains: "invalid timezone",
+		},
+		{
+			name: "error - trial longer than a year",
+			mutate: func(s *models.Subscription) {
+				s.TrialDays = models.MaxTrialDays + 1
+			},
+			wantError:   true,
+			errContains: "trial must be between 0 and 365 days",
+		},
+		{
+			name: "success - tags and notes accepted",
+			mutate: func(s *models.Subscription) {
+				s.Tags = []string{"work", "shared with sam"}
+				s.Notes = "Billed to the company card."
+			},
+		},
+		{
+			name: "error - too many tags",
+			mutate: func(s *models.Subscription) {
+				s.Tags = make([]string, models.MaxTags+1)
+				for i := range s.Tags {
+					s.Tags[i] = strings.Repeat("t", i+1)
+				}
+			},
+			wantError:   true,
+			errContains: "at most 20 tags are allowed",
+		},
+		{
+			name: "error - empty tag",
+			mutate: func(s *models.Subscription) {
+				s.Tags = []string{"work", ""}
+			},
+			wantError:   true,
+			errContains: "tags must be between 1 and 32 characters",
+		},
+		{
+			name: "error - tag too long",
+			mutate: func(s *models.Subscription) {
+				s.Tags = []string{strings.Repeat("ü", models.MaxTagLength+1)}
+			},
+			wantError:   true,
+			errContains: "tags must be between 1 and 32 characters",
+		},
+		{
+			name: "error - notes too long",
+			mutate: func(s *models.Subscription) {
+				s.Notes = strings.Repeat("n", models.MaxNotesLength+1)
+			},
+			wantError:   true,
+			errContains: "notes must be at most 2000 characters",
+		},
+		{
+			name: "valid - own reminder days",
+			mutate: func(s *models.Subscription) {
+				s.ReminderDays = []int{14, 3, 0}
+			},
+		},
+		{
+			name: "error - reminder day out of range",
+			mutate: func(s *models.Subscription) {
+				s.ReminderDays = []int{7, models.MaxReminderDays + 1}
+			},
+			wantError:   true,
+			errContains: "reminder days must be between 0 and 90",
+		},
+		{
+			name: "error - duplicate reminder day",
+			mutate: func(s *models.Subscription) {
+				s.ReminderDays = []int{7, 3, 7}
+			},
+			wantError:   true,
+			errContains: "reminder day 7 is listed more than once",
+		},
 	}
 
 	for _, tt := range tests {
@@ -251,6 +366,59 @@ func TestSubscription_Validate(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// NormalizeTags
+// ---------------------------------------------------------------------------
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Nil(t, models.NormalizeTags(nil))
+	assert.Equal(t,
+		[]string{"work", "family plan", ""},
+		models.NormalizeTags([]string{" Work", "family plan", "WORK ", " "}),
+	)
+}
+
+// ---------------------------------------------------------------------------
+// SubscriptionUpdateRequest.Apply
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionUpdateRequest_Apply(t *testing.T) {
+	validSub := func() *models.Subscription {
+		return &models.Subscription{Name: "Netflix", Category: models.Entertainment}
+	}
+
+	t.Run("changes only the given fields", func(t *testing.T) {
+		s := validSub()
+		s.Notes = "shared with family"
+		name := "Netflix Premium"
+		tags := []string{" Family ", "family"}
+
+		fields := (&models.SubscriptionUpdateRequest{Name: &name, Tags: &tags}).Apply(s)
+
+		assert.Equal(t, []string{"name", "tags"}, fields)
+		assert.Equal(t, "Netflix Premium", s.Name)
+		assert.Equal(t, []string{"family"}, s.Tags)
+		assert.Equal(t, "shared with family", s.Notes)
+		assert.Equal(t, models.Entertainment, s.Category)
+	})
+
+	t.Run("empty values clear the fields", func(t *testing.T) {
+		s := validSub()
+		s.Tags = []string{"work"}
+		s.Notes = "expensed"
+		s.ReminderDays = []int{3}
+		tags, notes, days := []string{}, "", []int{}
+
+		fields := (&models.SubscriptionUpdateRequest{Tags: &tags, Notes: &notes, ReminderDays: &days}).Apply(s)
+
+		assert.Equal(t, []string{"tags", "notes", "reminder_days"}, fields)
+		assert.Nil(t, s.Tags)
+		assert.Empty(t, s.Notes)
+		assert.Nil(t, s.ReminderDays)
+		assert.Equal(t, []int{7, 1}, s.ReminderDaysOr([]int{7, 1}))
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Bill.Validate
 // ---------------------------------------------------------------------------
@@ -303,6 +471,13 @@ func TestBill_Validate(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "success - failed status accepted",
+			mutate: func(b *models.Bill) {
+				b.Status = models.Failed
+			},
+			wantError: false,
+		},
 		{
 			name: "error - amount is zero",
 			mutate: func(b *models.Bill) {
@@ -330,10 +505,10 @@ func TestBill_Validate(t *testing.T) {
 		{
 			name: "error - invalid currency",
 			mutate: func(b *models.Bill) {
-				b.Currency = "INR"
+				b.Currency = "XYZ"
 			},
 			wantError:   true,
-			errContains: "currency must be one of",
+			errContains: "currency must be an ISO 4217 currency code",
 		},
 		{
 			name: "error - start date is zero",
@@ -379,10 +554,10 @@ func TestBill_Validate(t *testing.T) {
 		{
 			name: "error - invalid payment status",
 			mutate: func(b *models.Bill) {
-				b.Status = "pending"
+				b.Status = "processing"
 			},
 			wantError:   true,
-			errContains: "status must be either paid or refunded",
+			errContains: "status must be one of paid, pending, refunded, or failed",
 		},
 	}
 
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Webhook delivers the domain events of a user to an external URL. Each
+// delivery is signed with the webhook's secret.
+type Webhook struct {
+	ID        bson.ObjectID `bson:"_id"`
+	UserID    bson.ObjectID `bson:"user_id"`
+	URL       string        `bson:"url"`
+	Secret    string        `bson:"secret"` // HMAC key for delivery signatures; shown once on creation.
+	Events    []EventType   `bson:"events"` // Event types delivered to the URL.
+	CreatedAt time.Time     `bson:"created_at"`
+	UpdatedAt time.Time     `bson:"updated_at"`
+}
+
+// Validate validates the webhook fields.
+func (w *Webhook) Validate() error {
+	if w.URL == "" {
+		return apperror.NewValidationError("url is required")
+	}
+	if len(w.Events) == 0 {
+		return apperror.NewValidationError("at least one event is required")
+	}
+	for _, event := range w.Events {
+		if !event.Valid() {
+			return apperror.NewValidationError("unknown event %s", event)
+		}
+	}
+	if w.UserID.IsZero() {
+		return apperror.NewValidationError("user ID is required")
+	}
+	return nil
+}
+
+// Subscribes reports whether the webhook receives events of the given type.
+func (w *Webhook) Subscribes(eventType EventType) bool {
+	return slices.Contains(w.Events, eventType)
+}
+
+// WebhookRequest represents the data structure for webhook API requests.
+type WebhookRequest struct {
+	URL    string      `json:"url" validate:"required,http_url"`
+	Events []EventType `json:"events" validate:"required,min=1"`
+}
+
+// ToModel converts a WebhookRequest to a Webhook model.
+func (r *WebhookRequest) ToModel() *Webhook {
+	return &Webhook{
+		URL:    r.URL,
+		Events: r.Events,
+	}
+}
+
+// WebhookResponse represents the data structure for webhook API responses.
+// The secret is only set in the response to the creation request.
+type WebhookResponse struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ToResponse converts a Webhook model to a WebhookResponse without its
+// secret.
+func (w *Webhook) ToResponse() *WebhookResponse {
+	events := make([]string, len(w.Events))
+	for i, event := range w.Events {
+		events[i] = string(event)
+	}
+	return &WebhookResponse{
+		ID:        w.ID.Hex(),
+		URL:       w.URL,
+		Events:    events,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+// WebhookEvent is the JSON body POSTed to a webhook URL.
+type WebhookEvent struct {
+	ID         string          `json:"id"` // Event ID; identical across retries, so receivers can deduplicate.
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// WebhookDelivery records one attempt to deliver an event to a webhook.
+type WebhookDelivery struct {
+	ID         bson.ObjectID `bson:"_id"`
+	WebhookID  bson.ObjectID `bson:"webhook_id"`
+	EventID    bson.ObjectID `bson:"event_id"`
+	EventType  EventType     `bson:"event_type"`
+	Attempt    int           `bson:"attempt"`               // 1 for the first try.
+	StatusCode int           `bson:"status_code,omitempty"` // Unset when no response was received.
+	Error      string        `bson:"error,omitempty"`
+	Succeeded  bool          `bson:"succeeded"`
+	Duration   time.Duration `bson:"duration"`
+	CreatedAt  time.Time     `bson:"created_at"`
+}
+
+// WebhookDeliveryResponse represents the data structure for webhook delivery
+// API responses.
+type WebhookDeliveryResponse struct {
+	ID         string    `json:"id"`
+	EventID    string    `json:"eventId"`
+	EventType  string    `json:"eventType"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Succeeded  bool      `json:"succeeded"`
+	DurationMs int64     `json:"durationMs"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ToResponse converts a WebhookDelivery model to a WebhookDeliveryResponse.
+func (d *WebhookDelivery) ToResponse() *WebhookDeliveryResponse {
+	return &WebhookDeliveryResponse{
+		ID:         d.ID.Hex(),
+		EventID:    d.EventID.Hex(),
+		EventType:  string(d.EventType),
+		Attempt:    d.Attempt,
+		StatusCode: d.StatusCode,
+		Error:      d.Error,
+		Succeeded:  d.Succeeded,
+		DurationMs: d.Duration.Milliseconds(),
+		CreatedAt:  d.CreatedAt,
+	}
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ArchiveRepository stores expired subscriptions, with their bills embedded,
+// outside the hot subscriptions and bills collections.
+type ArchiveRepository interface {
+	Create(context.Context, *models.ArchivedSubscription) error
+	GetByID(context.Context, bson.ObjectID) (*models.ArchivedSubscription, error)
+	GetByUserID(context.Context, bson.ObjectID) ([]*models.ArchivedSubscription, error)
+	// List returns the page of archived subscriptions the query selects and
+	// the number matching its filters.
+	List(context.Context, *models.SubscriptionQuery) ([]*models.ArchivedSubscription, int64, error)
+	// Anonymize replaces the name of the user's archived subscriptions with
+	// models.AnonymizedName and removes their tags and notes, keeping their
+	// bills. It returns the number of subscriptions changed.
+	Anonymize(context.Context, bson.ObjectID) (int64, error)
+}
+
+type archiveRepository struct {
+	collection *mongo.Collection
+}
+
+func NewArchiveRepository(ctx context.Context, db *mongo.Database) (ArchiveRepository, error) {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("subscriptions_archive")
+	if _, err := collection.Indexes().CreateOne(ctx, indexModel); err != nil {
+		return nil, fmt.Errorf("failed to create index for user_id field: %w", err)
+	}
+	slog.Debug("Archive repository initialized and index verified")
+
+	return &archiveRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *archiveRepository) Create(ctx context.Context, archived *models.ArchivedSubscription) error {
+	return lib.Create(ctx, r.collection, archived)
+}
+
+func (r *archiveRepository) GetByID(ctx context.Context, id bson.ObjectID) (*models.ArchivedSubscription, error) {
+	filter := bson.M{"_id": id}
+	return lib.FindOne[models.ArchivedSubscription](ctx, r.collection, filter)
+}
+
+func (r *archiveRepository) GetByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.ArchivedSubscription, error) {
+	filter := bson.M{"user_id": userID}
+	return lib.FindMany[models.ArchivedSubscription](ctx, r.collection, filter)
+}
+
+func (r *archiveRepository) List(
+	ctx context.Context,
+	query *models.SubscriptionQuery,
+) ([]*models.ArchivedSubscription, int64, error) {
+	filter := query.Filter()
+	// Bills are not needed in listings.
+	opts := options.Find().
+		SetSort(query.SortSpec()).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit)).
+		SetProjection(bson.M{"bills": 0})
+
+	archived, err := lib.FindMany[models.ArchivedSubscription](ctx, r.collection, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := lib.Count(ctx, r.collection, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return archived, total, nil
+}
+
+func (r *archiveRepository) Anonymize(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	filter := bson.M{"user_id": userID}
+	update := bson.M{
+		"$set":   bson.M{"name": models.AnonymizedName},
+		"$unset": bson.M{"tags": "", "notes": ""},
+	}
+	return lib.UpdateMany(ctx, r.collection, filter, update)
+}
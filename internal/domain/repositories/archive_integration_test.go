@@ -0,0 +1,140 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func validArchivedSub() *models.ArchivedSubscription {
+	archivedAt := mockTime
+	sub := validExpiredSub()
+	sub.ArchivedAt = &archivedAt
+	return &models.ArchivedSubscription{
+		Subscription: *sub,
+		Bills:        []*models.Bill{validBill()},
+	}
+}
+
+func newArchiveRepo(t *testing.T) repositories.ArchiveRepository {
+	t.Helper()
+
+	dbName := "archive_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repositories.NewArchiveRepository(ctx, db)
+	require.NoError(t, err, "NewArchiveRepository should not error")
+
+	return repo
+}
+
+// ---------------------------------------------------------------------------
+// Create / GetByID
+// ---------------------------------------------------------------------------
+
+func TestArchiveRepository_CreateAndGetByID(t *testing.T) {
+	t.Run("success - round-trips subscription with embedded bills", func(t *testing.T) {
+		repo := newArchiveRepo(t)
+		archived := validArchivedSub()
+
+		require.NoError(t, repo.Create(t.Context(), archived))
+
+		got, err := repo.GetByID(t.Context(), archived.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, archived, got)
+	})
+
+	t.Run("error - archiving the same subscription twice returns conflict", func(t *testing.T) {
+		repo := newArchiveRepo(t)
+		archived := validArchivedSub()
+
+		require.NoError(t, repo.Create(t.Context(), archived))
+		err := repo.Create(t.Context(), archived)
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrConflict)
+	})
+
+	t.Run("error - not found returns not-found error", func(t *testing.T) {
+		repo := newArchiveRepo(t)
+
+		got, err := repo.GetByID(t.Context(), bson.NewObjectID())
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+		assert.Nil(t, got)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GetByUserID
+// ---------------------------------------------------------------------------
+
+func TestArchiveRepository_GetByUserID(t *testing.T) {
+	t.Run("returns only the user's archived subscriptions", func(t *testing.T) {
+		repo := newArchiveRepo(t)
+
+		target := validArchivedSub()
+		decoy := validArchivedSub()
+		decoy.UserID = bson.NewObjectID()
+
+		require.NoError(t, repo.Create(t.Context(), target))
+		require.NoError(t, repo.Create(t.Context(), decoy))
+
+		got, err := repo.GetByUserID(t.Context(), defaultUserID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*models.ArchivedSubscription{target}, got)
+	})
+}
+
+func TestArchiveRepository_Anonymize(t *testing.T) {
+	repo := newArchiveRepo(t)
+	archived := validArchivedSub()
+	archived.Tags = []string{"family"}
+	archived.Notes = "Shared with Bob"
+	require.NoError(t, repo.Create(t.Context(), archived))
+
+	others := validArchivedSub()
+	others.ID = bson.NewObjectID()
+	others.UserID = bson.NewObjectID()
+	require.NoError(t, repo.Create(t.Context(), others))
+
+	changed, err := repo.Anonymize(t.Context(), archived.UserID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, changed)
+
+	got, err := repo.GetByID(t.Context(), archived.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.AnonymizedName, got.Name)
+	assert.Empty(t, got.Tags)
+	assert.Empty(t, got.Notes)
+	assert.Len(t, got.Bills, 1, "bills are kept")
+
+	got, err = repo.GetByID(t.Context(), others.ID)
+	require.NoError(t, err)
+	assert.Equal(t, others.Name, got.Name)
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// AttachmentRepository stores the attachments of subscriptions. Their content
+// lives in the FileRepository.
+type AttachmentRepository interface {
+	Create(context.Context, *models.Attachment) (*models.Attachment, error)
+	GetByID(context.Context, bson.ObjectID) (*models.Attachment, error)
+	// GetBySubscriptionID returns the attachments of a subscription, oldest
+	// first.
+	GetBySubscriptionID(context.Context, bson.ObjectID) ([]*models.Attachment, error)
+	CountBySubscriptionID(context.Context, bson.ObjectID) (int64, error)
+	Delete(context.Context, bson.ObjectID) error
+	// DeleteByUserID deletes the attachments of every subscription of the
+	// user and returns how many were deleted. Their files are left alone.
+	DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+type attachmentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAttachmentRepository(ctx context.Context, db *mongo.Database) (AttachmentRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "subscription_id", Value: 1},
+				{Key: "created_at", Value: 1},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("attachments")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Attachment repository initialized and index verified")
+
+	return &attachmentRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error) {
+	if err := lib.Create(ctx, r.collection, attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (r *attachmentRepository) GetByID(ctx context.Context, id bson.ObjectID) (*models.Attachment, error) {
+	filter := bson.M{"_id": id}
+	return lib.FindOne[models.Attachment](ctx, r.collection, filter)
+}
+
+func (r *attachmentRepository) GetBySubscriptionID(ctx context.Context, subscriptionID bson.ObjectID) ([]*models.Attachment, error) {
+	filter := bson.M{"subscription_id": subscriptionID}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	return lib.FindMany[models.Attachment](ctx, r.collection, filter, opts)
+}
+
+func (r *attachmentRepository) CountBySubscriptionID(ctx context.Context, subscriptionID bson.ObjectID) (int64, error) {
+	filter := bson.M{"subscription_id": subscriptionID}
+	return lib.Count(ctx, r.collection, filter)
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, id bson.ObjectID) error {
+	filter := bson.M{"_id": id}
+	return lib.Delete(ctx, r.collection, filter)
+}
+
+func (r *attachmentRepository) DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	return lib.DeleteMany(ctx, r.collection, bson.M{"user_id": userID})
+}
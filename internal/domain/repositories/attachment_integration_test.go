@@ -0,0 +1,98 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newAttachmentRepo(t *testing.T) repositories.AttachmentRepository {
+	t.Helper()
+
+	db := mongoClient.Database("attachment_test_" + bson.NewObjectID().Hex())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	repo, err := repositories.NewAttachmentRepository(t.Context(), db)
+	require.NoError(t, err)
+	return repo
+}
+
+func attachmentFor(subscriptionID bson.ObjectID, createdAt time.Time) *models.Attachment {
+	return &models.Attachment{
+		ID:             bson.NewObjectID(),
+		SubscriptionID: subscriptionID,
+		UserID:         bson.NewObjectID(),
+		FileID:         bson.NewObjectID(),
+		Filename:       "receipt.pdf",
+		ContentType:    "application/pdf",
+		Size:           1024,
+		CreatedAt:      createdAt,
+	}
+}
+
+func TestAttachmentRepository(t *testing.T) {
+	repo := newAttachmentRepo(t)
+	subscriptionID := bson.NewObjectID()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	newer := attachmentFor(subscriptionID, now)
+	older := attachmentFor(subscriptionID, now.Add(-time.Hour))
+	other := attachmentFor(bson.NewObjectID(), now)
+	for _, a := range []*models.Attachment{newer, older, other} {
+		_, err := repo.Create(t.Context(), a)
+		require.NoError(t, err)
+	}
+
+	got, err := repo.GetBySubscriptionID(t.Context(), subscriptionID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, older.ID, got[0].ID, "oldest first")
+
+	count, err := repo.CountBySubscriptionID(t.Context(), subscriptionID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	stored, err := repo.GetByID(t.Context(), newer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, newer.FileID, stored.FileID)
+
+	require.NoError(t, repo.Delete(t.Context(), newer.ID))
+	_, err = repo.GetByID(t.Context(), newer.ID)
+	assertAppErrorCode(t, err, apperror.ErrNotFound)
+}
+
+func TestAttachmentRepository_DeleteByUserID(t *testing.T) {
+	repo := newAttachmentRepo(t)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	first := attachmentFor(bson.NewObjectID(), now)
+	second := attachmentFor(bson.NewObjectID(), now)
+	second.UserID = first.UserID
+	other := attachmentFor(bson.NewObjectID(), now)
+	for _, a := range []*models.Attachment{first, second, other} {
+		_, err := repo.Create(t.Context(), a)
+		require.NoError(t, err)
+	}
+
+	deleted, err := repo.DeleteByUserID(t.Context(), first.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	_, err = repo.GetByID(t.Context(), first.ID)
+	assertAppErrorCode(t, err, apperror.ErrNotFound)
+	_, err = repo.GetByID(t.Context(), other.ID)
+	require.NoError(t, err, "other users' attachments are kept")
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// AuditEventRepository stores the audit log.
+type AuditEventRepository interface {
+	Create(context.Context, *models.AuditEvent) error
+	// List returns a page of the events matching the query, newest first,
+	// and the total number of matching events.
+	List(context.Context, *models.AuditQuery) ([]*models.AuditEvent, int64, error)
+}
+
+type auditEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAuditEventRepository(ctx context.Context, db *mongo.Database) (AuditEventRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "occurred_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{
+				{Key: "resource_type", Value: 1},
+				{Key: "resource_id", Value: 1},
+				{Key: "occurred_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "actor_id", Value: 1},
+				{Key: "occurred_at", Value: -1},
+			},
+			Options: options.Index().SetSparse(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "action", Value: 1},
+				{Key: "occurred_at", Value: -1},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("audit_events")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Audit event repository initialized and index verified")
+
+	return &auditEventRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *auditEventRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	return lib.Create(ctx, r.collection, event)
+}
+
+// List breaks ties on the ID, so events recorded in the same instant keep
+// a stable order across pages.
+func (r *auditEventRepository) List(
+	ctx context.Context,
+	query *models.AuditQuery,
+) ([]*models.AuditEvent, int64, error) {
+	filter := bson.M{}
+	if query.Action != "" {
+		filter["action"] = query.Action
+	}
+	if query.ActorID != nil {
+		filter["actor_id"] = *query.ActorID
+	}
+	if query.ResourceType != "" {
+		filter["resource_type"] = query.ResourceType
+	}
+	if query.ResourceID != nil {
+		filter["resource_id"] = *query.ResourceID
+	}
+	occurred := bson.M{}
+	if !query.From.IsZero() {
+		occurred["$gte"] = query.From
+	}
+	if !query.To.IsZero() {
+		occurred["$lt"] = query.To
+	}
+	if len(occurred) > 0 {
+		filter["occurred_at"] = occurred
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "occurred_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+
+	events, err := lib.FindMany[models.AuditEvent](ctx, r.collection, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := lib.Count(ctx, r.collection, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
@@ -0,0 +1,108 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newAuditEventRepo(t *testing.T) repositories.AuditEventRepository {
+	t.Helper()
+
+	dbName := "audit_event_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repositories.NewAuditEventRepository(ctx, db)
+	require.NoError(t, err, "NewAuditEventRepository should not error")
+	return repo
+}
+
+func newAuditEvent(t *testing.T, action models.AuditAction, actorID *bson.ObjectID, resourceID bson.ObjectID, at time.Time) *models.AuditEvent {
+	t.Helper()
+
+	event, err := models.NewAuditEvent(action, models.ActorUser, actorID, resourceID, nil, map[string]string{"name": "Netflix"}, at)
+	require.NoError(t, err)
+	return event
+}
+
+func TestAuditEventRepository_List(t *testing.T) {
+	repo := newAuditEventRepo(t)
+	subscriptionID := bson.NewObjectID()
+	otherUserID := bson.NewObjectID()
+
+	created := newAuditEvent(t, models.AuditSubscriptionCreated, &defaultUserID, subscriptionID, mockOneMonthAgo)
+	canceled := newAuditEvent(t, models.AuditSubscriptionCanceled, &defaultUserID, subscriptionID, mockTime)
+	login := newAuditEvent(t, models.AuditUserLoggedIn, &defaultUserID, defaultUserID, mockTime)
+	other := newAuditEvent(t, models.AuditSubscriptionCreated, &otherUserID, bson.NewObjectID(), mockYesterday)
+	for _, event := range []*models.AuditEvent{created, canceled, login, other} {
+		require.NoError(t, repo.Create(t.Context(), event))
+	}
+
+	tests := []struct {
+		name      string
+		query     models.AuditQuery
+		wantIDs   []bson.ObjectID
+		wantTotal int64
+	}{
+		{
+			name:      "all events newest first",
+			query:     models.AuditQuery{PageQuery: models.PageQuery{Limit: 10}},
+			wantIDs:   []bson.ObjectID{login.ID, canceled.ID, other.ID, created.ID},
+			wantTotal: 4,
+		},
+		{
+			name:      "by resource",
+			query:     models.AuditQuery{ResourceType: "subscription", ResourceID: &subscriptionID, PageQuery: models.PageQuery{Limit: 10}},
+			wantIDs:   []bson.ObjectID{canceled.ID, created.ID},
+			wantTotal: 2,
+		},
+		{
+			name:      "by actor and action",
+			query:     models.AuditQuery{Action: models.AuditSubscriptionCreated, ActorID: &otherUserID, PageQuery: models.PageQuery{Limit: 10}},
+			wantIDs:   []bson.ObjectID{other.ID},
+			wantTotal: 1,
+		},
+		{
+			name:      "by time range",
+			query:     models.AuditQuery{From: mockYesterday, To: mockTime, PageQuery: models.PageQuery{Limit: 10}},
+			wantIDs:   []bson.ObjectID{other.ID},
+			wantTotal: 1,
+		},
+		{
+			name:      "second page",
+			query:     models.AuditQuery{PageQuery: models.PageQuery{Limit: 2, Offset: 2}},
+			wantIDs:   []bson.ObjectID{other.ID, created.ID},
+			wantTotal: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := repo.List(t.Context(), &tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, total)
+			ids := make([]bson.ObjectID, len(got))
+			for i, event := range got {
+				ids[i] = event.ID
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
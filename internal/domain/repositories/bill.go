@@ -16,8 +16,31 @@ import (
 type BillRepository interface {
 	Create(context.Context, *models.Bill) (*models.Bill, error)
 	GetByID(context.Context, bson.ObjectID) (*models.Bill, error)
+	// GetRecentBill returns the subscription's paid or pending bill with the
+	// latest start.
 	GetRecentBill(context.Context, bson.ObjectID) (*models.Bill, error)
+	// GetLatestBill returns the subscription's bill with the latest start,
+	// whatever its status.
+	GetLatestBill(context.Context, bson.ObjectID) (*models.Bill, error)
+	GetBySubscriptionID(context.Context, bson.ObjectID) ([]*models.Bill, error)
+	// ListBySubscriptionID returns a page of the subscription's bills, newest
+	// first, and the total number of its bills.
+	ListBySubscriptionID(context.Context, bson.ObjectID, *models.BillQuery) ([]*models.Bill, int64, error)
+	// Count returns the number of bills matching the filter.
+	Count(context.Context, *models.BillFilter) (int64, error)
+	// Each calls fn with every bill matching the filter, oldest first. It
+	// stops at the first error fn returns.
+	Each(ctx context.Context, filter *models.BillFilter, fn func(*models.Bill) error) error
+	// EachPending calls fn with every bill whose charge is still pending,
+	// oldest first. It stops at the first error fn returns.
+	EachPending(ctx context.Context, fn func(*models.Bill) error) error
 	Update(context.Context, *models.Bill) (*models.Bill, error)
+	DeleteBySubscriptionID(context.Context, bson.ObjectID) (int64, error)
+	// DeleteDangling deletes bills created before the time that a failed
+	// write left behind: bills whose subscription does not exist, and paid or
+	// pending bills ending after the validity of their subscription, whose
+	// renewal was never applied. It returns the number of bills deleted.
+	DeleteDangling(context.Context, time.Time) (int64, error)
 }
 
 type billRepository struct {
@@ -33,6 +56,14 @@ func NewBillRepository(ctx context.Context, db *mongo.Database) (BillRepository,
 				{Key: "start_date", Value: -1},
 			},
 		},
+		{
+			// Serves bill listings, which do not filter by status.
+			Keys: bson.D{
+				{Key: "subscription_id", Value: 1},
+				{Key: "start_date", Value: -1},
+				{Key: "_id", Value: -1},
+			},
+		},
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -64,12 +95,61 @@ func (r *billRepository) GetByID(ctx context.Context, id bson.ObjectID) (*models
 func (r *billRepository) GetRecentBill(ctx context.Context, subscriptionID bson.ObjectID) (*models.Bill, error) {
 	filter := bson.M{
 		"subscription_id": subscriptionID,
-		"status":          models.Paid,
+		"status":          bson.M{"$in": bson.A{models.Paid, models.Pending}},
 	}
 	opts := options.FindOne().SetSort(bson.M{"start_date": -1})
 	return lib.FindOne[models.Bill](ctx, r.collection, filter, opts)
 }
 
+func (r *billRepository) GetLatestBill(ctx context.Context, subscriptionID bson.ObjectID) (*models.Bill, error) {
+	filter := bson.M{"subscription_id": subscriptionID}
+	opts := options.FindOne().SetSort(bson.D{{Key: "start_date", Value: -1}, {Key: "_id", Value: -1}})
+	return lib.FindOne[models.Bill](ctx, r.collection, filter, opts)
+}
+
+func (r *billRepository) GetBySubscriptionID(ctx context.Context, subscriptionID bson.ObjectID) ([]*models.Bill, error) {
+	filter := bson.M{"subscription_id": subscriptionID}
+	opts := options.Find().SetSort(bson.D{{Key: "start_date", Value: 1}})
+	return lib.FindMany[models.Bill](ctx, r.collection, filter, opts)
+}
+
+func (r *billRepository) ListBySubscriptionID(
+	ctx context.Context,
+	subscriptionID bson.ObjectID,
+	query *models.BillQuery,
+) ([]*models.Bill, int64, error) {
+	filter := bson.M{"subscription_id": subscriptionID}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "start_date", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+
+	bills, err := lib.FindMany[models.Bill](ctx, r.collection, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := lib.Count(ctx, r.collection, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bills, total, nil
+}
+
+func (r *billRepository) Count(ctx context.Context, filter *models.BillFilter) (int64, error) {
+	return lib.Count(ctx, r.collection, filter.Filter())
+}
+
+func (r *billRepository) Each(ctx context.Context, filter *models.BillFilter, fn func(*models.Bill) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "start_date", Value: 1}, {Key: "_id", Value: 1}})
+	return lib.FindEach(ctx, r.collection, filter.Filter(), fn, opts)
+}
+
+func (r *billRepository) EachPending(ctx context.Context, fn func(*models.Bill) error) error {
+	filter := bson.M{"status": models.Pending}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	return lib.FindEach(ctx, r.collection, filter, fn, opts)
+}
+
 func (r *billRepository) Update(ctx context.Context, bill *models.Bill) (*models.Bill, error) {
 	// Update the bill in the collection
 	filter := bson.M{"_id": bill.ID}
@@ -79,3 +159,41 @@ func (r *billRepository) Update(ctx context.Context, bill *models.Bill) (*models
 
 	return bill, nil
 }
+
+func (r *billRepository) DeleteBySubscriptionID(ctx context.Context, subscriptionID bson.ObjectID) (int64, error) {
+	filter := bson.M{"subscription_id": subscriptionID}
+	return lib.DeleteMany(ctx, r.collection, filter)
+}
+
+func (r *billRepository) DeleteDangling(ctx context.Context, createdBefore time.Time) (int64, error) {
+	type billID struct {
+		ID bson.ObjectID `bson:"_id"`
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$lt": createdBefore}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "subscriptions",
+			"localField":   "subscription_id",
+			"foreignField": "_id",
+			"as":           "subscription",
+		}}},
+		{{Key: "$match", Value: bson.M{"$expr": bson.M{"$or": bson.A{
+			bson.M{"$eq": bson.A{bson.M{"$size": "$subscription"}, 0}},
+			bson.M{"$and": bson.A{
+				bson.M{"$in": bson.A{"$status", bson.A{models.Paid, models.Pending}}},
+				bson.M{"$gt": bson.A{"$end_date", bson.M{"$arrayElemAt": bson.A{"$subscription.valid_till", 0}}}},
+			}},
+		}}}}},
+		{{Key: "$project", Value: bson.M{"_id": 1}}},
+	}
+	dangling, err := lib.Aggregate[billID](ctx, r.collection, pipeline)
+	if err != nil || len(dangling) == 0 {
+		return 0, err
+	}
+
+	ids := make([]bson.ObjectID, len(dangling))
+	for i, bill := range dangling {
+		ids[i] = bill.ID
+	}
+	return lib.DeleteMany(ctx, r.collection, bson.M{"_id": bson.M{"$in": ids}})
+}
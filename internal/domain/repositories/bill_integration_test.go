@@ -169,6 +169,22 @@ func TestBillRepository_GetRecentBill(t *testing.T) {
 		assert.Equal(t, targetBill, got, "Failed to get the most recent paid bill. A decoy breached the filter/sort.")
 	})
 
+	t.Run("success - a pending bill counts as the most recent", func(t *testing.T) {
+		repo, collection := newBillRepo(t)
+
+		older := validBill()
+		older.StartDate = mockYesterday
+		pending := validBill()
+		pending.Status = models.Pending
+		_, err := collection.InsertMany(t.Context(), []*models.Bill{older, pending})
+		require.NoError(t, err)
+
+		got, err := repo.GetRecentBill(t.Context(), defaultSubID)
+
+		require.NoError(t, err)
+		assert.Equal(t, pending, got)
+	})
+
 	t.Run("error - no paid bills exist for sub returns not-found", func(t *testing.T) {
 		repo, collection := newBillRepo(t)
 
@@ -186,6 +202,43 @@ func TestBillRepository_GetRecentBill(t *testing.T) {
 	})
 }
 
+// ---------------------------------------------------------------------------
+// GetLatestBill
+// ---------------------------------------------------------------------------
+
+func TestBillRepository_GetLatestBill(t *testing.T) {
+	t.Run("success - retrieves the latest bill whatever its status", func(t *testing.T) {
+		repo, collection := newBillRepo(t)
+
+		decoyWrongSub := validBill()
+		decoyWrongSub.SubscriptionID = bson.NewObjectID()
+		decoyWrongSub.StartDate = mockTomorrow
+
+		olderPaid := validBill()
+		olderPaid.StartDate = mockYesterday
+
+		target := validBill()
+		target.Status = models.Failed
+
+		_, err := collection.InsertMany(t.Context(), []*models.Bill{decoyWrongSub, olderPaid, target})
+		require.NoError(t, err)
+
+		got, err := repo.GetLatestBill(t.Context(), defaultSubID)
+
+		require.NoError(t, err)
+		assert.Equal(t, target, got)
+	})
+
+	t.Run("error - no bills exist for sub returns not-found", func(t *testing.T) {
+		repo, _ := newBillRepo(t)
+
+		got, err := repo.GetLatestBill(t.Context(), defaultSubID)
+
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+		assert.Nil(t, got)
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Update
 // ---------------------------------------------------------------------------
@@ -237,3 +290,245 @@ func TestBillRepository_Update(t *testing.T) {
 		assert.Nil(t, got)
 	})
 }
+
+// ---------------------------------------------------------------------------
+// GetBySubscriptionID
+// ---------------------------------------------------------------------------
+
+func TestBillRepository_GetBySubscriptionID(t *testing.T) {
+	t.Run("returns bills for the subscription ordered by start date", func(t *testing.T) {
+		repo, collection := newBillRepo(t)
+
+		older := validBill()
+		older.StartDate = mockOneMonthAgo
+		older.EndDate = mockToday
+		newer := validBill()
+
+		decoy := validBill()
+		decoy.SubscriptionID = bson.NewObjectID()
+
+		_, err := collection.InsertMany(t.Context(), []*models.Bill{newer, decoy, older})
+		require.NoError(t, err)
+
+		got, err := repo.GetBySubscriptionID(t.Context(), defaultSubID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Bill{older, newer}, got)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// ListBySubscriptionID
+// ---------------------------------------------------------------------------
+
+func TestBillRepository_ListBySubscriptionID(t *testing.T) {
+	repo, collection := newBillRepo(t)
+
+	older := validBill()
+	older.StartDate = mockOneMonthAgo
+	older.EndDate = mockToday
+	newer := validBill()
+
+	decoy := validBill()
+	decoy.SubscriptionID = bson.NewObjectID()
+
+	_, err := collection.InsertMany(t.Context(), []*models.Bill{older, decoy, newer})
+	require.NoError(t, err)
+
+	t.Run("returns the newest bills first with the total", func(t *testing.T) {
+		got, total, err := repo.ListBySubscriptionID(t.Context(), defaultSubID, &models.BillQuery{PageQuery: models.PageQuery{Limit: 1}})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []*models.Bill{newer}, got)
+	})
+
+	t.Run("offset skips earlier pages", func(t *testing.T) {
+		got, total, err := repo.ListBySubscriptionID(t.Context(), defaultSubID, &models.BillQuery{PageQuery: models.PageQuery{Limit: 1, Offset: 1}})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []*models.Bill{older}, got)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Count and Each
+// ---------------------------------------------------------------------------
+
+func TestBillRepository_Each(t *testing.T) {
+	repo, collection := newBillRepo(t)
+
+	older := validBill()
+	older.StartDate = mockOneMonthAgo
+	older.EndDate = mockToday
+	newer := validBill()
+	failed := validBill()
+	failed.Status = models.Failed
+	otherSubID := bson.NewObjectID()
+	other := validBill()
+	other.SubscriptionID = otherSubID
+	decoy := validBill()
+	decoy.SubscriptionID = bson.NewObjectID()
+
+	_, err := collection.InsertMany(t.Context(), []*models.Bill{newer, decoy, failed, other, older})
+	require.NoError(t, err)
+
+	each := func(t *testing.T, filter *models.BillFilter) []*models.Bill {
+		t.Helper()
+		var got []*models.Bill
+		require.NoError(t, repo.Each(t.Context(), filter, func(bill *models.Bill) error {
+			got = append(got, bill)
+			return nil
+		}))
+		return got
+	}
+
+	t.Run("visits the bills of the subscriptions oldest first", func(t *testing.T) {
+		filter := &models.BillFilter{
+			SubscriptionIDs: []bson.ObjectID{defaultSubID, otherSubID},
+			Status:          models.Paid,
+		}
+
+		count, err := repo.Count(t.Context(), filter)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		got := each(t, filter)
+		require.Len(t, got, 3)
+		assert.Equal(t, older, got[0])
+		assert.ElementsMatch(t, []*models.Bill{newer, other}, got[1:])
+	})
+
+	t.Run("filters by start date", func(t *testing.T) {
+		got := each(t, &models.BillFilter{
+			SubscriptionIDs: []bson.ObjectID{defaultSubID},
+			From:            mockOneMonthAgo,
+			To:              mockToday,
+		})
+
+		assert.Equal(t, []*models.Bill{older}, got)
+	})
+
+	t.Run("matches nothing without subscriptions", func(t *testing.T) {
+		count, err := repo.Count(t.Context(), &models.BillFilter{})
+
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// EachPending
+// ---------------------------------------------------------------------------
+
+func TestBillRepository_EachPending(t *testing.T) {
+	repo, collection := newBillRepo(t)
+
+	older := validBill()
+	older.Status = models.Pending
+	older.CreatedAt = mockYesterday
+	newer := validBill()
+	newer.Status = models.Pending
+	newer.SubscriptionID = bson.NewObjectID()
+	paid := validBill()
+	failed := validBill()
+	failed.Status = models.Failed
+
+	_, err := collection.InsertMany(t.Context(), []*models.Bill{newer, paid, failed, older})
+	require.NoError(t, err)
+
+	var got []*models.Bill
+	require.NoError(t, repo.EachPending(t.Context(), func(bill *models.Bill) error {
+		got = append(got, bill)
+		return nil
+	}))
+
+	assert.Equal(t, []*models.Bill{older, newer}, got)
+}
+
+// ---------------------------------------------------------------------------
+// DeleteBySubscriptionID
+// ---------------------------------------------------------------------------
+
+func TestBillRepository_DeleteBySubscriptionID(t *testing.T) {
+	t.Run("deletes only bills of the subscription", func(t *testing.T) {
+		repo, collection := newBillRepo(t)
+
+		decoy := validBill()
+		decoy.SubscriptionID = bson.NewObjectID()
+
+		_, err := collection.InsertMany(t.Context(), []*models.Bill{validBill(), validBill(), decoy})
+		require.NoError(t, err)
+
+		deleted, err := repo.DeleteBySubscriptionID(t.Context(), defaultSubID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		remaining, err := collection.CountDocuments(t.Context(), bson.M{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), remaining)
+	})
+
+	t.Run("no matching bills is not an error", func(t *testing.T) {
+		repo, _ := newBillRepo(t)
+
+		deleted, err := repo.DeleteBySubscriptionID(t.Context(), defaultSubID)
+
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// DeleteDangling
+// ---------------------------------------------------------------------------
+
+func TestBillRepository_DeleteDangling(t *testing.T) {
+	repo, collection := newBillRepo(t)
+	_, err := collection.Database().Collection("subscriptions").InsertOne(t.Context(), &models.Subscription{
+		ID:        defaultSubID,
+		UserID:    defaultUserID,
+		Status:    models.Active,
+		ValidTill: mockOneMonthLater,
+	})
+	require.NoError(t, err)
+
+	applied := validBill()
+	// The next period was billed, but the subscription was not extended.
+	unapplied := validBill()
+	unapplied.StartDate = mockOneMonthLater
+	unapplied.EndDate = mockOneMonthLater.AddDate(0, 1, 0)
+	// The same for a renewal whose charge is still pending.
+	unappliedPending := validBill()
+	unappliedPending.StartDate = unapplied.StartDate
+	unappliedPending.EndDate = unapplied.EndDate
+	unappliedPending.Status = models.Pending
+	// A failed bill past the validity is dunning, not a failed write.
+	failed := validBill()
+	failed.StartDate = unapplied.StartDate
+	failed.EndDate = unapplied.EndDate
+	failed.Status = models.Failed
+	orphaned := validBill()
+	orphaned.SubscriptionID = bson.NewObjectID()
+	// Too recent: its write may still be in flight.
+	recent := validBill()
+	recent.SubscriptionID = orphaned.SubscriptionID
+	recent.CreatedAt = mockTime.Add(time.Minute)
+
+	_, err = collection.InsertMany(t.Context(), []*models.Bill{applied, unapplied, unappliedPending, failed, orphaned, recent})
+	require.NoError(t, err)
+
+	deleted, err := repo.DeleteDangling(t.Context(), mockTime.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	for _, bill := range []*models.Bill{applied, failed, recent} {
+		_, err := repo.GetByID(t.Context(), bill.ID)
+		assert.NoError(t, err, "bill %s should be kept", bill.ID.Hex())
+	}
+	for _, bill := range []*models.Bill{unapplied, unappliedPending, orphaned} {
+		_, err := repo.GetByID(t.Context(), bill.ID)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+	}
+}
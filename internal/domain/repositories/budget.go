@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// BudgetRepository stores the spending budgets of users, one document per
+// user.
+type BudgetRepository interface {
+	Upsert(context.Context, *models.Budget) error
+	GetByUserID(ctx context.Context, userID bson.ObjectID) (*models.Budget, error)
+	GetAll(context.Context) ([]*models.Budget, error)
+	Delete(ctx context.Context, userID bson.ObjectID) error
+}
+
+type budgetRepository struct {
+	collection *mongo.Collection
+}
+
+// NewBudgetRepository creates a BudgetRepository. Documents are keyed by user
+// ID, so no secondary index is needed.
+func NewBudgetRepository(db *mongo.Database) BudgetRepository {
+	return &budgetRepository{
+		collection: db.Collection("budgets"),
+	}
+}
+
+func (r *budgetRepository) Upsert(ctx context.Context, budget *models.Budget) error {
+	filter := bson.M{"_id": budget.UserID}
+	opts := options.Replace().SetUpsert(true)
+
+	if _, err := r.collection.ReplaceOne(ctx, filter, budget, opts); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return apperror.NewTimeoutError(err)
+		}
+		return apperror.NewDBError(err)
+	}
+	return nil
+}
+
+func (r *budgetRepository) GetByUserID(ctx context.Context, userID bson.ObjectID) (*models.Budget, error) {
+	filter := bson.M{"_id": userID}
+	return lib.FindOne[models.Budget](ctx, r.collection, filter)
+}
+
+func (r *budgetRepository) GetAll(ctx context.Context) ([]*models.Budget, error) {
+	return lib.FindMany[models.Budget](ctx, r.collection, bson.M{})
+}
+
+func (r *budgetRepository) Delete(ctx context.Context, userID bson.ObjectID) error {
+	filter := bson.M{"_id": userID}
+	return lib.Delete(ctx, r.collection, filter)
+}
@@ -0,0 +1,72 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newBudgetRepo(t *testing.T) repositories.BudgetRepository {
+	t.Helper()
+
+	dbName := "budget_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	return repositories.NewBudgetRepository(db)
+}
+
+func budgetFor(userID bson.ObjectID, overall int64) *models.Budget {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	return &models.Budget{
+		UserID:     userID,
+		Currency:   models.USD,
+		Overall:    overall,
+		Categories: map[models.Category]int64{models.Entertainment: 1500},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func TestBudgetRepository_UpsertAndGet(t *testing.T) {
+	repo := newBudgetRepo(t)
+	userID := bson.NewObjectID()
+
+	require.NoError(t, repo.Upsert(t.Context(), budgetFor(userID, 5000)))
+	require.NoError(t, repo.Upsert(t.Context(), budgetFor(userID, 6000)))
+
+	got, err := repo.GetByUserID(t.Context(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6000), got.Overall)
+	assert.Equal(t, int64(1500), got.Categories[models.Entertainment])
+
+	all, err := repo.GetAll(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func TestBudgetRepository_Delete(t *testing.T) {
+	repo := newBudgetRepo(t)
+	userID := bson.NewObjectID()
+	require.NoError(t, repo.Upsert(t.Context(), budgetFor(userID, 5000)))
+
+	require.NoError(t, repo.Delete(t.Context(), userID))
+
+	_, err := repo.GetByUserID(t.Context(), userID)
+	assertAppErrorCode(t, err, apperror.ErrNotFound)
+	assertAppErrorCode(t, repo.Delete(t.Context(), userID), apperror.ErrNotFound)
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// cachePrefix prefixes every key of the read-through caches, e.g.
+// "cache:subscription:<id>".
+const cachePrefix = "cache:"
+
+// readCache stores documents in Redis for the cached repositories. Cache
+// failures are logged and never fail the call: a failed read is a miss and a
+// failed write leaves the document to expire.
+//
+// Reads inside a transaction skip the cache in both directions, so a
+// read-modify-write never starts from a cached copy and uncommitted writes
+// are never cached.
+type readCache struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+// get decodes the document cached under key into v and reports whether there
+// was one.
+func (c readCache) get(ctx context.Context, key string, v any) bool {
+	if inTransaction(ctx) {
+		return false
+	}
+	data, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			slog.WarnContext(ctx, "Failed to read cached document",
+				logattr.Key(key),
+				logattr.Error(err))
+		}
+		return false
+	}
+	if err = bson.Unmarshal(data, v); err != nil {
+		slog.WarnContext(ctx, "Failed to decode cached document",
+			logattr.Key(key),
+			logattr.Error(err))
+		return false
+	}
+	return true
+}
+
+// set caches v under key for the cache's TTL.
+func (c readCache) set(ctx context.Context, key string, v any) {
+	if inTransaction(ctx) {
+		return
+	}
+	data, err := bson.Marshal(v)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to encode document for the cache",
+			logattr.Key(key),
+			logattr.Error(err))
+		return
+	}
+	if err = c.client.Set(ctx, key, data, c.ttl).Err(); err != nil {
+		slog.WarnContext(ctx, "Failed to cache document",
+			logattr.Key(key),
+			logattr.Error(err))
+	}
+}
+
+// del drops the documents cached under keys. Writes invalidate even inside a
+// transaction; a reader that caches the old document before the commit
+// leaves it stale for at most the TTL.
+func (c readCache) del(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		slog.WarnContext(ctx, "Failed to invalidate cached documents",
+			logattr.Keys(keys),
+			logattr.Error(err))
+	}
+}
+
+// inTransaction reports whether ctx carries a MongoDB session.
+func inTransaction(ctx context.Context) bool {
+	return mongo.SessionFromContext(ctx) != nil
+}
@@ -0,0 +1,143 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newTestRedis(t *testing.T) (redis.UniversalClient, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return rdb, mr
+}
+
+func TestCachedSubscriptionRepository(t *testing.T) {
+	setup := func(t *testing.T) (repositories.SubscriptionRepository, *miniredis.Miniredis, *models.Subscription) {
+		inner, collection := newSubRepo(t)
+		rdb, mr := newTestRedis(t)
+		sub := validSub()
+		_, err := collection.InsertOne(t.Context(), sub)
+		require.NoError(t, err)
+		return repositories.NewCachedSubscriptionRepository(inner, rdb, time.Minute), mr, sub
+	}
+
+	t.Run("serves repeated reads from the cache until they expire", func(t *testing.T) {
+		repo, mr, sub := setup(t)
+
+		first, err := repo.GetByID(t.Context(), sub.ID)
+		require.NoError(t, err)
+		cached, err := repo.GetByID(t.Context(), sub.ID)
+		require.NoError(t, err)
+		assert.Equal(t, first.Name, cached.Name)
+		assert.True(t, first.ValidTill.Equal(cached.ValidTill))
+		assert.Equal(t, time.Minute, mr.TTL("cache:subscription:"+sub.ID.Hex()))
+
+		mr.FastForward(time.Minute)
+		assert.False(t, mr.Exists("cache:subscription:"+sub.ID.Hex()))
+	})
+
+	t.Run("writes invalidate the subscription and its owner's listing", func(t *testing.T) {
+		repo, mr, sub := setup(t)
+		_, err := repo.GetByID(t.Context(), sub.ID)
+		require.NoError(t, err)
+		_, err = repo.GetByUserID(t.Context(), sub.UserID)
+		require.NoError(t, err)
+
+		got, err := repo.TransitionStatus(t.Context(), sub.ID, models.Active, models.Canceled, mockTomorrow)
+		require.NoError(t, err)
+		assert.Equal(t, models.Canceled, got.Status)
+
+		assert.False(t, mr.Exists("cache:subscription:"+sub.ID.Hex()))
+		assert.False(t, mr.Exists("cache:subscriptions:user:"+sub.UserID.Hex()))
+		stored, err := repo.GetByID(t.Context(), sub.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.Canceled, stored.Status)
+	})
+
+	t.Run("creating a subscription invalidates the owner's listing", func(t *testing.T) {
+		repo, _, sub := setup(t)
+		listed, err := repo.GetByUserID(t.Context(), sub.UserID)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+
+		_, err = repo.Create(t.Context(), validSub())
+		require.NoError(t, err)
+
+		listed, err = repo.GetByUserID(t.Context(), sub.UserID)
+		require.NoError(t, err)
+		assert.Len(t, listed, 2)
+	})
+
+	t.Run("unlinking a payment method invalidates the owner's subscriptions", func(t *testing.T) {
+		repo, _, sub := setup(t)
+		methodID := bson.NewObjectID()
+		_, err := repo.SetPaymentMethod(t.Context(), sub.ID, &methodID, mockTomorrow)
+		require.NoError(t, err)
+		_, err = repo.GetByID(t.Context(), sub.ID)
+		require.NoError(t, err)
+
+		_, err = repo.UnlinkPaymentMethod(t.Context(), sub.UserID, methodID, mockTomorrow)
+		require.NoError(t, err)
+
+		stored, err := repo.GetByID(t.Context(), sub.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.PaymentMethodID)
+	})
+
+	t.Run("deleting a subscription removes it from the cache", func(t *testing.T) {
+		repo, _, sub := setup(t)
+		_, err := repo.GetByID(t.Context(), sub.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(t.Context(), sub.ID))
+
+		_, err = repo.GetByID(t.Context(), sub.ID)
+		require.Error(t, err)
+	})
+
+	t.Run("falls back to the database when Redis is down", func(t *testing.T) {
+		repo, mr, sub := setup(t)
+		mr.Close()
+
+		got, err := repo.GetByID(t.Context(), sub.ID)
+		require.NoError(t, err)
+		assert.Equal(t, sub.ID, got.ID)
+	})
+}
+
+func TestCachedUserRepository(t *testing.T) {
+	inner, collection := newUserRepo(t)
+	rdb, mr := newTestRedis(t)
+	repo := repositories.NewCachedUserRepository(inner, rdb, time.Minute)
+	user := validUser()
+	_, err := collection.InsertOne(t.Context(), user)
+	require.NoError(t, err)
+
+	_, err = repo.FindByID(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.True(t, mr.Exists("cache:user:"+user.ID.Hex()))
+
+	user.Name = "Renamed"
+	_, err = repo.Update(t.Context(), user)
+	require.NoError(t, err)
+
+	got, err := repo.FindByID(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", got.Name)
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CatalogRepository stores the catalog of well-known services.
+type CatalogRepository interface {
+	// Sync replaces the catalog with entries: each entry is inserted or
+	// replaced by ID, and entries not among them are deleted.
+	Sync(context.Context, []*models.CatalogEntry) error
+	GetByID(context.Context, string) (*models.CatalogEntry, error)
+	// Search returns up to limit entries matching query, best matches first.
+	// Whole words are matched by the text index; when none match, names and
+	// aliases starting with query are returned instead, so typing "netf"
+	// finds Netflix. An empty query lists entries by name.
+	Search(ctx context.Context, query string, limit int64) ([]*models.CatalogEntry, error)
+}
+
+type catalogRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCatalogRepository(ctx context.Context, db *mongo.Database) (CatalogRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "name", Value: "text"},
+				{Key: "aliases", Value: "text"},
+			},
+			// Brand names are not words of a language, so they are not
+			// stemmed.
+			Options: options.Index().
+				SetWeights(bson.D{{Key: "name", Value: 10}, {Key: "aliases", Value: 5}}).
+				SetDefaultLanguage("none"),
+		},
+		{
+			Keys: bson.D{{Key: "name", Value: 1}},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("catalog")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Catalog repository initialized and index verified")
+
+	return &catalogRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *catalogRepository) Sync(ctx context.Context, entries []*models.CatalogEntry) error {
+	writes := make([]mongo.WriteModel, 0, len(entries)+1)
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ID
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": entry.ID}).
+			SetReplacement(entry).
+			SetUpsert(true))
+	}
+	writes = append(writes, mongo.NewDeleteManyModel().SetFilter(bson.M{"_id": bson.M{"$nin": ids}}))
+
+	if _, err := r.collection.BulkWrite(ctx, writes); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return apperror.NewTimeoutError(err)
+		}
+		return apperror.NewDBError(err)
+	}
+	return nil
+}
+
+func (r *catalogRepository) GetByID(ctx context.Context, id string) (*models.CatalogEntry, error) {
+	filter := bson.M{"_id": id}
+	return lib.FindOne[models.CatalogEntry](ctx, r.collection, filter)
+}
+
+func (r *catalogRepository) Search(ctx context.Context, query string, limit int64) ([]*models.CatalogEntry, error) {
+	byName := options.Find().SetSort(bson.D{{Key: "name", Value: 1}}).SetLimit(limit)
+	if query == "" {
+		return lib.FindMany[models.CatalogEntry](ctx, r.collection, bson.M{}, byName)
+	}
+
+	filter := bson.M{"$text": bson.M{"$search": query}}
+	byScore := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "name", Value: 1}}).
+		SetLimit(limit)
+	entries, err := lib.FindMany[models.CatalogEntry](ctx, r.collection, filter, byScore)
+	if err != nil || len(entries) > 0 {
+		return entries, err
+	}
+
+	prefix := bson.Regex{Pattern: "^" + regexp.QuoteMeta(query), Options: "i"}
+	filter = bson.M{"$or": bson.A{
+		bson.M{"name": prefix},
+		bson.M{"aliases": prefix},
+	}}
+	return lib.FindMany[models.CatalogEntry](ctx, r.collection, filter, byName)
+}
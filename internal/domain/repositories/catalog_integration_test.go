@@ -0,0 +1,106 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newCatalogRepo(t *testing.T) repositories.CatalogRepository {
+	t.Helper()
+
+	db := mongoClient.Database("catalog_test_" + bson.NewObjectID().Hex())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	repo, err := repositories.NewCatalogRepository(t.Context(), db)
+	require.NoError(t, err)
+	return repo
+}
+
+func catalogEntry(id, name string, aliases ...string) *models.CatalogEntry {
+	return &models.CatalogEntry{
+		ID:       id,
+		Name:     name,
+		Aliases:  aliases,
+		Category: models.Entertainment,
+		Plans: []models.CatalogPlan{
+			{Name: "Standard", Price: 999, Currency: models.USD, Frequency: models.Monthly},
+		},
+		UpdatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+}
+
+func catalogIDs(entries []*models.CatalogEntry) []string {
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ID
+	}
+	return ids
+}
+
+func TestCatalogRepository_Sync(t *testing.T) {
+	repo := newCatalogRepo(t)
+
+	require.NoError(t, repo.Sync(t.Context(), []*models.CatalogEntry{
+		catalogEntry("netflix", "Netflix"),
+		catalogEntry("hulu", "Hulu"),
+	}))
+	renamed := catalogEntry("netflix", "Netflix Premium")
+	require.NoError(t, repo.Sync(t.Context(), []*models.CatalogEntry{renamed}))
+
+	got, err := repo.GetByID(t.Context(), "netflix")
+	require.NoError(t, err)
+	assert.Equal(t, renamed, got)
+
+	_, err = repo.GetByID(t.Context(), "hulu")
+	appErr, ok := errors.AsType[apperror.AppError](err)
+	require.True(t, ok, "entries left out of a sync are deleted")
+	assert.Equal(t, apperror.ErrNotFound, appErr.Code())
+}
+
+func TestCatalogRepository_Search(t *testing.T) {
+	repo := newCatalogRepo(t)
+	require.NoError(t, repo.Sync(t.Context(), []*models.CatalogEntry{
+		catalogEntry("netflix", "Netflix"),
+		catalogEntry("max", "Max", "HBO Max", "HBO"),
+		catalogEntry("apple-tv-plus", "Apple TV+"),
+		catalogEntry("apple-music", "Apple Music"),
+	}))
+
+	tests := []struct {
+		name  string
+		query string
+		limit int64
+		want  []string
+	}{
+		{"empty query lists by name", "", 10, []string{"apple-music", "apple-tv-plus", "max", "netflix"}},
+		{"limit", "", 2, []string{"apple-music", "apple-tv-plus"}},
+		{"word match", "netflix", 10, []string{"netflix"}},
+		{"alias match", "hbo", 10, []string{"max"}},
+		{"best match first", "apple music", 10, []string{"apple-music", "apple-tv-plus"}},
+		{"prefix match", "netf", 10, []string{"netflix"}},
+		{"regex characters", "(", 10, []string{}},
+		{"no match", "zzz", 10, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.Search(t.Context(), tt.query, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, catalogIDs(got))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// DeadTaskRepository stores background tasks that failed for the last time.
+type DeadTaskRepository interface {
+	Create(context.Context, *models.DeadTask) error
+	GetByID(context.Context, bson.ObjectID) (*models.DeadTask, error)
+	// List returns a page of the tasks matching the query, most recent
+	// failure first, and the total number of matching tasks.
+	List(context.Context, *models.DeadTaskQuery) ([]*models.DeadTask, int64, error)
+	// MarkRequeued records that the task was put back on the queue as
+	// taskID and returns the updated task. It fails with a conflict if the
+	// task was requeued before.
+	MarkRequeued(ctx context.Context, id bson.ObjectID, taskID string, requeuedAt time.Time) (*models.DeadTask, error)
+}
+
+type deadTaskRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDeadTaskRepository(ctx context.Context, db *mongo.Database) (DeadTaskRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "failed_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{
+				{Key: "type", Value: 1},
+				{Key: "failed_at", Value: -1},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("dead_tasks")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Dead task repository initialized and index verified")
+
+	return &deadTaskRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *deadTaskRepository) Create(ctx context.Context, task *models.DeadTask) error {
+	return lib.Create(ctx, r.collection, task)
+}
+
+func (r *deadTaskRepository) GetByID(ctx context.Context, id bson.ObjectID) (*models.DeadTask, error) {
+	return lib.FindOne[models.DeadTask](ctx, r.collection, bson.M{"_id": id})
+}
+
+// List breaks ties on the ID, so tasks that failed in the same instant keep
+// a stable order across pages.
+func (r *deadTaskRepository) List(
+	ctx context.Context,
+	query *models.DeadTaskQuery,
+) ([]*models.DeadTask, int64, error) {
+	filter := bson.M{}
+	if query.Type != "" {
+		filter["type"] = query.Type
+	}
+	if query.Queue != "" {
+		filter["queue"] = query.Queue
+	}
+	if query.Requeued != nil {
+		filter["requeued_at"] = bson.M{"$exists": *query.Requeued}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "failed_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+
+	tasks, err := lib.FindMany[models.DeadTask](ctx, r.collection, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := lib.Count(ctx, r.collection, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tasks, total, nil
+}
+
+func (r *deadTaskRepository) MarkRequeued(
+	ctx context.Context,
+	id bson.ObjectID,
+	taskID string,
+	requeuedAt time.Time,
+) (*models.DeadTask, error) {
+	filter := bson.M{"_id": id, "requeued_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"requeued_at": requeuedAt, "requeued_task_id": taskID}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	task, err := lib.FindOneAndUpdate[models.DeadTask](ctx, r.collection, filter, update, opts)
+	if err == nil {
+		return task, nil
+	}
+	if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+		// Either the task does not exist or it was requeued concurrently.
+		if _, err = r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, apperror.NewConflictError("Dead task was already requeued")
+	}
+	return nil, err
+}
@@ -0,0 +1,149 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newDeadTaskRepo(t *testing.T) repositories.DeadTaskRepository {
+	t.Helper()
+
+	dbName := "dead_task_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repositories.NewDeadTaskRepository(ctx, db)
+	require.NoError(t, err, "NewDeadTaskRepository should not error")
+	return repo
+}
+
+func newDeadTask(taskType, queue string, failedAt time.Time) *models.DeadTask {
+	return &models.DeadTask{
+		ID:       bson.NewObjectID(),
+		TaskID:   bson.NewObjectID().Hex(),
+		Type:     taskType,
+		Queue:    queue,
+		Payload:  []byte(`{"subscription_id":"abc"}`),
+		Error:    "smtp: connection refused",
+		Retried:  3,
+		MaxRetry: 3,
+		FailedAt: failedAt,
+	}
+}
+
+func TestDeadTaskRepository_List(t *testing.T) {
+	repo := newDeadTaskRepo(t)
+
+	old := newDeadTask("reminder:email", "default", mockOneMonthAgo)
+	recent := newDeadTask("reminder:email", "critical", mockTime)
+	export := newDeadTask("export:generate", "default", mockYesterday)
+	for _, task := range []*models.DeadTask{old, recent, export} {
+		require.NoError(t, repo.Create(t.Context(), task))
+	}
+	_, err := repo.MarkRequeued(t.Context(), old.ID, "requeued", mockTime)
+	require.NoError(t, err)
+
+	requeued, pending := true, false
+	tests := []struct {
+		name      string
+		query     models.DeadTaskQuery
+		wantIDs   []bson.ObjectID
+		wantTotal int64
+	}{
+		{
+			name:      "all tasks newest first",
+			query:     models.DeadTaskQuery{PageQuery: models.PageQuery{Limit: 10}},
+			wantIDs:   []bson.ObjectID{recent.ID, export.ID, old.ID},
+			wantTotal: 3,
+		},
+		{
+			name:      "by type",
+			query:     models.DeadTaskQuery{Type: "reminder:email", PageQuery: models.PageQuery{Limit: 10}},
+			wantIDs:   []bson.ObjectID{recent.ID, old.ID},
+			wantTotal: 2,
+		},
+		{
+			name:      "by queue",
+			query:     models.DeadTaskQuery{Queue: "default", PageQuery: models.PageQuery{Limit: 10}},
+			wantIDs:   []bson.ObjectID{export.ID, old.ID},
+			wantTotal: 2,
+		},
+		{
+			name:      "requeued only",
+			query:     models.DeadTaskQuery{Requeued: &requeued, PageQuery: models.PageQuery{Limit: 10}},
+			wantIDs:   []bson.ObjectID{old.ID},
+			wantTotal: 1,
+		},
+		{
+			name:      "pending only",
+			query:     models.DeadTaskQuery{Requeued: &pending, PageQuery: models.PageQuery{Limit: 10}},
+			wantIDs:   []bson.ObjectID{recent.ID, export.ID},
+			wantTotal: 2,
+		},
+		{
+			name:      "second page",
+			query:     models.DeadTaskQuery{PageQuery: models.PageQuery{Limit: 2, Offset: 2}},
+			wantIDs:   []bson.ObjectID{old.ID},
+			wantTotal: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := repo.List(t.Context(), &tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, total)
+			ids := make([]bson.ObjectID, len(got))
+			for i, task := range got {
+				ids[i] = task.ID
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestDeadTaskRepository_MarkRequeued(t *testing.T) {
+	repo := newDeadTaskRepo(t)
+	task := newDeadTask("reminder:email", "default", mockYesterday)
+	require.NoError(t, repo.Create(t.Context(), task))
+
+	t.Run("marks the task", func(t *testing.T) {
+		got, err := repo.MarkRequeued(t.Context(), task.ID, "new-task", mockTime)
+		require.NoError(t, err)
+		require.NotNil(t, got.RequeuedAt)
+		assert.True(t, mockTime.Equal(*got.RequeuedAt))
+		assert.Equal(t, "new-task", got.RequeuedTaskID)
+
+		stored, err := repo.GetByID(t.Context(), task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "new-task", stored.RequeuedTaskID)
+	})
+
+	t.Run("already requeued", func(t *testing.T) {
+		_, err := repo.MarkRequeued(t.Context(), task.ID, "other-task", mockTime)
+		assertAppErrorCode(t, err, apperror.ErrConflict)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := repo.MarkRequeued(t.Context(), bson.NewObjectID(), "other-task", mockTime)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+	})
+}
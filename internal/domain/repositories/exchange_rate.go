@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ExchangeRateRepository stores the history of daily exchange rates, one
+// document per publication day.
+type ExchangeRateRepository interface {
+	Upsert(context.Context, *models.ExchangeRates) error
+	// GetLatestOnOrBefore returns the most recent rates published on or before
+	// date, since no rates are published on weekends and holidays.
+	GetLatestOnOrBefore(ctx context.Context, date time.Time) (*models.ExchangeRates, error)
+}
+
+type exchangeRateRepository struct {
+	collection *mongo.Collection
+}
+
+// NewExchangeRateRepository creates an ExchangeRateRepository. Documents are
+// keyed by date, so no secondary index is needed.
+func NewExchangeRateRepository(db *mongo.Database) ExchangeRateRepository {
+	return &exchangeRateRepository{
+		collection: db.Collection("exchange_rates"),
+	}
+}
+
+func (r *exchangeRateRepository) Upsert(ctx context.Context, rates *models.ExchangeRates) error {
+	filter := bson.M{"_id": rates.Date}
+	opts := options.Replace().SetUpsert(true)
+
+	if _, err := r.collection.ReplaceOne(ctx, filter, rates, opts); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return apperror.NewTimeoutError(err)
+		}
+		return apperror.NewDBError(err)
+	}
+	return nil
+}
+
+func (r *exchangeRateRepository) GetLatestOnOrBefore(ctx context.Context, date time.Time) (*models.ExchangeRates, error) {
+	filter := bson.M{"_id": bson.M{"$lte": date}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}})
+
+	return lib.FindOne[models.ExchangeRates](ctx, r.collection, filter, opts)
+}
@@ -0,0 +1,79 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newExchangeRateRepo(t *testing.T) repositories.ExchangeRateRepository {
+	t.Helper()
+
+	dbName := "exchange_rate_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	return repositories.NewExchangeRateRepository(db)
+}
+
+func ratesOn(date time.Time, usd float64) *models.ExchangeRates {
+	return &models.ExchangeRates{
+		Date:      date,
+		Base:      models.EUR,
+		Rates:     map[models.Currency]float64{models.USD: usd},
+		Provider:  "test",
+		FetchedAt: date.Add(16 * time.Hour),
+	}
+}
+
+func TestExchangeRateRepository_GetLatestOnOrBefore(t *testing.T) {
+	repo := newExchangeRateRepo(t)
+	friday := time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)
+	monday := friday.AddDate(0, 0, 3)
+
+	require.NoError(t, repo.Upsert(t.Context(), ratesOn(friday, 1.03)))
+	require.NoError(t, repo.Upsert(t.Context(), ratesOn(monday, 1.04)))
+
+	t.Run("returns the rates of the day", func(t *testing.T) {
+		got, err := repo.GetLatestOnOrBefore(t.Context(), monday)
+		require.NoError(t, err)
+		assert.Equal(t, 1.04, got.Rates[models.USD])
+	})
+
+	t.Run("falls back to the last publication", func(t *testing.T) {
+		got, err := repo.GetLatestOnOrBefore(t.Context(), friday.AddDate(0, 0, 2))
+		require.NoError(t, err)
+		assert.True(t, got.Date.Equal(friday))
+	})
+
+	t.Run("not found before the first publication", func(t *testing.T) {
+		_, err := repo.GetLatestOnOrBefore(t.Context(), friday.AddDate(0, 0, -1))
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+	})
+}
+
+func TestExchangeRateRepository_Upsert_Replaces(t *testing.T) {
+	repo := newExchangeRateRepo(t)
+	day := time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.Upsert(t.Context(), ratesOn(day, 1.03)))
+	require.NoError(t, repo.Upsert(t.Context(), ratesOn(day, 1.05)))
+
+	got, err := repo.GetLatestOnOrBefore(t.Context(), day)
+	require.NoError(t, err)
+	assert.Equal(t, 1.05, got.Rates[models.USD])
+}
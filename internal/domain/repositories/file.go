@@ -0,0 +1,278 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"github.com/anuragthepathak/subscription-management/internal/storage"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// FileRepository is the blob storage abstraction for generated and uploaded
+// files. The default implementation keeps content in GridFS next to the rest
+// of the data; NewBlobFileRepository keeps it on local disk or in S3.
+type FileRepository interface {
+	Upload(ctx context.Context, filename string, metadata models.FileMetadata, content io.Reader) (*models.StoredFile, error)
+	GetByID(context.Context, bson.ObjectID) (*models.StoredFile, error)
+	GetByKey(context.Context, string) (*models.StoredFile, error)
+	Open(context.Context, bson.ObjectID) (io.ReadCloser, error)
+	Delete(context.Context, bson.ObjectID) error
+	// DeleteByOwnerID deletes every file of the owner and returns how many
+	// were deleted.
+	DeleteByOwnerID(ctx context.Context, ownerID bson.ObjectID) (int64, error)
+}
+
+type gridFSFileRepository struct {
+	bucket *mongo.GridFSBucket
+	files  *mongo.Collection
+}
+
+func NewFileRepository(ctx context.Context, db *mongo.Database) (FileRepository, error) {
+	bucket := db.GridFSBucket(options.GridFSBucket().SetName("files"))
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "metadata.key", Value: 1},
+			{Key: "uploadDate", Value: -1},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	files := bucket.GetFilesCollection()
+	if _, err := files.Indexes().CreateOne(ctx, indexModel); err != nil {
+		return nil, fmt.Errorf("failed to create index for metadata.key field: %w", err)
+	}
+	slog.Debug("File repository initialized and index verified")
+
+	return &gridFSFileRepository{
+		bucket: bucket,
+		files:  files,
+	}, nil
+}
+
+func (r *gridFSFileRepository) Upload(
+	ctx context.Context,
+	filename string,
+	metadata models.FileMetadata,
+	content io.Reader,
+) (*models.StoredFile, error) {
+	opts := options.GridFSUpload().SetMetadata(metadata)
+	id, err := r.bucket.UploadFromStream(ctx, filename, content, opts)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, apperror.NewTimeoutError(err)
+		}
+		return nil, apperror.NewDBError(err)
+	}
+	return r.GetByID(ctx, id)
+}
+
+func (r *gridFSFileRepository) GetByID(ctx context.Context, id bson.ObjectID) (*models.StoredFile, error) {
+	filter := bson.M{"_id": id}
+	return lib.FindOne[models.StoredFile](ctx, r.files, filter)
+}
+
+// GetByKey returns the most recently uploaded file stored under key.
+func (r *gridFSFileRepository) GetByKey(ctx context.Context, key string) (*models.StoredFile, error) {
+	filter := bson.M{"metadata.key": key}
+	opts := options.FindOne().SetSort(bson.D{{Key: "uploadDate", Value: -1}})
+	return lib.FindOne[models.StoredFile](ctx, r.files, filter, opts)
+}
+
+func (r *gridFSFileRepository) Open(ctx context.Context, id bson.ObjectID) (io.ReadCloser, error) {
+	stream, err := r.bucket.OpenDownloadStream(ctx, id)
+	if err != nil {
+		return nil, translateGridFSError(err)
+	}
+	return stream, nil
+}
+
+func (r *gridFSFileRepository) Delete(ctx context.Context, id bson.ObjectID) error {
+	if err := r.bucket.Delete(ctx, id); err != nil {
+		return translateGridFSError(err)
+	}
+	return nil
+}
+
+// DeleteByOwnerID deletes the files one by one, since GridFS deletes a file's
+// chunks along with its document. Files deleted concurrently are not
+// counted.
+func (r *gridFSFileRepository) DeleteByOwnerID(ctx context.Context, ownerID bson.ObjectID) (int64, error) {
+	files, err := lib.FindMany[models.StoredFile](ctx, r.files, bson.M{"metadata.owner_id": ownerID})
+	if err != nil {
+		return 0, err
+	}
+	var deleted int64
+	for _, file := range files {
+		if err = r.bucket.Delete(ctx, file.ID); err != nil {
+			if errors.Is(err, mongo.ErrFileNotFound) {
+				continue
+			}
+			return deleted, translateGridFSError(err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+func translateGridFSError(err error) error {
+	if errors.Is(err, mongo.ErrFileNotFound) {
+		return apperror.NewNotFoundError("File not found")
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return apperror.NewTimeoutError(err)
+	}
+	return apperror.NewDBError(err)
+}
+
+// blobFileRepository keeps file documents shaped like GridFS files documents
+// in MongoDB and their content in a storage.Store, named by file ID.
+type blobFileRepository struct {
+	store storage.Store
+	files *mongo.Collection
+}
+
+// NewBlobFileRepository creates a FileRepository that stores content in
+// store. Its documents live in the stored_files collection, apart from the
+// GridFS bucket, so switching drivers leaves the other driver's files alone.
+func NewBlobFileRepository(ctx context.Context, db *mongo.Database, store storage.Store) (FileRepository, error) {
+	files := db.Collection("stored_files")
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "metadata.key", Value: 1},
+			{Key: "uploadDate", Value: -1},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := files.Indexes().CreateOne(ctx, indexModel); err != nil {
+		return nil, fmt.Errorf("failed to create index for metadata.key field: %w", err)
+	}
+	slog.Debug("File repository initialized and index verified",
+		logattr.StorageDriver(store.Name()),
+	)
+
+	return &blobFileRepository{
+		store: store,
+		files: files,
+	}, nil
+}
+
+// Upload writes the content before the document, so a file that can be
+// found can always be opened. Content left behind by a failed insert is
+// deleted again.
+func (r *blobFileRepository) Upload(
+	ctx context.Context,
+	filename string,
+	metadata models.FileMetadata,
+	content io.Reader,
+) (*models.StoredFile, error) {
+	file := &models.StoredFile{
+		ID:       bson.NewObjectID(),
+		Filename: filename,
+		Metadata: metadata,
+	}
+	size, err := r.store.Put(ctx, file.ID.Hex(), content)
+	if err != nil {
+		return nil, translateStorageError(err)
+	}
+	file.Size = size
+	// GridFS keeps millisecond precision; match it so both drivers agree.
+	file.UploadedAt = time.Now().UTC().Truncate(time.Millisecond)
+
+	if err = lib.Create(ctx, r.files, file); err != nil {
+		if deleteErr := r.store.Delete(context.WithoutCancel(ctx), file.ID.Hex()); deleteErr != nil {
+			slog.WarnContext(ctx, "Failed to delete content of unsaved file",
+				logattr.FileID(file.ID.Hex()),
+				logattr.Error(deleteErr),
+			)
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+func (r *blobFileRepository) GetByID(ctx context.Context, id bson.ObjectID) (*models.StoredFile, error) {
+	filter := bson.M{"_id": id}
+	return lib.FindOne[models.StoredFile](ctx, r.files, filter)
+}
+
+// GetByKey returns the most recently uploaded file stored under key.
+func (r *blobFileRepository) GetByKey(ctx context.Context, key string) (*models.StoredFile, error) {
+	filter := bson.M{"metadata.key": key}
+	opts := options.FindOne().SetSort(bson.D{{Key: "uploadDate", Value: -1}})
+	return lib.FindOne[models.StoredFile](ctx, r.files, filter, opts)
+}
+
+func (r *blobFileRepository) Open(ctx context.Context, id bson.ObjectID) (io.ReadCloser, error) {
+	content, err := r.store.Open(ctx, id.Hex())
+	if err != nil {
+		return nil, translateStorageError(err)
+	}
+	return content, nil
+}
+
+// Delete removes the document first, so the file disappears even if its
+// content can't be deleted; leftover content only costs storage.
+func (r *blobFileRepository) Delete(ctx context.Context, id bson.ObjectID) error {
+	if err := lib.Delete(ctx, r.files, bson.M{"_id": id}); err != nil {
+		return err
+	}
+	if err := r.store.Delete(ctx, id.Hex()); err != nil {
+		slog.WarnContext(ctx, "Failed to delete file content",
+			logattr.FileID(id.Hex()),
+			logattr.Error(err),
+		)
+	}
+	return nil
+}
+
+// DeleteByOwnerID deletes the documents first, like Delete, then the content
+// of each file.
+func (r *blobFileRepository) DeleteByOwnerID(ctx context.Context, ownerID bson.ObjectID) (int64, error) {
+	filter := bson.M{"metadata.owner_id": ownerID}
+	files, err := lib.FindMany[models.StoredFile](ctx, r.files, filter)
+	if err != nil {
+		return 0, err
+	}
+	ids := make([]bson.ObjectID, len(files))
+	for i, file := range files {
+		ids[i] = file.ID
+	}
+	deleted, err := lib.DeleteMany(ctx, r.files, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		if err = r.store.Delete(ctx, id.Hex()); err != nil {
+			slog.WarnContext(ctx, "Failed to delete file content",
+				logattr.FileID(id.Hex()),
+				logattr.Error(err),
+			)
+		}
+	}
+	return deleted, nil
+}
+
+func translateStorageError(err error) error {
+	if errors.Is(err, storage.ErrNotFound) {
+		return apperror.NewNotFoundError("File not found")
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return apperror.NewTimeoutError(err)
+	}
+	return apperror.NewInternalError(err)
+}
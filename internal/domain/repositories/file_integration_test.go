@@ -0,0 +1,256 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/anuragthepathak/subscription-management/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func validFileMetadata() models.FileMetadata {
+	return models.FileMetadata{
+		Key:         "invoice/" + bson.NewObjectID().Hex(),
+		OwnerID:     bson.NewObjectID(),
+		ContentType: "application/pdf",
+		Attributes:  map[string]string{"bill_id": "123"},
+	}
+}
+
+func newFileRepo(t *testing.T) repositories.FileRepository {
+	t.Helper()
+
+	dbName := "file_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repositories.NewFileRepository(ctx, db)
+	require.NoError(t, err, "NewFileRepository should not error")
+
+	return repo
+}
+
+// ---------------------------------------------------------------------------
+// Upload / Open
+// ---------------------------------------------------------------------------
+
+func TestFileRepository_UploadAndOpen(t *testing.T) {
+	t.Run("success - stores content with metadata", func(t *testing.T) {
+		repo := newFileRepo(t)
+		metadata := validFileMetadata()
+
+		file, err := repo.Upload(t.Context(), "invoice.pdf", metadata, strings.NewReader("%PDF-"))
+		require.NoError(t, err)
+		assert.Equal(t, "invoice.pdf", file.Filename)
+		assert.Equal(t, int64(5), file.Size)
+		assert.Equal(t, metadata, file.Metadata)
+
+		content, err := repo.Open(t.Context(), file.ID)
+		require.NoError(t, err)
+		defer content.Close()
+		body, err := io.ReadAll(content)
+		require.NoError(t, err)
+		assert.Equal(t, "%PDF-", string(body))
+	})
+
+	t.Run("not found - opening unknown id", func(t *testing.T) {
+		repo := newFileRepo(t)
+
+		content, err := repo.Open(t.Context(), bson.NewObjectID())
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+		assert.Nil(t, content)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GetByKey
+// ---------------------------------------------------------------------------
+
+func TestFileRepository_GetByKey(t *testing.T) {
+	t.Run("returns the latest version for a key", func(t *testing.T) {
+		repo := newFileRepo(t)
+		metadata := validFileMetadata()
+
+		_, err := repo.Upload(t.Context(), "v1.pdf", metadata, strings.NewReader("one"))
+		require.NoError(t, err)
+		// uploadDate has millisecond precision; keep the versions apart.
+		time.Sleep(5 * time.Millisecond)
+		latest, err := repo.Upload(t.Context(), "v2.pdf", metadata, strings.NewReader("two"))
+		require.NoError(t, err)
+
+		got, err := repo.GetByKey(t.Context(), metadata.Key)
+		require.NoError(t, err)
+		assert.Equal(t, latest.ID, got.ID)
+	})
+
+	t.Run("not found - unknown key", func(t *testing.T) {
+		repo := newFileRepo(t)
+
+		got, err := repo.GetByKey(t.Context(), "invoice/missing")
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+		assert.Nil(t, got)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Delete
+// ---------------------------------------------------------------------------
+
+func TestFileRepository_Delete(t *testing.T) {
+	t.Run("success - removes file and content", func(t *testing.T) {
+		repo := newFileRepo(t)
+		file, err := repo.Upload(t.Context(), "invoice.pdf", validFileMetadata(), strings.NewReader("%PDF-"))
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(t.Context(), file.ID))
+
+		_, err = repo.GetByID(t.Context(), file.ID)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("not found - unknown id", func(t *testing.T) {
+		repo := newFileRepo(t)
+
+		err := repo.Delete(t.Context(), bson.NewObjectID())
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// DeleteByOwnerID
+// ---------------------------------------------------------------------------
+
+// testDeleteByOwnerID checks that only the owner's files are deleted, with
+// their content.
+func testDeleteByOwnerID(t *testing.T, repo repositories.FileRepository) {
+	t.Helper()
+
+	owned := validFileMetadata()
+	var ownedIDs []bson.ObjectID
+	for range 2 {
+		file, err := repo.Upload(t.Context(), "invoice.pdf", owned, strings.NewReader("%PDF-"))
+		require.NoError(t, err)
+		ownedIDs = append(ownedIDs, file.ID)
+	}
+	other, err := repo.Upload(t.Context(), "invoice.pdf", validFileMetadata(), strings.NewReader("%PDF-"))
+	require.NoError(t, err)
+
+	deleted, err := repo.DeleteByOwnerID(t.Context(), owned.OwnerID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	for _, id := range ownedIDs {
+		_, err = repo.GetByID(t.Context(), id)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+		_, err = repo.Open(t.Context(), id)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+	}
+	_, err = repo.GetByID(t.Context(), other.ID)
+	require.NoError(t, err, "other owners' files are kept")
+}
+
+func TestFileRepository_DeleteByOwnerID(t *testing.T) {
+	t.Run("gridfs", func(t *testing.T) {
+		testDeleteByOwnerID(t, newFileRepo(t))
+	})
+
+	t.Run("blob", func(t *testing.T) {
+		testDeleteByOwnerID(t, newBlobFileRepo(t))
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Blob storage
+// ---------------------------------------------------------------------------
+
+func newBlobFileRepo(t *testing.T) repositories.FileRepository {
+	t.Helper()
+
+	dbName := "file_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	var config storage.Config
+	config.Driver = storage.DriverLocal
+	config.Local.Dir = t.TempDir()
+	store, err := storage.New(t.Context(), config)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repositories.NewBlobFileRepository(ctx, db, store)
+	require.NoError(t, err, "NewBlobFileRepository should not error")
+
+	return repo
+}
+
+func TestBlobFileRepository(t *testing.T) {
+	t.Run("success - stores, finds, and deletes a file", func(t *testing.T) {
+		repo := newBlobFileRepo(t)
+		metadata := validFileMetadata()
+
+		file, err := repo.Upload(t.Context(), "receipt.pdf", metadata, strings.NewReader("%PDF-"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), file.Size)
+		assert.Equal(t, metadata, file.Metadata)
+
+		got, err := repo.GetByKey(t.Context(), metadata.Key)
+		require.NoError(t, err)
+		assert.Equal(t, file, got)
+
+		content, err := repo.Open(t.Context(), file.ID)
+		require.NoError(t, err)
+		body, err := io.ReadAll(content)
+		require.NoError(t, err)
+		require.NoError(t, content.Close())
+		assert.Equal(t, "%PDF-", string(body))
+
+		require.NoError(t, repo.Delete(t.Context(), file.ID))
+		_, err = repo.GetByID(t.Context(), file.ID)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+		_, err = repo.Open(t.Context(), file.ID)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("not found - unknown id", func(t *testing.T) {
+		repo := newBlobFileRepo(t)
+
+		err := repo.Delete(t.Context(), bson.NewObjectID())
+
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+	})
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// LifecycleEventRepository stores the timelines of subscriptions.
+type LifecycleEventRepository interface {
+	Create(context.Context, *models.LifecycleEvent) error
+	// GetBySubscriptionID returns the events of a subscription, oldest first.
+	GetBySubscriptionID(context.Context, bson.ObjectID) ([]*models.LifecycleEvent, error)
+	// CountByTypeSince returns the number of events of the given type that
+	// occurred at or after since.
+	CountByTypeSince(ctx context.Context, eventType models.LifecycleEventType, since time.Time) (int64, error)
+	// DeleteByUserID deletes the events of the given type from the
+	// timelines of the user's subscriptions and returns how many were
+	// deleted.
+	DeleteByUserID(ctx context.Context, userID bson.ObjectID, eventType models.LifecycleEventType) (int64, error)
+}
+
+type lifecycleEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLifecycleEventRepository(ctx context.Context, db *mongo.Database) (LifecycleEventRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "subscription_id", Value: 1},
+				{Key: "occurred_at", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "type", Value: 1},
+				{Key: "occurred_at", Value: 1},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("subscription_events")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Lifecycle event repository initialized and index verified")
+
+	return &lifecycleEventRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *lifecycleEventRepository) Create(ctx context.Context, event *models.LifecycleEvent) error {
+	return lib.Create(ctx, r.collection, event)
+}
+
+// GetBySubscriptionID breaks ties on the ID, so events recorded in the same
+// instant keep their insertion order.
+func (r *lifecycleEventRepository) GetBySubscriptionID(
+	ctx context.Context,
+	subscriptionID bson.ObjectID,
+) ([]*models.LifecycleEvent, error) {
+	filter := bson.M{"subscription_id": subscriptionID}
+	opts := options.Find().SetSort(bson.D{
+		{Key: "occurred_at", Value: 1},
+		{Key: "_id", Value: 1},
+	})
+
+	return lib.FindMany[models.LifecycleEvent](ctx, r.collection, filter, opts)
+}
+
+func (r *lifecycleEventRepository) CountByTypeSince(
+	ctx context.Context,
+	eventType models.LifecycleEventType,
+	since time.Time,
+) (int64, error) {
+	filter := bson.M{
+		"type":        eventType,
+		"occurred_at": bson.M{"$gte": since},
+	}
+	return lib.Count(ctx, r.collection, filter)
+}
+
+func (r *lifecycleEventRepository) DeleteByUserID(
+	ctx context.Context,
+	userID bson.ObjectID,
+	eventType models.LifecycleEventType,
+) (int64, error) {
+	filter := bson.M{
+		"user_id": userID,
+		"type":    eventType,
+	}
+	return lib.DeleteMany(ctx, r.collection, filter)
+}
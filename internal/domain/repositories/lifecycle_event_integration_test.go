@@ -0,0 +1,110 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newLifecycleEventRepo(t *testing.T) repositories.LifecycleEventRepository {
+	t.Helper()
+
+	dbName := "lifecycle_event_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repositories.NewLifecycleEventRepository(ctx, db)
+	require.NoError(t, err, "NewLifecycleEventRepository should not error")
+	return repo
+}
+
+func TestLifecycleEventRepository_GetBySubscriptionID(t *testing.T) {
+	repo := newLifecycleEventRepo(t)
+	subscription := &models.Subscription{
+		ID:        bson.NewObjectID(),
+		UserID:    defaultUserID,
+		Status:    models.Active,
+		ValidTill: mockTime,
+	}
+	other := &models.Subscription{ID: bson.NewObjectID(), UserID: defaultUserID}
+
+	renewed := models.NewLifecycleEvent(subscription, models.LifecycleRenewed, models.ActorSystem, "Subscription renewed", mockTime)
+	created := models.NewLifecycleEvent(subscription, models.LifecycleCreated, models.ActorUser, "Subscription created", mockOneMonthAgo)
+	// Recorded in the same instant as renewed, but after it.
+	reminded := models.NewLifecycleEvent(subscription, models.LifecycleReminderSent, models.ActorSystem, "Renewal reminder sent", mockTime)
+	decoy := models.NewLifecycleEvent(other, models.LifecycleCreated, models.ActorUser, "Subscription created", mockTime)
+	for _, event := range []*models.LifecycleEvent{renewed, created, reminded, decoy} {
+		require.NoError(t, repo.Create(t.Context(), event))
+	}
+
+	got, err := repo.GetBySubscriptionID(t.Context(), subscription.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, created.ID, got[0].ID)
+	assert.Equal(t, renewed.ID, got[1].ID)
+	assert.Equal(t, reminded.ID, got[2].ID)
+	assert.Equal(t, models.ActorUser, got[0].Actor)
+	assert.Equal(t, models.Active, got[1].Status)
+}
+
+func TestLifecycleEventRepository_CountByTypeSince(t *testing.T) {
+	repo := newLifecycleEventRepo(t)
+	subscription := &models.Subscription{ID: bson.NewObjectID(), UserID: defaultUserID}
+
+	for _, event := range []*models.LifecycleEvent{
+		models.NewLifecycleEvent(subscription, models.LifecycleRenewed, models.ActorSystem, "", mockToday),
+		models.NewLifecycleEvent(subscription, models.LifecycleRenewed, models.ActorSystem, "", mockTime),
+		// Decoys: renewed yesterday, and another type today
+		models.NewLifecycleEvent(subscription, models.LifecycleRenewed, models.ActorSystem, "", mockYesterday),
+		models.NewLifecycleEvent(subscription, models.LifecycleCanceled, models.ActorUser, "", mockTime),
+	} {
+		require.NoError(t, repo.Create(t.Context(), event))
+	}
+
+	got, err := repo.CountByTypeSince(t.Context(), models.LifecycleRenewed, mockToday)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+}
+
+func TestLifecycleEventRepository_DeleteByUserID(t *testing.T) {
+	repo := newLifecycleEventRepo(t)
+	subscription := &models.Subscription{ID: bson.NewObjectID(), UserID: defaultUserID}
+	others := &models.Subscription{ID: bson.NewObjectID(), UserID: bson.NewObjectID()}
+
+	reminded := models.NewLifecycleEvent(subscription, models.LifecycleReminderSent, models.ActorSystem, "Renewal reminder sent", mockTime)
+	created := models.NewLifecycleEvent(subscription, models.LifecycleCreated, models.ActorUser, "Subscription created", mockTime)
+	othersReminder := models.NewLifecycleEvent(others, models.LifecycleReminderSent, models.ActorSystem, "Renewal reminder sent", mockTime)
+	for _, event := range []*models.LifecycleEvent{reminded, created, othersReminder} {
+		require.NoError(t, repo.Create(t.Context(), event))
+	}
+
+	deleted, err := repo.DeleteByUserID(t.Context(), defaultUserID, models.LifecycleReminderSent)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, deleted)
+
+	got, err := repo.GetBySubscriptionID(t.Context(), subscription.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, created.ID, got[0].ID)
+
+	got, err = repo.GetBySubscriptionID(t.Context(), others.ID)
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+}
@@ -0,0 +1,328 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockArchiveRepository is an autogenerated mock type for the ArchiveRepository type
+type MockArchiveRepository struct {
+	mock.Mock
+}
+
+type MockArchiveRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockArchiveRepository) EXPECT() *MockArchiveRepository_Expecter {
+	return &MockArchiveRepository_Expecter{mock: &_m.Mock}
+}
+
+// Anonymize provides a mock function with given fields: _a0, _a1
+func (_m *MockArchiveRepository) Anonymize(_a0 context.Context, _a1 bson.ObjectID) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Anonymize")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockArchiveRepository_Anonymize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Anonymize'
+type MockArchiveRepository_Anonymize_Call struct {
+	*mock.Call
+}
+
+// Anonymize is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockArchiveRepository_Expecter) Anonymize(_a0 interface{}, _a1 interface{}) *MockArchiveRepository_Anonymize_Call {
+	return &MockArchiveRepository_Anonymize_Call{Call: _e.mock.On("Anonymize", _a0, _a1)}
+}
+
+func (_c *MockArchiveRepository_Anonymize_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockArchiveRepository_Anonymize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockArchiveRepository_Anonymize_Call) Return(_a0 int64, _a1 error) *MockArchiveRepository_Anonymize_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockArchiveRepository_Anonymize_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockArchiveRepository_Anonymize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockArchiveRepository) Create(_a0 context.Context, _a1 *models.ArchivedSubscription) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ArchivedSubscription) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockArchiveRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockArchiveRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.ArchivedSubscription
+func (_e *MockArchiveRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockArchiveRepository_Create_Call {
+	return &MockArchiveRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockArchiveRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.ArchivedSubscription)) *MockArchiveRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ArchivedSubscription))
+	})
+	return _c
+}
+
+func (_c *MockArchiveRepository_Create_Call) Return(_a0 error) *MockArchiveRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockArchiveRepository_Create_Call) RunAndReturn(run func(context.Context, *models.ArchivedSubscription) error) *MockArchiveRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: _a0, _a1
+func (_m *MockArchiveRepository) GetByID(_a0 context.Context, _a1 bson.ObjectID) (*models.ArchivedSubscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.ArchivedSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.ArchivedSubscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.ArchivedSubscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ArchivedSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockArchiveRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockArchiveRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockArchiveRepository_Expecter) GetByID(_a0 interface{}, _a1 interface{}) *MockArchiveRepository_GetByID_Call {
+	return &MockArchiveRepository_GetByID_Call{Call: _e.mock.On("GetByID", _a0, _a1)}
+}
+
+func (_c *MockArchiveRepository_GetByID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockArchiveRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockArchiveRepository_GetByID_Call) Return(_a0 *models.ArchivedSubscription, _a1 error) *MockArchiveRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockArchiveRepository_GetByID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.ArchivedSubscription, error)) *MockArchiveRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function with given fields: _a0, _a1
+func (_m *MockArchiveRepository) GetByUserID(_a0 context.Context, _a1 bson.ObjectID) ([]*models.ArchivedSubscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []*models.ArchivedSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) ([]*models.ArchivedSubscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) []*models.ArchivedSubscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ArchivedSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockArchiveRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockArchiveRepository_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockArchiveRepository_Expecter) GetByUserID(_a0 interface{}, _a1 interface{}) *MockArchiveRepository_GetByUserID_Call {
+	return &MockArchiveRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", _a0, _a1)}
+}
+
+func (_c *MockArchiveRepository_GetByUserID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockArchiveRepository_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockArchiveRepository_GetByUserID_Call) Return(_a0 []*models.ArchivedSubscription, _a1 error) *MockArchiveRepository_GetByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockArchiveRepository_GetByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) ([]*models.ArchivedSubscription, error)) *MockArchiveRepository_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: _a0, _a1
+func (_m *MockArchiveRepository) List(_a0 context.Context, _a1 *models.SubscriptionQuery) ([]*models.ArchivedSubscription, int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.ArchivedSubscription
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SubscriptionQuery) ([]*models.ArchivedSubscription, int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SubscriptionQuery) []*models.ArchivedSubscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ArchivedSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SubscriptionQuery) int64); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.SubscriptionQuery) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockArchiveRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockArchiveRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.SubscriptionQuery
+func (_e *MockArchiveRepository_Expecter) List(_a0 interface{}, _a1 interface{}) *MockArchiveRepository_List_Call {
+	return &MockArchiveRepository_List_Call{Call: _e.mock.On("List", _a0, _a1)}
+}
+
+func (_c *MockArchiveRepository_List_Call) Run(run func(_a0 context.Context, _a1 *models.SubscriptionQuery)) *MockArchiveRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SubscriptionQuery))
+	})
+	return _c
+}
+
+func (_c *MockArchiveRepository_List_Call) Return(_a0 []*models.ArchivedSubscription, _a1 int64, _a2 error) *MockArchiveRepository_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockArchiveRepository_List_Call) RunAndReturn(run func(context.Context, *models.SubscriptionQuery) ([]*models.ArchivedSubscription, int64, error)) *MockArchiveRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockArchiveRepository creates a new instance of MockArchiveRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockArchiveRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockArchiveRepository {
+	mock := &MockArchiveRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,378 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockAttachmentRepository is an autogenerated mock type for the AttachmentRepository type
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+type MockAttachmentRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAttachmentRepository) EXPECT() *MockAttachmentRepository_Expecter {
+	return &MockAttachmentRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountBySubscriptionID provides a mock function with given fields: _a0, _a1
+func (_m *MockAttachmentRepository) CountBySubscriptionID(_a0 context.Context, _a1 bson.ObjectID) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountBySubscriptionID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAttachmentRepository_CountBySubscriptionID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBySubscriptionID'
+type MockAttachmentRepository_CountBySubscriptionID_Call struct {
+	*mock.Call
+}
+
+// CountBySubscriptionID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockAttachmentRepository_Expecter) CountBySubscriptionID(_a0 interface{}, _a1 interface{}) *MockAttachmentRepository_CountBySubscriptionID_Call {
+	return &MockAttachmentRepository_CountBySubscriptionID_Call{Call: _e.mock.On("CountBySubscriptionID", _a0, _a1)}
+}
+
+func (_c *MockAttachmentRepository_CountBySubscriptionID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockAttachmentRepository_CountBySubscriptionID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockAttachmentRepository_CountBySubscriptionID_Call) Return(_a0 int64, _a1 error) *MockAttachmentRepository_CountBySubscriptionID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAttachmentRepository_CountBySubscriptionID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockAttachmentRepository_CountBySubscriptionID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockAttachmentRepository) Create(_a0 context.Context, _a1 *models.Attachment) (*models.Attachment, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Attachment) (*models.Attachment, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Attachment) *models.Attachment); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Attachment) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAttachmentRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAttachmentRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Attachment
+func (_e *MockAttachmentRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockAttachmentRepository_Create_Call {
+	return &MockAttachmentRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockAttachmentRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.Attachment)) *MockAttachmentRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Attachment))
+	})
+	return _c
+}
+
+func (_c *MockAttachmentRepository_Create_Call) Return(_a0 *models.Attachment, _a1 error) *MockAttachmentRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAttachmentRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Attachment) (*models.Attachment, error)) *MockAttachmentRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: _a0, _a1
+func (_m *MockAttachmentRepository) Delete(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAttachmentRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockAttachmentRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockAttachmentRepository_Expecter) Delete(_a0 interface{}, _a1 interface{}) *MockAttachmentRepository_Delete_Call {
+	return &MockAttachmentRepository_Delete_Call{Call: _e.mock.On("Delete", _a0, _a1)}
+}
+
+func (_c *MockAttachmentRepository_Delete_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockAttachmentRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockAttachmentRepository_Delete_Call) Return(_a0 error) *MockAttachmentRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAttachmentRepository_Delete_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockAttachmentRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *MockAttachmentRepository) DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUserID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAttachmentRepository_DeleteByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUserID'
+type MockAttachmentRepository_DeleteByUserID_Call struct {
+	*mock.Call
+}
+
+// DeleteByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockAttachmentRepository_Expecter) DeleteByUserID(ctx interface{}, userID interface{}) *MockAttachmentRepository_DeleteByUserID_Call {
+	return &MockAttachmentRepository_DeleteByUserID_Call{Call: _e.mock.On("DeleteByUserID", ctx, userID)}
+}
+
+func (_c *MockAttachmentRepository_DeleteByUserID_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockAttachmentRepository_DeleteByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockAttachmentRepository_DeleteByUserID_Call) Return(_a0 int64, _a1 error) *MockAttachmentRepository_DeleteByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAttachmentRepository_DeleteByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockAttachmentRepository_DeleteByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: _a0, _a1
+func (_m *MockAttachmentRepository) GetByID(_a0 context.Context, _a1 bson.ObjectID) (*models.Attachment, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.Attachment, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.Attachment); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAttachmentRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockAttachmentRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockAttachmentRepository_Expecter) GetByID(_a0 interface{}, _a1 interface{}) *MockAttachmentRepository_GetByID_Call {
+	return &MockAttachmentRepository_GetByID_Call{Call: _e.mock.On("GetByID", _a0, _a1)}
+}
+
+func (_c *MockAttachmentRepository_GetByID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockAttachmentRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockAttachmentRepository_GetByID_Call) Return(_a0 *models.Attachment, _a1 error) *MockAttachmentRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAttachmentRepository_GetByID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.Attachment, error)) *MockAttachmentRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBySubscriptionID provides a mock function with given fields: _a0, _a1
+func (_m *MockAttachmentRepository) GetBySubscriptionID(_a0 context.Context, _a1 bson.ObjectID) ([]*models.Attachment, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySubscriptionID")
+	}
+
+	var r0 []*models.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) ([]*models.Attachment, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) []*models.Attachment); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAttachmentRepository_GetBySubscriptionID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySubscriptionID'
+type MockAttachmentRepository_GetBySubscriptionID_Call struct {
+	*mock.Call
+}
+
+// GetBySubscriptionID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockAttachmentRepository_Expecter) GetBySubscriptionID(_a0 interface{}, _a1 interface{}) *MockAttachmentRepository_GetBySubscriptionID_Call {
+	return &MockAttachmentRepository_GetBySubscriptionID_Call{Call: _e.mock.On("GetBySubscriptionID", _a0, _a1)}
+}
+
+func (_c *MockAttachmentRepository_GetBySubscriptionID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockAttachmentRepository_GetBySubscriptionID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockAttachmentRepository_GetBySubscriptionID_Call) Return(_a0 []*models.Attachment, _a1 error) *MockAttachmentRepository_GetBySubscriptionID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAttachmentRepository_GetBySubscriptionID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) ([]*models.Attachment, error)) *MockAttachmentRepository_GetBySubscriptionID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAttachmentRepository creates a new instance of MockAttachmentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAttachmentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAttachmentRepository {
+	mock := &MockAttachmentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
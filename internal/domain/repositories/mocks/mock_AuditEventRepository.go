@@ -0,0 +1,150 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAuditEventRepository is an autogenerated mock type for the AuditEventRepository type
+type MockAuditEventRepository struct {
+	mock.Mock
+}
+
+type MockAuditEventRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditEventRepository) EXPECT() *MockAuditEventRepository_Expecter {
+	return &MockAuditEventRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockAuditEventRepository) Create(_a0 context.Context, _a1 *models.AuditEvent) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditEvent) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuditEventRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAuditEventRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.AuditEvent
+func (_e *MockAuditEventRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockAuditEventRepository_Create_Call {
+	return &MockAuditEventRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockAuditEventRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.AuditEvent)) *MockAuditEventRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.AuditEvent))
+	})
+	return _c
+}
+
+func (_c *MockAuditEventRepository_Create_Call) Return(_a0 error) *MockAuditEventRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuditEventRepository_Create_Call) RunAndReturn(run func(context.Context, *models.AuditEvent) error) *MockAuditEventRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: _a0, _a1
+func (_m *MockAuditEventRepository) List(_a0 context.Context, _a1 *models.AuditQuery) ([]*models.AuditEvent, int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.AuditEvent
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditQuery) ([]*models.AuditEvent, int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditQuery) []*models.AuditEvent); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.AuditQuery) int64); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.AuditQuery) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockAuditEventRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockAuditEventRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.AuditQuery
+func (_e *MockAuditEventRepository_Expecter) List(_a0 interface{}, _a1 interface{}) *MockAuditEventRepository_List_Call {
+	return &MockAuditEventRepository_List_Call{Call: _e.mock.On("List", _a0, _a1)}
+}
+
+func (_c *MockAuditEventRepository_List_Call) Run(run func(_a0 context.Context, _a1 *models.AuditQuery)) *MockAuditEventRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.AuditQuery))
+	})
+	return _c
+}
+
+func (_c *MockAuditEventRepository_List_Call) Return(_a0 []*models.AuditEvent, _a1 int64, _a2 error) *MockAuditEventRepository_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockAuditEventRepository_List_Call) RunAndReturn(run func(context.Context, *models.AuditQuery) ([]*models.AuditEvent, int64, error)) *MockAuditEventRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditEventRepository creates a new instance of MockAuditEventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditEventRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditEventRepository {
+	mock := &MockAuditEventRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
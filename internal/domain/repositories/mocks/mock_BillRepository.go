@@ -10,6 +10,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+
+	time "time"
 )
 
 // MockBillRepository is an autogenerated mock type for the BillRepository type
@@ -25,6 +27,63 @@ func (_m *MockBillRepository) EXPECT() *MockBillRepository_Expecter {
 	return &MockBillRepository_Expecter{mock: &_m.Mock}
 }
 
+// Count provides a mock function with given fields: _a0, _a1
+func (_m *MockBillRepository) Count(_a0 context.Context, _a1 *models.BillFilter) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BillFilter) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BillFilter) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.BillFilter) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBillRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type MockBillRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.BillFilter
+func (_e *MockBillRepository_Expecter) Count(_a0 interface{}, _a1 interface{}) *MockBillRepository_Count_Call {
+	return &MockBillRepository_Count_Call{Call: _e.mock.On("Count", _a0, _a1)}
+}
+
+func (_c *MockBillRepository_Count_Call) Run(run func(_a0 context.Context, _a1 *models.BillFilter)) *MockBillRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.BillFilter))
+	})
+	return _c
+}
+
+func (_c *MockBillRepository_Count_Call) Return(_a0 int64, _a1 error) *MockBillRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBillRepository_Count_Call) RunAndReturn(run func(context.Context, *models.BillFilter) (int64, error)) *MockBillRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: _a0, _a1
 func (_m *MockBillRepository) Create(_a0 context.Context, _a1 *models.Bill) (*models.Bill, error) {
 	ret := _m.Called(_a0, _a1)
@@ -84,6 +143,215 @@ func (_c *MockBillRepository_Create_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// DeleteBySubscriptionID provides a mock function with given fields: _a0, _a1
+func (_m *MockBillRepository) DeleteBySubscriptionID(_a0 context.Context, _a1 bson.ObjectID) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBySubscriptionID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBillRepository_DeleteBySubscriptionID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBySubscriptionID'
+type MockBillRepository_DeleteBySubscriptionID_Call struct {
+	*mock.Call
+}
+
+// DeleteBySubscriptionID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockBillRepository_Expecter) DeleteBySubscriptionID(_a0 interface{}, _a1 interface{}) *MockBillRepository_DeleteBySubscriptionID_Call {
+	return &MockBillRepository_DeleteBySubscriptionID_Call{Call: _e.mock.On("DeleteBySubscriptionID", _a0, _a1)}
+}
+
+func (_c *MockBillRepository_DeleteBySubscriptionID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockBillRepository_DeleteBySubscriptionID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockBillRepository_DeleteBySubscriptionID_Call) Return(_a0 int64, _a1 error) *MockBillRepository_DeleteBySubscriptionID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBillRepository_DeleteBySubscriptionID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockBillRepository_DeleteBySubscriptionID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDangling provides a mock function with given fields: _a0, _a1
+func (_m *MockBillRepository) DeleteDangling(_a0 context.Context, _a1 time.Time) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDangling")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBillRepository_DeleteDangling_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDangling'
+type MockBillRepository_DeleteDangling_Call struct {
+	*mock.Call
+}
+
+// DeleteDangling is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 time.Time
+func (_e *MockBillRepository_Expecter) DeleteDangling(_a0 interface{}, _a1 interface{}) *MockBillRepository_DeleteDangling_Call {
+	return &MockBillRepository_DeleteDangling_Call{Call: _e.mock.On("DeleteDangling", _a0, _a1)}
+}
+
+func (_c *MockBillRepository_DeleteDangling_Call) Run(run func(_a0 context.Context, _a1 time.Time)) *MockBillRepository_DeleteDangling_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockBillRepository_DeleteDangling_Call) Return(_a0 int64, _a1 error) *MockBillRepository_DeleteDangling_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBillRepository_DeleteDangling_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockBillRepository_DeleteDangling_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Each provides a mock function with given fields: ctx, filter, fn
+func (_m *MockBillRepository) Each(ctx context.Context, filter *models.BillFilter, fn func(*models.Bill) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Each")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BillFilter, func(*models.Bill) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBillRepository_Each_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Each'
+type MockBillRepository_Each_Call struct {
+	*mock.Call
+}
+
+// Each is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *models.BillFilter
+//   - fn func(*models.Bill) error
+func (_e *MockBillRepository_Expecter) Each(ctx interface{}, filter interface{}, fn interface{}) *MockBillRepository_Each_Call {
+	return &MockBillRepository_Each_Call{Call: _e.mock.On("Each", ctx, filter, fn)}
+}
+
+func (_c *MockBillRepository_Each_Call) Run(run func(ctx context.Context, filter *models.BillFilter, fn func(*models.Bill) error)) *MockBillRepository_Each_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.BillFilter), args[2].(func(*models.Bill) error))
+	})
+	return _c
+}
+
+func (_c *MockBillRepository_Each_Call) Return(_a0 error) *MockBillRepository_Each_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBillRepository_Each_Call) RunAndReturn(run func(context.Context, *models.BillFilter, func(*models.Bill) error) error) *MockBillRepository_Each_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachPending provides a mock function with given fields: ctx, fn
+func (_m *MockBillRepository) EachPending(ctx context.Context, fn func(*models.Bill) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachPending")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*models.Bill) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBillRepository_EachPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachPending'
+type MockBillRepository_EachPending_Call struct {
+	*mock.Call
+}
+
+// EachPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*models.Bill) error
+func (_e *MockBillRepository_Expecter) EachPending(ctx interface{}, fn interface{}) *MockBillRepository_EachPending_Call {
+	return &MockBillRepository_EachPending_Call{Call: _e.mock.On("EachPending", ctx, fn)}
+}
+
+func (_c *MockBillRepository_EachPending_Call) Run(run func(ctx context.Context, fn func(*models.Bill) error)) *MockBillRepository_EachPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(*models.Bill) error))
+	})
+	return _c
+}
+
+func (_c *MockBillRepository_EachPending_Call) Return(_a0 error) *MockBillRepository_EachPending_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBillRepository_EachPending_Call) RunAndReturn(run func(context.Context, func(*models.Bill) error) error) *MockBillRepository_EachPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function with given fields: _a0, _a1
 func (_m *MockBillRepository) GetByID(_a0 context.Context, _a1 bson.ObjectID) (*models.Bill, error) {
 	ret := _m.Called(_a0, _a1)
@@ -143,6 +411,124 @@ func (_c *MockBillRepository_GetByID_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// GetBySubscriptionID provides a mock function with given fields: _a0, _a1
+func (_m *MockBillRepository) GetBySubscriptionID(_a0 context.Context, _a1 bson.ObjectID) ([]*models.Bill, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySubscriptionID")
+	}
+
+	var r0 []*models.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) ([]*models.Bill, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) []*models.Bill); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBillRepository_GetBySubscriptionID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySubscriptionID'
+type MockBillRepository_GetBySubscriptionID_Call struct {
+	*mock.Call
+}
+
+// GetBySubscriptionID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockBillRepository_Expecter) GetBySubscriptionID(_a0 interface{}, _a1 interface{}) *MockBillRepository_GetBySubscriptionID_Call {
+	return &MockBillRepository_GetBySubscriptionID_Call{Call: _e.mock.On("GetBySubscriptionID", _a0, _a1)}
+}
+
+func (_c *MockBillRepository_GetBySubscriptionID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockBillRepository_GetBySubscriptionID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockBillRepository_GetBySubscriptionID_Call) Return(_a0 []*models.Bill, _a1 error) *MockBillRepository_GetBySubscriptionID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBillRepository_GetBySubscriptionID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) ([]*models.Bill, error)) *MockBillRepository_GetBySubscriptionID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestBill provides a mock function with given fields: _a0, _a1
+func (_m *MockBillRepository) GetLatestBill(_a0 context.Context, _a1 bson.ObjectID) (*models.Bill, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBill")
+	}
+
+	var r0 *models.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.Bill, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.Bill); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBillRepository_GetLatestBill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestBill'
+type MockBillRepository_GetLatestBill_Call struct {
+	*mock.Call
+}
+
+// GetLatestBill is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockBillRepository_Expecter) GetLatestBill(_a0 interface{}, _a1 interface{}) *MockBillRepository_GetLatestBill_Call {
+	return &MockBillRepository_GetLatestBill_Call{Call: _e.mock.On("GetLatestBill", _a0, _a1)}
+}
+
+func (_c *MockBillRepository_GetLatestBill_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockBillRepository_GetLatestBill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockBillRepository_GetLatestBill_Call) Return(_a0 *models.Bill, _a1 error) *MockBillRepository_GetLatestBill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBillRepository_GetLatestBill_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.Bill, error)) *MockBillRepository_GetLatestBill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRecentBill provides a mock function with given fields: _a0, _a1
 func (_m *MockBillRepository) GetRecentBill(_a0 context.Context, _a1 bson.ObjectID) (*models.Bill, error) {
 	ret := _m.Called(_a0, _a1)
@@ -202,6 +588,73 @@ func (_c *MockBillRepository_GetRecentBill_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// ListBySubscriptionID provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockBillRepository) ListBySubscriptionID(_a0 context.Context, _a1 bson.ObjectID, _a2 *models.BillQuery) ([]*models.Bill, int64, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBySubscriptionID")
+	}
+
+	var r0 []*models.Bill
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, *models.BillQuery) ([]*models.Bill, int64, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, *models.BillQuery) []*models.Bill); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, *models.BillQuery) int64); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, bson.ObjectID, *models.BillQuery) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockBillRepository_ListBySubscriptionID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBySubscriptionID'
+type MockBillRepository_ListBySubscriptionID_Call struct {
+	*mock.Call
+}
+
+// ListBySubscriptionID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+//   - _a2 *models.BillQuery
+func (_e *MockBillRepository_Expecter) ListBySubscriptionID(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockBillRepository_ListBySubscriptionID_Call {
+	return &MockBillRepository_ListBySubscriptionID_Call{Call: _e.mock.On("ListBySubscriptionID", _a0, _a1, _a2)}
+}
+
+func (_c *MockBillRepository_ListBySubscriptionID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID, _a2 *models.BillQuery)) *MockBillRepository_ListBySubscriptionID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(*models.BillQuery))
+	})
+	return _c
+}
+
+func (_c *MockBillRepository_ListBySubscriptionID_Call) Return(_a0 []*models.Bill, _a1 int64, _a2 error) *MockBillRepository_ListBySubscriptionID_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockBillRepository_ListBySubscriptionID_Call) RunAndReturn(run func(context.Context, bson.ObjectID, *models.BillQuery) ([]*models.Bill, int64, error)) *MockBillRepository_ListBySubscriptionID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: _a0, _a1
 func (_m *MockBillRepository) Update(_a0 context.Context, _a1 *models.Bill) (*models.Bill, error) {
 	ret := _m.Called(_a0, _a1)
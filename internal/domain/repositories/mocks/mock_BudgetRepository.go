@@ -0,0 +1,251 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockBudgetRepository is an autogenerated mock type for the BudgetRepository type
+type MockBudgetRepository struct {
+	mock.Mock
+}
+
+type MockBudgetRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBudgetRepository) EXPECT() *MockBudgetRepository_Expecter {
+	return &MockBudgetRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, userID
+func (_m *MockBudgetRepository) Delete(ctx context.Context, userID bson.ObjectID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBudgetRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockBudgetRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockBudgetRepository_Expecter) Delete(ctx interface{}, userID interface{}) *MockBudgetRepository_Delete_Call {
+	return &MockBudgetRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, userID)}
+}
+
+func (_c *MockBudgetRepository_Delete_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockBudgetRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockBudgetRepository_Delete_Call) Return(_a0 error) *MockBudgetRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBudgetRepository_Delete_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockBudgetRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: _a0
+func (_m *MockBudgetRepository) GetAll(_a0 context.Context) ([]*models.Budget, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*models.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Budget, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Budget); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBudgetRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockBudgetRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockBudgetRepository_Expecter) GetAll(_a0 interface{}) *MockBudgetRepository_GetAll_Call {
+	return &MockBudgetRepository_GetAll_Call{Call: _e.mock.On("GetAll", _a0)}
+}
+
+func (_c *MockBudgetRepository_GetAll_Call) Run(run func(_a0 context.Context)) *MockBudgetRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockBudgetRepository_GetAll_Call) Return(_a0 []*models.Budget, _a1 error) *MockBudgetRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBudgetRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]*models.Budget, error)) *MockBudgetRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function with given fields: ctx, userID
+func (_m *MockBudgetRepository) GetByUserID(ctx context.Context, userID bson.ObjectID) (*models.Budget, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 *models.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.Budget, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.Budget); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBudgetRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockBudgetRepository_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockBudgetRepository_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockBudgetRepository_GetByUserID_Call {
+	return &MockBudgetRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+}
+
+func (_c *MockBudgetRepository_GetByUserID_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockBudgetRepository_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockBudgetRepository_GetByUserID_Call) Return(_a0 *models.Budget, _a1 error) *MockBudgetRepository_GetByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBudgetRepository_GetByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.Budget, error)) *MockBudgetRepository_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: _a0, _a1
+func (_m *MockBudgetRepository) Upsert(_a0 context.Context, _a1 *models.Budget) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Budget) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBudgetRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockBudgetRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Budget
+func (_e *MockBudgetRepository_Expecter) Upsert(_a0 interface{}, _a1 interface{}) *MockBudgetRepository_Upsert_Call {
+	return &MockBudgetRepository_Upsert_Call{Call: _e.mock.On("Upsert", _a0, _a1)}
+}
+
+func (_c *MockBudgetRepository_Upsert_Call) Run(run func(_a0 context.Context, _a1 *models.Budget)) *MockBudgetRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Budget))
+	})
+	return _c
+}
+
+func (_c *MockBudgetRepository_Upsert_Call) Return(_a0 error) *MockBudgetRepository_Upsert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBudgetRepository_Upsert_Call) RunAndReturn(run func(context.Context, *models.Budget) error) *MockBudgetRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBudgetRepository creates a new instance of MockBudgetRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBudgetRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBudgetRepository {
+	mock := &MockBudgetRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,203 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCatalogRepository is an autogenerated mock type for the CatalogRepository type
+type MockCatalogRepository struct {
+	mock.Mock
+}
+
+type MockCatalogRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCatalogRepository) EXPECT() *MockCatalogRepository_Expecter {
+	return &MockCatalogRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetByID provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalogRepository) GetByID(_a0 context.Context, _a1 string) (*models.CatalogEntry, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.CatalogEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CatalogEntry, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CatalogEntry); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CatalogEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCatalogRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockCatalogRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockCatalogRepository_Expecter) GetByID(_a0 interface{}, _a1 interface{}) *MockCatalogRepository_GetByID_Call {
+	return &MockCatalogRepository_GetByID_Call{Call: _e.mock.On("GetByID", _a0, _a1)}
+}
+
+func (_c *MockCatalogRepository_GetByID_Call) Run(run func(_a0 context.Context, _a1 string)) *MockCatalogRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCatalogRepository_GetByID_Call) Return(_a0 *models.CatalogEntry, _a1 error) *MockCatalogRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCatalogRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (*models.CatalogEntry, error)) *MockCatalogRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, query, limit
+func (_m *MockCatalogRepository) Search(ctx context.Context, query string, limit int64) ([]*models.CatalogEntry, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*models.CatalogEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]*models.CatalogEntry, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []*models.CatalogEntry); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CatalogEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCatalogRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockCatalogRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int64
+func (_e *MockCatalogRepository_Expecter) Search(ctx interface{}, query interface{}, limit interface{}) *MockCatalogRepository_Search_Call {
+	return &MockCatalogRepository_Search_Call{Call: _e.mock.On("Search", ctx, query, limit)}
+}
+
+func (_c *MockCatalogRepository_Search_Call) Run(run func(ctx context.Context, query string, limit int64)) *MockCatalogRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockCatalogRepository_Search_Call) Return(_a0 []*models.CatalogEntry, _a1 error) *MockCatalogRepository_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCatalogRepository_Search_Call) RunAndReturn(run func(context.Context, string, int64) ([]*models.CatalogEntry, error)) *MockCatalogRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sync provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalogRepository) Sync(_a0 context.Context, _a1 []*models.CatalogEntry) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sync")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.CatalogEntry) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCatalogRepository_Sync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sync'
+type MockCatalogRepository_Sync_Call struct {
+	*mock.Call
+}
+
+// Sync is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []*models.CatalogEntry
+func (_e *MockCatalogRepository_Expecter) Sync(_a0 interface{}, _a1 interface{}) *MockCatalogRepository_Sync_Call {
+	return &MockCatalogRepository_Sync_Call{Call: _e.mock.On("Sync", _a0, _a1)}
+}
+
+func (_c *MockCatalogRepository_Sync_Call) Run(run func(_a0 context.Context, _a1 []*models.CatalogEntry)) *MockCatalogRepository_Sync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*models.CatalogEntry))
+	})
+	return _c
+}
+
+func (_c *MockCatalogRepository_Sync_Call) Return(_a0 error) *MockCatalogRepository_Sync_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCatalogRepository_Sync_Call) RunAndReturn(run func(context.Context, []*models.CatalogEntry) error) *MockCatalogRepository_Sync_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCatalogRepository creates a new instance of MockCatalogRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCatalogRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCatalogRepository {
+	mock := &MockCatalogRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
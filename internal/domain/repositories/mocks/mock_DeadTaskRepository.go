@@ -0,0 +1,275 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+
+	time "time"
+)
+
+// MockDeadTaskRepository is an autogenerated mock type for the DeadTaskRepository type
+type MockDeadTaskRepository struct {
+	mock.Mock
+}
+
+type MockDeadTaskRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeadTaskRepository) EXPECT() *MockDeadTaskRepository_Expecter {
+	return &MockDeadTaskRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockDeadTaskRepository) Create(_a0 context.Context, _a1 *models.DeadTask) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeadTask) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDeadTaskRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockDeadTaskRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.DeadTask
+func (_e *MockDeadTaskRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockDeadTaskRepository_Create_Call {
+	return &MockDeadTaskRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockDeadTaskRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.DeadTask)) *MockDeadTaskRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.DeadTask))
+	})
+	return _c
+}
+
+func (_c *MockDeadTaskRepository_Create_Call) Return(_a0 error) *MockDeadTaskRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDeadTaskRepository_Create_Call) RunAndReturn(run func(context.Context, *models.DeadTask) error) *MockDeadTaskRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: _a0, _a1
+func (_m *MockDeadTaskRepository) GetByID(_a0 context.Context, _a1 bson.ObjectID) (*models.DeadTask, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.DeadTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.DeadTask, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.DeadTask); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeadTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeadTaskRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockDeadTaskRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockDeadTaskRepository_Expecter) GetByID(_a0 interface{}, _a1 interface{}) *MockDeadTaskRepository_GetByID_Call {
+	return &MockDeadTaskRepository_GetByID_Call{Call: _e.mock.On("GetByID", _a0, _a1)}
+}
+
+func (_c *MockDeadTaskRepository_GetByID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockDeadTaskRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockDeadTaskRepository_GetByID_Call) Return(_a0 *models.DeadTask, _a1 error) *MockDeadTaskRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeadTaskRepository_GetByID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.DeadTask, error)) *MockDeadTaskRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: _a0, _a1
+func (_m *MockDeadTaskRepository) List(_a0 context.Context, _a1 *models.DeadTaskQuery) ([]*models.DeadTask, int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.DeadTask
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeadTaskQuery) ([]*models.DeadTask, int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeadTaskQuery) []*models.DeadTask); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DeadTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DeadTaskQuery) int64); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.DeadTaskQuery) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockDeadTaskRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDeadTaskRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.DeadTaskQuery
+func (_e *MockDeadTaskRepository_Expecter) List(_a0 interface{}, _a1 interface{}) *MockDeadTaskRepository_List_Call {
+	return &MockDeadTaskRepository_List_Call{Call: _e.mock.On("List", _a0, _a1)}
+}
+
+func (_c *MockDeadTaskRepository_List_Call) Run(run func(_a0 context.Context, _a1 *models.DeadTaskQuery)) *MockDeadTaskRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.DeadTaskQuery))
+	})
+	return _c
+}
+
+func (_c *MockDeadTaskRepository_List_Call) Return(_a0 []*models.DeadTask, _a1 int64, _a2 error) *MockDeadTaskRepository_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockDeadTaskRepository_List_Call) RunAndReturn(run func(context.Context, *models.DeadTaskQuery) ([]*models.DeadTask, int64, error)) *MockDeadTaskRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRequeued provides a mock function with given fields: ctx, id, taskID, requeuedAt
+func (_m *MockDeadTaskRepository) MarkRequeued(ctx context.Context, id bson.ObjectID, taskID string, requeuedAt time.Time) (*models.DeadTask, error) {
+	ret := _m.Called(ctx, id, taskID, requeuedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRequeued")
+	}
+
+	var r0 *models.DeadTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, string, time.Time) (*models.DeadTask, error)); ok {
+		return rf(ctx, id, taskID, requeuedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, string, time.Time) *models.DeadTask); ok {
+		r0 = rf(ctx, id, taskID, requeuedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeadTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, string, time.Time) error); ok {
+		r1 = rf(ctx, id, taskID, requeuedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeadTaskRepository_MarkRequeued_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRequeued'
+type MockDeadTaskRepository_MarkRequeued_Call struct {
+	*mock.Call
+}
+
+// MarkRequeued is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - taskID string
+//   - requeuedAt time.Time
+func (_e *MockDeadTaskRepository_Expecter) MarkRequeued(ctx interface{}, id interface{}, taskID interface{}, requeuedAt interface{}) *MockDeadTaskRepository_MarkRequeued_Call {
+	return &MockDeadTaskRepository_MarkRequeued_Call{Call: _e.mock.On("MarkRequeued", ctx, id, taskID, requeuedAt)}
+}
+
+func (_c *MockDeadTaskRepository_MarkRequeued_Call) Run(run func(ctx context.Context, id bson.ObjectID, taskID string, requeuedAt time.Time)) *MockDeadTaskRepository_MarkRequeued_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDeadTaskRepository_MarkRequeued_Call) Return(_a0 *models.DeadTask, _a1 error) *MockDeadTaskRepository_MarkRequeued_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeadTaskRepository_MarkRequeued_Call) RunAndReturn(run func(context.Context, bson.ObjectID, string, time.Time) (*models.DeadTask, error)) *MockDeadTaskRepository_MarkRequeued_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeadTaskRepository creates a new instance of MockDeadTaskRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeadTaskRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeadTaskRepository {
+	mock := &MockDeadTaskRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockExchangeRateRepository is an autogenerated mock type for the ExchangeRateRepository type
+type MockExchangeRateRepository struct {
+	mock.Mock
+}
+
+type MockExchangeRateRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExchangeRateRepository) EXPECT() *MockExchangeRateRepository_Expecter {
+	return &MockExchangeRateRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetLatestOnOrBefore provides a mock function with given fields: ctx, date
+func (_m *MockExchangeRateRepository) GetLatestOnOrBefore(ctx context.Context, date time.Time) (*models.ExchangeRates, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestOnOrBefore")
+	}
+
+	var r0 *models.ExchangeRates
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*models.ExchangeRates, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *models.ExchangeRates); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExchangeRates)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExchangeRateRepository_GetLatestOnOrBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestOnOrBefore'
+type MockExchangeRateRepository_GetLatestOnOrBefore_Call struct {
+	*mock.Call
+}
+
+// GetLatestOnOrBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - date time.Time
+func (_e *MockExchangeRateRepository_Expecter) GetLatestOnOrBefore(ctx interface{}, date interface{}) *MockExchangeRateRepository_GetLatestOnOrBefore_Call {
+	return &MockExchangeRateRepository_GetLatestOnOrBefore_Call{Call: _e.mock.On("GetLatestOnOrBefore", ctx, date)}
+}
+
+func (_c *MockExchangeRateRepository_GetLatestOnOrBefore_Call) Run(run func(ctx context.Context, date time.Time)) *MockExchangeRateRepository_GetLatestOnOrBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockExchangeRateRepository_GetLatestOnOrBefore_Call) Return(_a0 *models.ExchangeRates, _a1 error) *MockExchangeRateRepository_GetLatestOnOrBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExchangeRateRepository_GetLatestOnOrBefore_Call) RunAndReturn(run func(context.Context, time.Time) (*models.ExchangeRates, error)) *MockExchangeRateRepository_GetLatestOnOrBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: _a0, _a1
+func (_m *MockExchangeRateRepository) Upsert(_a0 context.Context, _a1 *models.ExchangeRates) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ExchangeRates) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExchangeRateRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockExchangeRateRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.ExchangeRates
+func (_e *MockExchangeRateRepository_Expecter) Upsert(_a0 interface{}, _a1 interface{}) *MockExchangeRateRepository_Upsert_Call {
+	return &MockExchangeRateRepository_Upsert_Call{Call: _e.mock.On("Upsert", _a0, _a1)}
+}
+
+func (_c *MockExchangeRateRepository_Upsert_Call) Run(run func(_a0 context.Context, _a1 *models.ExchangeRates)) *MockExchangeRateRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ExchangeRates))
+	})
+	return _c
+}
+
+func (_c *MockExchangeRateRepository_Upsert_Call) Return(_a0 error) *MockExchangeRateRepository_Upsert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExchangeRateRepository_Upsert_Call) RunAndReturn(run func(context.Context, *models.ExchangeRates) error) *MockExchangeRateRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExchangeRateRepository creates a new instance of MockExchangeRateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExchangeRateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExchangeRateRepository {
+	mock := &MockExchangeRateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
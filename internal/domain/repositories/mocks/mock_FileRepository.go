@@ -0,0 +1,384 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockFileRepository is an autogenerated mock type for the FileRepository type
+type MockFileRepository struct {
+	mock.Mock
+}
+
+type MockFileRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFileRepository) EXPECT() *MockFileRepository_Expecter {
+	return &MockFileRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: _a0, _a1
+func (_m *MockFileRepository) Delete(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFileRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockFileRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockFileRepository_Expecter) Delete(_a0 interface{}, _a1 interface{}) *MockFileRepository_Delete_Call {
+	return &MockFileRepository_Delete_Call{Call: _e.mock.On("Delete", _a0, _a1)}
+}
+
+func (_c *MockFileRepository_Delete_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockFileRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_Delete_Call) Return(_a0 error) *MockFileRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileRepository_Delete_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockFileRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByOwnerID provides a mock function with given fields: ctx, ownerID
+func (_m *MockFileRepository) DeleteByOwnerID(ctx context.Context, ownerID bson.ObjectID) (int64, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByOwnerID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileRepository_DeleteByOwnerID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByOwnerID'
+type MockFileRepository_DeleteByOwnerID_Call struct {
+	*mock.Call
+}
+
+// DeleteByOwnerID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID bson.ObjectID
+func (_e *MockFileRepository_Expecter) DeleteByOwnerID(ctx interface{}, ownerID interface{}) *MockFileRepository_DeleteByOwnerID_Call {
+	return &MockFileRepository_DeleteByOwnerID_Call{Call: _e.mock.On("DeleteByOwnerID", ctx, ownerID)}
+}
+
+func (_c *MockFileRepository_DeleteByOwnerID_Call) Run(run func(ctx context.Context, ownerID bson.ObjectID)) *MockFileRepository_DeleteByOwnerID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_DeleteByOwnerID_Call) Return(_a0 int64, _a1 error) *MockFileRepository_DeleteByOwnerID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileRepository_DeleteByOwnerID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockFileRepository_DeleteByOwnerID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: _a0, _a1
+func (_m *MockFileRepository) GetByID(_a0 context.Context, _a1 bson.ObjectID) (*models.StoredFile, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.StoredFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.StoredFile, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.StoredFile); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StoredFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockFileRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockFileRepository_Expecter) GetByID(_a0 interface{}, _a1 interface{}) *MockFileRepository_GetByID_Call {
+	return &MockFileRepository_GetByID_Call{Call: _e.mock.On("GetByID", _a0, _a1)}
+}
+
+func (_c *MockFileRepository_GetByID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockFileRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_GetByID_Call) Return(_a0 *models.StoredFile, _a1 error) *MockFileRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileRepository_GetByID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.StoredFile, error)) *MockFileRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByKey provides a mock function with given fields: _a0, _a1
+func (_m *MockFileRepository) GetByKey(_a0 context.Context, _a1 string) (*models.StoredFile, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByKey")
+	}
+
+	var r0 *models.StoredFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.StoredFile, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.StoredFile); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StoredFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileRepository_GetByKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByKey'
+type MockFileRepository_GetByKey_Call struct {
+	*mock.Call
+}
+
+// GetByKey is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockFileRepository_Expecter) GetByKey(_a0 interface{}, _a1 interface{}) *MockFileRepository_GetByKey_Call {
+	return &MockFileRepository_GetByKey_Call{Call: _e.mock.On("GetByKey", _a0, _a1)}
+}
+
+func (_c *MockFileRepository_GetByKey_Call) Run(run func(_a0 context.Context, _a1 string)) *MockFileRepository_GetByKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_GetByKey_Call) Return(_a0 *models.StoredFile, _a1 error) *MockFileRepository_GetByKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileRepository_GetByKey_Call) RunAndReturn(run func(context.Context, string) (*models.StoredFile, error)) *MockFileRepository_GetByKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function with given fields: _a0, _a1
+func (_m *MockFileRepository) Open(_a0 context.Context, _a1 bson.ObjectID) (io.ReadCloser, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (io.ReadCloser, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) io.ReadCloser); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileRepository_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockFileRepository_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockFileRepository_Expecter) Open(_a0 interface{}, _a1 interface{}) *MockFileRepository_Open_Call {
+	return &MockFileRepository_Open_Call{Call: _e.mock.On("Open", _a0, _a1)}
+}
+
+func (_c *MockFileRepository_Open_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockFileRepository_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_Open_Call) Return(_a0 io.ReadCloser, _a1 error) *MockFileRepository_Open_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileRepository_Open_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (io.ReadCloser, error)) *MockFileRepository_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upload provides a mock function with given fields: ctx, filename, metadata, content
+func (_m *MockFileRepository) Upload(ctx context.Context, filename string, metadata models.FileMetadata, content io.Reader) (*models.StoredFile, error) {
+	ret := _m.Called(ctx, filename, metadata, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upload")
+	}
+
+	var r0 *models.StoredFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FileMetadata, io.Reader) (*models.StoredFile, error)); ok {
+		return rf(ctx, filename, metadata, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FileMetadata, io.Reader) *models.StoredFile); ok {
+		r0 = rf(ctx, filename, metadata, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StoredFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.FileMetadata, io.Reader) error); ok {
+		r1 = rf(ctx, filename, metadata, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileRepository_Upload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upload'
+type MockFileRepository_Upload_Call struct {
+	*mock.Call
+}
+
+// Upload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filename string
+//   - metadata models.FileMetadata
+//   - content io.Reader
+func (_e *MockFileRepository_Expecter) Upload(ctx interface{}, filename interface{}, metadata interface{}, content interface{}) *MockFileRepository_Upload_Call {
+	return &MockFileRepository_Upload_Call{Call: _e.mock.On("Upload", ctx, filename, metadata, content)}
+}
+
+func (_c *MockFileRepository_Upload_Call) Run(run func(ctx context.Context, filename string, metadata models.FileMetadata, content io.Reader)) *MockFileRepository_Upload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.FileMetadata), args[3].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_Upload_Call) Return(_a0 *models.StoredFile, _a1 error) *MockFileRepository_Upload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileRepository_Upload_Call) RunAndReturn(run func(context.Context, string, models.FileMetadata, io.Reader) (*models.StoredFile, error)) *MockFileRepository_Upload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFileRepository creates a new instance of MockFileRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFileRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFileRepository {
+	mock := &MockFileRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,264 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+
+	time "time"
+)
+
+// MockLifecycleEventRepository is an autogenerated mock type for the LifecycleEventRepository type
+type MockLifecycleEventRepository struct {
+	mock.Mock
+}
+
+type MockLifecycleEventRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLifecycleEventRepository) EXPECT() *MockLifecycleEventRepository_Expecter {
+	return &MockLifecycleEventRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountByTypeSince provides a mock function with given fields: ctx, eventType, since
+func (_m *MockLifecycleEventRepository) CountByTypeSince(ctx context.Context, eventType models.LifecycleEventType, since time.Time) (int64, error) {
+	ret := _m.Called(ctx, eventType, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByTypeSince")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.LifecycleEventType, time.Time) (int64, error)); ok {
+		return rf(ctx, eventType, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.LifecycleEventType, time.Time) int64); ok {
+		r0 = rf(ctx, eventType, since)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.LifecycleEventType, time.Time) error); ok {
+		r1 = rf(ctx, eventType, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLifecycleEventRepository_CountByTypeSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByTypeSince'
+type MockLifecycleEventRepository_CountByTypeSince_Call struct {
+	*mock.Call
+}
+
+// CountByTypeSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventType models.LifecycleEventType
+//   - since time.Time
+func (_e *MockLifecycleEventRepository_Expecter) CountByTypeSince(ctx interface{}, eventType interface{}, since interface{}) *MockLifecycleEventRepository_CountByTypeSince_Call {
+	return &MockLifecycleEventRepository_CountByTypeSince_Call{Call: _e.mock.On("CountByTypeSince", ctx, eventType, since)}
+}
+
+func (_c *MockLifecycleEventRepository_CountByTypeSince_Call) Run(run func(ctx context.Context, eventType models.LifecycleEventType, since time.Time)) *MockLifecycleEventRepository_CountByTypeSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.LifecycleEventType), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockLifecycleEventRepository_CountByTypeSince_Call) Return(_a0 int64, _a1 error) *MockLifecycleEventRepository_CountByTypeSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLifecycleEventRepository_CountByTypeSince_Call) RunAndReturn(run func(context.Context, models.LifecycleEventType, time.Time) (int64, error)) *MockLifecycleEventRepository_CountByTypeSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockLifecycleEventRepository) Create(_a0 context.Context, _a1 *models.LifecycleEvent) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.LifecycleEvent) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLifecycleEventRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockLifecycleEventRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.LifecycleEvent
+func (_e *MockLifecycleEventRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockLifecycleEventRepository_Create_Call {
+	return &MockLifecycleEventRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockLifecycleEventRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.LifecycleEvent)) *MockLifecycleEventRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.LifecycleEvent))
+	})
+	return _c
+}
+
+func (_c *MockLifecycleEventRepository_Create_Call) Return(_a0 error) *MockLifecycleEventRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLifecycleEventRepository_Create_Call) RunAndReturn(run func(context.Context, *models.LifecycleEvent) error) *MockLifecycleEventRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userID, eventType
+func (_m *MockLifecycleEventRepository) DeleteByUserID(ctx context.Context, userID bson.ObjectID, eventType models.LifecycleEventType) (int64, error) {
+	ret := _m.Called(ctx, userID, eventType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUserID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.LifecycleEventType) (int64, error)); ok {
+		return rf(ctx, userID, eventType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.LifecycleEventType) int64); ok {
+		r0 = rf(ctx, userID, eventType)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, models.LifecycleEventType) error); ok {
+		r1 = rf(ctx, userID, eventType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLifecycleEventRepository_DeleteByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUserID'
+type MockLifecycleEventRepository_DeleteByUserID_Call struct {
+	*mock.Call
+}
+
+// DeleteByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+//   - eventType models.LifecycleEventType
+func (_e *MockLifecycleEventRepository_Expecter) DeleteByUserID(ctx interface{}, userID interface{}, eventType interface{}) *MockLifecycleEventRepository_DeleteByUserID_Call {
+	return &MockLifecycleEventRepository_DeleteByUserID_Call{Call: _e.mock.On("DeleteByUserID", ctx, userID, eventType)}
+}
+
+func (_c *MockLifecycleEventRepository_DeleteByUserID_Call) Run(run func(ctx context.Context, userID bson.ObjectID, eventType models.LifecycleEventType)) *MockLifecycleEventRepository_DeleteByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(models.LifecycleEventType))
+	})
+	return _c
+}
+
+func (_c *MockLifecycleEventRepository_DeleteByUserID_Call) Return(_a0 int64, _a1 error) *MockLifecycleEventRepository_DeleteByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLifecycleEventRepository_DeleteByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID, models.LifecycleEventType) (int64, error)) *MockLifecycleEventRepository_DeleteByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBySubscriptionID provides a mock function with given fields: _a0, _a1
+func (_m *MockLifecycleEventRepository) GetBySubscriptionID(_a0 context.Context, _a1 bson.ObjectID) ([]*models.LifecycleEvent, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySubscriptionID")
+	}
+
+	var r0 []*models.LifecycleEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) ([]*models.LifecycleEvent, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) []*models.LifecycleEvent); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LifecycleEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLifecycleEventRepository_GetBySubscriptionID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySubscriptionID'
+type MockLifecycleEventRepository_GetBySubscriptionID_Call struct {
+	*mock.Call
+}
+
+// GetBySubscriptionID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockLifecycleEventRepository_Expecter) GetBySubscriptionID(_a0 interface{}, _a1 interface{}) *MockLifecycleEventRepository_GetBySubscriptionID_Call {
+	return &MockLifecycleEventRepository_GetBySubscriptionID_Call{Call: _e.mock.On("GetBySubscriptionID", _a0, _a1)}
+}
+
+func (_c *MockLifecycleEventRepository_GetBySubscriptionID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockLifecycleEventRepository_GetBySubscriptionID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockLifecycleEventRepository_GetBySubscriptionID_Call) Return(_a0 []*models.LifecycleEvent, _a1 error) *MockLifecycleEventRepository_GetBySubscriptionID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLifecycleEventRepository_GetBySubscriptionID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) ([]*models.LifecycleEvent, error)) *MockLifecycleEventRepository_GetBySubscriptionID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLifecycleEventRepository creates a new instance of MockLifecycleEventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLifecycleEventRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLifecycleEventRepository {
+	mock := &MockLifecycleEventRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,320 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockNotificationPreferenceRepository is an autogenerated mock type for the NotificationPreferenceRepository type
+type MockNotificationPreferenceRepository struct {
+	mock.Mock
+}
+
+type MockNotificationPreferenceRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationPreferenceRepository) EXPECT() *MockNotificationPreferenceRepository_Expecter {
+	return &MockNotificationPreferenceRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *MockNotificationPreferenceRepository) DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUserID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationPreferenceRepository_DeleteByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUserID'
+type MockNotificationPreferenceRepository_DeleteByUserID_Call struct {
+	*mock.Call
+}
+
+// DeleteByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockNotificationPreferenceRepository_Expecter) DeleteByUserID(ctx interface{}, userID interface{}) *MockNotificationPreferenceRepository_DeleteByUserID_Call {
+	return &MockNotificationPreferenceRepository_DeleteByUserID_Call{Call: _e.mock.On("DeleteByUserID", ctx, userID)}
+}
+
+func (_c *MockNotificationPreferenceRepository_DeleteByUserID_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockNotificationPreferenceRepository_DeleteByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceRepository_DeleteByUserID_Call) Return(_a0 int64, _a1 error) *MockNotificationPreferenceRepository_DeleteByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceRepository_DeleteByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockNotificationPreferenceRepository_DeleteByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DistinctReminderDays provides a mock function with given fields: _a0
+func (_m *MockNotificationPreferenceRepository) DistinctReminderDays(_a0 context.Context) ([]int, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DistinctReminderDays")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]int, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []int); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationPreferenceRepository_DistinctReminderDays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DistinctReminderDays'
+type MockNotificationPreferenceRepository_DistinctReminderDays_Call struct {
+	*mock.Call
+}
+
+// DistinctReminderDays is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockNotificationPreferenceRepository_Expecter) DistinctReminderDays(_a0 interface{}) *MockNotificationPreferenceRepository_DistinctReminderDays_Call {
+	return &MockNotificationPreferenceRepository_DistinctReminderDays_Call{Call: _e.mock.On("DistinctReminderDays", _a0)}
+}
+
+func (_c *MockNotificationPreferenceRepository_DistinctReminderDays_Call) Run(run func(_a0 context.Context)) *MockNotificationPreferenceRepository_DistinctReminderDays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceRepository_DistinctReminderDays_Call) Return(_a0 []int, _a1 error) *MockNotificationPreferenceRepository_DistinctReminderDays_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceRepository_DistinctReminderDays_Call) RunAndReturn(run func(context.Context) ([]int, error)) *MockNotificationPreferenceRepository_DistinctReminderDays_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function with given fields: ctx, userID
+func (_m *MockNotificationPreferenceRepository) GetByUserID(ctx context.Context, userID bson.ObjectID) (*models.NotificationPreferences, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 *models.NotificationPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.NotificationPreferences, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.NotificationPreferences); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationPreferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationPreferenceRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockNotificationPreferenceRepository_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockNotificationPreferenceRepository_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockNotificationPreferenceRepository_GetByUserID_Call {
+	return &MockNotificationPreferenceRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+}
+
+func (_c *MockNotificationPreferenceRepository_GetByUserID_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockNotificationPreferenceRepository_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceRepository_GetByUserID_Call) Return(_a0 *models.NotificationPreferences, _a1 error) *MockNotificationPreferenceRepository_GetByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceRepository_GetByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.NotificationPreferences, error)) *MockNotificationPreferenceRepository_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserIDs provides a mock function with given fields: ctx, userIDs
+func (_m *MockNotificationPreferenceRepository) GetByUserIDs(ctx context.Context, userIDs []bson.ObjectID) ([]*models.NotificationPreferences, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserIDs")
+	}
+
+	var r0 []*models.NotificationPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []bson.ObjectID) ([]*models.NotificationPreferences, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []bson.ObjectID) []*models.NotificationPreferences); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.NotificationPreferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []bson.ObjectID) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationPreferenceRepository_GetByUserIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserIDs'
+type MockNotificationPreferenceRepository_GetByUserIDs_Call struct {
+	*mock.Call
+}
+
+// GetByUserIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []bson.ObjectID
+func (_e *MockNotificationPreferenceRepository_Expecter) GetByUserIDs(ctx interface{}, userIDs interface{}) *MockNotificationPreferenceRepository_GetByUserIDs_Call {
+	return &MockNotificationPreferenceRepository_GetByUserIDs_Call{Call: _e.mock.On("GetByUserIDs", ctx, userIDs)}
+}
+
+func (_c *MockNotificationPreferenceRepository_GetByUserIDs_Call) Run(run func(ctx context.Context, userIDs []bson.ObjectID)) *MockNotificationPreferenceRepository_GetByUserIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceRepository_GetByUserIDs_Call) Return(_a0 []*models.NotificationPreferences, _a1 error) *MockNotificationPreferenceRepository_GetByUserIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceRepository_GetByUserIDs_Call) RunAndReturn(run func(context.Context, []bson.ObjectID) ([]*models.NotificationPreferences, error)) *MockNotificationPreferenceRepository_GetByUserIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: _a0, _a1
+func (_m *MockNotificationPreferenceRepository) Upsert(_a0 context.Context, _a1 *models.NotificationPreferences) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.NotificationPreferences) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotificationPreferenceRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockNotificationPreferenceRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.NotificationPreferences
+func (_e *MockNotificationPreferenceRepository_Expecter) Upsert(_a0 interface{}, _a1 interface{}) *MockNotificationPreferenceRepository_Upsert_Call {
+	return &MockNotificationPreferenceRepository_Upsert_Call{Call: _e.mock.On("Upsert", _a0, _a1)}
+}
+
+func (_c *MockNotificationPreferenceRepository_Upsert_Call) Run(run func(_a0 context.Context, _a1 *models.NotificationPreferences)) *MockNotificationPreferenceRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.NotificationPreferences))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceRepository_Upsert_Call) Return(_a0 error) *MockNotificationPreferenceRepository_Upsert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceRepository_Upsert_Call) RunAndReturn(run func(context.Context, *models.NotificationPreferences) error) *MockNotificationPreferenceRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotificationPreferenceRepository creates a new instance of MockNotificationPreferenceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationPreferenceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationPreferenceRepository {
+	mock := &MockNotificationPreferenceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,211 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockNotificationRepository is an autogenerated mock type for the NotificationRepository type
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+type MockNotificationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationRepository) EXPECT() *MockNotificationRepository_Expecter {
+	return &MockNotificationRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockNotificationRepository) Create(_a0 context.Context, _a1 *models.Notification) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Notification) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotificationRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockNotificationRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Notification
+func (_e *MockNotificationRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockNotificationRepository_Create_Call {
+	return &MockNotificationRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockNotificationRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.Notification)) *MockNotificationRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Notification))
+	})
+	return _c
+}
+
+func (_c *MockNotificationRepository_Create_Call) Return(_a0 error) *MockNotificationRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotificationRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Notification) error) *MockNotificationRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *MockNotificationRepository) DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUserID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationRepository_DeleteByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUserID'
+type MockNotificationRepository_DeleteByUserID_Call struct {
+	*mock.Call
+}
+
+// DeleteByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockNotificationRepository_Expecter) DeleteByUserID(ctx interface{}, userID interface{}) *MockNotificationRepository_DeleteByUserID_Call {
+	return &MockNotificationRepository_DeleteByUserID_Call{Call: _e.mock.On("DeleteByUserID", ctx, userID)}
+}
+
+func (_c *MockNotificationRepository_DeleteByUserID_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockNotificationRepository_DeleteByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockNotificationRepository_DeleteByUserID_Call) Return(_a0 int64, _a1 error) *MockNotificationRepository_DeleteByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationRepository_DeleteByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockNotificationRepository_DeleteByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUserID provides a mock function with given fields: ctx, userID, query
+func (_m *MockNotificationRepository) ListByUserID(ctx context.Context, userID bson.ObjectID, query *models.NotificationQuery) ([]*models.Notification, int64, error) {
+	ret := _m.Called(ctx, userID, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUserID")
+	}
+
+	var r0 []*models.Notification
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, *models.NotificationQuery) ([]*models.Notification, int64, error)); ok {
+		return rf(ctx, userID, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, *models.NotificationQuery) []*models.Notification); ok {
+		r0 = rf(ctx, userID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, *models.NotificationQuery) int64); ok {
+		r1 = rf(ctx, userID, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, bson.ObjectID, *models.NotificationQuery) error); ok {
+		r2 = rf(ctx, userID, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockNotificationRepository_ListByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUserID'
+type MockNotificationRepository_ListByUserID_Call struct {
+	*mock.Call
+}
+
+// ListByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+//   - query *models.NotificationQuery
+func (_e *MockNotificationRepository_Expecter) ListByUserID(ctx interface{}, userID interface{}, query interface{}) *MockNotificationRepository_ListByUserID_Call {
+	return &MockNotificationRepository_ListByUserID_Call{Call: _e.mock.On("ListByUserID", ctx, userID, query)}
+}
+
+func (_c *MockNotificationRepository_ListByUserID_Call) Run(run func(ctx context.Context, userID bson.ObjectID, query *models.NotificationQuery)) *MockNotificationRepository_ListByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(*models.NotificationQuery))
+	})
+	return _c
+}
+
+func (_c *MockNotificationRepository_ListByUserID_Call) Return(_a0 []*models.Notification, _a1 int64, _a2 error) *MockNotificationRepository_ListByUserID_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockNotificationRepository_ListByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID, *models.NotificationQuery) ([]*models.Notification, int64, error)) *MockNotificationRepository_ListByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotificationRepository creates a new instance of MockNotificationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationRepository {
+	mock := &MockNotificationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
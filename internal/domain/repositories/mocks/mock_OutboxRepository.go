@@ -0,0 +1,196 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+
+	time "time"
+)
+
+// MockOutboxRepository is an autogenerated mock type for the OutboxRepository type
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+type MockOutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxRepository) EXPECT() *MockOutboxRepository_Expecter {
+	return &MockOutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockOutboxRepository) Create(_a0 context.Context, _a1 *models.OutboxEvent) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OutboxEvent) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockOutboxRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockOutboxRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.OutboxEvent
+func (_e *MockOutboxRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockOutboxRepository_Create_Call {
+	return &MockOutboxRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockOutboxRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.OutboxEvent)) *MockOutboxRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.OutboxEvent))
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_Create_Call) Return(_a0 error) *MockOutboxRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOutboxRepository_Create_Call) RunAndReturn(run func(context.Context, *models.OutboxEvent) error) *MockOutboxRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnpublished provides a mock function with given fields: ctx, limit
+func (_m *MockOutboxRepository) GetUnpublished(ctx context.Context, limit int64) ([]*models.OutboxEvent, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnpublished")
+	}
+
+	var r0 []*models.OutboxEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*models.OutboxEvent, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*models.OutboxEvent); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OutboxEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOutboxRepository_GetUnpublished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnpublished'
+type MockOutboxRepository_GetUnpublished_Call struct {
+	*mock.Call
+}
+
+// GetUnpublished is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int64
+func (_e *MockOutboxRepository_Expecter) GetUnpublished(ctx interface{}, limit interface{}) *MockOutboxRepository_GetUnpublished_Call {
+	return &MockOutboxRepository_GetUnpublished_Call{Call: _e.mock.On("GetUnpublished", ctx, limit)}
+}
+
+func (_c *MockOutboxRepository_GetUnpublished_Call) Run(run func(ctx context.Context, limit int64)) *MockOutboxRepository_GetUnpublished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_GetUnpublished_Call) Return(_a0 []*models.OutboxEvent, _a1 error) *MockOutboxRepository_GetUnpublished_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOutboxRepository_GetUnpublished_Call) RunAndReturn(run func(context.Context, int64) ([]*models.OutboxEvent, error)) *MockOutboxRepository_GetUnpublished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkPublished provides a mock function with given fields: ctx, id, publishedAt
+func (_m *MockOutboxRepository) MarkPublished(ctx context.Context, id bson.ObjectID, publishedAt time.Time) error {
+	ret := _m.Called(ctx, id, publishedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPublished")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, time.Time) error); ok {
+		r0 = rf(ctx, id, publishedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockOutboxRepository_MarkPublished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkPublished'
+type MockOutboxRepository_MarkPublished_Call struct {
+	*mock.Call
+}
+
+// MarkPublished is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - publishedAt time.Time
+func (_e *MockOutboxRepository_Expecter) MarkPublished(ctx interface{}, id interface{}, publishedAt interface{}) *MockOutboxRepository_MarkPublished_Call {
+	return &MockOutboxRepository_MarkPublished_Call{Call: _e.mock.On("MarkPublished", ctx, id, publishedAt)}
+}
+
+func (_c *MockOutboxRepository_MarkPublished_Call) Run(run func(ctx context.Context, id bson.ObjectID, publishedAt time.Time)) *MockOutboxRepository_MarkPublished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_MarkPublished_Call) Return(_a0 error) *MockOutboxRepository_MarkPublished_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOutboxRepository_MarkPublished_Call) RunAndReturn(run func(context.Context, bson.ObjectID, time.Time) error) *MockOutboxRepository_MarkPublished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOutboxRepository creates a new instance of MockOutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxRepository {
+	mock := &MockOutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,380 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockPaymentMethodRepository is an autogenerated mock type for the PaymentMethodRepository type
+type MockPaymentMethodRepository struct {
+	mock.Mock
+}
+
+type MockPaymentMethodRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPaymentMethodRepository) EXPECT() *MockPaymentMethodRepository_Expecter {
+	return &MockPaymentMethodRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountByUserID provides a mock function with given fields: _a0, _a1
+func (_m *MockPaymentMethodRepository) CountByUserID(_a0 context.Context, _a1 bson.ObjectID) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByUserID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodRepository_CountByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByUserID'
+type MockPaymentMethodRepository_CountByUserID_Call struct {
+	*mock.Call
+}
+
+// CountByUserID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockPaymentMethodRepository_Expecter) CountByUserID(_a0 interface{}, _a1 interface{}) *MockPaymentMethodRepository_CountByUserID_Call {
+	return &MockPaymentMethodRepository_CountByUserID_Call{Call: _e.mock.On("CountByUserID", _a0, _a1)}
+}
+
+func (_c *MockPaymentMethodRepository_CountByUserID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockPaymentMethodRepository_CountByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_CountByUserID_Call) Return(_a0 int64, _a1 error) *MockPaymentMethodRepository_CountByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_CountByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockPaymentMethodRepository_CountByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockPaymentMethodRepository) Create(_a0 context.Context, _a1 *models.PaymentMethod) (*models.PaymentMethod, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PaymentMethod) (*models.PaymentMethod, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PaymentMethod) *models.PaymentMethod); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaymentMethod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PaymentMethod) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockPaymentMethodRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.PaymentMethod
+func (_e *MockPaymentMethodRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockPaymentMethodRepository_Create_Call {
+	return &MockPaymentMethodRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockPaymentMethodRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.PaymentMethod)) *MockPaymentMethodRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PaymentMethod))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_Create_Call) Return(_a0 *models.PaymentMethod, _a1 error) *MockPaymentMethodRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_Create_Call) RunAndReturn(run func(context.Context, *models.PaymentMethod) (*models.PaymentMethod, error)) *MockPaymentMethodRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: _a0, _a1
+func (_m *MockPaymentMethodRepository) Delete(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentMethodRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockPaymentMethodRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockPaymentMethodRepository_Expecter) Delete(_a0 interface{}, _a1 interface{}) *MockPaymentMethodRepository_Delete_Call {
+	return &MockPaymentMethodRepository_Delete_Call{Call: _e.mock.On("Delete", _a0, _a1)}
+}
+
+func (_c *MockPaymentMethodRepository_Delete_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockPaymentMethodRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_Delete_Call) Return(_a0 error) *MockPaymentMethodRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_Delete_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockPaymentMethodRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: _a0, _a1
+func (_m *MockPaymentMethodRepository) GetByID(_a0 context.Context, _a1 bson.ObjectID) (*models.PaymentMethod, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.PaymentMethod, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.PaymentMethod); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaymentMethod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockPaymentMethodRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockPaymentMethodRepository_Expecter) GetByID(_a0 interface{}, _a1 interface{}) *MockPaymentMethodRepository_GetByID_Call {
+	return &MockPaymentMethodRepository_GetByID_Call{Call: _e.mock.On("GetByID", _a0, _a1)}
+}
+
+func (_c *MockPaymentMethodRepository_GetByID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockPaymentMethodRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_GetByID_Call) Return(_a0 *models.PaymentMethod, _a1 error) *MockPaymentMethodRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_GetByID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.PaymentMethod, error)) *MockPaymentMethodRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function with given fields: _a0, _a1
+func (_m *MockPaymentMethodRepository) GetByUserID(_a0 context.Context, _a1 bson.ObjectID) ([]*models.PaymentMethod, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []*models.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) ([]*models.PaymentMethod, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) []*models.PaymentMethod); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PaymentMethod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockPaymentMethodRepository_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockPaymentMethodRepository_Expecter) GetByUserID(_a0 interface{}, _a1 interface{}) *MockPaymentMethodRepository_GetByUserID_Call {
+	return &MockPaymentMethodRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", _a0, _a1)}
+}
+
+func (_c *MockPaymentMethodRepository_GetByUserID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockPaymentMethodRepository_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_GetByUserID_Call) Return(_a0 []*models.PaymentMethod, _a1 error) *MockPaymentMethodRepository_GetByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_GetByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) ([]*models.PaymentMethod, error)) *MockPaymentMethodRepository_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: _a0, _a1
+func (_m *MockPaymentMethodRepository) Update(_a0 context.Context, _a1 *models.PaymentMethod) (*models.PaymentMethod, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *models.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PaymentMethod) (*models.PaymentMethod, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PaymentMethod) *models.PaymentMethod); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaymentMethod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PaymentMethod) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockPaymentMethodRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.PaymentMethod
+func (_e *MockPaymentMethodRepository_Expecter) Update(_a0 interface{}, _a1 interface{}) *MockPaymentMethodRepository_Update_Call {
+	return &MockPaymentMethodRepository_Update_Call{Call: _e.mock.On("Update", _a0, _a1)}
+}
+
+func (_c *MockPaymentMethodRepository_Update_Call) Run(run func(_a0 context.Context, _a1 *models.PaymentMethod)) *MockPaymentMethodRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PaymentMethod))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_Update_Call) Return(_a0 *models.PaymentMethod, _a1 error) *MockPaymentMethodRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodRepository_Update_Call) RunAndReturn(run func(context.Context, *models.PaymentMethod) (*models.PaymentMethod, error)) *MockPaymentMethodRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPaymentMethodRepository creates a new instance of MockPaymentMethodRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPaymentMethodRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPaymentMethodRepository {
+	mock := &MockPaymentMethodRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
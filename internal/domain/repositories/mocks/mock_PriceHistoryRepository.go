@@ -0,0 +1,269 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+
+	time "time"
+)
+
+// MockPriceHistoryRepository is an autogenerated mock type for the PriceHistoryRepository type
+type MockPriceHistoryRepository struct {
+	mock.Mock
+}
+
+type MockPriceHistoryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPriceHistoryRepository) EXPECT() *MockPriceHistoryRepository_Expecter {
+	return &MockPriceHistoryRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockPriceHistoryRepository) Create(_a0 context.Context, _a1 *models.PriceChange) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PriceChange) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPriceHistoryRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockPriceHistoryRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.PriceChange
+func (_e *MockPriceHistoryRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockPriceHistoryRepository_Create_Call {
+	return &MockPriceHistoryRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockPriceHistoryRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.PriceChange)) *MockPriceHistoryRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PriceChange))
+	})
+	return _c
+}
+
+func (_c *MockPriceHistoryRepository_Create_Call) Return(_a0 error) *MockPriceHistoryRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPriceHistoryRepository_Create_Call) RunAndReturn(run func(context.Context, *models.PriceChange) error) *MockPriceHistoryRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBySubscriptionID provides a mock function with given fields: _a0, _a1
+func (_m *MockPriceHistoryRepository) GetBySubscriptionID(_a0 context.Context, _a1 bson.ObjectID) ([]*models.PriceChange, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySubscriptionID")
+	}
+
+	var r0 []*models.PriceChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) ([]*models.PriceChange, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) []*models.PriceChange); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PriceChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPriceHistoryRepository_GetBySubscriptionID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySubscriptionID'
+type MockPriceHistoryRepository_GetBySubscriptionID_Call struct {
+	*mock.Call
+}
+
+// GetBySubscriptionID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockPriceHistoryRepository_Expecter) GetBySubscriptionID(_a0 interface{}, _a1 interface{}) *MockPriceHistoryRepository_GetBySubscriptionID_Call {
+	return &MockPriceHistoryRepository_GetBySubscriptionID_Call{Call: _e.mock.On("GetBySubscriptionID", _a0, _a1)}
+}
+
+func (_c *MockPriceHistoryRepository_GetBySubscriptionID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockPriceHistoryRepository_GetBySubscriptionID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockPriceHistoryRepository_GetBySubscriptionID_Call) Return(_a0 []*models.PriceChange, _a1 error) *MockPriceHistoryRepository_GetBySubscriptionID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPriceHistoryRepository_GetBySubscriptionID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) ([]*models.PriceChange, error)) *MockPriceHistoryRepository_GetBySubscriptionID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserIDBetween provides a mock function with given fields: ctx, userID, from, to
+func (_m *MockPriceHistoryRepository) GetByUserIDBetween(ctx context.Context, userID bson.ObjectID, from time.Time, to time.Time) ([]*models.PriceChange, error) {
+	ret := _m.Called(ctx, userID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserIDBetween")
+	}
+
+	var r0 []*models.PriceChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, time.Time, time.Time) ([]*models.PriceChange, error)); ok {
+		return rf(ctx, userID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, time.Time, time.Time) []*models.PriceChange); ok {
+		r0 = rf(ctx, userID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PriceChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPriceHistoryRepository_GetByUserIDBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserIDBetween'
+type MockPriceHistoryRepository_GetByUserIDBetween_Call struct {
+	*mock.Call
+}
+
+// GetByUserIDBetween is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+//   - from time.Time
+//   - to time.Time
+func (_e *MockPriceHistoryRepository_Expecter) GetByUserIDBetween(ctx interface{}, userID interface{}, from interface{}, to interface{}) *MockPriceHistoryRepository_GetByUserIDBetween_Call {
+	return &MockPriceHistoryRepository_GetByUserIDBetween_Call{Call: _e.mock.On("GetByUserIDBetween", ctx, userID, from, to)}
+}
+
+func (_c *MockPriceHistoryRepository_GetByUserIDBetween_Call) Run(run func(ctx context.Context, userID bson.ObjectID, from time.Time, to time.Time)) *MockPriceHistoryRepository_GetByUserIDBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockPriceHistoryRepository_GetByUserIDBetween_Call) Return(_a0 []*models.PriceChange, _a1 error) *MockPriceHistoryRepository_GetByUserIDBetween_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPriceHistoryRepository_GetByUserIDBetween_Call) RunAndReturn(run func(context.Context, bson.ObjectID, time.Time, time.Time) ([]*models.PriceChange, error)) *MockPriceHistoryRepository_GetByUserIDBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEffectiveAt provides a mock function with given fields: ctx, subscriptionID, at
+func (_m *MockPriceHistoryRepository) GetEffectiveAt(ctx context.Context, subscriptionID bson.ObjectID, at time.Time) (*models.PriceChange, error) {
+	ret := _m.Called(ctx, subscriptionID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEffectiveAt")
+	}
+
+	var r0 *models.PriceChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, time.Time) (*models.PriceChange, error)); ok {
+		return rf(ctx, subscriptionID, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, time.Time) *models.PriceChange); ok {
+		r0 = rf(ctx, subscriptionID, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PriceChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, time.Time) error); ok {
+		r1 = rf(ctx, subscriptionID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPriceHistoryRepository_GetEffectiveAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEffectiveAt'
+type MockPriceHistoryRepository_GetEffectiveAt_Call struct {
+	*mock.Call
+}
+
+// GetEffectiveAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID bson.ObjectID
+//   - at time.Time
+func (_e *MockPriceHistoryRepository_Expecter) GetEffectiveAt(ctx interface{}, subscriptionID interface{}, at interface{}) *MockPriceHistoryRepository_GetEffectiveAt_Call {
+	return &MockPriceHistoryRepository_GetEffectiveAt_Call{Call: _e.mock.On("GetEffectiveAt", ctx, subscriptionID, at)}
+}
+
+func (_c *MockPriceHistoryRepository_GetEffectiveAt_Call) Run(run func(ctx context.Context, subscriptionID bson.ObjectID, at time.Time)) *MockPriceHistoryRepository_GetEffectiveAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockPriceHistoryRepository_GetEffectiveAt_Call) Return(_a0 *models.PriceChange, _a1 error) *MockPriceHistoryRepository_GetEffectiveAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPriceHistoryRepository_GetEffectiveAt_Call) RunAndReturn(run func(context.Context, bson.ObjectID, time.Time) (*models.PriceChange, error)) *MockPriceHistoryRepository_GetEffectiveAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPriceHistoryRepository creates a new instance of MockPriceHistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPriceHistoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPriceHistoryRepository {
+	mock := &MockPriceHistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,301 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockSessionRepository is an autogenerated mock type for the SessionRepository type
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+type MockSessionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessionRepository) EXPECT() *MockSessionRepository_Expecter {
+	return &MockSessionRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockSessionRepository) Create(_a0 context.Context, _a1 *models.Session) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Session) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSessionRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Session
+func (_e *MockSessionRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockSessionRepository_Create_Call {
+	return &MockSessionRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockSessionRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.Session)) *MockSessionRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Session))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_Create_Call) Return(_a0 error) *MockSessionRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Session) error) *MockSessionRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id, userID
+func (_m *MockSessionRepository) Delete(ctx context.Context, id bson.ObjectID, userID bson.ObjectID) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, bson.ObjectID) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSessionRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - userID bson.ObjectID
+func (_e *MockSessionRepository_Expecter) Delete(ctx interface{}, id interface{}, userID interface{}) *MockSessionRepository_Delete_Call {
+	return &MockSessionRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id, userID)}
+}
+
+func (_c *MockSessionRepository_Delete_Call) Run(run func(ctx context.Context, id bson.ObjectID, userID bson.ObjectID)) *MockSessionRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_Delete_Call) Return(_a0 error) *MockSessionRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepository_Delete_Call) RunAndReturn(run func(context.Context, bson.ObjectID, bson.ObjectID) error) *MockSessionRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id, userID
+func (_m *MockSessionRepository) GetByID(ctx context.Context, id bson.ObjectID, userID bson.ObjectID) (*models.Session, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, bson.ObjectID) (*models.Session, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, bson.ObjectID) *models.Session); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, bson.ObjectID) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockSessionRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - userID bson.ObjectID
+func (_e *MockSessionRepository_Expecter) GetByID(ctx interface{}, id interface{}, userID interface{}) *MockSessionRepository_GetByID_Call {
+	return &MockSessionRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id, userID)}
+}
+
+func (_c *MockSessionRepository_GetByID_Call) Run(run func(ctx context.Context, id bson.ObjectID, userID bson.ObjectID)) *MockSessionRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_GetByID_Call) Return(_a0 *models.Session, _a1 error) *MockSessionRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepository_GetByID_Call) RunAndReturn(run func(context.Context, bson.ObjectID, bson.ObjectID) (*models.Session, error)) *MockSessionRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUserID provides a mock function with given fields: ctx, userID
+func (_m *MockSessionRepository) ListByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUserID")
+	}
+
+	var r0 []*models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) ([]*models.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) []*models.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepository_ListByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUserID'
+type MockSessionRepository_ListByUserID_Call struct {
+	*mock.Call
+}
+
+// ListByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockSessionRepository_Expecter) ListByUserID(ctx interface{}, userID interface{}) *MockSessionRepository_ListByUserID_Call {
+	return &MockSessionRepository_ListByUserID_Call{Call: _e.mock.On("ListByUserID", ctx, userID)}
+}
+
+func (_c *MockSessionRepository_ListByUserID_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockSessionRepository_ListByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_ListByUserID_Call) Return(_a0 []*models.Session, _a1 error) *MockSessionRepository_ListByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepository_ListByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) ([]*models.Session, error)) *MockSessionRepository_ListByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: _a0, _a1
+func (_m *MockSessionRepository) Update(_a0 context.Context, _a1 *models.Session) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Session) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockSessionRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Session
+func (_e *MockSessionRepository_Expecter) Update(_a0 interface{}, _a1 interface{}) *MockSessionRepository_Update_Call {
+	return &MockSessionRepository_Update_Call{Call: _e.mock.On("Update", _a0, _a1)}
+}
+
+func (_c *MockSessionRepository_Update_Call) Run(run func(_a0 context.Context, _a1 *models.Session)) *MockSessionRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Session))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_Update_Call) Return(_a0 error) *MockSessionRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepository_Update_Call) RunAndReturn(run func(context.Context, *models.Session) error) *MockSessionRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessionRepository creates a new instance of MockSessionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessionRepository {
+	mock := &MockSessionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
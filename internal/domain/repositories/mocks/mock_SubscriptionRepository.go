@@ -27,6 +27,124 @@ func (_m *MockSubscriptionRepository) EXPECT() *MockSubscriptionRepository_Expec
 	return &MockSubscriptionRepository_Expecter{mock: &_m.Mock}
 }
 
+// AdvanceDunning provides a mock function with given fields: ctx, id, attempts, updatedAt
+func (_m *MockSubscriptionRepository) AdvanceDunning(ctx context.Context, id bson.ObjectID, attempts int, updatedAt time.Time) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, attempts, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdvanceDunning")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, int, time.Time) (*models.Subscription, error)); ok {
+		return rf(ctx, id, attempts, updatedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, int, time.Time) *models.Subscription); ok {
+		r0 = rf(ctx, id, attempts, updatedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, int, time.Time) error); ok {
+		r1 = rf(ctx, id, attempts, updatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_AdvanceDunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdvanceDunning'
+type MockSubscriptionRepository_AdvanceDunning_Call struct {
+	*mock.Call
+}
+
+// AdvanceDunning is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - attempts int
+//   - updatedAt time.Time
+func (_e *MockSubscriptionRepository_Expecter) AdvanceDunning(ctx interface{}, id interface{}, attempts interface{}, updatedAt interface{}) *MockSubscriptionRepository_AdvanceDunning_Call {
+	return &MockSubscriptionRepository_AdvanceDunning_Call{Call: _e.mock.On("AdvanceDunning", ctx, id, attempts, updatedAt)}
+}
+
+func (_c *MockSubscriptionRepository_AdvanceDunning_Call) Run(run func(ctx context.Context, id bson.ObjectID, attempts int, updatedAt time.Time)) *MockSubscriptionRepository_AdvanceDunning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(int), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_AdvanceDunning_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionRepository_AdvanceDunning_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_AdvanceDunning_Call) RunAndReturn(run func(context.Context, bson.ObjectID, int, time.Time) (*models.Subscription, error)) *MockSubscriptionRepository_AdvanceDunning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionRepository) Count(_a0 context.Context, _a1 *models.SubscriptionQuery) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SubscriptionQuery) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SubscriptionQuery) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SubscriptionQuery) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type MockSubscriptionRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.SubscriptionQuery
+func (_e *MockSubscriptionRepository_Expecter) Count(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_Count_Call {
+	return &MockSubscriptionRepository_Count_Call{Call: _e.mock.On("Count", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionRepository_Count_Call) Run(run func(_a0 context.Context, _a1 *models.SubscriptionQuery)) *MockSubscriptionRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SubscriptionQuery))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Count_Call) Return(_a0 int64, _a1 error) *MockSubscriptionRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Count_Call) RunAndReturn(run func(context.Context, *models.SubscriptionQuery) (int64, error)) *MockSubscriptionRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountActiveSubscriptions provides a mock function with given fields: _a0, _a1
 func (_m *MockSubscriptionRepository) CountActiveSubscriptions(_a0 context.Context, _a1 time.Time) (int64, error) {
 	ret := _m.Called(_a0, _a1)
@@ -84,6 +202,64 @@ func (_c *MockSubscriptionRepository_CountActiveSubscriptions_Call) RunAndReturn
 	return _c
 }
 
+// CountByStatus provides a mock function with given fields: _a0
+func (_m *MockSubscriptionRepository) CountByStatus(_a0 context.Context) (map[models.Status]int64, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByStatus")
+	}
+
+	var r0 map[models.Status]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[models.Status]int64, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[models.Status]int64); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[models.Status]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_CountByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByStatus'
+type MockSubscriptionRepository_CountByStatus_Call struct {
+	*mock.Call
+}
+
+// CountByStatus is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockSubscriptionRepository_Expecter) CountByStatus(_a0 interface{}) *MockSubscriptionRepository_CountByStatus_Call {
+	return &MockSubscriptionRepository_CountByStatus_Call{Call: _e.mock.On("CountByStatus", _a0)}
+}
+
+func (_c *MockSubscriptionRepository_CountByStatus_Call) Run(run func(_a0 context.Context)) *MockSubscriptionRepository_CountByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_CountByStatus_Call) Return(_a0 map[models.Status]int64, _a1 error) *MockSubscriptionRepository_CountByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_CountByStatus_Call) RunAndReturn(run func(context.Context) (map[models.Status]int64, error)) *MockSubscriptionRepository_CountByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: _a0, _a1
 func (_m *MockSubscriptionRepository) Create(_a0 context.Context, _a1 *models.Subscription) (*models.Subscription, error) {
 	ret := _m.Called(_a0, _a1)
@@ -151,68 +327,1125 @@ func (_m *MockSubscriptionRepository) Delete(ctx context.Context, id bson.Object
 		panic("no return value specified for Delete")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
-		r0 = rf(ctx, id)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSubscriptionRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+func (_e *MockSubscriptionRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockSubscriptionRepository_Delete_Call {
+	return &MockSubscriptionRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockSubscriptionRepository_Delete_Call) Run(run func(ctx context.Context, id bson.ObjectID)) *MockSubscriptionRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Delete_Call) Return(_a0 error) *MockSubscriptionRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Delete_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockSubscriptionRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Each provides a mock function with given fields: ctx, query, fn
+func (_m *MockSubscriptionRepository) Each(ctx context.Context, query *models.SubscriptionQuery, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, query, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Each")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SubscriptionQuery, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, query, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionRepository_Each_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Each'
+type MockSubscriptionRepository_Each_Call struct {
+	*mock.Call
+}
+
+// Each is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *models.SubscriptionQuery
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionRepository_Expecter) Each(ctx interface{}, query interface{}, fn interface{}) *MockSubscriptionRepository_Each_Call {
+	return &MockSubscriptionRepository_Each_Call{Call: _e.mock.On("Each", ctx, query, fn)}
+}
+
+func (_c *MockSubscriptionRepository_Each_Call) Run(run func(ctx context.Context, query *models.SubscriptionQuery, fn func(*models.Subscription) error)) *MockSubscriptionRepository_Each_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SubscriptionQuery), args[2].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Each_Call) Return(_a0 error) *MockSubscriptionRepository_Each_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Each_Call) RunAndReturn(run func(context.Context, *models.SubscriptionQuery, func(*models.Subscription) error) error) *MockSubscriptionRepository_Each_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachCanceledExpired provides a mock function with given fields: ctx, validBefore, fn
+func (_m *MockSubscriptionRepository) EachCanceledExpired(ctx context.Context, validBefore time.Time, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, validBefore, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachCanceledExpired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, validBefore, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionRepository_EachCanceledExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachCanceledExpired'
+type MockSubscriptionRepository_EachCanceledExpired_Call struct {
+	*mock.Call
+}
+
+// EachCanceledExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - validBefore time.Time
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionRepository_Expecter) EachCanceledExpired(ctx interface{}, validBefore interface{}, fn interface{}) *MockSubscriptionRepository_EachCanceledExpired_Call {
+	return &MockSubscriptionRepository_EachCanceledExpired_Call{Call: _e.mock.On("EachCanceledExpired", ctx, validBefore, fn)}
+}
+
+func (_c *MockSubscriptionRepository_EachCanceledExpired_Call) Run(run func(ctx context.Context, validBefore time.Time, fn func(*models.Subscription) error)) *MockSubscriptionRepository_EachCanceledExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachCanceledExpired_Call) Return(_a0 error) *MockSubscriptionRepository_EachCanceledExpired_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachCanceledExpired_Call) RunAndReturn(run func(context.Context, time.Time, func(*models.Subscription) error) error) *MockSubscriptionRepository_EachCanceledExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachDueForReminder provides a mock function with given fields: ctx, daysBefore, referenceTime, fn
+func (_m *MockSubscriptionRepository) EachDueForReminder(ctx context.Context, daysBefore []int, referenceTime time.Time, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, daysBefore, referenceTime, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachDueForReminder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, time.Time, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, daysBefore, referenceTime, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionRepository_EachDueForReminder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachDueForReminder'
+type MockSubscriptionRepository_EachDueForReminder_Call struct {
+	*mock.Call
+}
+
+// EachDueForReminder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - daysBefore []int
+//   - referenceTime time.Time
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionRepository_Expecter) EachDueForReminder(ctx interface{}, daysBefore interface{}, referenceTime interface{}, fn interface{}) *MockSubscriptionRepository_EachDueForReminder_Call {
+	return &MockSubscriptionRepository_EachDueForReminder_Call{Call: _e.mock.On("EachDueForReminder", ctx, daysBefore, referenceTime, fn)}
+}
+
+func (_c *MockSubscriptionRepository_EachDueForReminder_Call) Run(run func(ctx context.Context, daysBefore []int, referenceTime time.Time, fn func(*models.Subscription) error)) *MockSubscriptionRepository_EachDueForReminder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int), args[2].(time.Time), args[3].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachDueForReminder_Call) Return(_a0 error) *MockSubscriptionRepository_EachDueForReminder_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachDueForReminder_Call) RunAndReturn(run func(context.Context, []int, time.Time, func(*models.Subscription) error) error) *MockSubscriptionRepository_EachDueForReminder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachDueForRenewal provides a mock function with given fields: ctx, startTime, endTime, fn
+func (_m *MockSubscriptionRepository) EachDueForRenewal(ctx context.Context, startTime time.Time, endTime time.Time, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, startTime, endTime, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachDueForRenewal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, startTime, endTime, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionRepository_EachDueForRenewal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachDueForRenewal'
+type MockSubscriptionRepository_EachDueForRenewal_Call struct {
+	*mock.Call
+}
+
+// EachDueForRenewal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - startTime time.Time
+//   - endTime time.Time
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionRepository_Expecter) EachDueForRenewal(ctx interface{}, startTime interface{}, endTime interface{}, fn interface{}) *MockSubscriptionRepository_EachDueForRenewal_Call {
+	return &MockSubscriptionRepository_EachDueForRenewal_Call{Call: _e.mock.On("EachDueForRenewal", ctx, startTime, endTime, fn)}
+}
+
+func (_c *MockSubscriptionRepository_EachDueForRenewal_Call) Run(run func(ctx context.Context, startTime time.Time, endTime time.Time, fn func(*models.Subscription) error)) *MockSubscriptionRepository_EachDueForRenewal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachDueForRenewal_Call) Return(_a0 error) *MockSubscriptionRepository_EachDueForRenewal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachDueForRenewal_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, func(*models.Subscription) error) error) *MockSubscriptionRepository_EachDueForRenewal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachExpiredBefore provides a mock function with given fields: ctx, validBefore, fn
+func (_m *MockSubscriptionRepository) EachExpiredBefore(ctx context.Context, validBefore time.Time, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, validBefore, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachExpiredBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, validBefore, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionRepository_EachExpiredBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachExpiredBefore'
+type MockSubscriptionRepository_EachExpiredBefore_Call struct {
+	*mock.Call
+}
+
+// EachExpiredBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - validBefore time.Time
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionRepository_Expecter) EachExpiredBefore(ctx interface{}, validBefore interface{}, fn interface{}) *MockSubscriptionRepository_EachExpiredBefore_Call {
+	return &MockSubscriptionRepository_EachExpiredBefore_Call{Call: _e.mock.On("EachExpiredBefore", ctx, validBefore, fn)}
+}
+
+func (_c *MockSubscriptionRepository_EachExpiredBefore_Call) Run(run func(ctx context.Context, validBefore time.Time, fn func(*models.Subscription) error)) *MockSubscriptionRepository_EachExpiredBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachExpiredBefore_Call) Return(_a0 error) *MockSubscriptionRepository_EachExpiredBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachExpiredBefore_Call) RunAndReturn(run func(context.Context, time.Time, func(*models.Subscription) error) error) *MockSubscriptionRepository_EachExpiredBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachPastDue provides a mock function with given fields: ctx, fn
+func (_m *MockSubscriptionRepository) EachPastDue(ctx context.Context, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachPastDue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionRepository_EachPastDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachPastDue'
+type MockSubscriptionRepository_EachPastDue_Call struct {
+	*mock.Call
+}
+
+// EachPastDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionRepository_Expecter) EachPastDue(ctx interface{}, fn interface{}) *MockSubscriptionRepository_EachPastDue_Call {
+	return &MockSubscriptionRepository_EachPastDue_Call{Call: _e.mock.On("EachPastDue", ctx, fn)}
+}
+
+func (_c *MockSubscriptionRepository_EachPastDue_Call) Run(run func(ctx context.Context, fn func(*models.Subscription) error)) *MockSubscriptionRepository_EachPastDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachPastDue_Call) Return(_a0 error) *MockSubscriptionRepository_EachPastDue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachPastDue_Call) RunAndReturn(run func(context.Context, func(*models.Subscription) error) error) *MockSubscriptionRepository_EachPastDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachUnusedDueForRenewal provides a mock function with given fields: ctx, renewFrom, renewTo, unusedSince, fn
+func (_m *MockSubscriptionRepository) EachUnusedDueForRenewal(ctx context.Context, renewFrom time.Time, renewTo time.Time, unusedSince time.Time, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, renewFrom, renewTo, unusedSince, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachUnusedDueForRenewal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, time.Time, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, renewFrom, renewTo, unusedSince, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionRepository_EachUnusedDueForRenewal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachUnusedDueForRenewal'
+type MockSubscriptionRepository_EachUnusedDueForRenewal_Call struct {
+	*mock.Call
+}
+
+// EachUnusedDueForRenewal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - renewFrom time.Time
+//   - renewTo time.Time
+//   - unusedSince time.Time
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionRepository_Expecter) EachUnusedDueForRenewal(ctx interface{}, renewFrom interface{}, renewTo interface{}, unusedSince interface{}, fn interface{}) *MockSubscriptionRepository_EachUnusedDueForRenewal_Call {
+	return &MockSubscriptionRepository_EachUnusedDueForRenewal_Call{Call: _e.mock.On("EachUnusedDueForRenewal", ctx, renewFrom, renewTo, unusedSince, fn)}
+}
+
+func (_c *MockSubscriptionRepository_EachUnusedDueForRenewal_Call) Run(run func(ctx context.Context, renewFrom time.Time, renewTo time.Time, unusedSince time.Time, fn func(*models.Subscription) error)) *MockSubscriptionRepository_EachUnusedDueForRenewal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(time.Time), args[4].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachUnusedDueForRenewal_Call) Return(_a0 error) *MockSubscriptionRepository_EachUnusedDueForRenewal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_EachUnusedDueForRenewal_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, time.Time, func(*models.Subscription) error) error) *MockSubscriptionRepository_EachUnusedDueForRenewal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveByUserIDRenewingBefore provides a mock function with given fields: ctx, userID, before
+func (_m *MockSubscriptionRepository) GetActiveByUserIDRenewingBefore(ctx context.Context, userID bson.ObjectID, before time.Time) ([]*models.Subscription, error) {
+	ret := _m.Called(ctx, userID, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveByUserIDRenewingBefore")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, time.Time) ([]*models.Subscription, error)); ok {
+		return rf(ctx, userID, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, time.Time) []*models.Subscription); ok {
+		r0 = rf(ctx, userID, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, time.Time) error); ok {
+		r1 = rf(ctx, userID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_GetActiveByUserIDRenewingBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveByUserIDRenewingBefore'
+type MockSubscriptionRepository_GetActiveByUserIDRenewingBefore_Call struct {
+	*mock.Call
+}
+
+// GetActiveByUserIDRenewingBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+//   - before time.Time
+func (_e *MockSubscriptionRepository_Expecter) GetActiveByUserIDRenewingBefore(ctx interface{}, userID interface{}, before interface{}) *MockSubscriptionRepository_GetActiveByUserIDRenewingBefore_Call {
+	return &MockSubscriptionRepository_GetActiveByUserIDRenewingBefore_Call{Call: _e.mock.On("GetActiveByUserIDRenewingBefore", ctx, userID, before)}
+}
+
+func (_c *MockSubscriptionRepository_GetActiveByUserIDRenewingBefore_Call) Run(run func(ctx context.Context, userID bson.ObjectID, before time.Time)) *MockSubscriptionRepository_GetActiveByUserIDRenewingBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetActiveByUserIDRenewingBefore_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetActiveByUserIDRenewingBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetActiveByUserIDRenewingBefore_Call) RunAndReturn(run func(context.Context, bson.ObjectID, time.Time) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetActiveByUserIDRenewingBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveSubscriptions provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionRepository) GetActiveSubscriptions(_a0 context.Context, _a1 time.Time) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveSubscriptions")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.Subscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.Subscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_GetActiveSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveSubscriptions'
+type MockSubscriptionRepository_GetActiveSubscriptions_Call struct {
+	*mock.Call
+}
+
+// GetActiveSubscriptions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 time.Time
+func (_e *MockSubscriptionRepository_Expecter) GetActiveSubscriptions(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_GetActiveSubscriptions_Call {
+	return &MockSubscriptionRepository_GetActiveSubscriptions_Call{Call: _e.mock.On("GetActiveSubscriptions", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionRepository_GetActiveSubscriptions_Call) Run(run func(_a0 context.Context, _a1 time.Time)) *MockSubscriptionRepository_GetActiveSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetActiveSubscriptions_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetActiveSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetActiveSubscriptions_Call) RunAndReturn(run func(context.Context, time.Time) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetActiveSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: _a0
+func (_m *MockSubscriptionRepository) GetAll(_a0 context.Context) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Subscription, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Subscription); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockSubscriptionRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockSubscriptionRepository_Expecter) GetAll(_a0 interface{}) *MockSubscriptionRepository_GetAll_Call {
+	return &MockSubscriptionRepository_GetAll_Call{Call: _e.mock.On("GetAll", _a0)}
+}
+
+func (_c *MockSubscriptionRepository_GetAll_Call) Run(run func(_a0 context.Context)) *MockSubscriptionRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetAll_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionRepository) GetByID(_a0 context.Context, _a1 bson.ObjectID) (*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.Subscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.Subscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockSubscriptionRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionRepository_Expecter) GetByID(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_GetByID_Call {
+	return &MockSubscriptionRepository_GetByID_Call{Call: _e.mock.On("GetByID", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionRepository_GetByID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetByID_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetByID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.Subscription, error)) *MockSubscriptionRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionRepository) GetByUserID(_a0 context.Context, _a1 bson.ObjectID) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) ([]*models.Subscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) []*models.Subscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockSubscriptionRepository_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionRepository_Expecter) GetByUserID(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_GetByUserID_Call {
+	return &MockSubscriptionRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionRepository_GetByUserID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionRepository_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetByUserID_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCanceledExpiredSubscriptions provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionRepository) GetCanceledExpiredSubscriptions(_a0 context.Context, _a1 time.Time) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCanceledExpiredSubscriptions")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.Subscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.Subscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCanceledExpiredSubscriptions'
+type MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call struct {
+	*mock.Call
+}
+
+// GetCanceledExpiredSubscriptions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 time.Time
+func (_e *MockSubscriptionRepository_Expecter) GetCanceledExpiredSubscriptions(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call {
+	return &MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call{Call: _e.mock.On("GetCanceledExpiredSubscriptions", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call) Run(run func(_a0 context.Context, _a1 time.Time)) *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call) RunAndReturn(run func(context.Context, time.Time) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExpiredBefore provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionRepository) GetExpiredBefore(_a0 context.Context, _a1 time.Time) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiredBefore")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.Subscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.Subscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_GetExpiredBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiredBefore'
+type MockSubscriptionRepository_GetExpiredBefore_Call struct {
+	*mock.Call
+}
+
+// GetExpiredBefore is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 time.Time
+func (_e *MockSubscriptionRepository_Expecter) GetExpiredBefore(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_GetExpiredBefore_Call {
+	return &MockSubscriptionRepository_GetExpiredBefore_Call{Call: _e.mock.On("GetExpiredBefore", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionRepository_GetExpiredBefore_Call) Run(run func(_a0 context.Context, _a1 time.Time)) *MockSubscriptionRepository_GetExpiredBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetExpiredBefore_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetExpiredBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetExpiredBefore_Call) RunAndReturn(run func(context.Context, time.Time) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetExpiredBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPastDue provides a mock function with given fields: _a0
+func (_m *MockSubscriptionRepository) GetPastDue(_a0 context.Context) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPastDue")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Subscription, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Subscription); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_GetPastDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPastDue'
+type MockSubscriptionRepository_GetPastDue_Call struct {
+	*mock.Call
+}
+
+// GetPastDue is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockSubscriptionRepository_Expecter) GetPastDue(_a0 interface{}) *MockSubscriptionRepository_GetPastDue_Call {
+	return &MockSubscriptionRepository_GetPastDue_Call{Call: _e.mock.On("GetPastDue", _a0)}
+}
+
+func (_c *MockSubscriptionRepository_GetPastDue_Call) Run(run func(_a0 context.Context)) *MockSubscriptionRepository_GetPastDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetPastDue_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetPastDue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetPastDue_Call) RunAndReturn(run func(context.Context) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetPastDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionsDueForReminder provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockSubscriptionRepository) GetSubscriptionsDueForReminder(_a0 context.Context, _a1 []int, _a2 time.Time) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionsDueForReminder")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, time.Time) ([]*models.Subscription, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int, time.Time) []*models.Subscription); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int, time.Time) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionsDueForReminder'
+type MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptionsDueForReminder is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []int
+//   - _a2 time.Time
+func (_e *MockSubscriptionRepository_Expecter) GetSubscriptionsDueForReminder(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call {
+	return &MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call{Call: _e.mock.On("GetSubscriptionsDueForReminder", _a0, _a1, _a2)}
+}
+
+func (_c *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call) Run(run func(_a0 context.Context, _a1 []int, _a2 time.Time)) *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call) RunAndReturn(run func(context.Context, []int, time.Time) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionsDueForRenewal provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockSubscriptionRepository) GetSubscriptionsDueForRenewal(_a0 context.Context, _a1 time.Time, _a2 time.Time) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionsDueForRenewal")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]*models.Subscription, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []*models.Subscription); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionsDueForRenewal'
+type MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptionsDueForRenewal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 time.Time
+//   - _a2 time.Time
+func (_e *MockSubscriptionRepository_Expecter) GetSubscriptionsDueForRenewal(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call {
+	return &MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call{Call: _e.mock.On("GetSubscriptionsDueForRenewal", _a0, _a1, _a2)}
+}
+
+func (_c *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call) Run(run func(_a0 context.Context, _a1 time.Time, _a2 time.Time)) *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnusedDueForRenewal provides a mock function with given fields: ctx, renewFrom, renewTo, unusedSince
+func (_m *MockSubscriptionRepository) GetUnusedDueForRenewal(ctx context.Context, renewFrom time.Time, renewTo time.Time, unusedSince time.Time) ([]*models.Subscription, error) {
+	ret := _m.Called(ctx, renewFrom, renewTo, unusedSince)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnusedDueForRenewal")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, time.Time) ([]*models.Subscription, error)); ok {
+		return rf(ctx, renewFrom, renewTo, unusedSince)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, time.Time) []*models.Subscription); ok {
+		r0 = rf(ctx, renewFrom, renewTo, unusedSince)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, renewFrom, renewTo, unusedSince)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_GetUnusedDueForRenewal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnusedDueForRenewal'
+type MockSubscriptionRepository_GetUnusedDueForRenewal_Call struct {
+	*mock.Call
+}
+
+// GetUnusedDueForRenewal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - renewFrom time.Time
+//   - renewTo time.Time
+//   - unusedSince time.Time
+func (_e *MockSubscriptionRepository_Expecter) GetUnusedDueForRenewal(ctx interface{}, renewFrom interface{}, renewTo interface{}, unusedSince interface{}) *MockSubscriptionRepository_GetUnusedDueForRenewal_Call {
+	return &MockSubscriptionRepository_GetUnusedDueForRenewal_Call{Call: _e.mock.On("GetUnusedDueForRenewal", ctx, renewFrom, renewTo, unusedSince)}
+}
+
+func (_c *MockSubscriptionRepository_GetUnusedDueForRenewal_Call) Run(run func(ctx context.Context, renewFrom time.Time, renewTo time.Time, unusedSince time.Time)) *MockSubscriptionRepository_GetUnusedDueForRenewal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetUnusedDueForRenewal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetUnusedDueForRenewal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetUnusedDueForRenewal_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, time.Time) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetUnusedDueForRenewal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionRepository) List(_a0 context.Context, _a1 *models.SubscriptionQuery) ([]*models.Subscription, int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.Subscription
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SubscriptionQuery) ([]*models.Subscription, int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SubscriptionQuery) []*models.Subscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SubscriptionQuery) int64); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.SubscriptionQuery) error); ok {
+		r2 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Error(0)
+		r2 = ret.Error(2)
 	}
 
-	return r0
+	return r0, r1, r2
 }
 
-// MockSubscriptionRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockSubscriptionRepository_Delete_Call struct {
+// MockSubscriptionRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockSubscriptionRepository_List_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
-//   - ctx context.Context
-//   - id bson.ObjectID
-func (_e *MockSubscriptionRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockSubscriptionRepository_Delete_Call {
-	return &MockSubscriptionRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+// List is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.SubscriptionQuery
+func (_e *MockSubscriptionRepository_Expecter) List(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_List_Call {
+	return &MockSubscriptionRepository_List_Call{Call: _e.mock.On("List", _a0, _a1)}
 }
 
-func (_c *MockSubscriptionRepository_Delete_Call) Run(run func(ctx context.Context, id bson.ObjectID)) *MockSubscriptionRepository_Delete_Call {
+func (_c *MockSubscriptionRepository_List_Call) Run(run func(_a0 context.Context, _a1 *models.SubscriptionQuery)) *MockSubscriptionRepository_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bson.ObjectID))
+		run(args[0].(context.Context), args[1].(*models.SubscriptionQuery))
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_Delete_Call) Return(_a0 error) *MockSubscriptionRepository_Delete_Call {
-	_c.Call.Return(_a0)
+func (_c *MockSubscriptionRepository_List_Call) Return(_a0 []*models.Subscription, _a1 int64, _a2 error) *MockSubscriptionRepository_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_Delete_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockSubscriptionRepository_Delete_Call {
+func (_c *MockSubscriptionRepository_List_Call) RunAndReturn(run func(context.Context, *models.SubscriptionQuery) ([]*models.Subscription, int64, error)) *MockSubscriptionRepository_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetActiveSubscriptions provides a mock function with given fields: _a0, _a1
-func (_m *MockSubscriptionRepository) GetActiveSubscriptions(_a0 context.Context, _a1 time.Time) ([]*models.Subscription, error) {
-	ret := _m.Called(_a0, _a1)
+// RecordUsage provides a mock function with given fields: ctx, id, usedAt, updatedAt
+func (_m *MockSubscriptionRepository) RecordUsage(ctx context.Context, id bson.ObjectID, usedAt time.Time, updatedAt time.Time) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, usedAt, updatedAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetActiveSubscriptions")
+		panic("no return value specified for RecordUsage")
 	}
 
-	var r0 []*models.Subscription
+	var r0 *models.Subscription
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.Subscription, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, time.Time, time.Time) (*models.Subscription, error)); ok {
+		return rf(ctx, id, usedAt, updatedAt)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.Subscription); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, time.Time, time.Time) *models.Subscription); ok {
+		r0 = rf(ctx, id, usedAt, updatedAt)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Subscription)
+			r0 = ret.Get(0).(*models.Subscription)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, id, usedAt, updatedAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -220,116 +1453,126 @@ func (_m *MockSubscriptionRepository) GetActiveSubscriptions(_a0 context.Context
 	return r0, r1
 }
 
-// MockSubscriptionRepository_GetActiveSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveSubscriptions'
-type MockSubscriptionRepository_GetActiveSubscriptions_Call struct {
+// MockSubscriptionRepository_RecordUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordUsage'
+type MockSubscriptionRepository_RecordUsage_Call struct {
 	*mock.Call
 }
 
-// GetActiveSubscriptions is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 time.Time
-func (_e *MockSubscriptionRepository_Expecter) GetActiveSubscriptions(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_GetActiveSubscriptions_Call {
-	return &MockSubscriptionRepository_GetActiveSubscriptions_Call{Call: _e.mock.On("GetActiveSubscriptions", _a0, _a1)}
+// RecordUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - usedAt time.Time
+//   - updatedAt time.Time
+func (_e *MockSubscriptionRepository_Expecter) RecordUsage(ctx interface{}, id interface{}, usedAt interface{}, updatedAt interface{}) *MockSubscriptionRepository_RecordUsage_Call {
+	return &MockSubscriptionRepository_RecordUsage_Call{Call: _e.mock.On("RecordUsage", ctx, id, usedAt, updatedAt)}
 }
 
-func (_c *MockSubscriptionRepository_GetActiveSubscriptions_Call) Run(run func(_a0 context.Context, _a1 time.Time)) *MockSubscriptionRepository_GetActiveSubscriptions_Call {
+func (_c *MockSubscriptionRepository_RecordUsage_Call) Run(run func(ctx context.Context, id bson.ObjectID, usedAt time.Time, updatedAt time.Time)) *MockSubscriptionRepository_RecordUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(time.Time))
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(time.Time), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetActiveSubscriptions_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetActiveSubscriptions_Call {
+func (_c *MockSubscriptionRepository_RecordUsage_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionRepository_RecordUsage_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetActiveSubscriptions_Call) RunAndReturn(run func(context.Context, time.Time) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetActiveSubscriptions_Call {
+func (_c *MockSubscriptionRepository_RecordUsage_Call) RunAndReturn(run func(context.Context, bson.ObjectID, time.Time, time.Time) (*models.Subscription, error)) *MockSubscriptionRepository_RecordUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAll provides a mock function with given fields: _a0
-func (_m *MockSubscriptionRepository) GetAll(_a0 context.Context) ([]*models.Subscription, error) {
-	ret := _m.Called(_a0)
+// Search provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionRepository) Search(_a0 context.Context, _a1 *models.SubscriptionSearch) ([]*models.SubscriptionMatch, int64, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for Search")
 	}
 
-	var r0 []*models.Subscription
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Subscription, error)); ok {
-		return rf(_a0)
+	var r0 []*models.SubscriptionMatch
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SubscriptionSearch) ([]*models.SubscriptionMatch, int64, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*models.Subscription); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SubscriptionSearch) []*models.SubscriptionMatch); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Subscription)
+			r0 = ret.Get(0).([]*models.SubscriptionMatch)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SubscriptionSearch) int64); ok {
+		r1 = rf(_a0, _a1)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, *models.SubscriptionSearch) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// MockSubscriptionRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
-type MockSubscriptionRepository_GetAll_Call struct {
+// MockSubscriptionRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockSubscriptionRepository_Search_Call struct {
 	*mock.Call
 }
 
-// GetAll is a helper method to define mock.On call
+// Search is a helper method to define mock.On call
 //   - _a0 context.Context
-func (_e *MockSubscriptionRepository_Expecter) GetAll(_a0 interface{}) *MockSubscriptionRepository_GetAll_Call {
-	return &MockSubscriptionRepository_GetAll_Call{Call: _e.mock.On("GetAll", _a0)}
+//   - _a1 *models.SubscriptionSearch
+func (_e *MockSubscriptionRepository_Expecter) Search(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_Search_Call {
+	return &MockSubscriptionRepository_Search_Call{Call: _e.mock.On("Search", _a0, _a1)}
 }
 
-func (_c *MockSubscriptionRepository_GetAll_Call) Run(run func(_a0 context.Context)) *MockSubscriptionRepository_GetAll_Call {
+func (_c *MockSubscriptionRepository_Search_Call) Run(run func(_a0 context.Context, _a1 *models.SubscriptionSearch)) *MockSubscriptionRepository_Search_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(*models.SubscriptionSearch))
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetAll_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetAll_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockSubscriptionRepository_Search_Call) Return(_a0 []*models.SubscriptionMatch, _a1 int64, _a2 error) *MockSubscriptionRepository_Search_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetAll_Call {
+func (_c *MockSubscriptionRepository_Search_Call) RunAndReturn(run func(context.Context, *models.SubscriptionSearch) ([]*models.SubscriptionMatch, int64, error)) *MockSubscriptionRepository_Search_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function with given fields: _a0, _a1
-func (_m *MockSubscriptionRepository) GetByID(_a0 context.Context, _a1 bson.ObjectID) (*models.Subscription, error) {
-	ret := _m.Called(_a0, _a1)
+// SetPaymentMethod provides a mock function with given fields: ctx, id, paymentMethodID, updatedAt
+func (_m *MockSubscriptionRepository) SetPaymentMethod(ctx context.Context, id bson.ObjectID, paymentMethodID *bson.ObjectID, updatedAt time.Time) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, paymentMethodID, updatedAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for SetPaymentMethod")
 	}
 
 	var r0 *models.Subscription
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.Subscription, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, *bson.ObjectID, time.Time) (*models.Subscription, error)); ok {
+		return rf(ctx, id, paymentMethodID, updatedAt)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.Subscription); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, *bson.ObjectID, time.Time) *models.Subscription); ok {
+		r0 = rf(ctx, id, paymentMethodID, updatedAt)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Subscription)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, *bson.ObjectID, time.Time) error); ok {
+		r1 = rf(ctx, id, paymentMethodID, updatedAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -337,58 +1580,60 @@ func (_m *MockSubscriptionRepository) GetByID(_a0 context.Context, _a1 bson.Obje
 	return r0, r1
 }
 
-// MockSubscriptionRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type MockSubscriptionRepository_GetByID_Call struct {
+// MockSubscriptionRepository_SetPaymentMethod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPaymentMethod'
+type MockSubscriptionRepository_SetPaymentMethod_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 bson.ObjectID
-func (_e *MockSubscriptionRepository_Expecter) GetByID(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_GetByID_Call {
-	return &MockSubscriptionRepository_GetByID_Call{Call: _e.mock.On("GetByID", _a0, _a1)}
+// SetPaymentMethod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - paymentMethodID *bson.ObjectID
+//   - updatedAt time.Time
+func (_e *MockSubscriptionRepository_Expecter) SetPaymentMethod(ctx interface{}, id interface{}, paymentMethodID interface{}, updatedAt interface{}) *MockSubscriptionRepository_SetPaymentMethod_Call {
+	return &MockSubscriptionRepository_SetPaymentMethod_Call{Call: _e.mock.On("SetPaymentMethod", ctx, id, paymentMethodID, updatedAt)}
 }
 
-func (_c *MockSubscriptionRepository_GetByID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionRepository_GetByID_Call {
+func (_c *MockSubscriptionRepository_SetPaymentMethod_Call) Run(run func(ctx context.Context, id bson.ObjectID, paymentMethodID *bson.ObjectID, updatedAt time.Time)) *MockSubscriptionRepository_SetPaymentMethod_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bson.ObjectID))
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(*bson.ObjectID), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetByID_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionRepository_GetByID_Call {
+func (_c *MockSubscriptionRepository_SetPaymentMethod_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionRepository_SetPaymentMethod_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetByID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.Subscription, error)) *MockSubscriptionRepository_GetByID_Call {
+func (_c *MockSubscriptionRepository_SetPaymentMethod_Call) RunAndReturn(run func(context.Context, bson.ObjectID, *bson.ObjectID, time.Time) (*models.Subscription, error)) *MockSubscriptionRepository_SetPaymentMethod_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByUserID provides a mock function with given fields: _a0, _a1
-func (_m *MockSubscriptionRepository) GetByUserID(_a0 context.Context, _a1 bson.ObjectID) ([]*models.Subscription, error) {
-	ret := _m.Called(_a0, _a1)
+// SumActivePrices provides a mock function with given fields: _a0
+func (_m *MockSubscriptionRepository) SumActivePrices(_a0 context.Context) ([]*models.PriceTotal, error) {
+	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByUserID")
+		panic("no return value specified for SumActivePrices")
 	}
 
-	var r0 []*models.Subscription
+	var r0 []*models.PriceTotal
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) ([]*models.Subscription, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.PriceTotal, error)); ok {
+		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) []*models.Subscription); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.PriceTotal); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Subscription)
+			r0 = ret.Get(0).([]*models.PriceTotal)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -396,58 +1641,57 @@ func (_m *MockSubscriptionRepository) GetByUserID(_a0 context.Context, _a1 bson.
 	return r0, r1
 }
 
-// MockSubscriptionRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
-type MockSubscriptionRepository_GetByUserID_Call struct {
+// MockSubscriptionRepository_SumActivePrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumActivePrices'
+type MockSubscriptionRepository_SumActivePrices_Call struct {
 	*mock.Call
 }
 
-// GetByUserID is a helper method to define mock.On call
+// SumActivePrices is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 bson.ObjectID
-func (_e *MockSubscriptionRepository_Expecter) GetByUserID(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_GetByUserID_Call {
-	return &MockSubscriptionRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", _a0, _a1)}
+func (_e *MockSubscriptionRepository_Expecter) SumActivePrices(_a0 interface{}) *MockSubscriptionRepository_SumActivePrices_Call {
+	return &MockSubscriptionRepository_SumActivePrices_Call{Call: _e.mock.On("SumActivePrices", _a0)}
 }
 
-func (_c *MockSubscriptionRepository_GetByUserID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionRepository_GetByUserID_Call {
+func (_c *MockSubscriptionRepository_SumActivePrices_Call) Run(run func(_a0 context.Context)) *MockSubscriptionRepository_SumActivePrices_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bson.ObjectID))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetByUserID_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetByUserID_Call {
+func (_c *MockSubscriptionRepository_SumActivePrices_Call) Return(_a0 []*models.PriceTotal, _a1 error) *MockSubscriptionRepository_SumActivePrices_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetByUserID_Call {
+func (_c *MockSubscriptionRepository_SumActivePrices_Call) RunAndReturn(run func(context.Context) ([]*models.PriceTotal, error)) *MockSubscriptionRepository_SumActivePrices_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCanceledExpiredSubscriptions provides a mock function with given fields: _a0, _a1
-func (_m *MockSubscriptionRepository) GetCanceledExpiredSubscriptions(_a0 context.Context, _a1 time.Time) ([]*models.Subscription, error) {
-	ret := _m.Called(_a0, _a1)
+// Summarize provides a mock function with given fields: ctx, userID, renewFrom, renewTo
+func (_m *MockSubscriptionRepository) Summarize(ctx context.Context, userID bson.ObjectID, renewFrom time.Time, renewTo time.Time) (*models.SubscriptionSummary, error) {
+	ret := _m.Called(ctx, userID, renewFrom, renewTo)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCanceledExpiredSubscriptions")
+		panic("no return value specified for Summarize")
 	}
 
-	var r0 []*models.Subscription
+	var r0 *models.SubscriptionSummary
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.Subscription, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, time.Time, time.Time) (*models.SubscriptionSummary, error)); ok {
+		return rf(ctx, userID, renewFrom, renewTo)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.Subscription); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, time.Time, time.Time) *models.SubscriptionSummary); ok {
+		r0 = rf(ctx, userID, renewFrom, renewTo)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Subscription)
+			r0 = ret.Get(0).(*models.SubscriptionSummary)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, renewFrom, renewTo)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -455,58 +1699,67 @@ func (_m *MockSubscriptionRepository) GetCanceledExpiredSubscriptions(_a0 contex
 	return r0, r1
 }
 
-// MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCanceledExpiredSubscriptions'
-type MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call struct {
+// MockSubscriptionRepository_Summarize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Summarize'
+type MockSubscriptionRepository_Summarize_Call struct {
 	*mock.Call
 }
 
-// GetCanceledExpiredSubscriptions is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 time.Time
-func (_e *MockSubscriptionRepository_Expecter) GetCanceledExpiredSubscriptions(_a0 interface{}, _a1 interface{}) *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call {
-	return &MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call{Call: _e.mock.On("GetCanceledExpiredSubscriptions", _a0, _a1)}
+// Summarize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+//   - renewFrom time.Time
+//   - renewTo time.Time
+func (_e *MockSubscriptionRepository_Expecter) Summarize(ctx interface{}, userID interface{}, renewFrom interface{}, renewTo interface{}) *MockSubscriptionRepository_Summarize_Call {
+	return &MockSubscriptionRepository_Summarize_Call{Call: _e.mock.On("Summarize", ctx, userID, renewFrom, renewTo)}
 }
 
-func (_c *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call) Run(run func(_a0 context.Context, _a1 time.Time)) *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call {
+func (_c *MockSubscriptionRepository_Summarize_Call) Run(run func(ctx context.Context, userID bson.ObjectID, renewFrom time.Time, renewTo time.Time)) *MockSubscriptionRepository_Summarize_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(time.Time))
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(time.Time), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call {
+func (_c *MockSubscriptionRepository_Summarize_Call) Return(_a0 *models.SubscriptionSummary, _a1 error) *MockSubscriptionRepository_Summarize_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call) RunAndReturn(run func(context.Context, time.Time) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetCanceledExpiredSubscriptions_Call {
+func (_c *MockSubscriptionRepository_Summarize_Call) RunAndReturn(run func(context.Context, bson.ObjectID, time.Time, time.Time) (*models.SubscriptionSummary, error)) *MockSubscriptionRepository_Summarize_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSubscriptionsDueForReminder provides a mock function with given fields: _a0, _a1, _a2
-func (_m *MockSubscriptionRepository) GetSubscriptionsDueForReminder(_a0 context.Context, _a1 []int, _a2 time.Time) ([]*models.Subscription, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// TransitionStatus provides a mock function with given fields: ctx, id, from, to, updatedAt, set
+func (_m *MockSubscriptionRepository) TransitionStatus(ctx context.Context, id bson.ObjectID, from models.Status, to models.Status, updatedAt time.Time, set ...bson.E) (*models.Subscription, error) {
+	_va := make([]interface{}, len(set))
+	for _i := range set {
+		_va[_i] = set[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id, from, to, updatedAt)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSubscriptionsDueForReminder")
+		panic("no return value specified for TransitionStatus")
 	}
 
-	var r0 []*models.Subscription
+	var r0 *models.Subscription
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []int, time.Time) ([]*models.Subscription, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.Status, models.Status, time.Time, ...bson.E) (*models.Subscription, error)); ok {
+		return rf(ctx, id, from, to, updatedAt, set...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []int, time.Time) []*models.Subscription); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.Status, models.Status, time.Time, ...bson.E) *models.Subscription); ok {
+		r0 = rf(ctx, id, from, to, updatedAt, set...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Subscription)
+			r0 = ret.Get(0).(*models.Subscription)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []int, time.Time) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, models.Status, models.Status, time.Time, ...bson.E) error); ok {
+		r1 = rf(ctx, id, from, to, updatedAt, set...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -514,59 +1767,67 @@ func (_m *MockSubscriptionRepository) GetSubscriptionsDueForReminder(_a0 context
 	return r0, r1
 }
 
-// MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionsDueForReminder'
-type MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call struct {
+// MockSubscriptionRepository_TransitionStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransitionStatus'
+type MockSubscriptionRepository_TransitionStatus_Call struct {
 	*mock.Call
 }
 
-// GetSubscriptionsDueForReminder is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 []int
-//   - _a2 time.Time
-func (_e *MockSubscriptionRepository_Expecter) GetSubscriptionsDueForReminder(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call {
-	return &MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call{Call: _e.mock.On("GetSubscriptionsDueForReminder", _a0, _a1, _a2)}
+// TransitionStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - from models.Status
+//   - to models.Status
+//   - updatedAt time.Time
+//   - set ...bson.E
+func (_e *MockSubscriptionRepository_Expecter) TransitionStatus(ctx interface{}, id interface{}, from interface{}, to interface{}, updatedAt interface{}, set ...interface{}) *MockSubscriptionRepository_TransitionStatus_Call {
+	return &MockSubscriptionRepository_TransitionStatus_Call{Call: _e.mock.On("TransitionStatus",
+		append([]interface{}{ctx, id, from, to, updatedAt}, set...)...)}
 }
 
-func (_c *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call) Run(run func(_a0 context.Context, _a1 []int, _a2 time.Time)) *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call {
+func (_c *MockSubscriptionRepository_TransitionStatus_Call) Run(run func(ctx context.Context, id bson.ObjectID, from models.Status, to models.Status, updatedAt time.Time, set ...bson.E)) *MockSubscriptionRepository_TransitionStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]int), args[2].(time.Time))
+		variadicArgs := make([]bson.E, len(args)-5)
+		for i, a := range args[5:] {
+			if a != nil {
+				variadicArgs[i] = a.(bson.E)
+			}
+		}
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(models.Status), args[3].(models.Status), args[4].(time.Time), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call {
+func (_c *MockSubscriptionRepository_TransitionStatus_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionRepository_TransitionStatus_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call) RunAndReturn(run func(context.Context, []int, time.Time) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetSubscriptionsDueForReminder_Call {
+func (_c *MockSubscriptionRepository_TransitionStatus_Call) RunAndReturn(run func(context.Context, bson.ObjectID, models.Status, models.Status, time.Time, ...bson.E) (*models.Subscription, error)) *MockSubscriptionRepository_TransitionStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSubscriptionsDueForRenewal provides a mock function with given fields: _a0, _a1, _a2
-func (_m *MockSubscriptionRepository) GetSubscriptionsDueForRenewal(_a0 context.Context, _a1 time.Time, _a2 time.Time) ([]*models.Subscription, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// UnlinkPaymentMethod provides a mock function with given fields: ctx, userID, paymentMethodID, updatedAt
+func (_m *MockSubscriptionRepository) UnlinkPaymentMethod(ctx context.Context, userID bson.ObjectID, paymentMethodID bson.ObjectID, updatedAt time.Time) (int64, error) {
+	ret := _m.Called(ctx, userID, paymentMethodID, updatedAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSubscriptionsDueForRenewal")
+		panic("no return value specified for UnlinkPaymentMethod")
 	}
 
-	var r0 []*models.Subscription
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]*models.Subscription, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, bson.ObjectID, time.Time) (int64, error)); ok {
+		return rf(ctx, userID, paymentMethodID, updatedAt)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []*models.Subscription); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, bson.ObjectID, time.Time) int64); ok {
+		r0 = rf(ctx, userID, paymentMethodID, updatedAt)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Subscription)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, bson.ObjectID, time.Time) error); ok {
+		r1 = rf(ctx, userID, paymentMethodID, updatedAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -574,32 +1835,33 @@ func (_m *MockSubscriptionRepository) GetSubscriptionsDueForRenewal(_a0 context.
 	return r0, r1
 }
 
-// MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionsDueForRenewal'
-type MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call struct {
+// MockSubscriptionRepository_UnlinkPaymentMethod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlinkPaymentMethod'
+type MockSubscriptionRepository_UnlinkPaymentMethod_Call struct {
 	*mock.Call
 }
 
-// GetSubscriptionsDueForRenewal is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 time.Time
-//   - _a2 time.Time
-func (_e *MockSubscriptionRepository_Expecter) GetSubscriptionsDueForRenewal(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call {
-	return &MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call{Call: _e.mock.On("GetSubscriptionsDueForRenewal", _a0, _a1, _a2)}
+// UnlinkPaymentMethod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+//   - paymentMethodID bson.ObjectID
+//   - updatedAt time.Time
+func (_e *MockSubscriptionRepository_Expecter) UnlinkPaymentMethod(ctx interface{}, userID interface{}, paymentMethodID interface{}, updatedAt interface{}) *MockSubscriptionRepository_UnlinkPaymentMethod_Call {
+	return &MockSubscriptionRepository_UnlinkPaymentMethod_Call{Call: _e.mock.On("UnlinkPaymentMethod", ctx, userID, paymentMethodID, updatedAt)}
 }
 
-func (_c *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call) Run(run func(_a0 context.Context, _a1 time.Time, _a2 time.Time)) *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call {
+func (_c *MockSubscriptionRepository_UnlinkPaymentMethod_Call) Run(run func(ctx context.Context, userID bson.ObjectID, paymentMethodID bson.ObjectID, updatedAt time.Time)) *MockSubscriptionRepository_UnlinkPaymentMethod_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(bson.ObjectID), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call {
+func (_c *MockSubscriptionRepository_UnlinkPaymentMethod_Call) Return(_a0 int64, _a1 error) *MockSubscriptionRepository_UnlinkPaymentMethod_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) ([]*models.Subscription, error)) *MockSubscriptionRepository_GetSubscriptionsDueForRenewal_Call {
+func (_c *MockSubscriptionRepository_UnlinkPaymentMethod_Call) RunAndReturn(run func(context.Context, bson.ObjectID, bson.ObjectID, time.Time) (int64, error)) *MockSubscriptionRepository_UnlinkPaymentMethod_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -663,6 +1925,80 @@ func (_c *MockSubscriptionRepository_Update_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// UpdatePartial provides a mock function with given fields: ctx, subscription, fields
+func (_m *MockSubscriptionRepository) UpdatePartial(ctx context.Context, subscription *models.Subscription, fields ...string) (*models.Subscription, error) {
+	_va := make([]interface{}, len(fields))
+	for _i := range fields {
+		_va[_i] = fields[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, subscription)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePartial")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Subscription, ...string) (*models.Subscription, error)); ok {
+		return rf(ctx, subscription, fields...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Subscription, ...string) *models.Subscription); ok {
+		r0 = rf(ctx, subscription, fields...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Subscription, ...string) error); ok {
+		r1 = rf(ctx, subscription, fields...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_UpdatePartial_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePartial'
+type MockSubscriptionRepository_UpdatePartial_Call struct {
+	*mock.Call
+}
+
+// UpdatePartial is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription *models.Subscription
+//   - fields ...string
+func (_e *MockSubscriptionRepository_Expecter) UpdatePartial(ctx interface{}, subscription interface{}, fields ...interface{}) *MockSubscriptionRepository_UpdatePartial_Call {
+	return &MockSubscriptionRepository_UpdatePartial_Call{Call: _e.mock.On("UpdatePartial",
+		append([]interface{}{ctx, subscription}, fields...)...)}
+}
+
+func (_c *MockSubscriptionRepository_UpdatePartial_Call) Run(run func(ctx context.Context, subscription *models.Subscription, fields ...string)) *MockSubscriptionRepository_UpdatePartial_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*models.Subscription), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_UpdatePartial_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionRepository_UpdatePartial_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_UpdatePartial_Call) RunAndReturn(run func(context.Context, *models.Subscription, ...string) (*models.Subscription, error)) *MockSubscriptionRepository_UpdatePartial_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockSubscriptionRepository creates a new instance of MockSubscriptionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockSubscriptionRepository(t interface {
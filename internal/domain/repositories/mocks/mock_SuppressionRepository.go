@@ -0,0 +1,254 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSuppressionRepository is an autogenerated mock type for the SuppressionRepository type
+type MockSuppressionRepository struct {
+	mock.Mock
+}
+
+type MockSuppressionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSuppressionRepository) EXPECT() *MockSuppressionRepository_Expecter {
+	return &MockSuppressionRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, email
+func (_m *MockSuppressionRepository) Delete(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSuppressionRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSuppressionRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockSuppressionRepository_Expecter) Delete(ctx interface{}, email interface{}) *MockSuppressionRepository_Delete_Call {
+	return &MockSuppressionRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, email)}
+}
+
+func (_c *MockSuppressionRepository_Delete_Call) Run(run func(ctx context.Context, email string)) *MockSuppressionRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSuppressionRepository_Delete_Call) Return(_a0 error) *MockSuppressionRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSuppressionRepository_Delete_Call) RunAndReturn(run func(context.Context, string) error) *MockSuppressionRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function with given fields: ctx, email
+func (_m *MockSuppressionRepository) Exists(ctx context.Context, email string) (bool, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSuppressionRepository_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type MockSuppressionRepository_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockSuppressionRepository_Expecter) Exists(ctx interface{}, email interface{}) *MockSuppressionRepository_Exists_Call {
+	return &MockSuppressionRepository_Exists_Call{Call: _e.mock.On("Exists", ctx, email)}
+}
+
+func (_c *MockSuppressionRepository_Exists_Call) Run(run func(ctx context.Context, email string)) *MockSuppressionRepository_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSuppressionRepository_Exists_Call) Return(_a0 bool, _a1 error) *MockSuppressionRepository_Exists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSuppressionRepository_Exists_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockSuppressionRepository_Exists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, query
+func (_m *MockSuppressionRepository) List(ctx context.Context, query *models.SuppressionQuery) ([]*models.Suppression, int64, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.Suppression
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SuppressionQuery) ([]*models.Suppression, int64, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SuppressionQuery) []*models.Suppression); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Suppression)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SuppressionQuery) int64); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.SuppressionQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSuppressionRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockSuppressionRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *models.SuppressionQuery
+func (_e *MockSuppressionRepository_Expecter) List(ctx interface{}, query interface{}) *MockSuppressionRepository_List_Call {
+	return &MockSuppressionRepository_List_Call{Call: _e.mock.On("List", ctx, query)}
+}
+
+func (_c *MockSuppressionRepository_List_Call) Run(run func(ctx context.Context, query *models.SuppressionQuery)) *MockSuppressionRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SuppressionQuery))
+	})
+	return _c
+}
+
+func (_c *MockSuppressionRepository_List_Call) Return(_a0 []*models.Suppression, _a1 int64, _a2 error) *MockSuppressionRepository_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSuppressionRepository_List_Call) RunAndReturn(run func(context.Context, *models.SuppressionQuery) ([]*models.Suppression, int64, error)) *MockSuppressionRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: _a0, _a1
+func (_m *MockSuppressionRepository) Upsert(_a0 context.Context, _a1 *models.Suppression) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Suppression) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSuppressionRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockSuppressionRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Suppression
+func (_e *MockSuppressionRepository_Expecter) Upsert(_a0 interface{}, _a1 interface{}) *MockSuppressionRepository_Upsert_Call {
+	return &MockSuppressionRepository_Upsert_Call{Call: _e.mock.On("Upsert", _a0, _a1)}
+}
+
+func (_c *MockSuppressionRepository_Upsert_Call) Run(run func(_a0 context.Context, _a1 *models.Suppression)) *MockSuppressionRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Suppression))
+	})
+	return _c
+}
+
+func (_c *MockSuppressionRepository_Upsert_Call) Return(_a0 error) *MockSuppressionRepository_Upsert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSuppressionRepository_Upsert_Call) RunAndReturn(run func(context.Context, *models.Suppression) error) *MockSuppressionRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSuppressionRepository creates a new instance of MockSuppressionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSuppressionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSuppressionRepository {
+	mock := &MockSuppressionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,205 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockWebhookDeliveryRepository is an autogenerated mock type for the WebhookDeliveryRepository type
+type MockWebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+type MockWebhookDeliveryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookDeliveryRepository) EXPECT() *MockWebhookDeliveryRepository_Expecter {
+	return &MockWebhookDeliveryRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockWebhookDeliveryRepository) Create(_a0 context.Context, _a1 *models.WebhookDelivery) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookDeliveryRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWebhookDeliveryRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.WebhookDelivery
+func (_e *MockWebhookDeliveryRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockWebhookDeliveryRepository_Create_Call {
+	return &MockWebhookDeliveryRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockWebhookDeliveryRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.WebhookDelivery)) *MockWebhookDeliveryRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *MockWebhookDeliveryRepository_Create_Call) Return(_a0 error) *MockWebhookDeliveryRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookDeliveryRepository_Create_Call) RunAndReturn(run func(context.Context, *models.WebhookDelivery) error) *MockWebhookDeliveryRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByWebhookIDs provides a mock function with given fields: ctx, webhookIDs
+func (_m *MockWebhookDeliveryRepository) DeleteByWebhookIDs(ctx context.Context, webhookIDs []bson.ObjectID) (int64, error) {
+	ret := _m.Called(ctx, webhookIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByWebhookIDs")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []bson.ObjectID) (int64, error)); ok {
+		return rf(ctx, webhookIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []bson.ObjectID) int64); ok {
+		r0 = rf(ctx, webhookIDs)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []bson.ObjectID) error); ok {
+		r1 = rf(ctx, webhookIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookDeliveryRepository_DeleteByWebhookIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByWebhookIDs'
+type MockWebhookDeliveryRepository_DeleteByWebhookIDs_Call struct {
+	*mock.Call
+}
+
+// DeleteByWebhookIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookIDs []bson.ObjectID
+func (_e *MockWebhookDeliveryRepository_Expecter) DeleteByWebhookIDs(ctx interface{}, webhookIDs interface{}) *MockWebhookDeliveryRepository_DeleteByWebhookIDs_Call {
+	return &MockWebhookDeliveryRepository_DeleteByWebhookIDs_Call{Call: _e.mock.On("DeleteByWebhookIDs", ctx, webhookIDs)}
+}
+
+func (_c *MockWebhookDeliveryRepository_DeleteByWebhookIDs_Call) Run(run func(ctx context.Context, webhookIDs []bson.ObjectID)) *MockWebhookDeliveryRepository_DeleteByWebhookIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookDeliveryRepository_DeleteByWebhookIDs_Call) Return(_a0 int64, _a1 error) *MockWebhookDeliveryRepository_DeleteByWebhookIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookDeliveryRepository_DeleteByWebhookIDs_Call) RunAndReturn(run func(context.Context, []bson.ObjectID) (int64, error)) *MockWebhookDeliveryRepository_DeleteByWebhookIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByWebhookID provides a mock function with given fields: ctx, webhookID, eventType, limit
+func (_m *MockWebhookDeliveryRepository) GetByWebhookID(ctx context.Context, webhookID bson.ObjectID, eventType models.EventType, limit int64) ([]*models.WebhookDelivery, error) {
+	ret := _m.Called(ctx, webhookID, eventType, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByWebhookID")
+	}
+
+	var r0 []*models.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.EventType, int64) ([]*models.WebhookDelivery, error)); ok {
+		return rf(ctx, webhookID, eventType, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.EventType, int64) []*models.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookID, eventType, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, models.EventType, int64) error); ok {
+		r1 = rf(ctx, webhookID, eventType, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookDeliveryRepository_GetByWebhookID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByWebhookID'
+type MockWebhookDeliveryRepository_GetByWebhookID_Call struct {
+	*mock.Call
+}
+
+// GetByWebhookID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID bson.ObjectID
+//   - eventType models.EventType
+//   - limit int64
+func (_e *MockWebhookDeliveryRepository_Expecter) GetByWebhookID(ctx interface{}, webhookID interface{}, eventType interface{}, limit interface{}) *MockWebhookDeliveryRepository_GetByWebhookID_Call {
+	return &MockWebhookDeliveryRepository_GetByWebhookID_Call{Call: _e.mock.On("GetByWebhookID", ctx, webhookID, eventType, limit)}
+}
+
+func (_c *MockWebhookDeliveryRepository_GetByWebhookID_Call) Run(run func(ctx context.Context, webhookID bson.ObjectID, eventType models.EventType, limit int64)) *MockWebhookDeliveryRepository_GetByWebhookID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(models.EventType), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockWebhookDeliveryRepository_GetByWebhookID_Call) Return(_a0 []*models.WebhookDelivery, _a1 error) *MockWebhookDeliveryRepository_GetByWebhookID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookDeliveryRepository_GetByWebhookID_Call) RunAndReturn(run func(context.Context, bson.ObjectID, models.EventType, int64) ([]*models.WebhookDelivery, error)) *MockWebhookDeliveryRepository_GetByWebhookID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookDeliveryRepository creates a new instance of MockWebhookDeliveryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookDeliveryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookDeliveryRepository {
+	mock := &MockWebhookDeliveryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,438 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockWebhookRepository is an autogenerated mock type for the WebhookRepository type
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+type MockWebhookRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookRepository) EXPECT() *MockWebhookRepository_Expecter {
+	return &MockWebhookRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountByUserID provides a mock function with given fields: _a0, _a1
+func (_m *MockWebhookRepository) CountByUserID(_a0 context.Context, _a1 bson.ObjectID) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByUserID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_CountByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByUserID'
+type MockWebhookRepository_CountByUserID_Call struct {
+	*mock.Call
+}
+
+// CountByUserID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockWebhookRepository_Expecter) CountByUserID(_a0 interface{}, _a1 interface{}) *MockWebhookRepository_CountByUserID_Call {
+	return &MockWebhookRepository_CountByUserID_Call{Call: _e.mock.On("CountByUserID", _a0, _a1)}
+}
+
+func (_c *MockWebhookRepository_CountByUserID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockWebhookRepository_CountByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_CountByUserID_Call) Return(_a0 int64, _a1 error) *MockWebhookRepository_CountByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_CountByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockWebhookRepository_CountByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *MockWebhookRepository) Create(_a0 context.Context, _a1 *models.Webhook) (*models.Webhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook) (*models.Webhook, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook) *models.Webhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Webhook) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWebhookRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Webhook
+func (_e *MockWebhookRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *MockWebhookRepository_Create_Call {
+	return &MockWebhookRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *MockWebhookRepository_Create_Call) Run(run func(_a0 context.Context, _a1 *models.Webhook)) *MockWebhookRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Webhook))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_Create_Call) Return(_a0 *models.Webhook, _a1 error) *MockWebhookRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Webhook) (*models.Webhook, error)) *MockWebhookRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: _a0, _a1
+func (_m *MockWebhookRepository) Delete(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockWebhookRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockWebhookRepository_Expecter) Delete(_a0 interface{}, _a1 interface{}) *MockWebhookRepository_Delete_Call {
+	return &MockWebhookRepository_Delete_Call{Call: _e.mock.On("Delete", _a0, _a1)}
+}
+
+func (_c *MockWebhookRepository_Delete_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockWebhookRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_Delete_Call) Return(_a0 error) *MockWebhookRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_Delete_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockWebhookRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *MockWebhookRepository) DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUserID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_DeleteByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUserID'
+type MockWebhookRepository_DeleteByUserID_Call struct {
+	*mock.Call
+}
+
+// DeleteByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockWebhookRepository_Expecter) DeleteByUserID(ctx interface{}, userID interface{}) *MockWebhookRepository_DeleteByUserID_Call {
+	return &MockWebhookRepository_DeleteByUserID_Call{Call: _e.mock.On("DeleteByUserID", ctx, userID)}
+}
+
+func (_c *MockWebhookRepository_DeleteByUserID_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockWebhookRepository_DeleteByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteByUserID_Call) Return(_a0 int64, _a1 error) *MockWebhookRepository_DeleteByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockWebhookRepository_DeleteByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: _a0, _a1
+func (_m *MockWebhookRepository) GetByID(_a0 context.Context, _a1 bson.ObjectID) (*models.Webhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.Webhook, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.Webhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockWebhookRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockWebhookRepository_Expecter) GetByID(_a0 interface{}, _a1 interface{}) *MockWebhookRepository_GetByID_Call {
+	return &MockWebhookRepository_GetByID_Call{Call: _e.mock.On("GetByID", _a0, _a1)}
+}
+
+func (_c *MockWebhookRepository_GetByID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockWebhookRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetByID_Call) Return(_a0 *models.Webhook, _a1 error) *MockWebhookRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetByID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.Webhook, error)) *MockWebhookRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function with given fields: _a0, _a1
+func (_m *MockWebhookRepository) GetByUserID(_a0 context.Context, _a1 bson.ObjectID) ([]*models.Webhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []*models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) ([]*models.Webhook, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) []*models.Webhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockWebhookRepository_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockWebhookRepository_Expecter) GetByUserID(_a0 interface{}, _a1 interface{}) *MockWebhookRepository_GetByUserID_Call {
+	return &MockWebhookRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", _a0, _a1)}
+}
+
+func (_c *MockWebhookRepository_GetByUserID_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockWebhookRepository_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetByUserID_Call) Return(_a0 []*models.Webhook, _a1 error) *MockWebhookRepository_GetByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetByUserID_Call) RunAndReturn(run func(context.Context, bson.ObjectID) ([]*models.Webhook, error)) *MockWebhookRepository_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserIDAndEvent provides a mock function with given fields: ctx, userID, eventType
+func (_m *MockWebhookRepository) GetByUserIDAndEvent(ctx context.Context, userID bson.ObjectID, eventType models.EventType) ([]*models.Webhook, error) {
+	ret := _m.Called(ctx, userID, eventType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserIDAndEvent")
+	}
+
+	var r0 []*models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.EventType) ([]*models.Webhook, error)); ok {
+		return rf(ctx, userID, eventType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.EventType) []*models.Webhook); ok {
+		r0 = rf(ctx, userID, eventType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, models.EventType) error); ok {
+		r1 = rf(ctx, userID, eventType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_GetByUserIDAndEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserIDAndEvent'
+type MockWebhookRepository_GetByUserIDAndEvent_Call struct {
+	*mock.Call
+}
+
+// GetByUserIDAndEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+//   - eventType models.EventType
+func (_e *MockWebhookRepository_Expecter) GetByUserIDAndEvent(ctx interface{}, userID interface{}, eventType interface{}) *MockWebhookRepository_GetByUserIDAndEvent_Call {
+	return &MockWebhookRepository_GetByUserIDAndEvent_Call{Call: _e.mock.On("GetByUserIDAndEvent", ctx, userID, eventType)}
+}
+
+func (_c *MockWebhookRepository_GetByUserIDAndEvent_Call) Run(run func(ctx context.Context, userID bson.ObjectID, eventType models.EventType)) *MockWebhookRepository_GetByUserIDAndEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(models.EventType))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetByUserIDAndEvent_Call) Return(_a0 []*models.Webhook, _a1 error) *MockWebhookRepository_GetByUserIDAndEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetByUserIDAndEvent_Call) RunAndReturn(run func(context.Context, bson.ObjectID, models.EventType) ([]*models.Webhook, error)) *MockWebhookRepository_GetByUserIDAndEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookRepository creates a new instance of MockWebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookRepository {
+	mock := &MockWebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
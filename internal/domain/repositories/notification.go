@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// NotificationRepository stores the delivery history of notifications.
+type NotificationRepository interface {
+	Create(context.Context, *models.Notification) error
+	// ListByUserID returns a page of the user's notifications, newest first,
+	// and the total number of their notifications.
+	ListByUserID(
+		ctx context.Context,
+		userID bson.ObjectID,
+		query *models.NotificationQuery,
+	) ([]*models.Notification, int64, error)
+	// DeleteByUserID deletes the notifications of the user and returns how
+	// many were deleted.
+	DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+type notificationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewNotificationRepository(ctx context.Context, db *mongo.Database) (NotificationRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "sent_at", Value: -1},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("notifications")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Notification repository initialized and index verified")
+
+	return &notificationRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	return lib.Create(ctx, r.collection, notification)
+}
+
+// ListByUserID breaks ties on the ID, so notifications sent in the same
+// instant keep a stable order across pages.
+func (r *notificationRepository) ListByUserID(
+	ctx context.Context,
+	userID bson.ObjectID,
+	query *models.NotificationQuery,
+) ([]*models.Notification, int64, error) {
+	filter := bson.M{"user_id": userID}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "sent_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+
+	notifications, err := lib.FindMany[models.Notification](ctx, r.collection, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := lib.Count(ctx, r.collection, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+func (r *notificationRepository) DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	return lib.DeleteMany(ctx, r.collection, bson.M{"user_id": userID})
+}
@@ -0,0 +1,94 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newNotificationRepo(t *testing.T) repositories.NotificationRepository {
+	t.Helper()
+
+	dbName := "notification_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repositories.NewNotificationRepository(ctx, db)
+	require.NoError(t, err, "NewNotificationRepository should not error")
+	return repo
+}
+
+func newNotification(userID bson.ObjectID, template string, sentAt time.Time) *models.Notification {
+	return &models.Notification{
+		ID:        bson.NewObjectID(),
+		UserID:    userID,
+		Channel:   models.ChannelEmail,
+		Recipient: "test@example.com",
+		Template:  template,
+		Status:    models.NotificationSent,
+		SentAt:    sentAt,
+	}
+}
+
+func TestNotificationRepository_ListByUserID(t *testing.T) {
+	repo := newNotificationRepo(t)
+
+	older := newNotification(defaultUserID, "reminder", mockOneMonthAgo)
+	newest := newNotification(defaultUserID, "renewal_confirmation", mockTime)
+	// Sent in the same instant as newest, but recorded after it.
+	tied := newNotification(defaultUserID, "reminder", mockTime)
+	decoy := newNotification(bson.NewObjectID(), "reminder", mockTime)
+	for _, n := range []*models.Notification{older, newest, tied, decoy} {
+		require.NoError(t, repo.Create(t.Context(), n))
+	}
+
+	got, total, err := repo.ListByUserID(t.Context(), defaultUserID, &models.NotificationQuery{PageQuery: models.PageQuery{Limit: 2}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, total)
+	require.Len(t, got, 2)
+	assert.Equal(t, tied.ID, got[0].ID)
+	assert.Equal(t, newest.ID, got[1].ID)
+
+	got, _, err = repo.ListByUserID(t.Context(), defaultUserID, &models.NotificationQuery{PageQuery: models.PageQuery{Limit: 2, Offset: 2}})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, older.ID, got[0].ID)
+	assert.Equal(t, "test@example.com", got[0].Recipient)
+}
+
+func TestNotificationRepository_DeleteByUserID(t *testing.T) {
+	repo := newNotificationRepo(t)
+	others := bson.NewObjectID()
+
+	for _, n := range []*models.Notification{
+		newNotification(defaultUserID, "reminder", mockTime),
+		newNotification(defaultUserID, "account_closed", mockTime),
+		newNotification(others, "reminder", mockTime),
+	} {
+		require.NoError(t, repo.Create(t.Context(), n))
+	}
+
+	deleted, err := repo.DeleteByUserID(t.Context(), defaultUserID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, deleted)
+
+	_, total, err := repo.ListByUserID(t.Context(), others, &models.NotificationQuery{PageQuery: models.PageQuery{Limit: 10}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+}
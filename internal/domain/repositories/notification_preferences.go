@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// NotificationPreferenceRepository stores the notification preferences of
+// users, one document per user. Users who never saved preferences have no
+// document.
+type NotificationPreferenceRepository interface {
+	Upsert(context.Context, *models.NotificationPreferences) error
+	GetByUserID(ctx context.Context, userID bson.ObjectID) (*models.NotificationPreferences, error)
+	GetByUserIDs(ctx context.Context, userIDs []bson.ObjectID) ([]*models.NotificationPreferences, error)
+	// DistinctReminderDays returns every day that some user has overridden
+	// their reminder days with.
+	DistinctReminderDays(context.Context) ([]int, error)
+	// DeleteByUserID deletes the preferences of the user and returns how
+	// many documents were deleted, 0 if they never saved any.
+	DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+type notificationPreferenceRepository struct {
+	collection *mongo.Collection
+}
+
+// NewNotificationPreferenceRepository creates a
+// NotificationPreferenceRepository. Documents are keyed by user ID, so no
+// secondary index is needed.
+func NewNotificationPreferenceRepository(db *mongo.Database) NotificationPreferenceRepository {
+	return &notificationPreferenceRepository{
+		collection: db.Collection("notification_preferences"),
+	}
+}
+
+func (r *notificationPreferenceRepository) Upsert(ctx context.Context, prefs *models.NotificationPreferences) error {
+	filter := bson.M{"_id": prefs.UserID}
+	opts := options.Replace().SetUpsert(true)
+
+	if _, err := r.collection.ReplaceOne(ctx, filter, prefs, opts); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return apperror.NewTimeoutError(err)
+		}
+		return apperror.NewDBError(err)
+	}
+	return nil
+}
+
+func (r *notificationPreferenceRepository) GetByUserID(
+	ctx context.Context,
+	userID bson.ObjectID,
+) (*models.NotificationPreferences, error) {
+	filter := bson.M{"_id": userID}
+	return lib.FindOne[models.NotificationPreferences](ctx, r.collection, filter)
+}
+
+func (r *notificationPreferenceRepository) GetByUserIDs(
+	ctx context.Context,
+	userIDs []bson.ObjectID,
+) ([]*models.NotificationPreferences, error) {
+	filter := bson.M{"_id": bson.M{"$in": userIDs}}
+	return lib.FindMany[models.NotificationPreferences](ctx, r.collection, filter)
+}
+
+func (r *notificationPreferenceRepository) DistinctReminderDays(ctx context.Context) ([]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$reminder_days"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":  nil,
+			"days": bson.M{"$addToSet": "$reminder_days"},
+		}}},
+	}
+
+	type result struct {
+		Days []int `bson:"days"`
+	}
+	results, err := lib.Aggregate[result](ctx, r.collection, pipeline)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0].Days, nil
+}
+
+func (r *notificationPreferenceRepository) DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	return lib.DeleteMany(ctx, r.collection, bson.M{"_id": userID})
+}
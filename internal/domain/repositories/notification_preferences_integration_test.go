@@ -0,0 +1,99 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newNotificationPreferenceRepo(t *testing.T) repositories.NotificationPreferenceRepository {
+	t.Helper()
+
+	dbName := "notification_preferences_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	return repositories.NewNotificationPreferenceRepository(db)
+}
+
+func preferencesFor(userID bson.ObjectID, reminderDays ...int) *models.NotificationPreferences {
+	return &models.NotificationPreferences{
+		UserID:       userID,
+		Channels:     []models.NotificationChannel{models.ChannelEmail},
+		ReminderDays: reminderDays,
+		QuietHours:   &models.QuietHours{Start: "22:00", End: "07:00", Timezone: "UTC"},
+		UpdatedAt:    time.Now().UTC().Truncate(time.Millisecond),
+	}
+}
+
+func TestNotificationPreferenceRepository_UpsertAndGet(t *testing.T) {
+	repo := newNotificationPreferenceRepo(t)
+	userID := bson.NewObjectID()
+
+	_, err := repo.GetByUserID(t.Context(), userID)
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperror.ErrNotFound, appErr.Code())
+
+	require.NoError(t, repo.Upsert(t.Context(), preferencesFor(userID, 3)))
+	require.NoError(t, repo.Upsert(t.Context(), preferencesFor(userID, 5)))
+
+	got, err := repo.GetByUserID(t.Context(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{5}, got.ReminderDays)
+	assert.Equal(t, "22:00", got.QuietHours.Start)
+
+	many, err := repo.GetByUserIDs(t.Context(), []bson.ObjectID{userID, bson.NewObjectID()})
+	require.NoError(t, err)
+	assert.Len(t, many, 1)
+}
+
+func TestNotificationPreferenceRepository_DeleteByUserID(t *testing.T) {
+	repo := newNotificationPreferenceRepo(t)
+	userID, otherID := bson.NewObjectID(), bson.NewObjectID()
+	require.NoError(t, repo.Upsert(t.Context(), preferencesFor(userID, 3)))
+	require.NoError(t, repo.Upsert(t.Context(), preferencesFor(otherID, 5)))
+
+	deleted, err := repo.DeleteByUserID(t.Context(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	deleted, err = repo.DeleteByUserID(t.Context(), userID)
+	require.NoError(t, err)
+	assert.Zero(t, deleted, "deleting preferences that are gone is not an error")
+
+	many, err := repo.GetByUserIDs(t.Context(), []bson.ObjectID{userID, otherID})
+	require.NoError(t, err)
+	require.Len(t, many, 1)
+	assert.Equal(t, otherID, many[0].UserID)
+}
+
+func TestNotificationPreferenceRepository_DistinctReminderDays(t *testing.T) {
+	repo := newNotificationPreferenceRepo(t)
+
+	days, err := repo.DistinctReminderDays(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, days)
+
+	require.NoError(t, repo.Upsert(t.Context(), preferencesFor(bson.NewObjectID(), 2, 14)))
+	require.NoError(t, repo.Upsert(t.Context(), preferencesFor(bson.NewObjectID(), 14)))
+	require.NoError(t, repo.Upsert(t.Context(), preferencesFor(bson.NewObjectID()))) // Server defaults.
+
+	days, err = repo.DistinctReminderDays(t.Context())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{2, 14}, days)
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// publishedEventRetention is how long relayed events are kept for debugging
+// before MongoDB's TTL monitor removes them.
+const publishedEventRetention = 7 * 24 * time.Hour
+
+type OutboxRepository interface {
+	Create(context.Context, *models.OutboxEvent) error
+	GetUnpublished(ctx context.Context, limit int64) ([]*models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id bson.ObjectID, publishedAt time.Time) error
+}
+
+type outboxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(ctx context.Context, db *mongo.Database) (OutboxRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "published_at", Value: 1},
+				{Key: "created_at", Value: 1},
+			},
+		},
+		{
+			Keys:    bson.D{{Key: "published_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(publishedEventRetention.Seconds())),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("outbox")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Outbox repository initialized and index verified")
+
+	return &outboxRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *outboxRepository) Create(ctx context.Context, event *models.OutboxEvent) error {
+	return lib.Create(ctx, r.collection, event)
+}
+
+// GetUnpublished returns up to limit events that have not been relayed yet,
+// oldest first.
+func (r *outboxRepository) GetUnpublished(ctx context.Context, limit int64) ([]*models.OutboxEvent, error) {
+	filter := bson.M{"published_at": nil}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetLimit(limit)
+
+	return lib.FindMany[models.OutboxEvent](ctx, r.collection, filter, opts)
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, id bson.ObjectID, publishedAt time.Time) error {
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": bson.M{"published_at": publishedAt}}
+
+	_, err := lib.FindOneAndUpdate[models.OutboxEvent](ctx, r.collection, filter, update)
+	return err
+}
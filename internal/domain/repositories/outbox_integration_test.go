@@ -0,0 +1,113 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func validOutboxEvent(t *testing.T, createdAt time.Time) *models.OutboxEvent {
+	t.Helper()
+
+	event, err := models.NewOutboxEvent(
+		models.SubscriptionCreatedEvent,
+		bson.NewObjectID(),
+		bson.NewObjectID(),
+		map[string]string{"name": "Netflix"},
+		createdAt,
+	)
+	require.NoError(t, err)
+	return event
+}
+
+func newOutboxRepo(t *testing.T) repositories.OutboxRepository {
+	t.Helper()
+
+	dbName := "outbox_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repositories.NewOutboxRepository(ctx, db)
+	require.NoError(t, err, "NewOutboxRepository should not error")
+
+	return repo
+}
+
+// ---------------------------------------------------------------------------
+// GetUnpublished
+// ---------------------------------------------------------------------------
+
+func TestOutboxRepository_GetUnpublished(t *testing.T) {
+	t.Run("returns pending events oldest first, up to limit", func(t *testing.T) {
+		repo := newOutboxRepo(t)
+		base := time.Now().UTC().Truncate(time.Millisecond)
+
+		newest := validOutboxEvent(t, base.Add(2*time.Minute))
+		oldest := validOutboxEvent(t, base)
+		middle := validOutboxEvent(t, base.Add(time.Minute))
+		for _, e := range []*models.OutboxEvent{newest, oldest, middle} {
+			require.NoError(t, repo.Create(t.Context(), e))
+		}
+
+		got, err := repo.GetUnpublished(t.Context(), 2)
+
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, oldest.ID, got[0].ID)
+		assert.Equal(t, middle.ID, got[1].ID)
+		assert.JSONEq(t, `{"name":"Netflix"}`, string(got[0].Payload))
+	})
+
+	t.Run("excludes published events", func(t *testing.T) {
+		repo := newOutboxRepo(t)
+		now := time.Now().UTC().Truncate(time.Millisecond)
+
+		published := validOutboxEvent(t, now)
+		pending := validOutboxEvent(t, now.Add(time.Second))
+		require.NoError(t, repo.Create(t.Context(), published))
+		require.NoError(t, repo.Create(t.Context(), pending))
+		require.NoError(t, repo.MarkPublished(t.Context(), published.ID, now))
+
+		got, err := repo.GetUnpublished(t.Context(), 10)
+
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, pending.ID, got[0].ID)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// MarkPublished
+// ---------------------------------------------------------------------------
+
+func TestOutboxRepository_MarkPublished(t *testing.T) {
+	t.Run("not found - unknown id", func(t *testing.T) {
+		repo := newOutboxRepo(t)
+
+		err := repo.MarkPublished(t.Context(), bson.NewObjectID(), time.Now())
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+	})
+}
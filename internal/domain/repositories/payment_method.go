@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// PaymentMethodRepository stores the payment methods users pay their
+// subscriptions with.
+type PaymentMethodRepository interface {
+	Create(context.Context, *models.PaymentMethod) (*models.PaymentMethod, error)
+	GetByID(context.Context, bson.ObjectID) (*models.PaymentMethod, error)
+	GetByUserID(context.Context, bson.ObjectID) ([]*models.PaymentMethod, error)
+	CountByUserID(context.Context, bson.ObjectID) (int64, error)
+	Update(context.Context, *models.PaymentMethod) (*models.PaymentMethod, error)
+	Delete(context.Context, bson.ObjectID) error
+}
+
+type paymentMethodRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPaymentMethodRepository(ctx context.Context, db *mongo.Database) (PaymentMethodRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "created_at", Value: 1},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("payment_methods")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Payment method repository initialized and index verified")
+
+	return &paymentMethodRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *paymentMethodRepository) Create(ctx context.Context, method *models.PaymentMethod) (*models.PaymentMethod, error) {
+	if err := lib.Create(ctx, r.collection, method); err != nil {
+		return nil, err
+	}
+	return method, nil
+}
+
+func (r *paymentMethodRepository) GetByID(ctx context.Context, id bson.ObjectID) (*models.PaymentMethod, error) {
+	filter := bson.M{"_id": id}
+	return lib.FindOne[models.PaymentMethod](ctx, r.collection, filter)
+}
+
+func (r *paymentMethodRepository) GetByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.PaymentMethod, error) {
+	filter := bson.M{"user_id": userID}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	return lib.FindMany[models.PaymentMethod](ctx, r.collection, filter, opts)
+}
+
+func (r *paymentMethodRepository) CountByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	filter := bson.M{"user_id": userID}
+	return lib.Count(ctx, r.collection, filter)
+}
+
+func (r *paymentMethodRepository) Update(ctx context.Context, method *models.PaymentMethod) (*models.PaymentMethod, error) {
+	filter := bson.M{"_id": method.ID}
+	if err := lib.Update(ctx, r.collection, filter, method); err != nil {
+		return nil, err
+	}
+	return method, nil
+}
+
+func (r *paymentMethodRepository) Delete(ctx context.Context, id bson.ObjectID) error {
+	filter := bson.M{"_id": id}
+	return lib.Delete(ctx, r.collection, filter)
+}
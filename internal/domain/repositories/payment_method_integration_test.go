@@ -0,0 +1,79 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newPaymentMethodRepo(t *testing.T) repositories.PaymentMethodRepository {
+	t.Helper()
+
+	db := mongoClient.Database("payment_method_test_" + bson.NewObjectID().Hex())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	repo, err := repositories.NewPaymentMethodRepository(t.Context(), db)
+	require.NoError(t, err)
+	return repo
+}
+
+func paymentMethodFor(userID bson.ObjectID, createdAt time.Time) *models.PaymentMethod {
+	return &models.PaymentMethod{
+		ID:        bson.NewObjectID(),
+		UserID:    userID,
+		Type:      models.Card,
+		Brand:     "Visa",
+		Last4:     "4242",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
+
+func TestPaymentMethodRepository(t *testing.T) {
+	repo := newPaymentMethodRepo(t)
+	userID := bson.NewObjectID()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	newer := paymentMethodFor(userID, now)
+	older := paymentMethodFor(userID, now.Add(-time.Hour))
+	other := paymentMethodFor(bson.NewObjectID(), now)
+	for _, m := range []*models.PaymentMethod{newer, older, other} {
+		_, err := repo.Create(t.Context(), m)
+		require.NoError(t, err)
+	}
+
+	got, err := repo.GetByUserID(t.Context(), userID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, older.ID, got[0].ID, "oldest first")
+
+	count, err := repo.CountByUserID(t.Context(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	newer.Last4 = ""
+	newer.Label = "Spare"
+	_, err = repo.Update(t.Context(), newer)
+	require.NoError(t, err)
+	stored, err := repo.GetByID(t.Context(), newer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Spare", stored.Label)
+	assert.Empty(t, stored.Last4)
+
+	require.NoError(t, repo.Delete(t.Context(), newer.ID))
+	_, err = repo.GetByID(t.Context(), newer.ID)
+	assertAppErrorCode(t, err, apperror.ErrNotFound)
+}
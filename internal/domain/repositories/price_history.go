@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// PriceHistoryRepository stores the price changes of subscriptions.
+type PriceHistoryRepository interface {
+	Create(context.Context, *models.PriceChange) error
+	// GetBySubscriptionID returns the price changes of a subscription, oldest
+	// first.
+	GetBySubscriptionID(context.Context, bson.ObjectID) ([]*models.PriceChange, error)
+	// GetEffectiveAt returns the change of a subscription in effect at the
+	// given time: the one with the latest effective date at or before it, the
+	// latest made among those. It returns nil if there is none.
+	GetEffectiveAt(ctx context.Context, subscriptionID bson.ObjectID, at time.Time) (*models.PriceChange, error)
+	// GetByUserIDBetween returns the price changes of the user's
+	// subscriptions made in [from, to), oldest first.
+	GetByUserIDBetween(ctx context.Context, userID bson.ObjectID, from, to time.Time) ([]*models.PriceChange, error)
+}
+
+type priceHistoryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPriceHistoryRepository(ctx context.Context, db *mongo.Database) (PriceHistoryRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "subscription_id", Value: 1},
+				{Key: "changed_at", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "subscription_id", Value: 1},
+				{Key: "effective_at", Value: -1},
+				{Key: "changed_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "changed_at", Value: 1},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("price_history")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Price history repository initialized and index verified")
+
+	return &priceHistoryRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *priceHistoryRepository) Create(ctx context.Context, change *models.PriceChange) error {
+	return lib.Create(ctx, r.collection, change)
+}
+
+func (r *priceHistoryRepository) GetBySubscriptionID(ctx context.Context, subscriptionID bson.ObjectID) ([]*models.PriceChange, error) {
+	filter := bson.M{"subscription_id": subscriptionID}
+	opts := options.Find().SetSort(bson.D{{Key: "changed_at", Value: 1}})
+
+	return lib.FindMany[models.PriceChange](ctx, r.collection, filter, opts)
+}
+
+func (r *priceHistoryRepository) GetEffectiveAt(
+	ctx context.Context,
+	subscriptionID bson.ObjectID,
+	at time.Time,
+) (*models.PriceChange, error) {
+	filter := bson.M{
+		"subscription_id": subscriptionID,
+		"effective_at":    bson.M{"$lte": at},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "effective_at", Value: -1}, {Key: "changed_at", Value: -1}})
+
+	change, err := lib.FindOne[models.PriceChange](ctx, r.collection, filter, opts)
+	if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+		return nil, nil
+	}
+	return change, err
+}
+
+func (r *priceHistoryRepository) GetByUserIDBetween(
+	ctx context.Context,
+	userID bson.ObjectID,
+	from, to time.Time,
+) ([]*models.PriceChange, error) {
+	filter := bson.M{
+		"user_id": userID,
+		"changed_at": bson.M{
+			"$gte": from,
+			"$lt":  to,
+		},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "changed_at", Value: 1}})
+
+	return lib.FindMany[models.PriceChange](ctx, r.collection, filter, opts)
+}
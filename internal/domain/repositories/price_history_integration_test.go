@@ -0,0 +1,121 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newPriceHistoryRepo(t *testing.T) repositories.PriceHistoryRepository {
+	t.Helper()
+
+	dbName := "price_history_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repositories.NewPriceHistoryRepository(ctx, db)
+	require.NoError(t, err, "NewPriceHistoryRepository should not error")
+	return repo
+}
+
+func priceChangeAt(subscriptionID, userID bson.ObjectID, changedAt time.Time) *models.PriceChange {
+	return &models.PriceChange{
+		ID:             bson.NewObjectID(),
+		SubscriptionID: subscriptionID,
+		UserID:         userID,
+		Name:           "Netflix",
+		OldPrice:       999,
+		NewPrice:       1199,
+		Currency:       models.USD,
+		Frequency:      models.Monthly,
+		ChangedAt:      changedAt,
+		EffectiveAt:    changedAt,
+	}
+}
+
+func TestPriceHistoryRepository_GetBySubscriptionID(t *testing.T) {
+	repo := newPriceHistoryRepo(t)
+	subscriptionID := bson.NewObjectID()
+
+	later := priceChangeAt(subscriptionID, defaultUserID, mockTime)
+	earlier := priceChangeAt(subscriptionID, defaultUserID, mockOneMonthAgo)
+	decoy := priceChangeAt(bson.NewObjectID(), defaultUserID, mockTime)
+	for _, change := range []*models.PriceChange{later, decoy, earlier} {
+		require.NoError(t, repo.Create(t.Context(), change))
+	}
+
+	got, err := repo.GetBySubscriptionID(t.Context(), subscriptionID)
+
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, earlier.ID, got[0].ID, "oldest first")
+	assert.Equal(t, later.ID, got[1].ID)
+}
+
+func TestPriceHistoryRepository_GetByUserIDBetween(t *testing.T) {
+	repo := newPriceHistoryRepo(t)
+
+	target := priceChangeAt(bson.NewObjectID(), defaultUserID, mockYesterday)
+	// Decoy 1: Before the window
+	decoyEarlier := priceChangeAt(bson.NewObjectID(), defaultUserID, mockOneMonthAgo)
+	// Decoy 2: Another user's change
+	decoyOtherUser := priceChangeAt(bson.NewObjectID(), bson.NewObjectID(), mockYesterday)
+	for _, change := range []*models.PriceChange{decoyEarlier, target, decoyOtherUser} {
+		require.NoError(t, repo.Create(t.Context(), change))
+	}
+
+	got, err := repo.GetByUserIDBetween(t.Context(), defaultUserID, mockOneMonthAgo.AddDate(0, 0, 1), mockToday)
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, target.ID, got[0].ID)
+}
+
+func TestPriceHistoryRepository_GetEffectiveAt(t *testing.T) {
+	repo := newPriceHistoryRepo(t)
+	subscriptionID := bson.NewObjectID()
+
+	current := priceChangeAt(subscriptionID, defaultUserID, mockOneMonthAgo)
+	// Scheduled after current but taking effect before the renewal.
+	scheduled := priceChangeAt(subscriptionID, defaultUserID, mockYesterday)
+	scheduled.EffectiveAt = mockToday
+	// Decoy 1: Takes effect after the renewal
+	decoyLater := priceChangeAt(subscriptionID, defaultUserID, mockYesterday)
+	decoyLater.EffectiveAt = mockOneMonthLater
+	// Decoy 2: Another subscription's change
+	decoyOther := priceChangeAt(bson.NewObjectID(), defaultUserID, mockToday)
+	for _, change := range []*models.PriceChange{current, scheduled, decoyLater, decoyOther} {
+		require.NoError(t, repo.Create(t.Context(), change))
+	}
+
+	t.Run("returns the latest change in effect", func(t *testing.T) {
+		got, err := repo.GetEffectiveAt(t.Context(), subscriptionID, mockToday)
+
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, scheduled.ID, got.ID)
+	})
+
+	t.Run("returns nil before any change takes effect", func(t *testing.T) {
+		got, err := repo.GetEffectiveAt(t.Context(), subscriptionID, mockOneMonthAgo.AddDate(0, 0, -1))
+
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// SessionRepository stores the signed-in devices of users. Sessions are
+// deleted by MongoDB's TTL monitor once their refresh token expires.
+type SessionRepository interface {
+	Create(context.Context, *models.Session) error
+	// GetByID returns the session with the given ID if it belongs to the
+	// user.
+	GetByID(ctx context.Context, id, userID bson.ObjectID) (*models.Session, error)
+	// ListByUserID returns the sessions of the user, most recently used
+	// first.
+	ListByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.Session, error)
+	Update(context.Context, *models.Session) error
+	// Delete deletes the session with the given ID if it belongs to the user.
+	Delete(ctx context.Context, id, userID bson.ObjectID) error
+}
+
+type sessionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSessionRepository(ctx context.Context, db *mongo.Database) (SessionRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "last_used_at", Value: -1},
+			},
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("sessions")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Session repository initialized and index verified")
+
+	return &sessionRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *models.Session) error {
+	return lib.Create(ctx, r.collection, session)
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, id, userID bson.ObjectID) (*models.Session, error) {
+	filter := bson.M{"_id": id, "user_id": userID}
+	return lib.FindOne[models.Session](ctx, r.collection, filter)
+}
+
+// ListByUserID breaks ties on the ID, so sessions used in the same instant
+// keep a stable order.
+func (r *sessionRepository) ListByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.Session, error) {
+	filter := bson.M{"user_id": userID}
+	opts := options.Find().SetSort(bson.D{{Key: "last_used_at", Value: -1}, {Key: "_id", Value: -1}})
+	return lib.FindMany[models.Session](ctx, r.collection, filter, opts)
+}
+
+func (r *sessionRepository) Update(ctx context.Context, session *models.Session) error {
+	filter := bson.M{"_id": session.ID, "user_id": session.UserID}
+	return lib.Update(ctx, r.collection, filter, session)
+}
+
+func (r *sessionRepository) Delete(ctx context.Context, id, userID bson.ObjectID) error {
+	filter := bson.M{"_id": id, "user_id": userID}
+	return lib.Delete(ctx, r.collection, filter)
+}
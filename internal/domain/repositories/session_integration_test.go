@@ -0,0 +1,94 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newSessionRepo(t *testing.T) repositories.SessionRepository {
+	t.Helper()
+
+	dbName := "session_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repositories.NewSessionRepository(ctx, db)
+	require.NoError(t, err, "NewSessionRepository should not error")
+	return repo
+}
+
+// newSession expires in the future, so the TTL monitor leaves it alone
+// during the test.
+func newSession(userID bson.ObjectID, lastUsedAt time.Time) *models.Session {
+	return &models.Session{
+		ID:         bson.NewObjectID(),
+		UserID:     userID,
+		Device:     "Laptop",
+		IP:         "203.0.113.7",
+		UserAgent:  "Mozilla/5.0",
+		CreatedAt:  mockOneMonthAgo,
+		LastUsedAt: lastUsedAt,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+}
+
+func TestSessionRepository_ListByUserID(t *testing.T) {
+	repo := newSessionRepo(t)
+
+	older := newSession(defaultUserID, mockOneMonthAgo)
+	newest := newSession(defaultUserID, mockTime)
+	decoy := newSession(bson.NewObjectID(), mockTime)
+	for _, s := range []*models.Session{older, newest, decoy} {
+		require.NoError(t, repo.Create(t.Context(), s))
+	}
+
+	got, err := repo.ListByUserID(t.Context(), defaultUserID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, newest.ID, got[0].ID)
+	assert.Equal(t, older.ID, got[1].ID)
+	assert.Equal(t, "Laptop", got[1].Device)
+	assert.False(t, got[1].Current, "Current is not stored")
+}
+
+func TestSessionRepository_UpdateAndDelete(t *testing.T) {
+	repo := newSessionRepo(t)
+	session := newSession(defaultUserID, mockOneMonthAgo)
+	require.NoError(t, repo.Create(t.Context(), session))
+
+	session.LastUsedAt = mockTime
+	session.IP = "198.51.100.1"
+	require.NoError(t, repo.Update(t.Context(), session))
+	got, err := repo.GetByID(t.Context(), session.ID, defaultUserID)
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.1", got.IP)
+	assert.True(t, mockTime.Equal(got.LastUsedAt))
+
+	// Sessions of another user are not found.
+	_, err = repo.GetByID(t.Context(), session.ID, bson.NewObjectID())
+	assertAppErrorCode(t, err, apperror.ErrNotFound)
+	err = repo.Delete(t.Context(), session.ID, bson.NewObjectID())
+	assertAppErrorCode(t, err, apperror.ErrNotFound)
+
+	require.NoError(t, repo.Delete(t.Context(), session.ID, defaultUserID))
+	_, err = repo.GetByID(t.Context(), session.ID, defaultUserID)
+	assertAppErrorCode(t, err, apperror.ErrNotFound)
+}
@@ -2,10 +2,13 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/anuragthepathak/subscription-management/internal/lib"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -18,12 +21,78 @@ type SubscriptionRepository interface {
 	GetByID(context.Context, bson.ObjectID) (*models.Subscription, error)
 	GetAll(context.Context) ([]*models.Subscription, error)
 	GetByUserID(context.Context, bson.ObjectID) ([]*models.Subscription, error)
+	// List returns the page of subscriptions the query selects and the
+	// number of subscriptions matching its filters.
+	List(context.Context, *models.SubscriptionQuery) ([]*models.Subscription, int64, error)
+	// Count returns the number of subscriptions matching the query's
+	// filters.
+	Count(context.Context, *models.SubscriptionQuery) (int64, error)
+	// Search returns the page of subscriptions matching a full-text search,
+	// best match first, and the number of matches.
+	Search(context.Context, *models.SubscriptionSearch) ([]*models.SubscriptionMatch, int64, error)
+	// Each calls fn with every subscription matching the query's filters, in
+	// its order, ignoring its page. It stops at the first error fn returns.
+	Each(ctx context.Context, query *models.SubscriptionQuery, fn func(*models.Subscription) error) error
 	GetActiveSubscriptions(context.Context, time.Time) ([]*models.Subscription, error)
 	CountActiveSubscriptions(context.Context, time.Time) (int64, error)
+	// CountByStatus returns the number of subscriptions in each status.
+	CountByStatus(context.Context) (map[models.Status]int64, error)
+	// SumActivePrices returns the combined price of active subscriptions per
+	// currency and billing frequency.
+	SumActivePrices(context.Context) ([]*models.PriceTotal, error)
+	// Summarize aggregates the subscriptions of a user: counts per status,
+	// active prices per category, currency, and frequency, the same per tag,
+	// and the active subscriptions renewing in [renewFrom, renewTo).
+	Summarize(ctx context.Context, userID bson.ObjectID, renewFrom, renewTo time.Time) (*models.SubscriptionSummary, error)
 	GetSubscriptionsDueForReminder(context.Context, []int, time.Time) ([]*models.Subscription, error)
 	GetSubscriptionsDueForRenewal(context.Context, time.Time, time.Time) ([]*models.Subscription, error)
 	GetCanceledExpiredSubscriptions(context.Context, time.Time) ([]*models.Subscription, error)
+	GetExpiredBefore(context.Context, time.Time) ([]*models.Subscription, error)
+	// EachDueForReminder, EachDueForRenewal, EachCanceledExpired,
+	// EachExpiredBefore, EachUnusedDueForRenewal, and EachPastDue match like
+	// their Get counterparts, but stream the subscriptions to fn one at a
+	// time instead of loading them all. They stop at the first error fn
+	// returns. EachDueForReminder widens the window of every subscription,
+	// as its callers count the days in the owner's timezone.
+	EachDueForReminder(ctx context.Context, daysBefore []int, referenceTime time.Time, fn func(*models.Subscription) error) error
+	EachDueForRenewal(ctx context.Context, startTime, endTime time.Time, fn func(*models.Subscription) error) error
+	EachCanceledExpired(ctx context.Context, validBefore time.Time, fn func(*models.Subscription) error) error
+	EachExpiredBefore(ctx context.Context, validBefore time.Time, fn func(*models.Subscription) error) error
+	EachUnusedDueForRenewal(ctx context.Context, renewFrom, renewTo, unusedSince time.Time, fn func(*models.Subscription) error) error
+	EachPastDue(ctx context.Context, fn func(*models.Subscription) error) error
+	// GetActiveByUserIDRenewingBefore returns the user's active subscriptions
+	// whose next renewal is before the given time.
+	GetActiveByUserIDRenewingBefore(ctx context.Context, userID bson.ObjectID, before time.Time) ([]*models.Subscription, error)
+	// GetUnusedDueForRenewal returns active subscriptions renewing between
+	// renewFrom and renewTo that have not been used since unusedSince.
+	// Subscriptions without reported usage count from their creation.
+	GetUnusedDueForRenewal(ctx context.Context, renewFrom, renewTo, unusedSince time.Time) ([]*models.Subscription, error)
+	// RecordUsage moves the last use of a subscription forward to usedAt.
+	// An older usedAt leaves the recorded last use unchanged.
+	RecordUsage(ctx context.Context, id bson.ObjectID, usedAt, updatedAt time.Time) (*models.Subscription, error)
 	Update(ctx context.Context, subscription *models.Subscription) (*models.Subscription, error)
+	// UpdatePartial writes only the given fields of the subscription, named
+	// by their bson keys, along with its updated_at, and returns the stored
+	// subscription. Empty fields the document omits are unset. Like Update,
+	// it conflicts if the subscription was written since it was read.
+	UpdatePartial(ctx context.Context, subscription *models.Subscription, fields ...string) (*models.Subscription, error)
+	// TransitionStatus moves a subscription from one status to another. The
+	// set fields, named by their bson keys, are written in the same update.
+	TransitionStatus(ctx context.Context, id bson.ObjectID, from, to models.Status, updatedAt time.Time, set ...bson.E) (*models.Subscription, error)
+	// GetPastDue returns the subscriptions whose renewal payment is being
+	// retried, along with those suspended after the final retry, whose
+	// failed bill is still open.
+	GetPastDue(context.Context) ([]*models.Subscription, error)
+	// AdvanceDunning records the given retry attempt of a past-due
+	// subscription. It returns a conflict unless attempts-1 retries were
+	// recorded before, so each attempt is counted once.
+	AdvanceDunning(ctx context.Context, id bson.ObjectID, attempts int, updatedAt time.Time) (*models.Subscription, error)
+	// SetPaymentMethod links a subscription to a payment method; a nil
+	// paymentMethodID unlinks it.
+	SetPaymentMethod(ctx context.Context, id bson.ObjectID, paymentMethodID *bson.ObjectID, updatedAt time.Time) (*models.Subscription, error)
+	// UnlinkPaymentMethod unlinks every subscription of the user paid with
+	// the payment method and returns how many were linked.
+	UnlinkPaymentMethod(ctx context.Context, userID, paymentMethodID bson.ObjectID, updatedAt time.Time) (int64, error)
 	Delete(ctx context.Context, id bson.ObjectID) error
 }
 
@@ -43,6 +112,65 @@ func NewSubscriptionRepository(ctx context.Context, db *mongo.Database) (Subscri
 				{Key: "valid_till", Value: 1},
 			},
 		},
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "status", Value: 1},
+				{Key: "valid_till", Value: 1},
+			},
+		},
+		// Listings of a user's subscriptions, one per sort key.
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "created_at", Value: 1},
+				{Key: "_id", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "price", Value: 1},
+				{Key: "_id", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "valid_till", Value: 1},
+				{Key: "_id", Value: 1},
+			},
+		},
+		{
+			Keys:    bson.D{{Key: "payment_method_id", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+		// Tag filters of a user's listings; multikey over the tags.
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "tags", Value: 1},
+			},
+		},
+		// Full-text search. Not prefixed by user_id, which would require an
+		// equality match on it and rule out searching every user. Without a
+		// language, words are not stemmed, so matches are the words
+		// highlighted.
+		{
+			Keys: bson.D{
+				{Key: "name", Value: "text"},
+				{Key: "tags", Value: "text"},
+				{Key: "notes", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("subscription_text").
+				SetWeights(bson.D{
+					{Key: "name", Value: 10},
+					{Key: "tags", Value: 5},
+					{Key: "notes", Value: 1},
+				}).
+				SetDefaultLanguage("none"),
+		},
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -80,6 +208,78 @@ func (r *subscriptionRepository) GetByUserID(ctx context.Context, userID bson.Ob
 	return lib.FindMany[models.Subscription](ctx, r.collection, filter)
 }
 
+func (r *subscriptionRepository) List(
+	ctx context.Context,
+	query *models.SubscriptionQuery,
+) ([]*models.Subscription, int64, error) {
+	filter := query.Filter()
+	opts := options.Find().
+		SetSort(query.SortSpec()).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+
+	subscriptions, err := lib.FindMany[models.Subscription](ctx, r.collection, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := lib.Count(ctx, r.collection, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return subscriptions, total, nil
+}
+
+func (r *subscriptionRepository) Search(
+	ctx context.Context,
+	search *models.SubscriptionSearch,
+) ([]*models.SubscriptionMatch, int64, error) {
+	filter := search.Filter()
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(search.Offset)).
+		SetLimit(int64(search.Limit))
+
+	matches, err := lib.FindMany[models.SubscriptionMatch](ctx, r.collection, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := lib.Count(ctx, r.collection, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return matches, total, nil
+}
+
+func (r *subscriptionRepository) Count(ctx context.Context, query *models.SubscriptionQuery) (int64, error) {
+	return lib.Count(ctx, r.collection, query.Filter())
+}
+
+func (r *subscriptionRepository) Each(
+	ctx context.Context,
+	query *models.SubscriptionQuery,
+	fn func(*models.Subscription) error,
+) error {
+	opts := options.Find().SetSort(query.SortSpec())
+	return lib.FindEach(ctx, r.collection, query.Filter(), fn, opts)
+}
+
+func (r *subscriptionRepository) GetActiveByUserIDRenewingBefore(
+	ctx context.Context,
+	userID bson.ObjectID,
+	before time.Time,
+) ([]*models.Subscription, error) {
+	filter := bson.M{
+		"user_id": userID,
+		"status":  models.Active,
+		"valid_till": bson.M{
+			"$lt": before,
+		},
+	}
+	return lib.FindMany[models.Subscription](ctx, r.collection, filter)
+}
+
 func (r *subscriptionRepository) GetActiveSubscriptions(ctx context.Context, validAfter time.Time) ([]*models.Subscription, error) {
 	filter := bson.M{
 		"status": models.Active,
@@ -101,64 +301,492 @@ func (r *subscriptionRepository) CountActiveSubscriptions(ctx context.Context, v
 	return lib.Count(ctx, r.collection, filter)
 }
 
+func (r *subscriptionRepository) CountByStatus(ctx context.Context) (map[models.Status]int64, error) {
+	type statusCount struct {
+		Status models.Status `bson:"_id"`
+		Count  int64         `bson:"count"`
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}}},
+	}
+	counts, err := lib.Aggregate[statusCount](ctx, r.collection, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[models.Status]int64, len(counts))
+	for _, c := range counts {
+		res[c.Status] = c.Count
+	}
+	return res, nil
+}
+
+func (r *subscriptionRepository) SumActivePrices(ctx context.Context) ([]*models.PriceTotal, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": models.Active}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"currency": "$currency", "frequency": "$frequency"},
+			"total": bson.M{"$sum": "$price"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":       0,
+			"currency":  "$_id.currency",
+			"frequency": "$_id.frequency",
+			"total":     1,
+		}}},
+	}
+	return lib.Aggregate[models.PriceTotal](ctx, r.collection, pipeline)
+}
+
+func (r *subscriptionRepository) Summarize(
+	ctx context.Context,
+	userID bson.ObjectID,
+	renewFrom, renewTo time.Time,
+) (*models.SubscriptionSummary, error) {
+	// One pass over the user's subscriptions, which the user_id index
+	// serves; each facet computes one part of the summary.
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userID}}},
+		{{Key: "$facet", Value: bson.M{
+			"by_status": bson.A{
+				bson.M{"$group": bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}},
+			},
+			"spend": bson.A{
+				bson.M{"$match": bson.M{"status": models.Active}},
+				bson.M{"$group": bson.M{
+					"_id":   bson.M{"category": "$category", "currency": "$currency", "frequency": "$frequency"},
+					"total": bson.M{"$sum": "$price"},
+				}},
+				bson.M{"$project": bson.M{
+					"_id":       0,
+					"category":  "$_id.category",
+					"currency":  "$_id.currency",
+					"frequency": "$_id.frequency",
+					"total":     1,
+				}},
+			},
+			"tag_spend": bson.A{
+				bson.M{"$match": bson.M{"status": models.Active, "tags.0": bson.M{"$exists": true}}},
+				bson.M{"$unwind": "$tags"},
+				bson.M{"$group": bson.M{
+					"_id":   bson.M{"tag": "$tags", "currency": "$currency", "frequency": "$frequency"},
+					"total": bson.M{"$sum": "$price"},
+					"count": bson.M{"$sum": 1},
+				}},
+				bson.M{"$project": bson.M{
+					"_id":       0,
+					"tag":       "$_id.tag",
+					"currency":  "$_id.currency",
+					"frequency": "$_id.frequency",
+					"total":     1,
+					"count":     1,
+				}},
+			},
+			"upcoming": bson.A{
+				bson.M{"$match": bson.M{
+					"status":     models.Active,
+					"valid_till": bson.M{"$gte": renewFrom, "$lt": renewTo},
+				}},
+				bson.M{"$sort": bson.D{{Key: "valid_till", Value: 1}, {Key: "_id", Value: 1}}},
+				bson.M{"$project": bson.M{
+					"name":       1,
+					"price":      1,
+					"currency":   1,
+					"valid_till": 1,
+				}},
+			},
+		}}},
+	}
+
+	res, err := lib.Aggregate[models.SubscriptionSummary](ctx, r.collection, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 { // $facet always yields one document; guard anyway.
+		return &models.SubscriptionSummary{}, nil
+	}
+	return res[0], nil
+}
+
+// maxUTCOffset bounds how far the local day of a billing timezone can be from
+// the day in the reference location.
+const maxUTCOffset = 14 * time.Hour
+
+// GetSubscriptionsDueForReminder matches the reminder days in the location of
+// referenceTime. Subscriptions with a billing timezone are matched on a window
+// widened by maxUTCOffset on both sides; callers narrow them down to their
+// local day. Subscriptions with reminder days of their own are matched on
+// those instead of daysBefore, always on the widened window.
 func (r *subscriptionRepository) GetSubscriptionsDueForReminder(
 	ctx context.Context,
 	daysBefore []int,
 	referenceTime time.Time,
 ) ([]*models.Subscription, error) {
+	return lib.FindMany[models.Subscription](ctx, r.collection, dueForReminderFilter(daysBefore, referenceTime, 0))
+}
+
+func (r *subscriptionRepository) EachDueForReminder(
+	ctx context.Context,
+	daysBefore []int,
+	referenceTime time.Time,
+	fn func(*models.Subscription) error,
+) error {
+	return lib.FindEach(ctx, r.collection, dueForReminderFilter(daysBefore, referenceTime, maxUTCOffset), fn)
+}
+
+// dueForReminderFilter widens the day windows of subscriptions without a
+// billing timezone by slack on both sides.
+func dueForReminderFilter(daysBefore []int, referenceTime time.Time, slack time.Duration) bson.M {
 	var orConditions []bson.M
 	for _, days := range daysBefore {
 		targetDay := referenceTime.AddDate(0, 0, days)
 		startOfTargetDay := time.Date(targetDay.Year(), targetDay.Month(), targetDay.Day(), 0, 0, 0, 0, targetDay.Location())
 		endOfTargetDay := startOfTargetDay.Add(24 * time.Hour)
 
-		orConditions = append(orConditions, bson.M{
-			"valid_till": bson.M{
-				"$gte": startOfTargetDay,
-				"$lt":  endOfTargetDay,
+		orConditions = append(orConditions,
+			bson.M{
+				"timezone": bson.M{"$exists": false},
+				"valid_till": bson.M{
+					"$gte": startOfTargetDay.Add(-slack),
+					"$lt":  endOfTargetDay.Add(slack),
+				},
+			},
+			bson.M{
+				"timezone": bson.M{"$exists": true},
+				"valid_till": bson.M{
+					"$gte": startOfTargetDay.Add(-maxUTCOffset),
+					"$lt":  endOfTargetDay.Add(maxUTCOffset),
+				},
 			},
-		})
+		)
 	}
 
-	filter := bson.M{
+	startOfToday := time.Date(referenceTime.Year(), referenceTime.Month(), referenceTime.Day(), 0, 0, 0, 0, referenceTime.Location())
+	from := startOfToday.Add(-maxUTCOffset)
+	to := startOfToday.Add(24*time.Hour + maxUTCOffset)
+	// The day windows of a subscription's own reminder days depend on the
+	// document, so they are computed in $expr. The bounds on valid_till keep
+	// the scan on the index range of the farthest reminder day.
+	ownDays := bson.M{
+		"reminder_days": bson.M{"$exists": true},
+		"valid_till": bson.M{
+			"$gte": from,
+			"$lt":  startOfToday.AddDate(0, 0, models.MaxReminderDays).Add(24*time.Hour + maxUTCOffset),
+		},
+		"$expr": bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+			"input": "$reminder_days",
+			"as":    "days",
+			"in": bson.M{"$and": bson.A{
+				bson.M{"$gte": bson.A{"$valid_till", bson.M{"$dateAdd": bson.M{"startDate": from, "unit": "day", "amount": "$$days"}}}},
+				bson.M{"$lt": bson.A{"$valid_till", bson.M{"$dateAdd": bson.M{"startDate": to, "unit": "day", "amount": "$$days"}}}},
+			}},
+		}}}},
+	}
+	if len(orConditions) == 0 { // $or must not be empty.
+		ownDays["status"] = models.Active
+		return ownDays
+	}
+
+	return bson.M{
 		"status": models.Active,
-		"$or":    orConditions,
+		"$or": bson.A{
+			bson.M{"reminder_days": bson.M{"$exists": false}, "$or": orConditions},
+			ownDays,
+		},
 	}
-	return lib.FindMany[models.Subscription](ctx, r.collection, filter)
 }
 
 func (r *subscriptionRepository) GetSubscriptionsDueForRenewal(ctx context.Context, startTime, endTime time.Time) ([]*models.Subscription, error) {
-	filter := bson.M{
+	opts := options.Find().SetSort(bson.D{{Key: "valid_till", Value: 1}})
+
+	return lib.FindMany[models.Subscription](ctx, r.collection, dueForRenewalFilter(startTime, endTime), opts)
+}
+
+func (r *subscriptionRepository) EachDueForRenewal(
+	ctx context.Context,
+	startTime, endTime time.Time,
+	fn func(*models.Subscription) error,
+) error {
+	opts := options.Find().SetSort(bson.D{{Key: "valid_till", Value: 1}})
+
+	return lib.FindEach(ctx, r.collection, dueForRenewalFilter(startTime, endTime), fn, opts)
+}
+
+func dueForRenewalFilter(startTime, endTime time.Time) bson.M {
+	return bson.M{
 		"status": models.Active,
 		"valid_till": bson.M{
 			"$gte": startTime,
 			"$lte": endTime,
 		},
 	}
+}
 
-	opts := options.Find().SetSort(bson.D{{Key: "valid_till", Value: 1}})
+func (r *subscriptionRepository) GetCanceledExpiredSubscriptions(ctx context.Context, validBefore time.Time) ([]*models.Subscription, error) {
+	return lib.FindMany[models.Subscription](ctx, r.collection, statusValidBeforeFilter(models.Canceled, validBefore))
+}
 
-	return lib.FindMany[models.Subscription](ctx, r.collection, filter, opts)
+func (r *subscriptionRepository) EachCanceledExpired(
+	ctx context.Context,
+	validBefore time.Time,
+	fn func(*models.Subscription) error,
+) error {
+	return lib.FindEach(ctx, r.collection, statusValidBeforeFilter(models.Canceled, validBefore), fn)
 }
 
-func (r *subscriptionRepository) GetCanceledExpiredSubscriptions(ctx context.Context, validBefore time.Time) ([]*models.Subscription, error) {
-	filter := bson.M{
-		"status": models.Canceled,
+func (r *subscriptionRepository) GetExpiredBefore(ctx context.Context, validBefore time.Time) ([]*models.Subscription, error) {
+	return lib.FindMany[models.Subscription](ctx, r.collection, statusValidBeforeFilter(models.Expired, validBefore))
+}
+
+func (r *subscriptionRepository) EachExpiredBefore(
+	ctx context.Context,
+	validBefore time.Time,
+	fn func(*models.Subscription) error,
+) error {
+	return lib.FindEach(ctx, r.collection, statusValidBeforeFilter(models.Expired, validBefore), fn)
+}
+
+func statusValidBeforeFilter(status models.Status, validBefore time.Time) bson.M {
+	return bson.M{
+		"status": status,
 		"valid_till": bson.M{
 			"$lt": validBefore,
 		},
 	}
+}
 
-	return lib.FindMany[models.Subscription](ctx, r.collection, filter)
+func (r *subscriptionRepository) GetUnusedDueForRenewal(
+	ctx context.Context,
+	renewFrom, renewTo, unusedSince time.Time,
+) ([]*models.Subscription, error) {
+	return lib.FindMany[models.Subscription](ctx, r.collection, unusedDueForRenewalFilter(renewFrom, renewTo, unusedSince))
 }
 
+func (r *subscriptionRepository) EachUnusedDueForRenewal(
+	ctx context.Context,
+	renewFrom, renewTo, unusedSince time.Time,
+	fn func(*models.Subscription) error,
+) error {
+	return lib.FindEach(ctx, r.collection, unusedDueForRenewalFilter(renewFrom, renewTo, unusedSince), fn)
+}
+
+func unusedDueForRenewalFilter(renewFrom, renewTo, unusedSince time.Time) bson.M {
+	return bson.M{
+		"status": models.Active,
+		"valid_till": bson.M{
+			"$gte": renewFrom,
+			"$lte": renewTo,
+		},
+		"$or": bson.A{
+			bson.M{"last_used_at": bson.M{"$lt": unusedSince}},
+			bson.M{
+				"last_used_at": bson.M{"$exists": false},
+				"created_at":   bson.M{"$lt": unusedSince},
+			},
+		},
+	}
+}
+
+func (r *subscriptionRepository) RecordUsage(
+	ctx context.Context,
+	id bson.ObjectID,
+	usedAt, updatedAt time.Time,
+) (*models.Subscription, error) {
+	filter := bson.M{"_id": id}
+	update := bson.M{
+		"$max": bson.M{"last_used_at": usedAt},
+		"$set": bson.M{"updated_at": updatedAt},
+		"$inc": bson.M{"version": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	return lib.FindOneAndUpdate[models.Subscription](ctx, r.collection, filter, update, opts)
+}
+
+// Update replaces the subscription only if it is still at the version it was
+// read at, and returns it at the next version. subscription itself is left
+// untouched, so a transaction that retries writes the same version again. A
+// subscription written since it was read is a conflict.
 func (r *subscriptionRepository) Update(ctx context.Context, subscription *models.Subscription) (*models.Subscription, error) {
-	filter := bson.M{"_id": subscription.ID}
-	if err := lib.Update(ctx, r.collection, filter, subscription); err != nil {
+	filter := bson.M{
+		"_id":     subscription.ID,
+		"version": subscription.Version,
+	}
+	next := *subscription
+	next.Version++
+
+	if err := lib.Update(ctx, r.collection, filter, &next); err != nil {
+		return nil, r.conflictIfStale(ctx, subscription.ID, err)
+	}
+	return &next, nil
+}
+
+// UpdatePartial writes only the fields named in the field mask, by their bson
+// keys, along with updated_at, and returns the subscription at its next
+// version. The version check of Update still applies: a subscription written
+// since it was read is turned into a 409 conflict by conflictIfStale.
+func (r *subscriptionRepository) UpdatePartial(
+	ctx context.Context,
+	subscription *models.Subscription,
+	fields ...string,
+) (*models.Subscription, error) {
+	update, err := lib.PartialUpdate(subscription, append(slices.Clip(fields), "updated_at")...)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+	update["$inc"] = bson.M{"version": 1}
+	filter := bson.M{
+		"_id":     subscription.ID,
+		"version": subscription.Version,
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	res, err := lib.FindOneAndUpdate[models.Subscription](ctx, r.collection, filter, update, opts)
+	if err != nil {
+		return nil, r.conflictIfStale(ctx, subscription.ID, err)
+	}
+	return res, nil
+}
+
+// conflictIfStale turns the not-found error of a versioned write into a
+// conflict when the subscription exists, i.e. was written since it was read.
+func (r *subscriptionRepository) conflictIfStale(ctx context.Context, id bson.ObjectID, err error) error {
+	if appErr, ok := errors.AsType[apperror.AppError](err); !ok || appErr.Code() != apperror.ErrNotFound {
+		return err
+	}
+	count, countErr := lib.Count(ctx, r.collection, bson.M{"_id": id})
+	if countErr != nil {
+		return countErr
+	}
+	if count == 0 {
+		return err
+	}
+	return apperror.NewConflictError("Subscription was modified by another request")
+}
+
+// TransitionStatus atomically moves a subscription from one status to another
+// and returns the updated document. The current status is part of the filter,
+// so when several workers race on the same subscription only one of them wins;
+// the others get a conflict error. Fields that belong to the new status are
+// passed as set, so they are never written apart from it.
+func (r *subscriptionRepository) TransitionStatus(
+	ctx context.Context,
+	id bson.ObjectID,
+	from, to models.Status,
+	updatedAt time.Time,
+	set ...bson.E,
+) (*models.Subscription, error) {
+	filter := bson.M{
+		"_id":    id,
+		"status": from,
+	}
+	fields := bson.M{
+		"status":     to,
+		"updated_at": updatedAt,
+	}
+	for _, field := range set {
+		fields[field.Key] = field.Value
+	}
+	update := bson.M{
+		"$set": fields,
+		"$inc": bson.M{"version": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	subscription, err := lib.FindOneAndUpdate[models.Subscription](ctx, r.collection, filter, update, opts)
+	if err == nil {
+		return subscription, nil
+	}
+	if appErr, ok := errors.AsType[apperror.AppError](err); !ok || appErr.Code() != apperror.ErrNotFound {
 		return nil, err
 	}
 
-	return subscription, nil
+	// Nothing matched: tell a missing subscription apart from a lost race.
+	count, countErr := lib.Count(ctx, r.collection, bson.M{"_id": id})
+	if countErr != nil {
+		return nil, countErr
+	}
+	if count == 0 {
+		return nil, err
+	}
+	return nil, apperror.NewConflictError("Subscription is no longer %s", from)
+}
+
+func (r *subscriptionRepository) GetPastDue(ctx context.Context) ([]*models.Subscription, error) {
+	filter := bson.M{"status": bson.M{"$in": bson.A{models.PastDue, models.Suspended}}}
+	return lib.FindMany[models.Subscription](ctx, r.collection, filter)
+}
+
+func (r *subscriptionRepository) EachPastDue(ctx context.Context, fn func(*models.Subscription) error) error {
+	filter := bson.M{"status": bson.M{"$in": bson.A{models.PastDue, models.Suspended}}}
+	return lib.FindEach(ctx, r.collection, filter, fn)
+}
+
+func (r *subscriptionRepository) AdvanceDunning(
+	ctx context.Context,
+	id bson.ObjectID,
+	attempts int,
+	updatedAt time.Time,
+) (*models.Subscription, error) {
+	filter := bson.M{
+		"_id":              id,
+		"status":           models.PastDue,
+		"dunning.attempts": attempts - 1,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"dunning.attempts": attempts,
+			"updated_at":       updatedAt,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	subscription, err := lib.FindOneAndUpdate[models.Subscription](ctx, r.collection, filter, update, opts)
+	if err == nil {
+		return subscription, nil
+	}
+	if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+		return nil, apperror.NewConflictError("Payment retry %d was already recorded", attempts)
+	}
+	return nil, err
+}
+
+func (r *subscriptionRepository) SetPaymentMethod(
+	ctx context.Context,
+	id bson.ObjectID,
+	paymentMethodID *bson.ObjectID,
+	updatedAt time.Time,
+) (*models.Subscription, error) {
+	filter := bson.M{"_id": id}
+	update := bson.M{
+		"$set": bson.M{"updated_at": updatedAt},
+		"$inc": bson.M{"version": 1},
+	}
+	if paymentMethodID != nil {
+		update["$set"].(bson.M)["payment_method_id"] = *paymentMethodID
+	} else {
+		update["$unset"] = bson.M{"payment_method_id": ""}
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	return lib.FindOneAndUpdate[models.Subscription](ctx, r.collection, filter, update, opts)
+}
+
+func (r *subscriptionRepository) UnlinkPaymentMethod(
+	ctx context.Context,
+	userID bson.ObjectID,
+	paymentMethodID bson.ObjectID,
+	updatedAt time.Time,
+) (int64, error) {
+	filter := bson.M{"user_id": userID, "payment_method_id": paymentMethodID}
+	update := bson.M{
+		"$unset": bson.M{"payment_method_id": ""},
+		"$set":   bson.M{"updated_at": updatedAt},
+		"$inc":   bson.M{"version": 1},
+	}
+	return lib.UpdateMany(ctx, r.collection, filter, update)
 }
 
 func (r *subscriptionRepository) Delete(ctx context.Context, id bson.ObjectID) error {
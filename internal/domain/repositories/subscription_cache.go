@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// cachedSubscriptionRepository caches single subscriptions and the listings
+// of a user in front of another SubscriptionRepository. Every write through
+// it invalidates the subscription and its owner's listing; calls it does not
+// override go straight to the wrapped repository.
+type cachedSubscriptionRepository struct {
+	SubscriptionRepository
+	cache readCache
+}
+
+// cachedSubscriptions wraps the subscriptions of a user, since a document
+// cannot be an array.
+type cachedSubscriptions struct {
+	Items []*models.Subscription `bson:"items"`
+}
+
+// NewCachedSubscriptionRepository returns a SubscriptionRepository that
+// serves GetByID and GetByUserID from Redis for up to ttl.
+func NewCachedSubscriptionRepository(
+	repository SubscriptionRepository,
+	client redis.UniversalClient,
+	ttl time.Duration,
+) SubscriptionRepository {
+	return &cachedSubscriptionRepository{
+		repository,
+		readCache{client, ttl},
+	}
+}
+
+func subscriptionCacheKey(id bson.ObjectID) string {
+	return cachePrefix + "subscription:" + id.Hex()
+}
+
+func userSubscriptionsCacheKey(userID bson.ObjectID) string {
+	return cachePrefix + "subscriptions:user:" + userID.Hex()
+}
+
+func (r *cachedSubscriptionRepository) GetByID(ctx context.Context, id bson.ObjectID) (*models.Subscription, error) {
+	key := subscriptionCacheKey(id)
+	var cached models.Subscription
+	if r.cache.get(ctx, key, &cached) {
+		return &cached, nil
+	}
+
+	subscription, err := r.SubscriptionRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.set(ctx, key, subscription)
+	return subscription, nil
+}
+
+func (r *cachedSubscriptionRepository) GetByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.Subscription, error) {
+	key := userSubscriptionsCacheKey(userID)
+	var cached cachedSubscriptions
+	if r.cache.get(ctx, key, &cached) {
+		return cached.Items, nil
+	}
+
+	subscriptions, err := r.SubscriptionRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.set(ctx, key, cachedSubscriptions{subscriptions})
+	return subscriptions, nil
+}
+
+func (r *cachedSubscriptionRepository) Create(ctx context.Context, subscription *models.Subscription) (*models.Subscription, error) {
+	res, err := r.SubscriptionRepository.Create(ctx, subscription)
+	r.cache.del(ctx, userSubscriptionsCacheKey(subscription.UserID))
+	return res, err
+}
+
+func (r *cachedSubscriptionRepository) Update(ctx context.Context, subscription *models.Subscription) (*models.Subscription, error) {
+	res, err := r.SubscriptionRepository.Update(ctx, subscription)
+	r.invalidate(ctx, subscription.ID, subscription.UserID)
+	return res, err
+}
+
+func (r *cachedSubscriptionRepository) UpdatePartial(
+	ctx context.Context,
+	subscription *models.Subscription,
+	fields ...string,
+) (*models.Subscription, error) {
+	res, err := r.SubscriptionRepository.UpdatePartial(ctx, subscription, fields...)
+	r.invalidate(ctx, subscription.ID, subscription.UserID)
+	return res, err
+}
+
+func (r *cachedSubscriptionRepository) RecordUsage(
+	ctx context.Context,
+	id bson.ObjectID,
+	usedAt, updatedAt time.Time,
+) (*models.Subscription, error) {
+	res, err := r.SubscriptionRepository.RecordUsage(ctx, id, usedAt, updatedAt)
+	r.invalidateWritten(ctx, id, res)
+	return res, err
+}
+
+func (r *cachedSubscriptionRepository) TransitionStatus(
+	ctx context.Context,
+	id bson.ObjectID,
+	from, to models.Status,
+	updatedAt time.Time,
+	set ...bson.E,
+) (*models.Subscription, error) {
+	res, err := r.SubscriptionRepository.TransitionStatus(ctx, id, from, to, updatedAt, set...)
+	r.invalidateWritten(ctx, id, res)
+	return res, err
+}
+
+func (r *cachedSubscriptionRepository) AdvanceDunning(
+	ctx context.Context,
+	id bson.ObjectID,
+	attempts int,
+	updatedAt time.Time,
+) (*models.Subscription, error) {
+	res, err := r.SubscriptionRepository.AdvanceDunning(ctx, id, attempts, updatedAt)
+	r.invalidateWritten(ctx, id, res)
+	return res, err
+}
+
+func (r *cachedSubscriptionRepository) SetPaymentMethod(
+	ctx context.Context,
+	id bson.ObjectID,
+	paymentMethodID *bson.ObjectID,
+	updatedAt time.Time,
+) (*models.Subscription, error) {
+	res, err := r.SubscriptionRepository.SetPaymentMethod(ctx, id, paymentMethodID, updatedAt)
+	r.invalidateWritten(ctx, id, res)
+	return res, err
+}
+
+// UnlinkPaymentMethod does not know which subscriptions it changed, so it
+// invalidates every subscription of the user.
+func (r *cachedSubscriptionRepository) UnlinkPaymentMethod(
+	ctx context.Context,
+	userID, paymentMethodID bson.ObjectID,
+	updatedAt time.Time,
+) (int64, error) {
+	unlinked, err := r.SubscriptionRepository.UnlinkPaymentMethod(ctx, userID, paymentMethodID, updatedAt)
+	if err != nil || unlinked == 0 {
+		return unlinked, err
+	}
+
+	keys := []string{userSubscriptionsCacheKey(userID)}
+	subscriptions, err := r.SubscriptionRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to list subscriptions to invalidate",
+			logattr.UserID(userID.Hex()),
+			logattr.Error(err))
+	}
+	for _, subscription := range subscriptions {
+		keys = append(keys, subscriptionCacheKey(subscription.ID))
+	}
+	r.cache.del(ctx, keys...)
+	return unlinked, nil
+}
+
+// Delete looks the subscription up first to invalidate its owner's listing.
+func (r *cachedSubscriptionRepository) Delete(ctx context.Context, id bson.ObjectID) error {
+	subscription, err := r.SubscriptionRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = r.SubscriptionRepository.Delete(ctx, id)
+	r.invalidate(ctx, id, subscription.UserID)
+	return err
+}
+
+// invalidateWritten invalidates a subscription a call wrote, along with the
+// listing of its owner when the call returned the subscription.
+func (r *cachedSubscriptionRepository) invalidateWritten(ctx context.Context, id bson.ObjectID, written *models.Subscription) {
+	if written == nil {
+		r.cache.del(ctx, subscriptionCacheKey(id))
+		return
+	}
+	r.invalidate(ctx, id, written.UserID)
+}
+
+func (r *cachedSubscriptionRepository) invalidate(ctx context.Context, id, userID bson.ObjectID) {
+	r.cache.del(ctx, subscriptionCacheKey(id), userSubscriptionsCacheKey(userID))
+}
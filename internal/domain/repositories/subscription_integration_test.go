@@ -4,6 +4,7 @@ package repositories_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -230,6 +231,183 @@ func TestSubscriptionRepository_GetByUserID(t *testing.T) {
 	})
 }
 
+// ---------------------------------------------------------------------------
+// List
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionRepository_List(t *testing.T) {
+	// Filters, sorts, and pages while counting every match
+	t.Run("returns the requested page of matching subscriptions", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+		cheap := validSub()
+		cheap.Price = 100
+		mid := validSub()
+		mid.Price = 500
+		pricey := validSub()
+		pricey.Price = 900
+		expired := validExpiredSub()
+		other := validSub()
+		other.UserID = bson.NewObjectID()
+
+		_, err := collection.InsertMany(
+			t.Context(), []*models.Subscription{mid, expired, pricey, other, cheap},
+		)
+		require.NoError(t, err)
+
+		query := &models.SubscriptionQuery{
+			UserID:     defaultUserID,
+			Status:     models.Active,
+			Sort:       models.SortByPrice,
+			Descending: true,
+			PageQuery:  models.PageQuery{Limit: 2, Offset: 1},
+		}
+		got, total, err := repo.List(t.Context(), query)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, []*models.Subscription{mid, cheap}, got)
+	})
+
+	t.Run("filters by tag", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+		work := validSub()
+		work.Tags = []string{"streaming", "work"}
+		personal := validSub()
+		personal.Tags = []string{"streaming"}
+		untagged := validSub()
+
+		_, err := collection.InsertMany(t.Context(), []*models.Subscription{work, personal, untagged})
+		require.NoError(t, err)
+
+		query := &models.SubscriptionQuery{UserID: defaultUserID, Tag: "work", PageQuery: models.PageQuery{Limit: 10}}
+		got, total, err := repo.List(t.Context(), query)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []*models.Subscription{work}, got)
+	})
+
+	// Error: Infrastructure failure / Timeout
+	t.Run("returns error when database operation fails", func(t *testing.T) {
+		repo, _ := newSubRepo(t)
+		ctx, cancel := context.WithDeadline(t.Context(), time.Now().Add(-1*time.Second))
+		defer cancel()
+
+		got, _, err := repo.List(ctx, &models.SubscriptionQuery{PageQuery: models.PageQuery{Limit: 1}})
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrTimeout)
+		assert.Nil(t, got)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Search
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionRepository_Search(t *testing.T) {
+	repo, collection := newSubRepo(t)
+	named := validSub()
+	named.Name = "Apple Music"
+	tagged := validSub()
+	tagged.Name = "Spotify"
+	tagged.Tags = []string{"music"}
+	noted := validSub()
+	noted.Name = "Audible"
+	noted.Notes = "Books and some music"
+	unrelated := validSub()
+	other := validSub()
+	other.Name = "Apple Music"
+	other.UserID = bson.NewObjectID()
+
+	_, err := collection.InsertMany(
+		t.Context(), []*models.Subscription{noted, unrelated, tagged, other, named},
+	)
+	require.NoError(t, err)
+
+	names := func(matches []*models.SubscriptionMatch) []string {
+		result := make([]string, len(matches))
+		for i, m := range matches {
+			result[i] = m.Name
+			assert.Positive(t, m.Score, m.Name)
+		}
+		return result
+	}
+
+	t.Run("ranks names above tags above notes", func(t *testing.T) {
+		search := &models.SubscriptionSearch{UserID: defaultUserID, Text: "music", PageQuery: models.PageQuery{Limit: 10}}
+		got, total, err := repo.Search(t.Context(), search)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, []string{"Apple Music", "Spotify", "Audible"}, names(got))
+		assert.Equal(t, named.ID, got[0].ID)
+	})
+
+	t.Run("pages and excludes words", func(t *testing.T) {
+		search := &models.SubscriptionSearch{UserID: defaultUserID, Text: "music -books", PageQuery: models.PageQuery{Limit: 1, Offset: 1}}
+		got, total, err := repo.Search(t.Context(), search)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"Spotify"}, names(got))
+	})
+
+	t.Run("searches every user without a user ID", func(t *testing.T) {
+		search := &models.SubscriptionSearch{Text: "apple", PageQuery: models.PageQuery{Limit: 10}}
+		got, total, err := repo.Search(t.Context(), search)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"Apple Music", "Apple Music"}, names(got))
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Count and Each
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionRepository_Each(t *testing.T) {
+	repo, collection := newSubRepo(t)
+	cheap := validSub()
+	cheap.Price = 100
+	pricey := validSub()
+	pricey.Price = 900
+	expired := validExpiredSub()
+	other := validSub()
+	other.UserID = bson.NewObjectID()
+
+	_, err := collection.InsertMany(
+		t.Context(), []*models.Subscription{pricey, expired, other, cheap},
+	)
+	require.NoError(t, err)
+
+	query := &models.SubscriptionQuery{
+		UserID:    defaultUserID,
+		Status:    models.Active,
+		Sort:      models.SortByPrice,
+		PageQuery: models.PageQuery{Limit: 1}, // Ignored.
+	}
+
+	t.Run("counts every match", func(t *testing.T) {
+		got, err := repo.Count(t.Context(), query)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), got)
+	})
+
+	t.Run("visits every match in order", func(t *testing.T) {
+		var got []*models.Subscription
+		err := repo.Each(t.Context(), query, func(sub *models.Subscription) error {
+			got = append(got, sub)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Subscription{cheap, pricey}, got)
+	})
+}
+
 // ---------------------------------------------------------------------------
 // GetActiveSubscriptions
 // ---------------------------------------------------------------------------
@@ -437,6 +615,28 @@ func TestSubscriptionRepository_GetSubscriptionsDueForReminder(t *testing.T) {
 		assert.Equal(t, sub1, got[0])
 	})
 
+	// Billing timezones
+	t.Run("widens the window for subscriptions with a billing timezone", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+		// Ten hours before the reminder day starts in UTC: already that day
+		// in UTC+14.
+		withZone := validSub()
+		withZone.Timezone = "Pacific/Kiritimati"
+		withZone.ValidTill = mockTomorrow.Add(-10 * time.Hour)
+		withoutZone := validSub()
+		withoutZone.ValidTill = mockTomorrow.Add(-10 * time.Hour)
+		_, err := collection.InsertMany(
+			t.Context(), []*models.Subscription{withZone, withoutZone},
+		)
+		require.NoError(t, err)
+
+		got, err := repo.GetSubscriptionsDueForReminder(t.Context(), []int{1}, mockTime)
+
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, withZone, got[0])
+	})
+
 	// Error: Infrastructure failure / Timeout
 	t.Run("returns error when database operation fails", func(t *testing.T) {
 		repo, _ := newSubRepo(t)
@@ -517,6 +717,128 @@ func TestSubscriptionRepository_GetSubscriptionsDueForRenewal(t *testing.T) {
 	})
 }
 
+// ---------------------------------------------------------------------------
+// EachDueForReminder / EachDueForRenewal
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionRepository_EachDueForReminder(t *testing.T) {
+	repo, collection := newSubRepo(t)
+	due := validSub()
+	due.ValidTill = mockToday.AddDate(0, 0, 3)
+	later := validSub()
+	later.ValidTill = mockToday.AddDate(0, 0, 15)
+	canceled := validCanceledSub()
+	canceled.ValidTill = due.ValidTill
+
+	_, err := collection.InsertMany(t.Context(), []*models.Subscription{due, later, canceled})
+	require.NoError(t, err)
+
+	var got []*models.Subscription
+	err = repo.EachDueForReminder(t.Context(), []int{3, 7}, mockTime, func(sub *models.Subscription) error {
+		got = append(got, sub)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Subscription{due}, got)
+
+	t.Run("widens the day of subscriptions without a billing timezone", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+		// Day 4 in the reference location, but day 3 where it is behind.
+		nextDay := validSub()
+		nextDay.ValidTill = mockToday.AddDate(0, 0, 4).Add(2 * time.Hour)
+		// Two days out even for the farthest timezone.
+		twoDays := validSub()
+		twoDays.ValidTill = mockToday.AddDate(0, 0, 5)
+
+		_, err := collection.InsertMany(t.Context(), []*models.Subscription{nextDay, twoDays})
+		require.NoError(t, err)
+
+		var got []*models.Subscription
+		err = repo.EachDueForReminder(t.Context(), []int{3}, mockTime, func(sub *models.Subscription) error {
+			got = append(got, sub)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Subscription{nextDay}, got)
+	})
+
+	t.Run("matches subscriptions on their own reminder days", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+		// Due on one of its own days, which is not scanned.
+		ownDay := validSub()
+		ownDay.ValidTill = mockToday.AddDate(0, 0, 14)
+		ownDay.ReminderDays = []int{14, 1}
+		// Due on a scanned day that is not one of its own.
+		scannedDay := validSub()
+		scannedDay.ValidTill = mockToday.AddDate(0, 0, 3)
+		scannedDay.ReminderDays = []int{14}
+		// Renews on the same day, but is only reminded the day before.
+		beyond := validSub()
+		beyond.ValidTill = mockToday.AddDate(0, 0, 14)
+		beyond.ReminderDays = []int{1}
+
+		_, err := collection.InsertMany(t.Context(), []*models.Subscription{ownDay, scannedDay, beyond})
+		require.NoError(t, err)
+
+		var got []*models.Subscription
+		err = repo.EachDueForReminder(t.Context(), []int{3}, mockTime, func(sub *models.Subscription) error {
+			got = append(got, sub)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Subscription{ownDay}, got)
+	})
+}
+
+func TestSubscriptionRepository_EachDueForRenewal(t *testing.T) {
+	repo, collection := newSubRepo(t)
+	first := validSub()
+	first.ValidTill = mockToday
+	second := validSub()
+	second.ValidTill = mockTomorrow
+	outside := validSub()
+
+	_, err := collection.InsertMany(t.Context(), []*models.Subscription{second, outside, first})
+	require.NoError(t, err)
+
+	t.Run("visits the window in renewal order", func(t *testing.T) {
+		var got []*models.Subscription
+		err := repo.EachDueForRenewal(t.Context(), mockToday, mockTomorrow, func(sub *models.Subscription) error {
+			got = append(got, sub)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Subscription{first, second}, got)
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		stop := errors.New("stop")
+		visited := 0
+		err := repo.EachDueForRenewal(t.Context(), mockToday, mockTomorrow, func(*models.Subscription) error {
+			visited++
+			return stop
+		})
+
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, visited)
+	})
+
+	t.Run("returns error when database operation fails", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(t.Context(), time.Now().Add(-1*time.Second))
+		defer cancel()
+
+		err := repo.EachDueForRenewal(ctx, mockToday, mockTomorrow, func(*models.Subscription) error {
+			return nil
+		})
+
+		assertAppErrorCode(t, err, apperror.ErrTimeout)
+	})
+}
+
 // // ---------------------------------------------------------------------------
 // // GetCanceledExpiredSubscriptions
 // // ---------------------------------------------------------------------------
@@ -587,6 +909,280 @@ func TestSubscriptionRepository_GetCanceledExpiredSubscriptions(t *testing.T) {
 	})
 }
 
+// ---------------------------------------------------------------------------
+// GetExpiredBefore
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionRepository_GetExpiredBefore(t *testing.T) {
+	t.Run("returns only expired subs with valid_till before the cutoff", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+
+		target := validExpiredSub()
+		target.ValidTill = mockOneMonthAgo
+
+		// Decoy 1: Expired but within retention
+		decoyRecent := validExpiredSub()
+
+		// Decoy 2: Canceled long ago but not yet marked expired
+		decoyCanceled := validCanceledSub()
+		decoyCanceled.ValidTill = mockOneMonthAgo
+
+		_, err := collection.InsertMany(
+			t.Context(),
+			[]*models.Subscription{decoyRecent, target, decoyCanceled},
+		)
+		require.NoError(t, err)
+
+		got, err := repo.GetExpiredBefore(t.Context(), mockYesterday)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Subscription{target}, got)
+	})
+
+	// Error: Infrastructure failure / Timeout
+	t.Run("returns error when database operation fails", func(t *testing.T) {
+		repo, _ := newSubRepo(t)
+		ctx, cancel := context.WithDeadline(t.Context(), time.Now().Add(-1*time.Second))
+		defer cancel()
+
+		got, err := repo.GetExpiredBefore(ctx, mockTime)
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrTimeout)
+		assert.Nil(t, got)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GetActiveByUserIDRenewingBefore
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionRepository_GetActiveByUserIDRenewingBefore(t *testing.T) {
+	repo, collection := newSubRepo(t)
+	before := mockOneMonthLater.AddDate(0, 0, 1)
+
+	target := validSub()
+
+	// Decoy 1: Another user's subscription
+	decoyOtherUser := validSub()
+	decoyOtherUser.UserID = bson.NewObjectID()
+
+	// Decoy 2: Renews after the cutoff
+	decoyLater := validSub()
+	decoyLater.ValidTill = before
+
+	// Decoy 3: Canceled, so it never renews
+	decoyCanceled := validCanceledSub()
+
+	_, err := collection.InsertMany(
+		t.Context(),
+		[]*models.Subscription{decoyOtherUser, target, decoyLater, decoyCanceled},
+	)
+	require.NoError(t, err)
+
+	got, err := repo.GetActiveByUserIDRenewingBefore(t.Context(), defaultUserID, before)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Subscription{target}, got)
+}
+
+// ---------------------------------------------------------------------------
+// CountByStatus / SumActivePrices
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionRepository_CountByStatus(t *testing.T) {
+	repo, collection := newSubRepo(t)
+
+	_, err := collection.InsertMany(
+		t.Context(),
+		[]*models.Subscription{validSub(), validSub(), validCanceledSub(), validExpiredSub()},
+	)
+	require.NoError(t, err)
+
+	got, err := repo.CountByStatus(t.Context())
+
+	require.NoError(t, err)
+	assert.Equal(t, map[models.Status]int64{
+		models.Active:   2,
+		models.Canceled: 1,
+		models.Expired:  1,
+	}, got)
+}
+
+func TestSubscriptionRepository_SumActivePrices(t *testing.T) {
+	repo, collection := newSubRepo(t)
+
+	yearly := validSub()
+	yearly.Frequency = models.Yearly
+	yearly.Price = 11999
+
+	euro := validSub()
+	euro.Currency = models.EUR
+	euro.Price = 500
+
+	// Decoy: canceled subscriptions bring in nothing
+	decoy := validCanceledSub()
+
+	_, err := collection.InsertMany(
+		t.Context(),
+		[]*models.Subscription{validSub(), validSub(), yearly, euro, decoy},
+	)
+	require.NoError(t, err)
+
+	got, err := repo.SumActivePrices(t.Context())
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*models.PriceTotal{
+		{Currency: models.USD, Frequency: models.Monthly, Total: 1998},
+		{Currency: models.USD, Frequency: models.Yearly, Total: 11999},
+		{Currency: models.EUR, Frequency: models.Monthly, Total: 500},
+	}, got)
+}
+
+// ---------------------------------------------------------------------------
+// Summarize
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionRepository_Summarize(t *testing.T) {
+	repo, collection := newSubRepo(t)
+	renewTo := mockOneMonthLater.AddDate(0, 0, 1)
+
+	yearly := validSub()
+	yearly.Frequency = models.Yearly
+	yearly.Price = 11999
+	yearly.ValidTill = renewTo // Renews after the window
+	yearly.Tags = []string{"work"}
+
+	news := validSub()
+	news.Category = models.News
+	news.Currency = models.EUR
+	news.Price = 500
+	news.Tags = []string{"work", "reading"}
+
+	// Decoy 1: Another user's subscription
+	decoyOtherUser := validSub()
+	decoyOtherUser.UserID = bson.NewObjectID()
+
+	// Decoy 2: Canceled, so it is counted but neither spends nor renews
+	decoyCanceled := validCanceledSub()
+	decoyCanceled.Tags = []string{"work"}
+
+	netflix := validSub()
+	_, err := collection.InsertMany(
+		t.Context(),
+		[]*models.Subscription{netflix, yearly, news, decoyOtherUser, decoyCanceled},
+	)
+	require.NoError(t, err)
+
+	got, err := repo.Summarize(t.Context(), defaultUserID, mockToday, renewTo)
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []models.StatusCount{
+		{Status: models.Active, Count: 3},
+		{Status: models.Canceled, Count: 1},
+	}, got.ByStatus)
+	assert.ElementsMatch(t, []models.CategorySpendTotal{
+		{Category: models.Entertainment, Currency: models.USD, Frequency: models.Monthly, Total: 999},
+		{Category: models.Entertainment, Currency: models.USD, Frequency: models.Yearly, Total: 11999},
+		{Category: models.News, Currency: models.EUR, Frequency: models.Monthly, Total: 500},
+	}, got.Spend)
+	assert.ElementsMatch(t, []models.TagSpendTotal{
+		{Tag: "work", Currency: models.USD, Frequency: models.Yearly, Total: 11999, Count: 1},
+		{Tag: "work", Currency: models.EUR, Frequency: models.Monthly, Total: 500, Count: 1},
+		{Tag: "reading", Currency: models.EUR, Frequency: models.Monthly, Total: 500, Count: 1},
+	}, got.TagSpend)
+	require.Len(t, got.Upcoming, 2)
+	assert.ElementsMatch(t, []bson.ObjectID{netflix.ID, news.ID}, []bson.ObjectID{got.Upcoming[0].ID, got.Upcoming[1].ID})
+}
+
+// ---------------------------------------------------------------------------
+// GetUnusedDueForRenewal
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionRepository_GetUnusedDueForRenewal(t *testing.T) {
+	repo, collection := newSubRepo(t)
+	renewTo := mockToday.AddDate(0, 0, 7)
+	unusedSince := mockOneMonthAgo
+	longAgo := mockOneMonthAgo.AddDate(0, 0, -1)
+	recently := mockYesterday
+
+	// Target 1: Last used before the cutoff, renewing within the window
+	staleUse := validSub()
+	staleUse.ValidTill = mockToday.AddDate(0, 0, 3)
+	staleUse.LastUsedAt = &longAgo
+
+	// Target 2: Never used, created before the cutoff
+	neverUsed := validSub()
+	neverUsed.ValidTill = mockToday.AddDate(0, 0, 5)
+	neverUsed.CreatedAt = longAgo
+
+	// Decoy 1: Used recently
+	decoyUsed := validSub()
+	decoyUsed.ValidTill = mockToday.AddDate(0, 0, 3)
+	decoyUsed.LastUsedAt = &recently
+
+	// Decoy 2: Never used but created after the cutoff
+	decoyNew := validSub()
+	decoyNew.ValidTill = mockToday.AddDate(0, 0, 3)
+	decoyNew.CreatedAt = mockYesterday
+
+	// Decoy 3: Unused but renewing after the window
+	decoyLater := validSub()
+	decoyLater.LastUsedAt = &longAgo
+
+	// Decoy 4: Unused but already canceled
+	decoyCanceled := validCanceledSub()
+	decoyCanceled.ValidTill = mockToday.AddDate(0, 0, 3)
+	decoyCanceled.LastUsedAt = &longAgo
+
+	_, err := collection.InsertMany(
+		t.Context(),
+		[]*models.Subscription{staleUse, decoyUsed, neverUsed, decoyNew, decoyLater, decoyCanceled},
+	)
+	require.NoError(t, err)
+
+	got, err := repo.GetUnusedDueForRenewal(t.Context(), mockToday, renewTo, unusedSince)
+
+	require.NoError(t, err)
+	gotIDs := make([]bson.ObjectID, 0, len(got))
+	for _, sub := range got {
+		gotIDs = append(gotIDs, sub.ID)
+	}
+	assert.ElementsMatch(t, []bson.ObjectID{staleUse.ID, neverUsed.ID}, gotIDs)
+}
+
+// ---------------------------------------------------------------------------
+// RecordUsage
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionRepository_RecordUsage(t *testing.T) {
+	t.Run("only moves last_used_at forward", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+		sub := validSub()
+		_, err := collection.InsertOne(t.Context(), sub)
+		require.NoError(t, err)
+
+		got, err := repo.RecordUsage(t.Context(), sub.ID, mockYesterday, mockTime)
+		require.NoError(t, err)
+		require.NotNil(t, got.LastUsedAt)
+		assert.True(t, mockYesterday.Equal(*got.LastUsedAt))
+
+		// An older, out-of-order report does not overwrite the newer one.
+		got, err = repo.RecordUsage(t.Context(), sub.ID, mockOneMonthAgo, mockTime)
+		require.NoError(t, err)
+		assert.True(t, mockYesterday.Equal(*got.LastUsedAt))
+	})
+
+	t.Run("returns not found for a missing subscription", func(t *testing.T) {
+		repo, _ := newSubRepo(t)
+
+		got, err := repo.RecordUsage(t.Context(), bson.NewObjectID(), mockYesterday, mockTime)
+
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+		assert.Nil(t, got)
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Update
 // ---------------------------------------------------------------------------
@@ -604,13 +1200,15 @@ func TestSubscriptionRepository_Update(t *testing.T) {
 		target.Status = models.Canceled
 		target.Price = 0
 
-		_, err = repo.Update(t.Context(), target)
+		got, err := repo.Update(t.Context(), target)
 
 		require.NoError(t, err)
+		assert.Equal(t, int64(1), got.Version, "Update must return the next version")
+		assert.Zero(t, target.Version, "Update must not mutate its argument")
 		updatedTarget := &models.Subscription{}
 		err = collection.FindOne(t.Context(), bson.M{"_id": target.ID}).Decode(updatedTarget)
 		require.NoError(t, err)
-		assert.Equal(t, target, updatedTarget)
+		assert.Equal(t, got, updatedTarget)
 
 		// Vault Lock: Prove Decoy was completely untouched
 		untouchedDecoy := &models.Subscription{}
@@ -632,12 +1230,243 @@ func TestSubscriptionRepository_Update(t *testing.T) {
 		assertAppErrorCode(t, err, apperror.ErrNotFound)
 		assert.Nil(t, got)
 	})
+
+	t.Run("conflict - a stale version does not overwrite a newer write", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+
+		original := validSub()
+		_, err := collection.InsertOne(t.Context(), original)
+		require.NoError(t, err)
+
+		// Two writers read version 0; the renewal wins.
+		renewed := *original
+		renewed.ValidTill = original.ValidTill.AddDate(0, 1, 0)
+		_, err = repo.Update(t.Context(), &renewed)
+		require.NoError(t, err)
+
+		canceled := *original
+		canceled.Status = models.Canceled
+		got, err := repo.Update(t.Context(), &canceled)
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrConflict)
+		assert.Nil(t, got)
+
+		stored := &models.Subscription{}
+		require.NoError(t, collection.FindOne(t.Context(), bson.M{"_id": original.ID}).Decode(stored))
+		assert.Equal(t, models.Active, stored.Status)
+		assert.True(t, stored.ValidTill.Equal(renewed.ValidTill), "the renewal must survive")
+	})
+
+	t.Run("versioned writes - atomic updates advance the version", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+
+		target := validSub()
+		_, err := collection.InsertOne(t.Context(), target)
+		require.NoError(t, err)
+
+		_, err = repo.TransitionStatus(t.Context(), target.ID, models.Active, models.Canceled, mockTime)
+		require.NoError(t, err)
+
+		_, err = repo.Update(t.Context(), target)
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrConflict)
+	})
+}
+
+func TestSubscriptionRepository_UpdatePartial(t *testing.T) {
+	t.Run("success - writes only the masked fields", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+
+		target := validSub()
+		target.Notes = "shared with family"
+		target.Tags = []string{"work"}
+		_, err := collection.InsertOne(t.Context(), target)
+		require.NoError(t, err)
+
+		// A stale copy: the price it carries must not be written back.
+		edited := *target
+		edited.Price = 1
+		edited.Name = "Netflix Premium"
+		edited.Tags = nil
+		edited.UpdatedAt = mockTime.Add(time.Hour)
+
+		got, err := repo.UpdatePartial(t.Context(), &edited, "name", "tags")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Netflix Premium", got.Name)
+		assert.Nil(t, got.Tags, "an empty field must be unset")
+		assert.Equal(t, target.Price, got.Price, "unmasked fields must be kept")
+		assert.Equal(t, "shared with family", got.Notes)
+		assert.True(t, got.UpdatedAt.Equal(edited.UpdatedAt))
+		assert.Equal(t, int64(1), got.Version)
+
+		raw, err := collection.FindOne(t.Context(), bson.M{"_id": target.ID}).Raw()
+		require.NoError(t, err)
+		_, err = raw.LookupErr("tags")
+		assert.Error(t, err, "tags must be removed from the document")
+	})
+
+	t.Run("conflict - a stale version is rejected", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+
+		target := validSub()
+		_, err := collection.InsertOne(t.Context(), target)
+		require.NoError(t, err)
+		_, err = repo.RecordUsage(t.Context(), target.ID, mockTime, mockTime)
+		require.NoError(t, err)
+
+		target.Name = "Renamed"
+		got, err := repo.UpdatePartial(t.Context(), target, "name")
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrConflict)
+		assert.Nil(t, got)
+	})
+
+	t.Run("not found - missing subscription", func(t *testing.T) {
+		repo, _ := newSubRepo(t)
+
+		got, err := repo.UpdatePartial(t.Context(), validSub(), "name")
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+		assert.Nil(t, got)
+	})
 }
 
 // ---------------------------------------------------------------------------
 // Delete
 // ---------------------------------------------------------------------------
 
+func TestSubscriptionRepository_TransitionStatus(t *testing.T) {
+	t.Run("success - moves status and returns the updated document", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+
+		target := validSub()
+		_, err := collection.InsertOne(t.Context(), target)
+		require.NoError(t, err)
+
+		updatedAt := mockTime.Add(time.Hour)
+		got, err := repo.TransitionStatus(t.Context(), target.ID, models.Active, models.Canceled, updatedAt)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.Canceled, got.Status)
+		assert.True(t, got.UpdatedAt.Equal(updatedAt))
+		assert.True(t, got.ValidTill.Equal(target.ValidTill), "unrelated fields must be preserved")
+	})
+
+	t.Run("success - writes the extra fields with the status", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+
+		target := validSub()
+		_, err := collection.InsertOne(t.Context(), target)
+		require.NoError(t, err)
+
+		got, err := repo.TransitionStatus(t.Context(), target.ID, models.Active, models.Paused, mockTime,
+			bson.E{Key: "paused_at", Value: mockTime})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.Paused, got.Status)
+		require.NotNil(t, got.PausedAt)
+		assert.True(t, got.PausedAt.Equal(mockTime))
+	})
+
+	t.Run("conflict - only one of two racing transitions wins", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+
+		target := validCanceledSub()
+		_, err := collection.InsertOne(t.Context(), target)
+		require.NoError(t, err)
+
+		_, err = repo.TransitionStatus(t.Context(), target.ID, models.Canceled, models.Expired, mockTime)
+		require.NoError(t, err)
+
+		got, err := repo.TransitionStatus(t.Context(), target.ID, models.Canceled, models.Expired, mockTime)
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrConflict)
+		assert.Nil(t, got)
+	})
+
+	t.Run("not found - unknown id returns not-found error", func(t *testing.T) {
+		repo, _ := newSubRepo(t)
+
+		got, err := repo.TransitionStatus(t.Context(), bson.NewObjectID(), models.Active, models.Canceled, mockTime)
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSubscriptionRepository_AdvanceDunning(t *testing.T) {
+	t.Run("success - counts the next attempt once", func(t *testing.T) {
+		repo, collection := newSubRepo(t)
+
+		target := validSub()
+		target.Status = models.PastDue
+		target.Dunning = &models.Dunning{BillID: bson.NewObjectID(), StartedAt: mockTime}
+		suspended := validSub()
+		suspended.Status = models.Suspended
+		suspended.Dunning = &models.Dunning{BillID: bson.NewObjectID(), StartedAt: mockTime, Attempts: 3}
+		decoy := validSub()
+		_, err := collection.InsertMany(t.Context(), []any{target, suspended, decoy})
+		require.NoError(t, err)
+
+		pastDue, err := repo.GetPastDue(t.Context())
+		require.NoError(t, err)
+		require.Len(t, pastDue, 2)
+		assert.ElementsMatch(t, []bson.ObjectID{target.ID, suspended.ID}, []bson.ObjectID{pastDue[0].ID, pastDue[1].ID})
+
+		got, err := repo.AdvanceDunning(t.Context(), target.ID, 1, mockTomorrow)
+		require.NoError(t, err)
+		assert.Equal(t, 1, got.Dunning.Attempts)
+		assert.True(t, got.Dunning.StartedAt.Equal(mockTime), "dunning start must be preserved")
+
+		_, err = repo.AdvanceDunning(t.Context(), target.ID, 1, mockTomorrow)
+		assertAppErrorCode(t, err, apperror.ErrConflict)
+	})
+}
+
+func TestSubscriptionRepository_PaymentMethod(t *testing.T) {
+	repo, collection := newSubRepo(t)
+	methodID := bson.NewObjectID()
+
+	first, second, other := validSub(), validSub(), validSub()
+	foreign := validSub()
+	foreign.UserID = bson.NewObjectID()
+	foreign.PaymentMethodID = &methodID
+	_, err := collection.InsertMany(t.Context(), []any{first, second, other, foreign})
+	require.NoError(t, err)
+
+	for _, sub := range []*models.Subscription{first, second} {
+		got, err := repo.SetPaymentMethod(t.Context(), sub.ID, &methodID, mockTomorrow)
+		require.NoError(t, err)
+		require.NotNil(t, got.PaymentMethodID)
+		assert.Equal(t, methodID, *got.PaymentMethodID)
+	}
+
+	got, err := repo.SetPaymentMethod(t.Context(), second.ID, nil, mockTomorrow)
+	require.NoError(t, err)
+	assert.Nil(t, got.PaymentMethodID)
+
+	_, err = repo.SetPaymentMethod(t.Context(), first.ID, &methodID, mockTomorrow)
+	require.NoError(t, err)
+	unlinked, err := repo.UnlinkPaymentMethod(t.Context(), defaultUserID, methodID, mockTomorrow)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), unlinked)
+
+	stored, err := repo.GetByID(t.Context(), first.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.PaymentMethodID)
+
+	// Another user's subscription naming the same method is left alone.
+	stored, err = repo.GetByID(t.Context(), foreign.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, stored.PaymentMethodID)
+}
+
 func TestSubscriptionRepository_Delete(t *testing.T) {
 	t.Run("success - deletes exact document and leaves others untouched", func(t *testing.T) {
 		repo, collection := newSubRepo(t)
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// SuppressionRepository stores the email addresses no email is sent to, one
+// document per address.
+type SuppressionRepository interface {
+	// Upsert adds the address of the suppression, or replaces the reason
+	// it is already suppressed for.
+	Upsert(context.Context, *models.Suppression) error
+	Exists(ctx context.Context, email string) (bool, error)
+	// List returns a page of suppressions, newest first, and the total
+	// number of suppressions.
+	List(ctx context.Context, query *models.SuppressionQuery) ([]*models.Suppression, int64, error)
+	Delete(ctx context.Context, email string) error
+}
+
+type suppressionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSuppressionRepository creates a SuppressionRepository. Documents are
+// keyed by address, which is what every send looks up.
+func NewSuppressionRepository(ctx context.Context, db *mongo.Database) (SuppressionRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("suppressions")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Suppression repository initialized and index verified")
+
+	return &suppressionRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *suppressionRepository) Upsert(ctx context.Context, suppression *models.Suppression) error {
+	filter := bson.M{"_id": suppression.Email}
+	opts := options.Replace().SetUpsert(true)
+
+	if _, err := r.collection.ReplaceOne(ctx, filter, suppression, opts); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return apperror.NewTimeoutError(err)
+		}
+		return apperror.NewDBError(err)
+	}
+	return nil
+}
+
+func (r *suppressionRepository) Exists(ctx context.Context, email string) (bool, error) {
+	count, err := lib.Count(ctx, r.collection, bson.M{"_id": email}, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+func (r *suppressionRepository) List(
+	ctx context.Context,
+	query *models.SuppressionQuery,
+) ([]*models.Suppression, int64, error) {
+	filter := bson.M{}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+
+	suppressions, err := lib.FindMany[models.Suppression](ctx, r.collection, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := lib.Count(ctx, r.collection, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return suppressions, total, nil
+}
+
+func (r *suppressionRepository) Delete(ctx context.Context, email string) error {
+	return lib.Delete(ctx, r.collection, bson.M{"_id": email})
+}
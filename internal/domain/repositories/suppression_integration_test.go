@@ -0,0 +1,104 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newSuppressionRepo(t *testing.T) repositories.SuppressionRepository {
+	t.Helper()
+
+	dbName := "suppression_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repositories.NewSuppressionRepository(ctx, db)
+	require.NoError(t, err, "NewSuppressionRepository should not error")
+	return repo
+}
+
+func TestSuppressionRepository_Upsert(t *testing.T) {
+	repo := newSuppressionRepo(t)
+
+	require.NoError(t, repo.Upsert(t.Context(), &models.Suppression{
+		Email:     "gone@example.com",
+		Reason:    models.SuppressionBounced,
+		CreatedAt: mockOneMonthAgo,
+	}))
+	require.NoError(t, repo.Upsert(t.Context(), &models.Suppression{
+		Email:     "gone@example.com",
+		Reason:    models.SuppressionComplained,
+		CreatedAt: mockTime,
+	}))
+
+	exists, err := repo.Exists(t.Context(), "gone@example.com")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = repo.Exists(t.Context(), "here@example.com")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	got, total, err := repo.List(t.Context(), &models.SuppressionQuery{PageQuery: models.PageQuery{Limit: 10}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	require.Len(t, got, 1)
+	assert.Equal(t, models.SuppressionComplained, got[0].Reason)
+}
+
+func TestSuppressionRepository_List(t *testing.T) {
+	repo := newSuppressionRepo(t)
+
+	for i, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		require.NoError(t, repo.Upsert(t.Context(), &models.Suppression{
+			Email:     email,
+			Reason:    models.SuppressionBounced,
+			CreatedAt: mockTime.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+
+	got, total, err := repo.List(t.Context(), &models.SuppressionQuery{PageQuery: models.PageQuery{Limit: 2, Offset: 1}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, total)
+	require.Len(t, got, 2)
+	assert.Equal(t, "b@example.com", got[0].Email)
+	assert.Equal(t, "a@example.com", got[1].Email)
+}
+
+func TestSuppressionRepository_Delete(t *testing.T) {
+	repo := newSuppressionRepo(t)
+	require.NoError(t, repo.Upsert(t.Context(), &models.Suppression{
+		Email:     "gone@example.com",
+		Reason:    models.SuppressionBounced,
+		CreatedAt: mockTime,
+	}))
+
+	require.NoError(t, repo.Delete(t.Context(), "gone@example.com"))
+
+	exists, err := repo.Exists(t.Context(), "gone@example.com")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	err = repo.Delete(t.Context(), "gone@example.com")
+	appErr, ok := errors.AsType[apperror.AppError](err)
+	require.True(t, ok, "expected an AppError, got %v", err)
+	assert.Equal(t, apperror.ErrNotFound, appErr.Code())
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.opentelemetry.io/otel"
 )
 
 type TxnFn func(ctx context.Context, fn func(ctx context.Context) error) error
@@ -23,7 +24,29 @@ func NewTxnExecutor(client *mongo.Client) TxnExecutor {
 	return &mongoTxnExecutor{client: client}
 }
 
-func (e *mongoTxnExecutor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+// NewNonTxnExecutor returns an executor that runs fn without a transaction,
+// for standalone servers. Writes made before a failure are not rolled back.
+func NewNonTxnExecutor() TxnExecutor {
+	return nonTxnExecutor{}
+}
+
+type nonTxnExecutor struct{}
+
+func (nonTxnExecutor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (e *mongoTxnExecutor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	// Commands inside fn are traced by the driver monitor; the span groups
+	// them, including retries of the whole transaction.
+	ctx, span := otel.Tracer("subscription-management/repositories").Start(ctx, "Mongo Transaction")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}()
+
 	session, err := e.client.StartSession()
 	if err != nil {
 		return apperror.NewInternalError(err)
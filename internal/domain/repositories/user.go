@@ -20,6 +20,7 @@ type UserRepository interface {
 	FindByEmail(context.Context, string) (*models.User, error)
 	FindByID(context.Context, bson.ObjectID) (*models.User, error)
 	GetAll(context.Context) ([]*models.User, error)
+	Count(context.Context) (int64, error)
 	Update(ctx context.Context, user *models.User) (*models.User, error)
 	Delete(ctx context.Context, id bson.ObjectID) error
 }
@@ -29,7 +30,8 @@ type userRepository struct {
 }
 
 func NewUserRepository(ctx context.Context, db *mongo.Database) (UserRepository, error) {
-	// Create a unique index for the email field
+	// Create a unique index for the email field. Emails are stored normalized
+	// (see models.NormalizeEmail), which makes the index case-insensitive.
 	indexModel := mongo.IndexModel{
 		Keys:    bson.D{{Key: "email", Value: 1}},
 		Options: options.Index().SetUnique(true),
@@ -51,6 +53,8 @@ func NewUserRepository(ctx context.Context, db *mongo.Database) (UserRepository,
 
 // Create adds a new user to the database from a signup request
 func (uc *userRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	user.Email = models.NormalizeEmail(user.Email)
+
 	// Insert into database
 	if err := lib.Create(ctx, uc.collection, user); err != nil {
 		if appErr, ok := errors.AsType[apperror.AppError](err); ok &&
@@ -64,7 +68,7 @@ func (uc *userRepository) Create(ctx context.Context, user *models.User) (*model
 }
 
 func (uc *userRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
-	filter := bson.M{"email": email}
+	filter := bson.M{"email": models.NormalizeEmail(email)}
 	return lib.FindOne[models.User](ctx, uc.collection, filter)
 }
 
@@ -77,7 +81,13 @@ func (uc *userRepository) GetAll(ctx context.Context) ([]*models.User, error) {
 	return lib.FindMany[models.User](ctx, uc.collection, bson.M{})
 }
 
+func (uc *userRepository) Count(ctx context.Context) (int64, error) {
+	return lib.Count(ctx, uc.collection, bson.M{})
+}
+
 func (uc *userRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	user.Email = models.NormalizeEmail(user.Email)
+
 	filter := bson.M{"_id": user.ID}
 	if err := lib.Update(ctx, uc.collection, filter, user); err != nil {
 		if appErr, ok := errors.AsType[apperror.AppError](err); ok &&
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// cachedUserRepository caches users by ID in front of another
+// UserRepository. Updates and deletes through it invalidate the user.
+type cachedUserRepository struct {
+	UserRepository
+	cache readCache
+}
+
+// NewCachedUserRepository returns a UserRepository that serves FindByID from
+// Redis for up to ttl.
+func NewCachedUserRepository(
+	repository UserRepository,
+	client redis.UniversalClient,
+	ttl time.Duration,
+) UserRepository {
+	return &cachedUserRepository{
+		repository,
+		readCache{client, ttl},
+	}
+}
+
+func userCacheKey(id bson.ObjectID) string {
+	return cachePrefix + "user:" + id.Hex()
+}
+
+func (r *cachedUserRepository) FindByID(ctx context.Context, id bson.ObjectID) (*models.User, error) {
+	key := userCacheKey(id)
+	var cached models.User
+	if r.cache.get(ctx, key, &cached) {
+		return &cached, nil
+	}
+
+	user, err := r.UserRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.set(ctx, key, user)
+	return user, nil
+}
+
+func (r *cachedUserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	res, err := r.UserRepository.Update(ctx, user)
+	r.cache.del(ctx, userCacheKey(user.ID))
+	return res, err
+}
+
+func (r *cachedUserRepository) Delete(ctx context.Context, id bson.ObjectID) error {
+	err := r.UserRepository.Delete(ctx, id)
+	r.cache.del(ctx, userCacheKey(id))
+	return err
+}
@@ -92,6 +92,36 @@ func TestUserRepository_Create(t *testing.T) {
 		assert.Nil(t, got)
 	})
 
+	t.Run("error - email differing only in case returns conflict", func(t *testing.T) {
+		repo, _ := newUserRepo(t)
+
+		_, err := repo.Create(t.Context(), validUser())
+		require.NoError(t, err)
+
+		user2 := validUser()
+		user2.Email = "  EMAIL@Gmail.com "
+		got, err := repo.Create(t.Context(), user2)
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrConflict)
+		assert.Nil(t, got)
+	})
+
+	t.Run("success - email is stored normalized", func(t *testing.T) {
+		repo, collection := newUserRepo(t)
+		user := validUser()
+		user.Email = "Mixed.Case@Gmail.com"
+
+		_, err := repo.Create(t.Context(), user)
+		require.NoError(t, err)
+
+		savedUser := &models.User{}
+		err = collection.FindOne(t.Context(), bson.M{"_id": user.ID}).Decode(savedUser)
+
+		require.NoError(t, err)
+		assert.Equal(t, "mixed.case@gmail.com", savedUser.Email)
+	})
+
 	// Error: Infrastructure failure / Timeout
 	t.Run("returns error when database operation fails", func(t *testing.T) {
 		repo, _ := newUserRepo(t)
@@ -129,6 +159,19 @@ func TestUserRepository_FindByEmail(t *testing.T) {
 		assert.Equal(t, target, got)
 	})
 
+	t.Run("success - lookup is case-insensitive", func(t *testing.T) {
+		repo, collection := newUserRepo(t)
+
+		target := validUser()
+		_, err := collection.InsertOne(t.Context(), target)
+		require.NoError(t, err)
+
+		got, err := repo.FindByEmail(t.Context(), "Email@GMAIL.com")
+
+		require.NoError(t, err)
+		assert.Equal(t, target, got)
+	})
+
 	t.Run("error - not found returns not-found error", func(t *testing.T) {
 		repo, collection := newUserRepo(t)
 		noise := validUser()
@@ -215,6 +258,21 @@ func TestUserRepository_GetAll(t *testing.T) {
 	})
 }
 
+func TestUserRepository_Count(t *testing.T) {
+	repo, collection := newUserRepo(t)
+	user1 := validUser()
+	user2 := validUser()
+	user2.Email = "user2@abc.com"
+
+	_, err := collection.InsertMany(t.Context(), []*models.User{user1, user2})
+	require.NoError(t, err)
+
+	got, err := repo.Count(t.Context())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+}
+
 // ---------------------------------------------------------------------------
 // Update
 // ---------------------------------------------------------------------------
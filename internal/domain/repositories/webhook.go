@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// WebhookRepository stores the webhooks users register for their events.
+type WebhookRepository interface {
+	Create(context.Context, *models.Webhook) (*models.Webhook, error)
+	GetByID(context.Context, bson.ObjectID) (*models.Webhook, error)
+	GetByUserID(context.Context, bson.ObjectID) ([]*models.Webhook, error)
+	// GetByUserIDAndEvent returns the webhooks of the user that receive
+	// events of the given type.
+	GetByUserIDAndEvent(ctx context.Context, userID bson.ObjectID, eventType models.EventType) ([]*models.Webhook, error)
+	CountByUserID(context.Context, bson.ObjectID) (int64, error)
+	Delete(context.Context, bson.ObjectID) error
+	// DeleteByUserID deletes the webhooks of the user and returns how many
+	// were deleted.
+	DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+type webhookRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookRepository(ctx context.Context, db *mongo.Database) (WebhookRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "events", Value: 1},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("webhooks")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Webhook repository initialized and index verified")
+
+	return &webhookRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	if err := lib.Create(ctx, r.collection, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id bson.ObjectID) (*models.Webhook, error) {
+	filter := bson.M{"_id": id}
+	return lib.FindOne[models.Webhook](ctx, r.collection, filter)
+}
+
+func (r *webhookRepository) GetByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.Webhook, error) {
+	filter := bson.M{"user_id": userID}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	return lib.FindMany[models.Webhook](ctx, r.collection, filter, opts)
+}
+
+func (r *webhookRepository) GetByUserIDAndEvent(
+	ctx context.Context,
+	userID bson.ObjectID,
+	eventType models.EventType,
+) ([]*models.Webhook, error) {
+	// Matching a scalar against an array field matches any element.
+	filter := bson.M{
+		"user_id": userID,
+		"events":  eventType,
+	}
+	return lib.FindMany[models.Webhook](ctx, r.collection, filter)
+}
+
+func (r *webhookRepository) CountByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	filter := bson.M{"user_id": userID}
+	return lib.Count(ctx, r.collection, filter)
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id bson.ObjectID) error {
+	filter := bson.M{"_id": id}
+	return lib.Delete(ctx, r.collection, filter)
+}
+
+func (r *webhookRepository) DeleteByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	return lib.DeleteMany(ctx, r.collection, bson.M{"user_id": userID})
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// webhookDeliveryRetention is how long delivery attempts are kept before
+// MongoDB's TTL monitor removes them. Attempts of deleted webhooks expire
+// the same way.
+const webhookDeliveryRetention = 30 * 24 * time.Hour
+
+// WebhookDeliveryRepository stores every attempt to deliver an event to a
+// webhook.
+type WebhookDeliveryRepository interface {
+	Create(context.Context, *models.WebhookDelivery) error
+	// GetByWebhookID returns the latest attempts for the webhook, newest
+	// first, optionally only those of one event type.
+	GetByWebhookID(ctx context.Context, webhookID bson.ObjectID, eventType models.EventType, limit int64) ([]*models.WebhookDelivery, error)
+	// DeleteByWebhookIDs deletes the attempts for the given webhooks and
+	// returns how many were deleted.
+	DeleteByWebhookIDs(ctx context.Context, webhookIDs []bson.ObjectID) (int64, error)
+}
+
+type webhookDeliveryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookDeliveryRepository(ctx context.Context, db *mongo.Database) (WebhookDeliveryRepository, error) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "webhook_id", Value: 1},
+				{Key: "event_type", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+		},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(webhookDeliveryRetention.Seconds())),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("webhook_deliveries")
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	slog.Debug("Webhook delivery repository initialized and index verified")
+
+	return &webhookDeliveryRepository{
+		collection: collection,
+	}, nil
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return lib.Create(ctx, r.collection, delivery)
+}
+
+func (r *webhookDeliveryRepository) GetByWebhookID(
+	ctx context.Context,
+	webhookID bson.ObjectID,
+	eventType models.EventType,
+	limit int64,
+) ([]*models.WebhookDelivery, error) {
+	filter := bson.M{"webhook_id": webhookID}
+	if eventType != "" {
+		filter["event_type"] = eventType
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(limit)
+
+	return lib.FindMany[models.WebhookDelivery](ctx, r.collection, filter, opts)
+}
+
+func (r *webhookDeliveryRepository) DeleteByWebhookIDs(ctx context.Context, webhookIDs []bson.ObjectID) (int64, error) {
+	filter := bson.M{"webhook_id": bson.M{"$in": webhookIDs}}
+	return lib.DeleteMany(ctx, r.collection, filter)
+}
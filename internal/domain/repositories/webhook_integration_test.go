@@ -0,0 +1,149 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func newWebhookDB(t *testing.T) *mongo.Database {
+	t.Helper()
+
+	dbName := "webhook_test_" + bson.NewObjectID().Hex()
+	db := mongoClient.Database(dbName)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+	return db
+}
+
+func webhookFor(userID bson.ObjectID, events ...models.EventType) *models.Webhook {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	return &models.Webhook{
+		ID:        bson.NewObjectID(),
+		UserID:    userID,
+		URL:       "https://example.com/hooks",
+		Secret:    "whsec_test",
+		Events:    events,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func TestWebhookRepository_GetByUserIDAndEvent(t *testing.T) {
+	repo, err := repositories.NewWebhookRepository(t.Context(), newWebhookDB(t))
+	require.NoError(t, err)
+	userID := bson.NewObjectID()
+
+	renewals := webhookFor(userID, models.SubscriptionRenewedEvent, models.SubscriptionExpiredEvent)
+	cancellations := webhookFor(userID, models.SubscriptionCanceledEvent)
+	otherUser := webhookFor(bson.NewObjectID(), models.SubscriptionRenewedEvent)
+	for _, w := range []*models.Webhook{renewals, cancellations, otherUser} {
+		_, err = repo.Create(t.Context(), w)
+		require.NoError(t, err)
+	}
+
+	got, err := repo.GetByUserIDAndEvent(t.Context(), userID, models.SubscriptionRenewedEvent)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, renewals.ID, got[0].ID)
+
+	count, err := repo.CountByUserID(t.Context(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestWebhookDeliveryRepository_GetByWebhookID(t *testing.T) {
+	repo, err := repositories.NewWebhookDeliveryRepository(t.Context(), newWebhookDB(t))
+	require.NoError(t, err)
+	webhookID := bson.NewObjectID()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	deliveries := []*models.WebhookDelivery{
+		{ID: bson.NewObjectID(), WebhookID: webhookID, EventType: models.BillFailedEvent, Attempt: 1, CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: bson.NewObjectID(), WebhookID: webhookID, EventType: models.BillFailedEvent, Attempt: 2, Succeeded: true, CreatedAt: now.Add(-time.Minute)},
+		{ID: bson.NewObjectID(), WebhookID: webhookID, EventType: models.SubscriptionRenewedEvent, Attempt: 1, Succeeded: true, CreatedAt: now},
+		{ID: bson.NewObjectID(), WebhookID: bson.NewObjectID(), EventType: models.BillFailedEvent, Attempt: 1, CreatedAt: now},
+	}
+	for _, d := range deliveries {
+		require.NoError(t, repo.Create(t.Context(), d))
+	}
+
+	t.Run("filters by event type, newest first", func(t *testing.T) {
+		got, err := repo.GetByWebhookID(t.Context(), webhookID, models.BillFailedEvent, 10)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, 2, got[0].Attempt)
+		assert.Equal(t, 1, got[1].Attempt)
+	})
+
+	t.Run("returns every type up to the limit", func(t *testing.T) {
+		got, err := repo.GetByWebhookID(t.Context(), webhookID, "", 2)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, models.SubscriptionRenewedEvent, got[0].EventType)
+	})
+}
+
+func TestWebhookRepository_DeleteByUserID(t *testing.T) {
+	repo, err := repositories.NewWebhookRepository(t.Context(), newWebhookDB(t))
+	require.NoError(t, err)
+	userID, otherID := bson.NewObjectID(), bson.NewObjectID()
+
+	for _, w := range []*models.Webhook{
+		webhookFor(userID, models.SubscriptionRenewedEvent),
+		webhookFor(userID, models.SubscriptionCanceledEvent),
+		webhookFor(otherID, models.SubscriptionRenewedEvent),
+	} {
+		_, err = repo.Create(t.Context(), w)
+		require.NoError(t, err)
+	}
+
+	deleted, err := repo.DeleteByUserID(t.Context(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	count, err := repo.CountByUserID(t.Context(), userID)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	count, err = repo.CountByUserID(t.Context(), otherID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count, "other users' webhooks are kept")
+}
+
+func TestWebhookDeliveryRepository_DeleteByWebhookIDs(t *testing.T) {
+	repo, err := repositories.NewWebhookDeliveryRepository(t.Context(), newWebhookDB(t))
+	require.NoError(t, err)
+	first, second, other := bson.NewObjectID(), bson.NewObjectID(), bson.NewObjectID()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	for _, webhookID := range []bson.ObjectID{first, first, second, other} {
+		require.NoError(t, repo.Create(t.Context(), &models.WebhookDelivery{
+			ID:        bson.NewObjectID(),
+			WebhookID: webhookID,
+			EventType: models.BillFailedEvent,
+			Attempt:   1,
+			CreatedAt: now,
+		}))
+	}
+
+	deleted, err := repo.DeleteByWebhookIDs(t.Context(), []bson.ObjectID{first, second})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	got, err := repo.GetByWebhookID(t.Context(), other, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, got, 1, "other webhooks' deliveries are kept")
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// emailDeliveriesPrefix prefixes the daily counters of sent and failed
+	// emails.
+	emailDeliveriesPrefix = "email_deliveries:"
+	// emailDeliveriesRetention is how long a daily counter is kept.
+	emailDeliveriesRetention = 8 * 24 * time.Hour
+)
+
+// EmailDeliveriesKey returns the Redis hash counting the emails sent and
+// failed on the UTC day of the given time.
+func EmailDeliveriesKey(day time.Time) string {
+	return emailDeliveriesPrefix + day.UTC().Format(time.DateOnly)
+}
+
+// notificationDeliveriesPrefix prefixes the daily counters of reminders sent
+// and failed per channel. They share the retention of the email counters.
+const notificationDeliveriesPrefix = "notification_deliveries:"
+
+// NotificationDeliveriesKey returns the Redis hash counting the reminders
+// sent and failed per channel on the UTC day of the given time. Fields are
+// "<channel>:sent" and "<channel>:failed".
+func NotificationDeliveriesKey(day time.Time) string {
+	return notificationDeliveriesPrefix + day.UTC().Format(time.DateOnly)
+}
+
+// QueueInspector reads the state of a task queue. *asynq.Inspector
+// satisfies it.
+type QueueInspector interface {
+	GetQueueInfo(queue string) (*asynq.QueueInfo, error)
+}
+
+type AnalyticsServiceExternal interface {
+	// GetPlatformStats summarizes users, subscriptions, renewals, email
+	// delivery, the task queue, and recurring revenue.
+	GetPlatformStats(ctx context.Context) (*models.PlatformStatsResponse, error)
+}
+
+type AnalyticsServiceInternal interface {
+	// RecordEmailDeliveryInternal counts an email as sent, or as failed when
+	// err is not nil.
+	RecordEmailDeliveryInternal(ctx context.Context, err error)
+	// RecordNotificationDeliveryInternal counts a reminder delivered through
+	// channel, or failed when err is not nil.
+	RecordNotificationDeliveryInternal(ctx context.Context, channel models.NotificationChannel, err error)
+}
+
+type AnalyticsService interface {
+	AnalyticsServiceExternal
+	AnalyticsServiceInternal
+}
+
+type analyticsService struct {
+	userRepository         repositories.UserRepository
+	subscriptionRepository repositories.SubscriptionRepository
+	lifecycleRepository    repositories.LifecycleEventRepository
+	redisClient            redis.UniversalClient
+	queueInspector         QueueInspector
+	queueName              string
+	getTime                clock.NowFn
+}
+
+// NewAnalyticsService creates a new instance of AnalyticsService.
+func NewAnalyticsService(
+	userRepository repositories.UserRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+	lifecycleRepository repositories.LifecycleEventRepository,
+	redisClient redis.UniversalClient,
+	queueInspector QueueInspector,
+	queueName string,
+	nowFn clock.NowFn,
+) AnalyticsService {
+	return &analyticsService{
+		userRepository,
+		subscriptionRepository,
+		lifecycleRepository,
+		redisClient,
+		queueInspector,
+		queueName,
+		nowFn,
+	}
+}
+
+// GetPlatformStats gathers the figures from MongoDB, the delivery counters in
+// Redis, and the queue inspector. The dashboard stays available while the
+// queue cannot be inspected; its figures are left out instead.
+func (s *analyticsService) GetPlatformStats(ctx context.Context) (*models.PlatformStatsResponse, error) {
+	now := s.getTime().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	totalUsers, err := s.userRepository.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byStatus, err := s.subscriptionRepository.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	renewals, err := s.lifecycleRepository.CountByTypeSince(ctx, models.LifecycleRenewed, today)
+	if err != nil {
+		return nil, err
+	}
+	priceTotals, err := s.subscriptionRepository.SumActivePrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	emails, err := s.emailDeliveries(ctx, today)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+	notifications, err := s.notificationDeliveries(ctx, today)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+
+	monthly := make(map[models.Currency]int64)
+	annual := make(map[models.Currency]int64)
+	for _, t := range priceTotals {
+		annual[t.Currency] += models.AnnualizedPrice(t.Total, t.Frequency)
+		if t.Frequency == models.Yearly {
+			monthly[t.Currency] += (t.Total + 6) / 12
+		} else {
+			monthly[t.Currency] += t.Total
+		}
+	}
+
+	return &models.PlatformStatsResponse{
+		GeneratedAt: now,
+		TotalUsers:  totalUsers,
+		Subscriptions: models.SubscriptionCounts{
+			Active:    byStatus[models.Active],
+			Canceled:  byStatus[models.Canceled],
+			Expired:   byStatus[models.Expired],
+			PastDue:   byStatus[models.PastDue],
+			Paused:    byStatus[models.Paused],
+			Suspended: byStatus[models.Suspended],
+		},
+		RenewalsToday:    renewals,
+		EmailsToday:      emails,
+		RemindersToday:   notifications,
+		Queue:            s.queueStats(ctx),
+		MonthlyRecurring: currencyAmounts(monthly),
+		AnnualRecurring:  currencyAmounts(annual),
+	}, nil
+}
+
+// RecordEmailDeliveryInternal increments the counter of the current day.
+// Counting is best effort: a failure is logged and the email is unaffected.
+func (s *analyticsService) RecordEmailDeliveryInternal(ctx context.Context, deliveryErr error) {
+	field := "sent"
+	if deliveryErr != nil {
+		field = "failed"
+	}
+
+	key := EmailDeliveriesKey(s.getTime())
+	_, err := s.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, key, field, 1)
+		pipe.Expire(ctx, key, emailDeliveriesRetention)
+		return nil
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to count email delivery",
+			logattr.Key(key),
+			logattr.Error(err),
+		)
+	}
+}
+
+// RecordNotificationDeliveryInternal increments the counter of the channel
+// for the current day. Like email counting, it is best effort.
+func (s *analyticsService) RecordNotificationDeliveryInternal(
+	ctx context.Context,
+	channel models.NotificationChannel,
+	deliveryErr error,
+) {
+	field := string(channel) + ":sent"
+	if deliveryErr != nil {
+		field = string(channel) + ":failed"
+	}
+
+	key := NotificationDeliveriesKey(s.getTime())
+	_, err := s.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, key, field, 1)
+		pipe.Expire(ctx, key, emailDeliveriesRetention)
+		return nil
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to count notification delivery",
+			logattr.Key(key),
+			logattr.Error(err),
+		)
+	}
+}
+
+// notificationDeliveries reads the per-channel reminder counters of the
+// given day.
+func (s *analyticsService) notificationDeliveries(
+	ctx context.Context,
+	day time.Time,
+) (map[models.NotificationChannel]models.DeliveryStats, error) {
+	counts, err := s.redisClient.HGetAll(ctx, NotificationDeliveriesKey(day)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification delivery counters: %w", err)
+	}
+
+	stats := make(map[models.NotificationChannel]models.DeliveryStats)
+	for field, value := range counts {
+		channel, outcome, ok := strings.Cut(field, ":")
+		if !ok {
+			continue
+		}
+		n, _ := strconv.ParseInt(value, 10, 64)
+		entry := stats[models.NotificationChannel(channel)]
+		switch outcome {
+		case "sent":
+			entry.Sent = n
+		case "failed":
+			entry.Failed = n
+		}
+		stats[models.NotificationChannel(channel)] = entry
+	}
+	return stats, nil
+}
+
+// emailDeliveries reads the delivery counters of the given day.
+func (s *analyticsService) emailDeliveries(ctx context.Context, day time.Time) (models.EmailDeliveryStats, error) {
+	var stats models.EmailDeliveryStats
+
+	counts, err := s.redisClient.HGetAll(ctx, EmailDeliveriesKey(day)).Result()
+	if err != nil {
+		return stats, fmt.Errorf("failed to read email delivery counters: %w", err)
+	}
+	stats.Sent, _ = strconv.ParseInt(counts["sent"], 10, 64)
+	stats.Failed, _ = strconv.ParseInt(counts["failed"], 10, 64)
+	return stats, nil
+}
+
+// queueStats inspects the task queue. It returns nil when the queue cannot
+// be inspected, e.g. before the first task was enqueued.
+func (s *analyticsService) queueStats(ctx context.Context) *models.QueueStats {
+	info, err := s.queueInspector.GetQueueInfo(s.queueName)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to inspect task queue",
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return nil
+	}
+
+	return &models.QueueStats{
+		Name:      info.Queue,
+		Pending:   info.Pending,
+		Active:    info.Active,
+		Scheduled: info.Scheduled,
+		Retry:     info.Retry,
+		Archived:  info.Archived,
+		Paused:    info.Paused,
+	}
+}
@@ -0,0 +1,149 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type analyticsMocks struct {
+	userRepo      *repomocks.MockUserRepository
+	subRepo       *repomocks.MockSubscriptionRepository
+	lifecycleRepo *repomocks.MockLifecycleEventRepository
+	inspector     *svcmocks.MockQueueInspector
+	rdb           *redis.Client
+}
+
+func setupAnalytics(t *testing.T) (services.AnalyticsService, analyticsMocks) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	m := analyticsMocks{
+		userRepo:      repomocks.NewMockUserRepository(t),
+		subRepo:       repomocks.NewMockSubscriptionRepository(t),
+		lifecycleRepo: repomocks.NewMockLifecycleEventRepository(t),
+		inspector:     svcmocks.NewMockQueueInspector(t),
+		rdb:           redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+	}
+	t.Cleanup(func() { _ = m.rdb.Close() })
+
+	svc := services.NewAnalyticsService(
+		m.userRepo,
+		m.subRepo,
+		m.lifecycleRepo,
+		m.rdb,
+		m.inspector,
+		"default",
+		func() time.Time { return mockTime },
+	)
+	return svc, m
+}
+
+// expectCounts registers the MongoDB lookups of GetPlatformStats.
+func (m analyticsMocks) expectCounts() {
+	m.userRepo.EXPECT().Count(mock.Anything).Return(12, nil).Once()
+	m.subRepo.EXPECT().CountByStatus(mock.Anything).Return(map[models.Status]int64{
+		models.Active:   30,
+		models.Canceled: 2,
+	}, nil).Once()
+	m.lifecycleRepo.EXPECT().CountByTypeSince(mock.Anything, models.LifecycleRenewed, mockToday).Return(4, nil).Once()
+	m.subRepo.EXPECT().SumActivePrices(mock.Anything).Return([]*models.PriceTotal{
+		{Currency: models.USD, Frequency: models.Monthly, Total: 2997},
+		{Currency: models.USD, Frequency: models.Yearly, Total: 11999},
+		{Currency: models.EUR, Frequency: models.Monthly, Total: 500},
+	}, nil).Once()
+}
+
+// ---------------------------------------------------------------------------
+// GetPlatformStats
+// ---------------------------------------------------------------------------
+
+func TestAnalyticsService_GetPlatformStats(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, m := setupAnalytics(t)
+		m.expectCounts()
+		m.inspector.EXPECT().GetQueueInfo("default").Return(&asynq.QueueInfo{
+			Queue:   "default",
+			Pending: 3,
+			Retry:   1,
+		}, nil).Once()
+
+		svc.RecordEmailDeliveryInternal(t.Context(), nil)
+		svc.RecordEmailDeliveryInternal(t.Context(), nil)
+		svc.RecordEmailDeliveryInternal(t.Context(), errors.New("smtp down"))
+		svc.RecordNotificationDeliveryInternal(t.Context(), models.ChannelEmail, nil)
+		svc.RecordNotificationDeliveryInternal(t.Context(), models.ChannelSMS, nil)
+		svc.RecordNotificationDeliveryInternal(t.Context(), models.ChannelSMS, errors.New("twilio down"))
+
+		got, err := svc.GetPlatformStats(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), got.TotalUsers)
+		assert.Equal(t, models.SubscriptionCounts{Active: 30, Canceled: 2}, got.Subscriptions)
+		assert.Equal(t, int64(4), got.RenewalsToday)
+		assert.Equal(t, models.EmailDeliveryStats{Sent: 2, Failed: 1}, got.EmailsToday)
+		assert.Equal(t, map[models.NotificationChannel]models.DeliveryStats{
+			models.ChannelEmail: {Sent: 1},
+			models.ChannelSMS:   {Sent: 1, Failed: 1},
+		}, got.RemindersToday)
+		assert.Equal(t, &models.QueueStats{Name: "default", Pending: 3, Retry: 1}, got.Queue)
+		// The yearly subscriptions add a twelfth of 119.99 per month.
+		assert.Equal(t, []models.CurrencyAmount{
+			models.NewCurrencyAmount(500, models.EUR),
+			models.NewCurrencyAmount(2997+1000, models.USD),
+		}, got.MonthlyRecurring)
+		assert.Equal(t, []models.CurrencyAmount{
+			models.NewCurrencyAmount(6000, models.EUR),
+			models.NewCurrencyAmount(2997*12+11999, models.USD),
+		}, got.AnnualRecurring)
+	})
+
+	t.Run("leaves the queue out when it cannot be inspected", func(t *testing.T) {
+		svc, m := setupAnalytics(t)
+		m.expectCounts()
+		m.inspector.EXPECT().GetQueueInfo("default").Return(nil, errors.New("queue not found")).Once()
+
+		got, err := svc.GetPlatformStats(t.Context())
+		require.NoError(t, err)
+		assert.Nil(t, got.Queue)
+		assert.Equal(t, models.EmailDeliveryStats{}, got.EmailsToday)
+	})
+
+	t.Run("propagates repository errors", func(t *testing.T) {
+		svc, m := setupAnalytics(t)
+		m.userRepo.EXPECT().Count(mock.Anything).Return(0, apperror.NewDBError(errors.New("db down"))).Once()
+
+		_, err := svc.GetPlatformStats(t.Context())
+		assertAppErr(t, err, apperror.ErrDB)
+	})
+}
+
+func TestAnalyticsService_RecordEmailDeliveryInternal(t *testing.T) {
+	svc, m := setupAnalytics(t)
+
+	svc.RecordEmailDeliveryInternal(t.Context(), nil)
+
+	key := services.EmailDeliveriesKey(mockTime)
+	assert.Equal(t, "email_deliveries:2025-01-15", key)
+	sent, err := m.rdb.HGet(t.Context(), key, "sent").Result()
+	require.NoError(t, err)
+	assert.Equal(t, "1", sent)
+
+	ttl, err := m.rdb.TTL(t.Context(), key).Result()
+	require.NoError(t, err)
+	assert.Equal(t, 8*24*time.Hour, ttl)
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// sniffLen is how many leading bytes http.DetectContentType looks at.
+const sniffLen = 512
+
+// AttachmentConfig limits the files users can attach to subscriptions.
+type AttachmentConfig struct {
+	MaxSize            int64    `mapstructure:"max_size"`             // Largest accepted file in bytes.
+	MaxPerSubscription int      `mapstructure:"max_per_subscription"` // Attachments one subscription can hold.
+	AllowedTypes       []string `mapstructure:"allowed_types"`        // Media types, detected from the content.
+}
+
+// AttachmentServiceExternal manages the receipts and other documents users
+// attach to their subscriptions.
+type AttachmentServiceExternal interface {
+	// UploadAttachment stores a file for a subscription of the caller. The
+	// type is detected from the content and must be one of the allowed
+	// types.
+	UploadAttachment(ctx context.Context, subscriptionID string, upload models.AttachmentUpload, claimedUserID string) (*models.SignedAttachment, error)
+	GetAttachments(ctx context.Context, subscriptionID string, claimedUserID string) ([]*models.SignedAttachment, error)
+	GetAttachmentByID(ctx context.Context, subscriptionID, id string, claimedUserID string) (*models.SignedAttachment, error)
+	DeleteAttachment(ctx context.Context, subscriptionID, id string, claimedUserID string) error
+}
+
+type AttachmentServiceInternal interface {
+	// DeleteAttachmentsInternal deletes the attachments of every
+	// subscription of the user and returns how many were deleted. Their
+	// files are deleted with the rest of the user's files.
+	DeleteAttachmentsInternal(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+type AttachmentService interface {
+	AttachmentServiceExternal
+	AttachmentServiceInternal
+}
+
+type attachmentService struct {
+	attachmentRepository   repositories.AttachmentRepository
+	subscriptionRepository repositories.SubscriptionRepository
+	fileService            FileServiceInternal
+	config                 AttachmentConfig
+	getTime                clock.NowFn
+}
+
+// NewAttachmentService creates a new instance of AttachmentService.
+func NewAttachmentService(
+	attachmentRepository repositories.AttachmentRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+	fileService FileServiceInternal,
+	config AttachmentConfig,
+	nowFn clock.NowFn,
+) AttachmentService {
+	return &attachmentService{
+		attachmentRepository,
+		subscriptionRepository,
+		fileService,
+		config,
+		nowFn,
+	}
+}
+
+func (s *attachmentService) UploadAttachment(
+	ctx context.Context,
+	subscriptionID string,
+	upload models.AttachmentUpload,
+	claimedUserID string,
+) (*models.SignedAttachment, error) {
+	subscription, err := s.ownedSubscription(ctx, subscriptionID, claimedUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.attachmentRepository.CountBySubscriptionID(ctx, subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(s.config.MaxPerSubscription) {
+		return nil, apperror.NewConflictError("A subscription can have at most %d attachments", s.config.MaxPerSubscription)
+	}
+
+	content := bufio.NewReaderSize(upload.Content, sniffLen)
+	head, err := content.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, apperror.NewBadRequestError("Failed to read the uploaded file")
+	}
+	if len(head) == 0 {
+		return nil, apperror.NewValidationError("The uploaded file is empty")
+	}
+	// The client's claimed type is ignored: only the content decides.
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !slices.Contains(s.config.AllowedTypes, contentType) {
+		return nil, apperror.NewValidationError("Files of type %s are not allowed", contentType)
+	}
+
+	attachment := &models.Attachment{
+		ID:             bson.NewObjectID(),
+		SubscriptionID: subscription.ID,
+		UserID:         subscription.UserID,
+		Filename:       attachmentFilename(upload.Filename),
+		ContentType:    contentType,
+		CreatedAt:      s.getTime(),
+	}
+	// One byte past the limit is enough to tell the file is too large.
+	file, err := s.fileService.StoreFileInternal(ctx, attachment.Filename, models.FileMetadata{
+		Key:         models.AttachmentKey(subscription.ID, attachment.ID),
+		OwnerID:     subscription.UserID,
+		ContentType: contentType,
+		Attributes: map[string]string{
+			"subscription_id": subscription.ID.Hex(),
+			"attachment_id":   attachment.ID.Hex(),
+		},
+	}, io.LimitReader(content, s.config.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if file.Size > s.config.MaxSize {
+		s.deleteFile(ctx, file.ID)
+		return nil, apperror.NewPayloadTooLargeError("Files can be at most %d bytes", s.config.MaxSize)
+	}
+
+	attachment.FileID = file.ID
+	attachment.Size = file.Size
+	if attachment, err = s.attachmentRepository.Create(ctx, attachment); err != nil {
+		s.deleteFile(ctx, file.ID)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Attachment uploaded",
+		logattr.AttachmentID(attachment.ID.Hex()),
+		logattr.SubscriptionID(subscription.ID.Hex()),
+		logattr.FileID(file.ID.Hex()),
+	)
+	return s.sign(attachment), nil
+}
+
+func (s *attachmentService) GetAttachments(
+	ctx context.Context,
+	subscriptionID string,
+	claimedUserID string,
+) ([]*models.SignedAttachment, error) {
+	subscription, err := s.ownedSubscription(ctx, subscriptionID, claimedUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepository.GetBySubscriptionID(ctx, subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+	signed := make([]*models.SignedAttachment, len(attachments))
+	for i, attachment := range attachments {
+		signed[i] = s.sign(attachment)
+	}
+	return signed, nil
+}
+
+func (s *attachmentService) GetAttachmentByID(
+	ctx context.Context,
+	subscriptionID, id string,
+	claimedUserID string,
+) (*models.SignedAttachment, error) {
+	attachment, err := s.ownedAttachment(ctx, subscriptionID, id, claimedUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.sign(attachment), nil
+}
+
+// DeleteAttachment removes an attachment and its content.
+func (s *attachmentService) DeleteAttachment(ctx context.Context, subscriptionID, id string, claimedUserID string) error {
+	attachment, err := s.ownedAttachment(ctx, subscriptionID, id, claimedUserID)
+	if err != nil {
+		return err
+	}
+	if err = s.attachmentRepository.Delete(ctx, attachment.ID); err != nil {
+		return err
+	}
+	s.deleteFile(ctx, attachment.FileID)
+
+	slog.InfoContext(ctx, "Attachment deleted",
+		logattr.AttachmentID(attachment.ID.Hex()),
+		logattr.SubscriptionID(attachment.SubscriptionID.Hex()),
+	)
+	return nil
+}
+
+func (s *attachmentService) DeleteAttachmentsInternal(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	return s.attachmentRepository.DeleteByUserID(ctx, userID)
+}
+
+// sign builds a download URL for the content of attachment.
+func (s *attachmentService) sign(attachment *models.Attachment) *models.SignedAttachment {
+	signed := s.fileService.SignFileInternal(&models.StoredFile{ID: attachment.FileID})
+	return &models.SignedAttachment{
+		Attachment: attachment,
+		URL:        signed.URL,
+		ExpiresAt:  signed.ExpiresAt,
+	}
+}
+
+// deleteFile removes the content of an attachment that is gone or was never
+// recorded. A leftover file only costs storage, so failures are logged.
+func (s *attachmentService) deleteFile(ctx context.Context, fileID bson.ObjectID) {
+	if err := s.fileService.DeleteFileInternal(ctx, fileID); err != nil {
+		slog.WarnContext(ctx, "Failed to delete attachment file",
+			logattr.FileID(fileID.Hex()),
+			logattr.Error(err),
+		)
+	}
+}
+
+func (s *attachmentService) ownedSubscription(
+	ctx context.Context,
+	subscriptionID string,
+	claimedUserID string,
+) (*models.Subscription, error) {
+	id, err := bson.ObjectIDFromHex(subscriptionID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid subscription ID")
+	}
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	subscription, err := s.subscriptionRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.UserID != userID {
+		return nil, apperror.NewForbiddenError("You are not allowed to access this subscription")
+	}
+	return subscription, nil
+}
+
+// ownedAttachment fetches an attachment of a subscription of the caller. An
+// attachment of another subscription is reported as missing.
+func (s *attachmentService) ownedAttachment(
+	ctx context.Context,
+	subscriptionID, id string,
+	claimedUserID string,
+) (*models.Attachment, error) {
+	subscription, err := s.ownedSubscription(ctx, subscriptionID, claimedUserID)
+	if err != nil {
+		return nil, err
+	}
+	attachmentID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid attachment ID")
+	}
+
+	attachment, err := s.attachmentRepository.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.SubscriptionID != subscription.ID {
+		return nil, apperror.NewNotFoundError("Attachment not found")
+	}
+	return attachment, nil
+}
+
+// attachmentFilename keeps the base name of a client-supplied filename, so
+// paths from the client's machine are not stored.
+func attachmentFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	if name == "." || name == "/" {
+		return "attachment"
+	}
+	if len(name) > 255 {
+		name = strings.ToValidUTF8(name[:255], "")
+	}
+	return name
+}
@@ -0,0 +1,266 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const pdfContent = "%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\n"
+
+type attachmentMocks struct {
+	attachmentRepo *repomocks.MockAttachmentRepository
+	subRepo        *repomocks.MockSubscriptionRepository
+	files          *svcmocks.MockFileServiceInternal
+}
+
+func setupAttachment(t *testing.T) (services.AttachmentService, *attachmentMocks) {
+	t.Helper()
+
+	m := &attachmentMocks{
+		attachmentRepo: repomocks.NewMockAttachmentRepository(t),
+		subRepo:        repomocks.NewMockSubscriptionRepository(t),
+		files:          svcmocks.NewMockFileServiceInternal(t),
+	}
+	svc := services.NewAttachmentService(m.attachmentRepo, m.subRepo, m.files, services.AttachmentConfig{
+		MaxSize:            64,
+		MaxPerSubscription: 2,
+		AllowedTypes:       []string{"application/pdf", "image/png"},
+	}, func() time.Time { return mockTime })
+	return svc, m
+}
+
+func validAttachment() *models.Attachment {
+	return &models.Attachment{
+		ID:             bson.NewObjectID(),
+		SubscriptionID: defaultSubID,
+		UserID:         defaultUserID,
+		FileID:         bson.NewObjectID(),
+		Filename:       "receipt.pdf",
+		ContentType:    "application/pdf",
+		Size:           int64(len(pdfContent)),
+		CreatedAt:      mockTime,
+	}
+}
+
+// expectSign expects the download URL of the file of attachment to be signed.
+func expectSign(m *attachmentMocks, attachment *models.Attachment) {
+	m.files.EXPECT().
+		SignFileInternal(&models.StoredFile{ID: attachment.FileID}).
+		Return(&models.SignedFile{URL: "/api/v1/files/" + attachment.FileID.Hex() + "/download", ExpiresAt: mockTime}).
+		Once()
+}
+
+// ---------------------------------------------------------------------------
+// UploadAttachment
+// ---------------------------------------------------------------------------
+
+func TestAttachmentService_UploadAttachment(t *testing.T) {
+	svc, m := setupAttachment(t)
+	m.subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+	m.attachmentRepo.EXPECT().CountBySubscriptionID(mock.Anything, defaultSubID).Return(1, nil).Once()
+
+	stored := &models.StoredFile{ID: bson.NewObjectID(), Size: int64(len(pdfContent))}
+	m.files.EXPECT().
+		StoreFileInternal(mock.Anything, "receipt.pdf", mock.MatchedBy(func(metadata models.FileMetadata) bool {
+			return strings.HasPrefix(metadata.Key, "attachment/"+defaultSubID.Hex()+"/") &&
+				metadata.OwnerID == defaultUserID &&
+				metadata.ContentType == "application/pdf"
+		}), mock.MatchedBy(func(content io.Reader) bool {
+			body, _ := io.ReadAll(content)
+			return string(body) == pdfContent
+		})).
+		Return(stored, nil).
+		Once()
+	m.attachmentRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, a *models.Attachment) (*models.Attachment, error) { return a, nil }).
+		Once()
+	m.files.EXPECT().SignFileInternal(mock.Anything).Return(&models.SignedFile{URL: "/download"}).Once()
+
+	got, err := svc.UploadAttachment(t.Context(), defaultSubHex, models.AttachmentUpload{
+		Filename: `C:\Users\alice\receipt.pdf`,
+		Content:  strings.NewReader(pdfContent),
+	}, defaultUserHex)
+
+	require.NoError(t, err)
+	assert.Equal(t, "receipt.pdf", got.Attachment.Filename, "the client's path is dropped")
+	assert.Equal(t, "application/pdf", got.Attachment.ContentType)
+	assert.Equal(t, stored.ID, got.Attachment.FileID)
+	assert.Equal(t, stored.Size, got.Attachment.Size)
+	assert.Equal(t, "/download", got.URL)
+}
+
+func TestAttachmentService_UploadAttachment_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		code    apperror.ErrorCode
+	}{
+		{"empty", "", apperror.ErrValidation},
+		{"type not allowed", "just some text", apperror.ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := setupAttachment(t)
+			m.subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+			m.attachmentRepo.EXPECT().CountBySubscriptionID(mock.Anything, defaultSubID).Return(0, nil).Once()
+
+			_, err := svc.UploadAttachment(t.Context(), defaultSubHex, models.AttachmentUpload{
+				Filename: "notes.txt",
+				Content:  strings.NewReader(tt.content),
+			}, defaultUserHex)
+
+			assertAppErr(t, err, tt.code)
+		})
+	}
+}
+
+func TestAttachmentService_UploadAttachment_TooLarge(t *testing.T) {
+	svc, m := setupAttachment(t)
+	m.subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+	m.attachmentRepo.EXPECT().CountBySubscriptionID(mock.Anything, defaultSubID).Return(0, nil).Once()
+	stored := &models.StoredFile{ID: bson.NewObjectID()}
+	m.files.EXPECT().StoreFileInternal(mock.Anything, "big.pdf", mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _ models.FileMetadata, content io.Reader) (*models.StoredFile, error) {
+			body, _ := io.ReadAll(content)
+			stored.Size = int64(len(body))
+			return stored, nil
+		}).
+		Once()
+	m.files.EXPECT().DeleteFileInternal(mock.Anything, stored.ID).Return(nil).Once()
+
+	_, err := svc.UploadAttachment(t.Context(), defaultSubHex, models.AttachmentUpload{
+		Filename: "big.pdf",
+		Content:  strings.NewReader(pdfContent + strings.Repeat("x", 1000)),
+	}, defaultUserHex)
+
+	assertAppErr(t, err, apperror.ErrTooLarge)
+	assert.Equal(t, int64(65), stored.Size, "reading stops one byte past the limit")
+}
+
+func TestAttachmentService_UploadAttachment_LimitReached(t *testing.T) {
+	svc, m := setupAttachment(t)
+	m.subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+	m.attachmentRepo.EXPECT().CountBySubscriptionID(mock.Anything, defaultSubID).Return(2, nil).Once()
+
+	_, err := svc.UploadAttachment(t.Context(), defaultSubHex, models.AttachmentUpload{
+		Filename: "receipt.pdf",
+		Content:  strings.NewReader(pdfContent),
+	}, defaultUserHex)
+
+	assertAppErr(t, err, apperror.ErrConflict)
+}
+
+func TestAttachmentService_UploadAttachment_CreateFails(t *testing.T) {
+	svc, m := setupAttachment(t)
+	m.subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+	m.attachmentRepo.EXPECT().CountBySubscriptionID(mock.Anything, defaultSubID).Return(0, nil).Once()
+	stored := &models.StoredFile{ID: bson.NewObjectID(), Size: int64(len(pdfContent))}
+	m.files.EXPECT().StoreFileInternal(mock.Anything, "receipt.pdf", mock.Anything, mock.Anything).Return(stored, nil).Once()
+	m.attachmentRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, apperror.NewDBError(assert.AnError)).Once()
+	m.files.EXPECT().DeleteFileInternal(mock.Anything, stored.ID).Return(nil).Once()
+
+	_, err := svc.UploadAttachment(t.Context(), defaultSubHex, models.AttachmentUpload{
+		Filename: "receipt.pdf",
+		Content:  strings.NewReader(pdfContent),
+	}, defaultUserHex)
+
+	assertAppErr(t, err, apperror.ErrDB)
+}
+
+func TestAttachmentService_UploadAttachment_Forbidden(t *testing.T) {
+	svc, m := setupAttachment(t)
+	sub := validSub()
+	sub.UserID = bson.NewObjectID()
+	m.subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(sub, nil).Once()
+
+	_, err := svc.UploadAttachment(t.Context(), defaultSubHex, models.AttachmentUpload{
+		Filename: "receipt.pdf",
+		Content:  strings.NewReader(pdfContent),
+	}, defaultUserHex)
+
+	assertAppErr(t, err, apperror.ErrForbidden)
+}
+
+// ---------------------------------------------------------------------------
+// GetAttachments / GetAttachmentByID
+// ---------------------------------------------------------------------------
+
+func TestAttachmentService_GetAttachments(t *testing.T) {
+	svc, m := setupAttachment(t)
+	attachment := validAttachment()
+	m.subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+	m.attachmentRepo.EXPECT().GetBySubscriptionID(mock.Anything, defaultSubID).Return([]*models.Attachment{attachment}, nil).Once()
+	expectSign(m, attachment)
+
+	got, err := svc.GetAttachments(t.Context(), defaultSubHex, defaultUserHex)
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, attachment, got[0].Attachment)
+	assert.Contains(t, got[0].URL, attachment.FileID.Hex())
+}
+
+func TestAttachmentService_GetAttachmentByID_OtherSubscription(t *testing.T) {
+	svc, m := setupAttachment(t)
+	attachment := validAttachment()
+	attachment.SubscriptionID = bson.NewObjectID()
+	m.subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+	m.attachmentRepo.EXPECT().GetByID(mock.Anything, attachment.ID).Return(attachment, nil).Once()
+
+	_, err := svc.GetAttachmentByID(t.Context(), defaultSubHex, attachment.ID.Hex(), defaultUserHex)
+
+	assertAppErr(t, err, apperror.ErrNotFound)
+}
+
+func TestAttachmentService_GetAttachmentByID_InvalidID(t *testing.T) {
+	svc, m := setupAttachment(t)
+	m.subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+
+	_, err := svc.GetAttachmentByID(t.Context(), defaultSubHex, "bad", defaultUserHex)
+
+	assertAppErr(t, err, apperror.ErrBadRequest)
+}
+
+// ---------------------------------------------------------------------------
+// DeleteAttachment
+// ---------------------------------------------------------------------------
+
+func TestAttachmentService_DeleteAttachment(t *testing.T) {
+	svc, m := setupAttachment(t)
+	attachment := validAttachment()
+	m.subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+	m.attachmentRepo.EXPECT().GetByID(mock.Anything, attachment.ID).Return(attachment, nil).Once()
+	m.attachmentRepo.EXPECT().Delete(mock.Anything, attachment.ID).Return(nil).Once()
+	m.files.EXPECT().DeleteFileInternal(mock.Anything, attachment.FileID).Return(assert.AnError).Once()
+
+	err := svc.DeleteAttachment(t.Context(), defaultSubHex, attachment.ID.Hex(), defaultUserHex)
+
+	assert.NoError(t, err, "a leftover file does not fail the delete")
+}
+
+// ---------------------------------------------------------------------------
+// DeleteAttachmentsInternal
+// ---------------------------------------------------------------------------
+
+func TestAttachmentService_DeleteAttachmentsInternal(t *testing.T) {
+	svc, m := setupAttachment(t)
+	m.attachmentRepo.EXPECT().DeleteByUserID(mock.Anything, defaultUserID).Return(int64(3), nil).Once()
+
+	deleted, err := svc.DeleteAttachmentsInternal(t.Context(), defaultUserID)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+}
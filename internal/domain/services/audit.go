@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AuditServiceExternal lets administrators search the audit log.
+type AuditServiceExternal interface {
+	// GetAuditEvents returns a page of the events matching the query,
+	// newest first.
+	GetAuditEvents(ctx context.Context, query models.AuditQuery) (*models.AuditPage, error)
+}
+
+// AuditServiceInternal records state changes made by other services.
+type AuditServiceInternal interface {
+	// RecordInternal records an action on a resource with snapshots of it
+	// before and after the change; either may be nil. Unless the actor is
+	// the system, it is identified as the authenticated user of ctx. Callers
+	// that change several documents run it inside their transaction, so the
+	// event commits or rolls back with the change.
+	RecordInternal(
+		ctx context.Context,
+		actor models.Actor,
+		action models.AuditAction,
+		resourceID bson.ObjectID,
+		before, after any,
+	) error
+}
+
+type AuditService interface {
+	AuditServiceExternal
+	AuditServiceInternal
+}
+
+type auditService struct {
+	auditRepository repositories.AuditEventRepository
+	getTime         clock.NowFn
+}
+
+// NewAuditService creates a new instance of AuditService.
+func NewAuditService(auditRepository repositories.AuditEventRepository, nowFn clock.NowFn) AuditService {
+	return &auditService{
+		auditRepository,
+		nowFn,
+	}
+}
+
+func (s *auditService) GetAuditEvents(ctx context.Context, query models.AuditQuery) (*models.AuditPage, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+	events, total, err := s.auditRepository.List(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AuditPage{Items: events, Total: total}, nil
+}
+
+func (s *auditService) RecordInternal(
+	ctx context.Context,
+	actor models.Actor,
+	action models.AuditAction,
+	resourceID bson.ObjectID,
+	before, after any,
+) error {
+	actor, actorID := auditActor(ctx, actor)
+	event, err := models.NewAuditEvent(action, actor, actorID, resourceID, before, after, s.getTime())
+	if err != nil {
+		return apperror.NewInternalError(err)
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		event.TraceID = spanContext.TraceID().String()
+	}
+	return s.auditRepository.Create(ctx, event)
+}
+
+// auditActor identifies a user actor from the authenticated user of ctx.
+// Administrators are recorded as such, without an ID when they are internal
+// tooling authenticated by a client certificate.
+func auditActor(ctx context.Context, actor models.Actor) (models.Actor, *bson.ObjectID) {
+	if actor == models.ActorSystem {
+		return actor, nil
+	}
+	if role, _ := appctx.GetUserRole(ctx); role == string(models.AdminRole) {
+		actor = models.ActorAdmin
+	}
+	claimedUserID, _ := appctx.GetUserID(ctx)
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return actor, nil
+	}
+	return actor, &userID
+}
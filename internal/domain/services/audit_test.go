@@ -0,0 +1,213 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newAuditService(repo *repomocks.MockAuditEventRepository) services.AuditService {
+	return services.NewAuditService(repo, func() time.Time { return mockTime })
+}
+
+// ---------------------------------------------------------------------------
+// RecordInternal
+// ---------------------------------------------------------------------------
+
+func Test_auditService_RecordInternal(t *testing.T) {
+	userCtx := appctx.WithUserID(context.Background(), defaultUserHex)
+	adminCtx := appctx.WithUserRole(userCtx, string(models.AdminRole))
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		actor       models.Actor
+		wantActor   models.Actor
+		wantActorID *bson.ObjectID
+	}{
+		{
+			name:        "user - identified from the context",
+			ctx:         userCtx,
+			actor:       models.ActorUser,
+			wantActor:   models.ActorUser,
+			wantActorID: &defaultUserID,
+		},
+		{
+			name:        "admin - recorded as such",
+			ctx:         adminCtx,
+			actor:       models.ActorUser,
+			wantActor:   models.ActorAdmin,
+			wantActorID: &defaultUserID,
+		},
+		{
+			// Worker contexts carry the subscription owner, who did not act.
+			name:      "system - never takes the user of the context",
+			ctx:       userCtx,
+			actor:     models.ActorSystem,
+			wantActor: models.ActorSystem,
+		},
+		{
+			name:      "admin - client certificate caller has no ID",
+			ctx:       appctx.WithUserRole(context.Background(), string(models.AdminRole)),
+			actor:     models.ActorUser,
+			wantActor: models.ActorAdmin,
+		},
+		{
+			name:      "user - signed out caller has no ID",
+			ctx:       context.Background(),
+			actor:     models.ActorUser,
+			wantActor: models.ActorUser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repomocks.NewMockAuditEventRepository(t)
+			repo.EXPECT().
+				Create(mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+					return e.Action == models.AuditSubscriptionCanceled &&
+						e.Actor == tt.wantActor &&
+						assert.ObjectsAreEqual(tt.wantActorID, e.ActorID) &&
+						e.ResourceType == "subscription" &&
+						e.ResourceID == defaultSubID &&
+						string(e.Before) == `{"status":"active"}` &&
+						string(e.After) == `{"status":"canceled"}` &&
+						e.OccurredAt.Equal(mockTime)
+				})).
+				Return(nil).
+				Once()
+
+			err := newAuditService(repo).RecordInternal(
+				tt.ctx,
+				tt.actor,
+				models.AuditSubscriptionCanceled,
+				defaultSubID,
+				map[string]string{"status": "active"},
+				map[string]string{"status": "canceled"},
+			)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("error - repository fails", func(t *testing.T) {
+		repo := repomocks.NewMockAuditEventRepository(t)
+		repo.EXPECT().
+			Create(mock.Anything, mock.Anything).
+			Return(apperror.NewDBError(errors.New("insert failed"))).
+			Once()
+
+		err := newAuditService(repo).RecordInternal(userCtx, models.ActorUser, models.AuditUserLoggedIn, defaultUserID, nil, nil)
+		assertAppErr(t, err, apperror.ErrDB)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GetAuditEvents
+// ---------------------------------------------------------------------------
+
+func Test_auditService_GetAuditEvents(t *testing.T) {
+	t.Run("success - defaults the page size", func(t *testing.T) {
+		repo := repomocks.NewMockAuditEventRepository(t)
+		event, err := models.NewAuditEvent(models.AuditUserCreated, models.ActorUser, nil, defaultUserID, nil, nil, mockTime)
+		require.NoError(t, err)
+		repo.EXPECT().
+			List(mock.Anything, &models.AuditQuery{ResourceType: "user", PageQuery: models.PageQuery{Limit: models.DefaultPageLimit}}).
+			Return([]*models.AuditEvent{event}, int64(1), nil).
+			Once()
+
+		got, err := newAuditService(repo).GetAuditEvents(t.Context(), models.AuditQuery{ResourceType: "user"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &models.AuditPage{Items: []*models.AuditEvent{event}, Total: 1}, got)
+	})
+
+	t.Run("error - unknown action", func(t *testing.T) {
+		repo := repomocks.NewMockAuditEventRepository(t)
+
+		got, err := newAuditService(repo).GetAuditEvents(t.Context(), models.AuditQuery{Action: "user.renamed"})
+
+		assertAppErr(t, err, apperror.ErrValidation)
+		assert.Nil(t, got)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Audited subscription changes
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_CancelSubscription_RecordsAudit(t *testing.T) {
+	newSvc := func(subRepo *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository, audit *svcmocks.MockAuditServiceInternal) services.SubscriptionService {
+		metrics := &svcmocks.MockSubscriptionMetrics{}
+		metrics.EXPECT().IncSubscriptionsCanceled(mock.Anything).Maybe()
+		return services.NewSubscriptionService(
+			noopTxnFn,
+			subRepo,
+			billRepo,
+			nil,
+			nopOutboxRepo(),
+			nopPriceHistoryRepo(),
+			nopLifecycleRepo(),
+			nil,
+			nil,
+			nil,
+			audit,
+			metrics,
+			func() time.Time { return mockTime },
+		)
+	}
+	setup := func(t *testing.T) (*repomocks.MockSubscriptionRepository, *repomocks.MockBillRepository) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+		billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(validBill(), nil).Once()
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.Active, models.Canceled, mockTime).
+			Return(validCanceledSub(), nil).
+			Once()
+		return subRepo, billRepo
+	}
+
+	t.Run("success - records both snapshots", func(t *testing.T) {
+		subRepo, billRepo := setup(t)
+		audit := svcmocks.NewMockAuditServiceInternal(t)
+		audit.EXPECT().
+			RecordInternal(
+				mock.Anything,
+				models.ActorUser,
+				models.AuditSubscriptionCanceled,
+				defaultSubID,
+				validSub().ToResponse(),
+				validCanceledSub().ToResponse(),
+			).
+			Return(nil).
+			Once()
+
+		_, err := newSvc(subRepo, billRepo, audit).CancelSubscription(t.Context(), defaultSubHex, defaultUserHex)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - audit failure fails the change", func(t *testing.T) {
+		subRepo, billRepo := setup(t)
+		audit := svcmocks.NewMockAuditServiceInternal(t)
+		audit.EXPECT().
+			RecordInternal(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(apperror.NewDBError(errors.New("insert failed"))).
+			Once()
+
+		got, err := newSvc(subRepo, billRepo, audit).CancelSubscription(t.Context(), defaultSubHex, defaultUserHex)
+		assertAppErr(t, err, apperror.ErrDB)
+		assert.Nil(t, got)
+	})
+}
@@ -14,25 +14,40 @@ import (
 
 // AuthService provides authentication operations.
 type AuthService interface {
-	Login(ctx context.Context, loginReq models.LoginRequest) (*models.TokenResponse, error)
-	RefreshToken(ctx context.Context, refreshToken string) (*models.TokenResponse, error)
+	// Login starts a session for the device of client.
+	Login(ctx context.Context, loginReq models.LoginRequest, client models.SessionClient) (*models.TokenResponse, error)
+	// RefreshToken issues new tokens of the same session, recording client
+	// as its latest use.
+	RefreshToken(ctx context.Context, refreshToken string, client models.SessionClient) (*models.TokenResponse, error)
+	// Logout revokes the caller's access token and the refresh token issued
+	// with it, and ends their session.
+	Logout(ctx context.Context, accessToken, refreshToken string) error
 }
 
 type authService struct {
-	userServiceInternal UserServiceInternal
-	jwtService          JWTService
+	userServiceInternal    UserServiceInternal
+	sessionServiceInternal SessionServiceInternal
+	jwtService             JWTService
+	audit                  AuditServiceInternal
 }
 
 // NewAuthService creates a new instance of AuthService.
-func NewAuthService(userServiceInternal UserServiceInternal, jwtService JWTService) AuthService {
+func NewAuthService(
+	userServiceInternal UserServiceInternal,
+	sessionServiceInternal SessionServiceInternal,
+	jwtService JWTService,
+	audit AuditServiceInternal,
+) AuthService {
 	return &authService{
-		userServiceInternal: userServiceInternal,
-		jwtService:          jwtService,
+		userServiceInternal:    userServiceInternal,
+		sessionServiceInternal: sessionServiceInternal,
+		jwtService:             jwtService,
+		audit:                  audit,
 	}
 }
 
 // Login authenticates a user and returns JWT tokens.
-func (s *authService) Login(ctx context.Context, loginReq models.LoginRequest) (*models.TokenResponse, error) {
+func (s *authService) Login(ctx context.Context, loginReq models.LoginRequest, client models.SessionClient) (*models.TokenResponse, error) {
 	// Find the user by email.
 	user, err := s.userServiceInternal.FetchUserByEmailInternal(ctx, loginReq.Email)
 	if err != nil {
@@ -48,12 +63,25 @@ func (s *authService) Login(ctx context.Context, loginReq models.LoginRequest) (
 		return nil, apperror.NewUnauthorizedError("Invalid credentials").
 			WithLogAttributes(logattr.AttemptedID(loginReq.Email))
 	}
+	if user.Closed() {
+		return nil, apperror.NewForbiddenError("Account is closed").
+			WithLogAttributes(logattr.UserID(user.ID.Hex()))
+	}
 
-	// Generate tokens.
-	tokens, err := s.jwtService.GenerateTokens(user.ID.Hex(), user.Email)
+	// Generate tokens of a new session.
+	client.Device = loginReq.Device
+	tokens, err := s.startSession(ctx, user, client)
 	if err != nil {
-		return nil, apperror.NewInternalError(err).
-			WithLogAttributes(logattr.UserID(user.ID.Hex()))
+		return nil, err
+	}
+
+	// The tokens are issued either way; a login missing from the audit log
+	// is only logged.
+	if err = s.audit.RecordInternal(ctx, models.ActorUser, models.AuditUserLoggedIn, user.ID, nil, nil); err != nil {
+		slog.WarnContext(ctx, "Failed to record login in audit log",
+			logattr.UserID(user.ID.Hex()),
+			logattr.Error(err),
+		)
 	}
 
 	slog.InfoContext(ctx, "Login successful", logattr.UserID(user.ID.Hex()))
@@ -61,9 +89,9 @@ func (s *authService) Login(ctx context.Context, loginReq models.LoginRequest) (
 }
 
 // RefreshToken validates a refresh token and issues new tokens.
-func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*models.TokenResponse, error) {
+func (s *authService) RefreshToken(ctx context.Context, refreshToken string, client models.SessionClient) (*models.TokenResponse, error) {
 	// Validate the refresh token.
-	claims, err := s.jwtService.ValidateToken(refreshToken, models.RefreshToken)
+	claims, err := s.jwtService.ValidateToken(ctx, refreshToken, models.RefreshToken)
 	if err != nil {
 		return nil, apperror.NewUnauthorizedError("Invalid refresh token")
 	}
@@ -82,14 +110,116 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*m
 			return nil, err
 		}
 	}
+	if user.Closed() {
+		return nil, apperror.NewForbiddenError("Account is closed").
+			WithLogAttributes(logattr.UserID(claims.UserID))
+	}
 
-	// Generate new tokens.
-	tokens, err := s.jwtService.GenerateTokens(user.ID.Hex(), user.Email)
+	// Refresh tokens are single-use, so a stolen one stops working once either
+	// party refreshes with it. Revoking atomically also rejects a concurrent
+	// second refresh with the same token.
+	revoked, err := s.jwtService.RevokeToken(ctx, claims)
+	if err != nil {
+		return nil, apperror.NewInternalError(err).
+			WithLogAttributes(logattr.UserID(claims.UserID))
+	}
+	if !revoked {
+		return nil, apperror.NewUnauthorizedError("Invalid refresh token").
+			WithLogAttributes(logattr.UserID(claims.UserID))
+	}
+
+	// Tokens issued before sessions were tracked start one.
+	if claims.SessionID == "" {
+		tokens, err := s.startSession(ctx, user, client)
+		if err != nil {
+			return nil, err
+		}
+		slog.InfoContext(ctx, "Token refreshed", logattr.UserID(user.ID.Hex()))
+		return tokens, nil
+	}
+
+	// Generate new tokens of the session.
+	tokens, err := s.jwtService.GenerateTokens(user.ID.Hex(), user.Email, user.Role.OrDefault(), claims.SessionID)
 	if err != nil {
 		return nil, apperror.NewInternalError(err).
 			WithLogAttributes(logattr.UserID(user.ID.Hex()))
 	}
+	err = s.sessionServiceInternal.RefreshSessionInternal(ctx, claims.SessionID, user.ID, client, tokens.RefreshExpiresAt)
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok {
+			return nil, appErr.WithLogAttributes(logattr.UserID(claims.UserID), logattr.SessionID(claims.SessionID))
+		}
+		return nil, err
+	}
 
 	slog.InfoContext(ctx, "Token refreshed", logattr.UserID(user.ID.Hex()))
 	return tokens, nil
 }
+
+// startSession issues the tokens of a new session of the user and records
+// the session.
+func (s *authService) startSession(ctx context.Context, user *models.User, client models.SessionClient) (*models.TokenResponse, error) {
+	sessionID := bson.NewObjectID()
+	tokens, err := s.jwtService.GenerateTokens(user.ID.Hex(), user.Email, user.Role.OrDefault(), sessionID.Hex())
+	if err != nil {
+		return nil, apperror.NewInternalError(err).
+			WithLogAttributes(logattr.UserID(user.ID.Hex()))
+	}
+
+	err = s.sessionServiceInternal.StartSessionInternal(ctx, &models.Session{
+		ID:        sessionID,
+		UserID:    user.ID,
+		Device:    client.Device,
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+		ExpiresAt: tokens.RefreshExpiresAt,
+	})
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok {
+			return nil, appErr.WithLogAttributes(logattr.UserID(user.ID.Hex()))
+		}
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Logout revokes both tokens. The refresh token must belong to the same user
+// as the access token.
+func (s *authService) Logout(ctx context.Context, accessToken, refreshToken string) error {
+	accessClaims, err := s.jwtService.ValidateToken(ctx, accessToken, models.AccessToken)
+	if err != nil {
+		return apperror.NewUnauthorizedError("Invalid token")
+	}
+	refreshClaims, err := s.jwtService.ValidateToken(ctx, refreshToken, models.RefreshToken)
+	if err != nil {
+		return apperror.NewUnauthorizedError("Invalid refresh token").
+			WithLogAttributes(logattr.UserID(accessClaims.UserID))
+	}
+	if refreshClaims.UserID != accessClaims.UserID {
+		return apperror.NewForbiddenError("Refresh token belongs to another user").
+			WithLogAttributes(logattr.UserID(accessClaims.UserID))
+	}
+
+	for _, claims := range []*models.Claims{refreshClaims, accessClaims} {
+		if _, err = s.jwtService.RevokeToken(ctx, claims); err != nil {
+			return apperror.NewInternalError(err).
+				WithLogAttributes(logattr.UserID(accessClaims.UserID))
+		}
+	}
+
+	// The tokens are revoked either way; a session left behind expires
+	// with them.
+	if refreshClaims.SessionID != "" {
+		userID, _ := bson.ObjectIDFromHex(refreshClaims.UserID)
+		if err = s.sessionServiceInternal.EndSessionInternal(ctx, refreshClaims.SessionID, userID); err != nil {
+			slog.WarnContext(ctx, "Failed to end session",
+				logattr.UserID(accessClaims.UserID),
+				logattr.SessionID(refreshClaims.SessionID),
+				logattr.Error(err),
+			)
+		}
+	}
+
+	slog.InfoContext(ctx, "Logout successful", logattr.UserID(accessClaims.UserID))
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -21,19 +22,27 @@ import (
 
 func validTokenResp() *models.TokenResponse {
 	return &models.TokenResponse{
-		AccessToken:  "access.token.string",
-		RefreshToken: "refresh.token.string",
-		ExpiresAt:    mockTime.Add(time.Hour),
+		AccessToken:      "access.token.string",
+		RefreshToken:     "refresh.token.string",
+		ExpiresAt:        mockTime.Add(time.Hour),
+		RefreshExpiresAt: mockTime.Add(7 * 24 * time.Hour),
 	}
 }
 
+// testClient is the device of the logins and refreshes under test.
+var testClient = models.SessionClient{IP: "203.0.113.7", UserAgent: "test-agent"}
+
+// defaultSessionHex is the session of the tokens under test.
+var defaultSessionHex = bson.NewObjectID().Hex()
+
 // newAuthService is a convenience constructor that wires up an authService
 // with the provided mocks so individual tests don't need to repeat the wiring.
 func newAuthService(
 	userSvc *svcmocks.MockUserServiceInternal,
+	sessionSvc *svcmocks.MockSessionServiceInternal,
 	jwtSvc *svcmocks.MockJWTService,
 ) services.AuthService {
-	return services.NewAuthService(userSvc, jwtSvc)
+	return services.NewAuthService(userSvc, sessionSvc, jwtSvc, nopAudit())
 }
 
 // ---------------------------------------------------------------------------
@@ -50,6 +59,7 @@ func Test_authService_Login(t *testing.T) {
 		return models.LoginRequest{
 			Email:    defaultUserEmail,
 			Password: plainPassword,
+			Device:   "Work laptop",
 		}
 	}
 	validUser := func() *models.User {
@@ -68,6 +78,7 @@ func Test_authService_Login(t *testing.T) {
 			jwtSvc *svcmocks.MockJWTService,
 			input models.LoginRequest,
 		)
+		setupSessions   func(sessionSvc *svcmocks.MockSessionServiceInternal)
 		wantErr         bool
 		wantErrCode     apperror.ErrorCode
 		wantEnrichedErr bool
@@ -89,10 +100,20 @@ func Test_authService_Login(t *testing.T) {
 					Once()
 
 				jwtSvc.EXPECT().
-					GenerateTokens(defaultUserHex, input.Email).
+					GenerateTokens(defaultUserHex, input.Email, models.UserRole, mock.AnythingOfType("string")).
 					Return(validTokenResp(), nil).
 					Once()
 			},
+			setupSessions: func(sessionSvc *svcmocks.MockSessionServiceInternal) {
+				sessionSvc.EXPECT().
+					StartSessionInternal(mock.Anything, mock.MatchedBy(func(s *models.Session) bool {
+						return !s.ID.IsZero() && s.UserID == defaultUserID && s.Device == "Work laptop" &&
+							s.IP == testClient.IP && s.UserAgent == testClient.UserAgent &&
+							s.ExpiresAt.Equal(validTokenResp().RefreshExpiresAt)
+					})).
+					Return(nil).
+					Once()
+			},
 		},
 		{
 			// User not found in the repository.
@@ -150,6 +171,26 @@ func Test_authService_Login(t *testing.T) {
 			wantErrCode:     apperror.ErrUnauthorized,
 			wantEnrichedErr: true,
 		},
+		{
+			// Correct credentials of a closed account → forbidden.
+			name:  "error - account closed",
+			input: validInput(),
+			setupMocks: func(
+				userSvc *svcmocks.MockUserServiceInternal,
+				jwtSvc *svcmocks.MockJWTService,
+				input models.LoginRequest,
+			) {
+				user := validUser()
+				user.ClosedAt = &mockTime
+				userSvc.EXPECT().
+					FetchUserByEmailInternal(mock.Anything, input.Email).
+					Return(user, nil).
+					Once()
+			},
+			wantErr:         true,
+			wantErrCode:     apperror.ErrForbidden,
+			wantEnrichedErr: true,
+		},
 		{
 			// Password matches but JWT signing fails.
 			name:  "error - token generation fails",
@@ -165,7 +206,7 @@ func Test_authService_Login(t *testing.T) {
 					Once()
 
 				jwtSvc.EXPECT().
-					GenerateTokens(defaultUserHex, input.Email).
+					GenerateTokens(defaultUserHex, input.Email, models.UserRole, mock.AnythingOfType("string")).
 					Return(nil, errors.New("signing failed")).
 					Once()
 			},
@@ -173,16 +214,50 @@ func Test_authService_Login(t *testing.T) {
 			wantErrCode:     apperror.ErrInternal,
 			wantEnrichedErr: true,
 		},
+		{
+			// Tokens are issued but the session cannot be recorded, e.g. an
+			// older session cannot be evicted.
+			name:  "error - session start fails",
+			input: validInput(),
+			setupMocks: func(
+				userSvc *svcmocks.MockUserServiceInternal,
+				jwtSvc *svcmocks.MockJWTService,
+				input models.LoginRequest,
+			) {
+				userSvc.EXPECT().
+					FetchUserByEmailInternal(mock.Anything, input.Email).
+					Return(validUser(), nil).
+					Once()
+
+				jwtSvc.EXPECT().
+					GenerateTokens(defaultUserHex, input.Email, models.UserRole, mock.AnythingOfType("string")).
+					Return(validTokenResp(), nil).
+					Once()
+			},
+			setupSessions: func(sessionSvc *svcmocks.MockSessionServiceInternal) {
+				sessionSvc.EXPECT().
+					StartSessionInternal(mock.Anything, mock.Anything).
+					Return(apperror.NewInternalError(errors.New("redis down"))).
+					Once()
+			},
+			wantErr:         true,
+			wantErrCode:     apperror.ErrInternal,
+			wantEnrichedErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			userSvc := svcmocks.NewMockUserServiceInternal(t)
 			jwtSvc := svcmocks.NewMockJWTService(t)
+			sessionSvc := svcmocks.NewMockSessionServiceInternal(t)
 			tt.setupMocks(userSvc, jwtSvc, tt.input)
+			if tt.setupSessions != nil {
+				tt.setupSessions(sessionSvc)
+			}
 
-			svc := newAuthService(userSvc, jwtSvc)
-			got, err := svc.Login(t.Context(), tt.input)
+			svc := newAuthService(userSvc, sessionSvc, jwtSvc)
+			got, err := svc.Login(t.Context(), tt.input, testClient)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -221,9 +296,10 @@ func Test_authService_RefreshToken(t *testing.T) {
 
 	validClaims := func() *models.Claims {
 		return &models.Claims{
-			UserID: defaultUserHex,
-			Email:  defaultUserEmail,
-			Type:   models.RefreshToken,
+			UserID:    defaultUserHex,
+			Email:     defaultUserEmail,
+			Type:      models.RefreshToken,
+			SessionID: defaultSessionHex,
 		}
 	}
 	validUser := func() *models.User {
@@ -241,6 +317,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 			jwtSvc *svcmocks.MockJWTService,
 			refreshToken string,
 		)
+		setupSessions   func(sessionSvc *svcmocks.MockSessionServiceInternal)
 		wantErr         bool
 		wantErrCode     apperror.ErrorCode
 		wantEnrichedErr bool
@@ -256,7 +333,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 				refreshToken string,
 			) {
 				jwtSvc.EXPECT().
-					ValidateToken(refreshToken, models.RefreshToken).
+					ValidateToken(mock.Anything, refreshToken, models.RefreshToken).
 					Return(validClaims(), nil).
 					Once()
 
@@ -266,12 +343,103 @@ func Test_authService_RefreshToken(t *testing.T) {
 					Once()
 
 				jwtSvc.EXPECT().
-					GenerateTokens(defaultUserHex, defaultUserEmail).
+					RevokeToken(mock.Anything, mock.Anything).
+					Return(true, nil).
+					Once()
+
+				jwtSvc.EXPECT().
+					GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex).
 					Return(validTokenResp(), nil).
 					Once()
 			},
+			setupSessions: func(sessionSvc *svcmocks.MockSessionServiceInternal) {
+				sessionSvc.EXPECT().
+					RefreshSessionInternal(mock.Anything, defaultSessionHex, defaultUserID, testClient, validTokenResp().RefreshExpiresAt).
+					Return(nil).
+					Once()
+			},
 			wantResp: validTokenResp(),
 		},
+		{
+			// Tokens issued before sessions were tracked start a session.
+			name:         "success - refresh token without a session",
+			refreshToken: refreshToken,
+			setupMocks: func(
+				userSvc *svcmocks.MockUserServiceInternal,
+				jwtSvc *svcmocks.MockJWTService,
+				refreshToken string,
+			) {
+				claims := validClaims()
+				claims.SessionID = ""
+				jwtSvc.EXPECT().
+					ValidateToken(mock.Anything, refreshToken, models.RefreshToken).
+					Return(claims, nil).
+					Once()
+
+				userSvc.EXPECT().
+					FetchUserByIDInternal(mock.Anything, defaultUserID).
+					Return(validUser(), nil).
+					Once()
+
+				jwtSvc.EXPECT().
+					RevokeToken(mock.Anything, mock.Anything).
+					Return(true, nil).
+					Once()
+
+				jwtSvc.EXPECT().
+					GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, mock.AnythingOfType("string")).
+					Return(validTokenResp(), nil).
+					Once()
+			},
+			setupSessions: func(sessionSvc *svcmocks.MockSessionServiceInternal) {
+				sessionSvc.EXPECT().
+					StartSessionInternal(mock.Anything, mock.MatchedBy(func(s *models.Session) bool {
+						return !s.ID.IsZero() && s.UserID == defaultUserID && s.IP == testClient.IP
+					})).
+					Return(nil).
+					Once()
+			},
+			wantResp: validTokenResp(),
+		},
+		{
+			// The session was revoked while the token was in flight.
+			name:         "error - session revoked",
+			refreshToken: refreshToken,
+			setupMocks: func(
+				userSvc *svcmocks.MockUserServiceInternal,
+				jwtSvc *svcmocks.MockJWTService,
+				refreshToken string,
+			) {
+				jwtSvc.EXPECT().
+					ValidateToken(mock.Anything, refreshToken, models.RefreshToken).
+					Return(validClaims(), nil).
+					Once()
+
+				userSvc.EXPECT().
+					FetchUserByIDInternal(mock.Anything, defaultUserID).
+					Return(validUser(), nil).
+					Once()
+
+				jwtSvc.EXPECT().
+					RevokeToken(mock.Anything, mock.Anything).
+					Return(true, nil).
+					Once()
+
+				jwtSvc.EXPECT().
+					GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex).
+					Return(validTokenResp(), nil).
+					Once()
+			},
+			setupSessions: func(sessionSvc *svcmocks.MockSessionServiceInternal) {
+				sessionSvc.EXPECT().
+					RefreshSessionInternal(mock.Anything, defaultSessionHex, defaultUserID, testClient, mock.Anything).
+					Return(apperror.NewUnauthorizedError("Session has been revoked")).
+					Once()
+			},
+			wantErr:         true,
+			wantErrCode:     apperror.ErrUnauthorized,
+			wantEnrichedErr: true,
+		},
 		{
 			// The refresh token itself is invalid (expired, bad signature, etc.).
 			name:         "error - invalid refresh token",
@@ -282,7 +450,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 				refreshToken string,
 			) {
 				jwtSvc.EXPECT().
-					ValidateToken(refreshToken, models.RefreshToken).
+					ValidateToken(mock.Anything, refreshToken, models.RefreshToken).
 					Return(nil, errors.New("token expired")).
 					Once()
 			},
@@ -301,7 +469,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 				claims := validClaims()
 				claims.UserID = "not-a-valid-hex"
 				jwtSvc.EXPECT().
-					ValidateToken(refreshToken, models.RefreshToken).
+					ValidateToken(mock.Anything, refreshToken, models.RefreshToken).
 					Return(claims, nil).
 					Once()
 			},
@@ -318,7 +486,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 				refreshToken string,
 			) {
 				jwtSvc.EXPECT().
-					ValidateToken(refreshToken, models.RefreshToken).
+					ValidateToken(mock.Anything, refreshToken, models.RefreshToken).
 					Return(validClaims(), nil).
 					Once()
 				userSvc.EXPECT().
@@ -340,7 +508,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 				refreshToken string,
 			) {
 				jwtSvc.EXPECT().
-					ValidateToken(refreshToken, models.RefreshToken).
+					ValidateToken(mock.Anything, refreshToken, models.RefreshToken).
 					Return(validClaims(), nil).
 					Once()
 
@@ -351,6 +519,62 @@ func Test_authService_RefreshToken(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			// The token was already used for a refresh or revoked concurrently.
+			name:         "error - refresh token already used",
+			refreshToken: refreshToken,
+			setupMocks: func(
+				userSvc *svcmocks.MockUserServiceInternal,
+				jwtSvc *svcmocks.MockJWTService,
+				refreshToken string,
+			) {
+				jwtSvc.EXPECT().
+					ValidateToken(mock.Anything, refreshToken, models.RefreshToken).
+					Return(validClaims(), nil).
+					Once()
+
+				userSvc.EXPECT().
+					FetchUserByIDInternal(mock.Anything, defaultUserID).
+					Return(validUser(), nil).
+					Once()
+
+				jwtSvc.EXPECT().
+					RevokeToken(mock.Anything, mock.Anything).
+					Return(false, nil).
+					Once()
+			},
+			wantErr:         true,
+			wantErrCode:     apperror.ErrUnauthorized,
+			wantEnrichedErr: true,
+		},
+		{
+			// Redis is unavailable, so the token cannot be rotated.
+			name:         "error - revocation fails",
+			refreshToken: refreshToken,
+			setupMocks: func(
+				userSvc *svcmocks.MockUserServiceInternal,
+				jwtSvc *svcmocks.MockJWTService,
+				refreshToken string,
+			) {
+				jwtSvc.EXPECT().
+					ValidateToken(mock.Anything, refreshToken, models.RefreshToken).
+					Return(validClaims(), nil).
+					Once()
+
+				userSvc.EXPECT().
+					FetchUserByIDInternal(mock.Anything, defaultUserID).
+					Return(validUser(), nil).
+					Once()
+
+				jwtSvc.EXPECT().
+					RevokeToken(mock.Anything, mock.Anything).
+					Return(false, errors.New("redis down")).
+					Once()
+			},
+			wantErr:         true,
+			wantErrCode:     apperror.ErrInternal,
+			wantEnrichedErr: true,
+		},
 		{
 			// User still exists, but new token generation fails.
 			name:         "error - token generation fails",
@@ -361,7 +585,7 @@ func Test_authService_RefreshToken(t *testing.T) {
 				refreshToken string,
 			) {
 				jwtSvc.EXPECT().
-					ValidateToken(refreshToken, models.RefreshToken).
+					ValidateToken(mock.Anything, refreshToken, models.RefreshToken).
 					Return(validClaims(), nil).
 					Once()
 
@@ -371,7 +595,12 @@ func Test_authService_RefreshToken(t *testing.T) {
 					Once()
 
 				jwtSvc.EXPECT().
-					GenerateTokens(defaultUserHex, defaultUserEmail).
+					RevokeToken(mock.Anything, mock.Anything).
+					Return(true, nil).
+					Once()
+
+				jwtSvc.EXPECT().
+					GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex).
 					Return(nil, errors.New("signing error")).
 					Once()
 			},
@@ -385,10 +614,14 @@ func Test_authService_RefreshToken(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			userSvc := svcmocks.NewMockUserServiceInternal(t)
 			jwtSvc := svcmocks.NewMockJWTService(t)
+			sessionSvc := svcmocks.NewMockSessionServiceInternal(t)
 			tt.setupMocks(userSvc, jwtSvc, tt.refreshToken)
+			if tt.setupSessions != nil {
+				tt.setupSessions(sessionSvc)
+			}
 
-			svc := newAuthService(userSvc, jwtSvc)
-			got, err := svc.RefreshToken(t.Context(), tt.refreshToken)
+			svc := newAuthService(userSvc, sessionSvc, jwtSvc)
+			got, err := svc.RefreshToken(t.Context(), tt.refreshToken, testClient)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -417,3 +650,102 @@ func Test_authService_RefreshToken(t *testing.T) {
 		})
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Logout
+// ---------------------------------------------------------------------------
+
+func Test_authService_Logout(t *testing.T) {
+	accessClaims := func(userID string) *models.Claims {
+		return &models.Claims{UserID: userID, Type: models.AccessToken}
+	}
+	refreshClaims := func(userID string) *models.Claims {
+		return &models.Claims{UserID: userID, Type: models.RefreshToken, SessionID: defaultSessionHex}
+	}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(jwtSvc *svcmocks.MockJWTService)
+		setupSessions func(sessionSvc *svcmocks.MockSessionServiceInternal)
+		wantErrCode   apperror.ErrorCode
+	}{
+		{
+			name: "success - revokes both tokens and ends the session",
+			setupMocks: func(jwtSvc *svcmocks.MockJWTService) {
+				jwtSvc.EXPECT().ValidateToken(mock.Anything, "access", models.AccessToken).
+					Return(accessClaims(defaultUserHex), nil).Once()
+				jwtSvc.EXPECT().ValidateToken(mock.Anything, "refresh", models.RefreshToken).
+					Return(refreshClaims(defaultUserHex), nil).Once()
+				jwtSvc.EXPECT().RevokeToken(mock.Anything, refreshClaims(defaultUserHex)).Return(true, nil).Once()
+				jwtSvc.EXPECT().RevokeToken(mock.Anything, accessClaims(defaultUserHex)).Return(true, nil).Once()
+			},
+			setupSessions: func(sessionSvc *svcmocks.MockSessionServiceInternal) {
+				sessionSvc.EXPECT().EndSessionInternal(mock.Anything, defaultSessionHex, defaultUserID).Return(nil).Once()
+			},
+		},
+		{
+			name: "success - a session that cannot be ended is only logged",
+			setupMocks: func(jwtSvc *svcmocks.MockJWTService) {
+				jwtSvc.EXPECT().ValidateToken(mock.Anything, "access", models.AccessToken).
+					Return(accessClaims(defaultUserHex), nil).Once()
+				jwtSvc.EXPECT().ValidateToken(mock.Anything, "refresh", models.RefreshToken).
+					Return(refreshClaims(defaultUserHex), nil).Once()
+				jwtSvc.EXPECT().RevokeToken(mock.Anything, mock.Anything).Return(true, nil).Twice()
+			},
+			setupSessions: func(sessionSvc *svcmocks.MockSessionServiceInternal) {
+				sessionSvc.EXPECT().EndSessionInternal(mock.Anything, defaultSessionHex, defaultUserID).
+					Return(apperror.NewDBError(errors.New("mongo down"))).Once()
+			},
+		},
+		{
+			name: "error - invalid refresh token",
+			setupMocks: func(jwtSvc *svcmocks.MockJWTService) {
+				jwtSvc.EXPECT().ValidateToken(mock.Anything, "access", models.AccessToken).
+					Return(accessClaims(defaultUserHex), nil).Once()
+				jwtSvc.EXPECT().ValidateToken(mock.Anything, "refresh", models.RefreshToken).
+					Return(nil, services.ErrTokenRevoked).Once()
+			},
+			wantErrCode: apperror.ErrUnauthorized,
+		},
+		{
+			name: "error - refresh token of another user",
+			setupMocks: func(jwtSvc *svcmocks.MockJWTService) {
+				jwtSvc.EXPECT().ValidateToken(mock.Anything, "access", models.AccessToken).
+					Return(accessClaims(defaultUserHex), nil).Once()
+				jwtSvc.EXPECT().ValidateToken(mock.Anything, "refresh", models.RefreshToken).
+					Return(refreshClaims(bson.NewObjectID().Hex()), nil).Once()
+			},
+			wantErrCode: apperror.ErrForbidden,
+		},
+		{
+			name: "error - revocation fails",
+			setupMocks: func(jwtSvc *svcmocks.MockJWTService) {
+				jwtSvc.EXPECT().ValidateToken(mock.Anything, "access", models.AccessToken).
+					Return(accessClaims(defaultUserHex), nil).Once()
+				jwtSvc.EXPECT().ValidateToken(mock.Anything, "refresh", models.RefreshToken).
+					Return(refreshClaims(defaultUserHex), nil).Once()
+				jwtSvc.EXPECT().RevokeToken(mock.Anything, mock.Anything).Return(false, errors.New("redis down")).Once()
+			},
+			wantErrCode: apperror.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtSvc := svcmocks.NewMockJWTService(t)
+			sessionSvc := svcmocks.NewMockSessionServiceInternal(t)
+			tt.setupMocks(jwtSvc)
+			if tt.setupSessions != nil {
+				tt.setupSessions(sessionSvc)
+			}
+
+			err := newAuthService(svcmocks.NewMockUserServiceInternal(t), sessionSvc, jwtSvc).Logout(t.Context(), "access", "refresh")
+
+			if tt.wantErrCode == "" {
+				require.NoError(t, err)
+				return
+			}
+			assertAppErr(t, err, tt.wantErrCode)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// BillServiceExternal exposes the bills of a user's subscriptions. Access is
+// checked through the subscription a bill belongs to.
+type BillServiceExternal interface {
+	// GetBillsBySubscriptionID returns a page of the subscription's bills,
+	// newest first.
+	GetBillsBySubscriptionID(
+		ctx context.Context,
+		subscriptionID string,
+		claimedUserID string,
+		query models.BillQuery,
+	) (*models.BillPage, error)
+	GetBillByID(ctx context.Context, id string, claimedUserID string) (*models.Bill, error)
+}
+
+type billService struct {
+	billRepository         repositories.BillRepository
+	subscriptionRepository repositories.SubscriptionRepository
+}
+
+// NewBillService creates a new instance of BillServiceExternal.
+func NewBillService(
+	billRepository repositories.BillRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+) BillServiceExternal {
+	return &billService{
+		billRepository,
+		subscriptionRepository,
+	}
+}
+
+func (s *billService) GetBillsBySubscriptionID(
+	ctx context.Context,
+	subscriptionID string,
+	claimedUserID string,
+	query models.BillQuery,
+) (*models.BillPage, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+	id, err := bson.ObjectIDFromHex(subscriptionID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid subscription ID")
+	}
+	if err = s.checkOwner(ctx, id, claimedUserID); err != nil {
+		return nil, err
+	}
+
+	bills, total, err := s.billRepository.ListBySubscriptionID(ctx, id, &query)
+	if err != nil {
+		return nil, err
+	}
+	return &models.BillPage{Items: bills, Total: total}, nil
+}
+
+func (s *billService) GetBillByID(ctx context.Context, id string, claimedUserID string) (*models.Bill, error) {
+	billID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid bill ID")
+	}
+
+	bill, err := s.billRepository.GetByID(ctx, billID)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkOwner(ctx, bill.SubscriptionID, claimedUserID); err != nil {
+		return nil, err
+	}
+	return bill, nil
+}
+
+// checkOwner returns Forbidden unless the subscription belongs to the claimed
+// user.
+func (s *billService) checkOwner(ctx context.Context, subscriptionID bson.ObjectID, claimedUserID string) error {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	subscription, err := s.subscriptionRepository.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if subscription.UserID != userID {
+		return apperror.NewForbiddenError("You are not allowed to view bills of this subscription")
+	}
+	return nil
+}
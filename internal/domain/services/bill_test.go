@@ -0,0 +1,109 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func setupBill(t *testing.T) (
+	services.BillServiceExternal,
+	*mocks.MockBillRepository,
+	*mocks.MockSubscriptionRepository,
+) {
+	t.Helper()
+
+	billRepo := mocks.NewMockBillRepository(t)
+	subRepo := mocks.NewMockSubscriptionRepository(t)
+	return services.NewBillService(billRepo, subRepo), billRepo, subRepo
+}
+
+func TestBillService_GetBillsBySubscriptionID(t *testing.T) {
+	t.Run("success - applies the default page size", func(t *testing.T) {
+		svc, billRepo, subRepo := setupBill(t)
+		bills := []*models.Bill{validBill()}
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+		billRepo.EXPECT().
+			ListBySubscriptionID(mock.Anything, defaultSubID, &models.BillQuery{PageQuery: models.PageQuery{Limit: models.DefaultPageLimit}}).
+			Return(bills, 3, nil).
+			Once()
+
+		page, err := svc.GetBillsBySubscriptionID(t.Context(), defaultSubHex, defaultUserHex, models.BillQuery{})
+
+		require.NoError(t, err)
+		assert.Equal(t, bills, page.Items)
+		assert.Equal(t, int64(3), page.Total)
+	})
+
+	t.Run("error - subscription of another user", func(t *testing.T) {
+		svc, _, subRepo := setupBill(t)
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+
+		_, err := svc.GetBillsBySubscriptionID(t.Context(), defaultSubHex, bson.NewObjectID().Hex(), models.BillQuery{})
+
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+
+	t.Run("error - limit above the maximum", func(t *testing.T) {
+		svc, _, _ := setupBill(t)
+
+		_, err := svc.GetBillsBySubscriptionID(t.Context(), defaultSubHex, defaultUserHex, models.BillQuery{PageQuery: models.PageQuery{Limit: models.MaxPageLimit + 1}})
+
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+
+	t.Run("error - invalid subscription ID", func(t *testing.T) {
+		svc, _, _ := setupBill(t)
+
+		_, err := svc.GetBillsBySubscriptionID(t.Context(), "not-an-id", defaultUserHex, models.BillQuery{})
+
+		assertAppErr(t, err, apperror.ErrBadRequest)
+	})
+}
+
+func TestBillService_GetBillByID(t *testing.T) {
+	t.Run("success - owner of the parent subscription", func(t *testing.T) {
+		svc, billRepo, subRepo := setupBill(t)
+		bill := validBill()
+
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().GetByID(mock.Anything, bill.SubscriptionID).Return(validSub(), nil).Once()
+
+		got, err := svc.GetBillByID(t.Context(), bill.ID.Hex(), defaultUserHex)
+
+		require.NoError(t, err)
+		assert.Equal(t, bill, got)
+	})
+
+	t.Run("error - bill of another user's subscription", func(t *testing.T) {
+		svc, billRepo, subRepo := setupBill(t)
+		bill := validBill()
+
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().GetByID(mock.Anything, bill.SubscriptionID).Return(validSub(), nil).Once()
+
+		_, err := svc.GetBillByID(t.Context(), bill.ID.Hex(), bson.NewObjectID().Hex())
+
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+
+	t.Run("error - bill not found", func(t *testing.T) {
+		svc, billRepo, _ := setupBill(t)
+		billID := bson.NewObjectID()
+
+		billRepo.EXPECT().GetByID(mock.Anything, billID).Return(nil, apperror.NewNotFoundError("Bill not found")).Once()
+
+		_, err := svc.GetBillByID(t.Context(), billID.Hex(), defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrNotFound)
+	})
+}
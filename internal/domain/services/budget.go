@@ -0,0 +1,267 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/currency"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// budgetAlertSentPrefix prefixes the markers that stop a budget alert from
+// being sent twice in a month.
+const budgetAlertSentPrefix = "budget_alert_sent:"
+
+// BudgetAlertSentKey returns the Redis key marking that the alert for a budget
+// of a user has been sent in the month containing t. The overall budget has an
+// empty category.
+func BudgetAlertSentKey(userID bson.ObjectID, category models.Category, t time.Time) string {
+	if category == "" {
+		category = "overall"
+	}
+	return fmt.Sprintf("%s%s:%s:%s", budgetAlertSentPrefix, userID.Hex(), category, t.UTC().Format("2006-01"))
+}
+
+type BudgetServiceExternal interface {
+	GetBudget(ctx context.Context, claimedUserID string) (*models.Budget, error)
+	SetBudget(ctx context.Context, budget *models.Budget, claimedUserID string) (*models.Budget, error)
+	DeleteBudget(ctx context.Context, claimedUserID string) error
+	// GetBudgetStats reports the monthly spend of the user per category
+	// against their budget.
+	GetBudgetStats(ctx context.Context, claimedUserID string) (*models.BudgetStatsResponse, error)
+}
+
+type BudgetServiceInternal interface {
+	// FetchExceededBudgetsInternal returns an alert for every overall or
+	// category budget that the monthly spend of its user exceeds.
+	FetchExceededBudgetsInternal(ctx context.Context) ([]*models.BudgetAlert, error)
+	// DeleteBudgetInternal deletes the budget of the user, if any.
+	DeleteBudgetInternal(ctx context.Context, userID bson.ObjectID) error
+}
+
+type BudgetService interface {
+	BudgetServiceExternal
+	BudgetServiceInternal
+}
+
+type budgetService struct {
+	budgetRepository       repositories.BudgetRepository
+	subscriptionRepository repositories.SubscriptionRepository
+	exchangeRates          currency.Service
+	getTime                clock.NowFn
+}
+
+// NewBudgetService creates a new instance of BudgetService.
+func NewBudgetService(
+	budgetRepository repositories.BudgetRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+	exchangeRates currency.Service,
+	nowFn clock.NowFn,
+) BudgetService {
+	return &budgetService{
+		budgetRepository,
+		subscriptionRepository,
+		exchangeRates,
+		nowFn,
+	}
+}
+
+func (s *budgetService) GetBudget(ctx context.Context, claimedUserID string) (*models.Budget, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	return s.budgetRepository.GetByUserID(ctx, userID)
+}
+
+// SetBudget creates or replaces the budget of the user.
+func (s *budgetService) SetBudget(
+	ctx context.Context,
+	budget *models.Budget,
+	claimedUserID string,
+) (*models.Budget, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	budget.UserID = userID
+
+	if err = budget.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := s.getTime()
+	budget.CreatedAt = now
+	budget.UpdatedAt = now
+	existing, err := s.budgetRepository.GetByUserID(ctx, userID)
+	if err == nil {
+		budget.CreatedAt = existing.CreatedAt
+	} else if appErr, ok := errors.AsType[apperror.AppError](err); !ok || appErr.Code() != apperror.ErrNotFound {
+		return nil, err
+	}
+
+	if err = s.budgetRepository.Upsert(ctx, budget); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Budget saved",
+		logattr.Total(len(budget.Categories)),
+	)
+	return budget, nil
+}
+
+func (s *budgetService) DeleteBudget(ctx context.Context, claimedUserID string) error {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	if err = s.budgetRepository.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Budget deleted")
+	return nil
+}
+
+func (s *budgetService) GetBudgetStats(ctx context.Context, claimedUserID string) (*models.BudgetStatsResponse, error) {
+	budget, err := s.GetBudget(ctx, claimedUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.stats(ctx, budget)
+}
+
+// FetchExceededBudgetsInternal checks every budget against the spend of its
+// user. A budget that cannot be checked, for example because no exchange
+// rates are stored yet, is logged and skipped.
+func (s *budgetService) FetchExceededBudgetsInternal(ctx context.Context) ([]*models.BudgetAlert, error) {
+	budgets, err := s.budgetRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []*models.BudgetAlert
+	for _, budget := range budgets {
+		stats, err := s.stats(ctx, budget)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to check budget",
+				logattr.UserID(budget.UserID.Hex()),
+				logattr.Error(err),
+			)
+			continue
+		}
+
+		lines := append([]models.BudgetLine{stats.Overall}, stats.Categories...)
+		for _, line := range lines {
+			if !line.Exceeded {
+				continue
+			}
+			alerts = append(alerts, &models.BudgetAlert{
+				UserID:   budget.UserID,
+				Category: models.Category(line.Category),
+				Budget:   line.Budget.Amount,
+				Actual:   line.Actual.Amount,
+				Currency: budget.Currency,
+			})
+		}
+	}
+	return alerts, nil
+}
+
+func (s *budgetService) DeleteBudgetInternal(ctx context.Context, userID bson.ObjectID) error {
+	err := s.budgetRepository.Delete(ctx, userID)
+	if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// stats sums the monthly cost of the user's active subscriptions per category
+// in the budget currency and compares it against the budget. Exchange rates
+// are only loaded when a subscription is priced in another currency.
+func (s *budgetService) stats(ctx context.Context, budget *models.Budget) (*models.BudgetStatsResponse, error) {
+	subscriptions, err := s.subscriptionRepository.GetByUserID(ctx, budget.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.getTime()
+	stats := &models.BudgetStatsResponse{
+		Currency:    string(budget.Currency),
+		Categories:  []models.BudgetLine{},
+		GeneratedAt: now,
+	}
+
+	var rates *models.ExchangeRates
+	spend := make(map[models.Category]int64)
+	var total int64
+	for _, sub := range subscriptions {
+		if sub.Status != models.Active || sub.ArchivedAt != nil {
+			continue
+		}
+		cost := monthlyCost(sub)
+		if sub.Currency != budget.Currency {
+			if rates == nil {
+				if rates, err = s.exchangeRates.RatesOn(ctx, now); err != nil {
+					return nil, err
+				}
+				stats.RatesDate = rates.Date.Format(time.DateOnly)
+			}
+			if cost, err = rates.Convert(cost, sub.Currency, budget.Currency); err != nil {
+				return nil, err
+			}
+		}
+		spend[sub.Category] += cost
+		total += cost
+	}
+
+	stats.Overall = budgetLine("", budget.Overall, total, budget.Currency)
+	categories := make(map[models.Category]struct{}, len(spend)+len(budget.Categories))
+	for category := range spend {
+		categories[category] = struct{}{}
+	}
+	for category := range budget.Categories {
+		categories[category] = struct{}{}
+	}
+	for category := range categories {
+		stats.Categories = append(stats.Categories,
+			budgetLine(category, budget.Categories[category], spend[category], budget.Currency))
+	}
+	slices.SortFunc(stats.Categories, func(a, b models.BudgetLine) int {
+		return cmp.Compare(a.Category, b.Category)
+	})
+	return stats, nil
+}
+
+// budgetLine compares actual against limit; a zero limit means no budget.
+func budgetLine(category models.Category, limit, actual int64, c models.Currency) models.BudgetLine {
+	line := models.BudgetLine{
+		Category: string(category),
+		Actual:   models.NewCurrencyAmount(actual, c),
+	}
+	if limit > 0 {
+		budget := models.NewCurrencyAmount(limit, c)
+		line.Budget = &budget
+		line.Exceeded = actual > limit
+	}
+	return line
+}
+
+// monthlyCost returns what a subscription costs per month, counting a yearly
+// subscription for a twelfth of its price, rounded half up.
+func monthlyCost(sub *models.Subscription) int64 {
+	if sub.Frequency == models.Yearly {
+		return (sub.Price + 6) / 12
+	}
+	return sub.Price
+}
@@ -0,0 +1,261 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	currencymocks "github.com/anuragthepathak/subscription-management/internal/currency/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func setupBudget(t *testing.T) (
+	services.BudgetService,
+	*repomocks.MockBudgetRepository,
+	*repomocks.MockSubscriptionRepository,
+	*currencymocks.MockService,
+) {
+	t.Helper()
+
+	budgetRepo := repomocks.NewMockBudgetRepository(t)
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	rates := currencymocks.NewMockService(t)
+	svc := services.NewBudgetService(budgetRepo, subRepo, rates, func() time.Time { return mockTime })
+	return svc, budgetRepo, subRepo, rates
+}
+
+func validBudget() *models.Budget {
+	return &models.Budget{
+		UserID:   defaultUserID,
+		Currency: models.USD,
+		Overall:  5000,
+		Categories: map[models.Category]int64{
+			models.Entertainment: 1500,
+			models.News:          1000,
+		},
+		CreatedAt: mockTime.AddDate(0, -1, 0),
+		UpdatedAt: mockTime.AddDate(0, -1, 0),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// SetBudget
+// ---------------------------------------------------------------------------
+
+func TestBudgetService_SetBudget(t *testing.T) {
+	t.Run("creates budget", func(t *testing.T) {
+		svc, budgetRepo, _, _ := setupBudget(t)
+		budgetRepo.EXPECT().
+			GetByUserID(mock.Anything, defaultUserID).
+			Return(nil, apperror.NewNotFoundError("Document not found")).
+			Once()
+		budgetRepo.EXPECT().Upsert(mock.Anything, mock.AnythingOfType("*models.Budget")).Return(nil).Once()
+
+		budget := &models.Budget{Currency: models.EUR, Overall: 2000}
+		got, err := svc.SetBudget(t.Context(), budget, defaultUserHex)
+		require.NoError(t, err)
+		assert.Equal(t, defaultUserID, got.UserID)
+		assert.Equal(t, mockTime, got.CreatedAt)
+		assert.Equal(t, mockTime, got.UpdatedAt)
+	})
+
+	t.Run("keeps creation time on update", func(t *testing.T) {
+		svc, budgetRepo, _, _ := setupBudget(t)
+		existing := validBudget()
+		budgetRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return(existing, nil).Once()
+		budgetRepo.EXPECT().Upsert(mock.Anything, mock.AnythingOfType("*models.Budget")).Return(nil).Once()
+
+		got, err := svc.SetBudget(t.Context(), &models.Budget{
+			Currency:   models.USD,
+			Categories: map[models.Category]int64{models.Sports: 800},
+		}, defaultUserHex)
+		require.NoError(t, err)
+		assert.Equal(t, existing.CreatedAt, got.CreatedAt)
+		assert.Equal(t, mockTime, got.UpdatedAt)
+	})
+
+	t.Run("rejects invalid budget", func(t *testing.T) {
+		svc, _, _, _ := setupBudget(t)
+
+		_, err := svc.SetBudget(t.Context(), &models.Budget{
+			Currency:   models.USD,
+			Categories: map[models.Category]int64{"gaming": 800},
+		}, defaultUserHex)
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+
+	t.Run("invalid user ID", func(t *testing.T) {
+		svc, _, _, _ := setupBudget(t)
+
+		_, err := svc.SetBudget(t.Context(), validBudget(), "not-an-id")
+		assertAppErr(t, err, apperror.ErrUnauthorized)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GetBudgetStats
+// ---------------------------------------------------------------------------
+
+func TestBudgetService_GetBudgetStats(t *testing.T) {
+	netflix := validSub() // 999 USD monthly, entertainment.
+	disney := validSub()
+	disney.Price = 799
+	yearly := validSub()
+	yearly.Category = models.News
+	yearly.Frequency = models.Yearly
+	yearly.Price = 12000 // 1000 per month.
+	canceled := validSub()
+	canceled.Category = models.Sports
+	canceled.Status = models.Canceled
+
+	svc, budgetRepo, subRepo, _ := setupBudget(t)
+	budgetRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return(validBudget(), nil).Once()
+	subRepo.EXPECT().
+		GetByUserID(mock.Anything, defaultUserID).
+		Return([]*models.Subscription{netflix, disney, yearly, canceled}, nil).
+		Once()
+
+	got, err := svc.GetBudgetStats(t.Context(), defaultUserHex)
+	require.NoError(t, err)
+
+	assert.Equal(t, "USD", got.Currency)
+	assert.Empty(t, got.RatesDate)
+	assert.Equal(t, models.NewCurrencyAmount(2798, models.USD), got.Overall.Actual)
+	assert.False(t, got.Overall.Exceeded)
+
+	require.Len(t, got.Categories, 2)
+	entertainment := got.Categories[0]
+	assert.Equal(t, "entertainment", entertainment.Category)
+	assert.Equal(t, models.NewCurrencyAmount(1798, models.USD), entertainment.Actual)
+	require.NotNil(t, entertainment.Budget)
+	assert.Equal(t, models.NewCurrencyAmount(1500, models.USD), *entertainment.Budget)
+	assert.True(t, entertainment.Exceeded)
+
+	news := got.Categories[1]
+	assert.Equal(t, "news", news.Category)
+	assert.Equal(t, models.NewCurrencyAmount(1000, models.USD), news.Actual)
+	assert.False(t, news.Exceeded, "spend equal to the budget does not exceed it")
+}
+
+func TestBudgetService_GetBudgetStats_Converted(t *testing.T) {
+	eur := validSub()
+	eur.Currency = models.EUR
+	eur.Price = 1000
+	eur.Category = models.Technology
+
+	svc, budgetRepo, subRepo, rates := setupBudget(t)
+	budgetRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return(validBudget(), nil).Once()
+	subRepo.EXPECT().
+		GetByUserID(mock.Anything, defaultUserID).
+		Return([]*models.Subscription{validSub(), eur}, nil).
+		Once()
+	rates.EXPECT().
+		RatesOn(mock.Anything, mockTime).
+		Return(&models.ExchangeRates{
+			Date:  mockToday,
+			Base:  models.EUR,
+			Rates: map[models.Currency]float64{models.USD: 1.25},
+		}, nil).
+		Once()
+
+	got, err := svc.GetBudgetStats(t.Context(), defaultUserHex)
+	require.NoError(t, err)
+
+	assert.Equal(t, mockToday.Format(time.DateOnly), got.RatesDate)
+	assert.Equal(t, models.NewCurrencyAmount(999+1250, models.USD), got.Overall.Actual)
+
+	// Categories with a budget are reported even without spend, and
+	// categories with spend even without a budget.
+	require.Len(t, got.Categories, 3)
+	assert.Equal(t, "news", got.Categories[1].Category)
+	assert.Equal(t, int64(0), got.Categories[1].Actual.Amount)
+	assert.Equal(t, "technology", got.Categories[2].Category)
+	assert.Nil(t, got.Categories[2].Budget)
+	assert.False(t, got.Categories[2].Exceeded)
+}
+
+func TestBudgetService_GetBudgetStats_NoBudget(t *testing.T) {
+	svc, budgetRepo, _, _ := setupBudget(t)
+	budgetRepo.EXPECT().
+		GetByUserID(mock.Anything, defaultUserID).
+		Return(nil, apperror.NewNotFoundError("Document not found")).
+		Once()
+
+	_, err := svc.GetBudgetStats(t.Context(), defaultUserHex)
+	assertAppErr(t, err, apperror.ErrNotFound)
+}
+
+// ---------------------------------------------------------------------------
+// FetchExceededBudgetsInternal
+// ---------------------------------------------------------------------------
+
+func TestBudgetService_FetchExceededBudgetsInternal(t *testing.T) {
+	overspent := validBudget()
+	overspent.Overall = 1500
+	other := validBudget()
+	other.UserID = bson.NewObjectID()
+	other.Currency = models.GBP
+
+	netflix := validSub()
+	disney := validSub()
+	disney.Price = 799
+
+	svc, budgetRepo, subRepo, rates := setupBudget(t)
+	budgetRepo.EXPECT().GetAll(mock.Anything).Return([]*models.Budget{overspent, other}, nil).Once()
+	subRepo.EXPECT().
+		GetByUserID(mock.Anything, defaultUserID).
+		Return([]*models.Subscription{netflix, disney}, nil).
+		Once()
+	subRepo.EXPECT().
+		GetByUserID(mock.Anything, other.UserID).
+		Return([]*models.Subscription{validSub()}, nil).
+		Once()
+	// A budget that cannot be checked is skipped.
+	rates.EXPECT().
+		RatesOn(mock.Anything, mockTime).
+		Return(nil, apperror.NewNotFoundError("Document not found")).
+		Once()
+
+	got, err := svc.FetchExceededBudgetsInternal(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, []*models.BudgetAlert{
+		{UserID: defaultUserID, Budget: 1500, Actual: 1798, Currency: models.USD},
+		{UserID: defaultUserID, Category: models.Entertainment, Budget: 1500, Actual: 1798, Currency: models.USD},
+	}, got)
+}
+
+// ---------------------------------------------------------------------------
+// DeleteBudgetInternal
+// ---------------------------------------------------------------------------
+
+func TestBudgetService_DeleteBudgetInternal(t *testing.T) {
+	t.Run("deletes the budget", func(t *testing.T) {
+		svc, budgetRepo, _, _ := setupBudget(t)
+		budgetRepo.EXPECT().Delete(mock.Anything, defaultUserID).Return(nil).Once()
+
+		require.NoError(t, svc.DeleteBudgetInternal(t.Context(), defaultUserID))
+	})
+
+	t.Run("succeeds when no budget was set", func(t *testing.T) {
+		svc, budgetRepo, _, _ := setupBudget(t)
+		budgetRepo.EXPECT().Delete(mock.Anything, defaultUserID).Return(apperror.NewNotFoundError("Document not found")).Once()
+
+		require.NoError(t, svc.DeleteBudgetInternal(t.Context(), defaultUserID))
+	})
+}
+
+func TestBudgetAlertSentKey(t *testing.T) {
+	id := bson.NewObjectID()
+
+	assert.Equal(t, "budget_alert_sent:"+id.Hex()+":overall:2025-01",
+		services.BudgetAlertSentKey(id, "", mockTime))
+	assert.Equal(t, "budget_alert_sent:"+id.Hex()+":news:2025-01",
+		services.BudgetAlertSentKey(id, models.News, mockTime))
+}
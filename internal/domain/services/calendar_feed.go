@@ -0,0 +1,267 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// icsLineLimit is the longest content line RFC 5545 allows, in octets,
+// before it must be folded.
+const icsLineLimit = 75
+
+// CalendarFeedConfig holds the settings for the private ICS feeds of renewals.
+type CalendarFeedConfig struct {
+	SigningSecret string `mapstructure:"signing_secret"`
+	BaseURL       string `mapstructure:"base_url"` // Public origin prepended to feed URLs; empty yields relative URLs.
+	Months        int    `mapstructure:"months"`   // How far ahead the feed lists renewals.
+}
+
+// CalendarFeedService issues private calendar feed URLs and renders the feeds.
+type CalendarFeedService interface {
+	// GetFeedURL returns the private feed URL of the user.
+	GetFeedURL(ctx context.Context, claimedUserID string) (*models.CalendarFeed, error)
+	// RegenerateFeedURL issues a new private feed URL for the user and
+	// revokes the URLs issued before.
+	RegenerateFeedURL(ctx context.Context, claimedUserID string) (*models.CalendarFeed, error)
+	// RenderFeed verifies a feed token and renders the upcoming renewals of
+	// its user as an iCalendar document.
+	RenderFeed(ctx context.Context, token string) ([]byte, error)
+	// SetReminderDays replaces the server's reminder days, as after a config
+	// reload.
+	SetReminderDays(days []int)
+}
+
+type calendarFeedService struct {
+	userRepository         repositories.UserRepository
+	subscriptionRepository repositories.SubscriptionRepository
+	preferenceService      NotificationPreferenceServiceInternal
+	config                 CalendarFeedConfig
+	mu                     sync.RWMutex // Guards reminderDays.
+	reminderDays           []int
+	getTime                clock.NowFn
+}
+
+// NewCalendarFeedService creates a new instance of CalendarFeedService. Each
+// renewal event carries an alarm for every day the user is reminded on,
+// reminderDays unless they set their own, matching the reminder emails.
+func NewCalendarFeedService(
+	userRepository repositories.UserRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+	preferenceService NotificationPreferenceServiceInternal,
+	config CalendarFeedConfig,
+	reminderDays []int,
+	nowFn clock.NowFn,
+) CalendarFeedService {
+	return &calendarFeedService{
+		userRepository:         userRepository,
+		subscriptionRepository: subscriptionRepository,
+		preferenceService:      preferenceService,
+		config:                 config,
+		reminderDays:           reminderDays,
+		getTime:                nowFn,
+	}
+}
+
+func (s *calendarFeedService) SetReminderDays(days []int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reminderDays = slices.Clone(days)
+}
+
+// GetFeedURL returns the feed URL of the caller. The URL does not expire, so
+// calendar apps can keep polling it, until the caller regenerates it. URLs
+// issued in the earlier /api/v1/calendar/<token>.ics form stay valid.
+func (s *calendarFeedService) GetFeedURL(ctx context.Context, claimedUserID string) (*models.CalendarFeed, error) {
+	user, err := s.claimedUser(ctx, claimedUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.feed(user), nil
+}
+
+func (s *calendarFeedService) RegenerateFeedURL(ctx context.Context, claimedUserID string) (*models.CalendarFeed, error) {
+	user, err := s.claimedUser(ctx, claimedUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.CalendarFeedNonce = rand.Text()
+	user.UpdatedAt = s.getTime()
+	if user, err = s.userRepository.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Calendar feed URL regenerated",
+		logattr.UserID(claimedUserID),
+	)
+	return s.feed(user), nil
+}
+
+func (s *calendarFeedService) claimedUser(ctx context.Context, claimedUserID string) (*models.User, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	return s.userRepository.FindByID(ctx, userID)
+}
+
+// feed builds the feed URL of the user.
+func (s *calendarFeedService) feed(user *models.User) *models.CalendarFeed {
+	token := user.ID.Hex() + "-" + hex.EncodeToString(s.sign(user))
+	return &models.CalendarFeed{
+		URL: fmt.Sprintf("%s/api/v1/subscriptions/calendar.ics?token=%s", s.config.BaseURL, token),
+	}
+}
+
+// RenderFeed renders the renewals of the token's user over the configured
+// number of months. The feed is generated on every request, so it always
+// reflects the current subscriptions. The token stands in for
+// authentication, so any mismatch, a revoked URL, or a closed account is
+// reported as unauthorized.
+func (s *calendarFeedService) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	rawID, signature, _ := strings.Cut(token, "-")
+	userID, err := bson.ObjectIDFromHex(rawID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid calendar feed")
+	}
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid calendar feed")
+	}
+	user, err := s.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			return nil, apperror.NewUnauthorizedError("Invalid calendar feed")
+		}
+		return nil, err
+	}
+	if user.ClosedAt != nil || !hmac.Equal(given, s.sign(user)) {
+		return nil, apperror.NewUnauthorizedError("Invalid calendar feed")
+	}
+
+	now := s.getTime()
+	end := now.AddDate(0, s.config.Months, 0)
+	subscriptions, err := s.subscriptionRepository.GetActiveByUserIDRenewingBefore(ctx, userID, end)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := s.preferenceService.FetchPreferencesInternal(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Renewals that are due but not yet processed are still listed.
+	renewals := projectRenewals(subscriptions, time.Time{}, end)
+	s.mu.RLock()
+	reminderDays := s.reminderDays
+	s.mu.RUnlock()
+	return renderICS(renewals, prefs.ReminderDaysOr(reminderDays), now), nil
+}
+
+// renderICS writes the renewals as an iCalendar document of all-day events,
+// each with an alarm reminderDays before it.
+func renderICS(renewals []models.UpcomingRenewal, reminderDays []int, now time.Time) []byte {
+	var buf bytes.Buffer
+	line := func(format string, args ...any) {
+		writeICSLine(&buf, fmt.Sprintf(format, args...))
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Subscription Management//Renewals//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:Subscription renewals")
+	line("REFRESH-INTERVAL;VALUE=DURATION:PT12H")
+	line("X-PUBLISHED-TTL:PT12H")
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, renewal := range renewals {
+		day := renewal.RenewsOn.UTC()
+		amount := models.FormatMoney(renewal.Amount, models.Currency(renewal.Currency))
+
+		line("BEGIN:VEVENT")
+		line("UID:%s-%s@subscription-management", renewal.SubscriptionID, day.Format("20060102"))
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART;VALUE=DATE:%s", day.Format("20060102"))
+		line("DTEND;VALUE=DATE:%s", day.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:%s", escapeICSText(fmt.Sprintf("%s renews (%s)", renewal.Name, amount)))
+		line("DESCRIPTION:%s", escapeICSText(fmt.Sprintf("%s renews for %s.", renewal.Name, amount)))
+		line("TRANSP:TRANSPARENT")
+		for _, days := range reminderDays {
+			line("BEGIN:VALARM")
+			line("ACTION:DISPLAY")
+			line("DESCRIPTION:%s", escapeICSText(renewal.Name+" renews soon"))
+			line("TRIGGER:-P%dD", days)
+			line("END:VALARM")
+		}
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// sign is domain-separated from file and cancel link signatures, so no other
+// signature can pass as a feed token even if they share a secret. Users who
+// never regenerated their URL have no nonce, which keeps the URLs issued
+// before nonces existed valid.
+func (s *calendarFeedService) sign(user *models.User) []byte {
+	mac := hmac.New(sha256.New, []byte(s.config.SigningSecret))
+	fmt.Fprintf(mac, "calendar.%s", user.ID.Hex())
+	if user.CalendarFeedNonce != "" {
+		fmt.Fprintf(mac, ".%s", user.CalendarFeedNonce)
+	}
+	return mac.Sum(nil)
+}
+
+// writeICSLine writes a content line terminated by CRLF, folding it into
+// continuation lines starting with a space once it exceeds the line limit.
+// Lines are only broken between UTF-8 characters.
+func writeICSLine(buf *bytes.Buffer, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icsLineLimit - 1 // The leading space counts towards the limit.
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+var icsTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// escapeICSText escapes a value of the iCalendar TEXT type.
+func escapeICSText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
@@ -0,0 +1,204 @@
+package services_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var calendarFeedCfg = services.CalendarFeedConfig{
+	SigningSecret: "test-calendar-secret",
+	BaseURL:       "https://subs.example.com",
+	Months:        3,
+}
+
+// setupCalendarFeed returns the service and its mocks. The user repository
+// serves feedUser for the default user and a user without a nonce for any
+// other ID.
+func setupCalendarFeed(t *testing.T, feedUser *models.User) (
+	services.CalendarFeedService,
+	*repomocks.MockSubscriptionRepository,
+	*mocks.MockNotificationPreferenceServiceInternal,
+) {
+	t.Helper()
+
+	userRepo := repomocks.NewMockUserRepository(t)
+	userRepo.EXPECT().
+		FindByID(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, id bson.ObjectID) (*models.User, error) {
+			if id == defaultUserID {
+				return feedUser, nil
+			}
+			return &models.User{ID: id}, nil
+		}).
+		Maybe()
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	prefs := mocks.NewMockNotificationPreferenceServiceInternal(t)
+	svc := services.NewCalendarFeedService(userRepo, subRepo, prefs, calendarFeedCfg, []int{1, 7}, func() time.Time { return mockTime })
+	return svc, subRepo, prefs
+}
+
+// feedToken returns the token of the feed URL issued for the default user.
+func feedToken(t *testing.T, svc services.CalendarFeedService) string {
+	t.Helper()
+
+	feed, err := svc.GetFeedURL(t.Context(), defaultUserHex)
+	require.NoError(t, err)
+	return tokenOf(t, feed)
+}
+
+func tokenOf(t *testing.T, feed *models.CalendarFeed) string {
+	t.Helper()
+
+	require.True(t, strings.HasPrefix(feed.URL, calendarFeedCfg.BaseURL+"/api/v1/subscriptions/calendar.ics?token="))
+	parsed, err := url.Parse(feed.URL)
+	require.NoError(t, err)
+	return parsed.Query().Get("token")
+}
+
+// ---------------------------------------------------------------------------
+// RenderFeed
+// ---------------------------------------------------------------------------
+
+func TestCalendarFeedService_RenderFeed(t *testing.T) {
+	netflix := validSub() // Renews 2025-02-15, then monthly.
+	netflix.Name = "Netflix, Premium; 4K"
+
+	svc, subRepo, prefs := setupCalendarFeed(t, &models.User{ID: defaultUserID})
+	subRepo.EXPECT().
+		GetActiveByUserIDRenewingBefore(mock.Anything, defaultUserID, mockTime.AddDate(0, 3, 0)).
+		Return([]*models.Subscription{netflix}, nil).
+		Once()
+	prefs.EXPECT().FetchPreferencesInternal(mock.Anything, defaultUserID).Return(&models.NotificationPreferences{}, nil).Once()
+
+	feed, err := svc.RenderFeed(t.Context(), feedToken(t, svc))
+	require.NoError(t, err)
+	ics := string(feed)
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 3, strings.Count(ics, "BEGIN:VEVENT"), "renewals on Feb 15, Mar 15 and Apr 15")
+	assert.Contains(t, ics, "DTSTART;VALUE=DATE:20250215\r\n")
+	assert.Contains(t, ics, "DTSTART;VALUE=DATE:20250315\r\n")
+	assert.Contains(t, ics, "UID:"+defaultSubID.Hex()+"-20250215@subscription-management\r\n")
+	assert.Contains(t, ics, `SUMMARY:Netflix\, Premium\; 4K renews (USD 9.99)`)
+	assert.Equal(t, 6, strings.Count(ics, "BEGIN:VALARM"), "one alarm per reminder day")
+	assert.Contains(t, ics, "TRIGGER:-P7D\r\n")
+
+	for line := range strings.SplitSeq(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, "line not folded: %q", line)
+	}
+}
+
+func TestCalendarFeedService_RenderFeed_UserReminderDays(t *testing.T) {
+	svc, subRepo, prefs := setupCalendarFeed(t, &models.User{ID: defaultUserID})
+	subRepo.EXPECT().
+		GetActiveByUserIDRenewingBefore(mock.Anything, defaultUserID, mockTime.AddDate(0, 3, 0)).
+		Return([]*models.Subscription{validSub()}, nil).
+		Once()
+	prefs.EXPECT().
+		FetchPreferencesInternal(mock.Anything, defaultUserID).
+		Return(&models.NotificationPreferences{ReminderDays: []int{14}}, nil).
+		Once()
+
+	feed, err := svc.RenderFeed(t.Context(), feedToken(t, svc))
+	require.NoError(t, err)
+	ics := string(feed)
+
+	assert.Equal(t, 3, strings.Count(ics, "BEGIN:VALARM"), "one alarm per renewal")
+	assert.Contains(t, ics, "TRIGGER:-P14D\r\n")
+	assert.NotContains(t, ics, "TRIGGER:-P7D")
+}
+
+func TestCalendarFeedService_RenderFeed_InvalidToken(t *testing.T) {
+	svc, _, _ := setupCalendarFeed(t, &models.User{ID: defaultUserID})
+	token := feedToken(t, svc)
+	otherUser := bson.NewObjectID().Hex()
+	_, signature, _ := strings.Cut(token, "-")
+
+	for name, token := range map[string]string{
+		"another user":      otherUser + "-" + signature,
+		"tampered":          defaultUserHex + "-" + strings.Repeat("0", 64),
+		"missing signature": defaultUserHex,
+		"malformed":         "not-a-token",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.RenderFeed(t.Context(), token)
+			assertAppErr(t, err, apperror.ErrUnauthorized)
+		})
+	}
+}
+
+func TestCalendarFeedService_RenderFeed_ClosedAccount(t *testing.T) {
+	closedAt := mockTime
+	svc, _, _ := setupCalendarFeed(t, &models.User{ID: defaultUserID, ClosedAt: &closedAt})
+
+	_, err := svc.RenderFeed(t.Context(), feedToken(t, svc))
+	assertAppErr(t, err, apperror.ErrUnauthorized)
+}
+
+func TestCalendarFeedService_GetFeedURL_InvalidUser(t *testing.T) {
+	svc, _, _ := setupCalendarFeed(t, &models.User{ID: defaultUserID})
+
+	_, err := svc.GetFeedURL(t.Context(), "not-an-id")
+	assertAppErr(t, err, apperror.ErrUnauthorized)
+}
+
+// ---------------------------------------------------------------------------
+// RegenerateFeedURL
+// ---------------------------------------------------------------------------
+
+func TestCalendarFeedService_RegenerateFeedURL(t *testing.T) {
+	user := &models.User{ID: defaultUserID}
+	userRepo := repomocks.NewMockUserRepository(t)
+	userRepo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(user, nil)
+	userRepo.EXPECT().
+		Update(mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+			return u.CalendarFeedNonce != "" && u.UpdatedAt.Equal(mockTime)
+		})).
+		Return(user, nil).
+		Once()
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	prefs := mocks.NewMockNotificationPreferenceServiceInternal(t)
+	svc := services.NewCalendarFeedService(userRepo, subRepo, prefs, calendarFeedCfg, []int{1, 7}, func() time.Time { return mockTime })
+
+	// Issued before any regeneration, without a nonce.
+	oldToken := feedToken(t, svc)
+
+	feed, err := svc.RegenerateFeedURL(t.Context(), defaultUserHex)
+	require.NoError(t, err)
+	newToken := tokenOf(t, feed)
+	assert.NotEqual(t, oldToken, newToken)
+
+	t.Run("revokes the old URL", func(t *testing.T) {
+		_, err := svc.RenderFeed(t.Context(), oldToken)
+		assertAppErr(t, err, apperror.ErrUnauthorized)
+	})
+
+	t.Run("serves the new URL", func(t *testing.T) {
+		subRepo.EXPECT().
+			GetActiveByUserIDRenewingBefore(mock.Anything, defaultUserID, mockTime.AddDate(0, 3, 0)).
+			Return(nil, nil).
+			Once()
+		prefs.EXPECT().FetchPreferencesInternal(mock.Anything, defaultUserID).Return(&models.NotificationPreferences{}, nil).Once()
+
+		_, err := svc.RenderFeed(t.Context(), newToken)
+		require.NoError(t, err)
+	})
+
+	t.Run("is returned by GetFeedURL", func(t *testing.T) {
+		assert.Equal(t, newToken, feedToken(t, svc))
+	})
+}
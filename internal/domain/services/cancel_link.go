@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// unusedSuggestionSentPrefix prefixes the markers that stop a savings
+// suggestion from being sent twice for the same renewal.
+const unusedSuggestionSentPrefix = "unused_suggestion_sent:"
+
+// UnusedSuggestionSentKey returns the Redis key marking that the savings
+// suggestion for the renewal of a subscription on renewsOn has been sent.
+func UnusedSuggestionSentKey(subscriptionID bson.ObjectID, renewsOn time.Time) string {
+	return fmt.Sprintf("%s%s:%d", unusedSuggestionSentPrefix, subscriptionID.Hex(), renewsOn.Unix())
+}
+
+// CancelLinkConfig holds the settings for the one-click cancel links sent
+// with savings suggestions.
+type CancelLinkConfig struct {
+	SigningSecret string `mapstructure:"signing_secret"`
+	BaseURL       string `mapstructure:"base_url"` // Public origin prepended to cancel links; empty yields relative URLs.
+}
+
+type CancelLinkServiceExternal interface {
+	// CancelWithLink cancels the subscription a signed cancel link was
+	// issued for.
+	CancelWithLink(ctx context.Context, id, expires, signature string) (*models.Subscription, error)
+}
+
+type CancelLinkServiceInternal interface {
+	// SignCancelLinkInternal builds a cancel link for the subscription that
+	// is valid until its next renewal.
+	SignCancelLinkInternal(*models.Subscription) string
+}
+
+type CancelLinkService interface {
+	CancelLinkServiceExternal
+	CancelLinkServiceInternal
+}
+
+type cancelLinkService struct {
+	subscriptionService SubscriptionService
+	config              CancelLinkConfig
+	getTime             clock.NowFn
+}
+
+// NewCancelLinkService creates a new instance of CancelLinkService.
+func NewCancelLinkService(
+	subscriptionService SubscriptionService,
+	config CancelLinkConfig,
+	nowFn clock.NowFn,
+) CancelLinkService {
+	return &cancelLinkService{
+		subscriptionService,
+		config,
+		nowFn,
+	}
+}
+
+// CancelWithLink verifies a signed cancel link and cancels the subscription
+// on behalf of its owner. The signature stands in for authentication, so any
+// mismatch or expiry is reported as unauthorized without revealing whether
+// the subscription exists.
+func (s *cancelLinkService) CancelWithLink(
+	ctx context.Context,
+	id, expires, signature string,
+) (*models.Subscription, error) {
+	subscriptionID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid subscription ID")
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid cancel link")
+	}
+	given, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(given, s.sign(subscriptionID, expiresAt)) {
+		return nil, apperror.NewUnauthorizedError("Invalid cancel link")
+	}
+	if s.getTime().Unix() > expiresAt {
+		return nil, apperror.NewUnauthorizedError("Cancel link expired")
+	}
+
+	subscription, err := s.subscriptionService.FetchSubscriptionByIDInternal(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	canceled, err := s.subscriptionService.CancelSubscription(ctx, id, subscription.UserID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Subscription canceled through cancel link",
+		logattr.SubscriptionID(id),
+		logattr.UserID(subscription.UserID.Hex()),
+	)
+	return canceled, nil
+}
+
+func (s *cancelLinkService) SignCancelLinkInternal(subscription *models.Subscription) string {
+	expiresAt := subscription.ValidTill.Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt, 10))
+	query.Set("signature", hex.EncodeToString(s.sign(subscription.ID, expiresAt)))
+
+	return fmt.Sprintf("%s/api/v1/cancel-links/%s?%s", s.config.BaseURL, subscription.ID.Hex(), query.Encode())
+}
+
+// sign is domain-separated from file signatures, so a download signature can
+// never pass as a cancel link even if both share a secret.
+func (s *cancelLinkService) sign(id bson.ObjectID, expiresAt int64) []byte {
+	mac := hmac.New(sha256.New, []byte(s.config.SigningSecret))
+	fmt.Fprintf(mac, "cancel.%s.%d", id.Hex(), expiresAt)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,98 @@
+package services_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var cancelLinkCfg = services.CancelLinkConfig{
+	SigningSecret: "test-cancel-secret",
+	BaseURL:       "https://subs.example.com",
+}
+
+func setupCancelLink(t *testing.T, now time.Time) (services.CancelLinkService, *svcmocks.MockSubscriptionService) {
+	t.Helper()
+
+	subSvc := svcmocks.NewMockSubscriptionService(t)
+	return services.NewCancelLinkService(subSvc, cancelLinkCfg, func() time.Time { return now }), subSvc
+}
+
+// signedCancelLink returns the id, expires and signature query values of a
+// cancel link issued for validSub().
+func signedCancelLink(t *testing.T, svc services.CancelLinkService) (string, string, string) {
+	t.Helper()
+
+	link := svc.SignCancelLinkInternal(validSub())
+	require.True(t, strings.HasPrefix(link, cancelLinkCfg.BaseURL+"/api/v1/cancel-links/"+defaultSubID.Hex()+"?"))
+
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	return defaultSubID.Hex(), parsed.Query().Get("expires"), parsed.Query().Get("signature")
+}
+
+// ---------------------------------------------------------------------------
+// CancelWithLink
+// ---------------------------------------------------------------------------
+
+func TestCancelLinkService_CancelWithLink(t *testing.T) {
+	t.Run("cancels on behalf of the owner", func(t *testing.T) {
+		svc, subSvc := setupCancelLink(t, mockTime)
+		id, expires, signature := signedCancelLink(t, svc)
+
+		canceled := validCanceledSub()
+		subSvc.EXPECT().FetchSubscriptionByIDInternal(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+		subSvc.EXPECT().CancelSubscription(mock.Anything, id, defaultUserHex).Return(canceled, nil).Once()
+
+		got, err := svc.CancelWithLink(t.Context(), id, expires, signature)
+		require.NoError(t, err)
+		assert.Equal(t, canceled, got)
+	})
+
+	t.Run("rejects a tampered signature", func(t *testing.T) {
+		svc, _ := setupCancelLink(t, mockTime)
+		id, expires, _ := signedCancelLink(t, svc)
+
+		_, err := svc.CancelWithLink(t.Context(), id, expires, strings.Repeat("0", 64))
+		assertAppErr(t, err, apperror.ErrUnauthorized)
+	})
+
+	t.Run("rejects a link for another subscription", func(t *testing.T) {
+		svc, _ := setupCancelLink(t, mockTime)
+		_, expires, signature := signedCancelLink(t, svc)
+
+		_, err := svc.CancelWithLink(t.Context(), bson.NewObjectID().Hex(), expires, signature)
+		assertAppErr(t, err, apperror.ErrUnauthorized)
+	})
+
+	t.Run("rejects an expired link", func(t *testing.T) {
+		svc, _ := setupCancelLink(t, mockOneMonthLater.Add(time.Second))
+		id, expires, signature := signedCancelLink(t, svc)
+
+		_, err := svc.CancelWithLink(t.Context(), id, expires, signature)
+		assertAppErr(t, err, apperror.ErrUnauthorized)
+	})
+
+	t.Run("invalid subscription ID", func(t *testing.T) {
+		svc, _ := setupCancelLink(t, mockTime)
+
+		_, err := svc.CancelWithLink(t.Context(), "not-an-id", "0", "")
+		assertAppErr(t, err, apperror.ErrBadRequest)
+	})
+}
+
+func TestUnusedSuggestionSentKey(t *testing.T) {
+	id := bson.NewObjectID()
+
+	assert.Equal(t, "unused_suggestion_sent:"+id.Hex()+":1739620800",
+		services.UnusedSuggestionSentKey(id, time.Date(2025, 2, 15, 12, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+)
+
+// catalogJSON is the curated list of well-known services. Prices are the
+// typical US prices in minor units.
+//
+//go:embed catalog.json
+var catalogJSON []byte
+
+// maxCatalogResults caps the entries one search returns.
+const maxCatalogResults = 50
+
+// CatalogConfig holds the settings for the catalog of well-known services.
+type CatalogConfig struct {
+	// LogoURL is the URL of a service's logo, with %s standing for the
+	// domain of its website. Empty leaves the catalog without logos.
+	LogoURL string `mapstructure:"logo_url"`
+}
+
+// CatalogServiceExternal searches the catalog of well-known services users
+// can create subscriptions from.
+type CatalogServiceExternal interface {
+	// SearchCatalog returns up to limit entries matching query, best matches
+	// first. An empty query lists the catalog by name.
+	SearchCatalog(ctx context.Context, query string, limit int) ([]*models.CatalogEntry, error)
+	GetCatalogEntry(ctx context.Context, id string) (*models.CatalogEntry, error)
+	// PrefillSubscription fills the fields of subscription left empty from
+	// the catalog entry and its plan, or its default plan for an empty plan.
+	PrefillSubscription(ctx context.Context, subscription *models.Subscription, catalogID, plan string) error
+}
+
+type CatalogServiceInternal interface {
+	// SyncCatalogInternal makes the stored catalog match the curated list
+	// shipped with the service.
+	SyncCatalogInternal(context.Context) error
+}
+
+type CatalogService interface {
+	CatalogServiceExternal
+	CatalogServiceInternal
+}
+
+type catalogService struct {
+	catalogRepository repositories.CatalogRepository
+	config            CatalogConfig
+	getTime           clock.NowFn
+}
+
+// NewCatalogService creates a new instance of CatalogService.
+func NewCatalogService(
+	catalogRepository repositories.CatalogRepository,
+	config CatalogConfig,
+	nowFn clock.NowFn,
+) CatalogService {
+	return &catalogService{
+		catalogRepository,
+		config,
+		nowFn,
+	}
+}
+
+func (s *catalogService) SearchCatalog(ctx context.Context, query string, limit int) ([]*models.CatalogEntry, error) {
+	if limit <= 0 || limit > maxCatalogResults {
+		limit = maxCatalogResults
+	}
+	return s.catalogRepository.Search(ctx, strings.TrimSpace(query), int64(limit))
+}
+
+func (s *catalogService) GetCatalogEntry(ctx context.Context, id string) (*models.CatalogEntry, error) {
+	return s.catalogRepository.GetByID(ctx, id)
+}
+
+func (s *catalogService) PrefillSubscription(
+	ctx context.Context,
+	subscription *models.Subscription,
+	catalogID, plan string,
+) error {
+	entry, err := s.catalogRepository.GetByID(ctx, catalogID)
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			return apperror.NewValidationError("unknown catalog entry %s", catalogID)
+		}
+		return err
+	}
+	catalogPlan, ok := entry.Plan(plan)
+	if !ok {
+		return apperror.NewValidationError("%s has no plan %s", entry.Name, plan)
+	}
+	entry.Prefill(subscription, catalogPlan)
+	return nil
+}
+
+func (s *catalogService) SyncCatalogInternal(ctx context.Context) error {
+	entries, err := s.curatedCatalog()
+	if err != nil {
+		return err
+	}
+	if err := s.catalogRepository.Sync(ctx, entries); err != nil {
+		return err
+	}
+	slog.Info("Catalog synced", logattr.Total(len(entries)))
+	return nil
+}
+
+// curatedCatalog decodes the embedded catalog and adds the logos.
+func (s *catalogService) curatedCatalog() ([]*models.CatalogEntry, error) {
+	var entries []*models.CatalogEntry
+	if err := json.Unmarshal(catalogJSON, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode the catalog: %w", err)
+	}
+	now := s.getTime()
+	for _, entry := range entries {
+		entry.UpdatedAt = now
+		if s.config.LogoURL == "" {
+			continue
+		}
+		if website, err := url.Parse(entry.Website); err == nil && website.Hostname() != "" {
+			domain := strings.TrimPrefix(website.Hostname(), "www.")
+			entry.LogoURL = fmt.Sprintf(s.config.LogoURL, domain)
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,177 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCatalog(t *testing.T, config services.CatalogConfig) (services.CatalogService, *repomocks.MockCatalogRepository) {
+	t.Helper()
+
+	repo := repomocks.NewMockCatalogRepository(t)
+	return services.NewCatalogService(repo, config, func() time.Time { return mockTime }), repo
+}
+
+func validCatalogEntry() *models.CatalogEntry {
+	return &models.CatalogEntry{
+		ID:       "netflix",
+		Name:     "Netflix",
+		Category: models.Entertainment,
+		Website:  "https://www.netflix.com",
+		Plans: []models.CatalogPlan{
+			{Name: "Standard", Price: 1799, Currency: models.USD, Frequency: models.Monthly},
+			{Name: "Premium", Price: 2499, Currency: models.USD, Frequency: models.Monthly},
+		},
+	}
+}
+
+// ---------------------------------------------------------------------------
+// SyncCatalogInternal
+// ---------------------------------------------------------------------------
+
+// TestCatalogService_SyncCatalogInternal checks the curated catalog shipped
+// with the service: every entry must pre-fill a valid subscription.
+func TestCatalogService_SyncCatalogInternal(t *testing.T) {
+	svc, repo := setupCatalog(t, services.CatalogConfig{LogoURL: "https://logos.example.com/%s.png"})
+	var synced []*models.CatalogEntry
+	repo.EXPECT().Sync(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, entries []*models.CatalogEntry) { synced = entries }).
+		Return(nil).
+		Once()
+
+	require.NoError(t, svc.SyncCatalogInternal(t.Context()))
+
+	require.NotEmpty(t, synced)
+	ids := make(map[string]bool)
+	for _, entry := range synced {
+		assert.False(t, ids[entry.ID], "duplicate catalog ID %s", entry.ID)
+		ids[entry.ID] = true
+		assert.NotEmpty(t, entry.LogoURL, entry.ID)
+		assert.Equal(t, mockTime, entry.UpdatedAt)
+		require.NotEmpty(t, entry.Plans, entry.ID)
+		for _, plan := range entry.Plans {
+			subscription := &models.Subscription{UserID: defaultUserID}
+			entry.Prefill(subscription, &plan)
+			subscription.Status = models.Active
+			subscription.ValidTill = mockTime.AddDate(0, 1, 0)
+			assert.NoError(t, subscription.Validate(mockTime), "%s plan %s", entry.ID, plan.Name)
+		}
+	}
+	netflix := synced[0]
+	assert.Equal(t, "netflix", netflix.ID)
+	assert.Equal(t, "https://logos.example.com/netflix.com.png", netflix.LogoURL)
+}
+
+func TestCatalogService_SyncCatalogInternal_NoLogos(t *testing.T) {
+	svc, repo := setupCatalog(t, services.CatalogConfig{})
+	repo.EXPECT().Sync(mock.Anything, mock.MatchedBy(func(entries []*models.CatalogEntry) bool {
+		return entries[0].LogoURL == ""
+	})).Return(nil).Once()
+
+	require.NoError(t, svc.SyncCatalogInternal(t.Context()))
+}
+
+// ---------------------------------------------------------------------------
+// SearchCatalog
+// ---------------------------------------------------------------------------
+
+func TestCatalogService_SearchCatalog(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int64
+	}{
+		{"default limit", 0, 50},
+		{"given limit", 5, 5},
+		{"limit capped", 500, 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := setupCatalog(t, services.CatalogConfig{})
+			repo.EXPECT().Search(mock.Anything, "netflix", tt.wantLimit).Return([]*models.CatalogEntry{validCatalogEntry()}, nil).Once()
+
+			got, err := svc.SearchCatalog(t.Context(), " netflix ", tt.limit)
+
+			require.NoError(t, err)
+			assert.Len(t, got, 1)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// PrefillSubscription
+// ---------------------------------------------------------------------------
+
+func TestCatalogService_PrefillSubscription(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription *models.Subscription
+		plan         string
+		want         *models.Subscription
+	}{
+		{
+			name:         "default plan",
+			subscription: &models.Subscription{},
+			want: &models.Subscription{
+				CatalogID: "netflix", Name: "Netflix", Category: models.Entertainment,
+				Price: 1799, Currency: models.USD, Frequency: models.Monthly,
+			},
+		},
+		{
+			name:         "named plan ignoring case",
+			subscription: &models.Subscription{},
+			plan:         "premium",
+			want: &models.Subscription{
+				CatalogID: "netflix", Name: "Netflix", Category: models.Entertainment,
+				Price: 2499, Currency: models.USD, Frequency: models.Monthly,
+			},
+		},
+		{
+			name:         "given fields are kept",
+			subscription: &models.Subscription{Name: "Family Netflix", Price: 1999, Currency: models.EUR, Frequency: models.Yearly},
+			want: &models.Subscription{
+				CatalogID: "netflix", Name: "Family Netflix", Category: models.Entertainment,
+				Price: 1999, Currency: models.EUR, Frequency: models.Yearly,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := setupCatalog(t, services.CatalogConfig{})
+			repo.EXPECT().GetByID(mock.Anything, "netflix").Return(validCatalogEntry(), nil).Once()
+
+			err := svc.PrefillSubscription(t.Context(), tt.subscription, "netflix", tt.plan)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tt.subscription)
+		})
+	}
+}
+
+func TestCatalogService_PrefillSubscription_Rejected(t *testing.T) {
+	t.Run("unknown entry", func(t *testing.T) {
+		svc, repo := setupCatalog(t, services.CatalogConfig{})
+		repo.EXPECT().GetByID(mock.Anything, "nope").Return(nil, apperror.NewNotFoundError("Document not found")).Once()
+
+		err := svc.PrefillSubscription(t.Context(), &models.Subscription{}, "nope", "")
+
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+	t.Run("unknown plan", func(t *testing.T) {
+		svc, repo := setupCatalog(t, services.CatalogConfig{})
+		repo.EXPECT().GetByID(mock.Anything, "netflix").Return(validCatalogEntry(), nil).Once()
+
+		err := svc.PrefillSubscription(t.Context(), &models.Subscription{}, "netflix", "Ultra")
+
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+}
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/core/otelattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/payments"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// chargeableMethod returns the payment method renewals of the subscription
+// are charged to, or nil when no payment provider is configured or the
+// subscription is not linked to a method saved with it.
+func (s *subscriptionService) chargeableMethod(
+	ctx context.Context,
+	subscription *models.Subscription,
+) (*models.PaymentMethod, error) {
+	if s.paymentProvider == nil || subscription.PaymentMethodID == nil {
+		return nil, nil
+	}
+	method, err := s.paymentMethods.GetByID(ctx, *subscription.PaymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+	if !method.Chargeable() {
+		return nil, nil
+	}
+	return method, nil
+}
+
+// charge charges a bill to the payment method. The idempotency key makes a
+// repeated charge after a failed write return the original result instead of
+// charging twice. A declined charge is returned as a *payments.DeclineError
+// with a nil error; other failures are internal errors, so the task is
+// retried. A charge the provider is still processing leaves the bill pending.
+func (s *subscriptionService) charge(
+	ctx context.Context,
+	subscription *models.Subscription,
+	method *models.PaymentMethod,
+	bill *models.Bill,
+	idempotencyKey string,
+) (*payments.DeclineError, error) {
+	charge, err := s.paymentProvider.Charge(ctx, payments.ChargeRequest{
+		IdempotencyKey:  idempotencyKey,
+		Amount:          bill.Amount,
+		Currency:        bill.Currency,
+		CustomerID:      method.ProviderCustomerID,
+		PaymentMethodID: method.ProviderMethodID,
+		Description:     subscription.Name,
+		Metadata: map[string]string{
+			"subscription_id": subscription.ID.Hex(),
+			"bill_id":         bill.ID.Hex(),
+		},
+	})
+	if decline, ok := errors.AsType[*payments.DeclineError](err); ok {
+		slog.WarnContext(ctx, "Payment declined",
+			logattr.PaymentProvider(s.paymentProvider.Name()),
+			logattr.DeclineCode(decline.Code),
+			logattr.ChargeID(decline.ChargeID),
+		)
+		return decline, nil
+	}
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to charge bill: %w", err))
+	}
+
+	bill.ChargeID = charge.ID
+	bill.FailureReason = ""
+	if charge.Pending {
+		bill.Status = models.Pending
+		slog.InfoContext(ctx, "Payment pending",
+			logattr.PaymentProvider(s.paymentProvider.Name()),
+			logattr.ChargeID(charge.ID),
+		)
+		return nil, nil
+	}
+	slog.InfoContext(ctx, "Payment charged",
+		logattr.PaymentProvider(s.paymentProvider.Name()),
+		logattr.ChargeID(charge.ID),
+	)
+	return nil, nil
+}
+
+// chargeRenewal charges the renewal bill of a subscription when it is paid
+// through the payment provider. Subscriptions without a chargeable payment
+// method renew without a charge.
+func (s *subscriptionService) chargeRenewal(
+	ctx context.Context,
+	subscription *models.Subscription,
+	bill *models.Bill,
+) (*payments.DeclineError, error) {
+	method, err := s.chargeableMethod(ctx, subscription)
+	if err != nil || method == nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("renewal-%s-%d", subscription.ID.Hex(), bill.StartDate.Unix())
+	return s.charge(ctx, subscription, method, bill, key)
+}
+
+// failRenewal records a declined renewal charge: the bill for the new period
+// is created failed and the subscription goes past due, keeping the new
+// validity while payment is retried.
+func (s *subscriptionService) failRenewal(
+	ctx context.Context,
+	subscription *models.Subscription,
+	before *models.SubscriptionResponse,
+	bill *models.Bill,
+	decline *payments.DeclineError,
+) (*models.Subscription, error) {
+	now := bill.CreatedAt
+	bill.Status = models.Failed
+	bill.ChargeID = decline.ChargeID
+	bill.FailureReason = decline.Reason()
+
+	var res *models.Subscription
+	err := s.runTx(ctx, func(ctx context.Context) error {
+		_, txnErr := s.billRepository.Create(ctx, bill)
+		if txnErr != nil {
+			return txnErr
+		}
+		res, txnErr = s.subscriptionRepository.TransitionStatus(ctx, subscription.ID, models.Active, models.PastDue, now)
+		if txnErr != nil {
+			return txnErr
+		}
+		res.ValidTill = bill.EndDate
+		res.Dunning = &models.Dunning{BillID: bill.ID, StartedAt: now}
+		if res, txnErr = s.subscriptionRepository.Update(ctx, res); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.recordEvent(ctx, models.BillFailedEvent, bill.ID, res.UserID, bill.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.audit.RecordInternal(ctx, models.ActorSystem, models.AuditSubscriptionRenewed, res.ID, before, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		description := fmt.Sprintf("Renewal payment of %s was declined: %s",
+			models.FormatMoney(bill.Amount, bill.Currency),
+			bill.FailureReason,
+		)
+		return s.recordLifecycle(ctx, res, models.LifecyclePastDue, models.ActorSystem, description)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Renewal payment declined, subscription is past due",
+		logattr.ValidTill(res.ValidTill),
+	)
+	return res, nil
+}
+
+// RetryPaymentInternal charges the failed bill of a past-due subscription
+// again. On success the bill is paid and the subscription is active again.
+// Subscriptions without a chargeable payment method are left to be settled
+// by hand. A pending charge is left to SettlePendingChargesInternal: the
+// retry reports a conflict, so it is neither counted nor charged again.
+func (s *subscriptionService) RetryPaymentInternal(
+	ctx context.Context,
+	id bson.ObjectID,
+	attempt int,
+) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "Retry Payment", otelattr.SubscriptionID(id.Hex()))
+	defer func() { endSpan(span, err) }()
+
+	subscription, err := s.subscriptionRepository.GetByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if subscription.Status != models.PastDue || subscription.Dunning == nil {
+		return false, nil
+	}
+	method, err := s.chargeableMethod(ctx, subscription)
+	if err != nil || method == nil {
+		return false, err
+	}
+	bill, err := s.billRepository.GetByID(ctx, subscription.Dunning.BillID)
+	if err != nil {
+		return false, err
+	}
+
+	if bill.Status == models.Pending {
+		return false, errChargePending()
+	}
+
+	// A pending charge that failed left its ID on the bill. Retrying that
+	// attempt needs a new key, or the provider returns the charge again.
+	key := fmt.Sprintf("retry-%s-%d", bill.ID.Hex(), attempt)
+	if bill.ChargeID != "" {
+		key += "-" + bill.ChargeID
+	}
+	decline, err := s.charge(ctx, subscription, method, bill, key)
+	if err != nil || decline != nil {
+		return false, err
+	}
+	if bill.Status == models.Pending {
+		bill.UpdatedAt = s.getTime()
+		if _, err = s.billRepository.Update(ctx, bill); err != nil {
+			return false, err
+		}
+		return false, errChargePending()
+	}
+	if _, err = s.recoverBill(ctx, bill, subscription, models.ActorSystem); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SettlePendingChargesInternal looks up the charge of each pending bill. A
+// renewal bill whose charge went through is paid; one whose charge failed
+// puts its subscription into dunning. A retried bill whose charge went
+// through recovers its subscription; one whose charge failed is failed again
+// and retried on the next attempt. Bills that cannot be settled are logged
+// and tried again on the next run.
+func (s *subscriptionService) SettlePendingChargesInternal(ctx context.Context) (_ int, err error) {
+	ctx, span := startSpan(ctx, "Settle Pending Charges")
+	defer func() { endSpan(span, err) }()
+
+	if s.paymentProvider == nil {
+		return 0, nil
+	}
+	settled := 0
+	err = s.billRepository.EachPending(ctx, func(bill *models.Bill) error {
+		ok, settleErr := s.settleCharge(ctx, bill)
+		if settleErr != nil {
+			if ctx.Err() != nil {
+				return settleErr
+			}
+			slog.WarnContext(ctx, "Failed to settle pending charge",
+				logattr.BillID(bill.ID.Hex()),
+				logattr.ChargeID(bill.ChargeID),
+				logattr.Error(settleErr),
+			)
+			return nil
+		}
+		if ok {
+			settled++
+		}
+		return nil
+	})
+	if err != nil {
+		return settled, err
+	}
+
+	if settled > 0 {
+		slog.InfoContext(ctx, "Pending charges settled",
+			logattr.Success(settled),
+		)
+	}
+	return settled, nil
+}
+
+// settleCharge settles a pending bill once its charge is no longer pending
+// and reports whether it did.
+func (s *subscriptionService) settleCharge(ctx context.Context, bill *models.Bill) (bool, error) {
+	charge, err := s.paymentProvider.GetCharge(ctx, bill.ChargeID)
+	decline, declined := errors.AsType[*payments.DeclineError](err)
+	if err != nil && !declined {
+		return false, apperror.NewInternalError(fmt.Errorf("failed to look up charge: %w", err))
+	}
+	if !declined && charge.Pending {
+		return false, nil
+	}
+	subscription, err := s.subscriptionRepository.GetByID(ctx, bill.SubscriptionID)
+	if err != nil {
+		return false, err
+	}
+	retried := subscription.Dunning != nil && subscription.Dunning.BillID == bill.ID
+
+	switch {
+	case declined && retried:
+		slog.WarnContext(ctx, "Pending payment retry declined",
+			logattr.SubscriptionID(subscription.ID.Hex()),
+			logattr.DeclineCode(decline.Code),
+		)
+		return true, s.settleBill(ctx, bill, subscription, models.Failed, decline.Reason())
+	case declined:
+		bill.FailureReason = decline.Reason()
+		_, err = s.failBill(ctx, bill, subscription, models.ActorSystem)
+		// A subscription no longer active, e.g. canceled since, is not
+		// dunned; the bill only records the failure.
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrConflict {
+			return true, s.settleBill(ctx, bill, subscription, models.Failed, decline.Reason())
+		}
+	case retried:
+		_, err = s.recoverBill(ctx, bill, subscription, models.ActorSystem)
+	default:
+		err = s.settleBill(ctx, bill, subscription, models.Paid, "")
+	}
+	return err == nil, err
+}
+
+// settleBill records the outcome of the pending charge of a bill without
+// changing its subscription.
+func (s *subscriptionService) settleBill(
+	ctx context.Context,
+	bill *models.Bill,
+	subscription *models.Subscription,
+	status models.PaymentStatus,
+	reason string,
+) error {
+	before := bill.ToResponse()
+	bill.Status = status
+	bill.FailureReason = reason
+	bill.UpdatedAt = s.getTime()
+
+	event := models.BillPaidEvent
+	if status == models.Failed {
+		event = models.BillFailedEvent
+	}
+	return s.runTx(ctx, func(ctx context.Context) error {
+		if _, txnErr := s.billRepository.Update(ctx, bill); txnErr != nil {
+			return txnErr
+		}
+		if txnErr := s.recordEvent(ctx, event, bill.ID, subscription.UserID, bill.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		return s.audit.RecordInternal(ctx, models.ActorSystem, models.AuditBillStatusChanged, bill.ID, before, bill.ToResponse())
+	})
+}
+
+// errChargePending reports a bill whose charge the payment provider is still
+// processing.
+func errChargePending() error {
+	return apperror.NewConflictError("The payment of this bill is still processing")
+}
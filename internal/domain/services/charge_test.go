@@ -0,0 +1,511 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/payments"
+	paymentmocks "github.com/anuragthepathak/subscription-management/internal/payments/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+var chargedMethodID = bson.NewObjectID()
+
+// chargedMethod returns a payment method saved with the payment provider.
+func chargedMethod() *models.PaymentMethod {
+	return &models.PaymentMethod{
+		ID:                 chargedMethodID,
+		UserID:             defaultUserID,
+		Type:               models.Card,
+		ProviderCustomerID: "cus_123",
+		ProviderMethodID:   "pm_123",
+	}
+}
+
+// chargedSub returns a renewal-due subscription paid with chargedMethod.
+func chargedSub() *models.Subscription {
+	sub := renewedSub()
+	sub.PaymentMethodID = &chargedMethodID
+	return sub
+}
+
+// newSubServiceWithPayments builds a subscriptionService that charges
+// renewals through provider.
+func newSubServiceWithPayments(
+	subRepo *repomocks.MockSubscriptionRepository,
+	billRepo *repomocks.MockBillRepository,
+	paymentMethodRepo *repomocks.MockPaymentMethodRepository,
+	provider *paymentmocks.MockProvider,
+) services.SubscriptionService {
+	provider.EXPECT().Name().Return(payments.ProviderStripe).Maybe()
+	return services.NewSubscriptionService(
+		noopTxnFn,
+		subRepo,
+		billRepo,
+		nil,
+		nopOutboxRepo(),
+		nopPriceHistoryRepo(),
+		nopLifecycleRepo(),
+		paymentMethodRepo,
+		nil,
+		provider,
+		nopAudit(),
+		nil,
+		func() time.Time { return mockTime },
+	)
+}
+
+// ---------------------------------------------------------------------------
+// RenewSubscriptionInternal
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_RenewSubscriptionInternal_Charge(t *testing.T) {
+	// The renewal bill starts where the latest one ends.
+	renewalKey := fmt.Sprintf("renewal-%s-%d", defaultSubHex, validBill().EndDate.Unix())
+
+	t.Run("successful charge renews with a paid bill", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		paymentMethodRepo := repomocks.NewMockPaymentMethodRepository(t)
+		provider := paymentmocks.NewMockProvider(t)
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(chargedSub(), nil).Once()
+		billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(validBill(), nil).Once()
+		paymentMethodRepo.EXPECT().GetByID(mock.Anything, chargedMethodID).Return(chargedMethod(), nil).Once()
+		provider.EXPECT().
+			Charge(mock.Anything, mock.MatchedBy(func(req payments.ChargeRequest) bool {
+				return req.Amount == 999 && req.Currency == models.USD &&
+					req.CustomerID == "cus_123" && req.PaymentMethodID == "pm_123" &&
+					req.IdempotencyKey == renewalKey
+			})).
+			Return(&payments.Charge{ID: "pi_123"}, nil).
+			Once()
+		billRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+				return b.Status == models.Paid && b.ChargeID == "pi_123"
+			})).
+			RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) { return b, nil }).
+			Once()
+		subRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(returnSub).Once()
+
+		svc := newSubServiceWithPayments(subRepo, billRepo, paymentMethodRepo, provider)
+		got, err := svc.RenewSubscriptionInternal(t.Context(), defaultSubID)
+		require.NoError(t, err)
+		assert.Equal(t, models.Active, got.Status)
+	})
+
+	t.Run("declined charge puts the subscription past due", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		paymentMethodRepo := repomocks.NewMockPaymentMethodRepository(t)
+		provider := paymentmocks.NewMockProvider(t)
+
+		pastDue := chargedSub()
+		pastDue.Status = models.PastDue
+		var failedBill *models.Bill
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(chargedSub(), nil).Once()
+		billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(validBill(), nil).Once()
+		paymentMethodRepo.EXPECT().GetByID(mock.Anything, chargedMethodID).Return(chargedMethod(), nil).Once()
+		provider.EXPECT().
+			Charge(mock.Anything, mock.Anything).
+			Return(nil, &payments.DeclineError{Code: "insufficient_funds", Message: "Your card has insufficient funds.", ChargeID: "pi_456"}).
+			Once()
+		billRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+				return b.Status == models.Failed && b.ChargeID == "pi_456" &&
+					b.FailureReason == "Your card has insufficient funds."
+			})).
+			RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) {
+				failedBill = b
+				return b, nil
+			}).
+			Once()
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.Active, models.PastDue, mockTime).
+			Return(pastDue, nil).
+			Once()
+		subRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+				return s.Dunning != nil && s.Dunning.BillID == failedBill.ID &&
+					s.ValidTill.Equal(failedBill.EndDate)
+			})).
+			RunAndReturn(returnSub).
+			Once()
+
+		svc := newSubServiceWithPayments(subRepo, billRepo, paymentMethodRepo, provider)
+		got, err := svc.RenewSubscriptionInternal(t.Context(), defaultSubID)
+		require.NoError(t, err)
+		assert.Equal(t, models.PastDue, got.Status)
+	})
+
+	t.Run("pending charge renews with a pending bill", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		paymentMethodRepo := repomocks.NewMockPaymentMethodRepository(t)
+		provider := paymentmocks.NewMockProvider(t)
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(chargedSub(), nil).Once()
+		billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(validBill(), nil).Once()
+		paymentMethodRepo.EXPECT().GetByID(mock.Anything, chargedMethodID).Return(chargedMethod(), nil).Once()
+		provider.EXPECT().
+			Charge(mock.Anything, mock.Anything).
+			Return(&payments.Charge{ID: "pi_123", Pending: true}, nil).
+			Once()
+		billRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+				return b.Status == models.Pending && b.ChargeID == "pi_123"
+			})).
+			RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) { return b, nil }).
+			Once()
+		subRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(returnSub).Once()
+
+		svc := newSubServiceWithPayments(subRepo, billRepo, paymentMethodRepo, provider)
+		got, err := svc.RenewSubscriptionInternal(t.Context(), defaultSubID)
+		require.NoError(t, err)
+		assert.Equal(t, models.Active, got.Status)
+	})
+
+	t.Run("provider error leaves the subscription unchanged", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		paymentMethodRepo := repomocks.NewMockPaymentMethodRepository(t)
+		provider := paymentmocks.NewMockProvider(t)
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(chargedSub(), nil).Once()
+		billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(validBill(), nil).Once()
+		paymentMethodRepo.EXPECT().GetByID(mock.Anything, chargedMethodID).Return(chargedMethod(), nil).Once()
+		provider.EXPECT().Charge(mock.Anything, mock.Anything).Return(nil, errors.New("connection reset")).Once()
+
+		svc := newSubServiceWithPayments(subRepo, billRepo, paymentMethodRepo, provider)
+		_, err := svc.RenewSubscriptionInternal(t.Context(), defaultSubID)
+		assertAppErr(t, err, apperror.ErrInternal)
+	})
+
+	t.Run("payment method not saved with the provider is not charged", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		paymentMethodRepo := repomocks.NewMockPaymentMethodRepository(t)
+		provider := paymentmocks.NewMockProvider(t)
+
+		method := chargedMethod()
+		method.ProviderCustomerID = ""
+		method.ProviderMethodID = ""
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(chargedSub(), nil).Once()
+		billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(validBill(), nil).Once()
+		paymentMethodRepo.EXPECT().GetByID(mock.Anything, chargedMethodID).Return(method, nil).Once()
+		billRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+				return b.Status == models.Paid && b.ChargeID == ""
+			})).
+			RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) { return b, nil }).
+			Once()
+		subRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(returnSub).Once()
+
+		svc := newSubServiceWithPayments(subRepo, billRepo, paymentMethodRepo, provider)
+		got, err := svc.RenewSubscriptionInternal(t.Context(), defaultSubID)
+		require.NoError(t, err)
+		assert.Equal(t, models.Active, got.Status)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// RetryPaymentInternal
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_RetryPaymentInternal(t *testing.T) {
+	failedBill := func() *models.Bill {
+		bill := validBill()
+		bill.Status = models.Failed
+		bill.FailureReason = "Your card has insufficient funds."
+		return bill
+	}
+	dunnedSub := func(bill *models.Bill) *models.Subscription {
+		sub := pastDueSub(bill, 1)
+		sub.PaymentMethodID = &chargedMethodID
+		return sub
+	}
+
+	t.Run("successful charge recovers the bill", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		paymentMethodRepo := repomocks.NewMockPaymentMethodRepository(t)
+		provider := paymentmocks.NewMockProvider(t)
+
+		bill := failedBill()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(dunnedSub(bill), nil).Once()
+		paymentMethodRepo.EXPECT().GetByID(mock.Anything, chargedMethodID).Return(chargedMethod(), nil).Once()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		provider.EXPECT().
+			Charge(mock.Anything, mock.MatchedBy(func(req payments.ChargeRequest) bool {
+				return req.IdempotencyKey == "retry-"+bill.ID.Hex()+"-2"
+			})).
+			Return(&payments.Charge{ID: "pi_789"}, nil).
+			Once()
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.PastDue, models.Active, mockTime).
+			Return(renewedSub(), nil).
+			Once()
+		subRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(returnSub).Once()
+		billRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+				return b.Status == models.Paid && b.ChargeID == "pi_789" && b.FailureReason == ""
+			})).
+			Return(bill, nil).
+			Once()
+
+		svc := newSubServiceWithPayments(subRepo, billRepo, paymentMethodRepo, provider)
+		recovered, err := svc.RetryPaymentInternal(t.Context(), defaultSubID, 2)
+		require.NoError(t, err)
+		assert.True(t, recovered)
+	})
+
+	t.Run("declined charge is not recovered", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		paymentMethodRepo := repomocks.NewMockPaymentMethodRepository(t)
+		provider := paymentmocks.NewMockProvider(t)
+
+		bill := failedBill()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(dunnedSub(bill), nil).Once()
+		paymentMethodRepo.EXPECT().GetByID(mock.Anything, chargedMethodID).Return(chargedMethod(), nil).Once()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		provider.EXPECT().
+			Charge(mock.Anything, mock.Anything).
+			Return(nil, &payments.DeclineError{Code: "card_declined"}).
+			Once()
+
+		svc := newSubServiceWithPayments(subRepo, billRepo, paymentMethodRepo, provider)
+		recovered, err := svc.RetryPaymentInternal(t.Context(), defaultSubID, 2)
+		require.NoError(t, err)
+		assert.False(t, recovered)
+	})
+
+	t.Run("pending charge leaves the bill pending", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		paymentMethodRepo := repomocks.NewMockPaymentMethodRepository(t)
+		provider := paymentmocks.NewMockProvider(t)
+
+		bill := failedBill()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(dunnedSub(bill), nil).Once()
+		paymentMethodRepo.EXPECT().GetByID(mock.Anything, chargedMethodID).Return(chargedMethod(), nil).Once()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		provider.EXPECT().
+			Charge(mock.Anything, mock.Anything).
+			Return(&payments.Charge{ID: "pi_789", Pending: true}, nil).
+			Once()
+		billRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+				return b.Status == models.Pending && b.ChargeID == "pi_789"
+			})).
+			Return(bill, nil).
+			Once()
+
+		svc := newSubServiceWithPayments(subRepo, billRepo, paymentMethodRepo, provider)
+		recovered, err := svc.RetryPaymentInternal(t.Context(), defaultSubID, 2)
+		assertAppErr(t, err, apperror.ErrConflict)
+		assert.False(t, recovered)
+	})
+
+	t.Run("pending bill is not charged again", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		paymentMethodRepo := repomocks.NewMockPaymentMethodRepository(t)
+		provider := paymentmocks.NewMockProvider(t)
+
+		bill := failedBill()
+		bill.Status = models.Pending
+		bill.ChargeID = "pi_789"
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(dunnedSub(bill), nil).Once()
+		paymentMethodRepo.EXPECT().GetByID(mock.Anything, chargedMethodID).Return(chargedMethod(), nil).Once()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+
+		svc := newSubServiceWithPayments(subRepo, billRepo, paymentMethodRepo, provider)
+		recovered, err := svc.RetryPaymentInternal(t.Context(), defaultSubID, 2)
+		assertAppErr(t, err, apperror.ErrConflict)
+		assert.False(t, recovered)
+	})
+
+	t.Run("retry after a failed pending charge uses a new key", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		paymentMethodRepo := repomocks.NewMockPaymentMethodRepository(t)
+		provider := paymentmocks.NewMockProvider(t)
+
+		bill := failedBill()
+		bill.ChargeID = "pi_789"
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(dunnedSub(bill), nil).Once()
+		paymentMethodRepo.EXPECT().GetByID(mock.Anything, chargedMethodID).Return(chargedMethod(), nil).Once()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		provider.EXPECT().
+			Charge(mock.Anything, mock.MatchedBy(func(req payments.ChargeRequest) bool {
+				return req.IdempotencyKey == "retry-"+bill.ID.Hex()+"-2-pi_789"
+			})).
+			Return(nil, &payments.DeclineError{Code: "card_declined"}).
+			Once()
+
+		svc := newSubServiceWithPayments(subRepo, billRepo, paymentMethodRepo, provider)
+		recovered, err := svc.RetryPaymentInternal(t.Context(), defaultSubID, 2)
+		require.NoError(t, err)
+		assert.False(t, recovered)
+	})
+
+	t.Run("subscription without a payment method is left for manual recovery", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		provider := paymentmocks.NewMockProvider(t)
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(pastDueSub(failedBill(), 1), nil).Once()
+
+		svc := newSubServiceWithPayments(subRepo, repomocks.NewMockBillRepository(t),
+			repomocks.NewMockPaymentMethodRepository(t), provider)
+		recovered, err := svc.RetryPaymentInternal(t.Context(), defaultSubID, 2)
+		require.NoError(t, err)
+		assert.False(t, recovered)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// SettlePendingChargesInternal
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_SettlePendingChargesInternal(t *testing.T) {
+	pendingBill := func() *models.Bill {
+		bill := validBill()
+		bill.Status = models.Pending
+		bill.ChargeID = "pi_123"
+		return bill
+	}
+	eachPending := func(billRepo *repomocks.MockBillRepository, bills ...*models.Bill) {
+		billRepo.EXPECT().
+			EachPending(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, fn func(*models.Bill) error) error {
+				for _, bill := range bills {
+					if err := fn(bill); err != nil {
+						return err
+					}
+				}
+				return nil
+			}).
+			Once()
+	}
+
+	tests := []struct {
+		name        string
+		charge      *payments.Charge
+		chargeErr   error
+		dunned      bool
+		setup       func(subRepo *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository, bill *models.Bill)
+		wantSettled int
+		wantStatus  models.PaymentStatus
+	}{
+		{
+			name:        "still pending",
+			charge:      &payments.Charge{ID: "pi_123", Pending: true},
+			wantStatus:  models.Pending,
+			wantSettled: 0,
+		},
+		{
+			name:   "renewal charge went through",
+			charge: &payments.Charge{ID: "pi_123"},
+			setup: func(_ *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository, bill *models.Bill) {
+				billRepo.EXPECT().Update(mock.Anything, bill).Return(bill, nil).Once()
+			},
+			wantStatus:  models.Paid,
+			wantSettled: 1,
+		},
+		{
+			name:      "renewal charge failed",
+			chargeErr: &payments.DeclineError{Code: "insufficient_funds", Message: "The debit failed."},
+			setup: func(subRepo *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository, bill *models.Bill) {
+				pastDue := renewedSub()
+				pastDue.Status = models.PastDue
+				billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(bill, nil).Once()
+				subRepo.EXPECT().
+					TransitionStatus(mock.Anything, defaultSubID, models.Active, models.PastDue, mockTime).
+					Return(pastDue, nil).
+					Once()
+				subRepo.EXPECT().
+					Update(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+						return s.Dunning != nil && s.Dunning.BillID == bill.ID
+					})).
+					RunAndReturn(returnSub).
+					Once()
+				billRepo.EXPECT().Update(mock.Anything, bill).Return(bill, nil).Once()
+			},
+			wantStatus:  models.Failed,
+			wantSettled: 1,
+		},
+		{
+			name:   "retried charge went through",
+			charge: &payments.Charge{ID: "pi_123"},
+			dunned: true,
+			setup: func(subRepo *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository, bill *models.Bill) {
+				subRepo.EXPECT().
+					TransitionStatus(mock.Anything, defaultSubID, models.PastDue, models.Active, mockTime).
+					Return(renewedSub(), nil).
+					Once()
+				subRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(returnSub).Once()
+				billRepo.EXPECT().Update(mock.Anything, bill).Return(bill, nil).Once()
+			},
+			wantStatus:  models.Paid,
+			wantSettled: 1,
+		},
+		{
+			name:      "retried charge failed",
+			chargeErr: &payments.DeclineError{Code: "insufficient_funds", Message: "The debit failed."},
+			dunned:    true,
+			setup: func(_ *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository, bill *models.Bill) {
+				billRepo.EXPECT().Update(mock.Anything, bill).Return(bill, nil).Once()
+			},
+			wantStatus:  models.Failed,
+			wantSettled: 1,
+		},
+		{
+			name:        "provider error leaves the bill pending",
+			chargeErr:   errors.New("connection reset"),
+			wantStatus:  models.Pending,
+			wantSettled: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subRepo := repomocks.NewMockSubscriptionRepository(t)
+			billRepo := repomocks.NewMockBillRepository(t)
+			provider := paymentmocks.NewMockProvider(t)
+
+			bill := pendingBill()
+			sub := renewedSub()
+			if tt.dunned {
+				sub = pastDueSub(bill, 1)
+			}
+			eachPending(billRepo, bill)
+			provider.EXPECT().GetCharge(mock.Anything, "pi_123").Return(tt.charge, tt.chargeErr).Once()
+			if tt.setup != nil {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(sub, nil).Once()
+				tt.setup(subRepo, billRepo, bill)
+			}
+
+			svc := newSubServiceWithPayments(subRepo, billRepo, repomocks.NewMockPaymentMethodRepository(t), provider)
+			settled, err := svc.SettlePendingChargesInternal(t.Context())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSettled, settled)
+			assert.Equal(t, tt.wantStatus, bill.Status)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+)
+
+// billRepairGrace keeps the repair away from bills whose write may still be
+// in flight, such as a renewal waiting on the payment provider.
+const billRepairGrace = 10 * time.Minute
+
+// RepairBillsInternal compensates for writes that failed halfway without a
+// transaction: a bill created for a subscription that was never inserted, or
+// a renewal bill the subscription was never extended for. Deleting an
+// unapplied renewal bill lets the next poll renew the subscription again;
+// its charge reuses the original idempotency key, so it is not charged twice.
+func (s *subscriptionService) RepairBillsInternal(ctx context.Context) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "Repair Bills")
+	defer func() { endSpan(span, err) }()
+
+	deleted, err := s.billRepository.DeleteDangling(ctx, s.getTime().Add(-billRepairGrace))
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		slog.WarnContext(ctx, "Deleted bills left behind by failed writes",
+			logattr.Total(int(deleted)),
+		)
+	}
+	return deleted, nil
+}
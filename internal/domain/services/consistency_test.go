@@ -0,0 +1,66 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// RepairBillsInternal
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_RepairBillsInternal(t *testing.T) {
+	t.Run("success - skips bills still being written", func(t *testing.T) {
+		billRepo := repomocks.NewMockBillRepository(t)
+		billRepo.EXPECT().
+			DeleteDangling(mock.Anything, mockTime.Add(-10*time.Minute)).
+			Return(int64(2), nil).
+			Once()
+
+		svc := newSubService(repomocks.NewMockSubscriptionRepository(t), billRepo, svcmocks.NewMockSubscriptionMetrics(t))
+		got, err := svc.RepairBillsInternal(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), got)
+	})
+
+	t.Run("error - repository fails", func(t *testing.T) {
+		billRepo := repomocks.NewMockBillRepository(t)
+		billRepo.EXPECT().
+			DeleteDangling(mock.Anything, mock.Anything).
+			Return(0, apperror.NewDBError(errors.New("aggregate failed"))).
+			Once()
+
+		svc := newSubService(repomocks.NewMockSubscriptionRepository(t), billRepo, svcmocks.NewMockSubscriptionMetrics(t))
+		_, err := svc.RepairBillsInternal(t.Context())
+
+		assertAppErr(t, err, apperror.ErrDB)
+	})
+}
+
+func Test_subscriptionService_RenewSubscriptionInternal_UnappliedBill(t *testing.T) {
+	// The renewal bill for the period starting today was written, but the
+	// subscription was not extended.
+	subscription := validSub()
+	subscription.ValidTill = mockToday
+	unapplied := validBill()
+
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	billRepo := repomocks.NewMockBillRepository(t)
+	subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(subscription, nil).Once()
+	billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(unapplied, nil).Once()
+
+	svc := newSubService(subRepo, billRepo, svcmocks.NewMockSubscriptionMetrics(t))
+	got, err := svc.RenewSubscriptionInternal(t.Context(), defaultSubID)
+
+	assertAppErr(t, err, apperror.ErrConflict)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// DeadTaskServiceExternal lets administrators inspect background tasks that
+// failed for the last time and put them back on the queue.
+type DeadTaskServiceExternal interface {
+	// GetDeadTasks returns a page of the dead tasks matching the query, most
+	// recent failure first.
+	GetDeadTasks(ctx context.Context, query models.DeadTaskQuery) (*models.DeadTaskPage, error)
+	GetDeadTask(ctx context.Context, id string) (*models.DeadTask, error)
+	// RequeueDeadTask enqueues the task again with a fresh retry budget and
+	// returns it marked as requeued. A task is requeued at most once; if it
+	// fails again, it is recorded as a new dead task.
+	RequeueDeadTask(ctx context.Context, id string) (*models.DeadTask, error)
+}
+
+type DeadTaskServiceInternal interface {
+	// RecordDeadTaskInternal stores a task that exhausted its retries or
+	// was told not to retry, stamped with the current time.
+	RecordDeadTaskInternal(ctx context.Context, task *models.DeadTask) error
+}
+
+type DeadTaskService interface {
+	DeadTaskServiceExternal
+	DeadTaskServiceInternal
+}
+
+// DeadTaskQueue puts dead tasks back on the task queue.
+type DeadTaskQueue interface {
+	// RequeueDeadTask enqueues a copy of the task and returns the ID of the
+	// new task. Enqueuing the same dead task twice is a conflict.
+	RequeueDeadTask(ctx context.Context, task *models.DeadTask) (string, error)
+}
+
+type deadTaskService struct {
+	deadTaskRepository repositories.DeadTaskRepository
+	queue              DeadTaskQueue // Nil without a task queue.
+	getTime            clock.NowFn
+}
+
+// NewDeadTaskService creates a new instance of DeadTaskService. Processes
+// without a task queue pass a nil queue and cannot requeue tasks.
+func NewDeadTaskService(
+	deadTaskRepository repositories.DeadTaskRepository,
+	queue DeadTaskQueue,
+	nowFn clock.NowFn,
+) DeadTaskService {
+	return &deadTaskService{
+		deadTaskRepository,
+		queue,
+		nowFn,
+	}
+}
+
+func (s *deadTaskService) GetDeadTasks(ctx context.Context, query models.DeadTaskQuery) (*models.DeadTaskPage, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+	tasks, total, err := s.deadTaskRepository.List(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	return &models.DeadTaskPage{Items: tasks, Total: total}, nil
+}
+
+func (s *deadTaskService) GetDeadTask(ctx context.Context, id string) (*models.DeadTask, error) {
+	taskID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid dead task ID")
+	}
+	return s.deadTaskRepository.GetByID(ctx, taskID)
+}
+
+func (s *deadTaskService) RequeueDeadTask(ctx context.Context, id string) (*models.DeadTask, error) {
+	task, err := s.GetDeadTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.RequeuedAt != nil {
+		return nil, apperror.NewConflictError("Dead task was already requeued")
+	}
+	if s.queue == nil {
+		return nil, apperror.NewInternalError(errors.New("no task queue to requeue dead tasks to"))
+	}
+
+	newTaskID, err := s.queue.RequeueDeadTask(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	return s.deadTaskRepository.MarkRequeued(ctx, task.ID, newTaskID, s.getTime())
+}
+
+func (s *deadTaskService) RecordDeadTaskInternal(ctx context.Context, task *models.DeadTask) error {
+	task.ID = bson.NewObjectID()
+	task.FailedAt = s.getTime()
+	return s.deadTaskRepository.Create(ctx, task)
+}
@@ -0,0 +1,148 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var deadTaskID = bson.NewObjectID()
+
+func newDeadTaskService(repo *repomocks.MockDeadTaskRepository, queue services.DeadTaskQueue) services.DeadTaskService {
+	return services.NewDeadTaskService(repo, queue, func() time.Time { return mockTime })
+}
+
+func validDeadTask() *models.DeadTask {
+	return &models.DeadTask{
+		ID:       deadTaskID,
+		TaskID:   "task-1",
+		Type:     "subscription:renewal",
+		Queue:    "default",
+		Payload:  []byte(`{"subscription_id":"abc"}`),
+		Error:    "payment provider unavailable",
+		Retried:  3,
+		MaxRetry: 3,
+		FailedAt: mockTime.Add(-time.Hour),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// RecordDeadTaskInternal
+// ---------------------------------------------------------------------------
+
+func Test_deadTaskService_RecordDeadTaskInternal(t *testing.T) {
+	t.Run("success - stamps the task", func(t *testing.T) {
+		repo := repomocks.NewMockDeadTaskRepository(t)
+		repo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(task *models.DeadTask) bool {
+				return !task.ID.IsZero() && task.FailedAt.Equal(mockTime) && task.TaskID == "task-1"
+			})).
+			Return(nil).
+			Once()
+
+		err := newDeadTaskService(repo, nil).RecordDeadTaskInternal(t.Context(), &models.DeadTask{TaskID: "task-1"})
+		require.NoError(t, err)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GetDeadTasks
+// ---------------------------------------------------------------------------
+
+func Test_deadTaskService_GetDeadTasks(t *testing.T) {
+	t.Run("success - defaults the page size", func(t *testing.T) {
+		repo := repomocks.NewMockDeadTaskRepository(t)
+		task := validDeadTask()
+		repo.EXPECT().
+			List(mock.Anything, &models.DeadTaskQuery{Type: "subscription:renewal", PageQuery: models.PageQuery{Limit: models.DefaultPageLimit}}).
+			Return([]*models.DeadTask{task}, int64(1), nil).
+			Once()
+
+		got, err := newDeadTaskService(repo, nil).GetDeadTasks(t.Context(), models.DeadTaskQuery{Type: "subscription:renewal"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &models.DeadTaskPage{Items: []*models.DeadTask{task}, Total: 1}, got)
+	})
+
+	t.Run("error - limit too large", func(t *testing.T) {
+		repo := repomocks.NewMockDeadTaskRepository(t)
+
+		got, err := newDeadTaskService(repo, nil).GetDeadTasks(t.Context(), models.DeadTaskQuery{PageQuery: models.PageQuery{Limit: models.MaxPageLimit + 1}})
+
+		assertAppErr(t, err, apperror.ErrValidation)
+		assert.Nil(t, got)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// RequeueDeadTask
+// ---------------------------------------------------------------------------
+
+func Test_deadTaskService_RequeueDeadTask(t *testing.T) {
+	t.Run("success - enqueues and marks the task", func(t *testing.T) {
+		repo := repomocks.NewMockDeadTaskRepository(t)
+		queue := svcmocks.NewMockDeadTaskQueue(t)
+		task := validDeadTask()
+		requeued := validDeadTask()
+		requeued.RequeuedAt = &mockTime
+		requeued.RequeuedTaskID = "task-2"
+		repo.EXPECT().GetByID(mock.Anything, deadTaskID).Return(task, nil).Once()
+		queue.EXPECT().RequeueDeadTask(mock.Anything, task).Return("task-2", nil).Once()
+		repo.EXPECT().MarkRequeued(mock.Anything, deadTaskID, "task-2", mockTime).Return(requeued, nil).Once()
+
+		got, err := newDeadTaskService(repo, queue).RequeueDeadTask(t.Context(), deadTaskID.Hex())
+
+		require.NoError(t, err)
+		assert.Equal(t, requeued, got)
+	})
+
+	t.Run("error - invalid ID", func(t *testing.T) {
+		repo := repomocks.NewMockDeadTaskRepository(t)
+		queue := svcmocks.NewMockDeadTaskQueue(t)
+
+		_, err := newDeadTaskService(repo, queue).RequeueDeadTask(t.Context(), "not-an-id")
+		assertAppErr(t, err, apperror.ErrBadRequest)
+	})
+
+	t.Run("error - already requeued", func(t *testing.T) {
+		repo := repomocks.NewMockDeadTaskRepository(t)
+		queue := svcmocks.NewMockDeadTaskQueue(t)
+		task := validDeadTask()
+		task.RequeuedAt = &mockTime
+		repo.EXPECT().GetByID(mock.Anything, deadTaskID).Return(task, nil).Once()
+
+		_, err := newDeadTaskService(repo, queue).RequeueDeadTask(t.Context(), deadTaskID.Hex())
+		assertAppErr(t, err, apperror.ErrConflict)
+	})
+
+	t.Run("error - enqueue fails", func(t *testing.T) {
+		repo := repomocks.NewMockDeadTaskRepository(t)
+		queue := svcmocks.NewMockDeadTaskQueue(t)
+		repo.EXPECT().GetByID(mock.Anything, deadTaskID).Return(validDeadTask(), nil).Once()
+		queue.EXPECT().
+			RequeueDeadTask(mock.Anything, mock.Anything).
+			Return("", apperror.NewInternalError(errors.New("redis down"))).
+			Once()
+
+		_, err := newDeadTaskService(repo, queue).RequeueDeadTask(t.Context(), deadTaskID.Hex())
+		assertAppErr(t, err, apperror.ErrInternal)
+	})
+
+	t.Run("error - no task queue", func(t *testing.T) {
+		repo := repomocks.NewMockDeadTaskRepository(t)
+		repo.EXPECT().GetByID(mock.Anything, deadTaskID).Return(validDeadTask(), nil).Once()
+
+		_, err := newDeadTaskService(repo, nil).RequeueDeadTask(t.Context(), deadTaskID.Hex())
+		assertAppErr(t, err, apperror.ErrInternal)
+	})
+}
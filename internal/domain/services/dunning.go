@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// UpdateBillStatus marks a renewal bill failed, which puts its subscription
+// past due and starts dunning, or marks a failed bill paid again, which ends
+// dunning and reactivates the subscription.
+func (s *subscriptionService) UpdateBillStatus(
+	ctx context.Context,
+	id string,
+	status models.PaymentStatus,
+) (*models.Bill, error) {
+	if status != models.Failed && status != models.Paid {
+		return nil, apperror.NewValidationError("status must be either paid or failed")
+	}
+	billID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid bill ID")
+	}
+
+	bill, err := s.billRepository.GetByID(ctx, billID)
+	if err != nil {
+		return nil, err
+	}
+	// The charge settles it, whichever way it goes.
+	if bill.Status == models.Pending {
+		return nil, errChargePending()
+	}
+	subscription, err := s.subscriptionRepository.GetByID(ctx, bill.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == models.Failed {
+		return s.failBill(ctx, bill, subscription, models.ActorUser)
+	}
+	return s.recoverBill(ctx, bill, subscription, models.ActorUser)
+}
+
+// failBill starts dunning for the latest renewal bill of an active
+// subscription, paid or with a pending charge. The subscription keeps its
+// validity while payment is retried. The actor is who failed it: an
+// administrator, or the system after a pending charge failed.
+func (s *subscriptionService) failBill(
+	ctx context.Context,
+	bill *models.Bill,
+	subscription *models.Subscription,
+	actor models.Actor,
+) (*models.Bill, error) {
+	if bill.Status != models.Paid && bill.Status != models.Pending {
+		return nil, apperror.NewConflictError("Only paid bills can be marked failed")
+	}
+	if subscription.Status != models.Active {
+		return nil, apperror.NewConflictError("Only bills of active subscriptions can be marked failed")
+	}
+	// The first bill is charged when the subscription is created; only
+	// renewals are retried.
+	if !bill.StartDate.After(subscription.CreatedAt) {
+		return nil, apperror.NewConflictError("Only renewal bills can be marked failed")
+	}
+	latestBill, err := s.billRepository.GetRecentBill(ctx, subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+	if latestBill.ID != bill.ID {
+		return nil, apperror.NewConflictError("Only the latest bill can be marked failed")
+	}
+
+	now := s.getTime()
+	before := bill.ToResponse()
+	bill.Status = models.Failed
+	bill.UpdatedAt = now
+
+	var res *models.Subscription
+	err = s.runTx(ctx, func(ctx context.Context) error {
+		var txnErr error
+		res, txnErr = s.subscriptionRepository.TransitionStatus(ctx, subscription.ID, models.Active, models.PastDue, now)
+		if txnErr != nil {
+			return txnErr
+		}
+		res.Dunning = &models.Dunning{BillID: bill.ID, StartedAt: now}
+		if res, txnErr = s.subscriptionRepository.Update(ctx, res); txnErr != nil {
+			return txnErr
+		}
+		if _, txnErr = s.billRepository.Update(ctx, bill); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.recordEvent(ctx, models.BillFailedEvent, bill.ID, res.UserID, bill.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.audit.RecordInternal(ctx, actor, models.AuditBillStatusChanged, bill.ID, before, bill.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		description := fmt.Sprintf("Renewal payment of %s failed",
+			models.FormatMoney(bill.Amount, bill.Currency),
+		)
+		if bill.FailureReason != "" {
+			description += ": " + bill.FailureReason
+		}
+		return s.recordLifecycle(ctx, res, models.LifecyclePastDue, models.ActorSystem, description)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Renewal payment failed, subscription is past due",
+		logattr.SubscriptionID(res.ID.Hex()),
+		logattr.ValidTill(res.ValidTill),
+	)
+	return bill, nil
+}
+
+// recoverBill settles the failed bill a past-due or suspended subscription is
+// being dunned for, or the bill whose retried charge was pending, and
+// reactivates the subscription. The actor is who settled it: an
+// administrator, or the system after a successful payment retry. A suspended
+// subscription is valid for the period of the bill again, or from now if
+// that period is over, so the next renewal picks it up.
+func (s *subscriptionService) recoverBill(
+	ctx context.Context,
+	bill *models.Bill,
+	subscription *models.Subscription,
+	actor models.Actor,
+) (*models.Bill, error) {
+	if bill.Status != models.Failed && bill.Status != models.Pending {
+		return nil, apperror.NewConflictError("Only failed bills can be marked paid")
+	}
+	dunned := subscription.Status == models.PastDue || subscription.Status == models.Suspended
+	if !dunned || subscription.Dunning == nil || subscription.Dunning.BillID != bill.ID {
+		return nil, apperror.NewConflictError("The subscription is no longer retrying this bill")
+	}
+
+	now := s.getTime()
+	before := bill.ToResponse()
+	bill.Status = models.Paid
+	bill.UpdatedAt = now
+
+	var res *models.Subscription
+	err := s.runTx(ctx, func(ctx context.Context) error {
+		var txnErr error
+		res, txnErr = s.subscriptionRepository.TransitionStatus(ctx, subscription.ID, subscription.Status, models.Active, now)
+		if txnErr != nil {
+			return txnErr
+		}
+		if subscription.Status == models.Suspended {
+			res.ValidTill = resumedValidTill(bill, now)
+		}
+		res.Dunning = nil
+		if res, txnErr = s.subscriptionRepository.Update(ctx, res); txnErr != nil {
+			return txnErr
+		}
+		if _, txnErr = s.billRepository.Update(ctx, bill); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.recordEvent(ctx, models.BillPaidEvent, bill.ID, res.UserID, bill.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.audit.RecordInternal(ctx, actor, models.AuditBillStatusChanged, bill.ID, before, bill.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		description := fmt.Sprintf("Renewal payment of %s recovered",
+			models.FormatMoney(bill.Amount, bill.Currency),
+		)
+		return s.recordLifecycle(ctx, res, models.LifecycleRecovered, models.ActorSystem, description)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Renewal payment recovered, subscription is active",
+		logattr.SubscriptionID(res.ID.Hex()),
+		logattr.ValidTill(res.ValidTill),
+	)
+	return bill, nil
+}
+
+func (s *subscriptionService) FetchPastDueSubscriptionsInternal(ctx context.Context) ([]*models.Subscription, error) {
+	return s.subscriptionRepository.GetPastDue(ctx)
+}
+
+func (s *subscriptionService) EachPastDueSubscriptionInternal(ctx context.Context, fn func(*models.Subscription) error) error {
+	return s.subscriptionRepository.EachPastDue(ctx, fn)
+}
+
+// RecordPaymentRetryInternal counts a payment retry of a past-due
+// subscription. After the final retry the subscription ends at the start of
+// the failed bill, the end of the last period that was paid for: it expires,
+// or with DunningSuspend is suspended and keeps its dunning record, so paying
+// the bill later reactivates it.
+func (s *subscriptionService) RecordPaymentRetryInternal(
+	ctx context.Context,
+	id bson.ObjectID,
+	attempt int,
+	final bool,
+	action models.DunningFinalAction,
+) error {
+	now := s.getTime()
+	if !final {
+		_, err := s.subscriptionRepository.AdvanceDunning(ctx, id, attempt, now)
+		return err
+	}
+
+	subscription, err := s.subscriptionRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if subscription.Dunning == nil || subscription.Dunning.Attempts != attempt-1 {
+		return apperror.NewConflictError("Payment retry %d was already recorded", attempt)
+	}
+	bill, err := s.billRepository.GetByID(ctx, subscription.Dunning.BillID)
+	if err != nil {
+		return err
+	}
+
+	if action == models.DunningSuspend {
+		return s.suspend(ctx, subscription, bill, attempt)
+	}
+
+	var res *models.Subscription
+	err = s.runTx(ctx, func(ctx context.Context) error {
+		var txnErr error
+		res, txnErr = s.subscriptionRepository.TransitionStatus(ctx, id, models.PastDue, models.Expired, now)
+		if txnErr != nil {
+			return txnErr
+		}
+		res.ValidTill = bill.StartDate
+		res.Dunning = nil
+		if res, txnErr = s.subscriptionRepository.Update(ctx, res); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.recordEvent(ctx, models.SubscriptionExpiredEvent, res.ID, res.UserID, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		description := fmt.Sprintf("Subscription expired after %d failed payment retries", attempt)
+		return s.recordLifecycle(ctx, res, models.LifecycleExpired, models.ActorSystem, description)
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Past-due subscription expired after final payment retry",
+		logattr.SubscriptionID(res.ID.Hex()),
+		logattr.ValidTill(res.ValidTill),
+	)
+	return nil
+}
+
+// suspend suspends a past-due subscription after its final payment retry.
+// The dunning record stays so the failed bill can still be settled.
+func (s *subscriptionService) suspend(
+	ctx context.Context,
+	subscription *models.Subscription,
+	bill *models.Bill,
+	attempt int,
+) error {
+	now := s.getTime()
+	var res *models.Subscription
+	err := s.runTx(ctx, func(ctx context.Context) error {
+		var txnErr error
+		res, txnErr = s.subscriptionRepository.TransitionStatus(ctx, subscription.ID, models.PastDue, models.Suspended, now)
+		if txnErr != nil {
+			return txnErr
+		}
+		res.ValidTill = bill.StartDate
+		res.Dunning.Attempts = attempt
+		if res, txnErr = s.subscriptionRepository.Update(ctx, res); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.recordEvent(ctx, models.SubscriptionSuspendedEvent, res.ID, res.UserID, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		description := fmt.Sprintf("Subscription suspended after %d failed payment retries", attempt)
+		return s.recordLifecycle(ctx, res, models.LifecycleSuspended, models.ActorSystem, description)
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Past-due subscription suspended after final payment retry",
+		logattr.SubscriptionID(res.ID.Hex()),
+		logattr.ValidTill(res.ValidTill),
+	)
+	return nil
+}
+
+// EndSettledDunningInternal reactivates a past-due or suspended subscription
+// whose failed bill was paid without dunning ending, e.g. by a write that
+// failed halfway without a transaction. The bill itself is left as it is.
+func (s *subscriptionService) EndSettledDunningInternal(ctx context.Context, id bson.ObjectID) error {
+	subscription, err := s.subscriptionRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	dunned := subscription.Status == models.PastDue || subscription.Status == models.Suspended
+	if !dunned || subscription.Dunning == nil {
+		return apperror.NewConflictError("The subscription is not retrying a payment")
+	}
+	bill, err := s.billRepository.GetByID(ctx, subscription.Dunning.BillID)
+	if err != nil {
+		return err
+	}
+	if bill.Status != models.Paid {
+		return apperror.NewConflictError("The bill being retried is not paid")
+	}
+
+	now := s.getTime()
+	before := subscription.ToResponse()
+	var res *models.Subscription
+	err = s.runTx(ctx, func(ctx context.Context) error {
+		var txnErr error
+		res, txnErr = s.subscriptionRepository.TransitionStatus(ctx, id, subscription.Status, models.Active, now)
+		if txnErr != nil {
+			return txnErr
+		}
+		if subscription.Status == models.Suspended {
+			res.ValidTill = resumedValidTill(bill, now)
+		}
+		res.Dunning = nil
+		if res, txnErr = s.subscriptionRepository.Update(ctx, res); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.audit.RecordInternal(ctx, models.ActorSystem, models.AuditSubscriptionReconciled, res.ID, before, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		description := fmt.Sprintf("Renewal payment of %s found paid, retries stopped",
+			models.FormatMoney(bill.Amount, bill.Currency),
+		)
+		return s.recordLifecycle(ctx, res, models.LifecycleRecovered, models.ActorSystem, description)
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.WarnContext(ctx, "Reactivated dunned subscription whose bill was already paid",
+		logattr.SubscriptionID(res.ID.Hex()),
+		logattr.Status(string(subscription.Status)),
+		logattr.BillID(bill.ID.Hex()),
+	)
+	return nil
+}
+
+// resumedValidTill returns the validity of a suspended subscription whose
+// failed bill was paid: the end of that bill, or now if it has passed.
+func resumedValidTill(bill *models.Bill, now time.Time) time.Time {
+	if bill.EndDate.Before(now) {
+		return now
+	}
+	return bill.EndDate
+}
@@ -0,0 +1,285 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// renewedSub returns an active subscription created a month before mockToday,
+// so the bill starting today is a renewal.
+func renewedSub() *models.Subscription {
+	sub := validSub()
+	sub.CreatedAt = mockToday.AddDate(0, -1, 0)
+	return sub
+}
+
+// pastDueSub returns a subscription dunned for bill after the given number of
+// retries.
+func pastDueSub(bill *models.Bill, attempts int) *models.Subscription {
+	sub := renewedSub()
+	sub.Status = models.PastDue
+	sub.Dunning = &models.Dunning{BillID: bill.ID, StartedAt: mockTime, Attempts: attempts}
+	return sub
+}
+
+func returnSub(_ context.Context, s *models.Subscription) (*models.Subscription, error) {
+	return s, nil
+}
+
+// ---------------------------------------------------------------------------
+// UpdateBillStatus
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_UpdateBillStatus(t *testing.T) {
+	t.Run("failed renewal bill puts the subscription past due", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+
+		bill := validBill()
+		pastDue := renewedSub()
+		pastDue.Status = models.PastDue
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(renewedSub(), nil).Once()
+		billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(bill, nil).Once()
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.Active, models.PastDue, mockTime).
+			Return(pastDue, nil).
+			Once()
+		subRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+				return s.Dunning != nil && s.Dunning.BillID == bill.ID &&
+					s.Dunning.StartedAt.Equal(mockTime) && s.Dunning.Attempts == 0
+			})).
+			RunAndReturn(returnSub).
+			Once()
+		billRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+				return b.Status == models.Failed
+			})).
+			Return(bill, nil).
+			Once()
+		expectEvents(outboxRepo, []models.EventType{models.BillFailedEvent}, nil)
+
+		svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, nil)
+		got, err := svc.UpdateBillStatus(t.Context(), bill.ID.Hex(), models.Failed)
+		require.NoError(t, err)
+		assert.Equal(t, models.Failed, got.Status)
+	})
+
+	t.Run("a bill with a pending charge is left to the charge", func(t *testing.T) {
+		billRepo := repomocks.NewMockBillRepository(t)
+
+		bill := validBill()
+		bill.Status = models.Pending
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Twice()
+
+		svc := newSubServiceWithOutbox(repomocks.NewMockSubscriptionRepository(t), billRepo, repomocks.NewMockOutboxRepository(t), nil)
+		for _, status := range []models.PaymentStatus{models.Paid, models.Failed} {
+			_, err := svc.UpdateBillStatus(t.Context(), bill.ID.Hex(), status)
+			assertAppErr(t, err, apperror.ErrConflict)
+		}
+	})
+
+	t.Run("the first bill is not dunned", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+
+		bill := validBill()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+
+		svc := newSubService(subRepo, billRepo, nil)
+		_, err := svc.UpdateBillStatus(t.Context(), bill.ID.Hex(), models.Failed)
+		assertAppErr(t, err, apperror.ErrConflict)
+	})
+
+	t.Run("only the latest bill can fail", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+
+		bill := validBill()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(renewedSub(), nil).Once()
+		billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(validBill(), nil).Once()
+
+		svc := newSubService(subRepo, billRepo, nil)
+		_, err := svc.UpdateBillStatus(t.Context(), bill.ID.Hex(), models.Failed)
+		assertAppErr(t, err, apperror.ErrConflict)
+	})
+
+	t.Run("paying the failed bill reactivates the subscription", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+
+		bill := validBill()
+		bill.Status = models.Failed
+		reactivated := pastDueSub(bill, 1)
+		reactivated.Status = models.Active
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(pastDueSub(bill, 1), nil).Once()
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.PastDue, models.Active, mockTime).
+			Return(reactivated, nil).
+			Once()
+		subRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+				return s.Dunning == nil && s.Status == models.Active
+			})).
+			RunAndReturn(returnSub).
+			Once()
+		billRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+				return b.Status == models.Paid
+			})).
+			Return(bill, nil).
+			Once()
+		expectEvents(outboxRepo, []models.EventType{models.BillPaidEvent}, nil)
+
+		svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, nil)
+		got, err := svc.UpdateBillStatus(t.Context(), bill.ID.Hex(), models.Paid)
+		require.NoError(t, err)
+		assert.Equal(t, models.Paid, got.Status)
+	})
+
+	t.Run("paying the failed bill reactivates a suspended subscription", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+
+		bill := validBill()
+		bill.Status = models.Failed
+		suspended := pastDueSub(bill, 3)
+		suspended.Status = models.Suspended
+		reactivated := pastDueSub(bill, 3)
+		reactivated.Status = models.Active
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(suspended, nil).Once()
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.Suspended, models.Active, mockTime).
+			Return(reactivated, nil).
+			Once()
+		subRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+				return s.Dunning == nil && s.ValidTill.Equal(bill.EndDate)
+			})).
+			RunAndReturn(returnSub).
+			Once()
+		billRepo.EXPECT().Update(mock.Anything, mock.Anything).Return(bill, nil).Once()
+		expectEvents(outboxRepo, []models.EventType{models.BillPaidEvent}, nil)
+
+		svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, nil)
+		_, err := svc.UpdateBillStatus(t.Context(), bill.ID.Hex(), models.Paid)
+		require.NoError(t, err)
+	})
+
+	t.Run("paying a bill that is not dunned is a conflict", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+
+		bill := validBill()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(renewedSub(), nil).Once()
+
+		svc := newSubService(subRepo, billRepo, nil)
+		_, err := svc.UpdateBillStatus(t.Context(), bill.ID.Hex(), models.Paid)
+		assertAppErr(t, err, apperror.ErrConflict)
+	})
+
+	t.Run("refunds are rejected", func(t *testing.T) {
+		svc := newSubService(nil, nil, nil)
+		_, err := svc.UpdateBillStatus(t.Context(), validBill().ID.Hex(), models.Refunded)
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// RecordPaymentRetryInternal
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_RecordPaymentRetryInternal(t *testing.T) {
+	t.Run("an intermediate retry is counted", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		subRepo.EXPECT().AdvanceDunning(mock.Anything, defaultSubID, 2, mockTime).
+			Return(pastDueSub(validBill(), 2), nil).
+			Once()
+
+		svc := newSubService(subRepo, nil, nil)
+		require.NoError(t, svc.RecordPaymentRetryInternal(t.Context(), defaultSubID, 2, false, models.DunningExpire))
+	})
+
+	t.Run("the final retry expires the subscription at the failed bill", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+
+		bill := validBill()
+		bill.Status = models.Failed
+		expired := pastDueSub(bill, 2)
+		expired.Status = models.Expired
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(pastDueSub(bill, 2), nil).Once()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.PastDue, models.Expired, mockTime).
+			Return(expired, nil).
+			Once()
+		subRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+				return s.Dunning == nil && s.ValidTill.Equal(bill.StartDate)
+			})).
+			RunAndReturn(returnSub).
+			Once()
+		expectEvents(outboxRepo, []models.EventType{models.SubscriptionExpiredEvent}, nil)
+
+		svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, nil)
+		require.NoError(t, svc.RecordPaymentRetryInternal(t.Context(), defaultSubID, 3, true, models.DunningExpire))
+	})
+
+	t.Run("the final retry suspends the subscription when configured", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+
+		bill := validBill()
+		bill.Status = models.Failed
+		suspended := pastDueSub(bill, 3)
+		suspended.Status = models.Suspended
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(pastDueSub(bill, 2), nil).Once()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.PastDue, models.Suspended, mockTime).
+			Return(suspended, nil).
+			Once()
+		subRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+				return s.Dunning != nil && s.Dunning.Attempts == 3 && s.ValidTill.Equal(bill.StartDate)
+			})).
+			RunAndReturn(returnSub).
+			Once()
+		expectEvents(outboxRepo, []models.EventType{models.SubscriptionSuspendedEvent}, nil)
+
+		svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, nil)
+		require.NoError(t, svc.RecordPaymentRetryInternal(t.Context(), defaultSubID, 3, true, models.DunningSuspend))
+	})
+
+	t.Run("a final retry already recorded is a conflict", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(pastDueSub(validBill(), 3), nil).Once()
+
+		svc := newSubService(subRepo, nil, nil)
+		err := svc.RecordPaymentRetryInternal(t.Context(), defaultSubID, 3, true, models.DunningExpire)
+		assertAppErr(t, err, apperror.ErrConflict)
+	})
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/i18n"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// EmailPreviewService lets administrators review changes to the email
+// templates without waiting for a real reminder.
+type EmailPreviewService interface {
+	// GetEmailTemplates lists the templates of the emails sent to users.
+	GetEmailTemplates(ctx context.Context) []models.EmailTemplate
+	// PreviewEmail renders an email without sending it.
+	PreviewEmail(ctx context.Context, request *models.EmailPreviewRequest) (*models.EmailPreview, error)
+	// SendTestEmail sends the preview of an email to the requesting
+	// administrator.
+	SendTestEmail(
+		ctx context.Context,
+		request *models.EmailPreviewRequest,
+		claimedUserID string,
+	) (*models.EmailPreview, error)
+}
+
+// EmailPreviewer renders the emails sent to users with sample data.
+type EmailPreviewer interface {
+	EmailTemplates() []models.EmailTemplate
+	PreviewEmail(ctx context.Context, template string, data *models.EmailPreviewData) (*models.EmailPreview, error)
+	SendTestEmail(ctx context.Context, to string, template string, data *models.EmailPreviewData) (*models.EmailPreview, error)
+}
+
+type emailPreviewService struct {
+	userService UserServiceInternal
+	previewer   EmailPreviewer
+}
+
+// NewEmailPreviewService creates a new instance of EmailPreviewService.
+func NewEmailPreviewService(userService UserServiceInternal, previewer EmailPreviewer) EmailPreviewService {
+	return &emailPreviewService{
+		userService,
+		previewer,
+	}
+}
+
+func (s *emailPreviewService) GetEmailTemplates(context.Context) []models.EmailTemplate {
+	return s.previewer.EmailTemplates()
+}
+
+func (s *emailPreviewService) PreviewEmail(
+	ctx context.Context,
+	request *models.EmailPreviewRequest,
+) (*models.EmailPreview, error) {
+	ctx, err := s.prepare(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return s.previewer.PreviewEmail(ctx, request.Template, &request.Data)
+}
+
+// SendTestEmail only ever sends to the address of the requesting
+// administrator, so the endpoint cannot be used to email anyone else.
+func (s *emailPreviewService) SendTestEmail(
+	ctx context.Context,
+	request *models.EmailPreviewRequest,
+	claimedUserID string,
+) (*models.EmailPreview, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	ctx, err = s.prepare(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.FetchUserByIDInternal(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	preview, err := s.previewer.SendTestEmail(ctx, user.Email, request.Template, &request.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Test email sent",
+		logattr.Template(request.Template),
+	)
+	return preview, nil
+}
+
+// prepare validates request and returns ctx in the language it asks for.
+func (s *emailPreviewService) prepare(
+	ctx context.Context,
+	request *models.EmailPreviewRequest,
+) (context.Context, error) {
+	known := slices.ContainsFunc(s.previewer.EmailTemplates(), func(t models.EmailTemplate) bool {
+		return t.Name == request.Template
+	})
+	if !known {
+		return nil, apperror.NewValidationError("unknown email template %s", request.Template)
+	}
+
+	request.Data.Currency = models.ParseCurrency(string(request.Data.Currency))
+	if err := request.Data.Validate(); err != nil {
+		return nil, err
+	}
+
+	if request.Locale != "" {
+		ctx = appctx.WithLocale(ctx, i18n.Match(request.Locale).Locale())
+	}
+	return ctx, nil
+}
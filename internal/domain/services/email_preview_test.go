@@ -0,0 +1,134 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupEmailPreview(t *testing.T) (
+	services.EmailPreviewService,
+	*svcmocks.MockUserServiceInternal,
+	*svcmocks.MockEmailPreviewer,
+) {
+	t.Helper()
+
+	userSvc := svcmocks.NewMockUserServiceInternal(t)
+	previewer := svcmocks.NewMockEmailPreviewer(t)
+	previewer.EXPECT().
+		EmailTemplates().
+		Return([]models.EmailTemplate{{Name: "reminder"}, {Name: "dunning"}}).
+		Maybe()
+	return services.NewEmailPreviewService(userSvc, previewer), userSvc, previewer
+}
+
+func hasLocale(locale string) any {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		got, _ := appctx.GetLocale(ctx)
+		return got == locale
+	})
+}
+
+// ---------------------------------------------------------------------------
+// PreviewEmail
+// ---------------------------------------------------------------------------
+
+func TestEmailPreviewService_PreviewEmail(t *testing.T) {
+	t.Run("renders in the requested language", func(t *testing.T) {
+		svc, _, previewer := setupEmailPreview(t)
+		preview := &models.EmailPreview{Template: "reminder", Subject: "Erinnerung"}
+		previewer.EXPECT().
+			PreviewEmail(hasLocale("de"), "reminder", &models.EmailPreviewData{Currency: "EUR"}).
+			Return(preview, nil).
+			Once()
+
+		got, err := svc.PreviewEmail(t.Context(), &models.EmailPreviewRequest{
+			Template: "reminder",
+			Locale:   "de-DE",
+			Data:     models.EmailPreviewData{Currency: "eur"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, preview, got)
+	})
+
+	tests := []struct {
+		name    string
+		request *models.EmailPreviewRequest
+	}{
+		{"unknown template", &models.EmailPreviewRequest{Template: "welcome"}},
+		{"invalid currency", &models.EmailPreviewRequest{
+			Template: "reminder",
+			Data:     models.EmailPreviewData{Currency: "XYZ"},
+		}},
+		{"invalid frequency", &models.EmailPreviewRequest{
+			Template: "reminder",
+			Data:     models.EmailPreviewData{Frequency: "weekly"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _ := setupEmailPreview(t)
+
+			_, err := svc.PreviewEmail(t.Context(), tt.request)
+
+			appErr, ok := errors.AsType[apperror.AppError](err)
+			require.True(t, ok, "expected an AppError, got %v", err)
+			assert.Equal(t, apperror.ErrValidation, appErr.Code())
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// SendTestEmail
+// ---------------------------------------------------------------------------
+
+func TestEmailPreviewService_SendTestEmail(t *testing.T) {
+	t.Run("sends to the requesting admin", func(t *testing.T) {
+		svc, userSvc, previewer := setupEmailPreview(t)
+		request := &models.EmailPreviewRequest{Template: "dunning", Data: models.EmailPreviewData{Final: true}}
+		preview := &models.EmailPreview{Template: "dunning"}
+		userSvc.EXPECT().FetchUserByIDInternal(mock.Anything, defaultUserID).Return(validUser(), nil).Once()
+		previewer.EXPECT().
+			SendTestEmail(mock.Anything, defaultUserEmail, "dunning", &request.Data).
+			Return(preview, nil).
+			Once()
+
+		got, err := svc.SendTestEmail(t.Context(), request, defaultUserHex)
+
+		require.NoError(t, err)
+		assert.Equal(t, preview, got)
+	})
+
+	t.Run("invalid user ID", func(t *testing.T) {
+		svc, _, _ := setupEmailPreview(t)
+
+		_, err := svc.SendTestEmail(t.Context(), &models.EmailPreviewRequest{Template: "reminder"}, "bad")
+
+		appErr, ok := errors.AsType[apperror.AppError](err)
+		require.True(t, ok, "expected an AppError, got %v", err)
+		assert.Equal(t, apperror.ErrUnauthorized, appErr.Code())
+	})
+
+	t.Run("send fails", func(t *testing.T) {
+		svc, userSvc, previewer := setupEmailPreview(t)
+		userSvc.EXPECT().FetchUserByIDInternal(mock.Anything, defaultUserID).Return(validUser(), nil).Once()
+		previewer.EXPECT().
+			SendTestEmail(mock.Anything, defaultUserEmail, "reminder", mock.Anything).
+			Return(nil, errors.New("smtp down")).
+			Once()
+
+		_, err := svc.SendTestEmail(t.Context(), &models.EmailPreviewRequest{Template: "reminder"}, defaultUserHex)
+
+		require.EqualError(t, err, "smtp down")
+	})
+}
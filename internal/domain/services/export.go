@@ -0,0 +1,428 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ExportConfig holds the settings for subscription and bill exports.
+type ExportConfig struct {
+	// MaxRows is the most rows an export streams in its response. Larger
+	// exports are generated in the background and their link is emailed.
+	MaxRows    int           `mapstructure:"max_rows"`
+	LinkExpiry time.Duration `mapstructure:"link_expiry"` // Lifetime of an emailed download link.
+}
+
+// ExportServiceExternal exports a user's subscriptions and bills as files.
+type ExportServiceExternal interface {
+	// Export returns the file the request selects, streamed from the
+	// database as it is read. Exports of more than the configured number of
+	// rows are queued instead and returned without a Body.
+	Export(ctx context.Context, claimedUserID string, request models.ExportRequest) (*models.Export, error)
+	// ExportAccount queues an archive of everything stored about the user
+	// and returns the export without a Body; the link is emailed.
+	ExportAccount(ctx context.Context, id string, claimedUserID string) (*models.Export, error)
+}
+
+type ExportServiceInternal interface {
+	// GenerateExportInternal stores a queued export, replacing the user's
+	// previous export of the same kind and format, and signs a download URL
+	// valid for the configured link expiry.
+	GenerateExportInternal(ctx context.Context, request models.ExportRequest) (*models.SignedFile, error)
+	// DeleteExportInternal removes a stored export once its link expired.
+	DeleteExportInternal(ctx context.Context, fileID bson.ObjectID) error
+}
+
+type ExportService interface {
+	ExportServiceExternal
+	ExportServiceInternal
+}
+
+// ExportQueue hands large exports over to the queue worker.
+type ExportQueue interface {
+	EnqueueExport(ctx context.Context, request models.ExportRequest) error
+}
+
+type exportService struct {
+	userRepository           repositories.UserRepository
+	subscriptionRepository   repositories.SubscriptionRepository
+	billRepository           repositories.BillRepository
+	archiveRepository        repositories.ArchiveRepository
+	lifecycleEventRepository repositories.LifecycleEventRepository
+	fileService              FileServiceInternal
+	queue                    ExportQueue
+	config                   ExportConfig
+	getTime                  clock.NowFn
+}
+
+// NewExportService creates a new instance of ExportService. Processes that
+// only generate queued exports may pass a nil queue.
+func NewExportService(
+	userRepository repositories.UserRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+	billRepository repositories.BillRepository,
+	archiveRepository repositories.ArchiveRepository,
+	lifecycleEventRepository repositories.LifecycleEventRepository,
+	fileService FileServiceInternal,
+	queue ExportQueue,
+	config ExportConfig,
+	nowFn clock.NowFn,
+) ExportService {
+	return &exportService{
+		userRepository,
+		subscriptionRepository,
+		billRepository,
+		archiveRepository,
+		lifecycleEventRepository,
+		fileService,
+		queue,
+		config,
+		nowFn,
+	}
+}
+
+// Export counts the rows of the export first, so the caller learns whether
+// the file comes in the response or by email before any of it is written.
+func (s *exportService) Export(ctx context.Context, claimedUserID string, request models.ExportRequest) (*models.Export, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	request.UserID = userID
+	if err = request.Validate(); err != nil {
+		return nil, err
+	}
+	if request.Kind == models.AccountExport {
+		return nil, apperror.NewValidationError("kind must be one of subscriptions, bills")
+	}
+
+	source, err := s.source(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+	export := &models.Export{
+		Request:     request,
+		Filename:    request.Filename(s.getTime()),
+		ContentType: request.Format.ContentType(),
+	}
+	if export.Rows, err = s.count(ctx, source); err != nil {
+		return nil, err
+	}
+
+	if export.Rows > int64(s.config.MaxRows) && s.queue != nil {
+		if err = s.queue.EnqueueExport(ctx, request); err != nil {
+			return nil, err
+		}
+		slog.InfoContext(ctx, "Export queued",
+			logattr.ExportKind(string(request.Kind)),
+			logattr.ExportFormat(string(request.Format)),
+			logattr.Total(int(export.Rows)),
+		)
+		return export, nil
+	}
+
+	// Rows are written to the response as they are read. Closing the body
+	// early fails the next write, which ends the goroutine.
+	body, w := io.Pipe()
+	go func() {
+		w.CloseWithError(s.write(ctx, source, w))
+	}()
+	export.Body = body
+	return export, nil
+}
+
+// ExportAccount always queues the archive: it gathers several collections,
+// and the link is only ever sent to the account's own address.
+func (s *exportService) ExportAccount(ctx context.Context, id string, claimedUserID string) (*models.Export, error) {
+	if id != claimedUserID {
+		return nil, apperror.NewForbiddenError("You can only export your own account")
+	}
+	userID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid user ID")
+	}
+	if s.queue == nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("no export queue configured"))
+	}
+
+	request := models.ExportRequest{
+		UserID: userID,
+		Kind:   models.AccountExport,
+		Format: models.ZIPFormat,
+	}
+	if err = s.queue.EnqueueExport(ctx, request); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Export queued",
+		logattr.ExportKind(string(request.Kind)),
+		logattr.ExportFormat(string(request.Format)),
+	)
+	return &models.Export{
+		Request:     request,
+		Filename:    request.Filename(s.getTime()),
+		ContentType: request.Format.ContentType(),
+	}, nil
+}
+
+func (s *exportService) GenerateExportInternal(ctx context.Context, request models.ExportRequest) (*models.SignedFile, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	source, err := s.source(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	body, w := io.Pipe()
+	defer body.Close()
+	go func() {
+		w.CloseWithError(s.write(ctx, source, w))
+	}()
+
+	metadata := models.FileMetadata{
+		Key:         fmt.Sprintf("export/%s/%s.%s", request.UserID.Hex(), request.Kind, request.Format),
+		OwnerID:     request.UserID,
+		ContentType: request.Format.ContentType(),
+		Attributes: map[string]string{
+			"kind":   string(request.Kind),
+			"format": string(request.Format),
+		},
+	}
+	file, err := s.fileService.StoreFileInternal(ctx, request.Filename(s.getTime()), metadata, body)
+	if err != nil {
+		return nil, err
+	}
+	return s.fileService.SignFileForInternal(file, s.config.LinkExpiry), nil
+}
+
+func (s *exportService) DeleteExportInternal(ctx context.Context, fileID bson.ObjectID) error {
+	return s.fileService.DeleteFileInternal(ctx, fileID)
+}
+
+// exportSource is an export request with the bill filter it resolves to,
+// so counting and writing the rows read the user's subscriptions once.
+type exportSource struct {
+	request    *models.ExportRequest
+	billFilter *models.BillFilter       // Nil for subscription exports.
+	names      map[bson.ObjectID]string // Subscription names by ID, for bill rows.
+}
+
+// source resolves the records request selects.
+func (s *exportService) source(ctx context.Context, request *models.ExportRequest) (*exportSource, error) {
+	source := &exportSource{request: request}
+	if request.Kind == models.BillExport {
+		var err error
+		if source.billFilter, source.names, err = s.billFilter(ctx, request); err != nil {
+			return nil, err
+		}
+	}
+	return source, nil
+}
+
+// count returns the number of rows the export lists.
+func (s *exportService) count(ctx context.Context, source *exportSource) (int64, error) {
+	if source.billFilter == nil {
+		return s.subscriptionRepository.Count(ctx, source.request.SubscriptionQuery())
+	}
+	return s.billRepository.Count(ctx, source.billFilter)
+}
+
+// write writes the export file to w.
+func (s *exportService) write(ctx context.Context, source *exportSource, w io.Writer) error {
+	if source.request.Kind == models.AccountExport {
+		return s.writeAccount(ctx, source.request.UserID, w)
+	}
+
+	out, err := newExportWriter(source.request.Format, w)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if source.billFilter == nil {
+		err = s.writeSubscriptions(ctx, source.request, out)
+	} else {
+		err = s.writeBills(ctx, source, out)
+	}
+	if err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// writeSubscriptions writes a row per subscription, with the fields of the
+// API responses under the same names.
+func (s *exportService) writeSubscriptions(ctx context.Context, request *models.ExportRequest, out exportWriter) error {
+	if err := out.WriteRow(
+		"id", "name", "category", "status", "frequency", "price", "currency",
+		"validTill", "trialEndsAt", "lastUsedAt", "createdAt",
+	); err != nil {
+		return err
+	}
+	return s.subscriptionRepository.Each(ctx, request.SubscriptionQuery(), func(sub *models.Subscription) error {
+		return out.WriteRow(
+			sub.ID.Hex(),
+			sub.Name,
+			string(sub.Category),
+			string(sub.Status),
+			string(sub.Frequency),
+			exportAmount{sub.Price, sub.Currency},
+			string(sub.Currency),
+			sub.ValidTill,
+			sub.TrialEndsAt,
+			sub.LastUsedAt,
+			sub.CreatedAt,
+		)
+	})
+}
+
+// writeBills writes a row per bill, oldest first, naming the subscription
+// each belongs to.
+func (s *exportService) writeBills(ctx context.Context, source *exportSource, out exportWriter) error {
+	if err := out.WriteRow(
+		"id", "subscriptionId", "subscriptionName", "status", "amount", "currency",
+		"startDate", "endDate", "chargeId", "failureReason", "createdAt",
+	); err != nil {
+		return err
+	}
+	return s.billRepository.Each(ctx, source.billFilter, func(bill *models.Bill) error {
+		return out.WriteRow(
+			bill.ID.Hex(),
+			bill.SubscriptionID.Hex(),
+			source.names[bill.SubscriptionID],
+			string(bill.Status),
+			exportAmount{bill.Amount, bill.Currency},
+			string(bill.Currency),
+			bill.StartDate,
+			bill.EndDate,
+			bill.ChargeID,
+			bill.FailureReason,
+			bill.CreatedAt,
+		)
+	})
+}
+
+// billFilter returns the filter selecting the bills of a bill export and the
+// names of the subscriptions they may belong to. Bills are reached through
+// their subscriptions, so a subscription filter must name one of the user's.
+func (s *exportService) billFilter(
+	ctx context.Context,
+	request *models.ExportRequest,
+) (*models.BillFilter, map[bson.ObjectID]string, error) {
+	var subscriptions []*models.Subscription
+	if request.SubscriptionID.IsZero() {
+		var err error
+		if subscriptions, err = s.subscriptionRepository.GetByUserID(ctx, request.UserID); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		subscription, err := s.subscriptionRepository.GetByID(ctx, request.SubscriptionID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if subscription.UserID != request.UserID {
+			return nil, nil, apperror.NewForbiddenError("You are not allowed to view bills of this subscription")
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	filter := &models.BillFilter{
+		SubscriptionIDs: make([]bson.ObjectID, 0, len(subscriptions)),
+		Status:          request.BillStatus,
+		From:            request.From,
+		To:              request.To,
+	}
+	names := make(map[bson.ObjectID]string, len(subscriptions))
+	for _, subscription := range subscriptions {
+		filter.SubscriptionIDs = append(filter.SubscriptionIDs, subscription.ID)
+		names[subscription.ID] = subscription.Name
+	}
+	return filter, names, nil
+}
+
+// writeAccount writes a ZIP archive of everything stored about the user:
+// their profile, live and archived subscriptions, bills, and the timeline of
+// each subscription, which includes the reminders sent. Each file holds the
+// records as the API returns them.
+func (s *exportService) writeAccount(ctx context.Context, userID bson.ObjectID, w io.Writer) error {
+	user, err := s.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	live, err := s.subscriptionRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	archived, err := s.archiveRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	subscriptions := make([]*models.SubscriptionResponse, 0, len(live)+len(archived))
+	bills := []*models.BillResponse{}
+	timeline := []*models.LifecycleEventResponse{}
+	filter := &models.BillFilter{SubscriptionIDs: make([]bson.ObjectID, 0, len(live))}
+	all := make([]*models.Subscription, 0, len(live)+len(archived))
+	for _, sub := range live {
+		filter.SubscriptionIDs = append(filter.SubscriptionIDs, sub.ID)
+		all = append(all, sub)
+	}
+	if len(filter.SubscriptionIDs) > 0 {
+		if err = s.billRepository.Each(ctx, filter, func(bill *models.Bill) error {
+			bills = append(bills, bill.ToResponse())
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	for _, sub := range archived {
+		all = append(all, &sub.Subscription)
+		for _, bill := range sub.Bills {
+			bills = append(bills, bill.ToResponse())
+		}
+	}
+	for _, sub := range all {
+		subscriptions = append(subscriptions, sub.ToResponse())
+		events, err := s.lifecycleEventRepository.GetBySubscriptionID(ctx, sub.ID)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			timeline = append(timeline, event.ToResponse())
+		}
+	}
+
+	archive := zip.NewWriter(w)
+	for _, entry := range []struct {
+		name    string
+		content any
+	}{
+		{"profile.json", user.ToResponse()},
+		{"subscriptions.json", subscriptions},
+		{"bills.json", bills},
+		{"timeline.json", timeline},
+	} {
+		f, err := archive.Create(entry.name)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(entry.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxSheet is the sheet XLSX exports are written to.
+const xlsxSheet = "Sheet1"
+
+// exportAmount is a money cell: a decimal string in CSV and a number shown
+// with the currency's minor units in XLSX.
+type exportAmount struct {
+	amount   int64
+	currency models.Currency
+}
+
+// decimal formats the amount in major units, e.g. "15.49" or "-3".
+func (a exportAmount) decimal() string {
+	units := a.currency.MinorUnits()
+	abs := a.amount
+	sign := ""
+	if abs < 0 {
+		abs, sign = -abs, "-"
+	}
+	if units == 0 {
+		return fmt.Sprintf("%s%d", sign, abs)
+	}
+	scale := int64(math.Pow10(units))
+	return fmt.Sprintf("%s%d.%0*d", sign, abs/scale, units, abs%scale)
+}
+
+// exportWriter writes the rows of an export in one file format. Cells are
+// strings, times, or exportAmounts; a nil *time.Time is an empty cell.
+type exportWriter interface {
+	WriteRow(cells ...any) error
+	// Flush writes out buffered rows. XLSX files are written out as a whole,
+	// so nothing reaches the underlying writer before then.
+	Flush() error
+	// Close releases the resources of the writer.
+	Close() error
+}
+
+// newExportWriter returns a writer of format writing to w.
+func newExportWriter(format models.ExportFormat, w io.Writer) (exportWriter, error) {
+	if format == models.XLSXFormat {
+		return newXLSXExportWriter(w)
+	}
+	return &csvExportWriter{csv.NewWriter(w)}, nil
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (x *csvExportWriter) WriteRow(cells ...any) error {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		switch v := cell.(type) {
+		case string:
+			record[i] = escapeFormula(v)
+		case time.Time:
+			record[i] = v.UTC().Format(time.RFC3339)
+		case *time.Time:
+			if v != nil {
+				record[i] = v.UTC().Format(time.RFC3339)
+			}
+		case exportAmount:
+			record[i] = v.decimal()
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return x.w.Write(record)
+}
+
+func (x *csvExportWriter) Flush() error {
+	x.w.Flush()
+	return x.w.Error()
+}
+
+func (x *csvExportWriter) Close() error {
+	return nil
+}
+
+// escapeFormula prefixes values that spreadsheet apps would evaluate as a
+// formula with a quote, so a subscription named "=HYPERLINK(...)" stays
+// text when the CSV is opened.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+type xlsxExportWriter struct {
+	out          io.Writer
+	file         *excelize.File
+	stream       *excelize.StreamWriter
+	rows         int
+	timeStyle    int
+	amountStyles map[int]int // Style IDs by number of minor units.
+}
+
+func newXLSXExportWriter(out io.Writer) (*xlsxExportWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to create XLSX stream writer: %w", err)
+	}
+	timeFormat := "yyyy-mm-dd hh:mm"
+	timeStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &timeFormat})
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to create XLSX time style: %w", err)
+	}
+	return &xlsxExportWriter{
+		out:          out,
+		file:         file,
+		stream:       stream,
+		timeStyle:    timeStyle,
+		amountStyles: make(map[int]int),
+	}, nil
+}
+
+func (x *xlsxExportWriter) WriteRow(cells ...any) error {
+	values := make([]any, len(cells))
+	for i, cell := range cells {
+		switch v := cell.(type) {
+		case time.Time:
+			values[i] = excelize.Cell{StyleID: x.timeStyle, Value: v.UTC()}
+		case *time.Time:
+			if v != nil {
+				values[i] = excelize.Cell{StyleID: x.timeStyle, Value: v.UTC()}
+			}
+		case exportAmount:
+			style, err := x.amountStyle(v.currency.MinorUnits())
+			if err != nil {
+				return err
+			}
+			values[i] = excelize.Cell{StyleID: style, Value: float64(v.amount) / math.Pow10(v.currency.MinorUnits())}
+		default:
+			values[i] = v
+		}
+	}
+
+	x.rows++
+	cell, err := excelize.CoordinatesToCellName(1, x.rows)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, values)
+}
+
+// amountStyle returns the style showing amounts with units decimal places.
+func (x *xlsxExportWriter) amountStyle(units int) (int, error) {
+	if style, ok := x.amountStyles[units]; ok {
+		return style, nil
+	}
+	format := "0"
+	if units > 0 {
+		format += "." + strings.Repeat("0", units)
+	}
+	style, err := x.file.NewStyle(&excelize.Style{CustomNumFmt: &format})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create XLSX amount style: %w", err)
+	}
+	x.amountStyles[units] = style
+	return style, nil
+}
+
+func (x *xlsxExportWriter) Flush() error {
+	if err := x.stream.Flush(); err != nil {
+		return fmt.Errorf("failed to flush XLSX rows: %w", err)
+	}
+	if err := x.file.Write(x.out); err != nil {
+		return fmt.Errorf("failed to write XLSX file: %w", err)
+	}
+	return nil
+}
+
+// Close removes the temporary files the stream writer keeps for large
+// sheets.
+func (x *xlsxExportWriter) Close() error {
+	return x.file.Close()
+}
@@ -0,0 +1,349 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var exportCfg = services.ExportConfig{MaxRows: 100, LinkExpiry: 72 * time.Hour}
+
+type exportMocks struct {
+	userRepo      *repomocks.MockUserRepository
+	subRepo       *repomocks.MockSubscriptionRepository
+	billRepo      *repomocks.MockBillRepository
+	archiveRepo   *repomocks.MockArchiveRepository
+	lifecycleRepo *repomocks.MockLifecycleEventRepository
+	fileService   *mocks.MockFileServiceInternal
+	queue         *mocks.MockExportQueue
+}
+
+func setupExportService(t *testing.T) (services.ExportService, exportMocks) {
+	t.Helper()
+
+	m := exportMocks{
+		userRepo:      repomocks.NewMockUserRepository(t),
+		subRepo:       repomocks.NewMockSubscriptionRepository(t),
+		billRepo:      repomocks.NewMockBillRepository(t),
+		archiveRepo:   repomocks.NewMockArchiveRepository(t),
+		lifecycleRepo: repomocks.NewMockLifecycleEventRepository(t),
+		fileService:   mocks.NewMockFileServiceInternal(t),
+		queue:         mocks.NewMockExportQueue(t),
+	}
+	svc := services.NewExportService(
+		m.userRepo, m.subRepo, m.billRepo, m.archiveRepo, m.lifecycleRepo,
+		m.fileService, m.queue, exportCfg, func() time.Time { return mockTime },
+	)
+	return svc, m
+}
+
+// readAll reads and closes the body of a streamed export.
+func readAll(t *testing.T, export *models.Export) []byte {
+	t.Helper()
+
+	require.NotNil(t, export.Body)
+	defer export.Body.Close()
+	content, err := io.ReadAll(export.Body)
+	require.NoError(t, err)
+	return content
+}
+
+// ---------------------------------------------------------------------------
+// Export
+// ---------------------------------------------------------------------------
+
+func TestExportService_Export_SubscriptionsCSV(t *testing.T) {
+	svc, m := setupExportService(t)
+	subs := validSubs()
+	subs[1].Name = "=HYPERLINK(\"https://evil.example\")"
+	subs[1].Currency = models.Currency("JPY")
+	subs[1].Price = 1200
+
+	query := &models.SubscriptionQuery{UserID: defaultUserID, Status: models.Active}
+	m.subRepo.EXPECT().Count(mock.Anything, query).Return(2, nil).Once()
+	m.subRepo.EXPECT().Each(mock.Anything, query, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *models.SubscriptionQuery, fn func(*models.Subscription) error) error {
+			for _, sub := range subs {
+				if err := fn(sub); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).
+		Once()
+
+	export, err := svc.Export(t.Context(), defaultUserHex, models.ExportRequest{
+		Kind:   models.SubscriptionExport,
+		Format: models.CSVFormat,
+		Status: models.Active,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "subscriptions-2025-01-15.csv", export.Filename)
+	assert.Equal(t, "text/csv; charset=utf-8", export.ContentType)
+	assert.EqualValues(t, 2, export.Rows)
+
+	records, err := csv.NewReader(bytes.NewReader(readAll(t, export))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{
+		"id", "name", "category", "status", "frequency", "price", "currency",
+		"validTill", "trialEndsAt", "lastUsedAt", "createdAt",
+	}, records[0])
+	assert.Equal(t, []string{
+		defaultSubHex, "Netflix", "entertainment", "active", "monthly", "9.99", "USD",
+		mockOneMonthLater.Format(time.RFC3339), "", "", "2025-01-15T12:00:00Z",
+	}, records[1])
+	assert.Equal(t, `'=HYPERLINK("https://evil.example")`, records[2][1], "formulas are escaped")
+	assert.Equal(t, "1200", records[2][5], "JPY has no minor units")
+}
+
+func TestExportService_Export_BillsXLSX(t *testing.T) {
+	svc, m := setupExportService(t)
+	bill := validBill()
+
+	m.subRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return([]*models.Subscription{validSub()}, nil).Once()
+	filter := &models.BillFilter{SubscriptionIDs: []bson.ObjectID{defaultSubID}, Status: models.Paid}
+	m.billRepo.EXPECT().Count(mock.Anything, filter).Return(1, nil).Once()
+	m.billRepo.EXPECT().Each(mock.Anything, filter, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *models.BillFilter, fn func(*models.Bill) error) error {
+			return fn(bill)
+		}).
+		Once()
+
+	export, err := svc.Export(t.Context(), defaultUserHex, models.ExportRequest{
+		Kind:       models.BillExport,
+		Format:     models.XLSXFormat,
+		BillStatus: models.Paid,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "bills-2025-01-15.xlsx", export.Filename)
+
+	file, err := excelize.OpenReader(bytes.NewReader(readAll(t, export)))
+	require.NoError(t, err)
+	defer file.Close()
+	rows, err := file.GetRows("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "subscriptionName", rows[0][2])
+	assert.Equal(t, bill.ID.Hex(), rows[1][0])
+	assert.Equal(t, "Netflix", rows[1][2])
+	assert.Equal(t, "9.99", rows[1][4])
+}
+
+func TestExportService_Export_QueuesLargeExports(t *testing.T) {
+	svc, m := setupExportService(t)
+
+	want := models.ExportRequest{UserID: defaultUserID, Kind: models.SubscriptionExport, Format: models.XLSXFormat}
+	m.subRepo.EXPECT().Count(mock.Anything, want.SubscriptionQuery()).Return(int64(exportCfg.MaxRows)+1, nil).Once()
+	m.queue.EXPECT().EnqueueExport(mock.Anything, want).Return(nil).Once()
+
+	export, err := svc.Export(t.Context(), defaultUserHex, models.ExportRequest{
+		Kind:   models.SubscriptionExport,
+		Format: models.XLSXFormat,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, export.Body)
+	assert.EqualValues(t, exportCfg.MaxRows+1, export.Rows)
+}
+
+func TestExportService_Export_Errors(t *testing.T) {
+	otherUsersSub := validSub()
+	otherUsersSub.UserID = bson.NewObjectID()
+
+	tests := []struct {
+		name       string
+		request    models.ExportRequest
+		setupMocks func(m exportMocks)
+		wantCode   apperror.ErrorCode
+	}{
+		{
+			name:       "unknown format",
+			request:    models.ExportRequest{Kind: models.SubscriptionExport, Format: "pdf"},
+			setupMocks: func(m exportMocks) {},
+			wantCode:   apperror.ErrValidation,
+		},
+		{
+			name:       "account exports are only queued",
+			request:    models.ExportRequest{Kind: models.AccountExport, Format: models.ZIPFormat},
+			setupMocks: func(m exportMocks) {},
+			wantCode:   apperror.ErrValidation,
+		},
+		{
+			name:       "invalid subscription filter",
+			request:    models.ExportRequest{Kind: models.SubscriptionExport, Format: models.CSVFormat, Status: "gone"},
+			setupMocks: func(m exportMocks) {},
+			wantCode:   apperror.ErrValidation,
+		},
+		{
+			name: "bills of another user's subscription",
+			request: models.ExportRequest{
+				Kind:           models.BillExport,
+				Format:         models.CSVFormat,
+				SubscriptionID: defaultSubID,
+			},
+			setupMocks: func(m exportMocks) {
+				m.subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(otherUsersSub, nil).Once()
+			},
+			wantCode: apperror.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := setupExportService(t)
+			tt.setupMocks(m)
+
+			_, err := svc.Export(t.Context(), defaultUserHex, tt.request)
+			appErr, ok := errors.AsType[apperror.AppError](err)
+			require.True(t, ok, "expected an AppError, got %v", err)
+			assert.Equal(t, tt.wantCode, appErr.Code())
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GenerateExportInternal
+// ---------------------------------------------------------------------------
+
+func TestExportService_GenerateExportInternal(t *testing.T) {
+	svc, m := setupExportService(t)
+	request := models.ExportRequest{UserID: defaultUserID, Kind: models.SubscriptionExport, Format: models.CSVFormat}
+	stored := &models.StoredFile{ID: bson.NewObjectID(), Filename: "subscriptions-2025-01-15.csv"}
+	signed := &models.SignedFile{File: stored, URL: "https://files.example/download"}
+
+	m.subRepo.EXPECT().Each(mock.Anything, request.SubscriptionQuery(), mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *models.SubscriptionQuery, fn func(*models.Subscription) error) error {
+			return fn(validSub())
+		}).
+		Once()
+	m.fileService.EXPECT().
+		StoreFileInternal(mock.Anything, "subscriptions-2025-01-15.csv", mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, metadata models.FileMetadata, content io.Reader) (*models.StoredFile, error) {
+			assert.Equal(t, "export/"+defaultUserHex+"/subscriptions.csv", metadata.Key)
+			assert.Equal(t, defaultUserID, metadata.OwnerID)
+			body, err := io.ReadAll(content)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), defaultSubHex+",Netflix,")
+			return stored, nil
+		}).
+		Once()
+	m.fileService.EXPECT().SignFileForInternal(stored, exportCfg.LinkExpiry).Return(signed).Once()
+
+	got, err := svc.GenerateExportInternal(t.Context(), request)
+	require.NoError(t, err)
+	assert.Equal(t, signed, got)
+}
+
+func TestExportService_GenerateExportInternal_Account(t *testing.T) {
+	svc, m := setupExportService(t)
+	request := models.ExportRequest{UserID: defaultUserID, Kind: models.AccountExport, Format: models.ZIPFormat}
+	stored := &models.StoredFile{ID: bson.NewObjectID(), Filename: "account-2025-01-15.zip"}
+	signed := &models.SignedFile{File: stored, URL: "https://files.example/download"}
+
+	archived := &models.ArchivedSubscription{Subscription: *validSub(), Bills: []*models.Bill{validBill()}}
+	archived.ID = bson.NewObjectID()
+	archived.Name = "Hulu"
+	event := models.NewLifecycleEvent(validSub(), models.LifecycleReminderSent, models.ActorSystem, "Reminder sent", mockTime)
+
+	m.userRepo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(validUser(), nil).Once()
+	m.subRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return([]*models.Subscription{validSub()}, nil).Once()
+	m.archiveRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return([]*models.ArchivedSubscription{archived}, nil).Once()
+	m.billRepo.EXPECT().Each(mock.Anything, &models.BillFilter{SubscriptionIDs: []bson.ObjectID{defaultSubID}}, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *models.BillFilter, fn func(*models.Bill) error) error {
+			return fn(validBill())
+		}).
+		Once()
+	m.lifecycleRepo.EXPECT().GetBySubscriptionID(mock.Anything, defaultSubID).Return([]*models.LifecycleEvent{event}, nil).Once()
+	m.lifecycleRepo.EXPECT().GetBySubscriptionID(mock.Anything, archived.ID).Return(nil, nil).Once()
+
+	var content []byte
+	m.fileService.EXPECT().
+		StoreFileInternal(mock.Anything, "account-2025-01-15.zip", mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, metadata models.FileMetadata, body io.Reader) (*models.StoredFile, error) {
+			assert.Equal(t, "export/"+defaultUserHex+"/account.zip", metadata.Key)
+			assert.Equal(t, "application/zip", metadata.ContentType)
+			var err error
+			content, err = io.ReadAll(body)
+			require.NoError(t, err)
+			return stored, nil
+		}).
+		Once()
+	m.fileService.EXPECT().SignFileForInternal(stored, exportCfg.LinkExpiry).Return(signed).Once()
+
+	got, err := svc.GenerateExportInternal(t.Context(), request)
+	require.NoError(t, err)
+	assert.Equal(t, signed, got)
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	files := map[string][]byte{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+	}
+	require.Len(t, files, 4)
+
+	var profile models.UserResponse
+	require.NoError(t, json.Unmarshal(files["profile.json"], &profile))
+	assert.Equal(t, defaultUserHex, profile.ID)
+
+	var subscriptions []models.SubscriptionResponse
+	require.NoError(t, json.Unmarshal(files["subscriptions.json"], &subscriptions))
+	require.Len(t, subscriptions, 2)
+	assert.Equal(t, "Netflix", subscriptions[0].Name)
+	assert.Equal(t, "Hulu", subscriptions[1].Name)
+
+	var bills []models.BillResponse
+	require.NoError(t, json.Unmarshal(files["bills.json"], &bills))
+	assert.Len(t, bills, 2)
+
+	var timeline []models.LifecycleEventResponse
+	require.NoError(t, json.Unmarshal(files["timeline.json"], &timeline))
+	require.Len(t, timeline, 1)
+	assert.Equal(t, string(models.LifecycleReminderSent), timeline[0].Type)
+}
+
+// ---------------------------------------------------------------------------
+// ExportAccount
+// ---------------------------------------------------------------------------
+
+func TestExportService_ExportAccount(t *testing.T) {
+	svc, m := setupExportService(t)
+	request := models.ExportRequest{UserID: defaultUserID, Kind: models.AccountExport, Format: models.ZIPFormat}
+	m.queue.EXPECT().EnqueueExport(mock.Anything, request).Return(nil).Once()
+
+	got, err := svc.ExportAccount(t.Context(), defaultUserHex, defaultUserHex)
+	require.NoError(t, err)
+	assert.Nil(t, got.Body)
+	assert.Equal(t, request, got.Request)
+	assert.Equal(t, "account-2025-01-15.zip", got.Filename)
+}
+
+func TestExportService_ExportAccount_OtherUser(t *testing.T) {
+	svc, _ := setupExportService(t)
+
+	_, err := svc.ExportAccount(t.Context(), bson.NewObjectID().Hex(), defaultUserHex)
+	appErr, ok := errors.AsType[apperror.AppError](err)
+	require.True(t, ok, "expected an AppError, got %v", err)
+	assert.Equal(t, apperror.ErrForbidden, appErr.Code())
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type FileServiceExternal interface {
+	GetFileByID(context.Context, string, string) (*models.SignedFile, error)
+	DeleteFile(context.Context, string, string) error
+	OpenSignedFile(ctx context.Context, id, expires, signature string) (*models.StoredFile, io.ReadCloser, error)
+}
+
+type FileServiceInternal interface {
+	StoreFileInternal(ctx context.Context, filename string, metadata models.FileMetadata, content io.Reader) (*models.StoredFile, error)
+	FetchFileByKeyInternal(context.Context, string) (*models.StoredFile, error)
+	OpenFileInternal(context.Context, bson.ObjectID) (io.ReadCloser, error)
+	SignFileInternal(*models.StoredFile) *models.SignedFile
+	// SignFileForInternal builds a download URL valid for expiry, for links
+	// that are not opened right away, such as emailed ones.
+	SignFileForInternal(file *models.StoredFile, expiry time.Duration) *models.SignedFile
+	DeleteFileInternal(context.Context, bson.ObjectID) error
+	// DeleteFilesInternal deletes every file the user owns, uploaded or
+	// generated, and returns how many were deleted.
+	DeleteFilesInternal(ctx context.Context, ownerID bson.ObjectID) (int64, error)
+}
+
+type FileService interface {
+	FileServiceExternal
+	FileServiceInternal
+}
+
+// FileConfig holds the settings for signed file download URLs.
+type FileConfig struct {
+	SigningSecret string        `mapstructure:"signing_secret"`
+	URLExpiry     time.Duration `mapstructure:"url_expiry"` // Lifetime of a signed download URL.
+	BaseURL       string        `mapstructure:"base_url"`   // Public origin prepended to download paths; empty yields relative URLs.
+}
+
+type fileService struct {
+	fileRepository repositories.FileRepository
+	config         FileConfig
+	getTime        clock.NowFn
+}
+
+// NewFileService creates a new instance of FileService.
+func NewFileService(
+	fileRepository repositories.FileRepository,
+	config FileConfig,
+	nowFn clock.NowFn,
+) FileService {
+	return &fileService{
+		fileRepository,
+		config,
+		nowFn,
+	}
+}
+
+// GetFileByID returns a file owned by the caller together with a fresh
+// signed download URL.
+func (s *fileService) GetFileByID(ctx context.Context, id string, claimedUserID string) (*models.SignedFile, error) {
+	file, err := s.fetchOwnedFile(ctx, id, claimedUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.SignFileInternal(file), nil
+}
+
+// DeleteFile removes a file owned by the caller.
+func (s *fileService) DeleteFile(ctx context.Context, id string, claimedUserID string) error {
+	file, err := s.fetchOwnedFile(ctx, id, claimedUserID)
+	if err != nil {
+		return err
+	}
+	if err = s.fileRepository.Delete(ctx, file.ID); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "File deleted", logattr.FileID(file.ID.Hex()))
+	return nil
+}
+
+func (s *fileService) DeleteFileInternal(ctx context.Context, id bson.ObjectID) error {
+	if err := s.fileRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "File deleted", logattr.FileID(id.Hex()))
+	return nil
+}
+
+func (s *fileService) DeleteFilesInternal(ctx context.Context, ownerID bson.ObjectID) (int64, error) {
+	return s.fileRepository.DeleteByOwnerID(ctx, ownerID)
+}
+
+// OpenSignedFile verifies a signed download URL and opens the file content.
+// The signature stands in for authentication, so any mismatch or expiry is
+// reported as unauthorized without revealing whether the file exists.
+func (s *fileService) OpenSignedFile(ctx context.Context, id, expires, signature string) (*models.StoredFile, io.ReadCloser, error) {
+	fileID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil, apperror.NewBadRequestError("Invalid file ID")
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return nil, nil, apperror.NewUnauthorizedError("Invalid download link")
+	}
+	given, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(given, s.sign(fileID, expiresAt)) {
+		return nil, nil, apperror.NewUnauthorizedError("Invalid download link")
+	}
+	if s.getTime().Unix() > expiresAt {
+		return nil, nil, apperror.NewUnauthorizedError("Download link expired")
+	}
+
+	file, err := s.fileRepository.GetByID(ctx, fileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := s.fileRepository.Open(ctx, fileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, content, nil
+}
+
+// StoreFileInternal uploads content under metadata.Key and removes any
+// previous version stored under the same key.
+func (s *fileService) StoreFileInternal(
+	ctx context.Context,
+	filename string,
+	metadata models.FileMetadata,
+	content io.Reader,
+) (*models.StoredFile, error) {
+	previous, err := s.FetchFileByKeyInternal(ctx, metadata.Key)
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); !ok || appErr.Code() != apperror.ErrNotFound {
+			return nil, err
+		}
+	}
+
+	file, err := s.fileRepository.Upload(ctx, filename, metadata, content)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "File stored",
+		logattr.FileID(file.ID.Hex()),
+		logattr.Key(metadata.Key),
+	)
+
+	if previous != nil {
+		// The new version is already in place; a leftover old version only
+		// costs storage, so don't fail the caller over it.
+		if err = s.fileRepository.Delete(ctx, previous.ID); err != nil {
+			slog.WarnContext(ctx, "Failed to delete replaced file",
+				logattr.FileID(previous.ID.Hex()),
+				logattr.Key(metadata.Key),
+				logattr.Error(err),
+			)
+		}
+	}
+	return file, nil
+}
+
+func (s *fileService) FetchFileByKeyInternal(ctx context.Context, key string) (*models.StoredFile, error) {
+	return s.fileRepository.GetByKey(ctx, key)
+}
+
+func (s *fileService) OpenFileInternal(ctx context.Context, id bson.ObjectID) (io.ReadCloser, error) {
+	return s.fileRepository.Open(ctx, id)
+}
+
+// SignFileInternal builds a download URL for file that is valid for the
+// configured expiry.
+func (s *fileService) SignFileInternal(file *models.StoredFile) *models.SignedFile {
+	return s.SignFileForInternal(file, s.config.URLExpiry)
+}
+
+func (s *fileService) SignFileForInternal(file *models.StoredFile, expiry time.Duration) *models.SignedFile {
+	expiresAt := s.getTime().Add(expiry).Truncate(time.Second)
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", hex.EncodeToString(s.sign(file.ID, expiresAt.Unix())))
+
+	return &models.SignedFile{
+		File:      file,
+		URL:       fmt.Sprintf("%s/api/v1/files/%s/download?%s", s.config.BaseURL, file.ID.Hex(), query.Encode()),
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (s *fileService) sign(id bson.ObjectID, expiresAt int64) []byte {
+	mac := hmac.New(sha256.New, []byte(s.config.SigningSecret))
+	fmt.Fprintf(mac, "%s.%d", id.Hex(), expiresAt)
+	return mac.Sum(nil)
+}
+
+func (s *fileService) fetchOwnedFile(ctx context.Context, id string, claimedUserID string) (*models.StoredFile, error) {
+	fileID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid file ID")
+	}
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	file, err := s.fileRepository.GetByID(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if file.Metadata.OwnerID != userID {
+		return nil, apperror.NewForbiddenError("You are not allowed to access this file")
+	}
+	return file, nil
+}
@@ -0,0 +1,392 @@
+package services_test
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var defaultFileID = bson.NewObjectID()
+
+// validFile returns a stored invoice owned by the default user.
+func validFile() *models.StoredFile {
+	return &models.StoredFile{
+		ID:         defaultFileID,
+		Filename:   "invoice.pdf",
+		Size:       5,
+		UploadedAt: mockTime,
+		Metadata: models.FileMetadata{
+			Key:         "invoice/123",
+			OwnerID:     defaultUserID,
+			ContentType: "application/pdf",
+		},
+	}
+}
+
+// newFileService wires a fileService whose clock is fixed at now.
+func newFileService(repo *repomocks.MockFileRepository, now time.Time) services.FileService {
+	return services.NewFileService(repo, services.FileConfig{
+		SigningSecret: "test-secret",
+		URLExpiry:     15 * time.Minute,
+		BaseURL:       "https://api.example.com",
+	}, func() time.Time { return now })
+}
+
+// signedQuery signs validFile at mockTime and returns the URL query values.
+func signedQuery(t *testing.T) url.Values {
+	t.Helper()
+
+	signed := newFileService(nil, mockTime).SignFileInternal(validFile())
+	u, err := url.Parse(signed.URL)
+	require.NoError(t, err)
+	return u.Query()
+}
+
+func assertAppErr(t *testing.T, err error, code apperror.ErrorCode) {
+	t.Helper()
+
+	require.Error(t, err)
+	appErr, ok := errors.AsType[apperror.AppError](err)
+	require.True(t, ok, "expected AppError, got %v", err)
+	assert.Equal(t, code, appErr.Code())
+}
+
+// ---------------------------------------------------------------------------
+// SignFileInternal
+// ---------------------------------------------------------------------------
+
+func Test_fileService_SignFileInternal(t *testing.T) {
+	signed := newFileService(nil, mockTime).SignFileInternal(validFile())
+
+	assert.True(t, strings.HasPrefix(signed.URL,
+		"https://api.example.com/api/v1/files/"+defaultFileID.Hex()+"/download?"))
+	assert.Equal(t, mockTime.Add(15*time.Minute), signed.ExpiresAt)
+	assert.Equal(t, validFile(), signed.File)
+}
+
+func Test_fileService_SignFileForInternal(t *testing.T) {
+	signed := newFileService(nil, mockTime).SignFileForInternal(validFile(), 72*time.Hour)
+	u, err := url.Parse(signed.URL)
+	require.NoError(t, err)
+
+	assert.Equal(t, mockTime.Add(72*time.Hour), signed.ExpiresAt)
+
+	// The link still opens after the configured expiry.
+	repo := repomocks.NewMockFileRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, defaultFileID).Return(validFile(), nil).Once()
+	repo.EXPECT().Open(mock.Anything, defaultFileID).Return(io.NopCloser(strings.NewReader("%PDF-")), nil).Once()
+	_, content, err := newFileService(repo, mockTime.Add(time.Hour)).
+		OpenSignedFile(t.Context(), defaultFileID.Hex(), u.Query().Get("expires"), u.Query().Get("signature"))
+	require.NoError(t, err)
+	_ = content.Close()
+}
+
+// ---------------------------------------------------------------------------
+// OpenSignedFile
+// ---------------------------------------------------------------------------
+
+func Test_fileService_OpenSignedFile(t *testing.T) {
+	valid := signedQuery(t)
+
+	tests := []struct {
+		name        string
+		id          string
+		expires     string
+		signature   string
+		now         time.Time
+		setupMocks  func(repo *repomocks.MockFileRepository)
+		wantErrCode apperror.ErrorCode
+	}{
+		{
+			// Happy path: signature matches and link has not expired.
+			name:      "success - valid signature opens content",
+			id:        defaultFileID.Hex(),
+			expires:   valid.Get("expires"),
+			signature: valid.Get("signature"),
+			now:       mockTime.Add(time.Minute),
+			setupMocks: func(repo *repomocks.MockFileRepository) {
+				repo.EXPECT().
+					GetByID(mock.Anything, defaultFileID).
+					Return(validFile(), nil).
+					Once()
+				repo.EXPECT().
+					Open(mock.Anything, defaultFileID).
+					Return(io.NopCloser(strings.NewReader("%PDF-")), nil).
+					Once()
+			},
+		},
+		{
+			// Expiry was extended by the caller, so the signature no longer matches.
+			name:        "error - tampered expiry",
+			id:          defaultFileID.Hex(),
+			expires:     "9999999999",
+			signature:   valid.Get("signature"),
+			now:         mockTime,
+			setupMocks:  func(_ *repomocks.MockFileRepository) {},
+			wantErrCode: apperror.ErrUnauthorized,
+		},
+		{
+			// Signature belongs to a different file.
+			name:        "error - signature for another file",
+			id:          bson.NewObjectID().Hex(),
+			expires:     valid.Get("expires"),
+			signature:   valid.Get("signature"),
+			now:         mockTime,
+			setupMocks:  func(_ *repomocks.MockFileRepository) {},
+			wantErrCode: apperror.ErrUnauthorized,
+		},
+		{
+			// Link used after its expiry.
+			name:        "error - expired link",
+			id:          defaultFileID.Hex(),
+			expires:     valid.Get("expires"),
+			signature:   valid.Get("signature"),
+			now:         mockTime.Add(time.Hour),
+			setupMocks:  func(_ *repomocks.MockFileRepository) {},
+			wantErrCode: apperror.ErrUnauthorized,
+		},
+		{
+			// File ID is not a valid hex string.
+			name:        "error - malformed file ID",
+			id:          "bad-hex",
+			now:         mockTime,
+			setupMocks:  func(_ *repomocks.MockFileRepository) {},
+			wantErrCode: apperror.ErrBadRequest,
+		},
+		{
+			// File was deleted after the link was issued.
+			name:      "error - file no longer exists",
+			id:        defaultFileID.Hex(),
+			expires:   valid.Get("expires"),
+			signature: valid.Get("signature"),
+			now:       mockTime,
+			setupMocks: func(repo *repomocks.MockFileRepository) {
+				repo.EXPECT().
+					GetByID(mock.Anything, defaultFileID).
+					Return(nil, apperror.NewNotFoundError("not found")).
+					Once()
+			},
+			wantErrCode: apperror.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repomocks.NewMockFileRepository(t)
+			tt.setupMocks(repo)
+
+			svc := newFileService(repo, tt.now)
+			file, content, err := svc.OpenSignedFile(t.Context(), tt.id, tt.expires, tt.signature)
+
+			if tt.wantErrCode != "" {
+				assertAppErr(t, err, tt.wantErrCode)
+				assert.Nil(t, file)
+				assert.Nil(t, content)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, validFile(), file)
+			body, err := io.ReadAll(content)
+			require.NoError(t, err)
+			assert.Equal(t, "%PDF-", string(body))
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GetFileByID
+// ---------------------------------------------------------------------------
+
+func Test_fileService_GetFileByID(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		claimedUserID string
+		setupMocks    func(repo *repomocks.MockFileRepository)
+		wantErrCode   apperror.ErrorCode
+	}{
+		{
+			// Happy path: owner receives metadata and a signed link.
+			name:          "success - owner gets signed file",
+			id:            defaultFileID.Hex(),
+			claimedUserID: defaultUserHex,
+			setupMocks: func(repo *repomocks.MockFileRepository) {
+				repo.EXPECT().
+					GetByID(mock.Anything, defaultFileID).
+					Return(validFile(), nil).
+					Once()
+			},
+		},
+		{
+			// File belongs to someone else.
+			name:          "error - forbidden (wrong owner)",
+			id:            defaultFileID.Hex(),
+			claimedUserID: bson.NewObjectID().Hex(),
+			setupMocks: func(repo *repomocks.MockFileRepository) {
+				repo.EXPECT().
+					GetByID(mock.Anything, defaultFileID).
+					Return(validFile(), nil).
+					Once()
+			},
+			wantErrCode: apperror.ErrForbidden,
+		},
+		{
+			// File ID is not a valid hex string.
+			name:          "error - malformed file ID",
+			id:            "bad-hex",
+			claimedUserID: defaultUserHex,
+			setupMocks:    func(_ *repomocks.MockFileRepository) {},
+			wantErrCode:   apperror.ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repomocks.NewMockFileRepository(t)
+			tt.setupMocks(repo)
+
+			got, err := newFileService(repo, mockTime).GetFileByID(t.Context(), tt.id, tt.claimedUserID)
+
+			if tt.wantErrCode != "" {
+				assertAppErr(t, err, tt.wantErrCode)
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, validFile(), got.File)
+			assert.NotEmpty(t, got.URL)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// StoreFileInternal
+// ---------------------------------------------------------------------------
+
+func Test_fileService_StoreFileInternal(t *testing.T) {
+	previous := validFile()
+	previous.ID = bson.NewObjectID()
+
+	tests := []struct {
+		name        string
+		setupMocks  func(repo *repomocks.MockFileRepository)
+		wantErrCode apperror.ErrorCode
+	}{
+		{
+			// First version of the artifact.
+			name: "success - stores new key",
+			setupMocks: func(repo *repomocks.MockFileRepository) {
+				repo.EXPECT().
+					GetByKey(mock.Anything, "invoice/123").
+					Return(nil, apperror.NewNotFoundError("not found")).
+					Once()
+				repo.EXPECT().
+					Upload(mock.Anything, "invoice.pdf", validFile().Metadata, mock.Anything).
+					Return(validFile(), nil).
+					Once()
+			},
+		},
+		{
+			// Regenerated artifact replaces the previous version.
+			name: "success - replaces previous version",
+			setupMocks: func(repo *repomocks.MockFileRepository) {
+				repo.EXPECT().
+					GetByKey(mock.Anything, "invoice/123").
+					Return(previous, nil).
+					Once()
+				repo.EXPECT().
+					Upload(mock.Anything, "invoice.pdf", validFile().Metadata, mock.Anything).
+					Return(validFile(), nil).
+					Once()
+				repo.EXPECT().
+					Delete(mock.Anything, previous.ID).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			// Cleanup of the old version fails; the new version is still returned.
+			name: "success - tolerates failed cleanup of previous version",
+			setupMocks: func(repo *repomocks.MockFileRepository) {
+				repo.EXPECT().
+					GetByKey(mock.Anything, "invoice/123").
+					Return(previous, nil).
+					Once()
+				repo.EXPECT().
+					Upload(mock.Anything, "invoice.pdf", validFile().Metadata, mock.Anything).
+					Return(validFile(), nil).
+					Once()
+				repo.EXPECT().
+					Delete(mock.Anything, previous.ID).
+					Return(apperror.NewDBError(errors.New("delete failed"))).
+					Once()
+			},
+		},
+		{
+			// Upload fails; the previous version must be kept.
+			name: "error - upload fails",
+			setupMocks: func(repo *repomocks.MockFileRepository) {
+				repo.EXPECT().
+					GetByKey(mock.Anything, "invoice/123").
+					Return(previous, nil).
+					Once()
+				repo.EXPECT().
+					Upload(mock.Anything, "invoice.pdf", validFile().Metadata, mock.Anything).
+					Return(nil, apperror.NewDBError(errors.New("upload failed"))).
+					Once()
+			},
+			wantErrCode: apperror.ErrDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repomocks.NewMockFileRepository(t)
+			tt.setupMocks(repo)
+
+			got, err := newFileService(repo, mockTime).StoreFileInternal(
+				t.Context(),
+				"invoice.pdf",
+				validFile().Metadata,
+				strings.NewReader("%PDF-"),
+			)
+
+			if tt.wantErrCode != "" {
+				assertAppErr(t, err, tt.wantErrCode)
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, validFile(), got)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// DeleteFilesInternal
+// ---------------------------------------------------------------------------
+
+func Test_fileService_DeleteFilesInternal(t *testing.T) {
+	repo := repomocks.NewMockFileRepository(t)
+	repo.EXPECT().DeleteByOwnerID(mock.Anything, defaultUserID).Return(int64(4), nil).Once()
+
+	deleted, err := newFileService(repo, mockTime).DeleteFilesInternal(t.Context(), defaultUserID)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), deleted)
+}
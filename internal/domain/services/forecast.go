@@ -0,0 +1,372 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/currency"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	// MaxForecastMonths caps how far ahead a forecast can look.
+	MaxForecastMonths = 36
+
+	forecastCachePrefix = "forecast:"
+	forecastMonthLayout = "2006-01"
+)
+
+// ForecastConfig holds the settings for spending forecasts.
+type ForecastConfig struct {
+	CacheTTL time.Duration `mapstructure:"cache_ttl"` // How long a computed forecast is reused; 0 disables caching.
+}
+
+// ForecastService projects future spend from a user's active subscriptions.
+type ForecastService interface {
+	// GetForecast returns the projected spend of the user over the next months,
+	// starting with the current calendar month. When target is set, totals are
+	// also converted into it.
+	GetForecast(
+		ctx context.Context,
+		claimedUserID string,
+		months int,
+		target models.Currency,
+	) (*models.ForecastResponse, error)
+	// GetRenewalCalendar returns the renewals of the user in the given month,
+	// formatted as YYYY-MM, grouped by day. An empty month means the current
+	// one.
+	GetRenewalCalendar(ctx context.Context, claimedUserID string, month string) (*models.RenewalCalendarResponse, error)
+}
+
+type forecastService struct {
+	subscriptionRepository repositories.SubscriptionRepository
+	exchangeRates          currency.Service
+	redisClient            redis.UniversalClient
+	config                 ForecastConfig
+	getTime                clock.NowFn
+}
+
+// NewForecastService creates a new instance of ForecastService.
+func NewForecastService(
+	subscriptionRepository repositories.SubscriptionRepository,
+	exchangeRates currency.Service,
+	redisClient redis.UniversalClient,
+	config ForecastConfig,
+	nowFn clock.NowFn,
+) ForecastService {
+	return &forecastService{
+		subscriptionRepository,
+		exchangeRates,
+		redisClient,
+		config,
+		nowFn,
+	}
+}
+
+// ForecastCacheKey returns the Redis key caching the forecast of a user over
+// the given number of months, converted into target if set.
+func ForecastCacheKey(userID bson.ObjectID, months int, target models.Currency) string {
+	key := fmt.Sprintf("%s%s:%d", forecastCachePrefix, userID.Hex(), months)
+	if target != "" {
+		key += ":" + string(target)
+	}
+	return key
+}
+
+// GetForecast returns the projected spend of the user over the next months.
+// Results are cached per user for the configured TTL, so subscription changes
+// show up once the cached forecast expires.
+func (s *forecastService) GetForecast(
+	ctx context.Context,
+	claimedUserID string,
+	months int,
+	target models.Currency,
+) (*models.ForecastResponse, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	if months < 1 || months > MaxForecastMonths {
+		return nil, apperror.NewValidationError(
+			fmt.Sprintf("months must be between 1 and %d", MaxForecastMonths))
+	}
+	if target != "" && !target.Valid() {
+		return nil, apperror.NewValidationError("unsupported currency %s", target)
+	}
+
+	key := ForecastCacheKey(userID, months, target)
+	if forecast := s.cached(ctx, key); forecast != nil {
+		return forecast, nil
+	}
+
+	subscriptions, err := s.subscriptionRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.getTime()
+	forecast := buildForecast(subscriptions, months, now)
+	if target != "" {
+		if err = s.convert(ctx, forecast, target, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.config.CacheTTL > 0 {
+		if data, err := json.Marshal(forecast); err == nil {
+			if err = s.redisClient.Set(ctx, key, data, s.config.CacheTTL).Err(); err != nil {
+				slog.WarnContext(ctx, "Failed to cache forecast",
+					logattr.Key(key),
+					logattr.Error(err))
+			}
+		}
+	}
+	return forecast, nil
+}
+
+// GetRenewalCalendar returns the renewals of the user in the given month. A
+// single range query fetches every active subscription renewing before the
+// month ends; renewals before the month are then projected forward by their
+// frequency.
+func (s *forecastService) GetRenewalCalendar(
+	ctx context.Context,
+	claimedUserID string,
+	month string,
+) (*models.RenewalCalendarResponse, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	now := s.getTime()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if month != "" {
+		if start, err = time.ParseInLocation(forecastMonthLayout, month, now.Location()); err != nil {
+			return nil, apperror.NewValidationError("month must be formatted as YYYY-MM")
+		}
+	}
+	end := start.AddDate(0, 1, 0)
+
+	subscriptions, err := s.subscriptionRepository.GetActiveByUserIDRenewingBefore(ctx, userID, end)
+	if err != nil {
+		return nil, err
+	}
+	return buildCalendar(subscriptions, start, end), nil
+}
+
+// buildCalendar groups the renewals of the subscriptions between start and end
+// by day.
+func buildCalendar(subscriptions []*models.Subscription, start, end time.Time) *models.RenewalCalendarResponse {
+	renewals := projectRenewals(subscriptions, start, end)
+	calendar := &models.RenewalCalendarResponse{
+		Month:  start.Format(forecastMonthLayout),
+		Days:   []models.CalendarDay{},
+		Totals: renewalTotals(renewals),
+	}
+	for _, renewal := range renewals {
+		date := renewal.RenewsOn.In(start.Location()).Format(time.DateOnly)
+		if n := len(calendar.Days); n == 0 || calendar.Days[n-1].Date != date {
+			calendar.Days = append(calendar.Days, models.CalendarDay{Date: date})
+		}
+		day := &calendar.Days[len(calendar.Days)-1]
+		day.Renewals = append(day.Renewals, renewal)
+	}
+	for i := range calendar.Days {
+		calendar.Days[i].Totals = renewalTotals(calendar.Days[i].Renewals)
+	}
+	return calendar
+}
+
+// projectRenewals lists the renewals of the subscriptions between start and
+// end, oldest first, projecting each forward from its next renewal by its
+// billing frequency.
+func projectRenewals(subscriptions []*models.Subscription, start, end time.Time) []models.UpcomingRenewal {
+	renewals := []models.UpcomingRenewal{}
+	for _, sub := range subscriptions {
+		for renewal := sub.ValidTill; renewal.Before(end); {
+			if !renewal.Before(start) {
+				renewals = append(renewals, models.UpcomingRenewal{
+					SubscriptionID: sub.ID.Hex(),
+					Name:           sub.Name,
+					Amount:         sub.Price,
+					Currency:       string(sub.Currency),
+					RenewsOn:       renewal,
+				})
+			}
+
+			next := lib.CalcRenewalDate(renewal, sub.Frequency)
+			if !next.After(renewal) { // Unknown frequency; renews only once.
+				break
+			}
+			renewal = next
+		}
+	}
+	slices.SortStableFunc(renewals, func(a, b models.UpcomingRenewal) int {
+		return a.RenewsOn.Compare(b.RenewsOn)
+	})
+	return renewals
+}
+
+// renewalTotals sums the renewals per currency.
+func renewalTotals(renewals []models.UpcomingRenewal) []models.CurrencyAmount {
+	sums := make(map[models.Currency]int64)
+	for _, renewal := range renewals {
+		sums[models.Currency(renewal.Currency)] += renewal.Amount
+	}
+	return currencyAmounts(sums)
+}
+
+// convert adds the totals of the forecast converted into target at the latest
+// rates published on or before now.
+func (s *forecastService) convert(
+	ctx context.Context,
+	forecast *models.ForecastResponse,
+	target models.Currency,
+	now time.Time,
+) error {
+	rates, err := s.exchangeRates.RatesOn(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for i := range forecast.MonthlyBreakdown {
+		if forecast.MonthlyBreakdown[i].Converted, err = convertTotal(rates, forecast.MonthlyBreakdown[i].Totals, target); err != nil {
+			return err
+		}
+	}
+	if forecast.Converted, err = convertTotal(rates, forecast.Totals, target); err != nil {
+		return err
+	}
+	forecast.RatesDate = rates.Date.Format(time.DateOnly)
+	return nil
+}
+
+// cached returns the forecast stored under key, or nil when there is none.
+// Cache failures are logged and treated as a miss.
+func (s *forecastService) cached(ctx context.Context, key string) *models.ForecastResponse {
+	if s.config.CacheTTL <= 0 {
+		return nil
+	}
+
+	data, err := s.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			slog.WarnContext(ctx, "Failed to read cached forecast",
+				logattr.Key(key),
+				logattr.Error(err))
+		}
+		return nil
+	}
+
+	var forecast models.ForecastResponse
+	if err = json.Unmarshal(data, &forecast); err != nil {
+		slog.WarnContext(ctx, "Discarding malformed cached forecast",
+			logattr.Key(key),
+			logattr.Error(err))
+		return nil
+	}
+	return &forecast
+}
+
+// buildForecast projects the renewals of the active subscriptions over the
+// months starting with the one containing now. A renewal that is already due
+// but not yet processed counts towards the current month.
+func buildForecast(subscriptions []*models.Subscription, months int, now time.Time) *models.ForecastResponse {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	end := start.AddDate(0, months, 0)
+
+	monthly := make([]map[models.Currency]int64, months)
+	totals := make(map[models.Currency]int64)
+	forecast := &models.ForecastResponse{
+		Months:           months,
+		MonthlyBreakdown: make([]models.ForecastMonth, months),
+		UpcomingRenewals: []models.UpcomingRenewal{},
+		GeneratedAt:      now,
+	}
+
+	for _, sub := range subscriptions {
+		if sub.Status != models.Active || sub.ArchivedAt != nil {
+			continue
+		}
+		forecast.UpcomingRenewals = append(forecast.UpcomingRenewals, models.UpcomingRenewal{
+			SubscriptionID: sub.ID.Hex(),
+			Name:           sub.Name,
+			Amount:         sub.Price,
+			Currency:       string(sub.Currency),
+			RenewsOn:       sub.ValidTill,
+		})
+
+		for renewal := sub.ValidTill; renewal.Before(end); {
+			index := 0
+			if !renewal.Before(start) {
+				index = monthsBetween(start, renewal.In(now.Location()))
+			}
+			if monthly[index] == nil {
+				monthly[index] = make(map[models.Currency]int64)
+			}
+			monthly[index][sub.Currency] += sub.Price
+			totals[sub.Currency] += sub.Price
+
+			next := lib.CalcRenewalDate(renewal, sub.Frequency)
+			if !next.After(renewal) { // Unknown frequency; renews only once.
+				break
+			}
+			renewal = next
+		}
+	}
+
+	for i := range months {
+		forecast.MonthlyBreakdown[i] = models.ForecastMonth{
+			Month:  start.AddDate(0, i, 0).Format(forecastMonthLayout),
+			Totals: currencyAmounts(monthly[i]),
+		}
+	}
+	forecast.Totals = currencyAmounts(totals)
+	slices.SortStableFunc(forecast.UpcomingRenewals, func(a, b models.UpcomingRenewal) int {
+		return a.RenewsOn.Compare(b.RenewsOn)
+	})
+	return forecast
+}
+
+// monthsBetween returns the number of calendar months from start to t.
+func monthsBetween(start, t time.Time) int {
+	return (t.Year()-start.Year())*12 + int(t.Month()-start.Month())
+}
+
+// currencyAmounts flattens per-currency sums into a slice sorted by currency.
+// convertTotal converts amounts into target and adds them up.
+func convertTotal(rates *models.ExchangeRates, amounts []models.CurrencyAmount, target models.Currency) (*models.CurrencyAmount, error) {
+	var total int64
+	for _, a := range amounts {
+		converted, err := rates.Convert(a.Amount, models.Currency(a.Currency), target)
+		if err != nil {
+			return nil, err
+		}
+		total += converted
+	}
+	converted := models.NewCurrencyAmount(total, target)
+	return &converted, nil
+}
+
+func currencyAmounts(sums map[models.Currency]int64) []models.CurrencyAmount {
+	amounts := make([]models.CurrencyAmount, 0, len(sums))
+	for currency, amount := range sums {
+		amounts = append(amounts, models.NewCurrencyAmount(amount, currency))
+	}
+	slices.SortFunc(amounts, func(a, b models.CurrencyAmount) int {
+		return cmp.Compare(a.Currency, b.Currency)
+	})
+	return amounts
+}
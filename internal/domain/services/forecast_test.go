@@ -0,0 +1,257 @@
+package services_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	currencymocks "github.com/anuragthepathak/subscription-management/internal/currency/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupForecast(t *testing.T, cacheTTL time.Duration) (
+	services.ForecastService,
+	*repomocks.MockSubscriptionRepository,
+	*currencymocks.MockService,
+	*miniredis.Miniredis,
+) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	rates := currencymocks.NewMockService(t)
+	svc := services.NewForecastService(
+		subRepo,
+		rates,
+		rdb,
+		services.ForecastConfig{CacheTTL: cacheTTL},
+		func() time.Time { return mockTime },
+	)
+	return svc, subRepo, rates, mr
+}
+
+func TestForecastService_GetForecast(t *testing.T) {
+	monthly := validSub() // Renews 2025-02-15.
+	yearly := validSub()
+	yearly.Name = "Prime"
+	yearly.Price = 5000
+	yearly.Currency = models.EUR
+	yearly.Frequency = models.Yearly
+	yearly.ValidTill = mockToday.AddDate(0, 3, 0)
+	overdue := validSub()
+	overdue.Name = "Overdue"
+	overdue.Price = 100
+	overdue.ValidTill = mockToday.AddDate(0, -1, 0)
+	canceled := validSub()
+	canceled.Status = models.Canceled
+
+	svc, subRepo, _, _ := setupForecast(t, 0)
+	subRepo.EXPECT().
+		GetByUserID(mock.Anything, defaultUserID).
+		Return([]*models.Subscription{monthly, yearly, overdue, canceled}, nil).
+		Once()
+
+	got, err := svc.GetForecast(t.Context(), defaultUserID.Hex(), 6, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, got.Months)
+	require.Len(t, got.MonthlyBreakdown, 6)
+	assert.Equal(t, "2025-01", got.MonthlyBreakdown[0].Month)
+	assert.Equal(t, "2025-06", got.MonthlyBreakdown[5].Month)
+
+	// The overdue renewal counts towards the current month, as does its
+	// regular January renewal.
+	assert.Equal(t, []models.CurrencyAmount{models.NewCurrencyAmount(200, models.USD)}, got.MonthlyBreakdown[0].Totals)
+	assert.Equal(t, []models.CurrencyAmount{models.NewCurrencyAmount(1099, models.USD)}, got.MonthlyBreakdown[1].Totals)
+	assert.Equal(t, []models.CurrencyAmount{
+		models.NewCurrencyAmount(5000, models.EUR),
+		models.NewCurrencyAmount(1099, models.USD),
+	}, got.MonthlyBreakdown[3].Totals)
+	assert.Equal(t, []models.CurrencyAmount{
+		models.NewCurrencyAmount(5000, models.EUR),
+		models.NewCurrencyAmount(100*7+999*5, models.USD),
+	}, got.Totals)
+
+	require.Len(t, got.UpcomingRenewals, 3)
+	assert.Equal(t, "Overdue", got.UpcomingRenewals[0].Name)
+	assert.Equal(t, "Netflix", got.UpcomingRenewals[1].Name)
+	assert.Equal(t, "Prime", got.UpcomingRenewals[2].Name)
+}
+
+func TestForecastService_GetForecast_Converted(t *testing.T) {
+	usd := validSub()
+	eur := validSub()
+	eur.Currency = models.EUR
+	eur.Price = 1000
+
+	svc, subRepo, rates, mr := setupForecast(t, 10*time.Minute)
+	subRepo.EXPECT().
+		GetByUserID(mock.Anything, defaultUserID).
+		Return([]*models.Subscription{usd, eur}, nil).
+		Once()
+	rates.EXPECT().
+		RatesOn(mock.Anything, mockTime).
+		Return(&models.ExchangeRates{
+			Date:  mockToday.AddDate(0, 0, -1),
+			Base:  models.EUR,
+			Rates: map[models.Currency]float64{models.USD: 1.25, models.GBP: 0.8},
+		}, nil).
+		Once()
+
+	got, err := svc.GetForecast(t.Context(), defaultUserID.Hex(), 2, models.GBP)
+	require.NoError(t, err)
+
+	// 999 USD = 799.2 EUR = 639.36 GBP; 1000 EUR = 800 GBP.
+	require.NotNil(t, got.MonthlyBreakdown[1].Converted)
+	assert.Equal(t, models.NewCurrencyAmount(639+800, models.GBP), *got.MonthlyBreakdown[1].Converted)
+	require.NotNil(t, got.MonthlyBreakdown[0].Converted)
+	assert.Equal(t, models.NewCurrencyAmount(0, models.GBP), *got.MonthlyBreakdown[0].Converted)
+	require.NotNil(t, got.Converted)
+	assert.Equal(t, models.NewCurrencyAmount(639+800, models.GBP), *got.Converted)
+	assert.Equal(t, "2025-01-14", got.RatesDate)
+
+	// Converted forecasts are cached separately.
+	assert.True(t, mr.Exists(services.ForecastCacheKey(defaultUserID, 2, models.GBP)))
+	assert.False(t, mr.Exists(services.ForecastCacheKey(defaultUserID, 2, "")))
+}
+
+func TestForecastService_GetForecast_Cache(t *testing.T) {
+	svc, subRepo, _, mr := setupForecast(t, 10*time.Minute)
+	subRepo.EXPECT().
+		GetByUserID(mock.Anything, defaultUserID).
+		Return([]*models.Subscription{validSub()}, nil).
+		Once()
+
+	first, err := svc.GetForecast(t.Context(), defaultUserID.Hex(), 12, "")
+	require.NoError(t, err)
+
+	key := services.ForecastCacheKey(defaultUserID, 12, "")
+	assert.True(t, mr.Exists(key))
+	assert.Equal(t, 10*time.Minute, mr.TTL(key))
+
+	// Served from the cache: the repository expectation allows one call only.
+	second, err := svc.GetForecast(t.Context(), defaultUserID.Hex(), 12, "")
+	require.NoError(t, err)
+	assert.Equal(t, first.Totals, second.Totals)
+	assert.True(t, first.GeneratedAt.Equal(second.GeneratedAt))
+
+	// A malformed entry is recomputed.
+	require.NoError(t, mr.Set(key, "{"))
+	subRepo.EXPECT().
+		GetByUserID(mock.Anything, defaultUserID).
+		Return([]*models.Subscription{validSub()}, nil).
+		Once()
+	_, err = svc.GetForecast(t.Context(), defaultUserID.Hex(), 12, "")
+	require.NoError(t, err)
+
+	raw, err := mr.Get(key)
+	require.NoError(t, err)
+	assert.True(t, json.Valid([]byte(raw)))
+}
+
+func TestForecastService_GetForecast_Errors(t *testing.T) {
+	svc, subRepo, _, _ := setupForecast(t, 0)
+
+	_, err := svc.GetForecast(t.Context(), "not-an-id", 12, "")
+	assertAppErr(t, err, apperror.ErrUnauthorized)
+
+	for _, months := range []int{0, services.MaxForecastMonths + 1} {
+		_, err = svc.GetForecast(t.Context(), defaultUserID.Hex(), months, "")
+		assertAppErr(t, err, apperror.ErrValidation)
+	}
+
+	_, err = svc.GetForecast(t.Context(), defaultUserID.Hex(), 12, "XYZ")
+	assertAppErr(t, err, apperror.ErrValidation)
+
+	subRepo.EXPECT().
+		GetByUserID(mock.Anything, defaultUserID).
+		Return(nil, apperror.NewInternalError(assert.AnError)).
+		Once()
+	_, err = svc.GetForecast(t.Context(), defaultUserID.Hex(), 12, "")
+	assertAppErr(t, err, apperror.ErrInternal)
+}
+
+// ---------------------------------------------------------------------------
+// GetRenewalCalendar
+// ---------------------------------------------------------------------------
+
+func TestForecastService_GetRenewalCalendar(t *testing.T) {
+	monthly := validSub() // Renews 2025-02-15, then monthly.
+	disney := validSub()
+	disney.Name = "Disney+"
+	disney.Price = 799
+	yearly := validSub()
+	yearly.Name = "Prime"
+	yearly.Price = 5000
+	yearly.Currency = models.EUR
+	yearly.Frequency = models.Yearly
+	yearly.ValidTill = time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC)
+	early := validSub()
+	early.Name = "Spotify"
+	early.Price = 1099
+	early.ValidTill = time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC) // Clamped to Feb 28, then monthly.
+
+	svc, subRepo, _, _ := setupForecast(t, 0)
+	end := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	subRepo.EXPECT().
+		GetActiveByUserIDRenewingBefore(mock.Anything, defaultUserID, end).
+		Return([]*models.Subscription{monthly, yearly, disney, early}, nil).
+		Once()
+
+	got, err := svc.GetRenewalCalendar(t.Context(), defaultUserHex, "2025-04")
+	require.NoError(t, err)
+
+	assert.Equal(t, "2025-04", got.Month)
+	require.Len(t, got.Days, 3)
+	assert.Equal(t, "2025-04-03", got.Days[0].Date)
+	assert.Equal(t, []models.CurrencyAmount{models.NewCurrencyAmount(5000, models.EUR)}, got.Days[0].Totals)
+
+	assert.Equal(t, "2025-04-15", got.Days[1].Date)
+	require.Len(t, got.Days[1].Renewals, 2)
+	assert.Equal(t, []models.CurrencyAmount{models.NewCurrencyAmount(999+799, models.USD)}, got.Days[1].Totals)
+	assert.Equal(t, "2025-04-28", got.Days[2].Date)
+	assert.Equal(t, []models.CurrencyAmount{
+		models.NewCurrencyAmount(5000, models.EUR),
+		models.NewCurrencyAmount(999+799+1099, models.USD),
+	}, got.Totals)
+}
+
+func TestForecastService_GetRenewalCalendar_DefaultsToCurrentMonth(t *testing.T) {
+	svc, subRepo, _, _ := setupForecast(t, 0)
+	subRepo.EXPECT().
+		GetActiveByUserIDRenewingBefore(mock.Anything, defaultUserID, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)).
+		Return(nil, nil).
+		Once()
+
+	got, err := svc.GetRenewalCalendar(t.Context(), defaultUserHex, "")
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01", got.Month)
+	assert.Empty(t, got.Days)
+	assert.NotNil(t, got.Days)
+}
+
+func TestForecastService_GetRenewalCalendar_Errors(t *testing.T) {
+	svc, _, _, _ := setupForecast(t, 0)
+
+	_, err := svc.GetRenewalCalendar(t.Context(), "not-an-id", "2025-04")
+	assertAppErr(t, err, apperror.ErrUnauthorized)
+
+	for _, month := range []string{"2025-13", "April", "2025-04-01"} {
+		_, err = svc.GetRenewalCalendar(t.Context(), defaultUserHex, month)
+		assertAppErr(t, err, apperror.ErrValidation)
+	}
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// InvoiceConfig holds the seller details and tax printed on invoices.
+type InvoiceConfig struct {
+	CompanyName    string   `mapstructure:"company_name"`
+	CompanyAddress []string `mapstructure:"company_address"` // Printed one line per entry.
+	CompanyEmail   string   `mapstructure:"company_email"`
+	TaxID          string   `mapstructure:"tax_id"`   // E.g. a VAT number; left out when empty.
+	TaxName        string   `mapstructure:"tax_name"` // Label of the tax line, e.g. "VAT".
+	// TaxRate is the percentage of tax included in bill amounts. 0 leaves
+	// the tax lines out.
+	TaxRate float64 `mapstructure:"tax_rate"`
+}
+
+// InvoiceServiceExternal serves the PDF invoices of a user's bills.
+type InvoiceServiceExternal interface {
+	// GetInvoice opens the invoice of a bill of the caller's subscriptions.
+	// An invoice the background task has not stored yet, or stored before
+	// the bill last changed, is generated first.
+	GetInvoice(ctx context.Context, billID string, claimedUserID string) (*models.StoredFile, io.ReadCloser, error)
+}
+
+type InvoiceServiceInternal interface {
+	// GenerateInvoiceInternal renders the invoice of a bill and stores it,
+	// replacing the previous version.
+	GenerateInvoiceInternal(ctx context.Context, billID bson.ObjectID) (*models.StoredFile, error)
+}
+
+type InvoiceService interface {
+	InvoiceServiceExternal
+	InvoiceServiceInternal
+}
+
+type invoiceService struct {
+	billService            BillServiceExternal
+	billRepository         repositories.BillRepository
+	subscriptionRepository repositories.SubscriptionRepository
+	userRepository         repositories.UserRepository
+	fileService            FileServiceInternal
+	config                 InvoiceConfig
+}
+
+// NewInvoiceService creates a new instance of InvoiceService.
+func NewInvoiceService(
+	billService BillServiceExternal,
+	billRepository repositories.BillRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+	userRepository repositories.UserRepository,
+	fileService FileServiceInternal,
+	config InvoiceConfig,
+) InvoiceService {
+	return &invoiceService{
+		billService,
+		billRepository,
+		subscriptionRepository,
+		userRepository,
+		fileService,
+		config,
+	}
+}
+
+func (s *invoiceService) GetInvoice(
+	ctx context.Context,
+	billID string,
+	claimedUserID string,
+) (*models.StoredFile, io.ReadCloser, error) {
+	bill, err := s.billService.GetBillByID(ctx, billID, claimedUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := s.fileService.FetchFileByKeyInternal(ctx, models.InvoiceKey(bill.ID))
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); !ok || appErr.Code() != apperror.ErrNotFound {
+			return nil, nil, err
+		}
+	}
+	// The task regenerates the invoice after every change of the bill, but
+	// it may not have run yet.
+	if file == nil || file.UploadedAt.Before(bill.UpdatedAt) {
+		if file, err = s.generate(ctx, bill); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	content, err := s.fileService.OpenFileInternal(ctx, file.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, content, nil
+}
+
+func (s *invoiceService) GenerateInvoiceInternal(ctx context.Context, billID bson.ObjectID) (*models.StoredFile, error) {
+	bill, err := s.billRepository.GetByID(ctx, billID)
+	if err != nil {
+		return nil, err
+	}
+	return s.generate(ctx, bill)
+}
+
+// generate renders the invoice of bill and stores it for the owner of its
+// subscription.
+func (s *invoiceService) generate(ctx context.Context, bill *models.Bill) (*models.StoredFile, error) {
+	subscription, err := s.subscriptionRepository.GetByID(ctx, bill.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepository.FindByID(ctx, subscription.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invoices are a page or two, so they are rendered in memory and the
+	// stored file always holds a complete document.
+	var buf bytes.Buffer
+	err = writeInvoicePDF(&buf, &invoiceDocument{
+		config:       s.config,
+		bill:         bill,
+		subscription: subscription,
+		user:         user,
+		loc:          subscription.BillingLocation(time.Local),
+	})
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+
+	file, err := s.fileService.StoreFileInternal(ctx, bill.InvoiceFilename(), models.FileMetadata{
+		Key:         models.InvoiceKey(bill.ID),
+		OwnerID:     user.ID,
+		ContentType: models.InvoiceContentType,
+		Attributes: map[string]string{
+			"bill_id": bill.ID.Hex(),
+			"number":  bill.InvoiceNumber(),
+		},
+	}, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Invoice generated",
+		logattr.BillID(bill.ID.Hex()),
+		logattr.FileID(file.ID.Hex()),
+	)
+	return file, nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// invoiceStatusLabels are the bill statuses as printed on an invoice.
+var invoiceStatusLabels = map[models.PaymentStatus]string{
+	models.Paid:     "Paid",
+	models.Pending:  "Payment processing",
+	models.Refunded: "Refunded",
+	models.Failed:   "Payment failed",
+}
+
+// invoiceDocument holds everything an invoice PDF shows. Dates are printed in
+// loc, the billing timezone of the subscription.
+type invoiceDocument struct {
+	config       InvoiceConfig
+	bill         *models.Bill
+	subscription *models.Subscription
+	user         *models.User
+	loc          *time.Location
+}
+
+// writeInvoicePDF renders doc as an A4 PDF to w. The built-in fonts only
+// cover Windows-1252, so other characters in names are replaced.
+func writeInvoicePDF(w io.Writer, doc *invoiceDocument) error {
+	const (
+		lineHeight = 6.0
+		amountCol  = 40.0
+		periodCol  = 55.0
+	)
+	bill := doc.bill
+	date := func(t time.Time) string { return t.In(doc.loc).Format(time.DateOnly) }
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTitle(tr("Invoice "+bill.InvoiceNumber()), false)
+	pdf.SetAuthor(tr(doc.config.CompanyName), false)
+	// Fixed dates keep a regenerated invoice byte for byte the same.
+	pdf.SetCreationDate(bill.CreatedAt)
+	pdf.SetModificationDate(bill.UpdatedAt)
+	pdf.AddPage()
+	left, top, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+	width := pageWidth - left - right
+
+	// Seller on the left, invoice details on the right.
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(width/2, 9, tr(doc.config.CompanyName), "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	for _, line := range doc.config.CompanyAddress {
+		pdf.CellFormat(width/2, lineHeight, tr(line), "", 2, "L", false, 0, "")
+	}
+	if doc.config.CompanyEmail != "" {
+		pdf.CellFormat(width/2, lineHeight, tr(doc.config.CompanyEmail), "", 2, "L", false, 0, "")
+	}
+	if doc.config.TaxID != "" {
+		pdf.CellFormat(width/2, lineHeight, tr("Tax ID: "+doc.config.TaxID), "", 2, "L", false, 0, "")
+	}
+	sellerBottom := pdf.GetY()
+
+	pdf.SetXY(left+width/2, top)
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.CellFormat(width/2, 9, "INVOICE", "", 2, "R", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	for _, line := range []string{
+		"Number: " + bill.InvoiceNumber(),
+		"Issued: " + date(bill.CreatedAt),
+		"Status: " + invoiceStatusLabels[bill.Status],
+	} {
+		pdf.CellFormat(width/2, lineHeight, line, "", 2, "R", false, 0, "")
+	}
+	pdf.SetXY(left, max(sellerBottom, pdf.GetY())+10)
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(width, lineHeight, "Bill to", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(width, lineHeight, tr(doc.user.Name), "", 1, "L", false, 0, "")
+	pdf.CellFormat(width, lineHeight, tr(doc.user.Email), "", 1, "L", false, 0, "")
+	pdf.Ln(10)
+
+	// The line item shows the net amount, so the totals below add up.
+	net, tax := models.SplitTax(bill.Amount, doc.config.TaxRate)
+	descriptionCol := width - periodCol - amountCol
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(235, 235, 235)
+	pdf.CellFormat(descriptionCol, 8, "Description", "B", 0, "L", true, 0, "")
+	pdf.CellFormat(periodCol, 8, "Period", "B", 0, "L", true, 0, "")
+	pdf.CellFormat(amountCol, 8, "Amount", "B", 1, "R", true, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	description := fmt.Sprintf("%s (%s plan)", doc.subscription.Name, doc.subscription.Frequency)
+	pdf.CellFormat(descriptionCol, 8, tr(description), "B", 0, "L", false, 0, "")
+	pdf.CellFormat(periodCol, 8, date(bill.StartDate)+" - "+date(bill.EndDate), "B", 0, "L", false, 0, "")
+	pdf.CellFormat(amountCol, 8, models.FormatMoney(net, bill.Currency), "B", 1, "R", false, 0, "")
+	pdf.Ln(2)
+
+	total := func(label, amount string) {
+		pdf.CellFormat(width-amountCol, lineHeight, tr(label), "", 0, "R", false, 0, "")
+		pdf.CellFormat(amountCol, lineHeight, amount, "", 1, "R", false, 0, "")
+	}
+	if doc.config.TaxRate > 0 {
+		total("Subtotal", models.FormatMoney(net, bill.Currency))
+		rate := strconv.FormatFloat(doc.config.TaxRate, 'f', -1, 64)
+		total(fmt.Sprintf("%s (%s%%)", doc.config.TaxName, rate), models.FormatMoney(tax, bill.Currency))
+	}
+	pdf.SetFont("Helvetica", "B", 10)
+	total("Total", models.FormatMoney(bill.Amount, bill.Currency))
+	pdf.Ln(10)
+
+	pdf.SetFont("Helvetica", "", 9)
+	switch bill.Status {
+	case models.Refunded:
+		pdf.MultiCell(width, 5, "This bill was refunded in full.", "", "L", false)
+	case models.Pending:
+		pdf.MultiCell(width, 5, "The payment of this bill is still being processed.", "", "L", false)
+	case models.Failed:
+		note := "The payment of this bill did not go through."
+		if bill.FailureReason != "" {
+			note += " Reason: " + bill.FailureReason
+		}
+		pdf.MultiCell(width, 5, tr(note), "", "L", false)
+	}
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,167 @@
+package services_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type invoiceMocks struct {
+	billSvc  *svcmocks.MockBillServiceExternal
+	billRepo *repomocks.MockBillRepository
+	subRepo  *repomocks.MockSubscriptionRepository
+	userRepo *repomocks.MockUserRepository
+	files    *svcmocks.MockFileServiceInternal
+}
+
+func setupInvoice(t *testing.T) (services.InvoiceService, *invoiceMocks) {
+	t.Helper()
+
+	m := &invoiceMocks{
+		billSvc:  svcmocks.NewMockBillServiceExternal(t),
+		billRepo: repomocks.NewMockBillRepository(t),
+		subRepo:  repomocks.NewMockSubscriptionRepository(t),
+		userRepo: repomocks.NewMockUserRepository(t),
+		files:    svcmocks.NewMockFileServiceInternal(t),
+	}
+	svc := services.NewInvoiceService(m.billSvc, m.billRepo, m.subRepo, m.userRepo, m.files, services.InvoiceConfig{
+		CompanyName:    "Example Inc.",
+		CompanyAddress: []string{"1 Main Street", "Springfield"},
+		TaxID:          "DE123456789",
+		TaxName:        "VAT",
+		TaxRate:        19,
+	})
+	return svc, m
+}
+
+// expectStore expects the invoice of bill to be rendered for the default user
+// and stored, and returns the stored file.
+func expectStore(m *invoiceMocks, bill *models.Bill) *models.StoredFile {
+	stored := &models.StoredFile{ID: bson.NewObjectID(), Filename: bill.InvoiceFilename(), UploadedAt: mockTime}
+	m.subRepo.EXPECT().GetByID(mock.Anything, bill.SubscriptionID).Return(validSub(), nil).Once()
+	m.userRepo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(validUser(), nil).Once()
+	m.files.EXPECT().
+		StoreFileInternal(mock.Anything, bill.InvoiceFilename(), models.FileMetadata{
+			Key:         models.InvoiceKey(bill.ID),
+			OwnerID:     defaultUserID,
+			ContentType: models.InvoiceContentType,
+			Attributes:  map[string]string{"bill_id": bill.ID.Hex(), "number": bill.InvoiceNumber()},
+		}, mock.MatchedBy(func(content io.Reader) bool {
+			pdf, _ := io.ReadAll(content)
+			return bytes.HasPrefix(pdf, []byte("%PDF-"))
+		})).
+		Return(stored, nil).
+		Once()
+	return stored
+}
+
+// ---------------------------------------------------------------------------
+// GenerateInvoiceInternal
+// ---------------------------------------------------------------------------
+
+func TestInvoiceService_GenerateInvoiceInternal(t *testing.T) {
+	for _, status := range []models.PaymentStatus{models.Paid, models.Refunded, models.Failed} {
+		t.Run(string(status), func(t *testing.T) {
+			svc, m := setupInvoice(t)
+			bill := validBill()
+			bill.Status = status
+			bill.FailureReason = "card declined"
+			m.billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+			stored := expectStore(m, bill)
+
+			got, err := svc.GenerateInvoiceInternal(t.Context(), bill.ID)
+
+			require.NoError(t, err)
+			assert.Equal(t, stored, got)
+		})
+	}
+
+	t.Run("bill not found", func(t *testing.T) {
+		svc, m := setupInvoice(t)
+		id := bson.NewObjectID()
+		m.billRepo.EXPECT().GetByID(mock.Anything, id).Return(nil, apperror.NewNotFoundError("Bill not found")).Once()
+
+		_, err := svc.GenerateInvoiceInternal(t.Context(), id)
+
+		assertAppErr(t, err, apperror.ErrNotFound)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GetInvoice
+// ---------------------------------------------------------------------------
+
+func TestInvoiceService_GetInvoice(t *testing.T) {
+	t.Run("opens the stored invoice", func(t *testing.T) {
+		svc, m := setupInvoice(t)
+		bill := validBill()
+		stored := &models.StoredFile{ID: bson.NewObjectID(), UploadedAt: bill.UpdatedAt}
+		m.billSvc.EXPECT().GetBillByID(mock.Anything, bill.ID.Hex(), defaultUserHex).Return(bill, nil).Once()
+		m.files.EXPECT().FetchFileByKeyInternal(mock.Anything, models.InvoiceKey(bill.ID)).Return(stored, nil).Once()
+		m.files.EXPECT().OpenFileInternal(mock.Anything, stored.ID).Return(io.NopCloser(strings.NewReader("%PDF-")), nil).Once()
+
+		file, content, err := svc.GetInvoice(t.Context(), bill.ID.Hex(), defaultUserHex)
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, file)
+		assert.NotNil(t, content)
+	})
+
+	t.Run("generates a missing invoice", func(t *testing.T) {
+		svc, m := setupInvoice(t)
+		bill := validBill()
+		m.billSvc.EXPECT().GetBillByID(mock.Anything, bill.ID.Hex(), defaultUserHex).Return(bill, nil).Once()
+		m.files.EXPECT().
+			FetchFileByKeyInternal(mock.Anything, models.InvoiceKey(bill.ID)).
+			Return(nil, apperror.NewNotFoundError("File not found")).
+			Once()
+		stored := expectStore(m, bill)
+		m.files.EXPECT().OpenFileInternal(mock.Anything, stored.ID).Return(io.NopCloser(strings.NewReader("%PDF-")), nil).Once()
+
+		file, _, err := svc.GetInvoice(t.Context(), bill.ID.Hex(), defaultUserHex)
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, file)
+	})
+
+	t.Run("regenerates an invoice older than the bill", func(t *testing.T) {
+		svc, m := setupInvoice(t)
+		bill := validBill()
+		bill.Status = models.Refunded
+		bill.UpdatedAt = mockTime.AddDate(0, 0, 1)
+		outdated := &models.StoredFile{ID: bson.NewObjectID(), UploadedAt: mockTime}
+		m.billSvc.EXPECT().GetBillByID(mock.Anything, bill.ID.Hex(), defaultUserHex).Return(bill, nil).Once()
+		m.files.EXPECT().FetchFileByKeyInternal(mock.Anything, models.InvoiceKey(bill.ID)).Return(outdated, nil).Once()
+		stored := expectStore(m, bill)
+		m.files.EXPECT().OpenFileInternal(mock.Anything, stored.ID).Return(io.NopCloser(strings.NewReader("%PDF-")), nil).Once()
+
+		file, _, err := svc.GetInvoice(t.Context(), bill.ID.Hex(), defaultUserHex)
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, file)
+	})
+
+	t.Run("bill of another user", func(t *testing.T) {
+		svc, m := setupInvoice(t)
+		id := bson.NewObjectID().Hex()
+		m.billSvc.EXPECT().
+			GetBillByID(mock.Anything, id, defaultUserHex).
+			Return(nil, apperror.NewForbiddenError("You are not allowed to view bills of this subscription")).
+			Once()
+
+		_, _, err := svc.GetInvoice(t.Context(), id, defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+}
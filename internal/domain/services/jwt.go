@@ -1,8 +1,11 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/core/clock"
@@ -10,15 +13,48 @@ import (
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 )
 
+// revokedTokenPrefix prefixes the Redis markers of revoked token IDs. A marker
+// expires together with its token.
+const revokedTokenPrefix = "revoked_token:"
+
+// revokedSessionPrefix prefixes the Redis markers of revoked sessions, which
+// reject every token of the session until its refresh token expires.
+const revokedSessionPrefix = "revoked_session:"
+
+// ErrTokenRevoked is returned by ValidateToken for a token that was revoked
+// by a logout or already used for a refresh.
+var ErrTokenRevoked = errors.New("token has been revoked")
+
 // JWTService handles JWT token operations.
 type JWTService interface {
-	GenerateTokens(userID, email string) (*models.TokenResponse, error)
+	// GenerateTokens issues an access and a refresh token of the session.
+	GenerateTokens(userID, email string, role models.Role, sessionID string) (*models.TokenResponse, error)
+	// ValidateToken checks the signature, expiry, and type of a token and
+	// that it has not been revoked.
 	ValidateToken(
+		ctx context.Context,
 		tokenString string,
 		tokenType models.TokenType,
 	) (*models.Claims, error)
+	// RevokeToken makes the token with the given claims fail validation until
+	// it expires. It reports false when the token was already revoked.
+	RevokeToken(ctx context.Context, claims *models.Claims) (bool, error)
+	// RevokeSession makes every token of the session fail validation. The
+	// marker lasts until expiresAt, when the last refresh token of the
+	// session expires.
+	RevokeSession(ctx context.Context, sessionID string, expiresAt time.Time) error
+	// RotateSecrets replaces the signing secrets. Tokens signed with the
+	// previous secrets stop validating.
+	RotateSecrets(accessSecret, refreshSecret string)
+	// RotateKeys replaces the asymmetric keys; nil returns to signing with
+	// the secrets. Tokens signed with a key left out stop validating.
+	RotateKeys(keys *JWTKeySet)
+	// JWKS returns the public keys tokens are validated with, empty with
+	// HS256.
+	JWKS() models.JWKS
 }
 
 // JWTConfig holds the JWT token generation and validation settings.
@@ -28,15 +64,25 @@ type JWTConfig struct {
 	AccessExpiryHours  int    `mapstructure:"access_timeout"`
 	RefreshExpiryHours int    `mapstructure:"refresh_timeout"`
 	Issuer             string `mapstructure:"issuer"`
+
+	Algorithm  string         `mapstructure:"algorithm"`   // HS256 (default), RS256, or EdDSA.
+	SigningKey string         `mapstructure:"signing_key"` // ID in Keys that signs; defaults to the first with a private key.
+	Keys       []JWTKeyConfig `mapstructure:"keys"`        // Key pairs for RS256 and EdDSA.
 }
 
 type jwtService struct {
-	config  JWTConfig
-	getTime clock.NowFn
+	mu          sync.RWMutex // Guards the secrets in config and keys.
+	config      JWTConfig
+	keys        *JWTKeySet            // Signs tokens when set; nil signs with the secrets.
+	redisClient redis.UniversalClient // Stores revoked token IDs; nil disables revocation.
+	getTime     clock.NowFn
 }
 
-// NewJWTService creates a new JWT service instance.
-func NewJWTService(config JWTConfig, nowFn clock.NowFn) JWTService {
+// NewJWTService creates a new JWT service instance. Tokens are signed with
+// keys, from LoadJWTKeySet, or with the secrets of config when keys is nil.
+// Revoked tokens are tracked in redisClient, which commands without a Redis
+// connection pass as nil.
+func NewJWTService(config JWTConfig, keys *JWTKeySet, redisClient redis.UniversalClient, nowFn clock.NowFn) JWTService {
 	slog.Info("JWT service created",
 		logattr.Issuer(config.Issuer),
 		logattr.AccessExpiryHours(config.AccessExpiryHours),
@@ -44,31 +90,73 @@ func NewJWTService(config JWTConfig, nowFn clock.NowFn) JWTService {
 	)
 
 	return &jwtService{
-		config:  config,
-		getTime: nowFn,
+		config:      config,
+		keys:        keys,
+		redisClient: redisClient,
+		getTime:     nowFn,
 	}
 }
 
 // getSecret returns the appropriate secret based on the token type.
 func (s *jwtService) getSecret(tokenType models.TokenType) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	if tokenType == models.AccessToken {
 		return s.config.AccessSecret
 	}
 	return s.config.RefreshSecret
 }
 
+// getKeys returns the asymmetric keys, or nil with HS256.
+func (s *jwtService) getKeys() *JWTKeySet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.keys
+}
+
+// RotateKeys replaces the asymmetric keys.
+func (s *jwtService) RotateKeys(keys *JWTKeySet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = keys
+}
+
+// JWKS returns the public keys of the current key set.
+func (s *jwtService) JWKS() models.JWKS {
+	if keys := s.getKeys(); keys != nil {
+		return keys.JWKS()
+	}
+	return models.JWKS{Keys: []models.JWK{}}
+}
+
+// RotateSecrets replaces the access and refresh signing secrets.
+func (s *jwtService) RotateSecrets(accessSecret, refreshSecret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config.AccessSecret = accessSecret
+	s.config.RefreshSecret = refreshSecret
+}
+
 // generateToken creates a new signed JWT token.
 func (s *jwtService) generateToken(
 	userID,
 	email string,
+	role models.Role,
+	sessionID string,
 	tokenType models.TokenType,
 	expiry time.Time,
 ) (string, error) {
 	now := s.getTime()
 	claims := models.Claims{
-		UserID: userID,
-		Email:  email,
-		Type:   tokenType,
+		UserID:    userID,
+		Email:     email,
+		Type:      tokenType,
+		Role:      role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        uuid.New().String(),
 			ExpiresAt: jwt.NewNumericDate(expiry),
@@ -78,25 +166,27 @@ func (s *jwtService) generateToken(
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	secret := s.getSecret(tokenType)
-	// Sign the token with the secret.
-	tokenString, err := token.SignedString([]byte(secret))
-	if err != nil {
-		return "", err
+	// Sign with the current key, named in the kid header so validators
+	// find it after the next rotation, or with the secret.
+	if keys := s.getKeys(); keys != nil {
+		token := jwt.NewWithClaims(keys.method, claims)
+		token.Header["kid"] = keys.signing.ID
+		return token.SignedString(keys.signing.PrivateKey)
 	}
-
-	return tokenString, nil
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.getSecret(tokenType)))
 }
 
 // GenerateTokens creates both access and refresh tokens for a user.
-func (s *jwtService) GenerateTokens(userID, email string) (*models.TokenResponse, error) {
+func (s *jwtService) GenerateTokens(userID, email string, role models.Role, sessionID string) (*models.TokenResponse, error) {
 	now := s.getTime()
 	// Generate access token.
 	accessExpiry := now.Add(time.Hour * time.Duration(s.config.AccessExpiryHours))
 	accessToken, err := s.generateToken(
 		userID,
 		email,
+		role,
+		sessionID,
 		models.AccessToken,
 		accessExpiry,
 	)
@@ -109,6 +199,8 @@ func (s *jwtService) GenerateTokens(userID, email string) (*models.TokenResponse
 	refreshToken, err := s.generateToken(
 		userID,
 		email,
+		role,
+		sessionID,
 		models.RefreshToken,
 		refreshExpiry,
 	)
@@ -117,22 +209,45 @@ func (s *jwtService) GenerateTokens(userID, email string) (*models.TokenResponse
 	}
 
 	return &models.TokenResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    accessExpiry,
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		ExpiresAt:        accessExpiry,
+		RefreshExpiresAt: refreshExpiry,
 	}, nil
 }
 
 // ValidateToken validates a token and returns the claims if valid.
-func (s *jwtService) ValidateToken(tokenString string, tokenType models.TokenType) (*models.Claims, error) {
-	// Choose the appropriate secret based on token type.
+func (s *jwtService) ValidateToken(
+	ctx context.Context,
+	tokenString string,
+	tokenType models.TokenType,
+) (*models.Claims, error) {
+	// Choose the appropriate secret based on token type. With keys, HS256
+	// tokens are still accepted while the secrets are set, so switching
+	// algorithms does not sign everyone out.
 	secret := s.getSecret(tokenType)
+	keys := s.getKeys()
+	methods := []string{jwt.SigningMethodHS256.Name}
+	if keys != nil {
+		methods = []string{keys.method.Alg()}
+		if secret != "" {
+			methods = append(methods, jwt.SigningMethodHS256.Name)
+		}
+	}
 	// Parse the token.
 	token, err := jwt.ParseWithClaims(tokenString, &models.Claims{},
 		func(token *jwt.Token) (any, error) {
-			return []byte(secret), nil
+			if keys == nil || token.Method == jwt.SigningMethodHS256 {
+				return []byte(secret), nil
+			}
+			kid, _ := token.Header["kid"].(string)
+			key := keys.key(kid)
+			if key == nil {
+				return nil, fmt.Errorf("unknown signing key %q", kid)
+			}
+			return key.PublicKey, nil
 		},
-		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}),
+		jwt.WithValidMethods(methods),
 		jwt.WithIssuer(s.config.Issuer),
 		jwt.WithTimeFunc(s.getTime),
 	)
@@ -154,5 +269,50 @@ func (s *jwtService) ValidateToken(tokenString string, tokenType models.TokenTyp
 		)
 	}
 
+	// Fail closed: a token cannot be trusted while its revocation is unknown.
+	// The token and its session are checked in one round trip.
+	if s.redisClient != nil {
+		markers := []string{revokedTokenPrefix + claims.ID}
+		if claims.SessionID != "" {
+			markers = append(markers, revokedSessionPrefix+claims.SessionID)
+		}
+		n, err := s.redisClient.Exists(ctx, markers...).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check token revocation: %w", err)
+		}
+		if n > 0 {
+			return nil, ErrTokenRevoked
+		}
+	}
+
 	return claims, nil
 }
+
+// RevokeToken marks the token ID as revoked for the rest of its lifetime.
+func (s *jwtService) RevokeToken(ctx context.Context, claims *models.Claims) (bool, error) {
+	if s.redisClient == nil {
+		return false, errors.New("token revocation requires Redis")
+	}
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return false, errors.New("token has no ID or expiry")
+	}
+
+	ttl := claims.ExpiresAt.Sub(s.getTime())
+	if ttl <= 0 {
+		return true, nil // Expired tokens fail validation anyway.
+	}
+	return s.redisClient.SetNX(ctx, revokedTokenPrefix+claims.ID, "", ttl).Result()
+}
+
+// RevokeSession marks the session as revoked until expiresAt.
+func (s *jwtService) RevokeSession(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	if s.redisClient == nil {
+		return errors.New("session revocation requires Redis")
+	}
+
+	ttl := expiresAt.Sub(s.getTime())
+	if ttl <= 0 {
+		return nil // Its tokens have all expired.
+	}
+	return s.redisClient.Set(ctx, revokedSessionPrefix+sessionID, "", ttl).Err()
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported JWT signing algorithms.
+const (
+	JWTAlgorithmHS256 = "HS256" // Shared secrets, jwt.access_secret and jwt.refresh_secret.
+	JWTAlgorithmRS256 = "RS256" // RSA key pairs from jwt.keys.
+	JWTAlgorithmEdDSA = "EdDSA" // Ed25519 key pairs from jwt.keys.
+)
+
+// JWTKeyConfig locates a key pair for RS256 or EdDSA. A key with only a
+// public key validates tokens but cannot sign, for keys being retired.
+type JWTKeyConfig struct {
+	ID             string `mapstructure:"id"`               // Sent as the kid header.
+	PrivateKeyPath string `mapstructure:"private_key_path"` // PEM, PKCS#8 or PKCS#1 for RSA.
+	PublicKeyPath  string `mapstructure:"public_key_path"`  // PEM; derived from the private key when empty.
+}
+
+// JWTKey is a key pair of a JWTKeySet. PrivateKey is nil for keys that only
+// validate.
+type JWTKey struct {
+	ID         string
+	PrivateKey crypto.Signer
+	PublicKey  crypto.PublicKey
+}
+
+// JWTKeySet holds the keys of an asymmetric algorithm: the one new tokens
+// are signed with, and every key accepted when validating, by ID. Keeping
+// the previous key in the set while a new one signs lets tokens it issued
+// validate until they expire.
+type JWTKeySet struct {
+	method  jwt.SigningMethod
+	signing *JWTKey
+	keys    []*JWTKey
+}
+
+// NewJWTKeySet checks that keys suit algorithm and that signingKeyID names
+// one of them with a private key. An empty signingKeyID picks the first key
+// with a private key.
+func NewJWTKeySet(algorithm, signingKeyID string, keys []JWTKey) (*JWTKeySet, error) {
+	set := &JWTKeySet{}
+	switch algorithm {
+	case JWTAlgorithmRS256:
+		set.method = jwt.SigningMethodRS256
+	case JWTAlgorithmEdDSA:
+		set.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported JWT key algorithm %q", algorithm)
+	}
+
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("JWT key has no ID")
+		}
+		if set.key(key.ID) != nil {
+			return nil, fmt.Errorf("JWT key %q is listed more than once", key.ID)
+		}
+		if !keyMatches(algorithm, key.PublicKey) {
+			return nil, fmt.Errorf("JWT key %q is not an %s key", key.ID, algorithm)
+		}
+		set.keys = append(set.keys, &key)
+		if key.PrivateKey != nil && set.signing == nil && (signingKeyID == "" || signingKeyID == key.ID) {
+			set.signing = set.keys[len(set.keys)-1]
+		}
+	}
+	if set.signing == nil {
+		if signingKeyID != "" {
+			return nil, fmt.Errorf("signing key %q is not a JWT key with a private key", signingKeyID)
+		}
+		return nil, errors.New("no JWT key has a private key to sign with")
+	}
+	return set, nil
+}
+
+// LoadJWTKeySet reads the key files of config. It returns nil for HS256,
+// which signs with the shared secrets instead.
+func LoadJWTKeySet(config JWTConfig) (*JWTKeySet, error) {
+	if config.Algorithm == "" || config.Algorithm == JWTAlgorithmHS256 {
+		return nil, nil
+	}
+
+	keys := make([]JWTKey, 0, len(config.Keys))
+	for _, keyConfig := range config.Keys {
+		key, err := loadJWTKey(config.Algorithm, keyConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWT key %q: %w", keyConfig.ID, err)
+		}
+		keys = append(keys, key)
+	}
+	return NewJWTKeySet(config.Algorithm, config.SigningKey, keys)
+}
+
+func loadJWTKey(algorithm string, config JWTKeyConfig) (JWTKey, error) {
+	key := JWTKey{ID: config.ID}
+	if config.PrivateKeyPath != "" {
+		pem, err := os.ReadFile(config.PrivateKeyPath)
+		if err != nil {
+			return key, err
+		}
+		if algorithm == JWTAlgorithmRS256 {
+			key.PrivateKey, err = jwt.ParseRSAPrivateKeyFromPEM(pem)
+		} else {
+			var private crypto.PrivateKey
+			private, err = jwt.ParseEdPrivateKeyFromPEM(pem)
+			key.PrivateKey, _ = private.(crypto.Signer)
+		}
+		if err != nil {
+			return key, err
+		}
+		key.PublicKey = key.PrivateKey.Public()
+	}
+	if config.PublicKeyPath != "" {
+		pem, err := os.ReadFile(config.PublicKeyPath)
+		if err != nil {
+			return key, err
+		}
+		if algorithm == JWTAlgorithmRS256 {
+			key.PublicKey, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+		} else {
+			key.PublicKey, err = jwt.ParseEdPublicKeyFromPEM(pem)
+		}
+		if err != nil {
+			return key, err
+		}
+	}
+	if key.PublicKey == nil {
+		return key, errors.New("has neither a private nor a public key")
+	}
+	return key, nil
+}
+
+func keyMatches(algorithm string, public crypto.PublicKey) bool {
+	switch public.(type) {
+	case *rsa.PublicKey:
+		return algorithm == JWTAlgorithmRS256
+	case ed25519.PublicKey:
+		return algorithm == JWTAlgorithmEdDSA
+	default:
+		return false
+	}
+}
+
+// key returns the key with id, or nil.
+func (s *JWTKeySet) key(id string) *JWTKey {
+	for _, key := range s.keys {
+		if key.ID == id {
+			return key
+		}
+	}
+	return nil
+}
+
+// JWKS returns the public keys of the set as a JSON Web Key Set.
+func (s *JWTKeySet) JWKS() models.JWKS {
+	jwks := models.JWKS{Keys: make([]models.JWK, 0, len(s.keys))}
+	for _, key := range s.keys {
+		jwk := models.JWK{
+			KeyID:     key.ID,
+			Use:       "sig",
+			Algorithm: s.method.Alg(),
+		}
+		switch public := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
@@ -0,0 +1,255 @@
+package services_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRSAKey(t *testing.T, id string) services.JWTKey {
+	t.Helper()
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return services.JWTKey{ID: id, PrivateKey: private, PublicKey: private.Public()}
+}
+
+func newEd25519Key(t *testing.T, id string) services.JWTKey {
+	t.Helper()
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return services.JWTKey{ID: id, PrivateKey: private, PublicKey: public}
+}
+
+func newKeySet(t *testing.T, algorithm, signingKeyID string, keys ...services.JWTKey) *services.JWTKeySet {
+	t.Helper()
+
+	set, err := services.NewJWTKeySet(algorithm, signingKeyID, keys)
+	require.NoError(t, err)
+	return set
+}
+
+// verifyOnly drops the private key, as for a key being retired.
+func verifyOnly(key services.JWTKey) services.JWTKey {
+	key.PrivateKey = nil
+	return key
+}
+
+func kidOf(t *testing.T, token string) string {
+	t.Helper()
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &models.Claims{})
+	require.NoError(t, err)
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+// ---------------------------------------------------------------------------
+// Asymmetric signing
+// ---------------------------------------------------------------------------
+
+func Test_jwtService_KeySet(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		key       func(t *testing.T, id string) services.JWTKey
+	}{
+		{"RS256", services.JWTAlgorithmRS256, newRSAKey},
+		{"EdDSA", services.JWTAlgorithmEdDSA, newEd25519Key},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := newKeySet(t, tt.algorithm, "", tt.key(t, "key-1"))
+			svc := services.NewJWTService(jwtCfg, keys, nil, func() time.Time { return mockTime })
+
+			tokens, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex)
+			require.NoError(t, err)
+			assert.Equal(t, "key-1", kidOf(t, tokens.AccessToken))
+
+			claims, err := svc.ValidateToken(t.Context(), tokens.AccessToken, models.AccessToken)
+			require.NoError(t, err)
+			assert.Equal(t, defaultUserHex, claims.UserID)
+			_, err = svc.ValidateToken(t.Context(), tokens.RefreshToken, models.RefreshToken)
+			require.NoError(t, err)
+
+			// Another key set with the same ID does not validate the token.
+			svc.RotateKeys(newKeySet(t, tt.algorithm, "", tt.key(t, "key-1")))
+			_, err = svc.ValidateToken(t.Context(), tokens.AccessToken, models.AccessToken)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_jwtService_RotateKeys(t *testing.T) {
+	oldKey, newKey := newRSAKey(t, "2025-01"), newRSAKey(t, "2025-06")
+	svc := services.NewJWTService(jwtCfg, newKeySet(t, services.JWTAlgorithmRS256, "", oldKey), nil, func() time.Time { return mockTime })
+	oldTokens, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex)
+	require.NoError(t, err)
+
+	// The new key signs while the old one still validates.
+	svc.RotateKeys(newKeySet(t, services.JWTAlgorithmRS256, "2025-06", verifyOnly(oldKey), newKey))
+	newTokens, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06", kidOf(t, newTokens.AccessToken))
+	_, err = svc.ValidateToken(t.Context(), oldTokens.RefreshToken, models.RefreshToken)
+	require.NoError(t, err)
+	_, err = svc.ValidateToken(t.Context(), newTokens.AccessToken, models.AccessToken)
+	require.NoError(t, err)
+
+	// Once the old key is removed its tokens stop validating.
+	svc.RotateKeys(newKeySet(t, services.JWTAlgorithmRS256, "", newKey))
+	_, err = svc.ValidateToken(t.Context(), oldTokens.RefreshToken, models.RefreshToken)
+	require.Error(t, err)
+	_, err = svc.ValidateToken(t.Context(), newTokens.AccessToken, models.AccessToken)
+	require.NoError(t, err)
+}
+
+func Test_jwtService_KeySet_AcceptsSecretTokens(t *testing.T) {
+	svc := newJWTService()
+	hsTokens, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex)
+	require.NoError(t, err)
+
+	// Switching to keys keeps tokens signed with the secrets valid.
+	svc.RotateKeys(newKeySet(t, services.JWTAlgorithmEdDSA, "", newEd25519Key(t, "ed-1")))
+	_, err = svc.ValidateToken(t.Context(), hsTokens.AccessToken, models.AccessToken)
+	require.NoError(t, err)
+
+	// Until the secrets are removed.
+	svc.RotateSecrets("", "")
+	_, err = svc.ValidateToken(t.Context(), hsTokens.AccessToken, models.AccessToken)
+	require.Error(t, err)
+}
+
+func Test_jwtService_RejectsUnknownKeyID(t *testing.T) {
+	key := newRSAKey(t, "known")
+	svc := services.NewJWTService(jwtCfg, newKeySet(t, services.JWTAlgorithmRS256, "", key), nil, func() time.Time { return mockTime })
+
+	claims := models.Claims{
+		UserID: defaultUserHex,
+		Type:   models.AccessToken,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    jwtCfg.Issuer,
+			ExpiresAt: jwt.NewNumericDate(mockTime.Add(time.Hour)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "unknown"
+	signed, err := token.SignedString(key.PrivateKey)
+	require.NoError(t, err)
+
+	_, err = svc.ValidateToken(t.Context(), signed, models.AccessToken)
+	assert.ErrorContains(t, err, `unknown signing key "unknown"`)
+}
+
+// ---------------------------------------------------------------------------
+// JWKS
+// ---------------------------------------------------------------------------
+
+func Test_jwtService_JWKS(t *testing.T) {
+	assert.Empty(t, newJWTService().JWKS().Keys, "HS256 publishes no keys")
+
+	rsaKey := newRSAKey(t, "rsa-1")
+	svc := services.NewJWTService(jwtCfg, newKeySet(t, services.JWTAlgorithmRS256, "", rsaKey, verifyOnly(newRSAKey(t, "rsa-0"))), nil, time.Now)
+	jwks := svc.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "rsa-1", jwks.Keys[0].KeyID)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "RS256", jwks.Keys[0].Algorithm)
+	assert.Equal(t, "sig", jwks.Keys[0].Use)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	assert.NotEmpty(t, jwks.Keys[0].N)
+	assert.Equal(t, "rsa-0", jwks.Keys[1].KeyID)
+
+	edKey := newEd25519Key(t, "ed-1")
+	svc.RotateKeys(newKeySet(t, services.JWTAlgorithmEdDSA, "", edKey))
+	jwks = svc.JWKS()
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, models.JWK{KeyType: "OKP", KeyID: "ed-1", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: jwks.Keys[0].X}, jwks.Keys[0])
+	assert.Len(t, jwks.Keys[0].X, 43, "32 bytes, base64url without padding")
+}
+
+// ---------------------------------------------------------------------------
+// NewJWTKeySet and LoadJWTKeySet
+// ---------------------------------------------------------------------------
+
+func TestNewJWTKeySet_Errors(t *testing.T) {
+	rsaKey := newRSAKey(t, "rsa")
+	tests := []struct {
+		name         string
+		algorithm    string
+		signingKeyID string
+		keys         []services.JWTKey
+		wantErr      string
+	}{
+		{"unknown algorithm", "HS512", "", []services.JWTKey{rsaKey}, "unsupported"},
+		{"wrong key type", services.JWTAlgorithmEdDSA, "", []services.JWTKey{rsaKey}, "is not an EdDSA key"},
+		{"duplicate ID", services.JWTAlgorithmRS256, "", []services.JWTKey{rsaKey, rsaKey}, "more than once"},
+		{"no private key", services.JWTAlgorithmRS256, "", []services.JWTKey{verifyOnly(rsaKey)}, "no JWT key has a private key"},
+		{"unknown signing key", services.JWTAlgorithmRS256, "other", []services.JWTKey{rsaKey}, `signing key "other"`},
+		{"missing ID", services.JWTAlgorithmRS256, "", []services.JWTKey{{PublicKey: rsaKey.PublicKey}}, "has no ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := services.NewJWTKeySet(tt.algorithm, tt.signingKeyID, tt.keys)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func TestLoadJWTKeySet(t *testing.T) {
+	dir := t.TempDir()
+	current, previous := newEd25519Key(t, "current"), newEd25519Key(t, "previous")
+	privateDER, err := x509.MarshalPKCS8PrivateKey(current.PrivateKey)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(previous.PublicKey)
+	require.NoError(t, err)
+
+	cfg := jwtCfg
+	cfg.Algorithm = services.JWTAlgorithmEdDSA
+	cfg.Keys = []services.JWTKeyConfig{
+		{ID: "previous", PublicKeyPath: writePEM(t, dir, "previous.pub", "PUBLIC KEY", publicDER)},
+		{ID: "current", PrivateKeyPath: writePEM(t, dir, "current.pem", "PRIVATE KEY", privateDER)},
+	}
+	keys, err := services.LoadJWTKeySet(cfg)
+	require.NoError(t, err)
+
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "previous", jwks.Keys[0].KeyID)
+	assert.Equal(t, "current", jwks.Keys[1].KeyID)
+
+	svc := services.NewJWTService(cfg, keys, nil, func() time.Time { return mockTime })
+	tokens, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex)
+	require.NoError(t, err)
+	assert.Equal(t, "current", kidOf(t, tokens.AccessToken), "the only key with a private key signs")
+
+	// HS256 signs with the secrets and needs no keys.
+	keys, err = services.LoadJWTKeySet(jwtCfg)
+	require.NoError(t, err)
+	assert.Nil(t, keys)
+
+	cfg.Keys = append(cfg.Keys, services.JWTKeyConfig{ID: "missing", PrivateKeyPath: filepath.Join(dir, "missing.pem")})
+	_, err = services.LoadJWTKeySet(cfg)
+	assert.ErrorContains(t, err, `JWT key "missing"`)
+}
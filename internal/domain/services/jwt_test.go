@@ -4,12 +4,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/anuragthepathak/subscription-management/internal/domain/services"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 // jwtCfg is the shared JWT configuration used across all JWT tests.
@@ -24,7 +27,22 @@ var jwtCfg = services.JWTConfig{
 // newJWTService builds a jwtService with jwtCfg and the provided nowFn so
 // individual tests don't need to repeat the wiring.
 func newJWTService() services.JWTService {
-	return services.NewJWTService(jwtCfg, func() time.Time { return mockTime })
+	return services.NewJWTService(jwtCfg, nil, nil, func() time.Time { return mockTime })
+}
+
+// newRevocableJWTService builds a jwtService that tracks revoked tokens in an
+// in-memory Redis.
+func newRevocableJWTService(t *testing.T) (services.JWTService, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	return services.NewJWTService(jwtCfg, nil, rdb, func() time.Time { return mockTime }), mr
 }
 
 // ---------------------------------------------------------------------------
@@ -35,7 +53,7 @@ func Test_jwtService_GenerateTokens(t *testing.T) {
 	expectedExpiry := mockTime.Add(time.Hour * time.Duration(jwtCfg.AccessExpiryHours))
 
 	svc := newJWTService()
-	got, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail)
+	got, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.AdminRole, defaultSessionHex)
 
 	// Assert the response
 	require.NoError(t, err)
@@ -45,6 +63,7 @@ func Test_jwtService_GenerateTokens(t *testing.T) {
 	assert.NotEqual(t, got.AccessToken, got.RefreshToken,
 		"access and refresh tokens must be distinct")
 	assert.Equal(t, expectedExpiry, got.ExpiresAt)
+	assert.Equal(t, mockTime.Add(time.Hour*time.Duration(jwtCfg.RefreshExpiryHours)), got.RefreshExpiresAt)
 
 	// Independent Mathematical Verification (The True Unit Test)
 	// We parse the generated token using the raw JWT library,
@@ -69,6 +88,8 @@ func Test_jwtService_GenerateTokens(t *testing.T) {
 	assert.Equal(t, defaultUserHex, claims["userId"])
 	assert.Equal(t, defaultUserEmail, claims["email"])
 	assert.Equal(t, string(models.AccessToken), claims["type"])
+	assert.Equal(t, string(models.AdminRole), claims["role"])
+	assert.Equal(t, defaultSessionHex, claims["sid"])
 	assert.Equal(t, jwtCfg.Issuer, claims["iss"])
 }
 
@@ -206,7 +227,7 @@ func Test_jwtService_ValidateToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			svc := newJWTService()
-			got, err := svc.ValidateToken(tt.inputToken, tt.tokenType)
+			got, err := svc.ValidateToken(t.Context(), tt.inputToken, tt.tokenType)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -223,3 +244,106 @@ func Test_jwtService_ValidateToken(t *testing.T) {
 		})
 	}
 }
+
+// ---------------------------------------------------------------------------
+// RotateSecrets
+// ---------------------------------------------------------------------------
+
+func Test_jwtService_RotateSecrets(t *testing.T) {
+	svc := newJWTService()
+	oldTokens, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex)
+	require.NoError(t, err)
+
+	svc.RotateSecrets("rotated-access-secret", "rotated-refresh-secret")
+
+	// Tokens signed with the previous secrets are rejected.
+	_, err = svc.ValidateToken(t.Context(), oldTokens.AccessToken, models.AccessToken)
+	require.Error(t, err)
+	_, err = svc.ValidateToken(t.Context(), oldTokens.RefreshToken, models.RefreshToken)
+	require.Error(t, err)
+
+	// New tokens are signed and validated with the rotated secrets.
+	newTokens, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex)
+	require.NoError(t, err)
+	claims, err := svc.ValidateToken(t.Context(), newTokens.AccessToken, models.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, defaultUserHex, claims.UserID)
+}
+
+// ---------------------------------------------------------------------------
+// RevokeToken
+// ---------------------------------------------------------------------------
+
+func Test_jwtService_RevokeToken(t *testing.T) {
+	svc, mr := newRevocableJWTService(t)
+	tokens, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex)
+	require.NoError(t, err)
+
+	claims, err := svc.ValidateToken(t.Context(), tokens.RefreshToken, models.RefreshToken)
+	require.NoError(t, err)
+
+	revoked, err := svc.RevokeToken(t.Context(), claims)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// The marker lives exactly as long as the token.
+	key := "revoked_token:" + claims.ID
+	require.True(t, mr.Exists(key))
+	assert.Equal(t, time.Duration(jwtCfg.RefreshExpiryHours)*time.Hour, mr.TTL(key))
+
+	_, err = svc.ValidateToken(t.Context(), tokens.RefreshToken, models.RefreshToken)
+	require.ErrorIs(t, err, services.ErrTokenRevoked)
+
+	// Revoking again reports that the token was already revoked.
+	revoked, err = svc.RevokeToken(t.Context(), claims)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Other tokens are unaffected.
+	_, err = svc.ValidateToken(t.Context(), tokens.AccessToken, models.AccessToken)
+	require.NoError(t, err)
+
+	t.Run("fails closed when Redis is unavailable", func(t *testing.T) {
+		mr.Close()
+		_, err := svc.ValidateToken(t.Context(), tokens.AccessToken, models.AccessToken)
+		require.Error(t, err)
+	})
+}
+
+func Test_jwtService_RevokeToken_WithoutRedis(t *testing.T) {
+	svc := newJWTService()
+	tokens, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex)
+	require.NoError(t, err)
+	claims, err := svc.ValidateToken(t.Context(), tokens.RefreshToken, models.RefreshToken)
+	require.NoError(t, err)
+
+	_, err = svc.RevokeToken(t.Context(), claims)
+	assert.Error(t, err)
+}
+
+// ---------------------------------------------------------------------------
+// RevokeSession
+// ---------------------------------------------------------------------------
+
+func Test_jwtService_RevokeSession(t *testing.T) {
+	svc, mr := newRevocableJWTService(t)
+	tokens, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, defaultSessionHex)
+	require.NoError(t, err)
+	otherTokens, err := svc.GenerateTokens(defaultUserHex, defaultUserEmail, models.UserRole, bson.NewObjectID().Hex())
+	require.NoError(t, err)
+
+	require.NoError(t, svc.RevokeSession(t.Context(), defaultSessionHex, tokens.RefreshExpiresAt))
+	assert.Equal(t, time.Duration(jwtCfg.RefreshExpiryHours)*time.Hour, mr.TTL("revoked_session:"+defaultSessionHex))
+
+	// Both tokens of the session are rejected, those of other sessions are not.
+	_, err = svc.ValidateToken(t.Context(), tokens.AccessToken, models.AccessToken)
+	require.ErrorIs(t, err, services.ErrTokenRevoked)
+	_, err = svc.ValidateToken(t.Context(), tokens.RefreshToken, models.RefreshToken)
+	require.ErrorIs(t, err, services.ErrTokenRevoked)
+	_, err = svc.ValidateToken(t.Context(), otherTokens.AccessToken, models.AccessToken)
+	require.NoError(t, err)
+
+	// A session whose tokens have expired needs no marker.
+	require.NoError(t, svc.RevokeSession(t.Context(), "expired", mockTime.Add(-time.Minute)))
+	assert.False(t, mr.Exists("revoked_session:expired"))
+}
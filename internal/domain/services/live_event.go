@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ErrLiveEventsClosed is returned for streams opened after the service shut
+// down.
+var ErrLiveEventsClosed = errors.New("live events are shut down")
+
+// LiveEventConfig configures the event streams of users.
+type LiveEventConfig struct {
+	Channel     string        `mapstructure:"channel"`      // Redis pub/sub channel the worker publishes to.
+	BufferSize  int           `mapstructure:"buffer_size"`  // Events a stream holds for a slow client before it is closed.
+	Heartbeat   time.Duration `mapstructure:"heartbeat"`    // Interval of keep-alive comments, so proxies keep idle streams open.
+	MaxDuration time.Duration `mapstructure:"max_duration"` // Streams are closed after this long; clients reconnect.
+}
+
+// LiveEventServiceExternal opens the event streams of users.
+type LiveEventServiceExternal interface {
+	// StreamLiveEvents returns the live events of the user from now on. The
+	// channel is closed when ctx is done, the service shuts down, or the
+	// client falls more than the buffer size behind.
+	StreamLiveEvents(ctx context.Context, claimedUserID string) (<-chan *models.LiveEvent, error)
+}
+
+// LiveEventServiceInternal publishes live events for the API instances to
+// pass on to the user's streams.
+type LiveEventServiceInternal interface {
+	// PublishLiveEventInternal sends an event to the open streams of its
+	// user. Events for users with no open stream are dropped, and none are
+	// stored: a client that reconnects has missed those in between.
+	PublishLiveEventInternal(ctx context.Context, event *models.LiveEvent) error
+}
+
+// LiveEventService combines the external and internal interfaces. Shutdown
+// closes every open stream, so the HTTP server is not held up by them.
+type LiveEventService interface {
+	LiveEventServiceExternal
+	LiveEventServiceInternal
+	Shutdown(ctx context.Context) error
+}
+
+// liveEventService relays events from a single Redis subscription, held
+// while any stream is open, to the streams of this instance.
+type liveEventService struct {
+	redisClient redis.UniversalClient
+	config      LiveEventConfig
+
+	mu      sync.Mutex
+	pubsub  *redis.PubSub
+	streams map[string]map[chan *models.LiveEvent]struct{} // By user ID.
+	closed  bool
+}
+
+// NewLiveEventService creates a new instance of LiveEventService.
+func NewLiveEventService(redisClient redis.UniversalClient, config LiveEventConfig) LiveEventService {
+	return &liveEventService{
+		redisClient: redisClient,
+		config:      config,
+		streams:     make(map[string]map[chan *models.LiveEvent]struct{}),
+	}
+}
+
+func (s *liveEventService) PublishLiveEventInternal(ctx context.Context, event *models.LiveEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode live event: %w", err)
+	}
+	if err = s.redisClient.Publish(ctx, s.config.Channel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish live event: %w", err)
+	}
+	return nil
+}
+
+func (s *liveEventService) StreamLiveEvents(ctx context.Context, claimedUserID string) (<-chan *models.LiveEvent, error) {
+	if _, err := bson.ObjectIDFromHex(claimedUserID); err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, apperror.NewInternalError(ErrLiveEventsClosed)
+	}
+	if s.pubsub == nil {
+		pubsub := s.redisClient.Subscribe(context.WithoutCancel(ctx), s.config.Channel)
+		// Wait for the subscription, so no event published after the stream
+		// opens is missed.
+		if _, err := pubsub.Receive(ctx); err != nil {
+			_ = pubsub.Close()
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to subscribe to live events: %w", err))
+		}
+		s.pubsub = pubsub
+		go s.relay(pubsub)
+	}
+
+	stream := make(chan *models.LiveEvent, s.config.BufferSize)
+	if s.streams[claimedUserID] == nil {
+		s.streams[claimedUserID] = make(map[chan *models.LiveEvent]struct{})
+	}
+	s.streams[claimedUserID][stream] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.remove(claimedUserID, stream)
+	}()
+	return stream, nil
+}
+
+// relay passes the messages of pubsub on to the streams of their user until
+// pubsub is closed.
+func (s *liveEventService) relay(pubsub *redis.PubSub) {
+	for msg := range pubsub.Channel() {
+		var event models.LiveEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			slog.Warn("Dropping malformed live event", logattr.Error(err))
+			continue
+		}
+
+		s.mu.Lock()
+		if s.pubsub != pubsub {
+			s.mu.Unlock()
+			return
+		}
+		for stream := range s.streams[event.UserID] {
+			select {
+			case stream <- &event:
+			default:
+				// The client cannot keep up; it reconnects and starts afresh.
+				slog.Warn("Closing live event stream that fell behind",
+					logattr.UserID(event.UserID),
+				)
+				s.remove(event.UserID, stream)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// remove closes a stream, and the Redis subscription with the last one. The
+// caller must hold s.mu.
+func (s *liveEventService) remove(userID string, stream chan *models.LiveEvent) {
+	if _, ok := s.streams[userID][stream]; !ok {
+		return
+	}
+	delete(s.streams[userID], stream)
+	if len(s.streams[userID]) == 0 {
+		delete(s.streams, userID)
+	}
+	close(stream)
+
+	if len(s.streams) == 0 && s.pubsub != nil {
+		if err := s.pubsub.Close(); err != nil {
+			slog.Warn("Failed to close live event subscription", logattr.Error(err))
+		}
+		s.pubsub = nil
+	}
+}
+
+func (s *liveEventService) Shutdown(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for userID, streams := range s.streams {
+		for stream := range streams {
+			s.remove(userID, stream)
+		}
+	}
+	slog.Info("Live event streams closed")
+	return nil
+}
@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func setupLiveEvents(t *testing.T, bufferSize int) (services.LiveEventService, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	svc := services.NewLiveEventService(rdb, services.LiveEventConfig{Channel: "live_events", BufferSize: bufferSize})
+	t.Cleanup(func() { _ = svc.Shutdown(context.Background()) })
+	return svc, mr
+}
+
+func liveEvent(userID string) *models.LiveEvent {
+	return &models.LiveEvent{
+		ID:         bson.NewObjectID().Hex(),
+		Type:       string(models.SubscriptionRenewedEvent),
+		UserID:     userID,
+		OccurredAt: mockTime,
+		Data:       json.RawMessage(`{"name":"Netflix"}`),
+	}
+}
+
+// receive returns the next event of stream, or nil if it was closed.
+func receive(t *testing.T, stream <-chan *models.LiveEvent) *models.LiveEvent {
+	t.Helper()
+
+	select {
+	case event := <-stream:
+		return event
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "no live event received")
+		return nil
+	}
+}
+
+func TestLiveEventService_StreamLiveEvents(t *testing.T) {
+	svc, _ := setupLiveEvents(t, 8)
+	other := bson.NewObjectID().Hex()
+
+	mine, err := svc.StreamLiveEvents(t.Context(), defaultUserHex)
+	require.NoError(t, err)
+	theirs, err := svc.StreamLiveEvents(t.Context(), other)
+	require.NoError(t, err)
+
+	event := liveEvent(defaultUserHex)
+	require.NoError(t, svc.PublishLiveEventInternal(t.Context(), event))
+	require.NoError(t, svc.PublishLiveEventInternal(t.Context(), liveEvent(other)))
+
+	assert.Equal(t, event, receive(t, mine))
+	assert.Equal(t, other, receive(t, theirs).UserID)
+	assert.Empty(t, mine, "events of other users are not streamed")
+}
+
+func TestLiveEventService_StreamLiveEvents_ClosedWithContext(t *testing.T) {
+	svc, mr := setupLiveEvents(t, 8)
+	ctx, cancel := context.WithCancel(t.Context())
+
+	stream, err := svc.StreamLiveEvents(ctx, defaultUserHex)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mr.PubSubNumSub("live_events")["live_events"])
+
+	cancel()
+	assert.Nil(t, receive(t, stream))
+	assert.Eventually(t, func() bool {
+		return mr.PubSubNumSub("live_events")["live_events"] == 0
+	}, 2*time.Second, 10*time.Millisecond, "the last stream ends the Redis subscription")
+
+	// A new stream subscribes again.
+	stream, err = svc.StreamLiveEvents(t.Context(), defaultUserHex)
+	require.NoError(t, err)
+	event := liveEvent(defaultUserHex)
+	require.NoError(t, svc.PublishLiveEventInternal(t.Context(), event))
+	assert.Equal(t, event, receive(t, stream))
+}
+
+func TestLiveEventService_StreamLiveEvents_SlowClient(t *testing.T) {
+	svc, _ := setupLiveEvents(t, 1)
+
+	stream, err := svc.StreamLiveEvents(t.Context(), defaultUserHex)
+	require.NoError(t, err)
+	const published = 10
+	for range published {
+		require.NoError(t, svc.PublishLiveEventInternal(t.Context(), liveEvent(defaultUserHex)))
+	}
+
+	// The stream is closed once it falls behind; what it buffered until
+	// then is still delivered.
+	received := 0
+	for event := receive(t, stream); event != nil; event = receive(t, stream) {
+		received++
+	}
+	assert.Positive(t, received)
+	assert.Less(t, received, published)
+}
+
+func TestLiveEventService_StreamLiveEvents_InvalidUser(t *testing.T) {
+	svc, _ := setupLiveEvents(t, 8)
+
+	_, err := svc.StreamLiveEvents(t.Context(), "invalid")
+
+	assertAppErr(t, err, apperror.ErrUnauthorized)
+}
+
+func TestLiveEventService_Shutdown(t *testing.T) {
+	svc, _ := setupLiveEvents(t, 8)
+	stream, err := svc.StreamLiveEvents(t.Context(), defaultUserHex)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Shutdown(t.Context()))
+
+	assert.Nil(t, receive(t, stream))
+	_, err = svc.StreamLiveEvents(t.Context(), defaultUserHex)
+	assert.ErrorIs(t, err, services.ErrLiveEventsClosed)
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAccountClosureQueue is an autogenerated mock type for the AccountClosureQueue type
+type MockAccountClosureQueue struct {
+	mock.Mock
+}
+
+type MockAccountClosureQueue_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccountClosureQueue) EXPECT() *MockAccountClosureQueue_Expecter {
+	return &MockAccountClosureQueue_Expecter{mock: &_m.Mock}
+}
+
+// EnqueueAccountClosure provides a mock function with given fields: ctx, user
+func (_m *MockAccountClosureQueue) EnqueueAccountClosure(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueAccountClosure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAccountClosureQueue_EnqueueAccountClosure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueAccountClosure'
+type MockAccountClosureQueue_EnqueueAccountClosure_Call struct {
+	*mock.Call
+}
+
+// EnqueueAccountClosure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *models.User
+func (_e *MockAccountClosureQueue_Expecter) EnqueueAccountClosure(ctx interface{}, user interface{}) *MockAccountClosureQueue_EnqueueAccountClosure_Call {
+	return &MockAccountClosureQueue_EnqueueAccountClosure_Call{Call: _e.mock.On("EnqueueAccountClosure", ctx, user)}
+}
+
+func (_c *MockAccountClosureQueue_EnqueueAccountClosure_Call) Run(run func(ctx context.Context, user *models.User)) *MockAccountClosureQueue_EnqueueAccountClosure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.User))
+	})
+	return _c
+}
+
+func (_c *MockAccountClosureQueue_EnqueueAccountClosure_Call) Return(_a0 error) *MockAccountClosureQueue_EnqueueAccountClosure_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAccountClosureQueue_EnqueueAccountClosure_Call) RunAndReturn(run func(context.Context, *models.User) error) *MockAccountClosureQueue_EnqueueAccountClosure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAccountClosureQueue creates a new instance of MockAccountClosureQueue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccountClosureQueue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccountClosureQueue {
+	mock := &MockAccountClosureQueue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
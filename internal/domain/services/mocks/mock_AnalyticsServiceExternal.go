@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAnalyticsServiceExternal is an autogenerated mock type for the AnalyticsServiceExternal type
+type MockAnalyticsServiceExternal struct {
+	mock.Mock
+}
+
+type MockAnalyticsServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAnalyticsServiceExternal) EXPECT() *MockAnalyticsServiceExternal_Expecter {
+	return &MockAnalyticsServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// GetPlatformStats provides a mock function with given fields: ctx
+func (_m *MockAnalyticsServiceExternal) GetPlatformStats(ctx context.Context) (*models.PlatformStatsResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPlatformStats")
+	}
+
+	var r0 *models.PlatformStatsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.PlatformStatsResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.PlatformStatsResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PlatformStatsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAnalyticsServiceExternal_GetPlatformStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPlatformStats'
+type MockAnalyticsServiceExternal_GetPlatformStats_Call struct {
+	*mock.Call
+}
+
+// GetPlatformStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAnalyticsServiceExternal_Expecter) GetPlatformStats(ctx interface{}) *MockAnalyticsServiceExternal_GetPlatformStats_Call {
+	return &MockAnalyticsServiceExternal_GetPlatformStats_Call{Call: _e.mock.On("GetPlatformStats", ctx)}
+}
+
+func (_c *MockAnalyticsServiceExternal_GetPlatformStats_Call) Run(run func(ctx context.Context)) *MockAnalyticsServiceExternal_GetPlatformStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAnalyticsServiceExternal_GetPlatformStats_Call) Return(_a0 *models.PlatformStatsResponse, _a1 error) *MockAnalyticsServiceExternal_GetPlatformStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAnalyticsServiceExternal_GetPlatformStats_Call) RunAndReturn(run func(context.Context) (*models.PlatformStatsResponse, error)) *MockAnalyticsServiceExternal_GetPlatformStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAnalyticsServiceExternal creates a new instance of MockAnalyticsServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAnalyticsServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAnalyticsServiceExternal {
+	mock := &MockAnalyticsServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,106 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAnalyticsServiceInternal is an autogenerated mock type for the AnalyticsServiceInternal type
+type MockAnalyticsServiceInternal struct {
+	mock.Mock
+}
+
+type MockAnalyticsServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAnalyticsServiceInternal) EXPECT() *MockAnalyticsServiceInternal_Expecter {
+	return &MockAnalyticsServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// RecordEmailDeliveryInternal provides a mock function with given fields: ctx, err
+func (_m *MockAnalyticsServiceInternal) RecordEmailDeliveryInternal(ctx context.Context, err error) {
+	_m.Called(ctx, err)
+}
+
+// MockAnalyticsServiceInternal_RecordEmailDeliveryInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordEmailDeliveryInternal'
+type MockAnalyticsServiceInternal_RecordEmailDeliveryInternal_Call struct {
+	*mock.Call
+}
+
+// RecordEmailDeliveryInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - err error
+func (_e *MockAnalyticsServiceInternal_Expecter) RecordEmailDeliveryInternal(ctx interface{}, err interface{}) *MockAnalyticsServiceInternal_RecordEmailDeliveryInternal_Call {
+	return &MockAnalyticsServiceInternal_RecordEmailDeliveryInternal_Call{Call: _e.mock.On("RecordEmailDeliveryInternal", ctx, err)}
+}
+
+func (_c *MockAnalyticsServiceInternal_RecordEmailDeliveryInternal_Call) Run(run func(ctx context.Context, err error)) *MockAnalyticsServiceInternal_RecordEmailDeliveryInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(error))
+	})
+	return _c
+}
+
+func (_c *MockAnalyticsServiceInternal_RecordEmailDeliveryInternal_Call) Return() *MockAnalyticsServiceInternal_RecordEmailDeliveryInternal_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAnalyticsServiceInternal_RecordEmailDeliveryInternal_Call) RunAndReturn(run func(context.Context, error)) *MockAnalyticsServiceInternal_RecordEmailDeliveryInternal_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RecordNotificationDeliveryInternal provides a mock function with given fields: ctx, channel, err
+func (_m *MockAnalyticsServiceInternal) RecordNotificationDeliveryInternal(ctx context.Context, channel models.NotificationChannel, err error) {
+	_m.Called(ctx, channel, err)
+}
+
+// MockAnalyticsServiceInternal_RecordNotificationDeliveryInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordNotificationDeliveryInternal'
+type MockAnalyticsServiceInternal_RecordNotificationDeliveryInternal_Call struct {
+	*mock.Call
+}
+
+// RecordNotificationDeliveryInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel models.NotificationChannel
+//   - err error
+func (_e *MockAnalyticsServiceInternal_Expecter) RecordNotificationDeliveryInternal(ctx interface{}, channel interface{}, err interface{}) *MockAnalyticsServiceInternal_RecordNotificationDeliveryInternal_Call {
+	return &MockAnalyticsServiceInternal_RecordNotificationDeliveryInternal_Call{Call: _e.mock.On("RecordNotificationDeliveryInternal", ctx, channel, err)}
+}
+
+func (_c *MockAnalyticsServiceInternal_RecordNotificationDeliveryInternal_Call) Run(run func(ctx context.Context, channel models.NotificationChannel, err error)) *MockAnalyticsServiceInternal_RecordNotificationDeliveryInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.NotificationChannel), args[2].(error))
+	})
+	return _c
+}
+
+func (_c *MockAnalyticsServiceInternal_RecordNotificationDeliveryInternal_Call) Return() *MockAnalyticsServiceInternal_RecordNotificationDeliveryInternal_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAnalyticsServiceInternal_RecordNotificationDeliveryInternal_Call) RunAndReturn(run func(context.Context, models.NotificationChannel, error)) *MockAnalyticsServiceInternal_RecordNotificationDeliveryInternal_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockAnalyticsServiceInternal creates a new instance of MockAnalyticsServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAnalyticsServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAnalyticsServiceInternal {
+	mock := &MockAnalyticsServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
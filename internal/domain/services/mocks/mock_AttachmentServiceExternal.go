@@ -0,0 +1,268 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAttachmentServiceExternal is an autogenerated mock type for the AttachmentServiceExternal type
+type MockAttachmentServiceExternal struct {
+	mock.Mock
+}
+
+type MockAttachmentServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAttachmentServiceExternal) EXPECT() *MockAttachmentServiceExternal_Expecter {
+	return &MockAttachmentServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// DeleteAttachment provides a mock function with given fields: ctx, subscriptionID, id, claimedUserID
+func (_m *MockAttachmentServiceExternal) DeleteAttachment(ctx context.Context, subscriptionID string, id string, claimedUserID string) error {
+	ret := _m.Called(ctx, subscriptionID, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, subscriptionID, id, claimedUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAttachmentServiceExternal_DeleteAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAttachment'
+type MockAttachmentServiceExternal_DeleteAttachment_Call struct {
+	*mock.Call
+}
+
+// DeleteAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID string
+//   - id string
+//   - claimedUserID string
+func (_e *MockAttachmentServiceExternal_Expecter) DeleteAttachment(ctx interface{}, subscriptionID interface{}, id interface{}, claimedUserID interface{}) *MockAttachmentServiceExternal_DeleteAttachment_Call {
+	return &MockAttachmentServiceExternal_DeleteAttachment_Call{Call: _e.mock.On("DeleteAttachment", ctx, subscriptionID, id, claimedUserID)}
+}
+
+func (_c *MockAttachmentServiceExternal_DeleteAttachment_Call) Run(run func(ctx context.Context, subscriptionID string, id string, claimedUserID string)) *MockAttachmentServiceExternal_DeleteAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockAttachmentServiceExternal_DeleteAttachment_Call) Return(_a0 error) *MockAttachmentServiceExternal_DeleteAttachment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAttachmentServiceExternal_DeleteAttachment_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockAttachmentServiceExternal_DeleteAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAttachmentByID provides a mock function with given fields: ctx, subscriptionID, id, claimedUserID
+func (_m *MockAttachmentServiceExternal) GetAttachmentByID(ctx context.Context, subscriptionID string, id string, claimedUserID string) (*models.SignedAttachment, error) {
+	ret := _m.Called(ctx, subscriptionID, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachmentByID")
+	}
+
+	var r0 *models.SignedAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.SignedAttachment, error)); ok {
+		return rf(ctx, subscriptionID, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.SignedAttachment); ok {
+		r0 = rf(ctx, subscriptionID, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignedAttachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, subscriptionID, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAttachmentServiceExternal_GetAttachmentByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAttachmentByID'
+type MockAttachmentServiceExternal_GetAttachmentByID_Call struct {
+	*mock.Call
+}
+
+// GetAttachmentByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID string
+//   - id string
+//   - claimedUserID string
+func (_e *MockAttachmentServiceExternal_Expecter) GetAttachmentByID(ctx interface{}, subscriptionID interface{}, id interface{}, claimedUserID interface{}) *MockAttachmentServiceExternal_GetAttachmentByID_Call {
+	return &MockAttachmentServiceExternal_GetAttachmentByID_Call{Call: _e.mock.On("GetAttachmentByID", ctx, subscriptionID, id, claimedUserID)}
+}
+
+func (_c *MockAttachmentServiceExternal_GetAttachmentByID_Call) Run(run func(ctx context.Context, subscriptionID string, id string, claimedUserID string)) *MockAttachmentServiceExternal_GetAttachmentByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockAttachmentServiceExternal_GetAttachmentByID_Call) Return(_a0 *models.SignedAttachment, _a1 error) *MockAttachmentServiceExternal_GetAttachmentByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAttachmentServiceExternal_GetAttachmentByID_Call) RunAndReturn(run func(context.Context, string, string, string) (*models.SignedAttachment, error)) *MockAttachmentServiceExternal_GetAttachmentByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAttachments provides a mock function with given fields: ctx, subscriptionID, claimedUserID
+func (_m *MockAttachmentServiceExternal) GetAttachments(ctx context.Context, subscriptionID string, claimedUserID string) ([]*models.SignedAttachment, error) {
+	ret := _m.Called(ctx, subscriptionID, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachments")
+	}
+
+	var r0 []*models.SignedAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.SignedAttachment, error)); ok {
+		return rf(ctx, subscriptionID, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.SignedAttachment); ok {
+		r0 = rf(ctx, subscriptionID, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SignedAttachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, subscriptionID, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAttachmentServiceExternal_GetAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAttachments'
+type MockAttachmentServiceExternal_GetAttachments_Call struct {
+	*mock.Call
+}
+
+// GetAttachments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID string
+//   - claimedUserID string
+func (_e *MockAttachmentServiceExternal_Expecter) GetAttachments(ctx interface{}, subscriptionID interface{}, claimedUserID interface{}) *MockAttachmentServiceExternal_GetAttachments_Call {
+	return &MockAttachmentServiceExternal_GetAttachments_Call{Call: _e.mock.On("GetAttachments", ctx, subscriptionID, claimedUserID)}
+}
+
+func (_c *MockAttachmentServiceExternal_GetAttachments_Call) Run(run func(ctx context.Context, subscriptionID string, claimedUserID string)) *MockAttachmentServiceExternal_GetAttachments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockAttachmentServiceExternal_GetAttachments_Call) Return(_a0 []*models.SignedAttachment, _a1 error) *MockAttachmentServiceExternal_GetAttachments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAttachmentServiceExternal_GetAttachments_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.SignedAttachment, error)) *MockAttachmentServiceExternal_GetAttachments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadAttachment provides a mock function with given fields: ctx, subscriptionID, upload, claimedUserID
+func (_m *MockAttachmentServiceExternal) UploadAttachment(ctx context.Context, subscriptionID string, upload models.AttachmentUpload, claimedUserID string) (*models.SignedAttachment, error) {
+	ret := _m.Called(ctx, subscriptionID, upload, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadAttachment")
+	}
+
+	var r0 *models.SignedAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AttachmentUpload, string) (*models.SignedAttachment, error)); ok {
+		return rf(ctx, subscriptionID, upload, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AttachmentUpload, string) *models.SignedAttachment); ok {
+		r0 = rf(ctx, subscriptionID, upload, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignedAttachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.AttachmentUpload, string) error); ok {
+		r1 = rf(ctx, subscriptionID, upload, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAttachmentServiceExternal_UploadAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadAttachment'
+type MockAttachmentServiceExternal_UploadAttachment_Call struct {
+	*mock.Call
+}
+
+// UploadAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID string
+//   - upload models.AttachmentUpload
+//   - claimedUserID string
+func (_e *MockAttachmentServiceExternal_Expecter) UploadAttachment(ctx interface{}, subscriptionID interface{}, upload interface{}, claimedUserID interface{}) *MockAttachmentServiceExternal_UploadAttachment_Call {
+	return &MockAttachmentServiceExternal_UploadAttachment_Call{Call: _e.mock.On("UploadAttachment", ctx, subscriptionID, upload, claimedUserID)}
+}
+
+func (_c *MockAttachmentServiceExternal_UploadAttachment_Call) Run(run func(ctx context.Context, subscriptionID string, upload models.AttachmentUpload, claimedUserID string)) *MockAttachmentServiceExternal_UploadAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.AttachmentUpload), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockAttachmentServiceExternal_UploadAttachment_Call) Return(_a0 *models.SignedAttachment, _a1 error) *MockAttachmentServiceExternal_UploadAttachment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAttachmentServiceExternal_UploadAttachment_Call) RunAndReturn(run func(context.Context, string, models.AttachmentUpload, string) (*models.SignedAttachment, error)) *MockAttachmentServiceExternal_UploadAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAttachmentServiceExternal creates a new instance of MockAttachmentServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAttachmentServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAttachmentServiceExternal {
+	mock := &MockAttachmentServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAttachmentServiceInternal is an autogenerated mock type for the AttachmentServiceInternal type
+type MockAttachmentServiceInternal struct {
+	mock.Mock
+}
+
+type MockAttachmentServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAttachmentServiceInternal) EXPECT() *MockAttachmentServiceInternal_Expecter {
+	return &MockAttachmentServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// DeleteAttachmentsInternal provides a mock function with given fields: ctx, userID
+func (_m *MockAttachmentServiceInternal) DeleteAttachmentsInternal(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttachmentsInternal")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAttachmentServiceInternal_DeleteAttachmentsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAttachmentsInternal'
+type MockAttachmentServiceInternal_DeleteAttachmentsInternal_Call struct {
+	*mock.Call
+}
+
+// DeleteAttachmentsInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockAttachmentServiceInternal_Expecter) DeleteAttachmentsInternal(ctx interface{}, userID interface{}) *MockAttachmentServiceInternal_DeleteAttachmentsInternal_Call {
+	return &MockAttachmentServiceInternal_DeleteAttachmentsInternal_Call{Call: _e.mock.On("DeleteAttachmentsInternal", ctx, userID)}
+}
+
+func (_c *MockAttachmentServiceInternal_DeleteAttachmentsInternal_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockAttachmentServiceInternal_DeleteAttachmentsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockAttachmentServiceInternal_DeleteAttachmentsInternal_Call) Return(_a0 int64, _a1 error) *MockAttachmentServiceInternal_DeleteAttachmentsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAttachmentServiceInternal_DeleteAttachmentsInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockAttachmentServiceInternal_DeleteAttachmentsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAttachmentServiceInternal creates a new instance of MockAttachmentServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAttachmentServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAttachmentServiceInternal {
+	mock := &MockAttachmentServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAuditServiceExternal is an autogenerated mock type for the AuditServiceExternal type
+type MockAuditServiceExternal struct {
+	mock.Mock
+}
+
+type MockAuditServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditServiceExternal) EXPECT() *MockAuditServiceExternal_Expecter {
+	return &MockAuditServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// GetAuditEvents provides a mock function with given fields: ctx, query
+func (_m *MockAuditServiceExternal) GetAuditEvents(ctx context.Context, query models.AuditQuery) (*models.AuditPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuditEvents")
+	}
+
+	var r0 *models.AuditPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.AuditQuery) (*models.AuditPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.AuditQuery) *models.AuditPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuditPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.AuditQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditServiceExternal_GetAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditEvents'
+type MockAuditServiceExternal_GetAuditEvents_Call struct {
+	*mock.Call
+}
+
+// GetAuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query models.AuditQuery
+func (_e *MockAuditServiceExternal_Expecter) GetAuditEvents(ctx interface{}, query interface{}) *MockAuditServiceExternal_GetAuditEvents_Call {
+	return &MockAuditServiceExternal_GetAuditEvents_Call{Call: _e.mock.On("GetAuditEvents", ctx, query)}
+}
+
+func (_c *MockAuditServiceExternal_GetAuditEvents_Call) Run(run func(ctx context.Context, query models.AuditQuery)) *MockAuditServiceExternal_GetAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.AuditQuery))
+	})
+	return _c
+}
+
+func (_c *MockAuditServiceExternal_GetAuditEvents_Call) Return(_a0 *models.AuditPage, _a1 error) *MockAuditServiceExternal_GetAuditEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditServiceExternal_GetAuditEvents_Call) RunAndReturn(run func(context.Context, models.AuditQuery) (*models.AuditPage, error)) *MockAuditServiceExternal_GetAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditServiceExternal creates a new instance of MockAuditServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditServiceExternal {
+	mock := &MockAuditServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
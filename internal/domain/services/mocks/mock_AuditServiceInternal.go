@@ -0,0 +1,91 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockAuditServiceInternal is an autogenerated mock type for the AuditServiceInternal type
+type MockAuditServiceInternal struct {
+	mock.Mock
+}
+
+type MockAuditServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditServiceInternal) EXPECT() *MockAuditServiceInternal_Expecter {
+	return &MockAuditServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// RecordInternal provides a mock function with given fields: ctx, actor, action, resourceID, before, after
+func (_m *MockAuditServiceInternal) RecordInternal(ctx context.Context, actor models.Actor, action models.AuditAction, resourceID bson.ObjectID, before interface{}, after interface{}) error {
+	ret := _m.Called(ctx, actor, action, resourceID, before, after)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Actor, models.AuditAction, bson.ObjectID, interface{}, interface{}) error); ok {
+		r0 = rf(ctx, actor, action, resourceID, before, after)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuditServiceInternal_RecordInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordInternal'
+type MockAuditServiceInternal_RecordInternal_Call struct {
+	*mock.Call
+}
+
+// RecordInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor models.Actor
+//   - action models.AuditAction
+//   - resourceID bson.ObjectID
+//   - before interface{}
+//   - after interface{}
+func (_e *MockAuditServiceInternal_Expecter) RecordInternal(ctx interface{}, actor interface{}, action interface{}, resourceID interface{}, before interface{}, after interface{}) *MockAuditServiceInternal_RecordInternal_Call {
+	return &MockAuditServiceInternal_RecordInternal_Call{Call: _e.mock.On("RecordInternal", ctx, actor, action, resourceID, before, after)}
+}
+
+func (_c *MockAuditServiceInternal_RecordInternal_Call) Run(run func(ctx context.Context, actor models.Actor, action models.AuditAction, resourceID bson.ObjectID, before interface{}, after interface{})) *MockAuditServiceInternal_RecordInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.Actor), args[2].(models.AuditAction), args[3].(bson.ObjectID), args[4].(interface{}), args[5].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockAuditServiceInternal_RecordInternal_Call) Return(_a0 error) *MockAuditServiceInternal_RecordInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuditServiceInternal_RecordInternal_Call) RunAndReturn(run func(context.Context, models.Actor, models.AuditAction, bson.ObjectID, interface{}, interface{}) error) *MockAuditServiceInternal_RecordInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditServiceInternal creates a new instance of MockAuditServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditServiceInternal {
+	mock := &MockAuditServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
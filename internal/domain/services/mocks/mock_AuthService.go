@@ -22,9 +22,9 @@ func (_m *MockAuthService) EXPECT() *MockAuthService_Expecter {
 	return &MockAuthService_Expecter{mock: &_m.Mock}
 }
 
-// Login provides a mock function with given fields: ctx, loginReq
-func (_m *MockAuthService) Login(ctx context.Context, loginReq models.LoginRequest) (*models.TokenResponse, error) {
-	ret := _m.Called(ctx, loginReq)
+// Login provides a mock function with given fields: ctx, loginReq, client
+func (_m *MockAuthService) Login(ctx context.Context, loginReq models.LoginRequest, client models.SessionClient) (*models.TokenResponse, error) {
+	ret := _m.Called(ctx, loginReq, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
@@ -32,19 +32,19 @@ func (_m *MockAuthService) Login(ctx context.Context, loginReq models.LoginReque
 
 	var r0 *models.TokenResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.LoginRequest) (*models.TokenResponse, error)); ok {
-		return rf(ctx, loginReq)
+	if rf, ok := ret.Get(0).(func(context.Context, models.LoginRequest, models.SessionClient) (*models.TokenResponse, error)); ok {
+		return rf(ctx, loginReq, client)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.LoginRequest) *models.TokenResponse); ok {
-		r0 = rf(ctx, loginReq)
+	if rf, ok := ret.Get(0).(func(context.Context, models.LoginRequest, models.SessionClient) *models.TokenResponse); ok {
+		r0 = rf(ctx, loginReq, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.TokenResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, models.LoginRequest) error); ok {
-		r1 = rf(ctx, loginReq)
+	if rf, ok := ret.Get(1).(func(context.Context, models.LoginRequest, models.SessionClient) error); ok {
+		r1 = rf(ctx, loginReq, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,13 +60,14 @@ type MockAuthService_Login_Call struct {
 // Login is a helper method to define mock.On call
 //   - ctx context.Context
 //   - loginReq models.LoginRequest
-func (_e *MockAuthService_Expecter) Login(ctx interface{}, loginReq interface{}) *MockAuthService_Login_Call {
-	return &MockAuthService_Login_Call{Call: _e.mock.On("Login", ctx, loginReq)}
+//   - client models.SessionClient
+func (_e *MockAuthService_Expecter) Login(ctx interface{}, loginReq interface{}, client interface{}) *MockAuthService_Login_Call {
+	return &MockAuthService_Login_Call{Call: _e.mock.On("Login", ctx, loginReq, client)}
 }
 
-func (_c *MockAuthService_Login_Call) Run(run func(ctx context.Context, loginReq models.LoginRequest)) *MockAuthService_Login_Call {
+func (_c *MockAuthService_Login_Call) Run(run func(ctx context.Context, loginReq models.LoginRequest, client models.SessionClient)) *MockAuthService_Login_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(models.LoginRequest))
+		run(args[0].(context.Context), args[1].(models.LoginRequest), args[2].(models.SessionClient))
 	})
 	return _c
 }
@@ -76,14 +77,62 @@ func (_c *MockAuthService_Login_Call) Return(_a0 *models.TokenResponse, _a1 erro
 	return _c
 }
 
-func (_c *MockAuthService_Login_Call) RunAndReturn(run func(context.Context, models.LoginRequest) (*models.TokenResponse, error)) *MockAuthService_Login_Call {
+func (_c *MockAuthService_Login_Call) RunAndReturn(run func(context.Context, models.LoginRequest, models.SessionClient) (*models.TokenResponse, error)) *MockAuthService_Login_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RefreshToken provides a mock function with given fields: ctx, refreshToken
-func (_m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string) (*models.TokenResponse, error) {
-	ret := _m.Called(ctx, refreshToken)
+// Logout provides a mock function with given fields: ctx, accessToken, refreshToken
+func (_m *MockAuthService) Logout(ctx context.Context, accessToken string, refreshToken string) error {
+	ret := _m.Called(ctx, accessToken, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, accessToken, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuthService_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockAuthService_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accessToken string
+//   - refreshToken string
+func (_e *MockAuthService_Expecter) Logout(ctx interface{}, accessToken interface{}, refreshToken interface{}) *MockAuthService_Logout_Call {
+	return &MockAuthService_Logout_Call{Call: _e.mock.On("Logout", ctx, accessToken, refreshToken)}
+}
+
+func (_c *MockAuthService_Logout_Call) Run(run func(ctx context.Context, accessToken string, refreshToken string)) *MockAuthService_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_Logout_Call) Return(_a0 error) *MockAuthService_Logout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthService_Logout_Call) RunAndReturn(run func(context.Context, string, string) error) *MockAuthService_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshToken provides a mock function with given fields: ctx, refreshToken, client
+func (_m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string, client models.SessionClient) (*models.TokenResponse, error) {
+	ret := _m.Called(ctx, refreshToken, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RefreshToken")
@@ -91,19 +140,19 @@ func (_m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string
 
 	var r0 *models.TokenResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.TokenResponse, error)); ok {
-		return rf(ctx, refreshToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.SessionClient) (*models.TokenResponse, error)); ok {
+		return rf(ctx, refreshToken, client)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TokenResponse); ok {
-		r0 = rf(ctx, refreshToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.SessionClient) *models.TokenResponse); ok {
+		r0 = rf(ctx, refreshToken, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.TokenResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, refreshToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.SessionClient) error); ok {
+		r1 = rf(ctx, refreshToken, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -119,13 +168,14 @@ type MockAuthService_RefreshToken_Call struct {
 // RefreshToken is a helper method to define mock.On call
 //   - ctx context.Context
 //   - refreshToken string
-func (_e *MockAuthService_Expecter) RefreshToken(ctx interface{}, refreshToken interface{}) *MockAuthService_RefreshToken_Call {
-	return &MockAuthService_RefreshToken_Call{Call: _e.mock.On("RefreshToken", ctx, refreshToken)}
+//   - client models.SessionClient
+func (_e *MockAuthService_Expecter) RefreshToken(ctx interface{}, refreshToken interface{}, client interface{}) *MockAuthService_RefreshToken_Call {
+	return &MockAuthService_RefreshToken_Call{Call: _e.mock.On("RefreshToken", ctx, refreshToken, client)}
 }
 
-func (_c *MockAuthService_RefreshToken_Call) Run(run func(ctx context.Context, refreshToken string)) *MockAuthService_RefreshToken_Call {
+func (_c *MockAuthService_RefreshToken_Call) Run(run func(ctx context.Context, refreshToken string, client models.SessionClient)) *MockAuthService_RefreshToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(models.SessionClient))
 	})
 	return _c
 }
@@ -135,7 +185,7 @@ func (_c *MockAuthService_RefreshToken_Call) Return(_a0 *models.TokenResponse, _
 	return _c
 }
 
-func (_c *MockAuthService_RefreshToken_Call) RunAndReturn(run func(context.Context, string) (*models.TokenResponse, error)) *MockAuthService_RefreshToken_Call {
+func (_c *MockAuthService_RefreshToken_Call) RunAndReturn(run func(context.Context, string, models.SessionClient) (*models.TokenResponse, error)) *MockAuthService_RefreshToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
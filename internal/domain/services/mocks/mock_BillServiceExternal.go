@@ -0,0 +1,158 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockBillServiceExternal is an autogenerated mock type for the BillServiceExternal type
+type MockBillServiceExternal struct {
+	mock.Mock
+}
+
+type MockBillServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBillServiceExternal) EXPECT() *MockBillServiceExternal_Expecter {
+	return &MockBillServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// GetBillByID provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockBillServiceExternal) GetBillByID(ctx context.Context, id string, claimedUserID string) (*models.Bill, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBillByID")
+	}
+
+	var r0 *models.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Bill, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Bill); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBillServiceExternal_GetBillByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBillByID'
+type MockBillServiceExternal_GetBillByID_Call struct {
+	*mock.Call
+}
+
+// GetBillByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockBillServiceExternal_Expecter) GetBillByID(ctx interface{}, id interface{}, claimedUserID interface{}) *MockBillServiceExternal_GetBillByID_Call {
+	return &MockBillServiceExternal_GetBillByID_Call{Call: _e.mock.On("GetBillByID", ctx, id, claimedUserID)}
+}
+
+func (_c *MockBillServiceExternal_GetBillByID_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockBillServiceExternal_GetBillByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockBillServiceExternal_GetBillByID_Call) Return(_a0 *models.Bill, _a1 error) *MockBillServiceExternal_GetBillByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBillServiceExternal_GetBillByID_Call) RunAndReturn(run func(context.Context, string, string) (*models.Bill, error)) *MockBillServiceExternal_GetBillByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBillsBySubscriptionID provides a mock function with given fields: ctx, subscriptionID, claimedUserID, query
+func (_m *MockBillServiceExternal) GetBillsBySubscriptionID(ctx context.Context, subscriptionID string, claimedUserID string, query models.BillQuery) (*models.BillPage, error) {
+	ret := _m.Called(ctx, subscriptionID, claimedUserID, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBillsBySubscriptionID")
+	}
+
+	var r0 *models.BillPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.BillQuery) (*models.BillPage, error)); ok {
+		return rf(ctx, subscriptionID, claimedUserID, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.BillQuery) *models.BillPage); ok {
+		r0 = rf(ctx, subscriptionID, claimedUserID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BillPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.BillQuery) error); ok {
+		r1 = rf(ctx, subscriptionID, claimedUserID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBillServiceExternal_GetBillsBySubscriptionID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBillsBySubscriptionID'
+type MockBillServiceExternal_GetBillsBySubscriptionID_Call struct {
+	*mock.Call
+}
+
+// GetBillsBySubscriptionID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID string
+//   - claimedUserID string
+//   - query models.BillQuery
+func (_e *MockBillServiceExternal_Expecter) GetBillsBySubscriptionID(ctx interface{}, subscriptionID interface{}, claimedUserID interface{}, query interface{}) *MockBillServiceExternal_GetBillsBySubscriptionID_Call {
+	return &MockBillServiceExternal_GetBillsBySubscriptionID_Call{Call: _e.mock.On("GetBillsBySubscriptionID", ctx, subscriptionID, claimedUserID, query)}
+}
+
+func (_c *MockBillServiceExternal_GetBillsBySubscriptionID_Call) Run(run func(ctx context.Context, subscriptionID string, claimedUserID string, query models.BillQuery)) *MockBillServiceExternal_GetBillsBySubscriptionID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.BillQuery))
+	})
+	return _c
+}
+
+func (_c *MockBillServiceExternal_GetBillsBySubscriptionID_Call) Return(_a0 *models.BillPage, _a1 error) *MockBillServiceExternal_GetBillsBySubscriptionID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBillServiceExternal_GetBillsBySubscriptionID_Call) RunAndReturn(run func(context.Context, string, string, models.BillQuery) (*models.BillPage, error)) *MockBillServiceExternal_GetBillsBySubscriptionID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBillServiceExternal creates a new instance of MockBillServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBillServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBillServiceExternal {
+	mock := &MockBillServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
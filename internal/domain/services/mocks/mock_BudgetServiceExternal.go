@@ -0,0 +1,262 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockBudgetServiceExternal is an autogenerated mock type for the BudgetServiceExternal type
+type MockBudgetServiceExternal struct {
+	mock.Mock
+}
+
+type MockBudgetServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBudgetServiceExternal) EXPECT() *MockBudgetServiceExternal_Expecter {
+	return &MockBudgetServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// DeleteBudget provides a mock function with given fields: ctx, claimedUserID
+func (_m *MockBudgetServiceExternal) DeleteBudget(ctx context.Context, claimedUserID string) error {
+	ret := _m.Called(ctx, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBudget")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, claimedUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBudgetServiceExternal_DeleteBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBudget'
+type MockBudgetServiceExternal_DeleteBudget_Call struct {
+	*mock.Call
+}
+
+// DeleteBudget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+func (_e *MockBudgetServiceExternal_Expecter) DeleteBudget(ctx interface{}, claimedUserID interface{}) *MockBudgetServiceExternal_DeleteBudget_Call {
+	return &MockBudgetServiceExternal_DeleteBudget_Call{Call: _e.mock.On("DeleteBudget", ctx, claimedUserID)}
+}
+
+func (_c *MockBudgetServiceExternal_DeleteBudget_Call) Run(run func(ctx context.Context, claimedUserID string)) *MockBudgetServiceExternal_DeleteBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockBudgetServiceExternal_DeleteBudget_Call) Return(_a0 error) *MockBudgetServiceExternal_DeleteBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBudgetServiceExternal_DeleteBudget_Call) RunAndReturn(run func(context.Context, string) error) *MockBudgetServiceExternal_DeleteBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBudget provides a mock function with given fields: ctx, claimedUserID
+func (_m *MockBudgetServiceExternal) GetBudget(ctx context.Context, claimedUserID string) (*models.Budget, error) {
+	ret := _m.Called(ctx, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBudget")
+	}
+
+	var r0 *models.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Budget, error)); ok {
+		return rf(ctx, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Budget); ok {
+		r0 = rf(ctx, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBudgetServiceExternal_GetBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBudget'
+type MockBudgetServiceExternal_GetBudget_Call struct {
+	*mock.Call
+}
+
+// GetBudget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+func (_e *MockBudgetServiceExternal_Expecter) GetBudget(ctx interface{}, claimedUserID interface{}) *MockBudgetServiceExternal_GetBudget_Call {
+	return &MockBudgetServiceExternal_GetBudget_Call{Call: _e.mock.On("GetBudget", ctx, claimedUserID)}
+}
+
+func (_c *MockBudgetServiceExternal_GetBudget_Call) Run(run func(ctx context.Context, claimedUserID string)) *MockBudgetServiceExternal_GetBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockBudgetServiceExternal_GetBudget_Call) Return(_a0 *models.Budget, _a1 error) *MockBudgetServiceExternal_GetBudget_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBudgetServiceExternal_GetBudget_Call) RunAndReturn(run func(context.Context, string) (*models.Budget, error)) *MockBudgetServiceExternal_GetBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBudgetStats provides a mock function with given fields: ctx, claimedUserID
+func (_m *MockBudgetServiceExternal) GetBudgetStats(ctx context.Context, claimedUserID string) (*models.BudgetStatsResponse, error) {
+	ret := _m.Called(ctx, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBudgetStats")
+	}
+
+	var r0 *models.BudgetStatsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.BudgetStatsResponse, error)); ok {
+		return rf(ctx, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.BudgetStatsResponse); ok {
+		r0 = rf(ctx, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BudgetStatsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBudgetServiceExternal_GetBudgetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBudgetStats'
+type MockBudgetServiceExternal_GetBudgetStats_Call struct {
+	*mock.Call
+}
+
+// GetBudgetStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+func (_e *MockBudgetServiceExternal_Expecter) GetBudgetStats(ctx interface{}, claimedUserID interface{}) *MockBudgetServiceExternal_GetBudgetStats_Call {
+	return &MockBudgetServiceExternal_GetBudgetStats_Call{Call: _e.mock.On("GetBudgetStats", ctx, claimedUserID)}
+}
+
+func (_c *MockBudgetServiceExternal_GetBudgetStats_Call) Run(run func(ctx context.Context, claimedUserID string)) *MockBudgetServiceExternal_GetBudgetStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockBudgetServiceExternal_GetBudgetStats_Call) Return(_a0 *models.BudgetStatsResponse, _a1 error) *MockBudgetServiceExternal_GetBudgetStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBudgetServiceExternal_GetBudgetStats_Call) RunAndReturn(run func(context.Context, string) (*models.BudgetStatsResponse, error)) *MockBudgetServiceExternal_GetBudgetStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetBudget provides a mock function with given fields: ctx, budget, claimedUserID
+func (_m *MockBudgetServiceExternal) SetBudget(ctx context.Context, budget *models.Budget, claimedUserID string) (*models.Budget, error) {
+	ret := _m.Called(ctx, budget, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBudget")
+	}
+
+	var r0 *models.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Budget, string) (*models.Budget, error)); ok {
+		return rf(ctx, budget, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Budget, string) *models.Budget); ok {
+		r0 = rf(ctx, budget, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Budget, string) error); ok {
+		r1 = rf(ctx, budget, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBudgetServiceExternal_SetBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBudget'
+type MockBudgetServiceExternal_SetBudget_Call struct {
+	*mock.Call
+}
+
+// SetBudget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - budget *models.Budget
+//   - claimedUserID string
+func (_e *MockBudgetServiceExternal_Expecter) SetBudget(ctx interface{}, budget interface{}, claimedUserID interface{}) *MockBudgetServiceExternal_SetBudget_Call {
+	return &MockBudgetServiceExternal_SetBudget_Call{Call: _e.mock.On("SetBudget", ctx, budget, claimedUserID)}
+}
+
+func (_c *MockBudgetServiceExternal_SetBudget_Call) Run(run func(ctx context.Context, budget *models.Budget, claimedUserID string)) *MockBudgetServiceExternal_SetBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Budget), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockBudgetServiceExternal_SetBudget_Call) Return(_a0 *models.Budget, _a1 error) *MockBudgetServiceExternal_SetBudget_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBudgetServiceExternal_SetBudget_Call) RunAndReturn(run func(context.Context, *models.Budget, string) (*models.Budget, error)) *MockBudgetServiceExternal_SetBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBudgetServiceExternal creates a new instance of MockBudgetServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBudgetServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBudgetServiceExternal {
+	mock := &MockBudgetServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
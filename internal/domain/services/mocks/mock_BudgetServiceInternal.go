@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockBudgetServiceInternal is an autogenerated mock type for the BudgetServiceInternal type
+type MockBudgetServiceInternal struct {
+	mock.Mock
+}
+
+type MockBudgetServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBudgetServiceInternal) EXPECT() *MockBudgetServiceInternal_Expecter {
+	return &MockBudgetServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// DeleteBudgetInternal provides a mock function with given fields: ctx, userID
+func (_m *MockBudgetServiceInternal) DeleteBudgetInternal(ctx context.Context, userID bson.ObjectID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBudgetInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBudgetServiceInternal_DeleteBudgetInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBudgetInternal'
+type MockBudgetServiceInternal_DeleteBudgetInternal_Call struct {
+	*mock.Call
+}
+
+// DeleteBudgetInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockBudgetServiceInternal_Expecter) DeleteBudgetInternal(ctx interface{}, userID interface{}) *MockBudgetServiceInternal_DeleteBudgetInternal_Call {
+	return &MockBudgetServiceInternal_DeleteBudgetInternal_Call{Call: _e.mock.On("DeleteBudgetInternal", ctx, userID)}
+}
+
+func (_c *MockBudgetServiceInternal_DeleteBudgetInternal_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockBudgetServiceInternal_DeleteBudgetInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockBudgetServiceInternal_DeleteBudgetInternal_Call) Return(_a0 error) *MockBudgetServiceInternal_DeleteBudgetInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBudgetServiceInternal_DeleteBudgetInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockBudgetServiceInternal_DeleteBudgetInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchExceededBudgetsInternal provides a mock function with given fields: ctx
+func (_m *MockBudgetServiceInternal) FetchExceededBudgetsInternal(ctx context.Context) ([]*models.BudgetAlert, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchExceededBudgetsInternal")
+	}
+
+	var r0 []*models.BudgetAlert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.BudgetAlert, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.BudgetAlert); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.BudgetAlert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBudgetServiceInternal_FetchExceededBudgetsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchExceededBudgetsInternal'
+type MockBudgetServiceInternal_FetchExceededBudgetsInternal_Call struct {
+	*mock.Call
+}
+
+// FetchExceededBudgetsInternal is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockBudgetServiceInternal_Expecter) FetchExceededBudgetsInternal(ctx interface{}) *MockBudgetServiceInternal_FetchExceededBudgetsInternal_Call {
+	return &MockBudgetServiceInternal_FetchExceededBudgetsInternal_Call{Call: _e.mock.On("FetchExceededBudgetsInternal", ctx)}
+}
+
+func (_c *MockBudgetServiceInternal_FetchExceededBudgetsInternal_Call) Run(run func(ctx context.Context)) *MockBudgetServiceInternal_FetchExceededBudgetsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockBudgetServiceInternal_FetchExceededBudgetsInternal_Call) Return(_a0 []*models.BudgetAlert, _a1 error) *MockBudgetServiceInternal_FetchExceededBudgetsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBudgetServiceInternal_FetchExceededBudgetsInternal_Call) RunAndReturn(run func(context.Context) ([]*models.BudgetAlert, error)) *MockBudgetServiceInternal_FetchExceededBudgetsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBudgetServiceInternal creates a new instance of MockBudgetServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBudgetServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBudgetServiceInternal {
+	mock := &MockBudgetServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,247 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCalendarFeedService is an autogenerated mock type for the CalendarFeedService type
+type MockCalendarFeedService struct {
+	mock.Mock
+}
+
+type MockCalendarFeedService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCalendarFeedService) EXPECT() *MockCalendarFeedService_Expecter {
+	return &MockCalendarFeedService_Expecter{mock: &_m.Mock}
+}
+
+// GetFeedURL provides a mock function with given fields: ctx, claimedUserID
+func (_m *MockCalendarFeedService) GetFeedURL(ctx context.Context, claimedUserID string) (*models.CalendarFeed, error) {
+	ret := _m.Called(ctx, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeedURL")
+	}
+
+	var r0 *models.CalendarFeed
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CalendarFeed, error)); ok {
+		return rf(ctx, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CalendarFeed); ok {
+		r0 = rf(ctx, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CalendarFeed)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCalendarFeedService_GetFeedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeedURL'
+type MockCalendarFeedService_GetFeedURL_Call struct {
+	*mock.Call
+}
+
+// GetFeedURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+func (_e *MockCalendarFeedService_Expecter) GetFeedURL(ctx interface{}, claimedUserID interface{}) *MockCalendarFeedService_GetFeedURL_Call {
+	return &MockCalendarFeedService_GetFeedURL_Call{Call: _e.mock.On("GetFeedURL", ctx, claimedUserID)}
+}
+
+func (_c *MockCalendarFeedService_GetFeedURL_Call) Run(run func(ctx context.Context, claimedUserID string)) *MockCalendarFeedService_GetFeedURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCalendarFeedService_GetFeedURL_Call) Return(_a0 *models.CalendarFeed, _a1 error) *MockCalendarFeedService_GetFeedURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCalendarFeedService_GetFeedURL_Call) RunAndReturn(run func(context.Context, string) (*models.CalendarFeed, error)) *MockCalendarFeedService_GetFeedURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegenerateFeedURL provides a mock function with given fields: ctx, claimedUserID
+func (_m *MockCalendarFeedService) RegenerateFeedURL(ctx context.Context, claimedUserID string) (*models.CalendarFeed, error) {
+	ret := _m.Called(ctx, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateFeedURL")
+	}
+
+	var r0 *models.CalendarFeed
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CalendarFeed, error)); ok {
+		return rf(ctx, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CalendarFeed); ok {
+		r0 = rf(ctx, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CalendarFeed)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCalendarFeedService_RegenerateFeedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateFeedURL'
+type MockCalendarFeedService_RegenerateFeedURL_Call struct {
+	*mock.Call
+}
+
+// RegenerateFeedURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+func (_e *MockCalendarFeedService_Expecter) RegenerateFeedURL(ctx interface{}, claimedUserID interface{}) *MockCalendarFeedService_RegenerateFeedURL_Call {
+	return &MockCalendarFeedService_RegenerateFeedURL_Call{Call: _e.mock.On("RegenerateFeedURL", ctx, claimedUserID)}
+}
+
+func (_c *MockCalendarFeedService_RegenerateFeedURL_Call) Run(run func(ctx context.Context, claimedUserID string)) *MockCalendarFeedService_RegenerateFeedURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCalendarFeedService_RegenerateFeedURL_Call) Return(_a0 *models.CalendarFeed, _a1 error) *MockCalendarFeedService_RegenerateFeedURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCalendarFeedService_RegenerateFeedURL_Call) RunAndReturn(run func(context.Context, string) (*models.CalendarFeed, error)) *MockCalendarFeedService_RegenerateFeedURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenderFeed provides a mock function with given fields: ctx, token
+func (_m *MockCalendarFeedService) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderFeed")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCalendarFeedService_RenderFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenderFeed'
+type MockCalendarFeedService_RenderFeed_Call struct {
+	*mock.Call
+}
+
+// RenderFeed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockCalendarFeedService_Expecter) RenderFeed(ctx interface{}, token interface{}) *MockCalendarFeedService_RenderFeed_Call {
+	return &MockCalendarFeedService_RenderFeed_Call{Call: _e.mock.On("RenderFeed", ctx, token)}
+}
+
+func (_c *MockCalendarFeedService_RenderFeed_Call) Run(run func(ctx context.Context, token string)) *MockCalendarFeedService_RenderFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCalendarFeedService_RenderFeed_Call) Return(_a0 []byte, _a1 error) *MockCalendarFeedService_RenderFeed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCalendarFeedService_RenderFeed_Call) RunAndReturn(run func(context.Context, string) ([]byte, error)) *MockCalendarFeedService_RenderFeed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetReminderDays provides a mock function with given fields: days
+func (_m *MockCalendarFeedService) SetReminderDays(days []int) {
+	_m.Called(days)
+}
+
+// MockCalendarFeedService_SetReminderDays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReminderDays'
+type MockCalendarFeedService_SetReminderDays_Call struct {
+	*mock.Call
+}
+
+// SetReminderDays is a helper method to define mock.On call
+//   - days []int
+func (_e *MockCalendarFeedService_Expecter) SetReminderDays(days interface{}) *MockCalendarFeedService_SetReminderDays_Call {
+	return &MockCalendarFeedService_SetReminderDays_Call{Call: _e.mock.On("SetReminderDays", days)}
+}
+
+func (_c *MockCalendarFeedService_SetReminderDays_Call) Run(run func(days []int)) *MockCalendarFeedService_SetReminderDays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]int))
+	})
+	return _c
+}
+
+func (_c *MockCalendarFeedService_SetReminderDays_Call) Return() *MockCalendarFeedService_SetReminderDays_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockCalendarFeedService_SetReminderDays_Call) RunAndReturn(run func([]int)) *MockCalendarFeedService_SetReminderDays_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockCalendarFeedService creates a new instance of MockCalendarFeedService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCalendarFeedService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCalendarFeedService {
+	mock := &MockCalendarFeedService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCancelLinkServiceExternal is an autogenerated mock type for the CancelLinkServiceExternal type
+type MockCancelLinkServiceExternal struct {
+	mock.Mock
+}
+
+type MockCancelLinkServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCancelLinkServiceExternal) EXPECT() *MockCancelLinkServiceExternal_Expecter {
+	return &MockCancelLinkServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// CancelWithLink provides a mock function with given fields: ctx, id, expires, signature
+func (_m *MockCancelLinkServiceExternal) CancelWithLink(ctx context.Context, id string, expires string, signature string) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, expires, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelWithLink")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.Subscription, error)); ok {
+		return rf(ctx, id, expires, signature)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.Subscription); ok {
+		r0 = rf(ctx, id, expires, signature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, id, expires, signature)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCancelLinkServiceExternal_CancelWithLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelWithLink'
+type MockCancelLinkServiceExternal_CancelWithLink_Call struct {
+	*mock.Call
+}
+
+// CancelWithLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - expires string
+//   - signature string
+func (_e *MockCancelLinkServiceExternal_Expecter) CancelWithLink(ctx interface{}, id interface{}, expires interface{}, signature interface{}) *MockCancelLinkServiceExternal_CancelWithLink_Call {
+	return &MockCancelLinkServiceExternal_CancelWithLink_Call{Call: _e.mock.On("CancelWithLink", ctx, id, expires, signature)}
+}
+
+func (_c *MockCancelLinkServiceExternal_CancelWithLink_Call) Run(run func(ctx context.Context, id string, expires string, signature string)) *MockCancelLinkServiceExternal_CancelWithLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockCancelLinkServiceExternal_CancelWithLink_Call) Return(_a0 *models.Subscription, _a1 error) *MockCancelLinkServiceExternal_CancelWithLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCancelLinkServiceExternal_CancelWithLink_Call) RunAndReturn(run func(context.Context, string, string, string) (*models.Subscription, error)) *MockCancelLinkServiceExternal_CancelWithLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCancelLinkServiceExternal creates a new instance of MockCancelLinkServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCancelLinkServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCancelLinkServiceExternal {
+	mock := &MockCancelLinkServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,81 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCancelLinkServiceInternal is an autogenerated mock type for the CancelLinkServiceInternal type
+type MockCancelLinkServiceInternal struct {
+	mock.Mock
+}
+
+type MockCancelLinkServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCancelLinkServiceInternal) EXPECT() *MockCancelLinkServiceInternal_Expecter {
+	return &MockCancelLinkServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// SignCancelLinkInternal provides a mock function with given fields: _a0
+func (_m *MockCancelLinkServiceInternal) SignCancelLinkInternal(_a0 *models.Subscription) string {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignCancelLinkInternal")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*models.Subscription) string); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockCancelLinkServiceInternal_SignCancelLinkInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignCancelLinkInternal'
+type MockCancelLinkServiceInternal_SignCancelLinkInternal_Call struct {
+	*mock.Call
+}
+
+// SignCancelLinkInternal is a helper method to define mock.On call
+//   - _a0 *models.Subscription
+func (_e *MockCancelLinkServiceInternal_Expecter) SignCancelLinkInternal(_a0 interface{}) *MockCancelLinkServiceInternal_SignCancelLinkInternal_Call {
+	return &MockCancelLinkServiceInternal_SignCancelLinkInternal_Call{Call: _e.mock.On("SignCancelLinkInternal", _a0)}
+}
+
+func (_c *MockCancelLinkServiceInternal_SignCancelLinkInternal_Call) Run(run func(_a0 *models.Subscription)) *MockCancelLinkServiceInternal_SignCancelLinkInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Subscription))
+	})
+	return _c
+}
+
+func (_c *MockCancelLinkServiceInternal_SignCancelLinkInternal_Call) Return(_a0 string) *MockCancelLinkServiceInternal_SignCancelLinkInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCancelLinkServiceInternal_SignCancelLinkInternal_Call) RunAndReturn(run func(*models.Subscription) string) *MockCancelLinkServiceInternal_SignCancelLinkInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCancelLinkServiceInternal creates a new instance of MockCancelLinkServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCancelLinkServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCancelLinkServiceInternal {
+	mock := &MockCancelLinkServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,205 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCatalogServiceExternal is an autogenerated mock type for the CatalogServiceExternal type
+type MockCatalogServiceExternal struct {
+	mock.Mock
+}
+
+type MockCatalogServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCatalogServiceExternal) EXPECT() *MockCatalogServiceExternal_Expecter {
+	return &MockCatalogServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// GetCatalogEntry provides a mock function with given fields: ctx, id
+func (_m *MockCatalogServiceExternal) GetCatalogEntry(ctx context.Context, id string) (*models.CatalogEntry, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCatalogEntry")
+	}
+
+	var r0 *models.CatalogEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CatalogEntry, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CatalogEntry); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CatalogEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCatalogServiceExternal_GetCatalogEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCatalogEntry'
+type MockCatalogServiceExternal_GetCatalogEntry_Call struct {
+	*mock.Call
+}
+
+// GetCatalogEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockCatalogServiceExternal_Expecter) GetCatalogEntry(ctx interface{}, id interface{}) *MockCatalogServiceExternal_GetCatalogEntry_Call {
+	return &MockCatalogServiceExternal_GetCatalogEntry_Call{Call: _e.mock.On("GetCatalogEntry", ctx, id)}
+}
+
+func (_c *MockCatalogServiceExternal_GetCatalogEntry_Call) Run(run func(ctx context.Context, id string)) *MockCatalogServiceExternal_GetCatalogEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCatalogServiceExternal_GetCatalogEntry_Call) Return(_a0 *models.CatalogEntry, _a1 error) *MockCatalogServiceExternal_GetCatalogEntry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCatalogServiceExternal_GetCatalogEntry_Call) RunAndReturn(run func(context.Context, string) (*models.CatalogEntry, error)) *MockCatalogServiceExternal_GetCatalogEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PrefillSubscription provides a mock function with given fields: ctx, subscription, catalogID, plan
+func (_m *MockCatalogServiceExternal) PrefillSubscription(ctx context.Context, subscription *models.Subscription, catalogID string, plan string) error {
+	ret := _m.Called(ctx, subscription, catalogID, plan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrefillSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Subscription, string, string) error); ok {
+		r0 = rf(ctx, subscription, catalogID, plan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCatalogServiceExternal_PrefillSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrefillSubscription'
+type MockCatalogServiceExternal_PrefillSubscription_Call struct {
+	*mock.Call
+}
+
+// PrefillSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription *models.Subscription
+//   - catalogID string
+//   - plan string
+func (_e *MockCatalogServiceExternal_Expecter) PrefillSubscription(ctx interface{}, subscription interface{}, catalogID interface{}, plan interface{}) *MockCatalogServiceExternal_PrefillSubscription_Call {
+	return &MockCatalogServiceExternal_PrefillSubscription_Call{Call: _e.mock.On("PrefillSubscription", ctx, subscription, catalogID, plan)}
+}
+
+func (_c *MockCatalogServiceExternal_PrefillSubscription_Call) Run(run func(ctx context.Context, subscription *models.Subscription, catalogID string, plan string)) *MockCatalogServiceExternal_PrefillSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Subscription), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockCatalogServiceExternal_PrefillSubscription_Call) Return(_a0 error) *MockCatalogServiceExternal_PrefillSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCatalogServiceExternal_PrefillSubscription_Call) RunAndReturn(run func(context.Context, *models.Subscription, string, string) error) *MockCatalogServiceExternal_PrefillSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchCatalog provides a mock function with given fields: ctx, query, limit
+func (_m *MockCatalogServiceExternal) SearchCatalog(ctx context.Context, query string, limit int) ([]*models.CatalogEntry, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchCatalog")
+	}
+
+	var r0 []*models.CatalogEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*models.CatalogEntry, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*models.CatalogEntry); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CatalogEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCatalogServiceExternal_SearchCatalog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchCatalog'
+type MockCatalogServiceExternal_SearchCatalog_Call struct {
+	*mock.Call
+}
+
+// SearchCatalog is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int
+func (_e *MockCatalogServiceExternal_Expecter) SearchCatalog(ctx interface{}, query interface{}, limit interface{}) *MockCatalogServiceExternal_SearchCatalog_Call {
+	return &MockCatalogServiceExternal_SearchCatalog_Call{Call: _e.mock.On("SearchCatalog", ctx, query, limit)}
+}
+
+func (_c *MockCatalogServiceExternal_SearchCatalog_Call) Run(run func(ctx context.Context, query string, limit int)) *MockCatalogServiceExternal_SearchCatalog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockCatalogServiceExternal_SearchCatalog_Call) Return(_a0 []*models.CatalogEntry, _a1 error) *MockCatalogServiceExternal_SearchCatalog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCatalogServiceExternal_SearchCatalog_Call) RunAndReturn(run func(context.Context, string, int) ([]*models.CatalogEntry, error)) *MockCatalogServiceExternal_SearchCatalog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCatalogServiceExternal creates a new instance of MockCatalogServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCatalogServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCatalogServiceExternal {
+	mock := &MockCatalogServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDeadTaskQueue is an autogenerated mock type for the DeadTaskQueue type
+type MockDeadTaskQueue struct {
+	mock.Mock
+}
+
+type MockDeadTaskQueue_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeadTaskQueue) EXPECT() *MockDeadTaskQueue_Expecter {
+	return &MockDeadTaskQueue_Expecter{mock: &_m.Mock}
+}
+
+// RequeueDeadTask provides a mock function with given fields: ctx, task
+func (_m *MockDeadTaskQueue) RequeueDeadTask(ctx context.Context, task *models.DeadTask) (string, error) {
+	ret := _m.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueDeadTask")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeadTask) (string, error)); ok {
+		return rf(ctx, task)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeadTask) string); ok {
+		r0 = rf(ctx, task)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DeadTask) error); ok {
+		r1 = rf(ctx, task)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeadTaskQueue_RequeueDeadTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueDeadTask'
+type MockDeadTaskQueue_RequeueDeadTask_Call struct {
+	*mock.Call
+}
+
+// RequeueDeadTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *models.DeadTask
+func (_e *MockDeadTaskQueue_Expecter) RequeueDeadTask(ctx interface{}, task interface{}) *MockDeadTaskQueue_RequeueDeadTask_Call {
+	return &MockDeadTaskQueue_RequeueDeadTask_Call{Call: _e.mock.On("RequeueDeadTask", ctx, task)}
+}
+
+func (_c *MockDeadTaskQueue_RequeueDeadTask_Call) Run(run func(ctx context.Context, task *models.DeadTask)) *MockDeadTaskQueue_RequeueDeadTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.DeadTask))
+	})
+	return _c
+}
+
+func (_c *MockDeadTaskQueue_RequeueDeadTask_Call) Return(_a0 string, _a1 error) *MockDeadTaskQueue_RequeueDeadTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeadTaskQueue_RequeueDeadTask_Call) RunAndReturn(run func(context.Context, *models.DeadTask) (string, error)) *MockDeadTaskQueue_RequeueDeadTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeadTaskQueue creates a new instance of MockDeadTaskQueue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeadTaskQueue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeadTaskQueue {
+	mock := &MockDeadTaskQueue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,214 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDeadTaskServiceExternal is an autogenerated mock type for the DeadTaskServiceExternal type
+type MockDeadTaskServiceExternal struct {
+	mock.Mock
+}
+
+type MockDeadTaskServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeadTaskServiceExternal) EXPECT() *MockDeadTaskServiceExternal_Expecter {
+	return &MockDeadTaskServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// GetDeadTask provides a mock function with given fields: ctx, id
+func (_m *MockDeadTaskServiceExternal) GetDeadTask(ctx context.Context, id string) (*models.DeadTask, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadTask")
+	}
+
+	var r0 *models.DeadTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.DeadTask, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.DeadTask); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeadTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeadTaskServiceExternal_GetDeadTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeadTask'
+type MockDeadTaskServiceExternal_GetDeadTask_Call struct {
+	*mock.Call
+}
+
+// GetDeadTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeadTaskServiceExternal_Expecter) GetDeadTask(ctx interface{}, id interface{}) *MockDeadTaskServiceExternal_GetDeadTask_Call {
+	return &MockDeadTaskServiceExternal_GetDeadTask_Call{Call: _e.mock.On("GetDeadTask", ctx, id)}
+}
+
+func (_c *MockDeadTaskServiceExternal_GetDeadTask_Call) Run(run func(ctx context.Context, id string)) *MockDeadTaskServiceExternal_GetDeadTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDeadTaskServiceExternal_GetDeadTask_Call) Return(_a0 *models.DeadTask, _a1 error) *MockDeadTaskServiceExternal_GetDeadTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeadTaskServiceExternal_GetDeadTask_Call) RunAndReturn(run func(context.Context, string) (*models.DeadTask, error)) *MockDeadTaskServiceExternal_GetDeadTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeadTasks provides a mock function with given fields: ctx, query
+func (_m *MockDeadTaskServiceExternal) GetDeadTasks(ctx context.Context, query models.DeadTaskQuery) (*models.DeadTaskPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadTasks")
+	}
+
+	var r0 *models.DeadTaskPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.DeadTaskQuery) (*models.DeadTaskPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.DeadTaskQuery) *models.DeadTaskPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeadTaskPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.DeadTaskQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeadTaskServiceExternal_GetDeadTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeadTasks'
+type MockDeadTaskServiceExternal_GetDeadTasks_Call struct {
+	*mock.Call
+}
+
+// GetDeadTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query models.DeadTaskQuery
+func (_e *MockDeadTaskServiceExternal_Expecter) GetDeadTasks(ctx interface{}, query interface{}) *MockDeadTaskServiceExternal_GetDeadTasks_Call {
+	return &MockDeadTaskServiceExternal_GetDeadTasks_Call{Call: _e.mock.On("GetDeadTasks", ctx, query)}
+}
+
+func (_c *MockDeadTaskServiceExternal_GetDeadTasks_Call) Run(run func(ctx context.Context, query models.DeadTaskQuery)) *MockDeadTaskServiceExternal_GetDeadTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.DeadTaskQuery))
+	})
+	return _c
+}
+
+func (_c *MockDeadTaskServiceExternal_GetDeadTasks_Call) Return(_a0 *models.DeadTaskPage, _a1 error) *MockDeadTaskServiceExternal_GetDeadTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeadTaskServiceExternal_GetDeadTasks_Call) RunAndReturn(run func(context.Context, models.DeadTaskQuery) (*models.DeadTaskPage, error)) *MockDeadTaskServiceExternal_GetDeadTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequeueDeadTask provides a mock function with given fields: ctx, id
+func (_m *MockDeadTaskServiceExternal) RequeueDeadTask(ctx context.Context, id string) (*models.DeadTask, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueDeadTask")
+	}
+
+	var r0 *models.DeadTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.DeadTask, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.DeadTask); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeadTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeadTaskServiceExternal_RequeueDeadTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueDeadTask'
+type MockDeadTaskServiceExternal_RequeueDeadTask_Call struct {
+	*mock.Call
+}
+
+// RequeueDeadTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeadTaskServiceExternal_Expecter) RequeueDeadTask(ctx interface{}, id interface{}) *MockDeadTaskServiceExternal_RequeueDeadTask_Call {
+	return &MockDeadTaskServiceExternal_RequeueDeadTask_Call{Call: _e.mock.On("RequeueDeadTask", ctx, id)}
+}
+
+func (_c *MockDeadTaskServiceExternal_RequeueDeadTask_Call) Run(run func(ctx context.Context, id string)) *MockDeadTaskServiceExternal_RequeueDeadTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDeadTaskServiceExternal_RequeueDeadTask_Call) Return(_a0 *models.DeadTask, _a1 error) *MockDeadTaskServiceExternal_RequeueDeadTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeadTaskServiceExternal_RequeueDeadTask_Call) RunAndReturn(run func(context.Context, string) (*models.DeadTask, error)) *MockDeadTaskServiceExternal_RequeueDeadTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeadTaskServiceExternal creates a new instance of MockDeadTaskServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeadTaskServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeadTaskServiceExternal {
+	mock := &MockDeadTaskServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDeadTaskServiceInternal is an autogenerated mock type for the DeadTaskServiceInternal type
+type MockDeadTaskServiceInternal struct {
+	mock.Mock
+}
+
+type MockDeadTaskServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeadTaskServiceInternal) EXPECT() *MockDeadTaskServiceInternal_Expecter {
+	return &MockDeadTaskServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// RecordDeadTaskInternal provides a mock function with given fields: ctx, task
+func (_m *MockDeadTaskServiceInternal) RecordDeadTaskInternal(ctx context.Context, task *models.DeadTask) error {
+	ret := _m.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDeadTaskInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeadTask) error); ok {
+		r0 = rf(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDeadTaskServiceInternal_RecordDeadTaskInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordDeadTaskInternal'
+type MockDeadTaskServiceInternal_RecordDeadTaskInternal_Call struct {
+	*mock.Call
+}
+
+// RecordDeadTaskInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *models.DeadTask
+func (_e *MockDeadTaskServiceInternal_Expecter) RecordDeadTaskInternal(ctx interface{}, task interface{}) *MockDeadTaskServiceInternal_RecordDeadTaskInternal_Call {
+	return &MockDeadTaskServiceInternal_RecordDeadTaskInternal_Call{Call: _e.mock.On("RecordDeadTaskInternal", ctx, task)}
+}
+
+func (_c *MockDeadTaskServiceInternal_RecordDeadTaskInternal_Call) Run(run func(ctx context.Context, task *models.DeadTask)) *MockDeadTaskServiceInternal_RecordDeadTaskInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.DeadTask))
+	})
+	return _c
+}
+
+func (_c *MockDeadTaskServiceInternal_RecordDeadTaskInternal_Call) Return(_a0 error) *MockDeadTaskServiceInternal_RecordDeadTaskInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDeadTaskServiceInternal_RecordDeadTaskInternal_Call) RunAndReturn(run func(context.Context, *models.DeadTask) error) *MockDeadTaskServiceInternal_RecordDeadTaskInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeadTaskServiceInternal creates a new instance of MockDeadTaskServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeadTaskServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeadTaskServiceInternal {
+	mock := &MockDeadTaskServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,204 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEmailPreviewService is an autogenerated mock type for the EmailPreviewService type
+type MockEmailPreviewService struct {
+	mock.Mock
+}
+
+type MockEmailPreviewService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEmailPreviewService) EXPECT() *MockEmailPreviewService_Expecter {
+	return &MockEmailPreviewService_Expecter{mock: &_m.Mock}
+}
+
+// GetEmailTemplates provides a mock function with given fields: ctx
+func (_m *MockEmailPreviewService) GetEmailTemplates(ctx context.Context) []models.EmailTemplate {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEmailTemplates")
+	}
+
+	var r0 []models.EmailTemplate
+	if rf, ok := ret.Get(0).(func(context.Context) []models.EmailTemplate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.EmailTemplate)
+		}
+	}
+
+	return r0
+}
+
+// MockEmailPreviewService_GetEmailTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEmailTemplates'
+type MockEmailPreviewService_GetEmailTemplates_Call struct {
+	*mock.Call
+}
+
+// GetEmailTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEmailPreviewService_Expecter) GetEmailTemplates(ctx interface{}) *MockEmailPreviewService_GetEmailTemplates_Call {
+	return &MockEmailPreviewService_GetEmailTemplates_Call{Call: _e.mock.On("GetEmailTemplates", ctx)}
+}
+
+func (_c *MockEmailPreviewService_GetEmailTemplates_Call) Run(run func(ctx context.Context)) *MockEmailPreviewService_GetEmailTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockEmailPreviewService_GetEmailTemplates_Call) Return(_a0 []models.EmailTemplate) *MockEmailPreviewService_GetEmailTemplates_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEmailPreviewService_GetEmailTemplates_Call) RunAndReturn(run func(context.Context) []models.EmailTemplate) *MockEmailPreviewService_GetEmailTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewEmail provides a mock function with given fields: ctx, request
+func (_m *MockEmailPreviewService) PreviewEmail(ctx context.Context, request *models.EmailPreviewRequest) (*models.EmailPreview, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewEmail")
+	}
+
+	var r0 *models.EmailPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmailPreviewRequest) (*models.EmailPreview, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmailPreviewRequest) *models.EmailPreview); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmailPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.EmailPreviewRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmailPreviewService_PreviewEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewEmail'
+type MockEmailPreviewService_PreviewEmail_Call struct {
+	*mock.Call
+}
+
+// PreviewEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *models.EmailPreviewRequest
+func (_e *MockEmailPreviewService_Expecter) PreviewEmail(ctx interface{}, request interface{}) *MockEmailPreviewService_PreviewEmail_Call {
+	return &MockEmailPreviewService_PreviewEmail_Call{Call: _e.mock.On("PreviewEmail", ctx, request)}
+}
+
+func (_c *MockEmailPreviewService_PreviewEmail_Call) Run(run func(ctx context.Context, request *models.EmailPreviewRequest)) *MockEmailPreviewService_PreviewEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EmailPreviewRequest))
+	})
+	return _c
+}
+
+func (_c *MockEmailPreviewService_PreviewEmail_Call) Return(_a0 *models.EmailPreview, _a1 error) *MockEmailPreviewService_PreviewEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmailPreviewService_PreviewEmail_Call) RunAndReturn(run func(context.Context, *models.EmailPreviewRequest) (*models.EmailPreview, error)) *MockEmailPreviewService_PreviewEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendTestEmail provides a mock function with given fields: ctx, request, claimedUserID
+func (_m *MockEmailPreviewService) SendTestEmail(ctx context.Context, request *models.EmailPreviewRequest, claimedUserID string) (*models.EmailPreview, error) {
+	ret := _m.Called(ctx, request, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendTestEmail")
+	}
+
+	var r0 *models.EmailPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmailPreviewRequest, string) (*models.EmailPreview, error)); ok {
+		return rf(ctx, request, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmailPreviewRequest, string) *models.EmailPreview); ok {
+		r0 = rf(ctx, request, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmailPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.EmailPreviewRequest, string) error); ok {
+		r1 = rf(ctx, request, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmailPreviewService_SendTestEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTestEmail'
+type MockEmailPreviewService_SendTestEmail_Call struct {
+	*mock.Call
+}
+
+// SendTestEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *models.EmailPreviewRequest
+//   - claimedUserID string
+func (_e *MockEmailPreviewService_Expecter) SendTestEmail(ctx interface{}, request interface{}, claimedUserID interface{}) *MockEmailPreviewService_SendTestEmail_Call {
+	return &MockEmailPreviewService_SendTestEmail_Call{Call: _e.mock.On("SendTestEmail", ctx, request, claimedUserID)}
+}
+
+func (_c *MockEmailPreviewService_SendTestEmail_Call) Run(run func(ctx context.Context, request *models.EmailPreviewRequest, claimedUserID string)) *MockEmailPreviewService_SendTestEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EmailPreviewRequest), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockEmailPreviewService_SendTestEmail_Call) Return(_a0 *models.EmailPreview, _a1 error) *MockEmailPreviewService_SendTestEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmailPreviewService_SendTestEmail_Call) RunAndReturn(run func(context.Context, *models.EmailPreviewRequest, string) (*models.EmailPreview, error)) *MockEmailPreviewService_SendTestEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEmailPreviewService creates a new instance of MockEmailPreviewService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEmailPreviewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEmailPreviewService {
+	mock := &MockEmailPreviewService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,205 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEmailPreviewer is an autogenerated mock type for the EmailPreviewer type
+type MockEmailPreviewer struct {
+	mock.Mock
+}
+
+type MockEmailPreviewer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEmailPreviewer) EXPECT() *MockEmailPreviewer_Expecter {
+	return &MockEmailPreviewer_Expecter{mock: &_m.Mock}
+}
+
+// EmailTemplates provides a mock function with no fields
+func (_m *MockEmailPreviewer) EmailTemplates() []models.EmailTemplate {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmailTemplates")
+	}
+
+	var r0 []models.EmailTemplate
+	if rf, ok := ret.Get(0).(func() []models.EmailTemplate); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.EmailTemplate)
+		}
+	}
+
+	return r0
+}
+
+// MockEmailPreviewer_EmailTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EmailTemplates'
+type MockEmailPreviewer_EmailTemplates_Call struct {
+	*mock.Call
+}
+
+// EmailTemplates is a helper method to define mock.On call
+func (_e *MockEmailPreviewer_Expecter) EmailTemplates() *MockEmailPreviewer_EmailTemplates_Call {
+	return &MockEmailPreviewer_EmailTemplates_Call{Call: _e.mock.On("EmailTemplates")}
+}
+
+func (_c *MockEmailPreviewer_EmailTemplates_Call) Run(run func()) *MockEmailPreviewer_EmailTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockEmailPreviewer_EmailTemplates_Call) Return(_a0 []models.EmailTemplate) *MockEmailPreviewer_EmailTemplates_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEmailPreviewer_EmailTemplates_Call) RunAndReturn(run func() []models.EmailTemplate) *MockEmailPreviewer_EmailTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewEmail provides a mock function with given fields: ctx, template, data
+func (_m *MockEmailPreviewer) PreviewEmail(ctx context.Context, template string, data *models.EmailPreviewData) (*models.EmailPreview, error) {
+	ret := _m.Called(ctx, template, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewEmail")
+	}
+
+	var r0 *models.EmailPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.EmailPreviewData) (*models.EmailPreview, error)); ok {
+		return rf(ctx, template, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.EmailPreviewData) *models.EmailPreview); ok {
+		r0 = rf(ctx, template, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmailPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.EmailPreviewData) error); ok {
+		r1 = rf(ctx, template, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmailPreviewer_PreviewEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewEmail'
+type MockEmailPreviewer_PreviewEmail_Call struct {
+	*mock.Call
+}
+
+// PreviewEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - template string
+//   - data *models.EmailPreviewData
+func (_e *MockEmailPreviewer_Expecter) PreviewEmail(ctx interface{}, template interface{}, data interface{}) *MockEmailPreviewer_PreviewEmail_Call {
+	return &MockEmailPreviewer_PreviewEmail_Call{Call: _e.mock.On("PreviewEmail", ctx, template, data)}
+}
+
+func (_c *MockEmailPreviewer_PreviewEmail_Call) Run(run func(ctx context.Context, template string, data *models.EmailPreviewData)) *MockEmailPreviewer_PreviewEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.EmailPreviewData))
+	})
+	return _c
+}
+
+func (_c *MockEmailPreviewer_PreviewEmail_Call) Return(_a0 *models.EmailPreview, _a1 error) *MockEmailPreviewer_PreviewEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmailPreviewer_PreviewEmail_Call) RunAndReturn(run func(context.Context, string, *models.EmailPreviewData) (*models.EmailPreview, error)) *MockEmailPreviewer_PreviewEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendTestEmail provides a mock function with given fields: ctx, to, template, data
+func (_m *MockEmailPreviewer) SendTestEmail(ctx context.Context, to string, template string, data *models.EmailPreviewData) (*models.EmailPreview, error) {
+	ret := _m.Called(ctx, to, template, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendTestEmail")
+	}
+
+	var r0 *models.EmailPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.EmailPreviewData) (*models.EmailPreview, error)); ok {
+		return rf(ctx, to, template, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.EmailPreviewData) *models.EmailPreview); ok {
+		r0 = rf(ctx, to, template, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmailPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.EmailPreviewData) error); ok {
+		r1 = rf(ctx, to, template, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmailPreviewer_SendTestEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTestEmail'
+type MockEmailPreviewer_SendTestEmail_Call struct {
+	*mock.Call
+}
+
+// SendTestEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - to string
+//   - template string
+//   - data *models.EmailPreviewData
+func (_e *MockEmailPreviewer_Expecter) SendTestEmail(ctx interface{}, to interface{}, template interface{}, data interface{}) *MockEmailPreviewer_SendTestEmail_Call {
+	return &MockEmailPreviewer_SendTestEmail_Call{Call: _e.mock.On("SendTestEmail", ctx, to, template, data)}
+}
+
+func (_c *MockEmailPreviewer_SendTestEmail_Call) Run(run func(ctx context.Context, to string, template string, data *models.EmailPreviewData)) *MockEmailPreviewer_SendTestEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.EmailPreviewData))
+	})
+	return _c
+}
+
+func (_c *MockEmailPreviewer_SendTestEmail_Call) Return(_a0 *models.EmailPreview, _a1 error) *MockEmailPreviewer_SendTestEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmailPreviewer_SendTestEmail_Call) RunAndReturn(run func(context.Context, string, string, *models.EmailPreviewData) (*models.EmailPreview, error)) *MockEmailPreviewer_SendTestEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEmailPreviewer creates a new instance of MockEmailPreviewer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEmailPreviewer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEmailPreviewer {
+	mock := &MockEmailPreviewer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockExportQueue is an autogenerated mock type for the ExportQueue type
+type MockExportQueue struct {
+	mock.Mock
+}
+
+type MockExportQueue_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportQueue) EXPECT() *MockExportQueue_Expecter {
+	return &MockExportQueue_Expecter{mock: &_m.Mock}
+}
+
+// EnqueueExport provides a mock function with given fields: ctx, request
+func (_m *MockExportQueue) EnqueueExport(ctx context.Context, request models.ExportRequest) error {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ExportRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExportQueue_EnqueueExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueExport'
+type MockExportQueue_EnqueueExport_Call struct {
+	*mock.Call
+}
+
+// EnqueueExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request models.ExportRequest
+func (_e *MockExportQueue_Expecter) EnqueueExport(ctx interface{}, request interface{}) *MockExportQueue_EnqueueExport_Call {
+	return &MockExportQueue_EnqueueExport_Call{Call: _e.mock.On("EnqueueExport", ctx, request)}
+}
+
+func (_c *MockExportQueue_EnqueueExport_Call) Run(run func(ctx context.Context, request models.ExportRequest)) *MockExportQueue_EnqueueExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ExportRequest))
+	})
+	return _c
+}
+
+func (_c *MockExportQueue_EnqueueExport_Call) Return(_a0 error) *MockExportQueue_EnqueueExport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExportQueue_EnqueueExport_Call) RunAndReturn(run func(context.Context, models.ExportRequest) error) *MockExportQueue_EnqueueExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportQueue creates a new instance of MockExportQueue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportQueue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportQueue {
+	mock := &MockExportQueue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,157 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockExportServiceExternal is an autogenerated mock type for the ExportServiceExternal type
+type MockExportServiceExternal struct {
+	mock.Mock
+}
+
+type MockExportServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportServiceExternal) EXPECT() *MockExportServiceExternal_Expecter {
+	return &MockExportServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// Export provides a mock function with given fields: ctx, claimedUserID, request
+func (_m *MockExportServiceExternal) Export(ctx context.Context, claimedUserID string, request models.ExportRequest) (*models.Export, error) {
+	ret := _m.Called(ctx, claimedUserID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 *models.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ExportRequest) (*models.Export, error)); ok {
+		return rf(ctx, claimedUserID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ExportRequest) *models.Export); ok {
+		r0 = rf(ctx, claimedUserID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ExportRequest) error); ok {
+		r1 = rf(ctx, claimedUserID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExportServiceExternal_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockExportServiceExternal_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+//   - request models.ExportRequest
+func (_e *MockExportServiceExternal_Expecter) Export(ctx interface{}, claimedUserID interface{}, request interface{}) *MockExportServiceExternal_Export_Call {
+	return &MockExportServiceExternal_Export_Call{Call: _e.mock.On("Export", ctx, claimedUserID, request)}
+}
+
+func (_c *MockExportServiceExternal_Export_Call) Run(run func(ctx context.Context, claimedUserID string, request models.ExportRequest)) *MockExportServiceExternal_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ExportRequest))
+	})
+	return _c
+}
+
+func (_c *MockExportServiceExternal_Export_Call) Return(_a0 *models.Export, _a1 error) *MockExportServiceExternal_Export_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExportServiceExternal_Export_Call) RunAndReturn(run func(context.Context, string, models.ExportRequest) (*models.Export, error)) *MockExportServiceExternal_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportAccount provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockExportServiceExternal) ExportAccount(ctx context.Context, id string, claimedUserID string) (*models.Export, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportAccount")
+	}
+
+	var r0 *models.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Export, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Export); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExportServiceExternal_ExportAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportAccount'
+type MockExportServiceExternal_ExportAccount_Call struct {
+	*mock.Call
+}
+
+// ExportAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockExportServiceExternal_Expecter) ExportAccount(ctx interface{}, id interface{}, claimedUserID interface{}) *MockExportServiceExternal_ExportAccount_Call {
+	return &MockExportServiceExternal_ExportAccount_Call{Call: _e.mock.On("ExportAccount", ctx, id, claimedUserID)}
+}
+
+func (_c *MockExportServiceExternal_ExportAccount_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockExportServiceExternal_ExportAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockExportServiceExternal_ExportAccount_Call) Return(_a0 *models.Export, _a1 error) *MockExportServiceExternal_ExportAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExportServiceExternal_ExportAccount_Call) RunAndReturn(run func(context.Context, string, string) (*models.Export, error)) *MockExportServiceExternal_ExportAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportServiceExternal creates a new instance of MockExportServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportServiceExternal {
+	mock := &MockExportServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,146 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockExportServiceInternal is an autogenerated mock type for the ExportServiceInternal type
+type MockExportServiceInternal struct {
+	mock.Mock
+}
+
+type MockExportServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportServiceInternal) EXPECT() *MockExportServiceInternal_Expecter {
+	return &MockExportServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// DeleteExportInternal provides a mock function with given fields: ctx, fileID
+func (_m *MockExportServiceInternal) DeleteExportInternal(ctx context.Context, fileID bson.ObjectID) error {
+	ret := _m.Called(ctx, fileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExportInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(ctx, fileID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExportServiceInternal_DeleteExportInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExportInternal'
+type MockExportServiceInternal_DeleteExportInternal_Call struct {
+	*mock.Call
+}
+
+// DeleteExportInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fileID bson.ObjectID
+func (_e *MockExportServiceInternal_Expecter) DeleteExportInternal(ctx interface{}, fileID interface{}) *MockExportServiceInternal_DeleteExportInternal_Call {
+	return &MockExportServiceInternal_DeleteExportInternal_Call{Call: _e.mock.On("DeleteExportInternal", ctx, fileID)}
+}
+
+func (_c *MockExportServiceInternal_DeleteExportInternal_Call) Run(run func(ctx context.Context, fileID bson.ObjectID)) *MockExportServiceInternal_DeleteExportInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockExportServiceInternal_DeleteExportInternal_Call) Return(_a0 error) *MockExportServiceInternal_DeleteExportInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExportServiceInternal_DeleteExportInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockExportServiceInternal_DeleteExportInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateExportInternal provides a mock function with given fields: ctx, request
+func (_m *MockExportServiceInternal) GenerateExportInternal(ctx context.Context, request models.ExportRequest) (*models.SignedFile, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateExportInternal")
+	}
+
+	var r0 *models.SignedFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ExportRequest) (*models.SignedFile, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ExportRequest) *models.SignedFile); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignedFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ExportRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExportServiceInternal_GenerateExportInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateExportInternal'
+type MockExportServiceInternal_GenerateExportInternal_Call struct {
+	*mock.Call
+}
+
+// GenerateExportInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request models.ExportRequest
+func (_e *MockExportServiceInternal_Expecter) GenerateExportInternal(ctx interface{}, request interface{}) *MockExportServiceInternal_GenerateExportInternal_Call {
+	return &MockExportServiceInternal_GenerateExportInternal_Call{Call: _e.mock.On("GenerateExportInternal", ctx, request)}
+}
+
+func (_c *MockExportServiceInternal_GenerateExportInternal_Call) Run(run func(ctx context.Context, request models.ExportRequest)) *MockExportServiceInternal_GenerateExportInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ExportRequest))
+	})
+	return _c
+}
+
+func (_c *MockExportServiceInternal_GenerateExportInternal_Call) Return(_a0 *models.SignedFile, _a1 error) *MockExportServiceInternal_GenerateExportInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExportServiceInternal_GenerateExportInternal_Call) RunAndReturn(run func(context.Context, models.ExportRequest) (*models.SignedFile, error)) *MockExportServiceInternal_GenerateExportInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportServiceInternal creates a new instance of MockExportServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportServiceInternal {
+	mock := &MockExportServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
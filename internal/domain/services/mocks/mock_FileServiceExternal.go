@@ -0,0 +1,217 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockFileServiceExternal is an autogenerated mock type for the FileServiceExternal type
+type MockFileServiceExternal struct {
+	mock.Mock
+}
+
+type MockFileServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFileServiceExternal) EXPECT() *MockFileServiceExternal_Expecter {
+	return &MockFileServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// DeleteFile provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockFileServiceExternal) DeleteFile(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFileServiceExternal_DeleteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFile'
+type MockFileServiceExternal_DeleteFile_Call struct {
+	*mock.Call
+}
+
+// DeleteFile is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+func (_e *MockFileServiceExternal_Expecter) DeleteFile(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockFileServiceExternal_DeleteFile_Call {
+	return &MockFileServiceExternal_DeleteFile_Call{Call: _e.mock.On("DeleteFile", _a0, _a1, _a2)}
+}
+
+func (_c *MockFileServiceExternal_DeleteFile_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockFileServiceExternal_DeleteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileServiceExternal_DeleteFile_Call) Return(_a0 error) *MockFileServiceExternal_DeleteFile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileServiceExternal_DeleteFile_Call) RunAndReturn(run func(context.Context, string, string) error) *MockFileServiceExternal_DeleteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFileByID provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockFileServiceExternal) GetFileByID(_a0 context.Context, _a1 string, _a2 string) (*models.SignedFile, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileByID")
+	}
+
+	var r0 *models.SignedFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.SignedFile, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.SignedFile); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignedFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileServiceExternal_GetFileByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileByID'
+type MockFileServiceExternal_GetFileByID_Call struct {
+	*mock.Call
+}
+
+// GetFileByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+func (_e *MockFileServiceExternal_Expecter) GetFileByID(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockFileServiceExternal_GetFileByID_Call {
+	return &MockFileServiceExternal_GetFileByID_Call{Call: _e.mock.On("GetFileByID", _a0, _a1, _a2)}
+}
+
+func (_c *MockFileServiceExternal_GetFileByID_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockFileServiceExternal_GetFileByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileServiceExternal_GetFileByID_Call) Return(_a0 *models.SignedFile, _a1 error) *MockFileServiceExternal_GetFileByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileServiceExternal_GetFileByID_Call) RunAndReturn(run func(context.Context, string, string) (*models.SignedFile, error)) *MockFileServiceExternal_GetFileByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenSignedFile provides a mock function with given fields: ctx, id, expires, signature
+func (_m *MockFileServiceExternal) OpenSignedFile(ctx context.Context, id string, expires string, signature string) (*models.StoredFile, io.ReadCloser, error) {
+	ret := _m.Called(ctx, id, expires, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenSignedFile")
+	}
+
+	var r0 *models.StoredFile
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.StoredFile, io.ReadCloser, error)); ok {
+		return rf(ctx, id, expires, signature)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.StoredFile); ok {
+		r0 = rf(ctx, id, expires, signature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StoredFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) io.ReadCloser); ok {
+		r1 = rf(ctx, id, expires, signature)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = rf(ctx, id, expires, signature)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockFileServiceExternal_OpenSignedFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenSignedFile'
+type MockFileServiceExternal_OpenSignedFile_Call struct {
+	*mock.Call
+}
+
+// OpenSignedFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - expires string
+//   - signature string
+func (_e *MockFileServiceExternal_Expecter) OpenSignedFile(ctx interface{}, id interface{}, expires interface{}, signature interface{}) *MockFileServiceExternal_OpenSignedFile_Call {
+	return &MockFileServiceExternal_OpenSignedFile_Call{Call: _e.mock.On("OpenSignedFile", ctx, id, expires, signature)}
+}
+
+func (_c *MockFileServiceExternal_OpenSignedFile_Call) Run(run func(ctx context.Context, id string, expires string, signature string)) *MockFileServiceExternal_OpenSignedFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileServiceExternal_OpenSignedFile_Call) Return(_a0 *models.StoredFile, _a1 io.ReadCloser, _a2 error) *MockFileServiceExternal_OpenSignedFile_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockFileServiceExternal_OpenSignedFile_Call) RunAndReturn(run func(context.Context, string, string, string) (*models.StoredFile, io.ReadCloser, error)) *MockFileServiceExternal_OpenSignedFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFileServiceExternal creates a new instance of MockFileServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFileServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFileServiceExternal {
+	mock := &MockFileServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
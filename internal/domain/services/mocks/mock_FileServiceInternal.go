@@ -0,0 +1,424 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+
+	time "time"
+)
+
+// MockFileServiceInternal is an autogenerated mock type for the FileServiceInternal type
+type MockFileServiceInternal struct {
+	mock.Mock
+}
+
+type MockFileServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFileServiceInternal) EXPECT() *MockFileServiceInternal_Expecter {
+	return &MockFileServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// DeleteFileInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockFileServiceInternal) DeleteFileInternal(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFileInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFileServiceInternal_DeleteFileInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFileInternal'
+type MockFileServiceInternal_DeleteFileInternal_Call struct {
+	*mock.Call
+}
+
+// DeleteFileInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockFileServiceInternal_Expecter) DeleteFileInternal(_a0 interface{}, _a1 interface{}) *MockFileServiceInternal_DeleteFileInternal_Call {
+	return &MockFileServiceInternal_DeleteFileInternal_Call{Call: _e.mock.On("DeleteFileInternal", _a0, _a1)}
+}
+
+func (_c *MockFileServiceInternal_DeleteFileInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockFileServiceInternal_DeleteFileInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockFileServiceInternal_DeleteFileInternal_Call) Return(_a0 error) *MockFileServiceInternal_DeleteFileInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileServiceInternal_DeleteFileInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockFileServiceInternal_DeleteFileInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFilesInternal provides a mock function with given fields: ctx, ownerID
+func (_m *MockFileServiceInternal) DeleteFilesInternal(ctx context.Context, ownerID bson.ObjectID) (int64, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFilesInternal")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileServiceInternal_DeleteFilesInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFilesInternal'
+type MockFileServiceInternal_DeleteFilesInternal_Call struct {
+	*mock.Call
+}
+
+// DeleteFilesInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID bson.ObjectID
+func (_e *MockFileServiceInternal_Expecter) DeleteFilesInternal(ctx interface{}, ownerID interface{}) *MockFileServiceInternal_DeleteFilesInternal_Call {
+	return &MockFileServiceInternal_DeleteFilesInternal_Call{Call: _e.mock.On("DeleteFilesInternal", ctx, ownerID)}
+}
+
+func (_c *MockFileServiceInternal_DeleteFilesInternal_Call) Run(run func(ctx context.Context, ownerID bson.ObjectID)) *MockFileServiceInternal_DeleteFilesInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockFileServiceInternal_DeleteFilesInternal_Call) Return(_a0 int64, _a1 error) *MockFileServiceInternal_DeleteFilesInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileServiceInternal_DeleteFilesInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockFileServiceInternal_DeleteFilesInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchFileByKeyInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockFileServiceInternal) FetchFileByKeyInternal(_a0 context.Context, _a1 string) (*models.StoredFile, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchFileByKeyInternal")
+	}
+
+	var r0 *models.StoredFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.StoredFile, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.StoredFile); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StoredFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileServiceInternal_FetchFileByKeyInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchFileByKeyInternal'
+type MockFileServiceInternal_FetchFileByKeyInternal_Call struct {
+	*mock.Call
+}
+
+// FetchFileByKeyInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockFileServiceInternal_Expecter) FetchFileByKeyInternal(_a0 interface{}, _a1 interface{}) *MockFileServiceInternal_FetchFileByKeyInternal_Call {
+	return &MockFileServiceInternal_FetchFileByKeyInternal_Call{Call: _e.mock.On("FetchFileByKeyInternal", _a0, _a1)}
+}
+
+func (_c *MockFileServiceInternal_FetchFileByKeyInternal_Call) Run(run func(_a0 context.Context, _a1 string)) *MockFileServiceInternal_FetchFileByKeyInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileServiceInternal_FetchFileByKeyInternal_Call) Return(_a0 *models.StoredFile, _a1 error) *MockFileServiceInternal_FetchFileByKeyInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileServiceInternal_FetchFileByKeyInternal_Call) RunAndReturn(run func(context.Context, string) (*models.StoredFile, error)) *MockFileServiceInternal_FetchFileByKeyInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenFileInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockFileServiceInternal) OpenFileInternal(_a0 context.Context, _a1 bson.ObjectID) (io.ReadCloser, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenFileInternal")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (io.ReadCloser, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) io.ReadCloser); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileServiceInternal_OpenFileInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenFileInternal'
+type MockFileServiceInternal_OpenFileInternal_Call struct {
+	*mock.Call
+}
+
+// OpenFileInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockFileServiceInternal_Expecter) OpenFileInternal(_a0 interface{}, _a1 interface{}) *MockFileServiceInternal_OpenFileInternal_Call {
+	return &MockFileServiceInternal_OpenFileInternal_Call{Call: _e.mock.On("OpenFileInternal", _a0, _a1)}
+}
+
+func (_c *MockFileServiceInternal_OpenFileInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockFileServiceInternal_OpenFileInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockFileServiceInternal_OpenFileInternal_Call) Return(_a0 io.ReadCloser, _a1 error) *MockFileServiceInternal_OpenFileInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileServiceInternal_OpenFileInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (io.ReadCloser, error)) *MockFileServiceInternal_OpenFileInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignFileForInternal provides a mock function with given fields: file, expiry
+func (_m *MockFileServiceInternal) SignFileForInternal(file *models.StoredFile, expiry time.Duration) *models.SignedFile {
+	ret := _m.Called(file, expiry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignFileForInternal")
+	}
+
+	var r0 *models.SignedFile
+	if rf, ok := ret.Get(0).(func(*models.StoredFile, time.Duration) *models.SignedFile); ok {
+		r0 = rf(file, expiry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignedFile)
+		}
+	}
+
+	return r0
+}
+
+// MockFileServiceInternal_SignFileForInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignFileForInternal'
+type MockFileServiceInternal_SignFileForInternal_Call struct {
+	*mock.Call
+}
+
+// SignFileForInternal is a helper method to define mock.On call
+//   - file *models.StoredFile
+//   - expiry time.Duration
+func (_e *MockFileServiceInternal_Expecter) SignFileForInternal(file interface{}, expiry interface{}) *MockFileServiceInternal_SignFileForInternal_Call {
+	return &MockFileServiceInternal_SignFileForInternal_Call{Call: _e.mock.On("SignFileForInternal", file, expiry)}
+}
+
+func (_c *MockFileServiceInternal_SignFileForInternal_Call) Run(run func(file *models.StoredFile, expiry time.Duration)) *MockFileServiceInternal_SignFileForInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.StoredFile), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockFileServiceInternal_SignFileForInternal_Call) Return(_a0 *models.SignedFile) *MockFileServiceInternal_SignFileForInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileServiceInternal_SignFileForInternal_Call) RunAndReturn(run func(*models.StoredFile, time.Duration) *models.SignedFile) *MockFileServiceInternal_SignFileForInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignFileInternal provides a mock function with given fields: _a0
+func (_m *MockFileServiceInternal) SignFileInternal(_a0 *models.StoredFile) *models.SignedFile {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignFileInternal")
+	}
+
+	var r0 *models.SignedFile
+	if rf, ok := ret.Get(0).(func(*models.StoredFile) *models.SignedFile); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignedFile)
+		}
+	}
+
+	return r0
+}
+
+// MockFileServiceInternal_SignFileInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignFileInternal'
+type MockFileServiceInternal_SignFileInternal_Call struct {
+	*mock.Call
+}
+
+// SignFileInternal is a helper method to define mock.On call
+//   - _a0 *models.StoredFile
+func (_e *MockFileServiceInternal_Expecter) SignFileInternal(_a0 interface{}) *MockFileServiceInternal_SignFileInternal_Call {
+	return &MockFileServiceInternal_SignFileInternal_Call{Call: _e.mock.On("SignFileInternal", _a0)}
+}
+
+func (_c *MockFileServiceInternal_SignFileInternal_Call) Run(run func(_a0 *models.StoredFile)) *MockFileServiceInternal_SignFileInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.StoredFile))
+	})
+	return _c
+}
+
+func (_c *MockFileServiceInternal_SignFileInternal_Call) Return(_a0 *models.SignedFile) *MockFileServiceInternal_SignFileInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileServiceInternal_SignFileInternal_Call) RunAndReturn(run func(*models.StoredFile) *models.SignedFile) *MockFileServiceInternal_SignFileInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreFileInternal provides a mock function with given fields: ctx, filename, metadata, content
+func (_m *MockFileServiceInternal) StoreFileInternal(ctx context.Context, filename string, metadata models.FileMetadata, content io.Reader) (*models.StoredFile, error) {
+	ret := _m.Called(ctx, filename, metadata, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreFileInternal")
+	}
+
+	var r0 *models.StoredFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FileMetadata, io.Reader) (*models.StoredFile, error)); ok {
+		return rf(ctx, filename, metadata, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FileMetadata, io.Reader) *models.StoredFile); ok {
+		r0 = rf(ctx, filename, metadata, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StoredFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.FileMetadata, io.Reader) error); ok {
+		r1 = rf(ctx, filename, metadata, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileServiceInternal_StoreFileInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreFileInternal'
+type MockFileServiceInternal_StoreFileInternal_Call struct {
+	*mock.Call
+}
+
+// StoreFileInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filename string
+//   - metadata models.FileMetadata
+//   - content io.Reader
+func (_e *MockFileServiceInternal_Expecter) StoreFileInternal(ctx interface{}, filename interface{}, metadata interface{}, content interface{}) *MockFileServiceInternal_StoreFileInternal_Call {
+	return &MockFileServiceInternal_StoreFileInternal_Call{Call: _e.mock.On("StoreFileInternal", ctx, filename, metadata, content)}
+}
+
+func (_c *MockFileServiceInternal_StoreFileInternal_Call) Run(run func(ctx context.Context, filename string, metadata models.FileMetadata, content io.Reader)) *MockFileServiceInternal_StoreFileInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.FileMetadata), args[3].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *MockFileServiceInternal_StoreFileInternal_Call) Return(_a0 *models.StoredFile, _a1 error) *MockFileServiceInternal_StoreFileInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileServiceInternal_StoreFileInternal_Call) RunAndReturn(run func(context.Context, string, models.FileMetadata, io.Reader) (*models.StoredFile, error)) *MockFileServiceInternal_StoreFileInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFileServiceInternal creates a new instance of MockFileServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFileServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFileServiceInternal {
+	mock := &MockFileServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,158 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockForecastService is an autogenerated mock type for the ForecastService type
+type MockForecastService struct {
+	mock.Mock
+}
+
+type MockForecastService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockForecastService) EXPECT() *MockForecastService_Expecter {
+	return &MockForecastService_Expecter{mock: &_m.Mock}
+}
+
+// GetForecast provides a mock function with given fields: ctx, claimedUserID, months, target
+func (_m *MockForecastService) GetForecast(ctx context.Context, claimedUserID string, months int, target models.Currency) (*models.ForecastResponse, error) {
+	ret := _m.Called(ctx, claimedUserID, months, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForecast")
+	}
+
+	var r0 *models.ForecastResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, models.Currency) (*models.ForecastResponse, error)); ok {
+		return rf(ctx, claimedUserID, months, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, models.Currency) *models.ForecastResponse); ok {
+		r0 = rf(ctx, claimedUserID, months, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ForecastResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, models.Currency) error); ok {
+		r1 = rf(ctx, claimedUserID, months, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockForecastService_GetForecast_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForecast'
+type MockForecastService_GetForecast_Call struct {
+	*mock.Call
+}
+
+// GetForecast is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+//   - months int
+//   - target models.Currency
+func (_e *MockForecastService_Expecter) GetForecast(ctx interface{}, claimedUserID interface{}, months interface{}, target interface{}) *MockForecastService_GetForecast_Call {
+	return &MockForecastService_GetForecast_Call{Call: _e.mock.On("GetForecast", ctx, claimedUserID, months, target)}
+}
+
+func (_c *MockForecastService_GetForecast_Call) Run(run func(ctx context.Context, claimedUserID string, months int, target models.Currency)) *MockForecastService_GetForecast_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(models.Currency))
+	})
+	return _c
+}
+
+func (_c *MockForecastService_GetForecast_Call) Return(_a0 *models.ForecastResponse, _a1 error) *MockForecastService_GetForecast_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockForecastService_GetForecast_Call) RunAndReturn(run func(context.Context, string, int, models.Currency) (*models.ForecastResponse, error)) *MockForecastService_GetForecast_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRenewalCalendar provides a mock function with given fields: ctx, claimedUserID, month
+func (_m *MockForecastService) GetRenewalCalendar(ctx context.Context, claimedUserID string, month string) (*models.RenewalCalendarResponse, error) {
+	ret := _m.Called(ctx, claimedUserID, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRenewalCalendar")
+	}
+
+	var r0 *models.RenewalCalendarResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.RenewalCalendarResponse, error)); ok {
+		return rf(ctx, claimedUserID, month)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.RenewalCalendarResponse); ok {
+		r0 = rf(ctx, claimedUserID, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RenewalCalendarResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, claimedUserID, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockForecastService_GetRenewalCalendar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRenewalCalendar'
+type MockForecastService_GetRenewalCalendar_Call struct {
+	*mock.Call
+}
+
+// GetRenewalCalendar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+//   - month string
+func (_e *MockForecastService_Expecter) GetRenewalCalendar(ctx interface{}, claimedUserID interface{}, month interface{}) *MockForecastService_GetRenewalCalendar_Call {
+	return &MockForecastService_GetRenewalCalendar_Call{Call: _e.mock.On("GetRenewalCalendar", ctx, claimedUserID, month)}
+}
+
+func (_c *MockForecastService_GetRenewalCalendar_Call) Run(run func(ctx context.Context, claimedUserID string, month string)) *MockForecastService_GetRenewalCalendar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockForecastService_GetRenewalCalendar_Call) Return(_a0 *models.RenewalCalendarResponse, _a1 error) *MockForecastService_GetRenewalCalendar_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockForecastService_GetRenewalCalendar_Call) RunAndReturn(run func(context.Context, string, string) (*models.RenewalCalendarResponse, error)) *MockForecastService_GetRenewalCalendar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockForecastService creates a new instance of MockForecastService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockForecastService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockForecastService {
+	mock := &MockForecastService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,108 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockInvoiceServiceExternal is an autogenerated mock type for the InvoiceServiceExternal type
+type MockInvoiceServiceExternal struct {
+	mock.Mock
+}
+
+type MockInvoiceServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInvoiceServiceExternal) EXPECT() *MockInvoiceServiceExternal_Expecter {
+	return &MockInvoiceServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// GetInvoice provides a mock function with given fields: ctx, billID, claimedUserID
+func (_m *MockInvoiceServiceExternal) GetInvoice(ctx context.Context, billID string, claimedUserID string) (*models.StoredFile, io.ReadCloser, error) {
+	ret := _m.Called(ctx, billID, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoice")
+	}
+
+	var r0 *models.StoredFile
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.StoredFile, io.ReadCloser, error)); ok {
+		return rf(ctx, billID, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.StoredFile); ok {
+		r0 = rf(ctx, billID, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StoredFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) io.ReadCloser); ok {
+		r1 = rf(ctx, billID, claimedUserID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, billID, claimedUserID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockInvoiceServiceExternal_GetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvoice'
+type MockInvoiceServiceExternal_GetInvoice_Call struct {
+	*mock.Call
+}
+
+// GetInvoice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - billID string
+//   - claimedUserID string
+func (_e *MockInvoiceServiceExternal_Expecter) GetInvoice(ctx interface{}, billID interface{}, claimedUserID interface{}) *MockInvoiceServiceExternal_GetInvoice_Call {
+	return &MockInvoiceServiceExternal_GetInvoice_Call{Call: _e.mock.On("GetInvoice", ctx, billID, claimedUserID)}
+}
+
+func (_c *MockInvoiceServiceExternal_GetInvoice_Call) Run(run func(ctx context.Context, billID string, claimedUserID string)) *MockInvoiceServiceExternal_GetInvoice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockInvoiceServiceExternal_GetInvoice_Call) Return(_a0 *models.StoredFile, _a1 io.ReadCloser, _a2 error) *MockInvoiceServiceExternal_GetInvoice_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockInvoiceServiceExternal_GetInvoice_Call) RunAndReturn(run func(context.Context, string, string) (*models.StoredFile, io.ReadCloser, error)) *MockInvoiceServiceExternal_GetInvoice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInvoiceServiceExternal creates a new instance of MockInvoiceServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInvoiceServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInvoiceServiceExternal {
+	mock := &MockInvoiceServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
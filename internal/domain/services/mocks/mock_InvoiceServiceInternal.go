@@ -0,0 +1,99 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockInvoiceServiceInternal is an autogenerated mock type for the InvoiceServiceInternal type
+type MockInvoiceServiceInternal struct {
+	mock.Mock
+}
+
+type MockInvoiceServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInvoiceServiceInternal) EXPECT() *MockInvoiceServiceInternal_Expecter {
+	return &MockInvoiceServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// GenerateInvoiceInternal provides a mock function with given fields: ctx, billID
+func (_m *MockInvoiceServiceInternal) GenerateInvoiceInternal(ctx context.Context, billID bson.ObjectID) (*models.StoredFile, error) {
+	ret := _m.Called(ctx, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateInvoiceInternal")
+	}
+
+	var r0 *models.StoredFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.StoredFile, error)); ok {
+		return rf(ctx, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.StoredFile); ok {
+		r0 = rf(ctx, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StoredFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInvoiceServiceInternal_GenerateInvoiceInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateInvoiceInternal'
+type MockInvoiceServiceInternal_GenerateInvoiceInternal_Call struct {
+	*mock.Call
+}
+
+// GenerateInvoiceInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - billID bson.ObjectID
+func (_e *MockInvoiceServiceInternal_Expecter) GenerateInvoiceInternal(ctx interface{}, billID interface{}) *MockInvoiceServiceInternal_GenerateInvoiceInternal_Call {
+	return &MockInvoiceServiceInternal_GenerateInvoiceInternal_Call{Call: _e.mock.On("GenerateInvoiceInternal", ctx, billID)}
+}
+
+func (_c *MockInvoiceServiceInternal_GenerateInvoiceInternal_Call) Run(run func(ctx context.Context, billID bson.ObjectID)) *MockInvoiceServiceInternal_GenerateInvoiceInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockInvoiceServiceInternal_GenerateInvoiceInternal_Call) Return(_a0 *models.StoredFile, _a1 error) *MockInvoiceServiceInternal_GenerateInvoiceInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInvoiceServiceInternal_GenerateInvoiceInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.StoredFile, error)) *MockInvoiceServiceInternal_GenerateInvoiceInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInvoiceServiceInternal creates a new instance of MockInvoiceServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInvoiceServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInvoiceServiceInternal {
+	mock := &MockInvoiceServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
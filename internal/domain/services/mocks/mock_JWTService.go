@@ -3,8 +3,13 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	services "github.com/anuragthepathak/subscription-management/internal/domain/services"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockJWTService is an autogenerated mock type for the JWTService type
@@ -20,9 +25,9 @@ func (_m *MockJWTService) EXPECT() *MockJWTService_Expecter {
 	return &MockJWTService_Expecter{mock: &_m.Mock}
 }
 
-// GenerateTokens provides a mock function with given fields: userID, email
-func (_m *MockJWTService) GenerateTokens(userID string, email string) (*models.TokenResponse, error) {
-	ret := _m.Called(userID, email)
+// GenerateTokens provides a mock function with given fields: userID, email, role, sessionID
+func (_m *MockJWTService) GenerateTokens(userID string, email string, role models.Role, sessionID string) (*models.TokenResponse, error) {
+	ret := _m.Called(userID, email, role, sessionID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateTokens")
@@ -30,19 +35,19 @@ func (_m *MockJWTService) GenerateTokens(userID string, email string) (*models.T
 
 	var r0 *models.TokenResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (*models.TokenResponse, error)); ok {
-		return rf(userID, email)
+	if rf, ok := ret.Get(0).(func(string, string, models.Role, string) (*models.TokenResponse, error)); ok {
+		return rf(userID, email, role, sessionID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) *models.TokenResponse); ok {
-		r0 = rf(userID, email)
+	if rf, ok := ret.Get(0).(func(string, string, models.Role, string) *models.TokenResponse); ok {
+		r0 = rf(userID, email, role, sessionID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.TokenResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(userID, email)
+	if rf, ok := ret.Get(1).(func(string, string, models.Role, string) error); ok {
+		r1 = rf(userID, email, role, sessionID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -58,13 +63,15 @@ type MockJWTService_GenerateTokens_Call struct {
 // GenerateTokens is a helper method to define mock.On call
 //   - userID string
 //   - email string
-func (_e *MockJWTService_Expecter) GenerateTokens(userID interface{}, email interface{}) *MockJWTService_GenerateTokens_Call {
-	return &MockJWTService_GenerateTokens_Call{Call: _e.mock.On("GenerateTokens", userID, email)}
+//   - role models.Role
+//   - sessionID string
+func (_e *MockJWTService_Expecter) GenerateTokens(userID interface{}, email interface{}, role interface{}, sessionID interface{}) *MockJWTService_GenerateTokens_Call {
+	return &MockJWTService_GenerateTokens_Call{Call: _e.mock.On("GenerateTokens", userID, email, role, sessionID)}
 }
 
-func (_c *MockJWTService_GenerateTokens_Call) Run(run func(userID string, email string)) *MockJWTService_GenerateTokens_Call {
+func (_c *MockJWTService_GenerateTokens_Call) Run(run func(userID string, email string, role models.Role, sessionID string)) *MockJWTService_GenerateTokens_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(models.Role), args[3].(string))
 	})
 	return _c
 }
@@ -74,14 +81,231 @@ func (_c *MockJWTService_GenerateTokens_Call) Return(_a0 *models.TokenResponse,
 	return _c
 }
 
-func (_c *MockJWTService_GenerateTokens_Call) RunAndReturn(run func(string, string) (*models.TokenResponse, error)) *MockJWTService_GenerateTokens_Call {
+func (_c *MockJWTService_GenerateTokens_Call) RunAndReturn(run func(string, string, models.Role, string) (*models.TokenResponse, error)) *MockJWTService_GenerateTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JWKS provides a mock function with no fields
+func (_m *MockJWTService) JWKS() models.JWKS {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 models.JWKS
+	if rf, ok := ret.Get(0).(func() models.JWKS); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(models.JWKS)
+	}
+
+	return r0
+}
+
+// MockJWTService_JWKS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JWKS'
+type MockJWTService_JWKS_Call struct {
+	*mock.Call
+}
+
+// JWKS is a helper method to define mock.On call
+func (_e *MockJWTService_Expecter) JWKS() *MockJWTService_JWKS_Call {
+	return &MockJWTService_JWKS_Call{Call: _e.mock.On("JWKS")}
+}
+
+func (_c *MockJWTService_JWKS_Call) Run(run func()) *MockJWTService_JWKS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockJWTService_JWKS_Call) Return(_a0 models.JWKS) *MockJWTService_JWKS_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJWTService_JWKS_Call) RunAndReturn(run func() models.JWKS) *MockJWTService_JWKS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: ctx, sessionID, expiresAt
+func (_m *MockJWTService) RevokeSession(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, sessionID, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, sessionID, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockJWTService_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type MockJWTService_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionID string
+//   - expiresAt time.Time
+func (_e *MockJWTService_Expecter) RevokeSession(ctx interface{}, sessionID interface{}, expiresAt interface{}) *MockJWTService_RevokeSession_Call {
+	return &MockJWTService_RevokeSession_Call{Call: _e.mock.On("RevokeSession", ctx, sessionID, expiresAt)}
+}
+
+func (_c *MockJWTService_RevokeSession_Call) Run(run func(ctx context.Context, sessionID string, expiresAt time.Time)) *MockJWTService_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockJWTService_RevokeSession_Call) Return(_a0 error) *MockJWTService_RevokeSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJWTService_RevokeSession_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *MockJWTService_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeToken provides a mock function with given fields: ctx, claims
+func (_m *MockJWTService) RevokeToken(ctx context.Context, claims *models.Claims) (bool, error) {
+	ret := _m.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Claims) (bool, error)); ok {
+		return rf(ctx, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Claims) bool); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Claims) error); ok {
+		r1 = rf(ctx, claims)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJWTService_RevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeToken'
+type MockJWTService_RevokeToken_Call struct {
+	*mock.Call
+}
+
+// RevokeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claims *models.Claims
+func (_e *MockJWTService_Expecter) RevokeToken(ctx interface{}, claims interface{}) *MockJWTService_RevokeToken_Call {
+	return &MockJWTService_RevokeToken_Call{Call: _e.mock.On("RevokeToken", ctx, claims)}
+}
+
+func (_c *MockJWTService_RevokeToken_Call) Run(run func(ctx context.Context, claims *models.Claims)) *MockJWTService_RevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Claims))
+	})
+	return _c
+}
+
+func (_c *MockJWTService_RevokeToken_Call) Return(_a0 bool, _a1 error) *MockJWTService_RevokeToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJWTService_RevokeToken_Call) RunAndReturn(run func(context.Context, *models.Claims) (bool, error)) *MockJWTService_RevokeToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ValidateToken provides a mock function with given fields: tokenString, tokenType
-func (_m *MockJWTService) ValidateToken(tokenString string, tokenType models.TokenType) (*models.Claims, error) {
-	ret := _m.Called(tokenString, tokenType)
+// RotateKeys provides a mock function with given fields: keys
+func (_m *MockJWTService) RotateKeys(keys *services.JWTKeySet) {
+	_m.Called(keys)
+}
+
+// MockJWTService_RotateKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateKeys'
+type MockJWTService_RotateKeys_Call struct {
+	*mock.Call
+}
+
+// RotateKeys is a helper method to define mock.On call
+//   - keys *services.JWTKeySet
+func (_e *MockJWTService_Expecter) RotateKeys(keys interface{}) *MockJWTService_RotateKeys_Call {
+	return &MockJWTService_RotateKeys_Call{Call: _e.mock.On("RotateKeys", keys)}
+}
+
+func (_c *MockJWTService_RotateKeys_Call) Run(run func(keys *services.JWTKeySet)) *MockJWTService_RotateKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*services.JWTKeySet))
+	})
+	return _c
+}
+
+func (_c *MockJWTService_RotateKeys_Call) Return() *MockJWTService_RotateKeys_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockJWTService_RotateKeys_Call) RunAndReturn(run func(*services.JWTKeySet)) *MockJWTService_RotateKeys_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RotateSecrets provides a mock function with given fields: accessSecret, refreshSecret
+func (_m *MockJWTService) RotateSecrets(accessSecret string, refreshSecret string) {
+	_m.Called(accessSecret, refreshSecret)
+}
+
+// MockJWTService_RotateSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateSecrets'
+type MockJWTService_RotateSecrets_Call struct {
+	*mock.Call
+}
+
+// RotateSecrets is a helper method to define mock.On call
+//   - accessSecret string
+//   - refreshSecret string
+func (_e *MockJWTService_Expecter) RotateSecrets(accessSecret interface{}, refreshSecret interface{}) *MockJWTService_RotateSecrets_Call {
+	return &MockJWTService_RotateSecrets_Call{Call: _e.mock.On("RotateSecrets", accessSecret, refreshSecret)}
+}
+
+func (_c *MockJWTService_RotateSecrets_Call) Run(run func(accessSecret string, refreshSecret string)) *MockJWTService_RotateSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockJWTService_RotateSecrets_Call) Return() *MockJWTService_RotateSecrets_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockJWTService_RotateSecrets_Call) RunAndReturn(run func(string, string)) *MockJWTService_RotateSecrets_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ValidateToken provides a mock function with given fields: ctx, tokenString, tokenType
+func (_m *MockJWTService) ValidateToken(ctx context.Context, tokenString string, tokenType models.TokenType) (*models.Claims, error) {
+	ret := _m.Called(ctx, tokenString, tokenType)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateToken")
@@ -89,19 +313,19 @@ func (_m *MockJWTService) ValidateToken(tokenString string, tokenType models.Tok
 
 	var r0 *models.Claims
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, models.TokenType) (*models.Claims, error)); ok {
-		return rf(tokenString, tokenType)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TokenType) (*models.Claims, error)); ok {
+		return rf(ctx, tokenString, tokenType)
 	}
-	if rf, ok := ret.Get(0).(func(string, models.TokenType) *models.Claims); ok {
-		r0 = rf(tokenString, tokenType)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TokenType) *models.Claims); ok {
+		r0 = rf(ctx, tokenString, tokenType)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Claims)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, models.TokenType) error); ok {
-		r1 = rf(tokenString, tokenType)
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.TokenType) error); ok {
+		r1 = rf(ctx, tokenString, tokenType)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,15 +339,16 @@ type MockJWTService_ValidateToken_Call struct {
 }
 
 // ValidateToken is a helper method to define mock.On call
+//   - ctx context.Context
 //   - tokenString string
 //   - tokenType models.TokenType
-func (_e *MockJWTService_Expecter) ValidateToken(tokenString interface{}, tokenType interface{}) *MockJWTService_ValidateToken_Call {
-	return &MockJWTService_ValidateToken_Call{Call: _e.mock.On("ValidateToken", tokenString, tokenType)}
+func (_e *MockJWTService_Expecter) ValidateToken(ctx interface{}, tokenString interface{}, tokenType interface{}) *MockJWTService_ValidateToken_Call {
+	return &MockJWTService_ValidateToken_Call{Call: _e.mock.On("ValidateToken", ctx, tokenString, tokenType)}
 }
 
-func (_c *MockJWTService_ValidateToken_Call) Run(run func(tokenString string, tokenType models.TokenType)) *MockJWTService_ValidateToken_Call {
+func (_c *MockJWTService_ValidateToken_Call) Run(run func(ctx context.Context, tokenString string, tokenType models.TokenType)) *MockJWTService_ValidateToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(models.TokenType))
+		run(args[0].(context.Context), args[1].(string), args[2].(models.TokenType))
 	})
 	return _c
 }
@@ -133,7 +358,7 @@ func (_c *MockJWTService_ValidateToken_Call) Return(_a0 *models.Claims, _a1 erro
 	return _c
 }
 
-func (_c *MockJWTService_ValidateToken_Call) RunAndReturn(run func(string, models.TokenType) (*models.Claims, error)) *MockJWTService_ValidateToken_Call {
+func (_c *MockJWTService_ValidateToken_Call) RunAndReturn(run func(context.Context, string, models.TokenType) (*models.Claims, error)) *MockJWTService_ValidateToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
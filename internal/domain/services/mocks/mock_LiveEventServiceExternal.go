@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLiveEventServiceExternal is an autogenerated mock type for the LiveEventServiceExternal type
+type MockLiveEventServiceExternal struct {
+	mock.Mock
+}
+
+type MockLiveEventServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLiveEventServiceExternal) EXPECT() *MockLiveEventServiceExternal_Expecter {
+	return &MockLiveEventServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// StreamLiveEvents provides a mock function with given fields: ctx, claimedUserID
+func (_m *MockLiveEventServiceExternal) StreamLiveEvents(ctx context.Context, claimedUserID string) (<-chan *models.LiveEvent, error) {
+	ret := _m.Called(ctx, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamLiveEvents")
+	}
+
+	var r0 <-chan *models.LiveEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (<-chan *models.LiveEvent, error)); ok {
+		return rf(ctx, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan *models.LiveEvent); ok {
+		r0 = rf(ctx, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *models.LiveEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLiveEventServiceExternal_StreamLiveEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamLiveEvents'
+type MockLiveEventServiceExternal_StreamLiveEvents_Call struct {
+	*mock.Call
+}
+
+// StreamLiveEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+func (_e *MockLiveEventServiceExternal_Expecter) StreamLiveEvents(ctx interface{}, claimedUserID interface{}) *MockLiveEventServiceExternal_StreamLiveEvents_Call {
+	return &MockLiveEventServiceExternal_StreamLiveEvents_Call{Call: _e.mock.On("StreamLiveEvents", ctx, claimedUserID)}
+}
+
+func (_c *MockLiveEventServiceExternal_StreamLiveEvents_Call) Run(run func(ctx context.Context, claimedUserID string)) *MockLiveEventServiceExternal_StreamLiveEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLiveEventServiceExternal_StreamLiveEvents_Call) Return(_a0 <-chan *models.LiveEvent, _a1 error) *MockLiveEventServiceExternal_StreamLiveEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLiveEventServiceExternal_StreamLiveEvents_Call) RunAndReturn(run func(context.Context, string) (<-chan *models.LiveEvent, error)) *MockLiveEventServiceExternal_StreamLiveEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLiveEventServiceExternal creates a new instance of MockLiveEventServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLiveEventServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLiveEventServiceExternal {
+	mock := &MockLiveEventServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
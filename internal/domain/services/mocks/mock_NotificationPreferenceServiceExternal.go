@@ -0,0 +1,158 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockNotificationPreferenceServiceExternal is an autogenerated mock type for the NotificationPreferenceServiceExternal type
+type MockNotificationPreferenceServiceExternal struct {
+	mock.Mock
+}
+
+type MockNotificationPreferenceServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationPreferenceServiceExternal) EXPECT() *MockNotificationPreferenceServiceExternal_Expecter {
+	return &MockNotificationPreferenceServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// GetPreferences provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockNotificationPreferenceServiceExternal) GetPreferences(ctx context.Context, id string, claimedUserID string) (*models.NotificationPreferences, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreferences")
+	}
+
+	var r0 *models.NotificationPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.NotificationPreferences, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.NotificationPreferences); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationPreferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationPreferenceServiceExternal_GetPreferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreferences'
+type MockNotificationPreferenceServiceExternal_GetPreferences_Call struct {
+	*mock.Call
+}
+
+// GetPreferences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockNotificationPreferenceServiceExternal_Expecter) GetPreferences(ctx interface{}, id interface{}, claimedUserID interface{}) *MockNotificationPreferenceServiceExternal_GetPreferences_Call {
+	return &MockNotificationPreferenceServiceExternal_GetPreferences_Call{Call: _e.mock.On("GetPreferences", ctx, id, claimedUserID)}
+}
+
+func (_c *MockNotificationPreferenceServiceExternal_GetPreferences_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockNotificationPreferenceServiceExternal_GetPreferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceExternal_GetPreferences_Call) Return(_a0 *models.NotificationPreferences, _a1 error) *MockNotificationPreferenceServiceExternal_GetPreferences_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceExternal_GetPreferences_Call) RunAndReturn(run func(context.Context, string, string) (*models.NotificationPreferences, error)) *MockNotificationPreferenceServiceExternal_GetPreferences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePreferences provides a mock function with given fields: ctx, id, prefs, claimedUserID
+func (_m *MockNotificationPreferenceServiceExternal) UpdatePreferences(ctx context.Context, id string, prefs *models.NotificationPreferences, claimedUserID string) (*models.NotificationPreferences, error) {
+	ret := _m.Called(ctx, id, prefs, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePreferences")
+	}
+
+	var r0 *models.NotificationPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.NotificationPreferences, string) (*models.NotificationPreferences, error)); ok {
+		return rf(ctx, id, prefs, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.NotificationPreferences, string) *models.NotificationPreferences); ok {
+		r0 = rf(ctx, id, prefs, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationPreferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.NotificationPreferences, string) error); ok {
+		r1 = rf(ctx, id, prefs, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationPreferenceServiceExternal_UpdatePreferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePreferences'
+type MockNotificationPreferenceServiceExternal_UpdatePreferences_Call struct {
+	*mock.Call
+}
+
+// UpdatePreferences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - prefs *models.NotificationPreferences
+//   - claimedUserID string
+func (_e *MockNotificationPreferenceServiceExternal_Expecter) UpdatePreferences(ctx interface{}, id interface{}, prefs interface{}, claimedUserID interface{}) *MockNotificationPreferenceServiceExternal_UpdatePreferences_Call {
+	return &MockNotificationPreferenceServiceExternal_UpdatePreferences_Call{Call: _e.mock.On("UpdatePreferences", ctx, id, prefs, claimedUserID)}
+}
+
+func (_c *MockNotificationPreferenceServiceExternal_UpdatePreferences_Call) Run(run func(ctx context.Context, id string, prefs *models.NotificationPreferences, claimedUserID string)) *MockNotificationPreferenceServiceExternal_UpdatePreferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.NotificationPreferences), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceExternal_UpdatePreferences_Call) Return(_a0 *models.NotificationPreferences, _a1 error) *MockNotificationPreferenceServiceExternal_UpdatePreferences_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceExternal_UpdatePreferences_Call) RunAndReturn(run func(context.Context, string, *models.NotificationPreferences, string) (*models.NotificationPreferences, error)) *MockNotificationPreferenceServiceExternal_UpdatePreferences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotificationPreferenceServiceExternal creates a new instance of MockNotificationPreferenceServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationPreferenceServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationPreferenceServiceExternal {
+	mock := &MockNotificationPreferenceServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,323 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockNotificationPreferenceServiceInternal is an autogenerated mock type for the NotificationPreferenceServiceInternal type
+type MockNotificationPreferenceServiceInternal struct {
+	mock.Mock
+}
+
+type MockNotificationPreferenceServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationPreferenceServiceInternal) EXPECT() *MockNotificationPreferenceServiceInternal_Expecter {
+	return &MockNotificationPreferenceServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// DeletePreferencesInternal provides a mock function with given fields: ctx, userID
+func (_m *MockNotificationPreferenceServiceInternal) DeletePreferencesInternal(ctx context.Context, userID bson.ObjectID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePreferencesInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotificationPreferenceServiceInternal_DeletePreferencesInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePreferencesInternal'
+type MockNotificationPreferenceServiceInternal_DeletePreferencesInternal_Call struct {
+	*mock.Call
+}
+
+// DeletePreferencesInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockNotificationPreferenceServiceInternal_Expecter) DeletePreferencesInternal(ctx interface{}, userID interface{}) *MockNotificationPreferenceServiceInternal_DeletePreferencesInternal_Call {
+	return &MockNotificationPreferenceServiceInternal_DeletePreferencesInternal_Call{Call: _e.mock.On("DeletePreferencesInternal", ctx, userID)}
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_DeletePreferencesInternal_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockNotificationPreferenceServiceInternal_DeletePreferencesInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_DeletePreferencesInternal_Call) Return(_a0 error) *MockNotificationPreferenceServiceInternal_DeletePreferencesInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_DeletePreferencesInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockNotificationPreferenceServiceInternal_DeletePreferencesInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableChannelInternal provides a mock function with given fields: ctx, userID, channel
+func (_m *MockNotificationPreferenceServiceInternal) DisableChannelInternal(ctx context.Context, userID bson.ObjectID, channel models.NotificationChannel) (*models.NotificationPreferences, error) {
+	ret := _m.Called(ctx, userID, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableChannelInternal")
+	}
+
+	var r0 *models.NotificationPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.NotificationChannel) (*models.NotificationPreferences, error)); ok {
+		return rf(ctx, userID, channel)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.NotificationChannel) *models.NotificationPreferences); ok {
+		r0 = rf(ctx, userID, channel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationPreferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, models.NotificationChannel) error); ok {
+		r1 = rf(ctx, userID, channel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationPreferenceServiceInternal_DisableChannelInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableChannelInternal'
+type MockNotificationPreferenceServiceInternal_DisableChannelInternal_Call struct {
+	*mock.Call
+}
+
+// DisableChannelInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+//   - channel models.NotificationChannel
+func (_e *MockNotificationPreferenceServiceInternal_Expecter) DisableChannelInternal(ctx interface{}, userID interface{}, channel interface{}) *MockNotificationPreferenceServiceInternal_DisableChannelInternal_Call {
+	return &MockNotificationPreferenceServiceInternal_DisableChannelInternal_Call{Call: _e.mock.On("DisableChannelInternal", ctx, userID, channel)}
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_DisableChannelInternal_Call) Run(run func(ctx context.Context, userID bson.ObjectID, channel models.NotificationChannel)) *MockNotificationPreferenceServiceInternal_DisableChannelInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(models.NotificationChannel))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_DisableChannelInternal_Call) Return(_a0 *models.NotificationPreferences, _a1 error) *MockNotificationPreferenceServiceInternal_DisableChannelInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_DisableChannelInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID, models.NotificationChannel) (*models.NotificationPreferences, error)) *MockNotificationPreferenceServiceInternal_DisableChannelInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchPreferencesByUserIDsInternal provides a mock function with given fields: ctx, userIDs
+func (_m *MockNotificationPreferenceServiceInternal) FetchPreferencesByUserIDsInternal(ctx context.Context, userIDs []bson.ObjectID) (map[bson.ObjectID]*models.NotificationPreferences, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchPreferencesByUserIDsInternal")
+	}
+
+	var r0 map[bson.ObjectID]*models.NotificationPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []bson.ObjectID) (map[bson.ObjectID]*models.NotificationPreferences, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []bson.ObjectID) map[bson.ObjectID]*models.NotificationPreferences); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[bson.ObjectID]*models.NotificationPreferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []bson.ObjectID) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationPreferenceServiceInternal_FetchPreferencesByUserIDsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchPreferencesByUserIDsInternal'
+type MockNotificationPreferenceServiceInternal_FetchPreferencesByUserIDsInternal_Call struct {
+	*mock.Call
+}
+
+// FetchPreferencesByUserIDsInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []bson.ObjectID
+func (_e *MockNotificationPreferenceServiceInternal_Expecter) FetchPreferencesByUserIDsInternal(ctx interface{}, userIDs interface{}) *MockNotificationPreferenceServiceInternal_FetchPreferencesByUserIDsInternal_Call {
+	return &MockNotificationPreferenceServiceInternal_FetchPreferencesByUserIDsInternal_Call{Call: _e.mock.On("FetchPreferencesByUserIDsInternal", ctx, userIDs)}
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_FetchPreferencesByUserIDsInternal_Call) Run(run func(ctx context.Context, userIDs []bson.ObjectID)) *MockNotificationPreferenceServiceInternal_FetchPreferencesByUserIDsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_FetchPreferencesByUserIDsInternal_Call) Return(_a0 map[bson.ObjectID]*models.NotificationPreferences, _a1 error) *MockNotificationPreferenceServiceInternal_FetchPreferencesByUserIDsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_FetchPreferencesByUserIDsInternal_Call) RunAndReturn(run func(context.Context, []bson.ObjectID) (map[bson.ObjectID]*models.NotificationPreferences, error)) *MockNotificationPreferenceServiceInternal_FetchPreferencesByUserIDsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchPreferencesInternal provides a mock function with given fields: ctx, userID
+func (_m *MockNotificationPreferenceServiceInternal) FetchPreferencesInternal(ctx context.Context, userID bson.ObjectID) (*models.NotificationPreferences, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchPreferencesInternal")
+	}
+
+	var r0 *models.NotificationPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.NotificationPreferences, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.NotificationPreferences); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationPreferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationPreferenceServiceInternal_FetchPreferencesInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchPreferencesInternal'
+type MockNotificationPreferenceServiceInternal_FetchPreferencesInternal_Call struct {
+	*mock.Call
+}
+
+// FetchPreferencesInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockNotificationPreferenceServiceInternal_Expecter) FetchPreferencesInternal(ctx interface{}, userID interface{}) *MockNotificationPreferenceServiceInternal_FetchPreferencesInternal_Call {
+	return &MockNotificationPreferenceServiceInternal_FetchPreferencesInternal_Call{Call: _e.mock.On("FetchPreferencesInternal", ctx, userID)}
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_FetchPreferencesInternal_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockNotificationPreferenceServiceInternal_FetchPreferencesInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_FetchPreferencesInternal_Call) Return(_a0 *models.NotificationPreferences, _a1 error) *MockNotificationPreferenceServiceInternal_FetchPreferencesInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_FetchPreferencesInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.NotificationPreferences, error)) *MockNotificationPreferenceServiceInternal_FetchPreferencesInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchReminderDaysInternal provides a mock function with given fields: ctx
+func (_m *MockNotificationPreferenceServiceInternal) FetchReminderDaysInternal(ctx context.Context) ([]int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchReminderDaysInternal")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationPreferenceServiceInternal_FetchReminderDaysInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchReminderDaysInternal'
+type MockNotificationPreferenceServiceInternal_FetchReminderDaysInternal_Call struct {
+	*mock.Call
+}
+
+// FetchReminderDaysInternal is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockNotificationPreferenceServiceInternal_Expecter) FetchReminderDaysInternal(ctx interface{}) *MockNotificationPreferenceServiceInternal_FetchReminderDaysInternal_Call {
+	return &MockNotificationPreferenceServiceInternal_FetchReminderDaysInternal_Call{Call: _e.mock.On("FetchReminderDaysInternal", ctx)}
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_FetchReminderDaysInternal_Call) Run(run func(ctx context.Context)) *MockNotificationPreferenceServiceInternal_FetchReminderDaysInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_FetchReminderDaysInternal_Call) Return(_a0 []int, _a1 error) *MockNotificationPreferenceServiceInternal_FetchReminderDaysInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationPreferenceServiceInternal_FetchReminderDaysInternal_Call) RunAndReturn(run func(context.Context) ([]int, error)) *MockNotificationPreferenceServiceInternal_FetchReminderDaysInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotificationPreferenceServiceInternal creates a new instance of MockNotificationPreferenceServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationPreferenceServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationPreferenceServiceInternal {
+	mock := &MockNotificationPreferenceServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockNotificationServiceExternal is an autogenerated mock type for the NotificationServiceExternal type
+type MockNotificationServiceExternal struct {
+	mock.Mock
+}
+
+type MockNotificationServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationServiceExternal) EXPECT() *MockNotificationServiceExternal_Expecter {
+	return &MockNotificationServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// GetNotifications provides a mock function with given fields: ctx, id, claimedUserID, query
+func (_m *MockNotificationServiceExternal) GetNotifications(ctx context.Context, id string, claimedUserID string, query models.NotificationQuery) (*models.NotificationPage, error) {
+	ret := _m.Called(ctx, id, claimedUserID, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotifications")
+	}
+
+	var r0 *models.NotificationPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.NotificationQuery) (*models.NotificationPage, error)); ok {
+		return rf(ctx, id, claimedUserID, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.NotificationQuery) *models.NotificationPage); ok {
+		r0 = rf(ctx, id, claimedUserID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.NotificationQuery) error); ok {
+		r1 = rf(ctx, id, claimedUserID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationServiceExternal_GetNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotifications'
+type MockNotificationServiceExternal_GetNotifications_Call struct {
+	*mock.Call
+}
+
+// GetNotifications is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - query models.NotificationQuery
+func (_e *MockNotificationServiceExternal_Expecter) GetNotifications(ctx interface{}, id interface{}, claimedUserID interface{}, query interface{}) *MockNotificationServiceExternal_GetNotifications_Call {
+	return &MockNotificationServiceExternal_GetNotifications_Call{Call: _e.mock.On("GetNotifications", ctx, id, claimedUserID, query)}
+}
+
+func (_c *MockNotificationServiceExternal_GetNotifications_Call) Run(run func(ctx context.Context, id string, claimedUserID string, query models.NotificationQuery)) *MockNotificationServiceExternal_GetNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.NotificationQuery))
+	})
+	return _c
+}
+
+func (_c *MockNotificationServiceExternal_GetNotifications_Call) Return(_a0 *models.NotificationPage, _a1 error) *MockNotificationServiceExternal_GetNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationServiceExternal_GetNotifications_Call) RunAndReturn(run func(context.Context, string, string, models.NotificationQuery) (*models.NotificationPage, error)) *MockNotificationServiceExternal_GetNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotificationServiceExternal creates a new instance of MockNotificationServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationServiceExternal {
+	mock := &MockNotificationServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,144 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockNotificationServiceInternal is an autogenerated mock type for the NotificationServiceInternal type
+type MockNotificationServiceInternal struct {
+	mock.Mock
+}
+
+type MockNotificationServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationServiceInternal) EXPECT() *MockNotificationServiceInternal_Expecter {
+	return &MockNotificationServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// DeleteNotificationsInternal provides a mock function with given fields: ctx, userID
+func (_m *MockNotificationServiceInternal) DeleteNotificationsInternal(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNotificationsInternal")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationServiceInternal_DeleteNotificationsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNotificationsInternal'
+type MockNotificationServiceInternal_DeleteNotificationsInternal_Call struct {
+	*mock.Call
+}
+
+// DeleteNotificationsInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockNotificationServiceInternal_Expecter) DeleteNotificationsInternal(ctx interface{}, userID interface{}) *MockNotificationServiceInternal_DeleteNotificationsInternal_Call {
+	return &MockNotificationServiceInternal_DeleteNotificationsInternal_Call{Call: _e.mock.On("DeleteNotificationsInternal", ctx, userID)}
+}
+
+func (_c *MockNotificationServiceInternal_DeleteNotificationsInternal_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockNotificationServiceInternal_DeleteNotificationsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockNotificationServiceInternal_DeleteNotificationsInternal_Call) Return(_a0 int64, _a1 error) *MockNotificationServiceInternal_DeleteNotificationsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationServiceInternal_DeleteNotificationsInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockNotificationServiceInternal_DeleteNotificationsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordNotificationInternal provides a mock function with given fields: ctx, notification
+func (_m *MockNotificationServiceInternal) RecordNotificationInternal(ctx context.Context, notification *models.Notification) error {
+	ret := _m.Called(ctx, notification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordNotificationInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Notification) error); ok {
+		r0 = rf(ctx, notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotificationServiceInternal_RecordNotificationInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordNotificationInternal'
+type MockNotificationServiceInternal_RecordNotificationInternal_Call struct {
+	*mock.Call
+}
+
+// RecordNotificationInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notification *models.Notification
+func (_e *MockNotificationServiceInternal_Expecter) RecordNotificationInternal(ctx interface{}, notification interface{}) *MockNotificationServiceInternal_RecordNotificationInternal_Call {
+	return &MockNotificationServiceInternal_RecordNotificationInternal_Call{Call: _e.mock.On("RecordNotificationInternal", ctx, notification)}
+}
+
+func (_c *MockNotificationServiceInternal_RecordNotificationInternal_Call) Run(run func(ctx context.Context, notification *models.Notification)) *MockNotificationServiceInternal_RecordNotificationInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Notification))
+	})
+	return _c
+}
+
+func (_c *MockNotificationServiceInternal_RecordNotificationInternal_Call) Return(_a0 error) *MockNotificationServiceInternal_RecordNotificationInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotificationServiceInternal_RecordNotificationInternal_Call) RunAndReturn(run func(context.Context, *models.Notification) error) *MockNotificationServiceInternal_RecordNotificationInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotificationServiceInternal creates a new instance of MockNotificationServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationServiceInternal {
+	mock := &MockNotificationServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,146 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockOutboxServiceInternal is an autogenerated mock type for the OutboxServiceInternal type
+type MockOutboxServiceInternal struct {
+	mock.Mock
+}
+
+type MockOutboxServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxServiceInternal) EXPECT() *MockOutboxServiceInternal_Expecter {
+	return &MockOutboxServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// FetchPendingEventsInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockOutboxServiceInternal) FetchPendingEventsInternal(_a0 context.Context, _a1 int) ([]*models.OutboxEvent, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchPendingEventsInternal")
+	}
+
+	var r0 []*models.OutboxEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.OutboxEvent, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.OutboxEvent); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OutboxEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOutboxServiceInternal_FetchPendingEventsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchPendingEventsInternal'
+type MockOutboxServiceInternal_FetchPendingEventsInternal_Call struct {
+	*mock.Call
+}
+
+// FetchPendingEventsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 int
+func (_e *MockOutboxServiceInternal_Expecter) FetchPendingEventsInternal(_a0 interface{}, _a1 interface{}) *MockOutboxServiceInternal_FetchPendingEventsInternal_Call {
+	return &MockOutboxServiceInternal_FetchPendingEventsInternal_Call{Call: _e.mock.On("FetchPendingEventsInternal", _a0, _a1)}
+}
+
+func (_c *MockOutboxServiceInternal_FetchPendingEventsInternal_Call) Run(run func(_a0 context.Context, _a1 int)) *MockOutboxServiceInternal_FetchPendingEventsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockOutboxServiceInternal_FetchPendingEventsInternal_Call) Return(_a0 []*models.OutboxEvent, _a1 error) *MockOutboxServiceInternal_FetchPendingEventsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOutboxServiceInternal_FetchPendingEventsInternal_Call) RunAndReturn(run func(context.Context, int) ([]*models.OutboxEvent, error)) *MockOutboxServiceInternal_FetchPendingEventsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkEventPublishedInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockOutboxServiceInternal) MarkEventPublishedInternal(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEventPublishedInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockOutboxServiceInternal_MarkEventPublishedInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkEventPublishedInternal'
+type MockOutboxServiceInternal_MarkEventPublishedInternal_Call struct {
+	*mock.Call
+}
+
+// MarkEventPublishedInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockOutboxServiceInternal_Expecter) MarkEventPublishedInternal(_a0 interface{}, _a1 interface{}) *MockOutboxServiceInternal_MarkEventPublishedInternal_Call {
+	return &MockOutboxServiceInternal_MarkEventPublishedInternal_Call{Call: _e.mock.On("MarkEventPublishedInternal", _a0, _a1)}
+}
+
+func (_c *MockOutboxServiceInternal_MarkEventPublishedInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockOutboxServiceInternal_MarkEventPublishedInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockOutboxServiceInternal_MarkEventPublishedInternal_Call) Return(_a0 error) *MockOutboxServiceInternal_MarkEventPublishedInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOutboxServiceInternal_MarkEventPublishedInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockOutboxServiceInternal_MarkEventPublishedInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOutboxServiceInternal creates a new instance of MockOutboxServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxServiceInternal {
+	mock := &MockOutboxServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
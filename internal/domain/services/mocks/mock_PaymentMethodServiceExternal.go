@@ -0,0 +1,386 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPaymentMethodServiceExternal is an autogenerated mock type for the PaymentMethodServiceExternal type
+type MockPaymentMethodServiceExternal struct {
+	mock.Mock
+}
+
+type MockPaymentMethodServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPaymentMethodServiceExternal) EXPECT() *MockPaymentMethodServiceExternal_Expecter {
+	return &MockPaymentMethodServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// CreatePaymentMethod provides a mock function with given fields: ctx, method, claimedUserID
+func (_m *MockPaymentMethodServiceExternal) CreatePaymentMethod(ctx context.Context, method *models.PaymentMethod, claimedUserID string) (*models.PaymentMethod, error) {
+	ret := _m.Called(ctx, method, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePaymentMethod")
+	}
+
+	var r0 *models.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PaymentMethod, string) (*models.PaymentMethod, error)); ok {
+		return rf(ctx, method, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PaymentMethod, string) *models.PaymentMethod); ok {
+		r0 = rf(ctx, method, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaymentMethod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PaymentMethod, string) error); ok {
+		r1 = rf(ctx, method, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodServiceExternal_CreatePaymentMethod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePaymentMethod'
+type MockPaymentMethodServiceExternal_CreatePaymentMethod_Call struct {
+	*mock.Call
+}
+
+// CreatePaymentMethod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - method *models.PaymentMethod
+//   - claimedUserID string
+func (_e *MockPaymentMethodServiceExternal_Expecter) CreatePaymentMethod(ctx interface{}, method interface{}, claimedUserID interface{}) *MockPaymentMethodServiceExternal_CreatePaymentMethod_Call {
+	return &MockPaymentMethodServiceExternal_CreatePaymentMethod_Call{Call: _e.mock.On("CreatePaymentMethod", ctx, method, claimedUserID)}
+}
+
+func (_c *MockPaymentMethodServiceExternal_CreatePaymentMethod_Call) Run(run func(ctx context.Context, method *models.PaymentMethod, claimedUserID string)) *MockPaymentMethodServiceExternal_CreatePaymentMethod_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PaymentMethod), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_CreatePaymentMethod_Call) Return(_a0 *models.PaymentMethod, _a1 error) *MockPaymentMethodServiceExternal_CreatePaymentMethod_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_CreatePaymentMethod_Call) RunAndReturn(run func(context.Context, *models.PaymentMethod, string) (*models.PaymentMethod, error)) *MockPaymentMethodServiceExternal_CreatePaymentMethod_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePaymentMethod provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockPaymentMethodServiceExternal) DeletePaymentMethod(ctx context.Context, id string, claimedUserID string) error {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePaymentMethod")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentMethodServiceExternal_DeletePaymentMethod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePaymentMethod'
+type MockPaymentMethodServiceExternal_DeletePaymentMethod_Call struct {
+	*mock.Call
+}
+
+// DeletePaymentMethod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockPaymentMethodServiceExternal_Expecter) DeletePaymentMethod(ctx interface{}, id interface{}, claimedUserID interface{}) *MockPaymentMethodServiceExternal_DeletePaymentMethod_Call {
+	return &MockPaymentMethodServiceExternal_DeletePaymentMethod_Call{Call: _e.mock.On("DeletePaymentMethod", ctx, id, claimedUserID)}
+}
+
+func (_c *MockPaymentMethodServiceExternal_DeletePaymentMethod_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockPaymentMethodServiceExternal_DeletePaymentMethod_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_DeletePaymentMethod_Call) Return(_a0 error) *MockPaymentMethodServiceExternal_DeletePaymentMethod_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_DeletePaymentMethod_Call) RunAndReturn(run func(context.Context, string, string) error) *MockPaymentMethodServiceExternal_DeletePaymentMethod_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPaymentMethod provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockPaymentMethodServiceExternal) GetPaymentMethod(ctx context.Context, id string, claimedUserID string) (*models.PaymentMethod, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentMethod")
+	}
+
+	var r0 *models.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.PaymentMethod, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PaymentMethod); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaymentMethod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodServiceExternal_GetPaymentMethod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentMethod'
+type MockPaymentMethodServiceExternal_GetPaymentMethod_Call struct {
+	*mock.Call
+}
+
+// GetPaymentMethod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockPaymentMethodServiceExternal_Expecter) GetPaymentMethod(ctx interface{}, id interface{}, claimedUserID interface{}) *MockPaymentMethodServiceExternal_GetPaymentMethod_Call {
+	return &MockPaymentMethodServiceExternal_GetPaymentMethod_Call{Call: _e.mock.On("GetPaymentMethod", ctx, id, claimedUserID)}
+}
+
+func (_c *MockPaymentMethodServiceExternal_GetPaymentMethod_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockPaymentMethodServiceExternal_GetPaymentMethod_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_GetPaymentMethod_Call) Return(_a0 *models.PaymentMethod, _a1 error) *MockPaymentMethodServiceExternal_GetPaymentMethod_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_GetPaymentMethod_Call) RunAndReturn(run func(context.Context, string, string) (*models.PaymentMethod, error)) *MockPaymentMethodServiceExternal_GetPaymentMethod_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPaymentMethods provides a mock function with given fields: ctx, claimedUserID
+func (_m *MockPaymentMethodServiceExternal) GetPaymentMethods(ctx context.Context, claimedUserID string) ([]*models.PaymentMethod, error) {
+	ret := _m.Called(ctx, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentMethods")
+	}
+
+	var r0 []*models.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.PaymentMethod, error)); ok {
+		return rf(ctx, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.PaymentMethod); ok {
+		r0 = rf(ctx, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PaymentMethod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodServiceExternal_GetPaymentMethods_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentMethods'
+type MockPaymentMethodServiceExternal_GetPaymentMethods_Call struct {
+	*mock.Call
+}
+
+// GetPaymentMethods is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+func (_e *MockPaymentMethodServiceExternal_Expecter) GetPaymentMethods(ctx interface{}, claimedUserID interface{}) *MockPaymentMethodServiceExternal_GetPaymentMethods_Call {
+	return &MockPaymentMethodServiceExternal_GetPaymentMethods_Call{Call: _e.mock.On("GetPaymentMethods", ctx, claimedUserID)}
+}
+
+func (_c *MockPaymentMethodServiceExternal_GetPaymentMethods_Call) Run(run func(ctx context.Context, claimedUserID string)) *MockPaymentMethodServiceExternal_GetPaymentMethods_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_GetPaymentMethods_Call) Return(_a0 []*models.PaymentMethod, _a1 error) *MockPaymentMethodServiceExternal_GetPaymentMethods_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_GetPaymentMethods_Call) RunAndReturn(run func(context.Context, string) ([]*models.PaymentMethod, error)) *MockPaymentMethodServiceExternal_GetPaymentMethods_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LinkSubscription provides a mock function with given fields: ctx, subscriptionID, paymentMethodID, claimedUserID
+func (_m *MockPaymentMethodServiceExternal) LinkSubscription(ctx context.Context, subscriptionID string, paymentMethodID string, claimedUserID string) (*models.Subscription, error) {
+	ret := _m.Called(ctx, subscriptionID, paymentMethodID, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkSubscription")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.Subscription, error)); ok {
+		return rf(ctx, subscriptionID, paymentMethodID, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.Subscription); ok {
+		r0 = rf(ctx, subscriptionID, paymentMethodID, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, subscriptionID, paymentMethodID, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodServiceExternal_LinkSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkSubscription'
+type MockPaymentMethodServiceExternal_LinkSubscription_Call struct {
+	*mock.Call
+}
+
+// LinkSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID string
+//   - paymentMethodID string
+//   - claimedUserID string
+func (_e *MockPaymentMethodServiceExternal_Expecter) LinkSubscription(ctx interface{}, subscriptionID interface{}, paymentMethodID interface{}, claimedUserID interface{}) *MockPaymentMethodServiceExternal_LinkSubscription_Call {
+	return &MockPaymentMethodServiceExternal_LinkSubscription_Call{Call: _e.mock.On("LinkSubscription", ctx, subscriptionID, paymentMethodID, claimedUserID)}
+}
+
+func (_c *MockPaymentMethodServiceExternal_LinkSubscription_Call) Run(run func(ctx context.Context, subscriptionID string, paymentMethodID string, claimedUserID string)) *MockPaymentMethodServiceExternal_LinkSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_LinkSubscription_Call) Return(_a0 *models.Subscription, _a1 error) *MockPaymentMethodServiceExternal_LinkSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_LinkSubscription_Call) RunAndReturn(run func(context.Context, string, string, string) (*models.Subscription, error)) *MockPaymentMethodServiceExternal_LinkSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePaymentMethod provides a mock function with given fields: ctx, id, method, claimedUserID
+func (_m *MockPaymentMethodServiceExternal) UpdatePaymentMethod(ctx context.Context, id string, method *models.PaymentMethod, claimedUserID string) (*models.PaymentMethod, error) {
+	ret := _m.Called(ctx, id, method, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePaymentMethod")
+	}
+
+	var r0 *models.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PaymentMethod, string) (*models.PaymentMethod, error)); ok {
+		return rf(ctx, id, method, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PaymentMethod, string) *models.PaymentMethod); ok {
+		r0 = rf(ctx, id, method, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaymentMethod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.PaymentMethod, string) error); ok {
+		r1 = rf(ctx, id, method, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodServiceExternal_UpdatePaymentMethod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePaymentMethod'
+type MockPaymentMethodServiceExternal_UpdatePaymentMethod_Call struct {
+	*mock.Call
+}
+
+// UpdatePaymentMethod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - method *models.PaymentMethod
+//   - claimedUserID string
+func (_e *MockPaymentMethodServiceExternal_Expecter) UpdatePaymentMethod(ctx interface{}, id interface{}, method interface{}, claimedUserID interface{}) *MockPaymentMethodServiceExternal_UpdatePaymentMethod_Call {
+	return &MockPaymentMethodServiceExternal_UpdatePaymentMethod_Call{Call: _e.mock.On("UpdatePaymentMethod", ctx, id, method, claimedUserID)}
+}
+
+func (_c *MockPaymentMethodServiceExternal_UpdatePaymentMethod_Call) Run(run func(ctx context.Context, id string, method *models.PaymentMethod, claimedUserID string)) *MockPaymentMethodServiceExternal_UpdatePaymentMethod_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.PaymentMethod), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_UpdatePaymentMethod_Call) Return(_a0 *models.PaymentMethod, _a1 error) *MockPaymentMethodServiceExternal_UpdatePaymentMethod_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceExternal_UpdatePaymentMethod_Call) RunAndReturn(run func(context.Context, string, *models.PaymentMethod, string) (*models.PaymentMethod, error)) *MockPaymentMethodServiceExternal_UpdatePaymentMethod_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPaymentMethodServiceExternal creates a new instance of MockPaymentMethodServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPaymentMethodServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPaymentMethodServiceExternal {
+	mock := &MockPaymentMethodServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
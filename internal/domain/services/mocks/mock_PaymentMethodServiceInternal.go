@@ -0,0 +1,156 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockPaymentMethodServiceInternal is an autogenerated mock type for the PaymentMethodServiceInternal type
+type MockPaymentMethodServiceInternal struct {
+	mock.Mock
+}
+
+type MockPaymentMethodServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPaymentMethodServiceInternal) EXPECT() *MockPaymentMethodServiceInternal_Expecter {
+	return &MockPaymentMethodServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// DeletePaymentMethodsInternal provides a mock function with given fields: ctx, userID
+func (_m *MockPaymentMethodServiceInternal) DeletePaymentMethodsInternal(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePaymentMethodsInternal")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodServiceInternal_DeletePaymentMethodsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePaymentMethodsInternal'
+type MockPaymentMethodServiceInternal_DeletePaymentMethodsInternal_Call struct {
+	*mock.Call
+}
+
+// DeletePaymentMethodsInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockPaymentMethodServiceInternal_Expecter) DeletePaymentMethodsInternal(ctx interface{}, userID interface{}) *MockPaymentMethodServiceInternal_DeletePaymentMethodsInternal_Call {
+	return &MockPaymentMethodServiceInternal_DeletePaymentMethodsInternal_Call{Call: _e.mock.On("DeletePaymentMethodsInternal", ctx, userID)}
+}
+
+func (_c *MockPaymentMethodServiceInternal_DeletePaymentMethodsInternal_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockPaymentMethodServiceInternal_DeletePaymentMethodsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceInternal_DeletePaymentMethodsInternal_Call) Return(_a0 int64, _a1 error) *MockPaymentMethodServiceInternal_DeletePaymentMethodsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceInternal_DeletePaymentMethodsInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockPaymentMethodServiceInternal_DeletePaymentMethodsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchPaymentMethodByIDInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockPaymentMethodServiceInternal) FetchPaymentMethodByIDInternal(_a0 context.Context, _a1 bson.ObjectID) (*models.PaymentMethod, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchPaymentMethodByIDInternal")
+	}
+
+	var r0 *models.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.PaymentMethod, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.PaymentMethod); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaymentMethod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethodServiceInternal_FetchPaymentMethodByIDInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchPaymentMethodByIDInternal'
+type MockPaymentMethodServiceInternal_FetchPaymentMethodByIDInternal_Call struct {
+	*mock.Call
+}
+
+// FetchPaymentMethodByIDInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockPaymentMethodServiceInternal_Expecter) FetchPaymentMethodByIDInternal(_a0 interface{}, _a1 interface{}) *MockPaymentMethodServiceInternal_FetchPaymentMethodByIDInternal_Call {
+	return &MockPaymentMethodServiceInternal_FetchPaymentMethodByIDInternal_Call{Call: _e.mock.On("FetchPaymentMethodByIDInternal", _a0, _a1)}
+}
+
+func (_c *MockPaymentMethodServiceInternal_FetchPaymentMethodByIDInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockPaymentMethodServiceInternal_FetchPaymentMethodByIDInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceInternal_FetchPaymentMethodByIDInternal_Call) Return(_a0 *models.PaymentMethod, _a1 error) *MockPaymentMethodServiceInternal_FetchPaymentMethodByIDInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethodServiceInternal_FetchPaymentMethodByIDInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.PaymentMethod, error)) *MockPaymentMethodServiceInternal_FetchPaymentMethodByIDInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPaymentMethodServiceInternal creates a new instance of MockPaymentMethodServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPaymentMethodServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPaymentMethodServiceInternal {
+	mock := &MockPaymentMethodServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
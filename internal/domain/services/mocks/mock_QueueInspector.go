@@ -0,0 +1,93 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	asynq "github.com/hibiken/asynq"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockQueueInspector is an autogenerated mock type for the QueueInspector type
+type MockQueueInspector struct {
+	mock.Mock
+}
+
+type MockQueueInspector_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockQueueInspector) EXPECT() *MockQueueInspector_Expecter {
+	return &MockQueueInspector_Expecter{mock: &_m.Mock}
+}
+
+// GetQueueInfo provides a mock function with given fields: queue
+func (_m *MockQueueInspector) GetQueueInfo(queue string) (*asynq.QueueInfo, error) {
+	ret := _m.Called(queue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueueInfo")
+	}
+
+	var r0 *asynq.QueueInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*asynq.QueueInfo, error)); ok {
+		return rf(queue)
+	}
+	if rf, ok := ret.Get(0).(func(string) *asynq.QueueInfo); ok {
+		r0 = rf(queue)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*asynq.QueueInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(queue)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueueInspector_GetQueueInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQueueInfo'
+type MockQueueInspector_GetQueueInfo_Call struct {
+	*mock.Call
+}
+
+// GetQueueInfo is a helper method to define mock.On call
+//   - queue string
+func (_e *MockQueueInspector_Expecter) GetQueueInfo(queue interface{}) *MockQueueInspector_GetQueueInfo_Call {
+	return &MockQueueInspector_GetQueueInfo_Call{Call: _e.mock.On("GetQueueInfo", queue)}
+}
+
+func (_c *MockQueueInspector_GetQueueInfo_Call) Run(run func(queue string)) *MockQueueInspector_GetQueueInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockQueueInspector_GetQueueInfo_Call) Return(_a0 *asynq.QueueInfo, _a1 error) *MockQueueInspector_GetQueueInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueueInspector_GetQueueInfo_Call) RunAndReturn(run func(string) (*asynq.QueueInfo, error)) *MockQueueInspector_GetQueueInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockQueueInspector creates a new instance of MockQueueInspector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockQueueInspector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockQueueInspector {
+	mock := &MockQueueInspector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	redis_rate "github.com/go-redis/redis_rate/v10"
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
@@ -23,9 +25,9 @@ func (_m *MockRateLimiterService) EXPECT() *MockRateLimiterService_Expecter {
 	return &MockRateLimiterService_Expecter{mock: &_m.Mock}
 }
 
-// Allowed provides a mock function with given fields: ctx, ip
-func (_m *MockRateLimiterService) Allowed(ctx context.Context, ip string) (bool, int, time.Duration, error) {
-	ret := _m.Called(ctx, ip)
+// Allowed provides a mock function with given fields: ctx, key
+func (_m *MockRateLimiterService) Allowed(ctx context.Context, key string) (bool, int, time.Duration, error) {
+	ret := _m.Called(ctx, key)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Allowed")
@@ -36,28 +38,28 @@ func (_m *MockRateLimiterService) Allowed(ctx context.Context, ip string) (bool,
 	var r2 time.Duration
 	var r3 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, int, time.Duration, error)); ok {
-		return rf(ctx, ip)
+		return rf(ctx, key)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = rf(ctx, ip)
+		r0 = rf(ctx, key)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
-		r1 = rf(ctx, ip)
+		r1 = rf(ctx, key)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
 	if rf, ok := ret.Get(2).(func(context.Context, string) time.Duration); ok {
-		r2 = rf(ctx, ip)
+		r2 = rf(ctx, key)
 	} else {
 		r2 = ret.Get(2).(time.Duration)
 	}
 
 	if rf, ok := ret.Get(3).(func(context.Context, string) error); ok {
-		r3 = rf(ctx, ip)
+		r3 = rf(ctx, key)
 	} else {
 		r3 = ret.Error(3)
 	}
@@ -72,12 +74,12 @@ type MockRateLimiterService_Allowed_Call struct {
 
 // Allowed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ip string
-func (_e *MockRateLimiterService_Expecter) Allowed(ctx interface{}, ip interface{}) *MockRateLimiterService_Allowed_Call {
-	return &MockRateLimiterService_Allowed_Call{Call: _e.mock.On("Allowed", ctx, ip)}
+//   - key string
+func (_e *MockRateLimiterService_Expecter) Allowed(ctx interface{}, key interface{}) *MockRateLimiterService_Allowed_Call {
+	return &MockRateLimiterService_Allowed_Call{Call: _e.mock.On("Allowed", ctx, key)}
 }
 
-func (_c *MockRateLimiterService_Allowed_Call) Run(run func(ctx context.Context, ip string)) *MockRateLimiterService_Allowed_Call {
+func (_c *MockRateLimiterService_Allowed_Call) Run(run func(ctx context.Context, key string)) *MockRateLimiterService_Allowed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
@@ -94,6 +96,98 @@ func (_c *MockRateLimiterService_Allowed_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// SetLimit provides a mock function with given fields: limit
+func (_m *MockRateLimiterService) SetLimit(limit redis_rate.Limit) {
+	_m.Called(limit)
+}
+
+// MockRateLimiterService_SetLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLimit'
+type MockRateLimiterService_SetLimit_Call struct {
+	*mock.Call
+}
+
+// SetLimit is a helper method to define mock.On call
+//   - limit redis_rate.Limit
+func (_e *MockRateLimiterService_Expecter) SetLimit(limit interface{}) *MockRateLimiterService_SetLimit_Call {
+	return &MockRateLimiterService_SetLimit_Call{Call: _e.mock.On("SetLimit", limit)}
+}
+
+func (_c *MockRateLimiterService_SetLimit_Call) Run(run func(limit redis_rate.Limit)) *MockRateLimiterService_SetLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(redis_rate.Limit))
+	})
+	return _c
+}
+
+func (_c *MockRateLimiterService_SetLimit_Call) Return() *MockRateLimiterService_SetLimit_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRateLimiterService_SetLimit_Call) RunAndReturn(run func(redis_rate.Limit)) *MockRateLimiterService_SetLimit_Call {
+	_c.Run(run)
+	return _c
+}
+
+// State provides a mock function with given fields: ctx, key
+func (_m *MockRateLimiterService) State(ctx context.Context, key string) (*models.RateLimitState, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for State")
+	}
+
+	var r0 *models.RateLimitState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.RateLimitState, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.RateLimitState); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RateLimitState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRateLimiterService_State_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'State'
+type MockRateLimiterService_State_Call struct {
+	*mock.Call
+}
+
+// State is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockRateLimiterService_Expecter) State(ctx interface{}, key interface{}) *MockRateLimiterService_State_Call {
+	return &MockRateLimiterService_State_Call{Call: _e.mock.On("State", ctx, key)}
+}
+
+func (_c *MockRateLimiterService_State_Call) Run(run func(ctx context.Context, key string)) *MockRateLimiterService_State_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRateLimiterService_State_Call) Return(_a0 *models.RateLimitState, _a1 error) *MockRateLimiterService_State_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRateLimiterService_State_Call) RunAndReturn(run func(context.Context, string) (*models.RateLimitState, error)) *MockRateLimiterService_State_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRateLimiterService creates a new instance of MockRateLimiterService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRateLimiterService(t interface {
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReconciliationServiceExternal is an autogenerated mock type for the ReconciliationServiceExternal type
+type MockReconciliationServiceExternal struct {
+	mock.Mock
+}
+
+type MockReconciliationServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReconciliationServiceExternal) EXPECT() *MockReconciliationServiceExternal_Expecter {
+	return &MockReconciliationServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// GetReconciliationReport provides a mock function with given fields: _a0
+func (_m *MockReconciliationServiceExternal) GetReconciliationReport(_a0 context.Context) (*models.ReconciliationReport, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReconciliationReport")
+	}
+
+	var r0 *models.ReconciliationReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.ReconciliationReport, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.ReconciliationReport); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ReconciliationReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReconciliationServiceExternal_GetReconciliationReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReconciliationReport'
+type MockReconciliationServiceExternal_GetReconciliationReport_Call struct {
+	*mock.Call
+}
+
+// GetReconciliationReport is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockReconciliationServiceExternal_Expecter) GetReconciliationReport(_a0 interface{}) *MockReconciliationServiceExternal_GetReconciliationReport_Call {
+	return &MockReconciliationServiceExternal_GetReconciliationReport_Call{Call: _e.mock.On("GetReconciliationReport", _a0)}
+}
+
+func (_c *MockReconciliationServiceExternal_GetReconciliationReport_Call) Run(run func(_a0 context.Context)) *MockReconciliationServiceExternal_GetReconciliationReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockReconciliationServiceExternal_GetReconciliationReport_Call) Return(_a0 *models.ReconciliationReport, _a1 error) *MockReconciliationServiceExternal_GetReconciliationReport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReconciliationServiceExternal_GetReconciliationReport_Call) RunAndReturn(run func(context.Context) (*models.ReconciliationReport, error)) *MockReconciliationServiceExternal_GetReconciliationReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReconciliationServiceExternal creates a new instance of MockReconciliationServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReconciliationServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReconciliationServiceExternal {
+	mock := &MockReconciliationServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
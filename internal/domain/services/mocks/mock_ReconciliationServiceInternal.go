@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReconciliationServiceInternal is an autogenerated mock type for the ReconciliationServiceInternal type
+type MockReconciliationServiceInternal struct {
+	mock.Mock
+}
+
+type MockReconciliationServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReconciliationServiceInternal) EXPECT() *MockReconciliationServiceInternal_Expecter {
+	return &MockReconciliationServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// ReconcileInternal provides a mock function with given fields: _a0
+func (_m *MockReconciliationServiceInternal) ReconcileInternal(_a0 context.Context) (*models.ReconciliationReport, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileInternal")
+	}
+
+	var r0 *models.ReconciliationReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.ReconciliationReport, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.ReconciliationReport); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ReconciliationReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReconciliationServiceInternal_ReconcileInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcileInternal'
+type MockReconciliationServiceInternal_ReconcileInternal_Call struct {
+	*mock.Call
+}
+
+// ReconcileInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockReconciliationServiceInternal_Expecter) ReconcileInternal(_a0 interface{}) *MockReconciliationServiceInternal_ReconcileInternal_Call {
+	return &MockReconciliationServiceInternal_ReconcileInternal_Call{Call: _e.mock.On("ReconcileInternal", _a0)}
+}
+
+func (_c *MockReconciliationServiceInternal_ReconcileInternal_Call) Run(run func(_a0 context.Context)) *MockReconciliationServiceInternal_ReconcileInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockReconciliationServiceInternal_ReconcileInternal_Call) Return(_a0 *models.ReconciliationReport, _a1 error) *MockReconciliationServiceInternal_ReconcileInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReconciliationServiceInternal_ReconcileInternal_Call) RunAndReturn(run func(context.Context) (*models.ReconciliationReport, error)) *MockReconciliationServiceInternal_ReconcileInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReconciliationServiceInternal creates a new instance of MockReconciliationServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReconciliationServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReconciliationServiceInternal {
+	mock := &MockReconciliationServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,154 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReminderDedupeService is an autogenerated mock type for the ReminderDedupeService type
+type MockReminderDedupeService struct {
+	mock.Mock
+}
+
+type MockReminderDedupeService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReminderDedupeService) EXPECT() *MockReminderDedupeService_Expecter {
+	return &MockReminderDedupeService_Expecter{mock: &_m.Mock}
+}
+
+// ClearReminderMarkers provides a mock function with given fields: ctx, subscriptionID, daysBefore
+func (_m *MockReminderDedupeService) ClearReminderMarkers(ctx context.Context, subscriptionID string, daysBefore *int) (int, error) {
+	ret := _m.Called(ctx, subscriptionID, daysBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearReminderMarkers")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) (int, error)); ok {
+		return rf(ctx, subscriptionID, daysBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) int); ok {
+		r0 = rf(ctx, subscriptionID, daysBefore)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = rf(ctx, subscriptionID, daysBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReminderDedupeService_ClearReminderMarkers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearReminderMarkers'
+type MockReminderDedupeService_ClearReminderMarkers_Call struct {
+	*mock.Call
+}
+
+// ClearReminderMarkers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID string
+//   - daysBefore *int
+func (_e *MockReminderDedupeService_Expecter) ClearReminderMarkers(ctx interface{}, subscriptionID interface{}, daysBefore interface{}) *MockReminderDedupeService_ClearReminderMarkers_Call {
+	return &MockReminderDedupeService_ClearReminderMarkers_Call{Call: _e.mock.On("ClearReminderMarkers", ctx, subscriptionID, daysBefore)}
+}
+
+func (_c *MockReminderDedupeService_ClearReminderMarkers_Call) Run(run func(ctx context.Context, subscriptionID string, daysBefore *int)) *MockReminderDedupeService_ClearReminderMarkers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*int))
+	})
+	return _c
+}
+
+func (_c *MockReminderDedupeService_ClearReminderMarkers_Call) Return(_a0 int, _a1 error) *MockReminderDedupeService_ClearReminderMarkers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReminderDedupeService_ClearReminderMarkers_Call) RunAndReturn(run func(context.Context, string, *int) (int, error)) *MockReminderDedupeService_ClearReminderMarkers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListReminderMarkers provides a mock function with given fields: ctx, subscriptionID
+func (_m *MockReminderDedupeService) ListReminderMarkers(ctx context.Context, subscriptionID string) ([]models.ReminderDedupeEntry, error) {
+	ret := _m.Called(ctx, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReminderMarkers")
+	}
+
+	var r0 []models.ReminderDedupeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.ReminderDedupeEntry, error)); ok {
+		return rf(ctx, subscriptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.ReminderDedupeEntry); ok {
+		r0 = rf(ctx, subscriptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReminderDedupeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReminderDedupeService_ListReminderMarkers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReminderMarkers'
+type MockReminderDedupeService_ListReminderMarkers_Call struct {
+	*mock.Call
+}
+
+// ListReminderMarkers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID string
+func (_e *MockReminderDedupeService_Expecter) ListReminderMarkers(ctx interface{}, subscriptionID interface{}) *MockReminderDedupeService_ListReminderMarkers_Call {
+	return &MockReminderDedupeService_ListReminderMarkers_Call{Call: _e.mock.On("ListReminderMarkers", ctx, subscriptionID)}
+}
+
+func (_c *MockReminderDedupeService_ListReminderMarkers_Call) Run(run func(ctx context.Context, subscriptionID string)) *MockReminderDedupeService_ListReminderMarkers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockReminderDedupeService_ListReminderMarkers_Call) Return(_a0 []models.ReminderDedupeEntry, _a1 error) *MockReminderDedupeService_ListReminderMarkers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReminderDedupeService_ListReminderMarkers_Call) RunAndReturn(run func(context.Context, string) ([]models.ReminderDedupeEntry, error)) *MockReminderDedupeService_ListReminderMarkers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReminderDedupeService creates a new instance of MockReminderDedupeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReminderDedupeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReminderDedupeService {
+	mock := &MockReminderDedupeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
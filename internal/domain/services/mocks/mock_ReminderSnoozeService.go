@@ -0,0 +1,146 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReminderSnoozeService is an autogenerated mock type for the ReminderSnoozeService type
+type MockReminderSnoozeService struct {
+	mock.Mock
+}
+
+type MockReminderSnoozeService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReminderSnoozeService) EXPECT() *MockReminderSnoozeService_Expecter {
+	return &MockReminderSnoozeService_Expecter{mock: &_m.Mock}
+}
+
+// SnoozeReminders provides a mock function with given fields: ctx, id, claimedUserID, days
+func (_m *MockReminderSnoozeService) SnoozeReminders(ctx context.Context, id string, claimedUserID string, days int) (*models.ReminderSnoozeResponse, error) {
+	ret := _m.Called(ctx, id, claimedUserID, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SnoozeReminders")
+	}
+
+	var r0 *models.ReminderSnoozeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) (*models.ReminderSnoozeResponse, error)); ok {
+		return rf(ctx, id, claimedUserID, days)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) *models.ReminderSnoozeResponse); ok {
+		r0 = rf(ctx, id, claimedUserID, days)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ReminderSnoozeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, id, claimedUserID, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReminderSnoozeService_SnoozeReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SnoozeReminders'
+type MockReminderSnoozeService_SnoozeReminders_Call struct {
+	*mock.Call
+}
+
+// SnoozeReminders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - days int
+func (_e *MockReminderSnoozeService_Expecter) SnoozeReminders(ctx interface{}, id interface{}, claimedUserID interface{}, days interface{}) *MockReminderSnoozeService_SnoozeReminders_Call {
+	return &MockReminderSnoozeService_SnoozeReminders_Call{Call: _e.mock.On("SnoozeReminders", ctx, id, claimedUserID, days)}
+}
+
+func (_c *MockReminderSnoozeService_SnoozeReminders_Call) Run(run func(ctx context.Context, id string, claimedUserID string, days int)) *MockReminderSnoozeService_SnoozeReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockReminderSnoozeService_SnoozeReminders_Call) Return(_a0 *models.ReminderSnoozeResponse, _a1 error) *MockReminderSnoozeService_SnoozeReminders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReminderSnoozeService_SnoozeReminders_Call) RunAndReturn(run func(context.Context, string, string, int) (*models.ReminderSnoozeResponse, error)) *MockReminderSnoozeService_SnoozeReminders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnsnoozeReminders provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockReminderSnoozeService) UnsnoozeReminders(ctx context.Context, id string, claimedUserID string) error {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsnoozeReminders")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReminderSnoozeService_UnsnoozeReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnsnoozeReminders'
+type MockReminderSnoozeService_UnsnoozeReminders_Call struct {
+	*mock.Call
+}
+
+// UnsnoozeReminders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockReminderSnoozeService_Expecter) UnsnoozeReminders(ctx interface{}, id interface{}, claimedUserID interface{}) *MockReminderSnoozeService_UnsnoozeReminders_Call {
+	return &MockReminderSnoozeService_UnsnoozeReminders_Call{Call: _e.mock.On("UnsnoozeReminders", ctx, id, claimedUserID)}
+}
+
+func (_c *MockReminderSnoozeService_UnsnoozeReminders_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockReminderSnoozeService_UnsnoozeReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockReminderSnoozeService_UnsnoozeReminders_Call) Return(_a0 error) *MockReminderSnoozeService_UnsnoozeReminders_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReminderSnoozeService_UnsnoozeReminders_Call) RunAndReturn(run func(context.Context, string, string) error) *MockReminderSnoozeService_UnsnoozeReminders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReminderSnoozeService creates a new instance of MockReminderSnoozeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReminderSnoozeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReminderSnoozeService {
+	mock := &MockReminderSnoozeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,146 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSessionServiceExternal is an autogenerated mock type for the SessionServiceExternal type
+type MockSessionServiceExternal struct {
+	mock.Mock
+}
+
+type MockSessionServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessionServiceExternal) EXPECT() *MockSessionServiceExternal_Expecter {
+	return &MockSessionServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// GetSessions provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockSessionServiceExternal) GetSessions(ctx context.Context, id string, claimedUserID string) ([]*models.Session, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessions")
+	}
+
+	var r0 []*models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.Session, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.Session); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionServiceExternal_GetSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessions'
+type MockSessionServiceExternal_GetSessions_Call struct {
+	*mock.Call
+}
+
+// GetSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockSessionServiceExternal_Expecter) GetSessions(ctx interface{}, id interface{}, claimedUserID interface{}) *MockSessionServiceExternal_GetSessions_Call {
+	return &MockSessionServiceExternal_GetSessions_Call{Call: _e.mock.On("GetSessions", ctx, id, claimedUserID)}
+}
+
+func (_c *MockSessionServiceExternal_GetSessions_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockSessionServiceExternal_GetSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionServiceExternal_GetSessions_Call) Return(_a0 []*models.Session, _a1 error) *MockSessionServiceExternal_GetSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionServiceExternal_GetSessions_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.Session, error)) *MockSessionServiceExternal_GetSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: ctx, id, claimedUserID, sessionID
+func (_m *MockSessionServiceExternal) RevokeSession(ctx context.Context, id string, claimedUserID string, sessionID string) error {
+	ret := _m.Called(ctx, id, claimedUserID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, id, claimedUserID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionServiceExternal_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type MockSessionServiceExternal_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - sessionID string
+func (_e *MockSessionServiceExternal_Expecter) RevokeSession(ctx interface{}, id interface{}, claimedUserID interface{}, sessionID interface{}) *MockSessionServiceExternal_RevokeSession_Call {
+	return &MockSessionServiceExternal_RevokeSession_Call{Call: _e.mock.On("RevokeSession", ctx, id, claimedUserID, sessionID)}
+}
+
+func (_c *MockSessionServiceExternal_RevokeSession_Call) Run(run func(ctx context.Context, id string, claimedUserID string, sessionID string)) *MockSessionServiceExternal_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionServiceExternal_RevokeSession_Call) Return(_a0 error) *MockSessionServiceExternal_RevokeSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionServiceExternal_RevokeSession_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockSessionServiceExternal_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessionServiceExternal creates a new instance of MockSessionServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessionServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessionServiceExternal {
+	mock := &MockSessionServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,244 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+
+	time "time"
+)
+
+// MockSessionServiceInternal is an autogenerated mock type for the SessionServiceInternal type
+type MockSessionServiceInternal struct {
+	mock.Mock
+}
+
+type MockSessionServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessionServiceInternal) EXPECT() *MockSessionServiceInternal_Expecter {
+	return &MockSessionServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// EndSessionInternal provides a mock function with given fields: ctx, sessionID, userID
+func (_m *MockSessionServiceInternal) EndSessionInternal(ctx context.Context, sessionID string, userID bson.ObjectID) error {
+	ret := _m.Called(ctx, sessionID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EndSessionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bson.ObjectID) error); ok {
+		r0 = rf(ctx, sessionID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionServiceInternal_EndSessionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EndSessionInternal'
+type MockSessionServiceInternal_EndSessionInternal_Call struct {
+	*mock.Call
+}
+
+// EndSessionInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionID string
+//   - userID bson.ObjectID
+func (_e *MockSessionServiceInternal_Expecter) EndSessionInternal(ctx interface{}, sessionID interface{}, userID interface{}) *MockSessionServiceInternal_EndSessionInternal_Call {
+	return &MockSessionServiceInternal_EndSessionInternal_Call{Call: _e.mock.On("EndSessionInternal", ctx, sessionID, userID)}
+}
+
+func (_c *MockSessionServiceInternal_EndSessionInternal_Call) Run(run func(ctx context.Context, sessionID string, userID bson.ObjectID)) *MockSessionServiceInternal_EndSessionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSessionServiceInternal_EndSessionInternal_Call) Return(_a0 error) *MockSessionServiceInternal_EndSessionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionServiceInternal_EndSessionInternal_Call) RunAndReturn(run func(context.Context, string, bson.ObjectID) error) *MockSessionServiceInternal_EndSessionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshSessionInternal provides a mock function with given fields: ctx, sessionID, userID, client, expiresAt
+func (_m *MockSessionServiceInternal) RefreshSessionInternal(ctx context.Context, sessionID string, userID bson.ObjectID, client models.SessionClient, expiresAt time.Time) error {
+	ret := _m.Called(ctx, sessionID, userID, client, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshSessionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bson.ObjectID, models.SessionClient, time.Time) error); ok {
+		r0 = rf(ctx, sessionID, userID, client, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionServiceInternal_RefreshSessionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshSessionInternal'
+type MockSessionServiceInternal_RefreshSessionInternal_Call struct {
+	*mock.Call
+}
+
+// RefreshSessionInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionID string
+//   - userID bson.ObjectID
+//   - client models.SessionClient
+//   - expiresAt time.Time
+func (_e *MockSessionServiceInternal_Expecter) RefreshSessionInternal(ctx interface{}, sessionID interface{}, userID interface{}, client interface{}, expiresAt interface{}) *MockSessionServiceInternal_RefreshSessionInternal_Call {
+	return &MockSessionServiceInternal_RefreshSessionInternal_Call{Call: _e.mock.On("RefreshSessionInternal", ctx, sessionID, userID, client, expiresAt)}
+}
+
+func (_c *MockSessionServiceInternal_RefreshSessionInternal_Call) Run(run func(ctx context.Context, sessionID string, userID bson.ObjectID, client models.SessionClient, expiresAt time.Time)) *MockSessionServiceInternal_RefreshSessionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bson.ObjectID), args[3].(models.SessionClient), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSessionServiceInternal_RefreshSessionInternal_Call) Return(_a0 error) *MockSessionServiceInternal_RefreshSessionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionServiceInternal_RefreshSessionInternal_Call) RunAndReturn(run func(context.Context, string, bson.ObjectID, models.SessionClient, time.Time) error) *MockSessionServiceInternal_RefreshSessionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSessionsInternal provides a mock function with given fields: ctx, userID
+func (_m *MockSessionServiceInternal) RevokeSessionsInternal(ctx context.Context, userID bson.ObjectID) (int, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessionsInternal")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionServiceInternal_RevokeSessionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSessionsInternal'
+type MockSessionServiceInternal_RevokeSessionsInternal_Call struct {
+	*mock.Call
+}
+
+// RevokeSessionsInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockSessionServiceInternal_Expecter) RevokeSessionsInternal(ctx interface{}, userID interface{}) *MockSessionServiceInternal_RevokeSessionsInternal_Call {
+	return &MockSessionServiceInternal_RevokeSessionsInternal_Call{Call: _e.mock.On("RevokeSessionsInternal", ctx, userID)}
+}
+
+func (_c *MockSessionServiceInternal_RevokeSessionsInternal_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockSessionServiceInternal_RevokeSessionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSessionServiceInternal_RevokeSessionsInternal_Call) Return(_a0 int, _a1 error) *MockSessionServiceInternal_RevokeSessionsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionServiceInternal_RevokeSessionsInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int, error)) *MockSessionServiceInternal_RevokeSessionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartSessionInternal provides a mock function with given fields: ctx, session
+func (_m *MockSessionServiceInternal) StartSessionInternal(ctx context.Context, session *models.Session) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartSessionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionServiceInternal_StartSessionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartSessionInternal'
+type MockSessionServiceInternal_StartSessionInternal_Call struct {
+	*mock.Call
+}
+
+// StartSessionInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session *models.Session
+func (_e *MockSessionServiceInternal_Expecter) StartSessionInternal(ctx interface{}, session interface{}) *MockSessionServiceInternal_StartSessionInternal_Call {
+	return &MockSessionServiceInternal_StartSessionInternal_Call{Call: _e.mock.On("StartSessionInternal", ctx, session)}
+}
+
+func (_c *MockSessionServiceInternal_StartSessionInternal_Call) Run(run func(ctx context.Context, session *models.Session)) *MockSessionServiceInternal_StartSessionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Session))
+	})
+	return _c
+}
+
+func (_c *MockSessionServiceInternal_StartSessionInternal_Call) Return(_a0 error) *MockSessionServiceInternal_StartSessionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionServiceInternal_StartSessionInternal_Call) RunAndReturn(run func(context.Context, *models.Session) error) *MockSessionServiceInternal_StartSessionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessionServiceInternal creates a new instance of MockSessionServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessionServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessionServiceInternal {
+	mock := &MockSessionServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,2621 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+
+	time "time"
+)
+
+// MockSubscriptionService is an autogenerated mock type for the SubscriptionService type
+type MockSubscriptionService struct {
+	mock.Mock
+}
+
+type MockSubscriptionService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSubscriptionService) EXPECT() *MockSubscriptionService_Expecter {
+	return &MockSubscriptionService_Expecter{mock: &_m.Mock}
+}
+
+// AnonymizeSubscriptionsInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) AnonymizeSubscriptionsInternal(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnonymizeSubscriptionsInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_AnonymizeSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnonymizeSubscriptionsInternal'
+type MockSubscriptionService_AnonymizeSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// AnonymizeSubscriptionsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionService_Expecter) AnonymizeSubscriptionsInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_AnonymizeSubscriptionsInternal_Call {
+	return &MockSubscriptionService_AnonymizeSubscriptionsInternal_Call{Call: _e.mock.On("AnonymizeSubscriptionsInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_AnonymizeSubscriptionsInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionService_AnonymizeSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_AnonymizeSubscriptionsInternal_Call) Return(_a0 error) *MockSubscriptionService_AnonymizeSubscriptionsInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_AnonymizeSubscriptionsInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockSubscriptionService_AnonymizeSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArchiveSubscriptionInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) ArchiveSubscriptionInternal(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveSubscriptionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_ArchiveSubscriptionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveSubscriptionInternal'
+type MockSubscriptionService_ArchiveSubscriptionInternal_Call struct {
+	*mock.Call
+}
+
+// ArchiveSubscriptionInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionService_Expecter) ArchiveSubscriptionInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_ArchiveSubscriptionInternal_Call {
+	return &MockSubscriptionService_ArchiveSubscriptionInternal_Call{Call: _e.mock.On("ArchiveSubscriptionInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_ArchiveSubscriptionInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionService_ArchiveSubscriptionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_ArchiveSubscriptionInternal_Call) Return(_a0 error) *MockSubscriptionService_ArchiveSubscriptionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_ArchiveSubscriptionInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockSubscriptionService_ArchiveSubscriptionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelSubscription provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockSubscriptionService) CancelSubscription(_a0 context.Context, _a1 string, _a2 string) (*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelSubscription")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Subscription, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Subscription); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_CancelSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelSubscription'
+type MockSubscriptionService_CancelSubscription_Call struct {
+	*mock.Call
+}
+
+// CancelSubscription is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+func (_e *MockSubscriptionService_Expecter) CancelSubscription(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockSubscriptionService_CancelSubscription_Call {
+	return &MockSubscriptionService_CancelSubscription_Call{Call: _e.mock.On("CancelSubscription", _a0, _a1, _a2)}
+}
+
+func (_c *MockSubscriptionService_CancelSubscription_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockSubscriptionService_CancelSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_CancelSubscription_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionService_CancelSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_CancelSubscription_Call) RunAndReturn(run func(context.Context, string, string) (*models.Subscription, error)) *MockSubscriptionService_CancelSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelSubscriptionsInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) CancelSubscriptionsInternal(_a0 context.Context, _a1 bson.ObjectID) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelSubscriptionsInternal")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_CancelSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelSubscriptionsInternal'
+type MockSubscriptionService_CancelSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// CancelSubscriptionsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionService_Expecter) CancelSubscriptionsInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_CancelSubscriptionsInternal_Call {
+	return &MockSubscriptionService_CancelSubscriptionsInternal_Call{Call: _e.mock.On("CancelSubscriptionsInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_CancelSubscriptionsInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionService_CancelSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_CancelSubscriptionsInternal_Call) Return(_a0 int, _a1 error) *MockSubscriptionService_CancelSubscriptionsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_CancelSubscriptionsInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int, error)) *MockSubscriptionService_CancelSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSubscription provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockSubscriptionService) CreateSubscription(_a0 context.Context, _a1 *models.Subscription, _a2 string) (*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSubscription")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Subscription, string) (*models.Subscription, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Subscription, string) *models.Subscription); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Subscription, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_CreateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSubscription'
+type MockSubscriptionService_CreateSubscription_Call struct {
+	*mock.Call
+}
+
+// CreateSubscription is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Subscription
+//   - _a2 string
+func (_e *MockSubscriptionService_Expecter) CreateSubscription(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockSubscriptionService_CreateSubscription_Call {
+	return &MockSubscriptionService_CreateSubscription_Call{Call: _e.mock.On("CreateSubscription", _a0, _a1, _a2)}
+}
+
+func (_c *MockSubscriptionService_CreateSubscription_Call) Run(run func(_a0 context.Context, _a1 *models.Subscription, _a2 string)) *MockSubscriptionService_CreateSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Subscription), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_CreateSubscription_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionService_CreateSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_CreateSubscription_Call) RunAndReturn(run func(context.Context, *models.Subscription, string) (*models.Subscription, error)) *MockSubscriptionService_CreateSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteReminderHistoryInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) DeleteReminderHistoryInternal(_a0 context.Context, _a1 bson.ObjectID) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReminderHistoryInternal")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_DeleteReminderHistoryInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReminderHistoryInternal'
+type MockSubscriptionService_DeleteReminderHistoryInternal_Call struct {
+	*mock.Call
+}
+
+// DeleteReminderHistoryInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionService_Expecter) DeleteReminderHistoryInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_DeleteReminderHistoryInternal_Call {
+	return &MockSubscriptionService_DeleteReminderHistoryInternal_Call{Call: _e.mock.On("DeleteReminderHistoryInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_DeleteReminderHistoryInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionService_DeleteReminderHistoryInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_DeleteReminderHistoryInternal_Call) Return(_a0 int64, _a1 error) *MockSubscriptionService_DeleteReminderHistoryInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_DeleteReminderHistoryInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockSubscriptionService_DeleteReminderHistoryInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSubscription provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockSubscriptionService) DeleteSubscription(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_DeleteSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubscription'
+type MockSubscriptionService_DeleteSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteSubscription is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+func (_e *MockSubscriptionService_Expecter) DeleteSubscription(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockSubscriptionService_DeleteSubscription_Call {
+	return &MockSubscriptionService_DeleteSubscription_Call{Call: _e.mock.On("DeleteSubscription", _a0, _a1, _a2)}
+}
+
+func (_c *MockSubscriptionService_DeleteSubscription_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockSubscriptionService_DeleteSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_DeleteSubscription_Call) Return(_a0 error) *MockSubscriptionService_DeleteSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_DeleteSubscription_Call) RunAndReturn(run func(context.Context, string, string) error) *MockSubscriptionService_DeleteSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachArchivableSubscriptionInternal provides a mock function with given fields: ctx, retentionMonths, fn
+func (_m *MockSubscriptionService) EachArchivableSubscriptionInternal(ctx context.Context, retentionMonths int, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, retentionMonths, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachArchivableSubscriptionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, retentionMonths, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_EachArchivableSubscriptionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachArchivableSubscriptionInternal'
+type MockSubscriptionService_EachArchivableSubscriptionInternal_Call struct {
+	*mock.Call
+}
+
+// EachArchivableSubscriptionInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - retentionMonths int
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionService_Expecter) EachArchivableSubscriptionInternal(ctx interface{}, retentionMonths interface{}, fn interface{}) *MockSubscriptionService_EachArchivableSubscriptionInternal_Call {
+	return &MockSubscriptionService_EachArchivableSubscriptionInternal_Call{Call: _e.mock.On("EachArchivableSubscriptionInternal", ctx, retentionMonths, fn)}
+}
+
+func (_c *MockSubscriptionService_EachArchivableSubscriptionInternal_Call) Run(run func(ctx context.Context, retentionMonths int, fn func(*models.Subscription) error)) *MockSubscriptionService_EachArchivableSubscriptionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachArchivableSubscriptionInternal_Call) Return(_a0 error) *MockSubscriptionService_EachArchivableSubscriptionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachArchivableSubscriptionInternal_Call) RunAndReturn(run func(context.Context, int, func(*models.Subscription) error) error) *MockSubscriptionService_EachArchivableSubscriptionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachCanceledExpiredSubscriptionInternal provides a mock function with given fields: ctx, fn
+func (_m *MockSubscriptionService) EachCanceledExpiredSubscriptionInternal(ctx context.Context, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachCanceledExpiredSubscriptionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_EachCanceledExpiredSubscriptionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachCanceledExpiredSubscriptionInternal'
+type MockSubscriptionService_EachCanceledExpiredSubscriptionInternal_Call struct {
+	*mock.Call
+}
+
+// EachCanceledExpiredSubscriptionInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionService_Expecter) EachCanceledExpiredSubscriptionInternal(ctx interface{}, fn interface{}) *MockSubscriptionService_EachCanceledExpiredSubscriptionInternal_Call {
+	return &MockSubscriptionService_EachCanceledExpiredSubscriptionInternal_Call{Call: _e.mock.On("EachCanceledExpiredSubscriptionInternal", ctx, fn)}
+}
+
+func (_c *MockSubscriptionService_EachCanceledExpiredSubscriptionInternal_Call) Run(run func(ctx context.Context, fn func(*models.Subscription) error)) *MockSubscriptionService_EachCanceledExpiredSubscriptionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachCanceledExpiredSubscriptionInternal_Call) Return(_a0 error) *MockSubscriptionService_EachCanceledExpiredSubscriptionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachCanceledExpiredSubscriptionInternal_Call) RunAndReturn(run func(context.Context, func(*models.Subscription) error) error) *MockSubscriptionService_EachCanceledExpiredSubscriptionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachPastDueSubscriptionInternal provides a mock function with given fields: ctx, fn
+func (_m *MockSubscriptionService) EachPastDueSubscriptionInternal(ctx context.Context, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachPastDueSubscriptionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_EachPastDueSubscriptionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachPastDueSubscriptionInternal'
+type MockSubscriptionService_EachPastDueSubscriptionInternal_Call struct {
+	*mock.Call
+}
+
+// EachPastDueSubscriptionInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionService_Expecter) EachPastDueSubscriptionInternal(ctx interface{}, fn interface{}) *MockSubscriptionService_EachPastDueSubscriptionInternal_Call {
+	return &MockSubscriptionService_EachPastDueSubscriptionInternal_Call{Call: _e.mock.On("EachPastDueSubscriptionInternal", ctx, fn)}
+}
+
+func (_c *MockSubscriptionService_EachPastDueSubscriptionInternal_Call) Run(run func(ctx context.Context, fn func(*models.Subscription) error)) *MockSubscriptionService_EachPastDueSubscriptionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachPastDueSubscriptionInternal_Call) Return(_a0 error) *MockSubscriptionService_EachPastDueSubscriptionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachPastDueSubscriptionInternal_Call) RunAndReturn(run func(context.Context, func(*models.Subscription) error) error) *MockSubscriptionService_EachPastDueSubscriptionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachSubscriptionDueForRenewalInternal provides a mock function with given fields: ctx, startTime, endTime, fn
+func (_m *MockSubscriptionService) EachSubscriptionDueForRenewalInternal(ctx context.Context, startTime time.Time, endTime time.Time, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, startTime, endTime, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachSubscriptionDueForRenewalInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, startTime, endTime, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_EachSubscriptionDueForRenewalInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachSubscriptionDueForRenewalInternal'
+type MockSubscriptionService_EachSubscriptionDueForRenewalInternal_Call struct {
+	*mock.Call
+}
+
+// EachSubscriptionDueForRenewalInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - startTime time.Time
+//   - endTime time.Time
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionService_Expecter) EachSubscriptionDueForRenewalInternal(ctx interface{}, startTime interface{}, endTime interface{}, fn interface{}) *MockSubscriptionService_EachSubscriptionDueForRenewalInternal_Call {
+	return &MockSubscriptionService_EachSubscriptionDueForRenewalInternal_Call{Call: _e.mock.On("EachSubscriptionDueForRenewalInternal", ctx, startTime, endTime, fn)}
+}
+
+func (_c *MockSubscriptionService_EachSubscriptionDueForRenewalInternal_Call) Run(run func(ctx context.Context, startTime time.Time, endTime time.Time, fn func(*models.Subscription) error)) *MockSubscriptionService_EachSubscriptionDueForRenewalInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachSubscriptionDueForRenewalInternal_Call) Return(_a0 error) *MockSubscriptionService_EachSubscriptionDueForRenewalInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachSubscriptionDueForRenewalInternal_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, func(*models.Subscription) error) error) *MockSubscriptionService_EachSubscriptionDueForRenewalInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachUnusedSubscriptionInternal provides a mock function with given fields: ctx, unusedAfterDays, noticeDays, fn
+func (_m *MockSubscriptionService) EachUnusedSubscriptionInternal(ctx context.Context, unusedAfterDays int, noticeDays int, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, unusedAfterDays, noticeDays, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachUnusedSubscriptionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, unusedAfterDays, noticeDays, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_EachUnusedSubscriptionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachUnusedSubscriptionInternal'
+type MockSubscriptionService_EachUnusedSubscriptionInternal_Call struct {
+	*mock.Call
+}
+
+// EachUnusedSubscriptionInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - unusedAfterDays int
+//   - noticeDays int
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionService_Expecter) EachUnusedSubscriptionInternal(ctx interface{}, unusedAfterDays interface{}, noticeDays interface{}, fn interface{}) *MockSubscriptionService_EachUnusedSubscriptionInternal_Call {
+	return &MockSubscriptionService_EachUnusedSubscriptionInternal_Call{Call: _e.mock.On("EachUnusedSubscriptionInternal", ctx, unusedAfterDays, noticeDays, fn)}
+}
+
+func (_c *MockSubscriptionService_EachUnusedSubscriptionInternal_Call) Run(run func(ctx context.Context, unusedAfterDays int, noticeDays int, fn func(*models.Subscription) error)) *MockSubscriptionService_EachUnusedSubscriptionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int), args[3].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachUnusedSubscriptionInternal_Call) Return(_a0 error) *MockSubscriptionService_EachUnusedSubscriptionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachUnusedSubscriptionInternal_Call) RunAndReturn(run func(context.Context, int, int, func(*models.Subscription) error) error) *MockSubscriptionService_EachUnusedSubscriptionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachUpcomingRenewalInternal provides a mock function with given fields: ctx, daysAhead, fn
+func (_m *MockSubscriptionService) EachUpcomingRenewalInternal(ctx context.Context, daysAhead []int, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, daysAhead, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachUpcomingRenewalInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, daysAhead, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_EachUpcomingRenewalInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachUpcomingRenewalInternal'
+type MockSubscriptionService_EachUpcomingRenewalInternal_Call struct {
+	*mock.Call
+}
+
+// EachUpcomingRenewalInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - daysAhead []int
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionService_Expecter) EachUpcomingRenewalInternal(ctx interface{}, daysAhead interface{}, fn interface{}) *MockSubscriptionService_EachUpcomingRenewalInternal_Call {
+	return &MockSubscriptionService_EachUpcomingRenewalInternal_Call{Call: _e.mock.On("EachUpcomingRenewalInternal", ctx, daysAhead, fn)}
+}
+
+func (_c *MockSubscriptionService_EachUpcomingRenewalInternal_Call) Run(run func(ctx context.Context, daysAhead []int, fn func(*models.Subscription) error)) *MockSubscriptionService_EachUpcomingRenewalInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int), args[2].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachUpcomingRenewalInternal_Call) Return(_a0 error) *MockSubscriptionService_EachUpcomingRenewalInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_EachUpcomingRenewalInternal_Call) RunAndReturn(run func(context.Context, []int, func(*models.Subscription) error) error) *MockSubscriptionService_EachUpcomingRenewalInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EndSettledDunningInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) EndSettledDunningInternal(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EndSettledDunningInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_EndSettledDunningInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EndSettledDunningInternal'
+type MockSubscriptionService_EndSettledDunningInternal_Call struct {
+	*mock.Call
+}
+
+// EndSettledDunningInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionService_Expecter) EndSettledDunningInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_EndSettledDunningInternal_Call {
+	return &MockSubscriptionService_EndSettledDunningInternal_Call{Call: _e.mock.On("EndSettledDunningInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_EndSettledDunningInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionService_EndSettledDunningInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_EndSettledDunningInternal_Call) Return(_a0 error) *MockSubscriptionService_EndSettledDunningInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_EndSettledDunningInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockSubscriptionService_EndSettledDunningInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchArchivableSubscriptionsInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) FetchArchivableSubscriptionsInternal(_a0 context.Context, _a1 int) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchArchivableSubscriptionsInternal")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.Subscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.Subscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_FetchArchivableSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchArchivableSubscriptionsInternal'
+type MockSubscriptionService_FetchArchivableSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// FetchArchivableSubscriptionsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 int
+func (_e *MockSubscriptionService_Expecter) FetchArchivableSubscriptionsInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_FetchArchivableSubscriptionsInternal_Call {
+	return &MockSubscriptionService_FetchArchivableSubscriptionsInternal_Call{Call: _e.mock.On("FetchArchivableSubscriptionsInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_FetchArchivableSubscriptionsInternal_Call) Run(run func(_a0 context.Context, _a1 int)) *MockSubscriptionService_FetchArchivableSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchArchivableSubscriptionsInternal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionService_FetchArchivableSubscriptionsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchArchivableSubscriptionsInternal_Call) RunAndReturn(run func(context.Context, int) ([]*models.Subscription, error)) *MockSubscriptionService_FetchArchivableSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchCanceledExpiredSubscriptionsInternal provides a mock function with given fields: _a0
+func (_m *MockSubscriptionService) FetchCanceledExpiredSubscriptionsInternal(_a0 context.Context) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchCanceledExpiredSubscriptionsInternal")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Subscription, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Subscription); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_FetchCanceledExpiredSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchCanceledExpiredSubscriptionsInternal'
+type MockSubscriptionService_FetchCanceledExpiredSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// FetchCanceledExpiredSubscriptionsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockSubscriptionService_Expecter) FetchCanceledExpiredSubscriptionsInternal(_a0 interface{}) *MockSubscriptionService_FetchCanceledExpiredSubscriptionsInternal_Call {
+	return &MockSubscriptionService_FetchCanceledExpiredSubscriptionsInternal_Call{Call: _e.mock.On("FetchCanceledExpiredSubscriptionsInternal", _a0)}
+}
+
+func (_c *MockSubscriptionService_FetchCanceledExpiredSubscriptionsInternal_Call) Run(run func(_a0 context.Context)) *MockSubscriptionService_FetchCanceledExpiredSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchCanceledExpiredSubscriptionsInternal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionService_FetchCanceledExpiredSubscriptionsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchCanceledExpiredSubscriptionsInternal_Call) RunAndReturn(run func(context.Context) ([]*models.Subscription, error)) *MockSubscriptionService_FetchCanceledExpiredSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchPastDueSubscriptionsInternal provides a mock function with given fields: _a0
+func (_m *MockSubscriptionService) FetchPastDueSubscriptionsInternal(_a0 context.Context) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchPastDueSubscriptionsInternal")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Subscription, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Subscription); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_FetchPastDueSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchPastDueSubscriptionsInternal'
+type MockSubscriptionService_FetchPastDueSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// FetchPastDueSubscriptionsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockSubscriptionService_Expecter) FetchPastDueSubscriptionsInternal(_a0 interface{}) *MockSubscriptionService_FetchPastDueSubscriptionsInternal_Call {
+	return &MockSubscriptionService_FetchPastDueSubscriptionsInternal_Call{Call: _e.mock.On("FetchPastDueSubscriptionsInternal", _a0)}
+}
+
+func (_c *MockSubscriptionService_FetchPastDueSubscriptionsInternal_Call) Run(run func(_a0 context.Context)) *MockSubscriptionService_FetchPastDueSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchPastDueSubscriptionsInternal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionService_FetchPastDueSubscriptionsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchPastDueSubscriptionsInternal_Call) RunAndReturn(run func(context.Context) ([]*models.Subscription, error)) *MockSubscriptionService_FetchPastDueSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchSubscriptionByIDInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) FetchSubscriptionByIDInternal(_a0 context.Context, _a1 bson.ObjectID) (*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchSubscriptionByIDInternal")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.Subscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.Subscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_FetchSubscriptionByIDInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSubscriptionByIDInternal'
+type MockSubscriptionService_FetchSubscriptionByIDInternal_Call struct {
+	*mock.Call
+}
+
+// FetchSubscriptionByIDInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionService_Expecter) FetchSubscriptionByIDInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_FetchSubscriptionByIDInternal_Call {
+	return &MockSubscriptionService_FetchSubscriptionByIDInternal_Call{Call: _e.mock.On("FetchSubscriptionByIDInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_FetchSubscriptionByIDInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionService_FetchSubscriptionByIDInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchSubscriptionByIDInternal_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionService_FetchSubscriptionByIDInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchSubscriptionByIDInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.Subscription, error)) *MockSubscriptionService_FetchSubscriptionByIDInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchSubscriptionsDueForRenewalInternal provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockSubscriptionService) FetchSubscriptionsDueForRenewalInternal(_a0 context.Context, _a1 time.Time, _a2 time.Time) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchSubscriptionsDueForRenewalInternal")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]*models.Subscription, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []*models.Subscription); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_FetchSubscriptionsDueForRenewalInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSubscriptionsDueForRenewalInternal'
+type MockSubscriptionService_FetchSubscriptionsDueForRenewalInternal_Call struct {
+	*mock.Call
+}
+
+// FetchSubscriptionsDueForRenewalInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 time.Time
+//   - _a2 time.Time
+func (_e *MockSubscriptionService_Expecter) FetchSubscriptionsDueForRenewalInternal(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockSubscriptionService_FetchSubscriptionsDueForRenewalInternal_Call {
+	return &MockSubscriptionService_FetchSubscriptionsDueForRenewalInternal_Call{Call: _e.mock.On("FetchSubscriptionsDueForRenewalInternal", _a0, _a1, _a2)}
+}
+
+func (_c *MockSubscriptionService_FetchSubscriptionsDueForRenewalInternal_Call) Run(run func(_a0 context.Context, _a1 time.Time, _a2 time.Time)) *MockSubscriptionService_FetchSubscriptionsDueForRenewalInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchSubscriptionsDueForRenewalInternal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionService_FetchSubscriptionsDueForRenewalInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchSubscriptionsDueForRenewalInternal_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) ([]*models.Subscription, error)) *MockSubscriptionService_FetchSubscriptionsDueForRenewalInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchUnusedSubscriptionsInternal provides a mock function with given fields: ctx, unusedAfterDays, noticeDays
+func (_m *MockSubscriptionService) FetchUnusedSubscriptionsInternal(ctx context.Context, unusedAfterDays int, noticeDays int) ([]*models.Subscription, error) {
+	ret := _m.Called(ctx, unusedAfterDays, noticeDays)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchUnusedSubscriptionsInternal")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*models.Subscription, error)); ok {
+		return rf(ctx, unusedAfterDays, noticeDays)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*models.Subscription); ok {
+		r0 = rf(ctx, unusedAfterDays, noticeDays)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, unusedAfterDays, noticeDays)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_FetchUnusedSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchUnusedSubscriptionsInternal'
+type MockSubscriptionService_FetchUnusedSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// FetchUnusedSubscriptionsInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - unusedAfterDays int
+//   - noticeDays int
+func (_e *MockSubscriptionService_Expecter) FetchUnusedSubscriptionsInternal(ctx interface{}, unusedAfterDays interface{}, noticeDays interface{}) *MockSubscriptionService_FetchUnusedSubscriptionsInternal_Call {
+	return &MockSubscriptionService_FetchUnusedSubscriptionsInternal_Call{Call: _e.mock.On("FetchUnusedSubscriptionsInternal", ctx, unusedAfterDays, noticeDays)}
+}
+
+func (_c *MockSubscriptionService_FetchUnusedSubscriptionsInternal_Call) Run(run func(ctx context.Context, unusedAfterDays int, noticeDays int)) *MockSubscriptionService_FetchUnusedSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchUnusedSubscriptionsInternal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionService_FetchUnusedSubscriptionsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchUnusedSubscriptionsInternal_Call) RunAndReturn(run func(context.Context, int, int) ([]*models.Subscription, error)) *MockSubscriptionService_FetchUnusedSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchUpcomingRenewalsInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) FetchUpcomingRenewalsInternal(_a0 context.Context, _a1 []int) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchUpcomingRenewalsInternal")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]*models.Subscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []*models.Subscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_FetchUpcomingRenewalsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchUpcomingRenewalsInternal'
+type MockSubscriptionService_FetchUpcomingRenewalsInternal_Call struct {
+	*mock.Call
+}
+
+// FetchUpcomingRenewalsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []int
+func (_e *MockSubscriptionService_Expecter) FetchUpcomingRenewalsInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_FetchUpcomingRenewalsInternal_Call {
+	return &MockSubscriptionService_FetchUpcomingRenewalsInternal_Call{Call: _e.mock.On("FetchUpcomingRenewalsInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_FetchUpcomingRenewalsInternal_Call) Run(run func(_a0 context.Context, _a1 []int)) *MockSubscriptionService_FetchUpcomingRenewalsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchUpcomingRenewalsInternal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionService_FetchUpcomingRenewalsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_FetchUpcomingRenewalsInternal_Call) RunAndReturn(run func(context.Context, []int) ([]*models.Subscription, error)) *MockSubscriptionService_FetchUpcomingRenewalsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDuplicateSubscription provides a mock function with given fields: ctx, subscription, claimedUserID
+func (_m *MockSubscriptionService) FindDuplicateSubscription(ctx context.Context, subscription *models.Subscription, claimedUserID string) (*models.Subscription, error) {
+	ret := _m.Called(ctx, subscription, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicateSubscription")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Subscription, string) (*models.Subscription, error)); ok {
+		return rf(ctx, subscription, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Subscription, string) *models.Subscription); ok {
+		r0 = rf(ctx, subscription, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Subscription, string) error); ok {
+		r1 = rf(ctx, subscription, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_FindDuplicateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDuplicateSubscription'
+type MockSubscriptionService_FindDuplicateSubscription_Call struct {
+	*mock.Call
+}
+
+// FindDuplicateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription *models.Subscription
+//   - claimedUserID string
+func (_e *MockSubscriptionService_Expecter) FindDuplicateSubscription(ctx interface{}, subscription interface{}, claimedUserID interface{}) *MockSubscriptionService_FindDuplicateSubscription_Call {
+	return &MockSubscriptionService_FindDuplicateSubscription_Call{Call: _e.mock.On("FindDuplicateSubscription", ctx, subscription, claimedUserID)}
+}
+
+func (_c *MockSubscriptionService_FindDuplicateSubscription_Call) Run(run func(ctx context.Context, subscription *models.Subscription, claimedUserID string)) *MockSubscriptionService_FindDuplicateSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Subscription), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_FindDuplicateSubscription_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionService_FindDuplicateSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_FindDuplicateSubscription_Call) RunAndReturn(run func(context.Context, *models.Subscription, string) (*models.Subscription, error)) *MockSubscriptionService_FindDuplicateSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllSubscriptions provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) GetAllSubscriptions(_a0 context.Context, _a1 models.SubscriptionQuery) (*models.SubscriptionPage, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllSubscriptions")
+	}
+
+	var r0 *models.SubscriptionPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SubscriptionQuery) (*models.SubscriptionPage, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.SubscriptionQuery) *models.SubscriptionPage); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SubscriptionPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.SubscriptionQuery) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_GetAllSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllSubscriptions'
+type MockSubscriptionService_GetAllSubscriptions_Call struct {
+	*mock.Call
+}
+
+// GetAllSubscriptions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 models.SubscriptionQuery
+func (_e *MockSubscriptionService_Expecter) GetAllSubscriptions(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_GetAllSubscriptions_Call {
+	return &MockSubscriptionService_GetAllSubscriptions_Call{Call: _e.mock.On("GetAllSubscriptions", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_GetAllSubscriptions_Call) Run(run func(_a0 context.Context, _a1 models.SubscriptionQuery)) *MockSubscriptionService_GetAllSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.SubscriptionQuery))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetAllSubscriptions_Call) Return(_a0 *models.SubscriptionPage, _a1 error) *MockSubscriptionService_GetAllSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetAllSubscriptions_Call) RunAndReturn(run func(context.Context, models.SubscriptionQuery) (*models.SubscriptionPage, error)) *MockSubscriptionService_GetAllSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDuplicateSubscriptions provides a mock function with given fields: ctx, claimedUserID
+func (_m *MockSubscriptionService) GetDuplicateSubscriptions(ctx context.Context, claimedUserID string) ([]*models.DuplicateGroup, error) {
+	ret := _m.Called(ctx, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicateSubscriptions")
+	}
+
+	var r0 []*models.DuplicateGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.DuplicateGroup, error)); ok {
+		return rf(ctx, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.DuplicateGroup); ok {
+		r0 = rf(ctx, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DuplicateGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_GetDuplicateSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDuplicateSubscriptions'
+type MockSubscriptionService_GetDuplicateSubscriptions_Call struct {
+	*mock.Call
+}
+
+// GetDuplicateSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+func (_e *MockSubscriptionService_Expecter) GetDuplicateSubscriptions(ctx interface{}, claimedUserID interface{}) *MockSubscriptionService_GetDuplicateSubscriptions_Call {
+	return &MockSubscriptionService_GetDuplicateSubscriptions_Call{Call: _e.mock.On("GetDuplicateSubscriptions", ctx, claimedUserID)}
+}
+
+func (_c *MockSubscriptionService_GetDuplicateSubscriptions_Call) Run(run func(ctx context.Context, claimedUserID string)) *MockSubscriptionService_GetDuplicateSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetDuplicateSubscriptions_Call) Return(_a0 []*models.DuplicateGroup, _a1 error) *MockSubscriptionService_GetDuplicateSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetDuplicateSubscriptions_Call) RunAndReturn(run func(context.Context, string) ([]*models.DuplicateGroup, error)) *MockSubscriptionService_GetDuplicateSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLifecycleEvents provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockSubscriptionService) GetLifecycleEvents(ctx context.Context, id string, claimedUserID string) ([]*models.LifecycleEvent, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLifecycleEvents")
+	}
+
+	var r0 []*models.LifecycleEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.LifecycleEvent, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.LifecycleEvent); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LifecycleEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_GetLifecycleEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLifecycleEvents'
+type MockSubscriptionService_GetLifecycleEvents_Call struct {
+	*mock.Call
+}
+
+// GetLifecycleEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockSubscriptionService_Expecter) GetLifecycleEvents(ctx interface{}, id interface{}, claimedUserID interface{}) *MockSubscriptionService_GetLifecycleEvents_Call {
+	return &MockSubscriptionService_GetLifecycleEvents_Call{Call: _e.mock.On("GetLifecycleEvents", ctx, id, claimedUserID)}
+}
+
+func (_c *MockSubscriptionService_GetLifecycleEvents_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockSubscriptionService_GetLifecycleEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetLifecycleEvents_Call) Return(_a0 []*models.LifecycleEvent, _a1 error) *MockSubscriptionService_GetLifecycleEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetLifecycleEvents_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.LifecycleEvent, error)) *MockSubscriptionService_GetLifecycleEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPriceChangeSummary provides a mock function with given fields: ctx, claimedUserID, month
+func (_m *MockSubscriptionService) GetPriceChangeSummary(ctx context.Context, claimedUserID string, month string) (*models.PriceChangeSummaryResponse, error) {
+	ret := _m.Called(ctx, claimedUserID, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPriceChangeSummary")
+	}
+
+	var r0 *models.PriceChangeSummaryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.PriceChangeSummaryResponse, error)); ok {
+		return rf(ctx, claimedUserID, month)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PriceChangeSummaryResponse); ok {
+		r0 = rf(ctx, claimedUserID, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PriceChangeSummaryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, claimedUserID, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_GetPriceChangeSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPriceChangeSummary'
+type MockSubscriptionService_GetPriceChangeSummary_Call struct {
+	*mock.Call
+}
+
+// GetPriceChangeSummary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+//   - month string
+func (_e *MockSubscriptionService_Expecter) GetPriceChangeSummary(ctx interface{}, claimedUserID interface{}, month interface{}) *MockSubscriptionService_GetPriceChangeSummary_Call {
+	return &MockSubscriptionService_GetPriceChangeSummary_Call{Call: _e.mock.On("GetPriceChangeSummary", ctx, claimedUserID, month)}
+}
+
+func (_c *MockSubscriptionService_GetPriceChangeSummary_Call) Run(run func(ctx context.Context, claimedUserID string, month string)) *MockSubscriptionService_GetPriceChangeSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetPriceChangeSummary_Call) Return(_a0 *models.PriceChangeSummaryResponse, _a1 error) *MockSubscriptionService_GetPriceChangeSummary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetPriceChangeSummary_Call) RunAndReturn(run func(context.Context, string, string) (*models.PriceChangeSummaryResponse, error)) *MockSubscriptionService_GetPriceChangeSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPriceHistory provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockSubscriptionService) GetPriceHistory(ctx context.Context, id string, claimedUserID string) ([]*models.PriceChange, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPriceHistory")
+	}
+
+	var r0 []*models.PriceChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.PriceChange, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.PriceChange); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PriceChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_GetPriceHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPriceHistory'
+type MockSubscriptionService_GetPriceHistory_Call struct {
+	*mock.Call
+}
+
+// GetPriceHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockSubscriptionService_Expecter) GetPriceHistory(ctx interface{}, id interface{}, claimedUserID interface{}) *MockSubscriptionService_GetPriceHistory_Call {
+	return &MockSubscriptionService_GetPriceHistory_Call{Call: _e.mock.On("GetPriceHistory", ctx, id, claimedUserID)}
+}
+
+func (_c *MockSubscriptionService_GetPriceHistory_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockSubscriptionService_GetPriceHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetPriceHistory_Call) Return(_a0 []*models.PriceChange, _a1 error) *MockSubscriptionService_GetPriceHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetPriceHistory_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.PriceChange, error)) *MockSubscriptionService_GetPriceHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionByID provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *MockSubscriptionService) GetSubscriptionByID(_a0 context.Context, _a1 string, _a2 string, _a3 bool) (*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionByID")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (*models.Subscription, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) *models.Subscription); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_GetSubscriptionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionByID'
+type MockSubscriptionService_GetSubscriptionByID_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptionByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+//   - _a3 bool
+func (_e *MockSubscriptionService_Expecter) GetSubscriptionByID(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *MockSubscriptionService_GetSubscriptionByID_Call {
+	return &MockSubscriptionService_GetSubscriptionByID_Call{Call: _e.mock.On("GetSubscriptionByID", _a0, _a1, _a2, _a3)}
+}
+
+func (_c *MockSubscriptionService_GetSubscriptionByID_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string, _a3 bool)) *MockSubscriptionService_GetSubscriptionByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetSubscriptionByID_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionService_GetSubscriptionByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetSubscriptionByID_Call) RunAndReturn(run func(context.Context, string, string, bool) (*models.Subscription, error)) *MockSubscriptionService_GetSubscriptionByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionsByUserID provides a mock function with given fields: ctx, id, claimedUserID, includeArchived, query
+func (_m *MockSubscriptionService) GetSubscriptionsByUserID(ctx context.Context, id string, claimedUserID string, includeArchived bool, query models.SubscriptionQuery) (*models.SubscriptionPage, error) {
+	ret := _m.Called(ctx, id, claimedUserID, includeArchived, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionsByUserID")
+	}
+
+	var r0 *models.SubscriptionPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, models.SubscriptionQuery) (*models.SubscriptionPage, error)); ok {
+		return rf(ctx, id, claimedUserID, includeArchived, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, models.SubscriptionQuery) *models.SubscriptionPage); ok {
+		r0 = rf(ctx, id, claimedUserID, includeArchived, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SubscriptionPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool, models.SubscriptionQuery) error); ok {
+		r1 = rf(ctx, id, claimedUserID, includeArchived, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_GetSubscriptionsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptionsByUserID'
+type MockSubscriptionService_GetSubscriptionsByUserID_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptionsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - includeArchived bool
+//   - query models.SubscriptionQuery
+func (_e *MockSubscriptionService_Expecter) GetSubscriptionsByUserID(ctx interface{}, id interface{}, claimedUserID interface{}, includeArchived interface{}, query interface{}) *MockSubscriptionService_GetSubscriptionsByUserID_Call {
+	return &MockSubscriptionService_GetSubscriptionsByUserID_Call{Call: _e.mock.On("GetSubscriptionsByUserID", ctx, id, claimedUserID, includeArchived, query)}
+}
+
+func (_c *MockSubscriptionService_GetSubscriptionsByUserID_Call) Run(run func(ctx context.Context, id string, claimedUserID string, includeArchived bool, query models.SubscriptionQuery)) *MockSubscriptionService_GetSubscriptionsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool), args[4].(models.SubscriptionQuery))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetSubscriptionsByUserID_Call) Return(_a0 *models.SubscriptionPage, _a1 error) *MockSubscriptionService_GetSubscriptionsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetSubscriptionsByUserID_Call) RunAndReturn(run func(context.Context, string, string, bool, models.SubscriptionQuery) (*models.SubscriptionPage, error)) *MockSubscriptionService_GetSubscriptionsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSummary provides a mock function with given fields: ctx, claimedUserID, target
+func (_m *MockSubscriptionService) GetSummary(ctx context.Context, claimedUserID string, target models.Currency) (*models.SubscriptionSummaryResponse, error) {
+	ret := _m.Called(ctx, claimedUserID, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSummary")
+	}
+
+	var r0 *models.SubscriptionSummaryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Currency) (*models.SubscriptionSummaryResponse, error)); ok {
+		return rf(ctx, claimedUserID, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Currency) *models.SubscriptionSummaryResponse); ok {
+		r0 = rf(ctx, claimedUserID, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SubscriptionSummaryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.Currency) error); ok {
+		r1 = rf(ctx, claimedUserID, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_GetSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSummary'
+type MockSubscriptionService_GetSummary_Call struct {
+	*mock.Call
+}
+
+// GetSummary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+//   - target models.Currency
+func (_e *MockSubscriptionService_Expecter) GetSummary(ctx interface{}, claimedUserID interface{}, target interface{}) *MockSubscriptionService_GetSummary_Call {
+	return &MockSubscriptionService_GetSummary_Call{Call: _e.mock.On("GetSummary", ctx, claimedUserID, target)}
+}
+
+func (_c *MockSubscriptionService_GetSummary_Call) Run(run func(ctx context.Context, claimedUserID string, target models.Currency)) *MockSubscriptionService_GetSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Currency))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetSummary_Call) Return(_a0 *models.SubscriptionSummaryResponse, _a1 error) *MockSubscriptionService_GetSummary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetSummary_Call) RunAndReturn(run func(context.Context, string, models.Currency) (*models.SubscriptionSummaryResponse, error)) *MockSubscriptionService_GetSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasActiveSubscriptionsInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) HasActiveSubscriptionsInternal(_a0 context.Context, _a1 bson.ObjectID) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasActiveSubscriptionsInternal")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (bool, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_HasActiveSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasActiveSubscriptionsInternal'
+type MockSubscriptionService_HasActiveSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// HasActiveSubscriptionsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionService_Expecter) HasActiveSubscriptionsInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_HasActiveSubscriptionsInternal_Call {
+	return &MockSubscriptionService_HasActiveSubscriptionsInternal_Call{Call: _e.mock.On("HasActiveSubscriptionsInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_HasActiveSubscriptionsInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionService_HasActiveSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_HasActiveSubscriptionsInternal_Call) Return(_a0 bool, _a1 error) *MockSubscriptionService_HasActiveSubscriptionsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_HasActiveSubscriptionsInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (bool, error)) *MockSubscriptionService_HasActiveSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkCanceledSubscriptionAsExpiredInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) MarkCanceledSubscriptionAsExpiredInternal(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkCanceledSubscriptionAsExpiredInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_MarkCanceledSubscriptionAsExpiredInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkCanceledSubscriptionAsExpiredInternal'
+type MockSubscriptionService_MarkCanceledSubscriptionAsExpiredInternal_Call struct {
+	*mock.Call
+}
+
+// MarkCanceledSubscriptionAsExpiredInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionService_Expecter) MarkCanceledSubscriptionAsExpiredInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_MarkCanceledSubscriptionAsExpiredInternal_Call {
+	return &MockSubscriptionService_MarkCanceledSubscriptionAsExpiredInternal_Call{Call: _e.mock.On("MarkCanceledSubscriptionAsExpiredInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_MarkCanceledSubscriptionAsExpiredInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionService_MarkCanceledSubscriptionAsExpiredInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_MarkCanceledSubscriptionAsExpiredInternal_Call) Return(_a0 error) *MockSubscriptionService_MarkCanceledSubscriptionAsExpiredInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_MarkCanceledSubscriptionAsExpiredInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockSubscriptionService_MarkCanceledSubscriptionAsExpiredInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseSubscription provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockSubscriptionService) PauseSubscription(ctx context.Context, id string, claimedUserID string) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseSubscription")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Subscription, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Subscription); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_PauseSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseSubscription'
+type MockSubscriptionService_PauseSubscription_Call struct {
+	*mock.Call
+}
+
+// PauseSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockSubscriptionService_Expecter) PauseSubscription(ctx interface{}, id interface{}, claimedUserID interface{}) *MockSubscriptionService_PauseSubscription_Call {
+	return &MockSubscriptionService_PauseSubscription_Call{Call: _e.mock.On("PauseSubscription", ctx, id, claimedUserID)}
+}
+
+func (_c *MockSubscriptionService_PauseSubscription_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockSubscriptionService_PauseSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_PauseSubscription_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionService_PauseSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_PauseSubscription_Call) RunAndReturn(run func(context.Context, string, string) (*models.Subscription, error)) *MockSubscriptionService_PauseSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordPaymentRetryInternal provides a mock function with given fields: ctx, id, attempt, final, action
+func (_m *MockSubscriptionService) RecordPaymentRetryInternal(ctx context.Context, id bson.ObjectID, attempt int, final bool, action models.DunningFinalAction) error {
+	ret := _m.Called(ctx, id, attempt, final, action)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPaymentRetryInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, int, bool, models.DunningFinalAction) error); ok {
+		r0 = rf(ctx, id, attempt, final, action)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_RecordPaymentRetryInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPaymentRetryInternal'
+type MockSubscriptionService_RecordPaymentRetryInternal_Call struct {
+	*mock.Call
+}
+
+// RecordPaymentRetryInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - attempt int
+//   - final bool
+//   - action models.DunningFinalAction
+func (_e *MockSubscriptionService_Expecter) RecordPaymentRetryInternal(ctx interface{}, id interface{}, attempt interface{}, final interface{}, action interface{}) *MockSubscriptionService_RecordPaymentRetryInternal_Call {
+	return &MockSubscriptionService_RecordPaymentRetryInternal_Call{Call: _e.mock.On("RecordPaymentRetryInternal", ctx, id, attempt, final, action)}
+}
+
+func (_c *MockSubscriptionService_RecordPaymentRetryInternal_Call) Run(run func(ctx context.Context, id bson.ObjectID, attempt int, final bool, action models.DunningFinalAction)) *MockSubscriptionService_RecordPaymentRetryInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(int), args[3].(bool), args[4].(models.DunningFinalAction))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_RecordPaymentRetryInternal_Call) Return(_a0 error) *MockSubscriptionService_RecordPaymentRetryInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_RecordPaymentRetryInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID, int, bool, models.DunningFinalAction) error) *MockSubscriptionService_RecordPaymentRetryInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordReminderSentInternal provides a mock function with given fields: ctx, subscription, daysBefore
+func (_m *MockSubscriptionService) RecordReminderSentInternal(ctx context.Context, subscription *models.Subscription, daysBefore int) error {
+	ret := _m.Called(ctx, subscription, daysBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordReminderSentInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Subscription, int) error); ok {
+		r0 = rf(ctx, subscription, daysBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_RecordReminderSentInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordReminderSentInternal'
+type MockSubscriptionService_RecordReminderSentInternal_Call struct {
+	*mock.Call
+}
+
+// RecordReminderSentInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription *models.Subscription
+//   - daysBefore int
+func (_e *MockSubscriptionService_Expecter) RecordReminderSentInternal(ctx interface{}, subscription interface{}, daysBefore interface{}) *MockSubscriptionService_RecordReminderSentInternal_Call {
+	return &MockSubscriptionService_RecordReminderSentInternal_Call{Call: _e.mock.On("RecordReminderSentInternal", ctx, subscription, daysBefore)}
+}
+
+func (_c *MockSubscriptionService_RecordReminderSentInternal_Call) Run(run func(ctx context.Context, subscription *models.Subscription, daysBefore int)) *MockSubscriptionService_RecordReminderSentInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Subscription), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_RecordReminderSentInternal_Call) Return(_a0 error) *MockSubscriptionService_RecordReminderSentInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_RecordReminderSentInternal_Call) RunAndReturn(run func(context.Context, *models.Subscription, int) error) *MockSubscriptionService_RecordReminderSentInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordUsage provides a mock function with given fields: ctx, id, claimedUserID, usedAt
+func (_m *MockSubscriptionService) RecordUsage(ctx context.Context, id string, claimedUserID string, usedAt time.Time) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, claimedUserID, usedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordUsage")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (*models.Subscription, error)); ok {
+		return rf(ctx, id, claimedUserID, usedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *models.Subscription); ok {
+		r0 = rf(ctx, id, claimedUserID, usedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, id, claimedUserID, usedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_RecordUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordUsage'
+type MockSubscriptionService_RecordUsage_Call struct {
+	*mock.Call
+}
+
+// RecordUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - usedAt time.Time
+func (_e *MockSubscriptionService_Expecter) RecordUsage(ctx interface{}, id interface{}, claimedUserID interface{}, usedAt interface{}) *MockSubscriptionService_RecordUsage_Call {
+	return &MockSubscriptionService_RecordUsage_Call{Call: _e.mock.On("RecordUsage", ctx, id, claimedUserID, usedAt)}
+}
+
+func (_c *MockSubscriptionService_RecordUsage_Call) Run(run func(ctx context.Context, id string, claimedUserID string, usedAt time.Time)) *MockSubscriptionService_RecordUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_RecordUsage_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionService_RecordUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_RecordUsage_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (*models.Subscription, error)) *MockSubscriptionService_RecordUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenewSubscriptionInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) RenewSubscriptionInternal(_a0 context.Context, _a1 bson.ObjectID) (*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewSubscriptionInternal")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.Subscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.Subscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_RenewSubscriptionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewSubscriptionInternal'
+type MockSubscriptionService_RenewSubscriptionInternal_Call struct {
+	*mock.Call
+}
+
+// RenewSubscriptionInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionService_Expecter) RenewSubscriptionInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_RenewSubscriptionInternal_Call {
+	return &MockSubscriptionService_RenewSubscriptionInternal_Call{Call: _e.mock.On("RenewSubscriptionInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_RenewSubscriptionInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionService_RenewSubscriptionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_RenewSubscriptionInternal_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionService_RenewSubscriptionInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_RenewSubscriptionInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.Subscription, error)) *MockSubscriptionService_RenewSubscriptionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RepairBillsInternal provides a mock function with given fields: _a0
+func (_m *MockSubscriptionService) RepairBillsInternal(_a0 context.Context) (int64, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepairBillsInternal")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_RepairBillsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RepairBillsInternal'
+type MockSubscriptionService_RepairBillsInternal_Call struct {
+	*mock.Call
+}
+
+// RepairBillsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockSubscriptionService_Expecter) RepairBillsInternal(_a0 interface{}) *MockSubscriptionService_RepairBillsInternal_Call {
+	return &MockSubscriptionService_RepairBillsInternal_Call{Call: _e.mock.On("RepairBillsInternal", _a0)}
+}
+
+func (_c *MockSubscriptionService_RepairBillsInternal_Call) Run(run func(_a0 context.Context)) *MockSubscriptionService_RepairBillsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_RepairBillsInternal_Call) Return(_a0 int64, _a1 error) *MockSubscriptionService_RepairBillsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_RepairBillsInternal_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockSubscriptionService_RepairBillsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeSubscription provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockSubscriptionService) ResumeSubscription(ctx context.Context, id string, claimedUserID string) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeSubscription")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Subscription, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Subscription); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_ResumeSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeSubscription'
+type MockSubscriptionService_ResumeSubscription_Call struct {
+	*mock.Call
+}
+
+// ResumeSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockSubscriptionService_Expecter) ResumeSubscription(ctx interface{}, id interface{}, claimedUserID interface{}) *MockSubscriptionService_ResumeSubscription_Call {
+	return &MockSubscriptionService_ResumeSubscription_Call{Call: _e.mock.On("ResumeSubscription", ctx, id, claimedUserID)}
+}
+
+func (_c *MockSubscriptionService_ResumeSubscription_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockSubscriptionService_ResumeSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_ResumeSubscription_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionService_ResumeSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_ResumeSubscription_Call) RunAndReturn(run func(context.Context, string, string) (*models.Subscription, error)) *MockSubscriptionService_ResumeSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryPaymentInternal provides a mock function with given fields: ctx, id, attempt
+func (_m *MockSubscriptionService) RetryPaymentInternal(ctx context.Context, id bson.ObjectID, attempt int) (bool, error) {
+	ret := _m.Called(ctx, id, attempt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryPaymentInternal")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, int) (bool, error)); ok {
+		return rf(ctx, id, attempt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, int) bool); ok {
+		r0 = rf(ctx, id, attempt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, int) error); ok {
+		r1 = rf(ctx, id, attempt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_RetryPaymentInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryPaymentInternal'
+type MockSubscriptionService_RetryPaymentInternal_Call struct {
+	*mock.Call
+}
+
+// RetryPaymentInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - attempt int
+func (_e *MockSubscriptionService_Expecter) RetryPaymentInternal(ctx interface{}, id interface{}, attempt interface{}) *MockSubscriptionService_RetryPaymentInternal_Call {
+	return &MockSubscriptionService_RetryPaymentInternal_Call{Call: _e.mock.On("RetryPaymentInternal", ctx, id, attempt)}
+}
+
+func (_c *MockSubscriptionService_RetryPaymentInternal_Call) Run(run func(ctx context.Context, id bson.ObjectID, attempt int)) *MockSubscriptionService_RetryPaymentInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_RetryPaymentInternal_Call) Return(_a0 bool, _a1 error) *MockSubscriptionService_RetryPaymentInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_RetryPaymentInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID, int) (bool, error)) *MockSubscriptionService_RetryPaymentInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchAllSubscriptions provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionService) SearchAllSubscriptions(_a0 context.Context, _a1 models.SubscriptionSearch) (*models.SubscriptionSearchPage, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchAllSubscriptions")
+	}
+
+	var r0 *models.SubscriptionSearchPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SubscriptionSearch) (*models.SubscriptionSearchPage, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.SubscriptionSearch) *models.SubscriptionSearchPage); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SubscriptionSearchPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.SubscriptionSearch) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_SearchAllSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchAllSubscriptions'
+type MockSubscriptionService_SearchAllSubscriptions_Call struct {
+	*mock.Call
+}
+
+// SearchAllSubscriptions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 models.SubscriptionSearch
+func (_e *MockSubscriptionService_Expecter) SearchAllSubscriptions(_a0 interface{}, _a1 interface{}) *MockSubscriptionService_SearchAllSubscriptions_Call {
+	return &MockSubscriptionService_SearchAllSubscriptions_Call{Call: _e.mock.On("SearchAllSubscriptions", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionService_SearchAllSubscriptions_Call) Run(run func(_a0 context.Context, _a1 models.SubscriptionSearch)) *MockSubscriptionService_SearchAllSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.SubscriptionSearch))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_SearchAllSubscriptions_Call) Return(_a0 *models.SubscriptionSearchPage, _a1 error) *MockSubscriptionService_SearchAllSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_SearchAllSubscriptions_Call) RunAndReturn(run func(context.Context, models.SubscriptionSearch) (*models.SubscriptionSearchPage, error)) *MockSubscriptionService_SearchAllSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchSubscriptions provides a mock function with given fields: ctx, claimedUserID, search
+func (_m *MockSubscriptionService) SearchSubscriptions(ctx context.Context, claimedUserID string, search models.SubscriptionSearch) (*models.SubscriptionSearchPage, error) {
+	ret := _m.Called(ctx, claimedUserID, search)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSubscriptions")
+	}
+
+	var r0 *models.SubscriptionSearchPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.SubscriptionSearch) (*models.SubscriptionSearchPage, error)); ok {
+		return rf(ctx, claimedUserID, search)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.SubscriptionSearch) *models.SubscriptionSearchPage); ok {
+		r0 = rf(ctx, claimedUserID, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SubscriptionSearchPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.SubscriptionSearch) error); ok {
+		r1 = rf(ctx, claimedUserID, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_SearchSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSubscriptions'
+type MockSubscriptionService_SearchSubscriptions_Call struct {
+	*mock.Call
+}
+
+// SearchSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+//   - search models.SubscriptionSearch
+func (_e *MockSubscriptionService_Expecter) SearchSubscriptions(ctx interface{}, claimedUserID interface{}, search interface{}) *MockSubscriptionService_SearchSubscriptions_Call {
+	return &MockSubscriptionService_SearchSubscriptions_Call{Call: _e.mock.On("SearchSubscriptions", ctx, claimedUserID, search)}
+}
+
+func (_c *MockSubscriptionService_SearchSubscriptions_Call) Run(run func(ctx context.Context, claimedUserID string, search models.SubscriptionSearch)) *MockSubscriptionService_SearchSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.SubscriptionSearch))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_SearchSubscriptions_Call) Return(_a0 *models.SubscriptionSearchPage, _a1 error) *MockSubscriptionService_SearchSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_SearchSubscriptions_Call) RunAndReturn(run func(context.Context, string, models.SubscriptionSearch) (*models.SubscriptionSearchPage, error)) *MockSubscriptionService_SearchSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SettlePendingChargesInternal provides a mock function with given fields: _a0
+func (_m *MockSubscriptionService) SettlePendingChargesInternal(_a0 context.Context) (int, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettlePendingChargesInternal")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_SettlePendingChargesInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SettlePendingChargesInternal'
+type MockSubscriptionService_SettlePendingChargesInternal_Call struct {
+	*mock.Call
+}
+
+// SettlePendingChargesInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockSubscriptionService_Expecter) SettlePendingChargesInternal(_a0 interface{}) *MockSubscriptionService_SettlePendingChargesInternal_Call {
+	return &MockSubscriptionService_SettlePendingChargesInternal_Call{Call: _e.mock.On("SettlePendingChargesInternal", _a0)}
+}
+
+func (_c *MockSubscriptionService_SettlePendingChargesInternal_Call) Run(run func(_a0 context.Context)) *MockSubscriptionService_SettlePendingChargesInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_SettlePendingChargesInternal_Call) Return(_a0 int, _a1 error) *MockSubscriptionService_SettlePendingChargesInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_SettlePendingChargesInternal_Call) RunAndReturn(run func(context.Context) (int, error)) *MockSubscriptionService_SettlePendingChargesInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBillStatus provides a mock function with given fields: ctx, id, status
+func (_m *MockSubscriptionService) UpdateBillStatus(ctx context.Context, id string, status models.PaymentStatus) (*models.Bill, error) {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBillStatus")
+	}
+
+	var r0 *models.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PaymentStatus) (*models.Bill, error)); ok {
+		return rf(ctx, id, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PaymentStatus) *models.Bill); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.PaymentStatus) error); ok {
+		r1 = rf(ctx, id, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_UpdateBillStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBillStatus'
+type MockSubscriptionService_UpdateBillStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateBillStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status models.PaymentStatus
+func (_e *MockSubscriptionService_Expecter) UpdateBillStatus(ctx interface{}, id interface{}, status interface{}) *MockSubscriptionService_UpdateBillStatus_Call {
+	return &MockSubscriptionService_UpdateBillStatus_Call{Call: _e.mock.On("UpdateBillStatus", ctx, id, status)}
+}
+
+func (_c *MockSubscriptionService_UpdateBillStatus_Call) Run(run func(ctx context.Context, id string, status models.PaymentStatus)) *MockSubscriptionService_UpdateBillStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PaymentStatus))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_UpdateBillStatus_Call) Return(_a0 *models.Bill, _a1 error) *MockSubscriptionService_UpdateBillStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_UpdateBillStatus_Call) RunAndReturn(run func(context.Context, string, models.PaymentStatus) (*models.Bill, error)) *MockSubscriptionService_UpdateBillStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePrice provides a mock function with given fields: ctx, id, claimedUserID, price, effectiveAt
+func (_m *MockSubscriptionService) UpdatePrice(ctx context.Context, id string, claimedUserID string, price int64, effectiveAt time.Time) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, claimedUserID, price, effectiveAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePrice")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, time.Time) (*models.Subscription, error)); ok {
+		return rf(ctx, id, claimedUserID, price, effectiveAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, time.Time) *models.Subscription); ok {
+		r0 = rf(ctx, id, claimedUserID, price, effectiveAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, time.Time) error); ok {
+		r1 = rf(ctx, id, claimedUserID, price, effectiveAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_UpdatePrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePrice'
+type MockSubscriptionService_UpdatePrice_Call struct {
+	*mock.Call
+}
+
+// UpdatePrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - price int64
+//   - effectiveAt time.Time
+func (_e *MockSubscriptionService_Expecter) UpdatePrice(ctx interface{}, id interface{}, claimedUserID interface{}, price interface{}, effectiveAt interface{}) *MockSubscriptionService_UpdatePrice_Call {
+	return &MockSubscriptionService_UpdatePrice_Call{Call: _e.mock.On("UpdatePrice", ctx, id, claimedUserID, price, effectiveAt)}
+}
+
+func (_c *MockSubscriptionService_UpdatePrice_Call) Run(run func(ctx context.Context, id string, claimedUserID string, price int64, effectiveAt time.Time)) *MockSubscriptionService_UpdatePrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_UpdatePrice_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionService_UpdatePrice_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_UpdatePrice_Call) RunAndReturn(run func(context.Context, string, string, int64, time.Time) (*models.Subscription, error)) *MockSubscriptionService_UpdatePrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSubscription provides a mock function with given fields: ctx, id, claimedUserID, update
+func (_m *MockSubscriptionService) UpdateSubscription(ctx context.Context, id string, claimedUserID string, update *models.SubscriptionUpdateRequest) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, claimedUserID, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSubscription")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.SubscriptionUpdateRequest) (*models.Subscription, error)); ok {
+		return rf(ctx, id, claimedUserID, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.SubscriptionUpdateRequest) *models.Subscription); ok {
+		r0 = rf(ctx, id, claimedUserID, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.SubscriptionUpdateRequest) error); ok {
+		r1 = rf(ctx, id, claimedUserID, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_UpdateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSubscription'
+type MockSubscriptionService_UpdateSubscription_Call struct {
+	*mock.Call
+}
+
+// UpdateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - update *models.SubscriptionUpdateRequest
+func (_e *MockSubscriptionService_Expecter) UpdateSubscription(ctx interface{}, id interface{}, claimedUserID interface{}, update interface{}) *MockSubscriptionService_UpdateSubscription_Call {
+	return &MockSubscriptionService_UpdateSubscription_Call{Call: _e.mock.On("UpdateSubscription", ctx, id, claimedUserID, update)}
+}
+
+func (_c *MockSubscriptionService_UpdateSubscription_Call) Run(run func(ctx context.Context, id string, claimedUserID string, update *models.SubscriptionUpdateRequest)) *MockSubscriptionService_UpdateSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.SubscriptionUpdateRequest))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_UpdateSubscription_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionService_UpdateSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_UpdateSubscription_Call) RunAndReturn(run func(context.Context, string, string, *models.SubscriptionUpdateRequest) (*models.Subscription, error)) *MockSubscriptionService_UpdateSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSubscriptionService creates a new instance of MockSubscriptionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSubscriptionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSubscriptionService {
+	mock := &MockSubscriptionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
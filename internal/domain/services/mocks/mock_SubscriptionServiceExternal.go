@@ -7,6 +7,8 @@ import (
 
 	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockSubscriptionServiceExternal is an autogenerated mock type for the SubscriptionServiceExternal type
@@ -190,29 +192,89 @@ func (_c *MockSubscriptionServiceExternal_DeleteSubscription_Call) RunAndReturn(
 	return _c
 }
 
-// GetAllSubscriptions provides a mock function with given fields: _a0
-func (_m *MockSubscriptionServiceExternal) GetAllSubscriptions(_a0 context.Context) ([]*models.Subscription, error) {
-	ret := _m.Called(_a0)
+// FindDuplicateSubscription provides a mock function with given fields: ctx, subscription, claimedUserID
+func (_m *MockSubscriptionServiceExternal) FindDuplicateSubscription(ctx context.Context, subscription *models.Subscription, claimedUserID string) (*models.Subscription, error) {
+	ret := _m.Called(ctx, subscription, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicateSubscription")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Subscription, string) (*models.Subscription, error)); ok {
+		return rf(ctx, subscription, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Subscription, string) *models.Subscription); ok {
+		r0 = rf(ctx, subscription, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Subscription, string) error); ok {
+		r1 = rf(ctx, subscription, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_FindDuplicateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDuplicateSubscription'
+type MockSubscriptionServiceExternal_FindDuplicateSubscription_Call struct {
+	*mock.Call
+}
+
+// FindDuplicateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription *models.Subscription
+//   - claimedUserID string
+func (_e *MockSubscriptionServiceExternal_Expecter) FindDuplicateSubscription(ctx interface{}, subscription interface{}, claimedUserID interface{}) *MockSubscriptionServiceExternal_FindDuplicateSubscription_Call {
+	return &MockSubscriptionServiceExternal_FindDuplicateSubscription_Call{Call: _e.mock.On("FindDuplicateSubscription", ctx, subscription, claimedUserID)}
+}
+
+func (_c *MockSubscriptionServiceExternal_FindDuplicateSubscription_Call) Run(run func(ctx context.Context, subscription *models.Subscription, claimedUserID string)) *MockSubscriptionServiceExternal_FindDuplicateSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Subscription), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_FindDuplicateSubscription_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionServiceExternal_FindDuplicateSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_FindDuplicateSubscription_Call) RunAndReturn(run func(context.Context, *models.Subscription, string) (*models.Subscription, error)) *MockSubscriptionServiceExternal_FindDuplicateSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllSubscriptions provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionServiceExternal) GetAllSubscriptions(_a0 context.Context, _a1 models.SubscriptionQuery) (*models.SubscriptionPage, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllSubscriptions")
 	}
 
-	var r0 []*models.Subscription
+	var r0 *models.SubscriptionPage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Subscription, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, models.SubscriptionQuery) (*models.SubscriptionPage, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*models.Subscription); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, models.SubscriptionQuery) *models.SubscriptionPage); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Subscription)
+			r0 = ret.Get(0).(*models.SubscriptionPage)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, models.SubscriptionQuery) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -227,30 +289,270 @@ type MockSubscriptionServiceExternal_GetAllSubscriptions_Call struct {
 
 // GetAllSubscriptions is a helper method to define mock.On call
 //   - _a0 context.Context
-func (_e *MockSubscriptionServiceExternal_Expecter) GetAllSubscriptions(_a0 interface{}) *MockSubscriptionServiceExternal_GetAllSubscriptions_Call {
-	return &MockSubscriptionServiceExternal_GetAllSubscriptions_Call{Call: _e.mock.On("GetAllSubscriptions", _a0)}
+//   - _a1 models.SubscriptionQuery
+func (_e *MockSubscriptionServiceExternal_Expecter) GetAllSubscriptions(_a0 interface{}, _a1 interface{}) *MockSubscriptionServiceExternal_GetAllSubscriptions_Call {
+	return &MockSubscriptionServiceExternal_GetAllSubscriptions_Call{Call: _e.mock.On("GetAllSubscriptions", _a0, _a1)}
 }
 
-func (_c *MockSubscriptionServiceExternal_GetAllSubscriptions_Call) Run(run func(_a0 context.Context)) *MockSubscriptionServiceExternal_GetAllSubscriptions_Call {
+func (_c *MockSubscriptionServiceExternal_GetAllSubscriptions_Call) Run(run func(_a0 context.Context, _a1 models.SubscriptionQuery)) *MockSubscriptionServiceExternal_GetAllSubscriptions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(models.SubscriptionQuery))
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionServiceExternal_GetAllSubscriptions_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionServiceExternal_GetAllSubscriptions_Call {
+func (_c *MockSubscriptionServiceExternal_GetAllSubscriptions_Call) Return(_a0 *models.SubscriptionPage, _a1 error) *MockSubscriptionServiceExternal_GetAllSubscriptions_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSubscriptionServiceExternal_GetAllSubscriptions_Call) RunAndReturn(run func(context.Context) ([]*models.Subscription, error)) *MockSubscriptionServiceExternal_GetAllSubscriptions_Call {
+func (_c *MockSubscriptionServiceExternal_GetAllSubscriptions_Call) RunAndReturn(run func(context.Context, models.SubscriptionQuery) (*models.SubscriptionPage, error)) *MockSubscriptionServiceExternal_GetAllSubscriptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSubscriptionByID provides a mock function with given fields: _a0, _a1, _a2
-func (_m *MockSubscriptionServiceExternal) GetSubscriptionByID(_a0 context.Context, _a1 string, _a2 string) (*models.Subscription, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// GetDuplicateSubscriptions provides a mock function with given fields: ctx, claimedUserID
+func (_m *MockSubscriptionServiceExternal) GetDuplicateSubscriptions(ctx context.Context, claimedUserID string) ([]*models.DuplicateGroup, error) {
+	ret := _m.Called(ctx, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicateSubscriptions")
+	}
+
+	var r0 []*models.DuplicateGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.DuplicateGroup, error)); ok {
+		return rf(ctx, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.DuplicateGroup); ok {
+		r0 = rf(ctx, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DuplicateGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_GetDuplicateSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDuplicateSubscriptions'
+type MockSubscriptionServiceExternal_GetDuplicateSubscriptions_Call struct {
+	*mock.Call
+}
+
+// GetDuplicateSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+func (_e *MockSubscriptionServiceExternal_Expecter) GetDuplicateSubscriptions(ctx interface{}, claimedUserID interface{}) *MockSubscriptionServiceExternal_GetDuplicateSubscriptions_Call {
+	return &MockSubscriptionServiceExternal_GetDuplicateSubscriptions_Call{Call: _e.mock.On("GetDuplicateSubscriptions", ctx, claimedUserID)}
+}
+
+func (_c *MockSubscriptionServiceExternal_GetDuplicateSubscriptions_Call) Run(run func(ctx context.Context, claimedUserID string)) *MockSubscriptionServiceExternal_GetDuplicateSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetDuplicateSubscriptions_Call) Return(_a0 []*models.DuplicateGroup, _a1 error) *MockSubscriptionServiceExternal_GetDuplicateSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetDuplicateSubscriptions_Call) RunAndReturn(run func(context.Context, string) ([]*models.DuplicateGroup, error)) *MockSubscriptionServiceExternal_GetDuplicateSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLifecycleEvents provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockSubscriptionServiceExternal) GetLifecycleEvents(ctx context.Context, id string, claimedUserID string) ([]*models.LifecycleEvent, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLifecycleEvents")
+	}
+
+	var r0 []*models.LifecycleEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.LifecycleEvent, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.LifecycleEvent); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LifecycleEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_GetLifecycleEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLifecycleEvents'
+type MockSubscriptionServiceExternal_GetLifecycleEvents_Call struct {
+	*mock.Call
+}
+
+// GetLifecycleEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockSubscriptionServiceExternal_Expecter) GetLifecycleEvents(ctx interface{}, id interface{}, claimedUserID interface{}) *MockSubscriptionServiceExternal_GetLifecycleEvents_Call {
+	return &MockSubscriptionServiceExternal_GetLifecycleEvents_Call{Call: _e.mock.On("GetLifecycleEvents", ctx, id, claimedUserID)}
+}
+
+func (_c *MockSubscriptionServiceExternal_GetLifecycleEvents_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockSubscriptionServiceExternal_GetLifecycleEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetLifecycleEvents_Call) Return(_a0 []*models.LifecycleEvent, _a1 error) *MockSubscriptionServiceExternal_GetLifecycleEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetLifecycleEvents_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.LifecycleEvent, error)) *MockSubscriptionServiceExternal_GetLifecycleEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPriceChangeSummary provides a mock function with given fields: ctx, claimedUserID, month
+func (_m *MockSubscriptionServiceExternal) GetPriceChangeSummary(ctx context.Context, claimedUserID string, month string) (*models.PriceChangeSummaryResponse, error) {
+	ret := _m.Called(ctx, claimedUserID, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPriceChangeSummary")
+	}
+
+	var r0 *models.PriceChangeSummaryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.PriceChangeSummaryResponse, error)); ok {
+		return rf(ctx, claimedUserID, month)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PriceChangeSummaryResponse); ok {
+		r0 = rf(ctx, claimedUserID, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PriceChangeSummaryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, claimedUserID, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_GetPriceChangeSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPriceChangeSummary'
+type MockSubscriptionServiceExternal_GetPriceChangeSummary_Call struct {
+	*mock.Call
+}
+
+// GetPriceChangeSummary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+//   - month string
+func (_e *MockSubscriptionServiceExternal_Expecter) GetPriceChangeSummary(ctx interface{}, claimedUserID interface{}, month interface{}) *MockSubscriptionServiceExternal_GetPriceChangeSummary_Call {
+	return &MockSubscriptionServiceExternal_GetPriceChangeSummary_Call{Call: _e.mock.On("GetPriceChangeSummary", ctx, claimedUserID, month)}
+}
+
+func (_c *MockSubscriptionServiceExternal_GetPriceChangeSummary_Call) Run(run func(ctx context.Context, claimedUserID string, month string)) *MockSubscriptionServiceExternal_GetPriceChangeSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetPriceChangeSummary_Call) Return(_a0 *models.PriceChangeSummaryResponse, _a1 error) *MockSubscriptionServiceExternal_GetPriceChangeSummary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetPriceChangeSummary_Call) RunAndReturn(run func(context.Context, string, string) (*models.PriceChangeSummaryResponse, error)) *MockSubscriptionServiceExternal_GetPriceChangeSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPriceHistory provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockSubscriptionServiceExternal) GetPriceHistory(ctx context.Context, id string, claimedUserID string) ([]*models.PriceChange, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPriceHistory")
+	}
+
+	var r0 []*models.PriceChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.PriceChange, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.PriceChange); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PriceChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_GetPriceHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPriceHistory'
+type MockSubscriptionServiceExternal_GetPriceHistory_Call struct {
+	*mock.Call
+}
+
+// GetPriceHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockSubscriptionServiceExternal_Expecter) GetPriceHistory(ctx interface{}, id interface{}, claimedUserID interface{}) *MockSubscriptionServiceExternal_GetPriceHistory_Call {
+	return &MockSubscriptionServiceExternal_GetPriceHistory_Call{Call: _e.mock.On("GetPriceHistory", ctx, id, claimedUserID)}
+}
+
+func (_c *MockSubscriptionServiceExternal_GetPriceHistory_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockSubscriptionServiceExternal_GetPriceHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetPriceHistory_Call) Return(_a0 []*models.PriceChange, _a1 error) *MockSubscriptionServiceExternal_GetPriceHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetPriceHistory_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.PriceChange, error)) *MockSubscriptionServiceExternal_GetPriceHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptionByID provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *MockSubscriptionServiceExternal) GetSubscriptionByID(_a0 context.Context, _a1 string, _a2 string, _a3 bool) (*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetSubscriptionByID")
@@ -258,19 +560,19 @@ func (_m *MockSubscriptionServiceExternal) GetSubscriptionByID(_a0 context.Conte
 
 	var r0 *models.Subscription
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Subscription, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (*models.Subscription, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Subscription); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) *models.Subscription); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Subscription)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -287,13 +589,14 @@ type MockSubscriptionServiceExternal_GetSubscriptionByID_Call struct {
 //   - _a0 context.Context
 //   - _a1 string
 //   - _a2 string
-func (_e *MockSubscriptionServiceExternal_Expecter) GetSubscriptionByID(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockSubscriptionServiceExternal_GetSubscriptionByID_Call {
-	return &MockSubscriptionServiceExternal_GetSubscriptionByID_Call{Call: _e.mock.On("GetSubscriptionByID", _a0, _a1, _a2)}
+//   - _a3 bool
+func (_e *MockSubscriptionServiceExternal_Expecter) GetSubscriptionByID(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *MockSubscriptionServiceExternal_GetSubscriptionByID_Call {
+	return &MockSubscriptionServiceExternal_GetSubscriptionByID_Call{Call: _e.mock.On("GetSubscriptionByID", _a0, _a1, _a2, _a3)}
 }
 
-func (_c *MockSubscriptionServiceExternal_GetSubscriptionByID_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockSubscriptionServiceExternal_GetSubscriptionByID_Call {
+func (_c *MockSubscriptionServiceExternal_GetSubscriptionByID_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string, _a3 bool)) *MockSubscriptionServiceExternal_GetSubscriptionByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool))
 	})
 	return _c
 }
@@ -303,34 +606,34 @@ func (_c *MockSubscriptionServiceExternal_GetSubscriptionByID_Call) Return(_a0 *
 	return _c
 }
 
-func (_c *MockSubscriptionServiceExternal_GetSubscriptionByID_Call) RunAndReturn(run func(context.Context, string, string) (*models.Subscription, error)) *MockSubscriptionServiceExternal_GetSubscriptionByID_Call {
+func (_c *MockSubscriptionServiceExternal_GetSubscriptionByID_Call) RunAndReturn(run func(context.Context, string, string, bool) (*models.Subscription, error)) *MockSubscriptionServiceExternal_GetSubscriptionByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSubscriptionsByUserID provides a mock function with given fields: _a0, _a1, _a2
-func (_m *MockSubscriptionServiceExternal) GetSubscriptionsByUserID(_a0 context.Context, _a1 string, _a2 string) ([]*models.Subscription, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// GetSubscriptionsByUserID provides a mock function with given fields: ctx, id, claimedUserID, includeArchived, query
+func (_m *MockSubscriptionServiceExternal) GetSubscriptionsByUserID(ctx context.Context, id string, claimedUserID string, includeArchived bool, query models.SubscriptionQuery) (*models.SubscriptionPage, error) {
+	ret := _m.Called(ctx, id, claimedUserID, includeArchived, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetSubscriptionsByUserID")
 	}
 
-	var r0 []*models.Subscription
+	var r0 *models.SubscriptionPage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.Subscription, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, models.SubscriptionQuery) (*models.SubscriptionPage, error)); ok {
+		return rf(ctx, id, claimedUserID, includeArchived, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.Subscription); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, models.SubscriptionQuery) *models.SubscriptionPage); ok {
+		r0 = rf(ctx, id, claimedUserID, includeArchived, query)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Subscription)
+			r0 = ret.Get(0).(*models.SubscriptionPage)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool, models.SubscriptionQuery) error); ok {
+		r1 = rf(ctx, id, claimedUserID, includeArchived, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -344,26 +647,571 @@ type MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call struct {
 }
 
 // GetSubscriptionsByUserID is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 string
-//   - _a2 string
-func (_e *MockSubscriptionServiceExternal_Expecter) GetSubscriptionsByUserID(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call {
-	return &MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call{Call: _e.mock.On("GetSubscriptionsByUserID", _a0, _a1, _a2)}
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - includeArchived bool
+//   - query models.SubscriptionQuery
+func (_e *MockSubscriptionServiceExternal_Expecter) GetSubscriptionsByUserID(ctx interface{}, id interface{}, claimedUserID interface{}, includeArchived interface{}, query interface{}) *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call {
+	return &MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call{Call: _e.mock.On("GetSubscriptionsByUserID", ctx, id, claimedUserID, includeArchived, query)}
 }
 
-func (_c *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call {
+func (_c *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call) Run(run func(ctx context.Context, id string, claimedUserID string, includeArchived bool, query models.SubscriptionQuery)) *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool), args[4].(models.SubscriptionQuery))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call) Return(_a0 *models.SubscriptionPage, _a1 error) *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call) RunAndReturn(run func(context.Context, string, string, bool, models.SubscriptionQuery) (*models.SubscriptionPage, error)) *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSummary provides a mock function with given fields: ctx, claimedUserID, target
+func (_m *MockSubscriptionServiceExternal) GetSummary(ctx context.Context, claimedUserID string, target models.Currency) (*models.SubscriptionSummaryResponse, error) {
+	ret := _m.Called(ctx, claimedUserID, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSummary")
+	}
+
+	var r0 *models.SubscriptionSummaryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Currency) (*models.SubscriptionSummaryResponse, error)); ok {
+		return rf(ctx, claimedUserID, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Currency) *models.SubscriptionSummaryResponse); ok {
+		r0 = rf(ctx, claimedUserID, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SubscriptionSummaryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.Currency) error); ok {
+		r1 = rf(ctx, claimedUserID, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_GetSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSummary'
+type MockSubscriptionServiceExternal_GetSummary_Call struct {
+	*mock.Call
+}
+
+// GetSummary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+//   - target models.Currency
+func (_e *MockSubscriptionServiceExternal_Expecter) GetSummary(ctx interface{}, claimedUserID interface{}, target interface{}) *MockSubscriptionServiceExternal_GetSummary_Call {
+	return &MockSubscriptionServiceExternal_GetSummary_Call{Call: _e.mock.On("GetSummary", ctx, claimedUserID, target)}
+}
+
+func (_c *MockSubscriptionServiceExternal_GetSummary_Call) Run(run func(ctx context.Context, claimedUserID string, target models.Currency)) *MockSubscriptionServiceExternal_GetSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Currency))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetSummary_Call) Return(_a0 *models.SubscriptionSummaryResponse, _a1 error) *MockSubscriptionServiceExternal_GetSummary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_GetSummary_Call) RunAndReturn(run func(context.Context, string, models.Currency) (*models.SubscriptionSummaryResponse, error)) *MockSubscriptionServiceExternal_GetSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseSubscription provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockSubscriptionServiceExternal) PauseSubscription(ctx context.Context, id string, claimedUserID string) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseSubscription")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Subscription, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Subscription); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_PauseSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseSubscription'
+type MockSubscriptionServiceExternal_PauseSubscription_Call struct {
+	*mock.Call
+}
+
+// PauseSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockSubscriptionServiceExternal_Expecter) PauseSubscription(ctx interface{}, id interface{}, claimedUserID interface{}) *MockSubscriptionServiceExternal_PauseSubscription_Call {
+	return &MockSubscriptionServiceExternal_PauseSubscription_Call{Call: _e.mock.On("PauseSubscription", ctx, id, claimedUserID)}
+}
+
+func (_c *MockSubscriptionServiceExternal_PauseSubscription_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockSubscriptionServiceExternal_PauseSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_PauseSubscription_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionServiceExternal_PauseSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_PauseSubscription_Call) RunAndReturn(run func(context.Context, string, string) (*models.Subscription, error)) *MockSubscriptionServiceExternal_PauseSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordUsage provides a mock function with given fields: ctx, id, claimedUserID, usedAt
+func (_m *MockSubscriptionServiceExternal) RecordUsage(ctx context.Context, id string, claimedUserID string, usedAt time.Time) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, claimedUserID, usedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordUsage")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (*models.Subscription, error)); ok {
+		return rf(ctx, id, claimedUserID, usedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *models.Subscription); ok {
+		r0 = rf(ctx, id, claimedUserID, usedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, id, claimedUserID, usedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_RecordUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordUsage'
+type MockSubscriptionServiceExternal_RecordUsage_Call struct {
+	*mock.Call
+}
+
+// RecordUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - usedAt time.Time
+func (_e *MockSubscriptionServiceExternal_Expecter) RecordUsage(ctx interface{}, id interface{}, claimedUserID interface{}, usedAt interface{}) *MockSubscriptionServiceExternal_RecordUsage_Call {
+	return &MockSubscriptionServiceExternal_RecordUsage_Call{Call: _e.mock.On("RecordUsage", ctx, id, claimedUserID, usedAt)}
+}
+
+func (_c *MockSubscriptionServiceExternal_RecordUsage_Call) Run(run func(ctx context.Context, id string, claimedUserID string, usedAt time.Time)) *MockSubscriptionServiceExternal_RecordUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_RecordUsage_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionServiceExternal_RecordUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_RecordUsage_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (*models.Subscription, error)) *MockSubscriptionServiceExternal_RecordUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeSubscription provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockSubscriptionServiceExternal) ResumeSubscription(ctx context.Context, id string, claimedUserID string) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeSubscription")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Subscription, error)); ok {
+		return rf(ctx, id, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Subscription); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_ResumeSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeSubscription'
+type MockSubscriptionServiceExternal_ResumeSubscription_Call struct {
+	*mock.Call
+}
+
+// ResumeSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockSubscriptionServiceExternal_Expecter) ResumeSubscription(ctx interface{}, id interface{}, claimedUserID interface{}) *MockSubscriptionServiceExternal_ResumeSubscription_Call {
+	return &MockSubscriptionServiceExternal_ResumeSubscription_Call{Call: _e.mock.On("ResumeSubscription", ctx, id, claimedUserID)}
+}
+
+func (_c *MockSubscriptionServiceExternal_ResumeSubscription_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockSubscriptionServiceExternal_ResumeSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_ResumeSubscription_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionServiceExternal_ResumeSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_ResumeSubscription_Call) RunAndReturn(run func(context.Context, string, string) (*models.Subscription, error)) *MockSubscriptionServiceExternal_ResumeSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchAllSubscriptions provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionServiceExternal) SearchAllSubscriptions(_a0 context.Context, _a1 models.SubscriptionSearch) (*models.SubscriptionSearchPage, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchAllSubscriptions")
+	}
+
+	var r0 *models.SubscriptionSearchPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SubscriptionSearch) (*models.SubscriptionSearchPage, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.SubscriptionSearch) *models.SubscriptionSearchPage); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SubscriptionSearchPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.SubscriptionSearch) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_SearchAllSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchAllSubscriptions'
+type MockSubscriptionServiceExternal_SearchAllSubscriptions_Call struct {
+	*mock.Call
+}
+
+// SearchAllSubscriptions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 models.SubscriptionSearch
+func (_e *MockSubscriptionServiceExternal_Expecter) SearchAllSubscriptions(_a0 interface{}, _a1 interface{}) *MockSubscriptionServiceExternal_SearchAllSubscriptions_Call {
+	return &MockSubscriptionServiceExternal_SearchAllSubscriptions_Call{Call: _e.mock.On("SearchAllSubscriptions", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionServiceExternal_SearchAllSubscriptions_Call) Run(run func(_a0 context.Context, _a1 models.SubscriptionSearch)) *MockSubscriptionServiceExternal_SearchAllSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.SubscriptionSearch))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_SearchAllSubscriptions_Call) Return(_a0 *models.SubscriptionSearchPage, _a1 error) *MockSubscriptionServiceExternal_SearchAllSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_SearchAllSubscriptions_Call) RunAndReturn(run func(context.Context, models.SubscriptionSearch) (*models.SubscriptionSearchPage, error)) *MockSubscriptionServiceExternal_SearchAllSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchSubscriptions provides a mock function with given fields: ctx, claimedUserID, search
+func (_m *MockSubscriptionServiceExternal) SearchSubscriptions(ctx context.Context, claimedUserID string, search models.SubscriptionSearch) (*models.SubscriptionSearchPage, error) {
+	ret := _m.Called(ctx, claimedUserID, search)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSubscriptions")
+	}
+
+	var r0 *models.SubscriptionSearchPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.SubscriptionSearch) (*models.SubscriptionSearchPage, error)); ok {
+		return rf(ctx, claimedUserID, search)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.SubscriptionSearch) *models.SubscriptionSearchPage); ok {
+		r0 = rf(ctx, claimedUserID, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SubscriptionSearchPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.SubscriptionSearch) error); ok {
+		r1 = rf(ctx, claimedUserID, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_SearchSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSubscriptions'
+type MockSubscriptionServiceExternal_SearchSubscriptions_Call struct {
+	*mock.Call
+}
+
+// SearchSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+//   - search models.SubscriptionSearch
+func (_e *MockSubscriptionServiceExternal_Expecter) SearchSubscriptions(ctx interface{}, claimedUserID interface{}, search interface{}) *MockSubscriptionServiceExternal_SearchSubscriptions_Call {
+	return &MockSubscriptionServiceExternal_SearchSubscriptions_Call{Call: _e.mock.On("SearchSubscriptions", ctx, claimedUserID, search)}
+}
+
+func (_c *MockSubscriptionServiceExternal_SearchSubscriptions_Call) Run(run func(ctx context.Context, claimedUserID string, search models.SubscriptionSearch)) *MockSubscriptionServiceExternal_SearchSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.SubscriptionSearch))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_SearchSubscriptions_Call) Return(_a0 *models.SubscriptionSearchPage, _a1 error) *MockSubscriptionServiceExternal_SearchSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_SearchSubscriptions_Call) RunAndReturn(run func(context.Context, string, models.SubscriptionSearch) (*models.SubscriptionSearchPage, error)) *MockSubscriptionServiceExternal_SearchSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBillStatus provides a mock function with given fields: ctx, id, status
+func (_m *MockSubscriptionServiceExternal) UpdateBillStatus(ctx context.Context, id string, status models.PaymentStatus) (*models.Bill, error) {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBillStatus")
+	}
+
+	var r0 *models.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PaymentStatus) (*models.Bill, error)); ok {
+		return rf(ctx, id, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PaymentStatus) *models.Bill); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.PaymentStatus) error); ok {
+		r1 = rf(ctx, id, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_UpdateBillStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBillStatus'
+type MockSubscriptionServiceExternal_UpdateBillStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateBillStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status models.PaymentStatus
+func (_e *MockSubscriptionServiceExternal_Expecter) UpdateBillStatus(ctx interface{}, id interface{}, status interface{}) *MockSubscriptionServiceExternal_UpdateBillStatus_Call {
+	return &MockSubscriptionServiceExternal_UpdateBillStatus_Call{Call: _e.mock.On("UpdateBillStatus", ctx, id, status)}
+}
+
+func (_c *MockSubscriptionServiceExternal_UpdateBillStatus_Call) Run(run func(ctx context.Context, id string, status models.PaymentStatus)) *MockSubscriptionServiceExternal_UpdateBillStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PaymentStatus))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_UpdateBillStatus_Call) Return(_a0 *models.Bill, _a1 error) *MockSubscriptionServiceExternal_UpdateBillStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_UpdateBillStatus_Call) RunAndReturn(run func(context.Context, string, models.PaymentStatus) (*models.Bill, error)) *MockSubscriptionServiceExternal_UpdateBillStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePrice provides a mock function with given fields: ctx, id, claimedUserID, price, effectiveAt
+func (_m *MockSubscriptionServiceExternal) UpdatePrice(ctx context.Context, id string, claimedUserID string, price int64, effectiveAt time.Time) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, claimedUserID, price, effectiveAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePrice")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, time.Time) (*models.Subscription, error)); ok {
+		return rf(ctx, id, claimedUserID, price, effectiveAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, time.Time) *models.Subscription); ok {
+		r0 = rf(ctx, id, claimedUserID, price, effectiveAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, time.Time) error); ok {
+		r1 = rf(ctx, id, claimedUserID, price, effectiveAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_UpdatePrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePrice'
+type MockSubscriptionServiceExternal_UpdatePrice_Call struct {
+	*mock.Call
+}
+
+// UpdatePrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - price int64
+//   - effectiveAt time.Time
+func (_e *MockSubscriptionServiceExternal_Expecter) UpdatePrice(ctx interface{}, id interface{}, claimedUserID interface{}, price interface{}, effectiveAt interface{}) *MockSubscriptionServiceExternal_UpdatePrice_Call {
+	return &MockSubscriptionServiceExternal_UpdatePrice_Call{Call: _e.mock.On("UpdatePrice", ctx, id, claimedUserID, price, effectiveAt)}
+}
+
+func (_c *MockSubscriptionServiceExternal_UpdatePrice_Call) Run(run func(ctx context.Context, id string, claimedUserID string, price int64, effectiveAt time.Time)) *MockSubscriptionServiceExternal_UpdatePrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_UpdatePrice_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionServiceExternal_UpdatePrice_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceExternal_UpdatePrice_Call) RunAndReturn(run func(context.Context, string, string, int64, time.Time) (*models.Subscription, error)) *MockSubscriptionServiceExternal_UpdatePrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSubscription provides a mock function with given fields: ctx, id, claimedUserID, update
+func (_m *MockSubscriptionServiceExternal) UpdateSubscription(ctx context.Context, id string, claimedUserID string, update *models.SubscriptionUpdateRequest) (*models.Subscription, error) {
+	ret := _m.Called(ctx, id, claimedUserID, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSubscription")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.SubscriptionUpdateRequest) (*models.Subscription, error)); ok {
+		return rf(ctx, id, claimedUserID, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.SubscriptionUpdateRequest) *models.Subscription); ok {
+		r0 = rf(ctx, id, claimedUserID, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.SubscriptionUpdateRequest) error); ok {
+		r1 = rf(ctx, id, claimedUserID, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceExternal_UpdateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSubscription'
+type MockSubscriptionServiceExternal_UpdateSubscription_Call struct {
+	*mock.Call
+}
+
+// UpdateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - update *models.SubscriptionUpdateRequest
+func (_e *MockSubscriptionServiceExternal_Expecter) UpdateSubscription(ctx interface{}, id interface{}, claimedUserID interface{}, update interface{}) *MockSubscriptionServiceExternal_UpdateSubscription_Call {
+	return &MockSubscriptionServiceExternal_UpdateSubscription_Call{Call: _e.mock.On("UpdateSubscription", ctx, id, claimedUserID, update)}
+}
+
+func (_c *MockSubscriptionServiceExternal_UpdateSubscription_Call) Run(run func(ctx context.Context, id string, claimedUserID string, update *models.SubscriptionUpdateRequest)) *MockSubscriptionServiceExternal_UpdateSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.SubscriptionUpdateRequest))
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call {
+func (_c *MockSubscriptionServiceExternal_UpdateSubscription_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionServiceExternal_UpdateSubscription_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.Subscription, error)) *MockSubscriptionServiceExternal_GetSubscriptionsByUserID_Call {
+func (_c *MockSubscriptionServiceExternal_UpdateSubscription_Call) RunAndReturn(run func(context.Context, string, string, *models.SubscriptionUpdateRequest) (*models.Subscription, error)) *MockSubscriptionServiceExternal_UpdateSubscription_Call {
 	_c.Call.Return(run)
 	return _c
 }
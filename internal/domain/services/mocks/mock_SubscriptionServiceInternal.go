@@ -27,12 +27,672 @@ func (_m *MockSubscriptionServiceInternal) EXPECT() *MockSubscriptionServiceInte
 	return &MockSubscriptionServiceInternal_Expecter{mock: &_m.Mock}
 }
 
+// AnonymizeSubscriptionsInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionServiceInternal) AnonymizeSubscriptionsInternal(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnonymizeSubscriptionsInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionServiceInternal_AnonymizeSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnonymizeSubscriptionsInternal'
+type MockSubscriptionServiceInternal_AnonymizeSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// AnonymizeSubscriptionsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionServiceInternal_Expecter) AnonymizeSubscriptionsInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionServiceInternal_AnonymizeSubscriptionsInternal_Call {
+	return &MockSubscriptionServiceInternal_AnonymizeSubscriptionsInternal_Call{Call: _e.mock.On("AnonymizeSubscriptionsInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionServiceInternal_AnonymizeSubscriptionsInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionServiceInternal_AnonymizeSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_AnonymizeSubscriptionsInternal_Call) Return(_a0 error) *MockSubscriptionServiceInternal_AnonymizeSubscriptionsInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_AnonymizeSubscriptionsInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockSubscriptionServiceInternal_AnonymizeSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArchiveSubscriptionInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionServiceInternal) ArchiveSubscriptionInternal(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveSubscriptionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionServiceInternal_ArchiveSubscriptionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveSubscriptionInternal'
+type MockSubscriptionServiceInternal_ArchiveSubscriptionInternal_Call struct {
+	*mock.Call
+}
+
+// ArchiveSubscriptionInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionServiceInternal_Expecter) ArchiveSubscriptionInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionServiceInternal_ArchiveSubscriptionInternal_Call {
+	return &MockSubscriptionServiceInternal_ArchiveSubscriptionInternal_Call{Call: _e.mock.On("ArchiveSubscriptionInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionServiceInternal_ArchiveSubscriptionInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionServiceInternal_ArchiveSubscriptionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_ArchiveSubscriptionInternal_Call) Return(_a0 error) *MockSubscriptionServiceInternal_ArchiveSubscriptionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_ArchiveSubscriptionInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockSubscriptionServiceInternal_ArchiveSubscriptionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelSubscriptionsInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionServiceInternal) CancelSubscriptionsInternal(_a0 context.Context, _a1 bson.ObjectID) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelSubscriptionsInternal")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceInternal_CancelSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelSubscriptionsInternal'
+type MockSubscriptionServiceInternal_CancelSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// CancelSubscriptionsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionServiceInternal_Expecter) CancelSubscriptionsInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionServiceInternal_CancelSubscriptionsInternal_Call {
+	return &MockSubscriptionServiceInternal_CancelSubscriptionsInternal_Call{Call: _e.mock.On("CancelSubscriptionsInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionServiceInternal_CancelSubscriptionsInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionServiceInternal_CancelSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_CancelSubscriptionsInternal_Call) Return(_a0 int, _a1 error) *MockSubscriptionServiceInternal_CancelSubscriptionsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_CancelSubscriptionsInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int, error)) *MockSubscriptionServiceInternal_CancelSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteReminderHistoryInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionServiceInternal) DeleteReminderHistoryInternal(_a0 context.Context, _a1 bson.ObjectID) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReminderHistoryInternal")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceInternal_DeleteReminderHistoryInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReminderHistoryInternal'
+type MockSubscriptionServiceInternal_DeleteReminderHistoryInternal_Call struct {
+	*mock.Call
+}
+
+// DeleteReminderHistoryInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionServiceInternal_Expecter) DeleteReminderHistoryInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionServiceInternal_DeleteReminderHistoryInternal_Call {
+	return &MockSubscriptionServiceInternal_DeleteReminderHistoryInternal_Call{Call: _e.mock.On("DeleteReminderHistoryInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionServiceInternal_DeleteReminderHistoryInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionServiceInternal_DeleteReminderHistoryInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_DeleteReminderHistoryInternal_Call) Return(_a0 int64, _a1 error) *MockSubscriptionServiceInternal_DeleteReminderHistoryInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_DeleteReminderHistoryInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockSubscriptionServiceInternal_DeleteReminderHistoryInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachArchivableSubscriptionInternal provides a mock function with given fields: ctx, retentionMonths, fn
+func (_m *MockSubscriptionServiceInternal) EachArchivableSubscriptionInternal(ctx context.Context, retentionMonths int, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, retentionMonths, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachArchivableSubscriptionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, retentionMonths, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionServiceInternal_EachArchivableSubscriptionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachArchivableSubscriptionInternal'
+type MockSubscriptionServiceInternal_EachArchivableSubscriptionInternal_Call struct {
+	*mock.Call
+}
+
+// EachArchivableSubscriptionInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - retentionMonths int
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionServiceInternal_Expecter) EachArchivableSubscriptionInternal(ctx interface{}, retentionMonths interface{}, fn interface{}) *MockSubscriptionServiceInternal_EachArchivableSubscriptionInternal_Call {
+	return &MockSubscriptionServiceInternal_EachArchivableSubscriptionInternal_Call{Call: _e.mock.On("EachArchivableSubscriptionInternal", ctx, retentionMonths, fn)}
+}
+
+func (_c *MockSubscriptionServiceInternal_EachArchivableSubscriptionInternal_Call) Run(run func(ctx context.Context, retentionMonths int, fn func(*models.Subscription) error)) *MockSubscriptionServiceInternal_EachArchivableSubscriptionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachArchivableSubscriptionInternal_Call) Return(_a0 error) *MockSubscriptionServiceInternal_EachArchivableSubscriptionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachArchivableSubscriptionInternal_Call) RunAndReturn(run func(context.Context, int, func(*models.Subscription) error) error) *MockSubscriptionServiceInternal_EachArchivableSubscriptionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachCanceledExpiredSubscriptionInternal provides a mock function with given fields: ctx, fn
+func (_m *MockSubscriptionServiceInternal) EachCanceledExpiredSubscriptionInternal(ctx context.Context, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachCanceledExpiredSubscriptionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionServiceInternal_EachCanceledExpiredSubscriptionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachCanceledExpiredSubscriptionInternal'
+type MockSubscriptionServiceInternal_EachCanceledExpiredSubscriptionInternal_Call struct {
+	*mock.Call
+}
+
+// EachCanceledExpiredSubscriptionInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionServiceInternal_Expecter) EachCanceledExpiredSubscriptionInternal(ctx interface{}, fn interface{}) *MockSubscriptionServiceInternal_EachCanceledExpiredSubscriptionInternal_Call {
+	return &MockSubscriptionServiceInternal_EachCanceledExpiredSubscriptionInternal_Call{Call: _e.mock.On("EachCanceledExpiredSubscriptionInternal", ctx, fn)}
+}
+
+func (_c *MockSubscriptionServiceInternal_EachCanceledExpiredSubscriptionInternal_Call) Run(run func(ctx context.Context, fn func(*models.Subscription) error)) *MockSubscriptionServiceInternal_EachCanceledExpiredSubscriptionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachCanceledExpiredSubscriptionInternal_Call) Return(_a0 error) *MockSubscriptionServiceInternal_EachCanceledExpiredSubscriptionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachCanceledExpiredSubscriptionInternal_Call) RunAndReturn(run func(context.Context, func(*models.Subscription) error) error) *MockSubscriptionServiceInternal_EachCanceledExpiredSubscriptionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachPastDueSubscriptionInternal provides a mock function with given fields: ctx, fn
+func (_m *MockSubscriptionServiceInternal) EachPastDueSubscriptionInternal(ctx context.Context, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachPastDueSubscriptionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionServiceInternal_EachPastDueSubscriptionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachPastDueSubscriptionInternal'
+type MockSubscriptionServiceInternal_EachPastDueSubscriptionInternal_Call struct {
+	*mock.Call
+}
+
+// EachPastDueSubscriptionInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionServiceInternal_Expecter) EachPastDueSubscriptionInternal(ctx interface{}, fn interface{}) *MockSubscriptionServiceInternal_EachPastDueSubscriptionInternal_Call {
+	return &MockSubscriptionServiceInternal_EachPastDueSubscriptionInternal_Call{Call: _e.mock.On("EachPastDueSubscriptionInternal", ctx, fn)}
+}
+
+func (_c *MockSubscriptionServiceInternal_EachPastDueSubscriptionInternal_Call) Run(run func(ctx context.Context, fn func(*models.Subscription) error)) *MockSubscriptionServiceInternal_EachPastDueSubscriptionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachPastDueSubscriptionInternal_Call) Return(_a0 error) *MockSubscriptionServiceInternal_EachPastDueSubscriptionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachPastDueSubscriptionInternal_Call) RunAndReturn(run func(context.Context, func(*models.Subscription) error) error) *MockSubscriptionServiceInternal_EachPastDueSubscriptionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachSubscriptionDueForRenewalInternal provides a mock function with given fields: ctx, startTime, endTime, fn
+func (_m *MockSubscriptionServiceInternal) EachSubscriptionDueForRenewalInternal(ctx context.Context, startTime time.Time, endTime time.Time, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, startTime, endTime, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachSubscriptionDueForRenewalInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, startTime, endTime, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionServiceInternal_EachSubscriptionDueForRenewalInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachSubscriptionDueForRenewalInternal'
+type MockSubscriptionServiceInternal_EachSubscriptionDueForRenewalInternal_Call struct {
+	*mock.Call
+}
+
+// EachSubscriptionDueForRenewalInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - startTime time.Time
+//   - endTime time.Time
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionServiceInternal_Expecter) EachSubscriptionDueForRenewalInternal(ctx interface{}, startTime interface{}, endTime interface{}, fn interface{}) *MockSubscriptionServiceInternal_EachSubscriptionDueForRenewalInternal_Call {
+	return &MockSubscriptionServiceInternal_EachSubscriptionDueForRenewalInternal_Call{Call: _e.mock.On("EachSubscriptionDueForRenewalInternal", ctx, startTime, endTime, fn)}
+}
+
+func (_c *MockSubscriptionServiceInternal_EachSubscriptionDueForRenewalInternal_Call) Run(run func(ctx context.Context, startTime time.Time, endTime time.Time, fn func(*models.Subscription) error)) *MockSubscriptionServiceInternal_EachSubscriptionDueForRenewalInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachSubscriptionDueForRenewalInternal_Call) Return(_a0 error) *MockSubscriptionServiceInternal_EachSubscriptionDueForRenewalInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachSubscriptionDueForRenewalInternal_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, func(*models.Subscription) error) error) *MockSubscriptionServiceInternal_EachSubscriptionDueForRenewalInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachUnusedSubscriptionInternal provides a mock function with given fields: ctx, unusedAfterDays, noticeDays, fn
+func (_m *MockSubscriptionServiceInternal) EachUnusedSubscriptionInternal(ctx context.Context, unusedAfterDays int, noticeDays int, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, unusedAfterDays, noticeDays, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachUnusedSubscriptionInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, unusedAfterDays, noticeDays, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionServiceInternal_EachUnusedSubscriptionInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachUnusedSubscriptionInternal'
+type MockSubscriptionServiceInternal_EachUnusedSubscriptionInternal_Call struct {
+	*mock.Call
+}
+
+// EachUnusedSubscriptionInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - unusedAfterDays int
+//   - noticeDays int
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionServiceInternal_Expecter) EachUnusedSubscriptionInternal(ctx interface{}, unusedAfterDays interface{}, noticeDays interface{}, fn interface{}) *MockSubscriptionServiceInternal_EachUnusedSubscriptionInternal_Call {
+	return &MockSubscriptionServiceInternal_EachUnusedSubscriptionInternal_Call{Call: _e.mock.On("EachUnusedSubscriptionInternal", ctx, unusedAfterDays, noticeDays, fn)}
+}
+
+func (_c *MockSubscriptionServiceInternal_EachUnusedSubscriptionInternal_Call) Run(run func(ctx context.Context, unusedAfterDays int, noticeDays int, fn func(*models.Subscription) error)) *MockSubscriptionServiceInternal_EachUnusedSubscriptionInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int), args[3].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachUnusedSubscriptionInternal_Call) Return(_a0 error) *MockSubscriptionServiceInternal_EachUnusedSubscriptionInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachUnusedSubscriptionInternal_Call) RunAndReturn(run func(context.Context, int, int, func(*models.Subscription) error) error) *MockSubscriptionServiceInternal_EachUnusedSubscriptionInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachUpcomingRenewalInternal provides a mock function with given fields: ctx, daysAhead, fn
+func (_m *MockSubscriptionServiceInternal) EachUpcomingRenewalInternal(ctx context.Context, daysAhead []int, fn func(*models.Subscription) error) error {
+	ret := _m.Called(ctx, daysAhead, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachUpcomingRenewalInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, func(*models.Subscription) error) error); ok {
+		r0 = rf(ctx, daysAhead, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionServiceInternal_EachUpcomingRenewalInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachUpcomingRenewalInternal'
+type MockSubscriptionServiceInternal_EachUpcomingRenewalInternal_Call struct {
+	*mock.Call
+}
+
+// EachUpcomingRenewalInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - daysAhead []int
+//   - fn func(*models.Subscription) error
+func (_e *MockSubscriptionServiceInternal_Expecter) EachUpcomingRenewalInternal(ctx interface{}, daysAhead interface{}, fn interface{}) *MockSubscriptionServiceInternal_EachUpcomingRenewalInternal_Call {
+	return &MockSubscriptionServiceInternal_EachUpcomingRenewalInternal_Call{Call: _e.mock.On("EachUpcomingRenewalInternal", ctx, daysAhead, fn)}
+}
+
+func (_c *MockSubscriptionServiceInternal_EachUpcomingRenewalInternal_Call) Run(run func(ctx context.Context, daysAhead []int, fn func(*models.Subscription) error)) *MockSubscriptionServiceInternal_EachUpcomingRenewalInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int), args[2].(func(*models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachUpcomingRenewalInternal_Call) Return(_a0 error) *MockSubscriptionServiceInternal_EachUpcomingRenewalInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EachUpcomingRenewalInternal_Call) RunAndReturn(run func(context.Context, []int, func(*models.Subscription) error) error) *MockSubscriptionServiceInternal_EachUpcomingRenewalInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EndSettledDunningInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionServiceInternal) EndSettledDunningInternal(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EndSettledDunningInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionServiceInternal_EndSettledDunningInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EndSettledDunningInternal'
+type MockSubscriptionServiceInternal_EndSettledDunningInternal_Call struct {
+	*mock.Call
+}
+
+// EndSettledDunningInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockSubscriptionServiceInternal_Expecter) EndSettledDunningInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionServiceInternal_EndSettledDunningInternal_Call {
+	return &MockSubscriptionServiceInternal_EndSettledDunningInternal_Call{Call: _e.mock.On("EndSettledDunningInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionServiceInternal_EndSettledDunningInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockSubscriptionServiceInternal_EndSettledDunningInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EndSettledDunningInternal_Call) Return(_a0 error) *MockSubscriptionServiceInternal_EndSettledDunningInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_EndSettledDunningInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockSubscriptionServiceInternal_EndSettledDunningInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchArchivableSubscriptionsInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockSubscriptionServiceInternal) FetchArchivableSubscriptionsInternal(_a0 context.Context, _a1 int) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchArchivableSubscriptionsInternal")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.Subscription, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.Subscription); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceInternal_FetchArchivableSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchArchivableSubscriptionsInternal'
+type MockSubscriptionServiceInternal_FetchArchivableSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// FetchArchivableSubscriptionsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 int
+func (_e *MockSubscriptionServiceInternal_Expecter) FetchArchivableSubscriptionsInternal(_a0 interface{}, _a1 interface{}) *MockSubscriptionServiceInternal_FetchArchivableSubscriptionsInternal_Call {
+	return &MockSubscriptionServiceInternal_FetchArchivableSubscriptionsInternal_Call{Call: _e.mock.On("FetchArchivableSubscriptionsInternal", _a0, _a1)}
+}
+
+func (_c *MockSubscriptionServiceInternal_FetchArchivableSubscriptionsInternal_Call) Run(run func(_a0 context.Context, _a1 int)) *MockSubscriptionServiceInternal_FetchArchivableSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_FetchArchivableSubscriptionsInternal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionServiceInternal_FetchArchivableSubscriptionsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_FetchArchivableSubscriptionsInternal_Call) RunAndReturn(run func(context.Context, int) ([]*models.Subscription, error)) *MockSubscriptionServiceInternal_FetchArchivableSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FetchCanceledExpiredSubscriptionsInternal provides a mock function with given fields: _a0
 func (_m *MockSubscriptionServiceInternal) FetchCanceledExpiredSubscriptionsInternal(_a0 context.Context) ([]*models.Subscription, error) {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FetchCanceledExpiredSubscriptionsInternal")
+		panic("no return value specified for FetchCanceledExpiredSubscriptionsInternal")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Subscription, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Subscription); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchCanceledExpiredSubscriptionsInternal'
+type MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// FetchCanceledExpiredSubscriptionsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockSubscriptionServiceInternal_Expecter) FetchCanceledExpiredSubscriptionsInternal(_a0 interface{}) *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call {
+	return &MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call{Call: _e.mock.On("FetchCanceledExpiredSubscriptionsInternal", _a0)}
+}
+
+func (_c *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call) Run(run func(_a0 context.Context)) *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call) RunAndReturn(run func(context.Context) ([]*models.Subscription, error)) *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchPastDueSubscriptionsInternal provides a mock function with given fields: _a0
+func (_m *MockSubscriptionServiceInternal) FetchPastDueSubscriptionsInternal(_a0 context.Context) ([]*models.Subscription, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchPastDueSubscriptionsInternal")
 	}
 
 	var r0 []*models.Subscription
@@ -57,30 +717,30 @@ func (_m *MockSubscriptionServiceInternal) FetchCanceledExpiredSubscriptionsInte
 	return r0, r1
 }
 
-// MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchCanceledExpiredSubscriptionsInternal'
-type MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call struct {
+// MockSubscriptionServiceInternal_FetchPastDueSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchPastDueSubscriptionsInternal'
+type MockSubscriptionServiceInternal_FetchPastDueSubscriptionsInternal_Call struct {
 	*mock.Call
 }
 
-// FetchCanceledExpiredSubscriptionsInternal is a helper method to define mock.On call
+// FetchPastDueSubscriptionsInternal is a helper method to define mock.On call
 //   - _a0 context.Context
-func (_e *MockSubscriptionServiceInternal_Expecter) FetchCanceledExpiredSubscriptionsInternal(_a0 interface{}) *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call {
-	return &MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call{Call: _e.mock.On("FetchCanceledExpiredSubscriptionsInternal", _a0)}
+func (_e *MockSubscriptionServiceInternal_Expecter) FetchPastDueSubscriptionsInternal(_a0 interface{}) *MockSubscriptionServiceInternal_FetchPastDueSubscriptionsInternal_Call {
+	return &MockSubscriptionServiceInternal_FetchPastDueSubscriptionsInternal_Call{Call: _e.mock.On("FetchPastDueSubscriptionsInternal", _a0)}
 }
 
-func (_c *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call) Run(run func(_a0 context.Context)) *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call {
+func (_c *MockSubscriptionServiceInternal_FetchPastDueSubscriptionsInternal_Call) Run(run func(_a0 context.Context)) *MockSubscriptionServiceInternal_FetchPastDueSubscriptionsInternal_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call {
+func (_c *MockSubscriptionServiceInternal_FetchPastDueSubscriptionsInternal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionServiceInternal_FetchPastDueSubscriptionsInternal_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call) RunAndReturn(run func(context.Context) ([]*models.Subscription, error)) *MockSubscriptionServiceInternal_FetchCanceledExpiredSubscriptionsInternal_Call {
+func (_c *MockSubscriptionServiceInternal_FetchPastDueSubscriptionsInternal_Call) RunAndReturn(run func(context.Context) ([]*models.Subscription, error)) *MockSubscriptionServiceInternal_FetchPastDueSubscriptionsInternal_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -204,6 +864,66 @@ func (_c *MockSubscriptionServiceInternal_FetchSubscriptionsDueForRenewalInterna
 	return _c
 }
 
+// FetchUnusedSubscriptionsInternal provides a mock function with given fields: ctx, unusedAfterDays, noticeDays
+func (_m *MockSubscriptionServiceInternal) FetchUnusedSubscriptionsInternal(ctx context.Context, unusedAfterDays int, noticeDays int) ([]*models.Subscription, error) {
+	ret := _m.Called(ctx, unusedAfterDays, noticeDays)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchUnusedSubscriptionsInternal")
+	}
+
+	var r0 []*models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*models.Subscription, error)); ok {
+		return rf(ctx, unusedAfterDays, noticeDays)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*models.Subscription); ok {
+		r0 = rf(ctx, unusedAfterDays, noticeDays)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, unusedAfterDays, noticeDays)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceInternal_FetchUnusedSubscriptionsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchUnusedSubscriptionsInternal'
+type MockSubscriptionServiceInternal_FetchUnusedSubscriptionsInternal_Call struct {
+	*mock.Call
+}
+
+// FetchUnusedSubscriptionsInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - unusedAfterDays int
+//   - noticeDays int
+func (_e *MockSubscriptionServiceInternal_Expecter) FetchUnusedSubscriptionsInternal(ctx interface{}, unusedAfterDays interface{}, noticeDays interface{}) *MockSubscriptionServiceInternal_FetchUnusedSubscriptionsInternal_Call {
+	return &MockSubscriptionServiceInternal_FetchUnusedSubscriptionsInternal_Call{Call: _e.mock.On("FetchUnusedSubscriptionsInternal", ctx, unusedAfterDays, noticeDays)}
+}
+
+func (_c *MockSubscriptionServiceInternal_FetchUnusedSubscriptionsInternal_Call) Run(run func(ctx context.Context, unusedAfterDays int, noticeDays int)) *MockSubscriptionServiceInternal_FetchUnusedSubscriptionsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_FetchUnusedSubscriptionsInternal_Call) Return(_a0 []*models.Subscription, _a1 error) *MockSubscriptionServiceInternal_FetchUnusedSubscriptionsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_FetchUnusedSubscriptionsInternal_Call) RunAndReturn(run func(context.Context, int, int) ([]*models.Subscription, error)) *MockSubscriptionServiceInternal_FetchUnusedSubscriptionsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FetchUpcomingRenewalsInternal provides a mock function with given fields: _a0, _a1
 func (_m *MockSubscriptionServiceInternal) FetchUpcomingRenewalsInternal(_a0 context.Context, _a1 []int) ([]*models.Subscription, error) {
 	ret := _m.Called(_a0, _a1)
@@ -367,6 +1087,104 @@ func (_c *MockSubscriptionServiceInternal_MarkCanceledSubscriptionAsExpiredInter
 	return _c
 }
 
+// RecordPaymentRetryInternal provides a mock function with given fields: ctx, id, attempt, final, action
+func (_m *MockSubscriptionServiceInternal) RecordPaymentRetryInternal(ctx context.Context, id bson.ObjectID, attempt int, final bool, action models.DunningFinalAction) error {
+	ret := _m.Called(ctx, id, attempt, final, action)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPaymentRetryInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, int, bool, models.DunningFinalAction) error); ok {
+		r0 = rf(ctx, id, attempt, final, action)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionServiceInternal_RecordPaymentRetryInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPaymentRetryInternal'
+type MockSubscriptionServiceInternal_RecordPaymentRetryInternal_Call struct {
+	*mock.Call
+}
+
+// RecordPaymentRetryInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - attempt int
+//   - final bool
+//   - action models.DunningFinalAction
+func (_e *MockSubscriptionServiceInternal_Expecter) RecordPaymentRetryInternal(ctx interface{}, id interface{}, attempt interface{}, final interface{}, action interface{}) *MockSubscriptionServiceInternal_RecordPaymentRetryInternal_Call {
+	return &MockSubscriptionServiceInternal_RecordPaymentRetryInternal_Call{Call: _e.mock.On("RecordPaymentRetryInternal", ctx, id, attempt, final, action)}
+}
+
+func (_c *MockSubscriptionServiceInternal_RecordPaymentRetryInternal_Call) Run(run func(ctx context.Context, id bson.ObjectID, attempt int, final bool, action models.DunningFinalAction)) *MockSubscriptionServiceInternal_RecordPaymentRetryInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(int), args[3].(bool), args[4].(models.DunningFinalAction))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_RecordPaymentRetryInternal_Call) Return(_a0 error) *MockSubscriptionServiceInternal_RecordPaymentRetryInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_RecordPaymentRetryInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID, int, bool, models.DunningFinalAction) error) *MockSubscriptionServiceInternal_RecordPaymentRetryInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordReminderSentInternal provides a mock function with given fields: ctx, subscription, daysBefore
+func (_m *MockSubscriptionServiceInternal) RecordReminderSentInternal(ctx context.Context, subscription *models.Subscription, daysBefore int) error {
+	ret := _m.Called(ctx, subscription, daysBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordReminderSentInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Subscription, int) error); ok {
+		r0 = rf(ctx, subscription, daysBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionServiceInternal_RecordReminderSentInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordReminderSentInternal'
+type MockSubscriptionServiceInternal_RecordReminderSentInternal_Call struct {
+	*mock.Call
+}
+
+// RecordReminderSentInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription *models.Subscription
+//   - daysBefore int
+func (_e *MockSubscriptionServiceInternal_Expecter) RecordReminderSentInternal(ctx interface{}, subscription interface{}, daysBefore interface{}) *MockSubscriptionServiceInternal_RecordReminderSentInternal_Call {
+	return &MockSubscriptionServiceInternal_RecordReminderSentInternal_Call{Call: _e.mock.On("RecordReminderSentInternal", ctx, subscription, daysBefore)}
+}
+
+func (_c *MockSubscriptionServiceInternal_RecordReminderSentInternal_Call) Run(run func(ctx context.Context, subscription *models.Subscription, daysBefore int)) *MockSubscriptionServiceInternal_RecordReminderSentInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Subscription), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_RecordReminderSentInternal_Call) Return(_a0 error) *MockSubscriptionServiceInternal_RecordReminderSentInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_RecordReminderSentInternal_Call) RunAndReturn(run func(context.Context, *models.Subscription, int) error) *MockSubscriptionServiceInternal_RecordReminderSentInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RenewSubscriptionInternal provides a mock function with given fields: _a0, _a1
 func (_m *MockSubscriptionServiceInternal) RenewSubscriptionInternal(_a0 context.Context, _a1 bson.ObjectID) (*models.Subscription, error) {
 	ret := _m.Called(_a0, _a1)
@@ -426,6 +1244,176 @@ func (_c *MockSubscriptionServiceInternal_RenewSubscriptionInternal_Call) RunAnd
 	return _c
 }
 
+// RepairBillsInternal provides a mock function with given fields: _a0
+func (_m *MockSubscriptionServiceInternal) RepairBillsInternal(_a0 context.Context) (int64, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepairBillsInternal")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceInternal_RepairBillsInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RepairBillsInternal'
+type MockSubscriptionServiceInternal_RepairBillsInternal_Call struct {
+	*mock.Call
+}
+
+// RepairBillsInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockSubscriptionServiceInternal_Expecter) RepairBillsInternal(_a0 interface{}) *MockSubscriptionServiceInternal_RepairBillsInternal_Call {
+	return &MockSubscriptionServiceInternal_RepairBillsInternal_Call{Call: _e.mock.On("RepairBillsInternal", _a0)}
+}
+
+func (_c *MockSubscriptionServiceInternal_RepairBillsInternal_Call) Run(run func(_a0 context.Context)) *MockSubscriptionServiceInternal_RepairBillsInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_RepairBillsInternal_Call) Return(_a0 int64, _a1 error) *MockSubscriptionServiceInternal_RepairBillsInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_RepairBillsInternal_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockSubscriptionServiceInternal_RepairBillsInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryPaymentInternal provides a mock function with given fields: ctx, id, attempt
+func (_m *MockSubscriptionServiceInternal) RetryPaymentInternal(ctx context.Context, id bson.ObjectID, attempt int) (bool, error) {
+	ret := _m.Called(ctx, id, attempt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryPaymentInternal")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, int) (bool, error)); ok {
+		return rf(ctx, id, attempt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, int) bool); ok {
+		r0 = rf(ctx, id, attempt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, int) error); ok {
+		r1 = rf(ctx, id, attempt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceInternal_RetryPaymentInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryPaymentInternal'
+type MockSubscriptionServiceInternal_RetryPaymentInternal_Call struct {
+	*mock.Call
+}
+
+// RetryPaymentInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id bson.ObjectID
+//   - attempt int
+func (_e *MockSubscriptionServiceInternal_Expecter) RetryPaymentInternal(ctx interface{}, id interface{}, attempt interface{}) *MockSubscriptionServiceInternal_RetryPaymentInternal_Call {
+	return &MockSubscriptionServiceInternal_RetryPaymentInternal_Call{Call: _e.mock.On("RetryPaymentInternal", ctx, id, attempt)}
+}
+
+func (_c *MockSubscriptionServiceInternal_RetryPaymentInternal_Call) Run(run func(ctx context.Context, id bson.ObjectID, attempt int)) *MockSubscriptionServiceInternal_RetryPaymentInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_RetryPaymentInternal_Call) Return(_a0 bool, _a1 error) *MockSubscriptionServiceInternal_RetryPaymentInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_RetryPaymentInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID, int) (bool, error)) *MockSubscriptionServiceInternal_RetryPaymentInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SettlePendingChargesInternal provides a mock function with given fields: _a0
+func (_m *MockSubscriptionServiceInternal) SettlePendingChargesInternal(_a0 context.Context) (int, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettlePendingChargesInternal")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionServiceInternal_SettlePendingChargesInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SettlePendingChargesInternal'
+type MockSubscriptionServiceInternal_SettlePendingChargesInternal_Call struct {
+	*mock.Call
+}
+
+// SettlePendingChargesInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockSubscriptionServiceInternal_Expecter) SettlePendingChargesInternal(_a0 interface{}) *MockSubscriptionServiceInternal_SettlePendingChargesInternal_Call {
+	return &MockSubscriptionServiceInternal_SettlePendingChargesInternal_Call{Call: _e.mock.On("SettlePendingChargesInternal", _a0)}
+}
+
+func (_c *MockSubscriptionServiceInternal_SettlePendingChargesInternal_Call) Run(run func(_a0 context.Context)) *MockSubscriptionServiceInternal_SettlePendingChargesInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_SettlePendingChargesInternal_Call) Return(_a0 int, _a1 error) *MockSubscriptionServiceInternal_SettlePendingChargesInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionServiceInternal_SettlePendingChargesInternal_Call) RunAndReturn(run func(context.Context) (int, error)) *MockSubscriptionServiceInternal_SettlePendingChargesInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockSubscriptionServiceInternal creates a new instance of MockSubscriptionServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockSubscriptionServiceInternal(t interface {
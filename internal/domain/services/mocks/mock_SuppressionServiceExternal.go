@@ -0,0 +1,202 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSuppressionServiceExternal is an autogenerated mock type for the SuppressionServiceExternal type
+type MockSuppressionServiceExternal struct {
+	mock.Mock
+}
+
+type MockSuppressionServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSuppressionServiceExternal) EXPECT() *MockSuppressionServiceExternal_Expecter {
+	return &MockSuppressionServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// AddSuppression provides a mock function with given fields: ctx, suppression
+func (_m *MockSuppressionServiceExternal) AddSuppression(ctx context.Context, suppression *models.Suppression) (*models.Suppression, error) {
+	ret := _m.Called(ctx, suppression)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSuppression")
+	}
+
+	var r0 *models.Suppression
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Suppression) (*models.Suppression, error)); ok {
+		return rf(ctx, suppression)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Suppression) *models.Suppression); ok {
+		r0 = rf(ctx, suppression)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Suppression)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Suppression) error); ok {
+		r1 = rf(ctx, suppression)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSuppressionServiceExternal_AddSuppression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSuppression'
+type MockSuppressionServiceExternal_AddSuppression_Call struct {
+	*mock.Call
+}
+
+// AddSuppression is a helper method to define mock.On call
+//   - ctx context.Context
+//   - suppression *models.Suppression
+func (_e *MockSuppressionServiceExternal_Expecter) AddSuppression(ctx interface{}, suppression interface{}) *MockSuppressionServiceExternal_AddSuppression_Call {
+	return &MockSuppressionServiceExternal_AddSuppression_Call{Call: _e.mock.On("AddSuppression", ctx, suppression)}
+}
+
+func (_c *MockSuppressionServiceExternal_AddSuppression_Call) Run(run func(ctx context.Context, suppression *models.Suppression)) *MockSuppressionServiceExternal_AddSuppression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Suppression))
+	})
+	return _c
+}
+
+func (_c *MockSuppressionServiceExternal_AddSuppression_Call) Return(_a0 *models.Suppression, _a1 error) *MockSuppressionServiceExternal_AddSuppression_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSuppressionServiceExternal_AddSuppression_Call) RunAndReturn(run func(context.Context, *models.Suppression) (*models.Suppression, error)) *MockSuppressionServiceExternal_AddSuppression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSuppression provides a mock function with given fields: ctx, email
+func (_m *MockSuppressionServiceExternal) DeleteSuppression(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSuppression")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSuppressionServiceExternal_DeleteSuppression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSuppression'
+type MockSuppressionServiceExternal_DeleteSuppression_Call struct {
+	*mock.Call
+}
+
+// DeleteSuppression is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockSuppressionServiceExternal_Expecter) DeleteSuppression(ctx interface{}, email interface{}) *MockSuppressionServiceExternal_DeleteSuppression_Call {
+	return &MockSuppressionServiceExternal_DeleteSuppression_Call{Call: _e.mock.On("DeleteSuppression", ctx, email)}
+}
+
+func (_c *MockSuppressionServiceExternal_DeleteSuppression_Call) Run(run func(ctx context.Context, email string)) *MockSuppressionServiceExternal_DeleteSuppression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSuppressionServiceExternal_DeleteSuppression_Call) Return(_a0 error) *MockSuppressionServiceExternal_DeleteSuppression_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSuppressionServiceExternal_DeleteSuppression_Call) RunAndReturn(run func(context.Context, string) error) *MockSuppressionServiceExternal_DeleteSuppression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSuppressions provides a mock function with given fields: ctx, query
+func (_m *MockSuppressionServiceExternal) GetSuppressions(ctx context.Context, query models.SuppressionQuery) (*models.SuppressionPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuppressions")
+	}
+
+	var r0 *models.SuppressionPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SuppressionQuery) (*models.SuppressionPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.SuppressionQuery) *models.SuppressionPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SuppressionPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.SuppressionQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSuppressionServiceExternal_GetSuppressions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSuppressions'
+type MockSuppressionServiceExternal_GetSuppressions_Call struct {
+	*mock.Call
+}
+
+// GetSuppressions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query models.SuppressionQuery
+func (_e *MockSuppressionServiceExternal_Expecter) GetSuppressions(ctx interface{}, query interface{}) *MockSuppressionServiceExternal_GetSuppressions_Call {
+	return &MockSuppressionServiceExternal_GetSuppressions_Call{Call: _e.mock.On("GetSuppressions", ctx, query)}
+}
+
+func (_c *MockSuppressionServiceExternal_GetSuppressions_Call) Run(run func(ctx context.Context, query models.SuppressionQuery)) *MockSuppressionServiceExternal_GetSuppressions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.SuppressionQuery))
+	})
+	return _c
+}
+
+func (_c *MockSuppressionServiceExternal_GetSuppressions_Call) Return(_a0 *models.SuppressionPage, _a1 error) *MockSuppressionServiceExternal_GetSuppressions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSuppressionServiceExternal_GetSuppressions_Call) RunAndReturn(run func(context.Context, models.SuppressionQuery) (*models.SuppressionPage, error)) *MockSuppressionServiceExternal_GetSuppressions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSuppressionServiceExternal creates a new instance of MockSuppressionServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSuppressionServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSuppressionServiceExternal {
+	mock := &MockSuppressionServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
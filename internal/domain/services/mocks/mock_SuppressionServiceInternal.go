@@ -0,0 +1,143 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSuppressionServiceInternal is an autogenerated mock type for the SuppressionServiceInternal type
+type MockSuppressionServiceInternal struct {
+	mock.Mock
+}
+
+type MockSuppressionServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSuppressionServiceInternal) EXPECT() *MockSuppressionServiceInternal_Expecter {
+	return &MockSuppressionServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// IsSuppressedInternal provides a mock function with given fields: ctx, email
+func (_m *MockSuppressionServiceInternal) IsSuppressedInternal(ctx context.Context, email string) (bool, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsSuppressedInternal")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSuppressionServiceInternal_IsSuppressedInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsSuppressedInternal'
+type MockSuppressionServiceInternal_IsSuppressedInternal_Call struct {
+	*mock.Call
+}
+
+// IsSuppressedInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockSuppressionServiceInternal_Expecter) IsSuppressedInternal(ctx interface{}, email interface{}) *MockSuppressionServiceInternal_IsSuppressedInternal_Call {
+	return &MockSuppressionServiceInternal_IsSuppressedInternal_Call{Call: _e.mock.On("IsSuppressedInternal", ctx, email)}
+}
+
+func (_c *MockSuppressionServiceInternal_IsSuppressedInternal_Call) Run(run func(ctx context.Context, email string)) *MockSuppressionServiceInternal_IsSuppressedInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSuppressionServiceInternal_IsSuppressedInternal_Call) Return(_a0 bool, _a1 error) *MockSuppressionServiceInternal_IsSuppressedInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSuppressionServiceInternal_IsSuppressedInternal_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockSuppressionServiceInternal_IsSuppressedInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SuppressInternal provides a mock function with given fields: ctx, email, reason, detail
+func (_m *MockSuppressionServiceInternal) SuppressInternal(ctx context.Context, email string, reason models.SuppressionReason, detail string) error {
+	ret := _m.Called(ctx, email, reason, detail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuppressInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.SuppressionReason, string) error); ok {
+		r0 = rf(ctx, email, reason, detail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSuppressionServiceInternal_SuppressInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuppressInternal'
+type MockSuppressionServiceInternal_SuppressInternal_Call struct {
+	*mock.Call
+}
+
+// SuppressInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - reason models.SuppressionReason
+//   - detail string
+func (_e *MockSuppressionServiceInternal_Expecter) SuppressInternal(ctx interface{}, email interface{}, reason interface{}, detail interface{}) *MockSuppressionServiceInternal_SuppressInternal_Call {
+	return &MockSuppressionServiceInternal_SuppressInternal_Call{Call: _e.mock.On("SuppressInternal", ctx, email, reason, detail)}
+}
+
+func (_c *MockSuppressionServiceInternal_SuppressInternal_Call) Run(run func(ctx context.Context, email string, reason models.SuppressionReason, detail string)) *MockSuppressionServiceInternal_SuppressInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.SuppressionReason), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockSuppressionServiceInternal_SuppressInternal_Call) Return(_a0 error) *MockSuppressionServiceInternal_SuppressInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSuppressionServiceInternal_SuppressInternal_Call) RunAndReturn(run func(context.Context, string, models.SuppressionReason, string) error) *MockSuppressionServiceInternal_SuppressInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSuppressionServiceInternal creates a new instance of MockSuppressionServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSuppressionServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSuppressionServiceInternal {
+	mock := &MockSuppressionServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
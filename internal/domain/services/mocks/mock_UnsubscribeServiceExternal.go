@@ -0,0 +1,98 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUnsubscribeServiceExternal is an autogenerated mock type for the UnsubscribeServiceExternal type
+type MockUnsubscribeServiceExternal struct {
+	mock.Mock
+}
+
+type MockUnsubscribeServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUnsubscribeServiceExternal) EXPECT() *MockUnsubscribeServiceExternal_Expecter {
+	return &MockUnsubscribeServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// Unsubscribe provides a mock function with given fields: ctx, email, channel, signature
+func (_m *MockUnsubscribeServiceExternal) Unsubscribe(ctx context.Context, email string, channel string, signature string) (*models.NotificationPreferences, error) {
+	ret := _m.Called(ctx, email, channel, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unsubscribe")
+	}
+
+	var r0 *models.NotificationPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.NotificationPreferences, error)); ok {
+		return rf(ctx, email, channel, signature)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.NotificationPreferences); ok {
+		r0 = rf(ctx, email, channel, signature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationPreferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, email, channel, signature)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUnsubscribeServiceExternal_Unsubscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unsubscribe'
+type MockUnsubscribeServiceExternal_Unsubscribe_Call struct {
+	*mock.Call
+}
+
+// Unsubscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - channel string
+//   - signature string
+func (_e *MockUnsubscribeServiceExternal_Expecter) Unsubscribe(ctx interface{}, email interface{}, channel interface{}, signature interface{}) *MockUnsubscribeServiceExternal_Unsubscribe_Call {
+	return &MockUnsubscribeServiceExternal_Unsubscribe_Call{Call: _e.mock.On("Unsubscribe", ctx, email, channel, signature)}
+}
+
+func (_c *MockUnsubscribeServiceExternal_Unsubscribe_Call) Run(run func(ctx context.Context, email string, channel string, signature string)) *MockUnsubscribeServiceExternal_Unsubscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockUnsubscribeServiceExternal_Unsubscribe_Call) Return(_a0 *models.NotificationPreferences, _a1 error) *MockUnsubscribeServiceExternal_Unsubscribe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUnsubscribeServiceExternal_Unsubscribe_Call) RunAndReturn(run func(context.Context, string, string, string) (*models.NotificationPreferences, error)) *MockUnsubscribeServiceExternal_Unsubscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUnsubscribeServiceExternal creates a new instance of MockUnsubscribeServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUnsubscribeServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUnsubscribeServiceExternal {
+	mock := &MockUnsubscribeServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUnsubscribeServiceInternal is an autogenerated mock type for the UnsubscribeServiceInternal type
+type MockUnsubscribeServiceInternal struct {
+	mock.Mock
+}
+
+type MockUnsubscribeServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUnsubscribeServiceInternal) EXPECT() *MockUnsubscribeServiceInternal_Expecter {
+	return &MockUnsubscribeServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// SignUnsubscribeLinkInternal provides a mock function with given fields: email, channel
+func (_m *MockUnsubscribeServiceInternal) SignUnsubscribeLinkInternal(email string, channel models.NotificationChannel) string {
+	ret := _m.Called(email, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignUnsubscribeLinkInternal")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, models.NotificationChannel) string); ok {
+		r0 = rf(email, channel)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockUnsubscribeServiceInternal_SignUnsubscribeLinkInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignUnsubscribeLinkInternal'
+type MockUnsubscribeServiceInternal_SignUnsubscribeLinkInternal_Call struct {
+	*mock.Call
+}
+
+// SignUnsubscribeLinkInternal is a helper method to define mock.On call
+//   - email string
+//   - channel models.NotificationChannel
+func (_e *MockUnsubscribeServiceInternal_Expecter) SignUnsubscribeLinkInternal(email interface{}, channel interface{}) *MockUnsubscribeServiceInternal_SignUnsubscribeLinkInternal_Call {
+	return &MockUnsubscribeServiceInternal_SignUnsubscribeLinkInternal_Call{Call: _e.mock.On("SignUnsubscribeLinkInternal", email, channel)}
+}
+
+func (_c *MockUnsubscribeServiceInternal_SignUnsubscribeLinkInternal_Call) Run(run func(email string, channel models.NotificationChannel)) *MockUnsubscribeServiceInternal_SignUnsubscribeLinkInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(models.NotificationChannel))
+	})
+	return _c
+}
+
+func (_c *MockUnsubscribeServiceInternal_SignUnsubscribeLinkInternal_Call) Return(_a0 string) *MockUnsubscribeServiceInternal_SignUnsubscribeLinkInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUnsubscribeServiceInternal_SignUnsubscribeLinkInternal_Call) RunAndReturn(run func(string, models.NotificationChannel) string) *MockUnsubscribeServiceInternal_SignUnsubscribeLinkInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUnsubscribeServiceInternal creates a new instance of MockUnsubscribeServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUnsubscribeServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUnsubscribeServiceInternal {
+	mock := &MockUnsubscribeServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
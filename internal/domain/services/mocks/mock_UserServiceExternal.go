@@ -82,21 +82,33 @@ func (_c *MockUserServiceExternal_CreateUser_Call) RunAndReturn(run func(context
 }
 
 // DeleteUser provides a mock function with given fields: _a0, _a1, _a2
-func (_m *MockUserServiceExternal) DeleteUser(_a0 context.Context, _a1 string, _a2 string) error {
+func (_m *MockUserServiceExternal) DeleteUser(_a0 context.Context, _a1 string, _a2 string) (*models.User, error) {
 	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteUser")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.User, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.User); ok {
 		r0 = rf(_a0, _a1, _a2)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MockUserServiceExternal_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
@@ -119,12 +131,12 @@ func (_c *MockUserServiceExternal_DeleteUser_Call) Run(run func(_a0 context.Cont
 	return _c
 }
 
-func (_c *MockUserServiceExternal_DeleteUser_Call) Return(_a0 error) *MockUserServiceExternal_DeleteUser_Call {
-	_c.Call.Return(_a0)
+func (_c *MockUserServiceExternal_DeleteUser_Call) Return(_a0 *models.User, _a1 error) *MockUserServiceExternal_DeleteUser_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockUserServiceExternal_DeleteUser_Call) RunAndReturn(run func(context.Context, string, string) error) *MockUserServiceExternal_DeleteUser_Call {
+func (_c *MockUserServiceExternal_DeleteUser_Call) RunAndReturn(run func(context.Context, string, string) (*models.User, error)) *MockUserServiceExternal_DeleteUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -143,6 +143,53 @@ func (_c *MockUserServiceInternal_FetchUserByIDInternal_Call) RunAndReturn(run f
 	return _c
 }
 
+// PurgeUserInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockUserServiceInternal) PurgeUserInternal(_a0 context.Context, _a1 bson.ObjectID) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeUserInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserServiceInternal_PurgeUserInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeUserInternal'
+type MockUserServiceInternal_PurgeUserInternal_Call struct {
+	*mock.Call
+}
+
+// PurgeUserInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockUserServiceInternal_Expecter) PurgeUserInternal(_a0 interface{}, _a1 interface{}) *MockUserServiceInternal_PurgeUserInternal_Call {
+	return &MockUserServiceInternal_PurgeUserInternal_Call{Call: _e.mock.On("PurgeUserInternal", _a0, _a1)}
+}
+
+func (_c *MockUserServiceInternal_PurgeUserInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockUserServiceInternal_PurgeUserInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInternal_PurgeUserInternal_Call) Return(_a0 error) *MockUserServiceInternal_PurgeUserInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserServiceInternal_PurgeUserInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) error) *MockUserServiceInternal_PurgeUserInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUserServiceInternal creates a new instance of MockUserServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserServiceInternal(t interface {
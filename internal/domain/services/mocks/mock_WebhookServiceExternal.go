@@ -0,0 +1,265 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookServiceExternal is an autogenerated mock type for the WebhookServiceExternal type
+type MockWebhookServiceExternal struct {
+	mock.Mock
+}
+
+type MockWebhookServiceExternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookServiceExternal) EXPECT() *MockWebhookServiceExternal_Expecter {
+	return &MockWebhookServiceExternal_Expecter{mock: &_m.Mock}
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, webhook, claimedUserID
+func (_m *MockWebhookServiceExternal) CreateWebhook(ctx context.Context, webhook *models.Webhook, claimedUserID string) (*models.Webhook, error) {
+	ret := _m.Called(ctx, webhook, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook, string) (*models.Webhook, error)); ok {
+		return rf(ctx, webhook, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook, string) *models.Webhook); ok {
+		r0 = rf(ctx, webhook, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Webhook, string) error); ok {
+		r1 = rf(ctx, webhook, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookServiceExternal_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type MockWebhookServiceExternal_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhook *models.Webhook
+//   - claimedUserID string
+func (_e *MockWebhookServiceExternal_Expecter) CreateWebhook(ctx interface{}, webhook interface{}, claimedUserID interface{}) *MockWebhookServiceExternal_CreateWebhook_Call {
+	return &MockWebhookServiceExternal_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, webhook, claimedUserID)}
+}
+
+func (_c *MockWebhookServiceExternal_CreateWebhook_Call) Run(run func(ctx context.Context, webhook *models.Webhook, claimedUserID string)) *MockWebhookServiceExternal_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Webhook), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookServiceExternal_CreateWebhook_Call) Return(_a0 *models.Webhook, _a1 error) *MockWebhookServiceExternal_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookServiceExternal_CreateWebhook_Call) RunAndReturn(run func(context.Context, *models.Webhook, string) (*models.Webhook, error)) *MockWebhookServiceExternal_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id, claimedUserID
+func (_m *MockWebhookServiceExternal) DeleteWebhook(ctx context.Context, id string, claimedUserID string) error {
+	ret := _m.Called(ctx, id, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, claimedUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookServiceExternal_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type MockWebhookServiceExternal_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+func (_e *MockWebhookServiceExternal_Expecter) DeleteWebhook(ctx interface{}, id interface{}, claimedUserID interface{}) *MockWebhookServiceExternal_DeleteWebhook_Call {
+	return &MockWebhookServiceExternal_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, id, claimedUserID)}
+}
+
+func (_c *MockWebhookServiceExternal_DeleteWebhook_Call) Run(run func(ctx context.Context, id string, claimedUserID string)) *MockWebhookServiceExternal_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookServiceExternal_DeleteWebhook_Call) Return(_a0 error) *MockWebhookServiceExternal_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookServiceExternal_DeleteWebhook_Call) RunAndReturn(run func(context.Context, string, string) error) *MockWebhookServiceExternal_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookDeliveries provides a mock function with given fields: ctx, id, claimedUserID, eventType
+func (_m *MockWebhookServiceExternal) GetWebhookDeliveries(ctx context.Context, id string, claimedUserID string, eventType models.EventType) ([]*models.WebhookDelivery, error) {
+	ret := _m.Called(ctx, id, claimedUserID, eventType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDeliveries")
+	}
+
+	var r0 []*models.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.EventType) ([]*models.WebhookDelivery, error)); ok {
+		return rf(ctx, id, claimedUserID, eventType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.EventType) []*models.WebhookDelivery); ok {
+		r0 = rf(ctx, id, claimedUserID, eventType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.EventType) error); ok {
+		r1 = rf(ctx, id, claimedUserID, eventType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookServiceExternal_GetWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDeliveries'
+type MockWebhookServiceExternal_GetWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - claimedUserID string
+//   - eventType models.EventType
+func (_e *MockWebhookServiceExternal_Expecter) GetWebhookDeliveries(ctx interface{}, id interface{}, claimedUserID interface{}, eventType interface{}) *MockWebhookServiceExternal_GetWebhookDeliveries_Call {
+	return &MockWebhookServiceExternal_GetWebhookDeliveries_Call{Call: _e.mock.On("GetWebhookDeliveries", ctx, id, claimedUserID, eventType)}
+}
+
+func (_c *MockWebhookServiceExternal_GetWebhookDeliveries_Call) Run(run func(ctx context.Context, id string, claimedUserID string, eventType models.EventType)) *MockWebhookServiceExternal_GetWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.EventType))
+	})
+	return _c
+}
+
+func (_c *MockWebhookServiceExternal_GetWebhookDeliveries_Call) Return(_a0 []*models.WebhookDelivery, _a1 error) *MockWebhookServiceExternal_GetWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookServiceExternal_GetWebhookDeliveries_Call) RunAndReturn(run func(context.Context, string, string, models.EventType) ([]*models.WebhookDelivery, error)) *MockWebhookServiceExternal_GetWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhooks provides a mock function with given fields: ctx, claimedUserID
+func (_m *MockWebhookServiceExternal) GetWebhooks(ctx context.Context, claimedUserID string) ([]*models.Webhook, error) {
+	ret := _m.Called(ctx, claimedUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhooks")
+	}
+
+	var r0 []*models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Webhook, error)); ok {
+		return rf(ctx, claimedUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Webhook); ok {
+		r0 = rf(ctx, claimedUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, claimedUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookServiceExternal_GetWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhooks'
+type MockWebhookServiceExternal_GetWebhooks_Call struct {
+	*mock.Call
+}
+
+// GetWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claimedUserID string
+func (_e *MockWebhookServiceExternal_Expecter) GetWebhooks(ctx interface{}, claimedUserID interface{}) *MockWebhookServiceExternal_GetWebhooks_Call {
+	return &MockWebhookServiceExternal_GetWebhooks_Call{Call: _e.mock.On("GetWebhooks", ctx, claimedUserID)}
+}
+
+func (_c *MockWebhookServiceExternal_GetWebhooks_Call) Run(run func(ctx context.Context, claimedUserID string)) *MockWebhookServiceExternal_GetWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookServiceExternal_GetWebhooks_Call) Return(_a0 []*models.Webhook, _a1 error) *MockWebhookServiceExternal_GetWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookServiceExternal_GetWebhooks_Call) RunAndReturn(run func(context.Context, string) ([]*models.Webhook, error)) *MockWebhookServiceExternal_GetWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookServiceExternal creates a new instance of MockWebhookServiceExternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookServiceExternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookServiceExternal {
+	mock := &MockWebhookServiceExternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
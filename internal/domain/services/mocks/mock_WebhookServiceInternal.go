@@ -0,0 +1,263 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bson "go.mongodb.org/mongo-driver/v2/bson"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// MockWebhookServiceInternal is an autogenerated mock type for the WebhookServiceInternal type
+type MockWebhookServiceInternal struct {
+	mock.Mock
+}
+
+type MockWebhookServiceInternal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookServiceInternal) EXPECT() *MockWebhookServiceInternal_Expecter {
+	return &MockWebhookServiceInternal_Expecter{mock: &_m.Mock}
+}
+
+// DeleteWebhooksInternal provides a mock function with given fields: ctx, userID
+func (_m *MockWebhookServiceInternal) DeleteWebhooksInternal(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhooksInternal")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookServiceInternal_DeleteWebhooksInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhooksInternal'
+type MockWebhookServiceInternal_DeleteWebhooksInternal_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhooksInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+func (_e *MockWebhookServiceInternal_Expecter) DeleteWebhooksInternal(ctx interface{}, userID interface{}) *MockWebhookServiceInternal_DeleteWebhooksInternal_Call {
+	return &MockWebhookServiceInternal_DeleteWebhooksInternal_Call{Call: _e.mock.On("DeleteWebhooksInternal", ctx, userID)}
+}
+
+func (_c *MockWebhookServiceInternal_DeleteWebhooksInternal_Call) Run(run func(ctx context.Context, userID bson.ObjectID)) *MockWebhookServiceInternal_DeleteWebhooksInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookServiceInternal_DeleteWebhooksInternal_Call) Return(_a0 int64, _a1 error) *MockWebhookServiceInternal_DeleteWebhooksInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookServiceInternal_DeleteWebhooksInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (int64, error)) *MockWebhookServiceInternal_DeleteWebhooksInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchWebhookByIDInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockWebhookServiceInternal) FetchWebhookByIDInternal(_a0 context.Context, _a1 bson.ObjectID) (*models.Webhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchWebhookByIDInternal")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) (*models.Webhook, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID) *models.Webhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookServiceInternal_FetchWebhookByIDInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchWebhookByIDInternal'
+type MockWebhookServiceInternal_FetchWebhookByIDInternal_Call struct {
+	*mock.Call
+}
+
+// FetchWebhookByIDInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bson.ObjectID
+func (_e *MockWebhookServiceInternal_Expecter) FetchWebhookByIDInternal(_a0 interface{}, _a1 interface{}) *MockWebhookServiceInternal_FetchWebhookByIDInternal_Call {
+	return &MockWebhookServiceInternal_FetchWebhookByIDInternal_Call{Call: _e.mock.On("FetchWebhookByIDInternal", _a0, _a1)}
+}
+
+func (_c *MockWebhookServiceInternal_FetchWebhookByIDInternal_Call) Run(run func(_a0 context.Context, _a1 bson.ObjectID)) *MockWebhookServiceInternal_FetchWebhookByIDInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookServiceInternal_FetchWebhookByIDInternal_Call) Return(_a0 *models.Webhook, _a1 error) *MockWebhookServiceInternal_FetchWebhookByIDInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookServiceInternal_FetchWebhookByIDInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID) (*models.Webhook, error)) *MockWebhookServiceInternal_FetchWebhookByIDInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchWebhooksForEventInternal provides a mock function with given fields: ctx, userID, eventType
+func (_m *MockWebhookServiceInternal) FetchWebhooksForEventInternal(ctx context.Context, userID bson.ObjectID, eventType models.EventType) ([]*models.Webhook, error) {
+	ret := _m.Called(ctx, userID, eventType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchWebhooksForEventInternal")
+	}
+
+	var r0 []*models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.EventType) ([]*models.Webhook, error)); ok {
+		return rf(ctx, userID, eventType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.ObjectID, models.EventType) []*models.Webhook); ok {
+		r0 = rf(ctx, userID, eventType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.ObjectID, models.EventType) error); ok {
+		r1 = rf(ctx, userID, eventType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookServiceInternal_FetchWebhooksForEventInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchWebhooksForEventInternal'
+type MockWebhookServiceInternal_FetchWebhooksForEventInternal_Call struct {
+	*mock.Call
+}
+
+// FetchWebhooksForEventInternal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID bson.ObjectID
+//   - eventType models.EventType
+func (_e *MockWebhookServiceInternal_Expecter) FetchWebhooksForEventInternal(ctx interface{}, userID interface{}, eventType interface{}) *MockWebhookServiceInternal_FetchWebhooksForEventInternal_Call {
+	return &MockWebhookServiceInternal_FetchWebhooksForEventInternal_Call{Call: _e.mock.On("FetchWebhooksForEventInternal", ctx, userID, eventType)}
+}
+
+func (_c *MockWebhookServiceInternal_FetchWebhooksForEventInternal_Call) Run(run func(ctx context.Context, userID bson.ObjectID, eventType models.EventType)) *MockWebhookServiceInternal_FetchWebhooksForEventInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bson.ObjectID), args[2].(models.EventType))
+	})
+	return _c
+}
+
+func (_c *MockWebhookServiceInternal_FetchWebhooksForEventInternal_Call) Return(_a0 []*models.Webhook, _a1 error) *MockWebhookServiceInternal_FetchWebhooksForEventInternal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookServiceInternal_FetchWebhooksForEventInternal_Call) RunAndReturn(run func(context.Context, bson.ObjectID, models.EventType) ([]*models.Webhook, error)) *MockWebhookServiceInternal_FetchWebhooksForEventInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordDeliveryInternal provides a mock function with given fields: _a0, _a1
+func (_m *MockWebhookServiceInternal) RecordDeliveryInternal(_a0 context.Context, _a1 *models.WebhookDelivery) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDeliveryInternal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookServiceInternal_RecordDeliveryInternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordDeliveryInternal'
+type MockWebhookServiceInternal_RecordDeliveryInternal_Call struct {
+	*mock.Call
+}
+
+// RecordDeliveryInternal is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.WebhookDelivery
+func (_e *MockWebhookServiceInternal_Expecter) RecordDeliveryInternal(_a0 interface{}, _a1 interface{}) *MockWebhookServiceInternal_RecordDeliveryInternal_Call {
+	return &MockWebhookServiceInternal_RecordDeliveryInternal_Call{Call: _e.mock.On("RecordDeliveryInternal", _a0, _a1)}
+}
+
+func (_c *MockWebhookServiceInternal_RecordDeliveryInternal_Call) Run(run func(_a0 context.Context, _a1 *models.WebhookDelivery)) *MockWebhookServiceInternal_RecordDeliveryInternal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *MockWebhookServiceInternal_RecordDeliveryInternal_Call) Return(_a0 error) *MockWebhookServiceInternal_RecordDeliveryInternal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookServiceInternal_RecordDeliveryInternal_Call) RunAndReturn(run func(context.Context, *models.WebhookDelivery) error) *MockWebhookServiceInternal_RecordDeliveryInternal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookServiceInternal creates a new instance of MockWebhookServiceInternal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookServiceInternal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookServiceInternal {
+	mock := &MockWebhookServiceInternal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
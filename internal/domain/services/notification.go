@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type NotificationServiceExternal interface {
+	// GetNotifications returns a page of the notifications sent to the
+	// user, newest first. Administrators may view those of any user.
+	GetNotifications(
+		ctx context.Context,
+		id string,
+		claimedUserID string,
+		query models.NotificationQuery,
+	) (*models.NotificationPage, error)
+}
+
+type NotificationServiceInternal interface {
+	// RecordNotificationInternal adds a delivery attempt to the user's
+	// notification history, stamped with the current time.
+	RecordNotificationInternal(ctx context.Context, notification *models.Notification) error
+	// DeleteNotificationsInternal deletes the notification history of the
+	// user and returns how many notifications were deleted.
+	DeleteNotificationsInternal(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+type NotificationService interface {
+	NotificationServiceExternal
+	NotificationServiceInternal
+}
+
+type notificationService struct {
+	notificationRepository repositories.NotificationRepository
+	getTime                clock.NowFn
+}
+
+// NewNotificationService creates a new instance of NotificationService.
+func NewNotificationService(
+	notificationRepository repositories.NotificationRepository,
+	nowFn clock.NowFn,
+) NotificationService {
+	return &notificationService{
+		notificationRepository,
+		nowFn,
+	}
+}
+
+func (s *notificationService) GetNotifications(
+	ctx context.Context,
+	id string,
+	claimedUserID string,
+	query models.NotificationQuery,
+) (*models.NotificationPage, error) {
+	if role, _ := appctx.GetUserRole(ctx); id != claimedUserID && role != string(models.AdminRole) {
+		return nil, apperror.NewForbiddenError("You can only view your own notifications")
+	}
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+	userID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid user ID")
+	}
+
+	notifications, total, err := s.notificationRepository.ListByUserID(ctx, userID, &query)
+	if err != nil {
+		return nil, err
+	}
+	return &models.NotificationPage{Items: notifications, Total: total}, nil
+}
+
+func (s *notificationService) RecordNotificationInternal(ctx context.Context, notification *models.Notification) error {
+	notification.ID = bson.NewObjectID()
+	notification.SentAt = s.getTime()
+	return s.notificationRepository.Create(ctx, notification)
+}
+
+func (s *notificationService) DeleteNotificationsInternal(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	return s.notificationRepository.DeleteByUserID(ctx, userID)
+}
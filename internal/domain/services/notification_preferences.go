@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type NotificationPreferenceServiceExternal interface {
+	// GetPreferences returns the preferences of the user, or the defaults if
+	// they have not saved any.
+	GetPreferences(ctx context.Context, id string, claimedUserID string) (*models.NotificationPreferences, error)
+	// UpdatePreferences replaces every preference of the user.
+	UpdatePreferences(
+		ctx context.Context,
+		id string,
+		prefs *models.NotificationPreferences,
+		claimedUserID string,
+	) (*models.NotificationPreferences, error)
+}
+
+type NotificationPreferenceServiceInternal interface {
+	// FetchPreferencesInternal returns the preferences of the user, or the
+	// defaults if they have not saved any.
+	FetchPreferencesInternal(ctx context.Context, userID bson.ObjectID) (*models.NotificationPreferences, error)
+	// FetchPreferencesByUserIDsInternal returns the preferences of every given
+	// user, keyed by user ID, with defaults for those who have not saved any.
+	FetchPreferencesByUserIDsInternal(
+		ctx context.Context,
+		userIDs []bson.ObjectID,
+	) (map[bson.ObjectID]*models.NotificationPreferences, error)
+	// FetchReminderDaysInternal returns every day that some user overrode
+	// their reminder days with.
+	FetchReminderDaysInternal(ctx context.Context) ([]int, error)
+	// DisableChannelInternal stops notifications to the user through
+	// channel, keeping their other preferences.
+	DisableChannelInternal(
+		ctx context.Context,
+		userID bson.ObjectID,
+		channel models.NotificationChannel,
+	) (*models.NotificationPreferences, error)
+	// DeletePreferencesInternal deletes the saved preferences of the user,
+	// if any.
+	DeletePreferencesInternal(ctx context.Context, userID bson.ObjectID) error
+}
+
+type NotificationPreferenceService interface {
+	NotificationPreferenceServiceExternal
+	NotificationPreferenceServiceInternal
+}
+
+type notificationPreferenceService struct {
+	preferenceRepository repositories.NotificationPreferenceRepository
+	channels             []models.NotificationChannel
+	getTime              clock.NowFn
+}
+
+// NewNotificationPreferenceService creates a new instance of
+// NotificationPreferenceService. Users can only enable the given channels,
+// those the server is configured to deliver through.
+func NewNotificationPreferenceService(
+	preferenceRepository repositories.NotificationPreferenceRepository,
+	channels []models.NotificationChannel,
+	nowFn clock.NowFn,
+) NotificationPreferenceService {
+	return &notificationPreferenceService{
+		preferenceRepository,
+		channels,
+		nowFn,
+	}
+}
+
+func (s *notificationPreferenceService) GetPreferences(
+	ctx context.Context,
+	id string,
+	claimedUserID string,
+) (*models.NotificationPreferences, error) {
+	if id != claimedUserID {
+		return nil, apperror.NewForbiddenError("You can only view your own preferences")
+	}
+	userID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	return s.FetchPreferencesInternal(ctx, userID)
+}
+
+func (s *notificationPreferenceService) UpdatePreferences(
+	ctx context.Context,
+	id string,
+	prefs *models.NotificationPreferences,
+	claimedUserID string,
+) (*models.NotificationPreferences, error) {
+	if id != claimedUserID {
+		return nil, apperror.NewForbiddenError("You can only update your own preferences")
+	}
+	userID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	prefs.UserID = userID
+
+	if err = prefs.Validate(); err != nil {
+		return nil, err
+	}
+	for _, channel := range prefs.Channels {
+		if !slices.Contains(s.channels, channel) {
+			return nil, apperror.NewValidationError("channel %s is not available", channel)
+		}
+	}
+
+	prefs.UpdatedAt = s.getTime()
+	if err = s.preferenceRepository.Upsert(ctx, prefs); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Notification preferences saved")
+	return prefs, nil
+}
+
+func (s *notificationPreferenceService) FetchPreferencesInternal(
+	ctx context.Context,
+	userID bson.ObjectID,
+) (*models.NotificationPreferences, error) {
+	prefs, err := s.preferenceRepository.GetByUserID(ctx, userID)
+	if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+		return models.DefaultNotificationPreferences(userID), nil
+	}
+	return prefs, err
+}
+
+func (s *notificationPreferenceService) FetchPreferencesByUserIDsInternal(
+	ctx context.Context,
+	userIDs []bson.ObjectID,
+) (map[bson.ObjectID]*models.NotificationPreferences, error) {
+	stored, err := s.preferenceRepository.GetByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := make(map[bson.ObjectID]*models.NotificationPreferences, len(userIDs))
+	for _, p := range stored {
+		prefs[p.UserID] = p
+	}
+	for _, userID := range userIDs {
+		if _, ok := prefs[userID]; !ok {
+			prefs[userID] = models.DefaultNotificationPreferences(userID)
+		}
+	}
+	return prefs, nil
+}
+
+func (s *notificationPreferenceService) FetchReminderDaysInternal(ctx context.Context) ([]int, error) {
+	return s.preferenceRepository.DistinctReminderDays(ctx)
+}
+
+// DisableChannelInternal saves nothing if the channel is already off, so
+// following an unsubscribe link twice changes nothing.
+func (s *notificationPreferenceService) DisableChannelInternal(
+	ctx context.Context,
+	userID bson.ObjectID,
+	channel models.NotificationChannel,
+) (*models.NotificationPreferences, error) {
+	prefs, err := s.FetchPreferencesInternal(ctx, userID)
+	if err != nil || !prefs.ChannelEnabled(channel) {
+		return prefs, err
+	}
+
+	prefs.Channels = slices.DeleteFunc(slices.Clone(prefs.Channels), func(c models.NotificationChannel) bool {
+		return c == channel
+	})
+	prefs.UpdatedAt = s.getTime()
+	if err = s.preferenceRepository.Upsert(ctx, prefs); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Notification channel disabled",
+		logattr.Channel(string(channel)),
+	)
+	return prefs, nil
+}
+
+func (s *notificationPreferenceService) DeletePreferencesInternal(ctx context.Context, userID bson.ObjectID) error {
+	_, err := s.preferenceRepository.DeleteByUserID(ctx, userID)
+	return err
+}
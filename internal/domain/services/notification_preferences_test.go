@@ -0,0 +1,191 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func setupNotificationPreferences(t *testing.T) (
+	services.NotificationPreferenceService,
+	*repomocks.MockNotificationPreferenceRepository,
+) {
+	t.Helper()
+
+	repo := repomocks.NewMockNotificationPreferenceRepository(t)
+	svc := services.NewNotificationPreferenceService(
+		repo,
+		[]models.NotificationChannel{models.ChannelEmail, models.ChannelSlack},
+		func() time.Time { return mockTime },
+	)
+	return svc, repo
+}
+
+// ---------------------------------------------------------------------------
+// GetPreferences
+// ---------------------------------------------------------------------------
+
+func TestNotificationPreferenceService_GetPreferences(t *testing.T) {
+	t.Run("success - defaults when none are saved", func(t *testing.T) {
+		svc, repo := setupNotificationPreferences(t)
+		repo.EXPECT().GetByUserID(mock.Anything, defaultUserID).
+			Return(nil, apperror.NewNotFoundError("document not found")).Once()
+
+		prefs, err := svc.GetPreferences(t.Context(), defaultUserHex, defaultUserHex)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.DefaultNotificationPreferences(defaultUserID), prefs)
+	})
+
+	t.Run("error - another user", func(t *testing.T) {
+		svc, _ := setupNotificationPreferences(t)
+
+		_, err := svc.GetPreferences(t.Context(), bson.NewObjectID().Hex(), defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+
+	t.Run("error - propagates repository error", func(t *testing.T) {
+		svc, repo := setupNotificationPreferences(t)
+		repo.EXPECT().GetByUserID(mock.Anything, defaultUserID).
+			Return(nil, apperror.NewDBError(assert.AnError)).Once()
+
+		_, err := svc.GetPreferences(t.Context(), defaultUserHex, defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrDB)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// UpdatePreferences
+// ---------------------------------------------------------------------------
+
+func TestNotificationPreferenceService_UpdatePreferences(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, repo := setupNotificationPreferences(t)
+		repo.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(p *models.NotificationPreferences) bool {
+			return p.UserID == defaultUserID && p.UpdatedAt.Equal(mockTime)
+		})).Return(nil).Once()
+
+		prefs, err := svc.UpdatePreferences(t.Context(), defaultUserHex, &models.NotificationPreferences{
+			Channels:     []models.NotificationChannel{models.ChannelEmail},
+			ReminderDays: []int{2},
+		}, defaultUserHex)
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, prefs.ReminderDays)
+	})
+
+	t.Run("error - another user", func(t *testing.T) {
+		svc, _ := setupNotificationPreferences(t)
+
+		_, err := svc.UpdatePreferences(t.Context(), bson.NewObjectID().Hex(), &models.NotificationPreferences{}, defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+
+	t.Run("error - channel not configured on the server", func(t *testing.T) {
+		svc, _ := setupNotificationPreferences(t)
+
+		_, err := svc.UpdatePreferences(t.Context(), defaultUserHex, &models.NotificationPreferences{
+			Channels: []models.NotificationChannel{models.ChannelSMS},
+			Phone:    "+14155550123",
+		}, defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+
+	t.Run("error - invalid preferences are not saved", func(t *testing.T) {
+		svc, _ := setupNotificationPreferences(t)
+
+		_, err := svc.UpdatePreferences(t.Context(), defaultUserHex, &models.NotificationPreferences{
+			ReminderDays: []int{-1},
+		}, defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// FetchPreferencesByUserIDsInternal
+// ---------------------------------------------------------------------------
+
+func TestNotificationPreferenceService_FetchPreferencesByUserIDsInternal(t *testing.T) {
+	svc, repo := setupNotificationPreferences(t)
+	other := bson.NewObjectID()
+	saved := &models.NotificationPreferences{UserID: other, ReminderDays: []int{14}}
+	repo.EXPECT().GetByUserIDs(mock.Anything, []bson.ObjectID{defaultUserID, other}).
+		Return([]*models.NotificationPreferences{saved}, nil).Once()
+
+	prefs, err := svc.FetchPreferencesByUserIDsInternal(t.Context(), []bson.ObjectID{defaultUserID, other})
+
+	require.NoError(t, err)
+	assert.Same(t, saved, prefs[other])
+	assert.Equal(t, models.DefaultNotificationPreferences(defaultUserID), prefs[defaultUserID])
+}
+
+// ---------------------------------------------------------------------------
+// DisableChannelInternal
+// ---------------------------------------------------------------------------
+
+func TestNotificationPreferenceService_DisableChannelInternal(t *testing.T) {
+	t.Run("success - keeps the other preferences", func(t *testing.T) {
+		svc, repo := setupNotificationPreferences(t)
+		saved := &models.NotificationPreferences{
+			UserID:          defaultUserID,
+			Channels:        []models.NotificationChannel{models.ChannelEmail, models.ChannelSlack},
+			ReminderDays:    []int{3},
+			SlackWebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX",
+		}
+		repo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return(saved, nil).Once()
+		repo.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(p *models.NotificationPreferences) bool {
+			return assert.ObjectsAreEqual([]models.NotificationChannel{models.ChannelSlack}, p.Channels) &&
+				assert.ObjectsAreEqual([]int{3}, p.ReminderDays) &&
+				p.UpdatedAt.Equal(mockTime)
+		})).Return(nil).Once()
+
+		prefs, err := svc.DisableChannelInternal(t.Context(), defaultUserID, models.ChannelEmail)
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.NotificationChannel{models.ChannelSlack}, prefs.Channels)
+	})
+
+	t.Run("success - channel already off saves nothing", func(t *testing.T) {
+		svc, repo := setupNotificationPreferences(t)
+		repo.EXPECT().GetByUserID(mock.Anything, defaultUserID).
+			Return(&models.NotificationPreferences{UserID: defaultUserID}, nil).Once()
+
+		prefs, err := svc.DisableChannelInternal(t.Context(), defaultUserID, models.ChannelEmail)
+
+		require.NoError(t, err)
+		assert.Empty(t, prefs.Channels)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// DeletePreferencesInternal
+// ---------------------------------------------------------------------------
+
+func TestNotificationPreferenceService_DeletePreferencesInternal(t *testing.T) {
+	t.Run("deletes the saved preferences", func(t *testing.T) {
+		svc, repo := setupNotificationPreferences(t)
+		repo.EXPECT().DeleteByUserID(mock.Anything, defaultUserID).Return(int64(1), nil).Once()
+
+		require.NoError(t, svc.DeletePreferencesInternal(t.Context(), defaultUserID))
+	})
+
+	t.Run("succeeds when none were saved", func(t *testing.T) {
+		svc, repo := setupNotificationPreferences(t)
+		repo.EXPECT().DeleteByUserID(mock.Anything, defaultUserID).Return(int64(0), nil).Once()
+
+		require.NoError(t, svc.DeletePreferencesInternal(t.Context(), defaultUserID))
+	})
+}
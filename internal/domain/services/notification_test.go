@@ -0,0 +1,134 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newNotificationService(repo *repomocks.MockNotificationRepository) services.NotificationService {
+	return services.NewNotificationService(repo, func() time.Time { return mockTime })
+}
+
+// ---------------------------------------------------------------------------
+// GetNotifications
+// ---------------------------------------------------------------------------
+
+func Test_notificationService_GetNotifications(t *testing.T) {
+	otherHex := bson.NewObjectID().Hex()
+	adminCtx := appctx.WithUserRole(context.Background(), string(models.AdminRole))
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		id          string
+		query       models.NotificationQuery
+		setupMocks  func(repo *repomocks.MockNotificationRepository)
+		wantErrCode apperror.ErrorCode
+		wantTotal   int64
+	}{
+		{
+			name: "success - own history with the default page size",
+			ctx:  context.Background(),
+			id:   defaultUserHex,
+			setupMocks: func(repo *repomocks.MockNotificationRepository) {
+				repo.EXPECT().
+					ListByUserID(mock.Anything, defaultUserID, &models.NotificationQuery{PageQuery: models.PageQuery{Limit: models.DefaultPageLimit}}).
+					Return([]*models.Notification{{ID: bson.NewObjectID()}}, 7, nil).
+					Once()
+			},
+			wantTotal: 7,
+		},
+		{
+			name:  "success - administrators view any user's history",
+			ctx:   adminCtx,
+			id:    otherHex,
+			query: models.NotificationQuery{PageQuery: models.PageQuery{Limit: 5, Offset: 5}},
+			setupMocks: func(repo *repomocks.MockNotificationRepository) {
+				repo.EXPECT().
+					ListByUserID(mock.Anything, mock.Anything, &models.NotificationQuery{PageQuery: models.PageQuery{Limit: 5, Offset: 5}}).
+					Return(nil, 5, nil).
+					Once()
+			},
+			wantTotal: 5,
+		},
+		{
+			name:        "error - another user's history",
+			ctx:         context.Background(),
+			id:          otherHex,
+			setupMocks:  func(repo *repomocks.MockNotificationRepository) {},
+			wantErrCode: apperror.ErrForbidden,
+		},
+		{
+			name:        "error - page too large",
+			ctx:         context.Background(),
+			id:          defaultUserHex,
+			query:       models.NotificationQuery{PageQuery: models.PageQuery{Limit: models.MaxPageLimit + 1}},
+			setupMocks:  func(repo *repomocks.MockNotificationRepository) {},
+			wantErrCode: apperror.ErrValidation,
+		},
+		{
+			name:        "error - invalid user ID",
+			ctx:         adminCtx,
+			id:          "not-an-id",
+			setupMocks:  func(repo *repomocks.MockNotificationRepository) {},
+			wantErrCode: apperror.ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repomocks.NewMockNotificationRepository(t)
+			tt.setupMocks(repo)
+
+			page, err := newNotificationService(repo).GetNotifications(tt.ctx, tt.id, defaultUserHex, tt.query)
+
+			if tt.wantErrCode != "" {
+				appErr, ok := errors.AsType[apperror.AppError](err)
+				require.True(t, ok, "expected an AppError, got %v", err)
+				assert.Equal(t, tt.wantErrCode, appErr.Code())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, page.Total)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// RecordNotificationInternal
+// ---------------------------------------------------------------------------
+
+func Test_notificationService_RecordNotificationInternal(t *testing.T) {
+	repo := repomocks.NewMockNotificationRepository(t)
+	repo.EXPECT().
+		Create(mock.Anything, mock.MatchedBy(func(n *models.Notification) bool {
+			return !n.ID.IsZero() &&
+				n.UserID == defaultUserID &&
+				n.Template == "reminder" &&
+				n.Status == models.NotificationSent &&
+				n.SentAt.Equal(mockTime)
+		})).
+		Return(nil).
+		Once()
+
+	err := newNotificationService(repo).RecordNotificationInternal(t.Context(), &models.Notification{
+		UserID:   defaultUserID,
+		Channel:  models.ChannelEmail,
+		Template: "reminder",
+		Status:   models.NotificationSent,
+	})
+
+	require.NoError(t, err)
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// OutboxServiceInternal exposes pending domain events to the relay.
+type OutboxServiceInternal interface {
+	FetchPendingEventsInternal(context.Context, int) ([]*models.OutboxEvent, error)
+	MarkEventPublishedInternal(context.Context, bson.ObjectID) error
+}
+
+type outboxService struct {
+	outboxRepository repositories.OutboxRepository
+	getTime          clock.NowFn
+}
+
+// NewOutboxService creates a new instance of OutboxServiceInternal.
+func NewOutboxService(outboxRepository repositories.OutboxRepository, nowFn clock.NowFn) OutboxServiceInternal {
+	return &outboxService{
+		outboxRepository,
+		nowFn,
+	}
+}
+
+func (s *outboxService) FetchPendingEventsInternal(ctx context.Context, limit int) ([]*models.OutboxEvent, error) {
+	return s.outboxRepository.GetUnpublished(ctx, int64(limit))
+}
+
+func (s *outboxService) MarkEventPublishedInternal(ctx context.Context, id bson.ObjectID) error {
+	return s.outboxRepository.MarkPublished(ctx, id, s.getTime())
+}
@@ -0,0 +1,84 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newOutboxService(repo *repomocks.MockOutboxRepository) services.OutboxServiceInternal {
+	return services.NewOutboxService(repo, func() time.Time { return mockTime })
+}
+
+// ---------------------------------------------------------------------------
+// FetchPendingEventsInternal
+// ---------------------------------------------------------------------------
+
+func Test_outboxService_FetchPendingEventsInternal(t *testing.T) {
+	t.Run("success - returns pending events", func(t *testing.T) {
+		repo := repomocks.NewMockOutboxRepository(t)
+		event, err := models.NewOutboxEvent(models.BillPaidEvent, bson.NewObjectID(), defaultUserID, map[string]int{"amount": 999}, mockTime)
+		require.NoError(t, err)
+
+		repo.EXPECT().
+			GetUnpublished(mock.Anything, int64(50)).
+			Return([]*models.OutboxEvent{event}, nil).
+			Once()
+
+		got, err := newOutboxService(repo).FetchPendingEventsInternal(t.Context(), 50)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*models.OutboxEvent{event}, got)
+	})
+
+	t.Run("error - repository fails", func(t *testing.T) {
+		repo := repomocks.NewMockOutboxRepository(t)
+		repo.EXPECT().
+			GetUnpublished(mock.Anything, int64(50)).
+			Return(nil, apperror.NewDBError(errors.New("find failed"))).
+			Once()
+
+		got, err := newOutboxService(repo).FetchPendingEventsInternal(t.Context(), 50)
+
+		assertAppErr(t, err, apperror.ErrDB)
+		assert.Nil(t, got)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// MarkEventPublishedInternal
+// ---------------------------------------------------------------------------
+
+func Test_outboxService_MarkEventPublishedInternal(t *testing.T) {
+	t.Run("success - stamps the current time", func(t *testing.T) {
+		repo := repomocks.NewMockOutboxRepository(t)
+		eventID := bson.NewObjectID()
+		repo.EXPECT().MarkPublished(mock.Anything, eventID, mockTime).Return(nil).Once()
+
+		err := newOutboxService(repo).MarkEventPublishedInternal(t.Context(), eventID)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("error - event not found", func(t *testing.T) {
+		repo := repomocks.NewMockOutboxRepository(t)
+		eventID := bson.NewObjectID()
+		repo.EXPECT().
+			MarkPublished(mock.Anything, eventID, mockTime).
+			Return(apperror.NewNotFoundError("not found")).
+			Once()
+
+		err := newOutboxService(repo).MarkEventPublishedInternal(t.Context(), eventID)
+
+		assertAppErr(t, err, apperror.ErrNotFound)
+	})
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// MaxPaymentMethodsPerUser caps how many payment methods a user can store.
+const MaxPaymentMethodsPerUser = 20
+
+type PaymentMethodServiceExternal interface {
+	CreatePaymentMethod(ctx context.Context, method *models.PaymentMethod, claimedUserID string) (*models.PaymentMethod, error)
+	GetPaymentMethods(ctx context.Context, claimedUserID string) ([]*models.PaymentMethod, error)
+	GetPaymentMethod(ctx context.Context, id, claimedUserID string) (*models.PaymentMethod, error)
+	// UpdatePaymentMethod replaces the details of a payment method.
+	UpdatePaymentMethod(ctx context.Context, id string, method *models.PaymentMethod, claimedUserID string) (*models.PaymentMethod, error)
+	// DeletePaymentMethod deletes a payment method and unlinks the
+	// subscriptions paid with it.
+	DeletePaymentMethod(ctx context.Context, id, claimedUserID string) error
+	// LinkSubscription sets the payment method a subscription is paid with.
+	// An empty paymentMethodID unlinks it.
+	LinkSubscription(ctx context.Context, subscriptionID, paymentMethodID, claimedUserID string) (*models.Subscription, error)
+}
+
+type PaymentMethodServiceInternal interface {
+	FetchPaymentMethodByIDInternal(context.Context, bson.ObjectID) (*models.PaymentMethod, error)
+	// DeletePaymentMethodsInternal deletes the payment methods of the user,
+	// unlinking the subscriptions paid with them, and returns how many were
+	// deleted.
+	DeletePaymentMethodsInternal(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+type PaymentMethodService interface {
+	PaymentMethodServiceExternal
+	PaymentMethodServiceInternal
+}
+
+type paymentMethodService struct {
+	runTx                   repositories.TxnFn
+	paymentMethodRepository repositories.PaymentMethodRepository
+	subscriptionRepository  repositories.SubscriptionRepository
+	getTime                 clock.NowFn
+}
+
+// NewPaymentMethodService creates a new instance of PaymentMethodService.
+func NewPaymentMethodService(
+	txnFn repositories.TxnFn,
+	paymentMethodRepository repositories.PaymentMethodRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+	nowFn clock.NowFn,
+) PaymentMethodService {
+	return &paymentMethodService{
+		txnFn,
+		paymentMethodRepository,
+		subscriptionRepository,
+		nowFn,
+	}
+}
+
+func (s *paymentMethodService) CreatePaymentMethod(
+	ctx context.Context,
+	method *models.PaymentMethod,
+	claimedUserID string,
+) (*models.PaymentMethod, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	now := s.getTime()
+	method.ID = bson.NewObjectID()
+	method.UserID = userID
+	method.CreatedAt = now
+	method.UpdatedAt = now
+	if err = method.Validate(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.paymentMethodRepository.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxPaymentMethodsPerUser {
+		return nil, apperror.NewConflictError("A user can store at most %d payment methods", MaxPaymentMethodsPerUser)
+	}
+
+	if method, err = s.paymentMethodRepository.Create(ctx, method); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Payment method added",
+		logattr.PaymentMethodID(method.ID.Hex()),
+		logattr.UserID(claimedUserID),
+	)
+	return method, nil
+}
+
+func (s *paymentMethodService) GetPaymentMethods(ctx context.Context, claimedUserID string) ([]*models.PaymentMethod, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	return s.paymentMethodRepository.GetByUserID(ctx, userID)
+}
+
+func (s *paymentMethodService) GetPaymentMethod(ctx context.Context, id, claimedUserID string) (*models.PaymentMethod, error) {
+	return s.ownedPaymentMethod(ctx, id, claimedUserID)
+}
+
+func (s *paymentMethodService) UpdatePaymentMethod(
+	ctx context.Context,
+	id string,
+	method *models.PaymentMethod,
+	claimedUserID string,
+) (*models.PaymentMethod, error) {
+	existing, err := s.ownedPaymentMethod(ctx, id, claimedUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	method.ID = existing.ID
+	method.UserID = existing.UserID
+	method.CreatedAt = existing.CreatedAt
+	method.UpdatedAt = s.getTime()
+	if err = method.Validate(); err != nil {
+		return nil, err
+	}
+	return s.paymentMethodRepository.Update(ctx, method)
+}
+
+func (s *paymentMethodService) DeletePaymentMethod(ctx context.Context, id, claimedUserID string) error {
+	method, err := s.ownedPaymentMethod(ctx, id, claimedUserID)
+	if err != nil {
+		return err
+	}
+
+	var unlinked int64
+	err = s.runTx(ctx, func(ctx context.Context) error {
+		var txnErr error
+		if unlinked, txnErr = s.subscriptionRepository.UnlinkPaymentMethod(ctx, method.UserID, method.ID, s.getTime()); txnErr != nil {
+			return txnErr
+		}
+		return s.paymentMethodRepository.Delete(ctx, method.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Payment method deleted",
+		logattr.PaymentMethodID(id),
+		logattr.UserID(claimedUserID),
+		logattr.Total(int(unlinked)),
+	)
+	return nil
+}
+
+func (s *paymentMethodService) LinkSubscription(
+	ctx context.Context,
+	subscriptionID, paymentMethodID, claimedUserID string,
+) (*models.Subscription, error) {
+	subID, err := bson.ObjectIDFromHex(subscriptionID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid subscription ID")
+	}
+
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	subscription, err := s.subscriptionRepository.GetByID(ctx, subID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if subscription.UserID != userID {
+		return nil, apperror.NewForbiddenError("You are not allowed to update this subscription")
+	}
+
+	var methodID *bson.ObjectID
+	if paymentMethodID != "" {
+		method, err := s.ownedPaymentMethod(ctx, paymentMethodID, claimedUserID)
+		if err != nil {
+			return nil, err
+		}
+		methodID = &method.ID
+	}
+	return s.subscriptionRepository.SetPaymentMethod(ctx, subID, methodID, s.getTime())
+}
+
+func (s *paymentMethodService) FetchPaymentMethodByIDInternal(ctx context.Context, id bson.ObjectID) (*models.PaymentMethod, error) {
+	return s.paymentMethodRepository.GetByID(ctx, id)
+}
+
+// DeletePaymentMethodsInternal deletes each method like DeletePaymentMethod,
+// so a failure leaves no subscription linked to a deleted method.
+func (s *paymentMethodService) DeletePaymentMethodsInternal(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	methods, err := s.paymentMethodRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	var deleted int64
+	for _, method := range methods {
+		err = s.runTx(ctx, func(ctx context.Context) error {
+			if _, txnErr := s.subscriptionRepository.UnlinkPaymentMethod(ctx, userID, method.ID, s.getTime()); txnErr != nil {
+				return txnErr
+			}
+			return s.paymentMethodRepository.Delete(ctx, method.ID)
+		})
+		if err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// ownedPaymentMethod loads the payment method and checks that it belongs to
+// the caller.
+func (s *paymentMethodService) ownedPaymentMethod(ctx context.Context, id, claimedUserID string) (*models.PaymentMethod, error) {
+	methodID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid payment method ID")
+	}
+	method, err := s.paymentMethodRepository.GetByID(ctx, methodID)
+	if err != nil {
+		return nil, err
+	}
+	if method.UserID.Hex() != claimedUserID {
+		return nil, apperror.NewForbiddenError("You are not allowed to access this payment method")
+	}
+	return method, nil
+}
@@ -0,0 +1,252 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func setupPaymentMethod(t *testing.T) (
+	services.PaymentMethodService,
+	*repomocks.MockPaymentMethodRepository,
+	*repomocks.MockSubscriptionRepository,
+) {
+	t.Helper()
+
+	methodRepo := repomocks.NewMockPaymentMethodRepository(t)
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	svc := services.NewPaymentMethodService(noopTxnFn, methodRepo, subRepo, func() time.Time { return mockTime })
+	return svc, methodRepo, subRepo
+}
+
+func validPaymentMethod() *models.PaymentMethod {
+	return &models.PaymentMethod{
+		ID:       bson.NewObjectID(),
+		UserID:   defaultUserID,
+		Type:     models.Card,
+		Brand:    "Visa",
+		Last4:    "4242",
+		ExpMonth: 12,
+		ExpYear:  2030,
+	}
+}
+
+// ---------------------------------------------------------------------------
+// CreatePaymentMethod
+// ---------------------------------------------------------------------------
+
+func TestPaymentMethodService_CreatePaymentMethod(t *testing.T) {
+	t.Run("stores the payment method for the caller", func(t *testing.T) {
+		svc, methodRepo, _ := setupPaymentMethod(t)
+		methodRepo.EXPECT().CountByUserID(mock.Anything, defaultUserID).Return(int64(0), nil).Once()
+		methodRepo.EXPECT().
+			Create(mock.Anything, mock.AnythingOfType("*models.PaymentMethod")).
+			RunAndReturn(func(_ context.Context, m *models.PaymentMethod) (*models.PaymentMethod, error) {
+				return m, nil
+			}).
+			Once()
+
+		got, err := svc.CreatePaymentMethod(t.Context(), &models.PaymentMethod{
+			Type:  models.Card,
+			Label: "Work card",
+			Brand: "Visa",
+			Last4: "4242",
+		}, defaultUserHex)
+
+		require.NoError(t, err)
+		assert.Equal(t, defaultUserID, got.UserID)
+		assert.False(t, got.ID.IsZero())
+		assert.Equal(t, mockTime, got.CreatedAt)
+		assert.Equal(t, "Work card (Visa ending in 4242)", got.Description())
+	})
+
+	t.Run("rejects an expiry date on a bank account", func(t *testing.T) {
+		svc, _, _ := setupPaymentMethod(t)
+
+		_, err := svc.CreatePaymentMethod(t.Context(), &models.PaymentMethod{
+			Type:     models.BankAccount,
+			ExpMonth: 1,
+			ExpYear:  2030,
+		}, defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+
+	t.Run("caps the payment methods per user", func(t *testing.T) {
+		svc, methodRepo, _ := setupPaymentMethod(t)
+		methodRepo.EXPECT().
+			CountByUserID(mock.Anything, defaultUserID).
+			Return(int64(services.MaxPaymentMethodsPerUser), nil).
+			Once()
+
+		_, err := svc.CreatePaymentMethod(t.Context(), &models.PaymentMethod{Type: models.PayPal}, defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrConflict)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// UpdatePaymentMethod
+// ---------------------------------------------------------------------------
+
+func TestPaymentMethodService_UpdatePaymentMethod(t *testing.T) {
+	t.Run("replaces the details and keeps the identity", func(t *testing.T) {
+		svc, methodRepo, _ := setupPaymentMethod(t)
+		existing := validPaymentMethod()
+		existing.CreatedAt = mockTime.Add(-time.Hour)
+		methodRepo.EXPECT().GetByID(mock.Anything, existing.ID).Return(existing, nil).Once()
+		methodRepo.EXPECT().
+			Update(mock.Anything, mock.AnythingOfType("*models.PaymentMethod")).
+			RunAndReturn(func(_ context.Context, m *models.PaymentMethod) (*models.PaymentMethod, error) {
+				return m, nil
+			}).
+			Once()
+
+		got, err := svc.UpdatePaymentMethod(t.Context(), existing.ID.Hex(), &models.PaymentMethod{
+			Type:  models.Card,
+			Brand: "Mastercard",
+			Last4: "5555",
+		}, defaultUserHex)
+
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, got.ID)
+		assert.Equal(t, defaultUserID, got.UserID)
+		assert.Equal(t, existing.CreatedAt, got.CreatedAt)
+		assert.Equal(t, mockTime, got.UpdatedAt)
+		assert.Equal(t, "5555", got.Last4)
+	})
+
+	t.Run("forbids updating another user's payment method", func(t *testing.T) {
+		svc, methodRepo, _ := setupPaymentMethod(t)
+		existing := validPaymentMethod()
+		methodRepo.EXPECT().GetByID(mock.Anything, existing.ID).Return(existing, nil).Once()
+
+		_, err := svc.UpdatePaymentMethod(t.Context(), existing.ID.Hex(), &models.PaymentMethod{Type: models.PayPal}, bson.NewObjectID().Hex())
+
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// DeletePaymentMethod
+// ---------------------------------------------------------------------------
+
+func TestPaymentMethodService_DeletePaymentMethod(t *testing.T) {
+	t.Run("unlinks subscriptions and deletes", func(t *testing.T) {
+		svc, methodRepo, subRepo := setupPaymentMethod(t)
+		method := validPaymentMethod()
+		methodRepo.EXPECT().GetByID(mock.Anything, method.ID).Return(method, nil).Once()
+		subRepo.EXPECT().UnlinkPaymentMethod(mock.Anything, method.UserID, method.ID, mockTime).Return(int64(2), nil).Once()
+		methodRepo.EXPECT().Delete(mock.Anything, method.ID).Return(nil).Once()
+
+		require.NoError(t, svc.DeletePaymentMethod(t.Context(), method.ID.Hex(), defaultUserHex))
+	})
+
+	t.Run("rejects an invalid ID", func(t *testing.T) {
+		svc, _, _ := setupPaymentMethod(t)
+
+		err := svc.DeletePaymentMethod(t.Context(), "bad-hex", defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrBadRequest)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// DeletePaymentMethodsInternal
+// ---------------------------------------------------------------------------
+
+func TestPaymentMethodService_DeletePaymentMethodsInternal(t *testing.T) {
+	t.Run("unlinks subscriptions and deletes every method of the user", func(t *testing.T) {
+		svc, methodRepo, subRepo := setupPaymentMethod(t)
+		first, second := validPaymentMethod(), validPaymentMethod()
+		methodRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return([]*models.PaymentMethod{first, second}, nil).Once()
+		for _, method := range []*models.PaymentMethod{first, second} {
+			subRepo.EXPECT().UnlinkPaymentMethod(mock.Anything, defaultUserID, method.ID, mockTime).Return(int64(1), nil).Once()
+			methodRepo.EXPECT().Delete(mock.Anything, method.ID).Return(nil).Once()
+		}
+
+		deleted, err := svc.DeletePaymentMethodsInternal(t.Context(), defaultUserID)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+	})
+
+	t.Run("keeps a method whose subscriptions cannot be unlinked", func(t *testing.T) {
+		svc, methodRepo, subRepo := setupPaymentMethod(t)
+		method := validPaymentMethod()
+		methodRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return([]*models.PaymentMethod{method}, nil).Once()
+		subRepo.EXPECT().
+			UnlinkPaymentMethod(mock.Anything, defaultUserID, method.ID, mockTime).
+			Return(int64(0), apperror.NewDBError(errors.New("write failed"))).
+			Once()
+
+		deleted, err := svc.DeletePaymentMethodsInternal(t.Context(), defaultUserID)
+
+		assertAppErr(t, err, apperror.ErrDB)
+		assert.Zero(t, deleted)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// LinkSubscription
+// ---------------------------------------------------------------------------
+
+func TestPaymentMethodService_LinkSubscription(t *testing.T) {
+	t.Run("links the caller's payment method", func(t *testing.T) {
+		svc, methodRepo, subRepo := setupPaymentMethod(t)
+		method := validPaymentMethod()
+		linked := validSub()
+		linked.PaymentMethodID = &method.ID
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+		methodRepo.EXPECT().GetByID(mock.Anything, method.ID).Return(method, nil).Once()
+		subRepo.EXPECT().SetPaymentMethod(mock.Anything, defaultSubID, &method.ID, mockTime).Return(linked, nil).Once()
+
+		got, err := svc.LinkSubscription(t.Context(), defaultSubHex, method.ID.Hex(), defaultUserHex)
+
+		require.NoError(t, err)
+		assert.Equal(t, &method.ID, got.PaymentMethodID)
+	})
+
+	t.Run("unlinks with an empty ID", func(t *testing.T) {
+		svc, _, subRepo := setupPaymentMethod(t)
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+		subRepo.EXPECT().SetPaymentMethod(mock.Anything, defaultSubID, (*bson.ObjectID)(nil), mockTime).Return(validSub(), nil).Once()
+
+		_, err := svc.LinkSubscription(t.Context(), defaultSubHex, "", defaultUserHex)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("forbids another user's payment method", func(t *testing.T) {
+		svc, methodRepo, subRepo := setupPaymentMethod(t)
+		method := validPaymentMethod()
+		method.UserID = bson.NewObjectID()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+		methodRepo.EXPECT().GetByID(mock.Anything, method.ID).Return(method, nil).Once()
+
+		_, err := svc.LinkSubscription(t.Context(), defaultSubHex, method.ID.Hex(), defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+
+	t.Run("forbids another user's subscription", func(t *testing.T) {
+		svc, _, subRepo := setupPaymentMethod(t)
+		sub := validSub()
+		sub.UserID = bson.NewObjectID()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(sub, nil).Once()
+
+		_, err := svc.LinkSubscription(t.Context(), defaultSubHex, bson.NewObjectID().Hex(), defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+}
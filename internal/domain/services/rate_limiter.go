@@ -4,20 +4,32 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/go-redis/redis_rate/v10"
 )
 
 // RateLimiterService defines the interface for rate limiting operations.
 type RateLimiterService interface {
-	// Allowed checks if the given IP has not exceeded the rate limit.
-	Allowed(ctx context.Context, ip string) (bool, int, time.Duration, error)
+	// Allowed checks if the given key, usually a client IP, has not exceeded
+	// the rate limit.
+	Allowed(ctx context.Context, key string) (bool, int, time.Duration, error)
+
+	// State reports the limit state for the given key without consuming a
+	// request.
+	State(ctx context.Context, key string) (*models.RateLimitState, error)
+
+	// SetLimit replaces the limit, as after a config reload. Counters are
+	// kept, so requests already counted still apply.
+	SetLimit(limit redis_rate.Limit)
 }
 
 type redisRateLimiter struct {
 	limiter *redis_rate.Limiter
+	mu      sync.RWMutex // Guards limit.
 	limit   redis_rate.Limit
 	prefix  string
 }
@@ -40,13 +52,46 @@ func NewRateLimiterService(
 	}
 }
 
-// Allowed checks if the given IP has not exceeded the rate limit.
+// NewRateLimiterGroups creates a named rate limiter for each route group. The
+// limiters keep their counters under "group:<name>" so that they never share
+// a budget with the app and auth limiters.
+func NewRateLimiterGroups(
+	limiter *redis_rate.Limiter, limits map[string]redis_rate.Limit,
+) map[string]RateLimiterService {
+	groups := make(map[string]RateLimiterService, len(limits))
+	for name, limit := range limits {
+		groups[name] = NewRateLimiterService(limiter, limit, "group:"+name)
+	}
+	return groups
+}
+
+// getLimit returns the current limit.
+func (r *redisRateLimiter) getLimit() redis_rate.Limit {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.limit
+}
+
+// SetLimit replaces the limit.
+func (r *redisRateLimiter) SetLimit(limit redis_rate.Limit) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.limit = limit
+	slog.Info("Rate limit changed",
+		logattr.Prefix(r.prefix),
+		logattr.Rate(limit.Rate),
+		logattr.Burst(limit.Burst),
+		logattr.Period(limit.Period),
+	)
+}
+
+// Allowed checks if the given key has not exceeded the rate limit.
 func (r *redisRateLimiter) Allowed(
 	ctx context.Context,
-	ip string,
+	key string,
 ) (bool, int, time.Duration, error) {
-	key := fmt.Sprintf("%s:%s", r.prefix, ip)
-	res, err := r.limiter.Allow(ctx, key, r.limit)
+	res, err := r.limiter.Allow(ctx, fmt.Sprintf("%s:%s", r.prefix, key), r.getLimit())
 	if err != nil {
 		return false, 0, 0, fmt.Errorf("error checking rate limit: %w", err)
 	}
@@ -55,3 +100,34 @@ func (r *redisRateLimiter) Allowed(
 	retryAfter := max(res.RetryAfter, 0)
 	return isAllowed, res.Remaining, retryAfter, nil
 }
+
+// State reports the limit state for the given key without consuming a request.
+func (r *redisRateLimiter) State(
+	ctx context.Context,
+	key string,
+) (*models.RateLimitState, error) {
+	limit := r.getLimit()
+	// A zero-cost request reads the limiter state without using up a token.
+	res, err := r.limiter.AllowN(ctx, fmt.Sprintf("%s:%s", r.prefix, key), limit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rate limit state: %w", err)
+	}
+
+	state := &models.RateLimitState{
+		Limiter:    r.prefix,
+		Key:        key,
+		Rate:       limit.Rate,
+		Burst:      limit.Burst,
+		Period:     limit.Period.String(),
+		Remaining:  res.Remaining,
+		ResetAfter: max(res.ResetAfter, 0).String(),
+	}
+	// The next request is allowed once all but one token of the burst have
+	// been replenished.
+	emission := limit.Period / time.Duration(limit.Rate)
+	if retryAfter := res.ResetAfter - emission*time.Duration(limit.Burst-1); retryAfter > 0 {
+		state.Limited = true
+		state.RetryAfter = retryAfter.String()
+	}
+	return state, nil
+}
@@ -85,3 +85,103 @@ func TestRedisRateLimiter_Error_FailOpen(t *testing.T) {
 	assert.Equal(t, time.Duration(0), retryAfter)
 	assert.Contains(t, err.Error(), "error checking rate limit")
 }
+
+func TestRedisRateLimiter_State(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	limit := redis_rate.Limit{Rate: 2, Burst: 2, Period: time.Minute}
+	svc := services.NewRateLimiterService(redis_rate.NewLimiter(rdb), limit, "test_prefix")
+	ctx := t.Context()
+	ip := "192.168.1.100"
+
+	// Reading the state of a fresh key reports the full burst.
+	state, err := svc.State(ctx, ip)
+	require.NoError(t, err)
+	assert.Equal(t, "test_prefix", state.Limiter)
+	assert.Equal(t, 2, state.Remaining)
+	assert.False(t, state.Limited)
+
+	// Reading the state again must not have consumed a token.
+	allowed, remaining, _, err := svc.Allowed(ctx, ip)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 1, remaining)
+
+	_, _, _, err = svc.Allowed(ctx, ip)
+	require.NoError(t, err)
+
+	state, err = svc.State(ctx, ip)
+	require.NoError(t, err)
+	assert.Equal(t, 0, state.Remaining)
+	assert.True(t, state.Limited)
+	assert.NotEmpty(t, state.RetryAfter)
+}
+
+func TestRedisRateLimiter_SetLimit(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	limit := redis_rate.Limit{Rate: 1, Burst: 1, Period: time.Minute}
+	svc := services.NewRateLimiterService(redis_rate.NewLimiter(rdb), limit, "test_prefix")
+	ctx := t.Context()
+	ip := "192.168.1.100"
+
+	allowed, _, _, err := svc.Allowed(ctx, ip)
+	require.NoError(t, err)
+	require.True(t, allowed)
+	allowed, _, _, err = svc.Allowed(ctx, ip)
+	require.NoError(t, err)
+	require.False(t, allowed)
+
+	svc.SetLimit(redis_rate.Limit{Rate: 10, Burst: 20, Period: time.Minute})
+
+	allowed, _, _, err = svc.Allowed(ctx, ip)
+	require.NoError(t, err)
+	assert.True(t, allowed, "the larger burst admits the key again")
+	state, err := svc.State(ctx, ip)
+	require.NoError(t, err)
+	assert.Equal(t, 10, state.Rate)
+}
+
+func TestNewRateLimiterGroups(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	groups := services.NewRateLimiterGroups(redis_rate.NewLimiter(rdb), map[string]redis_rate.Limit{
+		"subscriptions": {Rate: 1, Burst: 1, Period: time.Minute},
+		"bills":         {Rate: 5, Burst: 5, Period: time.Minute},
+	})
+	require.Len(t, groups, 2)
+	ctx := t.Context()
+	userID := "64b7f0f0f0f0f0f0f0f0f0f0"
+
+	// Exhausting one group leaves the budget of the others untouched.
+	allowed, _, _, err := groups["subscriptions"].Allowed(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, _, _, err = groups["subscriptions"].Allowed(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	allowed, remaining, _, err := groups["bills"].Allowed(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 4, remaining)
+
+	state, err := groups["bills"].State(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "group:bills", state.Limiter)
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// ReconciliationReportKey is the Redis key holding the report of the
+	// latest reconciliation run.
+	ReconciliationReportKey = "reconciliation:report"
+
+	// renewalMissedGrace is how long after its validity ends an active
+	// subscription may wait for renewal before it counts as missed; renewals
+	// run daily and retry for hours.
+	renewalMissedGrace = 24 * time.Hour
+)
+
+// ReconciliationServiceExternal reads reconciliation results for admins.
+type ReconciliationServiceExternal interface {
+	// GetReconciliationReport returns the report of the latest run.
+	GetReconciliationReport(context.Context) (*models.ReconciliationReport, error)
+}
+
+// ReconciliationServiceInternal checks subscriptions against their bills.
+type ReconciliationServiceInternal interface {
+	// ReconcileInternal checks every active, past-due, and suspended
+	// subscription against its bills, repairs what it safely can, and stores
+	// the report.
+	ReconcileInternal(context.Context) (*models.ReconciliationReport, error)
+}
+
+type ReconciliationService interface {
+	ReconciliationServiceExternal
+	ReconciliationServiceInternal
+}
+
+type reconciliationService struct {
+	subscriptionRepository repositories.SubscriptionRepository
+	billRepository         repositories.BillRepository
+	subscriptionService    SubscriptionServiceInternal
+	redisClient            redis.UniversalClient
+	getTime                clock.NowFn
+}
+
+// NewReconciliationService creates a new instance of ReconciliationService.
+func NewReconciliationService(
+	subscriptionRepository repositories.SubscriptionRepository,
+	billRepository repositories.BillRepository,
+	subscriptionService SubscriptionServiceInternal,
+	redisClient redis.UniversalClient,
+	nowFn clock.NowFn,
+) ReconciliationService {
+	return &reconciliationService{
+		subscriptionRepository,
+		billRepository,
+		subscriptionService,
+		redisClient,
+		nowFn,
+	}
+}
+
+// ReconcileInternal finds subscriptions whose status disagrees with their
+// bills. Only a past-due or suspended subscription whose failed bill has
+// since been paid is repaired, by reactivating it; every other finding needs a person to
+// decide and is only reported. Bills and subscriptions written within
+// billRepairGrace are skipped, since their writes may still be in flight.
+func (s *reconciliationService) ReconcileInternal(ctx context.Context) (_ *models.ReconciliationReport, err error) {
+	ctx, span := startSpan(ctx, "Reconcile Subscriptions")
+	defer func() { endSpan(span, err) }()
+
+	now := s.getTime()
+	report := &models.ReconciliationReport{
+		StartedAt: now,
+		Counts:    make(map[models.ReconciliationIssue]int),
+		Findings:  []models.ReconciliationFinding{},
+	}
+
+	active := &models.SubscriptionQuery{Status: models.Active, Sort: models.SortByCreatedAt}
+	if err = s.subscriptionRepository.Each(ctx, active, func(subscription *models.Subscription) error {
+		report.Checked++
+		return s.checkActive(ctx, subscription, now, report)
+	}); err != nil {
+		return nil, err
+	}
+	if err = s.subscriptionRepository.EachPastDue(ctx, func(subscription *models.Subscription) error {
+		report.Checked++
+		return s.checkPastDue(ctx, subscription, now, report)
+	}); err != nil {
+		return nil, err
+	}
+	report.FinishedAt = s.getTime()
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+	if err = s.redisClient.Set(ctx, ReconciliationReportKey, data, 0).Err(); err != nil {
+		return nil, apperror.NewDBError(err)
+	}
+
+	findings := 0
+	for _, count := range report.Counts {
+		findings += count
+	}
+	if findings > 0 {
+		slog.WarnContext(ctx, "Reconciliation found subscriptions inconsistent with their bills",
+			logattr.Subscriptions(report.Checked),
+			logattr.Total(findings),
+			logattr.Success(report.Repaired),
+		)
+	}
+	return report, nil
+}
+
+// checkActive reports an active subscription without a bill, with a failed
+// latest bill, with a renewal bill it was not extended for, or whose renewal
+// is long overdue.
+func (s *reconciliationService) checkActive(
+	ctx context.Context,
+	subscription *models.Subscription,
+	now time.Time,
+	report *models.ReconciliationReport,
+) error {
+	settled := now.Add(-billRepairGrace)
+	bill, err := s.latestBill(ctx, subscription)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case bill == nil:
+		if !subscription.InTrial() && subscription.CreatedAt.Before(settled) {
+			report.Add(newFinding(subscription, models.ReconcileMissingBill, "The subscription has no bill"))
+		}
+	case bill.Status == models.Failed && bill.UpdatedAt.Before(settled):
+		report.Add(newFinding(subscription, models.ReconcileFailedBill,
+			fmt.Sprintf("Bill %s failed, but no payment retries were started", bill.ID.Hex())))
+	case bill.Status == models.Paid && bill.EndDate.After(subscription.ValidTill) && bill.CreatedAt.Before(settled):
+		report.Add(newFinding(subscription, models.ReconcileUnappliedBill,
+			fmt.Sprintf("Bill %s ends on %s, after the subscription's validity",
+				bill.ID.Hex(), bill.EndDate.Format(time.DateOnly))))
+	}
+
+	if subscription.ValidTill.Before(now.Add(-renewalMissedGrace)) {
+		report.Add(newFinding(subscription, models.ReconcileRenewalMissed,
+			fmt.Sprintf("The subscription was valid until %s and was never renewed",
+				subscription.ValidTill.Format(time.DateOnly))))
+	}
+	return nil
+}
+
+// checkPastDue reports a past-due or suspended subscription whose dunning
+// does not point at a failed bill, or one whose retried charge is pending,
+// and reactivates it when that bill has been paid.
+func (s *reconciliationService) checkPastDue(
+	ctx context.Context,
+	subscription *models.Subscription,
+	now time.Time,
+	report *models.ReconciliationReport,
+) error {
+	if subscription.Dunning == nil {
+		report.Add(newFinding(subscription, models.ReconcileDunningBroken,
+			fmt.Sprintf("The subscription is %s without a failed bill to retry", subscription.Status)))
+		return nil
+	}
+	bill, err := s.billRepository.GetByID(ctx, subscription.Dunning.BillID)
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			report.Add(newFinding(subscription, models.ReconcileDunningBroken,
+				fmt.Sprintf("Bill %s being retried does not exist", subscription.Dunning.BillID.Hex())))
+			return nil
+		}
+		return err
+	}
+
+	switch bill.Status {
+	case models.Failed, models.Pending:
+		return nil
+	case models.Paid:
+		if !bill.UpdatedAt.Before(now.Add(-billRepairGrace)) {
+			return nil
+		}
+		finding := newFinding(subscription, models.ReconcileDunningSettled,
+			fmt.Sprintf("Bill %s being retried is already paid", bill.ID.Hex()))
+		if err = s.subscriptionService.EndSettledDunningInternal(ctx, subscription.ID); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			slog.ErrorContext(ctx, "Failed to reactivate dunned subscription",
+				logattr.SubscriptionID(subscription.ID.Hex()),
+				logattr.Error(err),
+			)
+			finding.RepairError = err.Error()
+		} else {
+			finding.Repaired = true
+		}
+		report.Add(finding)
+	default:
+		report.Add(newFinding(subscription, models.ReconcileDunningBroken,
+			fmt.Sprintf("Bill %s being retried is %s", bill.ID.Hex(), bill.Status)))
+	}
+	return nil
+}
+
+// latestBill returns the subscription's latest bill, or nil if it has none.
+func (s *reconciliationService) latestBill(ctx context.Context, subscription *models.Subscription) (*models.Bill, error) {
+	bill, err := s.billRepository.GetLatestBill(ctx, subscription.ID)
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return bill, nil
+}
+
+func newFinding(
+	subscription *models.Subscription,
+	issue models.ReconciliationIssue,
+	detail string,
+) models.ReconciliationFinding {
+	return models.ReconciliationFinding{
+		SubscriptionID: subscription.ID.Hex(),
+		UserID:         subscription.UserID.Hex(),
+		Status:         subscription.Status,
+		Issue:          issue,
+		Detail:         detail,
+	}
+}
+
+// GetReconciliationReport returns the report of the latest reconciliation
+// run, or a not-found error before the first one.
+func (s *reconciliationService) GetReconciliationReport(ctx context.Context) (*models.ReconciliationReport, error) {
+	data, err := s.redisClient.Get(ctx, ReconciliationReportKey).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, apperror.NewNotFoundError("No reconciliation has run yet")
+		}
+		return nil, apperror.NewDBError(err)
+	}
+
+	var report models.ReconciliationReport
+	if err = json.Unmarshal(data, &report); err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+	return &report, nil
+}
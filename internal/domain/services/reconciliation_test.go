@@ -0,0 +1,395 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+type reconciliationFixture struct {
+	svc      services.ReconciliationService
+	subRepo  *repomocks.MockSubscriptionRepository
+	billRepo *repomocks.MockBillRepository
+	subSvc   *svcmocks.MockSubscriptionServiceInternal
+	mr       *miniredis.Miniredis
+}
+
+func setupReconciliation(t *testing.T) *reconciliationFixture {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	f := &reconciliationFixture{
+		subRepo:  repomocks.NewMockSubscriptionRepository(t),
+		billRepo: repomocks.NewMockBillRepository(t),
+		subSvc:   svcmocks.NewMockSubscriptionServiceInternal(t),
+		mr:       mr,
+	}
+	f.svc = services.NewReconciliationService(
+		f.subRepo,
+		f.billRepo,
+		f.subSvc,
+		rdb,
+		func() time.Time { return mockTime },
+	)
+	return f
+}
+
+// expectSubscriptions streams active and pastDue to the reconciliation
+// scans.
+func (f *reconciliationFixture) expectSubscriptions(active, pastDue []*models.Subscription) {
+	f.subRepo.EXPECT().
+		Each(mock.Anything, mock.MatchedBy(func(q *models.SubscriptionQuery) bool {
+			return q.Status == models.Active
+		}), mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *models.SubscriptionQuery, fn func(*models.Subscription) error) error {
+			for _, s := range active {
+				if err := fn(s); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).
+		Once()
+	f.subRepo.EXPECT().
+		EachPastDue(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, fn func(*models.Subscription) error) error {
+			for _, s := range pastDue {
+				if err := fn(s); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).
+		Maybe()
+}
+
+// settledSub returns an active subscription created long enough ago that its
+// writes have settled.
+func settledSub() *models.Subscription {
+	sub := validSub()
+	sub.ID = bson.NewObjectID()
+	sub.CreatedAt = mockTime.AddDate(0, -1, 0)
+	return sub
+}
+
+// settledBill returns a paid bill of sub written long enough ago that its
+// writes have settled.
+func settledBill(sub *models.Subscription) *models.Bill {
+	bill := validBill()
+	bill.SubscriptionID = sub.ID
+	bill.CreatedAt = mockTime.Add(-time.Hour)
+	bill.UpdatedAt = mockTime.Add(-time.Hour)
+	return bill
+}
+
+func issues(report *models.ReconciliationReport) []models.ReconciliationIssue {
+	var got []models.ReconciliationIssue
+	for _, finding := range report.Findings {
+		got = append(got, finding.Issue)
+	}
+	return got
+}
+
+// ---------------------------------------------------------------------------
+// ReconcileInternal
+// ---------------------------------------------------------------------------
+
+func Test_reconciliationService_ReconcileInternal(t *testing.T) {
+	t.Run("consistent subscriptions have no findings", func(t *testing.T) {
+		f := setupReconciliation(t)
+		active := settledSub()
+		failed := validBill()
+		failed.Status = models.Failed
+		pastDue := pastDueSub(failed, 1)
+		f.expectSubscriptions([]*models.Subscription{active}, []*models.Subscription{pastDue})
+		f.billRepo.EXPECT().GetLatestBill(mock.Anything, active.ID).Return(settledBill(active), nil).Once()
+		f.billRepo.EXPECT().GetByID(mock.Anything, failed.ID).Return(failed, nil).Once()
+
+		report, err := f.svc.ReconcileInternal(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Checked)
+		assert.Empty(t, report.Findings)
+		assert.Empty(t, report.Counts)
+		assert.True(t, f.mr.Exists(services.ReconciliationReportKey))
+	})
+
+	t.Run("active subscriptions inconsistent with their bills are flagged", func(t *testing.T) {
+		f := setupReconciliation(t)
+
+		noBill := settledSub()
+		trial := settledSub()
+		trialEnd := trial.ValidTill
+		trial.TrialEndsAt = &trialEnd
+		failedSub := settledSub()
+		failed := settledBill(failedSub)
+		failed.Status = models.Failed
+		unappliedSub := settledSub()
+		unappliedSub.ValidTill = mockToday
+		unapplied := settledBill(unappliedSub)
+		missedSub := settledSub()
+		missedSub.ValidTill = mockToday.AddDate(0, 0, -3)
+		missed := settledBill(missedSub)
+		missed.StartDate = mockToday.AddDate(0, -1, -3)
+		missed.EndDate = missedSub.ValidTill
+
+		f.expectSubscriptions([]*models.Subscription{noBill, trial, failedSub, unappliedSub, missedSub}, nil)
+		notFound := apperror.NewNotFoundError("Document not found")
+		f.billRepo.EXPECT().GetLatestBill(mock.Anything, noBill.ID).Return(nil, notFound).Once()
+		f.billRepo.EXPECT().GetLatestBill(mock.Anything, trial.ID).Return(nil, notFound).Once()
+		f.billRepo.EXPECT().GetLatestBill(mock.Anything, failedSub.ID).Return(failed, nil).Once()
+		f.billRepo.EXPECT().GetLatestBill(mock.Anything, unappliedSub.ID).Return(unapplied, nil).Once()
+		f.billRepo.EXPECT().GetLatestBill(mock.Anything, missedSub.ID).Return(missed, nil).Once()
+
+		report, err := f.svc.ReconcileInternal(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, report.Checked)
+		assert.Equal(t, []models.ReconciliationIssue{
+			models.ReconcileMissingBill,
+			models.ReconcileFailedBill,
+			models.ReconcileUnappliedBill,
+			models.ReconcileRenewalMissed,
+		}, issues(report))
+		assert.Equal(t, noBill.ID.Hex(), report.Findings[0].SubscriptionID)
+		assert.Zero(t, report.Repaired)
+	})
+
+	t.Run("bills still being written are skipped", func(t *testing.T) {
+		f := setupReconciliation(t)
+		sub := settledSub()
+		sub.ValidTill = mockToday
+		inFlight := validBill()
+		inFlight.SubscriptionID = sub.ID
+		f.expectSubscriptions([]*models.Subscription{sub}, nil)
+		f.billRepo.EXPECT().GetLatestBill(mock.Anything, sub.ID).Return(inFlight, nil).Once()
+
+		report, err := f.svc.ReconcileInternal(t.Context())
+
+		require.NoError(t, err)
+		assert.Empty(t, report.Findings)
+	})
+
+	t.Run("a past-due subscription whose bill was paid is reactivated", func(t *testing.T) {
+		f := setupReconciliation(t)
+		paid := validBill()
+		paid.UpdatedAt = mockTime.Add(-time.Hour)
+		sub := pastDueSub(paid, 1)
+		f.expectSubscriptions(nil, []*models.Subscription{sub})
+		f.billRepo.EXPECT().GetByID(mock.Anything, paid.ID).Return(paid, nil).Once()
+		f.subSvc.EXPECT().EndSettledDunningInternal(mock.Anything, sub.ID).Return(nil).Once()
+
+		report, err := f.svc.ReconcileInternal(t.Context())
+
+		require.NoError(t, err)
+		require.Len(t, report.Findings, 1)
+		assert.Equal(t, models.ReconcileDunningSettled, report.Findings[0].Issue)
+		assert.True(t, report.Findings[0].Repaired)
+		assert.Equal(t, 1, report.Repaired)
+	})
+
+	t.Run("a suspended subscription whose bill was paid is reactivated", func(t *testing.T) {
+		f := setupReconciliation(t)
+		paid := validBill()
+		paid.UpdatedAt = mockTime.Add(-time.Hour)
+		sub := pastDueSub(paid, 3)
+		sub.Status = models.Suspended
+		f.expectSubscriptions(nil, []*models.Subscription{sub})
+		f.billRepo.EXPECT().GetByID(mock.Anything, paid.ID).Return(paid, nil).Once()
+		f.subSvc.EXPECT().EndSettledDunningInternal(mock.Anything, sub.ID).Return(nil).Once()
+
+		report, err := f.svc.ReconcileInternal(t.Context())
+
+		require.NoError(t, err)
+		require.Len(t, report.Findings, 1)
+		assert.Equal(t, models.Suspended, report.Findings[0].Status)
+		assert.True(t, report.Findings[0].Repaired)
+	})
+
+	t.Run("a failed repair is reported without stopping the run", func(t *testing.T) {
+		f := setupReconciliation(t)
+		paid := validBill()
+		paid.UpdatedAt = mockTime.Add(-time.Hour)
+		sub := pastDueSub(paid, 1)
+		f.expectSubscriptions(nil, []*models.Subscription{sub})
+		f.billRepo.EXPECT().GetByID(mock.Anything, paid.ID).Return(paid, nil).Once()
+		f.subSvc.EXPECT().
+			EndSettledDunningInternal(mock.Anything, sub.ID).
+			Return(apperror.NewConflictError("The subscription is not retrying a payment")).
+			Once()
+
+		report, err := f.svc.ReconcileInternal(t.Context())
+
+		require.NoError(t, err)
+		require.Len(t, report.Findings, 1)
+		assert.False(t, report.Findings[0].Repaired)
+		assert.NotEmpty(t, report.Findings[0].RepairError)
+		assert.Zero(t, report.Repaired)
+	})
+
+	t.Run("past-due subscriptions without a failed bill are flagged", func(t *testing.T) {
+		f := setupReconciliation(t)
+		noDunning := renewedSub()
+		noDunning.Status = models.PastDue
+		missing := validBill()
+		missingSub := pastDueSub(missing, 1)
+		refunded := validBill()
+		refunded.Status = models.Refunded
+		refundedSub := pastDueSub(refunded, 1)
+		f.expectSubscriptions(nil, []*models.Subscription{noDunning, missingSub, refundedSub})
+		f.billRepo.EXPECT().GetByID(mock.Anything, missing.ID).Return(nil, apperror.NewNotFoundError("Document not found")).Once()
+		f.billRepo.EXPECT().GetByID(mock.Anything, refunded.ID).Return(refunded, nil).Once()
+
+		report, err := f.svc.ReconcileInternal(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, map[models.ReconciliationIssue]int{models.ReconcileDunningBroken: 3}, report.Counts)
+	})
+
+	t.Run("error - repository fails", func(t *testing.T) {
+		f := setupReconciliation(t)
+		f.subRepo.EXPECT().
+			Each(mock.Anything, mock.Anything, mock.Anything).
+			Return(apperror.NewDBError(errors.New("cursor failed"))).
+			Once()
+
+		_, err := f.svc.ReconcileInternal(t.Context())
+
+		assertAppErr(t, err, apperror.ErrDB)
+		assert.False(t, f.mr.Exists(services.ReconciliationReportKey))
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GetReconciliationReport
+// ---------------------------------------------------------------------------
+
+func Test_reconciliationService_GetReconciliationReport(t *testing.T) {
+	t.Run("success - returns the latest report", func(t *testing.T) {
+		f := setupReconciliation(t)
+		stored := &models.ReconciliationReport{
+			StartedAt:  mockTime,
+			FinishedAt: mockTime,
+			Checked:    3,
+			Counts:     map[models.ReconciliationIssue]int{models.ReconcileMissingBill: 1},
+			Findings:   []models.ReconciliationFinding{{SubscriptionID: defaultSubHex, Issue: models.ReconcileMissingBill}},
+		}
+		data, err := json.Marshal(stored)
+		require.NoError(t, err)
+		require.NoError(t, f.mr.Set(services.ReconciliationReportKey, string(data)))
+
+		got, err := f.svc.GetReconciliationReport(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, got)
+	})
+
+	t.Run("error - no run yet", func(t *testing.T) {
+		f := setupReconciliation(t)
+
+		_, err := f.svc.GetReconciliationReport(t.Context())
+
+		assertAppErr(t, err, apperror.ErrNotFound)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// EndSettledDunningInternal
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_EndSettledDunningInternal(t *testing.T) {
+	t.Run("a paid bill reactivates the subscription", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+
+		bill := validBill()
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(pastDueSub(bill, 1), nil).Once()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.PastDue, models.Active, mockTime).
+			Return(pastDueSub(bill, 1), nil).
+			Once()
+		subRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool { return s.Dunning == nil })).
+			RunAndReturn(returnSub).
+			Once()
+
+		svc := newSubService(subRepo, billRepo, nil)
+		require.NoError(t, svc.EndSettledDunningInternal(t.Context(), defaultSubID))
+	})
+
+	t.Run("a paid bill reactivates a suspended subscription until the bill ends", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+
+		bill := validBill()
+		bill.EndDate = mockTime.AddDate(0, 1, 0)
+		suspended := pastDueSub(bill, 3)
+		suspended.Status = models.Suspended
+		suspended.ValidTill = bill.StartDate
+		reactivated := pastDueSub(bill, 3)
+		reactivated.ValidTill = bill.StartDate
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(suspended, nil).Once()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.Suspended, models.Active, mockTime).
+			Return(reactivated, nil).
+			Once()
+		subRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+				return s.Dunning == nil && s.ValidTill.Equal(bill.EndDate)
+			})).
+			RunAndReturn(returnSub).
+			Once()
+
+		svc := newSubService(subRepo, billRepo, nil)
+		require.NoError(t, svc.EndSettledDunningInternal(t.Context(), defaultSubID))
+	})
+
+	t.Run("an unpaid bill is a conflict", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+
+		bill := validBill()
+		bill.Status = models.Failed
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(pastDueSub(bill, 1), nil).Once()
+		billRepo.EXPECT().GetByID(mock.Anything, bill.ID).Return(bill, nil).Once()
+
+		svc := newSubService(subRepo, billRepo, nil)
+		err := svc.EndSettledDunningInternal(t.Context(), defaultSubID)
+		assertAppErr(t, err, apperror.ErrConflict)
+	})
+
+	t.Run("an active subscription is a conflict", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+
+		svc := newSubService(subRepo, nil, nil)
+		err := svc.EndSettledDunningInternal(t.Context(), defaultSubID)
+		assertAppErr(t, err, apperror.ErrConflict)
+	})
+}
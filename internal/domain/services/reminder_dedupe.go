@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// reminderSentPrefix prefixes the markers that stop a reminder from being
+// enqueued twice.
+const reminderSentPrefix = "reminder_sent:"
+
+// ReminderSentKey returns the Redis key marking that the reminder sent
+// daysBefore the renewal of a subscription has been delivered.
+func ReminderSentKey(subscriptionID bson.ObjectID, daysBefore int) string {
+	return fmt.Sprintf("%s%s:%d", reminderSentPrefix, subscriptionID.Hex(), daysBefore)
+}
+
+// trialEndingSentPrefix prefixes the markers that stop a trial-ending reminder
+// from being enqueued twice. They are kept apart from renewal reminders, so
+// neither flow holds back the other.
+const trialEndingSentPrefix = "trial_ending_sent:"
+
+// TrialEndingSentKey returns the Redis key marking that the reminder sent
+// daysBefore the end of a subscription's trial has been delivered.
+func TrialEndingSentKey(subscriptionID bson.ObjectID, daysBefore int) string {
+	return fmt.Sprintf("%s%s:%d", trialEndingSentPrefix, subscriptionID.Hex(), daysBefore)
+}
+
+// reminderDigestPrefix prefixes the keys identifying a reminder digest in
+// channel delivery markers.
+const reminderDigestPrefix = "reminder_digest:"
+
+// ReminderDigestKey returns the key identifying the reminder digest sent by
+// the task taskID. The digest itself is not deduplicated: once it is
+// delivered, each reminder it lists is marked with its ReminderSentKey.
+func ReminderDigestKey(taskID string) string {
+	return reminderDigestPrefix + taskID
+}
+
+// channelDeliveredPrefix prefixes the markers recording that a reminder went
+// out through one channel, so retrying a reminder after another channel failed
+// does not repeat it.
+const channelDeliveredPrefix = "channel_delivered:"
+
+// ChannelDeliveredKey returns the Redis key marking that the reminder
+// deduplicated on sentKey, a ReminderSentKey, TrialEndingSentKey, or
+// ReminderDigestKey, was delivered through channel.
+func ChannelDeliveredKey(sentKey string, channel models.NotificationChannel) string {
+	return channelDeliveredPrefix + string(channel) + ":" + sentKey
+}
+
+// ReminderDedupeService inspects and clears reminder dedupe markers, so support
+// can re-send a reminder that is held back by a stale marker.
+type ReminderDedupeService interface {
+	// ListReminderMarkers returns the markers stored for a subscription.
+	ListReminderMarkers(ctx context.Context, subscriptionID string) ([]models.ReminderDedupeEntry, error)
+	// ClearReminderMarkers deletes the marker for daysBefore, or every marker
+	// of the subscription when daysBefore is nil.
+	ClearReminderMarkers(ctx context.Context, subscriptionID string, daysBefore *int) (int, error)
+}
+
+type reminderDedupeService struct {
+	redisClient redis.UniversalClient
+}
+
+// NewReminderDedupeService creates a new instance of ReminderDedupeService.
+func NewReminderDedupeService(redisClient redis.UniversalClient) ReminderDedupeService {
+	return &reminderDedupeService{redisClient}
+}
+
+// ListReminderMarkers returns the markers stored for a subscription.
+func (s *reminderDedupeService) ListReminderMarkers(
+	ctx context.Context,
+	subscriptionID string,
+) ([]models.ReminderDedupeEntry, error) {
+	id, err := bson.ObjectIDFromHex(subscriptionID)
+	if err != nil {
+		return nil, apperror.NewValidationError("Invalid subscription ID format")
+	}
+
+	keys, err := s.scanMarkers(ctx, id)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+
+	entries := make([]models.ReminderDedupeEntry, 0, len(keys))
+	for _, key := range keys {
+		ttl, err := s.redisClient.TTL(ctx, key).Result()
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to read TTL of %s: %w", key, err))
+		}
+		if ttl == -2 { // Expired since the scan.
+			continue
+		}
+
+		entry := models.ReminderDedupeEntry{Key: key}
+		entry.DaysBefore, _ = strconv.Atoi(key[strings.LastIndexByte(key, ':')+1:])
+		if ttl > 0 {
+			entry.ExpiresIn = ttl.String()
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ClearReminderMarkers deletes the marker for daysBefore, or every marker of
+// the subscription when daysBefore is nil.
+func (s *reminderDedupeService) ClearReminderMarkers(
+	ctx context.Context,
+	subscriptionID string,
+	daysBefore *int,
+) (int, error) {
+	id, err := bson.ObjectIDFromHex(subscriptionID)
+	if err != nil {
+		return 0, apperror.NewValidationError("Invalid subscription ID format")
+	}
+
+	var keys []string
+	if daysBefore != nil {
+		keys = []string{ReminderSentKey(id, *daysBefore)}
+	} else if keys, err = s.scanMarkers(ctx, id); err != nil {
+		return 0, apperror.NewInternalError(err)
+	}
+
+	// Keys are deleted one by one: in cluster mode they may live in
+	// different slots.
+	deleted := 0
+	for _, key := range keys {
+		n, err := s.redisClient.Del(ctx, key).Result()
+		if err != nil {
+			return deleted, apperror.NewInternalError(fmt.Errorf("failed to delete %s: %w", key, err))
+		}
+		deleted += int(n)
+	}
+
+	slog.InfoContext(ctx, "Reminder dedupe markers cleared",
+		logattr.SubscriptionID(subscriptionID),
+		logattr.Total(deleted),
+	)
+	return deleted, nil
+}
+
+// scanMarkers returns the marker keys of a subscription. A cluster is scanned
+// on every master, since keys are spread across nodes.
+func (s *reminderDedupeService) scanMarkers(ctx context.Context, id bson.ObjectID) ([]string, error) {
+	pattern := fmt.Sprintf("%s%s:*", reminderSentPrefix, id.Hex())
+
+	scan := func(ctx context.Context, client redis.UniversalClient) ([]string, error) {
+		var keys []string
+		iter := client.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		return keys, iter.Err()
+	}
+
+	var (
+		keys []string
+		err  error
+	)
+	if cluster, ok := s.redisClient.(*redis.ClusterClient); ok {
+		var mu sync.Mutex
+		err = cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			nodeKeys, err := scan(ctx, node)
+			mu.Lock()
+			keys = append(keys, nodeKeys...)
+			mu.Unlock()
+			return err
+		})
+	} else {
+		keys, err = scan(ctx, s.redisClient)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan reminder markers: %w", err)
+	}
+
+	slices.Sort(keys)
+	return keys, nil
+}
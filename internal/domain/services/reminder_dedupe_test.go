@@ -0,0 +1,73 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func setupReminderDedupe(t *testing.T) (services.ReminderDedupeService, *redis.Client) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	return services.NewReminderDedupeService(rdb), rdb
+}
+
+func TestReminderDedupeService_ListReminderMarkers(t *testing.T) {
+	svc, rdb := setupReminderDedupe(t)
+	ctx := t.Context()
+	subID := bson.NewObjectID()
+	otherID := bson.NewObjectID()
+
+	require.NoError(t, rdb.Set(ctx, services.ReminderSentKey(subID, 7), "", 24*time.Hour).Err())
+	require.NoError(t, rdb.Set(ctx, services.ReminderSentKey(subID, 1), "", 24*time.Hour).Err())
+	require.NoError(t, rdb.Set(ctx, services.ReminderSentKey(otherID, 7), "", 24*time.Hour).Err())
+
+	entries, err := svc.ListReminderMarkers(ctx, subID.Hex())
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, 1, entries[0].DaysBefore)
+	assert.Equal(t, 7, entries[1].DaysBefore)
+	assert.Equal(t, "24h0m0s", entries[0].ExpiresIn)
+
+	_, err = svc.ListReminderMarkers(ctx, "not-an-id")
+	assert.Error(t, err)
+}
+
+func TestReminderDedupeService_ClearReminderMarkers(t *testing.T) {
+	svc, rdb := setupReminderDedupe(t)
+	ctx := t.Context()
+	subID := bson.NewObjectID()
+
+	for _, days := range []int{7, 3, 1} {
+		require.NoError(t, rdb.Set(ctx, services.ReminderSentKey(subID, days), "", 24*time.Hour).Err())
+	}
+
+	// A single marker.
+	days := 3
+	deleted, err := svc.ClearReminderMarkers(ctx, subID.Hex(), &days)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.Zero(t, rdb.Exists(ctx, services.ReminderSentKey(subID, 3)).Val())
+
+	// The remaining markers.
+	deleted, err = svc.ClearReminderMarkers(ctx, subID.Hex(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	entries, err := svc.ListReminderMarkers(ctx, subID.Hex())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// reminderSnoozedPrefix prefixes the markers that hold back the reminders of
+// a renewal cycle.
+const reminderSnoozedPrefix = "reminder_snoozed:"
+
+// ReminderSnoozedKey returns the Redis key marking that the reminders for the
+// renewal of a subscription on renewsOn are snoozed. Keying on the renewal
+// date scopes a snooze to a single cycle.
+func ReminderSnoozedKey(subscriptionID bson.ObjectID, renewsOn time.Time) string {
+	return fmt.Sprintf("%s%s:%d", reminderSnoozedPrefix, subscriptionID.Hex(), renewsOn.Unix())
+}
+
+// ReminderSnoozeService lets users hold back the renewal reminders of a
+// subscription.
+type ReminderSnoozeService interface {
+	// SnoozeReminders holds back reminders for the given number of days, or
+	// until the renewal when days is zero.
+	SnoozeReminders(ctx context.Context, id, claimedUserID string, days int) (*models.ReminderSnoozeResponse, error)
+	// UnsnoozeReminders lifts a snooze, so the remaining reminders of the
+	// cycle are sent again.
+	UnsnoozeReminders(ctx context.Context, id, claimedUserID string) error
+}
+
+type reminderSnoozeService struct {
+	subscriptionService SubscriptionServiceExternal
+	redisClient         redis.UniversalClient
+	getTime             clock.NowFn
+}
+
+// NewReminderSnoozeService creates a new instance of ReminderSnoozeService.
+func NewReminderSnoozeService(
+	subscriptionService SubscriptionServiceExternal,
+	redisClient redis.UniversalClient,
+	nowFn clock.NowFn,
+) ReminderSnoozeService {
+	return &reminderSnoozeService{
+		subscriptionService,
+		redisClient,
+		nowFn,
+	}
+}
+
+// SnoozeReminders stores a snooze marker that expires at the end of the
+// snooze. A snooze never outlives the current renewal cycle, so the reminders
+// of the next renewal are sent as usual. Snoozing again replaces the previous
+// snooze.
+func (s *reminderSnoozeService) SnoozeReminders(
+	ctx context.Context,
+	id, claimedUserID string,
+	days int,
+) (*models.ReminderSnoozeResponse, error) {
+	if days < 0 {
+		return nil, apperror.NewValidationError("days must be positive")
+	}
+
+	subscription, err := s.subscriptionService.GetSubscriptionByID(ctx, id, claimedUserID, false)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Status != models.Active {
+		return nil, apperror.NewConflictError("Only active subscriptions send reminders")
+	}
+
+	now := s.getTime()
+	until := subscription.ValidTill
+	if days > 0 && now.AddDate(0, 0, days).Before(until) {
+		until = now.AddDate(0, 0, days)
+	}
+	if !until.After(now) {
+		return nil, apperror.NewConflictError("Subscription is already due for renewal")
+	}
+
+	key := ReminderSnoozedKey(subscription.ID, subscription.ValidTill)
+	if err = s.redisClient.Set(ctx, key, "", until.Sub(now)).Err(); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to store reminder snooze: %w", err))
+	}
+
+	slog.InfoContext(ctx, "Reminders snoozed",
+		logattr.SubscriptionID(id),
+		logattr.ValidTill(subscription.ValidTill),
+	)
+	return &models.ReminderSnoozeResponse{
+		SubscriptionID: subscription.ID.Hex(),
+		RenewsOn:       subscription.ValidTill,
+		SnoozedUntil:   until,
+	}, nil
+}
+
+// UnsnoozeReminders deletes the snooze marker of the current renewal cycle.
+// Lifting a snooze that does not exist is not an error.
+func (s *reminderSnoozeService) UnsnoozeReminders(ctx context.Context, id, claimedUserID string) error {
+	subscription, err := s.subscriptionService.GetSubscriptionByID(ctx, id, claimedUserID, false)
+	if err != nil {
+		return err
+	}
+
+	key := ReminderSnoozedKey(subscription.ID, subscription.ValidTill)
+	if err = s.redisClient.Del(ctx, key).Err(); err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to delete reminder snooze: %w", err))
+	}
+
+	slog.InfoContext(ctx, "Reminders unsnoozed",
+		logattr.SubscriptionID(id),
+		logattr.ValidTill(subscription.ValidTill),
+	)
+	return nil
+}
@@ -0,0 +1,140 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func setupReminderSnooze(t *testing.T) (
+	services.ReminderSnoozeService,
+	*svcmocks.MockSubscriptionServiceExternal,
+	*redis.Client,
+) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	subSvc := svcmocks.NewMockSubscriptionServiceExternal(t)
+	return services.NewReminderSnoozeService(subSvc, rdb, func() time.Time { return mockTime }), subSvc, rdb
+}
+
+// ---------------------------------------------------------------------------
+// SnoozeReminders
+// ---------------------------------------------------------------------------
+
+func TestReminderSnoozeService_SnoozeReminders(t *testing.T) {
+	key := services.ReminderSnoozedKey(defaultSubID, mockOneMonthLater)
+
+	t.Run("snoozes for the given days", func(t *testing.T) {
+		svc, subSvc, rdb := setupReminderSnooze(t)
+		subSvc.EXPECT().GetSubscriptionByID(mock.Anything, defaultSubID.Hex(), defaultUserHex, false).Return(validSub(), nil).Once()
+
+		got, err := svc.SnoozeReminders(t.Context(), defaultSubID.Hex(), defaultUserHex, 3)
+		require.NoError(t, err)
+		assert.Equal(t, mockOneMonthLater, got.RenewsOn)
+		assert.Equal(t, mockTime.AddDate(0, 0, 3), got.SnoozedUntil)
+
+		ttl, err := rdb.TTL(t.Context(), key).Result()
+		require.NoError(t, err)
+		assert.Equal(t, 72*time.Hour, ttl)
+	})
+
+	t.Run("snoozes until the renewal", func(t *testing.T) {
+		svc, subSvc, rdb := setupReminderSnooze(t)
+		subSvc.EXPECT().GetSubscriptionByID(mock.Anything, defaultSubID.Hex(), defaultUserHex, false).Return(validSub(), nil).Once()
+
+		got, err := svc.SnoozeReminders(t.Context(), defaultSubID.Hex(), defaultUserHex, 0)
+		require.NoError(t, err)
+		assert.Equal(t, mockOneMonthLater, got.SnoozedUntil)
+
+		ttl, err := rdb.TTL(t.Context(), key).Result()
+		require.NoError(t, err)
+		assert.Equal(t, mockOneMonthLater.Sub(mockTime), ttl)
+	})
+
+	t.Run("never outlives the renewal cycle", func(t *testing.T) {
+		svc, subSvc, _ := setupReminderSnooze(t)
+		subSvc.EXPECT().GetSubscriptionByID(mock.Anything, defaultSubID.Hex(), defaultUserHex, false).Return(validSub(), nil).Once()
+
+		got, err := svc.SnoozeReminders(t.Context(), defaultSubID.Hex(), defaultUserHex, 90)
+		require.NoError(t, err)
+		assert.Equal(t, mockOneMonthLater, got.SnoozedUntil)
+	})
+
+	t.Run("rejects a canceled subscription", func(t *testing.T) {
+		svc, subSvc, _ := setupReminderSnooze(t)
+		subSvc.EXPECT().GetSubscriptionByID(mock.Anything, defaultSubID.Hex(), defaultUserHex, false).Return(validCanceledSub(), nil).Once()
+
+		_, err := svc.SnoozeReminders(t.Context(), defaultSubID.Hex(), defaultUserHex, 3)
+		assertAppErr(t, err, apperror.ErrConflict)
+	})
+
+	t.Run("rejects a subscription due for renewal", func(t *testing.T) {
+		svc, subSvc, _ := setupReminderSnooze(t)
+		due := validSub()
+		due.ValidTill = mockTime.Add(-time.Hour)
+		subSvc.EXPECT().GetSubscriptionByID(mock.Anything, defaultSubID.Hex(), defaultUserHex, false).Return(due, nil).Once()
+
+		_, err := svc.SnoozeReminders(t.Context(), defaultSubID.Hex(), defaultUserHex, 3)
+		assertAppErr(t, err, apperror.ErrConflict)
+	})
+
+	t.Run("rejects negative days", func(t *testing.T) {
+		svc, _, _ := setupReminderSnooze(t)
+
+		_, err := svc.SnoozeReminders(t.Context(), defaultSubID.Hex(), defaultUserHex, -1)
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+
+	t.Run("propagates ownership errors", func(t *testing.T) {
+		svc, subSvc, _ := setupReminderSnooze(t)
+		subSvc.EXPECT().
+			GetSubscriptionByID(mock.Anything, defaultSubID.Hex(), defaultUserHex, false).
+			Return(nil, apperror.NewForbiddenError("You are not allowed to access this subscription")).
+			Once()
+
+		_, err := svc.SnoozeReminders(t.Context(), defaultSubID.Hex(), defaultUserHex, 3)
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// UnsnoozeReminders
+// ---------------------------------------------------------------------------
+
+func TestReminderSnoozeService_UnsnoozeReminders(t *testing.T) {
+	svc, subSvc, rdb := setupReminderSnooze(t)
+	key := services.ReminderSnoozedKey(defaultSubID, mockOneMonthLater)
+	require.NoError(t, rdb.Set(t.Context(), key, "", time.Hour).Err())
+	subSvc.EXPECT().GetSubscriptionByID(mock.Anything, defaultSubID.Hex(), defaultUserHex, false).Return(validSub(), nil).Twice()
+
+	require.NoError(t, svc.UnsnoozeReminders(t.Context(), defaultSubID.Hex(), defaultUserHex))
+	exists, err := rdb.Exists(t.Context(), key).Result()
+	require.NoError(t, err)
+	assert.Zero(t, exists)
+
+	// Lifting it again is a no-op.
+	require.NoError(t, svc.UnsnoozeReminders(t.Context(), defaultSubID.Hex(), defaultUserHex))
+}
+
+func TestReminderSnoozedKey(t *testing.T) {
+	id := bson.NewObjectID()
+
+	assert.Equal(t, "reminder_snoozed:"+id.Hex()+":1739620800",
+		services.ReminderSnoozedKey(id, time.Date(2025, 2, 15, 12, 0, 0, 0, time.UTC)))
+}
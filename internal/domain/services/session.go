@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type SessionServiceExternal interface {
+	// GetSessions returns the sessions of the user, most recently used
+	// first, marking the one of the caller's token. Administrators may view
+	// those of any user.
+	GetSessions(ctx context.Context, id, claimedUserID string) ([]*models.Session, error)
+	// RevokeSession signs the device of the session out: every token of the
+	// session stops validating. Administrators may revoke those of any user.
+	RevokeSession(ctx context.Context, id, claimedUserID, sessionID string) error
+}
+
+type SessionServiceInternal interface {
+	// StartSessionInternal records a session started by a login, stamped
+	// with the current time. Beyond the configured limit, the least
+	// recently used sessions of the user are revoked first.
+	StartSessionInternal(ctx context.Context, session *models.Session) error
+	// RefreshSessionInternal records a refresh of the session from client,
+	// whose new refresh token expires at expiresAt. It returns an
+	// unauthorized error when the session has been revoked.
+	RefreshSessionInternal(
+		ctx context.Context,
+		sessionID string,
+		userID bson.ObjectID,
+		client models.SessionClient,
+		expiresAt time.Time,
+	) error
+	// EndSessionInternal deletes the session of a logout, whose tokens the
+	// caller revokes.
+	EndSessionInternal(ctx context.Context, sessionID string, userID bson.ObjectID) error
+	// RevokeSessionsInternal signs every device of the user out and returns
+	// how many sessions were revoked.
+	RevokeSessionsInternal(ctx context.Context, userID bson.ObjectID) (int, error)
+}
+
+type SessionService interface {
+	SessionServiceExternal
+	SessionServiceInternal
+}
+
+// SessionConfig holds the settings for signed-in devices.
+type SessionConfig struct {
+	// MaxConcurrent is how many sessions a user may have at once; 0 allows
+	// any number.
+	MaxConcurrent int `mapstructure:"max_concurrent"`
+}
+
+type sessionService struct {
+	sessionRepository repositories.SessionRepository
+	jwtService        JWTService
+	config            SessionConfig
+	getTime           clock.NowFn
+}
+
+// NewSessionService creates a new instance of SessionService.
+func NewSessionService(
+	sessionRepository repositories.SessionRepository,
+	jwtService JWTService,
+	config SessionConfig,
+	nowFn clock.NowFn,
+) SessionService {
+	return &sessionService{
+		sessionRepository,
+		jwtService,
+		config,
+		nowFn,
+	}
+}
+
+func (s *sessionService) GetSessions(ctx context.Context, id, claimedUserID string) ([]*models.Session, error) {
+	if role, _ := appctx.GetUserRole(ctx); id != claimedUserID && role != string(models.AdminRole) {
+		return nil, apperror.NewForbiddenError("You can only view your own sessions")
+	}
+	userID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid user ID")
+	}
+
+	sessions, err := s.sessionRepository.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if current, ok := appctx.GetSessionID(ctx); ok {
+		for _, session := range sessions {
+			session.Current = session.ID.Hex() == current
+		}
+	}
+	return sessions, nil
+}
+
+func (s *sessionService) RevokeSession(ctx context.Context, id, claimedUserID, sessionID string) error {
+	if role, _ := appctx.GetUserRole(ctx); id != claimedUserID && role != string(models.AdminRole) {
+		return apperror.NewForbiddenError("You can only revoke your own sessions")
+	}
+	userID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return apperror.NewBadRequestError("Invalid user ID")
+	}
+	sessionObjID, err := bson.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return apperror.NewBadRequestError("Invalid session ID")
+	}
+
+	session, err := s.sessionRepository.GetByID(ctx, sessionObjID, userID)
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			return apperror.NewNotFoundError("Session not found")
+		}
+		return err
+	}
+	if err = s.revoke(ctx, session); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Session revoked",
+		logattr.UserID(id),
+		logattr.SessionID(sessionID),
+	)
+	return nil
+}
+
+// StartSessionInternal makes room before recording the session, so a
+// failed eviction leaves no session without tokens behind.
+func (s *sessionService) StartSessionInternal(ctx context.Context, session *models.Session) error {
+	if s.config.MaxConcurrent > 0 {
+		sessions, err := s.sessionRepository.ListByUserID(ctx, session.UserID)
+		if err != nil {
+			return err
+		}
+		for i := s.config.MaxConcurrent - 1; i < len(sessions); i++ {
+			if err = s.revoke(ctx, sessions[i]); err != nil {
+				return err
+			}
+			slog.InfoContext(ctx, "Session evicted by a new login",
+				logattr.UserID(session.UserID.Hex()),
+				logattr.SessionID(sessions[i].ID.Hex()),
+			)
+		}
+	}
+
+	now := s.getTime()
+	session.CreatedAt = now
+	session.LastUsedAt = now
+	return s.sessionRepository.Create(ctx, session)
+}
+
+func (s *sessionService) RefreshSessionInternal(
+	ctx context.Context,
+	sessionID string,
+	userID bson.ObjectID,
+	client models.SessionClient,
+	expiresAt time.Time,
+) error {
+	id, err := bson.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return apperror.NewUnauthorizedError("Invalid session ID in token")
+	}
+	session, err := s.sessionRepository.GetByID(ctx, id, userID)
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			return apperror.NewUnauthorizedError("Session has been revoked")
+		}
+		return err
+	}
+
+	session.LastUsedAt = s.getTime()
+	session.ExpiresAt = expiresAt
+	session.IP = client.IP
+	session.UserAgent = client.UserAgent
+	return s.sessionRepository.Update(ctx, session)
+}
+
+// EndSessionInternal ignores sessions that are already gone.
+func (s *sessionService) EndSessionInternal(ctx context.Context, sessionID string, userID bson.ObjectID) error {
+	id, err := bson.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return nil
+	}
+	return s.deleteSession(ctx, id, userID)
+}
+
+// RevokeSessionsInternal stops at the first failure, leaving the remaining
+// sessions listed so that a retry revokes them.
+func (s *sessionService) RevokeSessionsInternal(ctx context.Context, userID bson.ObjectID) (int, error) {
+	sessions, err := s.sessionRepository.ListByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	for i, session := range sessions {
+		if err = s.revoke(ctx, session); err != nil {
+			return i, err
+		}
+	}
+	return len(sessions), nil
+}
+
+// revoke rejects the tokens of the session before deleting it, so a failure
+// leaves the session listed rather than its tokens valid.
+func (s *sessionService) revoke(ctx context.Context, session *models.Session) error {
+	if err := s.jwtService.RevokeSession(ctx, session.ID.Hex(), session.ExpiresAt); err != nil {
+		return apperror.NewInternalError(err).
+			WithLogAttributes(logattr.SessionID(session.ID.Hex()))
+	}
+	return s.deleteSession(ctx, session.ID, session.UserID)
+}
+
+// deleteSession deletes the session, treating one already deleted, e.g. by
+// a concurrent revocation, as done.
+func (s *sessionService) deleteSession(ctx context.Context, id, userID bson.ObjectID) error {
+	err := s.sessionRepository.Delete(ctx, id, userID)
+	if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,290 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newSessionService(
+	repo *repomocks.MockSessionRepository,
+	jwtSvc *svcmocks.MockJWTService,
+	maxConcurrent int,
+) services.SessionService {
+	return services.NewSessionService(
+		repo,
+		jwtSvc,
+		services.SessionConfig{MaxConcurrent: maxConcurrent},
+		func() time.Time { return mockTime },
+	)
+}
+
+func newTestSession(lastUsedAt time.Time) *models.Session {
+	return &models.Session{
+		ID:         bson.NewObjectID(),
+		UserID:     defaultUserID,
+		LastUsedAt: lastUsedAt,
+		ExpiresAt:  mockTime.Add(24 * time.Hour),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GetSessions
+// ---------------------------------------------------------------------------
+
+func Test_sessionService_GetSessions(t *testing.T) {
+	current, other := newTestSession(mockTime), newTestSession(mockTime.Add(-time.Hour))
+	userCtx := appctx.WithSessionID(context.Background(), current.ID.Hex())
+	adminCtx := appctx.WithUserRole(context.Background(), string(models.AdminRole))
+
+	t.Run("success - marks the session of the caller", func(t *testing.T) {
+		repo := repomocks.NewMockSessionRepository(t)
+		repo.EXPECT().ListByUserID(mock.Anything, defaultUserID).Return([]*models.Session{current, other}, nil).Once()
+
+		got, err := newSessionService(repo, nil, 0).GetSessions(userCtx, defaultUserHex, defaultUserHex)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.True(t, got[0].Current)
+		assert.False(t, got[1].Current)
+	})
+
+	t.Run("success - administrators view any user's sessions", func(t *testing.T) {
+		otherUser := bson.NewObjectID()
+		repo := repomocks.NewMockSessionRepository(t)
+		repo.EXPECT().ListByUserID(mock.Anything, otherUser).Return(nil, nil).Once()
+
+		_, err := newSessionService(repo, nil, 0).GetSessions(adminCtx, otherUser.Hex(), defaultUserHex)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - another user's sessions", func(t *testing.T) {
+		_, err := newSessionService(repomocks.NewMockSessionRepository(t), nil, 0).
+			GetSessions(userCtx, bson.NewObjectID().Hex(), defaultUserHex)
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// RevokeSession
+// ---------------------------------------------------------------------------
+
+func Test_sessionService_RevokeSession(t *testing.T) {
+	session := newTestSession(mockTime)
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		id          string
+		sessionID   string
+		setupMocks  func(repo *repomocks.MockSessionRepository, jwtSvc *svcmocks.MockJWTService)
+		wantErrCode apperror.ErrorCode
+	}{
+		{
+			name:      "success - rejects the tokens and deletes the session",
+			ctx:       context.Background(),
+			id:        defaultUserHex,
+			sessionID: session.ID.Hex(),
+			setupMocks: func(repo *repomocks.MockSessionRepository, jwtSvc *svcmocks.MockJWTService) {
+				repo.EXPECT().GetByID(mock.Anything, session.ID, defaultUserID).Return(session, nil).Once()
+				jwtSvc.EXPECT().RevokeSession(mock.Anything, session.ID.Hex(), session.ExpiresAt).Return(nil).Once()
+				repo.EXPECT().Delete(mock.Anything, session.ID, defaultUserID).Return(nil).Once()
+			},
+		},
+		{
+			name:        "error - another user's session",
+			ctx:         context.Background(),
+			id:          bson.NewObjectID().Hex(),
+			sessionID:   session.ID.Hex(),
+			setupMocks:  func(repo *repomocks.MockSessionRepository, jwtSvc *svcmocks.MockJWTService) {},
+			wantErrCode: apperror.ErrForbidden,
+		},
+		{
+			name:        "error - invalid session ID",
+			ctx:         context.Background(),
+			id:          defaultUserHex,
+			sessionID:   "not-an-id",
+			setupMocks:  func(repo *repomocks.MockSessionRepository, jwtSvc *svcmocks.MockJWTService) {},
+			wantErrCode: apperror.ErrBadRequest,
+		},
+		{
+			name:      "error - session not found",
+			ctx:       context.Background(),
+			id:        defaultUserHex,
+			sessionID: session.ID.Hex(),
+			setupMocks: func(repo *repomocks.MockSessionRepository, jwtSvc *svcmocks.MockJWTService) {
+				repo.EXPECT().GetByID(mock.Anything, session.ID, defaultUserID).
+					Return(nil, apperror.NewNotFoundError("Document not found")).Once()
+			},
+			wantErrCode: apperror.ErrNotFound,
+		},
+		{
+			name:      "error - the session is kept when its tokens cannot be revoked",
+			ctx:       context.Background(),
+			id:        defaultUserHex,
+			sessionID: session.ID.Hex(),
+			setupMocks: func(repo *repomocks.MockSessionRepository, jwtSvc *svcmocks.MockJWTService) {
+				repo.EXPECT().GetByID(mock.Anything, session.ID, defaultUserID).Return(session, nil).Once()
+				jwtSvc.EXPECT().RevokeSession(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("redis down")).Once()
+			},
+			wantErrCode: apperror.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repomocks.NewMockSessionRepository(t)
+			jwtSvc := svcmocks.NewMockJWTService(t)
+			tt.setupMocks(repo, jwtSvc)
+
+			err := newSessionService(repo, jwtSvc, 0).RevokeSession(tt.ctx, tt.id, defaultUserHex, tt.sessionID)
+
+			if tt.wantErrCode == "" {
+				require.NoError(t, err)
+				return
+			}
+			assertAppErr(t, err, tt.wantErrCode)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// StartSessionInternal
+// ---------------------------------------------------------------------------
+
+func Test_sessionService_StartSessionInternal(t *testing.T) {
+	recent, older, oldest := newTestSession(mockTime), newTestSession(mockTime.Add(-time.Hour)), newTestSession(mockTime.Add(-2*time.Hour))
+
+	t.Run("success - evicts the least recently used sessions beyond the limit", func(t *testing.T) {
+		repo := repomocks.NewMockSessionRepository(t)
+		jwtSvc := svcmocks.NewMockJWTService(t)
+		repo.EXPECT().ListByUserID(mock.Anything, defaultUserID).Return([]*models.Session{recent, older, oldest}, nil).Once()
+		for _, evicted := range []*models.Session{older, oldest} {
+			jwtSvc.EXPECT().RevokeSession(mock.Anything, evicted.ID.Hex(), evicted.ExpiresAt).Return(nil).Once()
+			// An eviction racing a logout finds the session gone.
+			repo.EXPECT().Delete(mock.Anything, evicted.ID, defaultUserID).
+				Return(apperror.NewNotFoundError("Document not found")).Once()
+		}
+		repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(s *models.Session) bool {
+			return s.CreatedAt.Equal(mockTime) && s.LastUsedAt.Equal(mockTime)
+		})).Return(nil).Once()
+
+		err := newSessionService(repo, jwtSvc, 2).StartSessionInternal(t.Context(), &models.Session{ID: bson.NewObjectID(), UserID: defaultUserID})
+		require.NoError(t, err)
+	})
+
+	t.Run("success - no limit", func(t *testing.T) {
+		repo := repomocks.NewMockSessionRepository(t)
+		repo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := newSessionService(repo, svcmocks.NewMockJWTService(t), 0).StartSessionInternal(t.Context(), &models.Session{UserID: defaultUserID})
+		require.NoError(t, err)
+	})
+
+	t.Run("error - nothing is recorded when an eviction fails", func(t *testing.T) {
+		repo := repomocks.NewMockSessionRepository(t)
+		jwtSvc := svcmocks.NewMockJWTService(t)
+		repo.EXPECT().ListByUserID(mock.Anything, defaultUserID).Return([]*models.Session{recent}, nil).Once()
+		jwtSvc.EXPECT().RevokeSession(mock.Anything, recent.ID.Hex(), recent.ExpiresAt).Return(errors.New("redis down")).Once()
+
+		err := newSessionService(repo, jwtSvc, 1).StartSessionInternal(t.Context(), &models.Session{UserID: defaultUserID})
+		assertAppErr(t, err, apperror.ErrInternal)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// RefreshSessionInternal
+// ---------------------------------------------------------------------------
+
+func Test_sessionService_RefreshSessionInternal(t *testing.T) {
+	client := models.SessionClient{IP: "198.51.100.1", UserAgent: "curl/8.0"}
+	expiresAt := mockTime.Add(48 * time.Hour)
+
+	t.Run("success - records the latest use", func(t *testing.T) {
+		session := newTestSession(mockTime.Add(-time.Hour))
+		session.Device = "Phone"
+		repo := repomocks.NewMockSessionRepository(t)
+		repo.EXPECT().GetByID(mock.Anything, session.ID, defaultUserID).Return(session, nil).Once()
+		repo.EXPECT().Update(mock.Anything, &models.Session{
+			ID:         session.ID,
+			UserID:     defaultUserID,
+			Device:     "Phone",
+			IP:         client.IP,
+			UserAgent:  client.UserAgent,
+			LastUsedAt: mockTime,
+			ExpiresAt:  expiresAt,
+		}).Return(nil).Once()
+
+		err := newSessionService(repo, nil, 0).RefreshSessionInternal(t.Context(), session.ID.Hex(), defaultUserID, client, expiresAt)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - revoked session", func(t *testing.T) {
+		id := bson.NewObjectID()
+		repo := repomocks.NewMockSessionRepository(t)
+		repo.EXPECT().GetByID(mock.Anything, id, defaultUserID).Return(nil, apperror.NewNotFoundError("Document not found")).Once()
+
+		err := newSessionService(repo, nil, 0).RefreshSessionInternal(t.Context(), id.Hex(), defaultUserID, client, expiresAt)
+		assertAppErr(t, err, apperror.ErrUnauthorized)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// EndSessionInternal
+// ---------------------------------------------------------------------------
+
+func Test_sessionService_EndSessionInternal(t *testing.T) {
+	id := bson.NewObjectID()
+	repo := repomocks.NewMockSessionRepository(t)
+	repo.EXPECT().Delete(mock.Anything, id, defaultUserID).Return(apperror.NewNotFoundError("Document not found")).Once()
+
+	svc := newSessionService(repo, nil, 0)
+	require.NoError(t, svc.EndSessionInternal(t.Context(), id.Hex(), defaultUserID), "a session already gone is ended")
+	require.NoError(t, svc.EndSessionInternal(t.Context(), "legacy", defaultUserID))
+}
+
+// ---------------------------------------------------------------------------
+// RevokeSessionsInternal
+// ---------------------------------------------------------------------------
+
+func Test_sessionService_RevokeSessionsInternal(t *testing.T) {
+	t.Run("revokes every session of the user", func(t *testing.T) {
+		first, second := newTestSession(mockTime), newTestSession(mockTime.Add(-time.Hour))
+		repo := repomocks.NewMockSessionRepository(t)
+		jwtSvc := svcmocks.NewMockJWTService(t)
+		repo.EXPECT().ListByUserID(mock.Anything, defaultUserID).Return([]*models.Session{first, second}, nil).Once()
+		for _, session := range []*models.Session{first, second} {
+			jwtSvc.EXPECT().RevokeSession(mock.Anything, session.ID.Hex(), session.ExpiresAt).Return(nil).Once()
+			repo.EXPECT().Delete(mock.Anything, session.ID, defaultUserID).Return(nil).Once()
+		}
+
+		revoked, err := newSessionService(repo, jwtSvc, 0).RevokeSessionsInternal(t.Context(), defaultUserID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, revoked)
+	})
+
+	t.Run("stops at a session whose tokens cannot be revoked", func(t *testing.T) {
+		first, second := newTestSession(mockTime), newTestSession(mockTime.Add(-time.Hour))
+		repo := repomocks.NewMockSessionRepository(t)
+		jwtSvc := svcmocks.NewMockJWTService(t)
+		repo.EXPECT().ListByUserID(mock.Anything, defaultUserID).Return([]*models.Session{first, second}, nil).Once()
+		jwtSvc.EXPECT().RevokeSession(mock.Anything, first.ID.Hex(), first.ExpiresAt).Return(errors.New("redis unavailable")).Once()
+
+		revoked, err := newSessionService(repo, jwtSvc, 0).RevokeSessionsInternal(t.Context(), defaultUserID)
+
+		assertAppErr(t, err, apperror.ErrInternal)
+		assert.Zero(t, revoked)
+	})
+}
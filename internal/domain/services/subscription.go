@@ -1,26 +1,92 @@
 package services
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
 	"github.com/anuragthepathak/subscription-management/internal/core/clock"
 	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/core/otelattr"
+	"github.com/anuragthepathak/subscription-management/internal/currency"
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
 	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/anuragthepathak/subscription-management/internal/payments"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+// MaxArchivedListingWindow caps offset+limit of listings that merge in
+// archived subscriptions, as both collections are read up to the end of the
+// page.
+const MaxArchivedListingWindow = 1000
+
 type SubscriptionServiceExternal interface {
 	CreateSubscription(context.Context, *models.Subscription, string) (*models.Subscription, error)
-	GetAllSubscriptions(context.Context) ([]*models.Subscription, error)
-	GetSubscriptionByID(context.Context, string, string) (*models.Subscription, error)
-	GetSubscriptionsByUserID(context.Context, string, string) ([]*models.Subscription, error)
+	// FindDuplicateSubscription returns a running subscription of the user
+	// that a new subscription would likely duplicate, one with the same
+	// models.NameKey, or nil if there is none.
+	FindDuplicateSubscription(ctx context.Context, subscription *models.Subscription, claimedUserID string) (*models.Subscription, error)
+	// GetDuplicateSubscriptions groups the user's running subscriptions that
+	// share a models.NameKey. Groups are ordered by their oldest
+	// subscription.
+	GetDuplicateSubscriptions(ctx context.Context, claimedUserID string) ([]*models.DuplicateGroup, error)
+	// GetAllSubscriptions returns a page of every user's subscriptions.
+	GetAllSubscriptions(context.Context, models.SubscriptionQuery) (*models.SubscriptionPage, error)
+	GetSubscriptionByID(context.Context, string, string, bool) (*models.Subscription, error)
+	// SearchSubscriptions returns a page of the user's subscriptions whose
+	// name, tags, or notes match the search, best match first, with the
+	// matching words highlighted.
+	SearchSubscriptions(ctx context.Context, claimedUserID string, search models.SubscriptionSearch) (*models.SubscriptionSearchPage, error)
+	// SearchAllSubscriptions is SearchSubscriptions across every user.
+	SearchAllSubscriptions(context.Context, models.SubscriptionSearch) (*models.SubscriptionSearchPage, error)
+	// GetSubscriptionsByUserID returns a page of the user's subscriptions,
+	// with archived ones merged in when includeArchived is set. Merged pages
+	// must end within MaxArchivedListingWindow.
+	GetSubscriptionsByUserID(ctx context.Context, id string, claimedUserID string, includeArchived bool, query models.SubscriptionQuery) (*models.SubscriptionPage, error)
 	DeleteSubscription(context.Context, string, string) error
 	CancelSubscription(context.Context, string, string) (*models.Subscription, error)
+	// PauseSubscription stops reminders and renewals of an active
+	// subscription until it is resumed.
+	PauseSubscription(ctx context.Context, id string, claimedUserID string) (*models.Subscription, error)
+	// ResumeSubscription reactivates a paused subscription, extending its
+	// validity and current bill by the time it was paused.
+	ResumeSubscription(ctx context.Context, id string, claimedUserID string) (*models.Subscription, error)
+	// RecordUsage reports that the subscription was used at usedAt; a zero
+	// usedAt means now.
+	RecordUsage(ctx context.Context, id string, claimedUserID string, usedAt time.Time) (*models.Subscription, error)
+	// UpdatePrice changes the price of an active subscription, recording the
+	// change in its price history. A future effectiveAt schedules the change
+	// for the first renewal at or after it; a zero one means now.
+	UpdatePrice(ctx context.Context, id string, claimedUserID string, price int64, effectiveAt time.Time) (*models.Subscription, error)
+	// GetPriceHistory returns the price changes of a subscription, oldest
+	// first. Archived subscriptions are included.
+	GetPriceHistory(ctx context.Context, id string, claimedUserID string) ([]*models.PriceChange, error)
+	// UpdateSubscription changes the details of a subscription: its name,
+	// category, tags, notes, and reminder days.
+	UpdateSubscription(ctx context.Context, id string, claimedUserID string, update *models.SubscriptionUpdateRequest) (*models.Subscription, error)
+	// GetPriceChangeSummary lists the price increases of the user's
+	// subscriptions in the given month, formatted as YYYY-MM. An empty month
+	// means the current one.
+	GetPriceChangeSummary(ctx context.Context, claimedUserID string, month string) (*models.PriceChangeSummaryResponse, error)
+	// GetSummary returns the spend, status counts, and upcoming renewals of
+	// the user's subscriptions. When target is set, spend is also converted
+	// into it.
+	GetSummary(ctx context.Context, claimedUserID string, target models.Currency) (*models.SubscriptionSummaryResponse, error)
+	// GetLifecycleEvents returns the timeline of a subscription, oldest
+	// first. Archived subscriptions are included.
+	GetLifecycleEvents(ctx context.Context, id string, claimedUserID string) ([]*models.LifecycleEvent, error)
+	// UpdateBillStatus marks a renewal bill failed or paid, starting or
+	// ending dunning of its subscription. It is meant for administrators and
+	// payment providers, so ownership is not checked.
+	UpdateBillStatus(ctx context.Context, id string, status models.PaymentStatus) (*models.Bill, error)
 }
 
 type SubscriptionServiceInternal interface {
@@ -31,6 +97,61 @@ type SubscriptionServiceInternal interface {
 	FetchCanceledExpiredSubscriptionsInternal(context.Context) ([]*models.Subscription, error)
 	MarkCanceledSubscriptionAsExpiredInternal(context.Context, bson.ObjectID) error
 	HasActiveSubscriptionsInternal(context.Context, bson.ObjectID) (bool, error)
+	// CancelSubscriptionsInternal cancels every active, paused, past-due, and
+	// suspended subscription of a user closing their account and returns how
+	// many were canceled.
+	CancelSubscriptionsInternal(context.Context, bson.ObjectID) (int, error)
+	// DeleteReminderHistoryInternal removes the reminders sent from the
+	// timelines of the user's subscriptions and returns how many were
+	// removed.
+	DeleteReminderHistoryInternal(context.Context, bson.ObjectID) (int64, error)
+	// AnonymizeSubscriptionsInternal strips the names, tags, and notes the
+	// user gave their live and archived subscriptions, keeping the
+	// subscriptions and bills themselves.
+	AnonymizeSubscriptionsInternal(context.Context, bson.ObjectID) error
+	FetchArchivableSubscriptionsInternal(context.Context, int) ([]*models.Subscription, error)
+	FetchUnusedSubscriptionsInternal(ctx context.Context, unusedAfterDays, noticeDays int) ([]*models.Subscription, error)
+	ArchiveSubscriptionInternal(context.Context, bson.ObjectID) error
+	// RecordReminderSentInternal adds a sent renewal reminder to the timeline
+	// of the subscription.
+	RecordReminderSentInternal(ctx context.Context, subscription *models.Subscription, daysBefore int) error
+	// FetchPastDueSubscriptionsInternal returns the past-due and suspended
+	// subscriptions, whose failed bill is still open.
+	FetchPastDueSubscriptionsInternal(context.Context) ([]*models.Subscription, error)
+	// RecordPaymentRetryInternal counts a payment retry of a past-due
+	// subscription and, after the final one, expires or suspends it as
+	// action says.
+	RecordPaymentRetryInternal(ctx context.Context, id bson.ObjectID, attempt int, final bool, action models.DunningFinalAction) error
+	// RetryPaymentInternal charges the failed bill of a past-due
+	// subscription again and reports whether the payment was recovered.
+	// While a charge of the bill is pending it returns a conflict instead of
+	// charging again.
+	RetryPaymentInternal(ctx context.Context, id bson.ObjectID, attempt int) (bool, error)
+	// SettlePendingChargesInternal settles the bills whose charge the
+	// payment provider was still processing once the charge went through or
+	// failed, and returns how many were settled.
+	SettlePendingChargesInternal(context.Context) (int, error)
+	// RepairBillsInternal deletes bills that failed non-transactional writes
+	// left behind and returns how many were deleted.
+	RepairBillsInternal(context.Context) (int64, error)
+	// EndSettledDunningInternal reactivates a past-due or suspended
+	// subscription whose failed bill has since been paid.
+	EndSettledDunningInternal(context.Context, bson.ObjectID) error
+
+	// EachUpcomingRenewalInternal, EachSubscriptionDueForRenewalInternal,
+	// EachCanceledExpiredSubscriptionInternal,
+	// EachArchivableSubscriptionInternal, EachUnusedSubscriptionInternal, and
+	// EachPastDueSubscriptionInternal select like their Fetch counterparts,
+	// but stream the subscriptions to fn one at a time, so scans of large
+	// collections run in constant memory. They stop at the first error fn
+	// returns. EachUpcomingRenewalInternal leaves subscriptions without a
+	// billing timezone for fn to narrow down in the owner's timezone.
+	EachUpcomingRenewalInternal(ctx context.Context, daysAhead []int, fn func(*models.Subscription) error) error
+	EachSubscriptionDueForRenewalInternal(ctx context.Context, startTime, endTime time.Time, fn func(*models.Subscription) error) error
+	EachCanceledExpiredSubscriptionInternal(ctx context.Context, fn func(*models.Subscription) error) error
+	EachArchivableSubscriptionInternal(ctx context.Context, retentionMonths int, fn func(*models.Subscription) error) error
+	EachUnusedSubscriptionInternal(ctx context.Context, unusedAfterDays, noticeDays int, fn func(*models.Subscription) error) error
+	EachPastDueSubscriptionInternal(ctx context.Context, fn func(*models.Subscription) error) error
 }
 
 type SubscriptionService interface {
@@ -47,6 +168,14 @@ type subscriptionService struct {
 	runTx                  repositories.TxnFn
 	subscriptionRepository repositories.SubscriptionRepository
 	billRepository         repositories.BillRepository
+	archiveRepository      repositories.ArchiveRepository
+	outboxRepository       repositories.OutboxRepository
+	priceHistoryRepository repositories.PriceHistoryRepository
+	lifecycleRepository    repositories.LifecycleEventRepository
+	paymentMethods         repositories.PaymentMethodRepository
+	exchangeRates          currency.Service
+	paymentProvider        payments.Provider
+	audit                  AuditServiceInternal
 	metrics                SubscriptionMetrics
 	getTime                clock.NowFn
 }
@@ -55,6 +184,14 @@ func NewSubscriptionService(
 	txnFn repositories.TxnFn,
 	subscriptionRepository repositories.SubscriptionRepository,
 	billRepository repositories.BillRepository,
+	archiveRepository repositories.ArchiveRepository,
+	outboxRepository repositories.OutboxRepository,
+	priceHistoryRepository repositories.PriceHistoryRepository,
+	lifecycleRepository repositories.LifecycleEventRepository,
+	paymentMethodRepository repositories.PaymentMethodRepository,
+	exchangeRates currency.Service,
+	paymentProvider payments.Provider,
+	audit AuditServiceInternal,
 	metrics SubscriptionMetrics,
 	nowFn clock.NowFn,
 ) SubscriptionService {
@@ -62,12 +199,23 @@ func NewSubscriptionService(
 		txnFn,
 		subscriptionRepository,
 		billRepository,
+		archiveRepository,
+		outboxRepository,
+		priceHistoryRepository,
+		lifecycleRepository,
+		paymentMethodRepository,
+		exchangeRates,
+		paymentProvider,
+		audit,
 		metrics,
 		nowFn,
 	}
 }
 
-func (s *subscriptionService) CreateSubscription(ctx context.Context, subscription *models.Subscription, claimedUserID string) (*models.Subscription, error) {
+func (s *subscriptionService) CreateSubscription(ctx context.Context, subscription *models.Subscription, claimedUserID string) (_ *models.Subscription, err error) {
+	ctx, span := startSpan(ctx, "Create Subscription")
+	defer func() { endSpan(span, err) }()
+
 	userID, err := bson.ObjectIDFromHex(claimedUserID)
 	if err != nil {
 		return nil, apperror.NewUnauthorizedError("Invalid user ID")
@@ -76,9 +224,19 @@ func (s *subscriptionService) CreateSubscription(ctx context.Context, subscripti
 	subscription.ID = bson.NewObjectID()
 
 	now := s.getTime()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// Billing days start at midnight in the billing timezone.
+	local := now.In(s.billingLocation(subscription))
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
 
 	subscription.ValidTill = lib.CalcRenewalDate(today, subscription.Frequency)
+	description := "Subscription created"
+	if subscription.TrialDays > 0 {
+		// The trial lasts until the first renewal, which makes the first charge.
+		subscription.ValidTill = today.AddDate(0, 0, subscription.TrialDays)
+		trialEndsAt := subscription.ValidTill
+		subscription.TrialEndsAt = &trialEndsAt
+		description = fmt.Sprintf("Subscription created with a %d-day trial", subscription.TrialDays)
+	}
 	// Create the subscription
 	subscription.Status = models.Active
 	// Continue with validation
@@ -88,27 +246,46 @@ func (s *subscriptionService) CreateSubscription(ctx context.Context, subscripti
 	subscription.CreatedAt = now
 	subscription.UpdatedAt = now
 
-	// Create the bill
-	bill := &models.Bill{
-		ID:             bson.NewObjectID(),
-		Amount:         subscription.Price,
-		Currency:       subscription.Currency,
-		SubscriptionID: subscription.ID,
-		StartDate:      today,
-		EndDate:        subscription.ValidTill,
-		Status:         models.Paid,
-		CreatedAt:      now,
-		UpdatedAt:      now,
+	// Create the bill, unless the trial defers it to the first renewal.
+	var bill *models.Bill
+	if !subscription.InTrial() {
+		bill = &models.Bill{
+			ID:             bson.NewObjectID(),
+			Amount:         subscription.Price,
+			Currency:       subscription.Currency,
+			SubscriptionID: subscription.ID,
+			StartDate:      today,
+			EndDate:        subscription.ValidTill,
+			Status:         models.Paid,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
 	}
 
 	var res *models.Subscription
 	err = s.runTx(ctx, func(ctx context.Context) error {
-		_, txnErr := s.billRepository.Create(ctx, bill)
+		var txnErr error
+		if bill != nil {
+			if _, txnErr = s.billRepository.Create(ctx, bill); txnErr != nil {
+				return txnErr
+			}
+		}
+		res, txnErr = s.subscriptionRepository.Create(ctx, subscription)
 		if txnErr != nil {
 			return txnErr
 		}
-		res, txnErr = s.subscriptionRepository.Create(ctx, subscription)
-		return txnErr
+		if txnErr = s.recordEvent(ctx, models.SubscriptionCreatedEvent, res.ID, res.UserID, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		if bill != nil {
+			if txnErr = s.recordEvent(ctx, models.BillPaidEvent, bill.ID, res.UserID, bill.ToResponse()); txnErr != nil {
+				return txnErr
+			}
+		}
+		if txnErr = s.audit.RecordInternal(ctx, models.ActorUser, models.AuditSubscriptionCreated, res.ID, nil, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		return s.recordLifecycle(ctx, res, models.LifecycleCreated, models.ActorUser, description)
 	})
 	if err != nil {
 		return nil, err
@@ -124,11 +301,70 @@ func (s *subscriptionService) CreateSubscription(ctx context.Context, subscripti
 	return res, nil
 }
 
-func (s *subscriptionService) GetAllSubscriptions(ctx context.Context) ([]*models.Subscription, error) {
-	return s.subscriptionRepository.GetAll(ctx)
+func (s *subscriptionService) GetAllSubscriptions(
+	ctx context.Context,
+	query models.SubscriptionQuery,
+) (*models.SubscriptionPage, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+	query.UserID = bson.ObjectID{}
+
+	subscriptions, total, err := s.subscriptionRepository.List(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SubscriptionPage{Items: subscriptions, Total: total}, nil
+}
+
+func (s *subscriptionService) SearchSubscriptions(
+	ctx context.Context,
+	claimedUserID string,
+	search models.SubscriptionSearch,
+) (*models.SubscriptionSearchPage, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	if err = search.Normalize(); err != nil {
+		return nil, err
+	}
+	search.UserID = userID
+	return s.search(ctx, &search)
+}
+
+func (s *subscriptionService) SearchAllSubscriptions(
+	ctx context.Context,
+	search models.SubscriptionSearch,
+) (*models.SubscriptionSearchPage, error) {
+	if err := search.Normalize(); err != nil {
+		return nil, err
+	}
+	search.UserID = bson.ObjectID{}
+	return s.search(ctx, &search)
+}
+
+func (s *subscriptionService) search(
+	ctx context.Context,
+	search *models.SubscriptionSearch,
+) (*models.SubscriptionSearchPage, error) {
+	matches, total, err := s.subscriptionRepository.Search(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+	terms := search.Terms()
+	for _, match := range matches {
+		match.Highlight(terms)
+	}
+	return &models.SubscriptionSearchPage{Items: matches, Total: total}, nil
 }
 
-func (s *subscriptionService) GetSubscriptionByID(ctx context.Context, id string, claimedUserID string) (*models.Subscription, error) {
+func (s *subscriptionService) GetSubscriptionByID(
+	ctx context.Context,
+	id string,
+	claimedUserID string,
+	includeArchived bool,
+) (*models.Subscription, error) {
 	subscriptionID, err := bson.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, apperror.NewBadRequestError("Invalid subscription ID")
@@ -138,10 +374,18 @@ func (s *subscriptionService) GetSubscriptionByID(ctx context.Context, id string
 		return nil, apperror.NewUnauthorizedError("Invalid user ID")
 	}
 
-	// Get the subscription
+	// Get the subscription, falling back to the archive if requested
 	subscription, err := s.subscriptionRepository.GetByID(ctx, subscriptionID)
 	if err != nil {
-		return nil, err
+		appErr, ok := errors.AsType[apperror.AppError](err)
+		if !includeArchived || !ok || appErr.Code() != apperror.ErrNotFound {
+			return nil, err
+		}
+		archived, archiveErr := s.archiveRepository.GetByID(ctx, subscriptionID)
+		if archiveErr != nil {
+			return nil, archiveErr
+		}
+		subscription = &archived.Subscription
 	}
 
 	// Verify ownership
@@ -151,7 +395,13 @@ func (s *subscriptionService) GetSubscriptionByID(ctx context.Context, id string
 	return subscription, nil
 }
 
-func (s *subscriptionService) GetSubscriptionsByUserID(ctx context.Context, id string, claimedUserID string) ([]*models.Subscription, error) {
+func (s *subscriptionService) GetSubscriptionsByUserID(
+	ctx context.Context,
+	id string,
+	claimedUserID string,
+	includeArchived bool,
+	query models.SubscriptionQuery,
+) (*models.SubscriptionPage, error) {
 	if claimedUserID != id {
 		return nil, apperror.NewForbiddenError("You are not allowed to view this subscription")
 	}
@@ -160,11 +410,55 @@ func (s *subscriptionService) GetSubscriptionsByUserID(ctx context.Context, id s
 	if err != nil {
 		return nil, apperror.NewUnauthorizedError("Invalid user ID")
 	}
+	if err = query.Normalize(); err != nil {
+		return nil, err
+	}
+	query.UserID = userID
+
+	if !includeArchived {
+		subscriptions, total, err := s.subscriptionRepository.List(ctx, &query)
+		if err != nil {
+			return nil, err
+		}
+		return &models.SubscriptionPage{Items: subscriptions, Total: total}, nil
+	}
+
+	// The page may start in either collection: fetch everything up to its
+	// end from both, merge them in order, and cut the page out. The limit is
+	// at most MaxPageLimit, so the comparison cannot overflow.
+	if query.Offset > MaxArchivedListingWindow-query.Limit {
+		return nil, apperror.NewValidationError(
+			"offset + limit must not exceed %d when including archived subscriptions", MaxArchivedListingWindow)
+	}
+	merged := query
+	merged.Limit = query.Offset + query.Limit
+	merged.Offset = 0
+
+	subscriptions, total, err := s.subscriptionRepository.List(ctx, &merged)
+	if err != nil {
+		return nil, err
+	}
+	archived, archivedTotal, err := s.archiveRepository.List(ctx, &merged)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range archived {
+		subscriptions = append(subscriptions, &a.Subscription)
+	}
+	slices.SortFunc(subscriptions, query.Compare)
 
-	return s.subscriptionRepository.GetByUserID(ctx, userID)
+	from := min(query.Offset, len(subscriptions))
+	to := min(query.Offset+query.Limit, len(subscriptions))
+	return &models.SubscriptionPage{
+		Items: subscriptions[from:to],
+		Total: total + archivedTotal,
+	}, nil
 }
 
-func (s *subscriptionService) DeleteSubscription(ctx context.Context, id string, claimedUserID string) error {
+func (s *subscriptionService) DeleteSubscription(ctx context.Context, id string, claimedUserID string) (err error) {
+	ctx, span := startSpan(ctx, "Delete Subscription", otelattr.SubscriptionID(id))
+	defer func() { endSpan(span, err) }()
+
 	subscriptionID, err := bson.ObjectIDFromHex(id)
 	if err != nil {
 		return apperror.NewBadRequestError("Invalid subscription ID")
@@ -189,7 +483,18 @@ func (s *subscriptionService) DeleteSubscription(ctx context.Context, id string,
 		return apperror.NewConflictError("You can only delete expired subscriptions")
 	}
 
-	if err = s.subscriptionRepository.Delete(ctx, subscriptionID); err != nil {
+	// Bills go with the subscription, so a bill without one is always the
+	// remains of a failed write.
+	err = s.runTx(ctx, func(ctx context.Context) error {
+		if _, txnErr := s.billRepository.DeleteBySubscriptionID(ctx, subscriptionID); txnErr != nil {
+			return txnErr
+		}
+		if txnErr := s.subscriptionRepository.Delete(ctx, subscriptionID); txnErr != nil {
+			return txnErr
+		}
+		return s.audit.RecordInternal(ctx, models.ActorUser, models.AuditSubscriptionDeleted, subscriptionID, subscription.ToResponse(), nil)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -199,7 +504,10 @@ func (s *subscriptionService) DeleteSubscription(ctx context.Context, id string,
 	return nil
 }
 
-func (s *subscriptionService) CancelSubscription(ctx context.Context, id string, claimedUserID string) (*models.Subscription, error) {
+func (s *subscriptionService) CancelSubscription(ctx context.Context, id string, claimedUserID string) (_ *models.Subscription, err error) {
+	ctx, span := startSpan(ctx, "Cancel Subscription", otelattr.SubscriptionID(id))
+	defer func() { endSpan(span, err) }()
+
 	subscriptionID, err := bson.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, apperror.NewBadRequestError("Invalid subscription ID")
@@ -223,28 +531,41 @@ func (s *subscriptionService) CancelSubscription(ctx context.Context, id string,
 	if subscription.Status != models.Active {
 		return nil, apperror.NewConflictError("Only active subscriptions can be canceled")
 	}
+	return s.cancel(ctx, subscription, "Subscription canceled")
+}
 
+// cancel cancels subscription in its current status, refunding its bill for
+// a period that has not started yet.
+func (s *subscriptionService) cancel(ctx context.Context, subscription *models.Subscription, description string) (*models.Subscription, error) {
 	latestBill, err := s.billRepository.GetRecentBill(ctx, subscription.ID)
 	if err != nil {
 		return nil, err
 	}
 
 	now := s.getTime()
-	// Update the subscription status
-	subscription.Status = models.Canceled
-	subscription.UpdatedAt = now
 
 	var res *models.Subscription
 	err = s.runTx(ctx, func(ctx context.Context) error {
-		if latestBill.StartDate.After(now) && latestBill.Status == models.Paid {
+		// Claim the transition first so a concurrent cancel can't refund twice.
+		var txnErr error
+		res, txnErr = s.subscriptionRepository.TransitionStatus(ctx, subscription.ID, subscription.Status, models.Canceled, now)
+		if txnErr != nil {
+			return txnErr
+		}
+
+		// A subscription still in its trial has no bill to refund.
+		if latestBill != nil && latestBill.StartDate.After(now) && latestBill.Status == models.Paid {
 			// Refund the bill
 			latestBill.Status = models.Refunded
 			latestBill.UpdatedAt = now
 
-			_, txnErr := s.billRepository.Update(ctx, latestBill)
+			_, txnErr = s.billRepository.Update(ctx, latestBill)
 			if txnErr != nil {
 				return txnErr
 			}
+			if txnErr = s.recordEvent(ctx, models.BillRefundedEvent, latestBill.ID, subscription.UserID, latestBill.ToResponse()); txnErr != nil {
+				return txnErr
+			}
 
 			// Update the subscription validity
 			activeBill, txnErr := s.billRepository.GetRecentBill(ctx, subscription.ID)
@@ -252,13 +573,19 @@ func (s *subscriptionService) CancelSubscription(ctx context.Context, id string,
 				return txnErr
 			}
 			if activeBill != nil && activeBill.Status == models.Paid {
-				subscription.ValidTill = activeBill.EndDate
+				res.ValidTill = activeBill.EndDate
+				if res, txnErr = s.subscriptionRepository.Update(ctx, res); txnErr != nil {
+					return txnErr
+				}
 			}
 		}
-
-		var txnErr error
-		res, txnErr = s.subscriptionRepository.Update(ctx, subscription)
-		return txnErr
+		if txnErr = s.recordEvent(ctx, models.SubscriptionCanceledEvent, res.ID, res.UserID, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.audit.RecordInternal(ctx, models.ActorUser, models.AuditSubscriptionCanceled, res.ID, subscription.ToResponse(), res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		return s.recordLifecycle(ctx, res, models.LifecycleCanceled, models.ActorUser, description)
 	})
 	if err != nil {
 		return nil, err
@@ -272,113 +599,1089 @@ func (s *subscriptionService) CancelSubscription(ctx context.Context, id string,
 	return res, nil
 }
 
-func (s *subscriptionService) RenewSubscriptionInternal(ctx context.Context, id bson.ObjectID) (*models.Subscription, error) {
-	subscription, err := s.subscriptionRepository.GetByID(ctx, id)
+func (s *subscriptionService) PauseSubscription(ctx context.Context, id string, claimedUserID string) (_ *models.Subscription, err error) {
+	ctx, span := startSpan(ctx, "Pause Subscription", otelattr.SubscriptionID(id))
+	defer func() { endSpan(span, err) }()
+
+	subscriptionID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid subscription ID")
+	}
+
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	subscription, err := s.subscriptionRepository.GetByID(ctx, subscriptionID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Verify ownership
+	if subscription.UserID != userID {
+		return nil, apperror.NewForbiddenError("You are not allowed to pause this subscription")
+	}
+
 	if subscription.Status != models.Active {
-		return nil, apperror.NewConflictError("Only active subscriptions can be renewed")
+		return nil, apperror.NewConflictError("Only active subscriptions can be paused")
 	}
 
-	// Get the latest bill
-	latestBill, err := s.billRepository.GetRecentBill(ctx, subscription.ID)
+	now := s.getTime()
+
+	var res *models.Subscription
+	err = s.runTx(ctx, func(ctx context.Context) error {
+		var txnErr error
+		res, txnErr = s.subscriptionRepository.TransitionStatus(ctx, subscription.ID, models.Active, models.Paused, now,
+			bson.E{Key: "paused_at", Value: now},
+		)
+		if txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.recordEvent(ctx, models.SubscriptionPausedEvent, res.ID, res.UserID, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.audit.RecordInternal(ctx, models.ActorUser, models.AuditSubscriptionPaused, res.ID, subscription.ToResponse(), res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		return s.recordLifecycle(ctx, res, models.LifecyclePaused, models.ActorUser, "Subscription paused")
+	})
 	if err != nil {
 		return nil, err
 	}
-	if latestBill == nil {
-		return nil, apperror.NewNotFoundError("No active bill found for this subscription")
+
+	slog.InfoContext(ctx, "Subscription paused",
+		logattr.ValidTill(res.ValidTill),
+	)
+	return res, nil
+}
+
+func (s *subscriptionService) ResumeSubscription(ctx context.Context, id string, claimedUserID string) (_ *models.Subscription, err error) {
+	ctx, span := startSpan(ctx, "Resume Subscription", otelattr.SubscriptionID(id))
+	defer func() { endSpan(span, err) }()
+
+	subscriptionID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid subscription ID")
 	}
-	if latestBill.Status != models.Paid {
-		return nil, apperror.NewConflictError("Only paid subscriptions can be renewed")
+
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
 	}
 
-	// Check if the subscription is already renewed
-	now := s.getTime()
-	if latestBill.StartDate.After(now) {
-		return nil, apperror.NewConflictError("Subscription is already renewed")
+	subscription, err := s.subscriptionRepository.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create a new bill
-	newStartDate := latestBill.EndDate
-	newValidity := lib.CalcRenewalDate(newStartDate, subscription.Frequency)
-	subscription.ValidTill = newValidity
-	subscription.UpdatedAt = now
+	// Verify ownership
+	if subscription.UserID != userID {
+		return nil, apperror.NewForbiddenError("You are not allowed to resume this subscription")
+	}
 
-	bill := &models.Bill{
-		ID:             bson.NewObjectID(),
-		Amount:         subscription.Price,
-		Currency:       subscription.Currency,
-		SubscriptionID: subscription.ID,
-		StartDate:      newStartDate,
-		EndDate:        newValidity,
-		Status:         models.Paid,
-		CreatedAt:      now,
-		UpdatedAt:      now,
+	if subscription.Status != models.Paused || subscription.PausedAt == nil {
+		return nil, apperror.NewConflictError("Only paused subscriptions can be resumed")
+	}
+
+	bills, err := s.billRepository.GetBySubscriptionID(ctx, subscription.ID)
+	if err != nil {
+		return nil, err
 	}
 
+	now := s.getTime()
+	pausedAt := *subscription.PausedAt
+	paused := now.Sub(pausedAt)
+
 	var res *models.Subscription
 	err = s.runTx(ctx, func(ctx context.Context) error {
-		_, txnErr := s.billRepository.Create(ctx, bill)
+		var txnErr error
+		res, txnErr = s.subscriptionRepository.TransitionStatus(ctx, subscription.ID, models.Paused, models.Active, now)
 		if txnErr != nil {
 			return txnErr
 		}
-		// Update the subscription
-		res, txnErr = s.subscriptionRepository.Update(ctx, subscription)
-		return txnErr
+
+		// The time spent paused is added to the period it interrupted and
+		// moves any later period back. Bills are copied so a retried
+		// transaction shifts them only once.
+		for _, bill := range bills {
+			if bill.Status != models.Paid || !bill.EndDate.After(pausedAt) {
+				continue
+			}
+			shifted := *bill
+			if !shifted.StartDate.Before(pausedAt) {
+				shifted.StartDate = shifted.StartDate.Add(paused)
+			}
+			shifted.EndDate = shifted.EndDate.Add(paused)
+			shifted.UpdatedAt = now
+			if _, txnErr = s.billRepository.Update(ctx, &shifted); txnErr != nil {
+				return txnErr
+			}
+		}
+
+		res.ValidTill = res.ValidTill.Add(paused)
+		if res.TrialEndsAt != nil && res.TrialEndsAt.After(pausedAt) {
+			trialEndsAt := res.TrialEndsAt.Add(paused)
+			res.TrialEndsAt = &trialEndsAt
+		}
+		res.PausedAt = nil
+		if res, txnErr = s.subscriptionRepository.Update(ctx, res); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.recordEvent(ctx, models.SubscriptionResumedEvent, res.ID, res.UserID, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		description := fmt.Sprintf("Subscription resumed after %d days, valid until %s",
+			int(paused.Hours()/24),
+			res.ValidTill.Format(time.DateOnly),
+		)
+		if txnErr = s.audit.RecordInternal(ctx, models.ActorUser, models.AuditSubscriptionResumed, res.ID, subscription.ToResponse(), res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		return s.recordLifecycle(ctx, res, models.LifecycleResumed, models.ActorUser, description)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	slog.InfoContext(ctx, "Subscription renewed",
+	slog.InfoContext(ctx, "Subscription resumed",
 		logattr.ValidTill(res.ValidTill),
 	)
 	return res, nil
 }
 
-func (s *subscriptionService) FetchUpcomingRenewalsInternal(ctx context.Context, daysAhead []int) ([]*models.Subscription, error) {
-	return s.subscriptionRepository.GetSubscriptionsDueForReminder(ctx, daysAhead, s.getTime())
-}
+func (s *subscriptionService) RecordUsage(
+	ctx context.Context,
+	id string,
+	claimedUserID string,
+	usedAt time.Time,
+) (*models.Subscription, error) {
+	subscriptionID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid subscription ID")
+	}
 
-func (s *subscriptionService) HasActiveSubscriptionsInternal(ctx context.Context, userID bson.ObjectID) (bool, error) {
-	subscriptions, err := s.subscriptionRepository.GetByUserID(ctx, userID)
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
 	if err != nil {
-		return false, err
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
 	}
-	return len(subscriptions) > 0, nil
-}
 
-func (s *subscriptionService) FetchSubscriptionByIDInternal(ctx context.Context, id bson.ObjectID) (*models.Subscription, error) {
-	// Get the subscription
-	return s.subscriptionRepository.GetByID(ctx, id)
-}
+	now := s.getTime()
+	if usedAt.IsZero() {
+		usedAt = now
+	}
+	if usedAt.After(now) {
+		return nil, apperror.NewValidationError("usedAt must not be in the future")
+	}
 
-func (s *subscriptionService) FetchSubscriptionsDueForRenewalInternal(ctx context.Context, startTime, endTime time.Time) ([]*models.Subscription, error) {
-	return s.subscriptionRepository.GetSubscriptionsDueForRenewal(ctx, startTime, endTime)
-}
+	subscription, err := s.subscriptionRepository.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
 
-func (s *subscriptionService) FetchCanceledExpiredSubscriptionsInternal(ctx context.Context) ([]*models.Subscription, error) {
-	return s.subscriptionRepository.GetCanceledExpiredSubscriptions(ctx, s.getTime())
+	// Verify ownership
+	if subscription.UserID != userID {
+		return nil, apperror.NewForbiddenError("You are not allowed to update this subscription")
+	}
+
+	return s.subscriptionRepository.RecordUsage(ctx, subscriptionID, usedAt, now)
 }
 
-func (s *subscriptionService) MarkCanceledSubscriptionAsExpiredInternal(ctx context.Context, id bson.ObjectID) error {
-	subscription, err := s.subscriptionRepository.GetByID(ctx, id)
+// UpdatePrice changes the price of an active subscription. The new price, the
+// price history entry, and the price change event are written in one
+// transaction. Setting the current price again changes nothing. A scheduled
+// change leaves the subscription as it is; the renewal it applies to picks it
+// up from the price history.
+func (s *subscriptionService) UpdatePrice(
+	ctx context.Context,
+	id string,
+	claimedUserID string,
+	price int64,
+	effectiveAt time.Time,
+) (_ *models.Subscription, err error) {
+	ctx, span := startSpan(ctx, "Update Subscription Price", otelattr.SubscriptionID(id))
+	defer func() { endSpan(span, err) }()
+
+	subscriptionID, err := bson.ObjectIDFromHex(id)
 	if err != nil {
-		return err
+		return nil, apperror.NewBadRequestError("Invalid subscription ID")
 	}
-	if subscription.Status != models.Canceled {
-		return apperror.NewConflictError("Only canceled subscriptions can be marked as expired")
+
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
 	}
-	subscription.Status = models.Expired
-	subscription.UpdatedAt = s.getTime()
-	_, err = s.subscriptionRepository.Update(ctx, subscription)
+
+	if price <= 0 {
+		return nil, apperror.NewValidationError("price must be greater than 0")
+	}
+
+	subscription, err := s.subscriptionRepository.GetByID(ctx, subscriptionID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	slog.InfoContext(ctx, "Canceled subscription marked as expired",
-		logattr.ValidTill(subscription.ValidTill),
-	)
-	return nil
+
+	// Verify ownership
+	if subscription.UserID != userID {
+		return nil, apperror.NewForbiddenError("You are not allowed to update this subscription")
+	}
+	if err = checkExpectedVersion(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	if subscription.Status != models.Active {
+		return nil, apperror.NewConflictError("Only active subscriptions can be repriced")
+	}
+	if subscription.Price == price {
+		return subscription, nil
+	}
+
+	now := s.getTime()
+	if effectiveAt.IsZero() {
+		effectiveAt = now
+	}
+	if effectiveAt.Before(now) {
+		return nil, apperror.NewValidationError("effective date must not be in the past")
+	}
+	change := &models.PriceChange{
+		ID:             bson.NewObjectID(),
+		SubscriptionID: subscription.ID,
+		UserID:         subscription.UserID,
+		Name:           subscription.Name,
+		OldPrice:       subscription.Price,
+		NewPrice:       price,
+		Currency:       subscription.Currency,
+		Frequency:      subscription.Frequency,
+		ChangedAt:      now,
+		EffectiveAt:    effectiveAt,
+	}
+	before := subscription.ToResponse()
+	description := fmt.Sprintf("Price changed from %s to %s",
+		models.FormatMoney(change.OldPrice, change.Currency),
+		models.FormatMoney(change.NewPrice, change.Currency),
+	)
+	if change.Pending(now) {
+		description = fmt.Sprintf("Price changes from %s to %s on %s",
+			models.FormatMoney(change.OldPrice, change.Currency),
+			models.FormatMoney(change.NewPrice, change.Currency),
+			effectiveAt.In(s.billingLocation(subscription)).Format(time.DateOnly),
+		)
+	}
+
+	res := subscription
+	err = s.runTx(ctx, func(ctx context.Context) error {
+		var txnErr error
+		if !change.Pending(now) {
+			subscription.Price = price
+			subscription.UpdatedAt = now
+			if res, txnErr = s.subscriptionRepository.UpdatePartial(ctx, subscription, "price"); txnErr != nil {
+				return txnErr
+			}
+		}
+		if txnErr = s.priceHistoryRepository.Create(ctx, change); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.recordEvent(ctx, models.SubscriptionPriceChangedEvent, subscription.ID, subscription.UserID, change.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.audit.RecordInternal(ctx, models.ActorUser, models.AuditSubscriptionPriceChanged, res.ID, before, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		return s.recordLifecycle(ctx, res, models.LifecyclePriceChanged, models.ActorUser, description)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Subscription price changed",
+		logattr.SubscriptionID(subscription.ID.Hex()),
+		logattr.OldPrice(change.OldPrice),
+		logattr.NewPrice(change.NewPrice),
+		logattr.EffectiveAt(change.EffectiveAt),
+	)
+	return res, nil
+}
+
+// checkExpectedVersion fails when the request expects the subscription at a
+// version it has since moved past, i.e. its If-Match named an older read. The
+// versioned write that follows filters on the same version, so no change can
+// land between the check and the write.
+func checkExpectedVersion(ctx context.Context, subscription *models.Subscription) error {
+	if version, ok := appctx.GetExpectedVersion(ctx); ok && subscription.Version != version {
+		return apperror.NewPreconditionFailedError("The resource has changed since it was read")
+	}
+	return nil
+}
+
+// GetPriceHistory returns the price history of a subscription once the caller
+// is verified as its owner.
+func (s *subscriptionService) GetPriceHistory(
+	ctx context.Context,
+	id string,
+	claimedUserID string,
+) ([]*models.PriceChange, error) {
+	subscription, err := s.GetSubscriptionByID(ctx, id, claimedUserID, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.priceHistoryRepository.GetBySubscriptionID(ctx, subscription.ID)
+}
+
+// UpdateSubscription applies the update to a subscription in any status.
+// None of the fields it changes affects billing, so unlike price changes no
+// lifecycle event is recorded.
+func (s *subscriptionService) UpdateSubscription(
+	ctx context.Context,
+	id string,
+	claimedUserID string,
+	update *models.SubscriptionUpdateRequest,
+) (_ *models.Subscription, err error) {
+	ctx, span := startSpan(ctx, "Update Subscription", otelattr.SubscriptionID(id))
+	defer func() { endSpan(span, err) }()
+
+	subscriptionID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid subscription ID")
+	}
+
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	subscription, err := s.subscriptionRepository.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if subscription.UserID != userID {
+		return nil, apperror.NewForbiddenError("You are not allowed to update this subscription")
+	}
+	if err = checkExpectedVersion(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	before := subscription.ToResponse()
+	fields := update.Apply(subscription)
+	if err = subscription.ValidateDetails(); err != nil {
+		return nil, err
+	}
+	subscription.UpdatedAt = s.getTime()
+
+	var res *models.Subscription
+	err = s.runTx(ctx, func(ctx context.Context) error {
+		var txnErr error
+		if res, txnErr = s.subscriptionRepository.UpdatePartial(ctx, subscription, fields...); txnErr != nil {
+			return txnErr
+		}
+		return s.audit.RecordInternal(ctx, models.ActorUser, models.AuditSubscriptionUpdated, res.ID, before, res.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Subscription updated",
+		logattr.SubscriptionID(subscription.ID.Hex()),
+	)
+	return res, nil
+}
+
+// FindDuplicateSubscription looks for a subscription of the user running now
+// whose name has the same models.NameKey as the new one. Names without a key
+// never duplicate anything.
+func (s *subscriptionService) FindDuplicateSubscription(
+	ctx context.Context,
+	subscription *models.Subscription,
+	claimedUserID string,
+) (*models.Subscription, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	key := models.NameKey(subscription.Name)
+	if key == "" {
+		return nil, nil
+	}
+
+	subscriptions, err := s.subscriptionRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// A new subscription starts now, so it overlaps every one running now.
+	now := s.getTime()
+	for _, existing := range subscriptions {
+		if existing.Covers(now) && models.NameKey(existing.Name) == key {
+			return existing, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetDuplicateSubscriptions groups the user's subscriptions running now by
+// models.NameKey, oldest first, and keeps the groups of more than one.
+func (s *subscriptionService) GetDuplicateSubscriptions(ctx context.Context, claimedUserID string) ([]*models.DuplicateGroup, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	subscriptions, err := s.subscriptionRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(subscriptions, func(a, b *models.Subscription) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	now := s.getTime()
+	byKey := make(map[string]*models.DuplicateGroup)
+	var groups []*models.DuplicateGroup
+	for _, subscription := range subscriptions {
+		key := models.NameKey(subscription.Name)
+		if key == "" || !subscription.Covers(now) {
+			continue
+		}
+		group, ok := byKey[key]
+		if !ok {
+			group = &models.DuplicateGroup{Name: subscription.Name}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.Subscriptions = append(group.Subscriptions, subscription)
+	}
+
+	duplicates := make([]*models.DuplicateGroup, 0, len(groups))
+	for _, group := range groups {
+		if len(group.Subscriptions) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+	return duplicates, nil
+}
+
+// GetSummary aggregates the user's subscriptions in the database and
+// normalizes the spend to a monthly amount per currency.
+func (s *subscriptionService) GetSummary(
+	ctx context.Context,
+	claimedUserID string,
+	target models.Currency,
+) (*models.SubscriptionSummaryResponse, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	if target != "" && !target.Valid() {
+		return nil, apperror.NewValidationError("unsupported currency %s", target)
+	}
+
+	now := s.getTime()
+	summary, err := s.subscriptionRepository.Summarize(ctx, userID, now, now.Add(models.SummaryRenewalWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	// Yearly totals are divided once per group rather than per subscription,
+	// matching the admin analytics.
+	monthly := make(map[models.Currency]int64)
+	byCategory := make(map[models.Category]map[models.Currency]int64)
+	for _, t := range summary.Spend {
+		amount := t.Total
+		if t.Frequency == models.Yearly {
+			amount = (t.Total + 6) / 12
+		}
+		monthly[t.Currency] += amount
+		if byCategory[t.Category] == nil {
+			byCategory[t.Category] = make(map[models.Currency]int64)
+		}
+		byCategory[t.Category][t.Currency] += amount
+	}
+
+	categories := make([]models.CategorySpend, 0, len(byCategory))
+	for category, sums := range byCategory {
+		categories = append(categories, models.CategorySpend{Category: category, Monthly: currencyAmounts(sums)})
+	}
+	slices.SortFunc(categories, func(a, b models.CategorySpend) int {
+		return cmp.Compare(a.Category, b.Category)
+	})
+
+	byTag := make(map[string]map[models.Currency]int64)
+	tagCounts := make(map[string]int64)
+	for _, t := range summary.TagSpend {
+		amount := t.Total
+		if t.Frequency == models.Yearly {
+			amount = (t.Total + 6) / 12
+		}
+		if byTag[t.Tag] == nil {
+			byTag[t.Tag] = make(map[models.Currency]int64)
+		}
+		byTag[t.Tag][t.Currency] += amount
+		tagCounts[t.Tag] += t.Count
+	}
+	tags := make([]models.TagSpend, 0, len(byTag))
+	for tag, sums := range byTag {
+		tags = append(tags, models.TagSpend{Tag: tag, Count: tagCounts[tag], Monthly: currencyAmounts(sums)})
+	}
+	slices.SortFunc(tags, func(a, b models.TagSpend) int {
+		return cmp.Compare(a.Tag, b.Tag)
+	})
+
+	var counts models.SubscriptionCounts
+	for _, c := range summary.ByStatus {
+		switch c.Status {
+		case models.Active:
+			counts.Active = c.Count
+		case models.Canceled:
+			counts.Canceled = c.Count
+		case models.Expired:
+			counts.Expired = c.Count
+		case models.PastDue:
+			counts.PastDue = c.Count
+		case models.Paused:
+			counts.Paused = c.Count
+		case models.Suspended:
+			counts.Suspended = c.Count
+		}
+	}
+
+	upcoming := make([]models.UpcomingRenewal, 0, len(summary.Upcoming))
+	for _, u := range summary.Upcoming {
+		upcoming = append(upcoming, u.ToUpcomingRenewal())
+	}
+
+	res := &models.SubscriptionSummaryResponse{
+		GeneratedAt:      now,
+		MonthlySpend:     currencyAmounts(monthly),
+		SpendByCategory:  categories,
+		SpendByTag:       tags,
+		Counts:           counts,
+		UpcomingRenewals: upcoming,
+	}
+	if target != "" {
+		if err = s.convertSummary(ctx, res, target, now); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// convertSummary adds the spend of a summary converted into target at the
+// latest exchange rates.
+func (s *subscriptionService) convertSummary(
+	ctx context.Context,
+	summary *models.SubscriptionSummaryResponse,
+	target models.Currency,
+	now time.Time,
+) error {
+	rates, err := s.exchangeRates.RatesOn(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for i := range summary.SpendByCategory {
+		if summary.SpendByCategory[i].Converted, err = convertTotal(rates, summary.SpendByCategory[i].Monthly, target); err != nil {
+			return err
+		}
+	}
+	for i := range summary.SpendByTag {
+		if summary.SpendByTag[i].Converted, err = convertTotal(rates, summary.SpendByTag[i].Monthly, target); err != nil {
+			return err
+		}
+	}
+	if summary.ConvertedSpend, err = convertTotal(rates, summary.MonthlySpend, target); err != nil {
+		return err
+	}
+	summary.RatesDate = rates.Date.Format(time.DateOnly)
+	return nil
+}
+
+// GetPriceChangeSummary lists the price increases of the user's subscriptions
+// in one calendar month with their combined yearly impact. Decreases are left
+// out.
+func (s *subscriptionService) GetPriceChangeSummary(
+	ctx context.Context,
+	claimedUserID string,
+	month string,
+) (*models.PriceChangeSummaryResponse, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	now := s.getTime()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if month != "" {
+		if start, err = time.ParseInLocation(forecastMonthLayout, month, now.Location()); err != nil {
+			return nil, apperror.NewValidationError("month must be formatted as YYYY-MM")
+		}
+	}
+
+	changes, err := s.priceHistoryRepository.GetByUserIDBetween(ctx, userID, start, start.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.PriceChangeSummaryResponse{
+		Month:     start.Format(forecastMonthLayout),
+		Increases: []*models.PriceChangeResponse{},
+	}
+	impact := make(map[models.Currency]int64)
+	for _, change := range changes {
+		if change.Delta() <= 0 {
+			continue
+		}
+		summary.Increases = append(summary.Increases, change.ToResponse())
+		impact[change.Currency] += change.AnnualImpact()
+	}
+	summary.AnnualImpact = currencyAmounts(impact)
+	return summary, nil
+}
+
+func (s *subscriptionService) RenewSubscriptionInternal(ctx context.Context, id bson.ObjectID) (_ *models.Subscription, err error) {
+	ctx, span := startSpan(ctx, "Renew Subscription", otelattr.SubscriptionID(id.Hex()))
+	defer func() { endSpan(span, err) }()
+
+	subscription, err := s.subscriptionRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Status != models.Active {
+		return nil, apperror.NewConflictError("Only active subscriptions can be renewed")
+	}
+
+	// Get the latest bill
+	latestBill, err := s.billRepository.GetRecentBill(ctx, subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.getTime()
+	// Dates are read back in UTC; the next renewal is computed in the billing
+	// timezone so it keeps landing on the same local day.
+	loc := s.billingLocation(subscription)
+	var newStartDate time.Time
+	if latestBill == nil && subscription.InTrial() {
+		// The trial is not billed; the first bill starts when it ends.
+		newStartDate = subscription.ValidTill.In(loc)
+	} else {
+		if latestBill == nil {
+			return nil, apperror.NewNotFoundError("No active bill found for this subscription")
+		}
+		if latestBill.Status != models.Paid {
+			return nil, apperror.NewConflictError("Only paid subscriptions can be renewed")
+		}
+		// A failed write without transactions can leave the bill of a renewal
+		// that was never applied; renewing again would bill the period twice.
+		if latestBill.EndDate.After(subscription.ValidTill) {
+			return nil, apperror.NewConflictError("Subscription has an unapplied renewal bill")
+		}
+
+		// Check if the subscription is already renewed
+		if latestBill.StartDate.After(now) {
+			return nil, apperror.NewConflictError("Subscription is already renewed")
+		}
+		newStartDate = latestBill.EndDate.In(loc)
+	}
+
+	// Create a new bill
+	before := subscription.ToResponse()
+	// The renewal is billed at the price in effect when it starts, which a
+	// scheduled price change may have set.
+	change, err := s.priceHistoryRepository.GetEffectiveAt(ctx, subscription.ID, newStartDate)
+	if err != nil {
+		return nil, err
+	}
+	if change != nil && change.NewPrice != subscription.Price {
+		slog.InfoContext(ctx, "Scheduled price change applied",
+			logattr.OldPrice(subscription.Price),
+			logattr.NewPrice(change.NewPrice),
+			logattr.EffectiveAt(change.EffectiveAt),
+		)
+		subscription.Price = change.NewPrice
+	}
+	newValidity := lib.CalcRenewalDate(newStartDate, subscription.Frequency)
+	subscription.ValidTill = newValidity
+	subscription.UpdatedAt = now
+
+	bill := &models.Bill{
+		ID:             bson.NewObjectID(),
+		Amount:         subscription.Price,
+		Currency:       subscription.Currency,
+		SubscriptionID: subscription.ID,
+		StartDate:      newStartDate,
+		EndDate:        newValidity,
+		Status:         models.Paid,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	decline, err := s.chargeRenewal(ctx, subscription, bill)
+	if err != nil {
+		return nil, err
+	}
+	if decline != nil {
+		return s.failRenewal(ctx, subscription, before, bill, decline)
+	}
+
+	var res *models.Subscription
+	err = s.runTx(ctx, func(ctx context.Context) error {
+		_, txnErr := s.billRepository.Create(ctx, bill)
+		if txnErr != nil {
+			return txnErr
+		}
+		// Update the subscription
+		res, txnErr = s.subscriptionRepository.Update(ctx, subscription)
+		if txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.recordEvent(ctx, models.SubscriptionRenewedEvent, res.ID, res.UserID, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		// A pending charge is reported paid once it settles.
+		if bill.Status == models.Paid {
+			if txnErr = s.recordEvent(ctx, models.BillPaidEvent, bill.ID, res.UserID, bill.ToResponse()); txnErr != nil {
+				return txnErr
+			}
+		}
+		description := fmt.Sprintf("Subscription renewed for %s until %s",
+			models.FormatMoney(bill.Amount, bill.Currency),
+			newValidity.Format(time.DateOnly),
+		)
+		if bill.Status == models.Pending {
+			description += "; the payment is processing"
+		}
+		if txnErr = s.audit.RecordInternal(ctx, models.ActorSystem, models.AuditSubscriptionRenewed, res.ID, before, res.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		return s.recordLifecycle(ctx, res, models.LifecycleRenewed, models.ActorSystem, description)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Subscription renewed",
+		logattr.ValidTill(res.ValidTill),
+	)
+	return res, nil
+}
+
+// FetchUpcomingRenewalsInternal narrows down the candidates of the repository:
+// for subscriptions with a billing timezone, it matches a wider window, and
+// only those renewing on a reminder day in their own timezone are kept.
+func (s *subscriptionService) FetchUpcomingRenewalsInternal(ctx context.Context, daysAhead []int) ([]*models.Subscription, error) {
+	now := s.getTime()
+	candidates, err := s.subscriptionRepository.GetSubscriptionsDueForReminder(ctx, daysAhead, now)
+	if err != nil {
+		return nil, err
+	}
+
+	due := candidates[:0]
+	for _, subscription := range candidates {
+		if s.renewsOnReminderDay(subscription, now, daysAhead) {
+			due = append(due, subscription)
+		}
+	}
+	return due, nil
+}
+
+// EachUpcomingRenewalInternal only narrows down subscriptions with a billing
+// timezone. The others are matched on a window as wide, and fn counts their
+// days in the timezone of the owner.
+func (s *subscriptionService) EachUpcomingRenewalInternal(
+	ctx context.Context,
+	daysAhead []int,
+	fn func(*models.Subscription) error,
+) error {
+	now := s.getTime()
+	return s.subscriptionRepository.EachDueForReminder(ctx, daysAhead, now, func(subscription *models.Subscription) error {
+		if subscription.Timezone != "" && !s.renewsOnReminderDay(subscription, now, daysAhead) {
+			return nil
+		}
+		return fn(subscription)
+	})
+}
+
+// renewsOnReminderDay reports whether a candidate of the repository renews on
+// one of the days, or on one of its own reminder days, in its billing
+// timezone. The repository matched the others exactly.
+func (s *subscriptionService) renewsOnReminderDay(subscription *models.Subscription, now time.Time, days []int) bool {
+	if subscription.Timezone == "" && subscription.ReminderDays == nil {
+		return true
+	}
+	return slices.Contains(subscription.ReminderDaysOr(days),
+		lib.DaysBetween(now, subscription.ValidTill, s.billingLocation(subscription)))
+}
+
+func (s *subscriptionService) HasActiveSubscriptionsInternal(ctx context.Context, userID bson.ObjectID) (bool, error) {
+	subscriptions, err := s.subscriptionRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return len(subscriptions) > 0, nil
+}
+
+// CancelSubscriptionsInternal stops at the first failure. Subscriptions
+// canceled before it stay canceled, and a retry skips them.
+func (s *subscriptionService) CancelSubscriptionsInternal(ctx context.Context, userID bson.ObjectID) (int, error) {
+	subscriptions, err := s.subscriptionRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	canceled := 0
+	for _, subscription := range subscriptions {
+		switch subscription.Status {
+		case models.Active, models.Paused, models.PastDue, models.Suspended:
+		default:
+			continue
+		}
+		if _, err = s.cancel(ctx, subscription, "Subscription canceled with the account"); err != nil {
+			return canceled, err
+		}
+		canceled++
+	}
+	return canceled, nil
+}
+
+func (s *subscriptionService) DeleteReminderHistoryInternal(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	return s.lifecycleRepository.DeleteByUserID(ctx, userID, models.LifecycleReminderSent)
+}
+
+// AnonymizeSubscriptionsInternal writes live subscriptions one at a time, so
+// the subscription cache drops each of them.
+func (s *subscriptionService) AnonymizeSubscriptionsInternal(ctx context.Context, userID bson.ObjectID) error {
+	subscriptions, err := s.subscriptionRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, subscription := range subscriptions {
+		subscription.Name = models.AnonymizedName
+		subscription.Tags = nil
+		subscription.Notes = ""
+		subscription.UpdatedAt = s.getTime()
+		if _, err = s.subscriptionRepository.UpdatePartial(ctx, subscription, "name", "tags", "notes"); err != nil {
+			return err
+		}
+	}
+
+	archived, err := s.archiveRepository.Anonymize(ctx, userID)
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "Subscriptions anonymized",
+		logattr.Total(len(subscriptions)+int(archived)),
+	)
+	return nil
+}
+
+func (s *subscriptionService) FetchSubscriptionByIDInternal(ctx context.Context, id bson.ObjectID) (*models.Subscription, error) {
+	// Get the subscription
+	return s.subscriptionRepository.GetByID(ctx, id)
+}
+
+func (s *subscriptionService) FetchSubscriptionsDueForRenewalInternal(ctx context.Context, startTime, endTime time.Time) ([]*models.Subscription, error) {
+	return s.subscriptionRepository.GetSubscriptionsDueForRenewal(ctx, startTime, endTime)
+}
+
+func (s *subscriptionService) EachSubscriptionDueForRenewalInternal(
+	ctx context.Context,
+	startTime, endTime time.Time,
+	fn func(*models.Subscription) error,
+) error {
+	return s.subscriptionRepository.EachDueForRenewal(ctx, startTime, endTime, fn)
+}
+
+func (s *subscriptionService) FetchCanceledExpiredSubscriptionsInternal(ctx context.Context) ([]*models.Subscription, error) {
+	return s.subscriptionRepository.GetCanceledExpiredSubscriptions(ctx, s.getTime())
+}
+
+func (s *subscriptionService) EachCanceledExpiredSubscriptionInternal(ctx context.Context, fn func(*models.Subscription) error) error {
+	return s.subscriptionRepository.EachCanceledExpired(ctx, s.getTime(), fn)
+}
+
+func (s *subscriptionService) MarkCanceledSubscriptionAsExpiredInternal(ctx context.Context, id bson.ObjectID) (err error) {
+	ctx, span := startSpan(ctx, "Expire Subscription", otelattr.SubscriptionID(id.Hex()))
+	defer func() { endSpan(span, err) }()
+
+	var subscription *models.Subscription
+	err = s.runTx(ctx, func(ctx context.Context) error {
+		var txnErr error
+		subscription, txnErr = s.subscriptionRepository.TransitionStatus(ctx, id, models.Canceled, models.Expired, s.getTime())
+		if txnErr != nil {
+			return txnErr
+		}
+		if txnErr = s.recordEvent(ctx, models.SubscriptionExpiredEvent, subscription.ID, subscription.UserID, subscription.ToResponse()); txnErr != nil {
+			return txnErr
+		}
+		return s.recordLifecycle(ctx, subscription, models.LifecycleExpired, models.ActorSystem, "Subscription expired")
+	})
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "Canceled subscription marked as expired",
+		logattr.ValidTill(subscription.ValidTill),
+	)
+	return nil
+}
+
+func (s *subscriptionService) FetchArchivableSubscriptionsInternal(ctx context.Context, retentionMonths int) ([]*models.Subscription, error) {
+	cutoff := s.getTime().AddDate(0, -retentionMonths, 0)
+	return s.subscriptionRepository.GetExpiredBefore(ctx, cutoff)
+}
+
+func (s *subscriptionService) EachArchivableSubscriptionInternal(
+	ctx context.Context,
+	retentionMonths int,
+	fn func(*models.Subscription) error,
+) error {
+	cutoff := s.getTime().AddDate(0, -retentionMonths, 0)
+	return s.subscriptionRepository.EachExpiredBefore(ctx, cutoff, fn)
+}
+
+// FetchUnusedSubscriptionsInternal returns active subscriptions renewing in
+// the next noticeDays days that have not been used for unusedAfterDays days.
+func (s *subscriptionService) FetchUnusedSubscriptionsInternal(
+	ctx context.Context,
+	unusedAfterDays, noticeDays int,
+) ([]*models.Subscription, error) {
+	now := s.getTime()
+	return s.subscriptionRepository.GetUnusedDueForRenewal(
+		ctx,
+		now,
+		now.AddDate(0, 0, noticeDays),
+		now.AddDate(0, 0, -unusedAfterDays),
+	)
+}
+
+func (s *subscriptionService) EachUnusedSubscriptionInternal(
+	ctx context.Context,
+	unusedAfterDays, noticeDays int,
+	fn func(*models.Subscription) error,
+) error {
+	now := s.getTime()
+	return s.subscriptionRepository.EachUnusedDueForRenewal(
+		ctx,
+		now,
+		now.AddDate(0, 0, noticeDays),
+		now.AddDate(0, 0, -unusedAfterDays),
+		fn,
+	)
+}
+
+// ArchiveSubscriptionInternal moves an expired subscription and its bills
+// into the archive collection in a single transaction.
+func (s *subscriptionService) ArchiveSubscriptionInternal(ctx context.Context, id bson.ObjectID) (err error) {
+	ctx, span := startSpan(ctx, "Archive Subscription", otelattr.SubscriptionID(id.Hex()))
+	defer func() { endSpan(span, err) }()
+
+	subscription, err := s.subscriptionRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if subscription.Status != models.Expired {
+		return apperror.NewConflictError("Only expired subscriptions can be archived")
+	}
+
+	bills, err := s.billRepository.GetBySubscriptionID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	now := s.getTime()
+	archived := &models.ArchivedSubscription{
+		Subscription: *subscription,
+		Bills:        bills,
+	}
+	archived.ArchivedAt = &now
+
+	err = s.runTx(ctx, func(ctx context.Context) error {
+		if txnErr := s.archiveRepository.Create(ctx, archived); txnErr != nil {
+			return txnErr
+		}
+		if _, txnErr := s.billRepository.DeleteBySubscriptionID(ctx, id); txnErr != nil {
+			return txnErr
+		}
+		if txnErr := s.subscriptionRepository.Delete(ctx, id); txnErr != nil {
+			return txnErr
+		}
+		return s.recordLifecycle(ctx, subscription, models.LifecycleArchived, models.ActorSystem, "Subscription archived")
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Subscription archived",
+		logattr.ValidTill(subscription.ValidTill),
+		logattr.Total(len(bills)),
+	)
+	return nil
+}
+
+// GetLifecycleEvents returns the timeline of a subscription once the caller
+// is verified as its owner.
+func (s *subscriptionService) GetLifecycleEvents(
+	ctx context.Context,
+	id string,
+	claimedUserID string,
+) ([]*models.LifecycleEvent, error) {
+	subscription, err := s.GetSubscriptionByID(ctx, id, claimedUserID, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.lifecycleRepository.GetBySubscriptionID(ctx, subscription.ID)
+}
+
+// RecordReminderSentInternal is called once the reminder email is out, so it
+// is recorded outside of a transaction. Reminders of a trial ending are told
+// apart from renewal reminders.
+func (s *subscriptionService) RecordReminderSentInternal(
+	ctx context.Context,
+	subscription *models.Subscription,
+	daysBefore int,
+) error {
+	kind, until := "Renewal reminder", "renewal"
+	if subscription.InTrial() {
+		kind, until = "Trial ending reminder", "the first charge"
+	}
+	description := fmt.Sprintf("%s sent %d days before %s", kind, daysBefore, until)
+	if daysBefore == 1 {
+		description = fmt.Sprintf("%s sent 1 day before %s", kind, until)
+	}
+	return s.recordLifecycle(ctx, subscription, models.LifecycleReminderSent, models.ActorSystem, description)
+}
+
+// billingLocation returns the zone the subscription is billed in, falling back
+// to the zone of the service clock.
+func (s *subscriptionService) billingLocation(subscription *models.Subscription) *time.Location {
+	return subscription.BillingLocation(s.getTime().Location())
+}
+
+// recordLifecycle adds an event describing the given subscription state to
+// its timeline. Callers changing the subscription run it inside the same
+// transaction.
+func (s *subscriptionService) recordLifecycle(
+	ctx context.Context,
+	subscription *models.Subscription,
+	eventType models.LifecycleEventType,
+	actor models.Actor,
+	description string,
+) error {
+	event := models.NewLifecycleEvent(subscription, eventType, actor, description, s.getTime())
+	return s.lifecycleRepository.Create(ctx, event)
+}
+
+// recordEvent writes a domain event to the outbox. Callers run it inside the
+// transaction that makes the change, so the event commits or rolls back with it.
+func (s *subscriptionService) recordEvent(
+	ctx context.Context,
+	eventType models.EventType,
+	aggregateID bson.ObjectID,
+	userID bson.ObjectID,
+	data any,
+) error {
+	event, err := models.NewOutboxEvent(eventType, aggregateID, userID, data, s.getTime())
+	if err != nil {
+		return apperror.NewInternalError(err)
+	}
+	event.TraceContext = observability.InjectIntoTaskHeaders(ctx)
+	return s.outboxRepository.Create(ctx, event)
 }
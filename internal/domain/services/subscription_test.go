@@ -3,10 +3,14 @@ package services_test
 import (
 	"context"
 	"errors"
+	"math"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	currencymocks "github.com/anuragthepathak/subscription-management/internal/currency/mocks"
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
 	"github.com/anuragthepathak/subscription-management/internal/domain/services"
@@ -32,6 +36,7 @@ var mockToday = time.Date(
 	0,
 	mockTime.Location(),
 )
+
 // mockOneMonthLater is a time one month after mockToday.
 var mockOneMonthLater = mockToday.AddDate(0, 1, 0)
 var mockTwoMonthsLater = mockToday.AddDate(0, 2, 0)
@@ -105,6 +110,8 @@ func noopTxnFn(ctx context.Context, fn func(context.Context) error) error {
 }
 
 // newSubService builds a subscriptionService wired with the provided mocks.
+// The archive repository is nil; tests touching the archive use
+// newSubServiceWithArchive.
 func newSubService(
 	subRepo *repomocks.MockSubscriptionRepository,
 	billRepo *repomocks.MockBillRepository,
@@ -114,11 +121,116 @@ func newSubService(
 		noopTxnFn,
 		subRepo,
 		billRepo,
+		nil,
+		nopOutboxRepo(),
+		nopPriceHistoryRepo(),
+		nopLifecycleRepo(),
+		nil,
+		nil,
+		nil,
+		nopAudit(),
+		metrics,
+		func() time.Time { return mockTime },
+	)
+}
+
+// nopOutboxRepo accepts any domain event, for tests that do not check the
+// outbox.
+func nopOutboxRepo() *repomocks.MockOutboxRepository {
+	repo := &repomocks.MockOutboxRepository{}
+	repo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Maybe()
+	return repo
+}
+
+// nopLifecycleRepo accepts any lifecycle event, for tests that do not check
+// the timeline.
+func nopLifecycleRepo() *repomocks.MockLifecycleEventRepository {
+	repo := &repomocks.MockLifecycleEventRepository{}
+	repo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Maybe()
+	return repo
+}
+
+// nopPriceHistoryRepo has no price changes, for tests that do not schedule
+// any.
+func nopPriceHistoryRepo() *repomocks.MockPriceHistoryRepository {
+	repo := &repomocks.MockPriceHistoryRepository{}
+	repo.EXPECT().GetEffectiveAt(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return repo
+}
+
+// nopAudit returns an audit service mock that accepts any event.
+func nopAudit() *svcmocks.MockAuditServiceInternal {
+	audit := &svcmocks.MockAuditServiceInternal{}
+	audit.EXPECT().RecordInternal(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return audit
+}
+
+// newSubServiceWithOutbox builds a subscriptionService that also has an
+// outbox repository mock, for operations that record domain events.
+func newSubServiceWithOutbox(
+	subRepo *repomocks.MockSubscriptionRepository,
+	billRepo *repomocks.MockBillRepository,
+	outboxRepo *repomocks.MockOutboxRepository,
+	metrics *svcmocks.MockSubscriptionMetrics,
+) services.SubscriptionService {
+	return services.NewSubscriptionService(
+		noopTxnFn,
+		subRepo,
+		billRepo,
+		nil,
+		outboxRepo,
+		nopPriceHistoryRepo(),
+		nopLifecycleRepo(),
+		nil,
+		nil,
+		nil,
+		nopAudit(),
 		metrics,
 		func() time.Time { return mockTime },
 	)
 }
 
+// expectEvents registers one outbox Create per event type, in order. When
+// failErr is set, the last event fails with it.
+func expectEvents(outboxRepo *repomocks.MockOutboxRepository, events []models.EventType, failErr error) {
+	for i, eventType := range events {
+		var err error
+		if i == len(events)-1 {
+			err = failErr
+		}
+		outboxRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(e *models.OutboxEvent) bool {
+				return e.Type == eventType && e.CreatedAt.Equal(mockTime) && e.PublishedAt == nil
+			})).
+			Return(err).
+			Once()
+	}
+}
+
+// newSubServiceWithArchive builds a subscriptionService that also has an
+// archive repository mock.
+func newSubServiceWithArchive(
+	subRepo *repomocks.MockSubscriptionRepository,
+	billRepo *repomocks.MockBillRepository,
+	archiveRepo *repomocks.MockArchiveRepository,
+) services.SubscriptionService {
+	return services.NewSubscriptionService(
+		noopTxnFn,
+		subRepo,
+		billRepo,
+		archiveRepo,
+		nil,
+		nil,
+		nopLifecycleRepo(),
+		nil,
+		nil,
+		nil,
+		nopAudit(),
+		nil,
+		func() time.Time { return mockTime },
+	)
+}
+
 // ---------------------------------------------------------------------------
 // CreateSubscription
 // ---------------------------------------------------------------------------
@@ -180,6 +292,8 @@ func Test_subscriptionService_CreateSubscription(t *testing.T) {
 			input models.Subscription,
 			userID bson.ObjectID,
 		)
+		wantEvents   []models.EventType
+		outboxErr    error
 		wantErr      bool
 		wantErrCode  apperror.ErrorCode
 		assertResult func(
@@ -216,6 +330,7 @@ func Test_subscriptionService_CreateSubscription(t *testing.T) {
 
 				metrics.EXPECT().IncSubscriptionsCreated(mock.Anything).Once()
 			},
+			wantEvents: []models.EventType{models.SubscriptionCreatedEvent, models.BillPaidEvent},
 			assertResult: func(
 				t *testing.T,
 				input models.Subscription,
@@ -317,6 +432,33 @@ func Test_subscriptionService_CreateSubscription(t *testing.T) {
 			wantErr:     true,
 			wantErrCode: apperror.ErrDB,
 		},
+		{
+			// Outbox write fails after both documents are created; the
+			// transaction rolls back so no subscription exists without its event.
+			name:          "error - outbox write fails",
+			input:         validInput(),
+			claimedUserID: defaultUserHex,
+			parsedUserID:  defaultUserID,
+			setupMocks: func(
+				subRepo *repomocks.MockSubscriptionRepository,
+				billRepo *repomocks.MockBillRepository,
+				_ *svcmocks.MockSubscriptionMetrics,
+				input models.Subscription,
+				userID bson.ObjectID,
+			) {
+				billRepo.EXPECT().
+					Create(mock.Anything, buildBillMatcher(input)).
+					RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) { return b, nil }).Once()
+
+				subRepo.EXPECT().
+					Create(mock.Anything, buildMatcher(input, userID)).
+					RunAndReturn(func(_ context.Context, s *models.Subscription) (*models.Subscription, error) { return s, nil }).Once()
+			},
+			wantEvents:  []models.EventType{models.SubscriptionCreatedEvent},
+			outboxErr:   apperror.NewDBError(errors.New("outbox insert failed")),
+			wantErr:     true,
+			wantErrCode: apperror.ErrDB,
+		},
 	}
 
 	for _, tt := range tests {
@@ -330,8 +472,10 @@ func Test_subscriptionService_CreateSubscription(t *testing.T) {
 				inputSnapshot = *tt.input
 			}
 			tt.setupMocks(subRepo, billRepo, metrics, inputSnapshot, tt.parsedUserID)
+			outboxRepo := repomocks.NewMockOutboxRepository(t)
+			expectEvents(outboxRepo, tt.wantEvents, tt.outboxErr)
 
-			svc := newSubService(subRepo, billRepo, metrics)
+			svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, metrics)
 			got, err := svc.CreateSubscription(
 				t.Context(), tt.input, tt.claimedUserID,
 			)
@@ -361,6 +505,81 @@ func Test_subscriptionService_CreateSubscription(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Duplicates
+// ---------------------------------------------------------------------------
+
+// namedSub returns a subscription of the default user with its own ID.
+func namedSub(name string, status models.Status, validTill time.Time, createdAt time.Time) *models.Subscription {
+	sub := validSub()
+	sub.ID = bson.NewObjectID()
+	sub.Name = name
+	sub.Status = status
+	sub.ValidTill = validTill
+	sub.CreatedAt = createdAt
+	return sub
+}
+
+func TestSubscriptionService_FindDuplicateSubscription(t *testing.T) {
+	running := namedSub("Netflix", models.Active, mockOneMonthLater, mockTime)
+	canceled := namedSub("Netflix", models.Canceled, mockOneMonthLater, mockTime)
+	tests := []struct {
+		name     string
+		existing []*models.Subscription
+		newName  string
+		want     *models.Subscription
+	}{
+		{"same name ignoring case and punctuation", []*models.Subscription{running}, " NET-flix ", running},
+		{"other name", []*models.Subscription{running}, "Hulu", nil},
+		{"canceled but still valid", []*models.Subscription{canceled}, "Netflix", canceled},
+		{"canceled and run out", []*models.Subscription{namedSub("Netflix", models.Canceled, mockTime.Add(-time.Hour), mockTime)}, "Netflix", nil},
+		{"expired", []*models.Subscription{namedSub("Netflix", models.Expired, mockOneMonthLater, mockTime)}, "Netflix", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subRepo := repomocks.NewMockSubscriptionRepository(t)
+			subRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return(tt.existing, nil).Once()
+			svc := newSubService(subRepo, repomocks.NewMockBillRepository(t), svcmocks.NewMockSubscriptionMetrics(t))
+
+			got, err := svc.FindDuplicateSubscription(t.Context(), &models.Subscription{Name: tt.newName}, defaultUserHex)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSubscriptionService_FindDuplicateSubscription_InvalidUser(t *testing.T) {
+	svc := newSubService(repomocks.NewMockSubscriptionRepository(t), repomocks.NewMockBillRepository(t), svcmocks.NewMockSubscriptionMetrics(t))
+
+	_, err := svc.FindDuplicateSubscription(t.Context(), &models.Subscription{Name: "Netflix"}, "bad")
+
+	assertAppErr(t, err, apperror.ErrUnauthorized)
+}
+
+func TestSubscriptionService_GetDuplicateSubscriptions(t *testing.T) {
+	older := namedSub("Netflix", models.Active, mockOneMonthLater, mockTime.Add(-48*time.Hour))
+	newer := namedSub("netflix ", models.Paused, mockOneMonthLater, mockTime)
+	expired := namedSub("Netflix", models.Expired, mockTime, mockTime)
+	spotify := namedSub("Spotify", models.Active, mockOneMonthLater, mockTime)
+	hulu := namedSub("Hulu", models.Active, mockOneMonthLater, mockTime)
+	otherHulu := namedSub("HULU", models.PastDue, mockTime, mockTime.Add(-time.Hour))
+
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	subRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).
+		Return([]*models.Subscription{newer, spotify, hulu, expired, older, otherHulu}, nil).
+		Once()
+	svc := newSubService(subRepo, repomocks.NewMockBillRepository(t), svcmocks.NewMockSubscriptionMetrics(t))
+
+	got, err := svc.GetDuplicateSubscriptions(t.Context(), defaultUserHex)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.DuplicateGroup{
+		{Name: "Netflix", Subscriptions: []*models.Subscription{older, newer}},
+		{Name: "HULU", Subscriptions: []*models.Subscription{otherHulu, hulu}},
+	}, got)
+}
+
 // ---------------------------------------------------------------------------
 // GetAllSubscriptions
 // ---------------------------------------------------------------------------
@@ -368,6 +587,7 @@ func Test_subscriptionService_CreateSubscription(t *testing.T) {
 func Test_subscriptionService_GetAllSubscriptions(t *testing.T) {
 	tests := []struct {
 		name        string
+		query       models.SubscriptionQuery
 		setupMocks  func(repo *repomocks.MockSubscriptionRepository)
 		wantErr     bool
 		wantErrCode apperror.ErrorCode
@@ -375,11 +595,14 @@ func Test_subscriptionService_GetAllSubscriptions(t *testing.T) {
 	}{
 		{
 			// Success
-			name: "success - repository GetAll returns the data",
+			name: "success - repository List returns the page with defaults applied",
 			setupMocks: func(repo *repomocks.MockSubscriptionRepository) {
 				repo.EXPECT().
-					GetAll(mock.Anything).
-					Return(validSubs(), nil).
+					List(mock.Anything, &models.SubscriptionQuery{
+						Sort:      models.SortByCreatedAt,
+						PageQuery: models.PageQuery{Limit: models.DefaultPageLimit},
+					}).
+					Return(validSubs(), int64(2), nil).
 					Once()
 			},
 			wantErr:  false,
@@ -387,16 +610,32 @@ func Test_subscriptionService_GetAllSubscriptions(t *testing.T) {
 		},
 		// Repo returns a DB error
 		{
-			name: "error - repository GetAll returns db error",
+			name: "error - repository List returns db error",
 			setupMocks: func(repo *repomocks.MockSubscriptionRepository) {
 				repo.EXPECT().
-					GetAll(mock.Anything).
-					Return(nil, apperror.NewDBError(errors.New("connection lost"))).
+					List(mock.Anything, mock.Anything).
+					Return(nil, int64(0), apperror.NewDBError(errors.New("connection lost"))).
 					Once()
 			},
 			wantErr:     true,
 			wantErrCode: apperror.ErrDB,
 		},
+		// Unknown sort key is rejected before any repo call
+		{
+			name:        "error - unknown sort key",
+			query:       models.SubscriptionQuery{Sort: "name"},
+			setupMocks:  func(_ *repomocks.MockSubscriptionRepository) {},
+			wantErr:     true,
+			wantErrCode: apperror.ErrValidation,
+		},
+		// Page size above the maximum is rejected
+		{
+			name:        "error - limit above the maximum",
+			query:       models.SubscriptionQuery{PageQuery: models.PageQuery{Limit: models.MaxPageLimit + 1}},
+			setupMocks:  func(_ *repomocks.MockSubscriptionRepository) {},
+			wantErr:     true,
+			wantErrCode: apperror.ErrValidation,
+		},
 	}
 
 	for _, tt := range tests {
@@ -407,7 +646,7 @@ func Test_subscriptionService_GetAllSubscriptions(t *testing.T) {
 			tt.setupMocks(subRepo)
 
 			svc := newSubService(subRepo, billRepo, metrics)
-			got, err := svc.GetAllSubscriptions(t.Context())
+			got, err := svc.GetAllSubscriptions(t.Context(), tt.query)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -428,11 +667,75 @@ func Test_subscriptionService_GetAllSubscriptions(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-			assert.Equal(t, tt.wantSubs, got)
+			assert.Equal(t, tt.wantSubs, got.Items)
+			assert.Equal(t, int64(len(tt.wantSubs)), got.Total)
 		})
 	}
 }
 
+// ---------------------------------------------------------------------------
+// SearchSubscriptions / SearchAllSubscriptions
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_SearchSubscriptions(t *testing.T) {
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	match := &models.SubscriptionMatch{Subscription: *validSub(), Score: 10}
+	subRepo.EXPECT().
+		Search(mock.Anything, &models.SubscriptionSearch{
+			UserID:    defaultUserID,
+			Text:      "netflix -hulu",
+			PageQuery: models.PageQuery{Limit: models.DefaultPageLimit},
+		}).
+		Return([]*models.SubscriptionMatch{match}, int64(1), nil).
+		Once()
+
+	svc := newSubService(subRepo, repomocks.NewMockBillRepository(t), svcmocks.NewMockSubscriptionMetrics(t))
+	got, err := svc.SearchSubscriptions(t.Context(), defaultUserHex, models.SubscriptionSearch{Text: " netflix -hulu "})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), got.Total)
+	require.Len(t, got.Items, 1)
+	assert.Equal(t, []models.Highlight{
+		{Field: "name", Fragments: []models.HighlightFragment{{Text: "Netflix", Match: true}}},
+	}, got.Items[0].Highlights)
+}
+
+func Test_subscriptionService_SearchSubscriptions_Rejected(t *testing.T) {
+	svc := newSubService(
+		repomocks.NewMockSubscriptionRepository(t),
+		repomocks.NewMockBillRepository(t),
+		svcmocks.NewMockSubscriptionMetrics(t),
+	)
+
+	_, err := svc.SearchSubscriptions(t.Context(), "invalid", models.SubscriptionSearch{Text: "netflix"})
+	assertAppErr(t, err, apperror.ErrUnauthorized)
+
+	_, err = svc.SearchSubscriptions(t.Context(), defaultUserHex, models.SubscriptionSearch{})
+	assertAppErr(t, err, apperror.ErrValidation)
+
+	_, err = svc.SearchAllSubscriptions(t.Context(), models.SubscriptionSearch{Text: "netflix", PageQuery: models.PageQuery{Offset: -1}})
+	assertAppErr(t, err, apperror.ErrValidation)
+}
+
+func Test_subscriptionService_SearchAllSubscriptions(t *testing.T) {
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	subRepo.EXPECT().
+		Search(mock.Anything, &models.SubscriptionSearch{Text: "netflix", PageQuery: models.PageQuery{Limit: 10}}).
+		Return(nil, int64(0), nil).
+		Once()
+
+	svc := newSubService(subRepo, repomocks.NewMockBillRepository(t), svcmocks.NewMockSubscriptionMetrics(t))
+	// A user ID set by the caller does not narrow the search.
+	got, err := svc.SearchAllSubscriptions(t.Context(), models.SubscriptionSearch{
+		UserID:    defaultUserID,
+		Text:      "netflix",
+		PageQuery: models.PageQuery{Limit: 10},
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, got.Items)
+}
+
 // ---------------------------------------------------------------------------
 // GetSubscriptionByID
 // ---------------------------------------------------------------------------
@@ -526,7 +829,7 @@ func Test_subscriptionService_GetSubscriptionByID(t *testing.T) {
 
 			svc := newSubService(subRepo, billRepo, metrics)
 			got, err := svc.GetSubscriptionByID(
-				t.Context(), tt.subID, tt.claimedUserID,
+				t.Context(), tt.subID, tt.claimedUserID, false,
 			)
 
 			if tt.wantErr {
@@ -573,8 +876,10 @@ func Test_subscriptionService_GetSubscriptionsByUserID(t *testing.T) {
 			parsedUserID:  defaultUserID,
 			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository, userID bson.ObjectID) {
 				subRepo.EXPECT().
-					GetByUserID(mock.Anything, userID).
-					Return(validSubs(), nil).
+					List(mock.Anything, mock.MatchedBy(func(q *models.SubscriptionQuery) bool {
+						return q.UserID == userID
+					})).
+					Return(validSubs(), int64(2), nil).
 					Once()
 			},
 			wantSubs: validSubs(),
@@ -599,14 +904,14 @@ func Test_subscriptionService_GetSubscriptionsByUserID(t *testing.T) {
 		},
 		{
 			// Repo returns a DB error.
-			name:          "error - repository GetByUserID returns db error",
+			name:          "error - repository List returns db error",
 			id:            defaultUserHex,
 			claimedUserID: defaultUserHex,
 			parsedUserID:  defaultUserID,
 			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository, userID bson.ObjectID) {
 				subRepo.EXPECT().
-					GetByUserID(mock.Anything, userID).
-					Return(nil, apperror.NewDBError(errors.New("connection lost"))).
+					List(mock.Anything, mock.Anything).
+					Return(nil, int64(0), apperror.NewDBError(errors.New("connection lost"))).
 					Once()
 			},
 			wantErr:     true,
@@ -622,7 +927,7 @@ func Test_subscriptionService_GetSubscriptionsByUserID(t *testing.T) {
 			tt.setupMocks(subRepo, tt.parsedUserID)
 
 			svc := newSubService(subRepo, billRepo, metrics)
-			got, err := svc.GetSubscriptionsByUserID(t.Context(), tt.id, tt.claimedUserID)
+			got, err := svc.GetSubscriptionsByUserID(t.Context(), tt.id, tt.claimedUserID, false, models.SubscriptionQuery{})
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -642,11 +947,123 @@ func Test_subscriptionService_GetSubscriptionsByUserID(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-			assert.Equal(t, tt.wantSubs, got)
+			assert.Equal(t, tt.wantSubs, got.Items)
 		})
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Archived reads
+// ---------------------------------------------------------------------------
+
+// validArchivedSub returns an expired subscription as stored in the archive.
+func validArchivedSub() *models.ArchivedSubscription {
+	archivedAt := mockTime
+	sub := validExpiredSub()
+	sub.ArchivedAt = &archivedAt
+	return &models.ArchivedSubscription{
+		Subscription: *sub,
+		Bills:        []*models.Bill{validBill()},
+	}
+}
+
+func Test_subscriptionService_GetSubscriptionByID_IncludeArchived(t *testing.T) {
+	t.Run("success - falls back to archive when not in hot storage", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		archiveRepo := repomocks.NewMockArchiveRepository(t)
+		archived := validArchivedSub()
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).
+			Return(nil, apperror.NewNotFoundError("not found")).Once()
+		archiveRepo.EXPECT().GetByID(mock.Anything, defaultSubID).
+			Return(archived, nil).Once()
+
+		svc := newSubServiceWithArchive(subRepo, repomocks.NewMockBillRepository(t), archiveRepo)
+		got, err := svc.GetSubscriptionByID(t.Context(), defaultSubHex, defaultUserHex, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, &archived.Subscription, got)
+		assert.NotNil(t, got.ArchivedAt)
+	})
+
+	t.Run("error - non-not-found errors are not masked by the archive", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		archiveRepo := repomocks.NewMockArchiveRepository(t)
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).
+			Return(nil, apperror.NewDBError(errors.New("connection lost"))).Once()
+
+		svc := newSubServiceWithArchive(subRepo, repomocks.NewMockBillRepository(t), archiveRepo)
+		got, err := svc.GetSubscriptionByID(t.Context(), defaultSubHex, defaultUserHex, true)
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func Test_subscriptionService_GetSubscriptionsByUserID_IncludeArchived(t *testing.T) {
+	// Archived subscriptions are older, so with the default order they follow
+	// the live ones.
+	archived := validArchivedSub()
+	archived.CreatedAt = mockTime.AddDate(-1, 0, 0)
+	archived.ID = bson.NewObjectID()
+	subs := validSubs()
+
+	t.Run("success - merges both collections in order", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		archiveRepo := repomocks.NewMockArchiveRepository(t)
+
+		subRepo.EXPECT().List(mock.Anything, mock.Anything).
+			Return(validSubs(), int64(2), nil).Once()
+		archiveRepo.EXPECT().List(mock.Anything, mock.Anything).
+			Return([]*models.ArchivedSubscription{archived}, int64(1), nil).Once()
+
+		svc := newSubServiceWithArchive(subRepo, repomocks.NewMockBillRepository(t), archiveRepo)
+		got, err := svc.GetSubscriptionsByUserID(t.Context(), defaultUserHex, defaultUserHex, true, models.SubscriptionQuery{
+			Descending: true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), got.Total)
+		require.Len(t, got.Items, 3)
+		assert.Equal(t, archived.ID, got.Items[2].ID)
+	})
+
+	t.Run("success - pages through the merged listing", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		archiveRepo := repomocks.NewMockArchiveRepository(t)
+
+		// Both collections are read from the start up to the end of the page.
+		window := mock.MatchedBy(func(q *models.SubscriptionQuery) bool {
+			return q.Offset == 0 && q.Limit == 3 && q.UserID == defaultUserID
+		})
+		subRepo.EXPECT().List(mock.Anything, window).
+			Return(validSubs(), int64(2), nil).Once()
+		archiveRepo.EXPECT().List(mock.Anything, window).
+			Return([]*models.ArchivedSubscription{archived}, int64(1), nil).Once()
+
+		svc := newSubServiceWithArchive(subRepo, repomocks.NewMockBillRepository(t), archiveRepo)
+		got, err := svc.GetSubscriptionsByUserID(t.Context(), defaultUserHex, defaultUserHex, true, models.SubscriptionQuery{
+			PageQuery: models.PageQuery{Limit: 2, Offset: 1},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), got.Total)
+		require.Len(t, got.Items, 2)
+		assert.ElementsMatch(t, []bson.ObjectID{subs[0].ID, subs[1].ID}, []bson.ObjectID{got.Items[0].ID, got.Items[1].ID})
+	})
+
+	t.Run("error - a page beyond the merged window is rejected", func(t *testing.T) {
+		for _, offset := range []int{services.MaxArchivedListingWindow, math.MaxInt} {
+			svc := newSubServiceWithArchive(nil, nil, nil)
+			_, err := svc.GetSubscriptionsByUserID(t.Context(), defaultUserHex, defaultUserHex, true, models.SubscriptionQuery{
+				PageQuery: models.PageQuery{Limit: 10, Offset: offset},
+			})
+			assertAppErr(t, err, apperror.ErrValidation)
+		}
+	})
+}
+
 // ---------------------------------------------------------------------------
 // DeleteSubscription
 // ---------------------------------------------------------------------------
@@ -659,6 +1076,7 @@ func Test_subscriptionService_DeleteSubscription(t *testing.T) {
 		parsedSubID   bson.ObjectID
 		setupMocks    func(
 			subRepo *repomocks.MockSubscriptionRepository,
+			billRepo *repomocks.MockBillRepository,
 			subID bson.ObjectID,
 		)
 		wantErr     bool
@@ -672,6 +1090,7 @@ func Test_subscriptionService_DeleteSubscription(t *testing.T) {
 			parsedSubID:   defaultSubID,
 			setupMocks: func(
 				subRepo *repomocks.MockSubscriptionRepository,
+				billRepo *repomocks.MockBillRepository,
 				subID bson.ObjectID,
 			) {
 				subRepo.EXPECT().
@@ -679,6 +1098,11 @@ func Test_subscriptionService_DeleteSubscription(t *testing.T) {
 					Return(validExpiredSub(), nil).
 					Once()
 
+				billRepo.EXPECT().
+					DeleteBySubscriptionID(mock.Anything, subID).
+					Return(int64(2), nil).
+					Once()
+
 				subRepo.EXPECT().
 					Delete(mock.Anything, subID).
 					Return(nil).
@@ -690,7 +1114,7 @@ func Test_subscriptionService_DeleteSubscription(t *testing.T) {
 			name:          "error - invalid subscription ID hex",
 			subID:         "bad-hex",
 			claimedUserID: defaultUserHex,
-			setupMocks:    func(_ *repomocks.MockSubscriptionRepository, _ bson.ObjectID) {},
+			setupMocks:    func(_ *repomocks.MockSubscriptionRepository, _ *repomocks.MockBillRepository, _ bson.ObjectID) {},
 			wantErr:       true,
 			wantErrCode:   apperror.ErrBadRequest,
 		},
@@ -699,7 +1123,7 @@ func Test_subscriptionService_DeleteSubscription(t *testing.T) {
 			name:          "error - invalid claimed user ID hex",
 			subID:         defaultSubHex,
 			claimedUserID: "bad-hex",
-			setupMocks:    func(_ *repomocks.MockSubscriptionRepository, _ bson.ObjectID) {},
+			setupMocks:    func(_ *repomocks.MockSubscriptionRepository, _ *repomocks.MockBillRepository, _ bson.ObjectID) {},
 			wantErr:       true,
 			wantErrCode:   apperror.ErrUnauthorized,
 		},
@@ -711,6 +1135,7 @@ func Test_subscriptionService_DeleteSubscription(t *testing.T) {
 			parsedSubID:   defaultSubID,
 			setupMocks: func(
 				subRepo *repomocks.MockSubscriptionRepository,
+				_ *repomocks.MockBillRepository,
 				subID bson.ObjectID,
 			) {
 				subRepo.EXPECT().
@@ -729,6 +1154,7 @@ func Test_subscriptionService_DeleteSubscription(t *testing.T) {
 			parsedSubID:   defaultSubID,
 			setupMocks: func(
 				subRepo *repomocks.MockSubscriptionRepository,
+				_ *repomocks.MockBillRepository,
 				subID bson.ObjectID,
 			) {
 				subRepo.EXPECT().
@@ -747,6 +1173,7 @@ func Test_subscriptionService_DeleteSubscription(t *testing.T) {
 			parsedSubID:   defaultSubID,
 			setupMocks: func(
 				subRepo *repomocks.MockSubscriptionRepository,
+				_ *repomocks.MockBillRepository,
 				subID bson.ObjectID,
 			) {
 				subRepo.EXPECT().
@@ -765,6 +1192,7 @@ func Test_subscriptionService_DeleteSubscription(t *testing.T) {
 			parsedSubID:   defaultSubID,
 			setupMocks: func(
 				subRepo *repomocks.MockSubscriptionRepository,
+				billRepo *repomocks.MockBillRepository,
 				subID bson.ObjectID,
 			) {
 				subRepo.EXPECT().
@@ -772,6 +1200,11 @@ func Test_subscriptionService_DeleteSubscription(t *testing.T) {
 					Return(validExpiredSub(), nil).
 					Once()
 
+				billRepo.EXPECT().
+					DeleteBySubscriptionID(mock.Anything, subID).
+					Return(int64(2), nil).
+					Once()
+
 				subRepo.EXPECT().
 					Delete(mock.Anything, subID).
 					Return(apperror.NewDBError(errors.New("delete failed"))).
@@ -787,7 +1220,7 @@ func Test_subscriptionService_DeleteSubscription(t *testing.T) {
 			subRepo := repomocks.NewMockSubscriptionRepository(t)
 			billRepo := repomocks.NewMockBillRepository(t)
 			metrics := svcmocks.NewMockSubscriptionMetrics(t)
-			tt.setupMocks(subRepo, tt.parsedSubID)
+			tt.setupMocks(subRepo, billRepo, tt.parsedSubID)
 
 			svc := newSubService(subRepo, billRepo, metrics)
 			err := svc.DeleteSubscription(t.Context(), tt.subID, tt.claimedUserID)
@@ -871,10 +1304,9 @@ func Test_subscriptionService_CancelSubscription(t *testing.T) {
 					Once()
 
 				subRepo.EXPECT().
-					Update(mock.Anything, buildMatcher(updatedSub)).
-					RunAndReturn(func(_ context.Context, s *models.Subscription) (*models.Subscription, error) {
-						return s, nil
-					}).Once()
+					TransitionStatus(mock.Anything, subID, models.Active, models.Canceled, mockTime).
+					Return(validCanceledSub(), nil).
+					Once()
 
 				metrics.EXPECT().IncSubscriptionsCanceled(mock.Anything).Once()
 			},
@@ -903,6 +1335,11 @@ func Test_subscriptionService_CancelSubscription(t *testing.T) {
 					Return(validFutureBill(), nil).
 					Once()
 
+				subRepo.EXPECT().
+					TransitionStatus(mock.Anything, subID, models.Active, models.Canceled, mockTime).
+					Return(validCanceledSub(), nil).
+					Once()
+
 				billMatcher := mock.MatchedBy(func(b *models.Bill) bool {
 					return b.Status == models.Refunded &&
 						b.SubscriptionID == subID &&
@@ -1063,6 +1500,11 @@ func Test_subscriptionService_CancelSubscription(t *testing.T) {
 					Return(validFutureBill(), nil).
 					Once()
 
+				subRepo.EXPECT().
+					TransitionStatus(mock.Anything, subID, models.Active, models.Canceled, mockTime).
+					Return(validCanceledSub(), nil).
+					Once()
+
 				billRepo.EXPECT().
 					Update(mock.Anything, mock.Anything).
 					RunAndReturn(func(ctx context.Context, b *models.Bill) (*models.Bill, error) {
@@ -1095,6 +1537,11 @@ func Test_subscriptionService_CancelSubscription(t *testing.T) {
 					Return(validFutureBill(), nil).
 					Once()
 
+				subRepo.EXPECT().
+					TransitionStatus(mock.Anything, subID, models.Active, models.Canceled, mockTime).
+					Return(validCanceledSub(), nil).
+					Once()
+
 				billRepo.EXPECT().
 					Update(mock.Anything, mock.Anything).
 					RunAndReturn(func(ctx context.Context, b *models.Bill) (*models.Bill, error) {
@@ -1110,7 +1557,38 @@ func Test_subscriptionService_CancelSubscription(t *testing.T) {
 			wantErrCode: apperror.ErrNotFound,
 		},
 		{
-			// Subscription Update fails.
+			// Another request canceled the subscription after it was read.
+			name:          "error - concurrent status change",
+			subID:         defaultSubHex,
+			claimedUserID: defaultUserHex,
+			parsedSubID:   defaultSubID,
+			setupMocks: func(
+				subRepo *repomocks.MockSubscriptionRepository,
+				billRepo *repomocks.MockBillRepository,
+				_ *svcmocks.MockSubscriptionMetrics,
+				subID bson.ObjectID,
+				_ models.Subscription,
+			) {
+				subRepo.EXPECT().
+					GetByID(mock.Anything, subID).
+					Return(validSub(), nil).
+					Once()
+
+				billRepo.EXPECT().
+					GetRecentBill(mock.Anything, subID).
+					Return(validFutureBill(), nil).
+					Once()
+
+				subRepo.EXPECT().
+					TransitionStatus(mock.Anything, subID, models.Active, models.Canceled, mockTime).
+					Return(nil, apperror.NewConflictError("Subscription is no longer active")).
+					Once()
+			},
+			wantErr:     true,
+			wantErrCode: apperror.ErrConflict,
+		},
+		{
+			// Subscription validity update after refund fails.
 			name:          "error - subscription Update fails",
 			subID:         defaultSubHex,
 			claimedUserID: defaultUserHex,
@@ -1127,6 +1605,22 @@ func Test_subscriptionService_CancelSubscription(t *testing.T) {
 					Return(validSub(), nil).
 					Once()
 
+				billRepo.EXPECT().
+					GetRecentBill(mock.Anything, subID).
+					Return(validFutureBill(), nil).
+					Once()
+
+				subRepo.EXPECT().
+					TransitionStatus(mock.Anything, subID, models.Active, models.Canceled, mockTime).
+					Return(validCanceledSub(), nil).
+					Once()
+
+				billRepo.EXPECT().
+					Update(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, b *models.Bill) (*models.Bill, error) {
+						return b, nil
+					}).Once()
+
 				billRepo.EXPECT().
 					GetRecentBill(mock.Anything, subID).
 					Return(validBill(), nil).
@@ -1181,6 +1675,55 @@ func Test_subscriptionService_CancelSubscription(t *testing.T) {
 	}
 }
 
+func Test_subscriptionService_CancelSubscription_RecordsEvent(t *testing.T) {
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	billRepo := repomocks.NewMockBillRepository(t)
+	outboxRepo := repomocks.NewMockOutboxRepository(t)
+	metrics := svcmocks.NewMockSubscriptionMetrics(t)
+
+	subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+	billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(validBill(), nil).Once()
+	subRepo.EXPECT().
+		TransitionStatus(mock.Anything, defaultSubID, models.Active, models.Canceled, mockTime).
+		Return(validCanceledSub(), nil).
+		Once()
+	metrics.EXPECT().IncSubscriptionsCanceled(mock.Anything).Once()
+	expectEvents(outboxRepo, []models.EventType{models.SubscriptionCanceledEvent}, nil)
+
+	svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, metrics)
+	_, err := svc.CancelSubscription(t.Context(), defaultSubHex, defaultUserHex)
+	require.NoError(t, err)
+}
+
+func Test_subscriptionService_CancelSubscription_RecordsRefundEvent(t *testing.T) {
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	billRepo := repomocks.NewMockBillRepository(t)
+	outboxRepo := repomocks.NewMockOutboxRepository(t)
+	metrics := svcmocks.NewMockSubscriptionMetrics(t)
+
+	upcoming := validBill()
+	upcoming.StartDate = mockOneMonthLater
+	upcoming.EndDate = mockTwoMonthsLater
+
+	subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+	billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(upcoming, nil).Once()
+	subRepo.EXPECT().
+		TransitionStatus(mock.Anything, defaultSubID, models.Active, models.Canceled, mockTime).
+		Return(validCanceledSub(), nil).
+		Once()
+	billRepo.EXPECT().
+		Update(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) { return b, nil }).
+		Once()
+	billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(nil, nil).Once()
+	metrics.EXPECT().IncSubscriptionsCanceled(mock.Anything).Once()
+	expectEvents(outboxRepo, []models.EventType{models.BillRefundedEvent, models.SubscriptionCanceledEvent}, nil)
+
+	svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, metrics)
+	_, err := svc.CancelSubscription(t.Context(), defaultSubHex, defaultUserHex)
+	require.NoError(t, err)
+}
+
 // ---------------------------------------------------------------------------
 // RenewSubscriptionInternal
 // ---------------------------------------------------------------------------
@@ -1220,6 +1763,8 @@ func Test_subscriptionService_RenewSubscriptionInternal(t *testing.T) {
 			subID bson.ObjectID,
 			updatedSub models.Subscription,
 		)
+		wantEvents  []models.EventType
+		outboxErr   error
 		wantErr     bool
 		wantErrCode apperror.ErrorCode
 		wantSub     *models.Subscription
@@ -1259,7 +1804,8 @@ func Test_subscriptionService_RenewSubscriptionInternal(t *testing.T) {
 						return s, nil
 					}).Once()
 			},
-			wantSub: renewedSub(),
+			wantEvents: []models.EventType{models.SubscriptionRenewedEvent, models.BillPaidEvent},
+			wantSub:    renewedSub(),
 		},
 		{
 			// Subscription not found.
@@ -1457,6 +2003,43 @@ func Test_subscriptionService_RenewSubscriptionInternal(t *testing.T) {
 			wantErr:     true,
 			wantErrCode: apperror.ErrDB,
 		},
+		{
+			// Outbox write fails after the update; the renewal rolls back.
+			name:  "error - outbox write fails",
+			subID: defaultSubID,
+			setupMocks: func(
+				subRepo *repomocks.MockSubscriptionRepository,
+				billRepo *repomocks.MockBillRepository,
+				subID bson.ObjectID,
+				_ models.Subscription,
+			) {
+				subRepo.EXPECT().
+					GetByID(mock.Anything, subID).
+					Return(validSub(), nil).
+					Once()
+
+				billRepo.EXPECT().
+					GetRecentBill(mock.Anything, subID).
+					Return(validBill(), nil).
+					Once()
+
+				billRepo.EXPECT().
+					Create(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) {
+						return b, nil
+					}).Once()
+
+				subRepo.EXPECT().
+					Update(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, s *models.Subscription) (*models.Subscription, error) {
+						return s, nil
+					}).Once()
+			},
+			wantEvents:  []models.EventType{models.SubscriptionRenewedEvent, models.BillPaidEvent},
+			outboxErr:   apperror.NewDBError(errors.New("outbox insert failed")),
+			wantErr:     true,
+			wantErrCode: apperror.ErrDB,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1469,8 +2052,10 @@ func Test_subscriptionService_RenewSubscriptionInternal(t *testing.T) {
 				expectedSub = *tt.wantSub
 			}
 			tt.setupMocks(subRepo, billRepo, tt.subID, expectedSub)
+			outboxRepo := repomocks.NewMockOutboxRepository(t)
+			expectEvents(outboxRepo, tt.wantEvents, tt.outboxErr)
 
-			svc := newSubService(subRepo, billRepo, metrics)
+			svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, metrics)
 			got, err := svc.RenewSubscriptionInternal(t.Context(), tt.subID)
 
 			if tt.wantErr {
@@ -1569,11 +2154,67 @@ func Test_subscriptionService_FetchUpcomingRenewalsInternal(t *testing.T) {
 }
 
 // ---------------------------------------------------------------------------
-// HasActiveSubscriptionsInternal
+// CancelSubscriptionsInternal
 // ---------------------------------------------------------------------------
 
-func Test_subscriptionService_HasActiveSubscriptionsInternal(t *testing.T) {
-	tests := []struct {
+func Test_subscriptionService_CancelSubscriptionsInternal(t *testing.T) {
+	withStatus := func(status models.Status) *models.Subscription {
+		sub := validSub()
+		sub.ID = bson.NewObjectID()
+		sub.Status = status
+		return sub
+	}
+	active, paused, pastDue := withStatus(models.Active), withStatus(models.Paused), withStatus(models.PastDue)
+	canceled, expired := withStatus(models.Canceled), withStatus(models.Expired)
+
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	billRepo := repomocks.NewMockBillRepository(t)
+	metrics := svcmocks.NewMockSubscriptionMetrics(t)
+	subRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).
+		Return([]*models.Subscription{active, canceled, paused, expired, pastDue}, nil).Once()
+	for _, sub := range []*models.Subscription{active, paused, pastDue} {
+		billRepo.EXPECT().GetRecentBill(mock.Anything, sub.ID).Return(validBill(), nil).Once()
+		res := *sub
+		res.Status = models.Canceled
+		subRepo.EXPECT().TransitionStatus(mock.Anything, sub.ID, sub.Status, models.Canceled, mockTime).Return(&res, nil).Once()
+	}
+	metrics.EXPECT().IncSubscriptionsCanceled(mock.Anything).Times(3)
+
+	got, err := newSubService(subRepo, billRepo, metrics).CancelSubscriptionsInternal(t.Context(), defaultUserID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, got)
+}
+
+// ---------------------------------------------------------------------------
+// AnonymizeSubscriptionsInternal
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_AnonymizeSubscriptionsInternal(t *testing.T) {
+	sub := validSub()
+	sub.Tags = []string{"family"}
+	sub.Notes = "Shared with Bob"
+
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	archiveRepo := repomocks.NewMockArchiveRepository(t)
+	subRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return([]*models.Subscription{sub}, nil).Once()
+	subRepo.EXPECT().
+		UpdatePartial(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+			return s.ID == defaultSubID && s.Name == models.AnonymizedName && s.Tags == nil && s.Notes == ""
+		}), "name", "tags", "notes").
+		Return(sub, nil).
+		Once()
+	archiveRepo.EXPECT().Anonymize(mock.Anything, defaultUserID).Return(2, nil).Once()
+
+	svc := newSubServiceWithArchive(subRepo, repomocks.NewMockBillRepository(t), archiveRepo)
+	require.NoError(t, svc.AnonymizeSubscriptionsInternal(t.Context(), defaultUserID))
+}
+
+// ---------------------------------------------------------------------------
+// HasActiveSubscriptionsInternal
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_HasActiveSubscriptionsInternal(t *testing.T) {
+	tests := []struct {
 		name        string
 		userID      bson.ObjectID
 		setupMocks  func(subRepo *repomocks.MockSubscriptionRepository, userID bson.ObjectID)
@@ -1881,6 +2522,8 @@ func Test_subscriptionService_MarkCanceledSubscriptionAsExpiredInternal(t *testi
 		name        string
 		subID       bson.ObjectID
 		setupMocks  func(subRepo *repomocks.MockSubscriptionRepository, subID bson.ObjectID)
+		wantEvents  []models.EventType
+		outboxErr   error
 		wantErr     bool
 		wantErrCode apperror.ErrorCode
 	}{
@@ -1890,25 +2533,26 @@ func Test_subscriptionService_MarkCanceledSubscriptionAsExpiredInternal(t *testi
 			subID: defaultSubID,
 			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository, subID bson.ObjectID) {
 				subRepo.EXPECT().
-					GetByID(mock.Anything, subID).
-					Return(validCanceledSub(), nil).
+					TransitionStatus(mock.Anything, subID, models.Canceled, models.Expired, mockTime).
+					Return(validExpiredSub(), nil).
 					Once()
-
-				matcher := mock.MatchedBy(func(s *models.Subscription) bool {
-					changeValid := s.ID == subID &&
-						s.Status == models.Expired &&
-						s.UpdatedAt.Equal(mockTime)
-
-					staticValid := s.ValidTill.Equal(mockOneMonthLater) &&
-						s.UserID == defaultUserID
-					return changeValid && staticValid
-				})
+			},
+			wantEvents: []models.EventType{models.SubscriptionExpiredEvent},
+		},
+		{
+			// Outbox write fails; the transition rolls back with it.
+			name:  "error - outbox write fails",
+			subID: defaultSubID,
+			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository, subID bson.ObjectID) {
 				subRepo.EXPECT().
-					Update(mock.Anything, matcher).
-					RunAndReturn(func(_ context.Context, s *models.Subscription) (*models.Subscription, error) {
-						return s, nil
-					}).Once()
+					TransitionStatus(mock.Anything, subID, models.Canceled, models.Expired, mockTime).
+					Return(validExpiredSub(), nil).
+					Once()
 			},
+			wantEvents:  []models.EventType{models.SubscriptionExpiredEvent},
+			outboxErr:   apperror.NewDBError(errors.New("outbox insert failed")),
+			wantErr:     true,
+			wantErrCode: apperror.ErrDB,
 		},
 		{
 			// Subscription not found.
@@ -1916,7 +2560,7 @@ func Test_subscriptionService_MarkCanceledSubscriptionAsExpiredInternal(t *testi
 			subID: defaultSubID,
 			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository, subID bson.ObjectID) {
 				subRepo.EXPECT().
-					GetByID(mock.Anything, subID).
+					TransitionStatus(mock.Anything, subID, models.Canceled, models.Expired, mockTime).
 					Return(nil, apperror.NewNotFoundError("not found")).
 					Once()
 			},
@@ -1924,30 +2568,26 @@ func Test_subscriptionService_MarkCanceledSubscriptionAsExpiredInternal(t *testi
 			wantErrCode: apperror.ErrNotFound,
 		},
 		{
-			// Subscription is not canceled (e.g. still active).
+			// Subscription is not canceled (e.g. still active, or already
+			// expired by another worker).
 			name:  "error - subscription is not canceled",
 			subID: defaultSubID,
 			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository, subID bson.ObjectID) {
 				subRepo.EXPECT().
-					GetByID(mock.Anything, subID).
-					Return(validSub(), nil). // status is Active
+					TransitionStatus(mock.Anything, subID, models.Canceled, models.Expired, mockTime).
+					Return(nil, apperror.NewConflictError("Subscription is no longer canceled")).
 					Once()
 			},
 			wantErr:     true,
 			wantErrCode: apperror.ErrConflict,
 		},
 		{
-			// Repository Update fails.
-			name:  "error - repository Update fails",
+			// Repository update fails.
+			name:  "error - repository TransitionStatus fails",
 			subID: defaultSubID,
 			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository, subID bson.ObjectID) {
 				subRepo.EXPECT().
-					GetByID(mock.Anything, subID).
-					Return(validCanceledSub(), nil).
-					Once()
-
-				subRepo.EXPECT().
-					Update(mock.Anything, mock.Anything).
+					TransitionStatus(mock.Anything, subID, models.Canceled, models.Expired, mockTime).
 					Return(nil, apperror.NewDBError(errors.New("update failed"))).
 					Once()
 			},
@@ -1962,8 +2602,10 @@ func Test_subscriptionService_MarkCanceledSubscriptionAsExpiredInternal(t *testi
 			billRepo := repomocks.NewMockBillRepository(t)
 			metrics := svcmocks.NewMockSubscriptionMetrics(t)
 			tt.setupMocks(subRepo, tt.subID)
+			outboxRepo := repomocks.NewMockOutboxRepository(t)
+			expectEvents(outboxRepo, tt.wantEvents, tt.outboxErr)
 
-			svc := newSubService(subRepo, billRepo, metrics)
+			svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, metrics)
 			err := svc.MarkCanceledSubscriptionAsExpiredInternal(t.Context(), tt.subID)
 
 			if tt.wantErr {
@@ -1986,3 +2628,1327 @@ func Test_subscriptionService_MarkCanceledSubscriptionAsExpiredInternal(t *testi
 		})
 	}
 }
+
+// ---------------------------------------------------------------------------
+// FetchArchivableSubscriptionsInternal
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_FetchArchivableSubscriptionsInternal(t *testing.T) {
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	subRepo.EXPECT().GetExpiredBefore(mock.Anything, mockTime.AddDate(0, -12, 0)).
+		Return([]*models.Subscription{validExpiredSub()}, nil).Once()
+
+	svc := newSubService(subRepo, repomocks.NewMockBillRepository(t), svcmocks.NewMockSubscriptionMetrics(t))
+	got, err := svc.FetchArchivableSubscriptionsInternal(t.Context(), 12)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Subscription{validExpiredSub()}, got)
+}
+
+// ---------------------------------------------------------------------------
+// RecordUsage
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_RecordUsage(t *testing.T) {
+	yesterday := mockTime.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name          string
+		subID         string
+		claimedUserID string
+		usedAt        time.Time
+		setupMocks    func(subRepo *repomocks.MockSubscriptionRepository)
+		wantErrCode   apperror.ErrorCode
+	}{
+		{
+			name:          "success - defaults to now",
+			subID:         defaultSubID.Hex(),
+			claimedUserID: defaultUserHex,
+			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository) {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+				subRepo.EXPECT().RecordUsage(mock.Anything, defaultSubID, mockTime, mockTime).
+					Return(validSub(), nil).Once()
+			},
+		},
+		{
+			name:          "success - past usage",
+			subID:         defaultSubID.Hex(),
+			claimedUserID: defaultUserHex,
+			usedAt:        yesterday,
+			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository) {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+				subRepo.EXPECT().RecordUsage(mock.Anything, defaultSubID, yesterday, mockTime).
+					Return(validSub(), nil).Once()
+			},
+		},
+		{
+			name:          "error - future usage",
+			subID:         defaultSubID.Hex(),
+			claimedUserID: defaultUserHex,
+			usedAt:        mockTime.Add(time.Hour),
+			setupMocks:    func(subRepo *repomocks.MockSubscriptionRepository) {},
+			wantErrCode:   apperror.ErrValidation,
+		},
+		{
+			name:          "error - not the owner",
+			subID:         defaultSubID.Hex(),
+			claimedUserID: bson.NewObjectID().Hex(),
+			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository) {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+			},
+			wantErrCode: apperror.ErrForbidden,
+		},
+		{
+			name:          "error - invalid subscription ID",
+			subID:         "not-an-id",
+			claimedUserID: defaultUserHex,
+			setupMocks:    func(subRepo *repomocks.MockSubscriptionRepository) {},
+			wantErrCode:   apperror.ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subRepo := repomocks.NewMockSubscriptionRepository(t)
+			tt.setupMocks(subRepo)
+
+			svc := newSubService(subRepo, repomocks.NewMockBillRepository(t), svcmocks.NewMockSubscriptionMetrics(t))
+			got, err := svc.RecordUsage(t.Context(), tt.subID, tt.claimedUserID, tt.usedAt)
+
+			if tt.wantErrCode != "" {
+				assertAppErr(t, err, tt.wantErrCode)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// UpdatePrice
+// ---------------------------------------------------------------------------
+
+// newSubServiceWithPriceHistory builds a subscriptionService with the mocks
+// that repricing writes to.
+func newSubServiceWithPriceHistory(
+	subRepo *repomocks.MockSubscriptionRepository,
+	priceRepo *repomocks.MockPriceHistoryRepository,
+	outboxRepo *repomocks.MockOutboxRepository,
+) services.SubscriptionService {
+	return services.NewSubscriptionService(
+		noopTxnFn,
+		subRepo,
+		nil,
+		nil,
+		outboxRepo,
+		priceRepo,
+		nopLifecycleRepo(),
+		nil,
+		nil,
+		nil,
+		nopAudit(),
+		nil,
+		func() time.Time { return mockTime },
+	)
+}
+
+func Test_subscriptionService_RenewSubscriptionInternal_ScheduledPrice(t *testing.T) {
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	billRepo := repomocks.NewMockBillRepository(t)
+	priceRepo := repomocks.NewMockPriceHistoryRepository(t)
+
+	subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+	billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(validBill(), nil).Once()
+	priceRepo.EXPECT().
+		GetEffectiveAt(mock.Anything, defaultSubID, mockOneMonthLater).
+		Return(&models.PriceChange{OldPrice: 999, NewPrice: 1199, EffectiveAt: mockOneMonthLater}, nil).
+		Once()
+	billRepo.EXPECT().
+		Create(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool { return b.Amount == 1199 })).
+		RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) { return b, nil }).
+		Once()
+	subRepo.EXPECT().
+		Update(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool { return s.Price == 1199 })).
+		RunAndReturn(func(_ context.Context, s *models.Subscription) (*models.Subscription, error) { return s, nil }).
+		Once()
+
+	svc := services.NewSubscriptionService(
+		noopTxnFn,
+		subRepo,
+		billRepo,
+		nil,
+		nopOutboxRepo(),
+		priceRepo,
+		nopLifecycleRepo(),
+		nil,
+		nil,
+		nil,
+		nopAudit(),
+		nil,
+		func() time.Time { return mockTime },
+	)
+	got, err := svc.RenewSubscriptionInternal(t.Context(), defaultSubID)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1199), got.Price)
+}
+
+func Test_subscriptionService_UpdatePrice(t *testing.T) {
+	t.Run("success - records the change and its event", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		priceRepo := repomocks.NewMockPriceHistoryRepository(t)
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+		subRepo.EXPECT().
+			UpdatePartial(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+				return s.Price == 1199 && s.UpdatedAt.Equal(mockTime)
+			}), "price").
+			RunAndReturn(func(_ context.Context, s *models.Subscription, _ ...string) (*models.Subscription, error) {
+				return s, nil
+			}).
+			Once()
+		priceRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(c *models.PriceChange) bool {
+				return c.SubscriptionID == defaultSubID && c.UserID == defaultUserID &&
+					c.OldPrice == 999 && c.NewPrice == 1199 && c.ChangedAt.Equal(mockTime) &&
+					c.EffectiveAt.Equal(mockTime)
+			})).
+			Return(nil).
+			Once()
+		expectEvents(outboxRepo, []models.EventType{models.SubscriptionPriceChangedEvent}, nil)
+
+		svc := newSubServiceWithPriceHistory(subRepo, priceRepo, outboxRepo)
+		got, err := svc.UpdatePrice(t.Context(), defaultSubID.Hex(), defaultUserHex, 1199, time.Time{})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1199), got.Price)
+	})
+
+	t.Run("success - scheduled change leaves the price until it takes effect", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		priceRepo := repomocks.NewMockPriceHistoryRepository(t)
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+		effectiveAt := mockOneMonthLater
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+		priceRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(c *models.PriceChange) bool {
+				return c.NewPrice == 1199 && c.ChangedAt.Equal(mockTime) && c.EffectiveAt.Equal(effectiveAt)
+			})).
+			Return(nil).
+			Once()
+		expectEvents(outboxRepo, []models.EventType{models.SubscriptionPriceChangedEvent}, nil)
+
+		svc := newSubServiceWithPriceHistory(subRepo, priceRepo, outboxRepo)
+		got, err := svc.UpdatePrice(t.Context(), defaultSubID.Hex(), defaultUserHex, 1199, effectiveAt)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(999), got.Price)
+	})
+
+	t.Run("success - unchanged price writes nothing", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+
+		svc := newSubServiceWithPriceHistory(subRepo, repomocks.NewMockPriceHistoryRepository(t), repomocks.NewMockOutboxRepository(t))
+		got, err := svc.UpdatePrice(t.Context(), defaultSubID.Hex(), defaultUserHex, 999, time.Time{})
+
+		require.NoError(t, err)
+		assert.Equal(t, validSub(), got)
+	})
+
+	tests := []struct {
+		name          string
+		subID         string
+		claimedUserID string
+		price         int64
+		effectiveAt   time.Time
+		stored        *models.Subscription
+		wantErrCode   apperror.ErrorCode
+	}{
+		{
+			name:          "error - invalid subscription ID",
+			subID:         "not-an-id",
+			claimedUserID: defaultUserHex,
+			price:         1199,
+			wantErrCode:   apperror.ErrBadRequest,
+		},
+		{
+			name:          "error - effective date in the past",
+			subID:         defaultSubID.Hex(),
+			claimedUserID: defaultUserHex,
+			price:         1199,
+			effectiveAt:   mockTime.Add(-time.Hour),
+			stored:        validSub(),
+			wantErrCode:   apperror.ErrValidation,
+		},
+		{
+			name:          "error - non-positive price",
+			subID:         defaultSubID.Hex(),
+			claimedUserID: defaultUserHex,
+			price:         0,
+			wantErrCode:   apperror.ErrValidation,
+		},
+		{
+			name:          "error - not the owner",
+			subID:         defaultSubID.Hex(),
+			claimedUserID: bson.NewObjectID().Hex(),
+			price:         1199,
+			stored:        validSub(),
+			wantErrCode:   apperror.ErrForbidden,
+		},
+		{
+			name:          "error - canceled subscription",
+			subID:         defaultSubID.Hex(),
+			claimedUserID: defaultUserHex,
+			price:         1199,
+			stored:        validCanceledSub(),
+			wantErrCode:   apperror.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subRepo := repomocks.NewMockSubscriptionRepository(t)
+			if tt.stored != nil {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(tt.stored, nil).Once()
+			}
+
+			svc := newSubServiceWithPriceHistory(subRepo, repomocks.NewMockPriceHistoryRepository(t), repomocks.NewMockOutboxRepository(t))
+			got, err := svc.UpdatePrice(t.Context(), tt.subID, tt.claimedUserID, tt.price, tt.effectiveAt)
+
+			assertAppErr(t, err, tt.wantErrCode)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// UpdateSubscription
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_UpdateSubscription(t *testing.T) {
+	t.Run("success - changes the given fields", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		stored := validCanceledSub()
+		stored.Notes = "shared with family"
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(stored, nil).Once()
+		subRepo.EXPECT().
+			UpdatePartial(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+				return slices.Equal(s.Tags, []string{"work"}) && slices.Equal(s.ReminderDays, []int{14, 2}) &&
+					s.Notes == "shared with family" && s.UpdatedAt.Equal(mockTime)
+			}), "tags", "reminder_days").
+			RunAndReturn(func(_ context.Context, s *models.Subscription, _ ...string) (*models.Subscription, error) {
+				return s, nil
+			}).
+			Once()
+
+		svc := newSubService(subRepo, nil, nil)
+		got, err := svc.UpdateSubscription(t.Context(), defaultSubID.Hex(), defaultUserHex, &models.SubscriptionUpdateRequest{
+			Tags:         &[]string{"Work"},
+			ReminderDays: &[]int{14, 2},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.Canceled, got.Status)
+		assert.Equal(t, []int{14, 2}, got.ReminderDays)
+	})
+
+	t.Run("success - writes at the version If-Match named", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		stored := validSub()
+		stored.Version = 3
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(stored, nil).Once()
+		subRepo.EXPECT().
+			UpdatePartial(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+				return s.Version == 3
+			}), "notes").
+			RunAndReturn(func(_ context.Context, s *models.Subscription, _ ...string) (*models.Subscription, error) {
+				return s, nil
+			}).
+			Once()
+
+		ctx := appctx.WithExpectedVersion(t.Context(), 3)
+		svc := newSubService(subRepo, nil, nil)
+		_, err := svc.UpdateSubscription(ctx, defaultSubID.Hex(), defaultUserHex, &models.SubscriptionUpdateRequest{
+			Notes: new("shared"),
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("error - If-Match named an older version", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		stored := validSub()
+		stored.Version = 4
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(stored, nil).Once()
+
+		ctx := appctx.WithExpectedVersion(t.Context(), 3)
+		svc := newSubService(subRepo, nil, nil)
+		got, err := svc.UpdateSubscription(ctx, defaultSubID.Hex(), defaultUserHex, &models.SubscriptionUpdateRequest{
+			Notes: new("shared"),
+		})
+
+		assertAppErr(t, err, apperror.ErrPrecondition)
+		assert.Nil(t, got)
+	})
+
+	tests := []struct {
+		name          string
+		subID         string
+		claimedUserID string
+		update        models.SubscriptionUpdateRequest
+		stored        *models.Subscription
+		wantErrCode   apperror.ErrorCode
+	}{
+		{
+			name:          "error - invalid subscription ID",
+			subID:         "not-an-id",
+			claimedUserID: defaultUserHex,
+			wantErrCode:   apperror.ErrBadRequest,
+		},
+		{
+			name:          "error - not the owner",
+			subID:         defaultSubID.Hex(),
+			claimedUserID: bson.NewObjectID().Hex(),
+			stored:        validSub(),
+			wantErrCode:   apperror.ErrForbidden,
+		},
+		{
+			name:          "error - invalid reminder days",
+			subID:         defaultSubID.Hex(),
+			claimedUserID: defaultUserHex,
+			update:        models.SubscriptionUpdateRequest{ReminderDays: &[]int{7, 7}},
+			stored:        validSub(),
+			wantErrCode:   apperror.ErrValidation,
+		},
+		{
+			name:          "error - invalid category",
+			subID:         defaultSubID.Hex(),
+			claimedUserID: defaultUserHex,
+			update:        models.SubscriptionUpdateRequest{Category: new(models.Category("gaming"))},
+			stored:        validSub(),
+			wantErrCode:   apperror.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subRepo := repomocks.NewMockSubscriptionRepository(t)
+			if tt.stored != nil {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(tt.stored, nil).Once()
+			}
+
+			svc := newSubService(subRepo, nil, nil)
+			got, err := svc.UpdateSubscription(t.Context(), tt.subID, tt.claimedUserID, &tt.update)
+
+			assertAppErr(t, err, tt.wantErrCode)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GetPriceChangeSummary
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_GetPriceChangeSummary(t *testing.T) {
+	increase := &models.PriceChange{
+		ID:             bson.NewObjectID(),
+		SubscriptionID: defaultSubID,
+		UserID:         defaultUserID,
+		Name:           "Netflix",
+		OldPrice:       999,
+		NewPrice:       1199,
+		Currency:       models.USD,
+		Frequency:      models.Monthly,
+		ChangedAt:      mockTime,
+	}
+	decrease := *increase
+	decrease.OldPrice, decrease.NewPrice = 1199, 999
+	yearly := *increase
+	yearly.Frequency = models.Yearly
+	yearly.OldPrice, yearly.NewPrice = 10000, 12000
+
+	priceRepo := repomocks.NewMockPriceHistoryRepository(t)
+	priceRepo.EXPECT().
+		GetByUserIDBetween(mock.Anything, defaultUserID,
+			time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)).
+		Return([]*models.PriceChange{increase, &decrease, &yearly}, nil).
+		Once()
+
+	svc := newSubServiceWithPriceHistory(repomocks.NewMockSubscriptionRepository(t), priceRepo, repomocks.NewMockOutboxRepository(t))
+	got, err := svc.GetPriceChangeSummary(t.Context(), defaultUserHex, "2025-01")
+
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01", got.Month)
+	require.Len(t, got.Increases, 2, "decreases are left out")
+	assert.InDelta(t, 20.0, got.Increases[0].PercentChange, 1e-9)
+	assert.Equal(t, []models.CurrencyAmount{models.NewCurrencyAmount(200*12+2000, models.USD)}, got.AnnualImpact)
+
+	_, err = svc.GetPriceChangeSummary(t.Context(), defaultUserHex, "January")
+	assertAppErr(t, err, apperror.ErrValidation)
+}
+
+// ---------------------------------------------------------------------------
+// GetSummary
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_GetSummary(t *testing.T) {
+	renewal := models.SummaryRenewal{
+		ID:        defaultSubID,
+		Name:      "Netflix",
+		Price:     999,
+		Currency:  models.USD,
+		ValidTill: mockTime.AddDate(0, 0, 5),
+	}
+
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	subRepo.EXPECT().
+		Summarize(mock.Anything, defaultUserID, mockTime, mockTime.Add(models.SummaryRenewalWindow)).
+		Return(&models.SubscriptionSummary{
+			ByStatus: []models.StatusCount{{Status: models.Active, Count: 3}, {Status: models.Expired, Count: 1}},
+			Spend: []models.CategorySpendTotal{
+				{Category: models.Entertainment, Currency: models.USD, Frequency: models.Monthly, Total: 1998},
+				{Category: models.Entertainment, Currency: models.USD, Frequency: models.Yearly, Total: 12000},
+				{Category: models.News, Currency: models.EUR, Frequency: models.Monthly, Total: 500},
+			},
+			TagSpend: []models.TagSpendTotal{
+				{Tag: "work", Currency: models.USD, Frequency: models.Monthly, Total: 999, Count: 1},
+				{Tag: "work", Currency: models.USD, Frequency: models.Yearly, Total: 12000, Count: 1},
+				{Tag: "family", Currency: models.EUR, Frequency: models.Monthly, Total: 500, Count: 1},
+			},
+			Upcoming: []models.SummaryRenewal{renewal},
+		}, nil).
+		Once()
+
+	svc := newSubService(subRepo, nil, nil)
+	got, err := svc.GetSummary(t.Context(), defaultUserHex, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, mockTime, got.GeneratedAt)
+	assert.Equal(t, []models.CurrencyAmount{
+		models.NewCurrencyAmount(500, models.EUR),
+		models.NewCurrencyAmount(1998+1000, models.USD), // The yearly plan counts a twelfth per month.
+	}, got.MonthlySpend)
+	assert.Equal(t, []models.CategorySpend{
+		{Category: models.Entertainment, Monthly: []models.CurrencyAmount{models.NewCurrencyAmount(2998, models.USD)}},
+		{Category: models.News, Monthly: []models.CurrencyAmount{models.NewCurrencyAmount(500, models.EUR)}},
+	}, got.SpendByCategory)
+	assert.Equal(t, []models.TagSpend{
+		{Tag: "family", Count: 1, Monthly: []models.CurrencyAmount{models.NewCurrencyAmount(500, models.EUR)}},
+		{Tag: "work", Count: 2, Monthly: []models.CurrencyAmount{models.NewCurrencyAmount(999+1000, models.USD)}},
+	}, got.SpendByTag)
+	assert.Equal(t, models.SubscriptionCounts{Active: 3, Expired: 1}, got.Counts)
+	assert.Equal(t, []models.UpcomingRenewal{renewal.ToUpcomingRenewal()}, got.UpcomingRenewals)
+
+	_, err = svc.GetSummary(t.Context(), "not-an-id", "")
+	assertAppErr(t, err, apperror.ErrUnauthorized)
+
+	_, err = svc.GetSummary(t.Context(), defaultUserHex, "XXX")
+	assertAppErr(t, err, apperror.ErrValidation)
+}
+
+func Test_subscriptionService_GetSummary_Converted(t *testing.T) {
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	subRepo.EXPECT().
+		Summarize(mock.Anything, defaultUserID, mockTime, mockTime.Add(models.SummaryRenewalWindow)).
+		Return(&models.SubscriptionSummary{
+			Spend: []models.CategorySpendTotal{
+				{Category: models.Entertainment, Currency: models.USD, Frequency: models.Monthly, Total: 2500},
+				{Category: models.News, Currency: models.EUR, Frequency: models.Monthly, Total: 500},
+			},
+		}, nil).
+		Once()
+	rates := currencymocks.NewMockService(t)
+	rates.EXPECT().
+		RatesOn(mock.Anything, mockTime).
+		Return(&models.ExchangeRates{
+			Date:  mockToday.AddDate(0, 0, -1),
+			Base:  models.EUR,
+			Rates: map[models.Currency]float64{models.USD: 1.25},
+		}, nil).
+		Once()
+
+	svc := services.NewSubscriptionService(
+		noopTxnFn, subRepo, nil, nil, nil, nil, nil, nil, rates, nil, nopAudit(), nil,
+		func() time.Time { return mockTime },
+	)
+	got, err := svc.GetSummary(t.Context(), defaultUserHex, models.EUR)
+
+	require.NoError(t, err)
+	converted := models.NewCurrencyAmount(2000+500, models.EUR)
+	assert.Equal(t, &converted, got.ConvertedSpend)
+	assert.Equal(t, mockToday.AddDate(0, 0, -1).Format(time.DateOnly), got.RatesDate)
+	require.Len(t, got.SpendByCategory, 2)
+	entertainment := models.NewCurrencyAmount(2000, models.EUR)
+	assert.Equal(t, &entertainment, got.SpendByCategory[0].Converted)
+}
+
+// ---------------------------------------------------------------------------
+// FetchUnusedSubscriptionsInternal
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_FetchUnusedSubscriptionsInternal(t *testing.T) {
+	subRepo := repomocks.NewMockSubscriptionRepository(t)
+	subRepo.EXPECT().
+		GetUnusedDueForRenewal(mock.Anything, mockTime, mockTime.AddDate(0, 0, 7), mockTime.AddDate(0, 0, -30)).
+		Return([]*models.Subscription{validSub()}, nil).
+		Once()
+
+	svc := newSubService(subRepo, repomocks.NewMockBillRepository(t), svcmocks.NewMockSubscriptionMetrics(t))
+	got, err := svc.FetchUnusedSubscriptionsInternal(t.Context(), 30, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Subscription{validSub()}, got)
+}
+
+// ---------------------------------------------------------------------------
+// ArchiveSubscriptionInternal
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_ArchiveSubscriptionInternal(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func(
+			subRepo *repomocks.MockSubscriptionRepository,
+			billRepo *repomocks.MockBillRepository,
+			archiveRepo *repomocks.MockArchiveRepository,
+		)
+		wantErr     bool
+		wantErrCode apperror.ErrorCode
+	}{
+		{
+			name: "success - archives subscription with bills and removes hot copies",
+			setupMocks: func(
+				subRepo *repomocks.MockSubscriptionRepository,
+				billRepo *repomocks.MockBillRepository,
+				archiveRepo *repomocks.MockArchiveRepository,
+			) {
+				bills := []*models.Bill{validBill()}
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).
+					Return(validExpiredSub(), nil).Once()
+				billRepo.EXPECT().GetBySubscriptionID(mock.Anything, defaultSubID).
+					Return(bills, nil).Once()
+				archiveRepo.EXPECT().
+					Create(mock.Anything, mock.MatchedBy(func(a *models.ArchivedSubscription) bool {
+						return a.ID == defaultSubID &&
+							a.ArchivedAt != nil && a.ArchivedAt.Equal(mockTime) &&
+							len(a.Bills) == len(bills)
+					})).
+					Return(nil).Once()
+				billRepo.EXPECT().DeleteBySubscriptionID(mock.Anything, defaultSubID).
+					Return(int64(1), nil).Once()
+				subRepo.EXPECT().Delete(mock.Anything, defaultSubID).
+					Return(nil).Once()
+			},
+		},
+		{
+			name: "error - subscription is not expired",
+			setupMocks: func(
+				subRepo *repomocks.MockSubscriptionRepository,
+				_ *repomocks.MockBillRepository,
+				_ *repomocks.MockArchiveRepository,
+			) {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).
+					Return(validSub(), nil).Once()
+			},
+			wantErr:     true,
+			wantErrCode: apperror.ErrConflict,
+		},
+		{
+			name: "error - archive insert fails, hot copies untouched",
+			setupMocks: func(
+				subRepo *repomocks.MockSubscriptionRepository,
+				billRepo *repomocks.MockBillRepository,
+				archiveRepo *repomocks.MockArchiveRepository,
+			) {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).
+					Return(validExpiredSub(), nil).Once()
+				billRepo.EXPECT().GetBySubscriptionID(mock.Anything, defaultSubID).
+					Return(nil, nil).Once()
+				archiveRepo.EXPECT().Create(mock.Anything, mock.Anything).
+					Return(apperror.NewConflictError("document already exists")).Once()
+			},
+			wantErr:     true,
+			wantErrCode: apperror.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subRepo := repomocks.NewMockSubscriptionRepository(t)
+			billRepo := repomocks.NewMockBillRepository(t)
+			archiveRepo := repomocks.NewMockArchiveRepository(t)
+			tt.setupMocks(subRepo, billRepo, archiveRepo)
+
+			svc := newSubServiceWithArchive(subRepo, billRepo, archiveRepo)
+			err := svc.ArchiveSubscriptionInternal(t.Context(), defaultSubID)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				if appErr, ok := errors.AsType[apperror.AppError](err); ok {
+					assert.Equal(t, tt.wantErrCode, appErr.Code())
+				} else {
+					assert.Failf(t, "Unexpected error type", "received raw error: %v", err)
+				}
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Lifecycle events
+// ---------------------------------------------------------------------------
+
+func newSubServiceWithLifecycle(
+	subRepo *repomocks.MockSubscriptionRepository,
+	outboxRepo *repomocks.MockOutboxRepository,
+	lifecycleRepo *repomocks.MockLifecycleEventRepository,
+) services.SubscriptionService {
+	return services.NewSubscriptionService(
+		noopTxnFn,
+		subRepo,
+		nil,
+		nil,
+		outboxRepo,
+		nil,
+		lifecycleRepo,
+		nil,
+		nil,
+		nil,
+		nopAudit(),
+		nil,
+		func() time.Time { return mockTime },
+	)
+}
+
+func Test_subscriptionService_RecordsLifecycleEvents(t *testing.T) {
+	t.Run("expiry is recorded as a system event", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+		lifecycleRepo := repomocks.NewMockLifecycleEventRepository(t)
+
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.Canceled, models.Expired, mockTime).
+			Return(validExpiredSub(), nil).
+			Once()
+		expectEvents(outboxRepo, []models.EventType{models.SubscriptionExpiredEvent}, nil)
+		lifecycleRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(e *models.LifecycleEvent) bool {
+				return e.SubscriptionID == defaultSubID &&
+					e.Type == models.LifecycleExpired &&
+					e.Actor == models.ActorSystem &&
+					e.Status == models.Expired &&
+					e.OccurredAt.Equal(mockTime)
+			})).
+			Return(nil).
+			Once()
+
+		svc := newSubServiceWithLifecycle(subRepo, outboxRepo, lifecycleRepo)
+		require.NoError(t, svc.MarkCanceledSubscriptionAsExpiredInternal(t.Context(), defaultSubID))
+	})
+
+	t.Run("a failed timeline write fails the transition", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+		lifecycleRepo := repomocks.NewMockLifecycleEventRepository(t)
+
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.Canceled, models.Expired, mockTime).
+			Return(validExpiredSub(), nil).
+			Once()
+		expectEvents(outboxRepo, []models.EventType{models.SubscriptionExpiredEvent}, nil)
+		lifecycleRepo.EXPECT().
+			Create(mock.Anything, mock.Anything).
+			Return(apperror.NewDBError(errors.New("insert failed"))).
+			Once()
+
+		svc := newSubServiceWithLifecycle(subRepo, outboxRepo, lifecycleRepo)
+		err := svc.MarkCanceledSubscriptionAsExpiredInternal(t.Context(), defaultSubID)
+		assertAppErr(t, err, apperror.ErrDB)
+	})
+
+	t.Run("sent reminders are recorded", func(t *testing.T) {
+		lifecycleRepo := repomocks.NewMockLifecycleEventRepository(t)
+		lifecycleRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(e *models.LifecycleEvent) bool {
+				return e.Type == models.LifecycleReminderSent &&
+					e.Actor == models.ActorSystem &&
+					e.Description == "Renewal reminder sent 3 days before renewal"
+			})).
+			Return(nil).
+			Once()
+
+		svc := newSubServiceWithLifecycle(nil, nil, lifecycleRepo)
+		require.NoError(t, svc.RecordReminderSentInternal(t.Context(), validSub(), 3))
+	})
+}
+
+func Test_subscriptionService_GetLifecycleEvents(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		lifecycleRepo := repomocks.NewMockLifecycleEventRepository(t)
+
+		events := []*models.LifecycleEvent{
+			models.NewLifecycleEvent(validSub(), models.LifecycleCreated, models.ActorUser, "Subscription created", mockTime),
+		}
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+		lifecycleRepo.EXPECT().GetBySubscriptionID(mock.Anything, defaultSubID).Return(events, nil).Once()
+
+		svc := newSubServiceWithLifecycle(subRepo, nil, lifecycleRepo)
+		got, err := svc.GetLifecycleEvents(t.Context(), defaultSubID.Hex(), defaultUserHex)
+		require.NoError(t, err)
+		assert.Equal(t, events, got)
+	})
+
+	t.Run("error - not the owner", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+
+		svc := newSubServiceWithLifecycle(subRepo, nil, repomocks.NewMockLifecycleEventRepository(t))
+		_, err := svc.GetLifecycleEvents(t.Context(), defaultSubID.Hex(), bson.NewObjectID().Hex())
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Free trials
+// ---------------------------------------------------------------------------
+
+// mockTrialEnd is when the 14-day trial of validTrialSub ends.
+var mockTrialEnd = mockToday.AddDate(0, 0, 14)
+
+// validTrialSub returns an active subscription whose trial ends at its
+// upcoming renewal.
+func validTrialSub() *models.Subscription {
+	sub := validSub()
+	sub.ValidTill = mockTrialEnd
+	sub.TrialDays = 14
+	trialEnd := mockTrialEnd
+	sub.TrialEndsAt = &trialEnd
+	return sub
+}
+
+func Test_subscriptionService_Trial(t *testing.T) {
+	t.Run("create defers the bill to the end of the trial", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+		metrics := svcmocks.NewMockSubscriptionMetrics(t)
+
+		subRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+				return s.ValidTill.Equal(mockTrialEnd) &&
+					s.TrialEndsAt != nil && s.TrialEndsAt.Equal(mockTrialEnd) &&
+					s.InTrial()
+			})).
+			RunAndReturn(func(_ context.Context, s *models.Subscription) (*models.Subscription, error) {
+				return s, nil
+			}).Once()
+		expectEvents(outboxRepo, []models.EventType{models.SubscriptionCreatedEvent}, nil)
+		metrics.EXPECT().IncSubscriptionsCreated(mock.Anything).Once()
+
+		input := &models.Subscription{
+			Name:      "Netflix",
+			Price:     999,
+			Currency:  models.USD,
+			Frequency: models.Monthly,
+			Category:  models.Entertainment,
+			TrialDays: 14,
+		}
+		svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, metrics)
+		got, err := svc.CreateSubscription(t.Context(), input, defaultUserHex)
+		require.NoError(t, err)
+		assert.Equal(t, 14, got.TrialDays)
+	})
+
+	t.Run("create rejects a trial longer than a year", func(t *testing.T) {
+		input := &models.Subscription{
+			Name:      "Netflix",
+			Price:     999,
+			Currency:  models.USD,
+			Frequency: models.Monthly,
+			Category:  models.Entertainment,
+			TrialDays: models.MaxTrialDays + 1,
+		}
+		svc := newSubService(
+			repomocks.NewMockSubscriptionRepository(t),
+			repomocks.NewMockBillRepository(t),
+			svcmocks.NewMockSubscriptionMetrics(t),
+		)
+		_, err := svc.CreateSubscription(t.Context(), input, defaultUserHex)
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+
+	t.Run("the first renewal makes the first charge", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validTrialSub(), nil).Once()
+		billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(nil, nil).Once()
+		billRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+				// January 29 renews on the last day of February.
+				return b.Amount == 999 &&
+					b.StartDate.Equal(mockTrialEnd) &&
+					b.EndDate.Equal(time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC))
+			})).
+			RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) {
+				return b, nil
+			}).Once()
+		subRepo.EXPECT().
+			Update(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, s *models.Subscription) (*models.Subscription, error) {
+				return s, nil
+			}).Once()
+		expectEvents(outboxRepo, []models.EventType{models.SubscriptionRenewedEvent, models.BillPaidEvent}, nil)
+
+		svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, nil)
+		got, err := svc.RenewSubscriptionInternal(t.Context(), defaultSubID)
+		require.NoError(t, err)
+		assert.False(t, got.InTrial())
+	})
+
+	t.Run("cancel during the trial refunds nothing", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		metrics := svcmocks.NewMockSubscriptionMetrics(t)
+
+		canceled := validTrialSub()
+		canceled.Status = models.Canceled
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validTrialSub(), nil).Once()
+		billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(nil, nil).Once()
+		subRepo.EXPECT().
+			TransitionStatus(mock.Anything, defaultSubID, models.Active, models.Canceled, mockTime).
+			Return(canceled, nil).
+			Once()
+		metrics.EXPECT().IncSubscriptionsCanceled(mock.Anything).Once()
+
+		svc := newSubService(subRepo, billRepo, metrics)
+		got, err := svc.CancelSubscription(t.Context(), defaultSubHex, defaultUserHex)
+		require.NoError(t, err)
+		assert.Equal(t, mockTrialEnd, got.ValidTill)
+	})
+
+	t.Run("trial ending reminders are recorded as such", func(t *testing.T) {
+		lifecycleRepo := repomocks.NewMockLifecycleEventRepository(t)
+		lifecycleRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(e *models.LifecycleEvent) bool {
+				return e.Type == models.LifecycleReminderSent &&
+					e.Description == "Trial ending reminder sent 1 day before the first charge"
+			})).
+			Return(nil).
+			Once()
+
+		svc := newSubServiceWithLifecycle(nil, nil, lifecycleRepo)
+		require.NoError(t, svc.RecordReminderSentInternal(t.Context(), validTrialSub(), 1))
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Billing timezone
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_BillingTimezone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	t.Run("create renews on local midnight of the billing timezone", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+		metrics := svcmocks.NewMockSubscriptionMetrics(t)
+
+		// mockTime is 07:00 on January 15 in New York.
+		wantValidTill := time.Date(2025, time.February, 15, 0, 0, 0, 0, newYork)
+		billRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+				return b.StartDate.Equal(time.Date(2025, time.January, 15, 0, 0, 0, 0, newYork)) &&
+					b.EndDate.Equal(wantValidTill)
+			})).
+			RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) {
+				return b, nil
+			}).Once()
+		subRepo.EXPECT().
+			Create(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, s *models.Subscription) (*models.Subscription, error) {
+				return s, nil
+			}).Once()
+		metrics.EXPECT().IncSubscriptionsCreated(mock.Anything).Once()
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+		expectEvents(outboxRepo, []models.EventType{models.SubscriptionCreatedEvent, models.BillPaidEvent}, nil)
+
+		input := &models.Subscription{
+			Name:      "Netflix",
+			Price:     999,
+			Currency:  models.USD,
+			Frequency: models.Monthly,
+			Category:  models.Entertainment,
+			Timezone:  "America/New_York",
+		}
+		svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, metrics)
+		got, err := svc.CreateSubscription(t.Context(), input, defaultUserHex)
+		require.NoError(t, err)
+		assert.True(t, got.ValidTill.Equal(wantValidTill))
+	})
+
+	t.Run("renewal keeps the local day across daylight saving time", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+		billRepo := repomocks.NewMockBillRepository(t)
+
+		// Dates come back from the database in UTC.
+		sub := validSub()
+		sub.Timezone = "America/New_York"
+		sub.ValidTill = time.Date(2025, time.February, 15, 5, 0, 0, 0, time.UTC)
+		bill := validBill()
+		bill.StartDate = time.Date(2025, time.January, 15, 5, 0, 0, 0, time.UTC)
+		bill.EndDate = sub.ValidTill
+
+		wantValidTill := time.Date(2025, time.March, 15, 0, 0, 0, 0, newYork)
+		subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(sub, nil).Once()
+		billRepo.EXPECT().GetRecentBill(mock.Anything, defaultSubID).Return(bill, nil).Once()
+		billRepo.EXPECT().
+			Create(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+				return b.EndDate.Equal(wantValidTill)
+			})).
+			RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) {
+				return b, nil
+			}).Once()
+		subRepo.EXPECT().
+			Update(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, s *models.Subscription) (*models.Subscription, error) {
+				return s, nil
+			}).Once()
+
+		outboxRepo := repomocks.NewMockOutboxRepository(t)
+		expectEvents(outboxRepo, []models.EventType{models.SubscriptionRenewedEvent, models.BillPaidEvent}, nil)
+
+		svc := newSubServiceWithOutbox(subRepo, billRepo, outboxRepo, nil)
+		got, err := svc.RenewSubscriptionInternal(t.Context(), defaultSubID)
+		require.NoError(t, err)
+		assert.True(t, got.ValidTill.Equal(wantValidTill), "got %s", got.ValidTill.UTC())
+	})
+
+	t.Run("reminders match the day in the billing timezone", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+
+		plain := validSub()
+		// Midnight of January 16 in New York: 1 day away there.
+		inNewYork := validSub()
+		inNewYork.Timezone = "America/New_York"
+		inNewYork.ValidTill = time.Date(2025, time.January, 16, 5, 0, 0, 0, time.UTC)
+		// Midnight of January 17 in Tokyo: 2 days away there.
+		inTokyo := validSub()
+		inTokyo.Timezone = "Asia/Tokyo"
+		inTokyo.ValidTill = time.Date(2025, time.January, 16, 15, 0, 0, 0, time.UTC)
+
+		subRepo.EXPECT().
+			GetSubscriptionsDueForReminder(mock.Anything, []int{1}, mockTime).
+			Return([]*models.Subscription{plain, inNewYork, inTokyo}, nil).
+			Once()
+
+		svc := newSubService(subRepo, nil, nil)
+		got, err := svc.FetchUpcomingRenewalsInternal(t.Context(), []int{1})
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Subscription{plain, inNewYork}, got)
+	})
+
+	t.Run("streamed reminders match the day in the billing timezone", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+
+		plain := validSub()
+		// Midnight of January 17 in Tokyo: 2 days away there.
+		inTokyo := validSub()
+		inTokyo.Timezone = "Asia/Tokyo"
+		inTokyo.ValidTill = time.Date(2025, time.January, 16, 15, 0, 0, 0, time.UTC)
+
+		subRepo.EXPECT().
+			EachDueForReminder(mock.Anything, []int{1}, mockTime, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ []int, _ time.Time, fn func(*models.Subscription) error) error {
+				for _, sub := range []*models.Subscription{plain, inTokyo} {
+					if err := fn(sub); err != nil {
+						return err
+					}
+				}
+				return nil
+			}).
+			Once()
+
+		svc := newSubService(subRepo, nil, nil)
+		var got []*models.Subscription
+		err := svc.EachUpcomingRenewalInternal(t.Context(), []int{1}, func(sub *models.Subscription) error {
+			got = append(got, sub)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Subscription{plain}, got)
+	})
+
+	t.Run("streamed reminders without a billing timezone are left to the caller", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+
+		// Renews in 2 days in UTC, but may be 1 day away for its owner.
+		plain := validSub()
+		plain.ValidTill = time.Date(2025, time.January, 17, 2, 0, 0, 0, time.UTC)
+		// Renews tomorrow, a scanned day, but not one of its own in UTC.
+		ownDays := validSub()
+		ownDays.ReminderDays = []int{14}
+		ownDays.ValidTill = time.Date(2025, time.January, 16, 0, 0, 0, 0, time.UTC)
+
+		subRepo.EXPECT().
+			EachDueForReminder(mock.Anything, []int{1}, mockTime, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ []int, _ time.Time, fn func(*models.Subscription) error) error {
+				for _, sub := range []*models.Subscription{plain, ownDays} {
+					if err := fn(sub); err != nil {
+						return err
+					}
+				}
+				return nil
+			}).
+			Once()
+
+		svc := newSubService(subRepo, nil, nil)
+		var got []*models.Subscription
+		err := svc.EachUpcomingRenewalInternal(t.Context(), []int{1}, func(sub *models.Subscription) error {
+			got = append(got, sub)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Subscription{plain, ownDays}, got)
+	})
+
+	t.Run("subscriptions with their own reminder days match those", func(t *testing.T) {
+		subRepo := repomocks.NewMockSubscriptionRepository(t)
+
+		// Renews in 14 days, one of its own reminder days.
+		ownDay := validSub()
+		ownDay.ReminderDays = []int{14}
+		ownDay.ValidTill = time.Date(2025, time.January, 29, 0, 0, 0, 0, time.UTC)
+		// Renews tomorrow, a scanned day, but not one of its own.
+		scannedDay := validSub()
+		scannedDay.ReminderDays = []int{14}
+		scannedDay.ValidTill = time.Date(2025, time.January, 16, 0, 0, 0, 0, time.UTC)
+
+		subRepo.EXPECT().
+			GetSubscriptionsDueForReminder(mock.Anything, []int{1}, mockTime).
+			Return([]*models.Subscription{ownDay, scannedDay}, nil).
+			Once()
+
+		svc := newSubService(subRepo, nil, nil)
+		got, err := svc.FetchUpcomingRenewalsInternal(t.Context(), []int{1})
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Subscription{ownDay}, got)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// PauseSubscription
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_PauseSubscription(t *testing.T) {
+	otherUserID := bson.NewObjectID()
+
+	tests := []struct {
+		name        string
+		claimedUser string
+		setupMocks  func(subRepo *repomocks.MockSubscriptionRepository)
+		wantErrCode apperror.ErrorCode
+	}{
+		{
+			// Happy path: the status changes and the pause is timestamped
+			name:        "success - active subscription paused",
+			claimedUser: defaultUserHex,
+			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository) {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+
+				paused := validSub()
+				paused.Status = models.Paused
+				paused.PausedAt = &mockTime
+				subRepo.EXPECT().
+					TransitionStatus(mock.Anything, defaultSubID, models.Active, models.Paused, mockTime,
+						bson.E{Key: "paused_at", Value: mockTime}).
+					Return(paused, nil).
+					Once()
+			},
+		},
+		{
+			// Only the owner may pause
+			name:        "error - not the owner",
+			claimedUser: otherUserID.Hex(),
+			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository) {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validSub(), nil).Once()
+			},
+			wantErrCode: apperror.ErrForbidden,
+		},
+		{
+			// Canceled subscriptions cannot be paused
+			name:        "error - not active",
+			claimedUser: defaultUserHex,
+			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository) {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validCanceledSub(), nil).Once()
+			},
+			wantErrCode: apperror.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subRepo := repomocks.NewMockSubscriptionRepository(t)
+			tt.setupMocks(subRepo)
+
+			svc := newSubService(subRepo, repomocks.NewMockBillRepository(t), svcmocks.NewMockSubscriptionMetrics(t))
+			got, err := svc.PauseSubscription(t.Context(), defaultSubHex, tt.claimedUser)
+
+			if tt.wantErrCode != "" {
+				require.Error(t, err)
+				appErr, ok := errors.AsType[apperror.AppError](err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantErrCode, appErr.Code())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, models.Paused, got.Status)
+			require.NotNil(t, got.PausedAt)
+			assert.Equal(t, mockTime, *got.PausedAt)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ResumeSubscription
+// ---------------------------------------------------------------------------
+
+func Test_subscriptionService_ResumeSubscription(t *testing.T) {
+	pausedAt := mockTime.AddDate(0, 0, -10)
+	pausedFor := mockTime.Sub(pausedAt)
+
+	// The subscription was renewed ahead of the pause, so it has a finished
+	// period, the period the pause interrupted, and a prepaid next one.
+	bill := func(start, end time.Time) *models.Bill {
+		b := validBill()
+		b.StartDate = start
+		b.EndDate = end
+		return b
+	}
+	pastBill := bill(mockToday.AddDate(0, -2, 0), mockToday.AddDate(0, -1, 0))
+	currentBill := bill(mockToday.AddDate(0, -1, 0), mockToday.AddDate(0, 0, 5))
+	nextBill := bill(mockToday.AddDate(0, 0, 5), mockToday.AddDate(0, 1, 5))
+	refundedBill := bill(mockToday.AddDate(0, 0, 5), mockToday.AddDate(0, 1, 5))
+	refundedBill.Status = models.Refunded
+
+	pausedSub := func() *models.Subscription {
+		s := validSub()
+		s.Status = models.Paused
+		s.PausedAt = &pausedAt
+		s.ValidTill = nextBill.EndDate
+		return s
+	}
+
+	tests := []struct {
+		name        string
+		stored      *models.Subscription
+		setupMocks  func(subRepo *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository)
+		wantErrCode apperror.ErrorCode
+	}{
+		{
+			// Happy path: validity and unfinished bills move by the paused time
+			name:   "success - validity and bills extended",
+			stored: pausedSub(),
+			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository) {
+				billRepo.EXPECT().
+					GetBySubscriptionID(mock.Anything, defaultSubID).
+					Return([]*models.Bill{pastBill, currentBill, nextBill, refundedBill}, nil).
+					Once()
+
+				active := pausedSub()
+				active.Status = models.Active
+				subRepo.EXPECT().
+					TransitionStatus(mock.Anything, defaultSubID, models.Paused, models.Active, mockTime).
+					Return(active, nil).
+					Once()
+
+				billRepo.EXPECT().
+					Update(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+						return b.ID == currentBill.ID &&
+							b.StartDate.Equal(currentBill.StartDate) &&
+							b.EndDate.Equal(currentBill.EndDate.Add(pausedFor))
+					})).
+					RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) { return b, nil }).
+					Once()
+				billRepo.EXPECT().
+					Update(mock.Anything, mock.MatchedBy(func(b *models.Bill) bool {
+						return b.ID == nextBill.ID &&
+							b.StartDate.Equal(nextBill.StartDate.Add(pausedFor)) &&
+							b.EndDate.Equal(nextBill.EndDate.Add(pausedFor))
+					})).
+					RunAndReturn(func(_ context.Context, b *models.Bill) (*models.Bill, error) { return b, nil }).
+					Once()
+
+				subRepo.EXPECT().
+					Update(mock.Anything, mock.MatchedBy(func(s *models.Subscription) bool {
+						return s.PausedAt == nil && s.ValidTill.Equal(nextBill.EndDate.Add(pausedFor))
+					})).
+					RunAndReturn(func(_ context.Context, s *models.Subscription) (*models.Subscription, error) {
+						return s, nil
+					}).
+					Once()
+			},
+		},
+		{
+			// Only paused subscriptions can be resumed
+			name:        "error - not paused",
+			stored:      validSub(),
+			setupMocks:  func(_ *repomocks.MockSubscriptionRepository, _ *repomocks.MockBillRepository) {},
+			wantErrCode: apperror.ErrConflict,
+		},
+		{
+			// A concurrent resume wins the transition
+			name:   "error - lost race",
+			stored: pausedSub(),
+			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository) {
+				billRepo.EXPECT().GetBySubscriptionID(mock.Anything, defaultSubID).Return(nil, nil).Once()
+				subRepo.EXPECT().
+					TransitionStatus(mock.Anything, defaultSubID, models.Paused, models.Active, mockTime).
+					Return(nil, apperror.NewConflictError("Subscription is no longer paused")).
+					Once()
+			},
+			wantErrCode: apperror.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subRepo := repomocks.NewMockSubscriptionRepository(t)
+			billRepo := repomocks.NewMockBillRepository(t)
+			subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(tt.stored, nil).Once()
+			tt.setupMocks(subRepo, billRepo)
+
+			svc := newSubService(subRepo, billRepo, svcmocks.NewMockSubscriptionMetrics(t))
+			got, err := svc.ResumeSubscription(t.Context(), defaultSubHex, defaultUserHex)
+
+			if tt.wantErrCode != "" {
+				require.Error(t, err)
+				appErr, ok := errors.AsType[apperror.AppError](err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantErrCode, appErr.Code())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, models.Active, got.Status)
+			assert.Nil(t, got.PausedAt)
+			assert.Equal(t, nextBill.EndDate.Add(pausedFor), got.ValidTill)
+			// The caller's bills are left untouched.
+			assert.Equal(t, mockToday.AddDate(0, 0, 5), currentBill.EndDate)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+)
+
+type SuppressionServiceExternal interface {
+	// GetSuppressions returns a page of the suppression list, newest first.
+	GetSuppressions(ctx context.Context, query models.SuppressionQuery) (*models.SuppressionPage, error)
+	// AddSuppression stops emails to an address, e.g. one an email provider
+	// reported a bounce or complaint for.
+	AddSuppression(ctx context.Context, suppression *models.Suppression) (*models.Suppression, error)
+	// DeleteSuppression lets emails go to an address again.
+	DeleteSuppression(ctx context.Context, email string) error
+}
+
+type SuppressionServiceInternal interface {
+	// IsSuppressedInternal reports whether no email may be sent to the
+	// address.
+	IsSuppressedInternal(ctx context.Context, email string) (bool, error)
+	// SuppressInternal stops emails to the address for reason.
+	SuppressInternal(ctx context.Context, email string, reason models.SuppressionReason, detail string) error
+}
+
+type SuppressionService interface {
+	SuppressionServiceExternal
+	SuppressionServiceInternal
+}
+
+type suppressionService struct {
+	suppressionRepository repositories.SuppressionRepository
+	getTime               clock.NowFn
+}
+
+// NewSuppressionService creates a new instance of SuppressionService.
+func NewSuppressionService(
+	suppressionRepository repositories.SuppressionRepository,
+	nowFn clock.NowFn,
+) SuppressionService {
+	return &suppressionService{
+		suppressionRepository,
+		nowFn,
+	}
+}
+
+func (s *suppressionService) GetSuppressions(
+	ctx context.Context,
+	query models.SuppressionQuery,
+) (*models.SuppressionPage, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	suppressions, total, err := s.suppressionRepository.List(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SuppressionPage{Items: suppressions, Total: total}, nil
+}
+
+func (s *suppressionService) AddSuppression(
+	ctx context.Context,
+	suppression *models.Suppression,
+) (*models.Suppression, error) {
+	suppression.Email = models.NormalizeEmail(suppression.Email)
+	suppression.CreatedAt = s.getTime()
+	if err := s.suppressionRepository.Upsert(ctx, suppression); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Email address suppressed",
+		logattr.SuppressionReason(string(suppression.Reason)),
+	)
+	return suppression, nil
+}
+
+func (s *suppressionService) DeleteSuppression(ctx context.Context, email string) error {
+	if err := s.suppressionRepository.Delete(ctx, models.NormalizeEmail(email)); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Email address suppression lifted")
+	return nil
+}
+
+func (s *suppressionService) IsSuppressedInternal(ctx context.Context, email string) (bool, error) {
+	return s.suppressionRepository.Exists(ctx, models.NormalizeEmail(email))
+}
+
+func (s *suppressionService) SuppressInternal(
+	ctx context.Context,
+	email string,
+	reason models.SuppressionReason,
+	detail string,
+) error {
+	_, err := s.AddSuppression(ctx, &models.Suppression{Email: email, Reason: reason, Detail: detail})
+	return err
+}
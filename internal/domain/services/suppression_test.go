@@ -0,0 +1,91 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newSuppressionService(repo *repomocks.MockSuppressionRepository) services.SuppressionService {
+	return services.NewSuppressionService(repo, func() time.Time { return mockTime })
+}
+
+// ---------------------------------------------------------------------------
+// GetSuppressions
+// ---------------------------------------------------------------------------
+
+func TestSuppressionService_GetSuppressions(t *testing.T) {
+	t.Run("success - default page size", func(t *testing.T) {
+		repo := repomocks.NewMockSuppressionRepository(t)
+		repo.EXPECT().List(mock.Anything, &models.SuppressionQuery{PageQuery: models.PageQuery{Limit: models.DefaultPageLimit}}).
+			Return([]*models.Suppression{{Email: "gone@example.com"}}, 3, nil).Once()
+
+		page, err := newSuppressionService(repo).GetSuppressions(t.Context(), models.SuppressionQuery{})
+
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, page.Total)
+		assert.Len(t, page.Items, 1)
+	})
+
+	t.Run("error - page too large", func(t *testing.T) {
+		repo := repomocks.NewMockSuppressionRepository(t)
+
+		_, err := newSuppressionService(repo).GetSuppressions(t.Context(), models.SuppressionQuery{PageQuery: models.PageQuery{Limit: models.MaxPageLimit + 1}})
+
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// SuppressInternal
+// ---------------------------------------------------------------------------
+
+func TestSuppressionService_SuppressInternal(t *testing.T) {
+	repo := repomocks.NewMockSuppressionRepository(t)
+	repo.EXPECT().Upsert(mock.Anything, &models.Suppression{
+		Email:     "gone@example.com",
+		Reason:    models.SuppressionBounced,
+		Detail:    "550 No such user",
+		CreatedAt: mockTime,
+	}).Return(nil).Once()
+
+	err := newSuppressionService(repo).SuppressInternal(
+		t.Context(),
+		"Gone@Example.com",
+		models.SuppressionBounced,
+		"550 No such user",
+	)
+
+	require.NoError(t, err)
+}
+
+// ---------------------------------------------------------------------------
+// IsSuppressedInternal and DeleteSuppression
+// ---------------------------------------------------------------------------
+
+func TestSuppressionService_IsSuppressedInternal(t *testing.T) {
+	repo := repomocks.NewMockSuppressionRepository(t)
+	repo.EXPECT().Exists(mock.Anything, "gone@example.com").Return(true, nil).Once()
+
+	suppressed, err := newSuppressionService(repo).IsSuppressedInternal(t.Context(), " GONE@example.com")
+
+	require.NoError(t, err)
+	assert.True(t, suppressed)
+}
+
+func TestSuppressionService_DeleteSuppression(t *testing.T) {
+	repo := repomocks.NewMockSuppressionRepository(t)
+	repo.EXPECT().Delete(mock.Anything, "gone@example.com").
+		Return(apperror.NewNotFoundError("Document not found")).Once()
+
+	err := newSuppressionService(repo).DeleteSuppression(t.Context(), "Gone@example.com")
+
+	assertAppErr(t, err, apperror.ErrNotFound)
+}
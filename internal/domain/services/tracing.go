@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans of domain operations. The tracer is looked
+// up from the global provider on each use, so nothing is exported while
+// OpenTelemetry is disabled.
+const tracerName = "subscription-management/services"
+
+// startSpan starts a span for a service operation and tags it with the user
+// and subscription already in ctx.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+	observability.EnrichSpan(ctx)
+	return ctx, span
+}
+
+// endSpan records err on the span and ends it. Like the HTTP layer, only
+// server-side failures mark the span as failed; rejected requests such as
+// validation or permission errors are recorded as events.
+func endSpan(span trace.Span, err error) {
+	defer span.End()
+
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Status() < 500 {
+		return
+	}
+	span.SetStatus(codes.Error, err.Error())
+}
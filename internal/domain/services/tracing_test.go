@@ -0,0 +1,102 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a global tracer provider that records ended spans and
+// restores the previous provider when the test finishes.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+		_ = tp.Shutdown(t.Context())
+	})
+	return recorder
+}
+
+func TestServiceSpans(t *testing.T) {
+	tests := []struct {
+		name       string
+		subID      string
+		setupMocks func(subRepo *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository)
+		wantErr    bool
+		wantStatus codes.Code
+	}{
+		{
+			// Success leaves the status unset
+			name:  "success",
+			subID: defaultSubHex,
+			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository) {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validExpiredSub(), nil).Once()
+				billRepo.EXPECT().DeleteBySubscriptionID(mock.Anything, defaultSubID).Return(int64(1), nil).Once()
+				subRepo.EXPECT().Delete(mock.Anything, defaultSubID).Return(nil).Once()
+			},
+			wantStatus: codes.Unset,
+		},
+		{
+			// Client errors are recorded but do not fail the span
+			name:       "client error",
+			subID:      "bad-hex",
+			setupMocks: func(_ *repomocks.MockSubscriptionRepository, _ *repomocks.MockBillRepository) {},
+			wantErr:    true,
+			wantStatus: codes.Unset,
+		},
+		{
+			// Server errors fail the span
+			name:  "server error",
+			subID: defaultSubHex,
+			setupMocks: func(subRepo *repomocks.MockSubscriptionRepository, billRepo *repomocks.MockBillRepository) {
+				subRepo.EXPECT().GetByID(mock.Anything, defaultSubID).Return(validExpiredSub(), nil).Once()
+				billRepo.EXPECT().DeleteBySubscriptionID(mock.Anything, defaultSubID).Return(int64(1), nil).Once()
+				subRepo.EXPECT().Delete(mock.Anything, defaultSubID).
+					Return(apperror.NewDBError(errors.New("delete failed"))).Once()
+			},
+			wantErr:    true,
+			wantStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := recordSpans(t)
+			subRepo := repomocks.NewMockSubscriptionRepository(t)
+			billRepo := repomocks.NewMockBillRepository(t)
+			tt.setupMocks(subRepo, billRepo)
+
+			svc := newSubService(subRepo, billRepo, svcmocks.NewMockSubscriptionMetrics(t))
+			err := svc.DeleteSubscription(t.Context(), tt.subID, defaultUserHex)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "Delete Subscription", spans[0].Name())
+			assert.Equal(t, tt.wantStatus, spans[0].Status().Code)
+			if tt.wantErr {
+				require.NotEmpty(t, spans[0].Events())
+				assert.Equal(t, "exception", spans[0].Events()[0].Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// UnsubscribeConfig holds the settings for the unsubscribe links in the
+// footer of every email.
+type UnsubscribeConfig struct {
+	SigningSecret string `mapstructure:"signing_secret"`
+	BaseURL       string `mapstructure:"base_url"` // Public origin prepended to unsubscribe links; empty yields relative URLs.
+}
+
+type UnsubscribeServiceExternal interface {
+	// Unsubscribe turns off the channel a signed unsubscribe link was issued
+	// for in the notification preferences of its owner, and returns the
+	// updated preferences.
+	Unsubscribe(ctx context.Context, email, channel, signature string) (*models.NotificationPreferences, error)
+}
+
+type UnsubscribeServiceInternal interface {
+	// SignUnsubscribeLinkInternal builds a link that stops notifications to
+	// the user with the email address through channel.
+	SignUnsubscribeLinkInternal(email string, channel models.NotificationChannel) string
+}
+
+type UnsubscribeService interface {
+	UnsubscribeServiceExternal
+	UnsubscribeServiceInternal
+}
+
+type unsubscribeService struct {
+	userService       UserServiceInternal
+	preferenceService NotificationPreferenceServiceInternal
+	config            UnsubscribeConfig
+}
+
+// NewUnsubscribeService creates a new instance of UnsubscribeService.
+func NewUnsubscribeService(
+	userService UserServiceInternal,
+	preferenceService NotificationPreferenceServiceInternal,
+	config UnsubscribeConfig,
+) UnsubscribeService {
+	return &unsubscribeService{
+		userService,
+		preferenceService,
+		config,
+	}
+}
+
+// Unsubscribe authenticates the request by the link signature alone, so a
+// mismatch is reported as unauthorized without revealing whether an account
+// uses the address. Links do not expire: they wait in inboxes, and
+// unsubscribing must keep working as long as the emails are read.
+func (s *unsubscribeService) Unsubscribe(
+	ctx context.Context,
+	email, channel, signature string,
+) (*models.NotificationPreferences, error) {
+	email = models.NormalizeEmail(email)
+	notificationChannel := models.NotificationChannel(channel)
+	if !notificationChannel.Valid() {
+		return nil, apperror.NewBadRequestError("Invalid channel")
+	}
+
+	given, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(given, s.sign(email, notificationChannel)) {
+		return nil, apperror.NewUnauthorizedError("Invalid unsubscribe link")
+	}
+
+	user, err := s.userService.FetchUserByEmailInternal(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.preferenceService.DisableChannelInternal(ctx, user.ID, notificationChannel)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "User unsubscribed through link",
+		logattr.UserID(user.ID.Hex()),
+		logattr.Channel(channel),
+	)
+	return prefs, nil
+}
+
+func (s *unsubscribeService) SignUnsubscribeLinkInternal(email string, channel models.NotificationChannel) string {
+	email = models.NormalizeEmail(email)
+
+	query := url.Values{}
+	query.Set("email", email)
+	query.Set("channel", string(channel))
+	query.Set("signature", hex.EncodeToString(s.sign(email, channel)))
+
+	return fmt.Sprintf("%s/api/v1/unsubscribe?%s", s.config.BaseURL, query.Encode())
+}
+
+// sign is domain-separated from the other link signatures, so none of them
+// can pass as an unsubscribe link even if they share a secret.
+func (s *unsubscribeService) sign(email string, channel models.NotificationChannel) []byte {
+	mac := hmac.New(sha256.New, []byte(s.config.SigningSecret))
+	fmt.Fprintf(mac, "unsubscribe.%s.%s", channel, email)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,108 @@
+package services_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	svcmocks "github.com/anuragthepathak/subscription-management/internal/domain/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var unsubscribeCfg = services.UnsubscribeConfig{
+	SigningSecret: "test-unsubscribe-secret",
+	BaseURL:       "https://subs.example.com",
+}
+
+func setupUnsubscribe(t *testing.T) (
+	services.UnsubscribeService,
+	*svcmocks.MockUserServiceInternal,
+	*svcmocks.MockNotificationPreferenceServiceInternal,
+) {
+	t.Helper()
+
+	userSvc := svcmocks.NewMockUserServiceInternal(t)
+	prefSvc := svcmocks.NewMockNotificationPreferenceServiceInternal(t)
+	return services.NewUnsubscribeService(userSvc, prefSvc, unsubscribeCfg), userSvc, prefSvc
+}
+
+// signedUnsubscribeLink returns the email, channel and signature query values
+// of an unsubscribe link issued for the address.
+func signedUnsubscribeLink(t *testing.T, svc services.UnsubscribeService, email string) (string, string, string) {
+	t.Helper()
+
+	link := svc.SignUnsubscribeLinkInternal(email, models.ChannelEmail)
+	require.True(t, strings.HasPrefix(link, unsubscribeCfg.BaseURL+"/api/v1/unsubscribe?"))
+
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	query := parsed.Query()
+	return query.Get("email"), query.Get("channel"), query.Get("signature")
+}
+
+// ---------------------------------------------------------------------------
+// Unsubscribe
+// ---------------------------------------------------------------------------
+
+func TestUnsubscribeService_Unsubscribe(t *testing.T) {
+	t.Run("disables the channel of the owner", func(t *testing.T) {
+		svc, userSvc, prefSvc := setupUnsubscribe(t)
+		email, channel, signature := signedUnsubscribeLink(t, svc, " Test@Example.com")
+		assert.Equal(t, "test@example.com", email)
+
+		prefs := &models.NotificationPreferences{UserID: defaultUserID}
+		userSvc.EXPECT().FetchUserByEmailInternal(mock.Anything, "test@example.com").Return(validUser(), nil).Once()
+		prefSvc.EXPECT().DisableChannelInternal(mock.Anything, defaultUserID, models.ChannelEmail).Return(prefs, nil).Once()
+
+		got, err := svc.Unsubscribe(t.Context(), email, channel, signature)
+		require.NoError(t, err)
+		assert.Same(t, prefs, got)
+	})
+
+	t.Run("rejects a tampered signature", func(t *testing.T) {
+		svc, _, _ := setupUnsubscribe(t)
+		email, channel, _ := signedUnsubscribeLink(t, svc, "test@example.com")
+
+		_, err := svc.Unsubscribe(t.Context(), email, channel, strings.Repeat("0", 64))
+		assertAppErr(t, err, apperror.ErrUnauthorized)
+	})
+
+	t.Run("rejects a link for another address", func(t *testing.T) {
+		svc, _, _ := setupUnsubscribe(t)
+		_, channel, signature := signedUnsubscribeLink(t, svc, "test@example.com")
+
+		_, err := svc.Unsubscribe(t.Context(), "other@example.com", channel, signature)
+		assertAppErr(t, err, apperror.ErrUnauthorized)
+	})
+
+	t.Run("rejects a link for another channel", func(t *testing.T) {
+		svc, _, _ := setupUnsubscribe(t)
+		email, _, signature := signedUnsubscribeLink(t, svc, "test@example.com")
+
+		_, err := svc.Unsubscribe(t.Context(), email, string(models.ChannelSMS), signature)
+		assertAppErr(t, err, apperror.ErrUnauthorized)
+	})
+
+	t.Run("rejects an unknown channel", func(t *testing.T) {
+		svc, _, _ := setupUnsubscribe(t)
+		email, _, signature := signedUnsubscribeLink(t, svc, "test@example.com")
+
+		_, err := svc.Unsubscribe(t.Context(), email, "pigeon", signature)
+		assertAppErr(t, err, apperror.ErrBadRequest)
+	})
+
+	t.Run("reports an account that no longer exists", func(t *testing.T) {
+		svc, userSvc, _ := setupUnsubscribe(t)
+		email, channel, signature := signedUnsubscribeLink(t, svc, "test@example.com")
+		userSvc.EXPECT().FetchUserByEmailInternal(mock.Anything, email).
+			Return(nil, apperror.NewNotFoundError("User not found")).Once()
+
+		_, err := svc.Unsubscribe(t.Context(), email, channel, signature)
+		assertAppErr(t, err, apperror.ErrNotFound)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"github.com/anuragthepathak/subscription-management/internal/core/clock"
@@ -18,12 +19,21 @@ type UserServiceExternal interface {
 	CreateUser(context.Context, *models.User) (*models.User, error)
 	GetAllUsers(context.Context) ([]*models.User, error)
 	GetUserByID(context.Context, string, string) (*models.User, error)
-	DeleteUser(context.Context, string, string) error
+	// DeleteUser closes the account: it cancels the user's subscriptions,
+	// deletes their reminder history, signs out every device, and schedules
+	// the account's deletion after the configured grace period. It returns
+	// the closed user.
+	DeleteUser(context.Context, string, string) (*models.User, error)
 }
 
 type UserServiceInternal interface {
 	FetchUserByIDInternal(context.Context, bson.ObjectID) (*models.User, error)
 	FetchUserByEmailInternal(context.Context, string) (*models.User, error)
+	// PurgeUserInternal deletes a closed account whose grace period is over,
+	// with everything the user stored: payment methods, webhooks,
+	// notification preferences and history, budget, attachments, and files.
+	// Its subscriptions are anonymized so their bills can be kept.
+	PurgeUserInternal(context.Context, bson.ObjectID) error
 }
 
 type UserService interface {
@@ -31,21 +41,68 @@ type UserService interface {
 	UserServiceInternal
 }
 
+// AccountConfig holds the settings for closing accounts.
+type AccountConfig struct {
+	// ClosureGracePeriod is how long a closed account is kept before it is
+	// deleted.
+	ClosureGracePeriod time.Duration `mapstructure:"closure_grace_period"`
+}
+
+// AccountClosureQueue hands closed accounts over to the queue worker, which
+// confirms the closure by email and deletes the account at its PurgeAt.
+type AccountClosureQueue interface {
+	EnqueueAccountClosure(ctx context.Context, user *models.User) error
+}
+
 type userService struct {
 	userRepository              repositories.UserRepository
 	subscriptionServiceInternal SubscriptionServiceInternal
+	notificationServiceInternal NotificationServiceInternal
+	sessionService              SessionServiceInternal
+	paymentMethodService        PaymentMethodServiceInternal
+	webhookService              WebhookServiceInternal
+	preferenceService           NotificationPreferenceServiceInternal
+	budgetService               BudgetServiceInternal
+	attachmentService           AttachmentServiceInternal
+	fileService                 FileServiceInternal
+	audit                       AuditServiceInternal
+	closureQueue                AccountClosureQueue
+	config                      AccountConfig
 	getTime                     clock.NowFn
 }
 
-// NewUserService creates a new instance of UserService.
+// NewUserService creates a new instance of UserService. Processes that do
+// not serve the API may pass a nil closure queue.
 func NewUserService(
 	userRepository repositories.UserRepository,
 	subscriptionServiceInternal SubscriptionServiceInternal,
+	notificationServiceInternal NotificationServiceInternal,
+	sessionService SessionServiceInternal,
+	paymentMethodService PaymentMethodServiceInternal,
+	webhookService WebhookServiceInternal,
+	preferenceService NotificationPreferenceServiceInternal,
+	budgetService BudgetServiceInternal,
+	attachmentService AttachmentServiceInternal,
+	fileService FileServiceInternal,
+	audit AuditServiceInternal,
+	closureQueue AccountClosureQueue,
+	config AccountConfig,
 	nowFn clock.NowFn,
 ) UserService {
 	return &userService{
 		userRepository,
 		subscriptionServiceInternal,
+		notificationServiceInternal,
+		sessionService,
+		paymentMethodService,
+		webhookService,
+		preferenceService,
+		budgetService,
+		attachmentService,
+		fileService,
+		audit,
+		closureQueue,
+		config,
 		nowFn,
 	}
 }
@@ -79,6 +136,11 @@ func (us *userService) CreateUser(ctx context.Context, user *models.User) (*mode
 	// Set ID
 	user.ID = bson.NewObjectID()
 
+	// Accounts get the least privileged role unless the caller chose one.
+	if user.Role == "" {
+		user.Role = models.UserRole
+	}
+
 	// Set timestamps
 	now := us.getTime()
 	user.CreatedAt = now
@@ -94,6 +156,8 @@ func (us *userService) CreateUser(ctx context.Context, user *models.User) (*mode
 		}
 	}
 
+	us.recordAudit(ctx, models.AuditUserCreated, result.ID, nil, result.ToResponse())
+
 	slog.InfoContext(ctx, "User created", logattr.UserID(result.ID.Hex()))
 	return result, nil
 }
@@ -114,31 +178,78 @@ func (us *userService) GetUserByID(ctx context.Context, id string, claimedUserID
 	return us.userRepository.FindByID(ctx, userID)
 }
 
-func (us *userService) DeleteUser(ctx context.Context, id string, claimedUserID string) error {
+// DeleteUser marks the account closed last, so a closure that fails midway
+// can be retried; canceled subscriptions, deleted reminders, and revoked
+// sessions are skipped the second time. Sessions are revoked before then,
+// since the tokens they issued would otherwise keep working until they
+// expire.
+func (us *userService) DeleteUser(ctx context.Context, id string, claimedUserID string) (*models.User, error) {
 	if id != claimedUserID {
-		return apperror.NewForbiddenError("You can only delete your own profile")
+		return nil, apperror.NewForbiddenError("You can only delete your own profile")
 	}
 	userID, err := bson.ObjectIDFromHex(id)
 	if err != nil {
-		return apperror.NewUnauthorizedError("Invalid user ID")
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	if us.closureQueue == nil {
+		return nil, apperror.NewInternalError(errors.New("no account closure queue configured"))
 	}
 
-	// Check if user has any active subscriptions
-	hasActive, err := us.subscriptionServiceInternal.HasActiveSubscriptionsInternal(ctx, userID)
+	user, err := us.userRepository.FindByID(ctx, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if hasActive {
-		return apperror.NewConflictError("User has active subscriptions and cannot be deleted")
+	if user.Closed() {
+		return nil, apperror.NewConflictError("Account is already closed")
 	}
 
-	// Delete the user
-	if err = us.userRepository.Delete(ctx, userID); err != nil {
-		return err
+	canceled, err := us.subscriptionServiceInternal.CancelSubscriptionsInternal(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = us.subscriptionServiceInternal.DeleteReminderHistoryInternal(ctx, userID); err != nil {
+		return nil, err
+	}
+	if _, err = us.sessionService.RevokeSessionsInternal(ctx, userID); err != nil {
+		return nil, err
 	}
 
-	slog.InfoContext(ctx, "User deleted")
-	return nil
+	now := us.getTime()
+	purgeAt := now.Add(us.config.ClosureGracePeriod)
+	user.ClosedAt = &now
+	user.PurgeAt = &purgeAt
+	user.UpdatedAt = now
+	if user, err = us.userRepository.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	// The audit log outlives the account, so it records no profile.
+	us.recordAudit(ctx, models.AuditUserClosed, userID, nil, nil)
+
+	if err = us.closureQueue.EnqueueAccountClosure(ctx, user); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "User account closed",
+		logattr.Total(canceled),
+		logattr.ProcessAt(purgeAt),
+	)
+	return user, nil
+}
+
+// recordAudit records a change that is already committed, so a failure is
+// only logged.
+func (us *userService) recordAudit(
+	ctx context.Context,
+	action models.AuditAction,
+	userID bson.ObjectID,
+	before, after any,
+) {
+	if err := us.audit.RecordInternal(ctx, models.ActorUser, action, userID, before, after); err != nil {
+		slog.WarnContext(ctx, "Failed to record audit event",
+			logattr.UserID(userID.Hex()),
+			logattr.Error(err),
+		)
+	}
 }
 
 func (us *userService) FetchUserByIDInternal(ctx context.Context, id bson.ObjectID) (*models.User, error) {
@@ -148,3 +259,49 @@ func (us *userService) FetchUserByIDInternal(ctx context.Context, id bson.Object
 func (us *userService) FetchUserByEmailInternal(ctx context.Context, email string) (*models.User, error) {
 	return us.userRepository.FindByEmail(ctx, email)
 }
+
+// PurgeUserInternal leaves accounts that are open or still in their grace
+// period alone, so a purge task that runs early deletes nothing. The user is
+// deleted last, so a purge that fails midway is retried in full.
+func (us *userService) PurgeUserInternal(ctx context.Context, id bson.ObjectID) error {
+	user, err := us.userRepository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !user.Closed() || user.PurgeAt.After(us.getTime()) {
+		slog.DebugContext(ctx, "Skipping purge: account not due")
+		return nil
+	}
+
+	if err = us.subscriptionServiceInternal.AnonymizeSubscriptionsInternal(ctx, id); err != nil {
+		return err
+	}
+	if _, err = us.paymentMethodService.DeletePaymentMethodsInternal(ctx, id); err != nil {
+		return err
+	}
+	if _, err = us.webhookService.DeleteWebhooksInternal(ctx, id); err != nil {
+		return err
+	}
+	if err = us.preferenceService.DeletePreferencesInternal(ctx, id); err != nil {
+		return err
+	}
+	if err = us.budgetService.DeleteBudgetInternal(ctx, id); err != nil {
+		return err
+	}
+	if _, err = us.attachmentService.DeleteAttachmentsInternal(ctx, id); err != nil {
+		return err
+	}
+	if _, err = us.fileService.DeleteFilesInternal(ctx, id); err != nil {
+		return err
+	}
+	if _, err = us.notificationServiceInternal.DeleteNotificationsInternal(ctx, id); err != nil {
+		return err
+	}
+	if err = us.userRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	us.recordAudit(ctx, models.AuditUserDeleted, id, nil, nil)
+
+	slog.InfoContext(ctx, "User deleted")
+	return nil
+}
@@ -36,9 +36,14 @@ func newService(
 	repo *repomocks.MockUserRepository,
 	subSvc *svcmocks.MockSubscriptionServiceInternal,
 ) services.UserService {
-	return services.NewUserService(repo, subSvc, func() time.Time { return mockTime })
+	return services.NewUserService(
+		repo, subSvc, nil, nil, nil, nil, nil, nil, nil, nil, nopAudit(), nil, accountCfg,
+		func() time.Time { return mockTime },
+	)
 }
 
+var accountCfg = services.AccountConfig{ClosureGracePeriod: 30 * 24 * time.Hour}
+
 // ---------------------------------------------------------------------------
 // CreateUser
 // ---------------------------------------------------------------------------
@@ -54,10 +59,15 @@ func Test_userService_CreateUser(t *testing.T) {
 		}
 	}
 	buildMatcher := func(input models.User) any {
+		wantRole := input.Role
+		if wantRole == "" {
+			wantRole = models.UserRole
+		}
 		return mock.MatchedBy(func(u *models.User) bool {
 			// Check exact matches for known data
 			isStaticValid := u.Name == input.Name &&
 				u.Email == input.Email &&
+				u.Role == wantRole &&
 				u.CreatedAt.Equal(mockTime) &&
 				u.UpdatedAt.Equal(mockTime)
 
@@ -111,6 +121,31 @@ func Test_userService_CreateUser(t *testing.T) {
 				assert.Equal(t, mockTime, got.UpdatedAt)
 			},
 		},
+		{
+			// A role chosen by the caller (e.g. the admin CLI) is kept.
+			name: "success - explicit role is kept",
+			input: func() *models.User {
+				u := validInput()
+				u.Role = models.AdminRole
+				return u
+			}(),
+			setupMocks: func(repo *repomocks.MockUserRepository, input models.User) {
+				repo.EXPECT().
+					FindByEmail(mock.Anything, input.Email).
+					Return(nil, apperror.NewNotFoundError("not found")).
+					Once()
+				repo.EXPECT().
+					Create(mock.Anything, buildMatcher(input)).
+					RunAndReturn(func(_ context.Context, u *models.User) (*models.User, error) {
+						return u, nil
+					}).
+					Once()
+			},
+			assertResult: func(t *testing.T, _ models.User, got *models.User) {
+				t.Helper()
+				assert.Equal(t, models.AdminRole, got.Role)
+			},
+		},
 		{
 			// Email is already registered → conflict error.
 			name:  "error - email already in use",
@@ -435,106 +470,115 @@ func Test_userService_GetUserByID(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func Test_userService_DeleteUser(t *testing.T) {
+	purgeAt := mockTime.Add(accountCfg.ClosureGracePeriod)
+	closed := func() *models.User {
+		user := validUser()
+		user.ClosedAt, user.PurgeAt = &mockTime, &purgeAt
+		return user
+	}
+	isClosed := mock.MatchedBy(func(u *models.User) bool {
+		return u.ClosedAt != nil && u.ClosedAt.Equal(mockTime) &&
+			u.PurgeAt != nil && u.PurgeAt.Equal(purgeAt) &&
+			u.UpdatedAt.Equal(mockTime)
+	})
+
 	tests := []struct {
 		name          string
 		id            string
 		claimedUserID string
-		parsedID      bson.ObjectID
-		setupSubSvc   func(subSvc *svcmocks.MockSubscriptionServiceInternal, id bson.ObjectID)
-		setupRepo     func(repo *repomocks.MockUserRepository, id bson.ObjectID)
-		wantErr       bool
-		wantErrCode   apperror.ErrorCode
+		setupMocks    func(
+			repo *repomocks.MockUserRepository,
+			subSvc *svcmocks.MockSubscriptionServiceInternal,
+			queue *svcmocks.MockAccountClosureQueue,
+			sessions *svcmocks.MockSessionServiceInternal,
+		)
+		wantErrCode apperror.ErrorCode
 	}{
 		{
-			// Happy path: caller owns the account, no active subs, repo.Delete succeeds.
-			name:          "success - user with no active subscriptions deleted",
+			name:          "success - cancels subscriptions, deletes reminders, revokes sessions, and schedules the purge",
 			id:            defaultUserHex,
 			claimedUserID: defaultUserHex,
-			parsedID:      defaultUserID,
-			setupSubSvc: func(subSvc *svcmocks.MockSubscriptionServiceInternal, id bson.ObjectID) {
-				subSvc.EXPECT().
-					HasActiveSubscriptionsInternal(mock.Anything, id).
-					Return(false, nil).
-					Once()
-			},
-			setupRepo: func(repo *repomocks.MockUserRepository, id bson.ObjectID) {
-				repo.EXPECT().
-					Delete(mock.Anything, id).
-					Return(nil).
-					Once()
+			setupMocks: func(
+				repo *repomocks.MockUserRepository,
+				subSvc *svcmocks.MockSubscriptionServiceInternal,
+				queue *svcmocks.MockAccountClosureQueue,
+				sessions *svcmocks.MockSessionServiceInternal,
+			) {
+				repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(validUser(), nil).Once()
+				subSvc.EXPECT().CancelSubscriptionsInternal(mock.Anything, defaultUserID).Return(2, nil).Once()
+				subSvc.EXPECT().DeleteReminderHistoryInternal(mock.Anything, defaultUserID).Return(5, nil).Once()
+				sessions.EXPECT().RevokeSessionsInternal(mock.Anything, defaultUserID).Return(3, nil).Once()
+				repo.EXPECT().Update(mock.Anything, isClosed).Return(closed(), nil).Once()
+				queue.EXPECT().EnqueueAccountClosure(mock.Anything, closed()).Return(nil).Once()
 			},
 		},
 		{
-			// Caller tries to delete another user's account.
 			name:          "error - caller does not own the account",
 			id:            defaultUserHex,
 			claimedUserID: bson.NewObjectID().Hex(),
-			setupSubSvc:   func(_ *svcmocks.MockSubscriptionServiceInternal, _ bson.ObjectID) {},
-			setupRepo:     func(_ *repomocks.MockUserRepository, _ bson.ObjectID) {},
-			wantErr:       true,
-			wantErrCode:   apperror.ErrForbidden,
+			setupMocks: func(_ *repomocks.MockUserRepository, _ *svcmocks.MockSubscriptionServiceInternal, _ *svcmocks.MockAccountClosureQueue, _ *svcmocks.MockSessionServiceInternal) {
+			},
+			wantErrCode: apperror.ErrForbidden,
 		},
 		{
-			// id is not a valid hex string (ownership passes because both are the same bad hex).
 			name:          "error - malformed id string",
 			id:            "bad-hex",
 			claimedUserID: "bad-hex",
-			setupSubSvc:   func(_ *svcmocks.MockSubscriptionServiceInternal, _ bson.ObjectID) {},
-			setupRepo:     func(_ *repomocks.MockUserRepository, _ bson.ObjectID) {},
-			wantErr:       true,
-			wantErrCode:   apperror.ErrUnauthorized,
+			setupMocks: func(_ *repomocks.MockUserRepository, _ *svcmocks.MockSubscriptionServiceInternal, _ *svcmocks.MockAccountClosureQueue, _ *svcmocks.MockSessionServiceInternal) {
+			},
+			wantErrCode: apperror.ErrUnauthorized,
 		},
 		{
-			// User has at least one active subscription → deletion blocked.
-			name:          "error - user has active subscriptions",
+			name:          "error - account already closed",
 			id:            defaultUserHex,
 			claimedUserID: defaultUserHex,
-			parsedID:      defaultUserID,
-			setupSubSvc: func(subSvc *svcmocks.MockSubscriptionServiceInternal, id bson.ObjectID) {
-				subSvc.EXPECT().
-					HasActiveSubscriptionsInternal(mock.Anything, id).
-					Return(true, nil).
-					Once()
+			setupMocks: func(repo *repomocks.MockUserRepository, _ *svcmocks.MockSubscriptionServiceInternal, _ *svcmocks.MockAccountClosureQueue, _ *svcmocks.MockSessionServiceInternal) {
+				repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(closed(), nil).Once()
 			},
-			setupRepo:   func(_ *repomocks.MockUserRepository, _ bson.ObjectID) {},
-			wantErr:     true,
 			wantErrCode: apperror.ErrConflict,
 		},
 		{
-			// HasActiveSubscriptionsInternal itself returns an error.
-			name:          "error - subscription service returns error",
+			// The account stays open, so the closure can be retried.
+			name:          "error - canceling a subscription fails",
 			id:            defaultUserHex,
 			claimedUserID: defaultUserHex,
-			parsedID:      defaultUserID,
-			setupSubSvc: func(subSvc *svcmocks.MockSubscriptionServiceInternal, id bson.ObjectID) {
+			setupMocks: func(repo *repomocks.MockUserRepository, subSvc *svcmocks.MockSubscriptionServiceInternal, _ *svcmocks.MockAccountClosureQueue, _ *svcmocks.MockSessionServiceInternal) {
+				repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(validUser(), nil).Once()
 				subSvc.EXPECT().
-					HasActiveSubscriptionsInternal(mock.Anything, id).
-					Return(false, apperror.NewDBError(errors.New("subscription lookup failed"))).
+					CancelSubscriptionsInternal(mock.Anything, defaultUserID).
+					Return(1, apperror.NewDBError(errors.New("write failed"))).
 					Once()
 			},
-			setupRepo:   func(_ *repomocks.MockUserRepository, _ bson.ObjectID) {},
-			wantErr:     true,
 			wantErrCode: apperror.ErrDB,
 		},
 		{
-			// repo.Delete fails (e.g. user was already deleted concurrently).
-			name:          "error - repository Delete returns not found",
+			// The account stays open, so the closure can be retried.
+			name:          "error - revoking the sessions fails",
 			id:            defaultUserHex,
 			claimedUserID: defaultUserHex,
-			parsedID:      defaultUserID,
-			setupSubSvc: func(subSvc *svcmocks.MockSubscriptionServiceInternal, id bson.ObjectID) {
-				subSvc.EXPECT().
-					HasActiveSubscriptionsInternal(mock.Anything, id).
-					Return(false, nil).
+			setupMocks: func(
+				repo *repomocks.MockUserRepository,
+				subSvc *svcmocks.MockSubscriptionServiceInternal,
+				_ *svcmocks.MockAccountClosureQueue,
+				sessions *svcmocks.MockSessionServiceInternal,
+			) {
+				repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(validUser(), nil).Once()
+				subSvc.EXPECT().CancelSubscriptionsInternal(mock.Anything, defaultUserID).Return(2, nil).Once()
+				subSvc.EXPECT().DeleteReminderHistoryInternal(mock.Anything, defaultUserID).Return(5, nil).Once()
+				sessions.EXPECT().
+					RevokeSessionsInternal(mock.Anything, defaultUserID).
+					Return(1, apperror.NewInternalError(errors.New("redis unavailable"))).
 					Once()
 			},
-			setupRepo: func(repo *repomocks.MockUserRepository, id bson.ObjectID) {
-				repo.EXPECT().
-					Delete(mock.Anything, id).
-					Return(apperror.NewNotFoundError("user not found")).
-					Once()
+			wantErrCode: apperror.ErrInternal,
+		},
+		{
+			name:          "error - user not found",
+			id:            defaultUserHex,
+			claimedUserID: defaultUserHex,
+			setupMocks: func(repo *repomocks.MockUserRepository, _ *svcmocks.MockSubscriptionServiceInternal, _ *svcmocks.MockAccountClosureQueue, _ *svcmocks.MockSessionServiceInternal) {
+				repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(nil, apperror.NewNotFoundError("user not found")).Once()
 			},
-			wantErr:     true,
 			wantErrCode: apperror.ErrNotFound,
 		},
 	}
@@ -543,29 +587,173 @@ func Test_userService_DeleteUser(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := repomocks.NewMockUserRepository(t)
 			subSvc := svcmocks.NewMockSubscriptionServiceInternal(t)
-			tt.setupSubSvc(subSvc, tt.parsedID)
-			tt.setupRepo(repo, tt.parsedID)
+			queue := svcmocks.NewMockAccountClosureQueue(t)
+			sessions := svcmocks.NewMockSessionServiceInternal(t)
+			tt.setupMocks(repo, subSvc, queue, sessions)
+
+			svc := services.NewUserService(
+				repo, subSvc, nil, sessions, nil, nil, nil, nil, nil, nil, nopAudit(), queue, accountCfg,
+				func() time.Time { return mockTime },
+			)
+			got, err := svc.DeleteUser(t.Context(), tt.id, tt.claimedUserID)
+
+			if tt.wantErrCode != "" {
+				appErr, ok := errors.AsType[apperror.AppError](err)
+				require.True(t, ok, "expected an AppError, got %v", err)
+				assert.Equal(t, tt.wantErrCode, appErr.Code())
+				return
+			}
 
-			svc := newService(repo, subSvc)
-			err := svc.DeleteUser(t.Context(), tt.id, tt.claimedUserID)
+			require.NoError(t, err)
+			assert.Equal(t, closed(), got)
+		})
+	}
+}
 
-			if tt.wantErr {
-				require.Error(t, err)
-				if appErr, ok := errors.AsType[apperror.AppError](err); ok {
-					assert.Equal(t, tt.wantErrCode, appErr.Code(),
-						"unexpected error code: got %s, want %s",
-						appErr.Code(),
-						tt.wantErrCode,
-					)
-				} else {
-					assert.Empty(t, tt.wantErrCode,
-						"test case defined a wantErrCode (%s), but received raw error: %v",
-						tt.wantErrCode, err,
-					)
-				}
+// ---------------------------------------------------------------------------
+// PurgeUserInternal
+// ---------------------------------------------------------------------------
+
+// purgeMocks holds a mock of every service a purge deletes data through.
+type purgeMocks struct {
+	repo           *repomocks.MockUserRepository
+	subscriptions  *svcmocks.MockSubscriptionServiceInternal
+	notifications  *svcmocks.MockNotificationServiceInternal
+	paymentMethods *svcmocks.MockPaymentMethodServiceInternal
+	webhooks       *svcmocks.MockWebhookServiceInternal
+	preferences    *svcmocks.MockNotificationPreferenceServiceInternal
+	budgets        *svcmocks.MockBudgetServiceInternal
+	attachments    *svcmocks.MockAttachmentServiceInternal
+	files          *svcmocks.MockFileServiceInternal
+}
+
+func Test_userService_PurgeUserInternal(t *testing.T) {
+	closedUntil := func(purgeAt time.Time) *models.User {
+		user := validUser()
+		user.ClosedAt, user.PurgeAt = &mockTime, &purgeAt
+		return user
+	}
+	writeFailed := apperror.NewDBError(errors.New("write failed"))
+
+	tests := []struct {
+		name        string
+		setupMocks  func(m *purgeMocks)
+		wantErrCode apperror.ErrorCode
+	}{
+		{
+			name: "success - anonymizes subscriptions, deletes every collection of the user and the user",
+			setupMocks: func(m *purgeMocks) {
+				m.repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(closedUntil(mockTime), nil).Once()
+				m.subscriptions.EXPECT().AnonymizeSubscriptionsInternal(mock.Anything, defaultUserID).Return(nil).Once()
+				m.paymentMethods.EXPECT().DeletePaymentMethodsInternal(mock.Anything, defaultUserID).Return(2, nil).Once()
+				m.webhooks.EXPECT().DeleteWebhooksInternal(mock.Anything, defaultUserID).Return(1, nil).Once()
+				m.preferences.EXPECT().DeletePreferencesInternal(mock.Anything, defaultUserID).Return(nil).Once()
+				m.budgets.EXPECT().DeleteBudgetInternal(mock.Anything, defaultUserID).Return(nil).Once()
+				m.attachments.EXPECT().DeleteAttachmentsInternal(mock.Anything, defaultUserID).Return(4, nil).Once()
+				m.files.EXPECT().DeleteFilesInternal(mock.Anything, defaultUserID).Return(6, nil).Once()
+				m.notifications.EXPECT().DeleteNotificationsInternal(mock.Anything, defaultUserID).Return(3, nil).Once()
+				m.repo.EXPECT().Delete(mock.Anything, defaultUserID).Return(nil).Once()
+			},
+		},
+		{
+			name: "skips an account still in its grace period",
+			setupMocks: func(m *purgeMocks) {
+				m.repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(closedUntil(mockTime.Add(time.Hour)), nil).Once()
+			},
+		},
+		{
+			name: "skips an open account",
+			setupMocks: func(m *purgeMocks) {
+				m.repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(validUser(), nil).Once()
+			},
+		},
+		{
+			name: "error - anonymizing fails before the user is deleted",
+			setupMocks: func(m *purgeMocks) {
+				m.repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(closedUntil(mockTime), nil).Once()
+				m.subscriptions.EXPECT().AnonymizeSubscriptionsInternal(mock.Anything, defaultUserID).Return(writeFailed).Once()
+			},
+			wantErrCode: apperror.ErrDB,
+		},
+		{
+			name: "error - deleting payment methods fails before the user is deleted",
+			setupMocks: func(m *purgeMocks) {
+				m.repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(closedUntil(mockTime), nil).Once()
+				m.subscriptions.EXPECT().AnonymizeSubscriptionsInternal(mock.Anything, defaultUserID).Return(nil).Once()
+				m.paymentMethods.EXPECT().DeletePaymentMethodsInternal(mock.Anything, defaultUserID).Return(0, writeFailed).Once()
+			},
+			wantErrCode: apperror.ErrDB,
+		},
+		{
+			name: "error - deleting files fails before the user is deleted",
+			setupMocks: func(m *purgeMocks) {
+				m.repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(closedUntil(mockTime), nil).Once()
+				m.subscriptions.EXPECT().AnonymizeSubscriptionsInternal(mock.Anything, defaultUserID).Return(nil).Once()
+				m.paymentMethods.EXPECT().DeletePaymentMethodsInternal(mock.Anything, defaultUserID).Return(0, nil).Once()
+				m.webhooks.EXPECT().DeleteWebhooksInternal(mock.Anything, defaultUserID).Return(0, nil).Once()
+				m.preferences.EXPECT().DeletePreferencesInternal(mock.Anything, defaultUserID).Return(nil).Once()
+				m.budgets.EXPECT().DeleteBudgetInternal(mock.Anything, defaultUserID).Return(nil).Once()
+				m.attachments.EXPECT().DeleteAttachmentsInternal(mock.Anything, defaultUserID).Return(0, nil).Once()
+				m.files.EXPECT().DeleteFilesInternal(mock.Anything, defaultUserID).Return(1, writeFailed).Once()
+			},
+			wantErrCode: apperror.ErrDB,
+		},
+		{
+			name: "error - deleting notifications fails before the user is deleted",
+			setupMocks: func(m *purgeMocks) {
+				m.repo.EXPECT().FindByID(mock.Anything, defaultUserID).Return(closedUntil(mockTime), nil).Once()
+				m.subscriptions.EXPECT().AnonymizeSubscriptionsInternal(mock.Anything, defaultUserID).Return(nil).Once()
+				m.paymentMethods.EXPECT().DeletePaymentMethodsInternal(mock.Anything, defaultUserID).Return(0, nil).Once()
+				m.webhooks.EXPECT().DeleteWebhooksInternal(mock.Anything, defaultUserID).Return(0, nil).Once()
+				m.preferences.EXPECT().DeletePreferencesInternal(mock.Anything, defaultUserID).Return(nil).Once()
+				m.budgets.EXPECT().DeleteBudgetInternal(mock.Anything, defaultUserID).Return(nil).Once()
+				m.attachments.EXPECT().DeleteAttachmentsInternal(mock.Anything, defaultUserID).Return(0, nil).Once()
+				m.files.EXPECT().DeleteFilesInternal(mock.Anything, defaultUserID).Return(0, nil).Once()
+				m.notifications.EXPECT().DeleteNotificationsInternal(mock.Anything, defaultUserID).Return(0, writeFailed).Once()
+			},
+			wantErrCode: apperror.ErrDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &purgeMocks{
+				repo:           repomocks.NewMockUserRepository(t),
+				subscriptions:  svcmocks.NewMockSubscriptionServiceInternal(t),
+				notifications:  svcmocks.NewMockNotificationServiceInternal(t),
+				paymentMethods: svcmocks.NewMockPaymentMethodServiceInternal(t),
+				webhooks:       svcmocks.NewMockWebhookServiceInternal(t),
+				preferences:    svcmocks.NewMockNotificationPreferenceServiceInternal(t),
+				budgets:        svcmocks.NewMockBudgetServiceInternal(t),
+				attachments:    svcmocks.NewMockAttachmentServiceInternal(t),
+				files:          svcmocks.NewMockFileServiceInternal(t),
+			}
+			tt.setupMocks(m)
+			svc := services.NewUserService(
+				m.repo,
+				m.subscriptions,
+				m.notifications,
+				nil,
+				m.paymentMethods,
+				m.webhooks,
+				m.preferences,
+				m.budgets,
+				m.attachments,
+				m.files,
+				nopAudit(),
+				nil,
+				accountCfg,
+				func() time.Time { return mockTime },
+			)
+
+			err := svc.PurgeUserInternal(t.Context(), defaultUserID)
+
+			if tt.wantErrCode != "" {
+				appErr, ok := errors.AsType[apperror.AppError](err)
+				require.True(t, ok, "expected an AppError, got %v", err)
+				assert.Equal(t, tt.wantErrCode, appErr.Code())
 				return
 			}
-
 			require.NoError(t, err)
 		})
 	}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	// MaxWebhooksPerUser caps how many webhooks a user can register.
+	MaxWebhooksPerUser = 10
+	// webhookDeliveriesLimit caps the delivery attempts returned per request.
+	webhookDeliveriesLimit = 100
+	// webhookSecretPrefix marks webhook signing secrets, so they are easy to
+	// spot in configuration and logs.
+	webhookSecretPrefix = "whsec_"
+)
+
+// HostCheckFn returns an error if requests must not be sent to host, such as
+// lib.CheckPublicHost for hosts resolving to internal addresses.
+type HostCheckFn func(ctx context.Context, host string) error
+
+type WebhookServiceExternal interface {
+	// CreateWebhook registers a webhook for the user. Only the returned
+	// webhook carries its signing secret. The URL's host must pass the
+	// service's host check.
+	CreateWebhook(ctx context.Context, webhook *models.Webhook, claimedUserID string) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context, claimedUserID string) ([]*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id, claimedUserID string) error
+	// GetWebhookDeliveries returns the latest delivery attempts of the
+	// webhook, newest first. An empty eventType returns every type.
+	GetWebhookDeliveries(ctx context.Context, id, claimedUserID string, eventType models.EventType) ([]*models.WebhookDelivery, error)
+}
+
+type WebhookServiceInternal interface {
+	// FetchWebhooksForEventInternal returns the webhooks of the user that
+	// receive events of the given type.
+	FetchWebhooksForEventInternal(ctx context.Context, userID bson.ObjectID, eventType models.EventType) ([]*models.Webhook, error)
+	FetchWebhookByIDInternal(context.Context, bson.ObjectID) (*models.Webhook, error)
+	RecordDeliveryInternal(context.Context, *models.WebhookDelivery) error
+	// DeleteWebhooksInternal deletes the webhooks of the user with their
+	// delivery attempts and returns how many webhooks were deleted.
+	DeleteWebhooksInternal(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+type WebhookService interface {
+	WebhookServiceExternal
+	WebhookServiceInternal
+}
+
+type webhookService struct {
+	webhookRepository  repositories.WebhookRepository
+	deliveryRepository repositories.WebhookDeliveryRepository
+	checkHost          HostCheckFn
+	getTime            clock.NowFn
+}
+
+// NewWebhookService creates a new instance of WebhookService. A nil
+// checkHost accepts every host.
+func NewWebhookService(
+	webhookRepository repositories.WebhookRepository,
+	deliveryRepository repositories.WebhookDeliveryRepository,
+	checkHost HostCheckFn,
+	nowFn clock.NowFn,
+) WebhookService {
+	return &webhookService{
+		webhookRepository,
+		deliveryRepository,
+		checkHost,
+		nowFn,
+	}
+}
+
+func (s *webhookService) CreateWebhook(
+	ctx context.Context,
+	webhook *models.Webhook,
+	claimedUserID string,
+) (*models.Webhook, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+
+	now := s.getTime()
+	webhook.ID = bson.NewObjectID()
+	webhook.UserID = userID
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+	// Each event type is delivered once, however often it is listed.
+	slices.Sort(webhook.Events)
+	webhook.Events = slices.Compact(webhook.Events)
+	if err = webhook.Validate(); err != nil {
+		return nil, err
+	}
+	if err = s.checkURL(ctx, webhook.URL); err != nil {
+		return nil, err
+	}
+
+	count, err := s.webhookRepository.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxWebhooksPerUser {
+		return nil, apperror.NewConflictError("A user can register at most %d webhooks", MaxWebhooksPerUser)
+	}
+
+	if webhook.Secret, err = newWebhookSecret(); err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+	if webhook, err = s.webhookRepository.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Webhook registered",
+		logattr.WebhookID(webhook.ID.Hex()),
+		logattr.UserID(claimedUserID),
+	)
+	return webhook, nil
+}
+
+func (s *webhookService) GetWebhooks(ctx context.Context, claimedUserID string) ([]*models.Webhook, error) {
+	userID, err := bson.ObjectIDFromHex(claimedUserID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Invalid user ID")
+	}
+	return s.webhookRepository.GetByUserID(ctx, userID)
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, id, claimedUserID string) error {
+	webhook, err := s.ownedWebhook(ctx, id, claimedUserID)
+	if err != nil {
+		return err
+	}
+	if err = s.webhookRepository.Delete(ctx, webhook.ID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Webhook deleted",
+		logattr.WebhookID(id),
+		logattr.UserID(claimedUserID),
+	)
+	return nil
+}
+
+func (s *webhookService) GetWebhookDeliveries(
+	ctx context.Context,
+	id, claimedUserID string,
+	eventType models.EventType,
+) ([]*models.WebhookDelivery, error) {
+	if eventType != "" && !eventType.Valid() {
+		return nil, apperror.NewValidationError("unknown event %s", eventType)
+	}
+	webhook, err := s.ownedWebhook(ctx, id, claimedUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.deliveryRepository.GetByWebhookID(ctx, webhook.ID, eventType, webhookDeliveriesLimit)
+}
+
+func (s *webhookService) FetchWebhooksForEventInternal(
+	ctx context.Context,
+	userID bson.ObjectID,
+	eventType models.EventType,
+) ([]*models.Webhook, error) {
+	return s.webhookRepository.GetByUserIDAndEvent(ctx, userID, eventType)
+}
+
+func (s *webhookService) FetchWebhookByIDInternal(ctx context.Context, id bson.ObjectID) (*models.Webhook, error) {
+	return s.webhookRepository.GetByID(ctx, id)
+}
+
+func (s *webhookService) RecordDeliveryInternal(ctx context.Context, delivery *models.WebhookDelivery) error {
+	delivery.ID = bson.NewObjectID()
+	delivery.CreatedAt = s.getTime()
+	return s.deliveryRepository.Create(ctx, delivery)
+}
+
+// DeleteWebhooksInternal deletes the delivery attempts first, so a purge that
+// fails midway still finds the webhooks, and their attempts, when retried.
+func (s *webhookService) DeleteWebhooksInternal(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	webhooks, err := s.webhookRepository.GetByUserID(ctx, userID)
+	if err != nil || len(webhooks) == 0 {
+		return 0, err
+	}
+	ids := make([]bson.ObjectID, len(webhooks))
+	for i, webhook := range webhooks {
+		ids[i] = webhook.ID
+	}
+	if _, err = s.deliveryRepository.DeleteByWebhookIDs(ctx, ids); err != nil {
+		return 0, err
+	}
+	return s.webhookRepository.DeleteByUserID(ctx, userID)
+}
+
+// ownedWebhook loads the webhook and checks that it belongs to the caller.
+func (s *webhookService) ownedWebhook(ctx context.Context, id, claimedUserID string) (*models.Webhook, error) {
+	webhookID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("Invalid webhook ID")
+	}
+	webhook, err := s.webhookRepository.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.UserID.Hex() != claimedUserID {
+		return nil, apperror.NewForbiddenError("You are not allowed to access this webhook")
+	}
+	return webhook, nil
+}
+
+// newWebhookSecret returns a random signing secret.
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
+
+// checkURL rejects webhook URLs whose host fails the host check. The sender
+// checks the address again when it connects, as the host may re-resolve.
+func (s *webhookService) checkURL(ctx context.Context, rawURL string) error {
+	if s.checkHost == nil {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return apperror.NewValidationError("url is invalid")
+	}
+	if err = s.checkHost(ctx, u.Hostname()); err != nil {
+		slog.InfoContext(ctx, "Rejected webhook URL",
+			logattr.Host(u.Hostname()),
+			logattr.Error(err),
+		)
+		return apperror.NewValidationError("url must resolve to a public address")
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	repomocks "github.com/anuragthepathak/subscription-management/internal/domain/repositories/mocks"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func setupWebhook(t *testing.T) (
+	services.WebhookService,
+	*repomocks.MockWebhookRepository,
+	*repomocks.MockWebhookDeliveryRepository,
+) {
+	t.Helper()
+
+	webhookRepo := repomocks.NewMockWebhookRepository(t)
+	deliveryRepo := repomocks.NewMockWebhookDeliveryRepository(t)
+	// Only IP literals are checked, so the tests do not resolve names.
+	checkHost := func(_ context.Context, host string) error {
+		if addr, err := netip.ParseAddr(host); err == nil && !lib.IsPublicAddr(addr) {
+			return lib.ErrNonPublicAddress
+		}
+		return nil
+	}
+	svc := services.NewWebhookService(webhookRepo, deliveryRepo, checkHost, func() time.Time { return mockTime })
+	return svc, webhookRepo, deliveryRepo
+}
+
+func validWebhook() *models.Webhook {
+	return &models.Webhook{
+		ID:     bson.NewObjectID(),
+		UserID: defaultUserID,
+		URL:    "https://example.com/hooks",
+		Secret: "whsec_test",
+		Events: []models.EventType{models.SubscriptionRenewedEvent},
+	}
+}
+
+// ---------------------------------------------------------------------------
+// CreateWebhook
+// ---------------------------------------------------------------------------
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	t.Run("registers the webhook with a fresh secret", func(t *testing.T) {
+		svc, webhookRepo, _ := setupWebhook(t)
+		webhookRepo.EXPECT().CountByUserID(mock.Anything, defaultUserID).Return(int64(0), nil).Once()
+		webhookRepo.EXPECT().
+			Create(mock.Anything, mock.AnythingOfType("*models.Webhook")).
+			RunAndReturn(func(_ context.Context, w *models.Webhook) (*models.Webhook, error) {
+				return w, nil
+			}).
+			Once()
+
+		got, err := svc.CreateWebhook(t.Context(), &models.Webhook{
+			URL: "https://example.com/hooks",
+			Events: []models.EventType{
+				models.SubscriptionExpiredEvent,
+				models.SubscriptionCanceledEvent,
+				models.SubscriptionExpiredEvent,
+			},
+		}, defaultUserHex)
+
+		require.NoError(t, err)
+		assert.Equal(t, defaultUserID, got.UserID)
+		assert.True(t, strings.HasPrefix(got.Secret, "whsec_"))
+		assert.Equal(t, []models.EventType{models.SubscriptionCanceledEvent, models.SubscriptionExpiredEvent}, got.Events)
+		assert.Equal(t, mockTime, got.CreatedAt)
+	})
+
+	t.Run("rejects unknown events", func(t *testing.T) {
+		svc, _, _ := setupWebhook(t)
+
+		_, err := svc.CreateWebhook(t.Context(), &models.Webhook{
+			URL:    "https://example.com/hooks",
+			Events: []models.EventType{"subscription.deleted"},
+		}, defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+
+	t.Run("rejects URLs of internal addresses", func(t *testing.T) {
+		for _, url := range []string{
+			"http://127.0.0.1:6060/debug/pprof",
+			"http://169.254.169.254/latest/meta-data",
+			"http://10.0.0.5/hooks",
+			"http://[::1]:8081/api/v1/admin",
+			"http://0.0.0.0/hooks",
+		} {
+			svc, _, _ := setupWebhook(t)
+
+			_, err := svc.CreateWebhook(t.Context(), &models.Webhook{
+				URL:    url,
+				Events: []models.EventType{models.SubscriptionRenewedEvent},
+			}, defaultUserHex)
+
+			assertAppErr(t, err, apperror.ErrValidation)
+		}
+	})
+
+	t.Run("caps the webhooks per user", func(t *testing.T) {
+		svc, webhookRepo, _ := setupWebhook(t)
+		webhookRepo.EXPECT().
+			CountByUserID(mock.Anything, defaultUserID).
+			Return(int64(services.MaxWebhooksPerUser), nil).
+			Once()
+
+		_, err := svc.CreateWebhook(t.Context(), &models.Webhook{
+			URL:    "https://example.com/hooks",
+			Events: []models.EventType{models.SubscriptionRenewedEvent},
+		}, defaultUserHex)
+
+		assertAppErr(t, err, apperror.ErrConflict)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// DeleteWebhook
+// ---------------------------------------------------------------------------
+
+func TestWebhookService_DeleteWebhook(t *testing.T) {
+	t.Run("deletes the caller's webhook", func(t *testing.T) {
+		svc, webhookRepo, _ := setupWebhook(t)
+		webhook := validWebhook()
+		webhookRepo.EXPECT().GetByID(mock.Anything, webhook.ID).Return(webhook, nil).Once()
+		webhookRepo.EXPECT().Delete(mock.Anything, webhook.ID).Return(nil).Once()
+
+		require.NoError(t, svc.DeleteWebhook(t.Context(), webhook.ID.Hex(), defaultUserHex))
+	})
+
+	t.Run("forbids deleting another user's webhook", func(t *testing.T) {
+		svc, webhookRepo, _ := setupWebhook(t)
+		webhook := validWebhook()
+		webhookRepo.EXPECT().GetByID(mock.Anything, webhook.ID).Return(webhook, nil).Once()
+
+		err := svc.DeleteWebhook(t.Context(), webhook.ID.Hex(), bson.NewObjectID().Hex())
+
+		assertAppErr(t, err, apperror.ErrForbidden)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// DeleteWebhooksInternal
+// ---------------------------------------------------------------------------
+
+func TestWebhookService_DeleteWebhooksInternal(t *testing.T) {
+	t.Run("deletes the webhooks of the user and their deliveries", func(t *testing.T) {
+		svc, webhookRepo, deliveryRepo := setupWebhook(t)
+		first, second := validWebhook(), validWebhook()
+		webhookRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return([]*models.Webhook{first, second}, nil).Once()
+		deliveryRepo.EXPECT().
+			DeleteByWebhookIDs(mock.Anything, []bson.ObjectID{first.ID, second.ID}).
+			Return(int64(7), nil).
+			Once()
+		webhookRepo.EXPECT().DeleteByUserID(mock.Anything, defaultUserID).Return(int64(2), nil).Once()
+
+		deleted, err := svc.DeleteWebhooksInternal(t.Context(), defaultUserID)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+	})
+
+	t.Run("keeps the webhooks when their deliveries cannot be deleted", func(t *testing.T) {
+		svc, webhookRepo, deliveryRepo := setupWebhook(t)
+		webhook := validWebhook()
+		webhookRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return([]*models.Webhook{webhook}, nil).Once()
+		deliveryRepo.EXPECT().
+			DeleteByWebhookIDs(mock.Anything, []bson.ObjectID{webhook.ID}).
+			Return(int64(0), apperror.NewDBError(errors.New("write failed"))).
+			Once()
+
+		_, err := svc.DeleteWebhooksInternal(t.Context(), defaultUserID)
+
+		assertAppErr(t, err, apperror.ErrDB)
+	})
+
+	t.Run("does nothing for a user without webhooks", func(t *testing.T) {
+		svc, webhookRepo, _ := setupWebhook(t)
+		webhookRepo.EXPECT().GetByUserID(mock.Anything, defaultUserID).Return(nil, nil).Once()
+
+		deleted, err := svc.DeleteWebhooksInternal(t.Context(), defaultUserID)
+
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// GetWebhookDeliveries
+// ---------------------------------------------------------------------------
+
+func TestWebhookService_GetWebhookDeliveries(t *testing.T) {
+	t.Run("filters by event type", func(t *testing.T) {
+		svc, webhookRepo, deliveryRepo := setupWebhook(t)
+		webhook := validWebhook()
+		deliveries := []*models.WebhookDelivery{{ID: bson.NewObjectID(), WebhookID: webhook.ID}}
+		webhookRepo.EXPECT().GetByID(mock.Anything, webhook.ID).Return(webhook, nil).Once()
+		deliveryRepo.EXPECT().
+			GetByWebhookID(mock.Anything, webhook.ID, models.SubscriptionRenewedEvent, int64(100)).
+			Return(deliveries, nil).
+			Once()
+
+		got, err := svc.GetWebhookDeliveries(t.Context(), webhook.ID.Hex(), defaultUserHex, models.SubscriptionRenewedEvent)
+
+		require.NoError(t, err)
+		assert.Equal(t, deliveries, got)
+	})
+
+	t.Run("rejects unknown event types", func(t *testing.T) {
+		svc, _, _ := setupWebhook(t)
+
+		_, err := svc.GetWebhookDeliveries(t.Context(), bson.NewObjectID().Hex(), defaultUserHex, "bill.voided")
+
+		assertAppErr(t, err, apperror.ErrValidation)
+	})
+}
@@ -0,0 +1,98 @@
+// Package eventbus publishes domain events to a message bus, so downstream
+// systems such as analytics and billing can consume them without polling the
+// API or registering webhooks.
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// DriverNATS publishes to NATS JetStream.
+const DriverNATS = "nats"
+
+// EnvelopeVersion is the version of the Envelope layout. It changes only if
+// the envelope itself does; payload changes bump the event's schema version.
+const EnvelopeVersion = 1
+
+// Config holds the message bus settings.
+type Config struct {
+	Driver string `mapstructure:"driver"` // Message bus; empty disables publishing.
+	URL    string `mapstructure:"url"`    // Server URL, e.g. nats://localhost:4222.
+	// SubjectPrefix is prepended to the event type to form the subject,
+	// e.g. "subman.events" publishes "subman.events.subscription.created".
+	SubjectPrefix string        `mapstructure:"subject_prefix"`
+	Timeout       time.Duration `mapstructure:"timeout"` // Per-publish timeout, including the acknowledgement.
+}
+
+// Event is a domain event relayed from the outbox.
+type Event struct {
+	ID          string
+	Type        string
+	AggregateID string
+	UserID      string
+	OccurredAt  time.Time
+	Data        json.RawMessage
+}
+
+// Envelope is the body published for an event. SchemaVersion is the version
+// of the Data schema of the event type, so consumers can handle payloads
+// written before and after an incompatible change.
+type Envelope struct {
+	EnvelopeVersion int             `json:"envelopeVersion"`
+	ID              string          `json:"id"` // Event ID; identical across redeliveries.
+	Type            string          `json:"type"`
+	SchemaVersion   int             `json:"schemaVersion"`
+	AggregateID     string          `json:"aggregateId"`
+	UserID          string          `json:"userId"`
+	OccurredAt      time.Time       `json:"occurredAt"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// Encode returns the envelope of event as JSON.
+func Encode(event Event) ([]byte, error) {
+	return json.Marshal(Envelope{
+		EnvelopeVersion: EnvelopeVersion,
+		ID:              event.ID,
+		Type:            event.Type,
+		SchemaVersion:   models.EventType(event.Type).SchemaVersion(),
+		AggregateID:     event.AggregateID,
+		UserID:          event.UserID,
+		OccurredAt:      event.OccurredAt,
+		Data:            event.Data,
+	})
+}
+
+// Publisher publishes domain events. Publish returns only once the bus has
+// accepted the event; an event published twice carries the same ID, which
+// the bus or its consumers use to drop the duplicate.
+type Publisher interface {
+	// Name identifies the bus in logs.
+	Name() string
+	Publish(ctx context.Context, event Event) error
+	Close() error
+}
+
+// NewPublisher creates the publisher selected by config, or one that drops
+// every event when no bus is configured.
+func NewPublisher(config Config) (Publisher, error) {
+	switch config.Driver {
+	case "":
+		return nopPublisher{}, nil
+	case DriverNATS:
+		return newNATSPublisher(config)
+	default:
+		return nil, fmt.Errorf("unknown event bus driver %q", config.Driver)
+	}
+}
+
+// nopPublisher drops every event.
+type nopPublisher struct{}
+
+func (nopPublisher) Name() string                         { return "none" }
+func (nopPublisher) Publish(context.Context, Event) error { return nil }
+func (nopPublisher) Close() error                         { return nil }
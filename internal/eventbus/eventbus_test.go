@@ -0,0 +1,47 @@
+package eventbus_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/eventbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPublisher(t *testing.T) {
+	publisher, err := eventbus.NewPublisher(eventbus.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "none", publisher.Name())
+	assert.NoError(t, publisher.Publish(t.Context(), eventbus.Event{ID: "1"}), "a disabled bus drops events")
+	assert.NoError(t, publisher.Close())
+
+	_, err = eventbus.NewPublisher(eventbus.Config{Driver: "kafka"})
+	assert.Error(t, err)
+}
+
+func TestEncode(t *testing.T) {
+	occurredAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	data, err := eventbus.Encode(eventbus.Event{
+		ID:          "6650f1a2b3c4d5e6f7a8b9c0",
+		Type:        string(models.BillRefundedEvent),
+		AggregateID: "6650f1a2b3c4d5e6f7a8b9c1",
+		UserID:      "6650f1a2b3c4d5e6f7a8b9c2",
+		OccurredAt:  occurredAt,
+		Data:        json.RawMessage(`{"status":"refunded"}`),
+	})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"envelopeVersion": 1,
+		"id": "6650f1a2b3c4d5e6f7a8b9c0",
+		"type": "bill.refunded",
+		"schemaVersion": 1,
+		"aggregateId": "6650f1a2b3c4d5e6f7a8b9c1",
+		"userId": "6650f1a2b3c4d5e6f7a8b9c2",
+		"occurredAt": "2025-06-01T12:00:00Z",
+		"data": {"status": "refunded"}
+	}`, string(data))
+}
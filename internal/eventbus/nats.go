@@ -0,0 +1,81 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// TypeHeader and SchemaVersionHeader repeat the envelope's type and
+	// schema version, so consumers can filter without decoding the body.
+	TypeHeader          = "Subman-Event-Type"
+	SchemaVersionHeader = "Subman-Schema-Version"
+)
+
+// natsPublisher publishes to JetStream. The event ID is the message ID, so a
+// stream with a duplicate window stores an event published twice once. The
+// stream capturing the subjects is provisioned outside the service.
+type natsPublisher struct {
+	conn          *nats.Conn
+	js            jetstream.JetStream
+	subjectPrefix string
+	timeout       time.Duration
+}
+
+func newNATSPublisher(config Config) (*natsPublisher, error) {
+	// The worker starts even while the server is unreachable; publishes fail,
+	// and their events are retried, until the connection is up.
+	conn, err := nats.Connect(config.URL,
+		nats.Name("subscription-management"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+	return &natsPublisher{conn, js, config.SubjectPrefix, config.Timeout}, nil
+}
+
+func (p *natsPublisher) Name() string {
+	return DriverNATS
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, event Event) error {
+	data, err := Encode(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	msg := nats.NewMsg(p.subject(event.Type))
+	msg.Data = data
+	msg.Header.Set(TypeHeader, event.Type)
+	msg.Header.Set(SchemaVersionHeader, strconv.Itoa(models.EventType(event.Type).SchemaVersion()))
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	if _, err = p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(event.ID)); err != nil {
+		return fmt.Errorf("failed to publish event to NATS: %w", err)
+	}
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
+
+func (p *natsPublisher) subject(eventType string) string {
+	if p.subjectPrefix == "" {
+		return eventType
+	}
+	return p.subjectPrefix + "." + eventType
+}
@@ -0,0 +1,159 @@
+// Package i18n translates user-facing messages. Messages are written in
+// English in the code and double as keys into the catalogs in locales/, in
+// the manner of gettext, so a message without a translation stays English.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the language messages are written in, used when no
+// catalog matches.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var catalogFiles embed.FS
+
+// bundle holds the catalogs, each mapping an English message to its
+// translation, and a matcher over their languages. English comes first, so
+// it is what the matcher falls back to.
+type bundle struct {
+	tags     []language.Tag
+	catalogs []map[string]string // Parallel to tags; nil for English.
+	matcher  language.Matcher
+}
+
+var catalogs = mustLoad()
+
+func mustLoad() *bundle {
+	b, err := load(catalogFiles)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// load reads every locales/<tag>.json catalog of fsys.
+func load(fsys fs.FS) (*bundle, error) {
+	files, err := fs.Glob(fsys, "locales/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	b := &bundle{
+		tags:     []language.Tag{language.MustParse(DefaultLocale)},
+		catalogs: []map[string]string{nil},
+	}
+	for _, file := range files {
+		tag, err := language.Parse(strings.TrimSuffix(path.Base(file), ".json"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid catalog name %s: %w", file, err)
+		}
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog %s: %w", file, err)
+		}
+		var messages map[string]string
+		if err = json.Unmarshal(content, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse catalog %s: %w", file, err)
+		}
+		b.tags = append(b.tags, tag)
+		b.catalogs = append(b.catalogs, messages)
+	}
+	b.matcher = language.NewMatcher(b.tags)
+	return b, nil
+}
+
+// Supported lists the locales there are messages in, English first.
+func Supported() []string {
+	locales := make([]string, len(catalogs.tags))
+	for i, tag := range catalogs.tags {
+		locales[i] = tag.String()
+	}
+	return locales
+}
+
+// Localizer translates messages into one locale. The nil *Localizer
+// translates into English.
+type Localizer struct {
+	tag      language.Tag
+	messages map[string]string
+}
+
+var defaultLocalizer = &Localizer{tag: language.MustParse(DefaultLocale)}
+
+// Default returns the English Localizer.
+func Default() *Localizer {
+	return defaultLocalizer
+}
+
+// Match returns the Localizer of the first of locales a catalog exists
+// for, or the English one. Each of locales is a BCP 47 tag, such as a user's
+// locale, or an Accept-Language header; empty ones are skipped.
+func Match(locales ...string) *Localizer {
+	for _, locale := range locales {
+		if locale == "" {
+			continue
+		}
+		desired, _, err := language.ParseAcceptLanguage(locale)
+		if err != nil || len(desired) == 0 {
+			continue
+		}
+		_, index, confidence := catalogs.matcher.Match(desired...)
+		if confidence == language.No {
+			continue
+		}
+		return &Localizer{tag: catalogs.tags[index], messages: catalogs.catalogs[index]}
+	}
+	return defaultLocalizer
+}
+
+// Locale returns the BCP 47 tag of the locale, e.g. "de".
+func (l *Localizer) Locale() string {
+	if l == nil {
+		return DefaultLocale
+	}
+	return l.tag.String()
+}
+
+// Sprintf translates format and formats it with args. Without args, the
+// translation is returned as is.
+func (l *Localizer) Sprintf(format string, args ...any) string {
+	if l != nil {
+		if translated, ok := l.messages[format]; ok {
+			format = translated
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Date formats t as a long date, e.g. "March 14, 2026".
+func (l *Localizer) Date(t time.Time) string {
+	return l.Sprintf("%[2]s %[1]d, %[3]d", t.Day(), l.Sprintf(t.Month().String()), t.Year())
+}
+
+// FromContext returns the Localizer of the locale carried by ctx, or the
+// English one.
+func FromContext(ctx context.Context) *Localizer {
+	locale, _ := appctx.GetLocale(ctx)
+	return Match(locale)
+}
+
+// T translates format into the locale carried by ctx and formats it with
+// args.
+func T(ctx context.Context, format string, args ...any) string {
+	return FromContext(ctx).Sprintf(format, args...)
+}
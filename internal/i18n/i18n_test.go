@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		locales []string
+		want    string
+	}{
+		{name: "exact locale", locales: []string{"de"}, want: "de"},
+		{name: "regional variant", locales: []string{"es-MX"}, want: "es"},
+		{name: "accept-language by quality", locales: []string{"fr;q=0.9, es;q=0.8, de;q=0.5"}, want: "es"},
+		{name: "first locale with a catalog wins", locales: []string{"", "fr", "de-AT"}, want: "de"},
+		{name: "unknown locale", locales: []string{"ja"}, want: DefaultLocale},
+		{name: "malformed locale", locales: []string{"not a locale!"}, want: DefaultLocale},
+		{name: "no locale", want: DefaultLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Match(tt.locales...).Locale())
+		})
+	}
+}
+
+func TestLocalizer(t *testing.T) {
+	t.Run("translates and formats", func(t *testing.T) {
+		assert.Equal(t, "Kanal sms ist nicht verfügbar",
+			Match("de").Sprintf("channel %s is not available", "sms"))
+	})
+
+	t.Run("messages without a translation stay English", func(t *testing.T) {
+		assert.Equal(t, "no such message 3", Match("de").Sprintf("no such message %d", 3))
+	})
+
+	t.Run("returns translations without args as they are", func(t *testing.T) {
+		assert.Equal(t, "%s debe ser un ID válido", Match("es").Sprintf("%s must be a valid ID"))
+	})
+
+	t.Run("nil translates into English", func(t *testing.T) {
+		var loc *Localizer
+
+		assert.Equal(t, DefaultLocale, loc.Locale())
+		assert.Equal(t, "Invalid JSON", loc.Sprintf("Invalid JSON"))
+	})
+
+	t.Run("formats dates", func(t *testing.T) {
+		date := time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC)
+
+		assert.Equal(t, "March 14, 2026", Default().Date(date))
+		assert.Equal(t, "14. März 2026", Match("de").Date(date))
+		assert.Equal(t, "14 de marzo de 2026", Match("es").Date(date))
+	})
+}
+
+func TestT(t *testing.T) {
+	ctx := appctx.WithLocale(context.Background(), "es")
+
+	assert.Equal(t, "JSON no válido", T(ctx, "Invalid JSON"))
+	assert.Equal(t, "Invalid JSON", T(context.Background(), "Invalid JSON"))
+}
+
+// verbPattern matches a fmt verb with its optional argument index.
+var verbPattern = regexp.MustCompile(`%(?:\[(\d+)\])?[-+# 0]*\d*(?:\.\d+)?([a-zA-Z%])`)
+
+// verbs maps each argument position of format to the verb formatting it.
+func verbs(format string) map[int]string {
+	found := map[int]string{}
+	position := 1
+	for _, match := range verbPattern.FindAllStringSubmatch(format, -1) {
+		if match[2] == "%" {
+			continue
+		}
+		if match[1] != "" {
+			position, _ = strconv.Atoi(match[1])
+		}
+		found[position] = match[2]
+		position++
+	}
+	return found
+}
+
+func TestCatalogs(t *testing.T) {
+	require.Greater(t, len(catalogs.tags), 1)
+	assert.Equal(t, DefaultLocale, Supported()[0])
+
+	for i, messages := range catalogs.catalogs[1:] {
+		locale := catalogs.tags[i+1].String()
+		for message := range catalogs.catalogs[1] {
+			assert.Contains(t, messages, message, "%s catalog misses a message", locale)
+		}
+		for message, translation := range messages {
+			assert.Equal(t, verbs(message), verbs(translation),
+				"%s translation of %q formats other arguments", locale, message)
+		}
+	}
+}
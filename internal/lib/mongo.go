@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -14,18 +16,18 @@ import (
 )
 
 // BuildMongoURI constructs a connection string dynamically based on the host type.
-func BuildMongoURI(host string, port int, username, password, dbName, authSource string) string {
+// Credentials are not embedded; they are supplied separately through
+// options.Credential so every auth mechanism is configured the same way.
+func BuildMongoURI(host string, port int, dbName string, srv bool) string {
 	// Construct the base URL using Go's native struct
 	u := &url.URL{
-		Scheme:   "mongodb",
-		User:     url.UserPassword(username, password), // This safely triggers the hidden password escaper!
-		Host:     fmt.Sprintf("%s:%d", host, port),
-		Path:     "/" + dbName,
-		RawQuery: "authSource=" + authSource,
+		Scheme: "mongodb",
+		Host:   fmt.Sprintf("%s:%d", host, port),
+		Path:   "/" + dbName,
 	}
 
 	// Handle the Atlas SRV protocol vs Standard protocol
-	if strings.HasSuffix(host, "mongodb.net") {
+	if srv || strings.HasSuffix(host, "mongodb.net") {
 		u.Scheme = "mongodb+srv"
 		u.Host = host // SRV drops the port
 	}
@@ -34,12 +36,64 @@ func BuildMongoURI(host string, port int, username, password, dbName, authSource
 	return u.String()
 }
 
+// OperationTimeouts bounds every MongoDB operation run through this package
+// by its class, on top of any deadline the caller's context already has, so a
+// slow server cannot hold a request or task forever. A zero bound leaves the
+// class to the caller's context. FindEach is never bounded: a scan runs its
+// callback between documents and lasts as long as its caller allows.
+type OperationTimeouts struct {
+	Read  time.Duration // FindOne, FindMany, and Count.
+	Write time.Duration // Create, Update, FindOneAndUpdate, and Delete.
+	Bulk  time.Duration // Aggregate, UpdateMany, and DeleteMany.
+}
+
+var operationTimeouts atomic.Pointer[OperationTimeouts]
+
+// SetOperationTimeouts replaces the bounds of subsequent operations.
+func SetOperationTimeouts(timeouts OperationTimeouts) {
+	operationTimeouts.Store(&timeouts)
+}
+
+// operationClass selects the bound of an operation in OperationTimeouts.
+type operationClass int
+
+const (
+	opRead operationClass = iota
+	opWrite
+	opBulk
+)
+
+// withTimeout derives the context of a single operation of the class. The
+// context's deadline is the earlier of the caller's and the class bound.
+func withTimeout(ctx context.Context, class operationClass) (context.Context, context.CancelFunc) {
+	timeouts := operationTimeouts.Load()
+	if timeouts == nil {
+		return ctx, func() {}
+	}
+	var timeout time.Duration
+	switch class {
+	case opRead:
+		timeout = timeouts.Read
+	case opWrite:
+		timeout = timeouts.Write
+	case opBulk:
+		timeout = timeouts.Bulk
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 func Create(
 	ctx context.Context,
 	collection *mongo.Collection,
 	model any,
 	opts ...options.Lister[options.InsertOneOptions],
 ) error {
+	ctx, cancel := withTimeout(ctx, opWrite)
+	defer cancel()
+
 	_, err := collection.InsertOne(ctx, model, opts...)
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
@@ -59,6 +113,9 @@ func FindOne[T any](
 	filter bson.M,
 	opts ...options.Lister[options.FindOneOptions],
 ) (*T, error) {
+	ctx, cancel := withTimeout(ctx, opRead)
+	defer cancel()
+
 	var res T
 	err := collection.FindOne(ctx, filter, opts...).Decode(&res)
 	if err != nil {
@@ -79,6 +136,9 @@ func FindMany[T any](
 	filter bson.M,
 	opts ...options.Lister[options.FindOptions],
 ) ([]*T, error) {
+	ctx, cancel := withTimeout(ctx, opRead)
+	defer cancel()
+
 	cursor, err := collection.Find(ctx, filter, opts...)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
@@ -106,12 +166,91 @@ func FindMany[T any](
 	return res, nil
 }
 
+// FindEach decodes the documents matching filter one at a time and calls fn
+// with each, so large results are never held in memory. It stops at the
+// first error fn returns and returns it unchanged.
+func FindEach[T any](
+	ctx context.Context,
+	collection *mongo.Collection,
+	filter bson.M,
+	fn func(*T) error,
+	opts ...options.Lister[options.FindOptions],
+) error {
+	cursor, err := collection.Find(ctx, filter, opts...)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return apperror.NewTimeoutError(err)
+		}
+		return apperror.NewDBError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var item T
+		if err := cursor.Decode(&item); err != nil {
+			return apperror.NewDBError(err)
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return apperror.NewTimeoutError(err)
+		}
+		return apperror.NewDBError(err)
+	}
+	return nil
+}
+
+// Aggregate runs an aggregation pipeline and decodes every resulting
+// document into T.
+func Aggregate[T any](
+	ctx context.Context,
+	collection *mongo.Collection,
+	pipeline mongo.Pipeline,
+	opts ...options.Lister[options.AggregateOptions],
+) ([]*T, error) {
+	ctx, cancel := withTimeout(ctx, opBulk)
+	defer cancel()
+
+	cursor, err := collection.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, apperror.NewTimeoutError(err)
+		}
+		return nil, apperror.NewDBError(err)
+	}
+	defer cursor.Close(ctx)
+
+	var res []*T
+	for cursor.Next(ctx) {
+		var item T
+		if err := cursor.Decode(&item); err != nil {
+			return nil, apperror.NewDBError(err)
+		}
+		res = append(res, &item)
+	}
+
+	if err := cursor.Err(); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, apperror.NewTimeoutError(err)
+		}
+		return nil, apperror.NewDBError(err)
+	}
+	return res, nil
+}
+
 func Count(
 	ctx context.Context,
 	collection *mongo.Collection,
 	filter bson.M,
 	opts ...options.Lister[options.CountOptions],
 ) (int64, error) {
+	ctx, cancel := withTimeout(ctx, opRead)
+	defer cancel()
+
 	res, err := collection.CountDocuments(ctx, filter, opts...)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
@@ -129,6 +268,9 @@ func Update(
 	model any,
 	opts ...options.Lister[options.ReplaceOptions],
 ) error {
+	ctx, cancel := withTimeout(ctx, opWrite)
+	defer cancel()
+
 	res, err := collection.ReplaceOne(ctx, filter, model, opts...)
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
@@ -145,12 +287,97 @@ func Update(
 	return nil
 }
 
+// FindOneAndUpdate applies update to the first document matching filter and
+// decodes the result. The pre-update document is returned unless opts request
+// options.After.
+func FindOneAndUpdate[T any](
+	ctx context.Context,
+	collection *mongo.Collection,
+	filter bson.M,
+	update any,
+	opts ...options.Lister[options.FindOneAndUpdateOptions],
+) (*T, error) {
+	ctx, cancel := withTimeout(ctx, opWrite)
+	defer cancel()
+
+	var res T
+	err := collection.FindOneAndUpdate(ctx, filter, update, opts...).Decode(&res)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperror.NewNotFoundError("Document not found")
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, apperror.NewTimeoutError(err)
+		}
+		return nil, apperror.NewDBError(err)
+	}
+	return &res, nil
+}
+
+// PartialUpdate builds an update document that writes only the given fields
+// of model, named by their bson keys. A field missing from the encoded model,
+// e.g. an empty value tagged omitempty, is unset rather than set to its zero
+// value. It returns an error if fields is empty.
+func PartialUpdate(model any, fields ...string) (bson.M, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("no fields to update")
+	}
+	doc, err := bson.Marshal(model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+
+	set, unset := bson.M{}, bson.M{}
+	for _, field := range fields {
+		value, err := bson.Raw(doc).LookupErr(field)
+		if err != nil {
+			unset[field] = ""
+			continue
+		}
+		set[field] = value
+	}
+
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update, nil
+}
+
+// UpdateMany applies update to every document matching filter and returns
+// how many were modified. Matching nothing is not an error.
+func UpdateMany(
+	ctx context.Context,
+	collection *mongo.Collection,
+	filter bson.M,
+	update any,
+	opts ...options.Lister[options.UpdateManyOptions],
+) (int64, error) {
+	ctx, cancel := withTimeout(ctx, opBulk)
+	defer cancel()
+
+	res, err := collection.UpdateMany(ctx, filter, update, opts...)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return 0, apperror.NewTimeoutError(err)
+		}
+		return 0, apperror.NewDBError(err)
+	}
+	return res.ModifiedCount, nil
+}
+
 func Delete(
 	ctx context.Context,
 	collection *mongo.Collection,
 	filter bson.M,
 	opts ...options.Lister[options.DeleteOneOptions],
 ) error {
+	ctx, cancel := withTimeout(ctx, opWrite)
+	defer cancel()
+
 	res, err := collection.DeleteOne(ctx, filter, opts...)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
@@ -163,3 +390,24 @@ func Delete(
 	}
 	return nil
 }
+
+// DeleteMany removes every document matching filter and returns how many were
+// deleted. Unlike Delete, matching nothing is not an error.
+func DeleteMany(
+	ctx context.Context,
+	collection *mongo.Collection,
+	filter bson.M,
+	opts ...options.Lister[options.DeleteManyOptions],
+) (int64, error) {
+	ctx, cancel := withTimeout(ctx, opBulk)
+	defer cancel()
+
+	res, err := collection.DeleteMany(ctx, filter, opts...)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return 0, apperror.NewTimeoutError(err)
+		}
+		return 0, apperror.NewDBError(err)
+	}
+	return res.DeletedCount, nil
+}
@@ -232,6 +232,56 @@ func TestFindMany(t *testing.T) {
 	})
 }
 
+func TestFindEach(t *testing.T) {
+	// Happy path
+	t.Run("calls fn with every matching document", func(t *testing.T) {
+		collection := newTestCollection(t)
+		doc1 := newDummyDoc("Target")
+		doc2 := newDummyDoc("Target")
+		noise := newDummyDoc("Noise")
+		_, err := collection.InsertMany(t.Context(), []any{doc1, doc2, noise})
+		require.NoError(t, err)
+
+		var got []*dummyDoc
+		err = lib.FindEach(t.Context(), collection, bson.M{"name": "Target"}, func(doc *dummyDoc) error {
+			got = append(got, doc)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []*dummyDoc{doc1, doc2}, got)
+	})
+
+	// Callback error
+	t.Run("stops at the first error of fn", func(t *testing.T) {
+		collection := newTestCollection(t)
+		_, err := collection.InsertMany(t.Context(), []any{newDummyDoc("A"), newDummyDoc("B")})
+		require.NoError(t, err)
+		stop := errors.New("stop")
+
+		calls := 0
+		err = lib.FindEach(t.Context(), collection, bson.M{}, func(*dummyDoc) error {
+			calls++
+			return stop
+		})
+
+		require.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+	})
+
+	// Deadline exceeded
+	t.Run("translates context.DeadlineExceeded to apperror", func(t *testing.T) {
+		collection := newTestCollection(t)
+		ctx, cancel := context.WithDeadline(t.Context(), time.Now().Add(-1*time.Second))
+		defer cancel()
+
+		err := lib.FindEach(ctx, collection, bson.M{}, func(*dummyDoc) error { return nil })
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrTimeout)
+	})
+}
+
 func TestCount(t *testing.T) {
 	// Happy path
 	t.Run("successfully counts matching documents", func(t *testing.T) {
@@ -274,6 +324,47 @@ func TestCount(t *testing.T) {
 	})
 }
 
+func TestAggregate(t *testing.T) {
+	type nameCount struct {
+		Name  string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+
+	// Happy path
+	t.Run("successfully decodes the pipeline output", func(t *testing.T) {
+		collection := newTestCollection(t)
+		_, err := collection.InsertMany(t.Context(), []any{
+			newDummyDoc("Target"),
+			newDummyDoc("Target"),
+			newDummyDoc("Noise"),
+		})
+		require.NoError(t, err)
+
+		res, err := lib.Aggregate[nameCount](t.Context(), collection, mongo.Pipeline{
+			{{Key: "$group", Value: bson.M{"_id": "$name", "count": bson.M{"$sum": 1}}}},
+			{{Key: "$sort", Value: bson.M{"_id": 1}}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, nameCount{Name: "Noise", Count: 1}, *res[0])
+		assert.Equal(t, nameCount{Name: "Target", Count: 2}, *res[1])
+	})
+
+	// Deadline exceeded
+	t.Run("translates context.DeadlineExceeded to apperror", func(t *testing.T) {
+		collection := newTestCollection(t)
+		ctx, cancel := context.WithDeadline(t.Context(), time.Now().Add(-1*time.Second))
+		defer cancel()
+
+		res, err := lib.Aggregate[nameCount](ctx, collection, mongo.Pipeline{})
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrTimeout)
+		assert.Nil(t, res)
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	// Happy path
 	t.Run("successfully updates a document", func(t *testing.T) {
@@ -348,6 +439,58 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestFindOneAndUpdate(t *testing.T) {
+	// Happy path
+	t.Run("successfully updates and returns the document", func(t *testing.T) {
+		collection := newTestCollection(t)
+		doc := newDummyDoc("Before")
+		_, err := collection.InsertOne(t.Context(), doc)
+		require.NoError(t, err)
+
+		got, err := lib.FindOneAndUpdate[dummyDoc](
+			t.Context(),
+			collection,
+			bson.M{"_id": doc.ID, "name": "Before"},
+			bson.M{"$set": bson.M{"name": "After"}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "After", got.Name)
+	})
+
+	// Precondition not met
+	t.Run("translates mongo.ErrNoDocuments to apperror.ErrNotFound", func(t *testing.T) {
+		collection := newTestCollection(t)
+		doc := newDummyDoc("Current")
+		_, err := collection.InsertOne(t.Context(), doc)
+		require.NoError(t, err)
+
+		got, err := lib.FindOneAndUpdate[dummyDoc](
+			t.Context(),
+			collection,
+			bson.M{"_id": doc.ID, "name": "Stale"},
+			bson.M{"$set": bson.M{"name": "After"}},
+		)
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrNotFound)
+		assert.Nil(t, got)
+	})
+
+	// Timeout
+	t.Run("translates context.DeadlineExceeded to apperror", func(t *testing.T) {
+		collection := newTestCollection(t)
+		ctx, cancel := context.WithDeadline(t.Context(), time.Now().Add(-1*time.Second))
+		defer cancel()
+
+		got, err := lib.FindOneAndUpdate[dummyDoc](ctx, collection, bson.M{}, bson.M{"$set": bson.M{"name": "x"}})
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrTimeout)
+		assert.Nil(t, got)
+	})
+}
+
 func TestDelete(t *testing.T) {
 	// Happy path
 	t.Run("successfully deletes a document", func(t *testing.T) {
@@ -387,3 +530,29 @@ func TestDelete(t *testing.T) {
 		assertAppErrorCode(t, err, apperror.ErrTimeout)
 	})
 }
+
+func TestSetOperationTimeouts(t *testing.T) {
+	t.Cleanup(func() { lib.SetOperationTimeouts(lib.OperationTimeouts{}) })
+
+	collection := newTestCollection(t)
+	doc := newDummyDoc("Test")
+	_, err := collection.InsertOne(t.Context(), doc)
+	require.NoError(t, err)
+
+	// Only reads are bounded, and too tightly to ever finish.
+	lib.SetOperationTimeouts(lib.OperationTimeouts{Read: time.Nanosecond})
+
+	t.Run("bounds operations of a class and translates the deadline", func(t *testing.T) {
+		got, err := lib.FindOne[dummyDoc](t.Context(), collection, bson.M{"_id": doc.ID})
+
+		require.Error(t, err)
+		assertAppErrorCode(t, err, apperror.ErrTimeout)
+		assert.Nil(t, got)
+	})
+
+	t.Run("leaves classes without a bound to the caller", func(t *testing.T) {
+		err := lib.Create(t.Context(), collection, newDummyDoc("Other"))
+
+		require.NoError(t, err)
+	})
+}
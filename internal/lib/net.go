@@ -1,13 +1,21 @@
 package lib
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"net/netip"
 	"strings"
+	"syscall"
 )
 
+// ErrNonPublicAddress is returned for hosts that resolve to an address other
+// services must not be made to call: loopback, private, link-local,
+// multicast, or unspecified.
+var ErrNonPublicAddress = errors.New("address is not public")
+
 // ClientIP extracts the true client IP, heavily defending against X-Forwarded-For spoofing.
 func ClientIP(r *http.Request) (string, error) {
 	// Establish the Trust Boundary
@@ -70,3 +78,56 @@ func ClientIP(r *http.Request) (string, error) {
 	// Used if the chain only contained private IPs, or headers were empty.
 	return remoteIPStr, nil
 }
+
+// IsPublicAddr reports whether addr can be reached from the internet, i.e.
+// it is none of loopback, private, link-local, multicast, or unspecified.
+// IPv4-mapped IPv6 addresses are judged by their IPv4 address.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified()
+}
+
+// CheckPublicHost resolves host and returns ErrNonPublicAddress if any of
+// its addresses is not public, so a name cannot mix in an internal address.
+// An IP literal is checked without a lookup.
+func CheckPublicHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !IsPublicAddr(addr) {
+			return fmt.Errorf("%s: %w", host, ErrNonPublicAddress)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !IsPublicAddr(addr) {
+			return fmt.Errorf("%s resolves to %s: %w", host, addr, ErrNonPublicAddress)
+		}
+	}
+	return nil
+}
+
+// PublicOnlyControl is a net.Dialer Control function that refuses to connect
+// to addresses that are not public. It sees the address actually dialed,
+// after name resolution, so a host re-resolving to an internal address
+// between a check and the request is still refused.
+func PublicOnlyControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("failed to parse dialed address %s: %w", address, err)
+	}
+	if !IsPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%s: %w", addrPort.Addr(), ErrNonPublicAddress)
+	}
+	return nil
+}
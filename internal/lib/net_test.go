@@ -2,6 +2,7 @@ package lib_test
 
 import (
 	"net/http"
+	"net/netip"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -135,3 +136,42 @@ func TestClientIP(t *testing.T) {
 		})
 	}
 }
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"203.0.113.5", true},
+		{"2001:db8::1", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"fd00::1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.want, lib.IsPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestCheckPublicHost(t *testing.T) {
+	require.NoError(t, lib.CheckPublicHost(t.Context(), "203.0.113.5"))
+	require.ErrorIs(t, lib.CheckPublicHost(t.Context(), "169.254.169.254"), lib.ErrNonPublicAddress)
+	require.ErrorIs(t, lib.CheckPublicHost(t.Context(), "localhost"), lib.ErrNonPublicAddress)
+}
+
+func TestPublicOnlyControl(t *testing.T) {
+	require.NoError(t, lib.PublicOnlyControl("tcp4", "203.0.113.5:443", nil))
+	require.ErrorIs(t, lib.PublicOnlyControl("tcp4", "127.0.0.1:6060", nil), lib.ErrNonPublicAddress)
+	require.ErrorIs(t, lib.PublicOnlyControl("tcp6", "[fe80::1]:80", nil), lib.ErrNonPublicAddress)
+}
@@ -0,0 +1,57 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Backoff describes an exponential backoff schedule.
+type Backoff struct {
+	Initial time.Duration // Wait after the first failure; doubled after each further one.
+	Max     time.Duration // Upper bound for a single wait.
+	MaxWait time.Duration // Total time to keep retrying; zero makes a single attempt.
+}
+
+// Retry calls op until it succeeds, b.MaxWait has passed, or ctx is done.
+// onRetry, when non-nil, is called after each failed attempt that will be
+// retried, with the attempt number and the wait before the next one.
+func Retry(
+	ctx context.Context,
+	b Backoff,
+	op func(context.Context) error,
+	onRetry func(attempt int, wait time.Duration, err error),
+) error {
+	start := time.Now()
+	wait := b.Initial
+
+	for attempt := 1; ; attempt++ {
+		err := op(ctx)
+		if err == nil {
+			return nil
+		}
+
+		remaining := b.MaxWait - time.Since(start)
+		if remaining <= 0 {
+			if attempt == 1 {
+				return err
+			}
+			return fmt.Errorf("gave up after %d attempts in %s: %w",
+				attempt, time.Since(start).Round(time.Millisecond), err)
+		}
+
+		wait = min(wait, b.Max, remaining)
+		if onRetry != nil {
+			onRetry(attempt, wait, err)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
@@ -0,0 +1,70 @@
+package lib_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+)
+
+func TestRetry(t *testing.T) {
+	errNotReady := errors.New("not ready")
+
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		calls := 0
+		var waits []time.Duration
+		err := lib.Retry(t.Context(),
+			lib.Backoff{Initial: time.Millisecond, Max: 3 * time.Millisecond, MaxWait: time.Second},
+			func(context.Context) error {
+				calls++
+				if calls < 4 {
+					return errNotReady
+				}
+				return nil
+			},
+			func(_ int, wait time.Duration, _ error) { waits = append(waits, wait) },
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, calls)
+		assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond}, waits)
+	})
+
+	t.Run("zero max wait makes a single attempt", func(t *testing.T) {
+		calls := 0
+		err := lib.Retry(t.Context(), lib.Backoff{Initial: time.Millisecond, Max: time.Millisecond},
+			func(context.Context) error {
+				calls++
+				return errNotReady
+			}, nil)
+
+		assert.Same(t, errNotReady, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("gives up after max wait", func(t *testing.T) {
+		err := lib.Retry(t.Context(),
+			lib.Backoff{Initial: 5 * time.Millisecond, Max: 5 * time.Millisecond, MaxWait: 20 * time.Millisecond},
+			func(context.Context) error { return errNotReady }, nil)
+
+		require.ErrorIs(t, err, errNotReady)
+		assert.Contains(t, err.Error(), "gave up after")
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		err := lib.Retry(ctx,
+			lib.Backoff{Initial: time.Hour, Max: time.Hour, MaxWait: time.Hour},
+			func(context.Context) error { return errNotReady },
+			func(int, time.Duration, error) { cancel() },
+		)
+
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, err, errNotReady)
+	})
+}
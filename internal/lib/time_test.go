@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/anuragthepathak/subscription-management/internal/lib"
@@ -170,6 +171,19 @@ func TestCalcRenewalDate(t *testing.T) {
 	}
 }
 
+func TestCalcRenewalDate_BillingTimezone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Daylight saving time starts in between, so local midnight moves from
+	// 05:00 to 04:00 UTC; the renewal stays on the local day.
+	start := time.Date(2025, time.February, 15, 0, 0, 0, 0, newYork)
+	got := lib.CalcRenewalDate(start, models.Monthly)
+
+	assert.Equal(t, time.Date(2025, time.March, 15, 0, 0, 0, 0, newYork), got)
+	assert.Equal(t, time.Date(2025, time.March, 15, 4, 0, 0, 0, time.UTC), got.UTC())
+}
+
 func TestDaysBetween(t *testing.T) {
 	// Helper to build a time at a specific hour (not necessarily midnight),
 	// so we can verify the function normalises to midnight correctly.
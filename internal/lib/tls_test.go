@@ -0,0 +1,121 @@
+package lib_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+)
+
+// writeSelfSignedCert writes a PEM certificate and key to dir and returns
+// their paths.
+func writeSelfSignedCert(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestLoadClientTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSignedCert(t, dir)
+	emptyFile := filepath.Join(dir, "empty.pem")
+	require.NoError(t, os.WriteFile(emptyFile, nil, 0o600))
+
+	tests := []struct {
+		name      string
+		caFile    string
+		certFile  string
+		keyFile   string
+		wantErr   bool
+		wantRoots bool
+		wantCerts int
+	}{
+		{name: "system roots only"},
+		{name: "custom CA", caFile: certFile, wantRoots: true},
+		{name: "client certificate", certFile: certFile, keyFile: keyFile, wantCerts: 1},
+		{name: "error - missing CA file", caFile: filepath.Join(dir, "missing.pem"), wantErr: true},
+		{name: "error - CA file without certificates", caFile: emptyFile, wantErr: true},
+		{name: "error - certificate without key", certFile: certFile, wantErr: true},
+		{name: "error - key does not match format", certFile: certFile, keyFile: emptyFile, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := lib.LoadClientTLSConfig(tt.caFile, tt.certFile, tt.keyFile, false)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRoots, cfg.RootCAs != nil)
+			assert.Len(t, cfg.Certificates, tt.wantCerts)
+		})
+	}
+}
+
+func TestLoadServerTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSignedCert(t, dir)
+	emptyFile := filepath.Join(dir, "empty.pem")
+	require.NoError(t, os.WriteFile(emptyFile, nil, 0o600))
+
+	tests := []struct {
+		name           string
+		certFile       string
+		keyFile        string
+		clientCAFile   string
+		wantErr        bool
+		wantClientAuth tls.ClientAuthType
+	}{
+		{name: "server certificate only", certFile: certFile, keyFile: keyFile, wantClientAuth: tls.NoClientCert},
+		{name: "mutual TLS", certFile: certFile, keyFile: keyFile, clientCAFile: certFile, wantClientAuth: tls.RequireAndVerifyClientCert},
+		{name: "error - missing certificate", keyFile: keyFile, wantErr: true},
+		{name: "error - missing client CA file", certFile: certFile, keyFile: keyFile, clientCAFile: filepath.Join(dir, "missing.pem"), wantErr: true},
+		{name: "error - client CA file without certificates", certFile: certFile, keyFile: keyFile, clientCAFile: emptyFile, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := lib.LoadServerTLSConfig(tt.certFile, tt.keyFile, tt.clientCAFile)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, cfg.Certificates, 1)
+			assert.Equal(t, tt.wantClientAuth, cfg.ClientAuth)
+			assert.Equal(t, tt.clientCAFile != "", cfg.ClientCAs != nil)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// normalizeUserEmails lowercases and trims stored user emails so the unique
+// email index also rejects addresses that differ only in case. If existing
+// accounts would collide after normalization the migration aborts without
+// changing anything; those accounts must be merged or renamed by hand.
+var normalizeUserEmails = Migration{
+	Version:     1,
+	Description: "normalize user emails to lowercase",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		users := db.Collection("users")
+		normalized := bson.M{"$toLower": bson.M{"$trim": bson.M{"input": "$email"}}}
+
+		cursor, err := users.Aggregate(ctx, mongo.Pipeline{
+			{{Key: "$group", Value: bson.M{
+				"_id":    normalized,
+				"emails": bson.M{"$push": "$email"},
+				"count":  bson.M{"$sum": 1},
+			}}},
+			{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check for conflicting emails: %w", err)
+		}
+
+		var conflicts []struct {
+			Emails []string `bson:"emails"`
+		}
+		if err := cursor.All(ctx, &conflicts); err != nil {
+			return fmt.Errorf("failed to decode conflicting emails: %w", err)
+		}
+		if len(conflicts) > 0 {
+			groups := make([][]string, len(conflicts))
+			for i, c := range conflicts {
+				groups[i] = c.Emails
+			}
+			return fmt.Errorf(
+				"%d email(s) collide when case-normalized, resolve manually: %v",
+				len(conflicts),
+				groups,
+			)
+		}
+
+		_, err = users.UpdateMany(ctx,
+			bson.M{"$expr": bson.M{"$ne": bson.A{"$email", normalized}}},
+			mongo.Pipeline{{{Key: "$set", Value: bson.M{"email": normalized}}}},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to normalize user emails: %w", err)
+		}
+		return nil
+	},
+}
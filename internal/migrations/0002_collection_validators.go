@@ -0,0 +1,9 @@
+package migrations
+
+// collectionValidators installs $jsonSchema validators on the users,
+// subscriptions, and bills collections.
+var collectionValidators = Migration{
+	Version:     2,
+	Description: "add $jsonSchema validators to collections",
+	Up:          applyValidators,
+}
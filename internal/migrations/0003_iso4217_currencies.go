@@ -0,0 +1,10 @@
+package migrations
+
+// iso4217Currencies reinstalls the collection validators so subscriptions and
+// bills accept every active ISO 4217 currency instead of only USD, EUR, and
+// GBP.
+var iso4217Currencies = Migration{
+	Version:     3,
+	Description: "accept every ISO 4217 currency in validators",
+	Up:          applyValidators,
+}
@@ -0,0 +1,9 @@
+package migrations
+
+// dunningStatuses reinstalls the collection validators so subscriptions accept
+// the past_due status and bills the failed status.
+var dunningStatuses = Migration{
+	Version:     4,
+	Description: "accept past_due subscriptions and failed bills in validators",
+	Up:          applyValidators,
+}
@@ -0,0 +1,9 @@
+package migrations
+
+// pausedStatus reinstalls the collection validators so subscriptions accept
+// the paused status.
+var pausedStatus = Migration{
+	Version:     5,
+	Description: "accept paused subscriptions in validators",
+	Up:          applyValidators,
+}
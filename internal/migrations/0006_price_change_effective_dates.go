@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// priceChangeEffectiveDates backfills the effective date of price changes
+// recorded before changes could be scheduled; they all took effect when they
+// were made.
+var priceChangeEffectiveDates = Migration{
+	Version:     6,
+	Description: "backfill effective dates of price changes",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		_, err := db.Collection("price_history").UpdateMany(ctx,
+			bson.M{"effective_at": bson.M{"$exists": false}},
+			mongo.Pipeline{{{Key: "$set", Value: bson.M{"effective_at": "$changed_at"}}}},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to backfill price change effective dates: %w", err)
+		}
+		return nil
+	},
+}
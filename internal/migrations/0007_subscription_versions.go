@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// subscriptionVersions starts the version of subscriptions written before
+// updates were versioned at 0, so Update's version filter matches them.
+var subscriptionVersions = Migration{
+	Version:     7,
+	Description: "backfill subscription versions",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		_, err := db.Collection("subscriptions").UpdateMany(ctx,
+			bson.M{"version": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"version": 0}},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to backfill subscription versions: %w", err)
+		}
+		return nil
+	},
+}
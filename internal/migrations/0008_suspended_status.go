@@ -0,0 +1,9 @@
+package migrations
+
+// suspendedStatus reinstalls the collection validators so subscriptions
+// accept the suspended status.
+var suspendedStatus = Migration{
+	Version:     8,
+	Description: "accept suspended subscriptions in validators",
+	Up:          applyValidators,
+}
@@ -0,0 +1,9 @@
+package migrations
+
+// pendingBills reinstalls the collection validators so bills accept the
+// pending status of a charge that is still processing.
+var pendingBills = Migration{
+	Version:     9,
+	Description: "accept pending bills in validators",
+	Up:          applyValidators,
+}
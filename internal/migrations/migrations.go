@@ -0,0 +1,207 @@
+// Package migrations applies versioned, forward-only changes to the MongoDB
+// schema and data. Applied versions are recorded in the schema_migrations
+// collection so each migration runs exactly once per database.
+package migrations
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	collectionName     = "schema_migrations"
+	lockCollectionName = "schema_migrations_lock"
+	lockID             = "lock"
+
+	// lockTTL is how long a lock may be held before it is considered
+	// abandoned (e.g. the holder crashed mid-migration) and can be taken over.
+	lockTTL = 10 * time.Minute
+	// lockRetryInterval is how often a waiting instance retries the lock.
+	lockRetryInterval = time.Second
+)
+
+// Migration is a single versioned change. Up must be idempotent: if the
+// process dies after Up succeeds but before the version is recorded, it will
+// run again on the next start.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// All returns every registered migration in version order.
+func All() []Migration {
+	return []Migration{
+		normalizeUserEmails,
+		collectionValidators,
+		iso4217Currencies,
+		dunningStatuses,
+		pausedStatus,
+		priceChangeEffectiveDates,
+		subscriptionVersions,
+		suspendedStatus,
+		pendingBills,
+	}
+}
+
+// record is the document stored in schema_migrations for each applied version.
+type record struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// Runner applies pending migrations against a database.
+type Runner struct {
+	db         *mongo.Database
+	migrations []Migration
+	now        clock.NowFn
+}
+
+// NewRunner creates a Runner for the given migrations. It returns an error if
+// two migrations share a version number.
+func NewRunner(db *mongo.Database, migrations []Migration, now clock.NowFn) (*Runner, error) {
+	sorted := slices.Clone(migrations)
+	slices.SortFunc(sorted, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Version == sorted[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %d", sorted[i].Version)
+		}
+	}
+
+	return &Runner{
+		db:         db,
+		migrations: sorted,
+		now:        now,
+	}, nil
+}
+
+// Up applies every migration that has not been recorded yet, in version
+// order, and returns how many were applied. Concurrent runners (e.g. several
+// replicas starting at once) are serialized through a lock document.
+func (r *Runner) Up(ctx context.Context) (int, error) {
+	owner := bson.NewObjectID()
+	if err := r.acquireLock(ctx, owner); err != nil {
+		return 0, err
+	}
+	defer r.releaseLock(owner)
+
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, m := range r.migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		start := time.Now()
+		if err := m.Up(ctx, r.db); err != nil {
+			return count, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Description, err)
+		}
+
+		rec := record{
+			Version:     m.Version,
+			Description: m.Description,
+			AppliedAt:   r.now(),
+		}
+		if _, err := r.db.Collection(collectionName).InsertOne(ctx, rec); err != nil {
+			return count, fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+		}
+
+		slog.Info("Applied database migration",
+			logattr.Version(m.Version),
+			logattr.Message(m.Description),
+			logattr.Latency(time.Since(start)),
+		)
+		count++
+	}
+
+	return count, nil
+}
+
+// appliedVersions returns the set of versions already recorded.
+func (r *Runner) appliedVersions(ctx context.Context) (map[int]struct{}, error) {
+	cursor, err := r.db.Collection(collectionName).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode applied migrations: %w", err)
+	}
+
+	applied := make(map[int]struct{}, len(records))
+	for _, rec := range records {
+		applied[rec.Version] = struct{}{}
+	}
+	return applied, nil
+}
+
+// acquireLock blocks until this runner holds the migration lock or ctx ends.
+func (r *Runner) acquireLock(ctx context.Context, owner bson.ObjectID) error {
+	collection := r.db.Collection(lockCollectionName)
+
+	for {
+		_, err := collection.InsertOne(ctx, bson.M{
+			"_id":       lockID,
+			"owner":     owner,
+			"locked_at": r.now(),
+		})
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		// Take over a lock abandoned by a crashed instance.
+		res, err := collection.DeleteOne(ctx, bson.M{
+			"_id":       lockID,
+			"locked_at": bson.M{"$lt": r.now().Add(-lockTTL)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to clear stale migration lock: %w", err)
+		}
+		if res.DeletedCount > 0 {
+			slog.Warn("Removed stale database migration lock")
+			continue
+		}
+
+		slog.Info("Waiting for database migration lock held by another instance")
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for migration lock: %w", ctx.Err())
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// releaseLock removes the lock if this runner still owns it. It uses its own
+// context so the lock is released even if the migration context was canceled.
+func (r *Runner) releaseLock(owner bson.ObjectID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.Collection(lockCollectionName).DeleteOne(ctx, bson.M{
+		"_id":   lockID,
+		"owner": owner,
+	})
+	if err != nil {
+		slog.Error("Failed to release database migration lock", logattr.Error(err))
+	}
+}
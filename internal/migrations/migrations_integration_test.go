@@ -0,0 +1,292 @@
+//go:build integration
+
+package migrations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go/modules/mongodb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var mockTime = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+var mongoClient *mongo.Client
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	container, err := mongodb.Run(ctx, "mongo:8")
+	if err != nil {
+		panic("failed to start MongoDB container: " + err.Error())
+	}
+	defer func() { _ = container.Terminate(ctx) }()
+
+	uri, err := container.ConnectionString(ctx)
+	if err != nil {
+		panic("failed to get MongoDB connection string: " + err.Error())
+	}
+
+	mongoClient, err = mongo.Connect(options.Client().ApplyURI(uri))
+	if err != nil {
+		panic("failed to connect to MongoDB: " + err.Error())
+	}
+	defer func() { _ = mongoClient.Disconnect(ctx) }()
+
+	m.Run()
+}
+
+func newTestDB(t *testing.T) *mongo.Database {
+	t.Helper()
+
+	db := mongoClient.Database("migrations_test_" + bson.NewObjectID().Hex())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+	})
+	return db
+}
+
+func newRunner(t *testing.T, db *mongo.Database, ms []migrations.Migration) *migrations.Runner {
+	t.Helper()
+
+	runner, err := migrations.NewRunner(db, ms, func() time.Time { return mockTime })
+	require.NoError(t, err)
+	return runner
+}
+
+// ---------------------------------------------------------------------------
+// Runner
+// ---------------------------------------------------------------------------
+
+func TestRunner_Up(t *testing.T) {
+	t.Run("applies pending migrations in order exactly once", func(t *testing.T) {
+		db := newTestDB(t)
+		var order []int
+		step := func(v int) migrations.Migration {
+			return migrations.Migration{
+				Version:     v,
+				Description: "step",
+				Up: func(context.Context, *mongo.Database) error {
+					order = append(order, v)
+					return nil
+				},
+			}
+		}
+		runner := newRunner(t, db, []migrations.Migration{step(2), step(1)})
+
+		applied, err := runner.Up(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 2, applied)
+		assert.Equal(t, []int{1, 2}, order)
+
+		applied, err = runner.Up(t.Context())
+		require.NoError(t, err)
+		assert.Zero(t, applied)
+		assert.Equal(t, []int{1, 2}, order, "already applied migrations must not rerun")
+	})
+
+	t.Run("stops at the first failing migration and does not record it", func(t *testing.T) {
+		db := newTestDB(t)
+		runner := newRunner(t, db, []migrations.Migration{{
+			Version: 1,
+			Up: func(context.Context, *mongo.Database) error {
+				return errors.New("boom")
+			},
+		}})
+
+		_, err := runner.Up(t.Context())
+		require.Error(t, err)
+
+		count, err := db.Collection("schema_migrations").CountDocuments(t.Context(), bson.M{})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("waits for a lock held by another instance", func(t *testing.T) {
+		db := newTestDB(t)
+		_, err := db.Collection("schema_migrations_lock").InsertOne(t.Context(), bson.M{
+			"_id":       "lock",
+			"owner":     bson.NewObjectID(),
+			"locked_at": mockTime,
+		})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err = newRunner(t, db, migrations.All()).Up(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestNewRunner_DuplicateVersion(t *testing.T) {
+	noop := func(context.Context, *mongo.Database) error { return nil }
+	_, err := migrations.NewRunner(nil, []migrations.Migration{
+		{Version: 1, Up: noop},
+		{Version: 1, Up: noop},
+	}, time.Now)
+	assert.Error(t, err)
+}
+
+// ---------------------------------------------------------------------------
+// 0001 normalize user emails
+// ---------------------------------------------------------------------------
+
+func TestNormalizeUserEmails(t *testing.T) {
+	t.Run("lowercases and trims existing emails", func(t *testing.T) {
+		db := newTestDB(t)
+		users := db.Collection("users")
+		id := bson.NewObjectID()
+		_, err := users.InsertOne(t.Context(), bson.M{"_id": id, "email": " Mixed@Example.COM "})
+		require.NoError(t, err)
+
+		_, err = newRunner(t, db, migrations.All()).Up(t.Context())
+		require.NoError(t, err)
+
+		var got struct {
+			Email string `bson:"email"`
+		}
+		require.NoError(t, users.FindOne(t.Context(), bson.M{"_id": id}).Decode(&got))
+		assert.Equal(t, "mixed@example.com", got.Email)
+	})
+
+	t.Run("aborts without changes when emails collide", func(t *testing.T) {
+		db := newTestDB(t)
+		users := db.Collection("users")
+		_, err := users.InsertMany(t.Context(), []bson.M{
+			{"email": "dup@example.com"},
+			{"email": "DUP@example.com"},
+		})
+		require.NoError(t, err)
+
+		_, err = newRunner(t, db, migrations.All()).Up(t.Context())
+		require.Error(t, err)
+
+		count, err := users.CountDocuments(t.Context(), bson.M{"email": "DUP@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count, "colliding emails must be left untouched")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// 0002 collection validators
+// ---------------------------------------------------------------------------
+
+func TestCollectionValidators(t *testing.T) {
+	t.Run("rejects documents missing required fields or with invalid enums", func(t *testing.T) {
+		db := newTestDB(t)
+		_, err := newRunner(t, db, migrations.All()).Up(t.Context())
+		require.NoError(t, err)
+
+		_, err = db.Collection("users").InsertOne(t.Context(), bson.M{"name": "No Email"})
+		assert.Error(t, err, "user without email must be rejected")
+
+		_, err = db.Collection("subscriptions").InsertOne(t.Context(), bson.M{
+			"name":       "Netflix",
+			"price":      int64(999),
+			"currency":   "USD",
+			"frequency":  "weekly",
+			"category":   "entertainment",
+			"status":     "active",
+			"valid_till": mockTime,
+			"user_id":    bson.NewObjectID(),
+			"created_at": mockTime,
+			"updated_at": mockTime,
+		})
+		assert.Error(t, err, "unknown frequency must be rejected")
+	})
+
+	t.Run("accepts valid documents and updates existing collections", func(t *testing.T) {
+		db := newTestDB(t)
+		// Pre-existing collection exercises the collMod path.
+		require.NoError(t, db.CreateCollection(t.Context(), "bills"))
+
+		_, err := newRunner(t, db, migrations.All()).Up(t.Context())
+		require.NoError(t, err)
+
+		_, err = db.Collection("bills").InsertOne(t.Context(), bson.M{
+			"amount":          int64(999),
+			"currency":        "EUR",
+			"subscription_id": bson.NewObjectID(),
+			"start_date":      mockTime,
+			"end_date":        mockTime.AddDate(0, 1, 0),
+			"status":          "paid",
+			"created_at":      mockTime,
+			"updated_at":      mockTime,
+		})
+		assert.NoError(t, err)
+
+		_, err = db.Collection("bills").InsertOne(t.Context(), bson.M{"amount": int64(1)})
+		assert.Error(t, err, "bill without required fields must be rejected")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// 0006 price change effective dates
+// ---------------------------------------------------------------------------
+
+func TestPriceChangeEffectiveDates(t *testing.T) {
+	t.Run("backfills missing effective dates with the change date", func(t *testing.T) {
+		db := newTestDB(t)
+		history := db.Collection("price_history")
+		scheduled := mockTime.AddDate(0, 1, 0)
+		oldID, scheduledID := bson.NewObjectID(), bson.NewObjectID()
+		_, err := history.InsertMany(t.Context(), []bson.M{
+			{"_id": oldID, "changed_at": mockTime},
+			{"_id": scheduledID, "changed_at": mockTime, "effective_at": scheduled},
+		})
+		require.NoError(t, err)
+
+		_, err = newRunner(t, db, migrations.All()).Up(t.Context())
+		require.NoError(t, err)
+
+		var got struct {
+			EffectiveAt time.Time `bson:"effective_at"`
+		}
+		require.NoError(t, history.FindOne(t.Context(), bson.M{"_id": oldID}).Decode(&got))
+		assert.True(t, got.EffectiveAt.Equal(mockTime), "old changes take effect when made")
+		require.NoError(t, history.FindOne(t.Context(), bson.M{"_id": scheduledID}).Decode(&got))
+		assert.True(t, got.EffectiveAt.Equal(scheduled), "set effective dates are kept")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// 0007 subscription versions
+// ---------------------------------------------------------------------------
+
+func TestSubscriptionVersions(t *testing.T) {
+	t.Run("starts unversioned subscriptions at 0", func(t *testing.T) {
+		db := newTestDB(t)
+		subscriptions := db.Collection("subscriptions")
+		oldID, versionedID := bson.NewObjectID(), bson.NewObjectID()
+		_, err := subscriptions.InsertMany(t.Context(), []bson.M{
+			{"_id": oldID, "name": "Old"},
+			{"_id": versionedID, "name": "Versioned", "version": int64(3)},
+		})
+		require.NoError(t, err)
+
+		_, err = newRunner(t, db, migrations.All()).Up(t.Context())
+		require.NoError(t, err)
+
+		var got struct {
+			Version *int64 `bson:"version"`
+		}
+		require.NoError(t, subscriptions.FindOne(t.Context(), bson.M{"_id": oldID}).Decode(&got))
+		require.NotNil(t, got.Version)
+		assert.Zero(t, *got.Version)
+		got.Version = nil
+		require.NoError(t, subscriptions.FindOne(t.Context(), bson.M{"_id": versionedID}).Decode(&got))
+		require.NotNil(t, got.Version)
+		assert.Equal(t, int64(3), *got.Version, "set versions are kept")
+	})
+}
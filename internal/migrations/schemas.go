@@ -0,0 +1,143 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Collection validators mirror the model Validate methods so documents
+// written outside the service (shell, scripts, bugs) can't skip required
+// fields or use unknown enum values. Schemas only constrain known fields;
+// extra fields are allowed so new model fields don't need a validator change
+// unless they are required.
+//
+// When a schema changes, register a new migration that calls applyValidators
+// again; collMod replaces the previous validator.
+
+func usersSchema() bson.M {
+	return bson.M{
+		"bsonType": "object",
+		"required": bson.A{"name", "email", "password", "created_at", "updated_at"},
+		"properties": bson.M{
+			"name":       bson.M{"bsonType": "string", "minLength": 1},
+			"email":      bson.M{"bsonType": "string", "minLength": 3},
+			"password":   bson.M{"bsonType": "string", "minLength": 1},
+			"created_at": bson.M{"bsonType": "date"},
+			"updated_at": bson.M{"bsonType": "date"},
+		},
+	}
+}
+
+func subscriptionsSchema() bson.M {
+	return bson.M{
+		"bsonType": "object",
+		"required": bson.A{
+			"name", "price", "currency", "frequency", "category", "status",
+			"valid_till", "user_id", "created_at", "updated_at",
+		},
+		"properties": bson.M{
+			"name":       bson.M{"bsonType": "string", "minLength": 2, "maxLength": 100},
+			"price":      bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 1},
+			"currency":   bson.M{"enum": enumOf(models.CurrencyCodes()...)},
+			"frequency":  bson.M{"enum": enumOf(models.Monthly, models.Yearly)},
+			"category":   bson.M{"enum": enumOf(categories()...)},
+			"status":     bson.M{"enum": enumOf(models.Active, models.Canceled, models.Expired, models.PastDue, models.Paused, models.Suspended)},
+			"valid_till": bson.M{"bsonType": "date"},
+			"user_id":    bson.M{"bsonType": "objectId"},
+			"created_at": bson.M{"bsonType": "date"},
+			"updated_at": bson.M{"bsonType": "date"},
+		},
+	}
+}
+
+func billsSchema() bson.M {
+	return bson.M{
+		"bsonType": "object",
+		"required": bson.A{
+			"amount", "currency", "subscription_id", "start_date", "end_date",
+			"status", "created_at", "updated_at",
+		},
+		"properties": bson.M{
+			"amount":          bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 1},
+			"currency":        bson.M{"enum": enumOf(models.CurrencyCodes()...)},
+			"subscription_id": bson.M{"bsonType": "objectId"},
+			"start_date":      bson.M{"bsonType": "date"},
+			"end_date":        bson.M{"bsonType": "date"},
+			"status":          bson.M{"enum": enumOf(models.Paid, models.Pending, models.Refunded, models.Failed)},
+			"created_at":      bson.M{"bsonType": "date"},
+			"updated_at":      bson.M{"bsonType": "date"},
+		},
+	}
+}
+
+func categories() []models.Category {
+	return []models.Category{
+		models.Sports, models.News, models.Entertainment, models.Lifestyle,
+		models.Technology, models.Finance, models.Politics, models.Other,
+	}
+}
+
+// enumOf converts typed string constants into a BSON array for an enum rule.
+func enumOf[T ~string](values ...T) bson.A {
+	out := make(bson.A, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// applyValidators installs the current schemas on every collection.
+func applyValidators(ctx context.Context, db *mongo.Database) error {
+	schemas := map[string]bson.M{
+		"users":         usersSchema(),
+		"subscriptions": subscriptionsSchema(),
+		"bills":         billsSchema(),
+	}
+	for name, schema := range schemas {
+		if err := setValidator(ctx, db, name, schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setValidator creates the collection with the validator, or updates the
+// validator in place if the collection already exists. The "moderate" level
+// validates inserts and updates to valid documents, so legacy documents that
+// predate the schema remain writable.
+func setValidator(ctx context.Context, db *mongo.Database, name string, schema bson.M) error {
+	validator := bson.M{"$jsonSchema": schema}
+
+	existing, err := db.ListCollectionNames(ctx, bson.M{"name": name})
+	if err != nil {
+		return fmt.Errorf("failed to list collections: %w", err)
+	}
+
+	if !slices.Contains(existing, name) {
+		opts := options.CreateCollection().
+			SetValidator(validator).
+			SetValidationLevel("moderate").
+			SetValidationAction("error")
+		if err := db.CreateCollection(ctx, name, opts); err != nil {
+			return fmt.Errorf("failed to create %s collection with validator: %w", name, err)
+		}
+		return nil
+	}
+
+	cmd := bson.D{
+		{Key: "collMod", Value: name},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: "error"},
+	}
+	if err := db.RunCommand(ctx, cmd).Err(); err != nil {
+		return fmt.Errorf("failed to set validator on %s: %w", name, err)
+	}
+	return nil
+}
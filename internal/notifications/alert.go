@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// AlertConfig holds where operators are alerted when a background task
+// fails for the last time. Alerts go to every destination set; with none,
+// failed tasks are only recorded.
+type AlertConfig struct {
+	Emails          []string `mapstructure:"emails"`
+	SlackWebhookURL string   `mapstructure:"slack_webhook_url"`
+}
+
+// Alerter notifies operators of failures that need a person to look at them.
+type Alerter interface {
+	// AlertDeadTask reports a task that exhausted its retries. The errors of
+	// failed destinations are joined.
+	AlertDeadTask(ctx context.Context, task *models.DeadTask) error
+}
+
+type alerter struct {
+	config      AlertConfig
+	emailSender EmailSender
+	slack       *slackNotifier
+}
+
+// NewAlerter returns an Alerter for the destinations in config.Alerts. Slack
+// requests time out after config.Timeout.
+func NewAlerter(config ChannelsConfig, emailSender EmailSender) Alerter {
+	client := &http.Client{
+		Timeout:   config.Timeout,
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	}
+	return &alerter{
+		config.Alerts,
+		emailSender,
+		&slackNotifier{client},
+	}
+}
+
+func (a *alerter) AlertDeadTask(ctx context.Context, task *models.DeadTask) error {
+	var errs []error
+	if len(a.config.Emails) > 0 {
+		if err := a.emailSender.SendDeadTaskAlertEmail(ctx, a.config.Emails, task); err != nil {
+			errs = append(errs, fmt.Errorf("email: %w", err))
+		}
+	}
+	if a.config.SlackWebhookURL != "" {
+		if err := a.slack.send(ctx, a.config.SlackWebhookURL, deadTaskMessage(task)); err != nil {
+			errs = append(errs, fmt.Errorf("slack: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deadTaskMessage describes a dead task for operators, in English.
+func deadTaskMessage(task *models.DeadTask) message {
+	return message{
+		title: fmt.Sprintf("Background task %s failed permanently", task.Type),
+		body: fmt.Sprintf(
+			"Task %s on queue %s failed after %d of %d retries at %s.\nError: %s\nDead task ID: %s",
+			task.TaskID,
+			task.Queue,
+			task.Retried,
+			task.MaxRetry,
+			task.FailedAt.UTC().Format(time.RFC3339),
+			task.Error,
+			task.ID.Hex(),
+		),
+	}
+}
@@ -0,0 +1,71 @@
+package notifications_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func testDeadTask() *models.DeadTask {
+	return &models.DeadTask{
+		ID:       bson.NewObjectID(),
+		TaskID:   "task-1",
+		Type:     "subscription:renewal",
+		Queue:    "default",
+		Error:    "payment provider unavailable",
+		Retried:  3,
+		MaxRetry: 3,
+		FailedAt: time.Date(2026, 3, 14, 8, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestAlerter_AlertDeadTask(t *testing.T) {
+	t.Run("posts to the Slack webhook of the operators", func(t *testing.T) {
+		var gotBody map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		alerter := notifications.NewAlerter(notifications.ChannelsConfig{
+			Timeout: time.Second,
+			Alerts:  notifications.AlertConfig{SlackWebhookURL: server.URL},
+		}, nil)
+		err := alerter.AlertDeadTask(t.Context(), testDeadTask())
+
+		require.NoError(t, err)
+		assert.Contains(t, gotBody["text"], "*Background task subscription:renewal failed permanently*")
+		assert.Contains(t, gotBody["text"], "after 3 of 3 retries at 2026-03-14T08:30:00Z")
+		assert.Contains(t, gotBody["text"], "Error: payment provider unavailable")
+	})
+
+	t.Run("nothing to do without destinations", func(t *testing.T) {
+		alerter := notifications.NewAlerter(notifications.ChannelsConfig{}, nil)
+
+		require.NoError(t, alerter.AlertDeadTask(t.Context(), testDeadTask()))
+	})
+
+	t.Run("a failed destination is an error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("no_service"))
+		}))
+		defer server.Close()
+
+		alerter := notifications.NewAlerter(notifications.ChannelsConfig{
+			Timeout: time.Second,
+			Alerts:  notifications.AlertConfig{SlackWebhookURL: server.URL},
+		}, nil)
+
+		require.ErrorContains(t, alerter.AlertDeadTask(t.Context(), testDeadTask()), "no_service")
+	})
+}
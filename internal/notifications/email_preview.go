@@ -0,0 +1,199 @@
+package notifications
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"gopkg.in/gomail.v2"
+)
+
+// emailTemplates describes the emails sent to users, in the order they are
+// listed.
+var emailTemplates = []models.EmailTemplate{
+	{Name: TemplateReminder, Description: "Upcoming renewal of one subscription"},
+	{Name: TemplateReminderDigest, Description: "Upcoming renewals of several subscriptions in one email"},
+	{Name: TemplateTrialEnding, Description: "Free trial ending and the first charge coming"},
+	{Name: TemplateRenewalConfirmation, Description: "Automatic renewal confirmed"},
+	{Name: TemplateDunning, Description: "Renewal payment failed; attempt and final choose the step"},
+	{Name: TemplateUnusedSubscription, Description: "Suggestion to cancel an unused subscription"},
+	{Name: TemplatePriceChange, Description: "Price of a subscription changed"},
+	{Name: TemplateBudgetAlert, Description: "Monthly spend exceeds a budget"},
+	{Name: TemplateExportReady, Description: "Download link of an export"},
+	{Name: TemplateAccountClosed, Description: "Account closed and deletion scheduled"},
+}
+
+// previewRecipient is the address previews are rendered for. It only shows
+// in the unsubscribe link.
+const previewRecipient = "user@example.com"
+
+// EmailTemplates lists the templates of the emails sent to users.
+func (es *emailSender) EmailTemplates() []models.EmailTemplate {
+	return slices.Clone(emailTemplates)
+}
+
+func (es *emailSender) PreviewEmail(
+	ctx context.Context,
+	template string,
+	data *models.EmailPreviewData,
+) (*models.EmailPreview, error) {
+	return es.render(ctx, previewRecipient, template, data)
+}
+
+// SendTestEmail delivers the preview like any other email, so the
+// suppression list applies to it too.
+func (es *emailSender) SendTestEmail(
+	ctx context.Context,
+	to string,
+	template string,
+	data *models.EmailPreviewData,
+) (*models.EmailPreview, error) {
+	preview, err := es.render(ctx, to, template, data)
+	if err != nil {
+		return nil, err
+	}
+
+	message := gomail.NewMessage()
+	message.SetHeader("From", fmt.Sprintf("%s <%s>", es.config.FromName, es.config.FromEmail))
+	message.SetHeader("To", to)
+	message.SetHeader("Subject", "[Test] "+preview.Subject)
+	message.SetBody("text/html", preview.HTML)
+	if err = es.deliver(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to send test email: %w", err)
+	}
+	return preview, nil
+}
+
+// render runs the Send method of template with sample data on a copy of the
+// sender that keeps the email instead of delivering it, so previews go
+// through the same code as the emails users get.
+func (es *emailSender) render(
+	ctx context.Context,
+	to string,
+	template string,
+	data *models.EmailPreviewData,
+) (*models.EmailPreview, error) {
+	preview := *es
+	preview.rendered = &models.EmailPreview{Template: template}
+	if err := preview.sendSample(ctx, to, template, newEmailSample(data, time.Now())); err != nil {
+		return nil, err
+	}
+	return preview.rendered, nil
+}
+
+// emailSample holds the domain objects an email preview is rendered from.
+type emailSample struct {
+	userName      string
+	subscription  *models.Subscription
+	paymentMethod *models.PaymentMethod
+	daysBefore    int
+	attempt       int
+	final         bool
+	finalAction   models.DunningFinalAction
+	now           time.Time
+}
+
+// newEmailSample fills in sample values for the fields data leaves empty.
+func newEmailSample(data *models.EmailPreviewData, now time.Time) *emailSample {
+	daysBefore := 3
+	if data.DaysBefore != nil {
+		daysBefore = *data.DaysBefore
+	}
+	renewal := now.AddDate(0, 0, daysBefore)
+	lastUsed := now.AddDate(0, -3, 0)
+
+	finalAction := models.DunningExpire
+	if data.Suspend {
+		finalAction = models.DunningSuspend
+	}
+
+	return &emailSample{
+		userName: cmp.Or(data.UserName, "Alex Doe"),
+		subscription: &models.Subscription{
+			ID:          bson.NewObjectID(),
+			Name:        cmp.Or(data.SubscriptionName, "Netflix"),
+			Price:       cmp.Or(data.Price, 1549),
+			Currency:    cmp.Or(data.Currency, models.USD),
+			Frequency:   cmp.Or(data.Frequency, models.Monthly),
+			Category:    models.Entertainment,
+			Status:      models.Active,
+			ValidTill:   renewal,
+			LastUsedAt:  &lastUsed,
+			TrialDays:   14,
+			TrialEndsAt: &renewal,
+		},
+		paymentMethod: &models.PaymentMethod{
+			Type:     models.Card,
+			Brand:    "Visa",
+			Last4:    "4242",
+			ExpMonth: 12,
+			ExpYear:  now.Year() + 2,
+		},
+		daysBefore:  daysBefore,
+		attempt:     data.Attempt,
+		final:       data.Final,
+		finalAction: finalAction,
+		now:         now,
+	}
+}
+
+// sendSample sends the email of template with the sample data to to.
+func (es *emailSender) sendSample(ctx context.Context, to, template string, sample *emailSample) error {
+	subscription := sample.subscription
+	switch template {
+	case TemplateReminder:
+		return es.SendReminderEmail(ctx, to, sample.userName, subscription, sample.paymentMethod, sample.daysBefore)
+	case TemplateReminderDigest:
+		return es.SendReminderDigestEmail(ctx, to, sample.userName, []Reminder{
+			{Subscription: subscription, PaymentMethod: sample.paymentMethod, DaysBefore: sample.daysBefore},
+			{Subscription: &models.Subscription{
+				Name:      "Spotify",
+				Price:     1099,
+				Currency:  subscription.Currency,
+				Frequency: models.Monthly,
+				ValidTill: subscription.ValidTill.AddDate(0, 0, 2),
+			}, DaysBefore: sample.daysBefore + 2},
+		})
+	case TemplateTrialEnding:
+		return es.SendTrialEndingEmail(ctx, to, sample.userName, subscription, sample.daysBefore)
+	case TemplateRenewalConfirmation:
+		return es.SendRenewalConfirmationEmail(ctx, to, sample.userName, subscription, sample.paymentMethod)
+	case TemplateDunning:
+		return es.SendDunningEmail(ctx, to, sample.userName, subscription, sample.attempt, sample.final, sample.finalAction)
+	case TemplateUnusedSubscription:
+		return es.SendUnusedSubscriptionEmail(ctx, to, sample.userName, subscription, "https://example.com/cancel")
+	case TemplatePriceChange:
+		change := &models.PriceChange{
+			ID:             bson.NewObjectID(),
+			SubscriptionID: subscription.ID,
+			Name:           subscription.Name,
+			OldPrice:       subscription.Price * 9 / 10,
+			NewPrice:       subscription.Price,
+			Currency:       subscription.Currency,
+			Frequency:      subscription.Frequency,
+			ChangedAt:      sample.now,
+			EffectiveAt:    subscription.ValidTill,
+		}
+		return es.SendPriceChangeEmail(ctx, to, sample.userName, change.ToResponse())
+	case TemplateBudgetAlert:
+		return es.SendBudgetAlertEmail(ctx, to, sample.userName, &models.BudgetAlert{
+			Budget:   subscription.Price * 2,
+			Actual:   subscription.Price * 3,
+			Currency: subscription.Currency,
+		})
+	case TemplateExportReady:
+		return es.SendExportReadyEmail(ctx, to, sample.userName, &models.SignedFile{
+			File:      &models.StoredFile{Filename: "subscriptions.csv"},
+			URL:       "https://example.com/download",
+			ExpiresAt: sample.now.Add(24 * time.Hour),
+		})
+	case TemplateAccountClosed:
+		return es.SendAccountClosedEmail(ctx, to, sample.userName, sample.now.AddDate(0, 0, 30))
+	default:
+		return fmt.Errorf("unknown email template %q", template)
+	}
+}
@@ -0,0 +1,72 @@
+package notifications_test
+
+import (
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailSender_PreviewEmail(t *testing.T) {
+	server := newFakeSMTPServer(t, nil)
+	sender := newTestEmailSender(t, server.config())
+
+	t.Run("renders every template with sample data", func(t *testing.T) {
+		templates := sender.EmailTemplates()
+		require.NotEmpty(t, templates)
+
+		for _, template := range templates {
+			t.Run(template.Name, func(t *testing.T) {
+				preview, err := sender.PreviewEmail(t.Context(), template.Name, &models.EmailPreviewData{})
+
+				require.NoError(t, err)
+				assert.Equal(t, template.Name, preview.Template)
+				assert.NotEmpty(t, preview.Subject)
+				assert.Contains(t, preview.HTML, "Alex Doe")
+			})
+		}
+		assert.EqualValues(t, 0, server.mails.Load(), "previews must not be sent")
+	})
+
+	t.Run("uses the supplied data and language", func(t *testing.T) {
+		daysBefore := 0
+		ctx := appctx.WithLocale(t.Context(), "de")
+
+		preview, err := sender.PreviewEmail(ctx, notifications.TemplateReminder, &models.EmailPreviewData{
+			UserName:         "Kim",
+			SubscriptionName: "Disney+",
+			Price:            899,
+			Currency:         "EUR",
+			DaysBefore:       &daysBefore,
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, preview.Subject, "Disney+")
+		assert.Contains(t, preview.HTML, "Kim")
+		assert.Contains(t, preview.HTML, "EUR 8.99")
+		assert.Contains(t, preview.HTML, "Hallo")
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		_, err := sender.PreviewEmail(t.Context(), "welcome", &models.EmailPreviewData{})
+
+		require.Error(t, err)
+	})
+}
+
+func TestEmailSender_SendTestEmail(t *testing.T) {
+	server := newFakeSMTPServer(t, nil)
+	sender := newTestEmailSender(t, server.config())
+
+	preview, err := sender.SendTestEmail(t.Context(), "admin@example.com", notifications.TemplateBudgetAlert, &models.EmailPreviewData{})
+
+	require.NoError(t, err)
+	require.NotNil(t, server.last.Load())
+	message := *server.last.Load()
+	assert.Contains(t, message, "To: admin@example.com")
+	assert.Contains(t, message, "Subject: [Test] ")
+	assert.Contains(t, preview.HTML, "Alex Doe")
+}
@@ -1,11 +1,23 @@
 package notifications
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
 
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
 	"github.com/anuragthepathak/subscription-management/internal/core/otelattr"
+	"github.com/anuragthepathak/subscription-management/internal/currency"
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/i18n"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -13,22 +25,127 @@ import (
 )
 
 type EmailSender interface {
+	// SendReminderEmail announces an upcoming renewal. paymentMethod is the
+	// one the subscription is paid with and may be nil.
 	SendReminderEmail(
 		ctx context.Context,
 		toEmail string,
 		userName string,
 		subscription *models.Subscription,
+		paymentMethod *models.PaymentMethod,
 		daysBefore int,
 	) error
+	// SendTrialEndingEmail warns that a free trial ends and the first charge
+	// is made in daysBefore days.
+	SendTrialEndingEmail(
+		ctx context.Context,
+		toEmail string,
+		userName string,
+		subscription *models.Subscription,
+		daysBefore int,
+	) error
+	// SendReminderDigestEmail announces several upcoming renewals in one
+	// email.
+	SendReminderDigestEmail(
+		ctx context.Context,
+		toEmail string,
+		userName string,
+		reminders []Reminder,
+	) error
+	// SendRenewalConfirmationEmail confirms an automatic renewal.
+	// paymentMethod is the one the subscription is paid with and may be nil.
 	SendRenewalConfirmationEmail(
 		ctx context.Context,
 		userEmail string,
 		userName string,
 		subscription *models.Subscription,
+		paymentMethod *models.PaymentMethod,
+	) error
+	// SendDunningEmail tells a user that the renewal payment of a
+	// subscription failed. Attempt 0 reports the declined renewal charge;
+	// the final attempt announces its expiration, or its suspension when
+	// finalAction is DunningSuspend.
+	SendDunningEmail(
+		ctx context.Context,
+		userEmail string,
+		userName string,
+		subscription *models.Subscription,
+		attempt int,
+		final bool,
+		finalAction models.DunningFinalAction,
+	) error
+	// SendUnusedSubscriptionEmail suggests canceling a subscription that
+	// has gone unused, with a link that cancels it in one click.
+	SendUnusedSubscriptionEmail(
+		ctx context.Context,
+		userEmail string,
+		userName string,
+		subscription *models.Subscription,
+		cancelURL string,
+	) error
+	// SendPriceChangeEmail tells a user that the price of one of their
+	// subscriptions changed and what it means over a year.
+	SendPriceChangeEmail(
+		ctx context.Context,
+		userEmail string,
+		userName string,
+		change *models.PriceChangeResponse,
+	) error
+	// SendBudgetAlertEmail notifies a user that their monthly spend exceeds
+	// a budget.
+	SendBudgetAlertEmail(
+		ctx context.Context,
+		userEmail string,
+		userName string,
+		alert *models.BudgetAlert,
+	) error
+	// SendExportReadyEmail sends the download link of an export generated
+	// in the background.
+	SendExportReadyEmail(
+		ctx context.Context,
+		userEmail string,
+		userName string,
+		export *models.SignedFile,
 	) error
+	// SendAccountClosedEmail confirms that a user closed their account and
+	// tells them when it will be deleted.
+	SendAccountClosedEmail(ctx context.Context, userEmail string, userName string, purgeAt time.Time) error
+	// SendDeadTaskAlertEmail tells operators that a background task failed
+	// for the last time. The email is plain text and not localized.
+	SendDeadTaskAlertEmail(ctx context.Context, toEmails []string, task *models.DeadTask) error
+	// EmailTemplates lists the templates of the emails sent to users.
+	EmailTemplates() []models.EmailTemplate
+	// PreviewEmail renders the email of template in the language of ctx
+	// without sending it. Sample values stand in for the fields data leaves
+	// empty.
+	PreviewEmail(ctx context.Context, template string, data *models.EmailPreviewData) (*models.EmailPreview, error)
+	// SendTestEmail sends the preview of template to an address, with the
+	// subject marked as a test.
+	SendTestEmail(ctx context.Context, to string, template string, data *models.EmailPreviewData) (*models.EmailPreview, error)
+	// UpdateCredentials replaces the SMTP credentials used for new
+	// connections.
+	UpdateCredentials(username, password string)
 	Close() error
 }
 
+// DeliveryRecorder counts the outcome of every email handed to the SMTP
+// server.
+type DeliveryRecorder interface {
+	RecordEmailDeliveryInternal(ctx context.Context, err error)
+}
+
+// SuppressionList holds the email addresses no email is sent to.
+type SuppressionList interface {
+	IsSuppressedInternal(ctx context.Context, email string) (bool, error)
+	SuppressInternal(ctx context.Context, email string, reason models.SuppressionReason, detail string) error
+}
+
+// UnsubscribeLinker signs the links that unsubscribe a recipient from a
+// channel.
+type UnsubscribeLinker interface {
+	SignUnsubscribeLinkInternal(email string, channel models.NotificationChannel) string
+}
+
 // EmailConfig holds email configuration.
 type EmailConfig struct {
 	SMTPHost     string `mapstructure:"smtp_host"`
@@ -40,29 +157,289 @@ type EmailConfig struct {
 	AccountURL   string `mapstructure:"account_url"`
 	SupportURL   string `mapstructure:"support_url"`
 	Name         string `mapstructure:"name"`
+	// DisplayCurrency, when set, adds an approximate conversion next to
+	// prices in other currencies.
+	DisplayCurrency string `mapstructure:"display_currency"`
+	// TemplateDir, when set, holds email templates replacing the built-in
+	// ones of the same file name.
+	TemplateDir string `mapstructure:"template_dir"`
+
+	// Delivery to the SMTP server.
+	SendTimeout      time.Duration `mapstructure:"send_timeout"`      // Per-attempt timeout; a sooner task deadline wins.
+	MaxRetries       int           `mapstructure:"max_retries"`       // Retries after the first failed attempt.
+	RetryBaseDelay   time.Duration `mapstructure:"retry_base_delay"`  // Delay before the first retry; doubles with each retry.
+	RetryMaxDelay    time.Duration `mapstructure:"retry_max_delay"`   // Upper bound of the retry delay.
+	MaxIdleConns     int           `mapstructure:"max_idle_conns"`    // Connections kept open for reuse; 0 dials one per email.
+	IdleTimeout      time.Duration `mapstructure:"idle_timeout"`      // How long an idle connection may be reused.
+	BreakerThreshold int           `mapstructure:"breaker_threshold"` // Consecutive failures that open the circuit breaker; 0 disables it.
+	BreakerCooldown  time.Duration `mapstructure:"breaker_cooldown"`  // How long the breaker stays open before a trial send.
 }
 
 // EmailSender handles email sending operations.
 type emailSender struct {
-	config EmailConfig
-	dialer *gomail.Dialer
-	tracer trace.Tracer
-}
-
-// NewEmailSender creates a new email service.
-func NewEmailSender(config EmailConfig) EmailSender {
-	dialer := gomail.NewDialer(
-		config.SMTPHost,
-		config.SMTPPort,
-		config.SMTPUsername,
-		config.SMTPPassword,
-	)
+	config       EmailConfig
+	pool         *smtpPool
+	breaker      *circuitBreaker
+	recorder     DeliveryRecorder  // May be nil.
+	rates        currency.Service  // May be nil.
+	suppressions SuppressionList   // May be nil.
+	unsubscribe  UnsubscribeLinker // May be nil.
+	templates    *templateRegistry
+	tracer       trace.Tracer
+
+	// rendered is set on the copies rendering previews: newMessage keeps
+	// the email there, and deliver sends nothing.
+	rendered *models.EmailPreview
+}
+
+// NewEmailSender creates a new email service. Deliveries are reported to
+// recorder unless it is nil, and prices are converted into the display
+// currency with rates unless it is nil. Unless they are nil, suppressions
+// are checked before every send and record addresses that bounce, and
+// unsubscribe signs the unsubscribe link of every templated email. It fails
+// if the email templates do not parse.
+func NewEmailSender(
+	config EmailConfig,
+	recorder DeliveryRecorder,
+	rates currency.Service,
+	suppressions SuppressionList,
+	unsubscribe UnsubscribeLinker,
+) (EmailSender, error) {
+	templates, err := loadTemplates(config.TemplateDir)
+	if err != nil {
+		return nil, err
+	}
 
 	return &emailSender{
-		config,
-		dialer,
-		otel.Tracer(config.Name),
+		config:       config,
+		pool:         newSMTPPool(config),
+		breaker:      &circuitBreaker{threshold: config.BreakerThreshold, cooldown: config.BreakerCooldown},
+		recorder:     recorder,
+		rates:        rates,
+		suppressions: suppressions,
+		unsubscribe:  unsubscribe,
+		templates:    templates,
+		tracer:       otel.Tracer(config.Name),
+	}, nil
+}
+
+// UpdateCredentials closes the idle connections, which authenticated with
+// the old credentials.
+func (es *emailSender) UpdateCredentials(username, password string) {
+	es.pool.setCredentials(username, password)
+}
+
+// deliver hands a message to the SMTP server and reports the outcome.
+// Suppressed recipients are dropped first, and a message left without any
+// fails with ErrRecipientSuppressed. Failures other than rejections of the
+// message are retried up to MaxRetries times with jittered exponential
+// backoff. While the circuit breaker is open, it fails with
+// ErrSMTPUnavailable without contacting the server.
+func (es *emailSender) deliver(ctx context.Context, message *gomail.Message) error {
+	if es.rendered != nil {
+		return nil
+	}
+	if err := es.dropSuppressed(ctx, message); err != nil {
+		return err
+	}
+
+	err := es.send(ctx, message)
+	if es.recorder != nil {
+		es.recorder.RecordEmailDeliveryInternal(ctx, err)
+	}
+	es.suppressBounced(ctx, err)
+	return err
+}
+
+// dropSuppressed removes the suppressed addresses from the recipients of
+// message. An address that can't be looked up is kept: a missed suppression
+// costs one bounce, while a missed email may cost a renewal.
+func (es *emailSender) dropSuppressed(ctx context.Context, message *gomail.Message) error {
+	if es.suppressions == nil {
+		return nil
+	}
+
+	to := message.GetHeader("To")
+	kept := make([]string, 0, len(to))
+	for _, addr := range to {
+		suppressed, err := es.suppressions.IsSuppressedInternal(ctx, addr)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to check email suppression list",
+				logattr.Error(err),
+			)
+		}
+		if !suppressed {
+			kept = append(kept, addr)
+		}
+	}
+
+	switch {
+	case len(kept) == 0:
+		return ErrRecipientSuppressed
+	case len(kept) < len(to):
+		message.SetHeader("To", kept...)
+	}
+	return nil
+}
+
+// suppressBounced adds the recipient to the suppression list if the server
+// refused it for good, e.g. because the mailbox does not exist.
+func (es *emailSender) suppressBounced(ctx context.Context, err error) {
+	rcptErr, ok := errors.AsType[*recipientError](err)
+	if es.suppressions == nil || !ok || !rcptErr.hardBounce() {
+		return
+	}
+
+	if err = es.suppressions.SuppressInternal(ctx, rcptErr.addr, models.SuppressionBounced, rcptErr.Error()); err != nil {
+		slog.ErrorContext(ctx, "Failed to suppress bounced email address",
+			logattr.Error(err),
+		)
+		return
+	}
+	slog.WarnContext(ctx, "Email address bounced and was suppressed",
+		logattr.Error(rcptErr),
+	)
+}
+
+func (es *emailSender) send(ctx context.Context, message *gomail.Message) error {
+	delay := es.config.RetryBaseDelay
+	for attempt := 1; ; attempt++ {
+		err := es.attempt(ctx, message)
+		if err == nil || errors.Is(err, ErrSMTPUnavailable) || permanentSMTPError(err) ||
+			attempt > es.config.MaxRetries || ctx.Err() != nil {
+			return err
+		}
+
+		wait := jitter(delay)
+		slog.WarnContext(ctx, "Failed to send email, retrying",
+			logattr.Attempt(attempt),
+			logattr.RetryIn(wait),
+			logattr.Error(err),
+		)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-timer.C:
+		}
+		delay = min(delay*2, es.config.RetryMaxDelay)
+	}
+}
+
+// attempt sends a message once, over an idle connection if there is one.
+// It must finish within SendTimeout and the deadline of ctx.
+func (es *emailSender) attempt(ctx context.Context, message *gomail.Message) error {
+	if !es.breaker.allow(time.Now()) {
+		return ErrSMTPUnavailable
+	}
+	deadline := time.Now().Add(es.config.SendTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	conn, err := es.pool.get(ctx, deadline)
+	if err == nil {
+		if err = conn.send(ctx, deadline, message); err != nil {
+			// The session may be mid-transaction; start afresh next time.
+			conn.close()
+		} else {
+			es.pool.put(conn)
+		}
+	}
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// The caller gave up; that says nothing about the server.
+		es.breaker.abort()
+	case permanentSMTPError(err):
+		// The server is up; it only rejected this message.
+		es.breaker.record(time.Now(), nil)
+	default:
+		if es.breaker.record(time.Now(), err) {
+			slog.ErrorContext(ctx, "SMTP circuit breaker opened",
+				logattr.Host(es.config.SMTPHost),
+				logattr.Period(es.config.BreakerCooldown),
+				logattr.Error(err),
+			)
+		}
+	}
+	return err
+}
+
+// newMessage addresses an email with the body template name rendered with
+// data. The email links to its unsubscribe link in the footer and in the
+// List-Unsubscribe header, which mail clients offer as a button.
+func (es *emailSender) newMessage(to, subject, name string, data templateData) (*gomail.Message, error) {
+	data.UnsubscribeURL = es.unsubscribeURL(to)
+	body, err := es.templates.render(name, data)
+	if err != nil {
+		return nil, err
+	}
+	if es.rendered != nil {
+		es.rendered.Subject = subject
+		es.rendered.HTML = body
+	}
+
+	message := gomail.NewMessage()
+	message.SetHeader("From", fmt.Sprintf("%s <%s>", es.config.FromName, es.config.FromEmail))
+	message.SetHeader("To", to)
+	message.SetHeader("Subject", subject)
+	if data.UnsubscribeURL != "" {
+		message.SetHeader("List-Unsubscribe", "<"+data.UnsubscribeURL+">")
+		// Lets clients unsubscribe with a POST rather than opening the link
+		// (RFC 8058).
+		message.SetHeader("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	message.SetBody("text/html", body)
+	return message, nil
+}
+
+// unsubscribeURL returns the link unsubscribing to from emails, or "" if
+// there is none or it is not absolute, which is useless outside the site.
+func (es *emailSender) unsubscribeURL(to string) string {
+	if es.unsubscribe == nil {
+		return ""
+	}
+	link := es.unsubscribe.SignUnsubscribeLinkInternal(to, models.ChannelEmail)
+	if u, err := url.Parse(link); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return link
+}
+
+// formatPrice formats an amount and, when it is not in the display currency,
+// appends its approximate value in that currency, e.g.
+// "USD 15.49 (about EUR 14.20)". Prices stay unconverted if no rates are
+// available.
+func (es *emailSender) formatPrice(ctx context.Context, amount int64, from models.Currency) string {
+	price := models.FormatMoney(amount, from)
+	to := models.ParseCurrency(es.config.DisplayCurrency)
+	if es.rates == nil || to == "" || to == from {
+		return price
+	}
+
+	converted, err := es.rates.Convert(ctx, amount, from, to, time.Now())
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to convert email price",
+			logattr.Currency(string(to)),
+			logattr.Error(err),
+		)
+		return price
+	}
+	return i18n.T(ctx, "%s (about %s)", price, models.FormatMoney(converted, to))
+}
+
+// describePaymentMethod names a payment method for emails, flagging expired
+// cards. It returns "" for nil.
+func describePaymentMethod(loc *i18n.Localizer, method *models.PaymentMethod) string {
+	if method == nil {
+		return ""
+	}
+	if method.Expired(time.Now()) {
+		return loc.Sprintf("%s - expired, please update it", method.Description())
 	}
+	return method.Description()
 }
 
 // SendReminderEmail sends a subscription reminder email.
@@ -71,6 +448,7 @@ func (es *emailSender) SendReminderEmail(
 	toEmail string,
 	userName string,
 	subscription *models.Subscription,
+	paymentMethod *models.PaymentMethod,
 	daysBefore int,
 ) error {
 	// Check context to allow for cancellation.
@@ -87,41 +465,41 @@ func (es *emailSender) SendReminderEmail(
 	)
 	defer span.End()
 
+	loc := i18n.FromContext(ctx)
+
 	// Get the template
 	template := getTemplate(daysBefore)
 
 	// Format price string.
-	priceStr := fmt.Sprintf("%s %d (%s)",
-		subscription.Currency,
-		subscription.Price,
-		subscription.Frequency,
+	priceStr := fmt.Sprintf("%s (%s)",
+		es.formatPrice(ctx, subscription.Price, subscription.Currency),
+		loc.Sprintf(string(subscription.Frequency)),
 	)
 
 	// Create template data.
 	data := templateData{
-		userName:         userName,
-		subscriptionName: subscription.Name,
-		renewalDate:      FormatTime(subscription.ValidTill.Local()),
-		planName:         subscription.Name,
-		price:            priceStr,
-		accountURL:       es.config.AccountURL,
-		supportURL:       es.config.SupportURL,
-		daysLeft:         daysBefore,
+		UserName:         userName,
+		SubscriptionName: subscription.Name,
+		RenewalDate:      loc.Date(renewalDate(ctx, subscription)),
+		PlanName:         subscription.Name,
+		Price:            priceStr,
+		AccountURL:       es.config.AccountURL,
+		SupportURL:       es.config.SupportURL,
+		DaysLeft:         daysBefore,
+		PaymentMethod:    describePaymentMethod(loc, paymentMethod),
+		loc:              loc,
 	}
 
-	// Generate email content.
-	subject := template.generateSubject(data)
-	htmlBody := template.generateBody(data)
-
 	// Create email message.
-	message := gomail.NewMessage()
-	message.SetHeader("From", fmt.Sprintf("%s <%s>", es.config.FromName, es.config.FromEmail))
-	message.SetHeader("To", toEmail)
-	message.SetHeader("Subject", subject)
-	message.SetBody("text/html", htmlBody)
+	message, err := es.newMessage(toEmail, template.generateSubject(data), template.name, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to render reminder email")
+		return err
+	}
 
 	// Send the email.
-	if err := es.dialer.DialAndSend(message); err != nil {
+	if err = es.deliver(ctx, message); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to send reminder email")
 		return fmt.Errorf("failed to send reminder email: %w", err)
@@ -130,12 +508,196 @@ func (es *emailSender) SendReminderEmail(
 	return nil
 }
 
+// SendReminderDigestEmail sends one email listing every renewal in
+// reminders, soonest first.
+func (es *emailSender) SendReminderDigestEmail(
+	ctx context.Context,
+	toEmail string,
+	userName string,
+	reminders []Reminder,
+) error {
+	// Check context to allow for cancellation.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Start the child span for the SMTP call
+	ctx, span := es.tracer.Start(ctx, "Send Reminder Digest Email",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			otelattr.ReminderCount(len(reminders)),
+		),
+	)
+	defer span.End()
+
+	loc := i18n.FromContext(ctx)
+
+	sorted := slices.Clone(reminders)
+	slices.SortStableFunc(sorted, func(a, b Reminder) int {
+		return cmp.Compare(a.DaysBefore, b.DaysBefore)
+	})
+	rows := make([]reminderData, len(sorted))
+	for i, reminder := range sorted {
+		rows[i] = reminderData{
+			SubscriptionName: reminder.Subscription.Name,
+			RenewalDate:      loc.Date(renewalDate(ctx, reminder.Subscription)),
+			DaysLeft:         reminder.DaysBefore,
+			Price: fmt.Sprintf("%s (%s)",
+				es.formatPrice(ctx, reminder.Subscription.Price, reminder.Subscription.Currency),
+				loc.Sprintf(string(reminder.Subscription.Frequency)),
+			),
+			PaymentMethod: describePaymentMethod(loc, reminder.PaymentMethod),
+		}
+	}
+
+	subject := loc.Sprintf("📅 %d of Your Subscriptions Renew Soon", len(rows))
+	data := templateData{
+		UserName:   userName,
+		AccountURL: es.config.AccountURL,
+		SupportURL: es.config.SupportURL,
+		Reminders:  rows,
+		loc:        loc,
+	}
+
+	// Create email message.
+	message, err := es.newMessage(toEmail, subject, TemplateReminderDigest, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to render reminder digest email")
+		return err
+	}
+
+	// Send the email.
+	if err = es.deliver(ctx, message); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to send reminder digest email")
+		return fmt.Errorf("failed to send reminder digest email: %w", err)
+	}
+
+	return nil
+}
+
+// SendTrialEndingEmail sends a reminder that a free trial is about to end,
+// stating the amount of the first charge.
+func (es *emailSender) SendTrialEndingEmail(
+	ctx context.Context,
+	toEmail string,
+	userName string,
+	subscription *models.Subscription,
+	daysBefore int,
+) error {
+	// Check context to allow for cancellation.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Start the child span for the SMTP call
+	ctx, span := es.tracer.Start(ctx, "Send Trial Ending Email",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			otelattr.DaysBefore(daysBefore),
+		),
+	)
+	defer span.End()
+
+	loc := i18n.FromContext(ctx)
+
+	template := getTrialEndingTemplate(daysBefore)
+	data := templateData{
+		UserName:         userName,
+		SubscriptionName: subscription.Name,
+		RenewalDate:      loc.Date(renewalDate(ctx, subscription)),
+		PlanName:         fmt.Sprintf("%s (%s)", subscription.Name, loc.Sprintf(string(subscription.Frequency))),
+		Price:            es.formatPrice(ctx, subscription.Price, subscription.Currency),
+		AccountURL:       es.config.AccountURL,
+		SupportURL:       es.config.SupportURL,
+		DaysLeft:         daysBefore,
+		loc:              loc,
+	}
+
+	// Create email message.
+	message, err := es.newMessage(toEmail, template.generateSubject(data), template.name, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to render trial ending email")
+		return err
+	}
+
+	// Send the email.
+	if err = es.deliver(ctx, message); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to send trial ending email")
+		return fmt.Errorf("failed to send trial ending email: %w", err)
+	}
+
+	return nil
+}
+
+// SendDunningEmail sends an email about a failed renewal payment, escalating
+// with each retry attempt.
+func (es *emailSender) SendDunningEmail(
+	ctx context.Context,
+	userEmail string,
+	userName string,
+	subscription *models.Subscription,
+	attempt int,
+	final bool,
+	finalAction models.DunningFinalAction,
+) error {
+	// Check context to allow for cancellation.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Start the child span for the SMTP call
+	ctx, span := es.tracer.Start(ctx, "Send Dunning Email",
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	defer span.End()
+
+	loc := i18n.FromContext(ctx)
+
+	suspend := finalAction == models.DunningSuspend
+	template := getDunningTemplate(attempt, final, suspend)
+	data := templateData{
+		UserName:         userName,
+		SubscriptionName: subscription.Name,
+		RenewalDate:      loc.Date(renewalDate(ctx, subscription)),
+		PlanName:         fmt.Sprintf("%s (%s)", subscription.Name, loc.Sprintf(string(subscription.Frequency))),
+		Price:            es.formatPrice(ctx, subscription.Price, subscription.Currency),
+		AccountURL:       es.config.AccountURL,
+		SupportURL:       es.config.SupportURL,
+		Attempt:          attempt,
+		Final:            final,
+		Suspend:          suspend,
+		loc:              loc,
+	}
+
+	// Create email message.
+	message, err := es.newMessage(userEmail, template.generateSubject(data), template.name, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to render dunning email")
+		return err
+	}
+
+	// Send the email.
+	if err = es.deliver(ctx, message); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to send dunning email")
+		return fmt.Errorf("failed to send dunning email: %w", err)
+	}
+
+	return nil
+}
+
 // SendRenewalConfirmationEmail sends an email notifying a user that their subscription has been automatically renewed
 func (es *emailSender) SendRenewalConfirmationEmail(
 	ctx context.Context,
 	userEmail string,
 	userName string,
 	subscription *models.Subscription,
+	paymentMethod *models.PaymentMethod,
 ) error {
 	// Check context to allow for cancellation.
 	if err := ctx.Err(); err != nil {
@@ -148,42 +710,31 @@ func (es *emailSender) SendRenewalConfirmationEmail(
 	)
 	defer span.End()
 
-	subject := fmt.Sprintf("Your %s subscription has been renewed", subscription.Name)
-	renewalAmount := fmt.Sprintf("%d %s", subscription.Price, subscription.Currency)
-	// Format the email body
-	body := fmt.Sprintf(`
-	Hello %s,
-	
-	Your subscription to %s has been automatically renewed.
-	
-	Subscription Details:
-	- Name: %s
-	- Amount: %s
-	- Valid Till: %s
-	
-	If you did not want this renewal, you can cancel your subscription through your account.
-	
-	Thank you for your continued subscription!
-	
-	Best regards,
-	The Subscription Management Team
-	`,
-		userName,
-		subscription.Name,
-		subscription.Name,
-		renewalAmount,
-		subscription.ValidTill.Format("January 2, 2006"),
-	)
+	loc := i18n.FromContext(ctx)
+
+	subject := loc.Sprintf("Your %s subscription has been renewed", subscription.Name)
+	data := templateData{
+		UserName:         userName,
+		SubscriptionName: subscription.Name,
+		RenewalDate:      loc.Date(renewalDate(ctx, subscription)),
+		PlanName:         subscription.Name,
+		Price:            es.formatPrice(ctx, subscription.Price, subscription.Currency),
+		AccountURL:       es.config.AccountURL,
+		SupportURL:       es.config.SupportURL,
+		PaymentMethod:    describePaymentMethod(loc, paymentMethod),
+		loc:              loc,
+	}
 
 	// Create the email message.
-	message := gomail.NewMessage()
-	message.SetHeader("From", fmt.Sprintf("%s <%s>", es.config.FromName, es.config.FromEmail))
-	message.SetHeader("To", userEmail)
-	message.SetHeader("Subject", subject)
-	message.SetBody("text/html", body)
+	message, err := es.newMessage(userEmail, subject, TemplateRenewalConfirmation, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to render renewal confirmation email")
+		return err
+	}
 
 	// Send the email.
-	if err := es.dialer.DialAndSend(message); err != nil {
+	if err = es.deliver(ctx, message); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to send renewal confirmation email")
 		return fmt.Errorf("failed to send renewal confirmation email: %w", err)
@@ -191,8 +742,331 @@ func (es *emailSender) SendRenewalConfirmationEmail(
 	return nil
 }
 
+// SendUnusedSubscriptionEmail sends an email suggesting to cancel an unused
+// subscription before it renews, showing what canceling saves per year.
+func (es *emailSender) SendUnusedSubscriptionEmail(
+	ctx context.Context,
+	userEmail string,
+	userName string,
+	subscription *models.Subscription,
+	cancelURL string,
+) error {
+	// Check context to allow for cancellation.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Start the child span for the SMTP call
+	ctx, span := es.tracer.Start(ctx, "Send Unused Subscription Email",
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	defer span.End()
+
+	loc := i18n.FromContext(ctx)
+
+	lastUsed := loc.Sprintf("never")
+	if subscription.LastUsedAt != nil {
+		lastUsed = loc.Date(*subscription.LastUsedAt)
+	}
+	savings := models.FormatMoney(subscription.YearlyCost(), subscription.Currency)
+
+	subject := loc.Sprintf("Still using %s? Cancel and save %s/year", subscription.Name, savings)
+	data := templateData{
+		UserName:         userName,
+		SubscriptionName: subscription.Name,
+		RenewalDate:      loc.Date(renewalDate(ctx, subscription)),
+		PlanName:         subscription.Name,
+		Price:            savings,
+		AccountURL:       es.config.AccountURL,
+		SupportURL:       es.config.SupportURL,
+		LastUsed:         lastUsed,
+		CancelURL:        cancelURL,
+		loc:              loc,
+	}
+
+	// Create the email message.
+	message, err := es.newMessage(userEmail, subject, TemplateUnusedSubscription, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to render unused subscription email")
+		return err
+	}
+
+	// Send the email.
+	if err = es.deliver(ctx, message); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to send unused subscription email")
+		return fmt.Errorf("failed to send unused subscription email: %w", err)
+	}
+	return nil
+}
+
+// SendPriceChangeEmail sends an email about a subscription price change, e.g.
+// "Your Netflix went up by 12%".
+func (es *emailSender) SendPriceChangeEmail(
+	ctx context.Context,
+	userEmail string,
+	userName string,
+	change *models.PriceChangeResponse,
+) error {
+	// Check context to allow for cancellation.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Start the child span for the SMTP call
+	ctx, span := es.tracer.Start(ctx, "Send Price Change Email",
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	defer span.End()
+
+	loc := i18n.FromContext(ctx)
+
+	up := change.Delta.Amount >= 0
+	percent := strconv.FormatFloat(math.Abs(change.PercentChange), 'f', -1, 64)
+	yearly := models.FormatMoney(absAmount(change.AnnualImpact.Amount), models.Currency(change.AnnualImpact.Currency))
+
+	subject := loc.Sprintf("Your %s went up by %s%%", change.Name, percent)
+	if !up {
+		subject = loc.Sprintf("Your %s went down by %s%%", change.Name, percent)
+	}
+	data := templateData{
+		UserName:         userName,
+		SubscriptionName: change.Name,
+		PlanName:         change.Name,
+		Price:            change.NewPrice.Formatted,
+		AccountURL:       es.config.AccountURL,
+		SupportURL:       es.config.SupportURL,
+		PriceChange: priceChangeData{
+			Up:        up,
+			Percent:   percent,
+			OldPrice:  change.OldPrice.Formatted,
+			Effective: loc.Date(change.EffectiveAt),
+			Yearly:    yearly,
+		},
+		loc: loc,
+	}
+
+	// Create the email message.
+	message, err := es.newMessage(userEmail, subject, TemplatePriceChange, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to render price change email")
+		return err
+	}
+
+	// Send the email.
+	if err = es.deliver(ctx, message); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to send price change email")
+		return fmt.Errorf("failed to send price change email: %w", err)
+	}
+	return nil
+}
+
+// renewalDate returns the renewal date of a subscription in its billing
+// timezone, so the email names the day the vendor bills on. Without one, the
+// date is in the timezone of the recipient carried by ctx.
+func renewalDate(ctx context.Context, subscription *models.Subscription) time.Time {
+	user, ok := appctx.GetLocation(ctx)
+	if !ok {
+		user = time.Local
+	}
+	return subscription.ValidTill.In(subscription.BillingLocation(user))
+}
+
+// absAmount returns the magnitude of an amount in minor units.
+func absAmount(amount int64) int64 {
+	if amount < 0 {
+		return -amount
+	}
+	return amount
+}
+
+// SendBudgetAlertEmail sends an email notifying a user that their monthly
+// subscription spend exceeds an overall or category budget.
+func (es *emailSender) SendBudgetAlertEmail(
+	ctx context.Context,
+	userEmail string,
+	userName string,
+	alert *models.BudgetAlert,
+) error {
+	// Check context to allow for cancellation.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Start the child span for the SMTP call
+	ctx, span := es.tracer.Start(ctx, "Send Budget Alert Email",
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	defer span.End()
+
+	loc := i18n.FromContext(ctx)
+
+	budgetName := loc.Sprintf("overall")
+	if alert.Category != "" {
+		budgetName = loc.Sprintf(string(alert.Category))
+	}
+	subject := loc.Sprintf("Your %s subscription budget has been exceeded", budgetName)
+	data := templateData{
+		UserName:   userName,
+		AccountURL: es.config.AccountURL,
+		SupportURL: es.config.SupportURL,
+		Budget: budgetData{
+			Name:  budgetName,
+			Limit: models.FormatMoney(alert.Budget, alert.Currency),
+			Spend: models.FormatMoney(alert.Actual, alert.Currency),
+		},
+		loc: loc,
+	}
+
+	// Create the email message.
+	message, err := es.newMessage(userEmail, subject, TemplateBudgetAlert, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to render budget alert email")
+		return err
+	}
+
+	// Send the email.
+	if err = es.deliver(ctx, message); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to send budget alert email")
+		return fmt.Errorf("failed to send budget alert email: %w", err)
+	}
+	return nil
+}
+
 // Close cleans up resources if needed.
+// SendExportReadyEmail sends an email with the download link of an export
+// generated in the background: one too large to stream in the response, or
+// an account archive.
+func (es *emailSender) SendExportReadyEmail(
+	ctx context.Context,
+	userEmail string,
+	userName string,
+	export *models.SignedFile,
+) error {
+	// Check context to allow for cancellation.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Start the child span for the SMTP call
+	ctx, span := es.tracer.Start(ctx, "Send Export Ready Email",
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	defer span.End()
+
+	loc := i18n.FromContext(ctx)
+
+	subject := loc.Sprintf("📦 Your export %s is ready", export.File.Filename)
+	data := templateData{
+		UserName:   userName,
+		AccountURL: es.config.AccountURL,
+		SupportURL: es.config.SupportURL,
+		Export: exportData{
+			Filename:    export.File.Filename,
+			DownloadURL: export.URL,
+			ExpiresAt:   loc.Date(export.ExpiresAt),
+		},
+		loc: loc,
+	}
+
+	// Create the email message.
+	message, err := es.newMessage(userEmail, subject, TemplateExportReady, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to render export ready email")
+		return err
+	}
+
+	// Send the email.
+	if err = es.deliver(ctx, message); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to send export ready email")
+		return fmt.Errorf("failed to send export ready email: %w", err)
+	}
+	return nil
+}
+
+// SendAccountClosedEmail sends the confirmation of an account closure.
+func (es *emailSender) SendAccountClosedEmail(
+	ctx context.Context,
+	userEmail string,
+	userName string,
+	purgeAt time.Time,
+) error {
+	// Check context to allow for cancellation.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Start the child span for the SMTP call
+	ctx, span := es.tracer.Start(ctx, "Send Account Closed Email",
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	defer span.End()
+
+	loc := i18n.FromContext(ctx)
+
+	subject := loc.Sprintf("👋 Your account has been closed")
+	data := templateData{
+		UserName:   userName,
+		SupportURL: es.config.SupportURL,
+		PurgeDate:  loc.Date(purgeAt),
+		loc:        loc,
+	}
+
+	// Create the email message.
+	message, err := es.newMessage(userEmail, subject, TemplateAccountClosed, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to render account closed email")
+		return err
+	}
+
+	// Send the email.
+	if err = es.deliver(ctx, message); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to send account closed email")
+		return fmt.Errorf("failed to send account closed email: %w", err)
+	}
+	return nil
+}
+
+// SendDeadTaskAlertEmail sends operators the details of a task that exhausted
+// its retries, in plain text so any mail client shows the error verbatim.
+func (es *emailSender) SendDeadTaskAlertEmail(ctx context.Context, toEmails []string, task *models.DeadTask) error {
+	// Check context to allow for cancellation.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Start the child span for the SMTP call
+	ctx, span := es.tracer.Start(ctx, "Send Dead Task Alert Email",
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	defer span.End()
+
+	msg := deadTaskMessage(task)
+	message := gomail.NewMessage()
+	message.SetHeader("From", fmt.Sprintf("%s <%s>", es.config.FromName, es.config.FromEmail))
+	message.SetHeader("To", toEmails...)
+	message.SetHeader("Subject", msg.title)
+	message.SetBody("text/plain", msg.body)
+
+	if err := es.deliver(ctx, message); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to send dead task alert email")
+		return fmt.Errorf("failed to send dead task alert email: %w", err)
+	}
+	return nil
+}
+
+// Close closes the idle SMTP connections.
 func (es *emailSender) Close() error {
-	// Nothing to clean up with gomail.
+	es.pool.close()
 	return nil
 }
@@ -0,0 +1,374 @@
+package notifications_test
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer speaks just enough SMTP to accept messages, without TLS or
+// authentication. mailReply answers the nth MAIL command, counting from 1
+// across connections; rcptReply, if set, answers every RCPT command; stall
+// keeps it from ever answering DATA.
+type fakeSMTPServer struct {
+	listener  net.Listener
+	mailReply func(n int) string
+	rcptReply string
+	stall     bool
+
+	conns     atomic.Int32
+	mails     atomic.Int32
+	delivered atomic.Int32
+	last      atomic.Pointer[string] // The last message delivered.
+	wg        sync.WaitGroup
+}
+
+func newFakeSMTPServer(t *testing.T, mailReply func(n int) string) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTPServer{listener: listener, mailReply: mailReply}
+	if s.mailReply == nil {
+		s.mailReply = func(int) string { return "250 OK" }
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+		s.wg.Wait()
+	})
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.conns.Add(1)
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.serve(conn)
+			}()
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := textproto.NewReader(bufio.NewReader(conn))
+	reply := func(line string) { _, _ = fmt.Fprintf(conn, "%s\r\n", line) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadLine()
+		if err != nil {
+			return
+		}
+		switch verb, _, _ := strings.Cut(strings.ToUpper(line), " "); verb {
+		case "EHLO", "HELO":
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case "MAIL":
+			reply(s.mailReply(int(s.mails.Add(1))))
+		case "RCPT":
+			reply(cmp.Or(s.rcptReply, "250 OK"))
+		case "DATA":
+			reply("354 Go ahead")
+			lines, err := r.ReadDotLines()
+			if err != nil {
+				return
+			}
+			if s.stall {
+				_, _ = r.ReadLine() // Until the client hangs up.
+				return
+			}
+			message := strings.Join(lines, "\n")
+			s.last.Store(&message)
+			s.delivered.Add(1)
+			reply("250 Queued")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) config() notifications.EmailConfig {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return notifications.EmailConfig{
+		SMTPHost:         "127.0.0.1",
+		SMTPPort:         addr.Port,
+		FromEmail:        "noreply@example.com",
+		FromName:         "SubDub",
+		SendTimeout:      5 * time.Second,
+		MaxRetries:       2,
+		RetryBaseDelay:   time.Millisecond,
+		RetryMaxDelay:    time.Millisecond,
+		MaxIdleConns:     1,
+		IdleTimeout:      time.Minute,
+		BreakerThreshold: 3,
+		BreakerCooldown:  time.Hour,
+	}
+}
+
+// fakeSuppressionList is a SuppressionList held in memory.
+type fakeSuppressionList struct {
+	mu         sync.Mutex
+	suppressed map[string]models.SuppressionReason
+}
+
+func (l *fakeSuppressionList) IsSuppressedInternal(_ context.Context, email string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.suppressed[email]
+	return ok, nil
+}
+
+func (l *fakeSuppressionList) SuppressInternal(
+	_ context.Context,
+	email string,
+	reason models.SuppressionReason,
+	_ string,
+) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.suppressed == nil {
+		l.suppressed = map[string]models.SuppressionReason{}
+	}
+	l.suppressed[email] = reason
+	return nil
+}
+
+// fakeUnsubscribeLinker links to baseURL with the address and channel in the
+// query.
+type fakeUnsubscribeLinker struct {
+	baseURL string
+}
+
+func (l fakeUnsubscribeLinker) SignUnsubscribeLinkInternal(email string, channel models.NotificationChannel) string {
+	return fmt.Sprintf("%s/api/v1/unsubscribe?channel=%s&email=%s", l.baseURL, channel, email)
+}
+
+func newTestEmailSender(t *testing.T, config notifications.EmailConfig) notifications.EmailSender {
+	t.Helper()
+	return newTestEmailSenderWith(t, config, nil, nil)
+}
+
+func newTestEmailSenderWith(
+	t *testing.T,
+	config notifications.EmailConfig,
+	suppressions notifications.SuppressionList,
+	unsubscribe notifications.UnsubscribeLinker,
+) notifications.EmailSender {
+	t.Helper()
+
+	sender, err := notifications.NewEmailSender(config, nil, nil, suppressions, unsubscribe)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sender.Close() })
+	return sender
+}
+
+func sendAlert(ctx context.Context, sender notifications.EmailSender) error {
+	return sender.SendDeadTaskAlertEmail(ctx, []string{"ops@example.com"}, testDeadTask())
+}
+
+func TestEmailSender_Delivery(t *testing.T) {
+	t.Run("reuses the connection", func(t *testing.T) {
+		server := newFakeSMTPServer(t, nil)
+		sender := newTestEmailSender(t, server.config())
+
+		for range 3 {
+			require.NoError(t, sendAlert(t.Context(), sender))
+		}
+
+		assert.EqualValues(t, 3, server.delivered.Load())
+		assert.EqualValues(t, 1, server.conns.Load())
+	})
+
+	t.Run("dials per email without idle connections", func(t *testing.T) {
+		server := newFakeSMTPServer(t, nil)
+		config := server.config()
+		config.MaxIdleConns = 0
+		sender := newTestEmailSender(t, config)
+
+		for range 2 {
+			require.NoError(t, sendAlert(t.Context(), sender))
+		}
+
+		assert.EqualValues(t, 2, server.conns.Load())
+	})
+
+	t.Run("retries transient failures", func(t *testing.T) {
+		server := newFakeSMTPServer(t, func(n int) string {
+			if n < 3 {
+				return "451 Try again later"
+			}
+			return "250 OK"
+		})
+		sender := newTestEmailSender(t, server.config())
+
+		require.NoError(t, sendAlert(t.Context(), sender))
+		assert.EqualValues(t, 3, server.mails.Load())
+		assert.EqualValues(t, 1, server.delivered.Load())
+	})
+
+	t.Run("gives up after the last retry", func(t *testing.T) {
+		server := newFakeSMTPServer(t, func(int) string { return "421 Service not available" })
+		config := server.config()
+		config.BreakerThreshold = 0
+		sender := newTestEmailSender(t, config)
+
+		err := sendAlert(t.Context(), sender)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "421")
+		assert.EqualValues(t, 3, server.mails.Load())
+	})
+
+	t.Run("does not retry rejections", func(t *testing.T) {
+		server := newFakeSMTPServer(t, func(int) string { return "550 Mailbox unavailable" })
+		sender := newTestEmailSender(t, server.config())
+
+		require.Error(t, sendAlert(t.Context(), sender))
+		assert.EqualValues(t, 1, server.mails.Load())
+
+		// Rejections don't open the breaker.
+		for range 3 {
+			require.Error(t, sendAlert(t.Context(), sender))
+		}
+		assert.EqualValues(t, 4, server.mails.Load())
+	})
+
+	t.Run("circuit breaker opens after repeated failures", func(t *testing.T) {
+		server := newFakeSMTPServer(t, func(int) string { return "421 Service not available" })
+		sender := newTestEmailSender(t, server.config())
+
+		// The three attempts of the first send open the breaker; later sends
+		// fail fast.
+		err := sendAlert(t.Context(), sender)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, notifications.ErrSMTPUnavailable)
+		require.ErrorIs(t, sendAlert(t.Context(), sender), notifications.ErrSMTPUnavailable)
+		assert.EqualValues(t, 3, server.mails.Load())
+	})
+
+	t.Run("context deadline bounds the send", func(t *testing.T) {
+		server := newFakeSMTPServer(t, nil)
+		server.stall = true
+		sender := newTestEmailSender(t, server.config())
+
+		ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+
+		require.Error(t, sendAlert(ctx, sender))
+		assert.Less(t, time.Since(start), 2*time.Second)
+		assert.EqualValues(t, 0, server.delivered.Load())
+	})
+}
+
+func TestEmailSender_Suppression(t *testing.T) {
+	t.Run("skips suppressed recipients", func(t *testing.T) {
+		server := newFakeSMTPServer(t, nil)
+		suppressions := &fakeSuppressionList{}
+		require.NoError(t, suppressions.SuppressInternal(
+			t.Context(), "ops@example.com", models.SuppressionComplained, "",
+		))
+		sender := newTestEmailSenderWith(t, server.config(), suppressions, nil)
+
+		require.ErrorIs(t, sendAlert(t.Context(), sender), notifications.ErrRecipientSuppressed)
+		assert.EqualValues(t, 0, server.mails.Load())
+	})
+
+	t.Run("sends to the recipients left", func(t *testing.T) {
+		server := newFakeSMTPServer(t, nil)
+		suppressions := &fakeSuppressionList{}
+		require.NoError(t, suppressions.SuppressInternal(
+			t.Context(), "gone@example.com", models.SuppressionBounced, "",
+		))
+		sender := newTestEmailSenderWith(t, server.config(), suppressions, nil)
+
+		err := sender.SendDeadTaskAlertEmail(t.Context(),
+			[]string{"gone@example.com", "ops@example.com"}, testDeadTask())
+
+		require.NoError(t, err)
+		require.NotNil(t, server.last.Load())
+		assert.NotContains(t, *server.last.Load(), "gone@example.com")
+	})
+
+	t.Run("suppresses hard bounces", func(t *testing.T) {
+		server := newFakeSMTPServer(t, nil)
+		server.rcptReply = "550 No such user"
+		suppressions := &fakeSuppressionList{}
+		sender := newTestEmailSenderWith(t, server.config(), suppressions, nil)
+
+		require.Error(t, sendAlert(t.Context(), sender))
+		assert.Equal(t, models.SuppressionBounced, suppressions.suppressed["ops@example.com"])
+
+		require.ErrorIs(t, sendAlert(t.Context(), sender), notifications.ErrRecipientSuppressed)
+		assert.EqualValues(t, 1, server.mails.Load())
+	})
+
+	t.Run("does not suppress soft bounces", func(t *testing.T) {
+		server := newFakeSMTPServer(t, nil)
+		server.rcptReply = "552 Mailbox full"
+		suppressions := &fakeSuppressionList{}
+		sender := newTestEmailSenderWith(t, server.config(), suppressions, nil)
+
+		require.Error(t, sendAlert(t.Context(), sender))
+		assert.Empty(t, suppressions.suppressed)
+	})
+}
+
+func TestEmailSender_Unsubscribe(t *testing.T) {
+	purgeAt := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("links to the unsubscribe link", func(t *testing.T) {
+		server := newFakeSMTPServer(t, nil)
+		linker := fakeUnsubscribeLinker{baseURL: "https://subdub.example.com"}
+		sender := newTestEmailSenderWith(t, server.config(), nil, linker)
+
+		require.NoError(t, sender.SendAccountClosedEmail(t.Context(), "user@example.com", "User", purgeAt))
+
+		require.NotNil(t, server.last.Load())
+		message := *server.last.Load()
+		assert.Contains(t, message,
+			"List-Unsubscribe: <https://subdub.example.com/api/v1/unsubscribe?channel=email&email=user@example.com>")
+		assert.Contains(t, message, "List-Unsubscribe-Post: List-Unsubscribe=One-Click")
+		body, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(message)))
+		require.NoError(t, err)
+		assert.Contains(t, string(body),
+			`<a href="https://subdub.example.com/api/v1/unsubscribe?channel=email&amp;email=user@example.com"`)
+	})
+
+	t.Run("omits relative links", func(t *testing.T) {
+		server := newFakeSMTPServer(t, nil)
+		sender := newTestEmailSenderWith(t, server.config(), nil, fakeUnsubscribeLinker{})
+
+		require.NoError(t, sender.SendAccountClosedEmail(t.Context(), "user@example.com", "User", purgeAt))
+
+		require.NotNil(t, server.last.Load())
+		assert.NotContains(t, *server.last.Load(), "List-Unsubscribe")
+		assert.NotContains(t, *server.last.Load(), "/api/v1/unsubscribe")
+	})
+}
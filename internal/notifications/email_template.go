@@ -1,132 +1,322 @@
 package notifications
 
 import (
+	"embed"
+	"errors"
 	"fmt"
-	"time"
+	"html/template"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/anuragthepathak/subscription-management/internal/i18n"
+)
+
+// Names of the email templates. The notification history records messages
+// of every channel under these names.
+const (
+	TemplateReminder            = "reminder"
+	TemplateReminderDigest      = "reminder_digest"
+	TemplateTrialEnding         = "trial_ending"
+	TemplateDunning             = "dunning"
+	TemplateRenewalConfirmation = "renewal_confirmation"
+	TemplateUnusedSubscription  = "unused_subscription"
+	TemplatePriceChange         = "price_change"
+	TemplateBudgetAlert         = "budget_alert"
+	TemplateExportReady         = "export_ready"
+	TemplateAccountClosed       = "account_closed"
 )
 
-// emailTemplate represents an email template with subject and body generators.
+// emailTemplate pairs the subject of an email with the template of its body.
 type emailTemplate struct {
-	label           string
+	name            string // Body template in the registry.
 	generateSubject func(templateData) string
-	generateBody    func(templateData) string
 }
 
-// templateData contains all data needed for email templates.
+// templateData contains all data needed for email templates. Templates
+// escape every value, so none may contain markup.
 type templateData struct {
-	userName         string
-	subscriptionName string
-	renewalDate      string
-	planName         string
-	price            string
-	accountURL       string
-	supportURL       string
-	daysLeft         int
+	UserName         string
+	SubscriptionName string
+	RenewalDate      string
+	PlanName         string
+	Price            string
+	AccountURL       string
+	SupportURL       string
+	DaysLeft         int
+	PaymentMethod    string // Empty when no payment method is linked.
+
+	// Dunning emails.
+	Attempt int
+	Final   bool
+	Suspend bool // The final retry suspends the subscription instead of expiring it.
+
+	// Unused subscription emails.
+	LastUsed  string
+	CancelURL string
+
+	PriceChange priceChangeData // Price change emails.
+	Budget      budgetData      // Budget alert emails.
+	Reminders   []reminderData  // Reminder digests, soonest first.
+	Export      exportData      // Export ready emails.
+	PurgeDate   string          // Account closed emails: when the account is deleted.
+
+	UnsubscribeURL string // Empty when emails carry no unsubscribe link.
+
+	loc *i18n.Localizer // Language of the email; nil for English.
+}
+
+// priceChangeData describes a price change, with Price as the new price.
+type priceChangeData struct {
+	Up        bool // Whether the price went up.
+	Percent   string
+	OldPrice  string
+	Effective string
+	Yearly    string // Yearly difference, without sign.
+}
+
+// reminderData describes one renewal of a reminder digest.
+type reminderData struct {
+	SubscriptionName string
+	RenewalDate      string
+	DaysLeft         int
+	Price            string
+	PaymentMethod    string // Empty when no payment method is linked.
+}
+
+// exportData describes an export generated in the background.
+type exportData struct {
+	Filename    string
+	DownloadURL string
+	ExpiresAt   string
+}
+
+// budgetData describes an exceeded budget.
+type budgetData struct {
+	Name  string // Category, or "overall".
+	Limit string
+	Spend string
+}
+
+// T translates format into the language of the email and formats it with
+// args. Translations come with the binary and may hold markup; string args
+// are escaped, while template.HTML ones, such as links, are kept as they are.
+func (d templateData) T(format string, args ...any) template.HTML {
+	for i, arg := range args {
+		if s, ok := arg.(string); ok {
+			args[i] = template.HTMLEscapeString(s)
+		}
+	}
+	return template.HTML(d.loc.Sprintf(format, args...))
+}
+
+// InDays words how far away a day is, e.g. "tomorrow" or "in 3 days", for T
+// args.
+func (d templateData) InDays(days int) string {
+	return inDays(d.loc, days)
+}
+
+// Highlight renders text in bold in the accent color, for T args.
+func (d templateData) Highlight(text string) template.HTML {
+	return template.HTML(`<strong style="color: #4a90e2;">` + template.HTMLEscapeString(text) + `</strong>`)
+}
+
+// Link renders a link to href with text, for T args. Links that are not
+// http, https, or mailto render the text alone.
+func (d templateData) Link(href string, text template.HTML) template.HTML {
+	u, err := url.Parse(href)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "mailto") {
+		return text
+	}
+	return template.HTML(`<a href="` + template.HTMLEscapeString(href) +
+		`" style="color: #4a90e2; text-decoration: none;">` + string(text) + `</a>`)
+}
+
+// reminderSubjects holds hand-written reminder subjects for the usual
+// reminder days.
+var reminderSubjects = map[int]func(templateData) string{
+	7: func(data templateData) string {
+		return data.loc.Sprintf("📅 Reminder: Your %s Subscription Renews in 7 Days!", data.SubscriptionName)
+	},
+	5: func(data templateData) string {
+		return data.loc.Sprintf("⏳ %s Renews in 5 Days - Stay Subscribed!", data.SubscriptionName)
+	},
+	3: func(data templateData) string {
+		return data.loc.Sprintf("🚀 3 Days Left! %s Subscription Renewal", data.SubscriptionName)
+	},
+	1: func(data templateData) string {
+		return data.loc.Sprintf("⚡ Final Reminder: %s Renews Tomorrow!", data.SubscriptionName)
+	},
+}
+
+// genericReminderSubject words the subject of a reminder sent any number of
+// days before the renewal.
+func genericReminderSubject(data templateData) string {
+	switch {
+	case data.DaysLeft > 7:
+		return data.loc.Sprintf("📆 Your %s Subscription Renews in %d Days", data.SubscriptionName, data.DaysLeft)
+	case data.DaysLeft > 1:
+		return data.loc.Sprintf("🔔 %s Subscription Renews in %d Days!", data.SubscriptionName, data.DaysLeft)
+	case data.DaysLeft == 0:
+		return data.loc.Sprintf("⚠️ URGENT: %s Subscription Renews Today!", data.SubscriptionName)
+	default:
+		return data.loc.Sprintf("⚠️ %s Subscription Renewal Notice", data.SubscriptionName)
+	}
 }
 
-// getTemplate returns the appropriate email template based on days before renewal
+// getTemplate returns the reminder template for a renewal daysBefore days
+// away. The body words any number of days; the subject is the hand-written
+// one for that day if there is one, and the generic one otherwise. Subjects
+// of nearby days are never borrowed, since they name their own day count.
 func getTemplate(daysBefore int) emailTemplate {
-	template := emailTemplate{
-		generateBody: func(data templateData) string {
-			return generateEmailTemplate(data)
+	subject, ok := reminderSubjects[daysBefore]
+	if !ok {
+		subject = genericReminderSubject
+	}
+	return emailTemplate{name: TemplateReminder, generateSubject: subject}
+}
+
+// getTrialEndingTemplate returns the template reminding that a free trial ends
+// and the first charge is coming. Unlike renewal reminders, the subject states
+// the amount about to be charged.
+func getTrialEndingTemplate(daysBefore int) emailTemplate {
+	return emailTemplate{
+		name: TemplateTrialEnding,
+		generateSubject: func(data templateData) string {
+			switch {
+			case daysBefore > 1:
+				return data.loc.Sprintf("⏰ Your %s Trial Ends in %d Days - You'll Be Charged %s", data.SubscriptionName, daysBefore, data.Price)
+			case daysBefore == 1:
+				return data.loc.Sprintf("⏰ Your %s Trial Ends Tomorrow - You'll Be Charged %s", data.SubscriptionName, data.Price)
+			default:
+				return data.loc.Sprintf("⚠️ Your %s Trial Ends Today - You'll Be Charged %s", data.SubscriptionName, data.Price)
+			}
 		},
 	}
+}
 
-	switch daysBefore {
-	case 7:
+// getDunningTemplate returns the template for a retry of a failed renewal
+// payment. Attempt 0 is sent when the renewal charge is declined. The tone
+// escalates with each attempt, and the final one announces that the
+// subscription has expired; the body follows templateData.Attempt and Final.
+func getDunningTemplate(attempt int, final bool, suspend bool) emailTemplate {
+	template := emailTemplate{name: TemplateDunning}
+	switch {
+	case final && suspend:
 		template.generateSubject = func(data templateData) string {
-			return fmt.Sprintf("📅 Reminder: Your %s Subscription Renews in 7 Days!", data.subscriptionName)
+			return data.loc.Sprintf("❌ Your %s Subscription Is Suspended - Payment Failed", data.SubscriptionName)
 		}
-	case 5:
+	case final:
 		template.generateSubject = func(data templateData) string {
-			return fmt.Sprintf("⏳ %s Renews in 5 Days - Stay Subscribed!", data.subscriptionName)
+			return data.loc.Sprintf("❌ Your %s Subscription Has Expired - Payment Failed", data.SubscriptionName)
 		}
-	case 3:
+	case attempt == 0:
 		template.generateSubject = func(data templateData) string {
-			return fmt.Sprintf("🚀 3 Days Left! %s Subscription Renewal", data.subscriptionName)
+			return data.loc.Sprintf("⚠️ We Couldn't Charge Your %s Renewal", data.SubscriptionName)
 		}
-	case 1:
+	case attempt == 1:
 		template.generateSubject = func(data templateData) string {
-			return fmt.Sprintf("⚡ Final Reminder: %s Renews Tomorrow!", data.subscriptionName)
+			return data.loc.Sprintf("⚠️ Payment Failed for Your %s Subscription", data.SubscriptionName)
 		}
 	default:
 		template.generateSubject = func(data templateData) string {
-			if data.daysLeft > 7 {
-				return fmt.Sprintf("📆 Your %s Subscription Renews in %d Days", data.subscriptionName, data.daysLeft)
-			} else if data.daysLeft > 1 {
-				return fmt.Sprintf("🔔 %s Subscription Renews in %d Days!", data.subscriptionName, data.daysLeft)
-			} else if data.daysLeft == 0 {
-				return fmt.Sprintf("⚠️ URGENT: %s Subscription Renews Today!", data.subscriptionName)
-			} else {
-				return fmt.Sprintf("⚠️ %s Subscription Renewal Notice", data.subscriptionName)
+			return data.loc.Sprintf("⏳ Action Needed: %s Payment Still Failing", data.SubscriptionName)
+		}
+	}
+	return template
+}
+
+//go:embed templates/*.html
+var embeddedTemplates embed.FS
+
+// layoutTemplate frames every email. The other templates only define the
+// "content" block it renders.
+const layoutTemplate = "layout.html"
+
+// templateRegistry holds the parsed body templates, keyed by name without
+// extension.
+type templateRegistry struct {
+	templates map[string]*template.Template
+}
+
+// loadTemplates parses the embedded email templates. A file in overrideDir,
+// if set, replaces the embedded template of the same name, the layout
+// included; other files there are ignored.
+func loadTemplates(overrideDir string) (*templateRegistry, error) {
+	if overrideDir != "" {
+		info, err := os.Stat(overrideDir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid email template directory: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("email template directory %s is not a directory", overrideDir)
+		}
+	}
+
+	source := func(name string) (string, error) {
+		if overrideDir != "" {
+			content, err := os.ReadFile(filepath.Join(overrideDir, name))
+			if err == nil {
+				return string(content), nil
 			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				return "", fmt.Errorf("failed to read email template %s: %w", name, err)
+			}
+		}
+		content, err := embeddedTemplates.ReadFile(path.Join("templates", name))
+		if err != nil {
+			return "", fmt.Errorf("failed to read email template %s: %w", name, err)
 		}
+		return string(content), nil
 	}
 
-	return template
+	layoutSource, err := source(layoutTemplate)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := template.New(layoutTemplate).Parse(layoutSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse email template %s: %w", layoutTemplate, err)
+	}
+
+	files, err := fs.Glob(embeddedTemplates, "templates/*.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+	registry := &templateRegistry{templates: make(map[string]*template.Template, len(files))}
+	for _, file := range files {
+		name := path.Base(file)
+		if name == layoutTemplate {
+			continue
+		}
+		content, err := source(name)
+		if err != nil {
+			return nil, err
+		}
+		page, err := template.Must(layout.Clone()).Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse email template %s: %w", name, err)
+		}
+		if page.Lookup("content") == nil {
+			return nil, fmt.Errorf("email template %s does not define \"content\"", name)
+		}
+		registry.templates[strings.TrimSuffix(name, ".html")] = page
+	}
+	return registry, nil
 }
 
-// FormatTime formats time.Time into a readable date string.
-func FormatTime(t time.Time) string {
-	return t.Format("Jan 2, 2006")
-}
-
-// generateEmailTemplate creates HTML email content based on template data.
-func generateEmailTemplate(data templateData) string {
-	return fmt.Sprintf(`
-<div style="font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 0; background-color: #f4f7fa;">
-    <table cellpadding="0" cellspacing="0" border="0" width="100%%" style="background-color: #ffffff; border-radius: 10px; overflow: hidden; box-shadow: 0 4px 6px rgba(0, 0, 0, 0.1);">
-        <tr>
-            <td style="background-color: #4a90e2; text-align: center;">
-                <p style="font-size: 54px; line-height: 54px; font-weight: 800;">SubDub</p>
-            </td>
-        </tr>
-        <tr>
-            <td style="padding: 40px 30px;">                
-                <p style="font-size: 16px; margin-bottom: 25px;">Hello <strong style="color: #4a90e2;">%s</strong>,</p>
-                <p style="font-size: 16px; margin-bottom: 25px;">Your <strong>%s</strong> subscription is set to renew on <strong style="color: #4a90e2;">%s</strong> (%d days from today).</p>
-                <table cellpadding="15" cellspacing="0" border="0" width="100%%" style="background-color: #f0f7ff; border-radius: 10px; margin-bottom: 25px;">
-                    <tr>
-                        <td style="font-size: 16px; border-bottom: 1px solid #d0e3ff;">
-                            <strong>Plan:</strong> %s
-                        </td>
-                    </tr>
-                    <tr>
-                        <td style="font-size: 16px; border-bottom: 1px solid #d0e3ff;">
-                            <strong>Price:</strong> %s
-                        </td>
-                    </tr>
-                </table>
-                <p style="font-size: 16px; margin-bottom: 25px;">If you'd like to make changes or cancel your subscription, please visit your <a href="%s" style="color: #4a90e2; text-decoration: none;">account settings</a> before the renewal date.</p>
-                <p style="font-size: 16px; margin-top: 30px;">Need help? <a href="%s" style="color: #4a90e2; text-decoration: none;">Contact our support team</a> anytime.</p>
-                <p style="font-size: 16px; margin-top: 30px;">
-                    Best regards,<br>
-                    <strong>The SubDub Team</strong>
-                </p>
-            </td>
-        </tr>
-        <tr>
-            <td style="background-color: #f0f7ff; padding: 20px; text-align: center; font-size: 14px;">
-                <p style="margin: 0 0 10px;">
-                    SubDub Inc. | 123 Main St, Anytown, AN 12345
-                </p>
-                <p style="margin: 0;">
-                    <a href="#" style="color: #4a90e2; text-decoration: none; margin: 0 10px;">Unsubscribe</a> | 
-                    <a href="#" style="color: #4a90e2; text-decoration: none; margin: 0 10px;">Privacy Policy</a> | 
-                    <a href="#" style="color: #4a90e2; text-decoration: none; margin: 0 10px;">Terms of Service</a>
-                </p>
-            </td>
-        </tr>
-    </table>
-</div>
-`,
-		data.userName,
-		data.subscriptionName,
-		data.renewalDate,
-		data.daysLeft,
-		data.planName,
-		data.price,
-		data.accountURL,
-		data.supportURL,
-	)
+// render executes the body template name with data.
+func (r *templateRegistry) render(name string, data templateData) (string, error) {
+	page, ok := r.templates[name]
+	if !ok {
+		return "", fmt.Errorf("unknown email template %q", name)
+	}
+	var body strings.Builder
+	if err := page.ExecuteTemplate(&body, layoutTemplate, data); err != nil {
+		return "", fmt.Errorf("failed to render email template %s: %w", name, err)
+	}
+	return body.String(), nil
 }
@@ -0,0 +1,184 @@
+package notifications
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anuragthepathak/subscription-management/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTemplates(t *testing.T) {
+	t.Run("every built-in template renders in the layout", func(t *testing.T) {
+		registry, err := loadTemplates("")
+		require.NoError(t, err)
+
+		for _, name := range []string{
+			"reminder", "trial_ending", "dunning", "renewal_confirmation",
+			"unused_subscription", "price_change", "budget_alert", "reminder_digest",
+			"account_closed",
+		} {
+			body, err := registry.render(name, templateData{UserName: "Ada"})
+			require.NoError(t, err, name)
+			assert.Contains(t, body, "The SubDub Team", name)
+			assert.Contains(t, body, "Ada", name)
+		}
+	})
+
+	t.Run("escapes values", func(t *testing.T) {
+		registry, err := loadTemplates("")
+		require.NoError(t, err)
+
+		body, err := registry.render("reminder", templateData{
+			SubscriptionName: `<script>alert("x")</script>`,
+			AccountURL:       `javascript:alert(1)`,
+		})
+
+		require.NoError(t, err)
+		assert.NotContains(t, body, "<script>")
+		assert.Contains(t, body, "&lt;script&gt;")
+		assert.NotContains(t, body, "javascript:")
+	})
+
+	t.Run("translates into the locale of the email", func(t *testing.T) {
+		registry, err := loadTemplates("")
+		require.NoError(t, err)
+
+		body, err := registry.render("reminder", templateData{
+			UserName:         "Ada & Co",
+			SubscriptionName: "Netflix",
+			AccountURL:       "https://example.com/account",
+			DaysLeft:         3,
+			loc:              i18n.Match("de"),
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, body, "Hallo")
+		assert.Contains(t, body, "Ada &amp; Co")
+		assert.Contains(t, body, "Dein Abo <strong>Netflix</strong> verlängert sich")
+		assert.Contains(t, body, `href="https://example.com/account"`)
+		assert.Contains(t, body, ">Kontoeinstellungen</a>")
+	})
+
+	t.Run("words any number of days", func(t *testing.T) {
+		registry, err := loadTemplates("")
+		require.NoError(t, err)
+
+		for days, want := range map[int]string{0: "(today)", 1: "(tomorrow)", 23: "(in 23 days)"} {
+			body, err := registry.render("reminder", templateData{DaysLeft: days})
+			require.NoError(t, err)
+			assert.Contains(t, body, want)
+		}
+	})
+
+	t.Run("digest lists every renewal", func(t *testing.T) {
+		registry, err := loadTemplates("")
+		require.NoError(t, err)
+
+		body, err := registry.render("reminder_digest", templateData{Reminders: []reminderData{
+			{SubscriptionName: "Netflix", RenewalDate: "Mar 14, 2026", DaysLeft: 3, Price: "USD 15.99", PaymentMethod: "Visa •••• 4242"},
+			{SubscriptionName: "Spotify", RenewalDate: "Mar 18, 2026", DaysLeft: 7, Price: "USD 9.99"},
+		}})
+
+		require.NoError(t, err)
+		assert.Contains(t, body, "Netflix")
+		assert.Contains(t, body, "Mar 14, 2026</strong> (in 3 days)")
+		assert.Contains(t, body, "Visa •••• 4242")
+		assert.Contains(t, body, "Spotify")
+		assert.Contains(t, body, "USD 9.99")
+	})
+
+	t.Run("dunning wording follows the attempt", func(t *testing.T) {
+		registry, err := loadTemplates("")
+		require.NoError(t, err)
+
+		declined, err := registry.render("dunning", templateData{Attempt: 0})
+		require.NoError(t, err)
+		expired, err := registry.render("dunning", templateData{Attempt: 3, Final: true})
+		require.NoError(t, err)
+		suspended, err := registry.render("dunning", templateData{Attempt: 3, Final: true, Suspend: true})
+		require.NoError(t, err)
+
+		assert.Contains(t, declined, "Your payment method was declined.")
+		assert.Contains(t, expired, "has <strong>expired</strong>")
+		assert.Contains(t, suspended, "is <strong>suspended</strong>")
+		assert.NotContains(t, suspended, "subscribe again")
+	})
+
+	t.Run("files in the override directory replace built-in ones", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "layout.html"),
+			[]byte(`<main>{{template "content" .}}</main>`), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "reminder.html"),
+			[]byte(`{{define "content"}}Renews {{.RenewalDate}}{{end}}`), 0o600))
+
+		registry, err := loadTemplates(dir)
+		require.NoError(t, err)
+
+		reminder, err := registry.render("reminder", templateData{RenewalDate: "Mar 14, 2026"})
+		require.NoError(t, err)
+		assert.Equal(t, "<main>Renews Mar 14, 2026</main>", reminder)
+
+		// Templates without an override keep their content in the new layout.
+		trial, err := registry.render("trial_ending", templateData{})
+		require.NoError(t, err)
+		assert.Contains(t, trial, "<main>")
+		assert.Contains(t, trial, "first charge")
+	})
+
+	t.Run("override without a content block", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "reminder.html"), []byte(`  `), 0o600))
+
+		_, err := loadTemplates(dir)
+
+		require.ErrorContains(t, err, "reminder.html")
+	})
+
+	t.Run("override that does not parse", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "reminder.html"),
+			[]byte(`{{define "content"}}{{.Missing`), 0o600))
+
+		_, err := loadTemplates(dir)
+
+		require.Error(t, err)
+	})
+
+	t.Run("missing override directory", func(t *testing.T) {
+		_, err := loadTemplates(filepath.Join(t.TempDir(), "missing"))
+
+		require.Error(t, err)
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		registry, err := loadTemplates("")
+		require.NoError(t, err)
+
+		_, err = registry.render("welcome", templateData{})
+
+		require.Error(t, err)
+	})
+}
+
+func TestGetTemplate(t *testing.T) {
+	tests := []struct {
+		days int
+		want string
+	}{
+		{days: 7, want: "📅 Reminder: Your Netflix Subscription Renews in 7 Days!"},
+		{days: 1, want: "⚡ Final Reminder: Netflix Renews Tomorrow!"},
+		{days: 14, want: "📆 Your Netflix Subscription Renews in 14 Days"},
+		{days: 2, want: "🔔 Netflix Subscription Renews in 2 Days!"},
+		{days: 0, want: "⚠️ URGENT: Netflix Subscription Renews Today!"},
+	}
+
+	for _, tt := range tests {
+		template := getTemplate(tt.days)
+
+		assert.Equal(t, "reminder", template.name)
+		assert.Equal(t, tt.want, template.generateSubject(templateData{SubscriptionName: "Netflix", DaysLeft: tt.days}))
+	}
+}
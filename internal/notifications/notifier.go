@@ -0,0 +1,243 @@
+package notifications
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/i18n"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Recipient is a user and the addresses their notifications go to. Only the
+// address of the channel being used needs to be set.
+type Recipient struct {
+	Name             string
+	Email            string
+	Phone            string // E.164.
+	PushSubscription *models.PushSubscription
+	SlackWebhookURL  string
+}
+
+// NewRecipient addresses a user according to their preferences.
+func NewRecipient(user *models.User, prefs *models.NotificationPreferences) Recipient {
+	return Recipient{
+		Name:             user.Name,
+		Email:            user.Email,
+		Phone:            prefs.Phone,
+		PushSubscription: prefs.PushSubscription,
+		SlackWebhookURL:  prefs.SlackWebhookURL,
+	}
+}
+
+// Address returns the address notifications through channel go to, as shown
+// in the user's notification history. Push subscriptions and Slack webhook
+// URLs are credentials, so they have none.
+func (r Recipient) Address(channel models.NotificationChannel) string {
+	switch channel {
+	case models.ChannelEmail:
+		return r.Email
+	case models.ChannelSMS:
+		return r.Phone
+	default:
+		return ""
+	}
+}
+
+// Reminder is an upcoming renewal listed in a reminder digest.
+type Reminder struct {
+	Subscription  *models.Subscription
+	PaymentMethod *models.PaymentMethod // May be nil.
+	DaysBefore    int
+}
+
+// Notifier delivers reminders to users through one channel.
+type Notifier interface {
+	Channel() models.NotificationChannel
+	// NotifyReminder announces an upcoming renewal. paymentMethod is the one
+	// the subscription is paid with and may be nil.
+	NotifyReminder(
+		ctx context.Context,
+		to Recipient,
+		subscription *models.Subscription,
+		paymentMethod *models.PaymentMethod,
+		daysBefore int,
+	) error
+	// NotifyTrialEnding warns that a free trial ends and the first charge is
+	// made in daysBefore days.
+	NotifyTrialEnding(
+		ctx context.Context,
+		to Recipient,
+		subscription *models.Subscription,
+		daysBefore int,
+	) error
+	// NotifyReminderDigest announces several upcoming renewals at once.
+	NotifyReminderDigest(ctx context.Context, to Recipient, reminders []Reminder) error
+}
+
+// ChannelsConfig holds the settings of the channels besides email. A channel
+// without its credentials is unavailable.
+type ChannelsConfig struct {
+	Timeout time.Duration `mapstructure:"timeout"` // Per-request timeout for SMS, push, and Slack.
+	SMS     SMSConfig     `mapstructure:"sms"`
+	Push    PushConfig    `mapstructure:"push"`
+	Slack   SlackConfig   `mapstructure:"slack"`
+	Alerts  AlertConfig   `mapstructure:"alerts"` // Operator alerts, not a user channel.
+}
+
+// NewNotifiers returns a Notifier for email and for every other channel
+// enabled in config.
+func NewNotifiers(config ChannelsConfig, emailSender EmailSender) ([]Notifier, error) {
+	client := &http.Client{
+		Timeout:   config.Timeout,
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	}
+
+	notifiers := []Notifier{&emailNotifier{emailSender}}
+	if config.SMS.Provider != "" {
+		sms, err := newSMSNotifier(config.SMS, client)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, sms)
+	}
+	if config.Push.VAPIDPrivateKey != "" {
+		push, err := newPushNotifier(config.Push, client)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, push)
+	}
+	if config.Slack.Enabled {
+		notifiers = append(notifiers, &slackNotifier{client})
+	}
+	return notifiers, nil
+}
+
+// ConfiguredChannels lists the channels NewNotifiers returns a Notifier for,
+// in the same order.
+func ConfiguredChannels(config ChannelsConfig) []models.NotificationChannel {
+	channels := []models.NotificationChannel{models.ChannelEmail}
+	if config.SMS.Provider != "" {
+		channels = append(channels, models.ChannelSMS)
+	}
+	if config.Push.VAPIDPrivateKey != "" {
+		channels = append(channels, models.ChannelPush)
+	}
+	if config.Slack.Enabled {
+		channels = append(channels, models.ChannelSlack)
+	}
+	return channels
+}
+
+// emailNotifier sends reminders with the email templates.
+type emailNotifier struct {
+	sender EmailSender
+}
+
+func (n *emailNotifier) Channel() models.NotificationChannel {
+	return models.ChannelEmail
+}
+
+func (n *emailNotifier) NotifyReminder(
+	ctx context.Context,
+	to Recipient,
+	subscription *models.Subscription,
+	paymentMethod *models.PaymentMethod,
+	daysBefore int,
+) error {
+	return n.sender.SendReminderEmail(ctx, to.Email, to.Name, subscription, paymentMethod, daysBefore)
+}
+
+func (n *emailNotifier) NotifyTrialEnding(
+	ctx context.Context,
+	to Recipient,
+	subscription *models.Subscription,
+	daysBefore int,
+) error {
+	return n.sender.SendTrialEndingEmail(ctx, to.Email, to.Name, subscription, daysBefore)
+}
+
+func (n *emailNotifier) NotifyReminderDigest(ctx context.Context, to Recipient, reminders []Reminder) error {
+	return n.sender.SendReminderDigestEmail(ctx, to.Email, to.Name, reminders)
+}
+
+// message is the short, plain text form of a notification used by every
+// channel but email.
+type message struct {
+	title string
+	body  string
+}
+
+// reminderMessage words a renewal reminder in the language carried by ctx.
+func reminderMessage(
+	ctx context.Context,
+	subscription *models.Subscription,
+	paymentMethod *models.PaymentMethod,
+	daysBefore int,
+) message {
+	loc := i18n.FromContext(ctx)
+	body := loc.Sprintf("%s renews %s on %s for %s (%s).",
+		subscription.Name,
+		inDays(loc, daysBefore),
+		loc.Date(renewalDate(ctx, subscription)),
+		models.FormatMoney(subscription.Price, subscription.Currency),
+		loc.Sprintf(string(subscription.Frequency)),
+	)
+	if method := describePaymentMethod(loc, paymentMethod); method != "" {
+		body += " " + loc.Sprintf("Payment method: %s.", method)
+	}
+	return message{
+		title: loc.Sprintf("Upcoming renewal: %s", subscription.Name),
+		body:  body,
+	}
+}
+
+// trialEndingMessage words a reminder that a free trial ends in the language
+// carried by ctx.
+func trialEndingMessage(ctx context.Context, subscription *models.Subscription, daysBefore int) message {
+	loc := i18n.FromContext(ctx)
+	return message{
+		title: loc.Sprintf("Free trial ending: %s", subscription.Name),
+		body: loc.Sprintf("Your free trial of %s ends %s on %s. You will be charged %s (%s) then unless you cancel.",
+			subscription.Name,
+			inDays(loc, daysBefore),
+			loc.Date(renewalDate(ctx, subscription)),
+			models.FormatMoney(subscription.Price, subscription.Currency),
+			loc.Sprintf(string(subscription.Frequency)),
+		),
+	}
+}
+
+// reminderDigestMessage words a digest of renewal reminders in the language
+// carried by ctx, one sentence per renewal.
+func reminderDigestMessage(ctx context.Context, reminders []Reminder) message {
+	loc := i18n.FromContext(ctx)
+	lines := make([]string, len(reminders))
+	for i, reminder := range reminders {
+		lines[i] = loc.Sprintf("%s renews %s on %s for %s (%s).",
+			reminder.Subscription.Name,
+			inDays(loc, reminder.DaysBefore),
+			loc.Date(renewalDate(ctx, reminder.Subscription)),
+			models.FormatMoney(reminder.Subscription.Price, reminder.Subscription.Currency),
+			loc.Sprintf(string(reminder.Subscription.Frequency)),
+		)
+	}
+	return message{
+		title: loc.Sprintf("Upcoming renewals: %d subscriptions", len(reminders)),
+		body:  strings.Join(lines, "\n"),
+	}
+}
+
+// inDays words how far away something is, e.g. "in 3 days".
+func inDays(loc *i18n.Localizer, days int) string {
+	switch days {
+	case 0:
+		return loc.Sprintf("today")
+	case 1:
+		return loc.Sprintf("tomorrow")
+	}
+	return loc.Sprintf("in %d days", days)
+}
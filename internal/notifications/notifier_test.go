@@ -0,0 +1,370 @@
+package notifications_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSubscription() *models.Subscription {
+	return &models.Subscription{
+		Name:      "Netflix",
+		Price:     1599,
+		Currency:  models.USD,
+		Frequency: models.Monthly,
+		ValidTill: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// notifierFor returns the notifier of channel built from config.
+func notifierFor(t *testing.T, config notifications.ChannelsConfig, channel models.NotificationChannel) notifications.Notifier {
+	t.Helper()
+	config.Timeout = time.Second
+	notifiers, err := notifications.NewNotifiers(config, nil)
+	require.NoError(t, err)
+	for _, n := range notifiers {
+		if n.Channel() == channel {
+			return n
+		}
+	}
+	t.Fatalf("no %s notifier", channel)
+	return nil
+}
+
+// channelsOf lists the channels of notifiers.
+func channelsOf(notifiers []notifications.Notifier) []models.NotificationChannel {
+	channels := make([]models.NotificationChannel, len(notifiers))
+	for i, n := range notifiers {
+		channels[i] = n.Channel()
+	}
+	return channels
+}
+
+func TestNewNotifiers(t *testing.T) {
+	t.Run("email only by default", func(t *testing.T) {
+		notifiers, err := notifications.NewNotifiers(notifications.ChannelsConfig{}, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.NotificationChannel{models.ChannelEmail}, channelsOf(notifiers))
+		assert.Equal(t, channelsOf(notifiers), notifications.ConfiguredChannels(notifications.ChannelsConfig{}))
+	})
+
+	t.Run("every configured channel", func(t *testing.T) {
+		pub, priv := vapidKeys(t)
+		config := notifications.ChannelsConfig{
+			SMS:   notifications.SMSConfig{Provider: notifications.SMSProviderTwilio},
+			Push:  notifications.PushConfig{VAPIDPublicKey: pub, VAPIDPrivateKey: priv},
+			Slack: notifications.SlackConfig{Enabled: true},
+		}
+		notifiers, err := notifications.NewNotifiers(config, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.NotificationChannel{
+			models.ChannelEmail, models.ChannelSMS, models.ChannelPush, models.ChannelSlack,
+		}, channelsOf(notifiers))
+		assert.Equal(t, channelsOf(notifiers), notifications.ConfiguredChannels(config))
+	})
+
+	t.Run("unknown SMS provider", func(t *testing.T) {
+		_, err := notifications.NewNotifiers(notifications.ChannelsConfig{
+			SMS: notifications.SMSConfig{Provider: "carrier-pigeon"},
+		}, nil)
+
+		require.Error(t, err)
+	})
+
+	t.Run("mismatched VAPID keys", func(t *testing.T) {
+		pub, _ := vapidKeys(t)
+		_, priv := vapidKeys(t)
+		_, err := notifications.NewNotifiers(notifications.ChannelsConfig{
+			Push: notifications.PushConfig{VAPIDPublicKey: pub, VAPIDPrivateKey: priv},
+		}, nil)
+
+		require.Error(t, err)
+	})
+}
+
+func TestRecipient_Address(t *testing.T) {
+	to := notifications.Recipient{
+		Name:             "Alice",
+		Email:            "alice@example.com",
+		Phone:            "+15551234567",
+		PushSubscription: &models.PushSubscription{Endpoint: "https://push.example.com/alice"},
+		SlackWebhookURL:  "https://hooks.slack.com/services/T/B/secret",
+	}
+
+	assert.Equal(t, "alice@example.com", to.Address(models.ChannelEmail))
+	assert.Equal(t, "+15551234567", to.Address(models.ChannelSMS))
+	assert.Empty(t, to.Address(models.ChannelPush))
+	assert.Empty(t, to.Address(models.ChannelSlack))
+}
+
+func TestTwilioNotifier(t *testing.T) {
+	t.Run("posts the message with basic auth", func(t *testing.T) {
+		var gotPath, gotUser, gotPassword string
+		var gotForm url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			gotUser, gotPassword, _ = r.BasicAuth()
+			require.NoError(t, r.ParseForm())
+			gotForm = r.PostForm
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		notifier := notifierFor(t, notifications.ChannelsConfig{SMS: notifications.SMSConfig{
+			Provider:   notifications.SMSProviderTwilio,
+			AccountSID: "AC123",
+			AuthToken:  "token",
+			FromNumber: "+15550001111",
+			BaseURL:    server.URL + "/",
+		}}, models.ChannelSMS)
+		err := notifier.NotifyReminder(t.Context(), notifications.Recipient{Phone: "+15552223333"}, testSubscription(), nil, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", gotPath)
+		assert.Equal(t, "AC123", gotUser)
+		assert.Equal(t, "token", gotPassword)
+		assert.Equal(t, "+15552223333", gotForm.Get("To"))
+		assert.Equal(t, "+15550001111", gotForm.Get("From"))
+		assert.Contains(t, gotForm.Get("Body"), "Netflix renews in 3 days")
+	})
+
+	t.Run("reports the provider error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":21211,"message":"Invalid 'To' Phone Number"}`))
+		}))
+		defer server.Close()
+
+		notifier := notifierFor(t, notifications.ChannelsConfig{SMS: notifications.SMSConfig{
+			Provider: notifications.SMSProviderTwilio,
+			BaseURL:  server.URL,
+		}}, models.ChannelSMS)
+		err := notifier.NotifyTrialEnding(t.Context(), notifications.Recipient{Phone: "+15552223333"}, testSubscription(), 1)
+
+		require.ErrorContains(t, err, "Invalid 'To' Phone Number")
+	})
+
+	t.Run("recipient without a phone number", func(t *testing.T) {
+		notifier := notifierFor(t, notifications.ChannelsConfig{SMS: notifications.SMSConfig{
+			Provider: notifications.SMSProviderTwilio,
+		}}, models.ChannelSMS)
+		err := notifier.NotifyReminder(t.Context(), notifications.Recipient{}, testSubscription(), nil, 3)
+
+		require.Error(t, err)
+	})
+}
+
+func TestSlackNotifier(t *testing.T) {
+	t.Run("posts to the webhook of the user", func(t *testing.T) {
+		var gotBody map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		notifier := notifierFor(t, notifications.ChannelsConfig{Slack: notifications.SlackConfig{Enabled: true}}, models.ChannelSlack)
+		err := notifier.NotifyTrialEnding(t.Context(), notifications.Recipient{SlackWebhookURL: server.URL}, testSubscription(), 1)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(gotBody["text"], "*Free trial ending: Netflix*\n"))
+		assert.Contains(t, gotBody["text"], "ends tomorrow")
+	})
+
+	t.Run("digest lists each renewal on a line", func(t *testing.T) {
+		var gotBody map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		spotify := testSubscription()
+		spotify.Name = "Spotify"
+		spotify.Price = 999
+		notifier := notifierFor(t, notifications.ChannelsConfig{Slack: notifications.SlackConfig{Enabled: true}}, models.ChannelSlack)
+		err := notifier.NotifyReminderDigest(t.Context(), notifications.Recipient{SlackWebhookURL: server.URL}, []notifications.Reminder{
+			{Subscription: testSubscription(), DaysBefore: 3},
+			{Subscription: spotify, DaysBefore: 7},
+		})
+
+		require.NoError(t, err)
+		lines := strings.Split(gotBody["text"], "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "*Upcoming renewals: 2 subscriptions*", lines[0])
+		assert.Contains(t, lines[1], "Netflix")
+		assert.Contains(t, lines[2], "Spotify")
+	})
+
+	t.Run("dates the renewal in the timezone of the user", func(t *testing.T) {
+		losAngeles, err := time.LoadLocation("America/Los_Angeles")
+		if err != nil {
+			t.Skip("tzdata unavailable")
+		}
+		var gotBody map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		ctx := appctx.WithLocation(t.Context(), losAngeles)
+		notifier := notifierFor(t, notifications.ChannelsConfig{Slack: notifications.SlackConfig{Enabled: true}}, models.ChannelSlack)
+		err = notifier.NotifyReminder(ctx, notifications.Recipient{SlackWebhookURL: server.URL}, testSubscription(), nil, 1)
+
+		require.NoError(t, err)
+		// Midnight UTC on March 14 is still March 13 in Los Angeles.
+		assert.Contains(t, gotBody["text"], "on March 13, 2026")
+	})
+
+	t.Run("a revoked webhook is an error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("no_service"))
+		}))
+		defer server.Close()
+
+		notifier := notifierFor(t, notifications.ChannelsConfig{Slack: notifications.SlackConfig{Enabled: true}}, models.ChannelSlack)
+		err := notifier.NotifyReminder(t.Context(), notifications.Recipient{SlackWebhookURL: server.URL}, testSubscription(), nil, 3)
+
+		require.ErrorContains(t, err, "no_service")
+	})
+}
+
+func TestPushNotifier(t *testing.T) {
+	browserKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, err = rand.Read(authSecret)
+	require.NoError(t, err)
+
+	pub, priv := vapidKeys(t)
+	config := notifications.ChannelsConfig{Push: notifications.PushConfig{
+		VAPIDPublicKey:  pub,
+		VAPIDPrivateKey: priv,
+		Subject:         "mailto:ops@example.com",
+		TTL:             time.Hour,
+	}}
+
+	t.Run("sends an encrypted, signed message", func(t *testing.T) {
+		var gotHeader http.Header
+		var gotBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotHeader = r.Header
+			gotBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		notifier := notifierFor(t, config, models.ChannelPush)
+		to := notifications.Recipient{PushSubscription: &models.PushSubscription{
+			Endpoint: server.URL + "/push/abc",
+			Keys: models.PushKeys{
+				P256DH: base64.RawURLEncoding.EncodeToString(browserKey.PublicKey().Bytes()),
+				Auth:   base64.RawURLEncoding.EncodeToString(authSecret),
+			},
+		}}
+		err := notifier.NotifyReminder(t.Context(), to, testSubscription(), nil, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, "aes128gcm", gotHeader.Get("Content-Encoding"))
+		assert.Equal(t, "3600", gotHeader.Get("TTL"))
+		assert.True(t, strings.HasPrefix(gotHeader.Get("Authorization"), "vapid t="))
+		assert.True(t, strings.HasSuffix(gotHeader.Get("Authorization"), ", k="+pub))
+
+		var got map[string]string
+		require.NoError(t, json.Unmarshal(decryptPush(t, browserKey, authSecret, gotBody), &got))
+		assert.Equal(t, "Upcoming renewal: Netflix", got["title"])
+		assert.Contains(t, got["body"], "Netflix renews in 3 days")
+	})
+
+	t.Run("an expired subscription is an error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer server.Close()
+
+		notifier := notifierFor(t, config, models.ChannelPush)
+		to := notifications.Recipient{PushSubscription: &models.PushSubscription{
+			Endpoint: server.URL,
+			Keys: models.PushKeys{
+				P256DH: base64.RawURLEncoding.EncodeToString(browserKey.PublicKey().Bytes()),
+				Auth:   base64.RawURLEncoding.EncodeToString(authSecret),
+			},
+		}}
+		err := notifier.NotifyTrialEnding(t.Context(), to, testSubscription(), 1)
+
+		require.ErrorContains(t, err, "expired")
+	})
+}
+
+// vapidKeys generates a VAPID key pair, encoded as in the config.
+func vapidKeys(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pub, err := key.PublicKey.Bytes()
+	require.NoError(t, err)
+	priv, err := key.Bytes()
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(pub), base64.RawURLEncoding.EncodeToString(priv)
+}
+
+// decryptPush decrypts an aes128gcm push message the way a browser does.
+func decryptPush(t *testing.T, browserKey *ecdh.PrivateKey, authSecret, body []byte) []byte {
+	t.Helper()
+	require.Greater(t, len(body), 21)
+	salt := body[:16]
+	assert.Equal(t, uint32(4096), binary.BigEndian.Uint32(body[16:20]))
+	idLen := int(body[20])
+	require.Equal(t, 65, idLen)
+	serverPublic := body[21 : 21+idLen]
+	ciphertext := body[21+idLen:]
+
+	serverKey, err := ecdh.P256().NewPublicKey(serverPublic)
+	require.NoError(t, err)
+	sharedSecret, err := browserKey.ECDH(serverKey)
+	require.NoError(t, err)
+
+	keyInfo := "WebPush: info\x00" + string(browserKey.PublicKey().Bytes()) + string(serverPublic)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	require.NoError(t, err)
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	require.NoError(t, err)
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	require.NoError(t, err)
+
+	// Strip the padding delimiter of the last record.
+	require.Equal(t, byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
@@ -0,0 +1,239 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// PushConfig holds the Web Push settings. Browsers subscribe with the public
+// VAPID key, and push services only accept messages signed with the matching
+// private key.
+type PushConfig struct {
+	VAPIDPublicKey  string        `mapstructure:"vapid_public_key"`  // Base64url uncompressed P-256 point.
+	VAPIDPrivateKey string        `mapstructure:"vapid_private_key"` // Base64url P-256 scalar; empty disables push.
+	Subject         string        `mapstructure:"subject"`           // mailto: or https: contact for push services.
+	TTL             time.Duration `mapstructure:"ttl"`               // How long push services hold an undelivered message.
+}
+
+// pushRecordSize is the record size announced in the encrypted payload. The
+// payload is always a single record.
+const pushRecordSize = 4096
+
+type pushNotifier struct {
+	key       *ecdsa.PrivateKey
+	publicKey string // Base64url, as sent in the Authorization header.
+	subject   string
+	ttl       time.Duration
+	client    *http.Client
+}
+
+func newPushNotifier(config PushConfig, client *http.Client) (Notifier, error) {
+	key, err := ParseVAPIDKeys(config.VAPIDPublicKey, config.VAPIDPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &pushNotifier{
+		key:       key,
+		publicKey: strings.TrimRight(config.VAPIDPublicKey, "="),
+		subject:   config.Subject,
+		ttl:       config.TTL,
+		client:    client,
+	}, nil
+}
+
+// ParseVAPIDKeys parses a VAPID key pair and checks that the keys match.
+func ParseVAPIDKeys(publicKey, privateKey string) (*ecdsa.PrivateKey, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(privateKey, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	derived, err := key.PublicKey.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	if base64.RawURLEncoding.EncodeToString(derived) != strings.TrimRight(publicKey, "=") {
+		return nil, fmt.Errorf("VAPID public key does not match the private key")
+	}
+	return key, nil
+}
+
+func (n *pushNotifier) Channel() models.NotificationChannel {
+	return models.ChannelPush
+}
+
+func (n *pushNotifier) NotifyReminder(
+	ctx context.Context,
+	to Recipient,
+	subscription *models.Subscription,
+	paymentMethod *models.PaymentMethod,
+	daysBefore int,
+) error {
+	return n.send(ctx, to.PushSubscription, reminderMessage(ctx, subscription, paymentMethod, daysBefore))
+}
+
+func (n *pushNotifier) NotifyTrialEnding(
+	ctx context.Context,
+	to Recipient,
+	subscription *models.Subscription,
+	daysBefore int,
+) error {
+	return n.send(ctx, to.PushSubscription, trialEndingMessage(ctx, subscription, daysBefore))
+}
+
+func (n *pushNotifier) NotifyReminderDigest(ctx context.Context, to Recipient, reminders []Reminder) error {
+	return n.send(ctx, to.PushSubscription, reminderDigestMessage(ctx, reminders))
+}
+
+// send encrypts the message for the browser and posts it to the push
+// service of its subscription. The service worker receives
+// {"title": ..., "body": ...}.
+func (n *pushNotifier) send(ctx context.Context, sub *models.PushSubscription, msg message) error {
+	if sub == nil {
+		return fmt.Errorf("no push subscription to send to")
+	}
+
+	payload, err := json.Marshal(map[string]string{"title": msg.title, "body": msg.body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal push message: %w", err)
+	}
+	body, err := encryptPushPayload(sub, payload)
+	if err != nil {
+		return err
+	}
+	authorization, err := n.vapidAuthorization(sub.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build push request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(n.ttl.Seconds())))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach push service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return fmt.Errorf("push subscription expired (%s); the user must subscribe again", resp.Status)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("push service returned %s", resp.Status)
+	}
+	return nil
+}
+
+// vapidAuthorization returns the Authorization header proving to the push
+// service of endpoint that the message comes from this server (RFC 8292).
+func (n *pushNotifier) vapidAuthorization(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid push endpoint: %w", err)
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": n.subject,
+	}).SignedString(n.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+	return fmt.Sprintf("vapid t=%s, k=%s", token, n.publicKey), nil
+}
+
+// encryptPushPayload encrypts payload for the browser of sub with the
+// aes128gcm content encoding (RFC 8188, RFC 8291).
+func encryptPushPayload(sub *models.PushSubscription, payload []byte) ([]byte, error) {
+	uaPublic, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(sub.Keys.P256DH, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid push subscription key: %w", err)
+	}
+	authSecret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(sub.Keys.Auth, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid push subscription secret: %w", err)
+	}
+	uaKey, err := ecdh.P256().NewPublicKey(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid push subscription key: %w", err)
+	}
+
+	// A fresh key pair and salt per message.
+	asKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate push key: %w", err)
+	}
+	asPublic := asKey.PublicKey().Bytes()
+	salt := make([]byte, 16)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate push salt: %w", err)
+	}
+
+	sharedSecret, err := asKey.ECDH(uaKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push secret: %w", err)
+	}
+	keyInfo := "WebPush: info\x00" + string(uaPublic) + string(asPublic)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push key: %w", err)
+	}
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push key: %w", err)
+	}
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push nonce: %w", err)
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push cipher: %w", err)
+	}
+
+	// Header: salt, record size, and the sender's public key as key ID.
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, pushRecordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	// 0x02 marks the last (and only) record.
+	plaintext := append(payload, 0x02)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
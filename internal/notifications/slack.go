@@ -0,0 +1,83 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// SlackConfig holds the Slack settings. Users supply their own incoming
+// webhook URL, so there are no credentials.
+type SlackConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// slackNotifier posts to the incoming webhook of each user.
+type slackNotifier struct {
+	client *http.Client
+}
+
+func (n *slackNotifier) Channel() models.NotificationChannel {
+	return models.ChannelSlack
+}
+
+func (n *slackNotifier) NotifyReminder(
+	ctx context.Context,
+	to Recipient,
+	subscription *models.Subscription,
+	paymentMethod *models.PaymentMethod,
+	daysBefore int,
+) error {
+	return n.send(ctx, to.SlackWebhookURL, reminderMessage(ctx, subscription, paymentMethod, daysBefore))
+}
+
+func (n *slackNotifier) NotifyTrialEnding(
+	ctx context.Context,
+	to Recipient,
+	subscription *models.Subscription,
+	daysBefore int,
+) error {
+	return n.send(ctx, to.SlackWebhookURL, trialEndingMessage(ctx, subscription, daysBefore))
+}
+
+func (n *slackNotifier) NotifyReminderDigest(ctx context.Context, to Recipient, reminders []Reminder) error {
+	return n.send(ctx, to.SlackWebhookURL, reminderDigestMessage(ctx, reminders))
+}
+
+func (n *slackNotifier) send(ctx context.Context, webhookURL string, msg message) error {
+	if webhookURL == "" {
+		return fmt.Errorf("no Slack webhook URL to post to")
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", msg.title, msg.body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach Slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Slack explains errors in a short plain text body, e.g.
+		// "no_service" for a revoked webhook.
+		reason, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("Slack returned %s: %s", resp.Status, bytes.TrimSpace(reason))
+	}
+	return nil
+}
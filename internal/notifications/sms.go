@@ -0,0 +1,113 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// SMSProviderTwilio sends SMS through the Twilio Messages API.
+const SMSProviderTwilio = "twilio"
+
+// SMSConfig holds the SMS provider settings.
+type SMSConfig struct {
+	Provider   string `mapstructure:"provider"`    // SMS provider; empty disables SMS.
+	AccountSID string `mapstructure:"account_sid"` // Twilio account SID.
+	AuthToken  string `mapstructure:"auth_token"`  // Twilio auth token.
+	FromNumber string `mapstructure:"from_number"` // E.164 number messages are sent from.
+	BaseURL    string `mapstructure:"base_url"`    // Provider API endpoint.
+}
+
+type twilioNotifier struct {
+	baseURL    string
+	accountSID string
+	authToken  string
+	from       string
+	client     *http.Client
+}
+
+func newSMSNotifier(config SMSConfig, client *http.Client) (Notifier, error) {
+	switch config.Provider {
+	case SMSProviderTwilio:
+		return &twilioNotifier{
+			baseURL:    strings.TrimSuffix(config.BaseURL, "/"),
+			accountSID: config.AccountSID,
+			authToken:  config.AuthToken,
+			from:       config.FromNumber,
+			client:     client,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown SMS provider %q", config.Provider)
+	}
+}
+
+func (n *twilioNotifier) Channel() models.NotificationChannel {
+	return models.ChannelSMS
+}
+
+func (n *twilioNotifier) NotifyReminder(
+	ctx context.Context,
+	to Recipient,
+	subscription *models.Subscription,
+	paymentMethod *models.PaymentMethod,
+	daysBefore int,
+) error {
+	return n.send(ctx, to.Phone, reminderMessage(ctx, subscription, paymentMethod, daysBefore))
+}
+
+func (n *twilioNotifier) NotifyTrialEnding(
+	ctx context.Context,
+	to Recipient,
+	subscription *models.Subscription,
+	daysBefore int,
+) error {
+	return n.send(ctx, to.Phone, trialEndingMessage(ctx, subscription, daysBefore))
+}
+
+func (n *twilioNotifier) NotifyReminderDigest(ctx context.Context, to Recipient, reminders []Reminder) error {
+	return n.send(ctx, to.Phone, reminderDigestMessage(ctx, reminders))
+}
+
+// send creates a message with POST
+// {baseURL}/2010-04-01/Accounts/{sid}/Messages.json.
+func (n *twilioNotifier) send(ctx context.Context, phone string, msg message) error {
+	if phone == "" {
+		return fmt.Errorf("no phone number to send SMS to")
+	}
+
+	form := url.Values{}
+	form.Set("To", phone)
+	form.Set("From", n.from)
+	form.Set("Body", msg.body)
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", n.baseURL, url.PathEscape(n.accountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build SMS request: %w", err)
+	}
+	req.SetBasicAuth(n.accountSID, n.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach SMS provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var body struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err == nil && body.Message != "" {
+			return fmt.Errorf("SMS provider returned %s: %s (code %d)", resp.Status, body.Message, body.Code)
+		}
+		return fmt.Errorf("SMS provider returned %s", resp.Status)
+	}
+	return nil
+}
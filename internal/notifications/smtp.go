@@ -0,0 +1,396 @@
+package notifications
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/gomail.v2"
+)
+
+// ErrSMTPUnavailable is returned without contacting the SMTP server while
+// the circuit breaker is open after repeated failures.
+var ErrSMTPUnavailable = errors.New("smtp server unavailable: circuit breaker open")
+
+// ErrRecipientSuppressed is returned without contacting the SMTP server when
+// every recipient of an email is on the suppression list.
+var ErrRecipientSuppressed = errors.New("email recipient is suppressed")
+
+// errInvalidMessage marks messages that can't be sent, such as one without
+// a valid sender address.
+var errInvalidMessage = errors.New("invalid email")
+
+// smtpConn is an authenticated connection to the SMTP server that sends one
+// message after another.
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	creds    int // Credential generation the connection authenticated with.
+	lastUsed time.Time
+}
+
+// send hands a message to the server. The connection's deadline bounds
+// every command, and cancelling ctx interrupts them.
+func (c *smtpConn) send(ctx context.Context, deadline time.Time, message *gomail.Message) error {
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { _ = c.conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	// gomail.Send flattens errors, so the server's reply is kept aside.
+	var smtpErr error
+	err := gomail.Send(gomail.SendFunc(func(from string, to []string, msg io.WriterTo) error {
+		smtpErr = c.transact(from, to, msg)
+		return smtpErr
+	}), message)
+	switch {
+	case smtpErr != nil:
+		return smtpErr
+	case err != nil:
+		return fmt.Errorf("%w: %w", errInvalidMessage, err)
+	}
+	return nil
+}
+
+// transact runs one mail transaction.
+func (c *smtpConn) transact(from string, to []string, msg io.WriterTo) error {
+	if err := c.client.Mail(from); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err := c.client.Rcpt(addr); err != nil {
+			return &recipientError{addr, err}
+		}
+	}
+	w, err := c.client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = msg.WriteTo(w); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// alive reports whether the server still answers on the connection. Servers
+// drop idle connections on their own.
+func (c *smtpConn) alive(deadline time.Time) bool {
+	return c.conn.SetDeadline(deadline) == nil && c.client.Noop() == nil
+}
+
+// close ends the session politely if the server is still listening and
+// drops the connection either way.
+func (c *smtpConn) close() {
+	_ = c.conn.SetDeadline(time.Now().Add(time.Second))
+	_ = c.client.Quit()
+	_ = c.conn.Close()
+}
+
+// smtpPool dials connections to the SMTP server and keeps up to maxIdle of
+// them for later messages. Connections idle for longer than idleTimeout are
+// closed rather than reused.
+type smtpPool struct {
+	host        string
+	port        int
+	maxIdle     int
+	idleTimeout time.Duration
+
+	mu       sync.Mutex // Guards the fields below.
+	username string
+	password string
+	creds    int // Bumped by setCredentials, retiring older connections.
+	idle     []*smtpConn
+}
+
+func newSMTPPool(config EmailConfig) *smtpPool {
+	return &smtpPool{
+		host:        config.SMTPHost,
+		port:        config.SMTPPort,
+		maxIdle:     config.MaxIdleConns,
+		idleTimeout: config.IdleTimeout,
+		username:    config.SMTPUsername,
+		password:    config.SMTPPassword,
+	}
+}
+
+// setCredentials uses the new credentials for connections dialed from now
+// on and closes the idle ones.
+func (p *smtpPool) setCredentials(username, password string) {
+	p.mu.Lock()
+	p.username, p.password = username, password
+	p.creds++
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	for _, c := range idle {
+		c.close()
+	}
+}
+
+// get returns an idle connection that still answers, or dials a new one by
+// deadline.
+func (p *smtpPool) get(ctx context.Context, deadline time.Time) (*smtpConn, error) {
+	for {
+		p.mu.Lock()
+		if len(p.idle) == 0 {
+			username, password, creds := p.username, p.password, p.creds
+			p.mu.Unlock()
+			return p.dial(ctx, deadline, username, password, creds)
+		}
+		c := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		p.mu.Unlock()
+
+		if time.Since(c.lastUsed) < p.idleTimeout && c.alive(deadline) {
+			return c, nil
+		}
+		c.close()
+	}
+}
+
+// put returns a connection that just sent a message successfully. It is
+// closed if the pool is full or its credentials were replaced meanwhile.
+func (p *smtpPool) put(c *smtpConn) {
+	c.lastUsed = time.Now()
+
+	p.mu.Lock()
+	if c.creds == p.creds && len(p.idle) < p.maxIdle {
+		p.idle = append(p.idle, c)
+		c = nil
+	}
+	p.mu.Unlock()
+
+	if c != nil {
+		c.close()
+	}
+}
+
+// close closes every idle connection.
+func (p *smtpPool) close() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	for _, c := range idle {
+		c.close()
+	}
+}
+
+// dial connects and authenticates like gomail's dialer: implicit TLS on port
+// 465, STARTTLS elsewhere when offered, and the strongest offered
+// authentication mechanism when there is a username.
+func (p *smtpPool) dial(
+	ctx context.Context,
+	deadline time.Time,
+	username, password string,
+	creds int,
+) (*smtpConn, error) {
+	dialer := &net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(p.host, strconv.Itoa(p.port)))
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{ServerName: p.host}
+	if p.port == 465 {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, p.host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	c := &smtpConn{conn: conn, client: client, creds: creds}
+	if err = c.handshake(p.port != 465, tlsConfig, p.host, username, password); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *smtpConn) handshake(startTLS bool, tlsConfig *tls.Config, host, username, password string) error {
+	if ok, _ := c.client.Extension("STARTTLS"); ok && startTLS {
+		if err := c.client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	if username == "" {
+		return nil
+	}
+	ok, mechanisms := c.client.Extension("AUTH")
+	if !ok {
+		return nil
+	}
+	var auth smtp.Auth
+	switch {
+	case strings.Contains(mechanisms, "CRAM-MD5"):
+		auth = smtp.CRAMMD5Auth(username, password)
+	case strings.Contains(mechanisms, "LOGIN") && !strings.Contains(mechanisms, "PLAIN"):
+		auth = &loginAuth{username, password, host}
+	default:
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return c.client.Auth(auth)
+}
+
+// loginAuth implements the LOGIN mechanism for servers that offer neither
+// PLAIN nor CRAM-MD5. Like PlainAuth, it refuses to send credentials over an
+// unencrypted connection except to localhost.
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch string(fromServer) {
+	case "Username:":
+		return []byte(a.username), nil
+	case "Password:":
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected server challenge: %s", fromServer)
+	}
+}
+
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
+
+// recipientError is the refusal of one recipient of a message.
+type recipientError struct {
+	addr string
+	err  error
+}
+
+func (e *recipientError) Error() string {
+	return fmt.Sprintf("recipient %s refused: %v", e.addr, e.err)
+}
+
+func (e *recipientError) Unwrap() error {
+	return e.err
+}
+
+// hardBounce reports whether the server said the mailbox can never receive
+// mail: 550 (no such mailbox), 551 (user not local), or 553 (mailbox name not
+// allowed). Other replies, such as a full mailbox, may pass.
+func (e *recipientError) hardBounce() bool {
+	protoErr, ok := errors.AsType[*textproto.Error](e.err)
+	return ok && (protoErr.Code == 550 || protoErr.Code == 551 || protoErr.Code == 553)
+}
+
+// permanentSMTPError reports whether err would recur on every attempt: a
+// 5xx reply, such as for an unknown recipient, or an invalid message.
+func permanentSMTPError(err error) bool {
+	if errors.Is(err, errInvalidMessage) {
+		return true
+	}
+	var protoErr *textproto.Error
+	return errors.As(err, &protoErr) && protoErr.Code >= 500
+}
+
+// circuitBreaker stops sending after threshold consecutive failures. Once
+// cooldown has passed, one attempt is let through; it closes the breaker if
+// it succeeds and opens it again if not. A zero threshold disables it.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex // Guards the fields below.
+	failures  int
+	openUntil time.Time
+	probing   bool // Whether the attempt after the cooldown is under way.
+}
+
+// allow reports whether an attempt may be made now.
+func (b *circuitBreaker) allow(now time.Time) bool {
+	if b.threshold <= 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || now.Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record counts the outcome of an attempt and reports whether it opened the
+// breaker.
+func (b *circuitBreaker) record(now time.Time, err error) (opened bool) {
+	if b.threshold <= 0 {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if err == nil {
+		b.failures = 0
+		return false
+	}
+	b.failures++
+	if b.failures < b.threshold {
+		return false
+	}
+	b.openUntil = now.Add(b.cooldown)
+	return true
+}
+
+// abort ends an attempt without counting its outcome, letting another one
+// through if it was the trial after the cooldown.
+func (b *circuitBreaker) abort() {
+	if b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// jitter returns a random duration between half of d and d, so senders that
+// failed together do not retry together.
+func jitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+	return d/2 + rand.N(d/2)
+}
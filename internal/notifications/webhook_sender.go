@@ -0,0 +1,127 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Headers set on every webhook delivery.
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature" // "t=<unix seconds>,v1=<hex HMAC-SHA256>"
+	WebhookEventHeader     = "X-Webhook-Event"     // Event type.
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"  // Event ID, identical across retries.
+)
+
+// WebhookConfig holds the settings for delivering events to webhooks.
+type WebhookConfig struct {
+	Timeout        time.Duration `mapstructure:"timeout"`          // Per-request timeout.
+	MaxRetries     int           `mapstructure:"max_retries"`      // Retries after the first failed attempt.
+	RetryBaseDelay time.Duration `mapstructure:"retry_base_delay"` // Delay before the first retry; doubles with each retry.
+	RetryMaxDelay  time.Duration `mapstructure:"retry_max_delay"`  // Upper bound of the retry delay.
+	// AllowPrivateNetworks lets webhooks target loopback, private, and
+	// link-local addresses, e.g. a receiver on the same host in development.
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks"`
+}
+
+// RetryDelay returns how long to wait before the next attempt of a delivery
+// that has already been retried n times.
+func (c WebhookConfig) RetryDelay(n int) time.Duration {
+	delay := c.RetryBaseDelay
+	for i := 0; i < n && delay < c.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, c.RetryMaxDelay)
+}
+
+// WebhookSender POSTs signed event payloads to webhook URLs.
+type WebhookSender interface {
+	// Send delivers body to url and returns the response status code, or 0
+	// when no response was received. A non-2xx response is an error.
+	Send(ctx context.Context, url, secret, eventType, eventID string, body []byte) (int, error)
+}
+
+type webhookSender struct {
+	client  *http.Client
+	getTime func() time.Time
+}
+
+// NewWebhookSender creates a WebhookSender that propagates trace context to
+// the receiver. Webhook URLs come from users, so unless
+// config.AllowPrivateNetworks is set, it refuses to connect to addresses that
+// are not public, checked at dial time so DNS rebinding cannot get around the
+// check at registration. It does not follow redirects or use a proxy, which
+// would connect on its behalf.
+func NewWebhookSender(config WebhookConfig) WebhookSender {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !config.AllowPrivateNetworks {
+		dialer.Control = lib.PublicOnlyControl
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &webhookSender{
+		client: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: otelhttp.NewTransport(transport),
+			// A redirect is answered like any other non-2xx response.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		getTime: time.Now,
+	}
+}
+
+func (s *webhookSender) Send(
+	ctx context.Context,
+	url, secret, eventType, eventID string,
+	body []byte,
+) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, eventType)
+	req.Header.Set(WebhookDeliveryHeader, eventID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, s.getTime().Unix(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload returns the signature header value for body sent at
+// timestamp. Receivers recompute the HMAC-SHA256 of "<timestamp>.<body>" with
+// the webhook secret and compare it to v1; checking the timestamp guards
+// against replays.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	ts := strconv.FormatInt(timestamp, 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
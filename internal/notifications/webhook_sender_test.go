@@ -0,0 +1,116 @@
+package notifications_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"github.com/anuragthepathak/subscription-management/internal/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// localWebhooks lets the sender reach the test servers on loopback.
+var localWebhooks = notifications.WebhookConfig{Timeout: time.Second, AllowPrivateNetworks: true}
+
+func TestWebhookSender_Send(t *testing.T) {
+	body := []byte(`{"id":"1","type":"subscription.renewed"}`)
+
+	t.Run("signs the body and sets the event headers", func(t *testing.T) {
+		var gotSignature, gotEvent, gotDelivery string
+		var gotBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotSignature = r.Header.Get(notifications.WebhookSignatureHeader)
+			gotEvent = r.Header.Get(notifications.WebhookEventHeader)
+			gotDelivery = r.Header.Get(notifications.WebhookDeliveryHeader)
+			gotBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		sender := notifications.NewWebhookSender(localWebhooks)
+		status, err := sender.Send(t.Context(), server.URL, "whsec_test", "subscription.renewed", "evt-1", body)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+		assert.Equal(t, "subscription.renewed", gotEvent)
+		assert.Equal(t, "evt-1", gotDelivery)
+		assert.Equal(t, body, gotBody)
+
+		// The receiver can recompute the signature from the timestamp it was sent with.
+		ts, _, ok := strings.Cut(strings.TrimPrefix(gotSignature, "t="), ",")
+		require.True(t, ok)
+		timestamp, err := strconv.ParseInt(ts, 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, notifications.SignWebhookPayload("whsec_test", timestamp, body), gotSignature)
+	})
+
+	t.Run("a non-2xx response is an error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		sender := notifications.NewWebhookSender(localWebhooks)
+		status, err := sender.Send(t.Context(), server.URL, "whsec_test", "subscription.renewed", "evt-1", body)
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+	})
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		var redirected bool
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			redirected = true
+		}))
+		defer target.Close()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+		defer server.Close()
+
+		sender := notifications.NewWebhookSender(localWebhooks)
+		status, err := sender.Send(t.Context(), server.URL, "whsec_test", "subscription.renewed", "evt-1", body)
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusTemporaryRedirect, status)
+		assert.False(t, redirected)
+	})
+
+	t.Run("private addresses are refused at dial time", func(t *testing.T) {
+		var reached bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			reached = true
+		}))
+		defer server.Close()
+
+		sender := notifications.NewWebhookSender(notifications.WebhookConfig{Timeout: time.Second})
+		status, err := sender.Send(t.Context(), server.URL, "whsec_test", "subscription.renewed", "evt-1", body)
+
+		require.ErrorIs(t, err, lib.ErrNonPublicAddress)
+		assert.Zero(t, status)
+		assert.False(t, reached)
+	})
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	a := notifications.SignWebhookPayload("secret", 1700000000, []byte("{}"))
+	assert.True(t, strings.HasPrefix(a, "t=1700000000,v1="))
+	assert.NotEqual(t, a, notifications.SignWebhookPayload("other", 1700000000, []byte("{}")))
+	assert.NotEqual(t, a, notifications.SignWebhookPayload("secret", 1700000001, []byte("{}")))
+}
+
+func TestWebhookConfig_RetryDelay(t *testing.T) {
+	config := notifications.WebhookConfig{RetryBaseDelay: 30 * time.Second, RetryMaxDelay: 5 * time.Minute}
+
+	assert.Equal(t, 30*time.Second, config.RetryDelay(0))
+	assert.Equal(t, time.Minute, config.RetryDelay(1))
+	assert.Equal(t, 4*time.Minute, config.RetryDelay(3))
+	assert.Equal(t, 5*time.Minute, config.RetryDelay(4))
+	assert.Equal(t, 5*time.Minute, config.RetryDelay(20))
+}
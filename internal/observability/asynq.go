@@ -2,6 +2,7 @@ package observability
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -28,6 +29,12 @@ func InjectIntoTaskHeaders(ctx context.Context) map[string]string {
 	return headers
 }
 
+// ExtractFromTaskHeaders returns ctx carrying the remote trace serialized by
+// InjectIntoTaskHeaders, so spans started from it join that trace.
+func ExtractFromTaskHeaders(ctx context.Context, headers map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers))
+}
+
 // AsynqTracingMiddleware is a server-side middleware for Asynq workers.
 // It intercepts incoming jobs, extracts the trace context from the headers, and sets up a child span.
 func AsynqTracingMiddleware(serviceName string) asynq.MiddlewareFunc {
@@ -60,12 +67,7 @@ func AsynqTracingMiddleware(serviceName string) asynq.MiddlewareFunc {
 	return func(next asynq.Handler) asynq.Handler {
 		return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
 			// Extract trace context from task headers (deserializes W3C headers into Go context)
-			headers := task.Headers()
-			if headers == nil {
-				headers = make(map[string]string)
-			}
-			carrier := propagation.MapCarrier(headers)
-			ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+			ctx = ExtractFromTaskHeaders(ctx, task.Headers())
 			// Inject Task type for logs
 			ctx = appctx.WithTaskType(ctx, task.Type())
 
@@ -92,15 +94,22 @@ func AsynqTracingMiddleware(serviceName string) asynq.MiddlewareFunc {
 			start := time.Now()
 			err := next.ProcessTask(ctx, task)
 			duration := time.Since(start).Seconds()
-			if err != nil {
+			// Handlers postpone a task by returning an error that reports
+			// when it runs again; that is not a failure.
+			var deferred interface{ Deferred() time.Time }
+			switch {
+			case err == nil:
+				otelAttrs = append(otelAttrs, otelattr.StatusSuccess)
+			case errors.As(err, &deferred):
+				span.AddEvent("Task deferred until " + deferred.Deferred().Format(time.RFC3339))
+				otelAttrs = append(otelAttrs, otelattr.StatusDeferred)
+			default:
 				span.RecordError(err)
 				span.SetStatus(
 					codes.Error,
 					fmt.Sprintf("Failed to process task %s", task.Type()),
 				)
 				otelAttrs = append(otelAttrs, otelattr.StatusError)
-			} else {
-				otelAttrs = append(otelAttrs, otelattr.StatusSuccess)
 			}
 
 			metricOptions := metric.WithAttributes(otelAttrs...)
@@ -12,7 +12,10 @@ type Config struct {
 	ServiceName    string `mapstructure:"service_name"` // Service name for traces and metrics.
 	Environment    string // Environment injected by main application config (not mapped from yaml).
 	JaegerEndpoint string `mapstructure:"jaeger_endpoint"` // OTLP gRPC endpoint for Jaeger.
-	Metrics        struct {
+	// Fraction of new traces to record, from 0 to 1. Spans continuing a
+	// remote trace, such as queued tasks, follow the caller's decision.
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+	Metrics     struct {
 		SubscriptionsCreatedCount  MetricConfig `mapstructure:"subscriptions_created_count"`
 		SubscriptionsCanceledCount MetricConfig `mapstructure:"subscriptions_canceled_count"`
 		ActiveSubscriptionsCount   MetricConfig `mapstructure:"active_subscriptions_count"`
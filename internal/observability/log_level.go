@@ -0,0 +1,126 @@
+package observability
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/clock"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+)
+
+// LogLevelState describes the current log level.
+type LogLevelState struct {
+	Level      string     `json:"level"`
+	Configured string     `json:"configured"`         // Level restored on revert.
+	RevertAt   *time.Time `json:"revertAt,omitempty"` // Nil when the level does not revert.
+}
+
+// LogLevel adjusts the level of the shared logger at runtime. A temporary
+// change reverts to the configured level once its duration expires.
+type LogLevel struct {
+	mu       sync.Mutex
+	level    *slog.LevelVar
+	base     slog.Level
+	revertAt time.Time
+	timer    *time.Timer
+	getTime  clock.NowFn
+}
+
+// NewLogLevel wraps the level shared by the logger handlers. The current
+// level is treated as the configured one.
+func NewLogLevel(level *slog.LevelVar, nowFn clock.NowFn) *LogLevel {
+	return &LogLevel{
+		level:   level,
+		base:    level.Level(),
+		getTime: nowFn,
+	}
+}
+
+// State returns the current level and when it reverts.
+func (l *LogLevel) State() LogLevelState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stateLocked()
+}
+
+// Set changes the level. A positive duration reverts it to the configured
+// level afterwards; zero keeps it until Reset or a restart. Any pending
+// revert is replaced.
+func (l *LogLevel) Set(level slog.Level, duration time.Duration) LogLevelState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopTimerLocked()
+	l.level.Set(level)
+	if duration > 0 {
+		l.revertAt = l.getTime().Add(duration)
+		l.timer = time.AfterFunc(duration, l.revert)
+	}
+
+	slog.Warn("Log level changed",
+		logattr.LogLevel(level.String()),
+		logattr.Timeout(duration),
+	)
+	return l.stateLocked()
+}
+
+// Reset restores the configured level immediately.
+func (l *LogLevel) Reset() LogLevelState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopTimerLocked()
+	l.level.Set(l.base)
+	slog.Info("Log level reset", logattr.LogLevel(l.base.String()))
+	return l.stateLocked()
+}
+
+// SetConfigured replaces the configured level, as after a config reload. The
+// current level follows unless an override is in place; a pending revert
+// then restores the new level.
+func (l *LogLevel) SetConfigured(level slog.Level) LogLevelState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.timer == nil && l.level.Level() == l.base {
+		l.level.Set(level)
+	}
+	l.base = level
+	slog.Info("Configured log level changed", logattr.LogLevel(level.String()))
+	return l.stateLocked()
+}
+
+func (l *LogLevel) revert() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// A newer Set may have replaced this timer after it fired.
+	if l.timer == nil || l.getTime().Before(l.revertAt) {
+		return
+	}
+	l.timer = nil
+	l.revertAt = time.Time{}
+	l.level.Set(l.base)
+	slog.Info("Log level reverted", logattr.LogLevel(l.base.String()))
+}
+
+func (l *LogLevel) stopTimerLocked() {
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+	l.revertAt = time.Time{}
+}
+
+func (l *LogLevel) stateLocked() LogLevelState {
+	state := LogLevelState{
+		Level:      l.level.Level().String(),
+		Configured: l.base.String(),
+	}
+	if l.timer != nil {
+		revertAt := l.revertAt
+		state.RevertAt = &revertAt
+	}
+	return state
+}
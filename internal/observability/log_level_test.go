@@ -0,0 +1,90 @@
+package observability_test
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevel_SetWithoutDuration(t *testing.T) {
+	var level slog.LevelVar
+	level.Set(slog.LevelInfo)
+	l := observability.NewLogLevel(&level, time.Now)
+
+	state := l.Set(slog.LevelDebug, 0)
+
+	assert.Equal(t, slog.LevelDebug, level.Level())
+	assert.Equal(t, "DEBUG", state.Level)
+	assert.Equal(t, "INFO", state.Configured)
+	assert.Nil(t, state.RevertAt)
+}
+
+func TestLogLevel_RevertsAfterDuration(t *testing.T) {
+	var level slog.LevelVar
+	level.Set(slog.LevelInfo)
+	l := observability.NewLogLevel(&level, time.Now)
+
+	state := l.Set(slog.LevelDebug, 20*time.Millisecond)
+	require.NotNil(t, state.RevertAt)
+	assert.Equal(t, slog.LevelDebug, level.Level())
+
+	assert.Eventually(t, func() bool {
+		return level.Level() == slog.LevelInfo
+	}, time.Second, 5*time.Millisecond)
+	assert.Nil(t, l.State().RevertAt)
+}
+
+func TestLogLevel_SetReplacesPendingRevert(t *testing.T) {
+	var level slog.LevelVar
+	level.Set(slog.LevelInfo)
+	l := observability.NewLogLevel(&level, time.Now)
+
+	l.Set(slog.LevelDebug, 20*time.Millisecond)
+	l.Set(slog.LevelWarn, 0)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, slog.LevelWarn, level.Level())
+}
+
+func TestLogLevel_Reset(t *testing.T) {
+	var level slog.LevelVar
+	level.Set(slog.LevelWarn)
+	l := observability.NewLogLevel(&level, time.Now)
+	l.Set(slog.LevelDebug, time.Hour)
+
+	state := l.Reset()
+
+	assert.Equal(t, slog.LevelWarn, level.Level())
+	assert.Equal(t, "WARN", state.Level)
+	assert.Nil(t, state.RevertAt)
+}
+
+func TestLogLevel_SetConfigured(t *testing.T) {
+	var level slog.LevelVar
+	level.Set(slog.LevelInfo)
+	l := observability.NewLogLevel(&level, time.Now)
+
+	state := l.SetConfigured(slog.LevelWarn)
+
+	assert.Equal(t, slog.LevelWarn, level.Level())
+	assert.Equal(t, "WARN", state.Configured)
+}
+
+func TestLogLevel_SetConfiguredKeepsOverride(t *testing.T) {
+	var level slog.LevelVar
+	level.Set(slog.LevelInfo)
+	l := observability.NewLogLevel(&level, time.Now)
+	l.Set(slog.LevelDebug, time.Hour)
+
+	state := l.SetConfigured(slog.LevelWarn)
+
+	assert.Equal(t, slog.LevelDebug, level.Level())
+	assert.Equal(t, "WARN", state.Configured)
+
+	l.Reset()
+	assert.Equal(t, slog.LevelWarn, level.Level())
+}
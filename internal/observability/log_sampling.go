@@ -0,0 +1,47 @@
+package observability
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// samplingHandler wraps an slog.Handler and passes through only one in every
+// n debug records. Records at Info and above are never dropped.
+type samplingHandler struct {
+	inner   slog.Handler
+	every   uint64
+	counter *atomic.Uint64 // Shared by handlers derived with WithAttrs/WithGroup.
+}
+
+// NewSamplingHandler keeps one in every n debug records. n below 2 disables
+// sampling and returns inner unchanged.
+func NewSamplingHandler(inner slog.Handler, n int) slog.Handler {
+	if n < 2 {
+		return inner
+	}
+	return &samplingHandler{
+		inner:   inner,
+		every:   uint64(n),
+		counter: new(atomic.Uint64),
+	}
+}
+
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level < slog.LevelInfo && (h.counter.Add(1)-1)%h.every != 0 {
+		return nil
+	}
+	return h.inner.Handle(ctx, record)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{inner: h.inner.WithAttrs(attrs), every: h.every, counter: h.counter}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{inner: h.inner.WithGroup(name), every: h.every, counter: h.counter}
+}
@@ -0,0 +1,34 @@
+package observability
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplingHandler(t *testing.T) {
+	var buf bytes.Buffer
+	inner := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger := slog.New(NewSamplingHandler(inner, 3))
+
+	for range 6 {
+		logger.Debug("debug record")
+	}
+	// Derived loggers share the counter.
+	logger.With("k", "v").Debug("debug record")
+	for range 2 {
+		logger.Info("info record")
+	}
+
+	out := buf.String()
+	assert.Equal(t, 3, strings.Count(out, "debug record"), "one in three debug records should pass")
+	assert.Equal(t, 2, strings.Count(out, "info record"), "info records should never be sampled")
+}
+
+func TestNewSamplingHandler_Disabled(t *testing.T) {
+	inner := slog.NewTextHandler(&bytes.Buffer{}, nil)
+	assert.Same(t, inner, NewSamplingHandler(inner, 1))
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/anuragthepathak/subscription-management/internal/core/buildinfo"
 	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
@@ -33,6 +34,7 @@ func InitOTel(ctx context.Context, cfg Config) (*Provider, error) {
 	res, err := resource.New(ctx,
 		resource.WithAttributes(
 			semconv.ServiceNameKey.String(cfg.ServiceName),
+			semconv.ServiceVersionKey.String(buildinfo.Get().Version),
 			semconv.DeploymentEnvironmentKey.String(cfg.Environment),
 		),
 	)
@@ -51,6 +53,7 @@ func InitOTel(ctx context.Context, cfg Config) (*Provider, error) {
 
 	tracerProvider := sdktrace.NewTracerProvider(
 		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
 		sdktrace.WithBatcher(traceExporter),
 	)
 	otel.SetTracerProvider(tracerProvider)
@@ -76,6 +79,7 @@ func InitOTel(ctx context.Context, cfg Config) (*Provider, error) {
 	slog.Info("OpenTelemetry initialized",
 		logattr.Service(cfg.ServiceName),
 		logattr.Jaeger(cfg.JaegerEndpoint),
+		logattr.SampleRatio(cfg.SampleRatio),
 	)
 
 	return &Provider{
@@ -0,0 +1,200 @@
+// Code generated by mockery v2.53.6. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	payments "github.com/anuragthepathak/subscription-management/internal/payments"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockProvider is an autogenerated mock type for the Provider type
+type MockProvider struct {
+	mock.Mock
+}
+
+type MockProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProvider) EXPECT() *MockProvider_Expecter {
+	return &MockProvider_Expecter{mock: &_m.Mock}
+}
+
+// Charge provides a mock function with given fields: ctx, req
+func (_m *MockProvider) Charge(ctx context.Context, req payments.ChargeRequest) (*payments.Charge, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Charge")
+	}
+
+	var r0 *payments.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, payments.ChargeRequest) (*payments.Charge, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, payments.ChargeRequest) *payments.Charge); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payments.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, payments.ChargeRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProvider_Charge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Charge'
+type MockProvider_Charge_Call struct {
+	*mock.Call
+}
+
+// Charge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req payments.ChargeRequest
+func (_e *MockProvider_Expecter) Charge(ctx interface{}, req interface{}) *MockProvider_Charge_Call {
+	return &MockProvider_Charge_Call{Call: _e.mock.On("Charge", ctx, req)}
+}
+
+func (_c *MockProvider_Charge_Call) Run(run func(ctx context.Context, req payments.ChargeRequest)) *MockProvider_Charge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(payments.ChargeRequest))
+	})
+	return _c
+}
+
+func (_c *MockProvider_Charge_Call) Return(_a0 *payments.Charge, _a1 error) *MockProvider_Charge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProvider_Charge_Call) RunAndReturn(run func(context.Context, payments.ChargeRequest) (*payments.Charge, error)) *MockProvider_Charge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCharge provides a mock function with given fields: ctx, id
+func (_m *MockProvider) GetCharge(ctx context.Context, id string) (*payments.Charge, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCharge")
+	}
+
+	var r0 *payments.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*payments.Charge, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *payments.Charge); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payments.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProvider_GetCharge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCharge'
+type MockProvider_GetCharge_Call struct {
+	*mock.Call
+}
+
+// GetCharge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockProvider_Expecter) GetCharge(ctx interface{}, id interface{}) *MockProvider_GetCharge_Call {
+	return &MockProvider_GetCharge_Call{Call: _e.mock.On("GetCharge", ctx, id)}
+}
+
+func (_c *MockProvider_GetCharge_Call) Run(run func(ctx context.Context, id string)) *MockProvider_GetCharge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockProvider_GetCharge_Call) Return(_a0 *payments.Charge, _a1 error) *MockProvider_GetCharge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProvider_GetCharge_Call) RunAndReturn(run func(context.Context, string) (*payments.Charge, error)) *MockProvider_GetCharge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Name provides a mock function with no fields
+func (_m *MockProvider) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockProvider_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type MockProvider_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *MockProvider_Expecter) Name() *MockProvider_Name_Call {
+	return &MockProvider_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *MockProvider_Name_Call) Run(run func()) *MockProvider_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockProvider_Name_Call) Return(_a0 string) *MockProvider_Name_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProvider_Name_Call) RunAndReturn(run func() string) *MockProvider_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProvider creates a new instance of MockProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProvider {
+	mock := &MockProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,100 @@
+// Package payments charges subscription renewals through a payment
+// provider. Charges are made off-session against a payment method the
+// customer saved with the provider beforehand.
+package payments
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+// ProviderStripe charges through the Stripe PaymentIntents API.
+const ProviderStripe = "stripe"
+
+// Config holds the payment provider settings.
+type Config struct {
+	Provider string        `mapstructure:"provider"` // Payment provider; empty disables charging.
+	APIKey   string        `mapstructure:"api_key"`  // Secret API key of the provider.
+	BaseURL  string        `mapstructure:"base_url"` // Provider API endpoint.
+	Timeout  time.Duration `mapstructure:"timeout"`  // Per-request timeout for the provider.
+}
+
+// ChargeRequest describes a single charge.
+type ChargeRequest struct {
+	// IdempotencyKey makes retries of the same charge safe; the provider
+	// returns the original result instead of charging twice.
+	IdempotencyKey  string
+	Amount          int64 // Minor units of Currency.
+	Currency        models.Currency
+	CustomerID      string // Customer ID at the provider.
+	PaymentMethodID string // Payment method ID at the provider.
+	Description     string
+	Metadata        map[string]string
+}
+
+// Charge is a charge the provider accepted. A pending charge is still being
+// processed, e.g. a bank debit, and settles later: look it up again with
+// GetCharge instead of charging again.
+type Charge struct {
+	ID      string // Charge ID at the provider.
+	Pending bool
+}
+
+// DeclineError reports a charge the provider refused, e.g. for insufficient
+// funds. Retrying it right away will not help. Any other error from Charge is
+// transient.
+type DeclineError struct {
+	Code     string // Provider decline code, e.g. "card_declined".
+	Message  string
+	ChargeID string // Set when the provider recorded the failed attempt.
+}
+
+func (e *DeclineError) Error() string {
+	if e.Code == "" {
+		return "payment declined: " + e.Message
+	}
+	return fmt.Sprintf("payment declined (%s): %s", e.Code, e.Message)
+}
+
+// Reason describes the decline for people.
+func (e *DeclineError) Reason() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.Code
+}
+
+// Provider charges customers.
+type Provider interface {
+	// Name identifies the provider in logs.
+	Name() string
+	// Charge charges the payment method in req. It returns a *DeclineError
+	// when the provider refuses the charge.
+	Charge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	// GetCharge looks up the charge with the given ID. Like Charge, it
+	// returns a *DeclineError when the charge failed.
+	GetCharge(ctx context.Context, id string) (*Charge, error)
+}
+
+// NewProvider creates the provider selected by config, or nil when none is
+// configured.
+func NewProvider(config Config) (Provider, error) {
+	client := &http.Client{Timeout: config.Timeout}
+	switch config.Provider {
+	case "":
+		return nil, nil
+	case ProviderStripe:
+		return &stripeProvider{
+			baseURL: strings.TrimSuffix(config.BaseURL, "/"),
+			apiKey:  config.APIKey,
+			client:  client,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", config.Provider)
+	}
+}
@@ -0,0 +1,201 @@
+package payments_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/payments"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider, err := payments.NewProvider(payments.Config{})
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	_, err = payments.NewProvider(payments.Config{Provider: "unknown"})
+	assert.Error(t, err)
+}
+
+func newStripe(t *testing.T, handler http.HandlerFunc) payments.Provider {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	provider, err := payments.NewProvider(payments.Config{
+		Provider: payments.ProviderStripe,
+		APIKey:   "sk_test_123",
+		BaseURL:  server.URL + "/",
+		Timeout:  time.Second,
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+var chargeRequest = payments.ChargeRequest{
+	IdempotencyKey:  "renewal-1",
+	Amount:          1549,
+	Currency:        models.USD,
+	CustomerID:      "cus_123",
+	PaymentMethodID: "pm_123",
+	Description:     "Netflix renewal",
+	Metadata:        map[string]string{"subscription_id": "abc"},
+}
+
+func TestStripeProvider_Charge(t *testing.T) {
+	provider := newStripe(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/payment_intents", r.URL.Path)
+		assert.Equal(t, "Bearer sk_test_123", r.Header.Get("Authorization"))
+		assert.Equal(t, "renewal-1", r.Header.Get("Idempotency-Key"))
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "1549", r.PostForm.Get("amount"))
+		assert.Equal(t, "usd", r.PostForm.Get("currency"))
+		assert.Equal(t, "cus_123", r.PostForm.Get("customer"))
+		assert.Equal(t, "pm_123", r.PostForm.Get("payment_method"))
+		assert.Equal(t, "true", r.PostForm.Get("off_session"))
+		assert.Equal(t, "true", r.PostForm.Get("confirm"))
+		assert.Equal(t, "abc", r.PostForm.Get("metadata[subscription_id]"))
+		_, _ = w.Write([]byte(`{"id":"pi_123","status":"succeeded"}`))
+	})
+
+	charge, err := provider.Charge(t.Context(), chargeRequest)
+	require.NoError(t, err)
+	assert.Equal(t, "pi_123", charge.ID)
+}
+
+func TestStripeProvider_Charge_Pending(t *testing.T) {
+	for _, status := range []string{"processing", "requires_action"} {
+		t.Run(status, func(t *testing.T) {
+			provider := newStripe(t, func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"id":"pi_123","status":"` + status + `"}`))
+			})
+
+			charge, err := provider.Charge(t.Context(), chargeRequest)
+			require.NoError(t, err)
+			assert.Equal(t, &payments.Charge{ID: "pi_123", Pending: true}, charge)
+		})
+	}
+}
+
+func TestStripeProvider_GetCharge(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		want        *payments.Charge
+		wantDecline *payments.DeclineError
+		wantErr     bool
+	}{
+		{
+			name:   "succeeded",
+			status: http.StatusOK,
+			body:   `{"id":"pi_123","status":"succeeded"}`,
+			want:   &payments.Charge{ID: "pi_123"},
+		},
+		{
+			name:   "still processing",
+			status: http.StatusOK,
+			body:   `{"id":"pi_123","status":"processing"}`,
+			want:   &payments.Charge{ID: "pi_123", Pending: true},
+		},
+		{
+			name:   "failed",
+			status: http.StatusOK,
+			body: `{"id":"pi_123","status":"requires_payment_method","last_payment_error":` +
+				`{"code":"payment_intent_payment_attempt_failed","decline_code":"insufficient_funds","message":"The debit failed."}}`,
+			wantDecline: &payments.DeclineError{
+				Code:     "insufficient_funds",
+				Message:  "The debit failed.",
+				ChargeID: "pi_123",
+			},
+		},
+		{name: "unknown charge", status: http.StatusNotFound, body: `{"error":{"type":"invalid_request_error"}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newStripe(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/v1/payment_intents/pi_123", r.URL.Path)
+				assert.Equal(t, "Bearer sk_test_123", r.Header.Get("Authorization"))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			charge, err := provider.GetCharge(t.Context(), "pi_123")
+			switch {
+			case tt.wantDecline != nil:
+				var decline *payments.DeclineError
+				require.ErrorAs(t, err, &decline)
+				assert.Equal(t, tt.wantDecline, decline)
+			case tt.wantErr:
+				var decline *payments.DeclineError
+				require.Error(t, err)
+				assert.NotErrorAs(t, err, &decline)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, charge)
+			}
+		})
+	}
+}
+
+func TestStripeProvider_Charge_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantDecline *payments.DeclineError
+	}{
+		{
+			name:   "card declined",
+			status: http.StatusPaymentRequired,
+			body: `{"error":{"type":"card_error","code":"card_declined","decline_code":"insufficient_funds",` +
+				`"message":"Your card has insufficient funds.","payment_intent":{"id":"pi_456","status":"requires_payment_method"}}}`,
+			wantDecline: &payments.DeclineError{
+				Code:     "insufficient_funds",
+				Message:  "Your card has insufficient funds.",
+				ChargeID: "pi_456",
+			},
+		},
+		{
+			name:   "canceled",
+			status: http.StatusOK,
+			body:   `{"id":"pi_789","status":"canceled"}`,
+			wantDecline: &payments.DeclineError{
+				Code:     "canceled",
+				Message:  "payment was not completed",
+				ChargeID: "pi_789",
+			},
+		},
+		{name: "server error", status: http.StatusInternalServerError, body: `{"error":{"type":"api_error"}}`},
+		{name: "rate limited", status: http.StatusTooManyRequests, body: `{"error":{"type":"invalid_request_error"}}`},
+		{name: "malformed body", status: http.StatusOK, body: `{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newStripe(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			_, err := provider.Charge(t.Context(), chargeRequest)
+			require.Error(t, err)
+
+			var decline *payments.DeclineError
+			if tt.wantDecline == nil {
+				assert.NotErrorAs(t, err, &decline)
+				return
+			}
+			require.ErrorAs(t, err, &decline)
+			assert.Equal(t, tt.wantDecline, decline)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type stripeProvider struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func (p *stripeProvider) Name() string {
+	return ProviderStripe
+}
+
+// stripePaymentIntent is the part of a PaymentIntent the provider reads.
+type stripePaymentIntent struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"`
+	LastPaymentError *struct {
+		Code        string `json:"code"`
+		DeclineCode string `json:"decline_code"`
+		Message     string `json:"message"`
+	} `json:"last_payment_error"`
+}
+
+type stripeError struct {
+	Error struct {
+		Type          string               `json:"type"`
+		Code          string               `json:"code"`
+		DeclineCode   string               `json:"decline_code"`
+		Message       string               `json:"message"`
+		PaymentIntent *stripePaymentIntent `json:"payment_intent"`
+	} `json:"error"`
+}
+
+// Charge creates and confirms a PaymentIntent with POST
+// {baseURL}/v1/payment_intents. Stripe answers a declined card with 402 and
+// a card_error.
+func (p *stripeProvider) Charge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(req.Amount, 10))
+	form.Set("currency", strings.ToLower(string(req.Currency)))
+	form.Set("customer", req.CustomerID)
+	form.Set("payment_method", req.PaymentMethodID)
+	form.Set("off_session", "true")
+	form.Set("confirm", "true")
+	if req.Description != "" {
+		form.Set("description", req.Description)
+	}
+	for key, value := range req.Metadata {
+		form.Set("metadata["+key+"]", value)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v1/payment_intents", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build charge request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if req.IdempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", req.IdempotencyKey)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach payment provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body stripeError
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Error.Type != "card_error" {
+			return nil, fmt.Errorf("payment provider returned %s", resp.Status)
+		}
+		decline := &DeclineError{
+			Code:    body.Error.DeclineCode,
+			Message: body.Error.Message,
+		}
+		if decline.Code == "" {
+			decline.Code = body.Error.Code
+		}
+		if body.Error.PaymentIntent != nil {
+			decline.ChargeID = body.Error.PaymentIntent.ID
+		}
+		return nil, decline
+	}
+
+	var intent stripePaymentIntent
+	if err := json.NewDecoder(resp.Body).Decode(&intent); err != nil {
+		return nil, fmt.Errorf("failed to decode charge: %w", err)
+	}
+	return intent.charge()
+}
+
+// GetCharge retrieves a PaymentIntent with GET
+// {baseURL}/v1/payment_intents/{id}.
+func (p *stripeProvider) GetCharge(ctx context.Context, id string) (*Charge, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/v1/payment_intents/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build charge lookup: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach payment provider: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("payment provider returned %s", resp.Status)
+	}
+
+	var intent stripePaymentIntent
+	if err := json.NewDecoder(resp.Body).Decode(&intent); err != nil {
+		return nil, fmt.Errorf("failed to decode charge: %w", err)
+	}
+	return intent.charge()
+}
+
+// charge maps the status of the intent to a charge. Processing intents, such
+// as bank debits, and intents waiting on the customer settle later, so they
+// are pending rather than declined; anything else but success failed.
+func (i *stripePaymentIntent) charge() (*Charge, error) {
+	switch i.Status {
+	case "succeeded":
+		return &Charge{ID: i.ID}, nil
+	case "processing", "requires_action":
+		return &Charge{ID: i.ID, Pending: true}, nil
+	}
+	decline := &DeclineError{
+		Code:     i.Status,
+		Message:  "payment was not completed",
+		ChargeID: i.ID,
+	}
+	if e := i.LastPaymentError; e != nil {
+		decline.Message = e.Message
+		if e.DeclineCode != "" {
+			decline.Code = e.DeclineCode
+		} else if e.Code != "" {
+			decline.Code = e.Code
+		}
+	}
+	return nil, decline
+}
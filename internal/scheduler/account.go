@@ -0,0 +1,176 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/notifications"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/hibiken/asynq"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	// AccountClosedTask is the task name for confirming the closure of an
+	// account by email.
+	AccountClosedTask = "account:closed"
+	// AccountPurgeTask is the task name for deleting a closed account once
+	// its grace period is over.
+	AccountPurgeTask = "account:purge"
+)
+
+// AccountPayload names the account an account task is about.
+type AccountPayload struct {
+	UserID string `json:"user_id"`
+}
+
+type accountClosureQueue struct {
+	taskEnqueuer TaskEnqueuer
+	queueName    string
+}
+
+// NewAccountClosureQueue returns the queue the user service hands closed
+// accounts to.
+func NewAccountClosureQueue(taskEnqueuer TaskEnqueuer, queueName string) services.AccountClosureQueue {
+	return &accountClosureQueue{
+		taskEnqueuer,
+		queueName,
+	}
+}
+
+// EnqueueAccountClosure enqueues the confirmation email right away and the
+// purge for the user's PurgeAt. Both task IDs are derived from the user, so
+// a retried closure enqueues each once.
+func (q *accountClosureQueue) EnqueueAccountClosure(ctx context.Context, user *models.User) error {
+	payload, err := json.Marshal(AccountPayload{UserID: user.ID.Hex()})
+	if err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to marshal account task payload: %w", err))
+	}
+
+	for _, t := range []struct {
+		name string
+		opts []asynq.Option
+	}{
+		{AccountClosedTask, nil},
+		{AccountPurgeTask, []asynq.Option{asynq.ProcessAt(*user.PurgeAt)}},
+	} {
+		headers := observability.InjectIntoTaskHeaders(ctx)
+		task := asynq.NewTaskWithHeaders(t.name, payload, headers)
+		opts := append([]asynq.Option{
+			asynq.TaskID(t.name + ":" + user.ID.Hex()),
+			asynq.MaxRetry(5),
+			asynq.Queue(q.queueName),
+		}, t.opts...)
+		if _, err = q.taskEnqueuer.Enqueue(task, opts...); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			slog.ErrorContext(ctx, "Failed to enqueue account task",
+				logattr.TaskType(t.name),
+				logattr.Queue(q.queueName),
+				logattr.Error(err),
+			)
+			return apperror.NewInternalError(fmt.Errorf("failed to enqueue %s task: %w", t.name, err))
+		}
+	}
+	return nil
+}
+
+// handleAccountClosed emails the user that their account was closed and
+// when it will be deleted.
+func (w *QueueWorker) handleAccountClosed(ctx context.Context, task *asynq.Task) error {
+	ctx, userID, err := w.accountPayload(ctx, task)
+	if err != nil {
+		return err
+	}
+
+	user, err := w.userService.FetchUserByIDInternal(ctx, userID)
+	if err != nil {
+		// Already purged; there is no address left to write to.
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			slog.DebugContext(ctx, "Skipping closure email: user not found",
+				logattr.Queue(w.queueName),
+			)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to fetch user for closure email",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+	if !user.Closed() {
+		return nil
+	}
+
+	ctx = w.localized(ctx, user, nil)
+	err = w.emailSender.SendAccountClosedEmail(ctx, user.Email, user.Name, *user.PurgeAt)
+	err = w.recordNotification(ctx, user.ID, models.ChannelEmail, user.Email, notifications.TemplateAccountClosed, err)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send account closed email",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to send account closed email: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Account closed email sent",
+		logattr.Queue(w.queueName),
+	)
+	return nil
+}
+
+// handleAccountPurge deletes a closed account whose grace period is over. An
+// account already deleted is skipped.
+func (w *QueueWorker) handleAccountPurge(ctx context.Context, task *asynq.Task) error {
+	ctx, userID, err := w.accountPayload(ctx, task)
+	if err != nil {
+		return err
+	}
+
+	if err = w.userService.PurgeUserInternal(ctx, userID); err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			slog.DebugContext(ctx, "Skipping purge: user not found",
+				logattr.Queue(w.queueName),
+			)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to purge account",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to purge account: %w", err)
+	}
+	return nil
+}
+
+// accountPayload decodes the user ID of an account task and returns ctx
+// enriched with it.
+func (w *QueueWorker) accountPayload(ctx context.Context, task *asynq.Task) (context.Context, bson.ObjectID, error) {
+	var payload AccountPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal account task payload",
+			logattr.TaskType(task.Type()),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return ctx, bson.NilObjectID, fmt.Errorf("failed to unmarshal account task payload: %w", err)
+	}
+	userID, err := bson.ObjectIDFromHex(payload.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid user ID",
+			logattr.TaskType(task.Type()),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return ctx, bson.NilObjectID, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	ctx = observability.EnrichContext(ctx, payload.UserID, "")
+	observability.EnrichSpan(ctx)
+	return ctx, userID, nil
+}
@@ -0,0 +1,160 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/hibiken/asynq"
+)
+
+const (
+	// ScanTaskPrefix prefixes the task type of every periodic scan in cron
+	// mode, e.g. "scan:renewals".
+	ScanTaskPrefix = "scan:"
+	// ScanQueue is the queue periodic scans run on. Only the scheduler
+	// processes it, so scans never compete with user-facing tasks.
+	ScanQueue = "scheduler"
+	// scanTimeout bounds a single scan. Scans are unique for as long, so
+	// schedulers firing at the same time run a scan once.
+	scanTimeout = 10 * time.Minute
+)
+
+// CronSchedule runs the scheduler in cron mode: every scan runs on its own
+// cron expression instead of all of them on a fixed interval.
+type CronSchedule struct {
+	// Specs maps a scan name to its cron expression. Scans missing from the
+	// map, or with an empty expression, do not run.
+	Specs    map[string]string
+	Location *time.Location // Time zone the expressions are evaluated in.
+	// RenewalLookahead is how far past the renewal window renewals are
+	// scheduled, so renewals scans may run far less often than the window.
+	RenewalLookahead time.Duration
+}
+
+// phase is one scan of the scheduler, run on every poll in poll mode and on
+// its own schedule in cron mode.
+type phase struct {
+	name string
+	run  func(context.Context) error
+}
+
+// phases returns the scans enabled by the scheduler's configuration, in the
+// order a poll runs them.
+// NOTE: Every phase owns its own telemetry. Any returned error is strictly
+// for control flow and has already been logged and attached to the trace.
+func (s *SubscriptionScheduler) phases() []phase {
+	phases := []phase{
+		{"reminders", s.handleReminderTasks},
+		{"renewals", s.handleRenewalTasks},
+		{"expirations", s.handleExpirationTasks},
+	}
+	if s.archiveAfterMonths > 0 {
+		phases = append(phases, phase{"archive", s.handleArchiveTasks})
+	}
+	if s.unusedAfterDays > 0 {
+		phases = append(phases, phase{"unused", s.handleUnusedTasks})
+	}
+	if len(s.dunningRetryDays) > 0 {
+		phases = append(phases, phase{"dunning", s.handleDunningTasks})
+	}
+	if s.refreshRates {
+		phases = append(phases, phase{"exchange_rates", s.scheduleExchangeRateTask})
+	}
+	phases = append(phases, phase{"budget_alerts", s.scheduleBudgetAlertTask})
+	if s.repairBills {
+		phases = append(phases, phase{"bill_repair", s.scheduleBillRepairTask})
+	}
+	if s.settleCharges {
+		phases = append(phases, phase{"charge_settlement", s.scheduleChargeSettlementTask})
+	}
+	if s.reconcile {
+		phases = append(phases, phase{"reconciliation", s.scheduleReconciliationTask})
+	}
+	return phases
+}
+
+// startCron registers every enabled scan that has a schedule as a periodic
+// task and processes the scans until ctx is done.
+func (s *SubscriptionScheduler) startCron(ctx context.Context) error {
+	mux := asynq.NewServeMux()
+	mux.Use(observability.AsynqTracingMiddleware(s.name))
+
+	registered := 0
+	for _, p := range s.phases() {
+		spec := s.cron.Specs[p.name]
+		if spec == "" {
+			slog.InfoContext(ctx, "Scan has no schedule, skipping",
+				logattr.TaskType(ScanTaskPrefix+p.name),
+				logattr.SchedulerName(s.name),
+			)
+			continue
+		}
+
+		taskType := ScanTaskPrefix + p.name
+		if _, err := s.cronScheduler.Register(spec, asynq.NewTask(taskType, nil),
+			asynq.Unique(scanTimeout), // One scan per tick across schedulers
+			asynq.Timeout(scanTimeout),
+			asynq.MaxRetry(0), // The next tick scans again
+			asynq.Queue(ScanQueue),
+		); err != nil {
+			return fmt.Errorf("failed to register scan %s: %w", p.name, err)
+		}
+		mux.HandleFunc(taskType, func(ctx context.Context, _ *asynq.Task) error {
+			// The phase logged any failure; returning it would only archive
+			// the scan.
+			_ = p.run(ctx)
+			return nil
+		})
+		registered++
+
+		slog.DebugContext(ctx, "Scan scheduled",
+			logattr.TaskType(taskType),
+			logattr.CronSpec(spec),
+			logattr.SchedulerName(s.name),
+		)
+	}
+
+	if err := s.scanServer.Start(mux); err != nil {
+		return fmt.Errorf("failed to start scan server: %w", err)
+	}
+	if err := s.cronScheduler.Start(); err != nil {
+		return fmt.Errorf("failed to start cron scheduler: %w", err)
+	}
+	slog.InfoContext(ctx, "Scheduler cron loop started",
+		logattr.SchedulerName(s.name),
+		logattr.Queue(s.queueName),
+		logattr.Total(registered),
+		logattr.ReminderDays(s.defaultReminderDays()),
+	)
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// logScanEnqueue reports a scan the cron scheduler failed to enqueue. A
+// duplicate means another scheduler enqueued the same tick.
+func (s *SubscriptionScheduler) logScanEnqueue(info *asynq.TaskInfo, err error) {
+	switch {
+	case err == nil:
+		slog.Debug("Scan enqueued",
+			logattr.TaskID(info.ID),
+			logattr.TaskType(info.Type),
+			logattr.Queue(ScanQueue),
+		)
+	case errors.Is(err, asynq.ErrDuplicateTask):
+		slog.Debug("Scan already enqueued",
+			logattr.Queue(ScanQueue),
+		)
+	default:
+		slog.Error("Failed to enqueue scan",
+			logattr.SchedulerName(s.name),
+			logattr.Queue(ScanQueue),
+			logattr.Error(err),
+		)
+	}
+}
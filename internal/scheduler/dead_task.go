@@ -0,0 +1,114 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/hibiken/asynq"
+)
+
+// deadTaskTimeout bounds recording a dead task and alerting about it. The
+// context of the failed task may already be done.
+const deadTaskTimeout = 30 * time.Second
+
+type deadTaskQueue struct {
+	taskEnqueuer TaskEnqueuer
+}
+
+// NewDeadTaskQueue returns the queue the dead task service requeues tasks
+// to.
+func NewDeadTaskQueue(taskEnqueuer TaskEnqueuer) services.DeadTaskQueue {
+	return &deadTaskQueue{
+		taskEnqueuer,
+	}
+}
+
+// RequeueDeadTask enqueues the task on its original queue with its original
+// retry limit. The new task ID is derived from the dead task, so requeuing it
+// twice while the first copy is still in the queue conflicts.
+func (q *deadTaskQueue) RequeueDeadTask(ctx context.Context, deadTask *models.DeadTask) (string, error) {
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(deadTask.Type, deadTask.Payload, headers)
+	info, err := q.taskEnqueuer.Enqueue(
+		task,
+		asynq.TaskID("requeue:"+deadTask.ID.Hex()),
+		asynq.MaxRetry(deadTask.MaxRetry),
+		asynq.Queue(deadTask.Queue),
+	)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return "", apperror.NewConflictError("Dead task was already requeued")
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to requeue dead task",
+			logattr.DeadTaskID(deadTask.ID.Hex()),
+			logattr.TaskType(deadTask.Type),
+			logattr.Queue(deadTask.Queue),
+			logattr.Error(err),
+		)
+		return "", apperror.NewInternalError(fmt.Errorf("failed to requeue dead task: %w", err))
+	}
+	return info.ID, nil
+}
+
+// handleTaskError records a task that failed for the last time, i.e. that
+// exhausted its retries or asked not to be retried, and alerts operators.
+// Failed webhook deliveries are left out: they are recorded as deliveries,
+// and a user's broken endpoint is not for operators to fix.
+func (w *QueueWorker) handleTaskError(ctx context.Context, task *asynq.Task, err error) {
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	if (retried < maxRetry && !errors.Is(err, asynq.SkipRetry)) || errors.Is(err, asynq.RevokeTask) {
+		return
+	}
+	if task.Type() == WebhookDeliveryTask {
+		return
+	}
+
+	taskID, _ := asynq.GetTaskID(ctx)
+	queue, _ := asynq.GetQueueName(ctx)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadTaskTimeout)
+	defer cancel()
+
+	deadTask := &models.DeadTask{
+		TaskID:   taskID,
+		Type:     task.Type(),
+		Queue:    queue,
+		Payload:  task.Payload(),
+		Error:    err.Error(),
+		Retried:  retried,
+		MaxRetry: maxRetry,
+	}
+	if recordErr := w.deadTaskService.RecordDeadTaskInternal(ctx, deadTask); recordErr != nil {
+		// The task stays archived in Redis, so it is not lost.
+		slog.ErrorContext(ctx, "Failed to record dead task",
+			logattr.TaskID(taskID),
+			logattr.TaskType(task.Type()),
+			logattr.Queue(queue),
+			logattr.Error(recordErr),
+		)
+		return
+	}
+	slog.WarnContext(ctx, "Task failed permanently",
+		logattr.DeadTaskID(deadTask.ID.Hex()),
+		logattr.TaskID(taskID),
+		logattr.TaskType(task.Type()),
+		logattr.Queue(queue),
+		logattr.Attempt(retried),
+		logattr.Error(err),
+	)
+
+	if alertErr := w.alerter.AlertDeadTask(ctx, deadTask); alertErr != nil {
+		slog.ErrorContext(ctx, "Failed to alert about dead task",
+			logattr.DeadTaskID(deadTask.ID.Hex()),
+			logattr.Error(alertErr),
+		)
+	}
+}
@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// drainPollInterval is how often Stop checks whether running tasks finished.
+const drainPollInterval = 100 * time.Millisecond
+
+// drainMetrics reports how the worker drains on shutdown.
+type drainMetrics struct {
+	running  metric.Int64UpDownCounter
+	duration metric.Float64Histogram
+	requeued metric.Int64Counter
+}
+
+func newDrainMetrics(name string) drainMetrics {
+	meter := otel.Meter(name)
+
+	running, err := meter.Int64UpDownCounter(
+		"worker.tasks.running",
+		metric.WithDescription("Number of tasks the worker is running"),
+	)
+	if err != nil {
+		slog.Error("Failed to create running tasks counter", logattr.WorkerName(name), logattr.Error(err))
+	}
+	duration, err := meter.Float64Histogram(
+		"worker.drain.duration_seconds",
+		metric.WithDescription("Time the worker waited for running tasks on shutdown"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		slog.Error("Failed to create drain duration histogram", logattr.WorkerName(name), logattr.Error(err))
+	}
+	requeued, err := meter.Int64Counter(
+		"worker.drain.requeued_total",
+		metric.WithDescription("Tasks still running at shutdown and returned to the queue"),
+	)
+	if err != nil {
+		slog.Error("Failed to create requeued tasks counter", logattr.WorkerName(name), logattr.Error(err))
+	}
+	return drainMetrics{running: running, duration: duration, requeued: requeued}
+}
+
+// trackRunning counts the tasks being processed, so Stop knows when the
+// worker has drained.
+func (w *QueueWorker) trackRunning(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		w.running.Add(1)
+		w.drainMetrics.running.Add(ctx, 1)
+		defer func() {
+			w.running.Add(-1)
+			w.drainMetrics.running.Add(context.WithoutCancel(ctx), -1)
+		}()
+		return next.ProcessTask(ctx, task)
+	})
+}
+
+// drain stops fetching new tasks and waits up to the drain timeout for
+// running ones to finish.
+func (w *QueueWorker) drain() {
+	start := time.Now()
+	slog.Info("Draining queue worker",
+		logattr.WorkerName(w.name),
+		logattr.RunningTasks(w.running.Load()),
+		logattr.Timeout(w.drainTimeout),
+	)
+	w.server.Stop()
+
+	deadline := time.NewTimer(w.drainTimeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for running := w.running.Load(); running > 0; running = w.running.Load() {
+		select {
+		case <-ticker.C:
+		case <-deadline.C:
+			slog.Warn("Queue worker drain timed out",
+				logattr.WorkerName(w.name),
+				logattr.RunningTasks(running),
+				logattr.Elapsed(time.Since(start)),
+			)
+			w.drainMetrics.duration.Record(context.Background(), time.Since(start).Seconds())
+			return
+		}
+	}
+
+	slog.Info("Queue worker drained",
+		logattr.WorkerName(w.name),
+		logattr.Elapsed(time.Since(start)),
+	)
+	w.drainMetrics.duration.Record(context.Background(), time.Since(start).Seconds())
+}
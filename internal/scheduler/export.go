@@ -0,0 +1,217 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/notifications"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/hibiken/asynq"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ExportTask is the task name for generating an export too large to stream
+// and emailing its download link. Its payload is a models.ExportRequest.
+const ExportTask = "export:generate"
+
+// ExportExpiryTask is the task name for deleting a stored account export once
+// its download link expired. Its payload is an ExportExpiryPayload.
+const ExportExpiryTask = "export:expire"
+
+// ExportExpiryPayload names the stored export to delete.
+type ExportExpiryPayload struct {
+	FileID string `json:"file_id"`
+}
+
+// exportTimeout bounds the generation and upload of one export.
+const exportTimeout = 10 * time.Minute
+
+type exportQueue struct {
+	taskEnqueuer TaskEnqueuer
+	queueName    string
+}
+
+// NewExportQueue returns the queue the export service hands large exports
+// to. Identical requests are enqueued once until the first is processed.
+func NewExportQueue(taskEnqueuer TaskEnqueuer, queueName string) services.ExportQueue {
+	return &exportQueue{
+		taskEnqueuer,
+		queueName,
+	}
+}
+
+func (q *exportQueue) EnqueueExport(ctx context.Context, request models.ExportRequest) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to marshal export task payload: %w", err))
+	}
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(ExportTask, payload, headers)
+	_, err = q.taskEnqueuer.Enqueue(
+		task,
+		asynq.Unique(exportTimeout),
+		asynq.Timeout(exportTimeout),
+		asynq.MaxRetry(3),
+		asynq.Queue(q.queueName),
+	)
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+		slog.ErrorContext(ctx, "Failed to enqueue export",
+			logattr.ExportKind(string(request.Kind)),
+			logattr.ExportFormat(string(request.Format)),
+			logattr.Queue(q.queueName),
+			logattr.Error(err),
+		)
+		return apperror.NewInternalError(fmt.Errorf("failed to enqueue export: %w", err))
+	}
+	return nil
+}
+
+// handleExport generates a queued export into file storage and emails its
+// download link. A retry regenerates the file, replacing the previous one.
+func (w *QueueWorker) handleExport(ctx context.Context, task *asynq.Task) error {
+	var request models.ExportRequest
+	if err := json.Unmarshal(task.Payload(), &request); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal export task payload",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal export task payload: %w", err)
+	}
+
+	ctx = observability.EnrichContext(ctx, request.UserID.Hex(), "")
+	observability.EnrichSpan(ctx)
+
+	user, err := w.userService.FetchUserByIDInternal(ctx, request.UserID)
+	if err != nil {
+		// The user was deleted while the export waited; nobody to send it to.
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			slog.DebugContext(ctx, "Skipping export: user not found",
+				logattr.Queue(w.queueName),
+			)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to fetch user for export",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	export, err := w.exportService.GenerateExportInternal(ctx, request)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to generate export",
+			logattr.ExportKind(string(request.Kind)),
+			logattr.ExportFormat(string(request.Format)),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to generate export: %w", err)
+	}
+
+	// An account export holds all of the user's personal data, so it is
+	// kept no longer than its link is valid. A retry replaces the file, and
+	// the expiry of the replaced one finds nothing to delete.
+	if request.Kind == models.AccountExport {
+		if err = w.scheduleExportExpiry(ctx, export); err != nil {
+			return err
+		}
+	}
+
+	ctx = w.localized(ctx, user, nil)
+	err = w.emailSender.SendExportReadyEmail(ctx, user.Email, user.Name, export)
+	err = w.recordNotification(ctx, user.ID, models.ChannelEmail, user.Email, notifications.TemplateExportReady, err)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send export ready email",
+			logattr.FileID(export.File.ID.Hex()),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to send export ready email: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Export sent",
+		logattr.ExportKind(string(request.Kind)),
+		logattr.ExportFormat(string(request.Format)),
+		logattr.FileID(export.File.ID.Hex()),
+		logattr.Queue(w.queueName),
+	)
+	return nil
+}
+
+// scheduleExportExpiry enqueues the deletion of export for when its link
+// expires. The task ID is derived from the file, so a retry of the export
+// task does not schedule the same deletion twice.
+func (w *QueueWorker) scheduleExportExpiry(ctx context.Context, export *models.SignedFile) error {
+	payload, err := json.Marshal(ExportExpiryPayload{FileID: export.File.ID.Hex()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal export expiry task payload: %w", err)
+	}
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(ExportExpiryTask, payload, headers)
+	_, err = w.taskEnqueuer.Enqueue(
+		task,
+		asynq.TaskID("export-expiry:"+export.File.ID.Hex()),
+		asynq.ProcessAt(export.ExpiresAt),
+		asynq.MaxRetry(3),
+		asynq.Queue(w.queueName),
+	)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		slog.ErrorContext(ctx, "Failed to schedule export expiry",
+			logattr.FileID(export.File.ID.Hex()),
+			logattr.ProcessAt(export.ExpiresAt),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to schedule export expiry: %w", err)
+	}
+	return nil
+}
+
+// handleExportExpiry deletes a stored account export whose link expired. A
+// file already gone was replaced by a newer export of the same account.
+func (w *QueueWorker) handleExportExpiry(ctx context.Context, task *asynq.Task) error {
+	var payload ExportExpiryPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal export expiry task payload",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal export expiry task payload: %w", err)
+	}
+	fileID, err := bson.ObjectIDFromHex(payload.FileID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid file ID",
+			logattr.FileID(payload.FileID),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("invalid file ID: %w", err)
+	}
+
+	if err = w.exportService.DeleteExportInternal(ctx, fileID); err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			slog.DebugContext(ctx, "Skipping export expiry: file not found",
+				logattr.FileID(payload.FileID),
+				logattr.Queue(w.queueName),
+			)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to delete expired export",
+			logattr.FileID(payload.FileID),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to delete expired export: %w", err)
+	}
+	return nil
+}
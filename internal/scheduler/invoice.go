@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/hibiken/asynq"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// InvoiceTask is the task name for generating the PDF invoice of a bill. Its
+// payload is an InvoicePayload.
+const InvoiceTask = "invoice:generate"
+
+// InvoicePayload names the bill to generate the invoice of.
+type InvoicePayload struct {
+	BillID string `json:"bill_id"`
+}
+
+// invoiceTimeout bounds the rendering and upload of one invoice.
+const invoiceTimeout = time.Minute
+
+// enqueueInvoice queues the invoice of the bill a bill event is about. Every
+// change of a bill raises an event, so the stored invoice follows its status.
+// The task ID is derived from the event ID, so a retried event does not
+// generate the invoice again.
+func (w *QueueWorker) enqueueInvoice(ctx context.Context, payload EventPayload) error {
+	body, err := json.Marshal(InvoicePayload{BillID: payload.AggregateID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal invoice task payload: %w", err)
+	}
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(InvoiceTask, body, headers)
+	_, err = w.taskEnqueuer.Enqueue(
+		task,
+		asynq.TaskID(payload.EventID+":invoice"),
+		asynq.Retention(24*time.Hour), // Keep the ID reserved after generation.
+		asynq.Timeout(invoiceTimeout),
+		asynq.MaxRetry(3),
+		asynq.Queue(w.queueName),
+	)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		slog.ErrorContext(ctx, "Failed to enqueue invoice",
+			logattr.EventID(payload.EventID),
+			logattr.BillID(payload.AggregateID),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to enqueue invoice: %w", err)
+	}
+	return nil
+}
+
+// handleInvoice generates the invoice of a bill into file storage. A bill
+// deleted in the meantime has nothing to invoice.
+func (w *QueueWorker) handleInvoice(ctx context.Context, task *asynq.Task) error {
+	var payload InvoicePayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal invoice task payload",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal invoice task payload: %w", err)
+	}
+
+	billID, err := bson.ObjectIDFromHex(payload.BillID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid bill ID",
+			logattr.BillID(payload.BillID),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("invalid bill ID: %w", err)
+	}
+
+	if _, err = w.invoiceService.GenerateInvoiceInternal(ctx, billID); err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			slog.DebugContext(ctx, "Skipping invoice: bill, subscription, or user not found",
+				logattr.BillID(payload.BillID),
+				logattr.Queue(w.queueName),
+			)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to generate invoice",
+			logattr.BillID(payload.BillID),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to generate invoice: %w", err)
+	}
+	return nil
+}
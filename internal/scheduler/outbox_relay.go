@@ -0,0 +1,219 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EventTaskPrefix prefixes the task type of every relayed domain event, e.g.
+// "event:subscription.created". The worker handles the whole prefix.
+const EventTaskPrefix = "event:"
+
+// EventPayload is the task body for a relayed domain event.
+type EventPayload struct {
+	EventID     string          `json:"event_id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	UserID      string          `json:"user_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// OutboxRelay moves committed domain events from the outbox collection onto the
+// task queue. Each task uses the event ID as its asynq task ID, so an event
+// re-sent after a crash between enqueue and acknowledgement is rejected as a
+// duplicate rather than delivered twice.
+type OutboxRelay struct {
+	outboxService services.OutboxServiceInternal
+	taskEnqueuer  TaskEnqueuer
+	interval      time.Duration
+	batchSize     int
+	queueName     string
+	name          string
+	tracer        trace.Tracer
+}
+
+// NewOutboxRelay creates a relay that polls the outbox every interval.
+func NewOutboxRelay(
+	outboxService services.OutboxServiceInternal,
+	redisConfig asynq.RedisConnOpt,
+	interval time.Duration,
+	batchSize int,
+	queueName string,
+	name string,
+) *OutboxRelay {
+	return &OutboxRelay{
+		outboxService: outboxService,
+		taskEnqueuer:  asynq.NewClient(redisConfig),
+		interval:      interval,
+		batchSize:     batchSize,
+		queueName:     queueName,
+		name:          name,
+		tracer:        otel.Tracer(name),
+	}
+}
+
+// Start runs the relay loop until ctx is canceled.
+func (r *OutboxRelay) Start(ctx context.Context) error {
+	slog.InfoContext(ctx, "Outbox relay started",
+		logattr.Queue(r.queueName),
+		logattr.Interval(r.interval),
+	)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			r.relayPending(ctx)
+		}
+	}
+}
+
+// relayPending publishes one batch of pending events in creation order. It
+// stops at the first failure so later events are not delivered ahead of an
+// earlier one; the failed event is retried on the next tick.
+func (r *OutboxRelay) relayPending(ctx context.Context) {
+	ctx, span := r.tracer.Start(ctx, "Relay Outbox Events")
+	defer span.End()
+
+	events, err := r.outboxService.FetchPendingEventsInternal(ctx, r.batchSize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to fetch pending outbox events")
+
+		slog.ErrorContext(ctx, "Failed to fetch pending outbox events",
+			logattr.Queue(r.queueName),
+			logattr.Error(err),
+		)
+		return
+	}
+
+	published := 0
+	for _, event := range events {
+		if err := r.publish(ctx, event); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Outbox relay stopped at a failed event")
+			break
+		}
+		published++
+	}
+
+	if published > 0 {
+		slog.InfoContext(ctx, "Outbox events relayed",
+			logattr.Total(len(events)),
+			logattr.Success(published),
+			logattr.Queue(r.queueName),
+		)
+	}
+}
+
+// publish enqueues a single event and marks it as published.
+// NOTE: This function owns its own telemetry. Any returned error is strictly
+// for control flow and has already been logged and attached to the trace.
+func (r *OutboxRelay) publish(ctx context.Context, event *models.OutboxEvent) error {
+	taskType := EventTaskPrefix + string(event.Type)
+	// Continue the trace of the operation that recorded the event, linked to
+	// this relay tick, so its consumers show up under the originating request.
+	relayTick := trace.LinkFromContext(ctx)
+	if len(event.TraceContext) > 0 {
+		ctx = observability.ExtractFromTaskHeaders(ctx, event.TraceContext)
+	}
+	ctx, span := r.tracer.Start(ctx, "Enqueue Event Task",
+		append(
+			observability.AsynqProducerAttributes(taskType, r.queueName),
+			trace.WithLinks(relayTick),
+		)...,
+	)
+	defer span.End()
+
+	payload := EventPayload{
+		EventID:     event.ID.Hex(),
+		Type:        string(event.Type),
+		AggregateID: event.AggregateID.Hex(),
+		UserID:      event.UserID.Hex(),
+		OccurredAt:  event.CreatedAt,
+		Data:        event.Payload,
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to marshal event payload")
+
+		slog.ErrorContext(ctx, "Failed to marshal event payload",
+			logattr.EventID(payload.EventID),
+			logattr.EventType(payload.Type),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(taskType, payloadBytes, headers)
+
+	info, err := r.taskEnqueuer.Enqueue(
+		task,
+		asynq.TaskID(payload.EventID), // Deduplicate re-relayed events.
+		asynq.Retention(24*time.Hour), // Keep the ID reserved after processing.
+		asynq.Timeout(30*time.Second), // Handler must finish in 30s.
+		asynq.MaxRetry(10),            // Consumers may be briefly unavailable.
+		asynq.Queue(r.queueName),
+	)
+	switch {
+	case errors.Is(err, asynq.ErrTaskIDConflict):
+		// Enqueued by an earlier attempt that failed to acknowledge.
+		slog.DebugContext(ctx, "Event already enqueued",
+			logattr.EventID(payload.EventID),
+			logattr.EventType(payload.Type),
+		)
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to enqueue event task")
+
+		slog.ErrorContext(ctx, "Failed to enqueue event task",
+			logattr.EventID(payload.EventID),
+			logattr.EventType(payload.Type),
+			logattr.Queue(r.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to enqueue event task: %w", err)
+	default:
+		span.SetAttributes(semconv.MessagingMessageID(info.ID))
+	}
+
+	if err := r.outboxService.MarkEventPublishedInternal(ctx, event.ID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to mark event as published")
+
+		slog.ErrorContext(ctx, "Failed to mark event as published",
+			logattr.EventID(payload.EventID),
+			logattr.EventType(payload.Type),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to mark event as published: %w", err)
+	}
+	return nil
+}
+
+// Close cleanly shuts down the relay.
+func (r *OutboxRelay) Close() error {
+	return r.taskEnqueuer.Close()
+}
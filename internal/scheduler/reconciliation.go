@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// reconciliationMetrics reports what the latest reconciliation found.
+type reconciliationMetrics struct {
+	findings metric.Int64Gauge
+	repaired metric.Int64Counter
+}
+
+func newReconciliationMetrics(name string) reconciliationMetrics {
+	meter := otel.Meter(name)
+
+	findings, err := meter.Int64Gauge(
+		"subscription.reconciliation.findings",
+		metric.WithDescription("Subscriptions the latest reconciliation found inconsistent with their bills, by issue"),
+	)
+	if err != nil {
+		slog.Error("Failed to create reconciliation findings gauge", logattr.WorkerName(name), logattr.Error(err))
+	}
+	repaired, err := meter.Int64Counter(
+		"subscription.reconciliation.repaired_total",
+		metric.WithDescription("Subscriptions reconciliation repaired"),
+	)
+	if err != nil {
+		slog.Error("Failed to create reconciliation repairs counter", logattr.WorkerName(name), logattr.Error(err))
+	}
+	return reconciliationMetrics{findings: findings, repaired: repaired}
+}
+
+// record reports the findings of report. Every issue is reported, so the
+// gauge of an issue that was fixed drops back to zero.
+func (m reconciliationMetrics) record(ctx context.Context, report *models.ReconciliationReport) {
+	for _, issue := range models.ReconciliationIssues {
+		m.findings.Record(ctx, int64(report.Counts[issue]),
+			metric.WithAttributes(attribute.String("issue", string(issue))),
+		)
+	}
+	m.repaired.Add(ctx, int64(report.Repaired))
+}
+
+// handleReconciliation checks subscription statuses against their bills and
+// reports the findings as metrics; admins read the full report through the
+// admin API.
+func (w *QueueWorker) handleReconciliation(ctx context.Context, _ *asynq.Task) error {
+	report, err := w.reconciliation.ReconcileInternal(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to reconcile subscriptions",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to reconcile subscriptions: %w", err)
+	}
+	w.reconciliationMetrics.record(ctx, report)
+
+	slog.InfoContext(ctx, "Subscriptions reconciled",
+		logattr.Subscriptions(report.Checked),
+		logattr.Success(report.Repaired),
+		logattr.Queue(w.queueName),
+	)
+	return nil
+}
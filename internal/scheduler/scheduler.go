@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
@@ -18,6 +21,7 @@ import (
 	"github.com/anuragthepathak/subscription-management/internal/observability"
 	"github.com/hibiken/asynq"
 	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
@@ -31,9 +35,42 @@ const (
 	RenewalTask = "subscription:renewal"
 	// ExpirationTask is the task name for subscription expiration.
 	ExpirationTask = "subscription:expiration"
+	// ArchiveTask is the task name for moving long-expired subscriptions to
+	// the archive.
+	ArchiveTask = "subscription:archive"
+	// ReminderDigestTask is the task name for sending a user the renewal
+	// reminders of a day in one notification.
+	ReminderDigestTask = "subscription:reminder_digest"
+	// TrialEndingTask is the task name for reminders that a free trial ends
+	// and the first charge is coming.
+	TrialEndingTask = "subscription:trial_ending"
+	// UnusedTask is the task name for suggesting the cancellation of an
+	// unused subscription before it renews.
+	UnusedTask = "subscription:unused_suggestion"
+	// DunningTask is the task name for retrying the failed renewal payment
+	// of a past-due subscription.
+	DunningTask = "subscription:dunning"
+	// ExchangeRateTask is the task name for refreshing the daily exchange
+	// rates.
+	ExchangeRateTask = "currency:refresh_rates"
+	// BudgetAlertTask is the task name for checking budgets and notifying
+	// users whose spend exceeds them.
+	BudgetAlertTask = "budget:check_alerts"
+	// BillRepairTask is the task name for deleting bills left behind by
+	// failed writes when transactions are disabled.
+	BillRepairTask = "bill:repair"
+	// ChargeSettlementTask is the task name for settling bills whose charge
+	// the payment provider was still processing.
+	ChargeSettlementTask = "bill:settle_charges"
+	// ReconciliationTask is the task name for checking subscription
+	// statuses against their bills.
+	ReconciliationTask = "subscription:reconcile"
 	// RenewalHoursBeforeDay is how many hours before the renewal date to process
 	// renewals
 	RenewalHoursBeforeDay = 4
+	// reminderBatchSize is how many subscriptions the reminder phase loads
+	// the owners' preferences for at once.
+	reminderBatchSize = 500
 )
 
 // ReminderPayload represents the data needed to process a reminder.
@@ -43,6 +80,19 @@ type ReminderPayload struct {
 	DaysBefore     int    `json:"days_before"`
 }
 
+// ReminderDigestPayload represents the data needed to send a reminder
+// digest.
+type ReminderDigestPayload struct {
+	UserID    string           `json:"user_id"`
+	Reminders []DigestReminder `json:"reminders"`
+}
+
+// DigestReminder is one reminder of a ReminderDigestPayload.
+type DigestReminder struct {
+	SubscriptionID string `json:"subscription_id"`
+	DaysBefore     int    `json:"days_before"`
+}
+
 // RenewalPayload represents the data needed to process an automatic renewal.
 type RenewalPayload struct {
 	SubscriptionID string `json:"subscription_id"`
@@ -55,14 +105,58 @@ type ExpirationPayload struct {
 	UserID         string `json:"user_id"`
 }
 
+// ArchivePayload represents the data needed to archive a subscription.
+type ArchivePayload struct {
+	SubscriptionID string `json:"subscription_id"`
+	UserID         string `json:"user_id"`
+}
+
+// UnusedPayload represents the data needed to send a savings suggestion.
+type UnusedPayload struct {
+	SubscriptionID string `json:"subscription_id"`
+	UserID         string `json:"user_id"`
+}
+
+// DunningPayload represents the data needed to retry a failed renewal
+// payment. Attempt counts from 1; the final attempt expires or suspends the
+// subscription, as FinalAction says, if the payment is still outstanding.
+// Tasks enqueued before FinalAction existed carry none and expire it.
+type DunningPayload struct {
+	SubscriptionID string                    `json:"subscription_id"`
+	UserID         string                    `json:"user_id"`
+	Attempt        int                       `json:"attempt"`
+	Final          bool                      `json:"final"`
+	FinalAction    models.DunningFinalAction `json:"final_action,omitempty"`
+}
+
+// ExchangeRatePayload represents the data needed to refresh exchange rates.
+type ExchangeRatePayload struct {
+	Date string `json:"date"` // Formatted as YYYY-MM-DD.
+}
+
 // SubscriptionScheduler handles scheduling of subscription-related tasks.
 type SubscriptionScheduler struct {
 	subscriptionService services.SubscriptionServiceInternal
+	preferenceService   services.NotificationPreferenceServiceInternal
 	redisClient         redis.UniversalClient
 	taskEnqueuer        TaskEnqueuer
 	interval            time.Duration
+	mu                  sync.RWMutex // Guards reminderDays.
 	reminderDays        []int
+	sendWindow          *models.SendWindow // Nil sends reminders at any time of day.
 	startupDelay        time.Duration
+	archiveAfterMonths  int
+	unusedAfterDays     int
+	unusedNoticeDays    int
+	dunningRetryDays    []int
+	dunningFinalAction  models.DunningFinalAction
+	refreshRates        bool
+	repairBills         bool
+	settleCharges       bool
+	reconcile           bool
+	cron                *CronSchedule    // Nil in poll mode.
+	cronScheduler       *asynq.Scheduler // Enqueues scans in cron mode.
+	scanServer          *asynq.Server    // Runs scans in cron mode.
 	queueName           string
 	name                string
 	getTime             clock.NowFn
@@ -75,41 +169,82 @@ type TaskEnqueuer interface {
 }
 
 // NewSubscriptionScheduler creates and initializes a new SubscriptionScheduler
-// with the provided dependencies and configuration.
+// with the provided dependencies and configuration. A nil cronSchedule polls
+// every interval; otherwise each scan runs on its own cron expression. A nil
+// sendWindow sends reminders at any time of day.
 func NewSubscriptionScheduler(
 	subscriptionService services.SubscriptionServiceInternal,
+	preferenceService services.NotificationPreferenceServiceInternal,
 	redisClient redis.UniversalClient,
 	redisConfig asynq.RedisConnOpt,
 	interval time.Duration,
 	reminderDays []int,
+	sendWindow *models.SendWindow,
 	startupDelay time.Duration,
+	archiveAfterMonths int,
+	unusedAfterDays int,
+	unusedNoticeDays int,
+	dunningRetryDays []int,
+	dunningFinalAction models.DunningFinalAction,
+	refreshRates bool,
+	repairBills bool,
+	settleCharges bool,
+	reconcile bool,
+	cronSchedule *CronSchedule,
 	queueName string,
 	name string,
 	nowFn clock.NowFn,
 ) *SubscriptionScheduler {
 	client := asynq.NewClient(redisConfig)
-	return &SubscriptionScheduler{
+	s := &SubscriptionScheduler{
 		subscriptionService: subscriptionService,
+		preferenceService:   preferenceService,
 		redisClient:         redisClient,
 		taskEnqueuer:        client,
 		interval:            interval,
 		reminderDays:        reminderDays,
+		sendWindow:          sendWindow,
 		startupDelay:        startupDelay,
+		archiveAfterMonths:  archiveAfterMonths,
+		unusedAfterDays:     unusedAfterDays,
+		unusedNoticeDays:    unusedNoticeDays,
+		dunningRetryDays:    dunningRetryDays,
+		dunningFinalAction:  dunningFinalAction,
+		refreshRates:        refreshRates,
+		repairBills:         repairBills,
+		settleCharges:       settleCharges,
+		reconcile:           reconcile,
+		cron:                cronSchedule,
 		queueName:           queueName,
 		name:                name,
 		getTime:             nowFn,
 		tracer:              otel.Tracer(name),
 	}
+	if cronSchedule != nil {
+		s.cronScheduler = asynq.NewScheduler(redisConfig, &asynq.SchedulerOpts{
+			Location:        cronSchedule.Location,
+			PostEnqueueFunc: s.logScanEnqueue,
+		})
+		s.scanServer = asynq.NewServer(redisConfig, asynq.Config{
+			Concurrency: 1, // Scans run one at a time, like a poll.
+			Queues:      map[string]int{ScanQueue: 1},
+		})
+	}
+	return s
 }
 
-// Start begins the scheduler loop.
+// Start begins the scheduler loop, or in cron mode the periodic scans.
 func (s *SubscriptionScheduler) Start(ctx context.Context) error {
+	if s.cron != nil {
+		return s.startCron(ctx)
+	}
+
 	slog.InfoContext(ctx, "Scheduler event loop started",
 		logattr.SchedulerName(s.name),
 		logattr.Queue(s.queueName),
 		logattr.Interval(s.interval),
 		logattr.StartupDelay(s.startupDelay),
-		logattr.ReminderDays(s.reminderDays),
+		logattr.ReminderDays(s.defaultReminderDays()),
 	)
 
 	delayTimer := time.NewTimer(s.startupDelay)
@@ -152,20 +287,10 @@ func (s *SubscriptionScheduler) pollSubscriptions(ctx context.Context) {
 	)
 
 	var errs []error
-
-	// Handle reminder tasks
-	if err := s.handleReminderTasks(ctx); err != nil {
-		errs = append(errs, err)
-	}
-
-	// Handle renewal tasks
-	if err := s.handleRenewalTasks(ctx); err != nil {
-		errs = append(errs, err)
-	}
-
-	// Handle expiration tasks
-	if err := s.handleExpirationTasks(ctx); err != nil {
-		errs = append(errs, err)
+	for _, p := range s.phases() {
+		if err := p.run(ctx); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
 	finalErr := errors.Join(errs...)
@@ -192,23 +317,71 @@ func (s *SubscriptionScheduler) handleReminderTasks(ctx context.Context) error {
 	)
 	defer span.End()
 
-	activeSubscriptions, err := s.getSubscriptionsDueForReminder(ctx)
+	days, err := s.reminderScanDays(ctx)
 	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "Failed to get subscriptions due for reminder")
+		span.SetStatus(codes.Error, "Failed to get reminder days")
 
-		slog.ErrorContext(ctx, "Failed to get subscriptions due for reminder",
+		slog.ErrorContext(ctx, "Failed to get reminder days",
 			logattr.Queue(s.queueName),
 			logattr.Error(err),
 		)
-		return fmt.Errorf("failed to get subscriptions due for reminder: %w", err)
+		return fmt.Errorf("failed to get reminder days: %w", err)
 	}
 
 	scheduled := 0
 	failed := 0
-	// Check each subscription for upcoming renewal dates.
-	for _, subscription := range activeSubscriptions {
-		if enqued, err := s.processReminderTask(ctx, subscription); err != nil {
+	// Check each subscription for upcoming renewal dates, loading the owners'
+	// preferences a batch at a time. The reminders of users who want a digest
+	// are collected and enqueued per user after the scan.
+	digests := make(map[bson.ObjectID][]*digestEntry)
+	digestPrefs := make(map[bson.ObjectID]*models.NotificationPreferences)
+	batch := make([]*models.Subscription, 0, reminderBatchSize)
+	processBatch := func() error {
+		userIDs := make([]bson.ObjectID, 0, len(batch))
+		for _, subscription := range batch {
+			userIDs = append(userIDs, subscription.UserID)
+		}
+		prefs, err := s.preferenceService.FetchPreferencesByUserIDsInternal(ctx, userIDs)
+		if err != nil {
+			return fmt.Errorf("failed to get notification preferences: %w", err)
+		}
+		for _, subscription := range batch {
+			if enqued, err := s.processReminderTask(ctx, subscription, prefs[subscription.UserID], digests); err != nil {
+				failed++
+			} else if enqued {
+				scheduled++
+			}
+			if _, ok := digests[subscription.UserID]; ok {
+				digestPrefs[subscription.UserID] = prefs[subscription.UserID]
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+	err = s.subscriptionService.EachUpcomingRenewalInternal(ctx, days, func(subscription *models.Subscription) error {
+		if batch = append(batch, subscription); len(batch) < reminderBatchSize {
+			return nil
+		}
+		return processBatch()
+	})
+	if err == nil && len(batch) > 0 {
+		err = processBatch()
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to scan subscriptions due for reminder")
+
+		slog.ErrorContext(ctx, "Failed to scan subscriptions due for reminder",
+			logattr.Success(scheduled),
+			logattr.Failed(failed),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to scan subscriptions due for reminder: %w", err)
+	}
+	for userID, entries := range digests {
+		if enqued, err := s.processReminderDigestTask(ctx, userID, entries, digestPrefs[userID]); err != nil {
 			failed++
 		} else if enqued {
 			scheduled++
@@ -242,30 +415,121 @@ func (s *SubscriptionScheduler) handleReminderTasks(ctx context.Context) error {
 	return nil
 }
 
-// getSubscriptionsDueForReminder retrieves subscriptions that are due for reminders.
-func (s *SubscriptionScheduler) getSubscriptionsDueForReminder(ctx context.Context) ([]*models.Subscription, error) {
-	return s.subscriptionService.FetchUpcomingRenewalsInternal(ctx, s.reminderDays)
+// SetReminderDays replaces the server's reminder days, as after a config
+// reload. The next scan uses them.
+func (s *SubscriptionScheduler) SetReminderDays(days []int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reminderDays = slices.Clone(days)
+	slog.Info("Scheduler reminder days changed",
+		logattr.SchedulerName(s.name),
+		logattr.ReminderDays(days),
+	)
+}
+
+// defaultReminderDays returns a copy of the server's reminder days.
+func (s *SubscriptionScheduler) defaultReminderDays() []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.reminderDays)
+}
+
+// reminderScanDays returns every day that the server defaults or a user's
+// preferences remind on. Subscriptions with reminder days of their own are
+// matched on those by the repository. Whether the owner wants a reminder that
+// day is decided per subscription.
+func (s *SubscriptionScheduler) reminderScanDays(ctx context.Context) ([]int, error) {
+	overrides, err := s.preferenceService.FetchReminderDaysInternal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	days := s.defaultReminderDays()
+	for _, d := range overrides {
+		if !slices.Contains(days, d) {
+			days = append(days, d)
+		}
+	}
+	return days, nil
 }
 
 // processReminderTask evaluates if a reminder should be sent for a subscription
-// and enqueues the task if necessary. It returns true if a task was successfully
-// enqueued, and false otherwise (e.g., if already sent or an error occurred).
+// and enqueues the task if necessary. A subscription whose trial ends at the
+// upcoming renewal gets a trial-ending reminder instead, deduplicated on its
+// own keys. The subscription's own reminder days, or else the owner's
+// preferences, decide the days reminders are sent on; the preferences decide
+// whether they are sent at all. Days are counted in the billing timezone of
+// the subscription, or else in the owner's. A reminder due in the owner's
+// quiet hours or outside the send window is held until sendAt. A renewal
+// reminder of an owner who wants a digest is added to digests instead of
+// being enqueued. It returns true if a task was successfully enqueued, and
+// false otherwise (e.g., if already sent, added to a digest, or an error
+// occurred).
 func (s *SubscriptionScheduler) processReminderTask(
-	ctx context.Context, subscription *models.Subscription,
+	ctx context.Context,
+	subscription *models.Subscription,
+	prefs *models.NotificationPreferences,
+	digests map[bson.ObjectID][]*digestEntry,
 ) (bool, error) {
+	taskType := ReminderTask
+	if subscription.InTrial() {
+		taskType = TrialEndingTask
+	}
+
 	ctx, span := s.tracer.Start(ctx, "Process Reminder Task",
 		trace.WithAttributes(
-			otelattr.TaskType(ReminderTask),
+			otelattr.TaskType(taskType),
 		),
 	)
 	defer span.End()
 	ctx = observability.EnrichContext(ctx, subscription.UserID.Hex(), subscription.ID.Hex())
 	observability.EnrichSpan(ctx)
 
-	daysBefore := lib.DaysBetween(s.getTime(), subscription.ValidTill, nil)
+	daysBefore := lib.DaysBetween(s.getTime(), subscription.ValidTill, subscription.BillingLocation(prefs.Location(time.Local)))
 	span.SetAttributes(otelattr.DaysBefore(daysBefore))
 
-	redisKey := fmt.Sprintf("reminder_sent:%s:%d", subscription.ID.Hex(), daysBefore)
+	wanted := subscription.ReminderDaysOr(prefs.ReminderDaysOr(s.defaultReminderDays()))
+	if len(prefs.Channels) == 0 || !slices.Contains(wanted, daysBefore) {
+		span.SetStatus(codes.Ok, "Reminder not wanted")
+
+		slog.DebugContext(ctx, "Skipping reminder excluded by preferences",
+			logattr.DaysBefore(daysBefore),
+			logattr.RenewalDate(subscription.ValidTill),
+			logattr.Queue(s.queueName),
+		)
+		return false, nil
+	}
+
+	// Snooze markers expire when the snooze ends, so an existing one holds the
+	// reminder back.
+	snoozed, err := s.redisClient.Exists(ctx, services.ReminderSnoozedKey(subscription.ID, subscription.ValidTill)).Result()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to check Redis for reminder snooze")
+
+		slog.ErrorContext(ctx, "Failed to check Redis for reminder snooze",
+			logattr.DaysBefore(daysBefore),
+			logattr.RenewalDate(subscription.ValidTill),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return false, fmt.Errorf("failed to check Redis for reminder snooze: %w", err)
+	}
+	if snoozed > 0 {
+		span.SetStatus(codes.Ok, "Reminders snoozed")
+
+		slog.DebugContext(ctx, "Skipping snoozed reminder",
+			logattr.DaysBefore(daysBefore),
+			logattr.RenewalDate(subscription.ValidTill),
+			logattr.Queue(s.queueName),
+		)
+		return false, nil
+	}
+
+	redisKey := services.ReminderSentKey(subscription.ID, daysBefore)
+	if taskType == TrialEndingTask {
+		redisKey = services.TrialEndingSentKey(subscription.ID, daysBefore)
+	}
 	exists, err := s.redisClient.Exists(ctx, redisKey).Result()
 	if err != nil {
 		span.RecordError(err)
@@ -290,7 +554,31 @@ func (s *SubscriptionScheduler) processReminderTask(
 		return false, nil
 	}
 
-	taskID, err := s.scheduleReminderTask(ctx, subscription, daysBefore)
+	if taskType == ReminderTask && prefs.Digest {
+		digests[subscription.UserID] = append(digests[subscription.UserID], &digestEntry{subscription, daysBefore})
+		span.SetStatus(codes.Ok, "Reminder added to digest")
+
+		slog.DebugContext(ctx, "Reminder added to digest",
+			logattr.DaysBefore(daysBefore),
+			logattr.RenewalDate(subscription.ValidTill),
+			logattr.Queue(s.queueName),
+		)
+		return false, nil
+	}
+
+	taskID, err := s.scheduleReminderTask(ctx, taskType, subscription, daysBefore, sendAt(s.getTime(), prefs, s.sendWindow))
+	if errors.Is(err, asynq.ErrDuplicateTask) {
+		// A reminder held back by quiet hours or the send window is still
+		// pending.
+		span.SetStatus(codes.Ok, "Reminder already enqueued")
+
+		slog.DebugContext(ctx, "Reminder already enqueued",
+			logattr.DaysBefore(daysBefore),
+			logattr.RenewalDate(subscription.ValidTill),
+			logattr.Queue(s.queueName),
+		)
+		return false, nil
+	}
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to schedule reminder task")
@@ -312,11 +600,14 @@ func (s *SubscriptionScheduler) processReminderTask(
 	return true, nil
 }
 
-// scheduleReminderTask creates and enqueues a reminder task.
-func (s *SubscriptionScheduler) scheduleReminderTask(ctx context.Context, subscription *models.Subscription, daysBefore int) (string, error) {
+// scheduleReminderTask creates and enqueues a reminder task of the given type.
+// A non-zero processAt delays the task until then.
+func (s *SubscriptionScheduler) scheduleReminderTask(
+	ctx context.Context, taskType string, subscription *models.Subscription, daysBefore int, processAt time.Time,
+) (string, error) {
 	// Create a dedicated child span for the network boundary
 	ctx, span := s.tracer.Start(ctx, "Enqueue Reminder Task",
-		observability.AsynqProducerAttributes(ReminderTask, s.queueName)...,
+		observability.AsynqProducerAttributes(taskType, s.queueName)...,
 	)
 	defer span.End()
 
@@ -334,16 +625,20 @@ func (s *SubscriptionScheduler) scheduleReminderTask(ctx context.Context, subscr
 	}
 
 	headers := observability.InjectIntoTaskHeaders(ctx)
-	task := asynq.NewTaskWithHeaders(ReminderTask, payloadBytes, headers)
+	task := asynq.NewTaskWithHeaders(taskType, payloadBytes, headers)
 
-	info, err := s.taskEnqueuer.Enqueue(
-		task,
-		asynq.Unique(24*time.Hour),    // Prevent duplicate pending tasks.
-		asynq.Retention(24*time.Hour), // Keep task for 24h after processing.
-		asynq.Timeout(45*time.Second), // Handler must finish in 45s.
-		asynq.MaxRetry(3),             // Retry up to 3 times if failed.
+	opts := []asynq.Option{
+		asynq.Unique(24 * time.Hour),    // Prevent duplicate pending tasks.
+		asynq.Retention(24 * time.Hour), // Keep task for 24h after processing.
+		asynq.Timeout(45 * time.Second), // Handler must finish in 45s.
+		asynq.MaxRetry(3),               // Retry up to 3 times if failed.
 		asynq.Queue(s.queueName),
-	)
+	}
+	if !processAt.IsZero() {
+		opts = append(opts, asynq.ProcessAt(processAt)) // Wait out quiet hours and the send window.
+	}
+
+	info, err := s.taskEnqueuer.Enqueue(task, opts...)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to enqueue reminder task")
@@ -354,6 +649,133 @@ func (s *SubscriptionScheduler) scheduleReminderTask(ctx context.Context, subscr
 	return info.ID, nil
 }
 
+// digestEntry is a reminder due today of a user who wants a digest.
+type digestEntry struct {
+	subscription *models.Subscription
+	daysBefore   int
+}
+
+// processReminderDigestTask enqueues one task sending the reminders of
+// entries, all owned by userID, as a digest. A single reminder is enqueued as
+// a regular one. Like other reminders, the digest is held until sendAt. It
+// returns true if a task was successfully enqueued.
+func (s *SubscriptionScheduler) processReminderDigestTask(
+	ctx context.Context,
+	userID bson.ObjectID,
+	entries []*digestEntry,
+	prefs *models.NotificationPreferences,
+) (bool, error) {
+	ctx, span := s.tracer.Start(ctx, "Process Reminder Digest Task",
+		trace.WithAttributes(
+			otelattr.TaskType(ReminderDigestTask),
+			otelattr.ReminderCount(len(entries)),
+		),
+	)
+	defer span.End()
+	ctx = observability.EnrichContext(ctx, userID.Hex(), "")
+	observability.EnrichSpan(ctx)
+
+	processAt := sendAt(s.getTime(), prefs, s.sendWindow)
+	var (
+		taskID string
+		err    error
+	)
+	if len(entries) == 1 {
+		taskID, err = s.scheduleReminderTask(
+			ctx, ReminderTask, entries[0].subscription, entries[0].daysBefore, processAt,
+		)
+	} else {
+		taskID, err = s.scheduleReminderDigestTask(ctx, userID, entries, processAt)
+	}
+	if errors.Is(err, asynq.ErrDuplicateTask) {
+		// A digest held back by quiet hours or the send window is still
+		// pending.
+		span.SetStatus(codes.Ok, "Reminder digest already enqueued")
+
+		slog.DebugContext(ctx, "Reminder digest already enqueued",
+			logattr.Total(len(entries)),
+			logattr.Queue(s.queueName),
+		)
+		return false, nil
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to schedule reminder digest task")
+
+		slog.ErrorContext(ctx, "Failed to schedule reminder digest task",
+			logattr.Total(len(entries)),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return false, fmt.Errorf("failed to schedule reminder digest task: %w", err)
+	}
+	slog.DebugContext(ctx, "Reminder digest task enqueued successfully",
+		logattr.TaskID(taskID),
+		logattr.Total(len(entries)),
+		logattr.Queue(s.queueName),
+	)
+	return true, nil
+}
+
+// scheduleReminderDigestTask creates and enqueues a reminder digest task. The
+// reminders are sorted, so the same digest found on the next poll is a
+// duplicate while the task is pending. A non-zero processAt delays the task
+// until then.
+func (s *SubscriptionScheduler) scheduleReminderDigestTask(
+	ctx context.Context, userID bson.ObjectID, entries []*digestEntry, processAt time.Time,
+) (string, error) {
+	// Create a dedicated child span for the network boundary
+	ctx, span := s.tracer.Start(ctx, "Enqueue Reminder Digest Task",
+		observability.AsynqProducerAttributes(ReminderDigestTask, s.queueName)...,
+	)
+	defer span.End()
+
+	payload := ReminderDigestPayload{
+		UserID:    userID.Hex(),
+		Reminders: make([]DigestReminder, len(entries)),
+	}
+	for i, entry := range entries {
+		payload.Reminders[i] = DigestReminder{
+			SubscriptionID: entry.subscription.ID.Hex(),
+			DaysBefore:     entry.daysBefore,
+		}
+	}
+	slices.SortFunc(payload.Reminders, func(a, b DigestReminder) int {
+		return strings.Compare(a.SubscriptionID, b.SubscriptionID)
+	})
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to marshal reminder digest task payload")
+		return "", fmt.Errorf("failed to marshal reminder digest payload: %w", err)
+	}
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(ReminderDigestTask, payloadBytes, headers)
+
+	opts := []asynq.Option{
+		asynq.Unique(24 * time.Hour),    // Prevent duplicate pending tasks.
+		asynq.Retention(24 * time.Hour), // Keep task for 24h after processing.
+		asynq.Timeout(45 * time.Second), // Handler must finish in 45s.
+		asynq.MaxRetry(3),               // Retry up to 3 times if failed.
+		asynq.Queue(s.queueName),
+	}
+	if !processAt.IsZero() {
+		opts = append(opts, asynq.ProcessAt(processAt)) // Wait out quiet hours and the send window.
+	}
+
+	info, err := s.taskEnqueuer.Enqueue(task, opts...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to enqueue reminder digest task")
+		return "", fmt.Errorf("failed to enqueue reminder digest task: %w", err)
+	}
+	span.SetAttributes(semconv.MessagingMessageID(info.ID))
+
+	return info.ID, nil
+}
+
 // handleRenewalTasks checks for subscriptions needing automatic renewal and
 // schedules tasks.
 func (s *SubscriptionScheduler) handleRenewalTasks(ctx context.Context) error {
@@ -365,26 +787,29 @@ func (s *SubscriptionScheduler) handleRenewalTasks(ctx context.Context) error {
 	)
 	defer span.End()
 
-	renewalSubscriptions, err := s.getSubscriptionsDueForRenewal(ctx)
+	scheduled := 0
+	failed := 0
+	windowStart, windowEnd := s.renewalWindow()
+	err := s.subscriptionService.EachSubscriptionDueForRenewalInternal(ctx, windowStart, windowEnd,
+		func(subscription *models.Subscription) error {
+			if taskID, err := s.scheduleRenewalTask(ctx, subscription); err != nil {
+				failed++
+			} else if taskID != "" {
+				scheduled++
+			}
+			return nil
+		})
 	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "Failed to get subscriptions due for renewal")
+		span.SetStatus(codes.Error, "Failed to scan subscriptions due for renewal")
 
-		slog.ErrorContext(ctx, "Failed to get subscriptions due for renewal",
+		slog.ErrorContext(ctx, "Failed to scan subscriptions due for renewal",
+			logattr.Success(scheduled),
+			logattr.Failed(failed),
 			logattr.Queue(s.queueName),
 			logattr.Error(err),
 		)
-		return fmt.Errorf("failed to get subscriptions due for renewal: %w", err)
-	}
-
-	scheduled := 0
-	failed := 0
-	for _, subscription := range renewalSubscriptions {
-		if _, err := s.scheduleRenewalTask(ctx, subscription); err != nil {
-			failed++
-		} else {
-			scheduled++
-		}
+		return fmt.Errorf("failed to scan subscriptions due for renewal: %w", err)
 	}
 
 	total := scheduled + failed
@@ -414,18 +839,22 @@ func (s *SubscriptionScheduler) handleRenewalTasks(ctx context.Context) error {
 	return nil
 }
 
-// getSubscriptionsDueForRenewal retrieves subscriptions that are due for
-// automatic renewal.
-func (s *SubscriptionScheduler) getSubscriptionsDueForRenewal(ctx context.Context) ([]*models.Subscription, error) {
+// renewalWindow returns the range of renewal dates due for automatic
+// renewal. In cron mode the window extends by the renewal lookahead, and the
+// renewals are scheduled for when they are due.
+func (s *SubscriptionScheduler) renewalWindow() (time.Time, time.Time) {
 	// Calculate time range: now to RenewalHoursBeforeDay hours ahead
 	now := s.getTime()
 	renewalWindowStart := now.Add(-RenewalHoursBeforeDay * time.Hour)
 	renewalWindowEnd := now.Add(RenewalHoursBeforeDay * time.Hour)
-
-	return s.subscriptionService.FetchSubscriptionsDueForRenewalInternal(ctx, renewalWindowStart, renewalWindowEnd)
+	if s.cron != nil {
+		renewalWindowEnd = renewalWindowEnd.Add(s.cron.RenewalLookahead)
+	}
+	return renewalWindowStart, renewalWindowEnd
 }
 
-// scheduleRenewalTask creates and enqueues a renewal task.
+// scheduleRenewalTask creates and enqueues a renewal task. It returns an empty
+// task ID if the renewal is already scheduled.
 func (s *SubscriptionScheduler) scheduleRenewalTask(ctx context.Context, subscription *models.Subscription) (string, error) {
 	// Create a dedicated child span for the network boundary
 	ctx, span := s.tracer.Start(ctx, "Enqueue Renewal Task",
@@ -464,16 +893,29 @@ func (s *SubscriptionScheduler) scheduleRenewalTask(ctx context.Context, subscri
 		processAt = s.getTime()
 	}
 	span.SetAttributes(otelattr.ProcessAt(processAt))
+	// A renewal scheduled ahead stays unique until a day after it is due.
+	uniqueFor := processAt.Sub(s.getTime()) + 24*time.Hour
 
 	info, err := s.taskEnqueuer.Enqueue(
 		task,
-		asynq.Unique(24*time.Hour),    // Prevent duplicate pending tasks.
+		asynq.Unique(uniqueFor),       // Prevent duplicate pending tasks.
 		asynq.Retention(24*time.Hour), // Keep task for 24h after processing.
 		asynq.Timeout(45*time.Second), // Handler must finish in 45s.
 		asynq.MaxRetry(5),             // Retry up to 5 times if failed.
 		asynq.ProcessAt(processAt),
 		asynq.Queue(s.queueName),
 	)
+	if errors.Is(err, asynq.ErrDuplicateTask) {
+		// Scheduled ahead by an earlier scan.
+		span.SetStatus(codes.Ok, "Renewal already scheduled")
+
+		slog.DebugContext(ctx, "Renewal task already scheduled",
+			logattr.ProcessAt(processAt),
+			logattr.RenewalDate(subscription.ValidTill),
+			logattr.Queue(s.queueName),
+		)
+		return "", nil
+	}
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to enqueue renewal task")
@@ -508,27 +950,30 @@ func (s *SubscriptionScheduler) handleExpirationTasks(ctx context.Context) error
 	)
 	defer span.End()
 
-	expiringSubscriptions, err := s.getSubscriptionsDueForExpiration(ctx)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "Failed to get subscriptions due for expiration")
-
-		slog.ErrorContext(ctx, "Failed to get subscriptions due for expiration",
-			logattr.Queue(s.queueName),
-			logattr.Error(err),
-		)
-		return fmt.Errorf("failed to get subscriptions due for expiration: %w", err)
-	}
-
 	scheduled := 0
 	failed := 0
-	for _, subscription := range expiringSubscriptions {
+	// Canceled subscriptions past their validity period but not marked as
+	// expired yet.
+	err := s.subscriptionService.EachCanceledExpiredSubscriptionInternal(ctx, func(subscription *models.Subscription) error {
 		// We receive the error purely for control flow. Telemetry is handled by the child.
 		if _, err := s.scheduleExpirationTask(ctx, subscription); err != nil {
 			failed++
 		} else {
 			scheduled++
 		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to scan subscriptions due for expiration")
+
+		slog.ErrorContext(ctx, "Failed to scan subscriptions due for expiration",
+			logattr.Success(scheduled),
+			logattr.Failed(failed),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to scan subscriptions due for expiration: %w", err)
 	}
 
 	// The 100% Failure Catch (Catastrophic Infrastructure Failure)
@@ -559,14 +1004,6 @@ func (s *SubscriptionScheduler) handleExpirationTasks(ctx context.Context) error
 	return nil
 }
 
-// getSubscriptionsDueForExpiration retrieves subscriptions that have reached
-// their validity end date but are not yet marked as expired.
-func (s *SubscriptionScheduler) getSubscriptionsDueForExpiration(ctx context.Context) ([]*models.Subscription, error) {
-	// Get canceled subscriptions that are past their validity period but not
-	// marked as expired yet
-	return s.subscriptionService.FetchCanceledExpiredSubscriptionsInternal(ctx)
-}
-
 // scheduleExpirationTask creates and enqueues a subscription expiration task.
 // NOTE: This function owns its own telemetry. Any returned error is strictly
 // for control flow and has already been logged and attached to the trace.
@@ -630,7 +1067,669 @@ func (s *SubscriptionScheduler) scheduleExpirationTask(ctx context.Context, subs
 	return info.ID, nil
 }
 
-// Close cleanly shuts down the scheduler.
-func (s *SubscriptionScheduler) Close() error {
+// handleArchiveTasks checks for subscriptions expired longer than the
+// retention period and schedules tasks to move them to the archive.
+func (s *SubscriptionScheduler) handleArchiveTasks(ctx context.Context) error {
+	ctx = appctx.WithTaskType(ctx, ArchiveTask)
+	ctx, span := s.tracer.Start(ctx, "Phase: Archive Tasks",
+		trace.WithAttributes(
+			otelattr.TaskType(ArchiveTask),
+		),
+	)
+	defer span.End()
+
+	scheduled := 0
+	failed := 0
+	err := s.subscriptionService.EachArchivableSubscriptionInternal(ctx, s.archiveAfterMonths,
+		func(subscription *models.Subscription) error {
+			if _, err := s.scheduleArchiveTask(ctx, subscription); err != nil {
+				failed++
+			} else {
+				scheduled++
+			}
+			return nil
+		})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to scan subscriptions due for archival")
+
+		slog.ErrorContext(ctx, "Failed to scan subscriptions due for archival",
+			logattr.Success(scheduled),
+			logattr.Failed(failed),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to scan subscriptions due for archival: %w", err)
+	}
+
+	total := scheduled + failed
+	if total > 0 && failed == total {
+		err := errors.New("100% archive task enqueue failure rate detected")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Catastrophic archive task enqueue failure")
+
+		slog.ErrorContext(ctx, "All archive tasks failed to enqueue",
+			logattr.Total(total),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		// Return to pollSubscriptions so the roll-up log knows the Phase died
+		return err
+	}
+
+	if scheduled > 0 {
+		slog.InfoContext(ctx, "Archive tasks scheduled",
+			logattr.Total(total),
+			logattr.Success(scheduled),
+			logattr.Failed(failed),
+			logattr.Queue(s.queueName),
+		)
+	}
+
+	return nil
+}
+
+// scheduleArchiveTask creates and enqueues a subscription archive task.
+// NOTE: This function owns its own telemetry. Any returned error is strictly
+// for control flow and has already been logged and attached to the trace.
+func (s *SubscriptionScheduler) scheduleArchiveTask(ctx context.Context, subscription *models.Subscription) (string, error) {
+	ctx, span := s.tracer.Start(ctx, "Enqueue Archive Task",
+		observability.AsynqProducerAttributes(ArchiveTask, s.queueName)...,
+	)
+	defer span.End()
+	ctx = observability.EnrichContext(ctx, subscription.UserID.Hex(), subscription.ID.Hex())
+	observability.EnrichSpan(ctx)
+
+	payload := ArchivePayload{
+		SubscriptionID: subscription.ID.Hex(),
+		UserID:         subscription.UserID.Hex(),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to marshal archive payload")
+
+		slog.ErrorContext(ctx, "Failed to marshal archive payload",
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return "", fmt.Errorf("failed to marshal archive payload: %w", err)
+	}
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(ArchiveTask, payloadBytes, headers)
+
+	info, err := s.taskEnqueuer.Enqueue(
+		task,
+		asynq.Unique(24*time.Hour),    // Prevent duplicate pending tasks
+		asynq.Retention(24*time.Hour), // Keep task for 24h after processing
+		asynq.Timeout(60*time.Second), // Handler must finish in 60s
+		asynq.MaxRetry(3),             // Retry up to 3 times if failed
+		asynq.Queue("low"),            // Housekeeping, not user-facing
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to enqueue archive task")
+
+		slog.ErrorContext(ctx, "Failed to enqueue archive task",
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return "", fmt.Errorf("failed to enqueue archive task: %w", err)
+	}
+	span.SetAttributes(semconv.MessagingMessageID(info.ID))
+
+	slog.DebugContext(ctx, "Archive task enqueued",
+		logattr.TaskID(info.ID),
+		logattr.Queue(s.queueName),
+	)
+
+	return info.ID, nil
+}
+
+// handleUnusedTasks checks for subscriptions that have gone unused before
+// their renewal and schedules savings suggestion tasks.
+func (s *SubscriptionScheduler) handleUnusedTasks(ctx context.Context) error {
+	ctx = appctx.WithTaskType(ctx, UnusedTask)
+	ctx, span := s.tracer.Start(ctx, "Phase: Unused Subscription Tasks",
+		trace.WithAttributes(
+			otelattr.TaskType(UnusedTask),
+		),
+	)
+	defer span.End()
+
+	scheduled := 0
+	failed := 0
+	err := s.subscriptionService.EachUnusedSubscriptionInternal(ctx, s.unusedAfterDays, s.unusedNoticeDays,
+		func(subscription *models.Subscription) error {
+			if enqueued, err := s.processUnusedTask(ctx, subscription); err != nil {
+				failed++
+			} else if enqueued {
+				scheduled++
+			}
+			return nil
+		})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to scan unused subscriptions")
+
+		slog.ErrorContext(ctx, "Failed to scan unused subscriptions",
+			logattr.Success(scheduled),
+			logattr.Failed(failed),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to scan unused subscriptions: %w", err)
+	}
+
+	total := scheduled + failed
+	if total > 0 && failed == total {
+		err := errors.New("100% unused subscription task enqueue failure rate detected")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Catastrophic unused subscription task enqueue failure")
+
+		slog.ErrorContext(ctx, "All unused subscription tasks failed to enqueue",
+			logattr.Total(total),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		// Return to pollSubscriptions so the roll-up log knows the Phase died
+		return err
+	}
+
+	if scheduled > 0 {
+		slog.InfoContext(ctx, "Unused subscription tasks scheduled",
+			logattr.Total(total),
+			logattr.Success(scheduled),
+			logattr.Failed(failed),
+			logattr.Queue(s.queueName),
+		)
+	}
+
+	return nil
+}
+
+// processUnusedTask enqueues a savings suggestion for a subscription unless one
+// was already sent for its upcoming renewal. It returns true if a task was
+// enqueued.
+// NOTE: This function owns its own telemetry. Any returned error is strictly
+// for control flow and has already been logged and attached to the trace.
+func (s *SubscriptionScheduler) processUnusedTask(
+	ctx context.Context, subscription *models.Subscription,
+) (bool, error) {
+	ctx, span := s.tracer.Start(ctx, "Enqueue Unused Subscription Task",
+		observability.AsynqProducerAttributes(UnusedTask, s.queueName)...,
+	)
+	defer span.End()
+	ctx = observability.EnrichContext(ctx, subscription.UserID.Hex(), subscription.ID.Hex())
+	observability.EnrichSpan(ctx)
+
+	redisKey := services.UnusedSuggestionSentKey(subscription.ID, subscription.ValidTill)
+	exists, err := s.redisClient.Exists(ctx, redisKey).Result()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to check Redis for sent suggestion")
+
+		slog.ErrorContext(ctx, "Failed to check Redis for sent suggestion",
+			logattr.RenewalDate(subscription.ValidTill),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return false, fmt.Errorf("failed to check Redis for sent suggestion: %w", err)
+	}
+	if exists > 0 {
+		return false, nil
+	}
+
+	payload := UnusedPayload{
+		SubscriptionID: subscription.ID.Hex(),
+		UserID:         subscription.UserID.Hex(),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to marshal unused subscription payload")
+
+		slog.ErrorContext(ctx, "Failed to marshal unused subscription payload",
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return false, fmt.Errorf("failed to marshal unused subscription payload: %w", err)
+	}
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(UnusedTask, payloadBytes, headers)
+
+	info, err := s.taskEnqueuer.Enqueue(
+		task,
+		asynq.Unique(24*time.Hour),    // Prevent duplicate pending tasks
+		asynq.Retention(24*time.Hour), // Keep task for 24h after processing
+		asynq.Timeout(45*time.Second), // Handler must finish in 45s
+		asynq.MaxRetry(3),             // Retry up to 3 times if failed
+		asynq.Queue(s.queueName),
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to enqueue unused subscription task")
+
+		slog.ErrorContext(ctx, "Failed to enqueue unused subscription task",
+			logattr.RenewalDate(subscription.ValidTill),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return false, fmt.Errorf("failed to enqueue unused subscription task: %w", err)
+	}
+	span.SetAttributes(semconv.MessagingMessageID(info.ID))
+
+	slog.DebugContext(ctx, "Unused subscription task enqueued",
+		logattr.TaskID(info.ID),
+		logattr.RenewalDate(subscription.ValidTill),
+		logattr.Queue(s.queueName),
+	)
+	return true, nil
+}
+
+// handleDunningTasks schedules the next payment retry of past-due
+// subscriptions once its day has come.
+func (s *SubscriptionScheduler) handleDunningTasks(ctx context.Context) error {
+	ctx = appctx.WithTaskType(ctx, DunningTask)
+	ctx, span := s.tracer.Start(ctx, "Phase: Dunning Tasks",
+		trace.WithAttributes(
+			otelattr.TaskType(DunningTask),
+		),
+	)
+	defer span.End()
+
+	scheduled := 0
+	failed := 0
+	err := s.subscriptionService.EachPastDueSubscriptionInternal(ctx, func(subscription *models.Subscription) error {
+		if enqueued, err := s.processDunningTask(ctx, subscription); err != nil {
+			failed++
+		} else if enqueued {
+			scheduled++
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to scan past-due subscriptions")
+
+		slog.ErrorContext(ctx, "Failed to scan past-due subscriptions",
+			logattr.Success(scheduled),
+			logattr.Failed(failed),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to scan past-due subscriptions: %w", err)
+	}
+
+	total := scheduled + failed
+	if total > 0 && failed == total {
+		err := errors.New("100% dunning task enqueue failure rate detected")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Catastrophic dunning task enqueue failure")
+
+		slog.ErrorContext(ctx, "All dunning tasks failed to enqueue",
+			logattr.Total(total),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		// Return to pollSubscriptions so the roll-up log knows the Phase died
+		return err
+	}
+
+	if scheduled > 0 {
+		slog.InfoContext(ctx, "Dunning tasks scheduled",
+			logattr.Total(total),
+			logattr.Success(scheduled),
+			logattr.Failed(failed),
+			logattr.Queue(s.queueName),
+		)
+	}
+
+	return nil
+}
+
+// processDunningTask enqueues the next payment retry of a past-due
+// subscription if it is due. The attempt is part of the payload, so a retry
+// that is still pending is not enqueued twice. It returns true if a task was
+// enqueued.
+// NOTE: This function owns its own telemetry. Any returned error is strictly
+// for control flow and has already been logged and attached to the trace.
+func (s *SubscriptionScheduler) processDunningTask(
+	ctx context.Context, subscription *models.Subscription,
+) (bool, error) {
+	// Suspended subscriptions had their final retry already.
+	if subscription.Status != models.PastDue || subscription.Dunning == nil ||
+		subscription.Dunning.Attempts >= len(s.dunningRetryDays) {
+		return false, nil
+	}
+	attempt := subscription.Dunning.Attempts + 1
+	dueAt := subscription.Dunning.StartedAt.AddDate(0, 0, s.dunningRetryDays[attempt-1])
+	if s.getTime().Before(dueAt) {
+		return false, nil
+	}
+
+	ctx, span := s.tracer.Start(ctx, "Enqueue Dunning Task",
+		observability.AsynqProducerAttributes(DunningTask, s.queueName)...,
+	)
+	defer span.End()
+	ctx = observability.EnrichContext(ctx, subscription.UserID.Hex(), subscription.ID.Hex())
+	observability.EnrichSpan(ctx)
+
+	payload := DunningPayload{
+		SubscriptionID: subscription.ID.Hex(),
+		UserID:         subscription.UserID.Hex(),
+		Attempt:        attempt,
+		Final:          attempt == len(s.dunningRetryDays),
+		FinalAction:    s.dunningFinalAction,
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to marshal dunning payload")
+
+		slog.ErrorContext(ctx, "Failed to marshal dunning payload",
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return false, fmt.Errorf("failed to marshal dunning payload: %w", err)
+	}
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(DunningTask, payloadBytes, headers)
+
+	info, err := s.taskEnqueuer.Enqueue(
+		task,
+		asynq.Unique(24*time.Hour),    // Prevent duplicate pending tasks
+		asynq.Retention(24*time.Hour), // Keep task for 24h after processing
+		asynq.Timeout(45*time.Second), // Handler must finish in 45s
+		asynq.MaxRetry(3),             // Retry up to 3 times if failed
+		asynq.Queue(s.queueName),
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to enqueue dunning task")
+
+		slog.ErrorContext(ctx, "Failed to enqueue dunning task",
+			logattr.Attempt(attempt),
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return false, fmt.Errorf("failed to enqueue dunning task: %w", err)
+	}
+	span.SetAttributes(semconv.MessagingMessageID(info.ID))
+
+	slog.DebugContext(ctx, "Dunning task enqueued",
+		logattr.TaskID(info.ID),
+		logattr.Attempt(attempt),
+		logattr.Queue(s.queueName),
+	)
+	return true, nil
+}
+
+// scheduleExchangeRateTask enqueues a refresh of today's exchange rates. The
+// task is unique per day for an hour, so schedulers polling at the same time
+// fetch the rates once.
+// NOTE: This function owns its own telemetry. Any returned error is strictly
+// for control flow and has already been logged and attached to the trace.
+func (s *SubscriptionScheduler) scheduleExchangeRateTask(ctx context.Context) error {
+	ctx = appctx.WithTaskType(ctx, ExchangeRateTask)
+	ctx, span := s.tracer.Start(ctx, "Enqueue Exchange Rate Task",
+		observability.AsynqProducerAttributes(ExchangeRateTask, s.queueName)...,
+	)
+	defer span.End()
+
+	payload := ExchangeRatePayload{
+		Date: s.getTime().UTC().Format(time.DateOnly),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to marshal exchange rate payload")
+
+		slog.ErrorContext(ctx, "Failed to marshal exchange rate payload",
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to marshal exchange rate payload: %w", err)
+	}
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(ExchangeRateTask, payloadBytes, headers)
+
+	info, err := s.taskEnqueuer.Enqueue(
+		task,
+		asynq.Unique(time.Hour),       // One refresh per day across schedulers
+		asynq.Retention(24*time.Hour), // Keep task for 24h after processing
+		asynq.Timeout(30*time.Second), // Handler must finish in 30s
+		asynq.MaxRetry(5),             // The provider may be briefly unavailable
+		asynq.Queue("low"),            // Housekeeping, not user-facing
+	)
+	switch {
+	case errors.Is(err, asynq.ErrDuplicateTask):
+		slog.DebugContext(ctx, "Exchange rate refresh already enqueued",
+			logattr.Queue(s.queueName),
+		)
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to enqueue exchange rate task")
+
+		slog.ErrorContext(ctx, "Failed to enqueue exchange rate task",
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to enqueue exchange rate task: %w", err)
+	default:
+		span.SetAttributes(semconv.MessagingMessageID(info.ID))
+
+		slog.DebugContext(ctx, "Exchange rate task enqueued",
+			logattr.TaskID(info.ID),
+			logattr.Queue(s.queueName),
+		)
+	}
+	return nil
+}
+
+// scheduleBudgetAlertTask enqueues a check of every budget. The task is unique
+// for an hour, so schedulers polling at the same time check budgets once.
+// NOTE: This function owns its own telemetry. Any returned error is strictly
+// for control flow and has already been logged and attached to the trace.
+func (s *SubscriptionScheduler) scheduleBudgetAlertTask(ctx context.Context) error {
+	ctx = appctx.WithTaskType(ctx, BudgetAlertTask)
+	ctx, span := s.tracer.Start(ctx, "Enqueue Budget Alert Task",
+		observability.AsynqProducerAttributes(BudgetAlertTask, s.queueName)...,
+	)
+	defer span.End()
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(BudgetAlertTask, nil, headers)
+
+	info, err := s.taskEnqueuer.Enqueue(
+		task,
+		asynq.Unique(time.Hour),       // One check per hour across schedulers
+		asynq.Retention(24*time.Hour), // Keep task for 24h after processing
+		asynq.Timeout(5*time.Minute),  // Checks every budget
+		asynq.MaxRetry(3),             // Retry up to 3 times if failed
+		asynq.Queue(s.queueName),
+	)
+	switch {
+	case errors.Is(err, asynq.ErrDuplicateTask):
+		slog.DebugContext(ctx, "Budget alert check already enqueued",
+			logattr.Queue(s.queueName),
+		)
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to enqueue budget alert task")
+
+		slog.ErrorContext(ctx, "Failed to enqueue budget alert task",
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to enqueue budget alert task: %w", err)
+	default:
+		span.SetAttributes(semconv.MessagingMessageID(info.ID))
+
+		slog.DebugContext(ctx, "Budget alert task enqueued",
+			logattr.TaskID(info.ID),
+			logattr.Queue(s.queueName),
+		)
+	}
+	return nil
+}
+
+// scheduleBillRepairTask enqueues a repair of bills left behind by failed
+// writes. The task is unique for an hour, so schedulers polling at the same
+// time repair once.
+// NOTE: This function owns its own telemetry. Any returned error is strictly
+// for control flow and has already been logged and attached to the trace.
+func (s *SubscriptionScheduler) scheduleBillRepairTask(ctx context.Context) error {
+	ctx = appctx.WithTaskType(ctx, BillRepairTask)
+	ctx, span := s.tracer.Start(ctx, "Enqueue Bill Repair Task",
+		observability.AsynqProducerAttributes(BillRepairTask, s.queueName)...,
+	)
+	defer span.End()
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(BillRepairTask, nil, headers)
+
+	info, err := s.taskEnqueuer.Enqueue(
+		task,
+		asynq.Unique(time.Hour),       // One repair per hour across schedulers
+		asynq.Retention(24*time.Hour), // Keep task for 24h after processing
+		asynq.Timeout(5*time.Minute),  // Scans every bill
+		asynq.MaxRetry(3),             // Retry up to 3 times if failed
+		asynq.Queue("low"),            // Housekeeping, not user-facing
+	)
+	switch {
+	case errors.Is(err, asynq.ErrDuplicateTask):
+		slog.DebugContext(ctx, "Bill repair already enqueued",
+			logattr.Queue(s.queueName),
+		)
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to enqueue bill repair task")
+
+		slog.ErrorContext(ctx, "Failed to enqueue bill repair task",
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to enqueue bill repair task: %w", err)
+	default:
+		span.SetAttributes(semconv.MessagingMessageID(info.ID))
+
+		slog.DebugContext(ctx, "Bill repair task enqueued",
+			logattr.TaskID(info.ID),
+			logattr.Queue(s.queueName),
+		)
+	}
+	return nil
+}
+
+// scheduleChargeSettlementTask enqueues a settlement of bills whose charge
+// was still pending. The task is unique for an hour, so schedulers polling at
+// the same time settle once.
+// NOTE: This function owns its own telemetry. Any returned error is strictly
+// for control flow and has already been logged and attached to the trace.
+func (s *SubscriptionScheduler) scheduleChargeSettlementTask(ctx context.Context) error {
+	ctx = appctx.WithTaskType(ctx, ChargeSettlementTask)
+	ctx, span := s.tracer.Start(ctx, "Enqueue Charge Settlement Task",
+		observability.AsynqProducerAttributes(ChargeSettlementTask, s.queueName)...,
+	)
+	defer span.End()
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(ChargeSettlementTask, nil, headers)
+
+	info, err := s.taskEnqueuer.Enqueue(
+		task,
+		asynq.Unique(time.Hour),       // One settlement per hour across schedulers
+		asynq.Retention(24*time.Hour), // Keep task for 24h after processing
+		asynq.Timeout(5*time.Minute),  // Looks up every pending charge
+		asynq.MaxRetry(3),             // Retry up to 3 times if failed
+	)
+	switch {
+	case errors.Is(err, asynq.ErrDuplicateTask):
+		slog.DebugContext(ctx, "Charge settlement already enqueued",
+			logattr.Queue(s.queueName),
+		)
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to enqueue charge settlement task")
+
+		slog.ErrorContext(ctx, "Failed to enqueue charge settlement task",
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to enqueue charge settlement task: %w", err)
+	default:
+		span.SetAttributes(semconv.MessagingMessageID(info.ID))
+
+		slog.DebugContext(ctx, "Charge settlement task enqueued",
+			logattr.TaskID(info.ID),
+			logattr.Queue(s.queueName),
+		)
+	}
+	return nil
+}
+
+// scheduleReconciliationTask enqueues a check of subscription statuses
+// against their bills. The task is unique for an hour, so schedulers polling
+// at the same time reconcile once.
+// NOTE: This function owns its own telemetry. Any returned error is strictly
+// for control flow and has already been logged and attached to the trace.
+func (s *SubscriptionScheduler) scheduleReconciliationTask(ctx context.Context) error {
+	ctx = appctx.WithTaskType(ctx, ReconciliationTask)
+	ctx, span := s.tracer.Start(ctx, "Enqueue Reconciliation Task",
+		observability.AsynqProducerAttributes(ReconciliationTask, s.queueName)...,
+	)
+	defer span.End()
+
+	headers := observability.InjectIntoTaskHeaders(ctx)
+	task := asynq.NewTaskWithHeaders(ReconciliationTask, nil, headers)
+
+	info, err := s.taskEnqueuer.Enqueue(
+		task,
+		asynq.Unique(time.Hour),       // One run per hour across schedulers
+		asynq.Retention(24*time.Hour), // Keep task for 24h after processing
+		asynq.Timeout(30*time.Minute), // Scans every active and past-due subscription
+		asynq.MaxRetry(3),             // Retry up to 3 times if failed
+		asynq.Queue("low"),            // Housekeeping, not user-facing
+	)
+	switch {
+	case errors.Is(err, asynq.ErrDuplicateTask):
+		slog.DebugContext(ctx, "Reconciliation already enqueued",
+			logattr.Queue(s.queueName),
+		)
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to enqueue reconciliation task")
+
+		slog.ErrorContext(ctx, "Failed to enqueue reconciliation task",
+			logattr.Queue(s.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to enqueue reconciliation task: %w", err)
+	default:
+		span.SetAttributes(semconv.MessagingMessageID(info.ID))
+
+		slog.DebugContext(ctx, "Reconciliation task enqueued",
+			logattr.TaskID(info.ID),
+			logattr.Queue(s.queueName),
+		)
+	}
+	return nil
+}
+
+// Close cleanly shuts down the scheduler.
+func (s *SubscriptionScheduler) Close() error {
+	if s.cron != nil {
+		s.cronScheduler.Shutdown()
+		s.scanServer.Shutdown()
+	}
 	return s.taskEnqueuer.Close()
 }
@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+)
+
+const (
+	// sendRateKey is the key of the send rate limiter, shared by every
+	// worker.
+	sendRateKey = "notifications"
+	// sendRateJitter bounds the random delay added to notifications held
+	// back by the send rate, so they do not all come back at once.
+	sendRateJitter = time.Minute
+)
+
+// deferredError postpones a task without failing it. The worker does not
+// count it as a failure, so no retry is used up, and retryDelay runs the task
+// again at until.
+type deferredError struct {
+	until  time.Time
+	reason string
+}
+
+func (e *deferredError) Error() string {
+	return fmt.Sprintf("deferred until %s: %s", e.until.Format(time.RFC3339), e.reason)
+}
+
+// Deferred reports when the task runs again.
+func (e *deferredError) Deferred() time.Time {
+	return e.until
+}
+
+// isFailure reports whether err returned by a task handler counts as a
+// failure; deferrals do not.
+func isFailure(err error) bool {
+	_, deferred := errors.AsType[*deferredError](err)
+	return !deferred
+}
+
+// sendAt returns when a reminder may be sent to the owner of prefs at now:
+// after their quiet hours and within the send window of their timezone. It
+// returns the zero time if the reminder may be sent right away. A nil window
+// allows any time of day.
+func sendAt(now time.Time, prefs *models.NotificationPreferences, window *models.SendWindow) time.Time {
+	loc := prefs.Location(time.Local)
+	at := now
+	// Waiting out the quiet hours may leave the send window and the other
+	// way round. A few rounds settle it unless the quiet hours cover the
+	// whole window; then the reminder goes out when the last round ends.
+	for range 3 {
+		moved := false
+		if prefs.QuietHours != nil {
+			if until, ok := prefs.QuietHours.Until(at); ok {
+				at, moved = until, true
+			}
+		}
+		if window != nil {
+			if until, ok := window.Until(at, loc); ok {
+				at, moved = until, true
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+	if at.Equal(now) {
+		return time.Time{}
+	}
+	return at
+}
+
+// holdBack returns a deferredError if a reminder to the owner of prefs may
+// not be sent now: the scheduler held it until sendAt, but the task may have
+// been retried or the preferences changed since. It also takes a slot of the
+// send rate shared by every worker, deferring the reminder to a later one
+// when none is left. A failed rate check lets the reminder through, so an
+// unreachable Redis does not hold back every reminder.
+func (w *QueueWorker) holdBack(ctx context.Context, prefs *models.NotificationPreferences) error {
+	now := w.getTime()
+	if until := sendAt(now, prefs, w.sendWindow); !until.IsZero() {
+		slog.DebugContext(ctx, "Deferring reminder outside the user's sending hours",
+			logattr.ProcessAt(until),
+			logattr.Queue(w.queueName),
+		)
+		return &deferredError{until, "outside the user's sending hours"}
+	}
+
+	if w.sendLimiter == nil {
+		return nil
+	}
+	allowed, _, retryAfter, err := w.sendLimiter.Allowed(ctx, sendRateKey)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to check the send rate",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return nil
+	}
+	if allowed {
+		return nil
+	}
+	until := now.Add(retryAfter + rand.N(sendRateJitter))
+	slog.DebugContext(ctx, "Deferring reminder over the send rate",
+		logattr.ProcessAt(until),
+		logattr.Queue(w.queueName),
+	)
+	return &deferredError{until, "send rate exceeded"}
+}
@@ -0,0 +1,196 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/observability"
+	"github.com/hibiken/asynq"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// WebhookDeliveryTask is the task name for delivering one domain event to one
+// webhook.
+const WebhookDeliveryTask = "webhook:deliver"
+
+// WebhookDeliveryPayload represents the data needed to deliver an event to a
+// webhook.
+type WebhookDeliveryPayload struct {
+	WebhookID string       `json:"webhook_id"`
+	Event     EventPayload `json:"event"`
+}
+
+// fanOutWebhooks enqueues a delivery task for every webhook of the event's
+// user that receives its type. Each task ID combines the event and webhook
+// IDs, so a retried event does not deliver twice.
+func (w *QueueWorker) fanOutWebhooks(ctx context.Context, payload EventPayload) error {
+	userID, err := bson.ObjectIDFromHex(payload.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid user ID",
+			logattr.EventID(payload.EventID),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	webhooks, err := w.webhookService.FetchWebhooksForEventInternal(ctx, userID, models.EventType(payload.Type))
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch webhooks",
+			logattr.EventID(payload.EventID),
+			logattr.EventType(payload.Type),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch webhooks: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		body, err := json.Marshal(WebhookDeliveryPayload{
+			WebhookID: webhook.ID.Hex(),
+			Event:     payload,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal webhook delivery payload: %w", err)
+		}
+
+		headers := observability.InjectIntoTaskHeaders(ctx)
+		task := asynq.NewTaskWithHeaders(WebhookDeliveryTask, body, headers)
+		_, err = w.taskEnqueuer.Enqueue(
+			task,
+			asynq.TaskID(payload.EventID+":"+webhook.ID.Hex()),
+			asynq.Retention(24*time.Hour), // Keep the ID reserved after delivery.
+			asynq.Timeout(w.webhookConfig.Timeout+5*time.Second),
+			asynq.MaxRetry(w.webhookConfig.MaxRetries),
+			asynq.Queue(w.queueName),
+		)
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			slog.ErrorContext(ctx, "Failed to enqueue webhook delivery",
+				logattr.EventID(payload.EventID),
+				logattr.WebhookID(webhook.ID.Hex()),
+				logattr.Queue(w.queueName),
+				logattr.Error(err),
+			)
+			return fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+// handleWebhookDelivery POSTs a signed event to a webhook and records the
+// attempt. A failed attempt returns an error, so asynq retries it with the
+// webhook backoff until max_retries is exhausted.
+func (w *QueueWorker) handleWebhookDelivery(ctx context.Context, task *asynq.Task) error {
+	var payload WebhookDeliveryPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal payload",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal task payload: %w", err)
+	}
+
+	ctx = observability.EnrichContext(ctx, payload.Event.UserID, "")
+	observability.EnrichSpan(ctx)
+
+	webhookID, err := bson.ObjectIDFromHex(payload.WebhookID)
+	if err != nil {
+		return fmt.Errorf("invalid webhook ID: %w", err)
+	}
+	eventID, err := bson.ObjectIDFromHex(payload.Event.EventID)
+	if err != nil {
+		return fmt.Errorf("invalid event ID: %w", err)
+	}
+	eventType := models.EventType(payload.Event.Type)
+
+	webhook, err := w.webhookService.FetchWebhookByIDInternal(ctx, webhookID)
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			slog.InfoContext(ctx, "Skipping delivery to deleted webhook",
+				logattr.WebhookID(payload.WebhookID),
+				logattr.EventID(payload.Event.EventID),
+			)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to fetch webhook",
+			logattr.WebhookID(payload.WebhookID),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch webhook: %w", err)
+	}
+
+	body, err := json.Marshal(models.WebhookEvent{
+		ID:         payload.Event.EventID,
+		Type:       payload.Event.Type,
+		OccurredAt: payload.Event.OccurredAt,
+		Data:       payload.Event.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	retried, _ := asynq.GetRetryCount(ctx)
+	start := time.Now()
+	statusCode, sendErr := w.webhookSender.Send(ctx, webhook.URL, webhook.Secret, payload.Event.Type, payload.Event.EventID, body)
+
+	delivery := &models.WebhookDelivery{
+		WebhookID:  webhook.ID,
+		EventID:    eventID,
+		EventType:  eventType,
+		Attempt:    retried + 1,
+		StatusCode: statusCode,
+		Succeeded:  sendErr == nil,
+		Duration:   time.Since(start),
+	}
+	if sendErr != nil {
+		delivery.Error = sendErr.Error()
+	}
+	// A lost record must not cause a successful delivery to be repeated.
+	if err = w.webhookService.RecordDeliveryInternal(ctx, delivery); err != nil {
+		slog.ErrorContext(ctx, "Failed to record webhook delivery",
+			logattr.WebhookID(payload.WebhookID),
+			logattr.EventID(payload.Event.EventID),
+			logattr.Error(err),
+		)
+	}
+
+	if sendErr != nil {
+		slog.WarnContext(ctx, "Webhook delivery failed",
+			logattr.WebhookID(payload.WebhookID),
+			logattr.EventID(payload.Event.EventID),
+			logattr.EventType(payload.Event.Type),
+			logattr.Attempt(delivery.Attempt),
+			logattr.HTTPStatus(statusCode),
+			logattr.Error(sendErr),
+		)
+		return fmt.Errorf("failed to deliver webhook: %w", sendErr)
+	}
+
+	slog.InfoContext(ctx, "Webhook delivered",
+		logattr.WebhookID(payload.WebhookID),
+		logattr.EventID(payload.Event.EventID),
+		logattr.EventType(payload.Event.Type),
+		logattr.Attempt(delivery.Attempt),
+	)
+	return nil
+}
+
+// retryDelay spaces out webhook retries with exponential backoff and leaves
+// every other task on the asynq default.
+func (w *QueueWorker) retryDelay(n int, err error, task *asynq.Task) time.Duration {
+	if deferred, ok := errors.AsType[*deferredError](err); ok {
+		return max(deferred.until.Sub(w.getTime()), 0)
+	}
+	if task.Type() == WebhookDeliveryTask {
+		return w.webhookConfig.RetryDelay(n)
+	}
+	return asynq.DefaultRetryDelayFunc(n, err, task)
+}
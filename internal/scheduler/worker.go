@@ -3,15 +3,22 @@ package scheduler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
+	"github.com/anuragthepathak/subscription-management/internal/api/shared/apperror"
+	"github.com/anuragthepathak/subscription-management/internal/core/appctx"
 	"github.com/anuragthepathak/subscription-management/internal/core/clock"
 	"github.com/anuragthepathak/subscription-management/internal/core/logattr"
 	"github.com/anuragthepathak/subscription-management/internal/core/otelattr"
+	"github.com/anuragthepathak/subscription-management/internal/currency"
 	"github.com/anuragthepathak/subscription-management/internal/domain/models"
 	"github.com/anuragthepathak/subscription-management/internal/domain/services"
+	"github.com/anuragthepathak/subscription-management/internal/eventbus"
+	"github.com/anuragthepathak/subscription-management/internal/i18n"
 	"github.com/anuragthepathak/subscription-management/internal/notifications"
 	"github.com/anuragthepathak/subscription-management/internal/observability"
 	"github.com/hibiken/asynq"
@@ -24,50 +31,135 @@ import (
 type QueueWorker struct {
 	subscriptionService services.SubscriptionServiceInternal
 	userService         services.UserServiceInternal
+	budgetService       services.BudgetServiceInternal
+	cancelLinks         services.CancelLinkServiceInternal
+	webhookService      services.WebhookServiceInternal
+	paymentMethods      services.PaymentMethodServiceInternal
+	preferenceService   services.NotificationPreferenceServiceInternal
+	notificationService services.NotificationServiceInternal // Records what was sent to users.
+	exportService       services.ExportServiceInternal
+	invoiceService      services.InvoiceServiceInternal
+	deadTaskService     services.DeadTaskServiceInternal
+	reconciliation      services.ReconciliationServiceInternal
+	deliveries          services.AnalyticsServiceInternal // Counts reminders per channel.
+	exchangeRates       currency.Service
 	emailSender         notifications.EmailSender
+	notifiers           map[models.NotificationChannel]notifications.Notifier
+	sendWindow          *models.SendWindow          // Nil sends reminders at any time of day.
+	sendLimiter         services.RateLimiterService // Caps reminders across workers; nil for no cap.
+	webhookSender       notifications.WebhookSender
+	webhookConfig       notifications.WebhookConfig
+	alerter             notifications.Alerter             // Reports dead tasks to operators.
+	eventPublisher      eventbus.Publisher                // Publishes domain events to the message bus.
+	liveEvents          services.LiveEventServiceInternal // Pushes events to the users' open streams.
 	redisClient         redis.UniversalClient
 	server              *asynq.Server
+	taskEnqueuer        TaskEnqueuer // Enqueues webhook deliveries fanned out from events.
 	queueName           string
 	concurrency         int
 	name                string
 	getTime             clock.NowFn
+
+	// Shutdown draining.
+	drainTimeout time.Duration
+	running      atomic.Int64
+	drainMetrics drainMetrics
+
+	// Reports what reconciliation found.
+	reconciliationMetrics reconciliationMetrics
 }
 
 // NewQueueWorker creates a new queue worker.
 func NewQueueWorker(
 	subscriptionService services.SubscriptionServiceInternal,
 	userService services.UserServiceInternal,
+	budgetService services.BudgetServiceInternal,
+	cancelLinks services.CancelLinkServiceInternal,
+	webhookService services.WebhookServiceInternal,
+	paymentMethods services.PaymentMethodServiceInternal,
+	preferenceService services.NotificationPreferenceServiceInternal,
+	notificationService services.NotificationServiceInternal,
+	exportService services.ExportServiceInternal,
+	invoiceService services.InvoiceServiceInternal,
+	deadTaskService services.DeadTaskServiceInternal,
+	reconciliation services.ReconciliationServiceInternal,
+	deliveries services.AnalyticsServiceInternal,
+	exchangeRates currency.Service,
 	emailSender notifications.EmailSender,
+	notifiers []notifications.Notifier,
+	sendWindow *models.SendWindow,
+	sendLimiter services.RateLimiterService,
+	webhookSender notifications.WebhookSender,
+	webhookConfig notifications.WebhookConfig,
+	alerter notifications.Alerter,
+	eventPublisher eventbus.Publisher,
+	liveEvents services.LiveEventServiceInternal,
 	redisClient redis.UniversalClient,
 	redisConfig asynq.RedisConnOpt,
 	concurrency int,
+	drainTimeout time.Duration,
+	shutdownTimeout time.Duration,
 	queueName string,
 	name string,
 	nowFn clock.NowFn,
 ) *QueueWorker {
-	// Configure the server with appropriate concurrency.
-	server := asynq.NewServer(
+	w := &QueueWorker{
+		subscriptionService: subscriptionService,
+		userService:         userService,
+		budgetService:       budgetService,
+		cancelLinks:         cancelLinks,
+		webhookService:      webhookService,
+		paymentMethods:      paymentMethods,
+		preferenceService:   preferenceService,
+		notificationService: notificationService,
+		exportService:       exportService,
+		invoiceService:      invoiceService,
+		deadTaskService:     deadTaskService,
+		reconciliation:      reconciliation,
+		deliveries:          deliveries,
+		exchangeRates:       exchangeRates,
+		emailSender:         emailSender,
+		notifiers:           make(map[models.NotificationChannel]notifications.Notifier, len(notifiers)),
+		sendWindow:          sendWindow,
+		sendLimiter:         sendLimiter,
+		webhookSender:       webhookSender,
+		webhookConfig:       webhookConfig,
+		alerter:             alerter,
+		eventPublisher:      eventPublisher,
+		liveEvents:          liveEvents,
+		redisClient:         redisClient,
+		taskEnqueuer:        asynq.NewClient(redisConfig),
+		queueName:           queueName,
+		concurrency:         concurrency,
+		name:                name,
+		getTime:             nowFn,
+		drainTimeout:        drainTimeout,
+		drainMetrics:        newDrainMetrics(name),
+
+		reconciliationMetrics: newReconciliationMetrics(name),
+	}
+	for _, n := range notifiers {
+		w.notifiers[n.Channel()] = n
+	}
+
+	// Configure the server with appropriate concurrency. On shutdown, tasks
+	// still running after shutdownTimeout are returned to the queue; Stop
+	// spends drainTimeout of it before shutting the server down.
+	w.server = asynq.NewServer(
 		redisConfig,
 		asynq.Config{
-			Concurrency: concurrency,
+			Concurrency:     concurrency,
+			ShutdownTimeout: shutdownTimeout - drainTimeout,
 			Queues: map[string]int{
 				queueName: 10, // Process reminder tasks with higher priority.
 				"low":     5,
 			},
+			RetryDelayFunc: w.retryDelay,
+			IsFailure:      isFailure, // Deferred reminders use up no retry.
+			ErrorHandler:   asynq.ErrorHandlerFunc(w.handleTaskError),
 		},
 	)
-
-	return &QueueWorker{
-		subscriptionService,
-		userService,
-		emailSender,
-		redisClient,
-		server,
-		queueName,
-		concurrency,
-		name,
-		nowFn,
-	}
+	return w
 }
 
 // Start begins processing tasks from the queue.
@@ -77,10 +169,28 @@ func (w *QueueWorker) Start() error {
 
 	// Inject OpenTelemetry middleware to extract trace IDs from the queue payload headers
 	mux.Use(observability.AsynqTracingMiddleware(w.name))
+	mux.Use(w.trackRunning)
 
 	mux.HandleFunc(ReminderTask, w.handleSubscriptionReminder)
+	mux.HandleFunc(ReminderDigestTask, w.handleReminderDigest)
+	mux.HandleFunc(TrialEndingTask, w.handleTrialEndingReminder)
 	mux.HandleFunc(RenewalTask, w.handleSubscriptionRenewal)
 	mux.HandleFunc(ExpirationTask, w.handleSubscriptionExpiration)
+	mux.HandleFunc(ArchiveTask, w.handleSubscriptionArchive)
+	mux.HandleFunc(UnusedTask, w.handleUnusedSubscription)
+	mux.HandleFunc(DunningTask, w.handleDunningRetry)
+	mux.HandleFunc(ExchangeRateTask, w.handleExchangeRateRefresh)
+	mux.HandleFunc(BudgetAlertTask, w.handleBudgetAlerts)
+	mux.HandleFunc(BillRepairTask, w.handleBillRepair)
+	mux.HandleFunc(ChargeSettlementTask, w.handleChargeSettlement)
+	mux.HandleFunc(ReconciliationTask, w.handleReconciliation)
+	mux.HandleFunc(ExportTask, w.handleExport)
+	mux.HandleFunc(ExportExpiryTask, w.handleExportExpiry)
+	mux.HandleFunc(InvoiceTask, w.handleInvoice)
+	mux.HandleFunc(AccountClosedTask, w.handleAccountClosed)
+	mux.HandleFunc(AccountPurgeTask, w.handleAccountPurge)
+	mux.HandleFunc(EventTaskPrefix, w.handleDomainEvent)
+	mux.HandleFunc(WebhookDeliveryTask, w.handleWebhookDelivery)
 
 	if err := w.server.Start(mux); err != nil {
 		return fmt.Errorf("failed to start queue worker: %w", err)
@@ -146,6 +256,26 @@ func (w *QueueWorker) handleSubscriptionReminder(ctx context.Context, task *asyn
 		return nil
 	}
 
+	// The user may have changed their channels since the task was scheduled.
+	prefs, err := w.preferenceService.FetchPreferencesInternal(ctx, subscription.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch notification preferences",
+			logattr.DaysBefore(payload.DaysBefore),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch notification preferences: %w", err)
+	}
+	if len(prefs.Channels) == 0 {
+		slog.DebugContext(ctx, "Skipping reminder for user opted out of notifications",
+			logattr.Queue(w.queueName),
+		)
+		return nil
+	}
+	if err = w.holdBack(ctx, prefs); err != nil {
+		return err
+	}
+
 	// Get the user information.
 	user, err := w.userService.FetchUserByIDInternal(ctx, subscription.UserID)
 	if err != nil {
@@ -158,33 +288,30 @@ func (w *QueueWorker) handleSubscriptionReminder(ctx context.Context, task *asyn
 		return fmt.Errorf("failed to fetch user: %w", err)
 	}
 
-	// Send the email notification.
-	if err = w.emailSender.SendReminderEmail(
-		ctx,
-		user.Email,
-		user.Name,
-		subscription,
-		payload.DaysBefore,
-	); err != nil {
-		slog.ErrorContext(ctx, "Failed to send reminder email",
+	// Notify the user on every channel they enabled.
+	to := notifications.NewRecipient(user, prefs)
+	ctx = w.localized(ctx, user, prefs)
+	paymentMethod := w.paymentMethod(ctx, subscription)
+	key := services.ReminderSentKey(subscription.ID, payload.DaysBefore)
+	if err = w.notify(ctx, key, notifications.TemplateReminder, user.ID, to, prefs.Channels, func(n notifications.Notifier) error {
+		return n.NotifyReminder(ctx, to, subscription, paymentMethod, payload.DaysBefore)
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to send reminder",
 			logattr.DaysBefore(payload.DaysBefore),
 			logattr.ValidTill(subscription.ValidTill),
 			logattr.Queue(w.queueName),
 			logattr.Error(err),
 		)
-		return fmt.Errorf("failed to send reminder email: %w", err)
+		return fmt.Errorf("failed to send reminder: %w", err)
 	}
-	slog.InfoContext(ctx, "Reminder email sent",
+	slog.InfoContext(ctx, "Reminder sent",
 		logattr.DaysBefore(payload.DaysBefore),
 		logattr.ValidTill(subscription.ValidTill),
 		logattr.Queue(w.queueName),
 	)
+	w.streamReminder(ctx, subscription, payload.DaysBefore, false)
 
 	// Store in Redis that the reminder was sent.
-	key := fmt.Sprintf("reminder_sent:%s:%d",
-		subscription.ID.Hex(),
-		payload.DaysBefore,
-	)
 	if err = w.redisClient.Set(ctx, key, "", 24*time.Hour).Err(); err != nil {
 		slog.ErrorContext(ctx, "Failed to set reminder sent key in Redis",
 			logattr.DaysBefore(payload.DaysBefore),
@@ -194,6 +321,308 @@ func (w *QueueWorker) handleSubscriptionReminder(ctx context.Context, task *asyn
 		)
 	}
 
+	// The reminder is out, so a missing timeline entry is not worth a retry.
+	if err = w.subscriptionService.RecordReminderSentInternal(ctx, subscription, payload.DaysBefore); err != nil {
+		slog.ErrorContext(ctx, "Failed to record reminder in subscription timeline",
+			logattr.DaysBefore(payload.DaysBefore),
+			logattr.ValidTill(subscription.ValidTill),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+	}
+
+	return nil
+}
+
+// handleReminderDigest sends a user the renewal reminders of a day in one
+// notification per channel. Reminders of subscriptions that are no longer
+// active, or that went out since the task was enqueued, are left out; once
+// the digest is delivered, each reminder is marked sent.
+func (w *QueueWorker) handleReminderDigest(ctx context.Context, task *asynq.Task) error {
+	var payload ReminderDigestPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal reminder digest task payload",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal reminder digest task payload: %w", err)
+	}
+
+	ctx = observability.EnrichContext(ctx, payload.UserID, "")
+	observability.EnrichSpan(ctx)
+
+	userID, err := bson.ObjectIDFromHex(payload.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid user ID",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	// The user may have changed their channels since the task was scheduled.
+	prefs, err := w.preferenceService.FetchPreferencesInternal(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch notification preferences",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch notification preferences: %w", err)
+	}
+	if len(prefs.Channels) == 0 {
+		slog.DebugContext(ctx, "Skipping reminder digest for user opted out of notifications",
+			logattr.Queue(w.queueName),
+		)
+		return nil
+	}
+
+	reminders := make([]notifications.Reminder, 0, len(payload.Reminders))
+	for _, item := range payload.Reminders {
+		reminder, err := w.digestReminder(ctx, item)
+		if err != nil {
+			return err
+		}
+		if reminder != nil {
+			reminders = append(reminders, *reminder)
+		}
+	}
+	if len(reminders) == 0 {
+		slog.DebugContext(ctx, "Skipping reminder digest without pending reminders",
+			logattr.Queue(w.queueName),
+		)
+		return nil
+	}
+	if err = w.holdBack(ctx, prefs); err != nil {
+		return err
+	}
+
+	user, err := w.userService.FetchUserByIDInternal(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch user",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	// Notify the user on every channel they enabled. The task ID stays the
+	// same across retries, so channels that got the digest are skipped.
+	taskID, _ := asynq.GetTaskID(ctx)
+	to := notifications.NewRecipient(user, prefs)
+	ctx = w.localized(ctx, user, prefs)
+	if err = w.notify(ctx, services.ReminderDigestKey(taskID), notifications.TemplateReminderDigest, user.ID, to, prefs.Channels, func(n notifications.Notifier) error {
+		return n.NotifyReminderDigest(ctx, to, reminders)
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to send reminder digest",
+			logattr.Total(len(reminders)),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to send reminder digest: %w", err)
+	}
+	slog.InfoContext(ctx, "Reminder digest sent",
+		logattr.Total(len(reminders)),
+		logattr.Queue(w.queueName),
+	)
+
+	for _, reminder := range reminders {
+		ctx := observability.EnrichContext(ctx, payload.UserID, reminder.Subscription.ID.Hex())
+
+		// Store in Redis that the reminder was sent.
+		key := services.ReminderSentKey(reminder.Subscription.ID, reminder.DaysBefore)
+		if err = w.redisClient.Set(ctx, key, "", 24*time.Hour).Err(); err != nil {
+			slog.ErrorContext(ctx, "Failed to set reminder sent key in Redis",
+				logattr.DaysBefore(reminder.DaysBefore),
+				logattr.Queue(w.queueName),
+				logattr.Error(err),
+			)
+		}
+
+		// The digest is out, so a missing timeline entry is not worth a retry.
+		if err = w.subscriptionService.RecordReminderSentInternal(ctx, reminder.Subscription, reminder.DaysBefore); err != nil {
+			slog.ErrorContext(ctx, "Failed to record reminder in subscription timeline",
+				logattr.DaysBefore(reminder.DaysBefore),
+				logattr.Queue(w.queueName),
+				logattr.Error(err),
+			)
+		}
+		w.streamReminder(ctx, reminder.Subscription, reminder.DaysBefore, false)
+	}
+
+	return nil
+}
+
+// digestReminder loads a reminder of a digest. It returns nil if the
+// reminder is no longer due: the subscription is gone or not active, or the
+// reminder was sent already.
+func (w *QueueWorker) digestReminder(ctx context.Context, item DigestReminder) (*notifications.Reminder, error) {
+	ctx = appctx.WithSubscriptionID(ctx, item.SubscriptionID)
+
+	subscriptionID, err := bson.ObjectIDFromHex(item.SubscriptionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid subscription ID",
+			logattr.DaysBefore(item.DaysBefore),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return nil, fmt.Errorf("invalid subscription ID: %w", err)
+	}
+
+	subscription, err := w.subscriptionService.FetchSubscriptionByIDInternal(ctx, subscriptionID)
+	if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch subscription",
+			logattr.DaysBefore(item.DaysBefore),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return nil, fmt.Errorf("failed to fetch subscription: %w", err)
+	}
+	if subscription.Status != models.Active {
+		return nil, nil
+	}
+
+	sent, err := w.redisClient.Exists(ctx, services.ReminderSentKey(subscriptionID, item.DaysBefore)).Result()
+	if err != nil {
+		// Sending twice beats not sending at all.
+		slog.WarnContext(ctx, "Failed to check Redis for sent reminder",
+			logattr.DaysBefore(item.DaysBefore),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+	} else if sent > 0 {
+		return nil, nil
+	}
+
+	return &notifications.Reminder{
+		Subscription:  subscription,
+		PaymentMethod: w.paymentMethod(ctx, subscription),
+		DaysBefore:    item.DaysBefore,
+	}, nil
+}
+
+// handleTrialEndingReminder emails a reminder that a free trial ends and the
+// subscription is charged for the first time.
+func (w *QueueWorker) handleTrialEndingReminder(ctx context.Context, task *asynq.Task) error {
+	var payload ReminderPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal trial ending task payload",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal trial ending task payload: %w", err)
+	}
+
+	ctx = observability.EnrichContext(ctx, payload.UserID, payload.SubscriptionID)
+	observability.EnrichSpan(ctx)
+	trace.SpanFromContext(ctx).SetAttributes(
+		otelattr.DaysBefore(payload.DaysBefore),
+	)
+
+	subscriptionID, err := bson.ObjectIDFromHex(payload.SubscriptionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid subscription ID",
+			logattr.DaysBefore(payload.DaysBefore),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("invalid subscription ID: %w", err)
+	}
+
+	subscription, err := w.subscriptionService.FetchSubscriptionByIDInternal(ctx, subscriptionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch subscription",
+			logattr.DaysBefore(payload.DaysBefore),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch subscription: %w", err)
+	}
+
+	// The user may have canceled during the trial, or it may have been
+	// renewed since the task was scheduled.
+	if subscription.Status != models.Active || !subscription.InTrial() {
+		slog.DebugContext(ctx, "Skipping trial ending reminder for subscription out of trial",
+			logattr.Status(string(subscription.Status)),
+			logattr.Queue(w.queueName),
+		)
+		return nil
+	}
+
+	// The user may have changed their channels since the task was scheduled.
+	prefs, err := w.preferenceService.FetchPreferencesInternal(ctx, subscription.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch notification preferences",
+			logattr.DaysBefore(payload.DaysBefore),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch notification preferences: %w", err)
+	}
+	if len(prefs.Channels) == 0 {
+		slog.DebugContext(ctx, "Skipping reminder for user opted out of notifications",
+			logattr.Queue(w.queueName),
+		)
+		return nil
+	}
+	if err = w.holdBack(ctx, prefs); err != nil {
+		return err
+	}
+
+	user, err := w.userService.FetchUserByIDInternal(ctx, subscription.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch user",
+			logattr.DaysBefore(payload.DaysBefore),
+			logattr.ValidTill(subscription.ValidTill),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	to := notifications.NewRecipient(user, prefs)
+	ctx = w.localized(ctx, user, prefs)
+	key := services.TrialEndingSentKey(subscription.ID, payload.DaysBefore)
+	if err = w.notify(ctx, key, notifications.TemplateTrialEnding, user.ID, to, prefs.Channels, func(n notifications.Notifier) error {
+		return n.NotifyTrialEnding(ctx, to, subscription, payload.DaysBefore)
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to send trial ending reminder",
+			logattr.DaysBefore(payload.DaysBefore),
+			logattr.ValidTill(subscription.ValidTill),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to send trial ending reminder: %w", err)
+	}
+	slog.InfoContext(ctx, "Trial ending reminder sent",
+		logattr.DaysBefore(payload.DaysBefore),
+		logattr.ValidTill(subscription.ValidTill),
+		logattr.Queue(w.queueName),
+	)
+	w.streamReminder(ctx, subscription, payload.DaysBefore, true)
+
+	// Store in Redis that the reminder was sent.
+	if err = w.redisClient.Set(ctx, key, "", 24*time.Hour).Err(); err != nil {
+		slog.ErrorContext(ctx, "Failed to set trial ending sent key in Redis",
+			logattr.DaysBefore(payload.DaysBefore),
+			logattr.ValidTill(subscription.ValidTill),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+	}
+
+	// The reminder is out, so a missing timeline entry is not worth a retry.
+	if err = w.subscriptionService.RecordReminderSentInternal(ctx, subscription, payload.DaysBefore); err != nil {
+		slog.ErrorContext(ctx, "Failed to record trial ending reminder in subscription timeline",
+			logattr.DaysBefore(payload.DaysBefore),
+			logattr.ValidTill(subscription.ValidTill),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+	}
+
 	return nil
 }
 
@@ -278,13 +707,38 @@ func (w *QueueWorker) handleSubscriptionRenewal(ctx context.Context, task *asynq
 		return nil
 	}
 
+	ctx = w.localized(ctx, user, nil)
+
+	// A declined charge puts the subscription past due; the dunning task
+	// retries the payment on the configured schedule.
+	if renewedSubscription.Status == models.PastDue {
+		err = w.emailSender.SendDunningEmail(ctx, user.Email, user.Name, renewedSubscription, 0, false, "")
+		err = w.recordNotification(ctx, user.ID, models.ChannelEmail, user.Email, notifications.TemplateDunning, err)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to send payment failed email",
+				logattr.ValidTill(renewedSubscription.ValidTill),
+				logattr.Queue(w.queueName),
+				logattr.Error(err),
+			)
+		} else {
+			slog.InfoContext(ctx, "Payment failed email sent",
+				logattr.ValidTill(renewedSubscription.ValidTill),
+				logattr.Queue(w.queueName),
+			)
+		}
+		return nil
+	}
+
 	// Send email notification of the successful renewal
-	if err = w.emailSender.SendRenewalConfirmationEmail(
+	err = w.emailSender.SendRenewalConfirmationEmail(
 		ctx,
 		user.Email,
 		user.Name,
 		renewedSubscription,
-	); err != nil {
+		w.paymentMethod(ctx, renewedSubscription),
+	)
+	err = w.recordNotification(ctx, user.ID, models.ChannelEmail, user.Email, notifications.TemplateRenewalConfirmation, err)
+	if err != nil {
 		slog.ErrorContext(ctx, "Failed to send renewal confirmation email",
 			logattr.ValidTill(renewedSubscription.ValidTill),
 			logattr.Queue(w.queueName),
@@ -359,6 +813,13 @@ func (w *QueueWorker) handleSubscriptionExpiration(ctx context.Context, task *as
 
 	// Update the subscription status to Expired
 	if err := w.subscriptionService.MarkCanceledSubscriptionAsExpiredInternal(ctx, subscriptionID); err != nil {
+		// Another worker already moved it out of canceled; nothing left to do.
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrConflict {
+			slog.DebugContext(ctx, "Skipping expiration: subscription status changed concurrently",
+				logattr.Queue(w.queueName),
+			)
+			return nil
+		}
 		slog.ErrorContext(ctx, "Failed to mark subscription as expired",
 			logattr.ValidTill(subscription.ValidTill),
 			logattr.Queue(w.queueName),
@@ -370,7 +831,770 @@ func (w *QueueWorker) handleSubscriptionExpiration(ctx context.Context, task *as
 	return nil
 }
 
-// Stop gracefully shuts down the worker.
-func (w *QueueWorker) Stop() {
-	w.server.Shutdown()
+func (w *QueueWorker) handleSubscriptionArchive(ctx context.Context, task *asynq.Task) error {
+	var payload ArchivePayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal archive task payload",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal archive task payload: %w", err)
+	}
+
+	ctx = observability.EnrichContext(ctx, payload.UserID, payload.SubscriptionID)
+	observability.EnrichSpan(ctx)
+
+	slog.DebugContext(ctx, "Processing subscription archive",
+		logattr.Queue(w.queueName),
+	)
+
+	subscriptionID, err := bson.ObjectIDFromHex(payload.SubscriptionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid subscription ID",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("invalid subscription ID: %w", err)
+	}
+
+	if err := w.subscriptionService.ArchiveSubscriptionInternal(ctx, subscriptionID); err != nil {
+		// Already archived by an earlier attempt; nothing left to do.
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			slog.DebugContext(ctx, "Skipping archive: subscription no longer in hot storage",
+				logattr.Queue(w.queueName),
+			)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to archive subscription",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to archive subscription: %w", err)
+	}
+
+	return nil
+}
+
+// handleUnusedSubscription emails a suggestion to cancel an unused
+// subscription before it renews, with a one-click cancel link.
+func (w *QueueWorker) handleUnusedSubscription(ctx context.Context, task *asynq.Task) error {
+	var payload UnusedPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal unused subscription task payload",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal unused subscription task payload: %w", err)
+	}
+
+	ctx = observability.EnrichContext(ctx, payload.UserID, payload.SubscriptionID)
+	observability.EnrichSpan(ctx)
+
+	subscriptionID, err := bson.ObjectIDFromHex(payload.SubscriptionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid subscription ID",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("invalid subscription ID: %w", err)
+	}
+
+	subscription, err := w.subscriptionService.FetchSubscriptionByIDInternal(ctx, subscriptionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch subscription",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch subscription: %w", err)
+	}
+
+	// The user may have canceled it since the task was scheduled.
+	if subscription.Status != models.Active {
+		slog.DebugContext(ctx, "Skipping suggestion for non-active subscription",
+			logattr.Status(string(subscription.Status)),
+			logattr.Queue(w.queueName),
+		)
+		return nil
+	}
+
+	user, err := w.userService.FetchUserByIDInternal(ctx, subscription.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch user",
+			logattr.ValidTill(subscription.ValidTill),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	ctx = w.localized(ctx, user, nil)
+	err = w.emailSender.SendUnusedSubscriptionEmail(
+		ctx,
+		user.Email,
+		user.Name,
+		subscription,
+		w.cancelLinks.SignCancelLinkInternal(subscription),
+	)
+	err = w.recordNotification(ctx, user.ID, models.ChannelEmail, user.Email, notifications.TemplateUnusedSubscription, err)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send unused subscription email",
+			logattr.ValidTill(subscription.ValidTill),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to send unused subscription email: %w", err)
+	}
+	slog.InfoContext(ctx, "Unused subscription email sent",
+		logattr.ValidTill(subscription.ValidTill),
+		logattr.Queue(w.queueName),
+	)
+
+	// Mark the suggestion as sent until the renewal has passed.
+	key := services.UnusedSuggestionSentKey(subscription.ID, subscription.ValidTill)
+	ttl := subscription.ValidTill.Sub(w.getTime()) + 24*time.Hour
+	if err = w.redisClient.Set(ctx, key, "", ttl).Err(); err != nil {
+		slog.ErrorContext(ctx, "Failed to set suggestion sent key in Redis",
+			logattr.ValidTill(subscription.ValidTill),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+	}
+
+	return nil
+}
+
+// handleExchangeRateRefresh fetches and stores the exchange rates for the day
+// in the payload.
+// handleDunningRetry emails the user about a failed renewal payment and counts
+// the retry. The final retry expires the subscription.
+func (w *QueueWorker) handleDunningRetry(ctx context.Context, task *asynq.Task) error {
+	var payload DunningPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal dunning task payload",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal dunning task payload: %w", err)
+	}
+
+	ctx = observability.EnrichContext(ctx, payload.UserID, payload.SubscriptionID)
+	observability.EnrichSpan(ctx)
+
+	subscriptionID, err := bson.ObjectIDFromHex(payload.SubscriptionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid subscription ID",
+			logattr.Attempt(payload.Attempt),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("invalid subscription ID: %w", err)
+	}
+
+	subscription, err := w.subscriptionService.FetchSubscriptionByIDInternal(ctx, subscriptionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch subscription",
+			logattr.Attempt(payload.Attempt),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch subscription: %w", err)
+	}
+
+	// The payment may have been recovered, or the attempt already counted,
+	// since the task was scheduled.
+	if subscription.Status != models.PastDue || subscription.Dunning == nil ||
+		subscription.Dunning.Attempts != payload.Attempt-1 {
+		slog.DebugContext(ctx, "Skipping dunning retry for subscription no longer at this attempt",
+			logattr.Status(string(subscription.Status)),
+			logattr.Attempt(payload.Attempt),
+			logattr.Queue(w.queueName),
+		)
+		return nil
+	}
+
+	recovered, err := w.subscriptionService.RetryPaymentInternal(ctx, subscriptionID, payload.Attempt)
+	if err != nil {
+		// A charge of the bill is still processing; the attempt is neither
+		// counted nor emailed until it settles.
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrConflict {
+			slog.InfoContext(ctx, "Skipping dunning retry: payment is still processing",
+				logattr.Attempt(payload.Attempt),
+				logattr.Queue(w.queueName),
+			)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to retry payment",
+			logattr.Attempt(payload.Attempt),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to retry payment: %w", err)
+	}
+	if recovered {
+		slog.InfoContext(ctx, "Payment retry succeeded",
+			logattr.Attempt(payload.Attempt),
+			logattr.Queue(w.queueName),
+		)
+		return nil
+	}
+
+	user, err := w.userService.FetchUserByIDInternal(ctx, subscription.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch user",
+			logattr.Attempt(payload.Attempt),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	ctx = w.localized(ctx, user, nil)
+	err = w.emailSender.SendDunningEmail(
+		ctx,
+		user.Email,
+		user.Name,
+		subscription,
+		payload.Attempt,
+		payload.Final,
+		payload.FinalAction,
+	)
+	err = w.recordNotification(ctx, user.ID, models.ChannelEmail, user.Email, notifications.TemplateDunning, err)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send dunning email",
+			logattr.Attempt(payload.Attempt),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to send dunning email: %w", err)
+	}
+
+	if err = w.subscriptionService.RecordPaymentRetryInternal(ctx, subscriptionID, payload.Attempt, payload.Final, payload.FinalAction); err != nil {
+		// Another worker counted this attempt, or the payment was recovered.
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrConflict {
+			slog.DebugContext(ctx, "Skipping dunning retry: subscription changed concurrently",
+				logattr.Attempt(payload.Attempt),
+				logattr.Queue(w.queueName),
+			)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to record payment retry",
+			logattr.Attempt(payload.Attempt),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to record payment retry: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Payment retry processed",
+		logattr.Attempt(payload.Attempt),
+		logattr.Queue(w.queueName),
+	)
+	return nil
+}
+
+func (w *QueueWorker) handleExchangeRateRefresh(ctx context.Context, task *asynq.Task) error {
+	var payload ExchangeRatePayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal exchange rate task payload",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal exchange rate task payload: %w", err)
+	}
+
+	date, err := time.Parse(time.DateOnly, payload.Date)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid exchange rate date",
+			logattr.RatesDate(payload.Date),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("invalid exchange rate date: %w", err)
+	}
+
+	rates, err := w.exchangeRates.RefreshRates(ctx, date)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to refresh exchange rates",
+			logattr.RatesDate(payload.Date),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to refresh exchange rates: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Exchange rates refreshed",
+		logattr.RatesDate(rates.Date.Format(time.DateOnly)),
+		logattr.RatesProvider(rates.Provider),
+		logattr.Total(len(rates.Rates)),
+	)
+	return nil
+}
+
+// budgetAlertMarkerTTL keeps a budget alert marker past the end of the month
+// it covers.
+const budgetAlertMarkerTTL = 32 * 24 * time.Hour
+
+// handleBudgetAlerts emails users whose monthly spend exceeds one of their
+// budgets. Each budget is alerted at most once per month; failed alerts are
+// retried with the task, which skips the ones already sent.
+func (w *QueueWorker) handleBudgetAlerts(ctx context.Context, _ *asynq.Task) error {
+	alerts, err := w.budgetService.FetchExceededBudgetsInternal(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to check budgets",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to check budgets: %w", err)
+	}
+
+	sent := 0
+	failed := 0
+	for _, alert := range alerts {
+		if ok, err := w.sendBudgetAlert(ctx, alert); err != nil {
+			failed++
+		} else if ok {
+			sent++
+		}
+	}
+
+	if sent > 0 || failed > 0 {
+		slog.InfoContext(ctx, "Budget alerts processed",
+			logattr.Total(len(alerts)),
+			logattr.Success(sent),
+			logattr.Failed(failed),
+			logattr.Queue(w.queueName),
+		)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to send %d budget alerts", failed)
+	}
+	return nil
+}
+
+// handleBillRepair deletes bills that failed writes left behind while
+// transactions are disabled.
+func (w *QueueWorker) handleBillRepair(ctx context.Context, _ *asynq.Task) error {
+	if _, err := w.subscriptionService.RepairBillsInternal(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to repair bills",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to repair bills: %w", err)
+	}
+	return nil
+}
+
+// handleChargeSettlement settles bills whose charge the payment provider was
+// still processing.
+func (w *QueueWorker) handleChargeSettlement(ctx context.Context, _ *asynq.Task) error {
+	if _, err := w.subscriptionService.SettlePendingChargesInternal(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to settle pending charges",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to settle pending charges: %w", err)
+	}
+	return nil
+}
+
+// sendBudgetAlert emails a budget alert unless it was already sent this month.
+// It returns true if an email was sent.
+// NOTE: This function owns its own telemetry. Any returned error is strictly
+// for control flow and has already been logged.
+func (w *QueueWorker) sendBudgetAlert(ctx context.Context, alert *models.BudgetAlert) (bool, error) {
+	ctx = observability.EnrichContext(ctx, alert.UserID.Hex(), "")
+
+	key := services.BudgetAlertSentKey(alert.UserID, alert.Category, w.getTime())
+	exists, err := w.redisClient.Exists(ctx, key).Result()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to check Redis for sent budget alert",
+			logattr.Category(string(alert.Category)),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return false, fmt.Errorf("failed to check Redis for sent budget alert: %w", err)
+	}
+	if exists > 0 {
+		return false, nil
+	}
+
+	user, err := w.userService.FetchUserByIDInternal(ctx, alert.UserID)
+	if err != nil {
+		// The budget outlived its user; nothing to notify.
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			slog.DebugContext(ctx, "Skipping budget alert: user not found",
+				logattr.Queue(w.queueName),
+			)
+			return false, nil
+		}
+		slog.ErrorContext(ctx, "Failed to fetch user for budget alert",
+			logattr.Category(string(alert.Category)),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return false, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	ctx = w.localized(ctx, user, nil)
+	err = w.emailSender.SendBudgetAlertEmail(ctx, user.Email, user.Name, alert)
+	err = w.recordNotification(ctx, user.ID, models.ChannelEmail, user.Email, notifications.TemplateBudgetAlert, err)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send budget alert email",
+			logattr.Category(string(alert.Category)),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return false, fmt.Errorf("failed to send budget alert email: %w", err)
+	}
+
+	if err = w.redisClient.Set(ctx, key, "", budgetAlertMarkerTTL).Err(); err != nil {
+		slog.ErrorContext(ctx, "Failed to set budget alert sent key in Redis",
+			logattr.Category(string(alert.Category)),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+	}
+	return true, nil
+}
+
+// handleDomainEvent processes a domain event relayed from the outbox. It is
+// the fan-out point for event consumers.
+func (w *QueueWorker) handleDomainEvent(ctx context.Context, task *asynq.Task) error {
+	var payload EventPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal event task payload",
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal task payload: %w", err)
+	}
+
+	ctx = observability.EnrichContext(ctx, payload.UserID, "")
+	observability.EnrichSpan(ctx)
+
+	// The bus and webhooks go first: the bus drops events it already stored
+	// by their ID and webhook task IDs make the fan-out safe to repeat, so a
+	// notification below failing and the event being retried is harmless.
+	if err := w.publishEvent(ctx, payload); err != nil {
+		return err
+	}
+	if err := w.fanOutWebhooks(ctx, payload); err != nil {
+		return err
+	}
+	w.streamEvent(ctx, &models.LiveEvent{
+		ID:         payload.EventID,
+		Type:       payload.Type,
+		UserID:     payload.UserID,
+		OccurredAt: payload.OccurredAt,
+		Data:       payload.Data,
+	})
+
+	switch models.EventType(payload.Type) {
+	case models.SubscriptionPriceChangedEvent:
+		if err := w.notifyPriceChange(ctx, payload); err != nil {
+			return err
+		}
+	case models.BillPaidEvent, models.BillFailedEvent, models.BillRefundedEvent:
+		if err := w.enqueueInvoice(ctx, payload); err != nil {
+			return err
+		}
+	}
+
+	slog.InfoContext(ctx, "Domain event delivered",
+		logattr.EventID(payload.EventID),
+		logattr.EventType(payload.Type),
+		logattr.Queue(w.queueName),
+	)
+	return nil
+}
+
+// publishEvent publishes a domain event to the message bus.
+func (w *QueueWorker) publishEvent(ctx context.Context, payload EventPayload) error {
+	err := w.eventPublisher.Publish(ctx, eventbus.Event{
+		ID:          payload.EventID,
+		Type:        payload.Type,
+		AggregateID: payload.AggregateID,
+		UserID:      payload.UserID,
+		OccurredAt:  payload.OccurredAt,
+		Data:        payload.Data,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to publish event to the message bus",
+			logattr.EventID(payload.EventID),
+			logattr.EventType(payload.Type),
+			logattr.EventBus(w.eventPublisher.Name()),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	return nil
+}
+
+// streamReminder pushes a sent reminder to the user's open streams.
+func (w *QueueWorker) streamReminder(ctx context.Context, subscription *models.Subscription, daysBefore int, trialEnding bool) {
+	event, err := models.NewReminderSentEvent(subscription, daysBefore, trialEnding, w.getTime())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to encode reminder live event",
+			logattr.DaysBefore(daysBefore),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return
+	}
+	w.streamEvent(ctx, event)
+}
+
+// streamEvent pushes an event to the user's open streams. Streams are a
+// convenience on top of what the user can fetch, so a failure is only
+// logged rather than retrying the task.
+func (w *QueueWorker) streamEvent(ctx context.Context, event *models.LiveEvent) {
+	if event.UserID == "" {
+		return
+	}
+	if err := w.liveEvents.PublishLiveEventInternal(ctx, event); err != nil {
+		slog.WarnContext(ctx, "Failed to publish live event",
+			logattr.EventID(event.ID),
+			logattr.EventType(event.Type),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+	}
+}
+
+// notifyPriceChange emails the owner of a subscription about its price
+// change. A user that no longer exists is skipped.
+func (w *QueueWorker) notifyPriceChange(ctx context.Context, payload EventPayload) error {
+	var change models.PriceChangeResponse
+	if err := json.Unmarshal(payload.Data, &change); err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal price change event data",
+			logattr.EventID(payload.EventID),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to unmarshal price change event data: %w", err)
+	}
+
+	userID, err := bson.ObjectIDFromHex(payload.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid user ID",
+			logattr.EventID(payload.EventID),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	user, err := w.userService.FetchUserByIDInternal(ctx, userID)
+	if err != nil {
+		if appErr, ok := errors.AsType[apperror.AppError](err); ok && appErr.Code() == apperror.ErrNotFound {
+			slog.WarnContext(ctx, "Skipping price change email for missing user",
+				logattr.EventID(payload.EventID),
+				logattr.Queue(w.queueName),
+			)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to fetch user",
+			logattr.EventID(payload.EventID),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	ctx = w.localized(ctx, user, nil)
+	err = w.emailSender.SendPriceChangeEmail(ctx, user.Email, user.Name, &change)
+	err = w.recordNotification(ctx, user.ID, models.ChannelEmail, user.Email, notifications.TemplatePriceChange, err)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send price change email",
+			logattr.EventID(payload.EventID),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return fmt.Errorf("failed to send price change email: %w", err)
+	}
+	slog.InfoContext(ctx, "Price change email sent",
+		logattr.EventID(payload.EventID),
+		logattr.Queue(w.queueName),
+	)
+	return nil
+}
+
+// Stop gracefully shuts down the worker in two phases. It first stops
+// fetching tasks and waits for running ones to finish, then shuts the server
+// down, which requeues tasks still running when the shutdown timeout ends.
+func (w *QueueWorker) Stop() {
+	w.drain()
+	w.server.Shutdown()
+	// Handlers of requeued tasks are abandoned, not canceled, so they still
+	// count as running.
+	if requeued := w.running.Load(); requeued > 0 {
+		slog.Warn("Queue worker requeued unfinished tasks",
+			logattr.WorkerName(w.name),
+			logattr.RunningTasks(requeued),
+		)
+		w.drainMetrics.requeued.Add(context.Background(), requeued)
+	}
+	if err := w.taskEnqueuer.Close(); err != nil {
+		slog.Error("Failed to close webhook task client", logattr.Error(err))
+	}
+	if err := w.eventPublisher.Close(); err != nil {
+		slog.Error("Failed to close event publisher",
+			logattr.EventBus(w.eventPublisher.Name()),
+			logattr.Error(err),
+		)
+	}
+}
+
+// notify delivers a reminder through each of channels with send, and counts
+// the outcome per channel. Each attempt is recorded in the history of the
+// user under template. sentKey is the dedupe key of the reminder; channels it
+// was already delivered through are skipped, so retrying after one channel
+// failed does not repeat the others. The errors of failed channels are joined.
+func (w *QueueWorker) notify(
+	ctx context.Context,
+	sentKey string,
+	template string,
+	userID bson.ObjectID,
+	to notifications.Recipient,
+	channels []models.NotificationChannel,
+	send func(notifications.Notifier) error,
+) error {
+	var errs []error
+	for _, channel := range channels {
+		notifier, ok := w.notifiers[channel]
+		if !ok {
+			// The channel was disabled on the server after the user chose it.
+			slog.WarnContext(ctx, "Skipping unavailable notification channel",
+				logattr.Channel(string(channel)),
+				logattr.Queue(w.queueName),
+			)
+			continue
+		}
+
+		deliveredKey := services.ChannelDeliveredKey(sentKey, channel)
+		delivered, err := w.redisClient.Exists(ctx, deliveredKey).Result()
+		if err != nil {
+			// Sending twice beats not sending at all.
+			slog.WarnContext(ctx, "Failed to check channel delivery marker in Redis",
+				logattr.Channel(string(channel)),
+				logattr.Queue(w.queueName),
+				logattr.Error(err),
+			)
+		} else if delivered > 0 {
+			continue
+		}
+
+		err = send(notifier)
+		if !errors.Is(err, notifications.ErrRecipientSuppressed) {
+			w.deliveries.RecordNotificationDeliveryInternal(ctx, channel, err)
+		}
+		if err = w.recordNotification(ctx, userID, channel, to.Address(channel), template, err); err != nil {
+			slog.WarnContext(ctx, "Failed to deliver notification",
+				logattr.Channel(string(channel)),
+				logattr.Queue(w.queueName),
+				logattr.Error(err),
+			)
+			errs = append(errs, fmt.Errorf("%s: %w", channel, err))
+			continue
+		}
+
+		if err = w.redisClient.Set(ctx, deliveredKey, "", 24*time.Hour).Err(); err != nil {
+			slog.ErrorContext(ctx, "Failed to set channel delivery marker in Redis",
+				logattr.Channel(string(channel)),
+				logattr.Queue(w.queueName),
+				logattr.Error(err),
+			)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// recordNotification adds a delivery attempt to the user's notification
+// history, failed if sendErr is set, and returns the error the task should
+// fail with. That is sendErr, except for an email withheld because the
+// address is suppressed: retrying it would only be withheld again. The
+// message is out or has failed either way, so a history that can't be
+// written is only logged.
+func (w *QueueWorker) recordNotification(
+	ctx context.Context,
+	userID bson.ObjectID,
+	channel models.NotificationChannel,
+	recipient string,
+	template string,
+	sendErr error,
+) error {
+	notification := &models.Notification{
+		UserID:    userID,
+		Channel:   channel,
+		Recipient: recipient,
+		Template:  template,
+		Status:    models.NotificationSent,
+	}
+	notification.TaskID, _ = asynq.GetTaskID(ctx)
+	switch {
+	case errors.Is(sendErr, notifications.ErrRecipientSuppressed):
+		notification.Status = models.NotificationSuppressed
+		slog.InfoContext(ctx, "Skipping notification to suppressed address",
+			logattr.Channel(string(channel)),
+			logattr.Template(template),
+			logattr.Queue(w.queueName),
+		)
+		sendErr = nil
+	case sendErr != nil:
+		notification.Status = models.NotificationFailed
+		notification.Error = sendErr.Error()
+	}
+
+	if err := w.notificationService.RecordNotificationInternal(ctx, notification); err != nil {
+		slog.ErrorContext(ctx, "Failed to record notification in history",
+			logattr.Channel(string(channel)),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+	}
+	return sendErr
+}
+
+// paymentMethod returns the payment method a subscription is paid with, or
+// nil if none is linked. A failed lookup is logged and the email goes out
+// without it.
+func (w *QueueWorker) paymentMethod(ctx context.Context, subscription *models.Subscription) *models.PaymentMethod {
+	if subscription.PaymentMethodID == nil {
+		return nil
+	}
+	method, err := w.paymentMethods.FetchPaymentMethodByIDInternal(ctx, *subscription.PaymentMethodID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to fetch payment method",
+			logattr.PaymentMethodID(subscription.PaymentMethodID.Hex()),
+			logattr.Queue(w.queueName),
+			logattr.Error(err),
+		)
+		return nil
+	}
+	return method
+}
+
+// localized returns ctx carrying the locale the user gets messages in: that
+// of their notification preferences, else the one they registered with. It
+// also carries the timezone of their preferences, which dates are shown in.
+// prefs are fetched if nil; a failed lookup is logged and the user's locale
+// and the server's timezone used.
+func (w *QueueWorker) localized(
+	ctx context.Context,
+	user *models.User,
+	prefs *models.NotificationPreferences,
+) context.Context {
+	if prefs == nil {
+		var err error
+		if prefs, err = w.preferenceService.FetchPreferencesInternal(ctx, user.ID); err != nil {
+			slog.WarnContext(ctx, "Failed to fetch notification preferences",
+				logattr.Queue(w.queueName),
+				logattr.Error(err),
+			)
+			prefs = &models.NotificationPreferences{}
+		}
+	}
+	ctx = appctx.WithLocation(ctx, prefs.Location(time.Local))
+	return appctx.WithLocale(ctx, i18n.Match(prefs.Locale, user.Locale).Locale())
 }
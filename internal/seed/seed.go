@@ -0,0 +1,229 @@
+// Package seed generates fake users, subscriptions, and bills for local
+// development, so the scheduler, analytics, and pagination have data to work
+// on without manual setup.
+package seed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/lib"
+	"github.com/brianvoe/gofakeit/v6"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// EmailDomain is the domain of generated email addresses. It is reserved
+// for examples, so reminders sent to generated users go nowhere.
+const EmailDomain = "example.com"
+
+// Options sizes the generated data.
+type Options struct {
+	Users                int   // Users to generate.
+	SubscriptionsPerUser int   // Each user gets between 1 and this many subscriptions.
+	Months               int   // Subscriptions start up to this many months ago.
+	Seed                 int64 // Seeds the generator; 0 picks a random seed.
+}
+
+// Dataset holds the generated documents, ready to insert.
+type Dataset struct {
+	Users         []*models.User
+	Subscriptions []*models.Subscription
+	Bills         []*models.Bill
+}
+
+// statusWeights sets how often each status is generated.
+var statusWeights = []struct {
+	status models.Status
+	weight float64
+}{
+	{models.Active, 0.6},
+	{models.Paused, 0.07},
+	{models.PastDue, 0.08},
+	{models.Canceled, 0.1},
+	{models.Expired, 0.15},
+}
+
+var (
+	categories = []models.Category{
+		models.Sports, models.News, models.Entertainment, models.Lifestyle,
+		models.Technology, models.Finance, models.Politics, models.Other,
+	}
+	currencies = []models.Currency{models.USD, models.USD, models.USD, models.EUR, models.GBP}
+	tags       = []string{"work", "family", "shared", "essential", "trial", "annual-review"}
+)
+
+// Generate creates the fake data. Users get passwordHash as their password
+// hash, so all of them log in with the same password, and unique addresses
+// at EmailDomain. The same seed generates the same names, amounts, and
+// dates; IDs are new each time but carry the creation time of their
+// document, so listings sorted by ID follow it.
+//
+// Each subscription starts on a midnight within opts.Months before now and
+// has a bill for every period since, so renewals fall due on every day
+// ahead. Statuses are spread over active, paused, past due, canceled, and
+// expired, and shaped as the services leave them: past-due subscriptions
+// end with a failed renewal bill and carry dunning state, expired ones
+// stopped before the period covering now.
+func Generate(opts Options, passwordHash string, now time.Time) *Dataset {
+	f := gofakeit.New(opts.Seed)
+	data := &Dataset{}
+	seen := make(map[string]bool)
+
+	for range opts.Users {
+		first, last := f.FirstName(), f.LastName()
+		email := ""
+		for email == "" || seen[email] {
+			email = fmt.Sprintf("%s.%s.%s@%s", slug(first), slug(last), strings.ToLower(f.LetterN(4)), EmailDomain)
+		}
+		seen[email] = true
+
+		createdAt := now.AddDate(0, -opts.Months, -f.IntRange(0, 30))
+		user := &models.User{
+			ID:        bson.NewObjectIDFromTimestamp(createdAt),
+			Name:      first + " " + last,
+			Email:     email,
+			Password:  passwordHash,
+			Role:      models.UserRole,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+		data.Users = append(data.Users, user)
+
+		for range f.IntRange(1, max(opts.SubscriptionsPerUser, 1)) {
+			subscription, bills := generateSubscription(f, user, opts.Months, now)
+			data.Subscriptions = append(data.Subscriptions, subscription)
+			data.Bills = append(data.Bills, bills...)
+		}
+	}
+	return data
+}
+
+// generateSubscription creates a subscription of user and its bills.
+func generateSubscription(f *gofakeit.Faker, user *models.User, months int, now time.Time) (*models.Subscription, []*models.Bill) {
+	subscription := &models.Subscription{
+		Name:      name(f),
+		Currency:  currencies[f.IntRange(0, len(currencies)-1)],
+		Frequency: models.Monthly,
+		Category:  categories[f.IntRange(0, len(categories)-1)],
+		Status:    pickStatus(f),
+		UserID:    user.ID,
+	}
+	subscription.Price = int64(f.IntRange(199, 2999))
+	if f.Rand.Float64() < 0.2 {
+		subscription.Frequency = models.Yearly
+		subscription.Price = int64(f.IntRange(1999, 29999))
+	}
+	if f.Rand.Float64() < 0.4 {
+		subscription.Tags = models.NormalizeTags([]string{tags[f.IntRange(0, len(tags)-1)], tags[f.IntRange(0, len(tags)-1)]})
+	}
+	if f.Rand.Float64() < 0.3 {
+		subscription.Notes = f.Sentence(f.IntRange(4, 12))
+	}
+
+	// Billing days start at midnight, as for subscriptions created through
+	// the API.
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := today.AddDate(0, 0, -f.IntRange(0, max(months*30, 1)))
+	subscription.ID = bson.NewObjectIDFromTimestamp(start)
+	subscription.CreatedAt = start
+
+	var bills []*models.Bill
+	for periodStart := start; !periodStart.After(now); {
+		periodEnd := lib.CalcRenewalDate(periodStart, subscription.Frequency)
+		bills = append(bills, &models.Bill{
+			ID:             bson.NewObjectIDFromTimestamp(periodStart),
+			Amount:         subscription.Price,
+			Currency:       subscription.Currency,
+			SubscriptionID: subscription.ID,
+			StartDate:      periodStart,
+			EndDate:        periodEnd,
+			Status:         models.Paid,
+			CreatedAt:      periodStart,
+			UpdatedAt:      periodStart,
+		})
+		periodStart = periodEnd
+	}
+
+	// Expiry and dunning need a renewal; a subscription with a single
+	// period stays active.
+	if len(bills) < 2 && (subscription.Status == models.Expired || subscription.Status == models.PastDue) {
+		subscription.Status = models.Active
+	}
+	switch subscription.Status {
+	case models.Expired:
+		// It was not renewed for the period covering now.
+		bills = bills[:len(bills)-1]
+	case models.PastDue:
+		last := bills[len(bills)-1]
+		last.Status = models.Failed
+		last.FailureReason = "Your card was declined."
+		subscription.Dunning = &models.Dunning{
+			BillID:    last.ID,
+			StartedAt: last.StartDate,
+			Attempts:  f.IntRange(0, 2),
+		}
+	case models.Paused:
+		// Paused within the current period.
+		pausedAt := now.Add(-time.Duration(f.IntRange(1, 14*24)) * time.Hour)
+		if start := bills[len(bills)-1].StartDate; pausedAt.Before(start) {
+			pausedAt = start
+		}
+		subscription.PausedAt = &pausedAt
+	}
+
+	last := bills[len(bills)-1]
+	subscription.ValidTill = last.EndDate
+	subscription.UpdatedAt = last.CreatedAt
+	if subscription.Status == models.Active && f.Rand.Float64() < 0.5 {
+		// Some go unused long enough for savings suggestions.
+		usedAt := now.Add(-time.Duration(f.IntRange(1, 120*24)) * time.Hour)
+		subscription.LastUsedAt = &usedAt
+	}
+	return subscription, bills
+}
+
+// pickStatus draws a status by statusWeights.
+func pickStatus(f *gofakeit.Faker) models.Status {
+	r := f.Rand.Float64()
+	for _, w := range statusWeights {
+		if r < w.weight {
+			return w.status
+		}
+		r -= w.weight
+	}
+	return models.Active
+}
+
+// name returns a made-up service name of 2 to 100 characters, as
+// subscriptions require.
+func name(f *gofakeit.Faker) string {
+	var n string
+	switch f.IntRange(0, 2) {
+	case 0:
+		n = f.AppName()
+	case 1:
+		n = f.Company()
+	default:
+		n = f.AppName() + " " + []string{"Plus", "Pro", "Premium", "Family"}[f.IntRange(0, 3)]
+	}
+	if len(n) < 2 {
+		n = "App " + n
+	}
+	if len(n) > 100 {
+		n = n[:100]
+	}
+	return n
+}
+
+// slug lowercases s and drops everything but letters, for email local parts.
+func slug(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
@@ -0,0 +1,111 @@
+package seed_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuragthepathak/subscription-management/internal/domain/models"
+	"github.com/anuragthepathak/subscription-management/internal/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var now = time.Date(2025, 6, 15, 10, 30, 0, 0, time.UTC)
+
+func generate(t *testing.T) *seed.Dataset {
+	t.Helper()
+
+	return seed.Generate(seed.Options{Users: 50, SubscriptionsPerUser: 8, Months: 12, Seed: 42}, "hash", now)
+}
+
+func TestGenerate_Users(t *testing.T) {
+	data := generate(t)
+
+	require.Len(t, data.Users, 50)
+	emails := make(map[string]bool)
+	for _, user := range data.Users {
+		assert.True(t, strings.HasSuffix(user.Email, "@"+seed.EmailDomain), user.Email)
+		assert.False(t, emails[user.Email], "duplicate email %s", user.Email)
+		emails[user.Email] = true
+		assert.Equal(t, "hash", user.Password)
+		assert.Equal(t, models.UserRole, user.Role)
+	}
+}
+
+func TestGenerate_Subscriptions(t *testing.T) {
+	data := generate(t)
+
+	owners := make(map[bson.ObjectID]int)
+	statuses := make(map[models.Status]int)
+	frequencies := make(map[models.Frequency]int)
+	for _, subscription := range data.Subscriptions {
+		owners[subscription.UserID]++
+		statuses[subscription.Status]++
+		frequencies[subscription.Frequency]++
+
+		require.NoError(t, subscription.ValidateDetails())
+		assert.Positive(t, subscription.Price)
+		assert.False(t, subscription.CreatedAt.After(now))
+		if subscription.Status == models.Expired {
+			assert.False(t, subscription.ValidTill.After(now), "expired subscriptions ended")
+		} else {
+			assert.True(t, subscription.ValidTill.After(now), "%s subscriptions are valid", subscription.Status)
+		}
+		assert.Equal(t, subscription.Status == models.PastDue, subscription.Dunning != nil)
+		assert.Equal(t, subscription.Status == models.Paused, subscription.PausedAt != nil)
+	}
+
+	assert.Len(t, owners, len(data.Users), "every user has subscriptions")
+	for _, n := range owners {
+		assert.LessOrEqual(t, n, 8)
+	}
+	for _, status := range []models.Status{models.Active, models.Paused, models.PastDue, models.Canceled, models.Expired} {
+		assert.Positive(t, statuses[status], "status %s", status)
+	}
+	assert.Positive(t, frequencies[models.Monthly])
+	assert.Positive(t, frequencies[models.Yearly])
+}
+
+func TestGenerate_Bills(t *testing.T) {
+	data := generate(t)
+
+	bills := make(map[bson.ObjectID][]*models.Bill)
+	for _, bill := range data.Bills {
+		require.NoError(t, bill.Validate())
+		bills[bill.SubscriptionID] = append(bills[bill.SubscriptionID], bill)
+	}
+	for _, subscription := range data.Subscriptions {
+		history := bills[subscription.ID]
+		require.NotEmpty(t, history)
+
+		// Periods follow each other from the start to ValidTill.
+		assert.Equal(t, subscription.CreatedAt, history[0].StartDate)
+		for i := 1; i < len(history); i++ {
+			assert.Equal(t, history[i-1].EndDate, history[i].StartDate)
+		}
+		last := history[len(history)-1]
+		assert.Equal(t, subscription.ValidTill, last.EndDate)
+
+		if subscription.Status == models.PastDue {
+			assert.Equal(t, models.Failed, last.Status)
+			assert.Equal(t, last.ID, subscription.Dunning.BillID)
+		} else {
+			assert.Equal(t, models.Paid, last.Status)
+		}
+	}
+}
+
+func TestGenerate_SameSeed(t *testing.T) {
+	a, b := generate(t), generate(t)
+
+	require.Len(t, b.Subscriptions, len(a.Subscriptions))
+	for i := range a.Users {
+		assert.Equal(t, a.Users[i].Email, b.Users[i].Email)
+	}
+	for i := range a.Subscriptions {
+		assert.Equal(t, a.Subscriptions[i].Name, b.Subscriptions[i].Name)
+		assert.Equal(t, a.Subscriptions[i].ValidTill, b.Subscriptions[i].ValidTill)
+	}
+}
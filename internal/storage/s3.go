@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+// emptyPayloadHash is the SHA-256 of an empty body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// s3Store keeps objects in an S3 bucket through the REST API, signing each
+// request with SigV4. Credentials come from the AWS SDK's default chain, so
+// environment variables, shared config files, and instance roles all work.
+type s3Store struct {
+	client      *http.Client
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	region      string
+	base        *url.URL // Bucket URL; object keys are appended to its path.
+	prefix      string
+}
+
+func newS3Store(ctx context.Context, config Config) (*s3Store, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(config.S3.Region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("no AWS region configured for S3")
+	}
+
+	endpoint := config.S3.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", cfg.Region)
+	}
+	base, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+	if config.S3.PathStyle {
+		base.Path = strings.TrimSuffix(base.Path, "/") + "/" + config.S3.Bucket
+	} else {
+		base.Host = config.S3.Bucket + "." + base.Host
+	}
+
+	return &s3Store{
+		client:      &http.Client{},
+		credentials: cfg.Credentials,
+		signer: v4.NewSigner(func(o *v4.SignerOptions) {
+			o.DisableURIPathEscaping = true // S3 signs the path as sent.
+		}),
+		region: cfg.Region,
+		base:   base,
+		prefix: config.S3.Prefix,
+	}, nil
+}
+
+func (s *s3Store) Name() string {
+	return DriverS3
+}
+
+// Put spools content to a temporary file first: S3 needs the length and
+// hash of the body before the upload starts.
+func (s *s3Store) Put(ctx context.Context, key string, content io.Reader) (int64, error) {
+	tmp, err := os.CreateTemp("", "s3-upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), contextReader{ctx, content})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read content: %w", err)
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to rewind temporary file: %w", err)
+	}
+
+	res, err := s.do(ctx, http.MethodPut, key, io.NopCloser(tmp), size, hex.EncodeToString(hash.Sum(nil)))
+	if err != nil {
+		return 0, err
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("S3 PUT returned %s", res.Status)
+	}
+	return size, nil
+}
+
+func (s *s3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	res, err := s.do(ctx, http.MethodGet, key, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res.Body, nil
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil, ErrNotFound
+	default:
+		res.Body.Close()
+		return nil, fmt.Errorf("S3 GET returned %s", res.Status)
+	}
+}
+
+// Delete succeeds for missing keys too, as S3 answers 204 either way.
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	res, err := s.do(ctx, http.MethodDelete, key, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return fmt.Errorf("S3 DELETE returned %s", res.Status)
+	}
+	return nil
+}
+
+// do sends a signed request for the object under key.
+func (s *s3Store) do(
+	ctx context.Context,
+	method string,
+	key string,
+	body io.ReadCloser,
+	size int64,
+	payloadHash string,
+) (*http.Response, error) {
+	if !validKey(key) {
+		return nil, fmt.Errorf("invalid object key %q", key)
+	}
+	target := *s.base
+	target.Path = strings.TrimSuffix(target.Path, "/") + "/" + s.prefix + key
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 request: %w", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	credentials, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+	if err = s.signer.SignHTTP(ctx, credentials, req, payloadHash, "s3", s.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign S3 request: %w", err)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("S3 %s failed: %w", method, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,89 @@
+// Package storage keeps the content of stored files outside MongoDB, on local
+// disk or in an S3 bucket. File metadata stays in MongoDB either way; see
+// repositories.NewBlobFileRepository.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Supported storage drivers. An empty driver keeps content in GridFS.
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// ErrNotFound is returned when no object is stored under a key.
+var ErrNotFound = errors.New("object not found")
+
+// Config selects where file content is stored.
+type Config struct {
+	Driver string `mapstructure:"driver"` // One of local, s3; empty stores content in GridFS.
+
+	Local struct {
+		Dir string `mapstructure:"dir"` // Directory the files are written to; created if missing.
+	} `mapstructure:"local"`
+
+	S3 struct {
+		Bucket string `mapstructure:"bucket"`
+		Region string `mapstructure:"region"` // Defaults to the SDK's region chain.
+		// Endpoint overrides the AWS endpoint for S3-compatible stores such
+		// as MinIO, e.g. http://localhost:9000.
+		Endpoint  string `mapstructure:"endpoint"`
+		PathStyle bool   `mapstructure:"path_style"` // Address the bucket in the path instead of the host name.
+		Prefix    string `mapstructure:"prefix"`     // Prepended to every object key, e.g. "files/".
+	} `mapstructure:"s3"`
+}
+
+// Store keeps file content by key. Keys are opaque strings of letters,
+// digits, and the characters "-", "_", and "/".
+type Store interface {
+	// Name identifies the store in logs.
+	Name() string
+	// Put stores content under key, replacing any previous content, and
+	// returns its size in bytes. Readers see either the old or the new
+	// content, never a partial write.
+	Put(ctx context.Context, key string, content io.Reader) (int64, error)
+	// Open returns the content stored under key, or ErrNotFound.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key. Deleting a missing key
+	// is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// New creates the store selected by config, or returns nil when content is
+// kept in GridFS.
+func New(ctx context.Context, config Config) (Store, error) {
+	switch config.Driver {
+	case "":
+		return nil, nil
+	case DriverLocal:
+		return newLocalStore(config.Local.Dir)
+	case DriverS3:
+		return newS3Store(ctx, config)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", config.Driver)
+	}
+}
+
+// validKey reports whether key may be used as an object key. It keeps keys
+// from escaping the local directory or the S3 prefix.
+func validKey(key string) bool {
+	if key == "" || key[0] == '/' || key[len(key)-1] == '/' {
+		return false
+	}
+	prev := rune(0)
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		case r == '/' && prev != '/':
+		default:
+			return false
+		}
+		prev = r
+	}
+	return true
+}
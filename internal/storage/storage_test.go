@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidKey(t *testing.T) {
+	for _, key := range []string{"abc", "invoice/0123abcd", "a-b_c/d"} {
+		assert.True(t, validKey(key), key)
+	}
+	for _, key := range []string{"", "/abc", "abc/", "a//b", "../etc/passwd", "a/./b", "a b", "a\\b"} {
+		assert.False(t, validKey(key), key)
+	}
+}
+
+func TestNew(t *testing.T) {
+	store, err := New(t.Context(), Config{})
+	require.NoError(t, err)
+	assert.Nil(t, store, "an empty driver keeps content in GridFS")
+
+	_, err = New(t.Context(), Config{Driver: "ftp"})
+	assert.EqualError(t, err, `unknown storage driver "ftp"`)
+}
+
+// testStore runs the behavior every Store must have.
+func testStore(t *testing.T, store Store) {
+	t.Helper()
+	ctx := t.Context()
+
+	size, err := store.Put(ctx, "files/one", strings.NewReader("first"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), size)
+
+	_, err = store.Put(ctx, "files/one", strings.NewReader("second"))
+	require.NoError(t, err)
+	content, err := store.Open(ctx, "files/one")
+	require.NoError(t, err)
+	body, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+	assert.Equal(t, "second", string(body), "Put replaces the content")
+
+	require.NoError(t, store.Delete(ctx, "files/one"))
+	_, err = store.Open(ctx, "files/one")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, store.Delete(ctx, "files/one"), "deleting a missing key succeeds")
+
+	_, err = store.Put(ctx, "../escape", strings.NewReader("x"))
+	assert.ErrorContains(t, err, "invalid object key")
+}
+
+func TestLocalStore(t *testing.T) {
+	var config Config
+	config.Driver = DriverLocal
+	config.Local.Dir = t.TempDir()
+	store, err := New(t.Context(), config)
+	require.NoError(t, err)
+
+	testStore(t, store)
+}
+
+func TestLocalStore_CanceledPut(t *testing.T) {
+	store, err := newLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err = store.Put(ctx, "files/one", strings.NewReader("content"))
+
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = store.Open(t.Context(), "files/one")
+	assert.ErrorIs(t, err, ErrNotFound, "a failed Put leaves nothing behind")
+}
+
+// fakeS3 serves objects from memory and records the requests it got.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+	paths   []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paths = append(f.paths, r.URL.Path)
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDTEST/") ||
+		r.Header.Get("X-Amz-Content-Sha256") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = string(body)
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDTEST")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_CONFIG_FILE", "/nonexistent")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/nonexistent")
+	fake := &fakeS3{objects: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	var config Config
+	config.Driver = DriverS3
+	config.S3.Bucket = "receipts"
+	config.S3.Region = "eu-central-1"
+	config.S3.Endpoint = server.URL
+	config.S3.PathStyle = true
+	config.S3.Prefix = "subman/"
+	store, err := New(t.Context(), config)
+	require.NoError(t, err)
+
+	testStore(t, store)
+	assert.Equal(t, "/receipts/subman/files/one", fake.paths[0], "bucket in the path, then prefix and key")
+}